@@ -0,0 +1,355 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/EXCCoin/exccd/chaincfg/chainhash"
+	"github.com/EXCCoin/exccd/exccutil"
+)
+
+const (
+	// defaultAlertReorgDepth is the default minimum depth of the chain
+	// reorganizations which trigger an alert.
+	defaultAlertReorgDepth = 3
+
+	// defaultAlertSyncStall is the default duration without a new best
+	// block after which the sync is considered stalled.
+	defaultAlertSyncStall = time.Hour
+
+	// defaultAlertMinPeers is the default number of connected peers below
+	// which an alert is triggered.
+	defaultAlertMinPeers = 1
+
+	// alertCheckInterval is the interval at which the conditions which
+	// trigger alerts, such as a stalled sync, are checked.
+	alertCheckInterval = time.Minute
+
+	// alertQueueSize is the maximum number of alerts waiting to be sent.
+	// Further alerts are dropped until the pending ones are sent.
+	alertQueueSize = 100
+
+	// alertWebhookTimeout is the maximum amount of time spent sending an
+	// alert to a webhook.
+	alertWebhookTimeout = 10 * time.Second
+
+	// alertCmdTimeout is the maximum amount of time the alert command may
+	// run for before it is killed.
+	alertCmdTimeout = 30 * time.Second
+
+	// alertCmdEventEnv is the name of the environment variable the alert
+	// command is passed the name of the event in.
+	alertCmdEventEnv = "EXCCD_EVENT"
+)
+
+// Names of the events alerts are sent for.
+const (
+	alertEventReorg         = "reorg"
+	alertEventSyncStalled   = "syncstalled"
+	alertEventSyncResumed   = "syncresumed"
+	alertEventLowPeers      = "lowpeers"
+	alertEventPeersRestored = "peersrestored"
+	alertEventBlockAccepted = "blockaccepted"
+	alertEventBlockRejected = "blockrejected"
+	alertEventIndexError    = "indexerror"
+	alertEventMiningPaused  = "miningpaused"
+	alertEventMiningResumed = "miningresumed"
+)
+
+// alertEvent is the JSON encoding of an event sent to the alert webhooks and
+// command.
+type alertEvent struct {
+	Event   string                 `json:"event"`
+	Time    time.Time              `json:"time"`
+	Network string                 `json:"network"`
+	Message string                 `json:"message"`
+	Data    map[string]interface{} `json:"data,omitempty"`
+}
+
+// alerter sends alerts for significant operational events, such as deep chain
+// reorganizations, a stalled sync, and losing peers, to HTTP webhooks and an
+// external command so they can be noticed without a monitoring stack.
+//
+// Each alert is sent as a JSON-encoded alertEvent in the body of a POST
+// request to every webhook and written to the standard input of the command.
+type alerter struct {
+	server     *server
+	webhooks   []string
+	command    []string
+	client     *http.Client
+	syncStall  time.Duration
+	minPeers   int
+	reorgDepth int64
+
+	// The following fields track the conditions which trigger alerts.  They
+	// are only accessed by the alert handler.
+	lastBest     chainhash.Hash
+	lastProgress time.Time
+	stalled      bool
+	lowPeers     bool
+
+	events chan *alertEvent
+	quit   chan struct{}
+	wg     sync.WaitGroup
+}
+
+// Notify queues an alert for the passed event to be sent.  Alerts are dropped
+// when too many are waiting to be sent.
+//
+// This function is safe for concurrent access and never blocks.
+func (a *alerter) Notify(event, message string, data map[string]interface{}) {
+	e := &alertEvent{
+		Event:   event,
+		Time:    time.Now().UTC(),
+		Network: activeNetParams.Name,
+		Message: message,
+		Data:    data,
+	}
+	select {
+	case a.events <- e:
+	default:
+		srvrLog.Warnf("Dropping %s alert -- too many alerts are pending",
+			event)
+	}
+}
+
+// webhookHost returns the host of the passed webhook URL for logging, since
+// the rest of the URL often contains a secret token.
+func webhookHost(webhook string) string {
+	u, err := url.Parse(webhook)
+	if err != nil {
+		return "<invalid>"
+	}
+	return u.Host
+}
+
+// postWebhook sends the passed encoded alert to the passed webhook.
+func (a *alerter) postWebhook(webhook string, body []byte) error {
+	resp, err := a.client.Post(webhook, "application/json",
+		bytes.NewReader(body))
+	if err != nil {
+		// Avoid logging the full URL included in the error.
+		if uErr, ok := err.(*url.Error); ok {
+			return uErr.Err
+		}
+		return err
+	}
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// runCommand runs the alert command with the passed encoded alert written to
+// its standard input and the name of the event in its environment.
+func (a *alerter) runCommand(event string, body []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), alertCmdTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, a.command[0], a.command[1:]...)
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Env = append(os.Environ(), alertCmdEventEnv+"="+event)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%v: %s", err, bytes.TrimSpace(out))
+	}
+	return nil
+}
+
+// send sends the passed alert to every webhook and the command.  Failures are
+// logged.
+func (a *alerter) send(e *alertEvent) {
+	body, err := json.Marshal(e)
+	if err != nil {
+		srvrLog.Errorf("Unable to encode %s alert: %v", e.Event, err)
+		return
+	}
+
+	for _, webhook := range a.webhooks {
+		if err := a.postWebhook(webhook, body); err != nil {
+			srvrLog.Warnf("Unable to send %s alert to webhook at %s: %v",
+				e.Event, webhookHost(webhook), err)
+		}
+	}
+	if len(a.command) > 0 {
+		if err := a.runCommand(e.Event, body); err != nil {
+			srvrLog.Warnf("Alert command for %s alert failed: %v",
+				e.Event, err)
+		}
+	}
+}
+
+// checkConditions sends alerts for the conditions which started or ended since
+// they were last checked given the passed number of connected peers, best
+// block, and current time.
+func (a *alerter) checkConditions(peers int, best *chainhash.Hash, height int64, now time.Time) {
+	if *best != a.lastBest {
+		if a.stalled {
+			a.Notify(alertEventSyncResumed, fmt.Sprintf("The best "+
+				"block advanced to height %d", height),
+				map[string]interface{}{
+					"height": height,
+					"hash":   best.String(),
+				})
+			a.stalled = false
+		}
+		a.lastBest = *best
+		a.lastProgress = now
+	}
+	if !a.stalled && now.Sub(a.lastProgress) >= a.syncStall {
+		a.Notify(alertEventSyncStalled, fmt.Sprintf("No new best block "+
+			"since %v at height %d", a.lastProgress.Truncate(time.Second),
+			height), map[string]interface{}{
+			"height":     height,
+			"hash":       best.String(),
+			"stalledfor": int64(now.Sub(a.lastProgress).Seconds()),
+		})
+		a.stalled = true
+	}
+
+	lowPeers := peers < a.minPeers
+	switch {
+	case lowPeers && !a.lowPeers:
+		a.Notify(alertEventLowPeers, fmt.Sprintf("Only %d peers are "+
+			"connected", peers), map[string]interface{}{
+			"peers":    peers,
+			"minpeers": a.minPeers,
+		})
+	case !lowPeers && a.lowPeers:
+		a.Notify(alertEventPeersRestored, fmt.Sprintf("%d peers are "+
+			"connected", peers), map[string]interface{}{
+			"peers":    peers,
+			"minpeers": a.minPeers,
+		})
+	}
+	a.lowPeers = lowPeers
+}
+
+// alertHandler sends queued alerts and periodically checks the conditions
+// which trigger alerts.  It must be run as a goroutine.
+func (a *alerter) alertHandler() {
+	ticker := time.NewTicker(alertCheckInterval)
+	defer ticker.Stop()
+
+out:
+	for {
+		select {
+		case e := <-a.events:
+			a.send(e)
+
+		case now := <-ticker.C:
+			best := a.server.blockManager.chain.BestSnapshot()
+			a.checkConditions(int(a.server.ConnectedCount()),
+				&best.Hash, best.Height, now)
+
+		case <-a.quit:
+			break out
+		}
+	}
+
+	a.wg.Done()
+}
+
+// Start begins sending alerts.
+func (a *alerter) Start() {
+	best := a.server.blockManager.chain.BestSnapshot()
+	a.lastBest = best.Hash
+	a.lastProgress = time.Now()
+
+	a.wg.Add(1)
+	spawn(a.alertHandler)
+}
+
+// Stop signals the alerter to stop sending alerts and waits for it to finish.
+func (a *alerter) Stop() {
+	close(a.quit)
+	a.wg.Wait()
+}
+
+// newAlerter returns a new alerter for the provided server which is configured
+// by the alert options.
+func newAlerter(s *server) *alerter {
+	return &alerter{
+		server:     s,
+		webhooks:   cfg.AlertWebhooks,
+		command:    strings.Fields(cfg.AlertCmd),
+		client:     &http.Client{Timeout: alertWebhookTimeout},
+		syncStall:  cfg.AlertSyncStall,
+		minPeers:   cfg.AlertMinPeers,
+		reorgDepth: cfg.AlertReorgDepth,
+		events:     make(chan *alertEvent, alertQueueSize),
+		quit:       make(chan struct{}),
+	}
+}
+
+// notifyAlert queues an alert for the passed event to be sent when alerts are
+// enabled.
+//
+// This function is safe for concurrent access and never blocks.
+func (s *server) notifyAlert(event, message string, data map[string]interface{}) {
+	if s.alerter != nil {
+		s.alerter.Notify(event, message, data)
+	}
+}
+
+// notifyReorg sends an alert for a chain reorganization from the passed old
+// best block to the passed new best block when it is at least as deep as the
+// configured depth.
+func (s *server) notifyReorg(oldHash *chainhash.Hash, oldHeight int64, newHash *chainhash.Hash, newHeight, forkHeight int64) {
+	if s.alerter == nil {
+		return
+	}
+	depth := oldHeight - forkHeight
+	if depth < s.alerter.reorgDepth {
+		return
+	}
+	s.alerter.Notify(alertEventReorg, fmt.Sprintf("Chain reorganization "+
+		"of depth %d from %v (height %d) to %v (height %d)", depth,
+		oldHash, oldHeight, newHash, newHeight),
+		map[string]interface{}{
+			"depth":      depth,
+			"forkheight": forkHeight,
+			"oldhash":    oldHash.String(),
+			"oldheight":  oldHeight,
+			"newhash":    newHash.String(),
+			"newheight":  newHeight,
+		})
+}
+
+// notifyMinedBlock sends an alert for a block mined by this node or submitted
+// to it by a miner via the passed source.  The block was accepted when the
+// passed rejection reason is empty.
+func (s *server) notifyMinedBlock(block *exccutil.Block, source, reason string) {
+	data := map[string]interface{}{
+		"hash":   block.Hash().String(),
+		"height": block.Height(),
+		"source": source,
+	}
+	if reason == "" {
+		s.notifyAlert(alertEventBlockAccepted, fmt.Sprintf("Block %v "+
+			"(height %d) submitted via %s was accepted", block.Hash(),
+			block.Height(), source), data)
+		return
+	}
+	data["reason"] = reason
+	s.notifyAlert(alertEventBlockRejected, fmt.Sprintf("Block %v (height "+
+		"%d) submitted via %s was rejected: %s", block.Hash(),
+		block.Height(), source, reason), data)
+}
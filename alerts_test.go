@@ -0,0 +1,109 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/EXCCoin/exccd/chaincfg/chainhash"
+)
+
+// TestAlerterConditions ensures alerts are sent once when a condition starts
+// and once when it ends.
+func TestAlerterConditions(t *testing.T) {
+	a := &alerter{
+		syncStall: time.Hour,
+		minPeers:  2,
+		events:    make(chan *alertEvent, alertQueueSize),
+	}
+	events := func() []string {
+		var names []string
+		for {
+			select {
+			case e := <-a.events:
+				names = append(names, e.Event)
+			default:
+				return names
+			}
+		}
+	}
+	checkEvents := func(desc string, want ...string) {
+		t.Helper()
+		got := events()
+		if len(got) != len(want) {
+			t.Fatalf("%s: unexpected events -- got %v, want %v", desc,
+				got, want)
+		}
+		for i := range got {
+			if got[i] != want[i] {
+				t.Fatalf("%s: unexpected events -- got %v, want %v",
+					desc, got, want)
+			}
+		}
+	}
+
+	start := time.Unix(1500000000, 0)
+	hash1 := chainhash.Hash{1}
+	hash2 := chainhash.Hash{2}
+	a.checkConditions(3, &hash1, 1, start)
+	checkEvents("progress")
+
+	a.checkConditions(1, &hash1, 1, start.Add(30*time.Minute))
+	checkEvents("low peers", alertEventLowPeers)
+	a.checkConditions(1, &hash1, 1, start.Add(time.Hour))
+	checkEvents("stalled", alertEventSyncStalled)
+	a.checkConditions(0, &hash1, 1, start.Add(2*time.Hour))
+	checkEvents("still stalled with low peers")
+
+	a.checkConditions(2, &hash2, 2, start.Add(3*time.Hour))
+	checkEvents("resumed", alertEventSyncResumed, alertEventPeersRestored)
+	a.checkConditions(2, &hash2, 2, start.Add(3*time.Hour+time.Minute))
+	checkEvents("healthy")
+}
+
+// TestAlerterWebhook ensures alerts are posted to the webhooks as JSON.
+func TestAlerterWebhook(t *testing.T) {
+	received := make(chan alertEvent, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var e alertEvent
+		if r.Method != "POST" ||
+			r.Header.Get("Content-Type") != "application/json" ||
+			json.NewDecoder(r.Body).Decode(&e) != nil {
+
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		received <- e
+	}))
+	defer srv.Close()
+
+	a := &alerter{
+		webhooks: []string{srv.URL + "/secret"},
+		client:   &http.Client{Timeout: alertWebhookTimeout},
+	}
+	a.send(&alertEvent{
+		Event:   alertEventReorg,
+		Message: "test",
+		Data:    map[string]interface{}{"depth": 5},
+	})
+	select {
+	case e := <-received:
+		if e.Event != alertEventReorg || e.Message != "test" ||
+			e.Data["depth"] != float64(5) {
+
+			t.Fatalf("unexpected alert %+v", e)
+		}
+	default:
+		t.Fatal("alert was not posted to the webhook")
+	}
+
+	if host := webhookHost(srv.URL + "/secret"); host != srv.Listener.Addr().String() {
+		t.Fatalf("unexpected webhook host %q", host)
+	}
+}
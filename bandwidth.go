@@ -0,0 +1,306 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/EXCCoin/exccd/exccjson"
+)
+
+const (
+	// bandwidthFilename is the name of the file in the data directory the
+	// bandwidth totals are saved to.
+	bandwidthFilename = "bandwidth.json"
+
+	// bandwidthVersion is the current version of the saved bandwidth
+	// totals.
+	bandwidthVersion = 1
+
+	// bandwidthSaveInterval is the interval at which the bandwidth totals
+	// are saved, which bounds the totals lost when the process does not
+	// shut down cleanly.
+	bandwidthSaveInterval = 10 * time.Minute
+
+	// maxBandwidthDays is the number of the most recent days the bandwidth
+	// totals are kept for.
+	maxBandwidthDays = 28
+
+	// bandwidthDailyPeriods and bandwidthWeeklyPeriods are the number of
+	// the most recent days and weeks the bandwidth totals are reported for.
+	bandwidthDailyPeriods  = 7
+	bandwidthWeeklyPeriods = 4
+
+	// uploadTargetTimeFrame is the duration of the cycles the upload target
+	// applies to.
+	uploadTargetTimeFrame = 24 * time.Hour
+
+	// historicalBlockAge is the minimum age of the blocks which are no
+	// longer served to peers which are not whitelisted once the upload
+	// target is reached.
+	historicalBlockAge = oneWeek
+
+	// unknownMsgCommand is the command the bandwidth used by messages which
+	// failed to be read is recorded under.
+	unknownMsgCommand = "unknown"
+
+	// oneDay and oneWeek are the durations of the periods the bandwidth
+	// totals are reported for.
+	oneDay  = 24 * time.Hour
+	oneWeek = 7 * oneDay
+)
+
+// bandwidthMsgTotals houses the bandwidth used by the messages with a command.
+type bandwidthMsgTotals struct {
+	Recv uint64 `json:"recv"`
+	Sent uint64 `json:"sent"`
+}
+
+// bandwidthPeriod houses the bandwidth used during the day which starts at the
+// Unix time Start.
+type bandwidthPeriod struct {
+	Start int64  `json:"start"`
+	Recv  uint64 `json:"recv"`
+	Sent  uint64 `json:"sent"`
+}
+
+// bandwidthState is the JSON encoding of the bandwidth totals saved to the data
+// directory.
+type bandwidthState struct {
+	Version    int                            `json:"version"`
+	Start      int64                          `json:"start"`
+	Recv       uint64                         `json:"recv"`
+	Sent       uint64                         `json:"sent"`
+	Messages   map[string]*bandwidthMsgTotals `json:"messages"`
+	Days       []bandwidthPeriod              `json:"days"`
+	CycleStart int64                          `json:"cyclestart"`
+	CycleSent  uint64                         `json:"cyclesent"`
+}
+
+// bandwidthAccounting tracks the bandwidth used by each direction and message
+// command across restarts, along with the data sent during the current cycle
+// of the upload target, so nodes on metered connections can keep track of
+// their usage.
+type bandwidthAccounting struct {
+	mtx          sync.Mutex
+	state        bandwidthState
+	uploadTarget uint64
+	now          func() time.Time
+}
+
+// newBandwidthAccounting returns new bandwidth accounting with the passed
+// upload target in bytes per cycle, or no upload target when it is zero.
+func newBandwidthAccounting(uploadTarget uint64, now func() time.Time) *bandwidthAccounting {
+	return &bandwidthAccounting{
+		state: bandwidthState{
+			Version:  bandwidthVersion,
+			Start:    now().Unix(),
+			Messages: make(map[string]*bandwidthMsgTotals),
+		},
+		uploadTarget: uploadTarget,
+		now:          now,
+	}
+}
+
+// record adds the passed number of bytes received and sent for a message with
+// the passed command to the totals.
+//
+// This function is safe for concurrent access.
+func (b *bandwidthAccounting) record(command string, recv, sent uint64) {
+	now := b.now()
+
+	b.mtx.Lock()
+	st := &b.state
+	st.Recv += recv
+	st.Sent += sent
+	msgTotals := st.Messages[command]
+	if msgTotals == nil {
+		msgTotals = new(bandwidthMsgTotals)
+		st.Messages[command] = msgTotals
+	}
+	msgTotals.Recv += recv
+	msgTotals.Sent += sent
+
+	dayStart := now.Truncate(oneDay).Unix()
+	if n := len(st.Days); n == 0 || st.Days[n-1].Start != dayStart {
+		st.Days = append(st.Days, bandwidthPeriod{Start: dayStart})
+		if len(st.Days) > maxBandwidthDays {
+			st.Days = append([]bandwidthPeriod(nil),
+				st.Days[len(st.Days)-maxBandwidthDays:]...)
+		}
+	}
+	today := &st.Days[len(st.Days)-1]
+	today.Recv += recv
+	today.Sent += sent
+
+	if sent > 0 {
+		if now.Unix() >= st.CycleStart+int64(uploadTargetTimeFrame/time.Second) {
+			st.CycleStart = now.Unix()
+			st.CycleSent = 0
+		}
+		st.CycleSent += sent
+	}
+	b.mtx.Unlock()
+}
+
+// uploadTargetReached returns whether the data sent during the current cycle
+// reached the upload target.
+//
+// This function MUST be called with the mutex held.
+func (b *bandwidthAccounting) uploadTargetReached(now time.Time) bool {
+	st := &b.state
+	cycleEnd := st.CycleStart + int64(uploadTargetTimeFrame/time.Second)
+	return b.uploadTarget > 0 && now.Unix() < cycleEnd &&
+		st.CycleSent >= b.uploadTarget
+}
+
+// UploadTargetReached returns whether the data sent during the current cycle
+// reached the upload target, in which case historical blocks are no longer
+// served to peers which are not whitelisted.
+//
+// This function is safe for concurrent access.
+func (b *bandwidthAccounting) UploadTargetReached() bool {
+	now := b.now()
+	b.mtx.Lock()
+	reached := b.uploadTargetReached(now)
+	b.mtx.Unlock()
+	return reached
+}
+
+// NetTotals sets the bandwidth totals of the passed getnettotals result.
+//
+// This function is safe for concurrent access.
+func (b *bandwidthAccounting) NetTotals(result *exccjson.GetNetTotalsResult) {
+	now := b.now().UTC()
+
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	st := &b.state
+	result.AccountingStart = st.Start
+	result.AccountedBytesRecv = st.Recv
+	result.AccountedBytesSent = st.Sent
+
+	result.Messages = make([]exccjson.NetTotalsMsg, 0, len(st.Messages))
+	for command, msgTotals := range st.Messages {
+		result.Messages = append(result.Messages, exccjson.NetTotalsMsg{
+			Command:   command,
+			BytesRecv: msgTotals.Recv,
+			BytesSent: msgTotals.Sent,
+		})
+	}
+	sort.Slice(result.Messages, func(i, j int) bool {
+		return result.Messages[i].Command < result.Messages[j].Command
+	})
+
+	// Report the most recent days and weeks, including those without any
+	// bandwidth used, from oldest to newest.  Weeks start on Monday.
+	today := now.Truncate(oneDay)
+	thisWeek := today.Add(-time.Duration((today.Weekday()+6)%7) * oneDay)
+	result.Daily = make([]exccjson.NetTotalsPeriod, bandwidthDailyPeriods)
+	for i := range result.Daily {
+		start := today.Add(-time.Duration(len(result.Daily)-1-i) * oneDay)
+		result.Daily[i].Start = start.Unix()
+	}
+	result.Weekly = make([]exccjson.NetTotalsPeriod, bandwidthWeeklyPeriods)
+	for i := range result.Weekly {
+		start := thisWeek.Add(-time.Duration(len(result.Weekly)-1-i) * oneWeek)
+		result.Weekly[i].Start = start.Unix()
+	}
+	addToPeriods := func(periods []exccjson.NetTotalsPeriod, d *bandwidthPeriod) {
+		for i := len(periods) - 1; i >= 0; i-- {
+			if d.Start >= periods[i].Start {
+				periods[i].BytesRecv += d.Recv
+				periods[i].BytesSent += d.Sent
+				return
+			}
+		}
+	}
+	for i := range st.Days {
+		d := &st.Days[i]
+		if d.Start > today.Unix() {
+			continue
+		}
+		addToPeriods(result.Daily, d)
+		addToPeriods(result.Weekly, d)
+	}
+
+	target := &result.UploadTarget
+	target.TimeFrame = int64(uploadTargetTimeFrame / time.Second)
+	target.Target = b.uploadTarget
+	target.TargetReached = b.uploadTargetReached(now)
+	target.ServeHistoricalBlocks = !target.TargetReached
+	if b.uploadTarget > 0 {
+		cycleEnd := st.CycleStart + target.TimeFrame
+		if now.Unix() < cycleEnd {
+			target.TimeLeftInCycle = cycleEnd - now.Unix()
+			if st.CycleSent < b.uploadTarget {
+				target.BytesLeftInCycle = b.uploadTarget -
+					st.CycleSent
+			}
+		} else {
+			target.BytesLeftInCycle = b.uploadTarget
+		}
+	}
+}
+
+// load replaces the bandwidth totals with those saved to the passed file by
+// save.  The totals are unchanged when the file does not exist.
+func (b *bandwidthAccounting) load(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	var st bandwidthState
+	if err := json.Unmarshal(data, &st); err != nil {
+		return err
+	}
+	if st.Version != bandwidthVersion {
+		return fmt.Errorf("unsupported version %d", st.Version)
+	}
+	if st.Messages == nil {
+		st.Messages = make(map[string]*bandwidthMsgTotals)
+	}
+
+	b.mtx.Lock()
+	b.state = st
+	b.mtx.Unlock()
+	return nil
+}
+
+// save saves the bandwidth totals to the passed file so they can be loaded by
+// load after a restart.
+//
+// This function is safe for concurrent access.
+func (b *bandwidthAccounting) save(path string) error {
+	b.mtx.Lock()
+	data, err := json.Marshal(&b.state)
+	b.mtx.Unlock()
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so an existing file is not truncated
+	// in case of failure.
+	tmpPath := path + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, data, 0600); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
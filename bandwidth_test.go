@@ -0,0 +1,119 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/EXCCoin/exccd/exccjson"
+)
+
+// TestBandwidthAccounting ensures the bandwidth totals are broken down by
+// message command, day, and week, the upload target is enforced per cycle, and
+// the totals are preserved across a save and load.
+func TestBandwidthAccounting(t *testing.T) {
+	// Wednesday, 3 January 2018 12:00 UTC.
+	now := time.Date(2018, 1, 3, 12, 0, 0, 0, time.UTC)
+	b := newBandwidthAccounting(1000, func() time.Time { return now })
+
+	// Record data on the previous Friday and today.
+	friday := now.Add(-5 * oneDay)
+	now = friday
+	b.record("block", 100, 0)
+	b.record("tx", 0, 600)
+	now = friday.Add(5 * oneDay)
+	b.record("block", 0, 400)
+	b.record("inv", 10, 0)
+
+	var result exccjson.GetNetTotalsResult
+	b.NetTotals(&result)
+	if result.AccountedBytesRecv != 110 || result.AccountedBytesSent != 1000 {
+		t.Fatalf("unexpected totals recv %d sent %d",
+			result.AccountedBytesRecv, result.AccountedBytesSent)
+	}
+	wantMsgs := []exccjson.NetTotalsMsg{
+		{Command: "block", BytesRecv: 100, BytesSent: 400},
+		{Command: "inv", BytesRecv: 10},
+		{Command: "tx", BytesSent: 600},
+	}
+	if !reflect.DeepEqual(result.Messages, wantMsgs) {
+		t.Fatalf("unexpected message totals %+v", result.Messages)
+	}
+
+	today := time.Date(2018, 1, 3, 0, 0, 0, 0, time.UTC)
+	if len(result.Daily) != bandwidthDailyPeriods {
+		t.Fatalf("unexpected number of days %d", len(result.Daily))
+	}
+	for i, period := range result.Daily {
+		start := today.Add(-time.Duration(len(result.Daily)-1-i) * oneDay)
+		want := exccjson.NetTotalsPeriod{Start: start.Unix()}
+		switch {
+		case start.Equal(today):
+			want.BytesRecv, want.BytesSent = 10, 400
+		case start.Equal(friday.Truncate(oneDay)):
+			want.BytesRecv, want.BytesSent = 100, 600
+		}
+		if period != want {
+			t.Fatalf("unexpected day %d: got %+v, want %+v", i, period,
+				want)
+		}
+	}
+
+	// The week of today starts on Monday, 1 January.
+	if len(result.Weekly) != bandwidthWeeklyPeriods {
+		t.Fatalf("unexpected number of weeks %d", len(result.Weekly))
+	}
+	thisWeek := result.Weekly[len(result.Weekly)-1]
+	lastWeek := result.Weekly[len(result.Weekly)-2]
+	if thisWeek.Start != time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC).Unix() ||
+		thisWeek.BytesRecv != 10 || thisWeek.BytesSent != 400 ||
+		lastWeek.BytesRecv != 100 || lastWeek.BytesSent != 600 {
+
+		t.Fatalf("unexpected weeks %+v", result.Weekly)
+	}
+
+	// The data sent on Friday was in a previous cycle, so the target is not
+	// reached until more is sent in the current cycle.
+	if b.UploadTargetReached() {
+		t.Fatal("upload target reached early")
+	}
+	if result.UploadTarget.BytesLeftInCycle != 600 ||
+		result.UploadTarget.TimeLeftInCycle != int64(oneDay/time.Second) {
+
+		t.Fatalf("unexpected upload target %+v", result.UploadTarget)
+	}
+	b.record("block", 0, 600)
+	if !b.UploadTargetReached() {
+		t.Fatal("upload target not reached")
+	}
+	now = now.Add(oneDay)
+	if b.UploadTargetReached() {
+		t.Fatal("upload target reached after the cycle ended")
+	}
+
+	// Ensure the totals are preserved across a save and load.
+	dir, err := ioutil.TempDir("", "bandwidth")
+	if err != nil {
+		t.Fatalf("TempDir: unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, bandwidthFilename)
+	if err := b.save(path); err != nil {
+		t.Fatalf("save: unexpected error: %v", err)
+	}
+	loaded := newBandwidthAccounting(1000, func() time.Time { return now })
+	if err := loaded.load(path); err != nil {
+		t.Fatalf("load: unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(loaded.state, b.state) {
+		t.Fatalf("unexpected loaded state %+v, want %+v", loaded.state,
+			b.state)
+	}
+}
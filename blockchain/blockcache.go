@@ -0,0 +1,145 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/EXCCoin/exccd/chaincfg/chainhash"
+	"github.com/EXCCoin/exccd/exccutil"
+)
+
+// blockCache provides a concurrency safe cache of deserialized blocks that is
+// limited to a maximum number of blocks with eviction of the least recently
+// used block when the limit is exceeded.
+//
+// It houses both the most recently connected blocks and the blocks which were
+// recently loaded from the database, so repeated requests for the same blocks,
+// such as those made by syncing peers and block explorers, don't need to load
+// and deserialize them again.
+type blockCache struct {
+	mtx    sync.Mutex
+	blocks map[chainhash.Hash]*list.Element // nearly O(1) lookups
+	lru    *list.List                       // O(1) insert, update, delete
+	limit  uint
+}
+
+// Lookup returns the block with the passed hash from the cache, or nil if it is
+// not in the cache.  Looking up a block makes it the most recently used block.
+//
+// This function is safe for concurrent access.
+func (c *blockCache) Lookup(hash *chainhash.Hash) *exccutil.Block {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	node, exists := c.blocks[*hash]
+	if !exists {
+		return nil
+	}
+	c.lru.MoveToFront(node)
+	return node.Value.(*exccutil.Block)
+}
+
+// Add adds the passed block to the cache and handles eviction of the least
+// recently used block if adding the new block would exceed the max limit.
+// Adding an existing block makes it the most recently used block.
+//
+// This function is safe for concurrent access.
+func (c *blockCache) Add(block *exccutil.Block) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	// When the limit is zero, nothing can be added to the cache, so just
+	// return.
+	if c.limit == 0 {
+		return
+	}
+
+	// When the block already exists move it to the front of the list
+	// thereby marking it most recently used.
+	hash := block.Hash()
+	if node, exists := c.blocks[*hash]; exists {
+		c.lru.MoveToFront(node)
+		return
+	}
+
+	// Evict the least recently used block (back of the list) if the new
+	// block would exceed the size limit for the cache.  Also reuse the list
+	// node so a new one doesn't have to be allocated.
+	if uint(len(c.blocks))+1 > c.limit {
+		node := c.lru.Back()
+		lru := node.Value.(*exccutil.Block)
+		delete(c.blocks, *lru.Hash())
+
+		node.Value = block
+		c.lru.MoveToFront(node)
+		c.blocks[*hash] = node
+		return
+	}
+
+	// The limit hasn't been reached yet, so just add the new block.
+	c.blocks[*hash] = c.lru.PushFront(block)
+}
+
+// Shrink evicts the least recently used blocks from the cache until no more
+// than the passed number of blocks remain in order to release memory.  The
+// cache may grow to its limit again afterwards.  It returns the number of
+// evicted blocks.
+//
+// This function is safe for concurrent access.
+func (c *blockCache) Shrink(maxEntries uint) int {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	var numEvicted int
+	for uint(len(c.blocks)) > maxEntries {
+		node := c.lru.Back()
+		lru := node.Value.(*exccutil.Block)
+		delete(c.blocks, *lru.Hash())
+		c.lru.Remove(node)
+		numEvicted++
+	}
+	return numEvicted
+}
+
+// Len returns the number of blocks in the cache.
+//
+// This function is safe for concurrent access.
+func (c *blockCache) Len() int {
+	c.mtx.Lock()
+	n := len(c.blocks)
+	c.mtx.Unlock()
+	return n
+}
+
+// newBlockCache returns a new block cache that is limited to the number of
+// blocks specified by limit.  When the number of blocks exceeds the limit, the
+// least recently used block is removed to make room for the new block.
+func newBlockCache(limit uint) *blockCache {
+	return &blockCache{
+		blocks: make(map[chainhash.Hash]*list.Element),
+		lru:    list.New(),
+		limit:  limit,
+	}
+}
+
+// ShrinkBlockCache evicts the least recently used blocks from the cache of
+// recently connected and requested blocks until no more than the passed number
+// of blocks remain in order to release memory.  It returns the number of
+// evicted blocks.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) ShrinkBlockCache(maxEntries uint) int {
+	return b.blockCache.Shrink(maxEntries)
+}
+
+// BlockCacheLen returns the number of blocks in the cache of recently connected
+// and requested blocks.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) BlockCacheLen() int {
+	return b.blockCache.Len()
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/EXCCoin/exccd/exccutil"
+	"github.com/EXCCoin/exccd/wire"
+)
+
+// TestBlockCache ensures the block cache evicts the least recently used blocks
+// once its limit is reached and when it is shrunk.
+func TestBlockCache(t *testing.T) {
+	blocks := make([]*exccutil.Block, 4)
+	for i := range blocks {
+		blocks[i] = exccutil.NewBlock(&wire.MsgBlock{
+			Header: wire.BlockHeader{Height: uint32(i)},
+		})
+	}
+
+	c := newBlockCache(3)
+	for _, block := range blocks[:3] {
+		c.Add(block)
+	}
+
+	// Looking up the first block makes the second one the least recently
+	// used, so it is evicted when the fourth block is added.
+	if c.Lookup(blocks[0].Hash()) != blocks[0] {
+		t.Fatal("first block is not cached")
+	}
+	c.Add(blocks[3])
+	if c.Len() != 3 {
+		t.Fatalf("unexpected number of cached blocks %d", c.Len())
+	}
+	if c.Lookup(blocks[1].Hash()) != nil {
+		t.Fatal("least recently used block was not evicted")
+	}
+	for _, i := range []int{0, 2, 3} {
+		if c.Lookup(blocks[i].Hash()) != blocks[i] {
+			t.Fatalf("block %d is not cached", i)
+		}
+	}
+
+	// Shrinking the cache evicts the least recently used blocks first.
+	if n := c.Shrink(1); n != 2 {
+		t.Fatalf("unexpected number of evicted blocks %d", n)
+	}
+	if c.Lookup(blocks[3].Hash()) != blocks[3] {
+		t.Fatal("most recently used block was evicted")
+	}
+	if n := c.Shrink(1); n != 0 {
+		t.Fatalf("unexpected number of evicted blocks %d", n)
+	}
+
+	// Nothing is cached when the limit is zero.
+	c = newBlockCache(0)
+	c.Add(blocks[0])
+	if c.Len() != 0 {
+		t.Fatalf("unexpected number of cached blocks %d", c.Len())
+	}
+}
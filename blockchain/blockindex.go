@@ -334,12 +334,22 @@ func (bi *blockIndex) loadBlockNode(dbTx database.Tx, hash *chainhash.Hash) (*bl
 	if err != nil {
 		return nil, err
 	}
+	return bi.addLoadedNode(entry)
+}
+
+// addLoadedNode creates a main chain block node from the passed block index
+// entry and connects it to the existing nodes of the block index, which must
+// include either its parent or one of its children.
+//
+// This function MUST be called with the block index lock held (for writes).
+func (bi *blockIndex) addLoadedNode(entry *blockIndexEntry) (*blockNode, error) {
 	node := new(blockNode)
 	initBlockNode(node, &entry.header, nil)
 	node.ticketsVoted = entry.ticketsVoted
 	node.ticketsRevoked = entry.ticketsRevoked
 	node.votes = entry.voteInfo
 	node.inMainChain = true
+	hash := &node.hash
 
 	// Add the node to the chain.
 	// There are a few possibilities here:
@@ -100,6 +100,7 @@ type BlockChain struct {
 	// The following fields are set when the instance is created and can't
 	// be changed afterwards, so there is no need to protect them with a
 	// separate mutex.
+	checkpoints         []chaincfg.Checkpoint
 	checkpointsByHeight map[int64]*chaincfg.Checkpoint
 	db                  database.DB
 	dbInfo              *databaseInfo
@@ -140,6 +141,15 @@ type BlockChain struct {
 	mainchainBlockCache     map[chainhash.Hash]*exccutil.Block
 	mainchainBlockCacheSize int
 
+	// blockCache houses the most recently connected and requested blocks
+	// so they don't need to be loaded from the database again.
+	blockCache *blockCache
+
+	// invalidBlocks houses the hashes of recently processed blocks which
+	// failed validation so they are rejected immediately when processed
+	// again.  It is protected by the chain lock.
+	invalidBlocks *invalidBlockCache
+
 	// These fields are related to checkpoint handling.  They are protected
 	// by the chain lock.
 	nextCheckpoint  *chaincfg.Checkpoint
@@ -385,6 +395,27 @@ func (b *BlockChain) GetOrphanRoot(hash *chainhash.Hash) *chainhash.Hash {
 	return orphanRoot
 }
 
+// RemoveOrphanBlocks removes all blocks from the orphan pool in order to free
+// the memory they use.  Removed orphans are requested again from peers when
+// their blocks are announced again.  It returns the number of removed orphans.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) RemoveOrphanBlocks() int {
+	b.chainLock.Lock()
+	defer b.chainLock.Unlock()
+
+	// The orphans are only added while the chain lock is held, and
+	// removeOrphanBlock does its own locking while the range iterator is
+	// not invalidated by removing map entries.
+	numRemoved := 0
+	for _, oBlock := range b.orphans {
+		b.removeOrphanBlock(oBlock)
+		numRemoved++
+	}
+	b.oldestOrphan = nil
+	return numRemoved
+}
+
 // removeOrphanBlock removes the passed orphan block from the orphan pool and
 // previous orphan index.
 func (b *BlockChain) removeOrphanBlock(orphan *orphanBlock) {
@@ -542,8 +573,8 @@ func (b *BlockChain) findNode(nodeHash *chainhash.Hash, searchDepth int) (*block
 }
 
 // fetchMainChainBlockByHash returns the block from the main chain with the
-// given hash.  It first attempts to use cache and then falls back to loading it
-// from the database.
+// given hash.  It first attempts to use the caches and then falls back to
+// loading it from the database.
 //
 // An error is returned if the block is either not found or not in the main
 // chain.
@@ -557,12 +588,28 @@ func (b *BlockChain) fetchMainChainBlockByHash(hash *chainhash.Hash) (*exccutil.
 		return block, nil
 	}
 
-	// Load the block from the database.
+	// Use the cache of recently connected and requested blocks when the
+	// block is in the main chain since it also houses side chain blocks.
+	// Otherwise, load the block from the database.
+	cached := b.blockCache.Lookup(hash)
 	err := b.db.View(func(dbTx database.Tx) error {
+		if cached != nil {
+			if !dbMainChainHasBlock(dbTx, hash) {
+				str := fmt.Sprintf("block %s is not in the main "+
+					"chain", hash)
+				return errNotInMainChain(str)
+			}
+			block = cached
+			return nil
+		}
+
 		var err error
 		block, err = dbFetchBlockByHash(dbTx, hash)
 		return err
 	})
+	if err == nil && cached == nil {
+		b.blockCache.Add(block)
+	}
 	return block, err
 }
 
@@ -587,6 +634,11 @@ func (b *BlockChain) fetchBlockByHash(hash *chainhash.Hash) (*exccutil.Block, er
 		return block, nil
 	}
 
+	// Check the cache of recently connected and requested blocks.
+	if block := b.blockCache.Lookup(hash); block != nil {
+		return block, nil
+	}
+
 	// Attempt to load the block from the database.
 	err := b.db.View(func(dbTx database.Tx) error {
 		// NOTE: This does not use the dbFetchBlockByHash function since that
@@ -600,6 +652,7 @@ func (b *BlockChain) fetchBlockByHash(hash *chainhash.Hash) (*exccutil.Block, er
 		return err
 	})
 	if err == nil && block != nil {
+		b.blockCache.Add(block)
 		return block, nil
 	}
 
@@ -777,6 +830,8 @@ func (b *BlockChain) pushMainChainBlockCache(block *exccutil.Block) {
 		}
 	}
 	b.mainchainBlockCacheLock.Unlock()
+
+	b.blockCache.Add(block)
 }
 
 // connectBlock handles connecting the passed node/block to the end of the main
@@ -958,7 +1013,8 @@ func (b *BlockChain) connectBlock(node *blockNode, block, parent *exccutil.Block
 
 	// Optimization: Before checkpoints, immediately dump the parent's stake
 	// node because we no longer need it.
-	if node.height < b.chainParams.LatestCheckpointHeight() {
+	if checkpoint := b.latestCheckpoint(); checkpoint != nil &&
+		node.height < checkpoint.Height {
 		b.bestNode.parent.stakeNode = nil
 		b.bestNode.parent.stakeUndoData = nil
 		b.bestNode.parent.newTickets = nil
@@ -1345,6 +1401,7 @@ func (b *BlockChain) reorganizeChain(detachNodes, attachNodes *list.List) error
 		oldBest.height,
 		newBest.hash,
 		newBest.height,
+		oldBest.height - int64(detachNodes.Len()),
 	}
 	b.chainLock.Unlock()
 	b.sendNotification(NTReorganization, reorgData)
@@ -1750,24 +1807,25 @@ func (b *BlockChain) BestSnapshot() *BestState {
 //
 // This function MUST be called with the chain state lock held (for reads).
 func (b *BlockChain) maxBlockSize(prevNode *blockNode) (int64, error) {
-	// Hard fork voting on block size is only enabled on testnet v1 and
-	// simnet.
-	if b.chainParams.Net != wire.SimNet {
+	// Hard fork voting on block size is only enabled on networks which
+	// define the agenda, such as simnet.
+	_, deployment := b.chainParams.DeploymentByID(chaincfg.VoteIDMaxBlockSize)
+	if deployment == nil {
 		return int64(b.chainParams.MaximumBlockSizes[0]), nil
 	}
 
-	// Return the larger block size if the version 4 stake vote for the max
-	// block size increase agenda is active.
+	// Return the larger block size if the stake vote for the max block size
+	// increase agenda is active.
 	//
-	// NOTE: The choice field of the return threshold state is not examined
-	// here because there is only one possible choice that can be active
-	// for the agenda, which is yes, so there is no need to check it.
+	// NOTE: The choice of the agenda is not examined here because there is
+	// only one possible choice that can be active for the agenda, which is
+	// yes, so there is no need to check it.
 	maxSize := int64(b.chainParams.MaximumBlockSizes[0])
-	state, err := b.deploymentState(prevNode, 4, chaincfg.VoteIDMaxBlockSize)
+	active, err := b.isDeploymentActive(prevNode, chaincfg.VoteIDMaxBlockSize)
 	if err != nil {
 		return maxSize, err
 	}
-	if state.State == ThresholdActive {
+	if active {
 		return int64(b.chainParams.MaximumBlockSizes[1]), nil
 	}
 
@@ -1866,6 +1924,14 @@ type Config struct {
 	// This field is required.
 	ChainParams *chaincfg.Params
 
+	// Checkpoints defines the checkpoints to use in place of the ones of the
+	// chain parameters, which allows callers to supply additional
+	// checkpoints.  They must be sorted by height.
+	//
+	// This field can be nil if the caller only wishes to use the
+	// checkpoints of the chain parameters.
+	Checkpoints []chaincfg.Checkpoint
+
 	// TimeSource defines the median time source to use for things such as
 	// block processing and determining whether or not the chain is current.
 	//
@@ -1898,6 +1964,15 @@ type Config struct {
 	// This field can be nil if the caller does not wish to make use of an
 	// index manager.
 	IndexManager IndexManager
+
+	// BlockCacheSize is the maximum number of the most recently connected
+	// and requested blocks to keep deserialized in memory so they don't
+	// need to be loaded from the database again when they are requested,
+	// such as by syncing peers.
+	//
+	// This field can be zero if the caller does not wish to make use of a
+	// block cache.
+	BlockCacheSize uint
 }
 
 // New returns a BlockChain instance using the provided configuration details.
@@ -1910,18 +1985,30 @@ func New(config *Config) (*BlockChain, error) {
 		return nil, AssertError("blockchain.New chain parameters nil")
 	}
 
-	// Generate a checkpoint by height map from the provided checkpoints.
+	// Generate a checkpoint by height map from the provided checkpoints
+	// and ensure they are sorted by height.
 	params := config.ChainParams
+	checkpoints := params.Checkpoints
+	if config.Checkpoints != nil {
+		checkpoints = config.Checkpoints
+	}
 	var checkpointsByHeight map[int64]*chaincfg.Checkpoint
-	if len(params.Checkpoints) > 0 {
+	if len(checkpoints) > 0 {
 		checkpointsByHeight = make(map[int64]*chaincfg.Checkpoint)
-		for i := range params.Checkpoints {
-			checkpoint := &params.Checkpoints[i]
+		prevCheckpointHeight := int64(0)
+		for i := range checkpoints {
+			checkpoint := &checkpoints[i]
+			if checkpoint.Height <= prevCheckpointHeight {
+				return nil, AssertError("blockchain.New " +
+					"checkpoints are not sorted by height")
+			}
 			checkpointsByHeight[checkpoint.Height] = checkpoint
+			prevCheckpointHeight = checkpoint.Height
 		}
 	}
 
 	b := BlockChain{
+		checkpoints:                   checkpoints,
 		checkpointsByHeight:           checkpointsByHeight,
 		db:                            config.DB,
 		chainParams:                   params,
@@ -1934,6 +2021,8 @@ func New(config *Config) (*BlockChain, error) {
 		prevOrphans:                   make(map[chainhash.Hash][]*orphanBlock),
 		mainchainBlockCache:           make(map[chainhash.Hash]*exccutil.Block),
 		mainchainBlockCacheSize:       mainchainBlockCacheSize,
+		blockCache:                    newBlockCache(config.BlockCacheSize),
+		invalidBlocks:                 newInvalidBlockCache(maxInvalidBlocks),
 		deploymentCaches:              newThresholdCaches(params),
 		isVoterMajorityVersionCache:   make(map[[stakeMajorityCacheKeySize]byte]bool),
 		isStakeMajorityVersionCache:   make(map[[stakeMajorityCacheKeySize]byte]bool),
@@ -1949,6 +2038,13 @@ func New(config *Config) (*BlockChain, error) {
 		return nil, err
 	}
 
+	// Ensure the local chain does not conflict with any of the checkpoints
+	// since checkpoints which were supplied after the blocks were
+	// downloaded could otherwise never be enforced.
+	if err := b.verifyCheckpoints(); err != nil {
+		return nil, err
+	}
+
 	// Initialize and catch up all of the currently active optional indexes
 	// as needed.
 	if config.IndexManager != nil {
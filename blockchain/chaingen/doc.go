@@ -21,5 +21,12 @@ In order to simplify this complex process, this package provides a generator
 that keeps track of all of the necessary state and generates and solves blocks
 accordingly while allowing the caller to manipulate the blocks via munge
 functions.
+
+The generated blocks are deterministic.  Their timestamps start from the
+genesis block instead of the current time and the data which must be unique,
+such as the extra nonces in the coinbase transactions, comes from a counter
+instead of random values, so the same sequence of calls always generates the
+same blocks.  This allows tests to refer to the resulting blocks and
+transactions by their hashes.
 */
 package chaingen
@@ -151,6 +151,9 @@ type Generator struct {
 	p2shOpTrueAddr   exccutil.Address
 	p2shOpTrueScript []byte
 
+	// Used for making the data which must be unique deterministic.
+	uniqueNonce uint64
+
 	// Used for tracking spendable coinbase outputs.
 	spendableOuts     [][]SpendableOut
 	prevCollectedHash chainhash.Hash
@@ -262,17 +265,40 @@ func opReturnScript(data []byte) []byte {
 	return script
 }
 
+// nextUniqueNonce returns a value which is unique for the generator instance.
+// It is used instead of a random value wherever data must be unique so that
+// the same sequence of calls always generates the same blocks.
+func (g *Generator) nextUniqueNonce() uint64 {
+	g.uniqueNonce++
+	return g.uniqueNonce
+}
+
+// uint64OpReturnScript returns a standard provably-pruneable OP_RETURN script
+// with the passed uint64 encoded as the data.
+func uint64OpReturnScript(value uint64) []byte {
+	data := make([]byte, 8)
+	binary.LittleEndian.PutUint64(data[0:8], value)
+	return opReturnScript(data)
+}
+
 // UniqueOpReturnScript returns a standard provably-pruneable OP_RETURN script
 // with a random uint64 encoded as the data.
+//
+// Scripts returned by this function differ between runs.  Use the
+// UniqueOpReturnScript method of a generator to generate the same blocks for
+// the same sequence of calls.
 func UniqueOpReturnScript() []byte {
 	rand, err := wire.RandomUint64()
 	if err != nil {
 		panic(err)
 	}
+	return uint64OpReturnScript(rand)
+}
 
-	data := make([]byte, 8)
-	binary.LittleEndian.PutUint64(data[0:8], rand)
-	return opReturnScript(data)
+// UniqueOpReturnScript returns a standard provably-pruneable OP_RETURN script
+// with a uint64 which is unique for the generator instance encoded as the data.
+func (g *Generator) UniqueOpReturnScript() []byte {
+	return uint64OpReturnScript(g.nextUniqueNonce())
 }
 
 // calcFullSubsidy returns the full block subsidy for the given block height.
@@ -334,19 +360,14 @@ func (g *Generator) calcPoSSubsidy(heightVotedOn uint32) exccutil.Amount {
 // the second output of a standard coinbase transaction of a new block.  In
 // particular, the serialized data used with the OP_RETURN starts with the block
 // height and is followed by 32 bytes which are treated as 4 uint64 extra
-// nonces.  This implementation puts a cryptographically random value into the
-// final extra nonce position.  The actual format of the data after the block
-// height is not defined however this effectively mirrors the actual mining code
-// at the time it was written.
-func standardCoinbaseOpReturnScript(blockHeight uint32) []byte {
-	rand, err := wire.RandomUint64()
-	if err != nil {
-		panic(err)
-	}
-
+// nonces.  This implementation puts the provided extra nonce into the final
+// extra nonce position.  The actual format of the data after the block height
+// is not defined however this effectively mirrors the actual mining code at the
+// time it was written.
+func standardCoinbaseOpReturnScript(blockHeight uint32, extraNonce uint64) []byte {
 	data := make([]byte, 36)
 	binary.LittleEndian.PutUint32(data[0:4], blockHeight)
-	binary.LittleEndian.PutUint64(data[28:36], rand)
+	binary.LittleEndian.PutUint64(data[28:36], extraNonce)
 	return opReturnScript(data)
 }
 
@@ -359,7 +380,8 @@ func (g *Generator) addCoinbaseTxOutputs(tx *wire.MsgTx, blockHeight uint32, pow
 
 	// First output is a provably prunable data-only output that is used
 	// to ensure the coinbase is unique.
-	tx.AddTxOut(wire.NewTxOut(0, standardCoinbaseOpReturnScript(blockHeight)))
+	script := standardCoinbaseOpReturnScript(blockHeight, g.nextUniqueNonce())
+	tx.AddTxOut(wire.NewTxOut(0, script))
 
 	// Final outputs are the proof-of-work subsidy split into more than one
 	// output.  These are in turn used throughout the tests as inputs to
@@ -1291,19 +1313,20 @@ const (
 	maxExtraNonce = ^uint64(0) // 2^64 - 1
 )
 
+// SolveBlockWithEquihash attempts to find an equihash solution for the passed
+// block header by iterating through the extra nonce and nonce ranges starting
+// from zero, so the same header always ends up with the same solution.  The
+// header is updated with the solution along with the nonces it was found with.
+//
+// It returns whether or not a solution was found.
 func SolveBlockWithEquihash(header *wire.BlockHeader, chainParams *chaincfg.Params) bool {
-	enOffset, err := wire.RandomUint64()
-	if err != nil {
-		enOffset = 0
-	}
-
 	solved := false
 	validator := solutionValidatorData{chainParams, &solved, header}
 
 	for extraNonce := uint64(0); extraNonce < maxExtraNonce && !solved; extraNonce++ {
 		// Update the extra nonce in the block template header with the
 		// new value.
-		binary.LittleEndian.PutUint64(header.ExtraData[:], extraNonce+enOffset)
+		binary.LittleEndian.PutUint64(header.ExtraData[:], extraNonce)
 
 		// Update equihash solver input bytes
 		headerBytes, _ := header.SerializeAllHeaderBytes()
@@ -1472,7 +1495,7 @@ func (g *Generator) CreateSpendTx(spend *SpendableOut, fee exccutil.Amount) *wir
 	})
 	spendTx.AddTxOut(wire.NewTxOut(int64(spend.amount-fee),
 		g.p2shOpTrueScript))
-	spendTx.AddTxOut(wire.NewTxOut(0, UniqueOpReturnScript()))
+	spendTx.AddTxOut(wire.NewTxOut(0, g.UniqueOpReturnScript()))
 	return spendTx
 }
 
@@ -1960,22 +1983,19 @@ func (g *Generator) NextBlock(blockName string, spend *SpendableOut, ticketSpend
 	}
 
 	// Use a timestamp that is 7/8 of target timespan after the previous
-	// block unless this is the first block in which case the current time
-	// is used or the proof-of-work difficulty parameters have been adjusted
-	// such that it's greater than the max 2 hours worth of blocks that can
-	// be tested in which case one second is used.  This helps maintain the
-	// retarget difficulty low as needed.  Also, ensure the timestamp is
-	// limited to one second precision.
+	// block, which starts from the timestamp of the genesis block so the
+	// generated blocks do not depend on the current time, unless the
+	// proof-of-work difficulty parameters have been adjusted such that it's
+	// greater than the max 2 hours worth of blocks that can be tested in
+	// which case one second is used.  This helps maintain the retarget
+	// difficulty low as needed.  Also, ensure the timestamp is limited to
+	// one second precision.
 	var ts time.Time
-	if nextHeight == 1 {
-		ts = time.Now()
+	if g.params.WorkDiffWindowSize > 7200 {
+		ts = g.tip.Header.Timestamp.Add(time.Second)
 	} else {
-		if g.params.WorkDiffWindowSize > 7200 {
-			ts = g.tip.Header.Timestamp.Add(time.Second)
-		} else {
-			addDuration := g.params.TargetTimespan * 7 / 8
-			ts = g.tip.Header.Timestamp.Add(addDuration)
-		}
+		addDuration := g.params.TargetTimespan * 7 / 8
+		ts = g.tip.Header.Timestamp.Add(addDuration)
 	}
 	ts = time.Unix(ts.Unix(), 0)
 
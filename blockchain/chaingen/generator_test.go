@@ -0,0 +1,50 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package chaingen
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/EXCCoin/exccd/chaincfg"
+	"github.com/EXCCoin/exccd/chaincfg/chainhash"
+)
+
+// TestDeterministicGeneration ensures generators which are driven by the same
+// sequence of calls generate the same blocks.
+func TestDeterministicGeneration(t *testing.T) {
+	params := &chaincfg.SimNetParams
+	generate := func() []chainhash.Hash {
+		g, err := MakeGenerator(params, nil)
+		if err != nil {
+			t.Fatalf("MakeGenerator: unexpected error: %v", err)
+		}
+		var hashes []chainhash.Hash
+		for i := 0; i < 3; i++ {
+			g.NextBlock(fmt.Sprintf("bm%d", i), nil, nil)
+			hashes = append(hashes, g.Tip().BlockHash())
+		}
+
+		// Create a side chain block at the same height as the tip to
+		// ensure the coinbase remains unique.
+		g.SetTip("bm1")
+		g.NextBlock("bm2a", nil, nil)
+		if g.Tip().Transactions[0].TxHash() ==
+			g.BlockByName("bm2").Transactions[0].TxHash() {
+
+			t.Fatal("side chain block has the same coinbase")
+		}
+		return append(hashes, g.Tip().BlockHash())
+	}
+
+	want := generate()
+	got := generate()
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("block %d: mismatched hash %v, want %v", i, got[i],
+				want[i])
+		}
+	}
+}
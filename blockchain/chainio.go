@@ -31,7 +31,7 @@ var (
 const (
 	// currentDatabaseVersion indicates what the current database
 	// version is.
-	currentDatabaseVersion = 3
+	currentDatabaseVersion = 4
 
 	// currentBlockIndexVersion indicates what the current block index
 	// database version.
@@ -59,6 +59,14 @@ func isNotInMainChainErr(err error) bool {
 	return ok
 }
 
+// IsNotInMainChainErr is the exported version of isNotInMainChainErr.  It is
+// primarily useful to callers of the exported database fetch functions which
+// need to distinguish requests past the end of the main chain from other
+// failures.
+func IsNotInMainChainErr(err error) bool {
+	return isNotInMainChainErr(err)
+}
+
 // errDeserialize signifies that a problem was encountered when deserializing
 // data.
 type errDeserialize string
@@ -1195,8 +1203,13 @@ func dbFetchUtxoEntry(dbTx database.Tx, hash *chainhash.Hash) (*UtxoEntry, error
 // dbPutUtxoView uses an existing database transaction to update the utxo set
 // in the database based on the provided utxo view contents and state.  In
 // particular, only the entries that have been marked as modified are written
-// to the database.
+// to the database.  The utxo set state is updated to reflect the changes.
 func dbPutUtxoView(dbTx database.Tx, view *UtxoViewpoint) error {
+	state, err := dbFetchUtxoSetState(dbTx)
+	if err != nil {
+		return err
+	}
+
 	utxoBucket := dbTx.Metadata().Bucket(dbnamespace.UtxoSetBucketName)
 	for txHashIter, entry := range view.entries {
 		// No need to update the database if the entry was not modified.
@@ -1216,6 +1229,23 @@ func dbPutUtxoView(dbTx database.Tx, view *UtxoViewpoint) error {
 		// data to change out from under the put/delete funcs below.
 		txHash := txHashIter
 
+		// Load the entry being replaced so only the outputs which
+		// changed are accounted for in the utxo set state.
+		var oldEntry *UtxoEntry
+		oldSerialized := utxoBucket.Get(txHash[:])
+		if oldSerialized != nil {
+			oldEntry, err = deserializeUtxoEntry(oldSerialized)
+			if err != nil {
+				return err
+			}
+		}
+		newEntry := entry
+		if serialized == nil {
+			newEntry = nil
+		}
+		state.updateEntry(&txHash, oldEntry, newEntry, oldSerialized,
+			serialized)
+
 		// Remove the utxo entry if it is now fully spent.
 		if serialized == nil {
 			if err := utxoBucket.Delete(txHash[:]); err != nil {
@@ -1233,7 +1263,7 @@ func dbPutUtxoView(dbTx database.Tx, view *UtxoViewpoint) error {
 		}
 	}
 
-	return nil
+	return dbPutUtxoSetState(dbTx, state)
 }
 
 // -----------------------------------------------------------------------------
@@ -1634,6 +1664,12 @@ func (b *BlockChain) createChainState() error {
 			return err
 		}
 
+		// Store the state of the empty utxo set.
+		err = dbPutUtxoSetState(dbTx, newUtxoSetState())
+		if err != nil {
+			return err
+		}
+
 		// Add the genesis block to the block index.
 		err = dbPutBlockNode(dbTx, node)
 		if err != nil {
@@ -1838,7 +1874,12 @@ func (b *BlockChain) initChainState(interrupt <-chan struct{}) error {
 
 		return nil
 	})
-	return err
+	if err != nil {
+		return err
+	}
+
+	// Load the most recent block nodes saved on the previous clean shutdown.
+	return b.loadHotState()
 }
 
 // dbFetchHeaderByHash uses an existing database transaction to retrieve the
@@ -40,11 +40,11 @@ func (b *BlockChain) Checkpoints() []chaincfg.Checkpoint {
 	b.chainLock.RLock()
 	defer b.chainLock.RUnlock()
 
-	if b.noCheckpoints || len(b.chainParams.Checkpoints) == 0 {
+	if b.noCheckpoints || len(b.checkpoints) == 0 {
 		return nil
 	}
 
-	return b.chainParams.Checkpoints
+	return b.checkpoints
 }
 
 // latestCheckpoint returns the most recent checkpoint (regardless of whether it
@@ -53,11 +53,11 @@ func (b *BlockChain) Checkpoints() []chaincfg.Checkpoint {
 //
 // This function MUST be called with the chain state lock held (for reads).
 func (b *BlockChain) latestCheckpoint() *chaincfg.Checkpoint {
-	if b.noCheckpoints || len(b.chainParams.Checkpoints) == 0 {
+	if b.noCheckpoints || len(b.checkpoints) == 0 {
 		return nil
 	}
 
-	checkpoints := b.chainParams.Checkpoints
+	checkpoints := b.checkpoints
 	return &checkpoints[len(checkpoints)-1]
 }
 
@@ -79,7 +79,7 @@ func (b *BlockChain) LatestCheckpoint() *chaincfg.Checkpoint {
 //
 // This function MUST be called with the chain lock held (for reads).
 func (b *BlockChain) verifyCheckpoint(height int64, hash *chainhash.Hash) bool {
-	if b.noCheckpoints || len(b.chainParams.Checkpoints) == 0 {
+	if b.noCheckpoints || len(b.checkpoints) == 0 {
 		return true
 	}
 
@@ -98,6 +98,36 @@ func (b *BlockChain) verifyCheckpoint(height int64, hash *chainhash.Hash) bool {
 	return true
 }
 
+// verifyCheckpoints ensures the blocks of the main chain at the heights of all
+// checkpoints which the main chain has already reached are the checkpointed
+// blocks.  This prevents a node which has already synced a chain that conflicts
+// with a checkpoint, such as one supplied after the fact, from silently
+// continuing to extend it.
+//
+// This function MUST be called with the chain state lock held (for reads).
+func (b *BlockChain) verifyCheckpoints() error {
+	if b.noCheckpoints {
+		return nil
+	}
+
+	return b.db.View(func(dbTx database.Tx) error {
+		for i := range b.checkpoints {
+			checkpoint := &b.checkpoints[i]
+			if checkpoint.Height > b.bestNode.height {
+				break
+			}
+
+			if !dbMainChainHasBlock(dbTx, checkpoint.Hash) {
+				str := fmt.Sprintf("the main chain does not "+
+					"contain checkpoint block %s at height %d",
+					checkpoint.Hash, checkpoint.Height)
+				return ruleError(ErrBadCheckpoint, str)
+			}
+		}
+		return nil
+	})
+}
+
 // findPreviousCheckpoint finds the most recent checkpoint that is already
 // available in the downloaded portion of the block chain and returns the
 // associated block.  It returns nil if a checkpoint can't be found (this should
@@ -105,12 +135,12 @@ func (b *BlockChain) verifyCheckpoint(height int64, hash *chainhash.Hash) bool {
 //
 // This function MUST be called with the chain lock held (for reads).
 func (b *BlockChain) findPreviousCheckpoint() (*exccutil.Block, error) {
-	if b.noCheckpoints || len(b.chainParams.Checkpoints) == 0 {
+	if b.noCheckpoints || len(b.checkpoints) == 0 {
 		return nil, nil
 	}
 
 	// No checkpoints.
-	checkpoints := b.chainParams.Checkpoints
+	checkpoints := b.checkpoints
 	numCheckpoints := len(checkpoints)
 	if numCheckpoints == 0 {
 		return nil, nil
@@ -0,0 +1,132 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock provides the current time along with the ability to wait for a duration
+// to elapse.  It allows simulations to control the passage of time which is
+// otherwise taken from the local clock.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// After waits for the duration to elapse and then sends the current
+	// time on the returned channel.
+	After(d time.Duration) <-chan time.Time
+}
+
+// systemClock provides an implementation of the Clock interface which uses the
+// local clock.
+type systemClock struct{}
+
+// Now returns the current local time.
+//
+// This is part of the Clock interface implementation.
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// After waits for the duration to elapse on the local clock and then sends the
+// current time on the returned channel.
+//
+// This is part of the Clock interface implementation.
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// SystemClock is the Clock which uses the local clock.
+var SystemClock Clock = systemClock{}
+
+// mockWaiter is a pending wait for a mock time to be reached.
+type mockWaiter struct {
+	deadline time.Time
+	c        chan time.Time
+}
+
+// MockClock provides an implementation of the Clock interface which uses the
+// local clock until a mock time is set and then only advances when a new mock
+// time is set.  This allows simulations to fast-forward time deterministically
+// instead of waiting for it to pass.
+type MockClock struct {
+	mtx      sync.Mutex
+	mockTime time.Time
+	waiters  []mockWaiter
+}
+
+// Ensure the MockClock type implements the Clock interface.
+var _ Clock = (*MockClock)(nil)
+
+// Now returns the mock time when it is set or the local time otherwise.
+//
+// This function is safe for concurrent access and is part of the Clock
+// interface implementation.
+func (c *MockClock) Now() time.Time {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.mockTime.IsZero() {
+		return time.Now()
+	}
+	return c.mockTime
+}
+
+// After waits for the duration to elapse and then sends the current time on the
+// returned channel.  The duration elapses once a mock time at or after the
+// deadline is set when the mock time is set, or on the local clock otherwise.
+//
+// This function is safe for concurrent access and is part of the Clock
+// interface implementation.
+func (c *MockClock) After(d time.Duration) <-chan time.Time {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.mockTime.IsZero() {
+		return time.After(d)
+	}
+
+	ch := make(chan time.Time, 1)
+	deadline := c.mockTime.Add(d)
+	if !deadline.After(c.mockTime) {
+		ch <- c.mockTime
+		return ch
+	}
+	c.waiters = append(c.waiters, mockWaiter{deadline: deadline, c: ch})
+	return ch
+}
+
+// SetTime sets the mock time and notifies the waiters whose deadline has been
+// reached.  Setting the zero time stops mocking the time, in which case all
+// waiters are notified since their deadlines can no longer be tracked.
+//
+// This function is safe for concurrent access.
+func (c *MockClock) SetTime(t time.Time) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.mockTime = t
+	now := t
+	if now.IsZero() {
+		now = time.Now()
+	}
+	waiters := c.waiters[:0]
+	for _, w := range c.waiters {
+		if !t.IsZero() && w.deadline.After(t) {
+			waiters = append(waiters, w)
+			continue
+		}
+		w.c <- now
+	}
+	c.waiters = waiters
+}
+
+// NewMockClock returns a new mock clock which uses the local clock until a mock
+// time is set via SetTime.
+func NewMockClock() *MockClock {
+	return &MockClock{}
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"testing"
+	"time"
+)
+
+// TestMockClock ensures the mock clock only advances when a new mock time is set
+// and notifies waiters once their deadline is reached.
+func TestMockClock(t *testing.T) {
+	c := NewMockClock()
+	if now := c.Now(); time.Since(now) > time.Minute {
+		t.Fatalf("unexpected time %v without mock time", now)
+	}
+
+	start := time.Unix(1500000000, 0)
+	c.SetTime(start)
+	if now := c.Now(); !now.Equal(start) {
+		t.Fatalf("unexpected time %v, want %v", now, start)
+	}
+
+	// Waits for non-positive durations complete immediately.
+	select {
+	case <-c.After(0):
+	default:
+		t.Fatal("wait for zero duration did not complete")
+	}
+
+	// Waiters are only notified once the mock time reaches their deadline.
+	short := c.After(10 * time.Second)
+	long := c.After(time.Minute)
+	c.SetTime(start.Add(30 * time.Second))
+	select {
+	case now := <-short:
+		if !now.Equal(start.Add(30 * time.Second)) {
+			t.Fatalf("unexpected notified time %v", now)
+		}
+	default:
+		t.Fatal("waiter was not notified once its deadline was reached")
+	}
+	select {
+	case <-long:
+		t.Fatal("waiter was notified before its deadline was reached")
+	default:
+	}
+
+	// All waiters are notified when the mock time is cleared.
+	c.SetTime(time.Time{})
+	select {
+	case <-long:
+	default:
+		t.Fatal("waiter was not notified when the mock time was cleared")
+	}
+
+	// The median time uses the time of its clock.
+	c.SetTime(start)
+	filter := NewMedianTimeWithClock(c)
+	if now := filter.AdjustedTime(); !now.Equal(start) {
+		t.Fatalf("unexpected adjusted time %v, want %v", now, start)
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+// isConsensusUpgradeActive returns whether or not the rules of the consensus
+// upgrade with the provided name apply to the block AFTER the given node.
+// False is returned when the network does not define the upgrade.
+//
+// Validation code enforcing the rules of a hard fork must use this instead of
+// comparing heights directly so the activation heights of all hard forks are
+// defined in one place by the chain params.
+func (b *BlockChain) isConsensusUpgradeActive(prevNode *blockNode, name string) bool {
+	height := int64(0)
+	if prevNode != nil {
+		height = prevNode.height + 1
+	}
+	return b.chainParams.IsConsensusUpgradeActive(name, height)
+}
+
+// IsConsensusUpgradeActive returns whether or not the rules of the consensus
+// upgrade with the provided name apply to the block AFTER the end of the
+// current best chain.  False is returned when the network does not define the
+// upgrade.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) IsConsensusUpgradeActive(name string) bool {
+	b.chainLock.RLock()
+	active := b.isConsensusUpgradeActive(b.bestNode, name)
+	b.chainLock.RUnlock()
+	return active
+}
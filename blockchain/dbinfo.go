@@ -0,0 +1,262 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/EXCCoin/exccd/blockchain/internal/dbnamespace"
+	"github.com/EXCCoin/exccd/chaincfg/chainhash"
+	"github.com/EXCCoin/exccd/database"
+	"github.com/EXCCoin/exccd/wire"
+)
+
+// maxDatabaseProblems is the maximum number of problems reported by
+// CheckDatabase.  Once it is reached, further problems are only counted.
+const maxDatabaseProblems = 50
+
+// DatabaseInfo describes the chain stored in a block database along with the
+// versions of the formats it is stored in.
+type DatabaseInfo struct {
+	Version            uint32
+	CompressionVersion uint32
+	BlockIndexVersion  uint32
+	Created            time.Time
+
+	BestHash     chainhash.Hash
+	BestHeight   int64
+	TotalTxns    uint64
+	TotalSubsidy int64
+}
+
+// FetchDatabaseInfo loads the details of the chain stored in the passed
+// database.  Unlike creating a chain instance, it never writes to the database,
+// so it may be used to inspect a database which is opened read only.
+func FetchDatabaseInfo(db database.DB) (*DatabaseInfo, error) {
+	var info DatabaseInfo
+	err := db.View(func(dbTx database.Tx) error {
+		dbInfo, err := dbFetchDatabaseInfo(dbTx)
+		if err != nil {
+			return err
+		}
+		if dbInfo == nil {
+			return errors.New("database does not contain a chain")
+		}
+		info.Version = dbInfo.version
+		info.CompressionVersion = dbInfo.compVer
+		info.BlockIndexVersion = dbInfo.bidxVer
+		info.Created = dbInfo.created
+
+		serializedState := dbTx.Metadata().Get(dbnamespace.ChainStateKeyName)
+		if serializedState == nil {
+			return database.Error{
+				ErrorCode:   database.ErrCorruption,
+				Description: "missing best chain state",
+			}
+		}
+		state, err := deserializeBestChainState(serializedState)
+		if err != nil {
+			return err
+		}
+		info.BestHash = state.hash
+		info.BestHeight = int64(state.height)
+		info.TotalTxns = state.totalTxns
+		info.TotalSubsidy = state.totalSubsidy
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+// DatabaseCheckResult houses the results of checking the consistency of the
+// chain stored in a block database.
+type DatabaseCheckResult struct {
+	// BlocksChecked is the number of blocks of the main chain which were
+	// checked.
+	BlocksChecked int64
+
+	// BlockIndexEntries and UtxoEntries are the number of entries of the
+	// block index and the utxo set which were decoded.
+	BlockIndexEntries uint64
+	UtxoEntries       uint64
+
+	// Problems describes the inconsistencies which were found, up to a
+	// maximum, while NumProblems is the total number of them.
+	Problems    []string
+	NumProblems int
+}
+
+// addProblem records a problem found while checking a database.
+func (r *DatabaseCheckResult) addProblem(format string, args ...interface{}) {
+	r.NumProblems++
+	if len(r.Problems) < maxDatabaseProblems {
+		r.Problems = append(r.Problems, fmt.Sprintf(format, args...))
+	}
+}
+
+// CheckDatabase checks the consistency of the chain stored in the passed
+// database.  It walks back the provided number of blocks of the main chain from
+// the best block, ensuring each has a block index entry which links to its
+// parent and block data which matches it, decodes every entry of the block
+// index and the utxo set, and ensures the utxo set state matches the utxo set.
+//
+// Inconsistencies are reported in the result rather than as an error so all of
+// them are found by a single check.  An error is only returned when the
+// database could not be read.  Like FetchDatabaseInfo, it never writes to the
+// database.
+func CheckDatabase(db database.DB, numBlocks int64) (*DatabaseCheckResult, error) {
+	var result DatabaseCheckResult
+	err := db.View(func(dbTx database.Tx) error {
+		meta := dbTx.Metadata()
+		serializedState := meta.Get(dbnamespace.ChainStateKeyName)
+		if serializedState == nil {
+			result.addProblem("missing best chain state")
+			return nil
+		}
+		state, err := deserializeBestChainState(serializedState)
+		if err != nil {
+			result.addProblem("corrupt best chain state: %v", err)
+			return nil
+		}
+
+		// Walk back the main chain from the best block.
+		blockIndex := meta.Bucket(dbnamespace.BlockIndexBucketName)
+		if blockIndex == nil {
+			result.addProblem("missing block index")
+			return nil
+		}
+		hash, height := state.hash, int64(state.height)
+		for ; result.BlocksChecked < numBlocks && height >= 0; height-- {
+			result.BlocksChecked++
+			serialized := blockIndex.Get(blockIndexKey(&hash,
+				uint32(height)))
+			if serialized == nil {
+				result.addProblem("missing block index entry for "+
+					"block %v (height %d)", hash, height)
+				break
+			}
+			entry, err := deserializeBlockIndexEntry(serialized)
+			if err != nil {
+				result.addProblem("corrupt block index entry for "+
+					"block %v (height %d): %v", hash, height, err)
+				break
+			}
+			header := &entry.header
+			if header.BlockHash() != hash ||
+				int64(header.Height) != height {
+
+				result.addProblem("block index entry for block %v "+
+					"(height %d) contains header for block %v "+
+					"(height %d)", hash, height, header.BlockHash(),
+					header.Height)
+			}
+			if !entry.status.HaveData() {
+				result.addProblem("block index entry for block %v "+
+					"(height %d) is not marked as having its data "+
+					"stored", hash, height)
+			}
+
+			blockBytes, err := dbTx.FetchBlock(&hash)
+			if err != nil {
+				result.addProblem("unable to load block %v (height "+
+					"%d): %v", hash, height, err)
+			} else {
+				var msgBlock wire.MsgBlock
+				err := msgBlock.FromBytes(blockBytes)
+				if err != nil {
+					result.addProblem("unable to decode block %v "+
+						"(height %d): %v", hash, height, err)
+				} else if msgBlock.BlockHash() != hash {
+					result.addProblem("data for block %v (height "+
+						"%d) is for block %v", hash, height,
+						msgBlock.BlockHash())
+				}
+			}
+
+			hash = header.PrevBlock
+		}
+
+		// Decode every entry of the block index.
+		err = blockIndex.ForEach(func(k, v []byte) error {
+			result.BlockIndexEntries++
+			if _, err := deserializeBlockIndexEntry(v); err != nil {
+				result.addProblem("corrupt block index entry %x: %v",
+					k, err)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		// Decode every entry of the utxo set.
+		utxoSet := meta.Bucket(dbnamespace.UtxoSetBucketName)
+		if utxoSet == nil {
+			result.addProblem("missing utxo set")
+			return nil
+		}
+		numProblems := result.NumProblems
+		utxoState := newUtxoSetState()
+		err = utxoSet.ForEach(func(k, v []byte) error {
+			result.UtxoEntries++
+			var txHash chainhash.Hash
+			copy(txHash[:], k)
+			if len(v) == 0 {
+				result.addProblem("utxo set contains entry for fully "+
+					"spent tx %v", txHash)
+				return nil
+			}
+			entry, err := deserializeUtxoEntry(v)
+			if err != nil {
+				result.addProblem("corrupt utxo entry for tx %v: %v",
+					txHash, err)
+				return nil
+			}
+			utxoState.updateEntry(&txHash, nil, entry, nil, v)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		// Ensure the incrementally maintained utxo set state of databases
+		// which have one matches the utxo set when all of its entries
+		// could be decoded.
+		dbInfo, err := dbFetchDatabaseInfo(dbTx)
+		if err != nil {
+			return err
+		}
+		if dbInfo == nil || dbInfo.version < 4 ||
+			result.NumProblems != numProblems {
+
+			return nil
+		}
+		storedState, err := dbFetchUtxoSetState(dbTx)
+		if err != nil {
+			result.addProblem("unable to load utxo set state: %v", err)
+			return nil
+		}
+		if !bytes.Equal(serializeUtxoSetState(storedState),
+			serializeUtxoSetState(utxoState)) {
+
+			result.addProblem("utxo set state (%d outputs, hash %x) "+
+				"does not match the utxo set (%d outputs, hash %x)",
+				storedState.numOutputs, storedState.hash.Finalize(),
+				utxoState.numOutputs, utxoState.hash.Finalize())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/EXCCoin/exccd/blockchain/internal/dbnamespace"
+	"github.com/EXCCoin/exccd/chaincfg"
+	"github.com/EXCCoin/exccd/chaincfg/chainhash"
+	"github.com/EXCCoin/exccd/database"
+)
+
+// TestDatabaseInfo ensures the chain stored in a database is reported and that
+// inconsistencies in it are found by CheckDatabase.
+func TestDatabaseInfo(t *testing.T) {
+	params := &chaincfg.SimNetParams
+	chain, teardown, err := chainSetup("databaseinfo", params)
+	if err != nil {
+		t.Fatalf("Failed to setup chain instance: %v", err)
+	}
+	defer teardown()
+
+	info, err := FetchDatabaseInfo(chain.db)
+	if err != nil {
+		t.Fatalf("FetchDatabaseInfo: unexpected error: %v", err)
+	}
+	if info.BestHash != *params.GenesisHash || info.BestHeight != 0 {
+		t.Fatalf("unexpected best block %v (height %d)", info.BestHash,
+			info.BestHeight)
+	}
+	if info.Version != currentDatabaseVersion ||
+		info.CompressionVersion != currentCompressionVersion ||
+		info.BlockIndexVersion != currentBlockIndexVersion {
+
+		t.Fatalf("unexpected database versions %+v", info)
+	}
+
+	result, err := CheckDatabase(chain.db, 10)
+	if err != nil {
+		t.Fatalf("CheckDatabase: unexpected error: %v", err)
+	}
+	if result.BlocksChecked != 1 || result.BlockIndexEntries != 1 ||
+		result.NumProblems != 0 {
+
+		t.Fatalf("unexpected check result %+v", result)
+	}
+
+	// Corrupt the utxo set and ensure the problem is found.
+	err = chain.db.Update(func(dbTx database.Tx) error {
+		bucket := dbTx.Metadata().Bucket(dbnamespace.UtxoSetBucketName)
+		hash := chainhash.HashH([]byte("corrupt"))
+		return bucket.Put(hash[:], []byte{0x01})
+	})
+	if err != nil {
+		t.Fatalf("unable to corrupt utxo set: %v", err)
+	}
+	result, err = CheckDatabase(chain.db, 10)
+	if err != nil {
+		t.Fatalf("CheckDatabase: unexpected error: %v", err)
+	}
+	if result.NumProblems != 1 || len(result.Problems) != 1 {
+		t.Fatalf("unexpected check result %+v", result)
+	}
+}
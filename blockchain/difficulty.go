@@ -33,6 +33,11 @@ var (
 // testnet difficulty).
 const maxShift = uint(256)
 
+// stakeDiffDampingDivisor is the divisor applied to the change in stake
+// difficulty calculated by the algorithm defined in DCP0001 once the stake
+// difficulty damping agenda is active.
+const stakeDiffDampingDivisor = 2
+
 // HashToBig converts a chainhash.Hash into a big.Int that can be used to
 // perform math comparisons.
 func HashToBig(hash *chainhash.Hash) *big.Int {
@@ -236,6 +241,88 @@ func (b *BlockChain) findPrevTestNetDifficulty(startNode *blockNode) (uint32, er
 	return lastBits, nil
 }
 
+// calcNextRequiredDifficultyLWMA calculates the required difficulty for the
+// block after the passed previous block node based on a linearly weighted
+// moving average of the solve times of the most recent blocks.
+//
+// The target is the average of the targets of the blocks in the window scaled
+// by the ratio of the weighted sum of their solve times to the weighted sum
+// expected at the target time per block, where the weight of each solve time
+// is its position in the window so recent blocks have the most influence.
+// Solve times are limited to six times the target time per block in either
+// direction so individual timestamps can not skew the result.
+//
+// This function MUST be called with the chain state lock held (for writes).
+func (b *BlockChain) calcNextRequiredDifficultyLWMA(curNode *blockNode) (uint32, error) {
+	// Require the minimum difficulty until there are enough blocks to fill
+	// the window.
+	windowSize := b.chainParams.WorkDiffLWMAWindowSize
+	if curNode.height < windowSize {
+		return b.chainParams.PowLimitBits, nil
+	}
+
+	// Collect the nodes in the window along with the node before the
+	// oldest one so the solve time of every node in the window is known.
+	nodes := make([]*blockNode, windowSize+1)
+	node := curNode
+	for i := windowSize; i >= 0; i-- {
+		nodes[i] = node
+		if i == 0 {
+			break
+		}
+
+		// Get the previous block node.  This function is used over
+		// simply accessing node.parent directly as it will dynamically
+		// create previous block nodes as needed.
+		var err error
+		node, err = b.index.PrevNodeFromNode(node)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	// Sum the targets and the solve times weighted by their position in
+	// the window.
+	targetSecs := int64(b.chainParams.TargetTimePerBlock / time.Second)
+	if targetSecs < 1 {
+		targetSecs = 1
+	}
+	maxSolveTime := 6 * targetSecs
+	var weightedSolveTimes int64
+	sumTargets := new(big.Int)
+	for i := int64(1); i <= windowSize; i++ {
+		solveTime := nodes[i].timestamp - nodes[i-1].timestamp
+		if solveTime > maxSolveTime {
+			solveTime = maxSolveTime
+		} else if solveTime < -maxSolveTime {
+			solveTime = -maxSolveTime
+		}
+		weightedSolveTimes += solveTime * i
+		sumTargets.Add(sumTargets, CompactToBig(nodes[i].bits))
+	}
+
+	// Limit the weighted solve times to a third of the expected value to
+	// keep the result reasonable when strange solve times occurred.
+	expectedWeightedSolveTimes := targetSecs * windowSize * (windowSize + 1) / 2
+	if weightedSolveTimes < expectedWeightedSolveTimes/3 {
+		weightedSolveTimes = expectedWeightedSolveTimes / 3
+	}
+
+	// nextTarget = (sumTargets / windowSize) *
+	//     (weightedSolveTimes / expectedWeightedSolveTimes)
+	nextTarget := sumTargets.Mul(sumTargets, big.NewInt(weightedSolveTimes))
+	nextTarget.Div(nextTarget, big.NewInt(windowSize*
+		expectedWeightedSolveTimes))
+	if nextTarget.Sign() == 0 {
+		nextTarget.SetInt64(1)
+	}
+	if nextTarget.Cmp(b.chainParams.PowLimit) > 0 {
+		nextTarget.Set(b.chainParams.PowLimit)
+	}
+
+	return BigToCompact(nextTarget), nil
+}
+
 // calcNextRequiredDifficulty calculates the required difficulty for the block
 // after the passed previous block node based on the difficulty retarget rules.
 // This function differs from the exported CalcNextRequiredDifficulty in that
@@ -249,6 +336,18 @@ func (b *BlockChain) calcNextRequiredDifficulty(curNode *blockNode, newBlockTime
 		return b.chainParams.PowLimitBits, nil
 	}
 
+	// Networks which do not adjust the difficulty always require the
+	// minimum difficulty.
+	if b.chainParams.NoDifficultyAdjustment {
+		return b.chainParams.PowLimitBits, nil
+	}
+
+	// Retarget every block once the per-block difficulty algorithm is
+	// active.
+	if b.isConsensusUpgradeActive(curNode, chaincfg.UpgradeLWMAWorkDiff) {
+		return b.calcNextRequiredDifficultyLWMA(curNode)
+	}
+
 	// Get the old difficulty; if we aren't at a block height where it changes,
 	// just return this.
 	oldDiff := curNode.bits
@@ -460,6 +559,85 @@ func (b *BlockChain) CalcNextRequiredDifficulty(timestamp time.Time) (uint32, er
 	return difficulty, err
 }
 
+// isWorkDiffRetarget returns whether the proof of work difficulty retarget
+// rules scheduled a new required difficulty for the block at the passed
+// height.  Note that the minimum difficulty rules of the test networks may
+// still change the difficulty of other blocks.
+func (b *BlockChain) isWorkDiffRetarget(height int64) bool {
+	if height == 0 || b.chainParams.NoDifficultyAdjustment {
+		return false
+	}
+	if b.chainParams.IsConsensusUpgradeActive(chaincfg.UpgradeLWMAWorkDiff,
+		height) {
+
+		return true
+	}
+	return height%b.chainParams.WorkDiffWindowSize == 0
+}
+
+// DifficultyHistoryEntry houses the proof of work difficulty of a block in the
+// main chain along with the total work of the chain up to and including it.
+type DifficultyHistoryEntry struct {
+	Hash      chainhash.Hash
+	Height    int64
+	Timestamp time.Time
+	Bits      uint32
+	WorkSum   *big.Int
+
+	// Retarget is whether the difficulty retarget rules scheduled a new
+	// required difficulty for the block.
+	Retarget bool
+}
+
+// DifficultyHistory returns the proof of work difficulty and the cumulative
+// work of the blocks in the main chain from the passed start height through the
+// passed end height, ordered from the oldest to the newest block.  A negative
+// end height uses the height of the current best block.  Nil is returned when
+// the range does not describe blocks in the main chain.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) DifficultyHistory(startHeight, endHeight int64) ([]DifficultyHistoryEntry, error) {
+	b.chainLock.RLock()
+	defer b.chainLock.RUnlock()
+
+	tip := b.bestNode
+	if endHeight < 0 {
+		endHeight = tip.height
+	}
+	if startHeight < 0 || startHeight > endHeight || endHeight > tip.height {
+		return nil, nil
+	}
+
+	node, err := b.index.AncestorNode(tip, endHeight)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]DifficultyHistoryEntry, endHeight-startHeight+1)
+	for i := len(entries) - 1; i >= 0; i-- {
+		entries[i] = DifficultyHistoryEntry{
+			Hash:      node.hash,
+			Height:    node.height,
+			Timestamp: time.Unix(node.timestamp, 0),
+			Bits:      node.bits,
+			WorkSum:   new(big.Int).Set(node.workSum),
+			Retarget:  b.isWorkDiffRetarget(node.height),
+		}
+		if i == 0 {
+			break
+		}
+
+		// Get the previous block node.  This function is used over
+		// simply accessing node.parent directly as it will dynamically
+		// create previous block nodes as needed.
+		node, err = b.index.PrevNodeFromNode(node)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return entries, nil
+}
+
 // mergeDifficulty takes an original stake difficulty and two new, scaled
 // stake difficulties, merges the new difficulties, and outputs a new
 // merged stake difficulty.
@@ -625,9 +803,64 @@ func calcNextStakeDiff(params *chaincfg.Params, nextHeight, curDiff, prevPoolSiz
 	return nextDiff
 }
 
+// calcNextDampedStakeDiff calculates the next stake difficulty for the given
+// set of parameters using the damped variant of the algorithm defined in
+// DCP0001 which is enabled by the stake difficulty damping agenda.
+//
+// The algorithm defined in DCP0001 fully applies the forces that counteract
+// the relative change in the pool size and push it towards its target value at
+// every retarget.  Since ticket purchases react to the price, this tends to
+// overshoot and makes the price oscillate between intervals with very high and
+// very low demand.  The damped variant only applies a fraction of the
+// calculated change to the current difficulty, so the price converges towards
+// the same value over more intervals while the size of each step, and hence
+// the oscillation, is reduced.
+//
+// This function is safe for concurrent access.
+func calcNextDampedStakeDiff(params *chaincfg.Params, nextHeight, curDiff, prevPoolSizeAll, curPoolSizeAll int64) int64 {
+	// The result of the undamped algorithm is already limited to the
+	// minimum and maximum allowed stake difficulty.  The damped difficulty
+	// lies between it and the current difficulty, so it only needs to be
+	// limited again in case the current difficulty is outside of the
+	// limits.
+	undampedDiff := calcNextStakeDiff(params, nextHeight, curDiff,
+		prevPoolSizeAll, curPoolSizeAll)
+	nextDiff := curDiff + (undampedDiff-curDiff)/stakeDiffDampingDivisor
+	maximumStakeDiff := estimateSupply(params, nextHeight) /
+		int64(params.TicketPoolSize)
+	if nextDiff > maximumStakeDiff {
+		nextDiff = maximumStakeDiff
+	}
+	if nextDiff < params.MinimumStakeDiff {
+		nextDiff = params.MinimumStakeDiff
+	}
+	return nextDiff
+}
+
+// stakeDiffCalculator returns the function which calculates the stake
+// difficulty for the block after the passed node per the active stake
+// difficulty algorithm.  The damped algorithm is used once the stake
+// difficulty damping agenda is active, which means the switch over happens at
+// the first stake difficulty retarget on or after the activation of the
+// agenda, and the algorithm defined in DCP0001 is used otherwise.
+//
+// This function MUST be called with the chain state lock held (for writes).
+func (b *BlockChain) stakeDiffCalculator(curNode *blockNode) (func(*chaincfg.Params, int64, int64, int64, int64) int64, error) {
+	active, err := b.isDeploymentActive(curNode,
+		chaincfg.VoteIDStakeDiffDamping)
+	if err != nil {
+		return nil, err
+	}
+	if active {
+		return calcNextDampedStakeDiff, nil
+	}
+	return calcNextStakeDiff, nil
+}
+
 // calcNextRequiredStakeDifficulty calculates the required stake difficulty
 // for the block after the passed previous block node based on the algorithm
-// defined in DCP0001.
+// defined in DCP0001, or its damped variant once the stake difficulty damping
+// agenda is active.
 //
 // This function MUST be called with the chain state lock held (for writes).
 func (b *BlockChain) calcNextRequiredStakeDifficulty(curNode *blockNode) (int64, error) {
@@ -688,8 +921,12 @@ func (b *BlockChain) calcNextRequiredStakeDifficulty(curNode *blockNode) (int64,
 	}
 
 	// Calculate and return the final next required difficulty.
+	calcStakeDiff, err := b.stakeDiffCalculator(curNode)
+	if err != nil {
+		return 0, err
+	}
 	curPoolSizeAll := int64(curNode.poolSize) + immatureTickets
-	return calcNextStakeDiff(b.chainParams, nextHeight, curDiff,
+	return calcStakeDiff(b.chainParams, nextHeight, curDiff,
 		prevPoolSizeAll, curPoolSizeAll), nil
 }
 
@@ -711,6 +948,12 @@ func (b *BlockChain) CalcNextRequiredStakeDifficulty() (int64, error) {
 // tickets is set in which case it will use the max possible number of tickets
 // that can be purchased in the remainder of the interval.
 //
+// The damped variant of the algorithm is used when the stake difficulty
+// damping agenda is active for the block after the passed node.  Since agendas
+// only become active at rule change interval boundaries, the estimate might
+// use a different algorithm than the actual retarget when the next rule change
+// interval starts before it.
+//
 // This function MUST be called with the chain state lock held (for writes).
 func (b *BlockChain) estimateNextStakeDifficulty(curNode *blockNode, newTickets int64, useMaxTickets bool) (int64, error) {
 	// Calculate the next retarget interval height.
@@ -862,7 +1105,11 @@ func (b *BlockChain) estimateNextStakeDifficulty(curNode *blockNode, newTickets
 	estimatedPoolSizeAll := estimatedPoolSize + remainingImmatureTickets
 
 	// Calculate and return the final estimated difficulty.
-	return calcNextStakeDiff(b.chainParams, nextRetargetHeight, curDiff,
+	calcStakeDiff, err := b.stakeDiffCalculator(curNode)
+	if err != nil {
+		return 0, err
+	}
+	return calcStakeDiff(b.chainParams, nextRetargetHeight, curDiff,
 		prevPoolSizeAll, estimatedPoolSizeAll), nil
 }
 
@@ -10,6 +10,7 @@ import (
 	"math/big"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/EXCCoin/exccd/chaincfg"
 	"github.com/EXCCoin/exccd/wire"
@@ -194,6 +195,74 @@ func assertStakeDiffParamsTestNet(t *testing.T, params *chaincfg.Params) {
 	}
 }
 
+// TestCalcNextDampedStakeDiff ensures the damped stake difficulty algorithm
+// only applies part of the change calculated by the algorithm defined in
+// DCP0001 and that the result remains within the allowed limits.
+func TestCalcNextDampedStakeDiff(t *testing.T) {
+	t.Parallel()
+
+	params := &chaincfg.MainNetParams
+	nextHeight := params.StakeDiffWindowSize * 1000
+	targetPoolSizeAll := int64(params.TicketsPerBlock) *
+		int64(params.TicketPoolSize+params.TicketMaturity)
+	maxDiff := estimateSupply(params, nextHeight) /
+		int64(params.TicketPoolSize)
+
+	tests := []struct {
+		name            string
+		curDiff         int64
+		prevPoolSizeAll int64
+		curPoolSizeAll  int64
+	}{{
+		name:            "pool at target",
+		curDiff:         params.MinimumStakeDiff * 10,
+		prevPoolSizeAll: targetPoolSizeAll,
+		curPoolSizeAll:  targetPoolSizeAll,
+	}, {
+		name:            "pool growing",
+		curDiff:         params.MinimumStakeDiff * 10,
+		prevPoolSizeAll: targetPoolSizeAll,
+		curPoolSizeAll:  targetPoolSizeAll * 11 / 10,
+	}, {
+		name:            "pool shrinking",
+		curDiff:         params.MinimumStakeDiff * 10,
+		prevPoolSizeAll: targetPoolSizeAll,
+		curPoolSizeAll:  targetPoolSizeAll * 9 / 10,
+	}, {
+		name:            "pool collapsing near minimum",
+		curDiff:         params.MinimumStakeDiff + 1,
+		prevPoolSizeAll: targetPoolSizeAll,
+		curPoolSizeAll:  targetPoolSizeAll / 2,
+	}}
+
+	for _, test := range tests {
+		undamped := calcNextStakeDiff(params, nextHeight, test.curDiff,
+			test.prevPoolSizeAll, test.curPoolSizeAll)
+		want := test.curDiff + (undamped-test.curDiff)/stakeDiffDampingDivisor
+		got := calcNextDampedStakeDiff(params, nextHeight, test.curDiff,
+			test.prevPoolSizeAll, test.curPoolSizeAll)
+		if got != want {
+			t.Errorf("%s: unexpected damped difficulty - got %d, "+
+				"want %d", test.name, got, want)
+			continue
+		}
+		if got < params.MinimumStakeDiff || got > maxDiff {
+			t.Errorf("%s: damped difficulty %d is outside of the "+
+				"limits [%d, %d]", test.name, got,
+				params.MinimumStakeDiff, maxDiff)
+		}
+	}
+
+	// Ensure the damped difficulty is limited when the current difficulty
+	// is above the maximum allowed difficulty.
+	got := calcNextDampedStakeDiff(params, nextHeight, maxDiff*2,
+		targetPoolSizeAll, targetPoolSizeAll)
+	if got != maxDiff {
+		t.Errorf("unexpected damped difficulty above the maximum - got "+
+			"%d, want %d", got, maxDiff)
+	}
+}
+
 // TestCalcNextRequiredStakeDiff ensure the stake diff calculation function
 // for the algorithm defined by DCP0001 works as expected.
 // TODO: once upon a time enable test and make it pass
@@ -1054,3 +1123,167 @@ nextTest:
 		}
 	}
 }
+
+// TestCalcNextRequiredDifficultyLWMA ensures the per-block difficulty
+// algorithm scales the average target of the window by the ratio of the
+// weighted solve times to the target time per block and only applies once the
+// consensus upgrade which selects it is active.
+func TestCalcNextRequiredDifficultyLWMA(t *testing.T) {
+	params := chaincfg.SimNetParams
+	params.TargetTimePerBlock = time.Minute
+	params.WorkDiffLWMAWindowSize = 10
+	params.ConsensusUpgrades = []chaincfg.ConsensusUpgrade{{
+		Name:   chaincfg.UpgradeLWMAWorkDiff,
+		Height: 1,
+	}}
+
+	target := new(big.Int).Lsh(bigOne, 240)
+	bits := BigToCompact(target)
+	scaledBits := func(num, den int64) uint32 {
+		scaled := new(big.Int).Mul(target, big.NewInt(num))
+		return BigToCompact(scaled.Div(scaled, big.NewInt(den)))
+	}
+
+	tests := []struct {
+		name      string
+		numNodes  int
+		solveTime time.Duration
+		expected  uint32
+	}{{
+		name:      "window not filled",
+		numNodes:  9,
+		solveTime: time.Minute,
+		expected:  params.PowLimitBits,
+	}, {
+		name:      "at target",
+		numNodes:  20,
+		solveTime: time.Minute,
+		expected:  bits,
+	}, {
+		name:      "twice target",
+		numNodes:  20,
+		solveTime: 2 * time.Minute,
+		expected:  scaledBits(2, 1),
+	}, {
+		name:      "half target",
+		numNodes:  20,
+		solveTime: 30 * time.Second,
+		expected:  scaledBits(1, 2),
+	}, {
+		name:      "slow blocks limited",
+		numNodes:  20,
+		solveTime: time.Hour,
+		expected:  scaledBits(6, 1),
+	}, {
+		name:      "fast blocks limited",
+		numNodes:  20,
+		solveTime: 0,
+		expected:  scaledBits(1, 3),
+	}}
+
+	for _, test := range tests {
+		bc := newFakeChain(&params)
+		node := bc.bestNode
+		blockTime := time.Unix(node.timestamp, 0)
+		for i := 0; i < test.numNodes; i++ {
+			blockTime = blockTime.Add(test.solveTime)
+			node = newFakeNode(node, 1, 1, bits, blockTime)
+			bc.index.AddNode(node)
+		}
+
+		got, err := bc.calcNextRequiredDifficulty(node, blockTime)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if got != test.expected {
+			t.Errorf("%s: got bits %08x, want %08x", test.name, got,
+				test.expected)
+		}
+	}
+
+	// Ensure the windowed retarget is used when the upgrade is not active.
+	params.ConsensusUpgrades[0].Height = 1000
+	bc := newFakeChain(&params)
+	node := bc.bestNode
+	blockTime := time.Unix(node.timestamp, 0)
+	for i := 0; i < 20; i++ {
+		blockTime = blockTime.Add(time.Hour)
+		node = newFakeNode(node, 1, 1, bits, blockTime)
+		bc.index.AddNode(node)
+	}
+	if (node.height+1)%params.WorkDiffWindowSize != 0 {
+		got, err := bc.calcNextRequiredDifficulty(node, blockTime)
+		if err != nil || got != bits {
+			t.Errorf("inactive upgrade: got bits %08x (%v), want %08x",
+				got, err, bits)
+		}
+	}
+}
+
+// TestDifficultyHistory ensures the difficulty history reports the difficulty
+// and the cumulative work of the requested blocks along with the retarget
+// boundaries of both the windowed and the per-block difficulty algorithms.
+func TestDifficultyHistory(t *testing.T) {
+	params := chaincfg.SimNetParams
+	params.WorkDiffWindowSize = 8
+	params.ConsensusUpgrades = []chaincfg.ConsensusUpgrade{{
+		Name:   chaincfg.UpgradeLWMAWorkDiff,
+		Height: 20,
+	}}
+
+	bc := newFakeChain(&params)
+	node := bc.bestNode
+	blockTime := time.Unix(node.timestamp, 0)
+	for i := 0; i < 24; i++ {
+		blockTime = blockTime.Add(time.Minute)
+		node = newFakeNode(node, 1, 1, params.PowLimitBits, blockTime)
+		bc.index.AddNode(node)
+	}
+	bc.bestNode = node
+
+	entries, err := bc.DifficultyHistory(0, -1)
+	if err != nil {
+		t.Fatalf("DifficultyHistory: unexpected error: %v", err)
+	}
+	if len(entries) != 25 {
+		t.Fatalf("got %d entries, want 25", len(entries))
+	}
+	workSum := new(big.Int)
+	for i, entry := range entries {
+		workSum.Add(workSum, CalcWork(entry.Bits))
+		if entry.Height != int64(i) || entry.WorkSum.Cmp(workSum) != 0 {
+			t.Fatalf("entry %d: got height %d and work %v, want "+
+				"height %d and work %v", i, entry.Height,
+				entry.WorkSum, i, workSum)
+		}
+		wantRetarget := i >= 20 || (i > 0 && i%8 == 0)
+		if entry.Retarget != wantRetarget {
+			t.Errorf("entry %d: got retarget %v, want %v", i,
+				entry.Retarget, wantRetarget)
+		}
+	}
+	if entries[24].Hash != node.hash {
+		t.Fatalf("got last hash %v, want %v", entries[24].Hash, node.hash)
+	}
+
+	// Ensure a range within the chain only returns the requested blocks.
+	entries, err = bc.DifficultyHistory(5, 7)
+	if err != nil {
+		t.Fatalf("DifficultyHistory: unexpected error: %v", err)
+	}
+	if len(entries) != 3 || entries[0].Height != 5 || entries[2].Height != 7 {
+		t.Fatalf("unexpected entries for heights 5 through 7: %+v",
+			entries)
+	}
+
+	// Ensure ranges which do not describe blocks in the main chain are
+	// rejected.
+	for _, heights := range [][2]int64{{-1, 5}, {6, 5}, {0, 25}} {
+		entries, err := bc.DifficultyHistory(heights[0], heights[1])
+		if err != nil || entries != nil {
+			t.Errorf("heights %d through %d: got %d entries (%v), "+
+				"want none", heights[0], heights[1], len(entries), err)
+		}
+	}
+}
@@ -188,19 +188,14 @@ func opReturnScript(data []byte) []byte {
 // the second output of a standard coinbase transaction of a new block.  In
 // particular, the serialized data used with the OP_RETURN starts with the block
 // height and is followed by 32 bytes which are treated as 4 uint64 extra
-// nonces.  This implementation puts a cryptographically random value into the
-// final extra nonce position.  The actual format of the data after the block
-// height is not defined however this effectively mirrors the actual mining code
-// at the time it was written.
-func standardCoinbaseOpReturnScript(blockHeight uint32) []byte {
-	rand, err := wire.RandomUint64()
-	if err != nil {
-		panic(err)
-	}
-
+// nonces.  This implementation puts the provided extra nonce into the final
+// extra nonce position.  The actual format of the data after the block height
+// is not defined however this effectively mirrors the actual mining code at the
+// time it was written.
+func standardCoinbaseOpReturnScript(blockHeight uint32, extraNonce uint64) []byte {
 	data := make([]byte, 36)
 	binary.LittleEndian.PutUint32(data[0:4], blockHeight)
-	binary.LittleEndian.PutUint64(data[28:36], rand)
+	binary.LittleEndian.PutUint64(data[28:36], extraNonce)
 	return opReturnScript(data)
 }
 
@@ -2100,7 +2095,8 @@ func Generate(includeLargeReorg bool) (tests [][]TestInstance, err error) {
 	//                  \-> bcb4(19)
 	g.SetTip("bsb2")
 	g.NextBlock("bcb4", outs[19], ticketOuts[19], func(b *wire.MsgBlock) {
-		script := standardCoinbaseOpReturnScript(b.Header.Height - 1)
+		script := standardCoinbaseOpReturnScript(b.Header.Height-1,
+			uint64(b.Header.Height))
 		b.Transactions[0].TxOut[0].PkScript = script
 	})
 	rejected(blockchain.ErrCoinbaseHeight)
@@ -2417,7 +2413,7 @@ func Generate(includeLargeReorg bool) (tests [][]TestInstance, err error) {
 		const zeroCoin = int64(0)
 		spendTx := b.Transactions[1]
 		for i := 0; i < numAdditionalOutputs; i++ {
-			opRetScript := chaingen.UniqueOpReturnScript()
+			opRetScript := g.UniqueOpReturnScript()
 			spendTx.AddTxOut(wire.NewTxOut(zeroCoin, opRetScript))
 		}
 	})
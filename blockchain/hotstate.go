@@ -0,0 +1,275 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/EXCCoin/exccd/blockchain/internal/dbnamespace"
+	"github.com/EXCCoin/exccd/chaincfg"
+	"github.com/EXCCoin/exccd/chaincfg/chainhash"
+	"github.com/EXCCoin/exccd/database"
+)
+
+// hotStateVersion is the current version of the serialized hot state.
+const hotStateVersion = 1
+
+// -----------------------------------------------------------------------------
+// The hot state consists of the most recent main chain block nodes which were
+// in memory when the node was shut down cleanly.  Block nodes are otherwise
+// loaded from the database one at a time as they are needed, which requires
+// thousands of random reads once the first new block is validated after a
+// restart since the difficulty and stake version calculations look back over
+// several windows of blocks.  Loading them in bulk from the hot state instead
+// lets the node validate new blocks right away.
+//
+// The hot state is only saved on a clean shutdown and is removed on startup,
+// so its presence also marks the previous shutdown as clean.  It is ignored
+// when the best block no longer matches, such as when the database was
+// modified by another tool in the meantime.
+//
+// The serialized format is:
+//
+//   <version><best hash><num entries><block index entries>
+//
+//   Field              Type                Size
+//   version            VLQ                 variable
+//   best hash          chainhash.Hash      chainhash.HashSize
+//   num entries        VLQ                 variable
+//   block index entry  blockIndexEntry     variable
+//
+// The entries start with the parent of the best block and continue with its
+// ancestors, each of which is serialized according to the block index entry
+// format described in chainio.go.
+// -----------------------------------------------------------------------------
+
+// hotState houses the best block hash along with the block index entries for
+// its most recent ancestors, newest first.
+type hotState struct {
+	bestHash chainhash.Hash
+	entries  []blockIndexEntry
+}
+
+// serializeHotState serializes the passed hot state according to the format
+// described above.
+func serializeHotState(state *hotState) ([]byte, error) {
+	size := serializeSizeVLQ(hotStateVersion) + chainhash.HashSize +
+		serializeSizeVLQ(uint64(len(state.entries)))
+	for i := range state.entries {
+		size += blockIndexEntrySerializeSize(&state.entries[i])
+	}
+
+	serialized := make([]byte, size)
+	offset := putVLQ(serialized, hotStateVersion)
+	copy(serialized[offset:], state.bestHash[:])
+	offset += chainhash.HashSize
+	offset += putVLQ(serialized[offset:], uint64(len(state.entries)))
+	for i := range state.entries {
+		n, err := putBlockIndexEntry(serialized[offset:], &state.entries[i])
+		if err != nil {
+			return nil, err
+		}
+		offset += n
+	}
+	return serialized, nil
+}
+
+// deserializeHotState decodes the passed serialized hot state according to the
+// format described above.
+func deserializeHotState(serialized []byte) (*hotState, error) {
+	version, offset := deserializeVLQ(serialized)
+	if offset == 0 {
+		return nil, errDeserialize("unexpected end of data while " +
+			"reading version")
+	}
+	if version != hotStateVersion {
+		return nil, fmt.Errorf("unsupported hot state version %d", version)
+	}
+
+	var state hotState
+	if offset+chainhash.HashSize > len(serialized) {
+		return nil, errDeserialize("unexpected end of data while " +
+			"reading best hash")
+	}
+	copy(state.bestHash[:], serialized[offset:])
+	offset += chainhash.HashSize
+
+	numEntries, bytesRead := deserializeVLQ(serialized[offset:])
+	if bytesRead == 0 {
+		return nil, errDeserialize("unexpected end of data while " +
+			"reading num entries")
+	}
+	offset += bytesRead
+
+	// Limit the allocation up front to the number of entries which could
+	// possibly fit in the remaining data.
+	maxEntries := uint64(len(serialized)-offset) / blockHdrSize
+	if numEntries > maxEntries {
+		return nil, errDeserialize(fmt.Sprintf("unexpected end of data "+
+			"for %d entries", numEntries))
+	}
+	state.entries = make([]blockIndexEntry, numEntries)
+	for i := range state.entries {
+		n, err := decodeBlockIndexEntry(serialized[offset:],
+			&state.entries[i])
+		if err != nil {
+			return nil, err
+		}
+		offset += n
+	}
+	return &state, nil
+}
+
+// hotStateDepth returns the maximum number of ancestors of the best block saved
+// in the hot state for the passed network parameters, which covers the blocks
+// looked back over when validating the next block.
+func hotStateDepth(params *chaincfg.Params) int {
+	depth := params.WorkDiffWindowSize * params.WorkDiffWindows
+	if n := params.StakeDiffWindowSize * params.StakeDiffWindows; n > depth {
+		depth = n
+	}
+	if n := 2 * params.StakeVersionInterval; n > depth {
+		depth = n
+	}
+	return int(depth) + medianTimeBlocks
+}
+
+// hotState returns the hot state for the current best block, which consists of
+// the block index entries for its ancestors which are in memory up to the
+// maximum depth.  Only the nodes which are already in memory are included
+// since loading more would defeat the purpose.
+//
+// This function MUST be called with the chain state lock held (for reads).
+func (b *BlockChain) hotState() *hotState {
+	state := &hotState{bestHash: b.bestNode.hash}
+	depth := hotStateDepth(b.chainParams)
+	b.index.RLock()
+	for node := b.bestNode.parent; node != nil; node = node.parent {
+		if len(state.entries) == depth {
+			break
+		}
+		state.entries = append(state.entries, blockIndexEntry{
+			header:         node.Header(),
+			status:         node.status,
+			voteInfo:       node.votes,
+			ticketsVoted:   node.ticketsVoted,
+			ticketsRevoked: node.ticketsRevoked,
+		})
+	}
+	b.index.RUnlock()
+	return state
+}
+
+// SaveHotState saves the most recent main chain block nodes which are in memory
+// to the database so they can be loaded in bulk on the next startup instead of
+// one at a time as they are needed.  It also marks the shutdown as clean, so
+// it must only be called once the node is shutting down and no more blocks will
+// be processed.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) SaveHotState() error {
+	b.chainLock.RLock()
+	state := b.hotState()
+	b.chainLock.RUnlock()
+
+	serialized, err := serializeHotState(state)
+	if err != nil {
+		return err
+	}
+	err = b.db.Update(func(dbTx database.Tx) error {
+		return dbTx.Metadata().Put(dbnamespace.HotStateKeyName, serialized)
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Saved %d block nodes for the next startup", len(state.entries))
+	return nil
+}
+
+// loadHotState loads the block nodes saved by SaveHotState on the previous
+// clean shutdown into the block index and removes them from the database so
+// they are not used again should the node not be shut down cleanly.  The
+// block nodes are otherwise loaded on demand as usual.
+//
+// This function MUST be called with the best node set while the chain is being
+// initialized.
+func (b *BlockChain) loadHotState() error {
+	var serialized []byte
+	err := b.db.Update(func(dbTx database.Tx) error {
+		meta := dbTx.Metadata()
+		v := meta.Get(dbnamespace.HotStateKeyName)
+		if v == nil {
+			return nil
+		}
+		serialized = append([]byte(nil), v...)
+		return meta.Delete(dbnamespace.HotStateKeyName)
+	})
+	if err != nil {
+		return err
+	}
+	if serialized == nil {
+		// There is nothing to load for a new database.
+		if b.bestNode.height > 0 {
+			log.Infof("The previous shutdown was not clean -- " +
+				"block nodes will be loaded as needed")
+		}
+		return nil
+	}
+
+	start := time.Now()
+	state, err := deserializeHotState(serialized)
+	if err != nil {
+		log.Warnf("Unable to load the saved block nodes: %v", err)
+		return nil
+	}
+	if state.bestHash != b.bestNode.hash {
+		log.Warnf("Ignoring the saved block nodes since the best block "+
+			"changed from %v to %v", state.bestHash, b.bestNode.hash)
+		return nil
+	}
+
+	numLoaded, err := b.addHotStateNodes(state)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Loaded %d block nodes saved on the previous shutdown in %v",
+		numLoaded, time.Since(start).Round(time.Millisecond))
+	return nil
+}
+
+// addHotStateNodes adds block nodes for the entries of the passed hot state,
+// which must be for the current best block, to the block index.  It returns the
+// number of added nodes.
+func (b *BlockChain) addHotStateNodes(state *hotState) (int, error) {
+	// Add the nodes from newest to oldest so each one is the parent of the
+	// previously added node, skipping those which were already loaded.
+	// Stop at the first entry which does not connect since the remaining
+	// ones can't be trusted.
+	b.index.Lock()
+	defer b.index.Unlock()
+	prevHash := b.bestNode.parentHash
+	var numAdded int
+	for i := range state.entries {
+		entry := &state.entries[i]
+		hash := entry.header.BlockHash()
+		if hash != prevHash {
+			log.Warnf("Ignoring saved block nodes below height %d "+
+				"which do not connect", entry.header.Height+1)
+			break
+		}
+		prevHash = entry.header.PrevBlock
+		if _, ok := b.index.index[hash]; ok {
+			continue
+		}
+		if _, err := b.index.addLoadedNode(entry); err != nil {
+			return numAdded, err
+		}
+		numAdded++
+	}
+	return numAdded, nil
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/EXCCoin/exccd/chaincfg"
+)
+
+// TestHotState ensures the hot state of a chain survives a serialization round
+// trip and the block nodes added from it to the block index of a new chain with
+// only the best node match the original ones.
+func TestHotState(t *testing.T) {
+	params := &chaincfg.SimNetParams
+	bc := newFakeChain(params)
+	nodes := chainedFakeNodes(bc.bestNode, 20)
+	for _, node := range nodes {
+		bc.index.AddNode(node)
+	}
+	bc.bestNode = nodes[len(nodes)-1]
+
+	// The hot state includes all ancestors of the best node since the chain
+	// is shorter than the maximum depth.
+	state := bc.hotState()
+	if len(state.entries) != len(nodes) {
+		t.Fatalf("unexpected number of entries %d", len(state.entries))
+	}
+	serialized, err := serializeHotState(state)
+	if err != nil {
+		t.Fatalf("serializeHotState: unexpected error: %v", err)
+	}
+	gotState, err := deserializeHotState(serialized)
+	if err != nil {
+		t.Fatalf("deserializeHotState: unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(gotState, state) {
+		t.Fatalf("mismatched hot state:\ngot %+v\nwant %+v", gotState,
+			state)
+	}
+
+	// Truncated data and unknown versions are rejected.
+	for i := 0; i < len(serialized); i += 37 {
+		if _, err := deserializeHotState(serialized[:i]); err == nil {
+			t.Fatalf("deserializeHotState: no error for %d bytes", i)
+		}
+	}
+	badVersion := append([]byte{hotStateVersion + 1}, serialized[1:]...)
+	if _, err := deserializeHotState(badVersion); err == nil {
+		t.Fatal("deserializeHotState: no error for unknown version")
+	}
+
+	// Create a chain with only the best node, as it is after startup, and
+	// add the nodes from the hot state.
+	best := bc.bestNode
+	header := best.Header()
+	newBest := newBlockNode(&header, nil)
+	newBest.inMainChain = true
+	newBest.workSum.Set(best.workSum)
+	newChain := &BlockChain{
+		chainParams: params,
+		bestNode:    newBest,
+		index:       newBlockIndex(nil, params),
+	}
+	newChain.index.AddNode(newBest)
+	numAdded, err := newChain.addHotStateNodes(gotState)
+	if err != nil {
+		t.Fatalf("addHotStateNodes: unexpected error: %v", err)
+	}
+	if numAdded != len(nodes) {
+		t.Fatalf("unexpected number of added nodes %d", numAdded)
+	}
+
+	// Ensure the added nodes are connected all the way to the genesis block
+	// and match the original ones.  The work sums of loaded nodes are
+	// derived from their children, which is only exact when a node has the
+	// same difficulty as its child, as is the case for all of the fake
+	// nodes but not the genesis block.
+	want, got := best, newBest
+	for want != nil {
+		if got == nil {
+			t.Fatalf("missing node at height %d", want.height)
+		}
+		if got.hash != want.hash || (want.height > 0 &&
+			got.workSum.Cmp(want.workSum) != 0) {
+
+			t.Fatalf("mismatched node at height %d", want.height)
+		}
+		if newChain.index.LookupNode(&want.hash) != got {
+			t.Fatalf("node at height %d is not indexed", want.height)
+		}
+		want, got = want.parent, got.parent
+	}
+	if got != nil {
+		t.Fatalf("unexpected node at height %d", got.height)
+	}
+
+	// Adding the nodes again is a no-op.
+	numAdded, err = newChain.addHotStateNodes(gotState)
+	if err != nil {
+		t.Fatalf("addHotStateNodes: unexpected error: %v", err)
+	}
+	if numAdded != 0 {
+		t.Fatalf("unexpected number of added nodes %d", numAdded)
+	}
+}
@@ -560,6 +560,9 @@ func addrToKey(addr exccutil.Address, params *chaincfg.Params) ([addrKeySize]byt
 		copy(result[1:], addr.Hash160()[:])
 		return result, nil
 
+	case *exccutil.AddressBech32:
+		return addrToKey(addr.Base58(), params)
+
 	case *exccutil.AddressSecpPubKey:
 		var result [addrKeySize]byte
 		result[0] = addrKeyTypePubKeyHash
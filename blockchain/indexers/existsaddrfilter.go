@@ -0,0 +1,92 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package indexers
+
+const (
+	// addrFilterBitsPerEntry is the number of filter bits allocated for
+	// each address the filter is sized to hold.
+	addrFilterBitsPerEntry = 16
+
+	// addrFilterNumHashes is the number of bits set for each address added
+	// to the filter.  Along with addrFilterBitsPerEntry, this results in a
+	// false positive rate of roughly 0.05% when the filter is full.
+	addrFilterNumHashes = 8
+
+	// addrFilterMinCapacity is the minimum number of addresses a filter is
+	// sized to hold.
+	addrFilterMinCapacity = 1 << 16
+)
+
+// addrFilter is a compact probabilistic set of address keys in the form of a
+// bloom filter.  It is used by the exists address index to quickly answer that
+// an address has never been seen without consulting the database, which is
+// by far the most common answer when wallets perform address discovery.
+//
+// A filter never reports an address that was added to it as missing, however
+// it might report an address that was never added as present, so positive
+// results must be confirmed against the database.
+//
+// This type is not safe for concurrent access.
+type addrFilter struct {
+	bits     []uint64
+	capacity uint64
+	count    uint64
+}
+
+// newAddrFilter returns a new empty address filter sized to hold the provided
+// number of addresses with the target false positive rate.
+func newAddrFilter(capacity uint64) *addrFilter {
+	if capacity < addrFilterMinCapacity {
+		capacity = addrFilterMinCapacity
+	}
+	numBits := capacity * addrFilterBitsPerEntry
+	return &addrFilter{
+		bits:     make([]uint64, (numBits+63)/64),
+		capacity: capacity,
+	}
+}
+
+// bitIndexes calls the provided function with each of the bit positions that
+// are associated with the passed address key.
+//
+// The bit positions are derived with double hashing from the hash160 that is
+// contained in the key since it is already uniformly distributed.  The address
+// type is mixed in so the same hash for different address types does not map
+// to the same bits.
+func (f *addrFilter) bitIndexes(k *[addrKeySize]byte, fn func(uint64)) {
+	h1 := byteOrder.Uint64(k[1:9]) ^ uint64(k[0])*0x9e3779b97f4a7c15
+	h2 := byteOrder.Uint64(k[9:17]) | 1
+	numBits := uint64(len(f.bits)) * 64
+	for i := uint64(0); i < addrFilterNumHashes; i++ {
+		fn((h1 + i*h2) % numBits)
+	}
+}
+
+// add adds the passed address key to the filter.
+func (f *addrFilter) add(k *[addrKeySize]byte) {
+	f.bitIndexes(k, func(bit uint64) {
+		f.bits[bit/64] |= 1 << (bit % 64)
+	})
+	f.count++
+}
+
+// mayContain returns false when the passed address key was definitely never
+// added to the filter and true when it might have been.
+func (f *addrFilter) mayContain(k *[addrKeySize]byte) bool {
+	contains := true
+	f.bitIndexes(k, func(bit uint64) {
+		if f.bits[bit/64]&(1<<(bit%64)) == 0 {
+			contains = false
+		}
+	})
+	return contains
+}
+
+// full returns whether or not the filter holds more addresses than it was sized
+// for, in which case it should be rebuilt with a larger capacity to maintain
+// the target false positive rate.
+func (f *addrFilter) full() bool {
+	return f.count > f.capacity
+}
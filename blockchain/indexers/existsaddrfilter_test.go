@@ -0,0 +1,66 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package indexers
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// TestAddrFilter ensures the address filter never reports added addresses as
+// missing and that its false positive rate is within the expected bounds.
+func TestAddrFilter(t *testing.T) {
+	t.Parallel()
+
+	rng := rand.New(rand.NewSource(1))
+	randKey := func() [addrKeySize]byte {
+		var k [addrKeySize]byte
+		k[0] = addrKeyTypePubKeyHash
+		rng.Read(k[1:])
+		return k
+	}
+
+	filter := newAddrFilter(0)
+	if filter.capacity != addrFilterMinCapacity {
+		t.Fatalf("unexpected capacity - got %d, want %d", filter.capacity,
+			addrFilterMinCapacity)
+	}
+
+	added := make([][addrKeySize]byte, addrFilterMinCapacity)
+	for i := range added {
+		added[i] = randKey()
+		filter.add(&added[i])
+	}
+	for i := range added {
+		if !filter.mayContain(&added[i]) {
+			t.Fatalf("filter does not contain added address %x",
+				added[i])
+		}
+	}
+	if filter.full() {
+		t.Fatal("filter unexpectedly full at capacity")
+	}
+
+	// Ensure the false positive rate of a full filter is reasonable.  The
+	// expected rate is roughly 0.05%, so allow for plenty of variance.
+	const numQueries = 100000
+	var falsePositives int
+	for i := 0; i < numQueries; i++ {
+		k := randKey()
+		if filter.mayContain(&k) {
+			falsePositives++
+		}
+	}
+	if falsePositives > numQueries/500 {
+		t.Fatalf("too many false positives - got %d of %d",
+			falsePositives, numQueries)
+	}
+
+	k := randKey()
+	filter.add(&k)
+	if !filter.full() {
+		t.Fatal("filter is not full after exceeding capacity")
+	}
+}
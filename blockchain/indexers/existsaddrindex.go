@@ -58,6 +58,13 @@ type ExistsAddrIndex struct {
 	// once they are included into a block.
 	unconfirmedLock sync.RWMutex
 	mpExistsAddr    map[[addrKeySize]byte]struct{}
+
+	// filter is a compact probabilistic set of all addresses in the index
+	// which allows queries for addresses that have never been seen to be
+	// answered without consulting the database.  It is loaded when the
+	// index is initialized and protected by the filterLock field.
+	filterLock sync.RWMutex
+	filter     *addrFilter
 }
 
 // NewExistsAddrIndex returns a new instance of an indexer that is used to
@@ -88,12 +95,53 @@ func (idx *ExistsAddrIndex) NeedsInputs() bool {
 	return false
 }
 
-// Init is only provided to satisfy the Indexer interface as there is nothing to
-// initialize for this index.
+// loadAddrFilter returns a new address filter that contains all of the
+// addresses in the exists address index using the provided database
+// transaction.  The filter is sized to hold twice the number of addresses that
+// are currently in the index.  The index is only walked once, so the address
+// keys are collected before they are added to the filter since its size
+// depends on their number.
+func loadAddrFilter(dbTx database.Tx) (*addrFilter, error) {
+	var addrKeys [][addrKeySize]byte
+	cursor := dbTx.Metadata().Bucket(existsAddrIndexKey).Cursor()
+	for ok := cursor.First(); ok; ok = cursor.Next() {
+		k := cursor.Key()
+		if len(k) != addrKeySize {
+			return nil, errDeserialize("unexpected exists address " +
+				"index key length")
+		}
+		var addrKey [addrKeySize]byte
+		copy(addrKey[:], k)
+		addrKeys = append(addrKeys, addrKey)
+	}
+
+	filter := newAddrFilter(uint64(len(addrKeys)) * 2)
+	for i := range addrKeys {
+		filter.add(&addrKeys[i])
+	}
+	return filter, nil
+}
+
+// Init loads the filter used to quickly answer queries for addresses that have
+// never been seen.
 //
 // This is part of the Indexer interface.
 func (idx *ExistsAddrIndex) Init() error {
-	// Nothing to do.
+	var filter *addrFilter
+	err := idx.db.View(func(dbTx database.Tx) error {
+		var err error
+		filter, err = loadAddrFilter(dbTx)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Debugf("Loaded %d addresses into the exists address filter",
+		filter.count)
+	idx.filterLock.Lock()
+	idx.filter = filter
+	idx.filterLock.Unlock()
 	return nil
 }
 
@@ -141,6 +189,21 @@ func (idx *ExistsAddrIndex) existsAddress(bucket database.Bucket, k [addrKeySize
 	return exists
 }
 
+// mayExist returns whether or not the address with the passed key might be in
+// the database according to the address filter.  It returns true when the
+// filter has not been loaded.
+//
+// This function is safe for concurrent access.
+func (idx *ExistsAddrIndex) mayExist(k *[addrKeySize]byte) bool {
+	idx.filterLock.RLock()
+	defer idx.filterLock.RUnlock()
+
+	if idx.filter == nil {
+		return true
+	}
+	return idx.filter.mayContain(k)
+}
+
 // ExistsAddress is the concurrency safe, exported function that returns
 // whether or not an address has been seen before.
 func (idx *ExistsAddrIndex) ExistsAddress(addr exccutil.Address) (bool, error) {
@@ -149,16 +212,20 @@ func (idx *ExistsAddrIndex) ExistsAddress(addr exccutil.Address) (bool, error) {
 		return false, err
 	}
 
+	// Only check the database when the filter indicates the address might
+	// be in it.
 	var exists bool
-	err = idx.db.View(func(dbTx database.Tx) error {
-		meta := dbTx.Metadata()
-		existsAddrIndex := meta.Bucket(existsAddrIndexKey)
-		exists = existsAddrIndex.Get(k[:]) != nil
+	if idx.mayExist(&k) {
+		err = idx.db.View(func(dbTx database.Tx) error {
+			meta := dbTx.Metadata()
+			existsAddrIndex := meta.Bucket(existsAddrIndexKey)
+			exists = existsAddrIndex.Get(k[:]) != nil
 
-		return nil
-	})
-	if err != nil {
-		return false, err
+			return nil
+		})
+		if err != nil {
+			return false, err
+		}
 	}
 
 	// Only check the in memory map if needed.
@@ -184,18 +251,32 @@ func (idx *ExistsAddrIndex) ExistsAddresses(addrs []exccutil.Address) ([]bool, e
 		}
 	}
 
-	err := idx.db.View(func(dbTx database.Tx) error {
-		for i := range addrKeys {
+	// Determine which addresses might be in the database according to the
+	// filter.  This typically eliminates the vast majority of the database
+	// lookups for large batches since most queried addresses, such as those
+	// queried during wallet address discovery, have never been seen.
+	candidates := make([]int, 0, len(addrKeys))
+	idx.filterLock.RLock()
+	for i := range addrKeys {
+		if idx.filter == nil || idx.filter.mayContain(&addrKeys[i]) {
+			candidates = append(candidates, i)
+		}
+	}
+	idx.filterLock.RUnlock()
+
+	if len(candidates) > 0 {
+		err := idx.db.View(func(dbTx database.Tx) error {
 			meta := dbTx.Metadata()
 			existsAddrIndex := meta.Bucket(existsAddrIndexKey)
+			for _, i := range candidates {
+				exists[i] = existsAddrIndex.Get(addrKeys[i][:]) != nil
+			}
 
-			exists[i] = existsAddrIndex.Get(addrKeys[i][:]) != nil
+			return nil
+		})
+		if err != nil {
+			return nil, err
 		}
-
-		return nil
-	})
-	if err != nil {
-		return nil, err
 	}
 
 	idx.unconfirmedLock.RLock()
@@ -317,6 +398,27 @@ func (idx *ExistsAddrIndex) ConnectBlock(dbTx database.Tx, block, parent *exccut
 		}
 	}
 
+	// Add the new addresses to the filter and rebuild it with a larger
+	// capacity once it holds more addresses than it was sized for.  Note
+	// that the addresses remain in the filter if the database transaction
+	// is rolled back, which is harmless since the filter only serves to
+	// avoid database lookups for addresses that have never been seen.
+	idx.filterLock.Lock()
+	defer idx.filterLock.Unlock()
+	if idx.filter == nil {
+		return nil
+	}
+	for k := range newUsedAddrs {
+		idx.filter.add(&k)
+	}
+	if idx.filter.full() {
+		filter, err := loadAddrFilter(dbTx)
+		if err != nil {
+			return err
+		}
+		idx.filter = filter
+	}
+
 	return nil
 }
 
@@ -0,0 +1,314 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package indexers
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"sync"
+	"time"
+
+	"github.com/EXCCoin/exccd/blockchain"
+	"github.com/EXCCoin/exccd/chaincfg"
+	"github.com/EXCCoin/exccd/database"
+	"github.com/EXCCoin/exccd/exccutil"
+)
+
+const (
+	// externalIndexKeyPrefix is the prefix of the keys used to track the
+	// tips of external indexes.  The name of the external index is appended
+	// to the prefix.
+	externalIndexKeyPrefix = "extidx-"
+
+	// ExternalIndexerService is the name of the JSON-RPC service an
+	// external indexer must register in order to receive notifications.
+	ExternalIndexerService = "ExternalIndexer"
+
+	// externalIndexerTimeout is the maximum amount of time to wait for a
+	// connection to an external indexer to be established and for it to
+	// reply to a notification.
+	externalIndexerTimeout = 30 * time.Second
+)
+
+// -----------------------------------------------------------------------------
+// External indexes are maintained by a separate process, referred to as a
+// sidecar, which exccd notifies about every block that is connected to or
+// disconnected from the main chain.  The notifications use the JSON-RPC codec
+// of the standard library net/rpc package over a TCP connection, so a sidecar
+// can be written in Go by registering a type with the following methods under
+// the ExternalIndexerService name, or in any other language capable of
+// serving JSON-RPC 1.0 requests:
+//
+//   Init(args *ExternalInitArgs, reply *ExternalReply) error
+//   ConnectBlock(args *ExternalBlockArgs, reply *ExternalReply) error
+//   DisconnectBlock(args *ExternalBlockArgs, reply *ExternalReply) error
+//
+// The index manager tracks the tip of each external index in the same way it
+// does for the built-in indexes.  Since notifications are delivered while the
+// database transaction which connects or disconnects the block is open, the
+// block is only considered processed by the index once the sidecar replies
+// without an error.  This ensures the external index stays consistent through
+// reorganizations and restarts, however it also means a sidecar which returns
+// errors prevents blocks from being connected, exactly like a failure in one
+// of the built-in indexes would.  Sidecars which do not reply within
+// externalIndexerTimeout are treated as having returned an error, so they can
+// not stall the chain and other database users indefinitely.
+//
+// A notification is sent again over a new connection when the connection to the
+// sidecar is lost before it replies, and the same block is notified again when
+// the database transaction is not committed after the sidecar replied, so
+// sidecars may receive the same notification more than once and MUST handle
+// them idempotently.  That is, ConnectBlock for a block which is already the
+// tip of the index and DisconnectBlock for a block which is no longer part of
+// it must succeed without making any changes.
+// -----------------------------------------------------------------------------
+
+// ExternalInitArgs houses the arguments sent to an external indexer when the
+// index manager initializes it.
+type ExternalInitArgs struct {
+	// Name is the name of the external index.
+	Name string
+
+	// Network is the name of the network the node is running on.
+	Network string
+}
+
+// ExternalBlockArgs houses the arguments sent to an external indexer when a
+// block is connected to or disconnected from the main chain.
+type ExternalBlockArgs struct {
+	// Name is the name of the external index.
+	Name string
+
+	// Hash and Height identify the block being connected or
+	// disconnected.
+	Hash   string
+	Height int64
+
+	// Block and Parent are the hex-encoded serialized block and its
+	// parent.  The parent is provided since the regular transaction tree
+	// of the parent is only applied to the chain when the block approves
+	// it, as indicated by ApprovesParent.
+	Block          string
+	Parent         string
+	ApprovesParent bool
+}
+
+// ExternalReply is the reply an external indexer returns for all requests.  It
+// is currently empty and only exists to satisfy the requirements of net/rpc.
+type ExternalReply struct{}
+
+// ExternalIndex implements an index which is maintained by an external process
+// that is notified of all blocks connected to and disconnected from the main
+// chain.
+type ExternalIndex struct {
+	name        string
+	addr        string
+	chainParams *chaincfg.Params
+	timeout     time.Duration
+
+	mtx    sync.Mutex
+	conn   net.Conn
+	client *rpc.Client
+}
+
+// Ensure the ExternalIndex type implements the Indexer interface.
+var _ Indexer = (*ExternalIndex)(nil)
+
+// Ensure the ExternalIndex type implements the NeedsInputser interface.
+var _ NeedsInputser = (*ExternalIndex)(nil)
+
+// externalIndexKey returns the key used to track the tip of the external index
+// with the passed name.
+func externalIndexKey(name string) []byte {
+	return []byte(externalIndexKeyPrefix + name)
+}
+
+// NeedsInputs signals that the index does not require the referenced inputs in
+// order to properly create the index since the sidecar is responsible for
+// looking up any information it requires.
+//
+// This implements the NeedsInputser interface.
+func (idx *ExternalIndex) NeedsInputs() bool {
+	return false
+}
+
+// call invokes the passed method of the external indexer, reconnecting to it
+// first when there is no connection or the previous connection was lost.  An
+// error is returned when the external indexer does not reply in time.
+func (idx *ExternalIndex) call(method string, args interface{}) error {
+	idx.mtx.Lock()
+	defer idx.mtx.Unlock()
+
+	serviceMethod := ExternalIndexerService + "." + method
+	for attempt := 0; attempt < 2; attempt++ {
+		if idx.client == nil {
+			conn, err := net.DialTimeout("tcp", idx.addr, idx.timeout)
+			if err != nil {
+				return fmt.Errorf("unable to connect to external "+
+					"indexer %s at %s: %v", idx.name, idx.addr, err)
+			}
+			idx.conn = conn
+			idx.client = jsonrpc.NewClient(conn)
+		}
+
+		err := idx.conn.SetDeadline(time.Now().Add(idx.timeout))
+		if err == nil {
+			err = idx.client.Call(serviceMethod, args, &ExternalReply{})
+		}
+		if _, ok := err.(rpc.ServerError); ok || err == nil {
+			return err
+		}
+
+		// The connection is no longer usable, so close it.  A sidecar
+		// which did not reply in time is not retried since it is
+		// likely to time out again.
+		idx.client.Close()
+		idx.client = nil
+		idx.conn = nil
+		if nErr, ok := err.(net.Error); ok && nErr.Timeout() {
+			return fmt.Errorf("external indexer %s at %s did not "+
+				"reply to %s within %v", idx.name, idx.addr, method,
+				idx.timeout)
+		}
+
+		// Retry with a new connection.
+		log.Warnf("Lost connection to external indexer %s: %v", idx.name,
+			err)
+	}
+
+	return fmt.Errorf("unable to reach external indexer %s at %s",
+		idx.name, idx.addr)
+}
+
+// Init notifies the external indexer that the index manager is initializing
+// the index.
+//
+// This is part of the Indexer interface.
+func (idx *ExternalIndex) Init() error {
+	return idx.call("Init", &ExternalInitArgs{
+		Name:    idx.name,
+		Network: idx.chainParams.Name,
+	})
+}
+
+// Key returns the database key to use for the index as a byte slice.
+//
+// This is part of the Indexer interface.
+func (idx *ExternalIndex) Key() []byte {
+	return externalIndexKey(idx.name)
+}
+
+// Name returns the human-readable name of the index.
+//
+// This is part of the Indexer interface.
+func (idx *ExternalIndex) Name() string {
+	return fmt.Sprintf("external index %s", idx.name)
+}
+
+// Create is invoked when the indexer manager determines the index needs to be
+// created for the first time.  There is nothing to create since all of the
+// index data is stored by the external indexer.
+//
+// This is part of the Indexer interface.
+func (idx *ExternalIndex) Create(dbTx database.Tx) error {
+	return nil
+}
+
+// blockArgs returns the arguments used to notify the external indexer about
+// the passed block.
+func (idx *ExternalIndex) blockArgs(block, parent *exccutil.Block) (*ExternalBlockArgs, error) {
+	blockBytes, err := block.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	var parentBytes []byte
+	if parent != nil {
+		parentBytes, err = parent.Bytes()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &ExternalBlockArgs{
+		Name:           idx.name,
+		Hash:           block.Hash().String(),
+		Height:         block.Height(),
+		Block:          hex.EncodeToString(blockBytes),
+		Parent:         hex.EncodeToString(parentBytes),
+		ApprovesParent: approvesParent(block),
+	}, nil
+}
+
+// ConnectBlock is invoked by the index manager when a new block has been
+// connected to the main chain.  The block is forwarded to the external indexer.
+//
+// This is part of the Indexer interface.
+func (idx *ExternalIndex) ConnectBlock(dbTx database.Tx, block, parent *exccutil.Block, view *blockchain.UtxoViewpoint) error {
+	args, err := idx.blockArgs(block, parent)
+	if err != nil {
+		return err
+	}
+	return idx.call("ConnectBlock", args)
+}
+
+// DisconnectBlock is invoked by the index manager when a block has been
+// disconnected from the main chain.  The block is forwarded to the external
+// indexer so it can remove the associated entries.
+//
+// This is part of the Indexer interface.
+func (idx *ExternalIndex) DisconnectBlock(dbTx database.Tx, block, parent *exccutil.Block, view *blockchain.UtxoViewpoint) error {
+	args, err := idx.blockArgs(block, parent)
+	if err != nil {
+		return err
+	}
+	return idx.call("DisconnectBlock", args)
+}
+
+// NewExternalIndex returns a new instance of an indexer that forwards all
+// connected and disconnected blocks to the external indexer with the provided
+// name listening at the provided address.
+//
+// It implements the Indexer interface which plugs into the IndexManager that in
+// turn is used by the blockchain package.  This allows the index to be
+// seamlessly maintained along with the chain.
+func NewExternalIndex(name, addr string, chainParams *chaincfg.Params) *ExternalIndex {
+	return &ExternalIndex{
+		name:        name,
+		addr:        addr,
+		chainParams: chainParams,
+		timeout:     externalIndexerTimeout,
+	}
+}
+
+// DropExternalIndex removes the tip of the external index with the provided
+// name from the database so the external indexer will be notified of every
+// block again the next time it is enabled.  The data stored by the external
+// indexer itself must be removed separately.
+func DropExternalIndex(db database.DB, name string, interrupt <-chan struct{}) error {
+	idxName := fmt.Sprintf("external index %s", name)
+	return dropIndex(db, externalIndexKey(name), idxName)
+}
+
+// ValidExternalIndexName returns whether or not the passed name may be used to
+// identify an external index.  Names must be non-empty and only consist of
+// ASCII letters, digits, dashes, and underscores.
+func ValidExternalIndexName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9',
+			r == '-', r == '_':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package indexers
+
+import (
+	"errors"
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/EXCCoin/exccd/chaincfg"
+	"github.com/EXCCoin/exccd/exccutil"
+	"github.com/EXCCoin/exccd/wire"
+)
+
+// mockSidecar implements the external indexer protocol by recording the
+// heights of all connected blocks.
+type mockSidecar struct {
+	mtx       sync.Mutex
+	initName  string
+	connected []int64
+	failAt    int64
+}
+
+// Init records the name of the initialized index.
+func (s *mockSidecar) Init(args *ExternalInitArgs, reply *ExternalReply) error {
+	s.mtx.Lock()
+	s.initName = args.Name
+	s.mtx.Unlock()
+	return nil
+}
+
+// ConnectBlock records the height of the connected block unless it is the
+// configured failure height.
+func (s *mockSidecar) ConnectBlock(args *ExternalBlockArgs, reply *ExternalReply) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if args.Height == s.failAt {
+		return errors.New("refusing block")
+	}
+	s.connected = append(s.connected, args.Height)
+	return nil
+}
+
+// DisconnectBlock removes the most recently connected block.
+func (s *mockSidecar) DisconnectBlock(args *ExternalBlockArgs, reply *ExternalReply) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.connected = s.connected[:len(s.connected)-1]
+	return nil
+}
+
+// TestExternalIndex ensures blocks are forwarded to an external indexer and
+// that errors returned by the indexer are propagated.
+func TestExternalIndex(t *testing.T) {
+	sidecar := &mockSidecar{failAt: -1}
+	server := rpc.NewServer()
+	if err := server.RegisterName(ExternalIndexerService, sidecar); err != nil {
+		t.Fatalf("unable to register sidecar: %v", err)
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unable to listen: %v", err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.ServeCodec(jsonrpc.NewServerCodec(conn))
+		}
+	}()
+
+	idx := NewExternalIndex("test", listener.Addr().String(),
+		&chaincfg.SimNetParams)
+	if err := idx.Init(); err != nil {
+		t.Fatalf("Init: unexpected error: %v", err)
+	}
+	sidecar.mtx.Lock()
+	initName := sidecar.initName
+	sidecar.mtx.Unlock()
+	if initName != "test" {
+		t.Fatalf("Init: unexpected name - got %q, want %q", initName,
+			"test")
+	}
+
+	newBlock := func(height uint32) *exccutil.Block {
+		return exccutil.NewBlock(&wire.MsgBlock{
+			Header: wire.BlockHeader{Height: height},
+		})
+	}
+	for height := uint32(1); height <= 3; height++ {
+		err := idx.ConnectBlock(nil, newBlock(height), newBlock(height-1),
+			nil)
+		if err != nil {
+			t.Fatalf("ConnectBlock: unexpected error: %v", err)
+		}
+	}
+	if err := idx.DisconnectBlock(nil, newBlock(3), newBlock(2), nil); err != nil {
+		t.Fatalf("DisconnectBlock: unexpected error: %v", err)
+	}
+	sidecar.mtx.Lock()
+	numConnected := len(sidecar.connected)
+	sidecar.failAt = 3
+	sidecar.mtx.Unlock()
+	if numConnected != 2 {
+		t.Fatalf("unexpected number of indexed blocks - got %d, want 2",
+			numConnected)
+	}
+
+	// Ensure errors from the sidecar are returned.
+	if err := idx.ConnectBlock(nil, newBlock(3), newBlock(2), nil); err == nil {
+		t.Fatal("ConnectBlock: did not receive expected error")
+	}
+}
+
+// TestExternalIndexTimeout ensures an error is returned when an external
+// indexer does not reply in time.
+func TestExternalIndexTimeout(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unable to listen: %v", err)
+	}
+	defer listener.Close()
+
+	// Accept connections without ever replying to requests.
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	idx := NewExternalIndex("test", listener.Addr().String(),
+		&chaincfg.SimNetParams)
+	idx.timeout = 100 * time.Millisecond
+	done := make(chan error, 1)
+	go func() { done <- idx.Init() }()
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("Init: did not receive expected error")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Init: did not time out")
+	}
+}
@@ -9,6 +9,7 @@ package indexers
 import (
 	"bytes"
 	"fmt"
+	"sync"
 
 	"github.com/EXCCoin/exccd/blockchain"
 	"github.com/EXCCoin/exccd/blockchain/internal/progresslog"
@@ -134,6 +135,30 @@ type Manager struct {
 	params         *chaincfg.Params
 	db             database.DB
 	enabledIndexes []Indexer
+
+	// chain and interrupt are the chain and interrupt channel the manager
+	// was initialized with.  See Init.
+	chain     *blockchain.BlockChain
+	interrupt <-chan struct{}
+
+	// ready tracks whether or not each of the enabled indexes has been
+	// loaded and is therefore updated along with the main chain, while
+	// synced tracks whether or not each of them has caught up to the main
+	// chain.  Indexes which are not synced are caught up in the background.
+	// loadErr is the error which prevented the indexes from being loaded,
+	// if any.
+	syncMtx sync.RWMutex
+	ready   []bool
+	synced  []bool
+	loadErr error
+
+	// errorHandler is invoked with the errors which prevent the indexes
+	// from being updated.  See SetErrorHandler.
+	errorHandler func(err error)
+
+	// panicHandler is deferred by the goroutine which loads the indexes.
+	// See SetPanicHandler.
+	panicHandler func()
 }
 
 // Ensure the Manager type implements the blockchain.IndexManager interface.
@@ -238,11 +263,13 @@ func (m *Manager) maybeCreateIndexes(dbTx database.Tx) error {
 }
 
 // Init initializes the enabled indexes.  This is called during chain
-// initialization and primarily consists of catching up all indexes to the
-// current best chain tip.  This is necessary since each index can be disabled
-// and re-enabled at any time and attempting to catch-up indexes at the same
-// time new blocks are being downloaded would lead to an overall longer time to
-// catch up due to the I/O contention.
+// initialization and only performs the work required for the indexes to be
+// safely queried, namely finishing any interrupted drops and creating the
+// indexes that do not exist yet.  Since opening the indexes, verifying they are
+// on the main chain, and catching them up to the current best chain tip can
+// take a long time on large databases, it is deferred until Load is called so
+// the node is able to start and process blocks in the meantime.  The progress
+// can be queried via IndexInfo.
 //
 // This is part of the blockchain.IndexManager interface.
 func (m *Manager) Init(chain *blockchain.BlockChain, interrupt <-chan struct{}) error {
@@ -275,46 +302,112 @@ func (m *Manager) Init(chain *blockchain.BlockChain, interrupt <-chan struct{})
 		return err
 	}
 
+	m.chain = chain
+	m.interrupt = interrupt
+	m.ready = make([]bool, len(m.enabledIndexes))
+	m.synced = make([]bool, len(m.enabledIndexes))
+	return nil
+}
+
+// Load opens the enabled indexes, rolls them back to the main chain if their
+// tip is an orphaned fork, and catches them up to the current best chain tip in
+// the background.  Each index is updated along with the main chain once it is
+// ready, and the indexes which fail to load are reported via the error handler
+// and IndexInfo.  It must only be called once after Init, typically once the
+// node is accepting connections so it is available while the indexes load.
+//
+// This function returns immediately.  See LoadAndWait for a variant which
+// blocks until the indexes are caught up.
+func (m *Manager) Load() {
+	// Nothing to do when no indexes are enabled.
+	if len(m.enabledIndexes) == 0 {
+		return
+	}
+
+	go func() {
+		if m.panicHandler != nil {
+			defer m.panicHandler()
+		}
+		err := m.load(m.interrupt)
+		if err != nil && !interruptRequested(m.interrupt) {
+			log.Errorf("Unable to load indexes: %v", err)
+			m.syncMtx.Lock()
+			m.loadErr = err
+			m.syncMtx.Unlock()
+			m.reportError(err)
+		}
+	}()
+}
+
+// LoadAndWait performs the same function as Load except it blocks until the
+// indexes are loaded and caught up to the current best chain tip, and returns
+// the error which prevented that, if any.  It is intended for callers which
+// update the chain right away and exit afterwards, such as block importers,
+// since the blocks connected before an index is ready are not indexed until it
+// catches up.
+func (m *Manager) LoadAndWait() error {
+	// Nothing to do when no indexes are enabled.
+	if len(m.enabledIndexes) == 0 {
+		return nil
+	}
+
+	err := m.load(m.interrupt)
+	if err != nil {
+		m.syncMtx.Lock()
+		m.loadErr = err
+		m.syncMtx.Unlock()
+	}
+	return err
+}
+
+// load opens the enabled indexes and rolls them back to the main chain as
+// needed, marking each one ready once it is done, and then catches up the ones
+// that are behind the main chain.
+func (m *Manager) load(interrupt <-chan struct{}) error {
 	// Initialize each of the enabled indexes.
 	for _, indexer := range m.enabledIndexes {
+		if interruptRequested(interrupt) {
+			return errInterruptRequested
+		}
 		if err := indexer.Init(); err != nil {
-			return err
+			return fmt.Errorf("unable to initialize the %s: %v",
+				indexer.Name(), err)
 		}
 	}
 
 	// Rollback indexes to the main chain if their tip is an orphaned fork.
 	// This is fairly unlikely, but it can happen if the chain is
-	// reorganized while the index is disabled.  This has to be done in
-	// reverse order because later indexes can depend on earlier ones.
+	// reorganized while the index is disabled or loading.  This has to be
+	// done in reverse order because later indexes can depend on earlier
+	// ones.
+	//
+	// Each index is marked ready in the same database transaction that
+	// verifies its tip is in the main chain, so that the chain updates it
+	// from the next block that is connected or disconnected onwards.
 	var cachedBlock *exccutil.Block
+	lowestHeight := int32(-1)
 	for i := len(m.enabledIndexes); i > 0; i-- {
 		indexer := m.enabledIndexes[i-1]
 
-		// Fetch the current tip for the index.
-		var height int32
-		var hash *chainhash.Hash
-		err := m.db.View(func(dbTx database.Tx) error {
-			idxKey := indexer.Key()
-			hash, height, err = dbFetchIndexerTip(dbTx, idxKey)
-			return err
-		})
-		if err != nil {
-			return err
-		}
-
-		// Nothing to do if the index does not have any entries yet.
-		if height == 0 {
-			continue
-		}
+		var interrupted, synced bool
+		var height, initialHeight int32
+		err := m.db.Update(func(dbTx database.Tx) error {
+			// Fetch the current tip for the index.
+			var hash *chainhash.Hash
+			var err error
+			hash, height, err = dbFetchIndexerTip(dbTx, indexer.Key())
+			if err != nil {
+				return err
+			}
+			initialHeight = height
 
-		// Loop until the tip is a block that exists in the main chain.
-		var interrupted bool
-		initialHeight := height
-		err = m.db.Update(func(dbTx database.Tx) error {
-			for !blockchain.DBMainChainHasBlock(dbTx, hash) {
+			// Loop until the tip is a block that exists in the main
+			// chain.  Nothing needs to be done if the index does not
+			// have any entries yet.
+			for height > 0 && !blockchain.DBMainChainHasBlock(dbTx, hash) {
 				// Get the block, unless it's already cached.
 				var block *exccutil.Block
-				if cachedBlock == nil && height > 0 {
+				if cachedBlock == nil {
 					block, err = blockchain.DBFetchBlockByHeight(dbTx,
 						int64(height))
 					if err != nil {
@@ -338,7 +431,6 @@ func (m *Manager) Init(chain *blockchain.BlockChain, interrupt <-chan struct{})
 				// transaction index.
 				var view *blockchain.UtxoViewpoint
 				if indexNeedsInputs(indexer) {
-					var err error
 					view, err = makeUtxoView(dbTx, block, parent,
 						interrupt)
 					if err != nil {
@@ -364,10 +456,23 @@ func (m *Manager) Init(chain *blockchain.BlockChain, interrupt <-chan struct{})
 				// undo all work that has been done.
 				if interruptRequested(interrupt) {
 					interrupted = true
-					break
+					return nil
 				}
 			}
 
+			// The index is synced when there is no block after its
+			// tip in the main chain.
+			_, err = blockchain.DBFetchBlockByHeight(dbTx,
+				int64(height)+1)
+			synced = blockchain.IsNotInMainChainErr(err)
+			if err != nil && !synced {
+				return err
+			}
+
+			m.syncMtx.Lock()
+			m.ready[i-1] = true
+			m.synced[i-1] = synced
+			m.syncMtx.Unlock()
 			return nil
 		})
 		if err != nil {
@@ -382,88 +487,126 @@ func (m *Manager) Init(chain *blockchain.BlockChain, interrupt <-chan struct{})
 				"(heights %d to %d)", initialHeight-height,
 				indexer.Name(), height+1, initialHeight)
 		}
-	}
-
-	// Fetch the current tip heights for each index along with tracking the
-	// lowest one so the catchup code only needs to start at the earliest
-	// block and is able to skip connecting the block for the indexes that
-	// don't need it.
-	bestHeight := int32(chain.BestSnapshot().Height)
-	lowestHeight := bestHeight
-	indexerHeights := make([]int32, len(m.enabledIndexes))
-	err = m.db.View(func(dbTx database.Tx) error {
-		for i, indexer := range m.enabledIndexes {
-			idxKey := indexer.Key()
-			hash, height, err := dbFetchIndexerTip(dbTx, idxKey)
-			if err != nil {
-				return err
-			}
-
-			log.Debugf("Current %s tip (height %d, hash %v)",
-				indexer.Name(), height, hash)
-			indexerHeights[i] = height
-			if height < lowestHeight {
-				lowestHeight = height
-			}
+		log.Debugf("Loaded %s (height %d, synced %v)", indexer.Name(),
+			height, synced)
+		if !synced && (lowestHeight == -1 || height < lowestHeight) {
+			lowestHeight = height
 		}
-		return nil
-	})
-	if err != nil {
-		return err
 	}
 
 	// Nothing to index if all of the indexes are caught up.
-	if lowestHeight == bestHeight {
+	if lowestHeight == -1 {
+		log.Infof("Loaded indexes")
 		return nil
 	}
 
-	// Create a progress logger for the indexing process below.
-	progressLogger := progresslog.NewBlockProgressLogger("Indexed", log)
-
 	// At this point, one or more indexes are behind the current best chain
-	// tip and need to be caught up, so log the details and loop through
-	// each block that needs to be indexed.
+	// tip and need to be caught up.
 	log.Infof("Catching up indexes from height %d to %d", lowestHeight,
-		bestHeight)
+		m.chain.BestSnapshot().Height)
+	return m.catchUp(interrupt)
+}
 
-	var cachedParent *exccutil.Block
-	for height := lowestHeight + 1; height <= bestHeight; height++ {
-		if interruptRequested(interrupt) {
-			return errInterruptRequested
-		}
+// isReady returns whether or not the enabled index at the provided position
+// has been loaded by Load and is therefore updated along with the main chain.
+//
+// This function is safe for concurrent access.
+func (m *Manager) isReady(i int) bool {
+	m.syncMtx.RLock()
+	ready := m.ready[i]
+	m.syncMtx.RUnlock()
+	return ready
+}
+
+// isSynced returns whether or not the enabled index at the provided position
+// has caught up to the main chain.
+//
+// This function is safe for concurrent access.
+func (m *Manager) isSynced(i int) bool {
+	m.syncMtx.RLock()
+	synced := m.synced[i]
+	m.syncMtx.RUnlock()
+	return synced
+}
+
+// markSynced marks the enabled index at the provided position as caught up to
+// the main chain.
+//
+// This function is safe for concurrent access.
+func (m *Manager) markSynced(i int) {
+	m.syncMtx.Lock()
+	m.synced[i] = true
+	m.syncMtx.Unlock()
+	log.Infof("Finished catching up the %s", m.enabledIndexes[i].Name())
+}
 
+// catchUp connects the blocks in the main chain to all indexes that are not
+// synced until they reach the current tip of the main chain.
+//
+// Each block is connected in its own database transaction which also
+// serializes the catch up process with the blocks that are connected to and
+// disconnected from the main chain by the chain while it runs.  The indexes
+// are caught up together starting from the lowest tip so that indexes which
+// rely on data from earlier ones, such as the address index relying on the
+// transaction index for the referenced inputs, always have it available.
+func (m *Manager) catchUp(interrupt <-chan struct{}) error {
+	progressLogger := progresslog.NewBlockProgressLogger("Indexed", log)
+	for !interruptRequested(interrupt) {
 		var block, parent *exccutil.Block
-		err = m.db.Update(func(dbTx database.Tx) error {
-			// Get the parent of the block, unless it's already cached.
-			if cachedParent == nil && height > 0 {
-				parent, err = blockchain.DBFetchBlockByHeight(
-					dbTx, int64(height-1))
+		err := m.db.Update(func(dbTx database.Tx) error {
+			// Fetch the current tip heights for each index that is
+			// not synced along with tracking the lowest one.
+			lowestHeight := int32(-1)
+			indexerHeights := make([]int32, len(m.enabledIndexes))
+			for i, indexer := range m.enabledIndexes {
+				if m.isSynced(i) {
+					continue
+				}
+
+				_, height, err := dbFetchIndexerTip(dbTx, indexer.Key())
 				if err != nil {
 					return err
 				}
-			} else {
-				parent = cachedParent
+				indexerHeights[i] = height
+				if lowestHeight == -1 || height < lowestHeight {
+					lowestHeight = height
+				}
 			}
 
-			// Load the block for the height since it is required to index
-			// it.
+			// Nothing left to do when all indexes are synced.
+			if lowestHeight == -1 {
+				return nil
+			}
+
+			// Load the next block to index.  The remaining indexes
+			// are caught up once there are no more blocks in the main
+			// chain.
+			var err error
 			block, err = blockchain.DBFetchBlockByHeight(dbTx,
-				int64(height))
+				int64(lowestHeight+1))
+			if blockchain.IsNotInMainChainErr(err) {
+				for i := range m.enabledIndexes {
+					if !m.isSynced(i) {
+						m.markSynced(i)
+					}
+				}
+				return nil
+			}
 			if err != nil {
 				return err
 			}
-			cachedParent = block
-
-			if interruptRequested(interrupt) {
-				return errInterruptRequested
+			parent, err = blockchain.DBFetchBlockByHeight(dbTx,
+				int64(lowestHeight))
+			if err != nil {
+				return err
 			}
 
 			// Connect the block for all indexes that need it.
 			var view *blockchain.UtxoViewpoint
 			for i, indexer := range m.enabledIndexes {
-				// Skip indexes that don't need to be updated with this
-				// block.
-				if indexerHeights[i] >= height {
+				// Skip indexes that don't need to be updated
+				// with this block.
+				if m.isSynced(i) || indexerHeights[i] != lowestHeight {
 					continue
 				}
 
@@ -472,11 +615,10 @@ func (m *Manager) Init(chain *blockchain.BlockChain, interrupt <-chan struct{})
 				// need to be retrieved from the transaction
 				// index.
 				if view == nil && indexNeedsInputs(indexer) {
-					var errMakeView error
-					view, errMakeView = makeUtxoView(dbTx, block, parent,
+					view, err = makeUtxoView(dbTx, block, parent,
 						interrupt)
-					if errMakeView != nil {
-						return errMakeView
+					if err != nil {
+						return err
 					}
 				}
 				err = dbIndexConnectBlock(dbTx, indexer, block,
@@ -484,20 +626,20 @@ func (m *Manager) Init(chain *blockchain.BlockChain, interrupt <-chan struct{})
 				if err != nil {
 					return err
 				}
-
-				indexerHeights[i] = height
 			}
 
 			return nil
 		})
 		if err != nil {
-			return err
+			return fmt.Errorf("unable to catch up indexes: %v", err)
+		}
+		if block == nil {
+			log.Infof("Indexes caught up")
+			return nil
 		}
 		progressLogger.LogBlockHeight(block.MsgBlock(), parent.MsgBlock())
 	}
-
-	log.Infof("Indexes caught up to height %d", bestHeight)
-	return nil
+	return errInterruptRequested
 }
 
 // indexNeedsInputs returns whether or not the index needs access to the txouts
@@ -624,9 +766,30 @@ func makeUtxoView(dbTx database.Tx, block, parent *exccutil.Block, interrupt <-c
 func (m *Manager) ConnectBlock(dbTx database.Tx, block, parent *exccutil.Block, view *blockchain.UtxoViewpoint) error {
 	// Call each of the currently active optional indexes with the block
 	// being connected so they can update accordingly.
-	for _, index := range m.enabledIndexes {
+	for i, index := range m.enabledIndexes {
+		// Indexes that have not been loaded yet are skipped since they
+		// are rolled back to the main chain and caught up once they are.
+		if !m.isReady(i) {
+			continue
+		}
+
+		// Indexes that are still being caught up in the background are
+		// only updated once their tip reaches the parent of the block,
+		// at which point they are synced.
+		if !m.isSynced(i) {
+			tipHash, _, err := dbFetchIndexerTip(dbTx, index.Key())
+			if err != nil {
+				return err
+			}
+			if *tipHash != block.MsgBlock().Header.PrevBlock {
+				continue
+			}
+			m.markSynced(i)
+		}
+
 		err := dbIndexConnectBlock(dbTx, index, block, parent, view)
 		if err != nil {
+			m.reportError(err)
 			return err
 		}
 	}
@@ -642,15 +805,198 @@ func (m *Manager) ConnectBlock(dbTx database.Tx, block, parent *exccutil.Block,
 func (m *Manager) DisconnectBlock(dbTx database.Tx, block, parent *exccutil.Block, view *blockchain.UtxoViewpoint) error {
 	// Call each of the currently active optional indexes with the block
 	// being disconnected so they can update accordingly.
-	for _, index := range m.enabledIndexes {
+	for i, index := range m.enabledIndexes {
+		// Indexes that have not been loaded yet are skipped since they
+		// are rolled back to the main chain once they are.
+		if !m.isReady(i) {
+			continue
+		}
+
+		// Indexes that are still being caught up in the background only
+		// need to be updated when they already include the block.
+		if !m.isSynced(i) {
+			tipHash, _, err := dbFetchIndexerTip(dbTx, index.Key())
+			if err != nil {
+				return err
+			}
+			if *tipHash != *block.Hash() {
+				continue
+			}
+		}
+
 		err := dbIndexDisconnectBlock(dbTx, index, block, parent, view)
 		if err != nil {
+			m.reportError(err)
 			return err
 		}
 	}
 	return nil
 }
 
+// SetErrorHandler sets the function which is invoked with the errors which
+// prevent the indexes from being updated, such as those caused by a corrupt
+// index, so they can be reported.  It is invoked while the chain is being
+// updated or loaded, so it must not block or call back into the chain.  It must
+// be called before Init.
+func (m *Manager) SetErrorHandler(handler func(err error)) {
+	m.errorHandler = handler
+}
+
+// SetPanicHandler sets the function which is deferred by the goroutine Load
+// starts so panics while the indexes are loaded in the background can be
+// reported.  Since it is deferred directly, it may call recover itself.  It must
+// be called before Load.
+func (m *Manager) SetPanicHandler(handler func()) {
+	m.panicHandler = handler
+}
+
+// reportError invokes the error handler with the passed error when one is set.
+func (m *Manager) reportError(err error) {
+	if m.errorHandler != nil {
+		m.errorHandler(err)
+	}
+}
+
+// IndexInfo describes the current state of an index managed by the index
+// manager.  LoadErr is the error which prevented the index from being loaded
+// when it is not ready.
+type IndexInfo struct {
+	Name    string
+	Hash    chainhash.Hash
+	Height  int64
+	Ready   bool
+	Synced  bool
+	LoadErr error
+}
+
+// IndexInfo returns the current tip of each enabled index along with whether
+// or not it has been loaded and caught up to the main chain.
+//
+// This function is safe for concurrent access.
+func (m *Manager) IndexInfo() ([]IndexInfo, error) {
+	infos := make([]IndexInfo, 0, len(m.enabledIndexes))
+	err := m.db.View(func(dbTx database.Tx) error {
+		for i, indexer := range m.enabledIndexes {
+			hash, height, err := dbFetchIndexerTip(dbTx, indexer.Key())
+			if err != nil {
+				return err
+			}
+			info := IndexInfo{
+				Name:   indexer.Name(),
+				Hash:   *hash,
+				Height: int64(height),
+			}
+			m.syncMtx.RLock()
+			info.Ready = m.ready[i]
+			info.Synced = m.synced[i]
+			if !info.Ready {
+				info.LoadErr = m.loadErr
+			}
+			m.syncMtx.RUnlock()
+			infos = append(infos, info)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return infos, nil
+}
+
+// IndexSynced returns whether or not the passed index has caught up to the main
+// chain, so queries of it return complete results.  Indexes which are not
+// enabled in the manager are not updated by it and are therefore considered
+// synced.
+//
+// This function is safe for concurrent access.
+func (m *Manager) IndexSynced(indexer Indexer) bool {
+	m.syncMtx.RLock()
+	defer m.syncMtx.RUnlock()
+	for i, idx := range m.enabledIndexes {
+		if idx == indexer {
+			return i < len(m.synced) && m.synced[i]
+		}
+	}
+	return true
+}
+
+// IndexTip describes the tip of an index stored in a database.
+type IndexTip struct {
+	Name     string
+	Hash     chainhash.Hash
+	Height   int64
+	Dropping bool
+}
+
+// indexNames maps the keys of the indexes to their names.
+var indexNames = map[string]string{
+	string(addrIndexKey):           addrIndexName,
+	string(cfIndexParentBucketKey): cfIndexName,
+	string(existsAddrIndexKey):     existsAddressIndexName,
+	string(ticketIndexKey):         ticketIndexName,
+	string(timeIndexKey):           timeIndexName,
+	string(txIndexKey):             txIndexName,
+}
+
+// FetchIndexTips returns the tip of every index stored in the passed database,
+// whether or not it is enabled, along with whether or not it is in the middle
+// of being dropped.  The tips are ordered by the keys of the indexes.
+//
+// Unlike the index manager, it never writes to the database, so it may be used
+// to inspect a database which is opened read only.
+func FetchIndexTips(db database.DB) ([]IndexTip, error) {
+	var tips []IndexTip
+	err := db.View(func(dbTx database.Tx) error {
+		indexesBucket := dbTx.Metadata().Bucket(indexTipsBucketName)
+		if indexesBucket == nil {
+			return nil
+		}
+
+		// Determine which indexes are being dropped first since the
+		// markers are stored alongside the tips.
+		dropping := make(map[string]bool)
+		err := indexesBucket.ForEach(func(k, v []byte) error {
+			if bytes.Equal(k, indexDropKey(v)) {
+				dropping[string(v)] = true
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		return indexesBucket.ForEach(func(k, v []byte) error {
+			if bytes.Equal(k, indexDropKey(v)) {
+				return nil
+			}
+			name, ok := indexNames[string(k)]
+			if !ok && bytes.HasPrefix(k, []byte(externalIndexKeyPrefix)) {
+				name = "external index " +
+					string(k[len(externalIndexKeyPrefix):])
+			} else if !ok {
+				name = string(k)
+			}
+			hash, height, err := dbFetchIndexerTip(dbTx, k)
+			if err != nil {
+				return err
+			}
+			tips = append(tips, IndexTip{
+				Name:     name,
+				Hash:     *hash,
+				Height:   int64(height),
+				Dropping: dropping[string(k)],
+			})
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tips, nil
+}
+
 // NewManager returns a new index manager with the provided indexes enabled.
 //
 // The manager returned satisfies the blockchain.IndexManager interface and thus
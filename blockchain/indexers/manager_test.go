@@ -0,0 +1,51 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package indexers
+
+import (
+	"testing"
+
+	"github.com/EXCCoin/exccd/chaincfg"
+)
+
+// TestIndexSynced ensures indexes are only reported as synced once they have
+// caught up to the main chain and that indexes which are not enabled in the
+// manager are always reported as synced.
+func TestIndexSynced(t *testing.T) {
+	txIndex := NewTxIndex(nil)
+	addrIndex := NewAddrIndex(nil, &chaincfg.SimNetParams)
+	cfIndex := NewCfIndex(nil, &chaincfg.SimNetParams)
+	m := NewManager(nil, []Indexer{txIndex, addrIndex}, &chaincfg.SimNetParams)
+
+	// Indexes are not synced before the manager is initialized.
+	if m.IndexSynced(txIndex) {
+		t.Fatal("index synced before initialization")
+	}
+
+	m.ready = make([]bool, len(m.enabledIndexes))
+	m.synced = make([]bool, len(m.enabledIndexes))
+	m.synced[1] = true
+	tests := []struct {
+		name    string
+		indexer Indexer
+		want    bool
+	}{
+		{"catching up", txIndex, false},
+		{"synced", addrIndex, true},
+		{"not enabled", cfIndex, true},
+	}
+	for _, test := range tests {
+		if got := m.IndexSynced(test.indexer); got != test.want {
+			t.Errorf("%s: unexpected synced flag - got %v, want %v",
+				test.name, got, test.want)
+		}
+	}
+
+	// Indexes are synced once caught up.
+	m.markSynced(0)
+	if !m.IndexSynced(txIndex) {
+		t.Fatal("index not synced after catching up")
+	}
+}
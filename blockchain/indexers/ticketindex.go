@@ -0,0 +1,621 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package indexers
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/EXCCoin/exccd/blockchain"
+	"github.com/EXCCoin/exccd/blockchain/stake"
+	"github.com/EXCCoin/exccd/chaincfg"
+	"github.com/EXCCoin/exccd/chaincfg/chainhash"
+	"github.com/EXCCoin/exccd/database"
+	"github.com/EXCCoin/exccd/exccutil"
+	"github.com/EXCCoin/exccd/txscript"
+	"github.com/EXCCoin/exccd/wire"
+)
+
+const (
+	// ticketIndexName is the human-readable name for the index.
+	ticketIndexName = "ticket lifecycle index"
+
+	// ticketEntrySize is the size of a serialized ticket entry.  It
+	// consists of the purchase, live, missed, and spend heights, a flags
+	// byte, and the hash of the transaction which spent the ticket.
+	ticketEntrySize = 4 + 4 + 4 + 4 + 1 + chainhash.HashSize
+
+	// The following prefixes are used to separate the different kinds of
+	// entries which are stored in the flat ticket index bucket.
+	ticketEntryPrefix  = 't'
+	ticketAddrPrefix   = 'a'
+	ticketHeightPrefix = 'h'
+
+	// The following flags are stored in the flags byte of a ticket entry.
+	ticketFlagExpired = 1 << 0
+	ticketFlagRevoked = 1 << 1
+)
+
+var (
+	// ticketIndexKey is the key of the ticket lifecycle index and the db
+	// bucket used to house it.
+	ticketIndexKey = []byte("ticketlifecycleidx")
+)
+
+// -----------------------------------------------------------------------------
+// The ticket lifecycle index consists of three kinds of entries which are all
+// stored in a single flat bucket and distinguished by a one byte prefix.
+//
+// The ticket entries track the heights at which a ticket changed state along
+// with the hash of the transaction which eventually spent it:
+//
+//   't'<ticket hash> = <purchase height><live height><missed height>
+//                      <spend height><flags><spender hash>
+//
+//   Field           Type             Size
+//   ticket hash     chainhash.Hash   chainhash.HashSize
+//   purchase height uint32           4 bytes
+//   live height     uint32           4 bytes
+//   missed height   uint32           4 bytes
+//   spend height    uint32           4 bytes
+//   flags           uint8            1 byte
+//   spender hash    chainhash.Hash   chainhash.HashSize
+//
+// A height of zero indicates the ticket has not reached the associated state.
+//
+// The address entries map each commitment (reward) address of a ticket to the
+// ticket and have empty values:
+//
+//   'a'<addr key><ticket hash> = nil
+//
+// Finally, the height entries record all of the tickets which were modified by
+// the main chain block at a given height so they can be reverted when the
+// block is disconnected:
+//
+//   'h'<height> = <ticket hash>...
+//
+// All heights in keys are serialized in big endian.
+// -----------------------------------------------------------------------------
+
+// TicketStatus describes the state of a ticket within its lifecycle.
+type TicketStatus byte
+
+// These constants define the possible states of a ticket.
+const (
+	// TicketImmature indicates the ticket has been purchased but has not
+	// reached maturity yet.
+	TicketImmature TicketStatus = iota
+
+	// TicketLive indicates the ticket is in the live ticket pool and may be
+	// selected to vote.
+	TicketLive
+
+	// TicketVoted indicates the ticket was spent by a vote.
+	TicketVoted
+
+	// TicketMissed indicates the ticket was selected to vote but the vote
+	// was not included in the block.
+	TicketMissed
+
+	// TicketExpired indicates the ticket was never selected to vote before
+	// it expired.
+	TicketExpired
+
+	// TicketRevoked indicates the ticket was missed or expired and then
+	// revoked.
+	TicketRevoked
+)
+
+// ticketStatusStrings is a map of ticket states back to their constant names
+// for pretty printing.
+var ticketStatusStrings = map[TicketStatus]string{
+	TicketImmature: "immature",
+	TicketLive:     "live",
+	TicketVoted:    "voted",
+	TicketMissed:   "missed",
+	TicketExpired:  "expired",
+	TicketRevoked:  "revoked",
+}
+
+// String returns the TicketStatus as a human-readable name.
+func (s TicketStatus) String() string {
+	if str, ok := ticketStatusStrings[s]; ok {
+		return str
+	}
+	return fmt.Sprintf("Unknown TicketStatus (%d)", byte(s))
+}
+
+// TicketLifecycle describes the lifecycle of a ticket as tracked by the ticket
+// lifecycle index.  Heights which are zero indicate the ticket has not reached
+// the associated state.
+type TicketLifecycle struct {
+	Hash           chainhash.Hash
+	PurchaseHeight int64
+	LiveHeight     int64
+	MissedHeight   int64
+	SpendHeight    int64
+	Expired        bool
+	Revoked        bool
+	Spender        chainhash.Hash
+}
+
+// Status returns the current state of the ticket.
+func (t *TicketLifecycle) Status() TicketStatus {
+	switch {
+	case t.SpendHeight != 0 && t.Revoked:
+		return TicketRevoked
+	case t.SpendHeight != 0:
+		return TicketVoted
+	case t.MissedHeight != 0 && t.Expired:
+		return TicketExpired
+	case t.MissedHeight != 0:
+		return TicketMissed
+	case t.LiveHeight != 0:
+		return TicketLive
+	}
+	return TicketImmature
+}
+
+// ticketEntryKey returns the key of the entry for the passed ticket.
+func ticketEntryKey(hash *chainhash.Hash) []byte {
+	key := make([]byte, 1+chainhash.HashSize)
+	key[0] = ticketEntryPrefix
+	copy(key[1:], hash[:])
+	return key
+}
+
+// ticketAddrKey returns the key which links the passed address key to the
+// passed ticket.
+func ticketAddrKey(addrKey [addrKeySize]byte, hash *chainhash.Hash) []byte {
+	key := make([]byte, 1+addrKeySize+chainhash.HashSize)
+	key[0] = ticketAddrPrefix
+	copy(key[1:], addrKey[:])
+	copy(key[1+addrKeySize:], hash[:])
+	return key
+}
+
+// ticketHeightKey returns the key of the entry which records the tickets
+// modified by the block at the passed height.
+func ticketHeightKey(height uint32) []byte {
+	key := make([]byte, 1+4)
+	key[0] = ticketHeightPrefix
+	binary.BigEndian.PutUint32(key[1:], height)
+	return key
+}
+
+// serializeTicketEntry returns the passed ticket lifecycle serialized
+// according to the format described in detail above.
+func serializeTicketEntry(t *TicketLifecycle) []byte {
+	serialized := make([]byte, ticketEntrySize)
+	byteOrder.PutUint32(serialized[0:4], uint32(t.PurchaseHeight))
+	byteOrder.PutUint32(serialized[4:8], uint32(t.LiveHeight))
+	byteOrder.PutUint32(serialized[8:12], uint32(t.MissedHeight))
+	byteOrder.PutUint32(serialized[12:16], uint32(t.SpendHeight))
+	var flags byte
+	if t.Expired {
+		flags |= ticketFlagExpired
+	}
+	if t.Revoked {
+		flags |= ticketFlagRevoked
+	}
+	serialized[16] = flags
+	copy(serialized[17:], t.Spender[:])
+	return serialized
+}
+
+// deserializeTicketEntry deserializes the passed serialized ticket entry for
+// the provided ticket hash.
+func deserializeTicketEntry(hash *chainhash.Hash, serialized []byte) (*TicketLifecycle, error) {
+	if len(serialized) != ticketEntrySize {
+		return nil, errDeserialize(fmt.Sprintf("unexpected ticket entry "+
+			"length for ticket %v: %d", hash, len(serialized)))
+	}
+
+	t := &TicketLifecycle{
+		Hash:           *hash,
+		PurchaseHeight: int64(byteOrder.Uint32(serialized[0:4])),
+		LiveHeight:     int64(byteOrder.Uint32(serialized[4:8])),
+		MissedHeight:   int64(byteOrder.Uint32(serialized[8:12])),
+		SpendHeight:    int64(byteOrder.Uint32(serialized[12:16])),
+		Expired:        serialized[16]&ticketFlagExpired != 0,
+		Revoked:        serialized[16]&ticketFlagRevoked != 0,
+	}
+	copy(t.Spender[:], serialized[17:])
+	return t, nil
+}
+
+// dbFetchTicketEntry uses an existing database transaction to fetch the
+// lifecycle of the passed ticket.  Nil is returned when the ticket is not
+// indexed.
+func dbFetchTicketEntry(bucket internalBucket, hash *chainhash.Hash) (*TicketLifecycle, error) {
+	serialized := bucket.Get(ticketEntryKey(hash))
+	if serialized == nil {
+		return nil, nil
+	}
+	return deserializeTicketEntry(hash, serialized)
+}
+
+// dbPutTicketEntry uses an existing database transaction to store the passed
+// ticket lifecycle.
+func dbPutTicketEntry(bucket internalBucket, t *TicketLifecycle) error {
+	return bucket.Put(ticketEntryKey(&t.Hash), serializeTicketEntry(t))
+}
+
+// TicketIndex implements an index which tracks the lifecycle of every ticket
+// from purchase until it is either spent by a vote or revoked.  In addition,
+// the tickets are indexed by the addresses of their commitment outputs, which
+// are the addresses that receive the rewards, so all of the tickets owned by a
+// given address can be quickly retrieved.
+//
+// The state changes of tickets which are not directly visible in the block,
+// such as a ticket becoming live, being missed, or expiring, are determined
+// from the ticket undo data that is stored by the stake database.
+type TicketIndex struct {
+	db          database.DB
+	chainParams *chaincfg.Params
+}
+
+// Ensure the TicketIndex type implements the Indexer interface.
+var _ Indexer = (*TicketIndex)(nil)
+
+// Ensure the TicketIndex type implements the NeedsInputser interface.
+var _ NeedsInputser = (*TicketIndex)(nil)
+
+// NeedsInputs signals that the index does not require the referenced inputs in
+// order to properly create the index.
+//
+// This implements the NeedsInputser interface.
+func (idx *TicketIndex) NeedsInputs() bool {
+	return false
+}
+
+// Init is only provided to satisfy the Indexer interface as there is nothing to
+// initialize for this index.
+//
+// This is part of the Indexer interface.
+func (idx *TicketIndex) Init() error {
+	// Nothing to do.
+	return nil
+}
+
+// Key returns the database key to use for the index as a byte slice.
+//
+// This is part of the Indexer interface.
+func (idx *TicketIndex) Key() []byte {
+	return ticketIndexKey
+}
+
+// Name returns the human-readable name of the index.
+//
+// This is part of the Indexer interface.
+func (idx *TicketIndex) Name() string {
+	return ticketIndexName
+}
+
+// Create is invoked when the indexer manager determines the index needs
+// to be created for the first time.  It creates the bucket for the ticket
+// lifecycle index.
+//
+// This is part of the Indexer interface.
+func (idx *TicketIndex) Create(dbTx database.Tx) error {
+	_, err := dbTx.Metadata().CreateBucket(ticketIndexKey)
+	return err
+}
+
+// ticketCommitmentAddrKeys returns the address keys of all of the commitment
+// outputs of the passed ticket purchase.  Unsupported address types are
+// skipped.
+func (idx *TicketIndex) ticketCommitmentAddrKeys(msgTx *wire.MsgTx) [][addrKeySize]byte {
+	var addrKeys [][addrKeySize]byte
+	for _, txOut := range msgTx.TxOut {
+		if txscript.GetScriptClass(txOut.Version, txOut.PkScript) !=
+			txscript.NullDataTy {
+			continue
+		}
+
+		addr, err := stake.AddrFromSStxPkScrCommitment(txOut.PkScript,
+			idx.chainParams)
+		if err != nil {
+			continue
+		}
+		addrKey, err := addrToKey(addr, idx.chainParams)
+		if err != nil {
+			continue
+		}
+		addrKeys = append(addrKeys, addrKey)
+	}
+	return addrKeys
+}
+
+// ticketSpenders returns a map of the tickets spent by the votes and
+// revocations in the passed block to the hash of the transaction which spent
+// them.
+func ticketSpenders(block *exccutil.Block) map[chainhash.Hash]chainhash.Hash {
+	spenders := make(map[chainhash.Hash]chainhash.Hash)
+	for _, stx := range block.STransactions() {
+		msgTx := stx.MsgTx()
+		switch {
+		case stake.IsSSGen(msgTx):
+			spenders[msgTx.TxIn[1].PreviousOutPoint.Hash] = *stx.Hash()
+		case stake.IsSSRtx(msgTx):
+			spenders[msgTx.TxIn[0].PreviousOutPoint.Hash] = *stx.Hash()
+		}
+	}
+	return spenders
+}
+
+// ConnectBlock is invoked by the index manager when a new block has been
+// connected to the main chain.  This indexer adds entries for all tickets
+// purchased in the block and updates the entries of all tickets which changed
+// state as a result of the block.
+//
+// This is part of the Indexer interface.
+func (idx *TicketIndex) ConnectBlock(dbTx database.Tx, block, parent *exccutil.Block, view *blockchain.UtxoViewpoint) error {
+	bucket := dbTx.Metadata().Bucket(ticketIndexKey)
+	height := uint32(block.Height())
+	var modified []chainhash.Hash
+
+	// Add an entry along with the commitment addresses for every ticket
+	// purchased in the block.
+	for _, stx := range block.STransactions() {
+		msgTx := stx.MsgTx()
+		if !stake.IsSStx(msgTx) {
+			continue
+		}
+
+		ticket := &TicketLifecycle{
+			Hash:           *stx.Hash(),
+			PurchaseHeight: int64(height),
+		}
+		if err := dbPutTicketEntry(bucket, ticket); err != nil {
+			return err
+		}
+		for _, addrKey := range idx.ticketCommitmentAddrKeys(msgTx) {
+			err := bucket.Put(ticketAddrKey(addrKey, stx.Hash()), nil)
+			if err != nil {
+				return err
+			}
+		}
+		modified = append(modified, *stx.Hash())
+	}
+
+	// Update the state of every ticket that was modified by the block
+	// according to the undo data stored by the stake database.
+	undoData, err := stake.FetchBlockUndoData(dbTx, height)
+	if err != nil {
+		return err
+	}
+	spenders := ticketSpenders(block)
+	for _, undo := range undoData {
+		ticket, err := dbFetchTicketEntry(bucket, &undo.TicketHash)
+		if err != nil {
+			return err
+		}
+		if ticket == nil {
+			ticket = &TicketLifecycle{
+				Hash:           undo.TicketHash,
+				PurchaseHeight: int64(undo.TicketHeight),
+			}
+		}
+
+		switch {
+		// All flags are unset; the ticket matured and became live.
+		case !undo.Missed && !undo.Revoked && !undo.Spent:
+			ticket.LiveHeight = int64(height)
+
+		// The ticket was previously missed or expired and is now
+		// revoked.
+		case undo.Missed && undo.Revoked:
+			ticket.SpendHeight = int64(height)
+			ticket.Revoked = true
+			ticket.Spender = spenders[undo.TicketHash]
+
+		// The ticket was live and either missed its vote or expired.
+		case undo.Missed:
+			ticket.MissedHeight = int64(height)
+			ticket.Expired = undo.Expired
+
+		// The ticket was spent by a vote.
+		case undo.Spent:
+			ticket.SpendHeight = int64(height)
+			ticket.Spender = spenders[undo.TicketHash]
+		}
+
+		if err := dbPutTicketEntry(bucket, ticket); err != nil {
+			return err
+		}
+		modified = append(modified, undo.TicketHash)
+	}
+
+	// Record all of the tickets that were modified so the changes can be
+	// undone when the block is disconnected.
+	if len(modified) == 0 {
+		return nil
+	}
+	serialized := make([]byte, 0, len(modified)*chainhash.HashSize)
+	for i := range modified {
+		serialized = append(serialized, modified[i][:]...)
+	}
+	return bucket.Put(ticketHeightKey(height), serialized)
+}
+
+// DisconnectBlock is invoked by the index manager when a block has been
+// disconnected from the main chain.  This indexer removes the entries for all
+// tickets purchased in the block and reverts the state changes made to all
+// other tickets by the block.
+//
+// This is part of the Indexer interface.
+func (idx *TicketIndex) DisconnectBlock(dbTx database.Tx, block, parent *exccutil.Block, view *blockchain.UtxoViewpoint) error {
+	bucket := dbTx.Metadata().Bucket(ticketIndexKey)
+	height := uint32(block.Height())
+	heightKey := ticketHeightKey(height)
+	serialized := bucket.Get(heightKey)
+	if len(serialized)%chainhash.HashSize != 0 {
+		return errDeserialize(fmt.Sprintf("unexpected modified tickets "+
+			"length for height %d: %d", height, len(serialized)))
+	}
+
+	for offset := 0; offset < len(serialized); offset += chainhash.HashSize {
+		var hash chainhash.Hash
+		copy(hash[:], serialized[offset:offset+chainhash.HashSize])
+		ticket, err := dbFetchTicketEntry(bucket, &hash)
+		if err != nil {
+			return err
+		}
+		if ticket == nil {
+			continue
+		}
+
+		// Remove tickets purchased in the block entirely.
+		if ticket.PurchaseHeight == int64(height) {
+			if err := bucket.Delete(ticketEntryKey(&hash)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if ticket.LiveHeight == int64(height) {
+			ticket.LiveHeight = 0
+		}
+		if ticket.MissedHeight == int64(height) {
+			ticket.MissedHeight = 0
+			ticket.Expired = false
+		}
+		if ticket.SpendHeight == int64(height) {
+			ticket.SpendHeight = 0
+			ticket.Revoked = false
+			ticket.Spender = chainhash.Hash{}
+		}
+		if err := dbPutTicketEntry(bucket, ticket); err != nil {
+			return err
+		}
+	}
+
+	// Remove the address entries for all tickets purchased in the block.
+	for _, stx := range block.STransactions() {
+		msgTx := stx.MsgTx()
+		if !stake.IsSStx(msgTx) {
+			continue
+		}
+		for _, addrKey := range idx.ticketCommitmentAddrKeys(msgTx) {
+			err := bucket.Delete(ticketAddrKey(addrKey, stx.Hash()))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return bucket.Delete(heightKey)
+}
+
+// TicketLifecycle returns the lifecycle of the passed ticket.  Nil is returned
+// when the ticket is not found in the index.
+//
+// This function is safe for concurrent access.
+func (idx *TicketIndex) TicketLifecycle(hash *chainhash.Hash) (*TicketLifecycle, error) {
+	var ticket *TicketLifecycle
+	err := idx.db.View(func(dbTx database.Tx) error {
+		var err error
+		bucket := dbTx.Metadata().Bucket(ticketIndexKey)
+		ticket, err = dbFetchTicketEntry(bucket, hash)
+		return err
+	})
+	return ticket, err
+}
+
+// TicketLifecycles returns the lifecycles of all of the passed tickets in the
+// same order.  The entry for a ticket which is not found in the index is nil.
+//
+// This function is safe for concurrent access.
+func (idx *TicketIndex) TicketLifecycles(hashes []chainhash.Hash) ([]*TicketLifecycle, error) {
+	tickets := make([]*TicketLifecycle, len(hashes))
+	err := idx.db.View(func(dbTx database.Tx) error {
+		bucket := dbTx.Metadata().Bucket(ticketIndexKey)
+		for i := range hashes {
+			ticket, err := dbFetchTicketEntry(bucket, &hashes[i])
+			if err != nil {
+				return err
+			}
+			tickets[i] = ticket
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tickets, nil
+}
+
+// TicketsForAddress returns the lifecycles of all tickets which commit their
+// rewards to the passed address ordered by ticket hash.
+//
+// This function is safe for concurrent access.
+func (idx *TicketIndex) TicketsForAddress(addr exccutil.Address) ([]*TicketLifecycle, error) {
+	addrKey, err := addrToKey(addr, idx.chainParams)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := make([]byte, 1+addrKeySize)
+	prefix[0] = ticketAddrPrefix
+	copy(prefix[1:], addrKey[:])
+
+	var tickets []*TicketLifecycle
+	err = idx.db.View(func(dbTx database.Tx) error {
+		bucket := dbTx.Metadata().Bucket(ticketIndexKey)
+		cursor := bucket.Cursor()
+		for ok := cursor.Seek(prefix); ok; ok = cursor.Next() {
+			key := cursor.Key()
+			if !bytes.HasPrefix(key, prefix) {
+				break
+			}
+
+			var hash chainhash.Hash
+			copy(hash[:], key[len(prefix):])
+			ticket, err := dbFetchTicketEntry(bucket, &hash)
+			if err != nil {
+				return err
+			}
+			if ticket == nil {
+				return AssertError(fmt.Sprintf("missing ticket "+
+					"entry for indexed ticket %v", hash))
+			}
+			tickets = append(tickets, ticket)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tickets, nil
+}
+
+// NewTicketIndex returns a new instance of an indexer that is used to track
+// the lifecycle of all tickets along with the addresses their rewards are
+// committed to.
+//
+// It implements the Indexer interface which plugs into the IndexManager that in
+// turn is used by the blockchain package.  This allows the index to be
+// seamlessly maintained along with the chain.
+func NewTicketIndex(db database.DB, chainParams *chaincfg.Params) *TicketIndex {
+	return &TicketIndex{
+		db:          db,
+		chainParams: chainParams,
+	}
+}
+
+// DropTicketIndex drops the ticket lifecycle index from the provided database
+// if it exists.
+func DropTicketIndex(db database.DB, interrupt <-chan struct{}) error {
+	return dropFlatIndex(db, ticketIndexKey, ticketIndexName, interrupt)
+}
+
+// DropIndex drops the ticket lifecycle index from the provided database if it
+// exists.
+func (*TicketIndex) DropIndex(db database.DB, interrupt <-chan struct{}) error {
+	return DropTicketIndex(db, interrupt)
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package indexers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/EXCCoin/exccd/chaincfg/chainhash"
+)
+
+// TestTicketEntrySerialization ensures serializing and deserializing ticket
+// lifecycle entries works as expected and that the derived status matches the
+// recorded state transitions.
+func TestTicketEntrySerialization(t *testing.T) {
+	t.Parallel()
+
+	hash := chainhash.Hash{0x01, 0x02, 0x03}
+	spender := chainhash.Hash{0x04, 0x05, 0x06}
+	tests := []struct {
+		name   string
+		ticket TicketLifecycle
+		status TicketStatus
+	}{
+		{
+			name:   "immature",
+			ticket: TicketLifecycle{Hash: hash, PurchaseHeight: 100},
+			status: TicketImmature,
+		},
+		{
+			name: "live",
+			ticket: TicketLifecycle{Hash: hash, PurchaseHeight: 100,
+				LiveHeight: 356},
+			status: TicketLive,
+		},
+		{
+			name: "voted",
+			ticket: TicketLifecycle{Hash: hash, PurchaseHeight: 100,
+				LiveHeight: 356, SpendHeight: 2000, Spender: spender},
+			status: TicketVoted,
+		},
+		{
+			name: "missed",
+			ticket: TicketLifecycle{Hash: hash, PurchaseHeight: 100,
+				LiveHeight: 356, MissedHeight: 2000},
+			status: TicketMissed,
+		},
+		{
+			name: "expired",
+			ticket: TicketLifecycle{Hash: hash, PurchaseHeight: 100,
+				LiveHeight: 356, MissedHeight: 41316, Expired: true},
+			status: TicketExpired,
+		},
+		{
+			name: "revoked",
+			ticket: TicketLifecycle{Hash: hash, PurchaseHeight: 100,
+				LiveHeight: 356, MissedHeight: 2000, SpendHeight: 2001,
+				Revoked: true, Spender: spender},
+			status: TicketRevoked,
+		},
+	}
+
+	for _, test := range tests {
+		serialized := serializeTicketEntry(&test.ticket)
+		if len(serialized) != ticketEntrySize {
+			t.Errorf("%s: unexpected serialized size - got %d, want %d",
+				test.name, len(serialized), ticketEntrySize)
+			continue
+		}
+
+		ticket, err := deserializeTicketEntry(&hash, serialized)
+		if err != nil {
+			t.Errorf("%s: unexpected deserialize error: %v", test.name,
+				err)
+			continue
+		}
+		if !reflect.DeepEqual(*ticket, test.ticket) {
+			t.Errorf("%s: mismatched ticket - got %+v, want %+v",
+				test.name, *ticket, test.ticket)
+			continue
+		}
+		if status := ticket.Status(); status != test.status {
+			t.Errorf("%s: unexpected status - got %v, want %v",
+				test.name, status, test.status)
+		}
+	}
+
+	// Ensure truncated entries are rejected.
+	_, err := deserializeTicketEntry(&hash, make([]byte, ticketEntrySize-1))
+	if !isDeserializeErr(err) {
+		t.Errorf("unexpected error for truncated entry - got %v, want "+
+			"errDeserialize", err)
+	}
+}
@@ -0,0 +1,239 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package indexers
+
+import (
+	"encoding/binary"
+	"math"
+	"time"
+
+	"github.com/EXCCoin/exccd/blockchain"
+	"github.com/EXCCoin/exccd/chaincfg/chainhash"
+	"github.com/EXCCoin/exccd/database"
+	"github.com/EXCCoin/exccd/exccutil"
+)
+
+const (
+	// timeIndexName is the human-readable name for the index.
+	timeIndexName = "block timestamp index"
+
+	// timeIndexKeySize is the size of the keys stored in the block
+	// timestamp index.  It consists of the 4-byte big-endian header
+	// timestamp followed by the 4-byte big-endian block height.
+	timeIndexKeySize = 8
+)
+
+var (
+	// timeIndexKey is the key of the block timestamp index and the db
+	// bucket used to house it.
+	timeIndexKey = []byte("blocktimeidx")
+)
+
+// -----------------------------------------------------------------------------
+// The block timestamp index consists of an entry for every block in the main
+// chain keyed by the timestamp found in its header along with its height.  The
+// keys are serialized in big endian so the natural ordering of the underlying
+// database yields the entries ordered by time, which in turn allows efficient
+// range queries via a cursor.
+//
+// The serialized format for keys and values in the index is:
+//
+//   <timestamp><height> = <block hash>
+//
+//   Field           Type             Size
+//   timestamp       uint32           4 bytes
+//   height          uint32           4 bytes
+//   block hash      chainhash.Hash   chainhash.HashSize
+// -----------------------------------------------------------------------------
+
+// TimeIndexEntry describes a single block located via the block timestamp
+// index.
+type TimeIndexEntry struct {
+	Hash      chainhash.Hash
+	Height    int64
+	Timestamp time.Time
+}
+
+// timeIndexTimestamp returns the passed time as a timestamp in the block
+// timestamp index.  Times outside of the range of block header timestamps are
+// clamped to it, so range queries with bounds outside of it include all blocks
+// on that side.
+func timeIndexTimestamp(t time.Time) uint32 {
+	unix := t.Unix()
+	switch {
+	case unix < 0:
+		return 0
+	case unix > math.MaxUint32:
+		return math.MaxUint32
+	}
+	return uint32(unix)
+}
+
+// timeIndexEntryKey returns the key used to store the block with the passed
+// timestamp and height in the block timestamp index.
+func timeIndexEntryKey(timestamp time.Time, height uint32) []byte {
+	key := make([]byte, timeIndexKeySize)
+	binary.BigEndian.PutUint32(key[0:4], timeIndexTimestamp(timestamp))
+	binary.BigEndian.PutUint32(key[4:8], height)
+	return key
+}
+
+// TimeIndex implements an index of main chain blocks keyed by the timestamp in
+// their headers.  It allows callers to locate the blocks that were mined during
+// a given time range without having to walk the chain.
+//
+// Note that block timestamps are not required to be strictly increasing, so a
+// range query returns every block whose header timestamp falls within the
+// range, ordered by timestamp and then height.
+type TimeIndex struct {
+	db database.DB
+}
+
+// Ensure the TimeIndex type implements the Indexer interface.
+var _ Indexer = (*TimeIndex)(nil)
+
+// Ensure the TimeIndex type implements the NeedsInputser interface.
+var _ NeedsInputser = (*TimeIndex)(nil)
+
+// NeedsInputs signals that the index does not require the referenced inputs in
+// order to properly create the index.
+//
+// This implements the NeedsInputser interface.
+func (idx *TimeIndex) NeedsInputs() bool {
+	return false
+}
+
+// Init is only provided to satisfy the Indexer interface as there is nothing to
+// initialize for this index.
+//
+// This is part of the Indexer interface.
+func (idx *TimeIndex) Init() error {
+	// Nothing to do.
+	return nil
+}
+
+// Key returns the database key to use for the index as a byte slice.
+//
+// This is part of the Indexer interface.
+func (idx *TimeIndex) Key() []byte {
+	return timeIndexKey
+}
+
+// Name returns the human-readable name of the index.
+//
+// This is part of the Indexer interface.
+func (idx *TimeIndex) Name() string {
+	return timeIndexName
+}
+
+// Create is invoked when the indexer manager determines the index needs
+// to be created for the first time.  It creates the bucket for the block
+// timestamp index.
+//
+// This is part of the Indexer interface.
+func (idx *TimeIndex) Create(dbTx database.Tx) error {
+	_, err := dbTx.Metadata().CreateBucket(timeIndexKey)
+	return err
+}
+
+// ConnectBlock is invoked by the index manager when a new block has been
+// connected to the main chain.  This indexer adds an entry for the block keyed
+// by its header timestamp.
+//
+// This is part of the Indexer interface.
+func (idx *TimeIndex) ConnectBlock(dbTx database.Tx, block, parent *exccutil.Block, view *blockchain.UtxoViewpoint) error {
+	header := &block.MsgBlock().Header
+	key := timeIndexEntryKey(header.Timestamp, header.Height)
+	timeIdxBucket := dbTx.Metadata().Bucket(timeIndexKey)
+	return timeIdxBucket.Put(key, block.Hash()[:])
+}
+
+// DisconnectBlock is invoked by the index manager when a block has been
+// disconnected from the main chain.  This indexer removes the entry for the
+// block.
+//
+// This is part of the Indexer interface.
+func (idx *TimeIndex) DisconnectBlock(dbTx database.Tx, block, parent *exccutil.Block, view *blockchain.UtxoViewpoint) error {
+	header := &block.MsgBlock().Header
+	key := timeIndexEntryKey(header.Timestamp, header.Height)
+	timeIdxBucket := dbTx.Metadata().Bucket(timeIndexKey)
+	return timeIdxBucket.Delete(key)
+}
+
+// BlocksInTimeRange returns the blocks whose header timestamps are within the
+// provided inclusive range, ordered by timestamp and then height.  A maximum of
+// maxEntries are returned when it is greater than zero.
+//
+// This function is safe for concurrent access.
+func (idx *TimeIndex) BlocksInTimeRange(start, end time.Time, maxEntries int) ([]TimeIndexEntry, error) {
+	if end.Before(start) {
+		return nil, nil
+	}
+
+	startKey := timeIndexEntryKey(start, 0)
+	endTimestamp := timeIndexTimestamp(end)
+
+	var entries []TimeIndexEntry
+	err := idx.db.View(func(dbTx database.Tx) error {
+		timeIdxBucket := dbTx.Metadata().Bucket(timeIndexKey)
+		cursor := timeIdxBucket.Cursor()
+		for ok := cursor.Seek(startKey); ok; ok = cursor.Next() {
+			key := cursor.Key()
+			if len(key) != timeIndexKeySize {
+				return errDeserialize("unexpected block timestamp " +
+					"index key length")
+			}
+			timestamp := binary.BigEndian.Uint32(key[0:4])
+			if timestamp > endTimestamp {
+				break
+			}
+
+			value := cursor.Value()
+			if len(value) != chainhash.HashSize {
+				return errDeserialize("unexpected block timestamp " +
+					"index value length")
+			}
+
+			var entry TimeIndexEntry
+			copy(entry.Hash[:], value)
+			entry.Height = int64(binary.BigEndian.Uint32(key[4:8]))
+			entry.Timestamp = time.Unix(int64(timestamp), 0)
+			entries = append(entries, entry)
+
+			if maxEntries > 0 && len(entries) >= maxEntries {
+				break
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// NewTimeIndex returns a new instance of an indexer that is used to create a
+// mapping of block header timestamps to the blocks in the main chain.
+//
+// It implements the Indexer interface which plugs into the IndexManager that in
+// turn is used by the blockchain package.  This allows the index to be
+// seamlessly maintained along with the chain.
+func NewTimeIndex(db database.DB) *TimeIndex {
+	return &TimeIndex{db: db}
+}
+
+// DropTimeIndex drops the block timestamp index from the provided database if
+// it exists.
+func DropTimeIndex(db database.DB, interrupt <-chan struct{}) error {
+	return dropFlatIndex(db, timeIndexKey, timeIndexName, interrupt)
+}
+
+// DropIndex drops the block timestamp index from the provided database if it
+// exists.
+func (*TimeIndex) DropIndex(db database.DB, interrupt <-chan struct{}) error {
+	return DropTimeIndex(db, interrupt)
+}
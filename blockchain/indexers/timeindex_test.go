@@ -0,0 +1,40 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package indexers
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+// TestTimeIndexEntryKey ensures the keys of the block timestamp index are
+// ordered by timestamp and then height, and that times outside of the range of
+// block header timestamps are clamped to it instead of wrapping around.
+func TestTimeIndexEntryKey(t *testing.T) {
+	tests := []struct {
+		name      string
+		timestamp time.Time
+		height    uint32
+		want      []byte
+	}{
+		{"zero", time.Unix(0, 0), 0, []byte{0, 0, 0, 0, 0, 0, 0, 0}},
+		{"timestamp and height", time.Unix(0x5b000000, 0), 0x0102,
+			[]byte{0x5b, 0, 0, 0, 0, 0, 0x01, 0x02}},
+		{"max timestamp", time.Unix(0xffffffff, 0), 1,
+			[]byte{0xff, 0xff, 0xff, 0xff, 0, 0, 0, 1}},
+		{"above max timestamp", time.Unix(9999999999, 0), 1,
+			[]byte{0xff, 0xff, 0xff, 0xff, 0, 0, 0, 1}},
+		{"negative timestamp", time.Unix(-1, 0), 1,
+			[]byte{0, 0, 0, 0, 0, 0, 0, 1}},
+	}
+	for _, test := range tests {
+		got := timeIndexEntryKey(test.timestamp, test.height)
+		if !bytes.Equal(got, test.want) {
+			t.Errorf("%s: unexpected key - got %x, want %x",
+				test.name, got, test.want)
+		}
+	}
+}
@@ -62,4 +62,14 @@ var (
 	// block index which consists of metadata for all known blocks both in
 	// the main chain and on side chains.
 	BlockIndexBucketName = []byte("blockidx")
+
+	// HotStateKeyName is the name of the db key used to store the most
+	// recent part of the block index on a clean shutdown so it can be
+	// loaded in bulk on the next startup.  Its presence also marks the
+	// previous shutdown as clean.
+	HotStateKeyName = []byte("hotstate")
+
+	// UtxoSetStateKeyName is the name of the db key used to store the
+	// incrementally maintained hash and totals of the utxo set.
+	UtxoSetStateKeyName = []byte("utxosetstate")
 )
@@ -0,0 +1,129 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+// Package muhash implements a multiplicative hash of a set of byte strings
+// which may be updated incrementally as elements are added to and removed from
+// the set.
+//
+// Each element is mapped to a number modulo the prime 2^3072 - 1103717 and the
+// hash of the set is the product of the numbers of its elements.  Since
+// multiplication is commutative, the hash does not depend on the order in
+// which elements were added or removed, and removing an element multiplies the
+// hash by the inverse of its number.
+package muhash
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math/big"
+)
+
+// SerializedSize is the size of a serialized hash state.
+const SerializedSize = 384
+
+// prime is the modulus of the group the elements of a set are mapped to.
+var prime = func() *big.Int {
+	p := new(big.Int).Lsh(big.NewInt(1), SerializedSize*8)
+	return p.Sub(p, big.NewInt(1103717))
+}()
+
+// MuHash is the incrementally updatable hash of a set.  The zero value is not
+// valid, so instances must be created with New or Deserialize.
+//
+// Removed elements are accumulated separately from added ones so that only a
+// single modular inversion is needed when the hash is finalized or serialized.
+type MuHash struct {
+	numerator   big.Int
+	denominator big.Int
+}
+
+// New returns the hash of the empty set.
+func New() *MuHash {
+	var h MuHash
+	h.numerator.SetInt64(1)
+	h.denominator.SetInt64(1)
+	return &h
+}
+
+// element maps the passed data to a number modulo the prime by expanding its
+// sha256 hash to the size of the prime and interpreting the result as a little
+// endian number.
+func element(data []byte) *big.Int {
+	seed := sha256.Sum256(data)
+	var expanded [SerializedSize]byte
+	var block [sha256.Size + 4]byte
+	copy(block[:], seed[:])
+	for i := 0; i < SerializedSize/sha256.Size; i++ {
+		binary.LittleEndian.PutUint32(block[sha256.Size:], uint32(i))
+		digest := sha256.Sum256(block[:])
+		copy(expanded[i*sha256.Size:], digest[:])
+	}
+	e := new(big.Int).SetBytes(reverse(expanded[:]))
+	return e.Mod(e, prime)
+}
+
+// reverse reverses the passed bytes in place and returns them.
+func reverse(b []byte) []byte {
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	return b
+}
+
+// Add adds the passed data to the set.
+func (h *MuHash) Add(data []byte) {
+	h.numerator.Mul(&h.numerator, element(data))
+	h.numerator.Mod(&h.numerator, prime)
+}
+
+// Remove removes the passed data from the set.  The data must have been added
+// previously, or the hash will no longer be the hash of a set.
+func (h *MuHash) Remove(data []byte) {
+	h.denominator.Mul(&h.denominator, element(data))
+	h.denominator.Mod(&h.denominator, prime)
+}
+
+// normalize folds the removed elements into the added ones so the denominator
+// is one.
+func (h *MuHash) normalize() {
+	if h.denominator.Cmp(big.NewInt(1)) == 0 {
+		return
+	}
+	inverse := new(big.Int).ModInverse(&h.denominator, prime)
+	h.numerator.Mul(&h.numerator, inverse)
+	h.numerator.Mod(&h.numerator, prime)
+	h.denominator.SetInt64(1)
+}
+
+// Serialize returns the state of the hash as a little endian number of
+// SerializedSize bytes.
+func (h *MuHash) Serialize() []byte {
+	h.normalize()
+	serialized := make([]byte, SerializedSize)
+	b := h.numerator.Bytes()
+	copy(serialized[SerializedSize-len(b):], b)
+	return reverse(serialized)
+}
+
+// Deserialize returns the hash with the passed serialized state.
+func Deserialize(serialized []byte) (*MuHash, error) {
+	if len(serialized) != SerializedSize {
+		return nil, errors.New("unexpected serialized muhash size")
+	}
+	b := reverse(append([]byte(nil), serialized...))
+	var h MuHash
+	h.numerator.SetBytes(b)
+	if h.numerator.Sign() == 0 || h.numerator.Cmp(prime) >= 0 {
+		return nil, errors.New("serialized muhash is out of range")
+	}
+	h.denominator.SetInt64(1)
+	return &h, nil
+}
+
+// Finalize returns the sha256 hash of the serialized state, which commits to
+// the contents of the set.
+func (h *MuHash) Finalize() [sha256.Size]byte {
+	return sha256.Sum256(h.Serialize())
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package muhash
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestMuHash ensures the hash of a set does not depend on the order in which
+// its elements were added and removed, and that it survives serialization.
+func TestMuHash(t *testing.T) {
+	a, b, c := []byte("a"), []byte("b"), []byte("c")
+
+	empty := New().Finalize()
+
+	h1 := New()
+	h1.Add(a)
+	h1.Add(b)
+
+	h2 := New()
+	h2.Add(c)
+	h2.Add(b)
+	h2.Remove(c)
+	h2.Add(a)
+	if h1.Finalize() != h2.Finalize() {
+		t.Fatal("hash depends on the order of updates")
+	}
+
+	h3 := New()
+	h3.Add(a)
+	h3.Add(c)
+	if h1.Finalize() == h3.Finalize() {
+		t.Fatal("hashes of different sets match")
+	}
+
+	// Removing every element results in the hash of the empty set.
+	h2.Remove(a)
+	h2.Remove(b)
+	if h2.Finalize() != empty {
+		t.Fatal("hash of emptied set does not match the empty set")
+	}
+
+	// Removing elements before adding them is allowed as long as they are
+	// eventually added.
+	h4 := New()
+	h4.Remove(c)
+	h4.Add(a)
+	h4.Add(b)
+	h4.Add(c)
+	if h4.Finalize() != h1.Finalize() {
+		t.Fatal("hash depends on removing before adding")
+	}
+
+	serialized := h1.Serialize()
+	if len(serialized) != SerializedSize {
+		t.Fatalf("serialized size is %d, want %d", len(serialized),
+			SerializedSize)
+	}
+	h5, err := Deserialize(serialized)
+	if err != nil {
+		t.Fatalf("unable to deserialize: %v", err)
+	}
+	if !bytes.Equal(h5.Serialize(), serialized) {
+		t.Fatal("serialization does not round trip")
+	}
+	h5.Remove(b)
+	h1.Remove(b)
+	if h5.Finalize() != h1.Finalize() {
+		t.Fatal("deserialized hash does not update like the original")
+	}
+
+	if _, err := Deserialize(serialized[1:]); err == nil {
+		t.Fatal("deserialized short state")
+	}
+	if _, err := Deserialize(make([]byte, SerializedSize)); err == nil {
+		t.Fatal("deserialized zero state")
+	}
+}
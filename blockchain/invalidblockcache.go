@@ -0,0 +1,157 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"container/list"
+
+	"github.com/EXCCoin/exccd/chaincfg/chainhash"
+	"github.com/EXCCoin/exccd/exccutil"
+)
+
+// maxInvalidBlocks is the maximum number of blocks which failed validation
+// that are remembered in order to reject them immediately when they are
+// processed again.
+const maxInvalidBlocks = 1000
+
+// invalidBlock houses the hash of a block which failed validation along with
+// the rule error describing why.
+type invalidBlock struct {
+	hash   chainhash.Hash
+	reason RuleError
+}
+
+// invalidBlockCache remembers the hashes of blocks which failed validation
+// along with the reason, so the same bad block relayed by several peers is
+// rejected immediately without running the expensive equihash and script
+// checks again.  It is limited to a maximum number of blocks with eviction of
+// the least recently seen block when the limit is exceeded.
+//
+// Blocks which are not part of the block index are not otherwise remembered
+// once they fail validation, so the cache only needs to cover those.
+type invalidBlockCache struct {
+	blocks map[chainhash.Hash]*list.Element // nearly O(1) lookups
+	lru    *list.List                       // O(1) insert, update, delete
+	limit  int
+}
+
+// Lookup returns the reason the block with the passed hash failed validation
+// and whether it is in the cache.  Looking up a block makes it the most
+// recently seen block.
+//
+// This function is NOT safe for concurrent access.
+func (c *invalidBlockCache) Lookup(hash *chainhash.Hash) (RuleError, bool) {
+	node, exists := c.blocks[*hash]
+	if !exists {
+		return RuleError{}, false
+	}
+	c.lru.MoveToFront(node)
+	return node.Value.(*invalidBlock).reason, true
+}
+
+// Add adds the passed block hash along with the reason it failed validation to
+// the cache and handles eviction of the least recently seen block if adding it
+// would exceed the max limit.
+//
+// This function is NOT safe for concurrent access.
+func (c *invalidBlockCache) Add(hash *chainhash.Hash, reason RuleError) {
+	if node, exists := c.blocks[*hash]; exists {
+		node.Value.(*invalidBlock).reason = reason
+		c.lru.MoveToFront(node)
+		return
+	}
+
+	// Evict the least recently seen block (back of the list) if the new
+	// block would exceed the size limit for the cache.
+	if len(c.blocks)+1 > c.limit {
+		node := c.lru.Back()
+		if node == nil {
+			return
+		}
+		delete(c.blocks, node.Value.(*invalidBlock).hash)
+		c.lru.Remove(node)
+	}
+
+	c.blocks[*hash] = c.lru.PushFront(&invalidBlock{hash: *hash, reason: reason})
+}
+
+// Len returns the number of blocks in the cache.
+//
+// This function is NOT safe for concurrent access.
+func (c *invalidBlockCache) Len() int {
+	return len(c.blocks)
+}
+
+// newInvalidBlockCache returns a new invalid block cache that is limited to the
+// number of blocks specified by limit.
+func newInvalidBlockCache(limit int) *invalidBlockCache {
+	return &invalidBlockCache{
+		blocks: make(map[chainhash.Hash]*list.Element),
+		lru:    list.New(),
+		limit:  limit,
+	}
+}
+
+// merkleTreeMutated returns whether the passed merkle tree store, as returned
+// by BuildMerkleTreeStore, has two identical sibling nodes.
+//
+// Since the parent of a node without a sibling is the hash of the node with
+// itself, duplicating the last transaction of a block with an odd number of
+// transactions, or the last subtree at any level, results in the same merkle
+// root (CVE-2012-2459).  Transactions are unique within valid blocks, so a tree
+// with identical siblings never commits to a valid block, but it may commit to
+// the same root as a valid block.
+func merkleTreeMutated(merkles []*chainhash.Hash) bool {
+	for i := 0; i < len(merkles)-1; i += 2 {
+		if merkles[i] != nil && merkles[i+1] != nil &&
+			*merkles[i] == *merkles[i+1] {
+			return true
+		}
+	}
+	return false
+}
+
+// isCacheableInvalidBlockErr returns whether the passed error from processing
+// the passed block shows the block, as identified by its hash, is invalid
+// regardless of when or from whom it is received, so it may be remembered as
+// invalid.
+//
+// Errors which are not rule violations, such as database errors, and rule
+// violations which may no longer apply later, such as a timestamp too far in
+// the future, are not cacheable.  Since the block hash only commits to the
+// transactions via the merkle roots in the header, a block whose transactions
+// do not match them, or whose merkle trees have duplicated transactions or
+// subtrees committing to the same roots as a different list of transactions,
+// may have been mutated in transit, so it is not cacheable either to avoid
+// rejecting the valid block with the same hash.  The sanity checks of the
+// transaction lists which such a mutation may fail are never cacheable for
+// the same reason.
+func isCacheableInvalidBlockErr(block *exccutil.Block, err error) bool {
+	rErr, ok := err.(RuleError)
+	if !ok {
+		return false
+	}
+	switch rErr.ErrorCode {
+	case ErrDuplicateBlock, ErrMissingParent, ErrTimeTooNew,
+		ErrBadMerkleRoot, ErrKnownInvalidBlock:
+		return false
+
+	case ErrDuplicateTx, ErrBlockTooBig, ErrWrongBlockSize,
+		ErrMultipleCoinbases, ErrTooManyRevocations,
+		ErrFreshStakeMismatch, ErrVotesMismatch,
+		ErrRevocationsMismatch, ErrTooManySigOps:
+		return false
+	}
+
+	header := &block.MsgBlock().Header
+	merkles := BuildMerkleTreeStore(block.Transactions())
+	if !header.MerkleRoot.IsEqual(merkles[len(merkles)-1]) ||
+		merkleTreeMutated(merkles) {
+		return false
+	}
+	merkleStake := BuildMerkleTreeStore(block.STransactions())
+	return header.StakeRoot.IsEqual(merkleStake[len(merkleStake)-1]) &&
+		!merkleTreeMutated(merkleStake)
+}
@@ -0,0 +1,253 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/EXCCoin/exccd/blockchain/chaingen"
+	"github.com/EXCCoin/exccd/chaincfg"
+	"github.com/EXCCoin/exccd/chaincfg/chainhash"
+	"github.com/EXCCoin/exccd/exccutil"
+	"github.com/EXCCoin/exccd/wire"
+)
+
+// TestInvalidBlockCache ensures the invalid block cache remembers the reason
+// blocks failed validation and evicts the least recently seen blocks once its
+// limit is reached.
+func TestInvalidBlockCache(t *testing.T) {
+	hashes := make([]chainhash.Hash, 4)
+	reasons := make([]RuleError, 4)
+	for i := range hashes {
+		hashes[i][0] = byte(i)
+		reasons[i] = ruleError(ErrInvalidEquihashSolution,
+			fmt.Sprintf("reason %d", i))
+	}
+
+	c := newInvalidBlockCache(3)
+	for i := range hashes[:3] {
+		c.Add(&hashes[i], reasons[i])
+	}
+
+	// Looking up the first block makes the second one the least recently
+	// seen, so it is evicted when the fourth block is added.
+	if reason, ok := c.Lookup(&hashes[0]); !ok || reason != reasons[0] {
+		t.Fatalf("unexpected lookup result for first block: %v, %v",
+			reason, ok)
+	}
+	c.Add(&hashes[3], reasons[3])
+	if c.Len() != 3 {
+		t.Fatalf("unexpected number of cached blocks %d", c.Len())
+	}
+	if _, ok := c.Lookup(&hashes[1]); ok {
+		t.Fatal("least recently seen block was not evicted")
+	}
+	for _, i := range []int{0, 2, 3} {
+		if reason, ok := c.Lookup(&hashes[i]); !ok || reason != reasons[i] {
+			t.Fatalf("unexpected lookup result for block %d: %v, %v",
+				i, reason, ok)
+		}
+	}
+
+	// Adding a block again updates the reason.
+	c.Add(&hashes[0], reasons[1])
+	if reason, _ := c.Lookup(&hashes[0]); reason != reasons[1] {
+		t.Fatalf("reason was not updated: got %v, want %v", reason,
+			reasons[1])
+	}
+
+	// Nothing is cached when the limit is zero.
+	c = newInvalidBlockCache(0)
+	c.Add(&hashes[0], reasons[0])
+	if c.Len() != 0 {
+		t.Fatalf("unexpected number of cached blocks %d", c.Len())
+	}
+}
+
+// TestIsCacheableInvalidBlockErr ensures only errors which show a block is
+// invalid regardless of when or from whom it is received are cacheable.
+func TestIsCacheableInvalidBlockErr(t *testing.T) {
+	// Create a block with a single transaction whose header commits to it.
+	msgTx := wire.NewMsgTx()
+	msgTx.AddTxOut(wire.NewTxOut(1, nil))
+	msgBlock := &wire.MsgBlock{Transactions: []*wire.MsgTx{msgTx}}
+	merkles := BuildMerkleTreeStore(exccutil.NewBlock(msgBlock).Transactions())
+	msgBlock.Header.MerkleRoot = *merkles[len(merkles)-1]
+	block := exccutil.NewBlock(msgBlock)
+
+	// Create a block with the same header whose transaction was mutated.
+	mutatedTx := msgTx.Copy()
+	mutatedTx.TxOut[0].Value = 2
+	mutatedBlock := exccutil.NewBlock(&wire.MsgBlock{
+		Header:       msgBlock.Header,
+		Transactions: []*wire.MsgTx{mutatedTx},
+	})
+
+	// Create a block with three transactions whose header commits to them
+	// and a block with the same header which duplicates the last one, so
+	// both blocks have the same merkle root and hash.
+	var honestTxns []*wire.MsgTx
+	for i := int64(0); i < 3; i++ {
+		tx := wire.NewMsgTx()
+		tx.AddTxOut(wire.NewTxOut(i, nil))
+		honestTxns = append(honestTxns, tx)
+	}
+	honestMsgBlock := &wire.MsgBlock{Transactions: honestTxns}
+	merkles = BuildMerkleTreeStore(exccutil.NewBlock(honestMsgBlock).Transactions())
+	honestMsgBlock.Header.MerkleRoot = *merkles[len(merkles)-1]
+	honestBlock := exccutil.NewBlock(honestMsgBlock)
+	duplicatedBlock := exccutil.NewBlock(&wire.MsgBlock{
+		Header:       honestMsgBlock.Header,
+		Transactions: append(honestTxns[:3:3], honestTxns[2]),
+	})
+
+	tests := []struct {
+		name  string
+		block *exccutil.Block
+		err   error
+		want  bool
+	}{{
+		name:  "invalid equihash solution",
+		block: block,
+		err:   ruleError(ErrInvalidEquihashSolution, ""),
+		want:  true,
+	}, {
+		name:  "bad transaction",
+		block: block,
+		err:   ruleError(ErrBadTxOutValue, ""),
+		want:  true,
+	}, {
+		name:  "not a rule error",
+		block: block,
+		err:   errors.New("database error"),
+		want:  false,
+	}, {
+		name:  "time too new",
+		block: block,
+		err:   ruleError(ErrTimeTooNew, ""),
+		want:  false,
+	}, {
+		name:  "missing parent",
+		block: block,
+		err:   ruleError(ErrMissingParent, ""),
+		want:  false,
+	}, {
+		name:  "bad merkle root",
+		block: mutatedBlock,
+		err:   ruleError(ErrBadMerkleRoot, ""),
+		want:  false,
+	}, {
+		name:  "mutated transaction",
+		block: mutatedBlock,
+		err:   ruleError(ErrBadTxOutValue, ""),
+		want:  false,
+	}, {
+		name:  "bad transaction with odd number of transactions",
+		block: honestBlock,
+		err:   ruleError(ErrBadTxOutValue, ""),
+		want:  true,
+	}, {
+		name:  "duplicated transaction",
+		block: duplicatedBlock,
+		err:   ruleError(ErrDuplicateTx, ""),
+		want:  false,
+	}, {
+		name:  "wrong block size",
+		block: block,
+		err:   ruleError(ErrWrongBlockSize, ""),
+		want:  false,
+	}, {
+		name:  "fresh stake mismatch",
+		block: block,
+		err:   ruleError(ErrFreshStakeMismatch, ""),
+		want:  false,
+	}, {
+		name:  "bad transaction with duplicated transaction",
+		block: duplicatedBlock,
+		err:   ruleError(ErrBadTxOutValue, ""),
+		want:  false,
+	}}
+
+	for _, test := range tests {
+		got := isCacheableInvalidBlockErr(test.block, test.err)
+		if got != test.want {
+			t.Errorf("%s: unexpected result: got %v, want %v",
+				test.name, got, test.want)
+		}
+	}
+}
+
+// TestInvalidBlockCacheMutatedBlock ensures a valid block is still accepted
+// after a copy of it whose transactions were mutated without changing its hash
+// by duplicating the last stake transaction is rejected.
+func TestInvalidBlockCacheMutatedBlock(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+
+	params := &chaincfg.SimNetParams
+
+	// Create a new database and chain instance to run tests against.
+	chain, teardownFunc, err := chainSetup("invalidblockcachetest", params)
+	if err != nil {
+		t.Fatalf("Failed to setup chain instance: %v", err)
+	}
+	defer teardownFunc()
+
+	// Create a test generator instance initialized with the genesis block
+	// as the tip.
+	g, err := chaingen.MakeGenerator(params, chain)
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+
+	// accepted expects the passed block to be accepted to the main chain.
+	accepted := func(msgBlock *wire.MsgBlock) {
+		block := exccutil.NewBlock(msgBlock)
+		isMainChain, _, err := chain.ProcessBlock(block, BFNone)
+		if err != nil {
+			t.Fatalf("block %s (height %d) should have been "+
+				"accepted: %v", block.Hash(),
+				msgBlock.Header.Height, err)
+		}
+		if !isMainChain {
+			t.Fatalf("block %s (height %d) is not in the main chain",
+				block.Hash(), msgBlock.Header.Height)
+		}
+	}
+
+	// Generate enough blocks to have mature coinbase outputs to purchase
+	// tickets with.
+	g.CreatePremineBlock("bp", 0)
+	accepted(g.Tip())
+	for i := uint16(0); i < params.CoinbaseMaturity; i++ {
+		g.NextBlock(fmt.Sprintf("bm%d", i), nil, nil)
+		g.SaveTipCoinbaseOuts()
+		accepted(g.Tip())
+	}
+
+	// Create a block with an odd number of stake transactions along with a
+	// copy of it which duplicates the last one, so it has the same hash.
+	outs := g.OldestCoinbaseOuts()
+	honest := g.NextBlock("b0", nil, outs[1:4])
+	mutated := &wire.MsgBlock{
+		Header:        honest.Header,
+		Transactions:  honest.Transactions,
+		STransactions: append(honest.STransactions[:3:3], honest.STransactions[2]),
+	}
+	if mutated.BlockHash() != honest.BlockHash() {
+		t.Fatal("mutated block does not have the same hash")
+	}
+
+	// The mutated block is rejected, but the honest block is still
+	// accepted afterwards.
+	_, _, err = chain.ProcessBlock(exccutil.NewBlock(mutated), BFNone)
+	if err == nil {
+		t.Fatal("mutated block was accepted")
+	}
+	accepted(honest)
+}
@@ -77,10 +77,11 @@ type BlockAcceptedNtfnsData struct {
 // ReorganizationNtfnsData is the structure for data indicating information
 // about a reorganization.
 type ReorganizationNtfnsData struct {
-	OldHash   chainhash.Hash
-	OldHeight int64
-	NewHash   chainhash.Hash
-	NewHeight int64
+	OldHash    chainhash.Hash
+	OldHeight  int64
+	NewHash    chainhash.Hash
+	NewHeight  int64
+	ForkHeight int64
 }
 
 // TicketNotificationsData is the structure for new/spent/missed ticket
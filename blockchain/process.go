@@ -13,6 +13,7 @@ import (
 	"github.com/EXCCoin/exccd/chaincfg/chainhash"
 	"github.com/EXCCoin/exccd/database"
 	"github.com/EXCCoin/exccd/exccutil"
+	"github.com/EXCCoin/exccd/tracing"
 )
 
 // BehaviorFlags is a bitmask defining tweaks to the normal behavior when
@@ -130,6 +131,19 @@ func (b *BlockChain) processOrphans(hash *chainhash.Hash, flags BehaviorFlags) e
 	return nil
 }
 
+// rememberInvalidBlock adds the passed block to the cache of blocks known to be
+// invalid when the passed error from processing it shows it is invalid
+// regardless of when or from whom it is received.
+//
+// This function MUST be called with the chain state lock held (for writes).
+func (b *BlockChain) rememberInvalidBlock(block *exccutil.Block, err error) {
+	if !isCacheableInvalidBlockErr(block, err) {
+		return
+	}
+	log.Debugf("Remembering invalid block %v: %v", block.Hash(), err)
+	b.invalidBlocks.Add(block.Hash(), err.(RuleError))
+}
+
 // ProcessBlock is the main workhorse for handling insertion of new blocks into
 // the block chain.  It includes functionality such as rejecting duplicate
 // blocks, ensuring blocks follow all rules, orphan handling, and insertion into
@@ -139,8 +153,11 @@ func (b *BlockChain) processOrphans(hash *chainhash.Hash, flags BehaviorFlags) e
 // whether or not the block is on the main chain and the second indicates
 // whether or not the block is an orphan.
 //
+// The processing of the block is recorded as a span with child spans for its
+// stages when tracing is enabled.
+//
 // This function is safe for concurrent access.
-func (b *BlockChain) ProcessBlock(block *exccutil.Block, flags BehaviorFlags) (bool, bool, error) {
+func (b *BlockChain) ProcessBlock(block *exccutil.Block, flags BehaviorFlags) (isMainChain, isOrphan bool, err error) {
 	b.chainLock.Lock()
 	defer b.chainLock.Unlock()
 
@@ -149,12 +166,28 @@ func (b *BlockChain) ProcessBlock(block *exccutil.Block, flags BehaviorFlags) (b
 	blockHash := block.Hash()
 	log.Tracef("Processing block %v", blockHash)
 	currentTime := time.Now()
+	span := tracing.Start("blockchain.ProcessBlock")
+	span.SetAttribute("block.hash", blockHash.String())
+	span.SetAttribute("block.height", block.Height())
+	span.SetAttribute("block.transactions",
+		len(block.MsgBlock().Transactions))
 	defer func() {
 		elapsedTime := time.Since(currentTime)
 		log.Debugf("Block %v (height %v) finished processing in %s",
 			blockHash, block.Height(), elapsedTime)
+		span.SetAttribute("block.mainchain", isMainChain)
+		span.SetAttribute("block.orphan", isOrphan)
+		span.SetError(err)
+		span.End()
 	}()
 
+	// Reject the block immediately when it is already known to be invalid.
+	if reason, ok := b.invalidBlocks.Lookup(blockHash); ok {
+		str := fmt.Sprintf("block %v is known to be invalid: %v",
+			blockHash, reason)
+		return false, false, ruleError(ErrKnownInvalidBlock, str)
+	}
+
 	// The block must not already exist in the main chain or side chains.
 	exists, err := b.blockExists(blockHash)
 	if err != nil {
@@ -172,8 +205,12 @@ func (b *BlockChain) ProcessBlock(block *exccutil.Block, flags BehaviorFlags) (b
 	}
 
 	// Perform preliminary sanity checks on the block and its transactions.
+	sanitySpan := span.StartChild("blockchain.checkBlockSanity")
 	err = checkBlockSanity(block, b.timeSource, flags, b.chainParams)
+	sanitySpan.SetError(err)
+	sanitySpan.End()
 	if err != nil {
+		b.rememberInvalidBlock(block, err)
 		return false, false, err
 	}
 
@@ -196,7 +233,9 @@ func (b *BlockChain) ProcessBlock(block *exccutil.Block, flags BehaviorFlags) (b
 			str := fmt.Sprintf("block %v has timestamp %v before "+
 				"last checkpoint timestamp %v", blockHash,
 				blockHeader.Timestamp, checkpointTime)
-			return false, false, ruleError(ErrCheckpointTimeTooOld, str)
+			err := ruleError(ErrCheckpointTimeTooOld, str)
+			b.rememberInvalidBlock(block, err)
+			return false, false, err
 		}
 
 		if !fastAdd {
@@ -214,7 +253,9 @@ func (b *BlockChain) ProcessBlock(block *exccutil.Block, flags BehaviorFlags) (b
 				str := fmt.Sprintf("block target difficulty of %064x "+
 					"is too low when compared to the previous "+
 					"checkpoint", currentTarget)
-				return false, false, ruleError(ErrDifficultyTooLow, str)
+				err := ruleError(ErrDifficultyTooLow, str)
+				b.rememberInvalidBlock(block, err)
+				return false, false, err
 			}
 		}
 	}
@@ -235,15 +276,22 @@ func (b *BlockChain) ProcessBlock(block *exccutil.Block, flags BehaviorFlags) (b
 
 	// The block has passed all context independent checks and appears sane
 	// enough to potentially accept it into the block chain.
-	isMainChain, err := b.maybeAcceptBlock(block, flags)
+	acceptSpan := span.StartChild("blockchain.maybeAcceptBlock")
+	isMainChain, err = b.maybeAcceptBlock(block, flags)
+	acceptSpan.SetError(err)
+	acceptSpan.End()
 	if err != nil {
+		b.rememberInvalidBlock(block, err)
 		return false, false, err
 	}
 
 	// Accept any orphan blocks that depend on this block (they are no
 	// longer orphans) and repeat for those accepted blocks until there are
 	// no more.
+	orphansSpan := span.StartChild("blockchain.processOrphans")
 	err = b.processOrphans(blockHash, flags)
+	orphansSpan.SetError(err)
+	orphansSpan.End()
 	if err != nil {
 		return false, false, err
 	}
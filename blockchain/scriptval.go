@@ -33,6 +33,7 @@ type txValidator struct {
 	utxoView     *UtxoViewpoint
 	flags        txscript.ScriptFlags
 	sigCache     *txscript.SigCache
+	batch        *txscript.BatchVerifier
 }
 
 // sendResult sends the result of a script pair validation on the internal
@@ -100,6 +101,9 @@ out:
 				v.sendResult(err)
 				break out
 			}
+			if v.batch != nil {
+				vm.SetBatchVerifier(v.batch)
+			}
 
 			// Execute the script pair.
 			if err := vm.Execute(); err != nil {
@@ -182,14 +186,18 @@ func (v *txValidator) Validate(items []*txValidateItem) error {
 }
 
 // newTxValidator returns a new instance of txValidator to be used for
-// validating transaction scripts asynchronously.
-func newTxValidator(utxoView *UtxoViewpoint, flags txscript.ScriptFlags, sigCache *txscript.SigCache) *txValidator {
+// validating transaction scripts asynchronously.  When a batch verifier is
+// provided, the OP_CHECKSIG and OP_CHECKSIGALT signature checks of all scripts
+// are deferred to it, so the validation result is only meaningful when it
+// subsequently verifies all of them.
+func newTxValidator(utxoView *UtxoViewpoint, flags txscript.ScriptFlags, sigCache *txscript.SigCache, batch *txscript.BatchVerifier) *txValidator {
 	return &txValidator{
 		validateChan: make(chan *txValidateItem),
 		quitChan:     make(chan struct{}),
 		resultChan:   make(chan error),
 		utxoView:     utxoView,
 		sigCache:     sigCache,
+		batch:        batch,
 		flags:        flags,
 	}
 }
@@ -216,7 +224,7 @@ func ValidateTransactionScripts(tx *exccutil.Tx, utxoView *UtxoViewpoint, flags
 	}
 
 	// Validate all of the inputs.
-	return newTxValidator(utxoView, flags, sigCache).Validate(txValItems)
+	return newTxValidator(utxoView, flags, sigCache, nil).Validate(txValItems)
 
 }
 
@@ -258,6 +266,22 @@ func checkBlockScripts(block *exccutil.Block, utxoView *UtxoViewpoint, txTree bo
 		}
 	}
 
-	// Validate all of the inputs.
-	return newTxValidator(utxoView, scriptFlags, sigCache).Validate(txValItems)
+	// Validate all of the inputs while deferring their signature checks so
+	// the signatures of the entire block can be verified in batches.
+	batch := txscript.NewBatchVerifier(sigCache)
+	err := newTxValidator(utxoView, scriptFlags, sigCache, batch).
+		Validate(txValItems)
+	if err == nil && batch.Verify() {
+		return nil
+	}
+
+	// Either a script failed or at least one signature is invalid, so fall
+	// back to validating all of the inputs with individual signature checks
+	// in order to determine the actual result.  This is required since the
+	// deferred signature checks were assumed to be valid, which might cause
+	// scripts that depend on a signature check failing to fail as well.
+	log.Debugf("Batch script validation of block %v failed, falling back "+
+		"to individual signature checks", block.Hash())
+	return newTxValidator(utxoView, scriptFlags, sigCache, nil).
+		Validate(txValItems)
 }
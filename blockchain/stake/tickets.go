@@ -819,6 +819,14 @@ func (sn *Node) DisconnectNode(parentLotteryIV chainhash.Hash, parentUtds UndoTi
 	return disconnectNode(sn, parentLotteryIV, parentUtds, parentTickets, dbTx)
 }
 
+// FetchBlockUndoData returns the ticket undo data stored in the database for
+// the main chain block at the provided height.  The undo data describes every
+// ticket which changed state as a result of connecting the block, and it is
+// overwritten when a block at the same height is connected during a reorg.
+func FetchBlockUndoData(dbTx database.Tx, height uint32) (UndoTicketDataSlice, error) {
+	return ticketdb.DbFetchBlockUndoData(dbTx, height)
+}
+
 // WriteConnectedBestNode writes the newly connected best node to the database
 // under an atomic database transaction, performing all the necessary writes to
 // the database buckets for live, missed, and revoked tickets.
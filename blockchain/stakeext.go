@@ -7,6 +7,9 @@
 package blockchain
 
 import (
+	"fmt"
+
+	"github.com/EXCCoin/exccd/blockchain/stake"
 	"github.com/EXCCoin/exccd/chaincfg/chainhash"
 	"github.com/EXCCoin/exccd/database"
 	"github.com/EXCCoin/exccd/exccutil"
@@ -247,3 +250,163 @@ func (b *BlockChain) TicketPoolValue() (exccutil.Amount, error) {
 	}
 	return exccutil.Amount(amt), nil
 }
+
+// TicketPoolStats houses the state of the ticket pool and the coin supply as
+// of a given block in the main chain.
+type TicketPoolStats struct {
+	Hash       chainhash.Hash
+	Height     int64
+	PoolSize   uint32
+	PoolValue  exccutil.Amount
+	CoinSupply exccutil.Amount
+}
+
+// TicketPoolStatsHistory returns the state of the ticket pool for the provided
+// number of most recent blocks in the main chain, starting with the current
+// best block and sampling every interval blocks.  The returned statistics are
+// ordered from the newest to the oldest block.
+//
+// Rather than requiring the state of every sampled block to be stored, the
+// state is calculated by starting from the current live ticket pool and
+// reversing the ticket changes recorded in the stake undo data of each block.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) TicketPoolStatsHistory(numBlocks, interval int64) ([]TicketPoolStats, error) {
+	if numBlocks < 1 || interval < 1 {
+		return nil, nil
+	}
+
+	b.chainLock.RLock()
+	defer b.chainLock.RUnlock()
+
+	tip := b.bestNode
+	sn := tip.stakeNode
+	b.stateLock.RLock()
+	supply := b.stateSnapshot.TotalSubsidy
+	b.stateLock.RUnlock()
+
+	stopHeight := tip.height - numBlocks + 1
+	if stopHeight < 0 {
+		stopHeight = 0
+	}
+
+	stats := make([]TicketPoolStats, 0, (tip.height-stopHeight)/interval+1)
+	err := b.db.View(func(dbTx database.Tx) error {
+		// The amounts of the tickets purchased in a given block are cached
+		// since the tickets of a block typically all mature together.
+		purchases := make(map[int64]map[chainhash.Hash]int64)
+		ticketAmount := func(hash *chainhash.Hash, purchaseHeight int64) (int64, error) {
+			amounts, ok := purchases[purchaseHeight]
+			if !ok {
+				block, err := dbFetchBlockByHeight(dbTx, purchaseHeight)
+				if err != nil {
+					return 0, err
+				}
+				amounts = make(map[chainhash.Hash]int64)
+				for _, stx := range block.MsgBlock().STransactions {
+					if stake.IsSStx(stx) {
+						amounts[stx.TxHash()] = stx.TxOut[0].Value
+					}
+				}
+				purchases[purchaseHeight] = amounts
+			}
+			amount, ok := amounts[*hash]
+			if !ok {
+				return 0, AssertError(fmt.Sprintf("ticket %v is not "+
+					"in block %d", hash, purchaseHeight))
+			}
+			return amount, nil
+		}
+
+		// Start with the value of the current live ticket pool.
+		var poolValue int64
+		for _, hash := range sn.LiveTickets() {
+			utxo, err := dbFetchUtxoEntry(dbTx, &hash)
+			if err != nil {
+				return err
+			}
+			if utxo == nil {
+				return AssertError(fmt.Sprintf("live ticket %v is "+
+					"missing from the utxo set", hash))
+			}
+			poolValue += utxo.sparseOutputs[0].amount
+		}
+		poolSize := int64(sn.PoolSize())
+
+		block, err := dbFetchBlockByHeight(dbTx, tip.height)
+		if err != nil {
+			return err
+		}
+		maturity := int64(b.chainParams.TicketMaturity)
+		for height := tip.height; height >= stopHeight; height-- {
+			if (tip.height-height)%interval == 0 {
+				stats = append(stats, TicketPoolStats{
+					Hash:       *block.Hash(),
+					Height:     height,
+					PoolSize:   uint32(poolSize),
+					PoolValue:  exccutil.Amount(poolValue),
+					CoinSupply: exccutil.Amount(supply),
+				})
+			}
+			if height == stopHeight {
+				break
+			}
+
+			// Reverse the changes the block made to the ticket pool.
+			// Tickets only enter the pool once staking is enabled.
+			if height >= b.chainParams.StakeEnabledHeight {
+				undoData, err := stake.FetchBlockUndoData(dbTx,
+					uint32(height))
+				if err != nil {
+					return err
+				}
+				for _, undo := range undoData {
+					switch {
+					// The ticket was missed and revoked, so it
+					// already left the pool in an earlier block.
+					case undo.Missed && undo.Revoked:
+						continue
+
+					// All flags are unset; the ticket matured
+					// and was added to the pool.
+					case !undo.Missed && !undo.Spent:
+						purchaseHeight := int64(undo.TicketHeight) -
+							maturity
+						amount, err := ticketAmount(&undo.TicketHash,
+							purchaseHeight)
+						if err != nil {
+							return err
+						}
+						poolValue -= amount
+						poolSize--
+
+					// The ticket voted, was missed, or expired and
+					// left the pool.
+					default:
+						purchaseHeight := int64(undo.TicketHeight) -
+							maturity
+						amount, err := ticketAmount(&undo.TicketHash,
+							purchaseHeight)
+						if err != nil {
+							return err
+						}
+						poolValue += amount
+						poolSize++
+					}
+				}
+			}
+
+			parent, err := dbFetchBlockByHeight(dbTx, height-1)
+			if err != nil {
+				return err
+			}
+			supply -= CalculateAddedSubsidy(block, parent)
+			block = parent
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
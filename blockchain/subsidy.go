@@ -167,6 +167,113 @@ func CalcStakeVoteSubsidy(subsidyCache *SubsidyCache, height int64, params *chai
 	return subsidy
 }
 
+// maxEmissionIntervals is the maximum number of subsidy reduction intervals
+// returned by EmissionSchedule.  It exceeds the number of intervals until the
+// subsidy of all standard networks is exhausted many times over.
+const maxEmissionIntervals = 10000
+
+// EmissionInterval describes the subsidy paid by the blocks of a subsidy
+// reduction interval assuming every block is approved and includes all of its
+// votes.
+type EmissionInterval struct {
+	// StartHeight and EndHeight are the first and last heights of the
+	// interval which are covered, inclusive.
+	StartHeight int64
+	EndHeight   int64
+
+	// WorkSubsidy is the proof of work subsidy of each block, VoteSubsidy is
+	// the subsidy of each vote, and BlockSubsidy is the total subsidy of a
+	// block once votes are required.
+	WorkSubsidy  int64
+	VoteSubsidy  int64
+	BlockSubsidy int64
+
+	// Emission is the total subsidy paid by the blocks of the interval.
+	Emission int64
+}
+
+// EmissionSchedule returns the subsidy reduction intervals covering the
+// heights starting at the provided height until the subsidy is exhausted.  The
+// first interval starts at the provided height rather than at the start of the
+// subsidy reduction interval containing it so that the schedule projects the
+// emission of future blocks.  At most maxIntervals intervals are returned
+// unless it is zero, in which case the intervals up to the end of the emission
+// are returned.
+//
+// The schedule also ends once the subsidy stops decreasing, which only happens
+// for networks whose subsidy is never exhausted, and it never has more than
+// maxEmissionIntervals intervals.
+//
+// Since blocks which do not include all of their votes pay less subsidy, the
+// emission is an upper bound of the actual emission of the intervals.
+//
+// Safe for concurrent access.
+func (s *SubsidyCache) EmissionSchedule(height int64, maxIntervals int) []EmissionInterval {
+	params := s.params
+	if height < 0 {
+		height = 0
+	}
+
+	var intervals []EmissionInterval
+	interval := params.SubsidyReductionInterval
+	if maxIntervals <= 0 || maxIntervals > maxEmissionIntervals {
+		maxIntervals = maxEmissionIntervals
+	}
+	for start := height; len(intervals) < maxIntervals; {
+		end := (start/interval+1)*interval - 1
+
+		// The subsidy of block one is special, so the subsidy of the
+		// regular blocks of the first interval is calculated from the
+		// height after it.
+		subsidyHeight := start
+		if subsidyHeight < 2 {
+			subsidyHeight = 2
+		}
+		work := CalcBlockWorkSubsidy(s, subsidyHeight,
+			params.TicketsPerBlock, params)
+		vote := CalcStakeVoteSubsidy(s, subsidyHeight, params)
+		stake := vote * int64(params.TicketsPerBlock)
+		if work+stake == 0 {
+			break
+		}
+		if len(intervals) > 0 &&
+			work+stake >= intervals[len(intervals)-1].BlockSubsidy {
+			break
+		}
+
+		// Blocks before the stake validation height do not include votes
+		// and neither the genesis block nor block one pay the regular
+		// subsidy.
+		regularStart := start
+		if regularStart < 2 {
+			regularStart = 2
+		}
+		emission := work * (end - regularStart + 1)
+		voteStart := regularStart
+		if voteStart < params.StakeValidationHeight {
+			voteStart = params.StakeValidationHeight
+		}
+		if voteStart <= end {
+			emission += stake * (end - voteStart + 1)
+		}
+		if start <= 1 {
+			emission += params.BlockOneSubsidy()
+		}
+
+		intervals = append(intervals, EmissionInterval{
+			StartHeight:  start,
+			EndHeight:    end,
+			WorkSubsidy:  work,
+			VoteSubsidy:  vote,
+			BlockSubsidy: work + stake,
+			Emission:     emission,
+		})
+		start = end + 1
+	}
+
+	return intervals
+}
+
 // BlockOneCoinbasePaysTokens checks to see if the first block coinbase pays
 // out to the network initial token ledger.
 func BlockOneCoinbasePaysTokens(tx *exccutil.Tx, params *chaincfg.Params) error {
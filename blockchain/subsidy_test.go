@@ -56,3 +56,86 @@ func TestBlockSubsidy(t *testing.T) {
 		t.Errorf("Bad total subsidy; want %v, got %v", expectedSubsidy, totalSubsidy)
 	}
 }
+
+// TestEmissionSchedule ensures the emission schedule sums to the total subsidy
+// of the network and accounts for the special subsidy of the first blocks when
+// the schedule starts at any height.
+func TestEmissionSchedule(t *testing.T) {
+	mainnet := &chaincfg.MainNetParams
+	subsidyCache := NewSubsidyCache(0, mainnet)
+
+	sumEmission := func(intervals []EmissionInterval) int64 {
+		var total int64
+		for _, interval := range intervals {
+			total += interval.Emission
+		}
+		return total
+	}
+
+	expectedSubsidy := int64(1985834211695360) + mainnet.BlockOneSubsidy()
+	schedule := subsidyCache.EmissionSchedule(0, 0)
+	if total := sumEmission(schedule); total != expectedSubsidy {
+		t.Fatalf("bad total emission; want %v, got %v", expectedSubsidy,
+			total)
+	}
+	last := schedule[len(schedule)-1]
+	if last.BlockSubsidy == 0 {
+		t.Fatalf("schedule includes interval without subsidy %+v", last)
+	}
+
+	// Calculate the emission of the blocks before the schedule starts one
+	// block at a time and ensure it makes up the difference.
+	tests := []int64{1, 2, 3, mainnet.StakeValidationHeight - 1,
+		mainnet.StakeValidationHeight, mainnet.StakeValidationHeight + 1,
+		mainnet.SubsidyReductionInterval,
+		mainnet.SubsidyReductionInterval + 1}
+	for _, height := range tests {
+		var prior int64
+		for h := int64(1); h < height; h++ {
+			if h == 1 {
+				prior += mainnet.BlockOneSubsidy()
+				continue
+			}
+			prior += CalcBlockWorkSubsidy(subsidyCache, h,
+				mainnet.TicketsPerBlock, mainnet)
+			if h >= mainnet.StakeValidationHeight {
+				prior += CalcStakeVoteSubsidy(subsidyCache, h,
+					mainnet) * int64(mainnet.TicketsPerBlock)
+			}
+		}
+
+		schedule := subsidyCache.EmissionSchedule(height, 0)
+		if schedule[0].StartHeight != height {
+			t.Errorf("height %d: bad start height %d", height,
+				schedule[0].StartHeight)
+		}
+		if total := prior + sumEmission(schedule); total != expectedSubsidy {
+			t.Errorf("height %d: bad total emission; want %v, got %v",
+				height, expectedSubsidy, total)
+		}
+	}
+
+	// Ensure the number of intervals is limited when requested.
+	schedule = subsidyCache.EmissionSchedule(100, 3)
+	if len(schedule) != 3 {
+		t.Fatalf("bad number of intervals; want 3, got %d", len(schedule))
+	}
+	if schedule[1].StartHeight != mainnet.SubsidyReductionInterval ||
+		schedule[1].EndHeight != 2*mainnet.SubsidyReductionInterval-1 {
+		t.Fatalf("bad second interval %+v", schedule[1])
+	}
+
+	// Ensure the schedule ends for networks whose subsidy never decreases
+	// instead of growing without bound.
+	for _, mulSubsidy := range []int64{mainnet.DivSubsidy,
+		mainnet.DivSubsidy + 1} {
+
+		params := *mainnet
+		params.MulSubsidy = mulSubsidy
+		schedule := NewSubsidyCache(0, &params).EmissionSchedule(0, 0)
+		if len(schedule) != 1 {
+			t.Errorf("mulsubsidy %d: bad number of intervals; want 1, "+
+				"got %d", mulSubsidy, len(schedule))
+		}
+	}
+}
@@ -496,6 +496,42 @@ func (b *BlockChain) deploymentState(prevNode *blockNode, version uint32, deploy
 	return invalidState, DeploymentError(deploymentID)
 }
 
+// isDeploymentActive returns whether or not the deployment with the provided
+// vote ID is active for the block AFTER the given node.  The deployment is
+// looked up across all stake versions defined by the chain params, so callers
+// enforcing the rules of a deployment do not need to know which stake version
+// it was defined for.  False is returned without an error when the network
+// does not define the deployment.
+//
+// NOTE: The choice of the deployment is not examined, so this is only suitable
+// for deployments which have a single choice that is neither abstain nor no.
+//
+// This function MUST be called with the chain state lock held (for writes).
+func (b *BlockChain) isDeploymentActive(prevNode *blockNode, deploymentID string) (bool, error) {
+	version, deployment := b.chainParams.DeploymentByID(deploymentID)
+	if deployment == nil {
+		return false, nil
+	}
+
+	state, err := b.deploymentState(prevNode, version, deploymentID)
+	if err != nil {
+		return false, err
+	}
+	return state.State == ThresholdActive, nil
+}
+
+// IsDeploymentActive returns whether or not the deployment with the provided
+// vote ID is active for the block AFTER the end of the current best chain.
+// False is returned when the network does not define the deployment.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) IsDeploymentActive(deploymentID string) (bool, error) {
+	b.chainLock.Lock()
+	active, err := b.isDeploymentActive(b.bestNode, deploymentID)
+	b.chainLock.Unlock()
+	return active, err
+}
+
 // ThresholdState returns the current rule change threshold state of the given
 // deployment ID for the block AFTER the provided block hash.
 //
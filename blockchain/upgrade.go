@@ -396,6 +396,40 @@ func upgradeToVersion3(db database.DB, dbInfo *databaseInfo, interrupt <-chan st
 	})
 }
 
+// upgradeToVersion4 upgrades a version 3 blockchain to version 4 by
+// calculating the state of the utxo set, which is maintained incrementally from
+// then on.
+func upgradeToVersion4(db database.DB, dbInfo *databaseInfo, interrupt <-chan struct{}) error {
+	log.Info("Calculating the utxo set hash.  This may take a while...")
+	start := time.Now()
+	var state *utxoSetState
+	err := db.View(func(dbTx database.Tx) error {
+		var err error
+		state, err = calcUtxoSetState(dbTx, interrupt)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	// Store the utxo set state along with the updated database version.
+	dbInfo.version = 4
+	err = db.Update(func(dbTx database.Tx) error {
+		if err := dbPutUtxoSetState(dbTx, state); err != nil {
+			return err
+		}
+		return dbPutDatabaseInfo(dbTx, dbInfo)
+	})
+	if err != nil {
+		return err
+	}
+
+	seconds := int64(time.Since(start) / time.Second)
+	log.Infof("Done calculating the utxo set hash.  Total outputs: %d in %d "+
+		"seconds", state.numOutputs, seconds)
+	return nil
+}
+
 // upgradeDB upgrades old database versions to the newest version by applying
 // all possible upgrades iteratively.
 //
@@ -416,5 +450,13 @@ func upgradeDB(db database.DB, chainParams *chaincfg.Params, dbInfo *databaseInf
 		}
 	}
 
+	// Calculate the utxo set state which is maintained incrementally from
+	// version 4 on.
+	if dbInfo.version == 3 {
+		if err := upgradeToVersion4(db, dbInfo, interrupt); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
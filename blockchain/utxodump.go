@@ -0,0 +1,432 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/EXCCoin/exccd/blockchain/internal/dbnamespace"
+	"github.com/EXCCoin/exccd/blockchain/stake"
+	"github.com/EXCCoin/exccd/chaincfg"
+	"github.com/EXCCoin/exccd/chaincfg/chainhash"
+	"github.com/EXCCoin/exccd/database"
+	"github.com/EXCCoin/exccd/wire"
+)
+
+// -----------------------------------------------------------------------------
+// A dump of the utxo set consists of a header, a record for every unspent
+// output, and a trailer.  All integers are encoded in little endian.
+//
+// The serialized format of the header is:
+//
+//   <magic><version><network><block hash><block height>
+//
+//   Field          Type              Size
+//   magic          [8]byte           8
+//   version        uint32            4
+//   network        uint32            4
+//   block hash     chainhash.Hash    chainhash.HashSize
+//   block height   uint32            4
+//
+// The serialized format of each unspent output is:
+//
+//   <tx hash><output index><tree><tx type><flags><block height><block index>
+//   <amount><script version><pkscript len><pkscript>
+//
+//   Field          Type              Size
+//   tx hash        chainhash.Hash    chainhash.HashSize
+//   output index   uint32            4
+//   tree           int8              1
+//   tx type        uint8             1
+//   flags          uint8             1
+//   block height   uint32            4
+//   block index    uint32            4
+//   amount         int64             8
+//   script version uint16            2
+//   pkscript len   VarInt            variable
+//   pkscript       []byte            variable
+//
+// The flags field encodes whether or not the transaction is a coinbase in bit
+// 0 and whether or not it has an expiry in bit 1.
+//
+// The outputs are ordered by the bytes of their transaction hash and then by
+// their index, which makes the dump of a given utxo set deterministic.
+//
+// The serialized format of the trailer is:
+//
+//   <num outputs><total amount><commitment>
+//
+//   Field          Type              Size
+//   num outputs    uint64            8
+//   total amount   int64             8
+//   commitment     chainhash.Hash    chainhash.HashSize
+//
+// The commitment is the sha256 hash of all of the preceding bytes of the dump.
+// -----------------------------------------------------------------------------
+
+const (
+	// utxoDumpVersion is the current version of the utxo set dump format.
+	utxoDumpVersion = 1
+
+	// utxoDumpFlagCoinBase and utxoDumpFlagHasExpiry are the flags of an
+	// unspent output in a utxo set dump.
+	utxoDumpFlagCoinBase  = 0x01
+	utxoDumpFlagHasExpiry = 0x02
+)
+
+// utxoDumpMagic are the bytes which identify a dump of the utxo set.
+var utxoDumpMagic = [8]byte{'e', 'x', 'c', 'c', 'u', 't', 'x', 'o'}
+
+// UtxoSetDump describes a dump of the utxo set.
+type UtxoSetDump struct {
+	// Hash and Height identify the best block at the time of the dump.
+	Hash   chainhash.Hash
+	Height int64
+
+	// NumOutputs and TotalAmount are the number of unspent outputs in the
+	// dump and the sum of their amounts in atoms.
+	NumOutputs  uint64
+	TotalAmount int64
+
+	// Commitment is the hash of the contents of the dump which commits to
+	// every unspent output it contains.
+	Commitment chainhash.Hash
+}
+
+// utxoDumpWriter writes the fields of a utxo set dump while also hashing them
+// to calculate the commitment of the dump.  Errors are sticky so they only
+// need to be checked once all fields have been written.
+type utxoDumpWriter struct {
+	w      *bufio.Writer
+	hasher io.Writer
+	buf    [8]byte
+	err    error
+}
+
+// write writes the passed bytes unless a previous write failed.
+func (dw *utxoDumpWriter) write(b []byte) {
+	if dw.err != nil {
+		return
+	}
+	dw.hasher.Write(b)
+	_, dw.err = dw.w.Write(b)
+}
+
+// writeUint32 writes the passed 32-bit unsigned integer.
+func (dw *utxoDumpWriter) writeUint32(v uint32) {
+	binary.LittleEndian.PutUint32(dw.buf[:4], v)
+	dw.write(dw.buf[:4])
+}
+
+// writeUint64 writes the passed 64-bit unsigned integer.
+func (dw *utxoDumpWriter) writeUint64(v uint64) {
+	binary.LittleEndian.PutUint64(dw.buf[:], v)
+	dw.write(dw.buf[:])
+}
+
+// DumpUtxoSet writes every output of the utxo set to the passed writer in a
+// deterministic format which commits to its contents.  The dump reflects the
+// utxo set as of the best block at the time it is started and the returned
+// details include the commitment of the dump so it may be verified later with
+// VerifyUtxoSetDump.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) DumpUtxoSet(w io.Writer) (*UtxoSetDump, error) {
+	var dump UtxoSetDump
+	err := b.db.View(func(dbTx database.Tx) error {
+		// Load the best chain state from the same database transaction
+		// as the utxo set so the two are consistent without the need to
+		// hold the chain lock for the duration of the dump.
+		meta := dbTx.Metadata()
+		state, err := deserializeBestChainState(meta.Get(
+			dbnamespace.ChainStateKeyName))
+		if err != nil {
+			return err
+		}
+		dump.Hash = state.hash
+		dump.Height = int64(state.height)
+
+		hasher := sha256.New()
+		dw := &utxoDumpWriter{w: bufio.NewWriter(w), hasher: hasher}
+		dw.write(utxoDumpMagic[:])
+		dw.writeUint32(utxoDumpVersion)
+		dw.writeUint32(uint32(b.chainParams.Net))
+		dw.write(state.hash[:])
+		dw.writeUint32(state.height)
+
+		cursor := meta.Bucket(dbnamespace.UtxoSetBucketName).Cursor()
+		for ok := cursor.First(); ok; ok = cursor.Next() {
+			var txHash chainhash.Hash
+			copy(txHash[:], cursor.Key())
+			entry, err := deserializeUtxoEntry(cursor.Value())
+			if err != nil {
+				// Ensure any deserialization errors are returned
+				// as database corruption errors.
+				if isDeserializeErr(err) {
+					return database.Error{
+						ErrorCode: database.ErrCorruption,
+						Description: fmt.Sprintf("corrupt utxo "+
+							"entry for %v: %v", txHash, err),
+					}
+				}
+				return err
+			}
+
+			// The outputs of an entry are kept in a map, so sort
+			// their indexes to keep the dump deterministic.
+			indexes := make([]uint32, 0, len(entry.sparseOutputs))
+			for idx, output := range entry.sparseOutputs {
+				if !output.spent {
+					indexes = append(indexes, idx)
+				}
+			}
+			sort.Slice(indexes, func(i, j int) bool {
+				return indexes[i] < indexes[j]
+			})
+
+			for _, idx := range indexes {
+				output := entry.sparseOutputs[idx]
+				dw.write(serializeUtxoRecord(&txHash, idx, entry,
+					output))
+
+				dump.NumOutputs++
+				dump.TotalAmount += output.amount
+			}
+			if dw.err != nil {
+				return dw.err
+			}
+		}
+
+		dw.writeUint64(dump.NumOutputs)
+		dw.writeUint64(uint64(dump.TotalAmount))
+		copy(dump.Commitment[:], hasher.Sum(nil))
+		if dw.err != nil {
+			return dw.err
+		}
+		if _, err := dw.w.Write(dump.Commitment[:]); err != nil {
+			return err
+		}
+		return dw.w.Flush()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dump, nil
+}
+
+// serializeUtxoRecord returns the serialized record of the passed unspent
+// output of a utxo entry as it appears in a utxo set dump.  The same records
+// are the elements of the utxo set hash.
+func serializeUtxoRecord(txHash *chainhash.Hash, index uint32, entry *UtxoEntry, output *utxoOutput) []byte {
+	tree := wire.TxTreeRegular
+	if entry.txType != stake.TxTypeRegular {
+		tree = wire.TxTreeStake
+	}
+	var flags uint8
+	if entry.isCoinBase {
+		flags |= utxoDumpFlagCoinBase
+	}
+	if entry.hasExpiry {
+		flags |= utxoDumpFlagHasExpiry
+	}
+	output.maybeDecompress(currentCompressionVersion)
+
+	var buf [8]byte
+	var record bytes.Buffer
+	record.Write(txHash[:])
+	binary.LittleEndian.PutUint32(buf[:4], index)
+	record.Write(buf[:4])
+	record.WriteByte(uint8(tree))
+	record.WriteByte(uint8(entry.txType))
+	record.WriteByte(flags)
+	binary.LittleEndian.PutUint32(buf[:4], entry.height)
+	record.Write(buf[:4])
+	binary.LittleEndian.PutUint32(buf[:4], entry.index)
+	record.Write(buf[:4])
+	binary.LittleEndian.PutUint64(buf[:], uint64(output.amount))
+	record.Write(buf[:])
+	binary.LittleEndian.PutUint16(buf[:2], output.scriptVersion)
+	record.Write(buf[:2])
+	wire.WriteVarInt(&record, 0, uint64(len(output.pkScript)))
+	record.Write(output.pkScript)
+	return record.Bytes()
+}
+
+// utxoDumpReader reads the fields of a utxo set dump while also hashing them
+// to verify the commitment of the dump.  Errors are sticky so they only need
+// to be checked once all fields have been read.
+type utxoDumpReader struct {
+	r      *bufio.Reader
+	hasher io.Writer
+	buf    [8]byte
+	err    error
+}
+
+// read reads exactly len(b) bytes into b unless a previous read failed.
+func (dr *utxoDumpReader) read(b []byte) {
+	if dr.err != nil {
+		return
+	}
+	if _, dr.err = io.ReadFull(dr.r, b); dr.err != nil {
+		return
+	}
+	dr.hasher.Write(b)
+}
+
+// readUint8 reads a byte.
+func (dr *utxoDumpReader) readUint8() uint8 {
+	dr.read(dr.buf[:1])
+	return dr.buf[0]
+}
+
+// readUint16 reads a 16-bit unsigned integer.
+func (dr *utxoDumpReader) readUint16() uint16 {
+	dr.read(dr.buf[:2])
+	return binary.LittleEndian.Uint16(dr.buf[:2])
+}
+
+// readUint32 reads a 32-bit unsigned integer.
+func (dr *utxoDumpReader) readUint32() uint32 {
+	dr.read(dr.buf[:4])
+	return binary.LittleEndian.Uint32(dr.buf[:4])
+}
+
+// readUint64 reads a 64-bit unsigned integer.
+func (dr *utxoDumpReader) readUint64() uint64 {
+	dr.read(dr.buf[:])
+	return binary.LittleEndian.Uint64(dr.buf[:])
+}
+
+// readVarBytes reads bytes prefixed by their length.
+func (dr *utxoDumpReader) readVarBytes() []byte {
+	if dr.err != nil {
+		return nil
+	}
+	var lenBuf bytes.Buffer
+	length, err := wire.ReadVarInt(io.TeeReader(dr.r, &lenBuf), 0)
+	if err != nil {
+		dr.err = err
+		return nil
+	}
+	dr.hasher.Write(lenBuf.Bytes())
+	if length > wire.MaxBlockPayload {
+		dr.err = fmt.Errorf("script length %d exceeds the maximum "+
+			"block payload", length)
+		return nil
+	}
+	b := make([]byte, length)
+	dr.read(b)
+	return b
+}
+
+// VerifyUtxoSetDump reads a dump of the utxo set written by DumpUtxoSet from
+// the passed reader and verifies that it is for the passed network, that its
+// outputs are in order, and that its totals and commitment match its contents.
+// The details of the dump are returned when it is valid.
+func VerifyUtxoSetDump(r io.Reader, params *chaincfg.Params) (*UtxoSetDump, error) {
+	hasher := sha256.New()
+	dr := &utxoDumpReader{r: bufio.NewReader(r), hasher: hasher}
+
+	var magic [8]byte
+	dr.read(magic[:])
+	version := dr.readUint32()
+	network := dr.readUint32()
+	var dump UtxoSetDump
+	dr.read(dump.Hash[:])
+	dump.Height = int64(dr.readUint32())
+	if dr.err != nil {
+		return nil, fmt.Errorf("unable to read header: %v", dr.err)
+	}
+	if magic != utxoDumpMagic {
+		return nil, errors.New("not a utxo set dump")
+	}
+	if version != utxoDumpVersion {
+		return nil, fmt.Errorf("unsupported utxo set dump version %d",
+			version)
+	}
+	if wire.CurrencyNet(network) != params.Net {
+		return nil, fmt.Errorf("utxo set dump is for network %v "+
+			"instead of %v", wire.CurrencyNet(network), params.Net)
+	}
+
+	// The records are followed by the trailer, which is distinguished by
+	// its size since no record is smaller than it.
+	const trailerSize = 8 + 8 + chainhash.HashSize
+	var prevHash chainhash.Hash
+	var prevIndex uint32
+	for {
+		_, err := dr.r.Peek(trailerSize + 1)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to read output %d: %v",
+				dump.NumOutputs, err)
+		}
+
+		var txHash chainhash.Hash
+		dr.read(txHash[:])
+		index := dr.readUint32()
+		dr.readUint8()  // tree
+		dr.readUint8()  // tx type
+		dr.readUint8()  // flags
+		dr.readUint32() // block height
+		dr.readUint32() // block index
+		amount := int64(dr.readUint64())
+		dr.readUint16() // script version
+		dr.readVarBytes()
+		if dr.err != nil {
+			return nil, fmt.Errorf("unable to read output %d: %v",
+				dump.NumOutputs, dr.err)
+		}
+
+		if dump.NumOutputs > 0 {
+			cmp := bytes.Compare(txHash[:], prevHash[:])
+			if cmp < 0 || (cmp == 0 && index <= prevIndex) {
+				return nil, fmt.Errorf("output %v:%d is out of "+
+					"order", txHash, index)
+			}
+		}
+		prevHash, prevIndex = txHash, index
+		dump.NumOutputs++
+		dump.TotalAmount += amount
+	}
+
+	numOutputs := dr.readUint64()
+	totalAmount := int64(dr.readUint64())
+	if dr.err != nil {
+		return nil, fmt.Errorf("unable to read trailer: %v", dr.err)
+	}
+	if numOutputs != dump.NumOutputs {
+		return nil, fmt.Errorf("utxo set dump contains %d outputs "+
+			"instead of the %d it claims", dump.NumOutputs, numOutputs)
+	}
+	if totalAmount != dump.TotalAmount {
+		return nil, fmt.Errorf("outputs of utxo set dump total %d "+
+			"instead of the %d it claims", dump.TotalAmount, totalAmount)
+	}
+	copy(dump.Commitment[:], hasher.Sum(nil))
+	var commitment chainhash.Hash
+	if _, err := io.ReadFull(dr.r, commitment[:]); err != nil {
+		return nil, fmt.Errorf("unable to read commitment: %v", err)
+	}
+	if commitment != dump.Commitment {
+		return nil, fmt.Errorf("utxo set dump commitment %v does not "+
+			"match its contents %v", commitment, dump.Commitment)
+	}
+	if _, err := dr.r.ReadByte(); err != io.EOF {
+		return nil, errors.New("unexpected data after utxo set dump")
+	}
+
+	return &dump, nil
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"bytes"
+	"encoding/hex"
+	"reflect"
+	"testing"
+
+	"github.com/EXCCoin/exccd/blockchain/stake"
+	"github.com/EXCCoin/exccd/chaincfg"
+	"github.com/EXCCoin/exccd/chaincfg/chainhash"
+	"github.com/EXCCoin/exccd/database"
+)
+
+// TestUtxoSetDump ensures the utxo set is dumped deterministically with the
+// expected totals and that dumps are verified against their commitment.
+func TestUtxoSetDump(t *testing.T) {
+	chain, teardown, err := chainSetup("utxosetdump",
+		&chaincfg.SimNetParams)
+	if err != nil {
+		t.Fatalf("Failed to setup chain instance: %v", err)
+	}
+	defer teardown()
+
+	var before bytes.Buffer
+	baseline, err := chain.DumpUtxoSet(&before)
+	if err != nil {
+		t.Fatalf("DumpUtxoSet: unexpected error: %v", err)
+	}
+
+	// Add a coinbase with an output which is spent and a ticket to the utxo
+	// set.
+	p2pkh, _ := hex.DecodeString("76a914000000000000000000000000000000000" +
+		"000000088ac")
+	view := NewUtxoViewpoint()
+	coinbase := newUtxoEntry(1, 1, 0, true, false, stake.TxTypeRegular)
+	coinbase.sparseOutputs[0] = &utxoOutput{amount: 5000, pkScript: p2pkh}
+	coinbase.sparseOutputs[1] = &utxoOutput{amount: 7000, pkScript: p2pkh,
+		spent: true}
+	coinbase.sparseOutputs[2] = &utxoOutput{amount: 3000,
+		pkScript: []byte{0x51}}
+	coinbase.modified = true
+	view.entries[chainhash.HashH([]byte("coinbase"))] = coinbase
+	ticket := newUtxoEntry(1, 2, 1, false, true, stake.TxTypeSStx)
+	ticket.sparseOutputs[0] = &utxoOutput{amount: 20000, pkScript: p2pkh}
+	ticket.modified = true
+	view.entries[chainhash.HashH([]byte("ticket"))] = ticket
+	err = chain.db.Update(func(dbTx database.Tx) error {
+		return dbPutUtxoView(dbTx, view)
+	})
+	if err != nil {
+		t.Fatalf("dbPutUtxoView: unexpected error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	dump, err := chain.DumpUtxoSet(&buf)
+	if err != nil {
+		t.Fatalf("DumpUtxoSet: unexpected error: %v", err)
+	}
+	if dump.NumOutputs != baseline.NumOutputs+3 {
+		t.Errorf("unexpected number of outputs - got %d, want %d",
+			dump.NumOutputs, baseline.NumOutputs+3)
+	}
+	if dump.TotalAmount != baseline.TotalAmount+28000 {
+		t.Errorf("unexpected total amount - got %d, want %d",
+			dump.TotalAmount, baseline.TotalAmount+28000)
+	}
+	if dump.Hash != chain.BestSnapshot().Hash ||
+		dump.Height != chain.BestSnapshot().Height {
+		t.Errorf("unexpected best block %v (%d)", dump.Hash, dump.Height)
+	}
+	if dump.Commitment == baseline.Commitment {
+		t.Error("commitment did not change with the utxo set")
+	}
+
+	// Ensure dumping the same utxo set again produces the same bytes.
+	var again bytes.Buffer
+	if _, err := chain.DumpUtxoSet(&again); err != nil {
+		t.Fatalf("DumpUtxoSet: unexpected error: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), again.Bytes()) {
+		t.Fatal("dumps of the same utxo set differ")
+	}
+
+	verified, err := VerifyUtxoSetDump(bytes.NewReader(buf.Bytes()),
+		&chaincfg.SimNetParams)
+	if err != nil {
+		t.Fatalf("VerifyUtxoSetDump: unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(verified, dump) {
+		t.Fatalf("mismatched verified dump - got %+v, want %+v",
+			verified, dump)
+	}
+
+	// Ensure dumps which are modified or for another network are rejected.
+	_, err = VerifyUtxoSetDump(bytes.NewReader(buf.Bytes()),
+		&chaincfg.MainNetParams)
+	if err == nil {
+		t.Error("VerifyUtxoSetDump: dump accepted for wrong network")
+	}
+	corrupt := append([]byte(nil), buf.Bytes()...)
+	corrupt[len(corrupt)-chainhash.HashSize-20]++
+	_, err = VerifyUtxoSetDump(bytes.NewReader(corrupt),
+		&chaincfg.SimNetParams)
+	if err == nil {
+		t.Error("VerifyUtxoSetDump: modified dump accepted")
+	}
+	_, err = VerifyUtxoSetDump(bytes.NewReader(buf.Bytes()[:buf.Len()-1]),
+		&chaincfg.SimNetParams)
+	if err == nil {
+		t.Error("VerifyUtxoSetDump: truncated dump accepted")
+	}
+}
@@ -0,0 +1,247 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"fmt"
+
+	"github.com/EXCCoin/exccd/blockchain/internal/dbnamespace"
+	"github.com/EXCCoin/exccd/blockchain/internal/muhash"
+	"github.com/EXCCoin/exccd/chaincfg/chainhash"
+	"github.com/EXCCoin/exccd/database"
+)
+
+// -----------------------------------------------------------------------------
+// The utxo set state houses totals of the utxo set along with a hash which
+// commits to every unspent output it contains.  It is updated in the same
+// database transaction as the utxo set, so the details of the utxo set are
+// available without scanning it.
+//
+// The serialized format is:
+//
+//   <num txns><num outputs><total amount><serialized size><utxo set hash>
+//
+//   Field            Type      Size
+//   num txns         uint64    8
+//   num outputs      uint64    8
+//   total amount     int64     8
+//   serialized size  uint64    8
+//   utxo set hash    muhash    muhash.SerializedSize
+//
+// The utxo set hash is the muhash of the records of every unspent output as
+// they appear in a utxo set dump, so it does not depend on the order of the
+// outputs and is updated by adding and removing the records of the outputs
+// which change.  The serialized size is the total size of the keys and values
+// of the utxo set bucket.
+// -----------------------------------------------------------------------------
+
+// utxoSetStateSize is the size of a serialized utxo set state.
+const utxoSetStateSize = 8 + 8 + 8 + 8 + muhash.SerializedSize
+
+// utxoSetState houses the incrementally maintained details of the utxo set.
+type utxoSetState struct {
+	numTxns        uint64
+	numOutputs     uint64
+	totalAmount    int64
+	serializedSize uint64
+	hash           *muhash.MuHash
+}
+
+// newUtxoSetState returns the state of an empty utxo set.
+func newUtxoSetState() *utxoSetState {
+	return &utxoSetState{hash: muhash.New()}
+}
+
+// serializeUtxoSetState returns the serialization of the passed utxo set
+// state.
+func serializeUtxoSetState(state *utxoSetState) []byte {
+	serialized := make([]byte, utxoSetStateSize)
+	byteOrder.PutUint64(serialized[0:], state.numTxns)
+	byteOrder.PutUint64(serialized[8:], state.numOutputs)
+	byteOrder.PutUint64(serialized[16:], uint64(state.totalAmount))
+	byteOrder.PutUint64(serialized[24:], state.serializedSize)
+	copy(serialized[32:], state.hash.Serialize())
+	return serialized
+}
+
+// deserializeUtxoSetState deserializes the passed serialized utxo set state.
+func deserializeUtxoSetState(serialized []byte) (*utxoSetState, error) {
+	if len(serialized) != utxoSetStateSize {
+		return nil, database.Error{
+			ErrorCode: database.ErrCorruption,
+			Description: fmt.Sprintf("corrupt utxo set state size; "+
+				"want %v got %v", utxoSetStateSize, len(serialized)),
+		}
+	}
+
+	hash, err := muhash.Deserialize(serialized[32:])
+	if err != nil {
+		return nil, database.Error{
+			ErrorCode: database.ErrCorruption,
+			Description: fmt.Sprintf("corrupt utxo set hash: %v",
+				err),
+		}
+	}
+	return &utxoSetState{
+		numTxns:        byteOrder.Uint64(serialized[0:]),
+		numOutputs:     byteOrder.Uint64(serialized[8:]),
+		totalAmount:    int64(byteOrder.Uint64(serialized[16:])),
+		serializedSize: byteOrder.Uint64(serialized[24:]),
+		hash:           hash,
+	}, nil
+}
+
+// dbFetchUtxoSetState uses an existing database transaction to load the utxo
+// set state.
+func dbFetchUtxoSetState(dbTx database.Tx) (*utxoSetState, error) {
+	serialized := dbTx.Metadata().Get(dbnamespace.UtxoSetStateKeyName)
+	if serialized == nil {
+		return nil, database.Error{
+			ErrorCode:   database.ErrCorruption,
+			Description: "missing utxo set state",
+		}
+	}
+	return deserializeUtxoSetState(serialized)
+}
+
+// dbPutUtxoSetState uses an existing database transaction to store the passed
+// utxo set state.
+func dbPutUtxoSetState(dbTx database.Tx, state *utxoSetState) error {
+	return dbTx.Metadata().Put(dbnamespace.UtxoSetStateKeyName,
+		serializeUtxoSetState(state))
+}
+
+// updateEntry updates the state for the utxo entry for the passed transaction
+// hash changing from the old entry to the new one along with their
+// serializations.  Either entry may be nil to indicate the entry is being
+// added or removed.  Only the outputs which differ between the entries are
+// hashed.
+func (state *utxoSetState) updateEntry(txHash *chainhash.Hash, oldEntry, newEntry *UtxoEntry, oldSerialized, newSerialized []byte) {
+	isUnspent := func(entry *UtxoEntry, index uint32) bool {
+		if entry == nil {
+			return false
+		}
+		output, ok := entry.sparseOutputs[index]
+		return ok && !output.spent
+	}
+
+	if oldEntry != nil {
+		state.numTxns--
+		state.serializedSize -= uint64(chainhash.HashSize +
+			len(oldSerialized))
+		for index, output := range oldEntry.sparseOutputs {
+			if output.spent || isUnspent(newEntry, index) {
+				continue
+			}
+			state.hash.Remove(serializeUtxoRecord(txHash, index,
+				oldEntry, output))
+			state.numOutputs--
+			state.totalAmount -= output.amount
+		}
+	}
+	if newEntry != nil {
+		state.numTxns++
+		state.serializedSize += uint64(chainhash.HashSize +
+			len(newSerialized))
+		for index, output := range newEntry.sparseOutputs {
+			if output.spent || isUnspent(oldEntry, index) {
+				continue
+			}
+			state.hash.Add(serializeUtxoRecord(txHash, index,
+				newEntry, output))
+			state.numOutputs++
+			state.totalAmount += output.amount
+		}
+	}
+}
+
+// calcUtxoSetState calculates the state of the utxo set stored in the database
+// by scanning all of it.
+func calcUtxoSetState(dbTx database.Tx, interrupt <-chan struct{}) (*utxoSetState, error) {
+	state := newUtxoSetState()
+	cursor := dbTx.Metadata().Bucket(dbnamespace.UtxoSetBucketName).Cursor()
+	for ok := cursor.First(); ok; ok = cursor.Next() {
+		if interruptRequested(interrupt) {
+			return nil, errInterruptRequested
+		}
+
+		var txHash chainhash.Hash
+		copy(txHash[:], cursor.Key())
+		serialized := cursor.Value()
+		entry, err := deserializeUtxoEntry(serialized)
+		if err != nil {
+			// Ensure any deserialization errors are returned as
+			// database corruption errors.
+			if isDeserializeErr(err) {
+				return nil, database.Error{
+					ErrorCode: database.ErrCorruption,
+					Description: fmt.Sprintf("corrupt utxo "+
+						"entry for %v: %v", txHash, err),
+				}
+			}
+			return nil, err
+		}
+		state.updateEntry(&txHash, nil, entry, nil, serialized)
+	}
+
+	return state, nil
+}
+
+// UtxoSetInfo describes the utxo set as of the best block.
+type UtxoSetInfo struct {
+	// Hash and Height identify the best block the utxo set is for.
+	Hash   chainhash.Hash
+	Height int64
+
+	// Transactions and Outputs are the number of transactions with unspent
+	// outputs and the number of unspent outputs, and TotalAmount is the sum
+	// of their amounts in atoms.
+	Transactions uint64
+	Outputs      uint64
+	TotalAmount  int64
+
+	// SerializedSize is the size of the utxo set as stored in the database.
+	SerializedSize uint64
+
+	// UtxoSetHash commits to every unspent output in the utxo set without
+	// depending on how it is stored, so it may be compared across nodes.
+	UtxoSetHash chainhash.Hash
+}
+
+// FetchUtxoSetInfo returns the details of the utxo set as of the best block.
+// The details are maintained as blocks are connected and disconnected, so no
+// scan of the utxo set is needed.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) FetchUtxoSetInfo() (*UtxoSetInfo, error) {
+	var info UtxoSetInfo
+	err := b.db.View(func(dbTx database.Tx) error {
+		// Load the best chain state from the same database transaction
+		// as the utxo set state so the two are consistent.
+		bestState, err := deserializeBestChainState(dbTx.Metadata().Get(
+			dbnamespace.ChainStateKeyName))
+		if err != nil {
+			return err
+		}
+		state, err := dbFetchUtxoSetState(dbTx)
+		if err != nil {
+			return err
+		}
+
+		info.Hash = bestState.hash
+		info.Height = int64(bestState.height)
+		info.Transactions = state.numTxns
+		info.Outputs = state.numOutputs
+		info.TotalAmount = state.totalAmount
+		info.SerializedSize = state.serializedSize
+		info.UtxoSetHash = state.hash.Finalize()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
@@ -0,0 +1,470 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/EXCCoin/exccd/blockchain/chaingen"
+	"github.com/EXCCoin/exccd/blockchain/internal/dbnamespace"
+	"github.com/EXCCoin/exccd/blockchain/stake"
+	"github.com/EXCCoin/exccd/chaincfg"
+	"github.com/EXCCoin/exccd/chaincfg/chainhash"
+	"github.com/EXCCoin/exccd/database"
+	"github.com/EXCCoin/exccd/exccutil"
+)
+
+// checkUtxoSetState ensures the utxo set state maintained by the passed chain
+// matches the state calculated by scanning its utxo set and returns the details
+// of the utxo set.
+func checkUtxoSetState(t *testing.T, chain *BlockChain, desc string) *UtxoSetInfo {
+	t.Helper()
+	err := chain.db.View(func(dbTx database.Tx) error {
+		state, err := dbFetchUtxoSetState(dbTx)
+		if err != nil {
+			return err
+		}
+		calcState, err := calcUtxoSetState(dbTx, nil)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(serializeUtxoSetState(state),
+			serializeUtxoSetState(calcState)) {
+
+			t.Fatalf("%s: utxo set state %+v does not match "+
+				"calculated state %+v", desc, state, calcState)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("%s: unexpected error: %v", desc, err)
+	}
+	info, err := chain.FetchUtxoSetInfo()
+	if err != nil {
+		t.Fatalf("%s: FetchUtxoSetInfo: unexpected error: %v", desc, err)
+	}
+	return info
+}
+
+// TestUtxoSetState ensures the utxo set state is maintained as the utxo set is
+// updated such that it always matches the state calculated by scanning the
+// utxo set, and that it returns to its initial state when the updates are
+// undone.
+func TestUtxoSetState(t *testing.T) {
+	chain, teardown, err := chainSetup("utxosetstate",
+		&chaincfg.SimNetParams)
+	if err != nil {
+		t.Fatalf("Failed to setup chain instance: %v", err)
+	}
+	defer teardown()
+
+	// checkState ensures the maintained utxo set state matches the utxo set
+	// and returns its details.
+	checkState := func(desc string) *UtxoSetInfo {
+		return checkUtxoSetState(t, chain, desc)
+	}
+	putView := func(desc string, view *UtxoViewpoint) {
+		err := chain.db.Update(func(dbTx database.Tx) error {
+			return dbPutUtxoView(dbTx, view)
+		})
+		if err != nil {
+			t.Fatalf("%s: dbPutUtxoView: unexpected error: %v", desc,
+				err)
+		}
+	}
+
+	baseline := checkState("baseline")
+	if baseline.Hash != chain.BestSnapshot().Hash ||
+		baseline.Height != chain.BestSnapshot().Height {
+
+		t.Fatalf("unexpected best block %v (%d)", baseline.Hash,
+			baseline.Height)
+	}
+
+	// Add a coinbase and a ticket to the utxo set.
+	p2pkh, _ := hex.DecodeString("76a914000000000000000000000000000000000" +
+		"000000088ac")
+	coinbaseHash := chainhash.HashH([]byte("coinbase"))
+	ticketHash := chainhash.HashH([]byte("ticket"))
+	newCoinbase := func() *UtxoEntry {
+		entry := newUtxoEntry(1, 1, 0, true, false, stake.TxTypeRegular)
+		entry.sparseOutputs[0] = &utxoOutput{amount: 5000,
+			pkScript: p2pkh}
+		entry.sparseOutputs[1] = &utxoOutput{amount: 3000,
+			pkScript: []byte{0x51}}
+		entry.modified = true
+		return entry
+	}
+	view := NewUtxoViewpoint()
+	view.entries[coinbaseHash] = newCoinbase()
+	ticket := newUtxoEntry(1, 2, 1, false, true, stake.TxTypeSStx)
+	ticket.sparseOutputs[0] = &utxoOutput{amount: 20000, pkScript: p2pkh}
+	ticket.modified = true
+	view.entries[ticketHash] = ticket
+	putView("add", view)
+	info := checkState("add")
+	if info.Transactions != baseline.Transactions+2 ||
+		info.Outputs != baseline.Outputs+3 ||
+		info.TotalAmount != baseline.TotalAmount+28000 {
+
+		t.Fatalf("unexpected utxo set info after adding outputs %+v",
+			info)
+	}
+	if info.UtxoSetHash == baseline.UtxoSetHash {
+		t.Fatal("utxo set hash did not change with the utxo set")
+	}
+
+	// Spend an output of the coinbase and all of the ticket.
+	view = NewUtxoViewpoint()
+	coinbase := newCoinbase()
+	coinbase.sparseOutputs[1].spent = true
+	view.entries[coinbaseHash] = coinbase
+	ticket = ticket.Clone()
+	ticket.SpendOutput(0)
+	view.entries[ticketHash] = ticket
+	putView("spend", view)
+	info = checkState("spend")
+	if info.Transactions != baseline.Transactions+1 ||
+		info.Outputs != baseline.Outputs+1 ||
+		info.TotalAmount != baseline.TotalAmount+5000 {
+
+		t.Fatalf("unexpected utxo set info after spending outputs %+v",
+			info)
+	}
+
+	// Spend the rest of the coinbase to restore the initial utxo set.
+	view = NewUtxoViewpoint()
+	coinbase = newCoinbase()
+	coinbase.SpendOutput(0)
+	coinbase.SpendOutput(1)
+	view.entries[coinbaseHash] = coinbase
+	putView("restore", view)
+	if info := checkState("restore"); *info != *baseline {
+		t.Fatalf("unexpected utxo set info after restoring the utxo "+
+			"set - got %+v, want %+v", info, baseline)
+	}
+
+	// Ensure the database check finds a utxo set which was modified without
+	// updating its state.
+	result, err := CheckDatabase(chain.db, 1)
+	if err != nil {
+		t.Fatalf("CheckDatabase: unexpected error: %v", err)
+	}
+	if result.NumProblems != 0 {
+		t.Fatalf("unexpected check result %+v", result)
+	}
+	err = chain.db.Update(func(dbTx database.Tx) error {
+		serialized, err := serializeUtxoEntry(newCoinbase())
+		if err != nil {
+			return err
+		}
+		bucket := dbTx.Metadata().Bucket(dbnamespace.UtxoSetBucketName)
+		return bucket.Put(coinbaseHash[:], serialized)
+	})
+	if err != nil {
+		t.Fatalf("unable to modify utxo set: %v", err)
+	}
+	result, err = CheckDatabase(chain.db, 1)
+	if err != nil {
+		t.Fatalf("CheckDatabase: unexpected error: %v", err)
+	}
+	if result.NumProblems != 1 {
+		t.Fatalf("unexpected check result %+v", result)
+	}
+}
+
+// TestUtxoSetStateDeserializeErrors ensures deserializing malformed utxo set
+// states returns database corruption errors.
+func TestUtxoSetStateDeserializeErrors(t *testing.T) {
+	serialized := serializeUtxoSetState(newUtxoSetState())
+	if _, err := deserializeUtxoSetState(serialized); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		serialized []byte
+	}{{
+		name:       "short",
+		serialized: serialized[:len(serialized)-1],
+	}, {
+		name:       "zero hash",
+		serialized: make([]byte, utxoSetStateSize),
+	}}
+	for _, test := range tests {
+		_, err := deserializeUtxoSetState(test.serialized)
+		derr, ok := err.(database.Error)
+		if !ok || derr.ErrorCode != database.ErrCorruption {
+			t.Errorf("deserializeUtxoSetState (%s): unexpected "+
+				"error %v", test.name, err)
+		}
+	}
+}
+
+// TestUtxoSetStateFullBlocks ensures the utxo set state matches the state
+// calculated by scanning the utxo set as real blocks are connected, including
+// ticket purchases and votes, and as they are disconnected by reorganizations,
+// and that it returns to the same state when a block is reconnected.
+func TestUtxoSetStateFullBlocks(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+
+	params := &chaincfg.SimNetParams
+
+	// Create a new database and chain instance to run tests against.
+	chain, teardownFunc, err := chainSetup("utxosetstatefullblocks", params)
+	if err != nil {
+		t.Fatalf("Failed to setup chain instance: %v", err)
+	}
+	defer teardownFunc()
+
+	// Create a test generator instance initialized with the genesis block
+	// as the tip.
+	g, err := chaingen.MakeGenerator(params, chain)
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+
+	// Define some convenience helper functions to process the current tip
+	// block associated with the generator.
+	//
+	// process processes the current tip block, expects it to be accepted
+	// either to the main chain or a side chain per the provided flag, and
+	// ensures the utxo set state matches the utxo set afterwards.
+	//
+	// expectTip expects the provided block to be the current tip of the
+	// main chain.
+	//
+	// forceTipReorg forces the chain instance to reorganize the current tip
+	// of the main chain from the given block to the given block and ensures
+	// the utxo set state matches the utxo set afterwards.
+	process := func(wantMainChain bool) {
+		msgBlock := g.Tip()
+		blockHeight := msgBlock.Header.Height
+		block := exccutil.NewBlock(msgBlock)
+		t.Logf("Testing block %s (hash %s, height %d)",
+			g.TipName(), block.Hash(), blockHeight)
+
+		isMainChain, isOrphan, err := chain.ProcessBlock(block, BFNone)
+		if err != nil {
+			t.Fatalf("block %q (hash %s, height %d) should "+
+				"have been accepted: %v", g.TipName(),
+				block.Hash(), blockHeight, err)
+		}
+		if isMainChain != wantMainChain {
+			t.Fatalf("block %q (hash %s, height %d) unexpected main "+
+				"chain flag -- got %v, want %v", g.TipName(),
+				block.Hash(), blockHeight, isMainChain,
+				wantMainChain)
+		}
+		if isOrphan {
+			t.Fatalf("block %q (hash %s, height %d) unexpected "+
+				"orphan flag -- got %v, want false", g.TipName(),
+				block.Hash(), blockHeight, isOrphan)
+		}
+		checkUtxoSetState(t, chain, g.TipName())
+	}
+	accepted := func() { process(true) }
+	acceptedToSideChain := func() { process(false) }
+	expectTip := func(tipName string) *UtxoSetInfo {
+		wantTip := g.BlockByName(tipName)
+		best := chain.BestSnapshot()
+		if best.Hash != wantTip.BlockHash() ||
+			best.Height != int64(wantTip.Header.Height) {
+			t.Fatalf("block %q (hash %s, height %d) should be "+
+				"the current tip -- got (hash %s, height %d)",
+				tipName, wantTip.BlockHash(),
+				wantTip.Header.Height, best.Hash, best.Height)
+		}
+		info := checkUtxoSetState(t, chain, tipName)
+		if info.Hash != wantTip.BlockHash() {
+			t.Fatalf("utxo set info for block %v instead of %q",
+				info.Hash, tipName)
+		}
+		return info
+	}
+	forceTipReorg := func(fromTipName, toTipName string) {
+		from := g.BlockByName(fromTipName)
+		to := g.BlockByName(toTipName)
+		err = chain.ForceHeadReorganization(from.BlockHash(), to.BlockHash())
+		if err != nil {
+			t.Fatalf("failed to force header reorg from block %q "+
+				"(hash %s, height %d) to block %q (hash %s, "+
+				"height %d): %v", fromTipName, from.BlockHash(),
+				from.Header.Height, toTipName, to.BlockHash(),
+				to.Header.Height, err)
+		}
+	}
+
+	// Shorter versions of useful params for convenience.
+	ticketsPerBlock := params.TicketsPerBlock
+	coinbaseMaturity := params.CoinbaseMaturity
+	stakeEnabledHeight := params.StakeEnabledHeight
+	stakeValidationHeight := params.StakeValidationHeight
+
+	// ---------------------------------------------------------------------
+	// Premine.
+	//
+	//   genesis -> bp
+	// ---------------------------------------------------------------------
+
+	g.CreatePremineBlock("bp", 0)
+	g.AssertTipHeight(1)
+	accepted()
+
+	// ---------------------------------------------------------------------
+	// Generate enough blocks to have mature coinbase outputs to work with.
+	//
+	//   genesis -> bp -> bm0 -> bm1 -> ... -> bm#
+	// ---------------------------------------------------------------------
+
+	for i := uint16(0); i < coinbaseMaturity; i++ {
+		blockName := fmt.Sprintf("bm%d", i)
+		g.NextBlock(blockName, nil, nil)
+		g.SaveTipCoinbaseOuts()
+		accepted()
+	}
+	g.AssertTipHeight(uint32(coinbaseMaturity) + 1)
+
+	// ---------------------------------------------------------------------
+	// Generate enough blocks to reach the stake enabled height while
+	// creating ticket purchases that spend from the coinbases matured
+	// above.
+	//
+	//   ... -> bm# ... -> bse0 -> bse1 -> ... -> bse#
+	// ---------------------------------------------------------------------
+
+	var ticketsPurchased int
+	for i := int64(0); int64(g.Tip().Header.Height) < stakeEnabledHeight; i++ {
+		outs := g.OldestCoinbaseOuts()
+		ticketOuts := outs[1:]
+		ticketsPurchased += len(ticketOuts)
+		blockName := fmt.Sprintf("bse%d", i)
+		g.NextBlock(blockName, nil, ticketOuts)
+		g.SaveTipCoinbaseOuts()
+		accepted()
+	}
+	g.AssertTipHeight(uint32(stakeEnabledHeight))
+
+	// ---------------------------------------------------------------------
+	// Generate enough blocks to reach the stake validation height while
+	// continuing to purchase tickets using the coinbases matured above and
+	// allowing the immature tickets to mature and thus become live.
+	//
+	//   ... -> bse# -> bsv0 -> bsv1 -> ... -> bsv#
+	// ---------------------------------------------------------------------
+
+	targetPoolSize := g.Params().TicketPoolSize * ticketsPerBlock
+	for i := int64(0); int64(g.Tip().Header.Height) < stakeValidationHeight; i++ {
+		// Only purchase tickets until the target ticket pool size is
+		// reached.
+		outs := g.OldestCoinbaseOuts()
+		ticketOuts := outs[1:]
+		if ticketsPurchased+len(ticketOuts) > int(targetPoolSize) {
+			ticketsNeeded := int(targetPoolSize) - ticketsPurchased
+			if ticketsNeeded > 0 {
+				ticketOuts = ticketOuts[1 : ticketsNeeded+1]
+			} else {
+				ticketOuts = nil
+			}
+		}
+		ticketsPurchased += len(ticketOuts)
+
+		blockName := fmt.Sprintf("bsv%d", i)
+		g.NextBlock(blockName, nil, ticketOuts)
+		g.SaveTipCoinbaseOuts()
+		accepted()
+	}
+	g.AssertTipHeight(uint32(stakeValidationHeight))
+
+	// ---------------------------------------------------------------------
+	// Generate enough blocks to have a known distance to the first mature
+	// coinbase outputs for the blocks that follow.  These blocks continue
+	// to purchase tickets to avoid running out of votes.
+	//
+	//   ... -> bsv# -> bbm0 -> bbm1 -> ... -> bbm#
+	// ---------------------------------------------------------------------
+
+	for i := uint16(0); i < coinbaseMaturity; i++ {
+		outs := g.OldestCoinbaseOuts()
+		blockName := fmt.Sprintf("bbm%d", i)
+		g.NextBlock(blockName, nil, outs[1:])
+		g.SaveTipCoinbaseOuts()
+		accepted()
+	}
+	g.AssertTipHeight(uint32(stakeValidationHeight) + uint32(coinbaseMaturity))
+
+	// Collect spendable outputs for regular transactions and ticket
+	// purchases.
+	var outs []*chaingen.SpendableOut
+	var ticketOuts [][]chaingen.SpendableOut
+	for i := uint16(0); i < coinbaseMaturity; i++ {
+		coinbaseOuts := g.OldestCoinbaseOuts()
+		outs = append(outs, &coinbaseOuts[0])
+		ticketOuts = append(ticketOuts, coinbaseOuts[1:])
+	}
+
+	// ---------------------------------------------------------------------
+	// Reorganization test.
+	// ---------------------------------------------------------------------
+
+	// Build a couple of blocks at the current tip which spend regular
+	// outputs (value in parens is which output is spent):
+	//
+	//   ... -> b1(0) -> b2(1)
+	g.NextBlock("b1", outs[0], ticketOuts[0])
+	accepted()
+	g.NextBlock("b2", outs[1], ticketOuts[1])
+	accepted()
+	expectTip("b2")
+
+	// Create a side chain from b1 which spends different outputs and
+	// becomes the main chain once it is longer, which disconnects b2.
+	//
+	//   ... -> b1(0) -> b3(2) -> b4(3)
+	//               \-> b2(1)
+	g.SetTip("b1")
+	g.NextBlock("b3", outs[2], ticketOuts[1])
+	acceptedToSideChain()
+	expectTip("b2")
+	g.NextBlock("b4", outs[3], ticketOuts[2])
+	accepted()
+	b4Info := expectTip("b4")
+
+	// Reorganize back to a sibling of b4 which spends another output and
+	// then to b4 again, which must return the utxo set to the same state.
+	//
+	//   ... -> b1(0) -> b3(2) -> b5(4)
+	//               \-> b2(1) \-> b4(3)
+	g.SetTip("b3")
+	g.NextBlock("b5", outs[4], ticketOuts[2])
+	acceptedToSideChain()
+	forceTipReorg("b4", "b5")
+	if info := expectTip("b5"); info.UtxoSetHash == b4Info.UtxoSetHash {
+		t.Fatal("utxo set hash did not change with the utxo set")
+	}
+	forceTipReorg("b5", "b4")
+	if info := expectTip("b4"); *info != *b4Info {
+		t.Fatalf("unexpected utxo set info after reconnecting b4 - "+
+			"got %+v, want %+v", info, b4Info)
+	}
+
+	// Extend the chain of b2 so it becomes the main chain again, which
+	// disconnects b4 and b3 and reconnects b2.
+	//
+	//   ... -> b1(0) -> b2(1) -> b6(2) -> b7(3)
+	//               \-> b3(2) -> b4(3)
+	//                        \-> b5(4)
+	g.SetTip("b2")
+	g.NextBlock("b6", outs[2], ticketOuts[2])
+	acceptedToSideChain()
+	g.NextBlock("b7", outs[3], ticketOuts[3])
+	accepted()
+	expectTip("b7")
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/EXCCoin/exccd/blockchain/stake"
+	"github.com/EXCCoin/exccd/chaincfg"
+	"github.com/EXCCoin/exccd/chaincfg/chainhash"
+	"github.com/EXCCoin/exccd/database"
+)
+
+// TestFetchUtxosMain ensures utxo entries are loaded into a view whether they
+// are loaded sequentially or by several goroutines concurrently, that missing
+// entries result in nil entries, and that entries already in the view are not
+// replaced.
+func TestFetchUtxosMain(t *testing.T) {
+	chain, teardown, err := chainSetup("fetchutxosmain",
+		&chaincfg.SimNetParams)
+	if err != nil {
+		t.Fatalf("Failed to setup chain instance: %v", err)
+	}
+	defer teardown()
+
+	// Add enough entries to the utxo set to load them concurrently.
+	const numEntries = maxUtxoFetchWorkers*minUtxoFetchesPerWorker + 5
+	hashes := make([]chainhash.Hash, numEntries)
+	view := NewUtxoViewpoint()
+	for i := range hashes {
+		hashes[i] = chainhash.HashH([]byte(fmt.Sprintf("tx %d", i)))
+		entry := newUtxoEntry(1, 1, 0, false, false, stake.TxTypeRegular)
+		entry.sparseOutputs[0] = &utxoOutput{amount: int64(i + 1),
+			pkScript: []byte{0x51}}
+		entry.modified = true
+		view.entries[hashes[i]] = entry
+	}
+	err = chain.db.Update(func(dbTx database.Tx) error {
+		return dbPutUtxoView(dbTx, view)
+	})
+	if err != nil {
+		t.Fatalf("dbPutUtxoView: unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		numEntries int
+	}{
+		{name: "sequential", numEntries: minUtxoFetchesPerWorker},
+		{name: "concurrent", numEntries: numEntries},
+	}
+	for _, test := range tests {
+		missing := chainhash.HashH([]byte("missing"))
+		txSet := map[chainhash.Hash]struct{}{missing: {}}
+		for _, hash := range hashes[:test.numEntries] {
+			txSet[hash] = struct{}{}
+		}
+
+		// Entries already in the view must not be replaced.
+		view := NewUtxoViewpoint()
+		existing := newUtxoEntry(1, 1, 0, false, false,
+			stake.TxTypeRegular)
+		view.entries[hashes[0]] = existing
+
+		err := view.fetchUtxosMain(chain.db, txSet)
+		if err != nil {
+			t.Fatalf("%s: fetchUtxosMain: unexpected error: %v",
+				test.name, err)
+		}
+		if len(view.entries) != test.numEntries+1 {
+			t.Fatalf("%s: unexpected number of entries %d, want %d",
+				test.name, len(view.entries), test.numEntries+1)
+		}
+		if entry, ok := view.entries[missing]; !ok || entry != nil {
+			t.Fatalf("%s: unexpected entry for missing tx: %v, %v",
+				test.name, entry, ok)
+		}
+		if view.LookupEntry(&hashes[0]) != existing {
+			t.Fatalf("%s: existing entry was replaced", test.name)
+		}
+		for i := 1; i < test.numEntries; i++ {
+			entry := view.LookupEntry(&hashes[i])
+			if entry == nil {
+				t.Fatalf("%s: missing entry %d", test.name, i)
+			}
+			if amount := entry.AmountByIndex(0); amount != int64(i+1) {
+				t.Fatalf("%s: unexpected amount for entry %d: "+
+					"got %d, want %d", test.name, i, amount,
+					i+1)
+			}
+		}
+	}
+}
@@ -48,12 +48,13 @@ func (c deploymentChecker) EndTime() uint64 {
 
 // RuleChangeActivationQuorum is the minimum votes required to reach quorum.
 //
-// This implementation returns the value defined by the chain params the checker
-// is associated with.
+// This implementation returns the value defined by the specific deployment the
+// checker is associated with when it is set and the value defined by the chain
+// params otherwise.
 //
 // This is part of the thresholdConditionChecker interface implementation.
 func (c deploymentChecker) RuleChangeActivationQuorum() uint32 {
-	return c.chain.chainParams.RuleChangeActivationQuorum
+	return c.chain.chainParams.DeploymentQuorum(c.deployment)
 }
 
 // RuleChangeActivationThreshold is the number of votes required to reach the
@@ -33,6 +33,12 @@ const (
 	// more.
 	minInFlightBlocks = 10
 
+	// maxMemPressureRequests is the maximum number of blocks and
+	// transactions requested from a peer at once while the process is under
+	// memory pressure.  It limits the number of downloaded blocks which are
+	// buffered in memory during the initial block download.
+	maxMemPressureRequests = 2 * minInFlightBlocks
+
 	// blockDbNamePrefix is the prefix for the block database name.  The
 	// database type is appended to this value to form the full block
 	// database name.
@@ -111,6 +117,21 @@ type getSyncPeerMsg struct {
 	reply chan *serverPeer
 }
 
+// syncStatus describes the state of the chain sync as seen by the block
+// manager.  The headers height is the height of the latest header downloaded
+// in headers-first mode, or zero when none are.
+type syncStatus struct {
+	current       bool
+	syncPeer      *serverPeer
+	headersHeight int64
+}
+
+// getSyncStatusMsg is a message type to be sent across the message channel for
+// retrieving the state of the chain sync.
+type getSyncStatusMsg struct {
+	reply chan syncStatus
+}
+
 // requestFromPeerMsg is a message type to be sent across the message channel
 // for requesting either blocks or transactions from a given peer. It routes
 // this through the block manager so the block manager doesn't ban the peer
@@ -316,6 +337,11 @@ type chainState struct {
 	missedTickets       []chainhash.Hash
 	curPrevHash         chainhash.Hash
 	pastMedianTime      time.Time
+
+	// newestTime is the time the first block at the newest height was
+	// connected.  It is not updated when the tip is replaced by another
+	// block at the same height.
+	newestTime time.Time
 }
 
 // Best returns the block hash and height known for the tip of the best known
@@ -384,6 +410,7 @@ type blockManager struct {
 	server              *server
 	started             int32
 	shutdown            int32
+	memPressure         int32
 	chain               *blockchain.BlockChain
 	rejectedTxns        map[chainhash.Hash]struct{}
 	requestedTxns       map[chainhash.Hash]struct{}
@@ -445,6 +472,11 @@ func (b *blockManager) updateChainState(newestHash *chainhash.Hash,
 	b.chainState.Lock()
 	defer b.chainState.Unlock()
 
+	if b.chainState.newestHash == nil ||
+		b.chainState.newestHeight != newestHeight {
+
+		b.chainState.newestTime = time.Now()
+	}
 	b.chainState.newestHash = newestHash
 	b.chainState.newestHeight = newestHeight
 	b.chainState.pastMedianTime = b.chain.BestSnapshot().MedianTime
@@ -466,7 +498,7 @@ func (b *blockManager) findNextHeaderCheckpoint(height int64) *chaincfg.Checkpoi
 	if cfg.DisableCheckpoints {
 		return nil
 	}
-	checkpoints := b.server.chainParams.Checkpoints
+	checkpoints := b.chain.Checkpoints()
 	if len(checkpoints) == 0 {
 		return nil
 	}
@@ -591,15 +623,28 @@ func (b *blockManager) startSync(peers *list.List) {
 // isSyncCandidate returns whether or not the peer is a candidate to consider
 // syncing from.
 func (b *blockManager) isSyncCandidate(sp *serverPeer) bool {
+	// The peer is not a candidate for sync if blocks are not accepted from
+	// it.
+	if !acceptsBlocksFrom(sp) {
+		return false
+	}
+
 	// The peer is not a candidate for sync if it's not a full node.
 	return sp.Services()&wire.SFNodeNetwork == wire.SFNodeNetwork
 }
 
+// acceptsBlocksFrom returns whether blocks may be requested from and accepted
+// from the peer.  When peers are configured with --synconly, blocks are only
+// accepted from them, and other peers are only used to relay transactions.
+func acceptsBlocksFrom(sp *serverPeer) bool {
+	return len(cfg.SyncOnly) == 0 || sp.isSyncOnlyPeer
+}
+
 // syncMiningStateAfterSync polls the blockMananger for the current sync
 // state; if the mananger is synced, it executes a call to the peer to
 // sync the mining state to the network.
 func (b *blockManager) syncMiningStateAfterSync(sp *serverPeer) {
-	go func() {
+	spawn(func() {
 		for {
 			time.Sleep(3 * time.Second)
 			if !sp.Connected() {
@@ -611,7 +656,7 @@ func (b *blockManager) syncMiningStateAfterSync(sp *serverPeer) {
 				return
 			}
 		}
-	}()
+	})
 }
 
 // handleNewPeerMsg deals with new peers that have signalled they may
@@ -896,7 +941,7 @@ func (b *blockManager) checkBlockForHiddenVotes(block *exccutil.Block) {
 
 	// Create a new coinbase and update the coinbase pointer
 	// in the underlying template msgBlock.
-	random, err := wire.RandomUint64()
+	random, err := randomExtraNonce()
 	if err != nil {
 		return
 	}
@@ -951,8 +996,19 @@ func (b *blockManager) checkBlockForHiddenVotes(block *exccutil.Block) {
 
 // handleBlockMsg handles block messages from all peers.
 func (b *blockManager) handleBlockMsg(bmsg *blockMsg) {
-	// If we didn't ask for this block then the peer is misbehaving.
+	// Blocks are never requested from peers blocks are not accepted from,
+	// so the peer is misbehaving.  This is checked separately from the
+	// requested blocks below since they may include blocks requested from
+	// other peers.
 	blockHash := bmsg.block.Hash()
+	if !acceptsBlocksFrom(bmsg.peer) {
+		bmgrLog.Warnf("Got block %v from %s which is not a sync only "+
+			"peer -- disconnecting", blockHash, bmsg.peer.Addr())
+		bmsg.peer.Disconnect()
+		return
+	}
+
+	// If we didn't ask for this block then the peer is misbehaving.
 	if _, exists := bmsg.peer.requestedBlocks[*blockHash]; !exists {
 		// Check to see if we ever requested this block, since it may
 		// have been accidentally sent in duplicate. If it was,
@@ -1271,6 +1327,7 @@ func (b *blockManager) fetchHeaderBlocks() {
 	// the function, so no need to double check it here.
 	gdmsg := wire.NewMsgGetDataSizeHint(uint(b.headerList.Len()))
 	numRequested := 0
+	maxRequests := b.maxRequests()
 	for e := b.startHeader; e != nil; e = e.Next() {
 		node, ok := e.Value.(*headerNode)
 		if !ok {
@@ -1298,7 +1355,7 @@ func (b *blockManager) fetchHeaderBlocks() {
 			numRequested++
 		}
 		b.startHeader = e.Next()
-		if numRequested >= wire.MaxInvPerMsg {
+		if numRequested >= maxRequests {
 			break
 		}
 	}
@@ -1498,6 +1555,12 @@ func (b *blockManager) handleInvMsg(imsg *invMsg) {
 			continue
 		}
 
+		// Ignore blocks announced by peers blocks are not accepted
+		// from.
+		if iv.Type == wire.InvTypeBlock && !acceptsBlocksFrom(imsg.peer) {
+			continue
+		}
+
 		// Request the inventory if we don't already have it.
 		haveInv, err := b.haveInventory(iv)
 		if err != nil {
@@ -1572,6 +1635,7 @@ func (b *blockManager) handleInvMsg(imsg *invMsg) {
 	// Request as much as possible at once.  Anything that won't fit into
 	// the request will be requested on the next inv message.
 	numRequested := 0
+	maxRequests := b.maxRequests()
 	gdmsg := wire.NewMsgGetData()
 	requestQueue := imsg.peer.requestQueue
 	for len(requestQueue) != 0 {
@@ -1605,7 +1669,7 @@ func (b *blockManager) handleInvMsg(imsg *invMsg) {
 			}
 		}
 
-		if numRequested >= wire.MaxInvPerMsg {
+		if numRequested >= maxRequests {
 			break
 		}
 	}
@@ -1615,6 +1679,27 @@ func (b *blockManager) handleInvMsg(imsg *invMsg) {
 	}
 }
 
+// SetMemoryPressure sets whether the process is under memory pressure, which
+// limits the number of blocks and transactions requested from peers at once.
+//
+// This function is safe for concurrent access.
+func (b *blockManager) SetMemoryPressure(pressure bool) {
+	var memPressure int32
+	if pressure {
+		memPressure = 1
+	}
+	atomic.StoreInt32(&b.memPressure, memPressure)
+}
+
+// maxRequests returns the maximum number of blocks and transactions to request
+// from a peer at once.
+func (b *blockManager) maxRequests() int {
+	if atomic.LoadInt32(&b.memPressure) != 0 {
+		return maxMemPressureRequests
+	}
+	return wire.MaxInvPerMsg
+}
+
 // limitMap is a helper function for maps that require a maximum limit by
 // evicting a random transaction if adding a new value would cause it to
 // overflow the maximum allowed.
@@ -1669,6 +1754,17 @@ out:
 			case getSyncPeerMsg:
 				msg.reply <- b.syncPeer
 
+			case getSyncStatusMsg:
+				status := syncStatus{
+					current:  b.current(),
+					syncPeer: b.syncPeer,
+				}
+				if b.headersFirstMode && b.headerList.Len() > 0 {
+					node := b.headerList.Back().Value.(*headerNode)
+					status.headersHeight = node.height
+				}
+				msg.reply <- status
+
 			case requestFromPeerMsg:
 				err := b.requestFromPeer(msg.peer, msg.blocks, msg.txs)
 				msg.reply <- requestFromPeerResponse{
@@ -2088,6 +2184,11 @@ func (b *blockManager) handleNotifyMsg(notification *blockchain.Notification) {
 
 			// Notify registered websocket clients of incoming block.
 			r.ntfnMgr.NotifyBlockConnected(block)
+			r.NotifyBestBlockChanged()
+		}
+
+		if s := b.server.simnetStaker; s != nil {
+			s.NotifyBlockConnected(block)
 		}
 
 	case blockchain.NTSpentAndMissedTickets: // Stake tickets are spent or missed from the most recently connected block.
@@ -2098,6 +2199,10 @@ func (b *blockManager) handleNotifyMsg(notification *blockchain.Notification) {
 			break
 		}
 
+		if r := b.server.ticketRevoker; r != nil {
+			r.NotifyMissedTickets(tnd.TicketsMissed)
+		}
+
 		if r := b.server.rpcServer; r != nil {
 			r.ntfnMgr.NotifySpentAndMissedTickets(tnd)
 		}
@@ -2169,6 +2274,7 @@ func (b *blockManager) handleNotifyMsg(notification *blockchain.Notification) {
 		// Notify registered websocket clients.
 		if r := b.server.rpcServer; r != nil {
 			r.ntfnMgr.NotifyBlockDisconnected(block)
+			r.NotifyBestBlockChanged()
 		}
 
 	case blockchain.NTReorganization: // The blockchain is reorganizing.
@@ -2183,6 +2289,10 @@ func (b *blockManager) handleNotifyMsg(notification *blockchain.Notification) {
 			r.ntfnMgr.NotifyReorganization(rd)
 		}
 
+		// Send an alert when the reorganization is deep.
+		b.server.notifyReorg(&rd.OldHash, rd.OldHeight, &rd.NewHash,
+			rd.NewHeight, rd.ForkHeight)
+
 		// Drop the associated mining template from the old chain, since it
 		// will be no longer valid.
 		b.cachedCurrentTemplate = nil
@@ -2263,7 +2373,7 @@ func (b *blockManager) Start() {
 
 	bmgrLog.Trace("Starting block manager")
 	b.wg.Add(1)
-	go b.blockHandler()
+	spawn(b.blockHandler)
 }
 
 // Stop gracefully shuts down the block manager by stopping all asynchronous
@@ -2278,6 +2388,14 @@ func (b *blockManager) Stop() error {
 	bmgrLog.Infof("Block manager shutting down")
 	close(b.quit)
 	b.wg.Wait()
+
+	// Save the most recent block nodes now that no more blocks will be
+	// processed so the next startup doesn't have to load them one at a
+	// time.
+	if err := b.chain.SaveHotState(); err != nil {
+		bmgrLog.Warnf("Unable to save block nodes for the next startup: %v",
+			err)
+	}
 	return nil
 }
 
@@ -2288,6 +2406,15 @@ func (b *blockManager) SyncPeer() *serverPeer {
 	return <-reply
 }
 
+// SyncStatus returns the state of the chain sync, namely whether or not the
+// chain is believed to be current, the sync peer, and the height of the latest
+// header downloaded in headers-first mode.
+func (b *blockManager) SyncStatus() syncStatus {
+	reply := make(chan syncStatus)
+	b.msgChan <- getSyncStatusMsg{reply: reply}
+	return <-reply
+}
+
 // RequestFromPeer allows an outside caller to request blocks or transactions
 // from a peer. The requests are logged in the blockmanager's internal map of
 // requests so they do not later ban the peer for sending the respective data.
@@ -2303,7 +2430,11 @@ func (b *blockManager) RequestFromPeer(p *serverPeer, blocks, txs []*chainhash.H
 func (b *blockManager) requestFromPeer(p *serverPeer, blocks, txs []*chainhash.Hash) error {
 	msgResp := wire.NewMsgGetData()
 
-	// Add the blocks to the request.
+	// Add the blocks to the request unless blocks are not accepted from
+	// the peer.
+	if !acceptsBlocksFrom(p) {
+		blocks = nil
+	}
 	for _, bh := range blocks {
 		// If we've already requested this block, skip it.
 		_, alreadyReqP := p.requestedBlocks[*bh]
@@ -2541,9 +2672,9 @@ func (b *blockManager) GetMiningAddr() (exccutil.Address, error) {
 	}
 	b.miningAddrMutex.RUnlock()
 
-	if len(cfg.miningAddrs) > 0 {
+	if miningAddrs := cfg.currentMiningAddrs(); len(miningAddrs) > 0 {
 		rand.Seed(time.Now().UnixNano())
-		return cfg.miningAddrs[rand.Intn(len(cfg.miningAddrs))], nil
+		return miningAddrs[rand.Intn(len(miningAddrs))], nil
 	}
 
 	return nil, fmt.Errorf("No payment address specified via --miningaddr or setgenerate")
@@ -2566,16 +2697,27 @@ func newBlockManager(s *server, indexManager blockchain.IndexManager, interrupt
 		quit:                make(chan struct{}),
 	}
 
+	// Merge the checkpoints of the network with the ones added via the
+	// --addcheckpoint option.  No checkpoints are used at all when they
+	// are disabled.
+	checkpoints := []chaincfg.Checkpoint{}
+	if !cfg.DisableCheckpoints {
+		checkpoints = mergeCheckpoints(s.chainParams.Checkpoints,
+			cfg.addCheckpoints)
+	}
+
 	// Create a new block chain instance with the appropriate configuration.
 	var err error
 	bm.chain, err = blockchain.New(&blockchain.Config{
-		DB:            s.db,
-		Interrupt:     interrupt,
-		ChainParams:   s.chainParams,
-		TimeSource:    s.timeSource,
-		Notifications: bm.handleNotifyMsg,
-		SigCache:      s.sigCache,
-		IndexManager:  indexManager,
+		DB:             s.db,
+		Interrupt:      interrupt,
+		ChainParams:    s.chainParams,
+		Checkpoints:    checkpoints,
+		TimeSource:     s.timeSource,
+		Notifications:  bm.handleNotifyMsg,
+		SigCache:       s.sigCache,
+		IndexManager:   indexManager,
+		BlockCacheSize: cfg.BlockCacheSize,
 	})
 	if err != nil {
 		return nil, err
@@ -0,0 +1,146 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package cequihash
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// blake2bBlockSize is the size of the blocks BLAKE2b compresses in bytes.
+const blake2bBlockSize = 128
+
+// blake2bIV is the BLAKE2b initialization vector.
+var blake2bIV = [8]uint64{
+	0x6a09e667f3bcc908, 0xbb67ae8584caa73b, 0x3c6ef372fe94f82b,
+	0xa54ff53a5f1d36f1, 0x510e527fade682d1, 0x9b05688c2b3e6c1f,
+	0x1f83d9abfb41bd6b, 0x5be0cd19137e2179,
+}
+
+// blake2bSigma is the BLAKE2b message word permutation for each round.
+var blake2bSigma = [12][16]byte{
+	{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+	{14, 10, 4, 8, 9, 15, 13, 6, 1, 12, 0, 2, 11, 7, 5, 3},
+	{11, 8, 12, 0, 5, 2, 15, 13, 10, 14, 3, 6, 7, 1, 9, 4},
+	{7, 9, 3, 1, 13, 12, 11, 14, 2, 6, 5, 10, 4, 0, 15, 8},
+	{9, 0, 5, 7, 2, 4, 10, 15, 14, 1, 11, 12, 6, 8, 3, 13},
+	{2, 12, 6, 10, 0, 11, 8, 3, 4, 13, 7, 5, 15, 14, 1, 9},
+	{12, 5, 1, 15, 14, 13, 4, 10, 0, 7, 6, 3, 9, 2, 8, 11},
+	{13, 11, 7, 14, 12, 1, 3, 9, 5, 0, 15, 4, 8, 6, 2, 10},
+	{6, 15, 14, 9, 11, 3, 0, 8, 12, 2, 13, 7, 1, 4, 10, 5},
+	{10, 2, 8, 4, 7, 6, 1, 5, 15, 11, 9, 14, 3, 12, 13, 0},
+	{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+	{14, 10, 4, 8, 9, 15, 13, 6, 1, 12, 0, 2, 11, 7, 5, 3},
+}
+
+// blake2b is a minimal pure Go BLAKE2b implementation which supports the
+// personalization parameter Equihash requires and which the standard library
+// and golang.org/x/crypto do not expose.  It is only used to verify solutions
+// independently of the C solver, so it is not optimized.
+//
+// The state is a value type so a midstate can be copied cheaply to hash
+// several inputs sharing the same prefix.
+type blake2b struct {
+	h      [8]uint64
+	t      [2]uint64
+	buf    [blake2bBlockSize]byte
+	bufLen int
+	size   int
+}
+
+// newBlake2bPersonal returns a BLAKE2b state for unkeyed, sequential hashing
+// to a digest of the passed size with the passed personalization.
+func newBlake2bPersonal(size int, personal [16]byte) blake2b {
+	// Parameter block: digest length, key length, fanout, depth, and the
+	// personalization in the last 16 bytes with all other fields zero.
+	var p [64]byte
+	p[0] = byte(size)
+	p[2] = 1
+	p[3] = 1
+	copy(p[48:], personal[:])
+
+	d := blake2b{size: size}
+	for i := range d.h {
+		d.h[i] = blake2bIV[i] ^ binary.LittleEndian.Uint64(p[i*8:])
+	}
+	return d
+}
+
+// write adds the passed bytes to the hashed data.
+func (d *blake2b) write(p []byte) {
+	for len(p) > 0 {
+		// The last block must be compressed with the final flag set, so
+		// a full buffer is only compressed once more data arrives.
+		if d.bufLen == blake2bBlockSize {
+			d.compress(blake2bBlockSize, false)
+			d.bufLen = 0
+		}
+		n := copy(d.buf[d.bufLen:], p)
+		d.bufLen += n
+		p = p[n:]
+	}
+}
+
+// sum returns the digest of the hashed data without modifying the state.
+func (d blake2b) sum() []byte {
+	for i := d.bufLen; i < blake2bBlockSize; i++ {
+		d.buf[i] = 0
+	}
+	d.compress(d.bufLen, true)
+
+	var out [64]byte
+	for i, v := range d.h {
+		binary.LittleEndian.PutUint64(out[i*8:], v)
+	}
+	return out[:d.size]
+}
+
+// compress increments the counter by the passed number of bytes and mixes the
+// buffered block into the state.
+func (d *blake2b) compress(n int, final bool) {
+	d.t[0] += uint64(n)
+	if d.t[0] < uint64(n) {
+		d.t[1]++
+	}
+
+	var m [16]uint64
+	for i := range m {
+		m[i] = binary.LittleEndian.Uint64(d.buf[i*8:])
+	}
+
+	var v [16]uint64
+	copy(v[:8], d.h[:])
+	copy(v[8:], blake2bIV[:])
+	v[12] ^= d.t[0]
+	v[13] ^= d.t[1]
+	if final {
+		v[14] = ^v[14]
+	}
+
+	g := func(a, b, c, d int, x, y uint64) {
+		v[a] += v[b] + x
+		v[d] = bits.RotateLeft64(v[d]^v[a], -32)
+		v[c] += v[d]
+		v[b] = bits.RotateLeft64(v[b]^v[c], -24)
+		v[a] += v[b] + y
+		v[d] = bits.RotateLeft64(v[d]^v[a], -16)
+		v[c] += v[d]
+		v[b] = bits.RotateLeft64(v[b]^v[c], -63)
+	}
+	for _, s := range blake2bSigma {
+		g(0, 4, 8, 12, m[s[0]], m[s[1]])
+		g(1, 5, 9, 13, m[s[2]], m[s[3]])
+		g(2, 6, 10, 14, m[s[4]], m[s[5]])
+		g(3, 7, 11, 15, m[s[6]], m[s[7]])
+		g(0, 5, 10, 15, m[s[8]], m[s[9]])
+		g(1, 6, 11, 12, m[s[10]], m[s[11]])
+		g(2, 7, 8, 13, m[s[12]], m[s[13]])
+		g(3, 4, 9, 14, m[s[14]], m[s[15]])
+	}
+
+	for i := range d.h {
+		d.h[i] ^= v[i] ^ v[i+8]
+	}
+}
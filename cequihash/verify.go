@@ -0,0 +1,177 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package cequihash
+
+import (
+	"encoding/binary"
+	"errors"
+	"sort"
+)
+
+// maxVerifyInputLen is the maximum length of the solver input the C
+// validator accepts.
+const maxVerifyInputLen = 180
+
+var (
+	// ErrUnknownParams indicates the Equihash parameters are not supported.
+	ErrUnknownParams = errors.New("unsupported equihash parameters")
+
+	// ErrInvalidInputLength indicates the solver input is too long.
+	ErrInvalidInputLength = errors.New("equihash input is too long")
+
+	// ErrSolutionSize indicates the solution is shorter than required by
+	// the Equihash parameters.
+	ErrSolutionSize = errors.New("equihash solution is too short")
+
+	// ErrDuplicateIndices indicates the solution contains an index more
+	// than once.
+	ErrDuplicateIndices = errors.New("equihash solution has duplicate indices")
+
+	// ErrIndicesOutOfOrder indicates the solution indices are not in the
+	// canonical order.
+	ErrIndicesOutOfOrder = errors.New("equihash solution indices are out of order")
+
+	// ErrNonZeroXor indicates the hashes of the solution indices do not
+	// collide as required.
+	ErrNonZeroXor = errors.New("equihash solution hashes do not collide")
+)
+
+// verifyParams are the Equihash parameters the C solver supports.
+var verifyParams = map[int]int{48: 5, 96: 5, 144: 5, 200: 9}
+
+// SolutionIndices returns the indices encoded in the passed compressed
+// solution for the Equihash parameters n and k.  It is implemented in pure Go
+// and does not use the C solver.
+func SolutionIndices(n, k int, solution []byte) []uint32 {
+	bitLen := uint(n/(k+1) + 1)
+	mask := uint64(1)<<bitLen - 1
+
+	indices := make([]uint32, 0, 1<<uint(k))
+	var acc uint64
+	var accBits uint
+	for _, b := range solution[:EquihashSolutionSize(n, k)] {
+		acc = acc<<8 | uint64(b)
+		accBits += 8
+		if accBits >= bitLen {
+			accBits -= bitLen
+			indices = append(indices, uint32(acc>>accBits&mask))
+		}
+	}
+	return indices
+}
+
+// VerifyEquihash verifies the passed compressed solution for the Equihash
+// parameters n and k, solver input, and nonce the same way ValidateEquihash
+// does, but implemented in pure Go independently of the C solver.  This allows
+// solutions found by the C solver to be cross-checked before they are relied
+// upon.
+//
+// A nil error is returned when the solution is valid.  Otherwise, the error is
+// one of the errors defined by this package describing why it is invalid.
+func VerifyEquihash(n, k int, input []byte, nonce int64, solution []byte) error {
+	if wantK, ok := verifyParams[n]; !ok || k != wantK {
+		return ErrUnknownParams
+	}
+	if len(input) > maxVerifyInputLen {
+		return ErrInvalidInputLength
+	}
+	if len(solution) < EquihashSolutionSize(n, k) {
+		return ErrSolutionSize
+	}
+
+	indices := SolutionIndices(n, k, solution)
+	sorted := make([]uint32, len(indices))
+	copy(sorted, indices)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i] == sorted[i-1] {
+			return ErrDuplicateIndices
+		}
+	}
+
+	// The hash state is personalized with the parameters and seeded with
+	// the input and the nonce expanded to 32 bytes.
+	var personal [16]byte
+	copy(personal[:], "ZcashPoW")
+	binary.LittleEndian.PutUint32(personal[8:], uint32(n))
+	binary.LittleEndian.PutUint32(personal[12:], uint32(k))
+	v := equihashVerifier{
+		n:              n,
+		k:              k,
+		hashesPerBlake: 512 / n,
+	}
+	v.state = newBlake2bPersonal(v.hashesPerBlake*n/8, personal)
+	v.state.write(input)
+	if nonce >= 0 {
+		var expandedNonce [32]byte
+		binary.LittleEndian.PutUint32(expandedNonce[:], uint32(nonce))
+		v.state.write(expandedNonce[:])
+	}
+
+	_, err := v.verify(indices, uint(k))
+	return err
+}
+
+// equihashVerifier houses the state needed to recursively verify the
+// collisions of an Equihash solution.
+type equihashVerifier struct {
+	n              int
+	k              int
+	hashesPerBlake int
+	state          blake2b
+}
+
+// hash returns the n-bit hash of the passed index.
+func (v *equihashVerifier) hash(index uint32) []byte {
+	state := v.state
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], index/uint32(v.hashesPerBlake))
+	state.write(b[:])
+	offset := int(index%uint32(v.hashesPerBlake)) * v.n / 8
+	return state.sum()[offset : offset+v.n/8]
+}
+
+// verify returns the xor of the hashes of the passed 2^r indices after
+// ensuring the two halves are in order and their hashes collide on the first
+// r digits, or on all bits for the final round.
+func (v *equihashVerifier) verify(indices []uint32, r uint) ([]byte, error) {
+	if r == 0 {
+		return v.hash(indices[0]), nil
+	}
+
+	half := 1 << (r - 1)
+	if indices[0] >= indices[half] {
+		return nil, ErrIndicesOutOfOrder
+	}
+	hash0, err := v.verify(indices[:half], r-1)
+	if err != nil {
+		return nil, err
+	}
+	hash1, err := v.verify(indices[half:], r-1)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := make([]byte, len(hash0))
+	for i := range hash {
+		hash[i] = hash0[i] ^ hash1[i]
+	}
+
+	numBits := v.n
+	if int(r) < v.k {
+		numBits = int(r) * v.n / (v.k + 1)
+	}
+	i := 0
+	for ; i < numBits/8; i++ {
+		if hash[i] != 0 {
+			return nil, ErrNonZeroXor
+		}
+	}
+	if numBits%8 != 0 && hash[i]>>uint(8-numBits%8) != 0 {
+		return nil, ErrNonZeroXor
+	}
+
+	return hash, nil
+}
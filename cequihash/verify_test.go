@@ -0,0 +1,81 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package cequihash
+
+import (
+	"bytes"
+	"encoding/hex"
+	"reflect"
+	"testing"
+)
+
+// TestBlake2b ensures the pure Go BLAKE2b implementation produces the expected
+// digests.
+func TestBlake2b(t *testing.T) {
+	// Test vector from RFC 7693 appendix A.
+	want, _ := hex.DecodeString("ba80a53f981c4d0d6a2797b69f12f6e94c212f14" +
+		"685ac4b74b12bb6fdbffa2d17d87c5392aab792dc252d5de4533cc9518d38aa8" +
+		"dbf1925ab92386edd4009923")
+	d := newBlake2bPersonal(64, [16]byte{})
+	d.write([]byte("abc"))
+	if got := d.sum(); !bytes.Equal(got, want) {
+		t.Fatalf("unexpected digest: got %x, want %x", got, want)
+	}
+
+	// Hashing data spanning several blocks in pieces must produce the
+	// same digest as hashing it at once.
+	data := bytes.Repeat([]byte("equihash"), 64)
+	d1 := newBlake2bPersonal(50, [16]byte{'p'})
+	d1.write(data)
+	d2 := newBlake2bPersonal(50, [16]byte{'p'})
+	for i := 0; i < len(data); i += 100 {
+		end := i + 100
+		if end > len(data) {
+			end = len(data)
+		}
+		d2.write(data[i:end])
+	}
+	if !bytes.Equal(d1.sum(), d2.sum()) {
+		t.Fatalf("digest of data hashed in pieces does not match")
+	}
+}
+
+// TestVerifyEquihash ensures the pure Go solution verification agrees with the
+// C validator.
+func TestVerifyEquihash(t *testing.T) {
+	for i, test := range validatorTests {
+		solution := compressIndices(test.n, test.k, test.nonce, test.I, test.solution)
+		if got := SolutionIndices(test.n, test.k, solution); !reflect.DeepEqual(got, test.solution) {
+			t.Fatalf("#%d: unexpected indices: got %v, want %v", i, got, test.solution)
+		}
+
+		err := VerifyEquihash(test.n, test.k, test.I, int64(test.nonce), solution)
+		if (err == nil) != test.valid {
+			t.Fatalf("#%d: unexpected verification result: got %v, want valid %v", i, err, test.valid)
+		}
+		cResult := ValidateEquihash(test.n, test.k, test.I, int64(test.nonce), solution)
+		if cResult != (err == nil) {
+			t.Fatalf("#%d: verification result %v disagrees with C validator %v", i, err, cResult)
+		}
+	}
+
+	for _, test := range solverTests {
+		for i, indices := range test.solutions {
+			solution := compressIndices(test.n, test.k, test.nonce, test.I, indices)
+			err := VerifyEquihash(test.n, test.k, test.I, int64(test.nonce), solution)
+			if err != nil {
+				t.Fatalf("solution %d for %q nonce %d: unexpected error: %v", i, test.I, test.nonce, err)
+			}
+		}
+	}
+
+	// Unsupported parameters and short solutions are rejected.
+	if err := VerifyEquihash(96, 6, nil, 0, nil); err != ErrUnknownParams {
+		t.Fatalf("unexpected error for unsupported parameters: got %v, want %v", err, ErrUnknownParams)
+	}
+	if err := VerifyEquihash(96, 5, nil, 0, make([]byte, 10)); err != ErrSolutionSize {
+		t.Fatalf("unexpected error for short solution: got %v, want %v", err, ErrSolutionSize)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/rsa"
 	_ "crypto/sha512" // Needed for RegisterHash in init
 	"crypto/x509"
 	"crypto/x509/pkix"
@@ -23,12 +24,11 @@ import (
 	"time"
 )
 
-// NewTLSCertPair returns a new PEM-encoded x.509 certificate pair.  The
-// machine's local interface addresses and all variants of IPv4 and IPv6
-// localhost are included as valid IP addresses.
+// NewTLSCertPair returns a new PEM-encoded x.509 certificate pair with an ECDSA
+// key on the passed curve.  The machine's local interface addresses and all
+// variants of IPv4 and IPv6 localhost are included as valid IP addresses.
 func NewTLSCertPair(curve elliptic.Curve, organization string, validUntil time.Time, extraHosts []string) (cert, key []byte, err error) {
-	now := time.Now()
-	if validUntil.Before(now) {
+	if validUntil.Before(time.Now()) {
 		return nil, nil, errors.New("validUntil would create an already-expired certificate")
 	}
 
@@ -36,6 +36,41 @@ func NewTLSCertPair(curve elliptic.Curve, organization string, validUntil time.T
 	if err != nil {
 		return nil, nil, err
 	}
+	keyBytes, err := x509.MarshalECPrivateKey(priv)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal private key: %v", err)
+	}
+	keyBlock := &pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}
+
+	return newTLSCertPair(priv, &priv.PublicKey, keyBlock, organization,
+		validUntil, extraHosts)
+}
+
+// NewRSATLSCertPair returns a new PEM-encoded x.509 certificate pair with an RSA
+// key of the passed size in bits.  The same addresses as for NewTLSCertPair
+// are included as valid IP addresses.
+func NewRSATLSCertPair(bits int, organization string, validUntil time.Time, extraHosts []string) (cert, key []byte, err error) {
+	if validUntil.Before(time.Now()) {
+		return nil, nil, errors.New("validUntil would create an already-expired certificate")
+	}
+
+	priv, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyBlock := &pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(priv),
+	}
+
+	return newTLSCertPair(priv, &priv.PublicKey, keyBlock, organization,
+		validUntil, extraHosts)
+}
+
+// newTLSCertPair returns a new PEM-encoded x.509 certificate that is self-signed
+// with the passed private key along with the passed PEM block of the key.
+func newTLSCertPair(priv, pub interface{}, keyBlock *pem.Block, organization string, validUntil time.Time, extraHosts []string) (cert, key []byte, err error) {
+	now := time.Now()
 
 	// end of ASN.1 time
 	endOfTime := time.Date(2049, 12, 31, 23, 59, 59, 0, time.UTC)
@@ -119,7 +154,7 @@ func NewTLSCertPair(curve elliptic.Curve, organization string, validUntil time.T
 	}
 
 	derBytes, err := x509.CreateCertificate(rand.Reader, &template,
-		&template, &priv.PublicKey, priv)
+		&template, pub, priv)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create certificate: %v", err)
 	}
@@ -130,13 +165,8 @@ func NewTLSCertPair(curve elliptic.Curve, organization string, validUntil time.T
 		return nil, nil, fmt.Errorf("failed to encode certificate: %v", err)
 	}
 
-	keybytes, err := x509.MarshalECPrivateKey(priv)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal private key: %v", err)
-	}
-
 	keyBuf := &bytes.Buffer{}
-	err = pem.Encode(keyBuf, &pem.Block{Type: "EC PRIVATE KEY", Bytes: keybytes})
+	err = pem.Encode(keyBuf, keyBlock)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to encode private key: %v", err)
 	}
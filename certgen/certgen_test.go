@@ -8,6 +8,7 @@ package certgen_test
 
 import (
 	"crypto/elliptic"
+	"crypto/tls"
 	"crypto/x509"
 	"encoding/pem"
 	"net"
@@ -124,3 +125,52 @@ func TestNewTLSCertPair(t *testing.T) {
 		t.Fatal("generated cert does not have valid basic constraints")
 	}
 }
+
+// TestNewRSATLSCertPair ensures the NewRSATLSCertPair function creates a usable
+// certificate pair with an RSA key of the requested size.
+func TestNewRSATLSCertPair(t *testing.T) {
+	validUntil := time.Unix(time.Now().Add(365*24*time.Hour).Unix(), 0)
+	extraHosts := []string{"testtlscert.bogus", "192.0.2.1"}
+	cert, key, err := certgen.NewRSATLSCertPair(2048, "test autogenerated cert",
+		validUntil, extraHosts)
+	if err != nil {
+		t.Fatalf("failed with unexpected error: %v", err)
+	}
+
+	// Ensure the pair can be loaded for use by a TLS server.
+	if _, err := tls.X509KeyPair(cert, key); err != nil {
+		t.Fatalf("failed to load key pair: %v", err)
+	}
+
+	// Ensure the key is an RSA key of the requested size.
+	pemKey, _ := pem.Decode(key)
+	if pemKey == nil {
+		t.Fatalf("pem.Decode was unable to decode the key")
+	}
+	rsaKey, err := x509.ParsePKCS1PrivateKey(pemKey.Bytes)
+	if err != nil {
+		t.Fatalf("failed with unexpected error: %v", err)
+	}
+	if bits := rsaKey.N.BitLen(); bits != 2048 {
+		t.Fatalf("generated key has %d bits, want 2048", bits)
+	}
+
+	// Ensure the specified valid until value and extra hosts are present.
+	pemCert, _ := pem.Decode(cert)
+	if pemCert == nil {
+		t.Fatalf("pem.Decode was unable to decode the certificate")
+	}
+	x509Cert, err := x509.ParseCertificate(pemCert.Bytes)
+	if err != nil {
+		t.Fatalf("failed with unexpected error: %v", err)
+	}
+	if !x509Cert.NotAfter.Equal(validUntil) {
+		t.Fatalf("generated cert valid until field mismatch, got %v, "+
+			"want %v", x509Cert.NotAfter, validUntil)
+	}
+	for _, host := range extraHosts {
+		if err := x509Cert.VerifyHostname(host); err != nil {
+			t.Fatalf("failed to verify extra host '%s'", host)
+		}
+	}
+}
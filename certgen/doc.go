@@ -9,9 +9,9 @@ new TLS certificate key pair.
 
 Overview
 
-This package currently contains a single function for creating
-a new TLS certificate key pair, typically used for encrypting
-RPC and websocket communications.
+This package contains functions for creating a new TLS certificate
+key pair with either an ECDSA or an RSA key, typically used for
+encrypting RPC and websocket communications.
 
 */
 package certgen
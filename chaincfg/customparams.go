@@ -0,0 +1,454 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package chaincfg
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/EXCCoin/exccd/wire"
+)
+
+// customDuration is a time.Duration which is encoded in JSON as a string in the
+// format accepted by time.ParseDuration such as "2m30s".
+type customDuration time.Duration
+
+// UnmarshalJSON decodes the duration from its string form.
+func (d *customDuration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	duration, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = customDuration(duration)
+	return nil
+}
+
+// customHex is a byte slice which is encoded in JSON as a hex string.
+type customHex []byte
+
+// UnmarshalJSON decodes the bytes from their hex string form.
+func (h *customHex) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	decoded, err := hex.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	*h = decoded
+	return nil
+}
+
+// customGenesis describes the genesis block of a custom network.  The genesis
+// block is not evaluated for proof of work, so only the fields which are used
+// by the rest of the block chain may be specified.
+type customGenesis struct {
+	Timestamp         *int64     `json:"timestamp"`
+	Bits              *uint32    `json:"bits"`
+	SBits             *int64     `json:"sbits"`
+	Nonce             *uint32    `json:"nonce"`
+	ExtraData         *customHex `json:"extradata"`
+	EquihashSolution  *customHex `json:"equihashsolution"`
+	CoinbaseSigScript *customHex `json:"coinbasesigscript"`
+	CoinbasePkScript  *customHex `json:"coinbasepkscript"`
+}
+
+// customParams describes the JSON encoding of a custom network read by
+// LoadCustomParams.  All fields other than the name, network magic, and
+// default port are optional and default to the parameters of the base network.
+type customParams struct {
+	Base        string `json:"base"`
+	Name        string `json:"name"`
+	Net         uint32 `json:"net"`
+	DefaultPort string `json:"defaultport"`
+	DNSSeeds    []struct {
+		Host         string `json:"host"`
+		HasFiltering bool   `json:"hasfiltering"`
+	} `json:"dnsseeds"`
+	Genesis *customGenesis `json:"genesis"`
+
+	// Proof of work parameters.
+	PowLimit           *customHex      `json:"powlimit"`
+	TargetTimePerBlock *customDuration `json:"targettimeperblock"`
+	EquihashN          *int            `json:"equihashn"`
+	EquihashK          *int            `json:"equihashk"`
+
+	// WorkDiffLWMAWindowSize and ConsensusUpgrades select the per-block
+	// difficulty algorithm.  The consensus upgrades replace the ones of the
+	// base network and map the names of upgrades to their activation
+	// heights.
+	WorkDiffLWMAWindowSize *int64           `json:"workdifflwmawindowsize"`
+	ConsensusUpgrades      map[string]int64 `json:"consensusupgrades"`
+
+	// Subsidy parameters.
+	BaseSubsidy              *int64  `json:"basesubsidy"`
+	MulSubsidy               *int64  `json:"mulsubsidy"`
+	DivSubsidy               *int64  `json:"divsubsidy"`
+	SubsidyReductionInterval *int64  `json:"subsidyreductioninterval"`
+	WorkRewardProportion     *uint16 `json:"workrewardproportion"`
+	StakeRewardProportion    *uint16 `json:"stakerewardproportion"`
+	BlockOneLedger           []struct {
+		Address string `json:"address"`
+		Amount  int64  `json:"amount"`
+	} `json:"blockoneledger"`
+
+	// Stake parameters.
+	MinimumStakeDiff      *int64  `json:"minimumstakediff"`
+	TicketPoolSize        *uint16 `json:"ticketpoolsize"`
+	TicketsPerBlock       *uint16 `json:"ticketsperblock"`
+	TicketMaturity        *uint16 `json:"ticketmaturity"`
+	TicketExpiry          *uint32 `json:"ticketexpiry"`
+	CoinbaseMaturity      *uint16 `json:"coinbasematurity"`
+	StakeEnabledHeight    *int64  `json:"stakeenabledheight"`
+	StakeValidationHeight *int64  `json:"stakevalidationheight"`
+
+	// Address encoding parameters.
+	NetworkAddressPrefix *string    `json:"networkaddressprefix"`
+	Bech32HRP            *string    `json:"bech32hrp"`
+	PubKeyAddrID         *customHex `json:"pubkeyaddrid"`
+	PubKeyHashAddrID     *customHex `json:"pubkeyhashaddrid"`
+	PKHEdwardsAddrID     *customHex `json:"pkhedwardsaddrid"`
+	PKHSchnorrAddrID     *customHex `json:"pkhschnorraddrid"`
+	ScriptHashAddrID     *customHex `json:"scripthashaddrid"`
+	PrivateKeyID         *byte      `json:"privatekeyid"`
+	HDPrivateKeyID       *customHex `json:"hdprivatekeyid"`
+	HDPublicKeyID        *customHex `json:"hdpublickeyid"`
+	HDCoinType           *uint32    `json:"hdcointype"`
+}
+
+// customBaseParams houses the networks a custom network may be based on keyed
+// by their names.
+var customBaseParams = map[string]*Params{
+	MainNetParams.Name:  &MainNetParams,
+	TestNet2Params.Name: &TestNet2Params,
+	SimNetParams.Name:   &SimNetParams,
+	RegNetParams.Name:   &RegNetParams,
+}
+
+// setAddrID sets the passed address identifier to the provided bytes and
+// returns an error when they are not of the same length.
+func setAddrID(id []byte, b *customHex, field string) error {
+	if b == nil {
+		return nil
+	}
+	if len(*b) != len(id) {
+		return fmt.Errorf("%s must be %d bytes", field, len(id))
+	}
+	copy(id, *b)
+	return nil
+}
+
+// LoadCustomParams decodes the JSON description of a custom network and returns
+// its parameters so private networks can be deployed without modifying this
+// package.  The parameters are based on those of the network named by the base
+// field, which defaults to the simulation test network, and every other field
+// overrides the corresponding parameter.  The genesis block is derived from the
+// genesis block of the base network with the specified fields replaced.
+//
+// Since the custom network has a different genesis block, the checkpoints of
+// the base network do not apply to it, and the block one ledger of the base
+// network is only used when it is explicitly specified.
+//
+// The returned parameters must be registered with Register before addresses of
+// the network can be decoded.
+func LoadCustomParams(data []byte) (*Params, error) {
+	var c customParams
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+
+	baseName := c.Base
+	if baseName == "" {
+		baseName = SimNetParams.Name
+	}
+	base, ok := customBaseParams[baseName]
+	if !ok {
+		return nil, fmt.Errorf("unknown base network %q", baseName)
+	}
+	if c.Name == "" {
+		return nil, errors.New("name must be specified")
+	}
+	if _, ok := customBaseParams[c.Name]; ok {
+		return nil, fmt.Errorf("name %q is already used by a standard "+
+			"network", c.Name)
+	}
+	if c.Net == 0 {
+		return nil, errors.New("net must be specified")
+	}
+	if c.DefaultPort == "" {
+		return nil, errors.New("defaultport must be specified")
+	}
+
+	params := *base
+	params.Name = c.Name
+	params.Net = wire.CurrencyNet(c.Net)
+	params.DefaultPort = c.DefaultPort
+	params.DNSSeeds = make([]DNSSeed, 0, len(c.DNSSeeds))
+	for _, seed := range c.DNSSeeds {
+		params.DNSSeeds = append(params.DNSSeeds, DNSSeed{
+			Host:         seed.Host,
+			HasFiltering: seed.HasFiltering,
+		})
+	}
+	params.Checkpoints = nil
+	params.BlockOneLedger = nil
+
+	// Proof of work parameters.
+	if c.PowLimit != nil {
+		params.PowLimit = new(big.Int).SetBytes(*c.PowLimit)
+		if params.PowLimit.Sign() == 0 {
+			return nil, errors.New("powlimit must not be zero")
+		}
+		params.PowLimitBits = bigToCompact(params.PowLimit)
+	}
+	if c.TargetTimePerBlock != nil {
+		params.TargetTimePerBlock = time.Duration(*c.TargetTimePerBlock)
+		if params.TargetTimePerBlock <= 0 {
+			return nil, errors.New("targettimeperblock must be " +
+				"positive")
+		}
+		params.TargetTimespan = params.TargetTimePerBlock *
+			time.Duration(params.WorkDiffWindowSize)
+	}
+	if c.EquihashN != nil {
+		params.N = *c.EquihashN
+	}
+	if c.EquihashK != nil {
+		params.K = *c.EquihashK
+	}
+	if params.K <= 0 || params.N <= 0 || params.N%(params.K+1) != 0 {
+		return nil, fmt.Errorf("invalid equihash parameters n=%d k=%d",
+			params.N, params.K)
+	}
+
+	// Block headers have a fixed amount of space for equihash solutions,
+	// so only parameters which produce solutions that fit are supported.
+	solutionLen := (1 << uint(params.K)) * (params.N/(params.K+1) + 1) / 8
+	if solutionLen > wire.EquihashSolutionLen {
+		return nil, fmt.Errorf("equihash parameters n=%d k=%d produce "+
+			"%d byte solutions which exceed the %d bytes available "+
+			"in block headers", params.N, params.K, solutionLen,
+			wire.EquihashSolutionLen)
+	}
+
+	if c.WorkDiffLWMAWindowSize != nil {
+		params.WorkDiffLWMAWindowSize = *c.WorkDiffLWMAWindowSize
+	}
+	if c.ConsensusUpgrades != nil {
+		upgrades := make([]ConsensusUpgrade, 0, len(c.ConsensusUpgrades))
+		for name, height := range c.ConsensusUpgrades {
+			description, ok := knownConsensusUpgrades[name]
+			if !ok {
+				return nil, fmt.Errorf("unknown consensus "+
+					"upgrade %q", name)
+			}
+			upgrades = append(upgrades, ConsensusUpgrade{
+				Name:        name,
+				Description: description,
+				Height:      height,
+			})
+		}
+		sort.Slice(upgrades, func(i, j int) bool {
+			if upgrades[i].Height == upgrades[j].Height {
+				return upgrades[i].Name < upgrades[j].Name
+			}
+			return upgrades[i].Height < upgrades[j].Height
+		})
+		if _, err := validateConsensusUpgrades(upgrades); err != nil {
+			return nil, err
+		}
+		params.ConsensusUpgrades = upgrades
+	}
+	if _, ok := params.ConsensusUpgradeHeight(UpgradeLWMAWorkDiff); ok &&
+		params.WorkDiffLWMAWindowSize < 2 {
+
+		return nil, errors.New("workdifflwmawindowsize must be at " +
+			"least 2")
+	}
+
+	// Subsidy parameters.
+	if c.BaseSubsidy != nil {
+		params.BaseSubsidy = *c.BaseSubsidy
+	}
+	if c.MulSubsidy != nil {
+		params.MulSubsidy = *c.MulSubsidy
+	}
+	if c.DivSubsidy != nil {
+		params.DivSubsidy = *c.DivSubsidy
+	}
+	if c.SubsidyReductionInterval != nil {
+		params.SubsidyReductionInterval = *c.SubsidyReductionInterval
+	}
+	if c.WorkRewardProportion != nil {
+		params.WorkRewardProportion = *c.WorkRewardProportion
+	}
+	if c.StakeRewardProportion != nil {
+		params.StakeRewardProportion = *c.StakeRewardProportion
+	}
+	if params.DivSubsidy <= 0 || params.SubsidyReductionInterval <= 0 {
+		return nil, errors.New("divsubsidy and subsidyreductioninterval " +
+			"must be positive")
+	}
+	if params.MulSubsidy < 0 || params.MulSubsidy >= params.DivSubsidy {
+		return nil, errors.New("mulsubsidy must not be negative and " +
+			"must be less than divsubsidy so the subsidy is reduced " +
+			"until it is exhausted")
+	}
+	if params.TotalSubsidyProportions() == 0 {
+		return nil, errors.New("workrewardproportion and " +
+			"stakerewardproportion must not both be zero")
+	}
+	for _, payout := range c.BlockOneLedger {
+		params.BlockOneLedger = append(params.BlockOneLedger,
+			&TokenPayout{Address: payout.Address, Amount: payout.Amount})
+	}
+
+	// Stake parameters.
+	if c.MinimumStakeDiff != nil {
+		params.MinimumStakeDiff = *c.MinimumStakeDiff
+	}
+	if c.TicketPoolSize != nil {
+		params.TicketPoolSize = *c.TicketPoolSize
+	}
+	if c.TicketsPerBlock != nil {
+		params.TicketsPerBlock = *c.TicketsPerBlock
+	}
+	if c.TicketMaturity != nil {
+		params.TicketMaturity = *c.TicketMaturity
+	}
+	if c.TicketExpiry != nil {
+		params.TicketExpiry = *c.TicketExpiry
+	}
+	if c.CoinbaseMaturity != nil {
+		params.CoinbaseMaturity = *c.CoinbaseMaturity
+	}
+	if c.StakeEnabledHeight != nil {
+		params.StakeEnabledHeight = *c.StakeEnabledHeight
+	}
+	if c.StakeValidationHeight != nil {
+		params.StakeValidationHeight = *c.StakeValidationHeight
+	}
+	if params.TicketPoolSize == 0 || params.TicketsPerBlock == 0 {
+		return nil, errors.New("ticketpoolsize and ticketsperblock " +
+			"must be positive")
+	}
+	if params.StakeValidationHeight < params.StakeEnabledHeight {
+		return nil, errors.New("stakevalidationheight must not be " +
+			"less than stakeenabledheight")
+	}
+
+	// Address encoding parameters.
+	if c.NetworkAddressPrefix != nil {
+		params.NetworkAddressPrefix = *c.NetworkAddressPrefix
+	}
+	if c.Bech32HRP != nil {
+		hrp := *c.Bech32HRP
+		if len(hrp) > 20 || hrp != strings.ToLower(hrp) ||
+			strings.IndexFunc(hrp, func(r rune) bool {
+				return r < 33 || r > 126
+			}) != -1 {
+			return nil, fmt.Errorf("invalid bech32hrp %q", hrp)
+		}
+		params.Bech32HRP = hrp
+	}
+	err := setAddrID(params.PubKeyAddrID[:], c.PubKeyAddrID,
+		"pubkeyaddrid")
+	if err == nil {
+		err = setAddrID(params.PubKeyHashAddrID[:], c.PubKeyHashAddrID,
+			"pubkeyhashaddrid")
+	}
+	if err == nil {
+		err = setAddrID(params.PKHEdwardsAddrID[:], c.PKHEdwardsAddrID,
+			"pkhedwardsaddrid")
+	}
+	if err == nil {
+		err = setAddrID(params.PKHSchnorrAddrID[:], c.PKHSchnorrAddrID,
+			"pkhschnorraddrid")
+	}
+	if err == nil {
+		err = setAddrID(params.ScriptHashAddrID[:], c.ScriptHashAddrID,
+			"scripthashaddrid")
+	}
+	if err == nil {
+		err = setAddrID(params.HDPrivateKeyID[:], c.HDPrivateKeyID,
+			"hdprivatekeyid")
+	}
+	if err == nil {
+		err = setAddrID(params.HDPublicKeyID[:], c.HDPublicKeyID,
+			"hdpublickeyid")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if c.PrivateKeyID != nil {
+		params.PrivateKeyID = *c.PrivateKeyID
+	}
+	if c.HDCoinType != nil {
+		params.HDCoinType = *c.HDCoinType
+	}
+
+	// Derive the genesis block from the genesis block of the base network.
+	genesis := *base.GenesisBlock
+	coinbase := genesis.Transactions[0].Copy()
+	genesis.Transactions = []*wire.MsgTx{coinbase}
+	if g := c.Genesis; g != nil {
+		if g.Timestamp != nil {
+			genesis.Header.Timestamp = time.Unix(*g.Timestamp, 0)
+		}
+		if g.Bits != nil {
+			genesis.Header.Bits = *g.Bits
+		}
+		if g.SBits != nil {
+			genesis.Header.SBits = *g.SBits
+		}
+		if g.Nonce != nil {
+			genesis.Header.Nonce = *g.Nonce
+		}
+		if g.ExtraData != nil {
+			if len(*g.ExtraData) != len(genesis.Header.ExtraData) {
+				return nil, fmt.Errorf("genesis extra data must "+
+					"be %d bytes", len(genesis.Header.ExtraData))
+			}
+			copy(genesis.Header.ExtraData[:], *g.ExtraData)
+		}
+		if g.EquihashSolution != nil {
+			solution := *g.EquihashSolution
+			if len(solution) > wire.EquihashSolutionLen {
+				return nil, fmt.Errorf("genesis equihash solution "+
+					"must not be more than %d bytes",
+					wire.EquihashSolutionLen)
+			}
+			genesis.Header.EquihashSolution = [wire.EquihashSolutionLen]byte{}
+			copy(genesis.Header.EquihashSolution[:], solution)
+		}
+		if g.CoinbaseSigScript != nil {
+			coinbase.TxIn[0].SignatureScript = *g.CoinbaseSigScript
+		}
+		if g.CoinbasePkScript != nil {
+			if len(coinbase.TxOut) == 0 {
+				return nil, errors.New("genesis coinbase of the " +
+					"base network has no outputs")
+			}
+			coinbase.TxOut[0].PkScript = *g.CoinbasePkScript
+		}
+	}
+	genesis.Header.MerkleRoot = coinbase.TxHashFull()
+	genesisHash := genesis.BlockHash()
+	params.GenesisBlock = &genesis
+	params.GenesisHash = &genesisHash
+
+	return &params, nil
+}
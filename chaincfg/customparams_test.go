@@ -0,0 +1,134 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package chaincfg
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/EXCCoin/exccd/wire"
+)
+
+// TestLoadCustomParams ensures custom networks are loaded with the specified
+// parameters overriding those of the base network and that invalid networks
+// are rejected.
+func TestLoadCustomParams(t *testing.T) {
+	t.Parallel()
+
+	simNetGenesisTimestamp := SimNetParams.GenesisBlock.Header.Timestamp
+	params, err := LoadCustomParams([]byte(`{
+		"base": "simnet",
+		"name": "privnet",
+		"net": 305419896,
+		"defaultport": "19777",
+		"dnsseeds": [{"host": "seed.example.com", "hasfiltering": true}],
+		"genesis": {"timestamp": 1538352000, "coinbasepkscript": "6a",
+			"equihashsolution": "0102"},
+		"targettimeperblock": "2m",
+		"basesubsidy": 1000000000,
+		"ticketsperblock": 3,
+		"consensusupgrades": {"lwmaworkdiff": 100},
+		"workdifflwmawindowsize": 60,
+		"pubkeyhashaddrid": "0e91",
+		"bech32hrp": "privx",
+		"blockoneledger": [{"address": "addr", "amount": 100}]
+	}`))
+	if err != nil {
+		t.Fatalf("LoadCustomParams: unexpected error: %v", err)
+	}
+
+	if params.Name != "privnet" || params.Net != wire.CurrencyNet(0x12345678) ||
+		params.DefaultPort != "19777" {
+		t.Fatalf("unexpected network identity - got %s %v %s",
+			params.Name, params.Net, params.DefaultPort)
+	}
+	if len(params.DNSSeeds) != 1 || params.DNSSeeds[0].Host !=
+		"seed.example.com" || !params.DNSSeeds[0].HasFiltering {
+		t.Fatalf("unexpected dns seeds %v", params.DNSSeeds)
+	}
+	if params.TargetTimePerBlock != 2*time.Minute ||
+		params.TargetTimespan != 2*time.Minute*
+			time.Duration(params.WorkDiffWindowSize) {
+		t.Fatalf("unexpected target times - got %v and %v",
+			params.TargetTimePerBlock, params.TargetTimespan)
+	}
+	if params.BaseSubsidy != 1000000000 || params.TicketsPerBlock != 3 {
+		t.Fatalf("unexpected subsidy or stake parameters")
+	}
+	if !params.IsConsensusUpgradeActive(UpgradeLWMAWorkDiff, 100) ||
+		params.WorkDiffLWMAWindowSize != 60 {
+		t.Fatalf("unexpected difficulty algorithm parameters")
+	}
+	if params.PubKeyHashAddrID != [2]byte{0x0e, 0x91} {
+		t.Fatalf("unexpected pubkey hash address id %x",
+			params.PubKeyHashAddrID)
+	}
+	if params.Bech32HRP != "privx" {
+		t.Fatalf("unexpected bech32 human-readable part %q",
+			params.Bech32HRP)
+	}
+	if params.ScriptHashAddrID != SimNetParams.ScriptHashAddrID {
+		t.Fatalf("unspecified parameter not inherited from base network")
+	}
+	if len(params.BlockOneLedger) != 1 ||
+		params.BlockOneLedger[0].Amount != 100 {
+		t.Fatalf("unexpected block one ledger")
+	}
+
+	// Ensure the genesis block is derived from the one of the base network
+	// without modifying it.
+	genesis := params.GenesisBlock
+	if genesis.Header.Timestamp.Unix() != 1538352000 {
+		t.Fatalf("unexpected genesis timestamp %v",
+			genesis.Header.Timestamp)
+	}
+	if !bytes.Equal(genesis.Transactions[0].TxOut[0].PkScript, []byte{0x6a}) {
+		t.Fatalf("unexpected genesis coinbase script")
+	}
+	if genesis.Header.EquihashSolution[0] != 0x01 ||
+		genesis.Header.EquihashSolution[1] != 0x02 {
+		t.Fatalf("unexpected genesis equihash solution")
+	}
+	if genesis.Header.MerkleRoot != genesis.Transactions[0].TxHashFull() {
+		t.Fatalf("genesis merkle root does not commit to the coinbase")
+	}
+	if *params.GenesisHash != genesis.BlockHash() ||
+		*params.GenesisHash == *SimNetParams.GenesisHash {
+		t.Fatalf("unexpected genesis hash %v", params.GenesisHash)
+	}
+	if SimNetParams.GenesisBlock.Header.Timestamp != simNetGenesisTimestamp ||
+		bytes.Equal(SimNetParams.GenesisBlock.Transactions[0].TxOut[0].PkScript,
+			[]byte{0x6a}) {
+		t.Fatalf("genesis block of the base network was modified")
+	}
+
+	tests := []struct {
+		name string
+		json string
+	}{
+		{"unknown base", `{"base": "x", "name": "n", "net": 1, "defaultport": "1"}`},
+		{"missing name", `{"net": 1, "defaultport": "1"}`},
+		{"standard name", `{"name": "mainnet", "net": 1, "defaultport": "1"}`},
+		{"missing net", `{"name": "n", "defaultport": "1"}`},
+		{"missing port", `{"name": "n", "net": 1}`},
+		{"bad equihash", `{"name": "n", "net": 1, "defaultport": "1", "equihashn": 200, "equihashk": 9}`},
+		{"bad duration", `{"name": "n", "net": 1, "defaultport": "1", "targettimeperblock": "x"}`},
+		{"bad address id", `{"name": "n", "net": 1, "defaultport": "1", "scripthashaddrid": "01"}`},
+		{"bad bech32 hrp", `{"name": "n", "net": 1, "defaultport": "1", "bech32hrp": "Priv"}`},
+		{"bad extra data", `{"name": "n", "net": 1, "defaultport": "1", "genesis": {"extradata": "01"}}`},
+		{"unknown upgrade", `{"name": "n", "net": 1, "defaultport": "1", "consensusupgrades": {"x": 1}}`},
+		{"bad upgrade height", `{"name": "n", "net": 1, "defaultport": "1", "consensusupgrades": {"lwmaworkdiff": 0}}`},
+		{"bad lwma window", `{"name": "n", "net": 1, "defaultport": "1", "consensusupgrades": {"lwmaworkdiff": 1}, "workdifflwmawindowsize": 1}`},
+		{"zero tickets", `{"name": "n", "net": 1, "defaultport": "1", "ticketsperblock": 0}`},
+		{"subsidy not reduced", `{"name": "n", "net": 1, "defaultport": "1", "mulsubsidy": 100, "divsubsidy": 100}`},
+		{"subsidy increased", `{"name": "n", "net": 1, "defaultport": "1", "mulsubsidy": 101, "divsubsidy": 100}`},
+	}
+	for _, test := range tests {
+		if _, err := LoadCustomParams([]byte(test.json)); err == nil {
+			t.Errorf("%s: invalid network loaded without error", test.name)
+		}
+	}
+}
@@ -188,3 +188,64 @@ func TestSimNetGenesisBlock(t *testing.T) {
 			spew.Sdump(SimNetParams.GenesisHash))
 	}
 }
+
+// TestRegNetGenesisBlock tests the genesis block of the regression test network
+// for validity by checking the encoded bytes and hashes.
+func TestRegNetGenesisBlock(t *testing.T) {
+	// Encode the genesis block to raw bytes.
+	var buf bytes.Buffer
+	err := RegNetParams.GenesisBlock.Serialize(&buf)
+	if err != nil {
+		t.Fatalf("TestRegNetGenesisBlock: %v", err)
+	}
+
+	regNetGenesisBlockBytes, _ := hex.DecodeString(
+		"01000000000000000000000000000000" +
+			"00000000000000000000000000000000" +
+			"00000000e0d744c8c5cc4ddb8db59b14" +
+			"0e6ff5e09aca011219c25ddb5bbaca5a" +
+			"aa432c8a000000000000000000000000" +
+			"00000000000000000000000000000000" +
+			"00000000000000000000000000000000" +
+			"00000000ffff00210000000000000000" +
+			"00000000000000008063b15b00000000" +
+			"00000000000000000000000000000000" +
+			"00000000000000000000000000000000" +
+			"00000000000000000000000000000000" +
+			"00000000000000000000000000000000" +
+			"00000000000000000000000000000000" +
+			"00000000000000000000000000000000" +
+			"00000000000000000000000000000000" +
+			"00000000000000000000000000000000" +
+			"00000000000000000101000000010000" +
+			"00000000000000000000000000000000" +
+			"0000000000000000000000000000ffff" +
+			"ffff00ffffffff010000000000000000" +
+			"0000434104678afdb0fe5548271967f1" +
+			"a67130b7105cd6a828e03909a67962e0" +
+			"ea1f61deb649f6bc3f4cef38c4f35504" +
+			"e51ec112de5c384df7ba0b8d578a4c70" +
+			"2b6bf11d5fac00000000000000000100" +
+			"0000000000000000000000000000004d" +
+			"04ffff001d0104455468652054696d65" +
+			"732030332f4a616e2f32303039204368" +
+			"616e63656c6c6f72206f6e206272696e" +
+			"6b206f66207365636f6e64206261696c" +
+			"6f757420666f722062616e6b7300")
+
+	// Ensure the encoded block matches the expected bytes.
+	if !bytes.Equal(buf.Bytes(), regNetGenesisBlockBytes) {
+		t.Fatalf("TestRegNetGenesisBlock: Genesis block does not "+
+			"appear valid - got %v, want %v",
+			spew.Sdump(buf.Bytes()),
+			spew.Sdump(regNetGenesisBlockBytes))
+	}
+
+	// Check hash of the block against expected hash.
+	hash := RegNetParams.GenesisBlock.BlockHash()
+	if !RegNetParams.GenesisHash.IsEqual(&hash) {
+		t.Fatalf("TestRegNetGenesisBlock: Genesis block hash does "+
+			"not appear valid - got %v, want %v", spew.Sdump(hash),
+			spew.Sdump(RegNetParams.GenesisHash))
+	}
+}
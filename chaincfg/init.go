@@ -24,6 +24,14 @@ var (
 	ErrInvalidBothFlags = errors.New("IsNo and IsAbstain may not be both " +
 		"set to true")
 	ErrDuplicateChoiceId = errors.New("duplicate choice ID")
+	ErrOverlappingMask   = errors.New("mask overlaps with the mask of " +
+		"another vote")
+	ErrInvalidTimes = errors.New("start time must be before expire time")
+
+	ErrMissingUpgradeName   = errors.New("missing consensus upgrade name")
+	ErrDuplicateUpgrade     = errors.New("duplicate consensus upgrade name")
+	ErrInvalidUpgradeHeight = errors.New("consensus upgrade height must be " +
+		"positive and not lower than the height of the previous upgrade")
 )
 
 // bitsSet counts number of bits set.
@@ -142,11 +150,27 @@ func validateDeployments(deployments []ConsensusDeployment) (int, error) {
 		dups[id] = struct{}{}
 	}
 
+	var usedBits uint16
+	for index, deployment := range deployments {
+		// Check that votes of the same version do not share bits since
+		// a vote would otherwise be counted for multiple agendas.
+		if usedBits&deployment.Vote.Mask != 0 {
+			return index, ErrOverlappingMask
+		}
+		usedBits |= deployment.Vote.Mask
+
+		// Check that the deployment is able to start before it
+		// expires.
+		if deployment.StartTime >= deployment.ExpireTime {
+			return index, ErrInvalidTimes
+		}
+	}
+
 	return -1, nil
 }
 
 func validateAgendas() {
-	for i := 0; i < 3; i++ {
+	for i := 0; i < 4; i++ {
 		var params Params
 		switch i {
 		case 0:
@@ -155,6 +179,8 @@ func validateAgendas() {
 			params = TestNet2Params
 		case 2:
 			params = SimNetParams
+		case 3:
+			params = RegNetParams
 		default:
 			panic("invalid net")
 		}
@@ -183,6 +209,50 @@ func validateAgendas() {
 	}
 }
 
+// validateConsensusUpgrades ensures the passed consensus upgrades have unique,
+// non-empty names and positive activation heights in ascending order.  The
+// index of the first invalid upgrade is returned along with the error.
+func validateConsensusUpgrades(upgrades []ConsensusUpgrade) (int, error) {
+	names := make(map[string]struct{}, len(upgrades))
+	lastHeight := int64(1)
+	for i, upgrade := range upgrades {
+		if upgrade.Name == "" {
+			return i, ErrMissingUpgradeName
+		}
+		if _, ok := names[upgrade.Name]; ok {
+			return i, ErrDuplicateUpgrade
+		}
+		names[upgrade.Name] = struct{}{}
+
+		if upgrade.Height < lastHeight {
+			return i, ErrInvalidUpgradeHeight
+		}
+		lastHeight = upgrade.Height
+	}
+
+	return -1, nil
+}
+
+func validateUpgrades() {
+	nets := []*Params{&MainNetParams, &TestNet2Params, &SimNetParams,
+		&RegNetParams}
+	for _, params := range nets {
+		index, err := validateConsensusUpgrades(params.ConsensusUpgrades)
+		if err != nil {
+			e := fmt.Sprintf("invalid consensus upgrade %d on %v: %v",
+				index, params.Name, err)
+			panic(e)
+		}
+
+		_, ok := params.ConsensusUpgradeHeight(UpgradeLWMAWorkDiff)
+		if ok && params.WorkDiffLWMAWindowSize < 2 {
+			panic(fmt.Sprintf("invalid lwma window size on %v",
+				params.Name))
+		}
+	}
+}
+
 func init() {
 	validateAgendas()
+	validateUpgrades()
 }
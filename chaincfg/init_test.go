@@ -356,6 +356,17 @@ var (
 		{Vote: Vote{Id: "moo"}},
 		{Vote: Vote{Id: "moo"}},
 	}
+
+	overlappingMask = []ConsensusDeployment{
+		{Vote: Vote{Id: "moo", Mask: 0x0006}, ExpireTime: 1},
+		{Vote: Vote{Id: "oink", Mask: 0x0018}, ExpireTime: 1},
+		{Vote: Vote{Id: "quack", Mask: 0x0030}, ExpireTime: 1},
+	}
+
+	invalidTimes = []ConsensusDeployment{
+		{Vote: Vote{Id: "moo", Mask: 0x0006}, StartTime: 2,
+			ExpireTime: 2},
+	}
 )
 
 func TestDeployments(t *testing.T) {
@@ -369,6 +380,16 @@ func TestDeployments(t *testing.T) {
 			deployments: dupVote,
 			expected:    ErrDuplicateVoteId,
 		},
+		{
+			name:        "overlapping mask",
+			deployments: overlappingMask,
+			expected:    ErrOverlappingMask,
+		},
+		{
+			name:        "invalid times",
+			deployments: invalidTimes,
+			expected:    ErrInvalidTimes,
+		},
 	}
 
 	for _, test := range tests {
@@ -380,3 +401,73 @@ func TestDeployments(t *testing.T) {
 		}
 	}
 }
+
+func TestConsensusUpgrades(t *testing.T) {
+	tests := []struct {
+		name     string
+		upgrades []ConsensusUpgrade
+		index    int
+		expected error
+	}{
+		{
+			name:     "none",
+			upgrades: nil,
+			index:    -1,
+			expected: nil,
+		},
+		{
+			name: "ordered",
+			upgrades: []ConsensusUpgrade{
+				{Name: "a", Height: 10},
+				{Name: "b", Height: 10},
+				{Name: "c", Height: 20},
+			},
+			index:    -1,
+			expected: nil,
+		},
+		{
+			name: "missing name",
+			upgrades: []ConsensusUpgrade{
+				{Name: "a", Height: 10},
+				{Height: 20},
+			},
+			index:    1,
+			expected: ErrMissingUpgradeName,
+		},
+		{
+			name: "duplicate name",
+			upgrades: []ConsensusUpgrade{
+				{Name: "a", Height: 10},
+				{Name: "a", Height: 20},
+			},
+			index:    1,
+			expected: ErrDuplicateUpgrade,
+		},
+		{
+			name: "genesis height",
+			upgrades: []ConsensusUpgrade{
+				{Name: "a", Height: 0},
+			},
+			index:    0,
+			expected: ErrInvalidUpgradeHeight,
+		},
+		{
+			name: "unordered heights",
+			upgrades: []ConsensusUpgrade{
+				{Name: "a", Height: 20},
+				{Name: "b", Height: 10},
+			},
+			index:    1,
+			expected: ErrInvalidUpgradeHeight,
+		},
+	}
+
+	for _, test := range tests {
+		t.Logf("running: %v", test.name)
+		index, err := validateConsensusUpgrades(test.upgrades)
+		if err != test.expected || index != test.index {
+			t.Fatalf("%v: got '%v' at %d expected '%v' at %d",
+				test.name, err, index, test.expected, test.index)
+		}
+	}
+}
@@ -48,6 +48,17 @@ var (
 	// simNetPowLimit is the highest proof of work value a ExchangeCoin block
 	// can have for the simulation test network.  It is the value 2^256 - 1.
 	simNetPowLimit = new(big.Int).Sub(new(big.Int).Lsh(bigOne, 256), bigOne)
+
+	// RegNet parameters
+
+	regTicketPoolSize       = uint16(64)
+	regCoinbaseMaturity     = uint16(16)
+	regTicketMaturity       = uint16(16)
+	regStakeVersionInterval = int64(8 * 2 * 7)
+
+	// regNetPowLimit is the highest proof of work value a ExchangeCoin block
+	// can have for the regression test network.  It is the value 2^256 - 1.
+	regNetPowLimit = new(big.Int).Sub(new(big.Int).Lsh(bigOne, 256), bigOne)
 )
 
 // SigHashOptimization is an optimization for verification of transactions that
@@ -177,8 +188,31 @@ const (
 	// VoteIDMaxBlockSize is the vote ID for the the maximum block size
 	// increase agenda used for the hard fork demo.
 	VoteIDMaxBlockSize = "maxblocksize"
+
+	// VoteIDStakeDiffDamping is the vote ID for the agenda which changes
+	// the stake difficulty algorithm to only apply a fraction of the change
+	// calculated by the algorithm defined in DCP0001 at each retarget in
+	// order to reduce the oscillation of the ticket price.
+	VoteIDStakeDiffDamping = "sdiffdamping"
+)
+
+const (
+	// UpgradeLWMAWorkDiff is the name of the consensus upgrade which
+	// replaces the windowed proof of work difficulty retarget with one that
+	// retargets every block based on a linearly weighted moving average of
+	// the solve times of the most recent blocks.  It reacts to large swings
+	// in hashrate, which are common on small networks, without the
+	// oscillation of the windowed retarget.
+	UpgradeLWMAWorkDiff = "lwmaworkdiff"
 )
 
+// knownConsensusUpgrades houses the descriptions of all consensus upgrades the
+// validation code implements keyed by their names.
+var knownConsensusUpgrades = map[string]string{
+	UpgradeLWMAWorkDiff: "Retarget the proof of work difficulty every " +
+		"block using a linearly weighted moving average",
+}
+
 // ConsensusDeployment defines details related to a specific consensus rule
 // change that is voted in.  This is part of BIP0009.
 type ConsensusDeployment struct {
@@ -194,6 +228,25 @@ type ConsensusDeployment struct {
 	// ExpireTime is the median block time after which the attempted
 	// deployment expires.
 	ExpireTime uint64
+
+	// Quorum is the minimum number of non-abstaining votes required in a
+	// rule change interval for the deployment to be locked in.  The
+	// RuleChangeActivationQuorum of the network is used when it is zero.
+	Quorum uint32
+}
+
+// ConsensusUpgrade defines a consensus rule change that activates at a fixed
+// block height rather than being voted in, such as a hard fork.
+type ConsensusUpgrade struct {
+	// Name uniquely identifies the upgrade.  Validation code refers to the
+	// upgrade by this name in order to determine whether its rules apply.
+	Name string
+
+	// Description is a human readable description of the rule change.
+	Description string
+
+	// Height is the height of the first block the new rules apply to.
+	Height int64
 }
 
 // TokenPayout is a payout for block 1 which specifies an address and an amount
@@ -287,6 +340,13 @@ type Params struct {
 	// of the exponentially weighted average.
 	WorkDiffWindows int64
 
+	// WorkDiffLWMAWindowSize is the number of most recent blocks the
+	// linearly weighted moving average of solve times is calculated over
+	// once the UpgradeLWMAWorkDiff consensus upgrade is active.
+	//
+	// NOTE: This only applies to networks which define the upgrade.
+	WorkDiffLWMAWindowSize int64
+
 	// TargetTimespan is the desired amount of time that should elapse
 	// before the block difficulty requirement is examined to determine how
 	// it should be changed in order to maintain the desired block
@@ -299,6 +359,14 @@ type Params struct {
 	// difficulty retargets.
 	RetargetAdjustmentFactor int64
 
+	// NoDifficultyAdjustment defines whether the network keeps the
+	// required difficulty of every block at the proof of work limit instead
+	// of retargeting it based on the rate blocks are generated.  This is
+	// only useful for the regression test network, where blocks are
+	// generated far faster than the target time per block, and must not be
+	// set on a main network.
+	NoDifficultyAdjustment bool
+
 	// Subsidy parameters.
 	//
 	// Subsidy calculation for exponential reductions:
@@ -331,6 +399,14 @@ type Params struct {
 	// Checkpoints ordered from oldest to newest.
 	Checkpoints []Checkpoint
 
+	// ConsensusUpgrades defines the consensus rule changes which activate at
+	// fixed heights on the network ordered by activation height.  This is
+	// the single place the activation heights of hard forks are defined, so
+	// validation code must consult it via IsConsensusUpgradeActive instead
+	// of comparing heights directly.  Upgrades which are not listed never
+	// activate on the network.
+	ConsensusUpgrades []ConsensusUpgrade
+
 	// These fields are related to voting on consensus rule changes as
 	// defined by BIP0009.
 	//
@@ -365,6 +441,11 @@ type Params struct {
 	// for any given address encoded as a string.
 	NetworkAddressPrefix string
 
+	// Bech32HRP is the human-readable part of bech32 encoded pay-to-pubkey-
+	// hash and pay-to-script-hash addresses.  Bech32 addresses are not
+	// accepted on the network when it is empty.
+	Bech32HRP string
+
 	// Address encoding magics
 	PubKeyAddrID     [2]byte // First 2 bytes of a P2PK address
 	PubKeyHashAddrID [2]byte // First 2 bytes of a P2PKH address
@@ -490,6 +571,7 @@ var MainNetParams = Params{
 	WorkDiffAlpha:            1,
 	WorkDiffWindowSize:       144,
 	WorkDiffWindows:          20,
+	WorkDiffLWMAWindowSize:   45,
 	TargetTimespan:           defaultTargetTimePerBlock * 144, // TimePerBlock * WindowSize
 	RetargetAdjustmentFactor: 4,
 
@@ -504,6 +586,10 @@ var MainNetParams = Params{
 	// Checkpoints ordered from oldest to newest.
 	Checkpoints: []Checkpoint{},
 
+	// Consensus rule changes which activate at fixed heights ordered by
+	// activation height.
+	ConsensusUpgrades: nil,
+
 	// The miner confirmation window is defined as:
 	//   target proof of work timespan / target proof of work spacing
 	RuleChangeActivationQuorum:     4032, // 10 % of RuleChangeActivationInterval * TicketsPerBlock
@@ -540,6 +626,9 @@ var MainNetParams = Params{
 	PrivateKeyID:     0x80,                // starts with 5 (uncompressed) or K (compressed)
 	// ---------------------------------------------------------------------------------------------
 
+	// Bech32 addresses are not enabled on the main network yet.
+	Bech32HRP: "",
+
 	// BIP32 hierarchical deterministic extended key magics
 	// In order to see actual prefixes, encoded string must consist of prefix mentioned below
 	// followed by zeros up to 82 bytes total length.
@@ -599,6 +688,7 @@ var TestNet2Params = Params{
 	WorkDiffAlpha:            1,
 	WorkDiffWindowSize:       144,
 	WorkDiffWindows:          20,
+	WorkDiffLWMAWindowSize:   45,
 	TargetTimespan:           defaultTargetTimePerBlock * 144, // TimePerBlock * WindowSize
 	RetargetAdjustmentFactor: 4,
 
@@ -613,6 +703,10 @@ var TestNet2Params = Params{
 	// Checkpoints ordered from oldest to newest.
 	Checkpoints: []Checkpoint{},
 
+	// Consensus rule changes which activate at fixed heights ordered by
+	// activation height.
+	ConsensusUpgrades: nil,
+
 	// Consensus rule change deployments.
 	//
 	// The miner confirmation window is defined as:
@@ -639,6 +733,7 @@ var TestNet2Params = Params{
 
 	// Address encoding magics
 	NetworkAddressPrefix: "T",
+	Bech32HRP:            "texcc",
 	PubKeyAddrID:         [2]byte{0x28, 0xf7}, // starts with Tk
 	PubKeyHashAddrID:     [2]byte{0x0f, 0x21}, // starts with Ts
 	PKHEdwardsAddrID:     [2]byte{0x0f, 0x01}, // starts with Te
@@ -707,6 +802,7 @@ var SimNetParams = Params{
 	WorkDiffAlpha:            1,
 	WorkDiffWindowSize:       8,
 	WorkDiffWindows:          4,
+	WorkDiffLWMAWindowSize:   45,
 	TargetTimespan:           time.Second * 8, // TimePerBlock * WindowSize
 	RetargetAdjustmentFactor: 4,
 
@@ -721,6 +817,10 @@ var SimNetParams = Params{
 	// Checkpoints ordered from oldest to newest.
 	Checkpoints: nil,
 
+	// Consensus rule changes which activate at fixed heights ordered by
+	// activation height.
+	ConsensusUpgrades: nil,
+
 	// Consensus rule change deployments.
 	//
 	// The miner confirmation window is defined as:
@@ -758,6 +858,34 @@ var SimNetParams = Params{
 			StartTime:  0,             // Always available for vote
 			ExpireTime: math.MaxInt64, // Never expires
 		}},
+		5: {{
+			Vote: Vote{
+				Id:          VoteIDStakeDiffDamping,
+				Description: "Change the stake difficulty algorithm to dampen ticket price changes",
+				Mask:        0x0006, // Bits 1 and 2
+				Choices: []Choice{{
+					Id:          "abstain",
+					Description: "abstain voting for change",
+					Bits:        0x0000,
+					IsAbstain:   true,
+					IsNo:        false,
+				}, {
+					Id:          "no",
+					Description: "keep the existing stake difficulty algorithm",
+					Bits:        0x0002, // Bit 1
+					IsAbstain:   false,
+					IsNo:        true,
+				}, {
+					Id:          "yes",
+					Description: "change to the damped stake difficulty algorithm",
+					Bits:        0x0004, // Bit 2
+					IsAbstain:   false,
+					IsNo:        false,
+				}},
+			},
+			StartTime:  0,             // Always available for vote
+			ExpireTime: math.MaxInt64, // Never expires
+		}},
 	},
 
 	// Enforce current block version once majority of the network has
@@ -776,6 +904,7 @@ var SimNetParams = Params{
 
 	// Address encoding magics
 	NetworkAddressPrefix: "S",
+	Bech32HRP:            "sexcc",
 	PubKeyAddrID:         [2]byte{0x27, 0x6f}, // starts with Sk
 	PubKeyHashAddrID:     [2]byte{0x0e, 0x91}, // starts with Ss
 	PKHEdwardsAddrID:     [2]byte{0x0e, 0x71}, // starts with Se
@@ -815,6 +944,125 @@ var SimNetParams = Params{
 	BlockOneLedger: BlockOneLedgerSimNet,
 }
 
+// RegNetParams defines the network parameters for the regression test
+// ExchangeCoin network.  This network is intended for automated testing of
+// applications built on exccd.  Unlike the simulation test network, the
+// difficulty never rises above the proof of work limit regardless of how fast
+// blocks are generated and votes are never required, so thousands of blocks
+// can be generated in a matter of seconds without having to purchase tickets
+// or run a voting wallet.  As with the simulation test network, only peers
+// which are specifically specified are used.
+var RegNetParams = Params{
+	Name:        "regnet",
+	Net:         wire.RegTest,
+	DefaultPort: "18655",
+	DNSSeeds:    []DNSSeed{}, // NOTE: There must NOT be any seeds.
+	N:           48,
+	K:           5,
+
+	// Chain parameters
+	GenesisBlock:             &regNetGenesisBlock,
+	GenesisHash:              &regNetGenesisHash,
+	PowLimit:                 regNetPowLimit,
+	PowLimitBits:             bigToCompact(regNetPowLimit),
+	ReduceMinDifficulty:      false,
+	MinDiffReductionTime:     0, // Does not apply since ReduceMinDifficulty false
+	GenerateSupported:        true,
+	MaximumBlockSizes:        []int{1310720},
+	MaxTxSize:                1000000,
+	TargetTimePerBlock:       time.Second,
+	WorkDiffAlpha:            1,
+	WorkDiffWindowSize:       8,
+	WorkDiffWindows:          4,
+	WorkDiffLWMAWindowSize:   45,
+	TargetTimespan:           time.Second * 8, // TimePerBlock * WindowSize
+	RetargetAdjustmentFactor: 4,
+	NoDifficultyAdjustment:   true,
+
+	// Subsidy parameters.
+	BaseSubsidy:              50000000000,
+	MulSubsidy:               100,
+	DivSubsidy:               101,
+	SubsidyReductionInterval: 128,
+	WorkRewardProportion:     7,
+	StakeRewardProportion:    3,
+
+	// Checkpoints ordered from oldest to newest.
+	Checkpoints: nil,
+
+	// Consensus rule changes which activate at fixed heights ordered by
+	// activation height.
+	ConsensusUpgrades: nil,
+
+	// Consensus rule change deployments.
+	//
+	// There are no deployments since votes are never required and
+	// therefore rule changes could never be voted in.
+	RuleChangeActivationQuorum:     160, // 10 % of RuleChangeActivationInterval * TicketsPerBlock
+	RuleChangeActivationMultiplier: 3,   // 75%
+	RuleChangeActivationDivisor:    4,
+	RuleChangeActivationInterval:   320, // 320 seconds
+	Deployments:                    map[uint32][]ConsensusDeployment{},
+
+	// Enforce current block version once majority of the network has
+	// upgraded.
+	// 51% (51 / 100)
+	// Reject previous block versions once a majority of the network has
+	// upgraded.
+	// 75% (75 / 100)
+	BlockEnforceNumRequired: 51,
+	BlockRejectNumRequired:  75,
+	BlockUpgradeNumToCheck:  100,
+
+	// AcceptNonStdTxs is a mempool param to either accept and relay
+	// non standard txs to the network or reject them
+	AcceptNonStdTxs: true,
+
+	// Address encoding magics
+	NetworkAddressPrefix: "R",
+	Bech32HRP:            "rexcc",
+	PubKeyAddrID:         [2]byte{0x25, 0xe5}, // starts with Rk
+	PubKeyHashAddrID:     [2]byte{0x0e, 0x00}, // starts with Rs
+	PKHEdwardsAddrID:     [2]byte{0x0d, 0xe0}, // starts with Re
+	PKHSchnorrAddrID:     [2]byte{0x0d, 0xc2}, // starts with RS
+	ScriptHashAddrID:     [2]byte{0x0d, 0xbb}, // starts with Rc
+	PrivateKeyID:         0xef,                // starts with 9 (uncompressed) or c (compressed)
+
+	// BIP32 hierarchical deterministic extended key magics
+	HDPrivateKeyID: [4]byte{0xea, 0xb4, 0x04, 0x48}, // starts with rprv
+	HDPublicKeyID:  [4]byte{0xea, 0xb4, 0xf9, 0x87}, // starts with rpub
+
+	// BIP44 coin type used in the hierarchical deterministic path for
+	// address generation.
+	HDCoinType: 1,
+
+	// ExchangeCoin PoS parameters
+	//
+	// The stake validation height is set such that it is never reached, so
+	// tickets may be purchased, but blocks never require votes.
+	MinimumStakeDiff:        20000,
+	TicketPoolSize:          regTicketPoolSize,
+	TicketsPerBlock:         5,
+	TicketMaturity:          regTicketMaturity,
+	TicketExpiry:            uint32(6 * regTicketPoolSize), // 6*TicketPoolSize
+	CoinbaseMaturity:        regCoinbaseMaturity,
+	SStxChangeMaturity:      1,
+	TicketPoolSizeWeight:    4,
+	StakeDiffAlpha:          1,
+	StakeDiffWindowSize:     8,
+	StakeDiffWindows:        8,
+	StakeVersionInterval:    regStakeVersionInterval,
+	MaxFreshStakePerBlock:   20,                                             // 4*TicketsPerBlock
+	StakeEnabledHeight:      int64(regCoinbaseMaturity + regTicketMaturity), // CoinbaseMaturity + TicketMaturity
+	StakeValidationHeight:   math.MaxInt32,                                  // Never reached
+	StakeBaseSigScript:      []byte{0xDE, 0xAD, 0xBE, 0xEF},
+	StakeMajorityMultiplier: 3,
+	StakeMajorityDivisor:    4,
+
+	// ExchangeCoin organization related parameters
+	BlockOneLedger: BlockOneLedgerSimNet,
+}
+
 var (
 	// ErrDuplicateNet describes an error where the parameters for a ExchangeCoin
 	// network could not be set due to the network already being a standard
@@ -835,6 +1083,8 @@ var (
 	pkhSchnorrAddrIDs = make(map[[2]byte]struct{})
 	scriptHashAddrIDs = make(map[[2]byte]struct{})
 	hdPrivToPubKeyIDs = make(map[[4]byte][]byte)
+	addrPrefixParams  = make(map[string][]*Params)
+	bech32HRPParams   = make(map[string]*Params)
 )
 
 // String returns the hostname of the DNS seed in human-readable form.
@@ -860,6 +1110,15 @@ func Register(params *Params) error {
 	pubKeyHashAddrIDs[params.PubKeyHashAddrID] = struct{}{}
 	scriptHashAddrIDs[params.ScriptHashAddrID] = struct{}{}
 	hdPrivToPubKeyIDs[params.HDPrivateKeyID] = params.HDPublicKeyID[:]
+	if params.NetworkAddressPrefix != "" {
+		prefix := params.NetworkAddressPrefix
+		addrPrefixParams[prefix] = append(addrPrefixParams[prefix], params)
+	}
+	if _, ok := bech32HRPParams[params.Bech32HRP]; !ok &&
+		params.Bech32HRP != "" {
+
+		bech32HRPParams[params.Bech32HRP] = params
+	}
 	return nil
 }
 
@@ -914,6 +1173,24 @@ func IsScriptHashAddrID(id [2]byte) bool {
 	return ok
 }
 
+// ParamsByNetworkAddressPrefix returns the parameters of all default and
+// registered networks whose string encoded addresses start with the passed
+// prefix, in the order the networks were registered.  Since a custom network
+// may reuse the prefix of another network, callers must check the address
+// identifiers of the returned networks to pick the one an address belongs to.
+func ParamsByNetworkAddressPrefix(prefix string) []*Params {
+	return addrPrefixParams[prefix]
+}
+
+// ParamsByBech32HRP returns the parameters of the default or registered
+// network which uses the passed human-readable part for its bech32 addresses.
+// Bech32 addresses carry no other network identifier, so when several networks
+// share a human-readable part, the one registered first is returned.
+func ParamsByBech32HRP(hrp string) (*Params, bool) {
+	params, ok := bech32HRPParams[hrp]
+	return params, ok
+}
+
 // HDPrivateKeyToPublicKeyID accepts a private hierarchical deterministic
 // extended key id and returns the associated public key id.  When the provided
 // id is not registered, the ErrUnknownHDKeyID error will be returned.
@@ -988,11 +1265,71 @@ func (p *Params) LatestCheckpointHeight() int64 {
 	return p.Checkpoints[len(p.Checkpoints)-1].Height
 }
 
+// ConsensusUpgradeHeight returns the height of the first block the consensus
+// upgrade with the provided name applies to and whether the network defines
+// the upgrade at all.
+func (p *Params) ConsensusUpgradeHeight(name string) (int64, bool) {
+	for i := range p.ConsensusUpgrades {
+		if p.ConsensusUpgrades[i].Name == name {
+			return p.ConsensusUpgrades[i].Height, true
+		}
+	}
+	return 0, false
+}
+
+// IsConsensusUpgradeActive returns whether the rules of the consensus upgrade
+// with the provided name apply to the block at the passed height.  False is
+// returned when the network does not define the upgrade.
+func (p *Params) IsConsensusUpgradeActive(name string, height int64) bool {
+	activationHeight, ok := p.ConsensusUpgradeHeight(name)
+	return ok && height >= activationHeight
+}
+
+// DeploymentByID returns the stake version and the definition of the consensus
+// deployment with the provided vote ID.  Nil is returned for the deployment
+// when the network does not define it.
+//
+// A deployment may be defined again for a later stake version, such as when
+// its vote failed and is held again, so the definition for the highest stake
+// version is returned in that case.
+//
+// This allows code which enforces the rules of a deployment to determine
+// whether it is active without having to hardcode the stake version the
+// deployment was defined for on each network.
+func (p *Params) DeploymentByID(voteID string) (uint32, *ConsensusDeployment) {
+	var version uint32
+	var deployment *ConsensusDeployment
+	for v, deployments := range p.Deployments {
+		if deployment != nil && v < version {
+			continue
+		}
+		for i := range deployments {
+			if deployments[i].Vote.Id == voteID {
+				version, deployment = v, &deployments[i]
+				break
+			}
+		}
+	}
+	return version, deployment
+}
+
+// DeploymentQuorum returns the minimum number of non-abstaining votes required
+// for the provided deployment to be locked in, which is the quorum defined by
+// the deployment when it is set and the rule change activation quorum of the
+// network otherwise.
+func (p *Params) DeploymentQuorum(deployment *ConsensusDeployment) uint32 {
+	if deployment.Quorum != 0 {
+		return deployment.Quorum
+	}
+	return p.RuleChangeActivationQuorum
+}
+
 func init() {
 	// Register all default networks when the package is initialized.
 	mustRegister(&MainNetParams)
 	mustRegister(&TestNet2Params)
 	mustRegister(&SimNetParams)
+	mustRegister(&RegNetParams)
 }
 
 // BigToCompact converts a whole number N to a compact representation using
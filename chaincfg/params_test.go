@@ -24,3 +24,88 @@ func TestMustRegisterPanic(t *testing.T) {
 	// Intentionally try to register duplicate params to force a panic.
 	mustRegister(&MainNetParams)
 }
+
+// TestDeploymentByID ensures deployments are found by their vote ID regardless
+// of the stake version they are defined for, that the definition for the
+// highest version is found for a deployment defined for multiple versions, and
+// that the quorum of a deployment falls back to the quorum of the network.
+func TestDeploymentByID(t *testing.T) {
+	t.Parallel()
+
+	params := SimNetParams
+	version, deployment := params.DeploymentByID(VoteIDMaxBlockSize)
+	if deployment == nil {
+		t.Fatalf("DeploymentByID: did not find %q", VoteIDMaxBlockSize)
+	}
+	if version != 4 {
+		t.Fatalf("DeploymentByID: unexpected version - got %d, want 4",
+			version)
+	}
+	if _, d := params.DeploymentByID("nonexistent"); d != nil {
+		t.Fatal("DeploymentByID: found nonexistent deployment")
+	}
+
+	// The definition for the highest stake version is returned when a
+	// deployment is defined for multiple versions.  Since the deployments
+	// are kept in a map, look it up repeatedly to make sure the result
+	// does not depend on the iteration order.
+	revote := SimNetParams
+	revote.Deployments = make(map[uint32][]ConsensusDeployment)
+	for v := uint32(4); v < 12; v++ {
+		revote.Deployments[v] = []ConsensusDeployment{{
+			Vote:       Vote{Id: VoteIDMaxBlockSize},
+			ExpireTime: uint64(v),
+		}}
+	}
+	for i := 0; i < 20; i++ {
+		version, d := revote.DeploymentByID(VoteIDMaxBlockSize)
+		if version != 11 || d == nil || d.ExpireTime != 11 {
+			t.Fatalf("DeploymentByID: unexpected duplicate deployment "+
+				"- got version %d, want 11", version)
+		}
+	}
+
+	quorum := params.DeploymentQuorum(deployment)
+	if quorum != params.RuleChangeActivationQuorum {
+		t.Fatalf("DeploymentQuorum: unexpected quorum - got %d, want %d",
+			quorum, params.RuleChangeActivationQuorum)
+	}
+	override := *deployment
+	override.Quorum = 10
+	if quorum := params.DeploymentQuorum(&override); quorum != 10 {
+		t.Fatalf("DeploymentQuorum: unexpected quorum - got %d, want 10",
+			quorum)
+	}
+}
+
+// TestConsensusUpgradeActive ensures consensus upgrades only apply from their
+// activation height onwards and never apply when the network does not define
+// them.
+func TestConsensusUpgradeActive(t *testing.T) {
+	t.Parallel()
+
+	params := SimNetParams
+	params.ConsensusUpgrades = []ConsensusUpgrade{{Name: "fork", Height: 100}}
+	if height, ok := params.ConsensusUpgradeHeight("fork"); !ok ||
+		height != 100 {
+		t.Fatalf("ConsensusUpgradeHeight: unexpected height %d (%v)",
+			height, ok)
+	}
+	tests := []struct {
+		name   string
+		height int64
+		want   bool
+	}{
+		{"fork", 99, false},
+		{"fork", 100, true},
+		{"fork", 101, true},
+		{"undefined", 1000000, false},
+	}
+	for _, test := range tests {
+		got := params.IsConsensusUpgradeActive(test.name, test.height)
+		if got != test.want {
+			t.Errorf("IsConsensusUpgradeActive(%q, %d): got %v, want %v",
+				test.name, test.height, got, test.want)
+		}
+	}
+}
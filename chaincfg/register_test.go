@@ -66,6 +66,11 @@ func TestRegister(t *testing.T) {
 					params: &SimNetParams,
 					err:    ErrDuplicateNet,
 				},
+				{
+					name:   "duplicate regnet",
+					params: &RegNetParams,
+					err:    ErrDuplicateNet,
+				},
 			},
 			p2pkhMagics: []magicTest{
 				{
@@ -220,6 +225,11 @@ func TestRegister(t *testing.T) {
 					params: &SimNetParams,
 					err:    ErrDuplicateNet,
 				},
+				{
+					name:   "duplicate regnet",
+					params: &RegNetParams,
+					err:    ErrDuplicateNet,
+				},
 				{
 					name:   "duplicate mocknet",
 					params: &mockNetParams,
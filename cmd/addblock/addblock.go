@@ -8,6 +8,7 @@ package main
 
 import (
 	"os"
+	"os/signal"
 	"path/filepath"
 	"runtime"
 
@@ -93,21 +94,48 @@ func realMain() error {
 	}
 	defer fi.Close()
 
+	// The progress of an import is tracked by the absolute path and size of
+	// the input file so it is only resumed for the same file.
+	inFile, err := filepath.Abs(cfg.InFile)
+	if err != nil {
+		log.Errorf("Failed to determine path of file %v: %v", cfg.InFile,
+			err)
+		return err
+	}
+	fileInfo, err := fi.Stat()
+	if err != nil {
+		log.Errorf("Failed to stat file %v: %v", cfg.InFile, err)
+		return err
+	}
+
 	// Create a block importer for the database and input file and start it.
 	// The done channel returned from start will contain an error if
 	// anything went wrong.
-	importer, err := newBlockImporter(db, fi)
+	importer, err := newBlockImporter(db, fi, inFile, fileInfo.Size())
 	if err != nil {
 		log.Errorf("Failed create block importer: %v", err)
 		return err
 	}
 
-	// Perform the import asynchronously.  This allows blocks to be
-	// processed and read in parallel.  The results channel returned from
-	// Import contains the statistics about the import including an error
-	// if something went wrong.
+	// Stop reading blocks on interrupt so the import can be resumed from
+	// where it left off.
+	interrupt := make(chan struct{})
+	interruptSignal := make(chan os.Signal, 1)
+	signal.Notify(interruptSignal, os.Interrupt)
+	defer signal.Stop(interruptSignal)
+	go func() {
+		<-interruptSignal
+		log.Info("Received interrupt signal, finishing the blocks " +
+			"already read")
+		close(interrupt)
+	}()
+
+	// Perform the import asynchronously.  This allows blocks to be read,
+	// validated, and processed in parallel.  The results channel returned
+	// from Import contains the statistics about the import including an
+	// error if something went wrong.
 	log.Info("Starting import")
-	resultsChan := importer.Import()
+	resultsChan := importer.Import(interrupt)
 	results := <-resultsChan
 	if results.err != nil {
 		log.Errorf("%v", results.err)
@@ -117,6 +145,17 @@ func realMain() error {
 	log.Infof("Processed a total of %d blocks (%d imported, %d already "+
 		"known) in %v", results.blocksProcessed, results.blocksImported,
 		results.blocksProcessed-results.blocksImported, results.duration)
+	if results.interrupted {
+		log.Info("Import interrupted -- run again with the same block " +
+			"file to resume it")
+		return nil
+	}
+
+	// The import is complete, so there is nothing left to resume.
+	if err := removeProgress(); err != nil {
+		log.Errorf("Failed to remove import progress: %v", err)
+		return err
+	}
 
 	return nil
 }
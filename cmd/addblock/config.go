@@ -45,6 +45,8 @@ type config struct {
 	TxIndex           bool   `long:"txindex" description:"Build a full hash-based transaction index which makes all transactions available via the getrawtransaction RPC"`
 	AddrIndex         bool   `long:"addrindex" description:"Build a full address-based transaction index which makes the searchrawtransactions RPC available"`
 	Progress          int    `short:"p" long:"progress" description:"Show a progress message each time this number of seconds have passed -- Use 0 to disable progress announcements"`
+	Workers           int    `short:"w" long:"workers" description:"Number of workers which validate blocks in parallel -- Use 0 for one per processor core"`
+	NoResume          bool   `long:"noresume" description:"Do not resume an interrupted import of the block file and start from its beginning instead"`
 }
 
 // filesExists reports whether the named file or directory exists.
@@ -138,6 +140,16 @@ func loadConfig() (*config, []string, error) {
 		return nil, nil, err
 	}
 
+	// Validate the number of workers.
+	if cfg.Workers < 0 {
+		str := "%s: the number of workers may not be negative -- " +
+			"parsed [%d]"
+		err := fmt.Errorf(str, "loadConfig", cfg.Workers)
+		fmt.Fprintln(os.Stderr, err)
+		parser.WriteHelp(os.Stderr)
+		return nil, nil, err
+	}
+
 	// Append the network type to the data directory so it is "namespaced"
 	// per network.  In addition to the block database, there are other
 	// pieces of data that are saved to disk such as address manager state.
@@ -10,6 +10,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"io"
+	"runtime"
 	"sync"
 	"time"
 
@@ -23,25 +24,70 @@ import (
 
 var zeroHash = chainhash.Hash{}
 
+const (
+	// maxBlocksInFlightPerWorker is the maximum number of blocks per
+	// worker which may be read from the import file ahead of the block
+	// currently being processed.  It bounds the memory used by blocks
+	// which are validated out of order while waiting for their turn to be
+	// processed.
+	maxBlocksInFlightPerWorker = 8
+
+	// progressSaveInterval is the number of processed blocks between saves
+	// of the import progress.
+	progressSaveInterval = 1000
+)
+
 // importResults houses the stats and result as an import operation.
 type importResults struct {
 	blocksProcessed int64
 	blocksImported  int64
 	duration        time.Duration
+	interrupted     bool
+	err             error
+}
+
+// importJob houses a block read from the import file which is to be validated
+// by a worker.  Blocks are numbered by their position in the file so they are
+// processed in order once validated, and errors reading the file are passed
+// along in the same way so they are reported after the blocks preceding them.
+type importJob struct {
+	seq             int64
+	offset          int64
+	serializedBlock []byte
 	err             error
 }
 
+// validatedBlock houses a block which passed the context-free checks performed
+// by a worker or the error which caused it to fail them.  The offset is that
+// of the block following it in the import file.
+type validatedBlock struct {
+	seq    int64
+	offset int64
+	block  *exccutil.Block
+	err    error
+}
+
 // blockImporter houses information about an ongoing import from a block data
 // file to the block database.
 type blockImporter struct {
 	db                database.DB
 	chain             *blockchain.BlockChain
+	timeSource        blockchain.MedianTimeSource
 	r                 io.ReadSeeker
-	processQueue      chan []byte
+	numWorkers        int
+	jobs              chan *importJob
+	validated         chan *validatedBlock
+	slots             chan struct{}
 	doneChan          chan bool
 	errChan           chan error
 	quit              chan struct{}
+	interrupt         <-chan struct{}
+	interrupted       bool
 	wg                sync.WaitGroup
+	workerWg          sync.WaitGroup
+	offset            int64
+	progress          importProgress
+	unsavedBlocks     int64
 	blocksProcessed   int64
 	blocksImported    int64
 	receivedLogBlocks int64
@@ -86,25 +132,81 @@ func (bi *blockImporter) readBlock() ([]byte, error) {
 	if _, err := io.ReadFull(bi.r, serializedBlock); err != nil {
 		return nil, err
 	}
+	bi.offset += 8 + int64(blockLen)
 
 	return serializedBlock, nil
 }
 
-// processBlock potentially imports the block into the database.  It first
-// deserializes the raw block while checking for errors.  Already known blocks
-// are skipped and orphan blocks are considered errors.  Finally, it runs the
-// block through the chain rules to ensure it follows all rules and matches
-// up to the known checkpoint.  Returns whether the block was imported along
-// with any potential errors.
-func (bi *blockImporter) processBlock(serializedBlock []byte) (bool, error) {
-	// Deserialize the block which includes checks for malformed blocks.
-	block, err := exccutil.NewBlockFromBytes(serializedBlock)
+// resume seeks the import file to the block following the last block processed
+// by a previous import of the same file into the database when its progress
+// was saved.  The import starts from the beginning of the file when there is no
+// saved progress for the file or the database no longer contains the last
+// processed block, such as when it was not flushed to disk before an unclean
+// shutdown.
+func (bi *blockImporter) resume(inFile string, size int64) error {
+	bi.progress = importProgress{InFile: inFile, Size: size}
+	if cfg.NoResume {
+		return nil
+	}
+
+	progress, err := loadProgress()
 	if err != nil {
-		return false, err
+		return fmt.Errorf("unable to load import progress: %v", err)
+	}
+	if progress == nil || progress.InFile != inFile ||
+		progress.Size != size || progress.Offset > size {
+
+		return nil
 	}
+	hash, err := chainhash.NewHashFromStr(progress.Hash)
+	if err != nil {
+		return fmt.Errorf("unable to load import progress: %v", err)
+	}
+	exists, err := bi.chain.HaveBlock(hash)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		log.Infof("Restarting import from the beginning of the file "+
+			"since the database does not contain block %v", hash)
+		return nil
+	}
+
+	if _, err := bi.r.Seek(progress.Offset, io.SeekStart); err != nil {
+		return err
+	}
+	bi.offset = progress.Offset
+	bi.progress = *progress
+	log.Infof("Resuming import after block %v (%d blocks of the file "+
+		"already processed)", hash, progress.Blocks)
+	return nil
+}
 
+// saveProgress saves the progress of the import when any blocks were processed
+// since it was last saved.
+func (bi *blockImporter) saveProgress() error {
+	if bi.unsavedBlocks == 0 {
+		return nil
+	}
+	if err := saveProgress(&bi.progress); err != nil {
+		return fmt.Errorf("unable to save import progress: %v", err)
+	}
+	bi.unsavedBlocks = 0
+	return nil
+}
+
+// processBlock potentially imports the block into the database.  Already known
+// blocks are skipped and orphan blocks are considered errors.  Finally, it runs
+// the block through the chain rules to ensure it follows all rules and matches
+// up to the known checkpoint.  Returns whether the block was imported along
+// with any potential errors.
+//
+// The block must have already passed the context-free checks, including the
+// proof of work check, which are performed by the workers.
+func (bi *blockImporter) processBlock(block *exccutil.Block) (bool, error) {
 	// update progress statistics
 	bi.lastBlockTime = block.MsgBlock().Header.Timestamp
+	bi.lastHeight = block.Height()
 	bi.receivedLogTx += int64(len(block.MsgBlock().Transactions))
 
 	// Skip blocks that already exist.
@@ -132,9 +234,10 @@ func (bi *blockImporter) processBlock(serializedBlock []byte) (bool, error) {
 	}
 
 	// Ensure the blocks follows all of the chain rules and match up to the
-	// known checkpoints.
+	// known checkpoints.  The proof of work was already checked by the
+	// worker which validated the block.
 	isMainChain, isOrphan, err := bi.chain.ProcessBlock(block,
-		blockchain.BFFastAdd)
+		blockchain.BFFastAdd|blockchain.BFNoPoWCheck)
 	if err != nil {
 		return false, err
 	}
@@ -152,36 +255,93 @@ func (bi *blockImporter) processBlock(serializedBlock []byte) (bool, error) {
 
 // readHandler is the main handler for reading blocks from the import file.
 // This allows block processing to take place in parallel with block reads.
-// It must be run as a goroutine.
+// The number of blocks read ahead of the block being processed is limited by
+// the available slots.  It must be run as a goroutine.
 func (bi *blockImporter) readHandler() {
-out:
-	for {
-		// Read the next block from the file and if anything goes wrong
-		// notify the status handler with the error and bail.
-		serializedBlock, err := bi.readBlock()
-		if err != nil {
-			bi.errChan <- fmt.Errorf("error reading from input "+
-				"file: %v", err.Error())
-			break out
+	defer bi.wg.Done()
+	defer close(bi.jobs)
+
+	for seq := int64(0); ; seq++ {
+		// Wait for a slot to become available or quit if we've been
+		// signalled to exit by the status handler due to an error
+		// elsewhere or interrupted by the user.
+		select {
+		case bi.slots <- struct{}{}:
+		case <-bi.quit:
+			return
+		case <-bi.interrupt:
+			bi.interrupted = true
+			return
 		}
 
-		// A nil block with no error means we're done.
-		if serializedBlock == nil {
-			break out
+		// Read the next block from the file and if anything goes wrong
+		// pass the error along so it is reported once the blocks
+		// preceding it are processed.
+		job := &importJob{seq: seq}
+		job.serializedBlock, job.err = bi.readBlock()
+		if job.err != nil {
+			job.err = fmt.Errorf("error reading from input file: %v",
+				job.err)
+		} else if job.serializedBlock == nil {
+			// A nil block with no error means we're done.
+			return
 		}
+		job.offset = bi.offset
 
-		// Send the block or quit if we've been signalled to exit by
-		// the status handler due to an error elsewhere.
 		select {
-		case bi.processQueue <- serializedBlock:
+		case bi.jobs <- job:
 		case <-bi.quit:
-			break out
+			return
+		}
+		if job.err != nil {
+			return
 		}
 	}
+}
+
+// checkBlock performs the context-free checks on the passed block, such as the
+// proof of work check.  Blocks which already exist are skipped since they are
+// not processed.
+func (bi *blockImporter) checkBlock(block *exccutil.Block) error {
+	exists, err := bi.chain.HaveBlock(block.Hash())
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
 
-	// Close the processing channel to signal no more blocks are coming.
-	close(bi.processQueue)
-	bi.wg.Done()
+	err = blockchain.CheckBlockSanity(block, bi.timeSource, activeNetParams)
+	if err != nil {
+		return fmt.Errorf("import file contains invalid block %v: %v",
+			block.Hash(), err)
+	}
+	return nil
+}
+
+// validateHandler is the handler for the workers which deserialize the blocks
+// read from the import file and perform the context-free checks on them, such
+// as the proof of work check, in parallel.  It must be run as a goroutine.
+func (bi *blockImporter) validateHandler() {
+	defer bi.workerWg.Done()
+
+	for job := range bi.jobs {
+		v := &validatedBlock{seq: job.seq, offset: job.offset, err: job.err}
+		if v.err == nil {
+			// Deserialize the block which includes checks for
+			// malformed blocks.
+			v.block, v.err = exccutil.NewBlockFromBytes(job.serializedBlock)
+		}
+		if v.err == nil {
+			v.err = bi.checkBlock(v.block)
+		}
+
+		select {
+		case bi.validated <- v:
+		case <-bi.quit:
+			return
+		}
+	}
 }
 
 // logProgress logs block progress as an information message.  In order to
@@ -219,37 +379,77 @@ func (bi *blockImporter) logProgress() {
 }
 
 // processHandler is the main handler for processing blocks.  This allows block
-// processing to take place in parallel with block reads from the import file.
-// It must be run as a goroutine.
+// processing to take place in parallel with block reads from the import file
+// and their validation by the workers.  Since the workers may finish validating
+// blocks out of order, blocks are held until all of the blocks preceding them
+// in the import file are processed.  The progress of the import is saved
+// periodically and when the handler exits.  It must be run as a goroutine.
 func (bi *blockImporter) processHandler() {
+	defer bi.wg.Done()
+
+	pending := make(map[int64]*validatedBlock)
+	var next int64
+	var processErr error
 out:
 	for {
-		select {
-		case serializedBlock, ok := <-bi.processQueue:
-			// We're done when the channel is closed.
-			if !ok {
+		v, ok := pending[next]
+		if !ok {
+			select {
+			case v, ok := <-bi.validated:
+				// We're done when the channel is closed.
+				if !ok {
+					break out
+				}
+				pending[v.seq] = v
+
+			case <-bi.quit:
 				break out
 			}
+			continue
+		}
+		delete(pending, next)
+		next++
 
-			bi.blocksProcessed++
-			bi.lastHeight++
-			imported, err := bi.processBlock(serializedBlock)
-			if err != nil {
-				bi.errChan <- err
-				break out
-			}
+		if v.err != nil {
+			processErr = v.err
+			break out
+		}
+		bi.blocksProcessed++
+		imported, err := bi.processBlock(v.block)
+		if err != nil {
+			processErr = err
+			break out
+		}
+		if imported {
+			bi.blocksImported++
+		}
 
-			if imported {
-				bi.blocksImported++
+		// Release the slot of the block so another may be read and
+		// record the progress.
+		<-bi.slots
+		bi.progress.Offset = v.offset
+		bi.progress.Blocks++
+		bi.progress.Hash = v.block.Hash().String()
+		bi.unsavedBlocks++
+		if bi.unsavedBlocks >= progressSaveInterval {
+			if err := bi.saveProgress(); err != nil {
+				processErr = err
+				break out
 			}
+		}
 
-			bi.logProgress()
+		bi.logProgress()
+	}
 
-		case <-bi.quit:
-			break out
-		}
+	// Save the progress made before exiting so it can be resumed.  An
+	// error processing a block takes precedence over one saving the
+	// progress.
+	if err := bi.saveProgress(); err != nil && processErr == nil {
+		processErr = err
+	}
+	if processErr != nil {
+		bi.errChan <- processErr
 	}
-	bi.wg.Done()
 }
 
 // statusHandler waits for updates from the import operation and notifies
@@ -274,6 +474,7 @@ func (bi *blockImporter) statusHandler(resultsChan chan *importResults) {
 			blocksProcessed: bi.blocksProcessed,
 			blocksImported:  bi.blocksImported,
 			duration:        time.Since(bi.startTime),
+			interrupted:     bi.interrupted,
 			err:             nil,
 		}
 	}
@@ -282,11 +483,26 @@ func (bi *blockImporter) statusHandler(resultsChan chan *importResults) {
 // Import is the core function which handles importing the blocks from the file
 // associated with the block importer to the database.  It returns a channel
 // on which the results will be returned when the operation has completed.
-func (bi *blockImporter) Import() chan *importResults {
-	// Start up the read and process handling goroutines.  This setup allows
-	// blocks to be read from disk in parallel while being processed.
+//
+// Reading stops when the passed interrupt channel is closed, in which case the
+// blocks already read are still processed and the progress is saved so the
+// import can be resumed.
+func (bi *blockImporter) Import(interrupt <-chan struct{}) chan *importResults {
+	bi.interrupt = interrupt
+
+	// Start up the read, validation, and process handling goroutines.
+	// This setup allows blocks to be read from disk and validated by
+	// several workers in parallel while being processed.
 	bi.wg.Add(2)
 	go bi.readHandler()
+	bi.workerWg.Add(bi.numWorkers)
+	for i := 0; i < bi.numWorkers; i++ {
+		go bi.validateHandler()
+	}
+	go func() {
+		bi.workerWg.Wait()
+		close(bi.validated)
+	}()
 	go bi.processHandler()
 
 	// Wait for the import to finish in a separate goroutine and signal
@@ -304,8 +520,10 @@ func (bi *blockImporter) Import() chan *importResults {
 }
 
 // newBlockImporter returns a new importer for the provided file reader seeker
-// and database.
-func newBlockImporter(db database.DB, r io.ReadSeeker) (*blockImporter, error) {
+// and database.  The import resumes from the progress saved by a previous
+// import of the same file, which is identified by the provided path and size,
+// unless disabled by the configuration.
+func newBlockImporter(db database.DB, r io.ReadSeeker, inFile string, size int64) (*blockImporter, error) {
 	// Create the various indexes as needed.
 	//
 	// CAUTION: the txindex needs to be first in the indexes array because
@@ -337,29 +555,54 @@ func newBlockImporter(db database.DB, r io.ReadSeeker) (*blockImporter, error) {
 
 	// Create an index manager if any of the optional indexes are enabled.
 	var indexManager blockchain.IndexManager
+	var idxManager *indexers.Manager
 	if len(indexes) > 0 {
-		indexManager = indexers.NewManager(db, indexes, activeNetParams)
+		idxManager = indexers.NewManager(db, indexes, activeNetParams)
+		indexManager = idxManager
 	}
 
+	timeSource := blockchain.NewMedianTime()
 	chain, err := blockchain.New(&blockchain.Config{
 		DB:           db,
 		ChainParams:  activeNetParams,
-		TimeSource:   blockchain.NewMedianTime(),
+		TimeSource:   timeSource,
 		IndexManager: indexManager,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return &blockImporter{
-		db:           db,
-		r:            r,
-		processQueue: make(chan []byte, 2),
-		doneChan:     make(chan bool),
-		errChan:      make(chan error),
-		quit:         make(chan struct{}),
-		chain:        chain,
-		lastLogTime:  time.Now(),
-		startTime:    time.Now(),
-	}, nil
+	// Load the indexes and wait for them to catch up to the current best
+	// chain tip so they are updated along with the imported blocks.
+	if idxManager != nil {
+		if err := idxManager.LoadAndWait(); err != nil {
+			return nil, err
+		}
+	}
+
+	numWorkers := cfg.Workers
+	if numWorkers == 0 {
+		numWorkers = runtime.NumCPU()
+	}
+	log.Infof("Validating blocks with %d workers", numWorkers)
+
+	bi := &blockImporter{
+		db:          db,
+		r:           r,
+		numWorkers:  numWorkers,
+		jobs:        make(chan *importJob, numWorkers),
+		validated:   make(chan *validatedBlock, numWorkers),
+		slots:       make(chan struct{}, numWorkers*maxBlocksInFlightPerWorker),
+		doneChan:    make(chan bool),
+		errChan:     make(chan error),
+		quit:        make(chan struct{}),
+		chain:       chain,
+		timeSource:  timeSource,
+		lastLogTime: time.Now(),
+		startTime:   time.Now(),
+	}
+	if err := bi.resume(inFile, size); err != nil {
+		return nil, err
+	}
+	return bi, nil
 }
@@ -0,0 +1,76 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// progressFileName is the name of the file in the data directory which tracks
+// the progress of an import so it may be resumed after an interruption.
+const progressFileName = "addblock.progress"
+
+// importProgress records how far an import has progressed through an import
+// file.  The offset is that of the block following the last processed block,
+// which is identified by its hash so a resumed import can ensure the database
+// still contains it.
+type importProgress struct {
+	InFile string `json:"infile"`
+	Size   int64  `json:"size"`
+	Offset int64  `json:"offset"`
+	Blocks int64  `json:"blocks"`
+	Hash   string `json:"hash"`
+}
+
+// progressFilePath returns the path of the file which tracks the progress of
+// imports into the configured data directory.
+func progressFilePath() string {
+	return filepath.Join(cfg.DataDir, progressFileName)
+}
+
+// loadProgress loads the progress of a previous import from the progress file.
+// Nil is returned without an error when there is no progress file.
+func loadProgress() (*importProgress, error) {
+	b, err := ioutil.ReadFile(progressFilePath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var progress importProgress
+	if err := json.Unmarshal(b, &progress); err != nil {
+		return nil, err
+	}
+	return &progress, nil
+}
+
+// saveProgress writes the passed progress to the progress file.  It is written
+// to a temporary file first so an interruption while saving never leaves a
+// partially written progress file behind.
+func saveProgress(progress *importProgress) error {
+	b, err := json.Marshal(progress)
+	if err != nil {
+		return err
+	}
+	path := progressFilePath()
+	tmpPath := path + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, b, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// removeProgress removes the progress file once an import has completed.
+func removeProgress() error {
+	err := os.Remove(progressFilePath())
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/EXCCoin/exccd/exccjson"
+)
+
+// batchResponse models a single response of a batched JSON-RPC request.
+type batchResponse struct {
+	Result json.RawMessage    `json:"result"`
+	Error  *exccjson.RPCError `json:"error"`
+	ID     *int               `json:"id"`
+}
+
+// batchResult is the JSON object written for the result of each command of a
+// batch.
+type batchResult struct {
+	Command string             `json:"command"`
+	Result  json.RawMessage    `json:"result"`
+	Error   *exccjson.RPCError `json:"error"`
+}
+
+// readBatch reads the commands of a batch from the passed reader, one per
+// line, with arguments quoted as they would be in a shell.  Blank lines and
+// lines starting with # are skipped.  It returns the lines of the commands
+// along with the marshalled batch request, which identifies each command by
+// its index.
+func readBatch(r io.Reader) ([]string, []byte, error) {
+	var lines []string
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	bio := bufio.NewReader(r)
+	for lineNum := 1; ; lineNum++ {
+		line, err := bio.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return nil, nil, err
+		}
+		atEOF := err == io.EOF
+
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			args, err := splitArgs(line)
+			if err != nil {
+				return nil, nil, fmt.Errorf("line %d: %v", lineNum,
+					err)
+			}
+			method := args[0]
+			if err := checkMethod(method); err != nil {
+				return nil, nil, fmt.Errorf("line %d: %v", lineNum,
+					err)
+			}
+			params := make([]interface{}, 0, len(args[1:]))
+			for _, arg := range args[1:] {
+				params = append(params, arg)
+			}
+			cmd, err := createCommand(method, params)
+			if err != nil {
+				return nil, nil, fmt.Errorf("line %d: %v", lineNum,
+					err)
+			}
+			marshalledJSON, err := exccjson.MarshalCmd("1.0",
+				len(lines), cmd)
+			if err != nil {
+				return nil, nil, fmt.Errorf("line %d: %v", lineNum,
+					err)
+			}
+			if len(lines) > 0 {
+				buf.WriteByte(',')
+			}
+			buf.Write(marshalledJSON)
+			lines = append(lines, line)
+		}
+
+		if atEOF {
+			break
+		}
+	}
+	buf.WriteByte(']')
+
+	if len(lines) == 0 {
+		return nil, nil, errors.New("no commands specified")
+	}
+	return lines, buf.Bytes(), nil
+}
+
+// runBatch sends the commands read from the passed file, or stdin when it is
+// "-", to the RPC server as a single batched JSON-RPC request.  The result of
+// each command is written to stdout as a single line of JSON in the same order
+// as the commands were read.  An error is returned when any of the commands
+// failed.
+func runBatch(cfg *config, file string) error {
+	r := os.Stdin
+	if file != "-" {
+		f, err := os.Open(cleanAndExpandPath(file))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+	lines, marshalledJSON, err := readBatch(r)
+	if err != nil {
+		return err
+	}
+
+	respBytes, err := postRequest(marshalledJSON, cfg)
+	if err != nil {
+		return err
+	}
+
+	// The server responds with a single error rather than an array of
+	// responses when the batch as a whole is invalid.
+	var responses []batchResponse
+	if err := json.Unmarshal(respBytes, &responses); err != nil {
+		var resp batchResponse
+		if json.Unmarshal(respBytes, &resp) == nil && resp.Error != nil {
+			return resp.Error
+		}
+		return err
+	}
+
+	// Match the responses back to the commands by their IDs since the
+	// order of the responses is not guaranteed.
+	results := make([]batchResult, len(lines))
+	for i, line := range lines {
+		results[i].Command = line
+	}
+	matched := make([]bool, len(lines))
+	for _, resp := range responses {
+		if resp.ID == nil || *resp.ID < 0 || *resp.ID >= len(lines) {
+			continue
+		}
+		results[*resp.ID].Result = resp.Result
+		results[*resp.ID].Error = resp.Error
+		matched[*resp.ID] = true
+	}
+
+	var failed int
+	for i := range results {
+		if !matched[i] {
+			results[i].Error = &exccjson.RPCError{
+				Code:    exccjson.ErrRPCInternal.Code,
+				Message: "no response received for command",
+			}
+		}
+		if results[i].Error != nil {
+			failed++
+		}
+
+		line, err := json.Marshal(&results[i])
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(line))
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d commands failed", failed, len(lines))
+	}
+	return nil
+}
@@ -17,6 +17,7 @@ import (
 	"runtime"
 	"strings"
 
+	"github.com/EXCCoin/exccd/chaincfg"
 	"github.com/EXCCoin/exccd/exccjson"
 	"github.com/EXCCoin/exccd/exccutil"
 
@@ -39,8 +40,38 @@ var (
 	defaultWalletRPCServer = "localhost"
 	defaultRPCCertFile     = filepath.Join(exccdHomeDir, "rpc.cert")
 	defaultWalletCertFile  = filepath.Join(exccwalletHomeDir, "rpc.cert")
+	exccdDataDir           = filepath.Join(exccdHomeDir, "data")
 )
 
+// defaultRPCCookieFile returns the path of the auth cookie exccd writes to its
+// default data directory for the selected network.
+func defaultRPCCookieFile(useTestNet, useSimNet, useRegNet bool) string {
+	netName := chaincfg.MainNetParams.Name
+	switch {
+	case useTestNet:
+		netName = chaincfg.TestNet2Params.Name
+	case useSimNet:
+		netName = chaincfg.SimNetParams.Name
+	case useRegNet:
+		netName = chaincfg.RegNetParams.Name
+	}
+	return filepath.Join(exccdDataDir, netName, ".cookie")
+}
+
+// readRPCCookie returns the username and password in the auth cookie file at
+// the passed path.
+func readRPCCookie(path string) (string, string, error) {
+	cookie, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", "", err
+	}
+	parts := strings.SplitN(strings.TrimSpace(string(cookie)), ":", 2)
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("malformed auth cookie %s", path)
+	}
+	return parts[0], parts[1], nil
+}
+
 // listCommands categorizes and lists all of the usable commands along with
 // their one-line usage.
 func listCommands() {
@@ -100,12 +131,18 @@ func listCommands() {
 type config struct {
 	ShowVersion     bool   `short:"V" long:"version" description:"Display version information and exit"`
 	ListCommands    bool   `short:"l" long:"listcommands" description:"List all of the supported commands and exit"`
+	Interactive     bool   `short:"i" long:"interactive" description:"Start an interactive session with command completion and history"`
+	Notify          string `long:"notify" description:"Stream the comma-separated notifications (eg. blockconnected,txaccepted) to stdout as JSON lines"`
+	Batch           string `long:"batch" description:"Send the commands in the file, one per line, as a single batch request and write their results to stdout as JSON lines (- reads the commands from stdin)"`
 	ConfigFile      string `short:"C" long:"configfile" description:"Path to configuration file"`
 	RPCUser         string `short:"u" long:"rpcuser" description:"RPC username"`
 	RPCPassword     string `short:"P" long:"rpcpass" default-mask:"-" description:"RPC password"`
+	RPCCookieFile   string `long:"rpccookiefile" description:"Auth cookie of the RPC server used when no RPC username and password are specified (default: .cookie in the exccd data directory of the network)"`
 	RPCServer       string `short:"s" long:"rpcserver" description:"RPC server to connect to"`
 	WalletRPCServer string `short:"w" long:"walletrpcserver" description:"Wallet RPC server to connect to"`
 	RPCCert         string `short:"c" long:"rpccert" description:"RPC server certificate chain for validation"`
+	ClientCert      string `long:"clientcert" description:"Certificate presented to RPC servers which authenticate clients with certificates"`
+	ClientKey       string `long:"clientkey" description:"Key of the certificate specified by --clientcert"`
 	PrintJSON       bool   `short:"j" long:"json" description:"Print json messages sent and received"`
 	NoTLS           bool   `long:"notls" description:"Disable TLS"`
 	Proxy           string `long:"proxy" description:"Connect via SOCKS5 proxy (eg. 127.0.0.1:9050)"`
@@ -113,13 +150,14 @@ type config struct {
 	ProxyPass       string `long:"proxypass" default-mask:"-" description:"Password for proxy server"`
 	TestNet         bool   `long:"testnet" description:"Connect to testnet"`
 	SimNet          bool   `long:"simnet" description:"Connect to the simulation test network"`
+	RegNet          bool   `long:"regnet" description:"Connect to the regression test network"`
 	TLSSkipVerify   bool   `long:"skipverify" description:"Do not verify tls certificates (not recommended!)"`
 	Wallet          bool   `long:"wallet" description:"Connect to wallet"`
 }
 
 // normalizeAddress returns addr with the passed default port appended if
 // there is not already a port specified.
-func normalizeAddress(addr string, useTestNet, useSimNet, useWallet bool) string {
+func normalizeAddress(addr string, useTestNet, useSimNet, useRegNet, useWallet bool) string {
 	_, _, err := net.SplitHostPort(addr)
 	if err != nil {
 		var defaultPort string
@@ -136,6 +174,12 @@ func normalizeAddress(addr string, useTestNet, useSimNet, useWallet bool) string
 			} else {
 				defaultPort = "19556"
 			}
+		case useRegNet:
+			if useWallet {
+				defaultPort = "18657"
+			} else {
+				defaultPort = "18656"
+			}
 		default:
 			if useWallet {
 				defaultPort = "9110"
@@ -306,14 +350,35 @@ func loadConfig() (*config, []string, error) {
 	if cfg.SimNet {
 		numNets++
 	}
+	if cfg.RegNet {
+		numNets++
+	}
 	if numNets > 1 {
-		str := "%s: the testnet and simnet params can't be used " +
-			"together -- choose one of the two"
+		str := "%s: the testnet, simnet, and regnet params can't be " +
+			"used together -- choose one of the three"
 		err := fmt.Errorf(str, "loadConfig")
 		fmt.Fprintln(os.Stderr, err)
 		return nil, nil, err
 	}
 
+	// The interactive, notify, and batch modes are mutually exclusive.
+	numModes := 0
+	if cfg.Interactive {
+		numModes++
+	}
+	if cfg.Notify != "" {
+		numModes++
+	}
+	if cfg.Batch != "" {
+		numModes++
+	}
+	if numModes > 1 {
+		err := fmt.Errorf("%s: the interactive, notify, and batch "+
+			"options can't be used together", "loadConfig")
+		fmt.Fprintln(os.Stderr, err)
+		return nil, nil, err
+	}
+
 	// Override the RPC certificate if the --wallet flag was specified and
 	// the user did not specify one.
 	if cfg.Wallet && cfg.RPCCert == defaultRPCCertFile {
@@ -328,10 +393,44 @@ func loadConfig() (*config, []string, error) {
 	// Handle environment variable expansion in the RPC certificate path.
 	cfg.RPCCert = cleanAndExpandPath(cfg.RPCCert)
 
+	// A client certificate requires its key and vice versa.
+	if (cfg.ClientCert == "") != (cfg.ClientKey == "") {
+		err := fmt.Errorf("%s: the clientcert and clientkey options "+
+			"must be specified together", "loadConfig")
+		fmt.Fprintln(os.Stderr, err)
+		return nil, nil, err
+	}
+	if cfg.ClientCert != "" {
+		cfg.ClientCert = cleanAndExpandPath(cfg.ClientCert)
+		cfg.ClientKey = cleanAndExpandPath(cfg.ClientKey)
+	}
+
+	// Authenticate with the auth cookie of exccd when no credentials are
+	// specified.  A missing cookie at the default path is not an error
+	// since the server may not be running locally.
+	if !cfg.Wallet && cfg.RPCUser == "" && cfg.RPCPassword == "" {
+		cookieFile := cfg.RPCCookieFile
+		if cookieFile == "" {
+			cookieFile = defaultRPCCookieFile(cfg.TestNet,
+				cfg.SimNet, cfg.RegNet)
+		}
+		cookieFile = cleanAndExpandPath(cookieFile)
+		user, pass, err := readRPCCookie(cookieFile)
+		switch {
+		case err == nil:
+			cfg.RPCUser, cfg.RPCPassword = user, pass
+		case cfg.RPCCookieFile != "" || !os.IsNotExist(err):
+			err := fmt.Errorf("%s: unable to read the RPC auth "+
+				"cookie: %v", "loadConfig", err)
+			fmt.Fprintln(os.Stderr, err)
+			return nil, nil, err
+		}
+	}
+
 	// Add default port to RPC server based on --testnet and --wallet flags
 	// if needed.
 	cfg.RPCServer = normalizeAddress(cfg.RPCServer, cfg.TestNet,
-		cfg.SimNet, cfg.Wallet)
+		cfg.SimNet, cfg.RegNet, cfg.Wallet)
 
 	return &cfg, remainingArgs, nil
 }
@@ -47,12 +47,117 @@ func usage(errorMessage string) {
 	fmt.Fprintln(os.Stderr, listCmdMessage)
 }
 
+// checkMethod ensures the specified method identifies a valid registered
+// command and is one of the usable types.
+func checkMethod(method string) error {
+	usageFlags, err := exccjson.MethodUsageFlags(method)
+	if err != nil {
+		return fmt.Errorf("Unrecognized command '%s'", method)
+	}
+	if usageFlags&unusableFlags != 0 {
+		return fmt.Errorf("The '%s' command can only be used via "+
+			"websockets", method)
+	}
+	return nil
+}
+
+// createCommand attempts to create the command for the passed method using the
+// provided parameters.
+func createCommand(method string, params []interface{}) (interface{}, error) {
+	cmd, err := exccjson.NewCmd(method, params...)
+	if err != nil {
+		// Show the error along with its error code when it's a
+		// exccjson.Error as it reallistcally will always be since the
+		// NewCmd function is only supposed to return errors of that
+		// type.
+		if jerr, ok := err.(exccjson.Error); ok {
+			return nil, fmt.Errorf("%s command: %v (code: %s)",
+				method, err, jerr.Code)
+		}
+
+		// The error is not a exccjson.Error and this really should not
+		// happen.  Nevertheless, fallback to just showing the error
+		// if it should happen due to a bug in the package.
+		return nil, fmt.Errorf("%s command: %v", method, err)
+	}
+	return cmd, nil
+}
+
+// sendCommand marshals the passed command into a JSON-RPC request, sends it to
+// the RPC server using the user-specified connection configuration, and
+// returns the result.
+func sendCommand(cmd interface{}, cfg *config) ([]byte, error) {
+	marshalledJSON, err := exccjson.MarshalCmd("1.0", 1, cmd)
+	if err != nil {
+		return nil, err
+	}
+	return sendPostRequest(marshalledJSON, cfg)
+}
+
+// formatResult chooses how to display the passed result based on its type.
+// Objects and arrays are indented and strings are unquoted.  An empty string
+// is returned for null results.
+func formatResult(result []byte) (string, error) {
+	strResult := string(result)
+	switch {
+	case strings.HasPrefix(strResult, "{") || strings.HasPrefix(strResult, "["):
+		var dst bytes.Buffer
+		if err := json.Indent(&dst, result, "", "  "); err != nil {
+			return "", fmt.Errorf("Failed to format result: %v", err)
+		}
+		return dst.String(), nil
+
+	case strings.HasPrefix(strResult, `"`):
+		var str string
+		if err := json.Unmarshal(result, &str); err != nil {
+			return "", fmt.Errorf("Failed to unmarshal result: %v",
+				err)
+		}
+		return str, nil
+
+	case strResult == "null":
+		return "", nil
+	}
+
+	return strResult, nil
+}
+
 func main() {
 	cfg, args, err := loadConfig()
 	if err != nil {
 		os.Exit(1)
 	}
 
+	// Start an interactive session when requested.  Any command specified
+	// on the command line is ignored in this case.
+	if cfg.Interactive {
+		if err := runInteractive(cfg); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Stream notifications when requested.  Any command specified on the
+	// command line is ignored in this case.
+	if cfg.Notify != "" {
+		if err := streamNotifications(cfg, cfg.Notify); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Send the commands of a batch when requested.  Any command specified
+	// on the command line is ignored in this case.
+	if cfg.Batch != "" {
+		if err := runBatch(cfg, cfg.Batch); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	if len(args) < 1 {
 		usage("No command specified")
 		os.Exit(1)
@@ -61,15 +166,8 @@ func main() {
 	// Ensure the specified method identifies a valid registered command and
 	// is one of the usable types.
 	method := args[0]
-	usageFlags, err := exccjson.MethodUsageFlags(method)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Unrecognized command '%s'\n", method)
-		fmt.Fprintln(os.Stderr, listCmdMessage)
-		os.Exit(1)
-	}
-	if usageFlags&unusableFlags != 0 {
-		fmt.Fprintf(os.Stderr, "The '%s' command can only be used via "+
-			"websockets\n", method)
+	if err := checkMethod(method); err != nil {
+		fmt.Fprintln(os.Stderr, err)
 		fmt.Fprintln(os.Stderr, listCmdMessage)
 		os.Exit(1)
 	}
@@ -106,64 +204,28 @@ func main() {
 
 	// Attempt to create the appropriate command using the arguments
 	// provided by the user.
-	cmd, err := exccjson.NewCmd(method, params...)
+	cmd, err := createCommand(method, params)
 	if err != nil {
-		// Show the error along with its error code when it's a
-		// exccjson.Error as it reallistcally will always be since the
-		// NewCmd function is only supposed to return errors of that
-		// type.
-		if jerr, ok := err.(exccjson.Error); ok {
-			fmt.Fprintf(os.Stderr, "%s command: %v (code: %s)\n",
-				method, err, jerr.Code)
-			commandUsage(method)
-			os.Exit(1)
-		}
-
-		// The error is not a exccjson.Error and this really should not
-		// happen.  Nevertheless, fallback to just showing the error
-		// if it should happen due to a bug in the package.
-		fmt.Fprintf(os.Stderr, "%s command: %v\n", method, err)
+		fmt.Fprintln(os.Stderr, err)
 		commandUsage(method)
 		os.Exit(1)
 	}
 
-	// Marshal the command into a JSON-RPC byte slice in preparation for
-	// sending it to the RPC server.
-	marshalledJSON, err := exccjson.MarshalCmd("1.0", 1, cmd)
+	// Send the JSON-RPC request to the server using the user-specified
+	// connection configuration.
+	result, err := sendCommand(cmd, cfg)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 
-	// Send the JSON-RPC request to the server using the user-specified
-	// connection configuration.
-	result, err := sendPostRequest(marshalledJSON, cfg)
+	// Display the result.
+	output, err := formatResult(result)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
-
-	// Choose how to display the result based on its type.
-	strResult := string(result)
-	if strings.HasPrefix(strResult, "{") || strings.HasPrefix(strResult, "[") {
-		var dst bytes.Buffer
-		if err := json.Indent(&dst, result, "", "  "); err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to format result: %v",
-				err)
-			os.Exit(1)
-		}
-		fmt.Println(dst.String())
-
-	} else if strings.HasPrefix(strResult, `"`) {
-		var str string
-		if err := json.Unmarshal(result, &str); err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to unmarshal result: %v",
-				err)
-			os.Exit(1)
-		}
-		fmt.Println(str)
-
-	} else if strResult != "null" {
-		fmt.Println(strResult)
+	if output != "" {
+		fmt.Println(output)
 	}
 }
@@ -16,6 +16,45 @@ import (
 	"github.com/btcsuite/go-socks/socks"
 )
 
+// newTLSConfig returns the TLS configuration used to connect to the RPC server
+// according to the TLS settings in the associated connection configuration.
+// It returns nil when TLS is disabled or neither a server nor a client
+// certificate is configured.
+func newTLSConfig(cfg *config) (*tls.Config, error) {
+	if cfg.NoTLS || (cfg.RPCCert == "" && cfg.ClientCert == "") {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: cfg.TLSSkipVerify,
+	}
+	if cfg.RPCCert != "" {
+		pem, err := ioutil.ReadFile(cfg.RPCCert)
+		if err != nil {
+			return nil, err
+		}
+
+		pool := x509.NewCertPool()
+		if ok := pool.AppendCertsFromPEM(pem); !ok {
+			return nil, fmt.Errorf("invalid certificate file: %v",
+				cfg.RPCCert)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	// Present the client certificate to servers which authenticate
+	// clients with certificates.
+	if cfg.ClientCert != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.ClientCert, cfg.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate: %v",
+				err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
 // newHTTPClient returns a new HTTP client that is configured according to the
 // proxy and TLS settings in the associated connection configuration.
 func newHTTPClient(cfg *config) (*http.Client, error) {
@@ -37,22 +76,9 @@ func newHTTPClient(cfg *config) (*http.Client, error) {
 	}
 
 	// Configure TLS if needed.
-	var tlsConfig *tls.Config
-	if !cfg.NoTLS && cfg.RPCCert != "" {
-		pem, err := ioutil.ReadFile(cfg.RPCCert)
-		if err != nil {
-			return nil, err
-		}
-
-		pool := x509.NewCertPool()
-		if ok := pool.AppendCertsFromPEM(pem); !ok {
-			return nil, fmt.Errorf("invalid certificate file: %v",
-				cfg.RPCCert)
-		}
-		tlsConfig = &tls.Config{
-			RootCAs:            pool,
-			InsecureSkipVerify: cfg.TLSSkipVerify,
-		}
+	tlsConfig, err := newTLSConfig(cfg)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create and return the new HTTP client potentially configured with a
@@ -66,11 +92,10 @@ func newHTTPClient(cfg *config) (*http.Client, error) {
 	return &client, nil
 }
 
-// sendPostRequest sends the marshalled JSON-RPC command using HTTP-POST mode
-// to the server described in the passed config struct.  It also attempts to
-// unmarshal the response as a JSON-RPC response and returns either the result
-// field or the error field depending on whether or not there is an error.
-func sendPostRequest(marshalledJSON []byte, cfg *config) ([]byte, error) {
+// postRequest sends the marshalled JSON-RPC request using HTTP-POST mode to the
+// server described in the passed config struct and returns the raw bytes of
+// the response.
+func postRequest(marshalledJSON []byte, cfg *config) ([]byte, error) {
 	// Generate a request to the configured RPC server.
 	protocol := "http"
 	if !cfg.NoTLS {
@@ -128,6 +153,19 @@ func sendPostRequest(marshalledJSON []byte, cfg *config) ([]byte, error) {
 		fmt.Println(string(respBytes))
 	}
 
+	return respBytes, nil
+}
+
+// sendPostRequest sends the marshalled JSON-RPC command using HTTP-POST mode
+// to the server described in the passed config struct.  It also attempts to
+// unmarshal the response as a JSON-RPC response and returns either the result
+// field or the error field depending on whether or not there is an error.
+func sendPostRequest(marshalledJSON []byte, cfg *config) ([]byte, error) {
+	respBytes, err := postRequest(marshalledJSON, cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	// Unmarshal the response.
 	var resp exccjson.Response
 	if err := json.Unmarshal(respBytes, &resp); err != nil {
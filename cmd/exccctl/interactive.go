@@ -0,0 +1,454 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/EXCCoin/exccd/exccjson"
+
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+const (
+	// interactivePrompt is the prompt displayed while reading commands in
+	// an interactive session.
+	interactivePrompt = "exccctl> "
+
+	// maxCompletions is the maximum number of candidates that are listed
+	// when a tab completion is ambiguous.
+	maxCompletions = 100
+)
+
+var (
+	// errExit is returned by the session when the user requests to end it.
+	errExit = errors.New("exit requested")
+
+	// builtinCommands are the commands handled by the session itself
+	// rather than sent to the RPC server.
+	builtinCommands = []string{"exit", "history", "quit"}
+)
+
+// paramUsage describes a parameter of a method as parsed from the one-line
+// usage of the method.
+type paramUsage struct {
+	usage   string
+	choices []string
+}
+
+// methodUsage describes a method which may be completed along with its
+// parameters.
+type methodUsage struct {
+	usage  string
+	params []paramUsage
+}
+
+// interactiveSession houses the state of an interactive session.
+type interactiveSession struct {
+	cfg     *config
+	term    *terminal.Terminal
+	methods map[string]*methodUsage
+	names   []string
+	history []string
+}
+
+// splitUsage splits the passed one-line method usage into the method and the
+// usage of each parameter.  Spaces inside of quotes, arrays, and objects do not
+// separate parameters.
+func splitUsage(usage string) []string {
+	var tokens []string
+	var depth int
+	var quoted bool
+	start := 0
+	for i := 0; i < len(usage); i++ {
+		switch c := usage[i]; {
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == '[' || c == '{':
+			depth++
+		case c == ']' || c == '}':
+			depth--
+		case c == ' ' && depth == 0:
+			if i > start {
+				tokens = append(tokens, usage[start:i])
+			}
+			start = i + 1
+		}
+	}
+	if start < len(usage) {
+		tokens = append(tokens, usage[start:])
+	}
+	return tokens
+}
+
+// parseUsage parses the passed one-line method usage as generated by the
+// exccjson package.  Parameters which are booleans or which list the values
+// they accept, such as "add|remove", may be completed with those values.
+func parseUsage(usage string) *methodUsage {
+	mu := &methodUsage{usage: usage}
+	tokens := splitUsage(usage)
+	if len(tokens) == 0 {
+		return mu
+	}
+	for _, token := range tokens[1:] {
+		// Optional parameters are enclosed in parentheses as a group.
+		token = strings.TrimSuffix(strings.TrimPrefix(token, "("), ")")
+		param := paramUsage{usage: token}
+
+		// Parameters with a default value are shown as name=value.
+		value := token
+		if i := strings.Index(token, "="); i != -1 &&
+			!strings.ContainsAny(token[:i], `"[{`) {
+
+			value = token[i+1:]
+		}
+		switch {
+		case value == "true" || value == "false":
+			param.choices = []string{"true", "false"}
+		case strings.HasPrefix(value, `"`) && strings.Contains(value, "|"):
+			param.choices = strings.Split(strings.Trim(value, `"`), "|")
+		}
+		mu.params = append(mu.params, param)
+	}
+	return mu
+}
+
+// splitArgs splits the passed line into arguments in a similar way to a shell.
+// Arguments are separated by whitespace unless it is quoted or escaped with a
+// backslash.  Nothing is escaped inside of single quotes, which makes them
+// convenient for JSON arguments.
+func splitArgs(line string) ([]string, error) {
+	var args []string
+	var arg []byte
+	var inArg bool
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote == '\'':
+			if c == '\'' {
+				quote = 0
+				continue
+			}
+			arg = append(arg, c)
+
+		case quote == '"':
+			switch {
+			case c == '"':
+				quote = 0
+			case c == '\\' && i+1 < len(line) &&
+				(line[i+1] == '"' || line[i+1] == '\\'):
+
+				i++
+				arg = append(arg, line[i])
+			default:
+				arg = append(arg, c)
+			}
+
+		case c == '\'' || c == '"':
+			quote = c
+			inArg = true
+
+		case c == '\\' && i+1 < len(line):
+			i++
+			arg = append(arg, line[i])
+			inArg = true
+
+		case c == ' ' || c == '\t':
+			if inArg {
+				args = append(args, string(arg))
+				arg = arg[:0]
+				inArg = false
+			}
+
+		default:
+			arg = append(arg, c)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, errors.New("unterminated quoted argument")
+	}
+	if inArg {
+		args = append(args, string(arg))
+	}
+	return args, nil
+}
+
+// matchingPrefix returns the candidates which start with the passed prefix.
+func matchingPrefix(candidates []string, prefix string) []string {
+	var matches []string
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, prefix) {
+			matches = append(matches, candidate)
+		}
+	}
+	return matches
+}
+
+// commonPrefix returns the longest prefix shared by all of the passed strings.
+func commonPrefix(strs []string) string {
+	if len(strs) == 0 {
+		return ""
+	}
+	prefix := strs[0]
+	for _, str := range strs[1:] {
+		for !strings.HasPrefix(str, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
+
+// loadMethods loads the usage of the methods which may be completed.  The
+// methods are limited to those listed by the help of the RPC server when it is
+// reachable so only the commands supported by the server, such as the wallet
+// commands when connected to a wallet, are offered.
+func (s *interactiveSession) loadMethods() {
+	var supported map[string]struct{}
+	if cmd, err := createCommand("help", nil); err == nil {
+		result, err := sendCommand(cmd, s.cfg)
+		var help string
+		if err == nil && json.Unmarshal(result, &help) == nil {
+			supported = make(map[string]struct{})
+			for _, line := range strings.Split(help, "\n") {
+				fields := strings.Fields(line)
+				if len(fields) > 0 {
+					supported[fields[0]] = struct{}{}
+				}
+			}
+		}
+	}
+
+	s.methods = make(map[string]*methodUsage)
+	for _, method := range exccjson.RegisteredCmdMethods() {
+		if checkMethod(method) != nil {
+			continue
+		}
+		if _, ok := supported[method]; supported != nil && !ok {
+			continue
+		}
+		usage, err := exccjson.MethodUsageText(method)
+		if err != nil {
+			// This should never happen since the method was just
+			// returned from the package, but be safe.
+			continue
+		}
+		s.methods[method] = parseUsage(usage)
+		s.names = append(s.names, method)
+	}
+	s.names = append(s.names, builtinCommands...)
+	sort.Strings(s.names)
+}
+
+// complete implements the tab completion of the terminal.  The method is
+// completed from the names of the methods, while the parameters are completed
+// from the values they accept when known.  The usage of the method is shown
+// otherwise.
+func (s *interactiveSession) complete(line string, pos int, key rune) (string, int, bool) {
+	if key != '\t' {
+		return "", 0, false
+	}
+
+	// Determine the word being completed along with the arguments which
+	// precede it.
+	prefix := line[:pos]
+	wordStart := strings.LastIndexAny(prefix, " \t") + 1
+	word := prefix[wordStart:]
+	args, err := splitArgs(prefix[:wordStart])
+	if err != nil {
+		return "", 0, false
+	}
+
+	var candidates []string
+	switch {
+	case len(args) == 0:
+		candidates = matchingPrefix(s.names, word)
+
+	case args[0] == "help" && len(args) == 1:
+		candidates = matchingPrefix(s.names, word)
+
+	default:
+		mu, ok := s.methods[args[0]]
+		if !ok {
+			return "", 0, false
+		}
+		if idx := len(args) - 1; idx < len(mu.params) {
+			candidates = matchingPrefix(mu.params[idx].choices, word)
+		}
+		if len(candidates) == 0 {
+			fmt.Fprintf(s.term, "Usage: %s\n", mu.usage)
+			return "", 0, false
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return "", 0, false
+
+	case 1:
+		completion := candidates[0] + " "
+		return prefix[:wordStart] + completion + line[pos:],
+			wordStart + len(completion), true
+	}
+
+	// Complete as much as is shared by all of the candidates and list them
+	// when nothing more can be completed.
+	if common := commonPrefix(candidates); len(common) > len(word) {
+		return prefix[:wordStart] + common + line[pos:],
+			wordStart + len(common), true
+	}
+	if len(candidates) > maxCompletions {
+		fmt.Fprintf(s.term, "%d possibilities\n", len(candidates))
+		return "", 0, false
+	}
+	fmt.Fprintln(s.term, strings.Join(candidates, "  "))
+	return "", 0, false
+}
+
+// execute runs the command in the passed line and displays its result.
+// Builtin commands are handled by the session while all other commands are
+// sent to the RPC server.  errExit is returned when the user requests to end
+// the session.
+func (s *interactiveSession) execute(line string) error {
+	args, err := splitArgs(line)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return nil
+	}
+	s.history = append(s.history, line)
+
+	switch args[0] {
+	case "exit", "quit":
+		return errExit
+
+	case "history":
+		for i, cmd := range s.history {
+			fmt.Printf("%4d  %s\n", i+1, cmd)
+		}
+		return nil
+	}
+
+	method := args[0]
+	if err := checkMethod(method); err != nil {
+		return err
+	}
+	params := make([]interface{}, 0, len(args[1:]))
+	for _, arg := range args[1:] {
+		params = append(params, arg)
+	}
+	cmd, err := createCommand(method, params)
+	if err != nil {
+		usage, _ := exccjson.MethodUsageText(method)
+		return fmt.Errorf("%v\nUsage:\n  %s", err, usage)
+	}
+	result, err := sendCommand(cmd, s.cfg)
+	if err != nil {
+		return err
+	}
+	output, err := formatResult(result)
+	if err != nil {
+		return err
+	}
+	if output != "" {
+		fmt.Println(output)
+	}
+	return nil
+}
+
+// runScript executes the commands read from the passed reader one line at a
+// time.  This is used when the standard input is not a terminal so that
+// sessions may be scripted.
+func (s *interactiveSession) runScript(r io.Reader) error {
+	var failed bool
+	bio := bufio.NewReader(r)
+	for {
+		line, err := bio.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if len(line) > 0 {
+			line = strings.TrimRight(line, "\r\n")
+			execErr := s.execute(line)
+			if execErr == errExit {
+				break
+			}
+			if execErr != nil {
+				fmt.Fprintln(os.Stderr, execErr)
+				failed = true
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+	}
+	if failed {
+		return errors.New("one or more commands failed")
+	}
+	return nil
+}
+
+// runInteractive starts an interactive session which repeatedly reads commands
+// from the terminal, sends them to the RPC server, and displays their results.
+// Commands and their parameters are completed with the tab key and previous
+// commands are recalled with the arrow keys.
+func runInteractive(cfg *config) error {
+	s := &interactiveSession{cfg: cfg}
+	s.loadMethods()
+
+	fd := int(os.Stdin.Fd())
+	if !terminal.IsTerminal(fd) {
+		return s.runScript(os.Stdin)
+	}
+
+	rw := struct {
+		io.Reader
+		io.Writer
+	}{os.Stdin, os.Stdout}
+	s.term = terminal.NewTerminal(rw, interactivePrompt)
+	s.term.AutoCompleteCallback = s.complete
+	if width, height, err := terminal.GetSize(fd); err == nil {
+		s.term.SetSize(width, height)
+	}
+
+	fmt.Println("Enter commands to send them to the RPC server.  Press tab " +
+		"to complete commands and their parameters and use 'exit' or " +
+		"Ctrl-D to quit.")
+	for {
+		// The terminal is only placed in raw mode while reading a line
+		// so the results of commands are displayed normally.
+		oldState, err := terminal.MakeRaw(fd)
+		if err != nil {
+			return err
+		}
+		line, err := s.term.ReadLine()
+		terminal.Restore(fd, oldState)
+		if err == io.EOF {
+			fmt.Println()
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		err = s.execute(line)
+		if err == errExit {
+			return nil
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+	}
+}
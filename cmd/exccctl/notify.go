@@ -0,0 +1,235 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/EXCCoin/exccd/exccjson"
+
+	"github.com/btcsuite/go-socks/socks"
+	"github.com/btcsuite/websocket"
+)
+
+// notificationCmds maps the notifications which may be streamed to the
+// commands which register for them.  Several notifications are registered by
+// the same command.
+var notificationCmds = map[string]func() interface{}{
+	exccjson.BlockConnectedNtfnMethod: func() interface{} {
+		return exccjson.NewNotifyBlocksCmd()
+	},
+	exccjson.BlockDisconnectedNtfnMethod: func() interface{} {
+		return exccjson.NewNotifyBlocksCmd()
+	},
+	exccjson.ReorganizationNtfnMethod: func() interface{} {
+		return exccjson.NewNotifyBlocksCmd()
+	},
+	exccjson.TxAcceptedNtfnMethod: func() interface{} {
+		return exccjson.NewNotifyNewTransactionsCmd(exccjson.Bool(false))
+	},
+	exccjson.TxAcceptedVerboseNtfnMethod: func() interface{} {
+		return exccjson.NewNotifyNewTransactionsCmd(exccjson.Bool(true))
+	},
+	exccjson.WinningTicketsNtfnMethod: func() interface{} {
+		return exccjson.NewNotifyWinningTicketsCmd()
+	},
+	exccjson.SpentAndMissedTicketsNtfnMethod: func() interface{} {
+		return exccjson.NewNotifySpentAndMissedTicketsCmd()
+	},
+	exccjson.NewTicketsNtfnMethod: func() interface{} {
+		return exccjson.NewNotifyNewTicketsCmd()
+	},
+	exccjson.StakeDifficultyNtfnMethod: func() interface{} {
+		return exccjson.NewNotifyStakeDifficultyCmd()
+	},
+}
+
+// notificationNames returns the sorted names of the notifications which may be
+// streamed.
+func notificationNames() []string {
+	names := make([]string, 0, len(notificationCmds))
+	for name := range notificationCmds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// wsMessage models both the responses and the notifications received from the
+// websocket of the RPC server.  Notifications are distinguished by their
+// method.
+type wsMessage struct {
+	Method string             `json:"method"`
+	Params []json.RawMessage  `json:"params"`
+	Error  *exccjson.RPCError `json:"error"`
+}
+
+// notification is the JSON object written for each streamed notification.
+type notification struct {
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+// parseNotifications parses the comma-separated list of notifications to
+// stream and returns the set of them.
+func parseNotifications(list string) (map[string]struct{}, error) {
+	ntfns := make(map[string]struct{})
+	for _, name := range strings.Split(list, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if _, ok := notificationCmds[name]; !ok {
+			return nil, fmt.Errorf("unsupported notification %q -- "+
+				"supported notifications are %s", name,
+				strings.Join(notificationNames(), ", "))
+		}
+		ntfns[name] = struct{}{}
+	}
+	if len(ntfns) == 0 {
+		return nil, errors.New("no notifications specified")
+	}
+
+	// The server sends either the regular or the verbose notification for
+	// accepted transactions to each client, but not both.
+	_, txAccepted := ntfns[exccjson.TxAcceptedNtfnMethod]
+	_, txAcceptedVerbose := ntfns[exccjson.TxAcceptedVerboseNtfnMethod]
+	if txAccepted && txAcceptedVerbose {
+		return nil, fmt.Errorf("the %s and %s notifications can't be "+
+			"used together", exccjson.TxAcceptedNtfnMethod,
+			exccjson.TxAcceptedVerboseNtfnMethod)
+	}
+	return ntfns, nil
+}
+
+// dialWebsocket opens a websocket connection to the RPC server according to
+// the proxy, TLS, and authentication settings in the associated connection
+// configuration.
+func dialWebsocket(cfg *config) (*websocket.Conn, error) {
+	tlsConfig, err := newTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	scheme := "wss"
+	if cfg.NoTLS {
+		scheme = "ws"
+	}
+	dialer := websocket.Dialer{TLSClientConfig: tlsConfig}
+	if cfg.Proxy != "" {
+		proxy := &socks.Proxy{
+			Addr:     cfg.Proxy,
+			Username: cfg.ProxyUser,
+			Password: cfg.ProxyPass,
+		}
+		dialer.NetDial = proxy.Dial
+	}
+
+	// The RPC server requires basic authorization, so create a custom
+	// request header with the Authorization header set.
+	login := cfg.RPCUser + ":" + cfg.RPCPassword
+	auth := "Basic " + base64.StdEncoding.EncodeToString([]byte(login))
+	requestHeader := make(http.Header)
+	requestHeader.Add("Authorization", auth)
+
+	url := fmt.Sprintf("%s://%s/ws", scheme, cfg.RPCServer)
+	wsConn, resp, err := dialer.Dial(url, requestHeader)
+	if err != nil {
+		if err == websocket.ErrBadHandshake && resp != nil {
+			return nil, fmt.Errorf("websocket handshake failed: %s",
+				resp.Status)
+		}
+		return nil, err
+	}
+	return wsConn, nil
+}
+
+// streamNotifications registers for the notifications in the passed
+// comma-separated list over a websocket connection to the RPC server and
+// writes each of them received to stdout as a single line of JSON until the
+// connection is closed.
+func streamNotifications(cfg *config, list string) error {
+	ntfns, err := parseNotifications(list)
+	if err != nil {
+		return err
+	}
+
+	wsConn, err := dialWebsocket(cfg)
+	if err != nil {
+		return err
+	}
+	defer wsConn.Close()
+
+	// Register for the requested notifications, skipping commands which
+	// have already been sent since several notifications are registered
+	// by the same command.
+	sent := make(map[string]struct{})
+	for name := range ntfns {
+		cmd := notificationCmds[name]()
+		method, err := exccjson.CmdMethod(cmd)
+		if err != nil {
+			return err
+		}
+		if _, ok := sent[method]; ok {
+			continue
+		}
+		sent[method] = struct{}{}
+
+		marshalledJSON, err := exccjson.MarshalCmd("1.0", len(sent), cmd)
+		if err != nil {
+			return err
+		}
+		if cfg.PrintJSON {
+			fmt.Fprintln(os.Stderr, string(marshalledJSON))
+		}
+		err = wsConn.WriteMessage(websocket.TextMessage, marshalledJSON)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Stream the requested notifications.  The responses to the commands
+	// above are only checked for errors and notifications which were not
+	// requested, such as a block disconnected notification when only
+	// connected blocks were requested, are skipped.
+	for {
+		_, msg, err := wsConn.ReadMessage()
+		if err != nil {
+			return err
+		}
+		if cfg.PrintJSON {
+			fmt.Fprintln(os.Stderr, string(msg))
+		}
+
+		var m wsMessage
+		if err := json.Unmarshal(msg, &m); err != nil {
+			return fmt.Errorf("failed to unmarshal message: %v", err)
+		}
+		if m.Method == "" {
+			if m.Error != nil {
+				return m.Error
+			}
+			continue
+		}
+		if _, ok := ntfns[m.Method]; !ok {
+			continue
+		}
+
+		line, err := json.Marshal(notification{
+			Method: m.Method,
+			Params: m.Params,
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(line))
+	}
+}
@@ -0,0 +1,269 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/EXCCoin/exccd/blockchain/chaingen"
+	"github.com/EXCCoin/exccd/cequihash"
+	"github.com/EXCCoin/exccd/chaincfg"
+	"github.com/EXCCoin/exccd/wire"
+	flags "github.com/jessevdk/go-flags"
+)
+
+// config defines the configuration options for gengenesis.
+type config struct {
+	TestNet           bool   `long:"testnet" description:"Use the test network parameters"`
+	SimNet            bool   `long:"simnet" description:"Use the simulation test network parameters"`
+	RegNet            bool   `long:"regnet" description:"Use the regression test network parameters"`
+	ChainParams       string `long:"chainparams" description:"Use the parameters of the custom network defined by the specified JSON file"`
+	Timestamp         int64  `long:"timestamp" description:"Timestamp of the genesis block in seconds since the unix epoch (default: now)"`
+	Bits              uint32 `long:"bits" description:"Target difficulty of the genesis block in compact form (default: proof of work limit of the network)"`
+	CoinbaseSigScript string `long:"coinbasesigscript" description:"Hex-encoded signature script of the genesis coinbase"`
+	CoinbasePkScript  string `long:"coinbasepkscript" description:"Hex-encoded public key script of the genesis coinbase output"`
+	UseGoOutput       bool   `short:"g" long:"gooutput" description:"Display the genesis block along with its coinbase transaction using Go syntax that is ready to insert into chaincfg"`
+}
+
+// genesisJSON is the genesis object of the JSON format read by
+// chaincfg.LoadCustomParams.
+type genesisJSON struct {
+	Timestamp         int64  `json:"timestamp"`
+	Bits              uint32 `json:"bits"`
+	Nonce             uint32 `json:"nonce"`
+	ExtraData         string `json:"extradata"`
+	EquihashSolution  string `json:"equihashsolution"`
+	CoinbaseSigScript string `json:"coinbasesigscript"`
+	CoinbasePkScript  string `json:"coinbasepkscript"`
+}
+
+// loadParams returns the parameters of the network selected by the config.
+func loadParams(cfg *config) (*chaincfg.Params, error) {
+	numNets := 0
+	params := &chaincfg.MainNetParams
+	if cfg.TestNet {
+		numNets++
+		params = &chaincfg.TestNet2Params
+	}
+	if cfg.SimNet {
+		numNets++
+		params = &chaincfg.SimNetParams
+	}
+	if cfg.RegNet {
+		numNets++
+		params = &chaincfg.RegNetParams
+	}
+	if cfg.ChainParams != "" {
+		numNets++
+		data, err := ioutil.ReadFile(cfg.ChainParams)
+		if err != nil {
+			return nil, err
+		}
+		params, err = chaincfg.LoadCustomParams(data)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if numNets > 1 {
+		return nil, fmt.Errorf("the testnet, simnet, regnet, and " +
+			"chainparams params can't be used together -- choose " +
+			"one of the four")
+	}
+	return params, nil
+}
+
+// goBytes returns the passed bytes formatted as the elements of a Go byte
+// array literal with eight bytes per line.
+func goBytes(b []byte, indent string) string {
+	var lines []string
+	for i := 0; i < len(b); i += 8 {
+		end := i + 8
+		if end > len(b) {
+			end = len(b)
+		}
+		elems := make([]string, 0, end-i)
+		for _, v := range b[i:end] {
+			elems = append(elems, fmt.Sprintf("0x%02x,", v))
+		}
+		lines = append(lines, indent+strings.Join(elems, " "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// goGenesisBlock returns the passed genesis block formatted as Go variable
+// declarations of its coinbase transaction and the block itself, which is
+// ready to insert into chaincfg.  Only the first solutionLen bytes of the
+// equihash solution are written since the rest of them are always zero.
+func goGenesisBlock(block *wire.MsgBlock, solutionLen int) string {
+	var b bytes.Buffer
+	coinbase := block.Transactions[0]
+	fmt.Fprintf(&b, "var genesisCoinbaseTx = wire.MsgTx{\n")
+	if coinbase.SerType == wire.TxSerializeFull {
+		fmt.Fprintf(&b, "\tSerType: wire.TxSerializeFull,\n")
+	} else {
+		fmt.Fprintf(&b, "\tSerType: %d,\n", coinbase.SerType)
+	}
+	fmt.Fprintf(&b, "\tVersion: %d,\n", coinbase.Version)
+	fmt.Fprintf(&b, "\tTxIn: []*wire.TxIn{\n")
+	for _, txIn := range coinbase.TxIn {
+		prevOut := &txIn.PreviousOutPoint
+		fmt.Fprintf(&b, "\t\t{\n")
+		fmt.Fprintf(&b, "\t\t\tPreviousOutPoint: wire.OutPoint{\n")
+		fmt.Fprintf(&b, "\t\t\t\tHash: chainhash.Hash{\n%s\n\t\t\t\t},\n",
+			goBytes(prevOut.Hash[:], "\t\t\t\t\t"))
+		fmt.Fprintf(&b, "\t\t\t\tIndex: 0x%08x,\n", prevOut.Index)
+		fmt.Fprintf(&b, "\t\t\t\tTree:  %d,\n", prevOut.Tree)
+		fmt.Fprintf(&b, "\t\t\t},\n")
+		fmt.Fprintf(&b, "\t\t\tSignatureScript: []byte{\n%s\n\t\t\t},\n",
+			goBytes(txIn.SignatureScript, "\t\t\t\t"))
+		fmt.Fprintf(&b, "\t\t\tSequence:    0x%08x,\n", txIn.Sequence)
+		fmt.Fprintf(&b, "\t\t\tBlockHeight: 0x%08x,\n", txIn.BlockHeight)
+		fmt.Fprintf(&b, "\t\t\tBlockIndex:  0x%08x,\n", txIn.BlockIndex)
+		fmt.Fprintf(&b, "\t\t\tValueIn:     %d,\n", txIn.ValueIn)
+		fmt.Fprintf(&b, "\t\t},\n")
+	}
+	fmt.Fprintf(&b, "\t},\n")
+	fmt.Fprintf(&b, "\tTxOut: []*wire.TxOut{\n")
+	for _, txOut := range coinbase.TxOut {
+		fmt.Fprintf(&b, "\t\t{\n")
+		fmt.Fprintf(&b, "\t\t\tVersion: 0x%04x,\n", txOut.Version)
+		fmt.Fprintf(&b, "\t\t\tValue:   %d,\n", txOut.Value)
+		fmt.Fprintf(&b, "\t\t\tPkScript: []byte{\n%s\n\t\t\t},\n",
+			goBytes(txOut.PkScript, "\t\t\t\t"))
+		fmt.Fprintf(&b, "\t\t},\n")
+	}
+	fmt.Fprintf(&b, "\t},\n")
+	fmt.Fprintf(&b, "\tLockTime: %d,\n", coinbase.LockTime)
+	fmt.Fprintf(&b, "\tExpiry:   %d,\n", coinbase.Expiry)
+	fmt.Fprintf(&b, "}\n\n")
+
+	header := &block.Header
+	fmt.Fprintf(&b, "var genesisBlock = wire.MsgBlock{\n")
+	fmt.Fprintf(&b, "\tHeader: wire.BlockHeader{\n")
+	fmt.Fprintf(&b, "\t\tVersion: %d,\n", header.Version)
+	fmt.Fprintf(&b, "\t\tPrevBlock: chainhash.Hash{\n%s\n\t\t},\n",
+		goBytes(header.PrevBlock[:], "\t\t\t"))
+	fmt.Fprintf(&b, "\t\t// %v\n", header.MerkleRoot)
+	fmt.Fprintf(&b, "\t\tMerkleRoot: chainhash.Hash{\n%s\n\t\t},\n",
+		goBytes(header.MerkleRoot[:], "\t\t\t"))
+	fmt.Fprintf(&b, "\t\tStakeRoot: chainhash.Hash{\n%s\n\t\t},\n",
+		goBytes(header.StakeRoot[:], "\t\t\t"))
+	fmt.Fprintf(&b, "\t\tVoteBits:    0x%04x,\n", header.VoteBits)
+	fmt.Fprintf(&b, "\t\tFinalState:  [6]byte{%s},\n",
+		strings.TrimSuffix(goBytes(header.FinalState[:], ""), ","))
+	fmt.Fprintf(&b, "\t\tVoters:      %d,\n", header.Voters)
+	fmt.Fprintf(&b, "\t\tFreshStake:  %d,\n", header.FreshStake)
+	fmt.Fprintf(&b, "\t\tRevocations: %d,\n", header.Revocations)
+	fmt.Fprintf(&b, "\t\tPoolSize:    %d,\n", header.PoolSize)
+	fmt.Fprintf(&b, "\t\tBits:        0x%08x,\n", header.Bits)
+	fmt.Fprintf(&b, "\t\tSBits:       %d,\n", header.SBits)
+	fmt.Fprintf(&b, "\t\tHeight:      %d,\n", header.Height)
+	fmt.Fprintf(&b, "\t\tSize:        %d,\n", header.Size)
+	fmt.Fprintf(&b, "\t\tTimestamp:   time.Unix(%d, 0), // %v\n",
+		header.Timestamp.Unix(), header.Timestamp.UTC())
+	fmt.Fprintf(&b, "\t\tNonce:       0x%08x,\n", header.Nonce)
+	fmt.Fprintf(&b, "\t\tExtraData: [32]byte{\n%s\n\t\t},\n",
+		goBytes(header.ExtraData[:], "\t\t\t"))
+	fmt.Fprintf(&b, "\t\tStakeVersion: %d,\n", header.StakeVersion)
+	fmt.Fprintf(&b, "\t\tEquihashSolution: [wire.EquihashSolutionLen]byte{\n"+
+		"%s\n\t\t},\n", goBytes(header.EquihashSolution[:solutionLen],
+		"\t\t\t"))
+	fmt.Fprintf(&b, "\t},\n")
+	fmt.Fprintf(&b, "\tTransactions: []*wire.MsgTx{&genesisCoinbaseTx},\n")
+	fmt.Fprintf(&b, "}\n")
+	return b.String()
+}
+
+func main() {
+	cfg := config{
+		Timestamp: time.Now().Unix(),
+	}
+	parser := flags.NewParser(&cfg, flags.Default)
+	_, err := parser.Parse()
+	if err != nil {
+		if e, ok := err.(*flags.Error); !ok || e.Type != flags.ErrHelp {
+			parser.WriteHelp(os.Stderr)
+		}
+		return
+	}
+
+	params, err := loadParams(&cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cannot load network parameters: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Derive the genesis block from the one of the network with the
+	// requested fields replaced.
+	genesis := *params.GenesisBlock
+	coinbase := genesis.Transactions[0].Copy()
+	genesis.Transactions = []*wire.MsgTx{coinbase}
+	if cfg.CoinbaseSigScript != "" {
+		script, err := hex.DecodeString(cfg.CoinbaseSigScript)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid coinbase signature script: %v\n", err)
+			os.Exit(1)
+		}
+		coinbase.TxIn[0].SignatureScript = script
+	}
+	if cfg.CoinbasePkScript != "" {
+		script, err := hex.DecodeString(cfg.CoinbasePkScript)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid coinbase public key script: %v\n", err)
+			os.Exit(1)
+		}
+		coinbase.TxOut[0].PkScript = script
+	}
+	header := &genesis.Header
+	header.Timestamp = time.Unix(cfg.Timestamp, 0)
+	header.Bits = params.PowLimitBits
+	if cfg.Bits != 0 {
+		header.Bits = cfg.Bits
+	}
+	header.MerkleRoot = coinbase.TxHashFull()
+
+	fmt.Fprintf(os.Stderr, "Mining genesis block with equihash N=%d, K=%d "+
+		"and bits %08x...\n", params.N, params.K, header.Bits)
+	if !chaingen.SolveBlockWithEquihash(header, params) {
+		fmt.Fprintln(os.Stderr, "unable to find a solution for the genesis block")
+		os.Exit(1)
+	}
+	solutionLen := cequihash.EquihashSolutionSize(params.N, params.K)
+	solution := header.EquihashSolution[:solutionLen]
+	hash := header.BlockHash()
+	fmt.Fprintf(os.Stderr, "Genesis hash: %v\n", hash)
+
+	if cfg.UseGoOutput {
+		fmt.Print(goGenesisBlock(&genesis, solutionLen))
+		return
+	}
+
+	out := struct {
+		Genesis genesisJSON `json:"genesis"`
+	}{
+		Genesis: genesisJSON{
+			Timestamp:         cfg.Timestamp,
+			Bits:              header.Bits,
+			Nonce:             header.Nonce,
+			ExtraData:         hex.EncodeToString(header.ExtraData[:]),
+			EquihashSolution:  hex.EncodeToString(solution),
+			CoinbaseSigScript: hex.EncodeToString(coinbase.TxIn[0].SignatureScript),
+			CoinbasePkScript:  hex.EncodeToString(coinbase.TxOut[0].PkScript),
+		},
+	}
+	b, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cannot encode genesis block: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println(string(b))
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+// simagenda drives a simnet node through the full lifecycle of a consensus
+// agenda by generating blocks until the agenda becomes active or fails.
+//
+// The node must run with --simnetautostake and the choice to cast on the agenda
+// via --simnetvote, so the votes required by the stake version upgrade and the
+// agenda itself are cast automatically.  For example:
+//
+//	exccd --simnet --simnetautostake --simnetvote=maxblocksize=yes
+//	simagenda -u user -P pass --agenda=maxblocksize
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/EXCCoin/exccd/chaincfg"
+	"github.com/EXCCoin/exccd/exccjson"
+	"github.com/EXCCoin/exccd/exccutil"
+	"github.com/EXCCoin/exccd/rpcclient"
+	flags "github.com/jessevdk/go-flags"
+)
+
+var (
+	exccdHomeDir          = exccutil.AppDataDir("exccd", false)
+	defaultRPCCertFile    = filepath.Join(exccdHomeDir, "rpc.cert")
+	defaultRPCServer      = "127.0.0.1:19556"
+	defaultBlocksPerCheck = uint32(16)
+	defaultMaxBlocks      = uint32(20000)
+)
+
+type config struct {
+	RPCServer      string `short:"s" long:"rpcserver" description:"RPC server of the simnet node to connect to"`
+	RPCUser        string `short:"u" long:"rpcuser" description:"RPC username"`
+	RPCPassword    string `short:"P" long:"rpcpass" default-mask:"-" description:"RPC password"`
+	RPCCert        string `short:"c" long:"rpccert" description:"RPC server certificate chain for validation"`
+	Agenda         string `long:"agenda" description:"ID of the agenda to drive through its lifecycle"`
+	BlocksPerCheck uint32 `long:"blockspercheck" description:"Number of blocks to generate between checks of the agenda status"`
+	MaxBlocks      uint32 `long:"maxblocks" description:"Maximum number of blocks to generate before giving up"`
+}
+
+// agendaVersion returns the vote version the agenda with the passed ID belongs
+// to on simnet.
+func agendaVersion(agendaID string) (uint32, error) {
+	for version, deployments := range chaincfg.SimNetParams.Deployments {
+		for _, deployment := range deployments {
+			if deployment.Vote.Id == agendaID {
+				return version, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("unknown simnet agenda %q", agendaID)
+}
+
+// findAgenda returns the agenda with the passed ID from the passed vote info.
+func findAgenda(info *exccjson.GetVoteInfoResult, agendaID string) (*exccjson.Agenda, error) {
+	for i := range info.Agendas {
+		if info.Agendas[i].Id == agendaID {
+			return &info.Agendas[i], nil
+		}
+	}
+	return nil, fmt.Errorf("agenda %q is not in the vote info of version %d",
+		agendaID, info.VoteVersion)
+}
+
+// choiceProgress returns the progress of the choices of the passed agenda in a
+// form suitable for display.
+func choiceProgress(agenda *exccjson.Agenda) string {
+	var s string
+	for i, choice := range agenda.Choices {
+		if i > 0 {
+			s += ", "
+		}
+		s += fmt.Sprintf("%s %.1f%%", choice.Id, choice.Progress*100)
+	}
+	return s
+}
+
+// run drives the simnet node through the lifecycle of the configured agenda and
+// reports each change of the stake version and agenda status along the way.
+func run(cfg *config) error {
+	version, err := agendaVersion(cfg.Agenda)
+	if err != nil {
+		return err
+	}
+
+	certs, err := ioutil.ReadFile(cfg.RPCCert)
+	if err != nil {
+		return err
+	}
+	client, err := rpcclient.New(&rpcclient.ConnConfig{
+		Host:         cfg.RPCServer,
+		User:         cfg.RPCUser,
+		Pass:         cfg.RPCPassword,
+		Certificates: certs,
+		HTTPPostMode: true,
+	}, nil)
+	if err != nil {
+		return err
+	}
+	defer client.Shutdown()
+
+	var generated uint32
+	var lastStakeVersion uint32
+	var lastStatus string
+	for {
+		info, err := client.GetVoteInfo(version)
+		if err != nil {
+			return err
+		}
+		agenda, err := findAgenda(info, cfg.Agenda)
+		if err != nil {
+			return err
+		}
+		stakeVersions, err := client.GetStakeVersions(info.Hash, 1)
+		if err != nil {
+			return err
+		}
+
+		// Report the changes of the stake version, which must reach the
+		// version of the agenda before voting on it starts, and of the
+		// agenda status.
+		if len(stakeVersions.StakeVersions) > 0 {
+			stakeVersion := stakeVersions.StakeVersions[0].StakeVersion
+			if stakeVersion != lastStakeVersion {
+				fmt.Printf("Height %d: stake version %d (agenda "+
+					"version %d)\n", info.CurrentHeight,
+					stakeVersion, version)
+				lastStakeVersion = stakeVersion
+			}
+		}
+		if agenda.Status != lastStatus {
+			fmt.Printf("Height %d: agenda %s is %s\n",
+				info.CurrentHeight, agenda.Id, agenda.Status)
+			lastStatus = agenda.Status
+		}
+
+		switch agenda.Status {
+		case "active":
+			return nil
+		case "failed":
+			return fmt.Errorf("agenda %s failed at height %d",
+				agenda.Id, info.CurrentHeight)
+		case "started":
+			fmt.Printf("Height %d: quorum %.1f%%, %s\n",
+				info.CurrentHeight, agenda.QuorumProgress*100,
+				choiceProgress(agenda))
+		}
+
+		if generated >= cfg.MaxBlocks {
+			return fmt.Errorf("agenda %s is still %s after generating "+
+				"%d blocks", agenda.Id, agenda.Status, generated)
+		}
+		if _, err := client.Generate(cfg.BlocksPerCheck); err != nil {
+			return err
+		}
+		generated += cfg.BlocksPerCheck
+	}
+}
+
+func main() {
+	cfg := config{
+		RPCServer:      defaultRPCServer,
+		RPCCert:        defaultRPCCertFile,
+		BlocksPerCheck: defaultBlocksPerCheck,
+		MaxBlocks:      defaultMaxBlocks,
+	}
+	parser := flags.NewParser(&cfg, flags.Default)
+	_, err := parser.Parse()
+	if err != nil {
+		if e, ok := err.(*flags.Error); !ok || e.Type != flags.ErrHelp {
+			parser.WriteHelp(os.Stderr)
+		}
+		return
+	}
+	if cfg.Agenda == "" {
+		fmt.Fprintln(os.Stderr, "the agenda to drive must be specified "+
+			"with --agenda")
+		os.Exit(1)
+	}
+	if cfg.BlocksPerCheck == 0 {
+		fmt.Fprintln(os.Stderr, "blockspercheck must be greater than zero")
+		os.Exit(1)
+	}
+
+	if err := run(&cfg); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
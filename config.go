@@ -9,9 +9,11 @@ package main
 import (
 	"crypto/rand"
 	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"net"
+	"net/url"
 	"os"
 	"os/user"
 	"path/filepath"
@@ -22,12 +24,18 @@ import (
 	"strings"
 	"time"
 
+	"github.com/EXCCoin/exccd/blockchain/indexers"
+	"github.com/EXCCoin/exccd/chaincfg"
+	"github.com/EXCCoin/exccd/chaincfg/chainec"
+	"github.com/EXCCoin/exccd/chaincfg/chainhash"
 	"github.com/EXCCoin/exccd/connmgr"
 	"github.com/EXCCoin/exccd/database"
 	_ "github.com/EXCCoin/exccd/database/ffldb"
 	"github.com/EXCCoin/exccd/exccutil"
 	"github.com/EXCCoin/exccd/mempool"
+	"github.com/EXCCoin/exccd/mining"
 	"github.com/EXCCoin/exccd/sampleconfig"
+	"github.com/EXCCoin/exccd/txscript"
 	"github.com/btcsuite/btclog"
 	"github.com/btcsuite/go-socks/socks"
 	flags "github.com/jessevdk/go-flags"
@@ -39,12 +47,17 @@ const (
 	defaultLogLevel              = "info"
 	defaultLogDirname            = "logs"
 	defaultLogFilename           = "exccd.log"
+	defaultLogMaxSize            = 10 * 1024
+	defaultLogMaxRolls           = 3
 	defaultMaxPeers              = 125
 	defaultBanDuration           = time.Hour * 24
 	defaultBanThreshold          = 100
 	defaultMaxRPCClients         = 10
 	defaultMaxRPCWebsockets      = 25
 	defaultMaxRPCConcurrentReqs  = 20
+	defaultMaxRPCWSSubscriptions = 250000
+	defaultMaxRPCWSQueuedNtfns   = 10000
+	defaultRPCCookieFilename     = ".cookie"
 	defaultDbType                = "ffldb"
 	defaultFreeTxRelayLimit      = 15.0
 	defaultBlockMinSize          = 0
@@ -56,8 +69,13 @@ const (
 	defaultAllowOldVotes         = false
 	defaultMaxOrphanTransactions = 1000
 	defaultMaxOrphanTxSize       = 5000
+	defaultDataCarrierSize       = txscript.MaxDataCarrierSize
+	defaultMaxDataCarriers       = mining.DefaultMaxDataCarrierOutputs
 	defaultSigCacheMaxSize       = 100000
+	defaultBlockCacheSize        = 50
 	defaultTxIndex               = false
+	defaultTimeIndex             = false
+	defaultTicketIndex           = false
 	defaultNoExistsAddrIndex     = false
 	defaultNoCFilters            = false
 )
@@ -95,8 +113,15 @@ type config struct {
 	DataDir              string        `short:"b" long:"datadir" description:"Directory to store data"`
 	LogDir               string        `long:"logdir" description:"Directory to log output."`
 	NoFileLogging        bool          `long:"nofilelogging" description:"Disable file logging."`
+	LogFormat            string        `long:"logformat" description:"Format of log output {text, json} -- json writes an object per message with the subsystem, level, and fields such as the peer, hash, and height"`
+	LogMaxSize           int64         `long:"logmaxsize" description:"Roll log files once they reach this size in KiB -- Use 0 to disable rolling by size"`
+	LogMaxAge            time.Duration `long:"logmaxage" description:"Roll log files once they are older than this duration, such as 24h -- Use 0 to disable rolling by age"`
+	LogMaxRolls          int           `long:"logmaxrolls" description:"Maximum number of rolled files to keep for each log file -- Use 0 to keep all of them"`
+	LogCompression       bool          `long:"logcompression" description:"Compress rolled log files with gzip"`
+	LogSplit             []string      `long:"logsplit" description:"Write the log messages of a subsystem, such as PEER, to its own file in the log directory instead of the main log file -- May be specified multiple times"`
 	AddPeers             []string      `short:"a" long:"addpeer" description:"Add a peer to connect with at startup"`
 	ConnectPeers         []string      `long:"connect" description:"Connect only to the specified peers at startup"`
+	SyncOnly             []string      `long:"synconly" description:"Only accept blocks from the specified peers, which are connected to persistently at startup, and only use other peers to relay transactions -- May be specified multiple times"`
 	DisableListen        bool          `long:"nolisten" description:"Disable listening for incoming connections -- NOTE: Listening is automatically disabled if the --connect or --proxy options are used without also specifying listen interfaces via --listen"`
 	Listeners            []string      `long:"listen" description:"Add an interface/port to listen for connections (default all interfaces port: 9108, testnet: 19108)"`
 	MaxPeers             int           `long:"maxpeers" description:"Max number of inbound and outbound peers"`
@@ -111,12 +136,25 @@ type config struct {
 	RPCListeners         []string      `long:"rpclisten" description:"Add an interface/port to listen for RPC connections (default port: 9109, testnet: 19109)"`
 	RPCCert              string        `long:"rpccert" description:"File containing the certificate file"`
 	RPCKey               string        `long:"rpckey" description:"File containing the certificate key"`
+	RPCCertHosts         []string      `long:"rpccerthost" description:"Add a hostname or IP address to the RPC server certificate when it is generated (may be used multiple times)"`
+	RPCCertKeyType       string        `long:"rpccertkeytype" description:"Type of the key of the RPC server certificate when it is generated {P-256, P-384, P-521, RSA-2048, RSA-3072, RSA-4096}"`
+	RPCCertValidity      time.Duration `long:"rpccertvalidity" description:"Duration the RPC server certificate is valid for when it is generated"`
+	RPCAuthType          string        `long:"rpcauthtype" description:"Method used to authenticate RPC clients {basic, clientcert} -- basic uses the RPC usernames and passwords, clientcert grants full access to clients with a certificate signed by a CA of --rpcclientcafile instead"`
+	RPCClientCAFile      string        `long:"rpcclientcafile" description:"File containing the certificates of the CAs which sign the certificates of RPC clients -- Clients must present such a certificate, in addition to a password unless --rpcauthtype=clientcert is used"`
+	RPCCookieFile        string        `long:"rpccookiefile" description:"File the RPC auth cookie is written to while the RPC server runs (default: .cookie in the data directory)"`
+	NoRPCCookie          bool          `long:"norpccookie" description:"Disable cookie-based RPC authentication"`
+	RPCPublicMethods     []string      `long:"rpcpublicmethod" description:"Allow clients of the HTTP POST endpoint of the RPC server to call the given read-only method, such as getblock, without authenticating -- Methods which change the state of the server or are expensive to serve are rejected (may be used multiple times)"`
+	RPCCORSOrigins       []string      `long:"rpccorsorigin" description:"Allow browser clients served from the given origin, such as https://explorer.example.com, to make cross-origin requests to the HTTP POST endpoint of the RPC server -- Use * to allow any origin without credentials (may be used multiple times)"`
 	RPCMaxClients        int           `long:"rpcmaxclients" description:"Max number of RPC clients for standard connections"`
 	RPCMaxWebsockets     int           `long:"rpcmaxwebsockets" description:"Max number of RPC websocket connections"`
 	RPCMaxConcurrentReqs int           `long:"rpcmaxconcurrentreqs" description:"Max number of concurrent RPC requests that may be processed concurrently"`
-	DisableRPC           bool          `long:"norpc" description:"Disable built-in RPC server -- NOTE: The RPC server is disabled by default if no rpcuser/rpcpass or rpclimituser/rpclimitpass is specified"`
+	RPCMaxWSSubs         int           `long:"rpcmaxwssubscriptions" description:"Max number of addresses and outpoints each RPC websocket client may add to its transaction filter (0 for no limit)"`
+	RPCMaxWSQueuedNtfns  int           `long:"rpcmaxwsqueuedntfns" description:"Max number of notifications queued for each RPC websocket client which is not reading them fast enough -- Further notifications are dropped and the client is notified of the number dropped once it catches up (0 for no limit)"`
+	DisableRPC           bool          `long:"norpc" description:"Disable built-in RPC server -- NOTE: The RPC server is disabled by default if no rpcuser/rpcpass or rpclimituser/rpclimitpass is specified and --norpccookie is used"`
 	DisableTLS           bool          `long:"notls" description:"Disable TLS for the RPC server -- NOTE: This is only allowed if the RPC server is bound to localhost"`
 	DisableDNSSeed       bool          `long:"nodnsseed" description:"Disable DNS seeding for peers"`
+	AddDNSSeeds          []string      `long:"adddnsseed" description:"Add a DNS seed to the default seeds of the network"`
+	RemoveDNSSeeds       []string      `long:"removednsseed" description:"Remove a DNS seed from the default seeds of the network"`
 	ExternalIPs          []string      `long:"externalip" description:"Add an ip to the list of local addresses we claim to listen on to peers"`
 	Proxy                string        `long:"proxy" description:"Connect via SOCKS5 proxy (eg. 127.0.0.1:9050)"`
 	ProxyUser            string        `long:"proxyuser" description:"Username for proxy server"`
@@ -128,11 +166,26 @@ type config struct {
 	TorIsolation         bool          `long:"torisolation" description:"Enable Tor stream isolation by randomizing user credentials for each connection."`
 	TestNet              bool          `long:"testnet" description:"Use the test network"`
 	SimNet               bool          `long:"simnet" description:"Use the simulation test network"`
+	SimNetMagic          uint32        `long:"simnetmagic" description:"Use the specified network magic instead of the standard one for the simulation test network so independent simnets on the same host do not communicate with each other -- Only valid with --simnet"`
+	SimNetPort           string        `long:"simnetport" description:"Use the specified default peer port instead of the standard one for the simulation test network -- Only valid with --simnet"`
+	SimNetRPCPort        string        `long:"simnetrpcport" description:"Use the specified default RPC port instead of the standard one for the simulation test network -- Only valid with --simnet"`
+	SimNetGenesisTime    int64         `long:"simnetgenesistime" description:"Use the specified timestamp (seconds since the unix epoch) for the genesis block of the simulation test network, which gives it a distinct genesis block -- Only valid with --simnet"`
+	RegNet               bool          `long:"regnet" description:"Use the regression test network"`
+	ChainParams          string        `long:"chainparams" description:"Use the custom network defined by the specified JSON file"`
 	DisableCheckpoints   bool          `long:"nocheckpoints" description:"Disable built-in checkpoints.  Don't do this unless you know what you're doing."`
+	AddCheckpoints       []string      `long:"addcheckpoint" description:"Add a custom checkpoint which is enforced in addition to the built-in ones and verified against the existing chain on startup.  Format: '<height>:<hash>'"`
 	DbType               string        `long:"dbtype" description:"Database backend to use for the Block Chain"`
 	Profile              string        `long:"profile" description:"Enable HTTP profiling on given [addr:]port -- NOTE port must be between 1024 and 65536"`
 	CPUProfile           string        `long:"cpuprofile" description:"Write CPU profile to the specified file"`
 	MemProfile           string        `long:"memprofile" description:"Write mem profile to the specified file"`
+	HealthListen         string        `long:"healthlisten" description:"Serve the /healthz liveness and /readyz readiness HTTP endpoints on the given [addr:]port -- all interfaces are used when only a port is given"`
+	HealthMaxBlockAge    time.Duration `long:"healthmaxblockage" description:"Maximum age of the best block for /readyz to report the chain as synced"`
+	OTLPEndpoint         string        `long:"otlpendpoint" description:"Export traces of block processing, block template generation, and RPC handling to the specified OpenTelemetry collector using OTLP over HTTP, such as http://127.0.0.1:4318"`
+	AlertWebhooks        []string      `long:"alertwebhook" description:"POST a JSON description of significant operational events, such as deep chain reorganizations, a stalled sync, and losing peers, to the specified HTTP(S) URL (may be used multiple times)"`
+	AlertCmd             string        `long:"alertcmd" description:"Run the specified command for significant operational events -- The JSON description of the event is written to its standard input and the name of the event is set in the EXCCD_EVENT environment variable"`
+	AlertReorgDepth      int64         `long:"alertreorgdepth" description:"Minimum number of blocks a chain reorganization must disconnect to trigger an alert"`
+	AlertSyncStall       time.Duration `long:"alertsyncstall" description:"Duration without a new best block after which the sync is considered stalled and an alert is triggered"`
+	AlertMinPeers        int           `long:"alertminpeers" description:"Trigger an alert when the number of connected peers drops below this number"`
 	DumpBlockchain       string        `long:"dumpblockchain" description:"Write blockchain as a flat file of blocks for use with addblock, to the specified filename"`
 	MiningTimeOffset     int           `long:"miningtimeoffset" description:"Offset the mining timestamp of a block by this many seconds (positive values are in the past)"`
 	DebugLevel           string        `short:"d" long:"debuglevel" description:"Logging level for all subsystems {trace, debug, info, warn, error, critical} -- You may also specify <subsystem>=<level>,<subsystem2>=<level>,... to set the log level for individual subsystems -- Use show to list available subsystems"`
@@ -141,18 +194,40 @@ type config struct {
 	FreeTxRelayLimit     float64       `long:"limitfreerelay" description:"Limit relay of transactions with no transaction fee to the given amount in thousands of bytes per minute"`
 	NoRelayPriority      bool          `long:"norelaypriority" description:"Do not require free or low-fee transactions to have high priority for relaying"`
 	MaxOrphanTxs         int           `long:"maxorphantx" description:"Max number of orphan transactions to keep in memory"`
+	NoDataCarrier        bool          `long:"nodatacarrier" description:"Do not relay or mine regular transactions with null data (OP_RETURN) outputs"`
+	DataCarrierSize      int           `long:"datacarriersize" description:"Maximum number of bytes of data carried by a null data (OP_RETURN) output of relayed and mined regular transactions"`
+	MaxDataCarriers      int           `long:"maxdatacarriers" description:"Maximum number of null data (OP_RETURN) outputs of relayed and mined regular transactions"`
 	Generate             bool          `long:"generate" description:"Generate (mine) coins using the CPU"`
 	MiningAddrs          []string      `long:"miningaddr" description:"Add the specified payment address to the list of addresses to use for generated blocks -- At least one address is required if the generate option is set"`
+	MiningRigID          uint16        `long:"miningrigid" description:"Identifier between 1 and 65535 of this rig which is encoded in the extra nonces of mined blocks, so rigs mining to the same address with distinct identifiers never search the same block headers (0 to disable)"`
+	MiningRefreshMin     time.Duration `long:"miningrefreshmin" description:"Minimum duration the CPU miner works on a block template before refreshing it to include new transactions paying at least the miningrefreshfee in fees or new votes"`
+	MiningRefreshMax     time.Duration `long:"miningrefreshmax" description:"Maximum duration the CPU miner works on a block template before refreshing it -- Templates are refreshed sooner the more fees new transactions pay, down to miningrefreshmin"`
+	MiningRefreshFee     float64       `long:"miningrefreshfee" description:"Fees in EXCC paid by the transactions which arrived since a block template was generated for the CPU miner to refresh it after miningrefreshmin"`
+	MiningArchive        uint32        `long:"miningarchive" description:"Keep the specified number of the most recent block templates issued to miners and solutions submitted by them, with timestamps and outcomes, in a ring buffer file in the data directory which is queryable via the getminingarchive RPC (0 to disable, max 100000)"`
 	BlockMinSize         uint32        `long:"blockminsize" description:"Mininum block size in bytes to be used when creating a block"`
 	BlockMaxSize         uint32        `long:"blockmaxsize" description:"Maximum block size in bytes to be used when creating a block"`
 	BlockPrioritySize    uint32        `long:"blockprioritysize" description:"Size in bytes for high-priority/low-fee transactions when creating a block"`
+	BlockMinVotes        uint16        `long:"blockminvotes" description:"Minimum number of votes for a block required before extending it when creating a block -- Defaults to the consensus minimum"`
+	BlockMaxVoteWait     time.Duration `long:"blockmaxvotewait" description:"Maximum duration to wait for missing votes for a new block before extending it with fewer than all of its votes when creating a block (e.g. 5s)"`
+	BlockNoRevocations   bool          `long:"blocknorevocations" description:"Do not include revocations of missed and expired tickets when creating a block"`
 	GetWorkKeys          []string      `long:"getworkkey" description:"DEPRECATED -- Use the --miningaddr option instead"`
 	NoPeerBloomFilters   bool          `long:"nopeerbloomfilters" description:"Disable bloom filtering support"`
 	SigCacheMaxSize      uint          `long:"sigcachemaxsize" description:"The maximum number of entries in the signature verification cache"`
+	PersistSigCache      bool          `long:"persistsigcache" description:"Save the signature verification cache on shutdown and load it on startup so signatures that were already verified are not verified again after a restart"`
+	BlockCacheSize       uint          `long:"blockcachesize" description:"The maximum number of recently connected and requested blocks to keep in memory so they are served to peers and RPC clients without loading them from the database again (0 to disable)"`
+	MemLimit             uint64        `long:"memlimit" description:"Soft limit in MiB on the memory used by the heap -- Caches and pools are trimmed and block downloads are throttled when the heap approaches it instead of running out of memory (0 to disable)"`
 	NonAggressive        bool          `long:"nonaggressive" description:"Disable mining off of the parent block of the blockchain if there aren't enough voters"`
 	NoMiningStateSync    bool          `long:"nominingstatesync" description:"Disable synchronizing the mining state with other nodes"`
 	AllowOldVotes        bool          `long:"allowoldvotes" description:"Enable the addition of very old votes to the mempool"`
+	AutoRevokeScripts    []string      `long:"autorevokescript" description:"Automatically create and relay revocations for missed and expired tickets with voting rights committed to the P2SH address of the given hex-encoded redeem script -- Signatures required by the redeem script are created with the keys given with --autorevokekey (may be used multiple times)"`
+	AutoRevokeKeys       []string      `long:"autorevokekey" description:"Automatically create and relay revocations for missed and expired tickets with voting rights committed to the P2PKH address of the given WIF-encoded secp256k1 private key, and sign the revocations of --autorevokescript redeem scripts with it (may be used multiple times)"`
+	SimNetAutoStake      bool          `long:"simnetautostake" description:"Automatically purchase tickets and vote with a node-held key so blocks can be generated without an external wallet -- The key is publicly known, so this is only valid with --simnet"`
+	SimNetVotes          []string      `long:"simnetvote" description:"Cast the given choice on the given agenda in the form <agenda>=<choice> with the votes of the simnet staker, which are cast with the vote version of the agendas, so they must all belong to the same version (may be used multiple times)"`
 	BlocksOnly           bool          `long:"blocksonly" description:"Do not accept transactions from remote peers."`
+	MiningOnly           bool          `long:"miningonly" description:"Run a dedicated mining node which keeps the chain and transactions received from peers for building block templates, but does not relay transactions other than those submitted via RPC, does not serve blocks older than a week to peers which are not whitelisted or filters to any peer, and does not advertise itself as a full node"`
+	PrivateRelay         bool          `long:"privaterelay" description:"Relay transactions submitted via RPC after a short random delay to a random subset of peers instead of immediately to all of them to make it harder for network observers to infer that they originated from this node"`
+	PrivateRelayTor      bool          `long:"privaterelaytor" description:"Only relay transactions submitted via RPC to peers connected through Tor -- Implies --privaterelay and requires --proxy or --onion"`
+	MaxUploadTarget      uint64        `long:"maxuploadtarget" description:"Try to keep the data uploaded to peers within the given number of MiB per 24 hours by disconnecting peers which are not whitelisted when they request blocks older than a week once it is reached (0 for no limit)"`
 	AcceptNonStd         bool          `long:"acceptnonstd" description:"Accept and relay non-standard transactions to the network regardless of the default settings for the active network."`
 	RejectNonStd         bool          `long:"rejectnonstd" description:"Reject non-standard transactions regardless of the default settings for the active network."`
 	TxIndex              bool          `long:"txindex" description:"Maintain a full hash-based transaction index which makes all transactions available via the getrawtransaction RPC"`
@@ -163,6 +238,13 @@ type config struct {
 	DropExistsAddrIndex  bool          `long:"dropexistsaddrindex" description:"Deletes the exists address index from the database on start up and then exits."`
 	NoCFilters           bool          `long:"nocfilters" description:"Disable compact filtering (CF) support"`
 	DropCFIndex          bool          `long:"dropcfindex" description:"Deletes the index used for compact filtering (CF) support from the database on start up and then exits."`
+	TimeIndex            bool          `long:"timeindex" description:"Maintain an index of blocks by their header timestamps which makes the getblockhashbytime RPC available"`
+	DropTimeIndex        bool          `long:"droptimeindex" description:"Deletes the block timestamp index from the database on start up and then exits."`
+	TicketIndex          bool          `long:"ticketindex" description:"Maintain an index of the lifecycle of every ticket which makes the getticketinfo and getaddresstickets RPCs available"`
+	DropTicketIndex      bool          `long:"dropticketindex" description:"Deletes the ticket lifecycle index from the database on start up and then exits."`
+	RebuildIndexes       []string      `long:"rebuildindex" description:"Drop the specified index on start up and rebuild it from the existing block data {txindex, addrindex, existsaddrindex, cfindex, timeindex, ticketindex} (may be used multiple times)"`
+	ExternalIndexers     []string      `long:"externalindexer" description:"Maintain an index in an external process that is notified of all connected and disconnected blocks in the form of name@host:port (may be used multiple times)"`
+	DropExternalIndexers []string      `long:"dropexternalindexer" description:"Deletes the tip of the named external index from the database on start up and then exits so it will be rebuilt from scratch (may be used multiple times)"`
 	PipeRx               uint          `long:"piperx" description:"File descriptor of read end pipe to enable parent -> child process communication"`
 	PipeTx               uint          `long:"pipetx" description:"File descriptor of write end pipe to enable parent <- child process communication"`
 	LifetimeEvents       bool          `long:"lifetimeevents" description:"Send lifetime notifications over the TX pipe"`
@@ -172,7 +254,19 @@ type config struct {
 	dial                 func(string, string) (net.Conn, error)
 	miningAddrs          []exccutil.Address
 	minRelayTxFee        exccutil.Amount
+	miningRefreshFee     exccutil.Amount
 	whitelists           []*net.IPNet
+	externalIndexers     []externalIndexer
+	autoRevokeScripts    [][]byte
+	autoRevokeKeys       []*exccutil.WIF
+	addCheckpoints       []chaincfg.Checkpoint
+}
+
+// externalIndexer houses the name and network address of an external indexer
+// as parsed from the --externalindexer option.
+type externalIndexer struct {
+	name string
+	addr string
 }
 
 // serviceOptions defines the configuration options for the daemon as a service on
@@ -181,6 +275,82 @@ type serviceOptions struct {
 	ServiceCommand string `short:"s" long:"service" description:"Service command {install, remove, start, stop}"`
 }
 
+// newCheckpointFromStr parses a checkpoint in the '<height>:<hash>' format.
+func newCheckpointFromStr(checkpoint string) (chaincfg.Checkpoint, error) {
+	parts := strings.Split(checkpoint, ":")
+	if len(parts) != 2 {
+		return chaincfg.Checkpoint{}, fmt.Errorf("unable to parse "+
+			"checkpoint %q -- use the syntax <height>:<hash>",
+			checkpoint)
+	}
+
+	height, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || height <= 0 {
+		return chaincfg.Checkpoint{}, fmt.Errorf("unable to parse "+
+			"checkpoint %q due to malformed height", checkpoint)
+	}
+
+	hash, err := chainhash.NewHashFromStr(parts[1])
+	if err != nil || len(parts[1]) != chainhash.MaxHashStringSize {
+		return chaincfg.Checkpoint{}, fmt.Errorf("unable to parse "+
+			"checkpoint %q due to malformed hash", checkpoint)
+	}
+
+	return chaincfg.Checkpoint{
+		Height: height,
+		Hash:   hash,
+	}, nil
+}
+
+// parseCheckpoints checks the checkpoint strings for valid syntax
+// ('<height>:<hash>') and parses them to chaincfg.Checkpoint instances.
+func parseCheckpoints(checkpointStrings []string) ([]chaincfg.Checkpoint, error) {
+	if len(checkpointStrings) == 0 {
+		return nil, nil
+	}
+	checkpoints := make([]chaincfg.Checkpoint, len(checkpointStrings))
+	for i, cpString := range checkpointStrings {
+		checkpoint, err := newCheckpointFromStr(cpString)
+		if err != nil {
+			return nil, err
+		}
+		checkpoints[i] = checkpoint
+	}
+	return checkpoints, nil
+}
+
+// mergeCheckpoints returns the passed default and additional checkpoints
+// merged into a single slice sorted by height.  An additional checkpoint takes
+// precedence over a default checkpoint at the same height, and when several
+// additional checkpoints share a height, the last one specified is used.
+func mergeCheckpoints(defaultCheckpoints, additional []chaincfg.Checkpoint) []chaincfg.Checkpoint {
+	// Create a map of the additional checkpoints to remove duplicates while
+	// leaving the most recently-specified checkpoint.
+	extra := make(map[int64]chaincfg.Checkpoint)
+	for _, checkpoint := range additional {
+		extra[checkpoint.Height] = checkpoint
+	}
+
+	// Add all default checkpoints that do not have an override in the
+	// additional checkpoints.
+	checkpoints := make([]chaincfg.Checkpoint, 0,
+		len(defaultCheckpoints)+len(extra))
+	for _, checkpoint := range defaultCheckpoints {
+		if _, exists := extra[checkpoint.Height]; !exists {
+			checkpoints = append(checkpoints, checkpoint)
+		}
+	}
+
+	// Append the additional checkpoints and return the sorted results.
+	for _, checkpoint := range extra {
+		checkpoints = append(checkpoints, checkpoint)
+	}
+	sort.Slice(checkpoints, func(i, j int) bool {
+		return checkpoints[i].Height < checkpoints[j].Height
+	})
+	return checkpoints
+}
+
 // cleanAndExpandPath expands environment variables and leading ~ in the
 // passed path, cleans the result, and returns it.
 func cleanAndExpandPath(path string) string {
@@ -356,6 +526,102 @@ func fileExists(name string) bool {
 	return true
 }
 
+// parseWhitelists parses the passed whitelisted IP addresses and networks.  IP
+// addresses are treated as networks which only contain the address.
+func parseWhitelists(whitelists []string) ([]*net.IPNet, error) {
+	if len(whitelists) == 0 {
+		return nil, nil
+	}
+
+	ipnets := make([]*net.IPNet, 0, len(whitelists))
+	for _, addr := range whitelists {
+		_, ipnet, err := net.ParseCIDR(addr)
+		if err != nil {
+			ip := net.ParseIP(addr)
+			if ip == nil {
+				str := "the whitelist value of '%s' is invalid"
+				return nil, fmt.Errorf(str, addr)
+			}
+			var bits int
+			if ip.To4() == nil {
+				// IPv6
+				bits = 128
+			} else {
+				bits = 32
+			}
+			ipnet = &net.IPNet{
+				IP:   ip,
+				Mask: net.CIDRMask(bits, bits),
+			}
+		}
+		ipnets = append(ipnets, ipnet)
+	}
+	return ipnets, nil
+}
+
+// checkRPCPublicMethods ensures the passed methods which are made callable
+// without authenticating are read-only methods served over HTTP POST.
+func checkRPCPublicMethods(methods []string) error {
+	for _, method := range methods {
+		_, isAllowed := rpcPublicAllowed[method]
+		_, isHandled := rpcHandlers[method]
+		if !isAllowed || !isHandled {
+			str := "the rpcpublicmethod option %q is not a read-only " +
+				"method which may be made public"
+			return fmt.Errorf(str, method)
+		}
+	}
+	return nil
+}
+
+// checkRPCCertOptions ensures the options used to generate the RPC server
+// certificate are valid.
+func checkRPCCertOptions(keyType string, validity time.Duration) error {
+	if _, ok := rpcCertKeyTypes[keyType]; !ok {
+		str := "the rpccertkeytype option must be one of %s -- " +
+			"parsed [%v]"
+		return fmt.Errorf(str, strings.Join(supportedRPCCertKeyTypes(),
+			", "), keyType)
+	}
+	if validity <= 0 {
+		str := "the rpccertvalidity option must be positive -- " +
+			"parsed [%v]"
+		return fmt.Errorf(str, validity)
+	}
+	return nil
+}
+
+// parseMiningAddrs decodes the passed getwork keys and mining addresses and
+// ensures they are for the passed network.
+func parseMiningAddrs(getWorkKeys, miningAddrs []string, params *chaincfg.Params) ([]exccutil.Address, error) {
+	addrs := make([]exccutil.Address, 0, len(getWorkKeys)+len(miningAddrs))
+	for _, strAddr := range getWorkKeys {
+		addr, err := exccutil.DecodeAddress(strAddr)
+		if err != nil {
+			str := "getworkkey '%s' failed to decode: %v"
+			return nil, fmt.Errorf(str, strAddr, err)
+		}
+		if !addr.IsForNet(params) {
+			str := "getworkkey '%s' is on the wrong network"
+			return nil, fmt.Errorf(str, strAddr)
+		}
+		addrs = append(addrs, addr)
+	}
+	for _, strAddr := range miningAddrs {
+		addr, err := exccutil.DecodeAddress(strAddr)
+		if err != nil {
+			str := "mining address '%s' failed to decode: %v"
+			return nil, fmt.Errorf(str, strAddr, err)
+		}
+		if !addr.IsForNet(params) {
+			str := "mining address '%s' is on the wrong network"
+			return nil, fmt.Errorf(str, strAddr)
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs, nil
+}
+
 // newConfigParser returns a new command line flags parser.
 func newConfigParser(cfg *config, so *serviceOptions, options flags.Options) *flags.Parser {
 	parser := flags.NewParser(cfg, options)
@@ -409,21 +675,10 @@ func createDefaultConfigFile(destPath string) error {
 	return err
 }
 
-// loadConfig initializes and parses the config using a config file and command
-// line options.
-//
-// The configuration proceeds as follows:
-// 	1) Start with a default config with sane settings
-// 	2) Pre-parse the command line to check for an alternative config file
-// 	3) Load configuration file overwriting defaults with any specified options
-// 	4) Parse CLI options and overwrite/add any specified options
-//
-// The above results in exccd functioning properly without any config settings
-// while still allowing the user to override settings with config files and
-// command line options.  Command line options always take precedence.
-func loadConfig() (*config, []string, error) {
-	// Default config.
-	cfg := config{
+// newDefaultConfig returns a config with the default settings, which are
+// overridden by the config file and command line options.
+func newDefaultConfig() config {
+	return config{
 		HomeDir:              defaultHomeDir,
 		ConfigFile:           defaultConfigFile,
 		DebugLevel:           defaultLogLevel,
@@ -433,26 +688,63 @@ func loadConfig() (*config, []string, error) {
 		RPCMaxClients:        defaultMaxRPCClients,
 		RPCMaxWebsockets:     defaultMaxRPCWebsockets,
 		RPCMaxConcurrentReqs: defaultMaxRPCConcurrentReqs,
+		RPCMaxWSSubs:         defaultMaxRPCWSSubscriptions,
+		RPCMaxWSQueuedNtfns:  defaultMaxRPCWSQueuedNtfns,
 		DataDir:              defaultDataDir,
 		LogDir:               defaultLogDir,
+		LogFormat:            logFormatText,
+		LogMaxSize:           defaultLogMaxSize,
+		LogMaxRolls:          defaultLogMaxRolls,
 		DbType:               defaultDbType,
+		HealthMaxBlockAge:    defaultHealthMaxBlockAge,
+		AlertReorgDepth:      defaultAlertReorgDepth,
+		AlertSyncStall:       defaultAlertSyncStall,
+		AlertMinPeers:        defaultAlertMinPeers,
 		RPCKey:               defaultRPCKeyFile,
 		RPCCert:              defaultRPCCertFile,
+		RPCCertKeyType:       defaultRPCCertKeyType,
+		RPCCertValidity:      defaultRPCCertValidity,
+		RPCAuthType:          rpcAuthTypeBasic,
 		MinRelayTxFee:        mempool.DefaultMinRelayTxFee.ToCoin(),
 		FreeTxRelayLimit:     defaultFreeTxRelayLimit,
+		MiningRefreshMin:     defaultMiningRefreshMin,
+		MiningRefreshMax:     defaultMiningRefreshMax,
+		MiningRefreshFee:     defaultMiningRefreshFee.ToCoin(),
 		BlockMinSize:         defaultBlockMinSize,
 		BlockMaxSize:         defaultBlockMaxSize,
 		BlockPrioritySize:    mempool.DefaultBlockPrioritySize,
 		MaxOrphanTxs:         defaultMaxOrphanTransactions,
+		DataCarrierSize:      defaultDataCarrierSize,
+		MaxDataCarriers:      defaultMaxDataCarriers,
 		SigCacheMaxSize:      defaultSigCacheMaxSize,
+		BlockCacheSize:       defaultBlockCacheSize,
 		Generate:             defaultGenerate,
 		NoMiningStateSync:    defaultNoMiningStateSync,
 		TxIndex:              defaultTxIndex,
 		AddrIndex:            defaultAddrIndex,
+		TimeIndex:            defaultTimeIndex,
+		TicketIndex:          defaultTicketIndex,
 		AllowOldVotes:        defaultAllowOldVotes,
 		NoExistsAddrIndex:    defaultNoExistsAddrIndex,
 		NoCFilters:           defaultNoCFilters,
 	}
+}
+
+// loadConfig initializes and parses the config using a config file and command
+// line options.
+//
+// The configuration proceeds as follows:
+// 	1) Start with a default config with sane settings
+// 	2) Pre-parse the command line to check for an alternative config file
+// 	3) Load configuration file overwriting defaults with any specified options
+// 	4) Parse CLI options and overwrite/add any specified options
+//
+// The above results in exccd functioning properly without any config settings
+// while still allowing the user to override settings with config files and
+// command line options.  Command line options always take precedence.
+func loadConfig() (*config, []string, error) {
+	// Default config.
+	cfg := newDefaultConfig()
 
 	// Service options which are only added on Windows.
 	serviceOpts := serviceOptions{}
@@ -532,7 +824,8 @@ func loadConfig() (*config, []string, error) {
 
 	// Create a default config file when one does not exist and the user did
 	// not specify an override.
-	if !preCfg.SimNet && preCfg.ConfigFile == defaultConfigFile &&
+	if !preCfg.SimNet && !preCfg.RegNet &&
+		preCfg.ConfigFile == defaultConfigFile &&
 		!fileExists(preCfg.ConfigFile) {
 
 		err := createDefaultConfigFile(preCfg.ConfigFile)
@@ -545,7 +838,7 @@ func loadConfig() (*config, []string, error) {
 	// Load additional config from file.
 	var configFileError error
 	parser := newConfigParser(&cfg, &serviceOpts, flags.Default)
-	if !cfg.SimNet || preCfg.ConfigFile != defaultConfigFile {
+	if !(cfg.SimNet || cfg.RegNet) || preCfg.ConfigFile != defaultConfigFile {
 		err := flags.NewIniParser(parser).ParseFile(preCfg.ConfigFile)
 		if err != nil {
 			if _, ok := err.(*os.PathError); !ok {
@@ -602,15 +895,60 @@ func loadConfig() (*config, []string, error) {
 		activeNetParams = &simNetParams
 		cfg.DisableDNSSeed = true
 	}
+	if cfg.RegNet {
+		numNets++
+		// Also disable dns seeding on the regression test network.
+		activeNetParams = &regNetParams
+		cfg.DisableDNSSeed = true
+	}
+	if cfg.ChainParams != "" {
+		numNets++
+		customNetParams, err := loadCustomNetParams(
+			cleanAndExpandPath(cfg.ChainParams))
+		if err != nil {
+			str := "%s: failed to load custom network parameters: %v"
+			err := fmt.Errorf(str, funcName, err)
+			fmt.Fprintln(os.Stderr, err)
+			return nil, nil, err
+		}
+		activeNetParams = customNetParams
+	}
 	if numNets > 1 {
-		str := "%s: the testnet and simnet params can't be " +
-			"used together -- choose one of the three"
+		str := "%s: the testnet, simnet, regnet, and chainparams " +
+			"params can't be used together -- choose one of the five"
 		err := fmt.Errorf(str, funcName)
 		fmt.Fprintln(os.Stderr, err)
 		fmt.Fprintln(os.Stderr, usageMessage)
 		return nil, nil, err
 	}
 
+	// Override the parameters of the simulation test network as requested so
+	// several independent simnets can run on the same host.
+	if cfg.SimNetMagic != 0 || cfg.SimNetPort != "" ||
+		cfg.SimNetRPCPort != "" || cfg.SimNetGenesisTime != 0 {
+
+		if !cfg.SimNet {
+			str := "%s: the simnetmagic, simnetport, simnetrpcport, " +
+				"and simnetgenesistime options are only valid with " +
+				"--simnet"
+			err := fmt.Errorf(str, funcName)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, nil, err
+		}
+
+		isolatedNetParams, err := isolatedSimNetParams(cfg.SimNetMagic,
+			cfg.SimNetPort, cfg.SimNetRPCPort, cfg.SimNetGenesisTime)
+		if err != nil {
+			str := "%s: unable to override the simulation test " +
+				"network parameters: %v"
+			err := fmt.Errorf(str, funcName, err)
+			fmt.Fprintln(os.Stderr, err)
+			return nil, nil, err
+		}
+		activeNetParams = isolatedNetParams
+	}
+
 	// Set the default policy for relaying non-standard transactions
 	// according to the default of the active network. The set
 	// configuration value takes precedence over the default value for the
@@ -644,6 +982,42 @@ func loadConfig() (*config, []string, error) {
 	var oldTestNets []string
 	oldTestNets = append(oldTestNets, filepath.Join(cfg.DataDir, "testnet"))
 	cfg.DataDir = filepath.Join(cfg.DataDir, netName(activeNetParams))
+	// Validate the log format.
+	if cfg.LogFormat != logFormatText && cfg.LogFormat != logFormatJSON {
+		str := "%s: the specified log format [%v] is invalid -- " +
+			"supported formats %v"
+		err := fmt.Errorf(str, funcName, cfg.LogFormat,
+			[]string{logFormatText, logFormatJSON})
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+
+	// Validate the log rotation settings.
+	if cfg.LogMaxSize < 0 || cfg.LogMaxAge < 0 || cfg.LogMaxRolls < 0 {
+		str := "%s: the logmaxsize, logmaxage, and logmaxrolls options " +
+			"may not be negative"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+
+	// Validate the subsystems which are logged to their own file.
+	for i, subsystemID := range cfg.LogSplit {
+		subsystemID = strings.ToUpper(subsystemID)
+		if _, ok := subsystemLoggers[subsystemID]; !ok {
+			str := "%s: the specified subsystem [%v] to log to its " +
+				"own file is invalid -- supported subsystems %v"
+			err := fmt.Errorf(str, funcName, cfg.LogSplit[i],
+				supportedSubsystems())
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, nil, err
+		}
+		cfg.LogSplit[i] = subsystemID
+	}
+
 	logRotator = nil
 	if !cfg.NoFileLogging {
 		// Append the network type to the log directory so it is "namespaced"
@@ -653,9 +1027,20 @@ func loadConfig() (*config, []string, error) {
 
 		// Initialize log rotation.  After log rotation has been initialized, the
 		// logger variables may be used.
-		initLogRotator(filepath.Join(cfg.LogDir, defaultLogFilename))
+		initLogRotators(filepath.Join(cfg.LogDir, defaultLogFilename),
+			logRotationConfig{
+				maxSize:  cfg.LogMaxSize * 1024,
+				maxAge:   cfg.LogMaxAge,
+				maxRolls: cfg.LogMaxRolls,
+				compress: cfg.LogCompression,
+			}, cfg.LogSplit)
 	}
 
+	// Replace the subsystem loggers with loggers of the configured format
+	// which write to the configured log files.  This must be done before
+	// the log levels are set.
+	useLogBackends(cfg.LogFormat)
+
 	// Special show command to list supported subsystems and exit.
 	if cfg.DebugLevel == "show" {
 		fmt.Println("Supported subsystems", supportedSubsystems())
@@ -707,6 +1092,72 @@ func loadConfig() (*config, []string, error) {
 		}
 	}
 
+	// Validate format of the health server address, which can be an
+	// address:port, or just a port to listen on all interfaces.
+	if cfg.HealthListen != "" {
+		if _, err := strconv.Atoi(cfg.HealthListen); err == nil {
+			cfg.HealthListen = net.JoinHostPort("", cfg.HealthListen)
+		}
+		_, portStr, err := net.SplitHostPort(cfg.HealthListen)
+		if err == nil {
+			var port int
+			port, err = strconv.Atoi(portStr)
+			if err == nil && (port < 1 || port > 65535) {
+				err = fmt.Errorf("port %d out of range", port)
+			}
+		}
+		if err != nil {
+			str := "%s: healthlisten: %v"
+			err := fmt.Errorf(str, funcName, err)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, nil, err
+		}
+	}
+	if cfg.HealthMaxBlockAge <= 0 {
+		str := "%s: the healthmaxblockage option must be positive -- parsed [%v]"
+		err := fmt.Errorf(str, funcName, cfg.HealthMaxBlockAge)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+
+	// Validate the alert options.
+	for _, webhook := range cfg.AlertWebhooks {
+		u, err := url.Parse(webhook)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") ||
+			u.Host == "" {
+
+			str := "%s: the alertwebhook option must be an HTTP or " +
+				"HTTPS URL -- parsed [%v]"
+			err := fmt.Errorf(str, funcName, webhook)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, nil, err
+		}
+	}
+	if cfg.AlertReorgDepth < 1 {
+		str := "%s: the alertreorgdepth option must be positive -- parsed [%v]"
+		err := fmt.Errorf(str, funcName, cfg.AlertReorgDepth)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+	if cfg.AlertSyncStall <= 0 {
+		str := "%s: the alertsyncstall option must be positive -- parsed [%v]"
+		err := fmt.Errorf(str, funcName, cfg.AlertSyncStall)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+	if cfg.AlertMinPeers < 0 {
+		str := "%s: the alertminpeers option may not be negative -- parsed [%v]"
+		err := fmt.Errorf(str, funcName, cfg.AlertMinPeers)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+
 	// Don't allow ban durations that are too short.
 	if cfg.BanDuration < time.Second {
 		str := "%s: the banduration option may not be less than 1s -- parsed [%v]"
@@ -717,35 +1168,23 @@ func loadConfig() (*config, []string, error) {
 	}
 
 	// Validate any given whitelisted IP addresses and networks.
-	if len(cfg.Whitelists) > 0 {
-		var ip net.IP
-		cfg.whitelists = make([]*net.IPNet, 0, len(cfg.Whitelists))
+	cfg.whitelists, err = parseWhitelists(cfg.Whitelists)
+	if err != nil {
+		err := fmt.Errorf("%s: %v", funcName, err)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
 
-		for _, addr := range cfg.Whitelists {
-			_, ipnet, err := net.ParseCIDR(addr)
-			if err != nil {
-				ip = net.ParseIP(addr)
-				if ip == nil {
-					str := "%s: the whitelist value of '%s' is invalid"
-					err = fmt.Errorf(str, funcName, addr)
-					fmt.Fprintln(os.Stderr, err)
-					fmt.Fprintln(os.Stderr, usageMessage)
-					return nil, nil, err
-				}
-				var bits int
-				if ip.To4() == nil {
-					// IPv6
-					bits = 128
-				} else {
-					bits = 32
-				}
-				ipnet = &net.IPNet{
-					IP:   ip,
-					Mask: net.CIDRMask(bits, bits),
-				}
-			}
-			cfg.whitelists = append(cfg.whitelists, ipnet)
-		}
+	// Mining-only nodes build block templates from the transactions they
+	// receive from peers.
+	if cfg.MiningOnly && cfg.BlocksOnly {
+		str := "%s: the --miningonly and --blocksonly options can not " +
+			"be mixed"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
 	}
 
 	// --addPeer and --connect do not mix.
@@ -798,12 +1237,96 @@ func loadConfig() (*config, []string, error) {
 		return nil, nil, err
 	}
 
-	// The RPC server is disabled if no username or password is provided.
-	if (cfg.RPCUser == "" || cfg.RPCPass == "") &&
-		(cfg.RPCLimitUser == "" || cfg.RPCLimitPass == "") {
+	// Validate the RPC client authentication options.
+	switch cfg.RPCAuthType {
+	case rpcAuthTypeBasic:
+	case rpcAuthTypeClientCert:
+		if cfg.RPCClientCAFile == "" {
+			str := "%s: the rpcauthtype option %s requires the " +
+				"rpcclientcafile option"
+			err := fmt.Errorf(str, funcName, rpcAuthTypeClientCert)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, nil, err
+		}
+	default:
+		str := "%s: the rpcauthtype option must be one of %s or %s -- " +
+			"parsed [%v]"
+		err := fmt.Errorf(str, funcName, rpcAuthTypeBasic,
+			rpcAuthTypeClientCert, cfg.RPCAuthType)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+	if cfg.RPCClientCAFile != "" {
+		if cfg.DisableTLS {
+			str := "%s: the rpcclientcafile option may not be used " +
+				"with the notls option"
+			err := fmt.Errorf(str, funcName)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, nil, err
+		}
+		cfg.RPCClientCAFile = cleanAndExpandPath(cfg.RPCClientCAFile)
+	}
+
+	// Only the read-only methods which are served over HTTP POST may be
+	// called without authenticating.  Clients which do not authenticate are
+	// unable to connect when client certificates are required.
+	if err := checkRPCPublicMethods(cfg.RPCPublicMethods); err != nil {
+		err := fmt.Errorf("%s: %v", funcName, err)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+	if len(cfg.RPCPublicMethods) > 0 && cfg.RPCClientCAFile != "" {
+		str := "%s: the rpcpublicmethod option may not be used with the " +
+			"rpcclientcafile option"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+
+	// CORS origins must either be a scheme and host without a path or the
+	// wildcard.  They are normalized to the form browsers send them in.
+	for i, origin := range cfg.RPCCORSOrigins {
+		if origin == "*" {
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || u.Scheme == "" || u.Host == "" ||
+			(u.Path != "" && u.Path != "/") {
+
+			str := "%s: the rpccorsorigin option %q is not an origin " +
+				"such as https://example.com"
+			err := fmt.Errorf(str, funcName, origin)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, nil, err
+		}
+		cfg.RPCCORSOrigins[i] = strings.ToLower(u.Scheme + "://" + u.Host)
+	}
+
+	// The RPC server is disabled if no username or password is provided
+	// and cookie authentication is disabled unless clients authenticate
+	// with certificates instead or may call some methods without
+	// authenticating.
+	if cfg.RPCAuthType == rpcAuthTypeBasic && cfg.NoRPCCookie &&
+		(cfg.RPCUser == "" || cfg.RPCPass == "") &&
+		(cfg.RPCLimitUser == "" || cfg.RPCLimitPass == "") &&
+		len(cfg.RPCPublicMethods) == 0 {
 		cfg.DisableRPC = true
 	}
 
+	// The auth cookie is written to the network specific data directory
+	// by default.
+	if cfg.RPCCookieFile == "" {
+		cfg.RPCCookieFile = filepath.Join(cfg.DataDir, defaultRPCCookieFilename)
+	} else {
+		cfg.RPCCookieFile = cleanAndExpandPath(cfg.RPCCookieFile)
+	}
+
 	// Default RPC to listen on localhost only.
 	if !cfg.DisableRPC && len(cfg.RPCListeners) == 0 {
 		addrs, err := net.LookupHost("localhost")
@@ -817,6 +1340,15 @@ func loadConfig() (*config, []string, error) {
 		}
 	}
 
+	// Validate the options used to generate the RPC server certificate.
+	err = checkRPCCertOptions(cfg.RPCCertKeyType, cfg.RPCCertValidity)
+	if err != nil {
+		err := fmt.Errorf("%s: %v", funcName, err)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+
 	if cfg.RPCMaxConcurrentReqs < 0 {
 		str := "%s: the rpcmaxwebsocketconcurrentrequests option may " +
 			"not be less than 0 -- parsed [%d]"
@@ -826,6 +1358,24 @@ func loadConfig() (*config, []string, error) {
 		return nil, nil, err
 	}
 
+	if cfg.RPCMaxWSSubs < 0 {
+		str := "%s: the rpcmaxwssubscriptions option may not be less " +
+			"than 0 -- parsed [%d]"
+		err := fmt.Errorf(str, funcName, cfg.RPCMaxWSSubs)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+
+	if cfg.RPCMaxWSQueuedNtfns < 0 {
+		str := "%s: the rpcmaxwsqueuedntfns option may not be less " +
+			"than 0 -- parsed [%d]"
+		err := fmt.Errorf(str, funcName, cfg.RPCMaxWSQueuedNtfns)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+
 	// Validate the the minrelaytxfee.
 	cfg.minRelayTxFee, err = exccutil.NewAmount(cfg.MinRelayTxFee)
 	if err != nil {
@@ -861,6 +1411,103 @@ func loadConfig() (*config, []string, error) {
 		return nil, nil, err
 	}
 
+	// Ensure the data carrier size is in the range of sizes null data
+	// outputs are able to carry.
+	if cfg.DataCarrierSize < 0 ||
+		cfg.DataCarrierSize > txscript.MaxDataCarrierSize {
+
+		str := "%s: the datacarriersize option must be in between 0 " +
+			"and %d -- parsed [%d]"
+		err := fmt.Errorf(str, funcName, txscript.MaxDataCarrierSize,
+			cfg.DataCarrierSize)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+
+	// Limit the max data carrier outputs to a sane value.
+	if cfg.MaxDataCarriers < 0 {
+		str := "%s: the maxdatacarriers option may not be less than 0 " +
+			"-- parsed [%d]"
+		err := fmt.Errorf(str, funcName, cfg.MaxDataCarriers)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+
+	// Ensure the minimum number of votes is in the range allowed by the
+	// consensus rules.
+	if cfg.BlockMinVotes != 0 {
+		ticketsPerBlock := activeNetParams.TicketsPerBlock
+		consensusMinVotes := ticketsPerBlock/2 + 1
+		if cfg.BlockMinVotes < consensusMinVotes ||
+			cfg.BlockMinVotes > ticketsPerBlock {
+
+			str := "%s: the blockminvotes option must be in between %d " +
+				"and %d -- parsed [%d]"
+			err := fmt.Errorf(str, funcName, consensusMinVotes,
+				ticketsPerBlock, cfg.BlockMinVotes)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, nil, err
+		}
+	}
+
+	// The maximum duration to wait for votes may not be negative.
+	if cfg.BlockMaxVoteWait < 0 {
+		str := "%s: the blockmaxvotewait option may not be negative " +
+			"-- parsed [%v]"
+		err := fmt.Errorf(str, funcName, cfg.BlockMaxVoteWait)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+
+	// The CPU miner must work on block templates for a positive duration
+	// before refreshing them and the maximum duration may not be shorter
+	// than the minimum.
+	if cfg.MiningRefreshMin <= 0 {
+		str := "%s: the miningrefreshmin option must be positive " +
+			"-- parsed [%v]"
+		err := fmt.Errorf(str, funcName, cfg.MiningRefreshMin)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+	if cfg.MiningRefreshMax < cfg.MiningRefreshMin {
+		str := "%s: the miningrefreshmax option may not be less than " +
+			"miningrefreshmin [%v] -- parsed [%v]"
+		err := fmt.Errorf(str, funcName, cfg.MiningRefreshMin,
+			cfg.MiningRefreshMax)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+
+	// Limit the size of the mining archive.
+	if cfg.MiningArchive > maxMiningArchiveRecords {
+		str := "%s: the miningarchive option may not be more than " +
+			"%d -- parsed [%d]"
+		err := fmt.Errorf(str, funcName, maxMiningArchiveRecords,
+			cfg.MiningArchive)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+
+	// Validate the miningrefreshfee.
+	cfg.miningRefreshFee, err = exccutil.NewAmount(cfg.MiningRefreshFee)
+	if err == nil && cfg.miningRefreshFee < 0 {
+		err = fmt.Errorf("negative amount %v", cfg.miningRefreshFee)
+	}
+	if err != nil {
+		str := "%s: invalid miningrefreshfee: %v"
+		err := fmt.Errorf(str, funcName, err)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+
 	// Limit the block priority and minimum block sizes to max block size.
 	cfg.BlockPrioritySize = minUint32(cfg.BlockPrioritySize, cfg.BlockMaxSize)
 	cfg.BlockMinSize = minUint32(cfg.BlockMinSize, cfg.BlockMaxSize)
@@ -897,6 +1544,113 @@ func loadConfig() (*config, []string, error) {
 		return nil, nil, err
 	}
 
+	// --timeindex and --droptimeindex do not mix.
+	if cfg.TimeIndex && cfg.DropTimeIndex {
+		err := fmt.Errorf("%s: the --timeindex and --droptimeindex "+
+			"options may not be activated at the same time",
+			funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+
+	// --ticketindex and --dropticketindex do not mix.
+	if cfg.TicketIndex && cfg.DropTicketIndex {
+		err := fmt.Errorf("%s: the --ticketindex and --dropticketindex "+
+			"options may not be activated at the same time",
+			funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+
+	// Enable all indexes which are requested to be rebuilt while ensuring
+	// they are not also requested to be dropped or disabled.
+	for _, idxName := range cfg.RebuildIndexes {
+		var conflict string
+		switch idxName {
+		case "txindex":
+			if cfg.DropTxIndex {
+				conflict = "--droptxindex"
+			}
+			cfg.TxIndex = true
+		case "addrindex":
+			if cfg.DropAddrIndex || cfg.DropTxIndex {
+				conflict = "--dropaddrindex and --droptxindex"
+			}
+			cfg.AddrIndex = true
+		case "existsaddrindex":
+			if cfg.NoExistsAddrIndex || cfg.DropExistsAddrIndex {
+				conflict = "--noexistsaddrindex and --dropexistsaddrindex"
+			}
+		case "cfindex":
+			if cfg.NoCFilters || cfg.DropCFIndex {
+				conflict = "--nocfilters and --dropcfindex"
+			}
+		case "timeindex":
+			if cfg.DropTimeIndex {
+				conflict = "--droptimeindex"
+			}
+			cfg.TimeIndex = true
+		case "ticketindex":
+			if cfg.DropTicketIndex {
+				conflict = "--dropticketindex"
+			}
+			cfg.TicketIndex = true
+		default:
+			str := "%s: the --rebuildindex option does not support " +
+				"index '%s' -- supported indexes are txindex, " +
+				"addrindex, existsaddrindex, cfindex, timeindex, and " +
+				"ticketindex"
+			err := fmt.Errorf(str, funcName, idxName)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, nil, err
+		}
+		if conflict != "" {
+			str := "%s: --rebuildindex=%s may not be used with %s"
+			err := fmt.Errorf(str, funcName, idxName, conflict)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, nil, err
+		}
+	}
+
+	// Validate the external indexers and save the parsed versions.
+	for _, extIndexer := range cfg.ExternalIndexers {
+		parts := strings.SplitN(extIndexer, "@", 2)
+		if len(parts) != 2 || parts[1] == "" {
+			str := "%s: external indexer '%s' must be in the form " +
+				"name@host:port"
+			err := fmt.Errorf(str, funcName, extIndexer)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, nil, err
+		}
+		if !indexers.ValidExternalIndexName(parts[0]) {
+			str := "%s: external indexer name '%s' may only contain " +
+				"letters, digits, dashes, and underscores"
+			err := fmt.Errorf(str, funcName, parts[0])
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, nil, err
+		}
+		for _, dropName := range cfg.DropExternalIndexers {
+			if dropName == parts[0] {
+				str := "%s: the --externalindexer and " +
+					"--dropexternalindexer options may not be " +
+					"activated at the same time for external " +
+					"indexer '%s'"
+				err := fmt.Errorf(str, funcName, dropName)
+				fmt.Fprintln(os.Stderr, err)
+				fmt.Fprintln(os.Stderr, usageMessage)
+				return nil, nil, err
+			}
+		}
+		cfg.externalIndexers = append(cfg.externalIndexers,
+			externalIndexer{name: parts[0], addr: parts[1]})
+	}
+
 	// !--noexistsaddrindex and --dropexistsaddrindex do not mix.
 	if !cfg.NoExistsAddrIndex && cfg.DropExistsAddrIndex {
 		err := fmt.Errorf("dropexistsaddrindex cannot be activated when " +
@@ -914,51 +1668,119 @@ func loadConfig() (*config, []string, error) {
 		return nil, nil, err
 	}
 
-	// Check getwork keys are valid and saved parsed versions.
-	cfg.miningAddrs = make([]exccutil.Address, 0, len(cfg.GetWorkKeys)+
-		len(cfg.MiningAddrs))
-	for _, strAddr := range cfg.GetWorkKeys {
-		addr, err := exccutil.DecodeAddress(strAddr)
+	// Check the custom checkpoints for valid syntax and save the parsed
+	// versions.
+	cfg.addCheckpoints, err = parseCheckpoints(cfg.AddCheckpoints)
+	if err != nil {
+		str := "%s: error parsing checkpoints: %v"
+		err := fmt.Errorf(str, funcName, err)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+
+	// Check getwork keys and mining addresses are valid and save the parsed
+	// versions.
+	cfg.miningAddrs, err = parseMiningAddrs(cfg.GetWorkKeys,
+		cfg.MiningAddrs, activeNetParams.Params)
+	if err != nil {
+		err := fmt.Errorf("%s: %v", funcName, err)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+
+	// Check the automatic revocation redeem scripts are valid and save the
+	// parsed versions.
+	for _, strScript := range cfg.AutoRevokeScripts {
+		script, err := hex.DecodeString(strScript)
 		if err != nil {
-			str := "%s: getworkkey '%s' failed to decode: %v"
-			err := fmt.Errorf(str, funcName, strAddr, err)
+			str := "%s: auto revoke script '%s' failed to decode: %v"
+			err := fmt.Errorf(str, funcName, strScript, err)
 			fmt.Fprintln(os.Stderr, err)
 			fmt.Fprintln(os.Stderr, usageMessage)
 			return nil, nil, err
 		}
-		if !addr.IsForNet(activeNetParams.Params) {
-			str := "%s: getworkkey '%s' is on the wrong network"
-			err := fmt.Errorf(str, funcName, strAddr)
+		if len(script) == 0 || len(script) > txscript.MaxScriptElementSize {
+			str := "%s: auto revoke script '%s' must be between 1 and " +
+				"%d bytes"
+			err := fmt.Errorf(str, funcName, strScript,
+				txscript.MaxScriptElementSize)
 			fmt.Fprintln(os.Stderr, err)
 			fmt.Fprintln(os.Stderr, usageMessage)
 			return nil, nil, err
 		}
-		cfg.miningAddrs = append(cfg.miningAddrs, addr)
+		cfg.autoRevokeScripts = append(cfg.autoRevokeScripts, script)
 	}
 
-	// Check mining addresses are valid and saved parsed versions.
-	for _, strAddr := range cfg.MiningAddrs {
-		addr, err := exccutil.DecodeAddress(strAddr)
+	// Check the automatic revocation private keys are valid and save the
+	// decoded versions.  The keys themselves are intentionally not included
+	// in any errors.
+	for i, strKey := range cfg.AutoRevokeKeys {
+		wif, err := exccutil.DecodeWIF(strKey)
+		if err == nil && !wif.IsForNet(activeNetParams.Params) {
+			err = errors.New("the key is not for the active network")
+		}
+		if err == nil && wif.DSA() != chainec.ECTypeSecp256k1 {
+			err = errors.New("the key is not a secp256k1 key")
+		}
 		if err != nil {
-			str := "%s: mining address '%s' failed to decode: %v"
-			err := fmt.Errorf(str, funcName, strAddr, err)
+			str := "%s: auto revoke key %d is invalid: %v"
+			err := fmt.Errorf(str, funcName, i, err)
 			fmt.Fprintln(os.Stderr, err)
 			fmt.Fprintln(os.Stderr, usageMessage)
 			return nil, nil, err
 		}
-		if !addr.IsForNet(activeNetParams.Params) {
-			str := "%s: mining address '%s' is on the wrong network"
-			err := fmt.Errorf(str, funcName, strAddr)
+		cfg.autoRevokeKeys = append(cfg.autoRevokeKeys, wif)
+	}
+
+	// Automatic staking is only allowed on simnet since the key it uses is
+	// publicly known.  The coinbases of generated blocks are also paid to
+	// the staker so it is able to purchase tickets.
+	if cfg.SimNetAutoStake {
+		if !cfg.SimNet {
+			str := "%s: the simnetautostake option is only valid " +
+				"with --simnet"
+			err := fmt.Errorf(str, funcName)
 			fmt.Fprintln(os.Stderr, err)
 			fmt.Fprintln(os.Stderr, usageMessage)
 			return nil, nil, err
 		}
+		_, addr, err := simnetStakerKey(activeNetParams.Params)
+		if err != nil {
+			str := "%s: unable to derive simnet staker address: %v"
+			err := fmt.Errorf(str, funcName, err)
+			fmt.Fprintln(os.Stderr, err)
+			return nil, nil, err
+		}
 		cfg.miningAddrs = append(cfg.miningAddrs, addr)
 	}
 
+	// The agenda choices are cast with the votes of the simnet staker, so
+	// they require automatic staking.
+	if len(cfg.SimNetVotes) > 0 {
+		if !cfg.SimNetAutoStake {
+			str := "%s: the simnetvote option is only valid with " +
+				"--simnetautostake"
+			err := fmt.Errorf(str, funcName)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, nil, err
+		}
+		_, _, err := parseSimnetVotes(activeNetParams.Params,
+			cfg.SimNetVotes)
+		if err != nil {
+			str := "%s: invalid simnetvote option: %v"
+			err := fmt.Errorf(str, funcName, err)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, nil, err
+		}
+	}
+
 	// Ensure there is at least one mining address when the generate flag is
 	// set.
-	if cfg.Generate && len(cfg.MiningAddrs) == 0 {
+	if cfg.Generate && len(cfg.miningAddrs) == 0 {
 		str := "%s: the generate flag is set, but there are no mining " +
 			"addresses specified "
 		err := fmt.Errorf(str, funcName)
@@ -1013,6 +1835,8 @@ func loadConfig() (*config, []string, error) {
 		activeNetParams.DefaultPort)
 	cfg.ConnectPeers = normalizeAddresses(cfg.ConnectPeers,
 		activeNetParams.DefaultPort)
+	cfg.SyncOnly = normalizeAddresses(cfg.SyncOnly,
+		activeNetParams.DefaultPort)
 
 	// Tor stream isolation requires either proxy or onion proxy to be set.
 	if cfg.TorIsolation && cfg.Proxy == "" && cfg.OnionProxy == "" {
@@ -1024,6 +1848,21 @@ func loadConfig() (*config, []string, error) {
 		return nil, nil, err
 	}
 
+	// Relaying transactions submitted via RPC only through Tor requires a
+	// proxy which is treated as Tor.
+	if cfg.PrivateRelayTor {
+		if cfg.NoOnion || (cfg.Proxy == "" && cfg.OnionProxy == "") {
+			str := "%s: Relaying transactions only through Tor " +
+				"requires either proxy or onionproxy to be set " +
+				"without --noonion"
+			err := fmt.Errorf(str, funcName)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, nil, err
+		}
+		cfg.PrivateRelay = true
+	}
+
 	// Setup dial and DNS resolution (lookup) functions depending on the
 	// specified options.  The default is to use the standard net.Dial
 	// function as well as the system DNS resolver.  When a proxy is
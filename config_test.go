@@ -0,0 +1,139 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"net"
+	"testing"
+
+	"github.com/EXCCoin/exccd/chaincfg"
+	"github.com/EXCCoin/exccd/chaincfg/chainhash"
+)
+
+// TestCheckpoints ensures custom checkpoints are parsed from the
+// '<height>:<hash>' format and merged with the default checkpoints.
+func TestCheckpoints(t *testing.T) {
+	const hashStr = "0000000000000000000000000000000000000000000000000000000000000001"
+	hash, _ := chainhash.NewHashFromStr(hashStr)
+
+	checkpoints, err := parseCheckpoints([]string{"100:" + hashStr})
+	if err != nil {
+		t.Fatalf("parseCheckpoints: unexpected error: %v", err)
+	}
+	if len(checkpoints) != 1 || checkpoints[0].Height != 100 ||
+		*checkpoints[0].Hash != *hash {
+		t.Fatalf("parseCheckpoints: unexpected checkpoints %v", checkpoints)
+	}
+
+	invalid := []string{
+		"100",
+		"100:" + hashStr + ":1",
+		"x:" + hashStr,
+		"0:" + hashStr,
+		"-1:" + hashStr,
+		"100:",
+		"100:01",
+		"100:zz",
+	}
+	for _, cp := range invalid {
+		if _, err := parseCheckpoints([]string{cp}); err == nil {
+			t.Errorf("parseCheckpoints: %q parsed without error", cp)
+		}
+	}
+
+	defaults := []chaincfg.Checkpoint{
+		{Height: 10, Hash: &chainhash.Hash{10}},
+		{Height: 20, Hash: &chainhash.Hash{20}},
+	}
+	additional := []chaincfg.Checkpoint{
+		{Height: 30, Hash: &chainhash.Hash{30}},
+		{Height: 20, Hash: &chainhash.Hash{21}},
+		{Height: 5, Hash: &chainhash.Hash{5}},
+		{Height: 20, Hash: &chainhash.Hash{22}},
+	}
+	merged := mergeCheckpoints(defaults, additional)
+	want := []chaincfg.Checkpoint{
+		{Height: 5, Hash: &chainhash.Hash{5}},
+		{Height: 10, Hash: &chainhash.Hash{10}},
+		{Height: 20, Hash: &chainhash.Hash{22}},
+		{Height: 30, Hash: &chainhash.Hash{30}},
+	}
+	if len(merged) != len(want) {
+		t.Fatalf("mergeCheckpoints: got %d checkpoints, want %d",
+			len(merged), len(want))
+	}
+	for i := range want {
+		if merged[i].Height != want[i].Height ||
+			*merged[i].Hash != *want[i].Hash {
+			t.Errorf("mergeCheckpoints: checkpoint %d is %d:%v, want "+
+				"%d:%v", i, merged[i].Height, merged[i].Hash,
+				want[i].Height, want[i].Hash)
+		}
+	}
+}
+
+// TestWhitelists ensures whitelisted IP addresses and networks are parsed and
+// invalid values are rejected.
+func TestWhitelists(t *testing.T) {
+	whitelists, err := parseWhitelists([]string{"192.168.1.0/24", "::1",
+		"10.0.0.1"})
+	if err != nil {
+		t.Fatalf("parseWhitelists: unexpected error: %v", err)
+	}
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"192.168.1.100", true},
+		{"192.168.2.1", false},
+		{"::1", true},
+		{"10.0.0.1", true},
+		{"10.0.0.2", false},
+	}
+	for _, test := range tests {
+		var got bool
+		for _, ipnet := range whitelists {
+			if ipnet.Contains(net.ParseIP(test.ip)) {
+				got = true
+			}
+		}
+		if got != test.want {
+			t.Errorf("whitelisted %s: got %v, want %v", test.ip, got,
+				test.want)
+		}
+	}
+
+	if _, err := parseWhitelists([]string{"192.168.1.0/33"}); err == nil {
+		t.Fatal("parseWhitelists: accepted an invalid network")
+	}
+	if whitelists, err := parseWhitelists(nil); err != nil || whitelists != nil {
+		t.Fatalf("parseWhitelists: unexpected result %v (err %v)",
+			whitelists, err)
+	}
+}
+
+// TestRPCPublicMethods ensures only read-only methods may be made callable
+// without authenticating.
+func TestRPCPublicMethods(t *testing.T) {
+	if err := checkRPCPublicMethods([]string{"getblock", "getblockcount"}); err != nil {
+		t.Fatalf("unexpected error for read-only methods: %v", err)
+	}
+
+	invalid := []string{
+		"sendrawtransaction",
+		"submitblock",
+		"searchrawtransactions",
+		"getemissionschedule",
+		"rescanrange",
+		"stop",
+		"nosuchmethod",
+	}
+	for _, method := range invalid {
+		err := checkRPCPublicMethods([]string{"getblock", method})
+		if err == nil {
+			t.Errorf("method %q made public without error", method)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/EXCCoin/exccd/exccutil"
+	flags "github.com/jessevdk/go-flags"
+)
+
+// reloadMtx protects the options of the global config which may be changed
+// while the process is running by reloading the configuration: BanDuration,
+// BanThreshold, whitelists, miningAddrs, minRelayTxFee, and the options used to
+// generate the RPC server certificate along with the option strings they are
+// parsed from.  It also protects the TxMinFreeFee of
+// the mining policy, which is derived from minRelayTxFee.
+//
+// The options are only modified while the process is running by reloadConfig,
+// so code which runs before the server is started may access them directly.
+var reloadMtx sync.RWMutex
+
+// reloadConfigMtx serializes reloads of the config requested concurrently via
+// signals and RPC.
+var reloadConfigMtx sync.Mutex
+
+// currentBanSettings returns the duration misbehaving peers are banned for and
+// the ban score which causes them to be banned.
+//
+// This function is safe for concurrent access.
+func (c *config) currentBanSettings() (time.Duration, uint32) {
+	reloadMtx.RLock()
+	duration, threshold := c.BanDuration, c.BanThreshold
+	reloadMtx.RUnlock()
+	return duration, threshold
+}
+
+// currentWhitelists returns the whitelisted IP networks.  The returned slice
+// must not be modified.
+//
+// This function is safe for concurrent access.
+func (c *config) currentWhitelists() []*net.IPNet {
+	reloadMtx.RLock()
+	whitelists := c.whitelists
+	reloadMtx.RUnlock()
+	return whitelists
+}
+
+// currentMiningAddrs returns the addresses used to pay generated blocks.  The
+// returned slice must not be modified.
+//
+// This function is safe for concurrent access.
+func (c *config) currentMiningAddrs() []exccutil.Address {
+	reloadMtx.RLock()
+	miningAddrs := c.miningAddrs
+	reloadMtx.RUnlock()
+	return miningAddrs
+}
+
+// currentMinRelayTxFee returns the minimum transaction fee per kilobyte for a
+// transaction to be considered a non-zero fee.
+//
+// This function is safe for concurrent access.
+func (c *config) currentMinRelayTxFee() exccutil.Amount {
+	reloadMtx.RLock()
+	fee := c.minRelayTxFee
+	reloadMtx.RUnlock()
+	return fee
+}
+
+// currentRPCCertOptions returns the type of the key, the validity, and the
+// additional hosts of generated RPC server certificates.  The returned slice
+// must not be modified.
+//
+// This function is safe for concurrent access.
+func (c *config) currentRPCCertOptions() (string, time.Duration, []string) {
+	reloadMtx.RLock()
+	keyType, validity, hosts := c.RPCCertKeyType, c.RPCCertValidity,
+		c.RPCCertHosts
+	reloadMtx.RUnlock()
+	return keyType, validity, hosts
+}
+
+// parseReloadedConfig parses the config file and command line options the
+// process was started with into a new config, so changes made to the config
+// file since then are picked up.  Command line options take precedence over
+// the config file as they do on startup.
+//
+// Only the options which may be reloaded are validated and parsed.
+func parseReloadedConfig() (*config, error) {
+	newCfg := newDefaultConfig()
+	parser := newConfigParser(&newCfg, &serviceOptions{}, flags.None)
+	if !(cfg.SimNet || cfg.RegNet) || cfg.ConfigFile != defaultConfigFile {
+		err := flags.NewIniParser(parser).ParseFile(cfg.ConfigFile)
+		if err != nil {
+			if _, ok := err.(*os.PathError); !ok {
+				return nil, fmt.Errorf("unable to parse config "+
+					"file: %v", err)
+			}
+		}
+	}
+	if _, err := parser.Parse(); err != nil {
+		return nil, err
+	}
+
+	if newCfg.BanDuration < time.Second {
+		str := "the banduration option may not be less than 1s -- " +
+			"parsed [%v]"
+		return nil, fmt.Errorf(str, newCfg.BanDuration)
+	}
+	var err error
+	newCfg.whitelists, err = parseWhitelists(newCfg.Whitelists)
+	if err != nil {
+		return nil, err
+	}
+	newCfg.miningAddrs, err = parseMiningAddrs(newCfg.GetWorkKeys,
+		newCfg.MiningAddrs, activeNetParams.Params)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.SimNetAutoStake {
+		_, addr, err := simnetStakerKey(activeNetParams.Params)
+		if err != nil {
+			return nil, err
+		}
+		newCfg.miningAddrs = append(newCfg.miningAddrs, addr)
+	}
+	newCfg.minRelayTxFee, err = exccutil.NewAmount(newCfg.MinRelayTxFee)
+	if err != nil {
+		return nil, fmt.Errorf("invalid minrelaytxfee: %v", err)
+	}
+	err = checkRPCCertOptions(newCfg.RPCCertKeyType, newCfg.RPCCertValidity)
+	if err != nil {
+		return nil, err
+	}
+	return &newCfg, nil
+}
+
+// reloadConfig reloads the config file and applies the options which may be
+// changed while the process is running without dropping any peers:
+//
+//  - debuglevel changes the logging levels
+//  - banduration and banthreshold apply to peers banned afterwards
+//  - whitelist applies to peers which connect afterwards
+//  - miningaddr and getworkkey apply to block templates generated afterwards
+//  - minrelaytxfee applies to the mempool and block templates generated
+//    afterwards
+//  - rpccerthost, rpccertkeytype, and rpccertvalidity apply to RPC server
+//    certificates generated afterwards by the regeneratecert RPC
+//
+// Changes to any other options are ignored until the process is restarted.  The
+// names of the options which changed are returned.  No options are changed when
+// an error is returned, except for the logging levels when an invalid debug
+// level only fails to parse part way through.
+func (s *server) reloadConfig() ([]string, error) {
+	reloadConfigMtx.Lock()
+	defer reloadConfigMtx.Unlock()
+
+	newCfg, err := parseReloadedConfig()
+	if err != nil {
+		return nil, err
+	}
+	if s.cpuMiner.IsMining() && len(newCfg.miningAddrs) == 0 {
+		return nil, fmt.Errorf("the CPU miner is running, but there " +
+			"are no mining addresses specified")
+	}
+
+	// Only the reload code modifies the options and reloads are
+	// serialized, so they may be read without the lock here.
+	var changed []string
+	if newCfg.DebugLevel != cfg.DebugLevel {
+		if newCfg.DebugLevel == "show" {
+			return nil, fmt.Errorf("the debuglevel option may not " +
+				"be show when reloading the config")
+		}
+		if err := parseAndSetDebugLevels(newCfg.DebugLevel); err != nil {
+			return nil, err
+		}
+		changed = append(changed, "debuglevel")
+	}
+	if newCfg.BanDuration != cfg.BanDuration {
+		changed = append(changed, "banduration")
+	}
+	if newCfg.BanThreshold != cfg.BanThreshold {
+		changed = append(changed, "banthreshold")
+	}
+	if !reflect.DeepEqual(newCfg.whitelists, cfg.whitelists) {
+		changed = append(changed, "whitelist")
+	}
+	if !reflect.DeepEqual(newCfg.miningAddrs, cfg.miningAddrs) {
+		changed = append(changed, "miningaddr")
+	}
+	feeChanged := newCfg.minRelayTxFee != cfg.minRelayTxFee
+	if feeChanged {
+		changed = append(changed, "minrelaytxfee")
+	}
+	if !reflect.DeepEqual(newCfg.RPCCertHosts, cfg.RPCCertHosts) {
+		changed = append(changed, "rpccerthost")
+	}
+	if newCfg.RPCCertKeyType != cfg.RPCCertKeyType {
+		changed = append(changed, "rpccertkeytype")
+	}
+	if newCfg.RPCCertValidity != cfg.RPCCertValidity {
+		changed = append(changed, "rpccertvalidity")
+	}
+
+	reloadMtx.Lock()
+	cfg.DebugLevel = newCfg.DebugLevel
+	cfg.BanDuration = newCfg.BanDuration
+	cfg.BanThreshold = newCfg.BanThreshold
+	cfg.Whitelists = newCfg.Whitelists
+	cfg.whitelists = newCfg.whitelists
+	cfg.GetWorkKeys = newCfg.GetWorkKeys
+	cfg.MiningAddrs = newCfg.MiningAddrs
+	cfg.miningAddrs = newCfg.miningAddrs
+	cfg.MinRelayTxFee = newCfg.MinRelayTxFee
+	cfg.minRelayTxFee = newCfg.minRelayTxFee
+	cfg.RPCCertHosts = newCfg.RPCCertHosts
+	cfg.RPCCertKeyType = newCfg.RPCCertKeyType
+	cfg.RPCCertValidity = newCfg.RPCCertValidity
+	s.cpuMiner.policy.TxMinFreeFee = newCfg.minRelayTxFee
+	reloadMtx.Unlock()
+	if feeChanged {
+		s.txMemPool.SetMinRelayTxFee(newCfg.minRelayTxFee)
+	}
+
+	if len(changed) == 0 {
+		srvrLog.Infof("Reloaded config file %s -- no reloadable options "+
+			"changed", cfg.ConfigFile)
+	} else {
+		srvrLog.Infof("Reloaded config file %s -- changed options: %v",
+			cfg.ConfigFile, changed)
+	}
+	return changed, nil
+}
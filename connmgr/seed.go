@@ -6,10 +6,13 @@
 package connmgr
 
 import (
+	"errors"
 	"fmt"
 	mrand "math/rand"
 	"net"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/EXCCoin/exccd/chaincfg"
@@ -30,45 +33,194 @@ type OnSeed func(addrs []*wire.NetAddress)
 // LookupFunc is the signature of the DNS lookup function.
 type LookupFunc func(string) ([]net.IP, error)
 
-// SeedFromDNS uses DNS seeding to populate the address manager with peers.
-func SeedFromDNS(chainParams *chaincfg.Params, reqServices wire.ServiceFlag, lookupFn LookupFunc, seedFn OnSeed) {
-	for _, dnsseed := range chainParams.DNSSeeds {
-		var host string
-		if !dnsseed.HasFiltering || reqServices == wire.SFNodeNetwork {
-			host = dnsseed.Host
-		} else {
-			host = fmt.Sprintf("x%x.%s", uint64(reqServices), dnsseed.Host)
+// DNSSeedInfo houses a DNS seed along with metrics about the lookups that
+// have been performed against it.
+type DNSSeedInfo struct {
+	chaincfg.DNSSeed
+
+	// Lookups is the number of lookups that have been attempted.
+	Lookups uint32
+
+	// Successes is the number of lookups that returned at least one
+	// address.
+	Successes uint32
+
+	// Addresses is the total number of addresses returned by the seed.
+	Addresses uint64
+
+	// LastSuccess is the time of the last lookup that returned at least
+	// one address.  It is the zero time when no lookup has succeeded.
+	LastSuccess time.Time
+
+	// LastError is the error of the last failed lookup or the empty string
+	// when the last lookup succeeded.
+	LastError string
+}
+
+// DNSSeeder maintains a set of DNS seeds which may be modified at runtime and
+// records metrics about the lookups performed against each of them.  It is
+// safe for concurrent access.
+type DNSSeeder struct {
+	defaultPort string
+	reqServices wire.ServiceFlag
+	lookupFn    LookupFunc
+	seedFn      OnSeed
+
+	mtx   sync.Mutex
+	seeds []*DNSSeedInfo
+}
+
+// NewDNSSeeder returns a new DNS seeder for the provided initial seeds which
+// passes the addresses it discovers to seedFn.  Discovered peers are assumed
+// to listen on defaultPort and seeds that support filtering are queried for
+// peers that provide the passed required services.
+func NewDNSSeeder(seeds []chaincfg.DNSSeed, defaultPort string, reqServices wire.ServiceFlag, lookupFn LookupFunc, seedFn OnSeed) *DNSSeeder {
+	s := &DNSSeeder{
+		defaultPort: defaultPort,
+		reqServices: reqServices,
+		lookupFn:    lookupFn,
+		seedFn:      seedFn,
+	}
+	for _, seed := range seeds {
+		if s.find(seed.Host) == -1 {
+			s.seeds = append(s.seeds, &DNSSeedInfo{DNSSeed: seed})
 		}
+	}
+	return s
+}
+
+// find returns the index of the seed with the passed host or -1 when there is
+// no such seed.
+//
+// This function MUST be called with the seeder lock held.
+func (s *DNSSeeder) find(host string) int {
+	for i, seed := range s.seeds {
+		if strings.EqualFold(seed.Host, host) {
+			return i
+		}
+	}
+	return -1
+}
 
-		go func(host string) {
-			randSource := mrand.New(mrand.NewSource(time.Now().UnixNano()))
-
-			seedpeers, err := lookupFn(host)
-			if err != nil {
-				log.Infof("DNS discovery failed on seed %s: %v", host, err)
-				return
-			}
-			numPeers := len(seedpeers)
-
-			log.Infof("%d addresses found from DNS seed %s", numPeers, host)
-
-			if numPeers == 0 {
-				return
-			}
-			addresses := make([]*wire.NetAddress, len(seedpeers))
-			// if this errors then we have *real* problems
-			intPort, _ := strconv.Atoi(chainParams.DefaultPort)
-			for i, peer := range seedpeers {
-				addresses[i] = wire.NewNetAddressTimestamp(
-					// bitcoind seeds with addresses from
-					// a time randomly selected between 3
-					// and 7 days ago.
-					time.Now().Add(-1*time.Second*time.Duration(secondsIn3Days+
-						randSource.Int31n(secondsIn4Days))),
-					0, peer, uint16(intPort))
-			}
-
-			seedFn(addresses)
-		}(host)
+// AddSeed adds the passed seed to the set of seeds.  An error is returned when
+// a seed for the same host already exists.
+func (s *DNSSeeder) AddSeed(seed chaincfg.DNSSeed) error {
+	if seed.Host == "" {
+		return errors.New("DNS seed host must not be empty")
 	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.find(seed.Host) != -1 {
+		return fmt.Errorf("DNS seed %s already exists", seed.Host)
+	}
+	s.seeds = append(s.seeds, &DNSSeedInfo{DNSSeed: seed})
+	return nil
+}
+
+// RemoveSeed removes the seed with the passed host from the set of seeds.  An
+// error is returned when there is no such seed.
+func (s *DNSSeeder) RemoveSeed(host string) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	i := s.find(host)
+	if i == -1 {
+		return fmt.Errorf("DNS seed %s does not exist", host)
+	}
+	s.seeds = append(s.seeds[:i], s.seeds[i+1:]...)
+	return nil
+}
+
+// Seeds returns a snapshot of the current seeds and their lookup metrics.
+func (s *DNSSeeder) Seeds() []DNSSeedInfo {
+	s.mtx.Lock()
+	seeds := make([]DNSSeedInfo, 0, len(s.seeds))
+	for _, seed := range s.seeds {
+		seeds = append(seeds, *seed)
+	}
+	s.mtx.Unlock()
+	return seeds
+}
+
+// Seed queries every seed in the set for peers in a separate goroutine.
+func (s *DNSSeeder) Seed() {
+	s.mtx.Lock()
+	for _, seed := range s.seeds {
+		go s.seed(seed)
+	}
+	s.mtx.Unlock()
+}
+
+// SeedHost queries the seed with the passed host for peers in a separate
+// goroutine.  An error is returned when there is no such seed.
+func (s *DNSSeeder) SeedHost(host string) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	i := s.find(host)
+	if i == -1 {
+		return fmt.Errorf("DNS seed %s does not exist", host)
+	}
+	go s.seed(s.seeds[i])
+	return nil
+}
+
+// seed queries the passed seed for peers, records the result of the lookup,
+// and passes any discovered addresses to the seed callback.  It must be run in
+// a goroutine.
+func (s *DNSSeeder) seed(dnsseed *DNSSeedInfo) {
+	var host string
+	if !dnsseed.HasFiltering || s.reqServices == wire.SFNodeNetwork {
+		host = dnsseed.Host
+	} else {
+		host = fmt.Sprintf("x%x.%s", uint64(s.reqServices), dnsseed.Host)
+	}
+
+	randSource := mrand.New(mrand.NewSource(time.Now().UnixNano()))
+
+	seedpeers, err := s.lookupFn(host)
+	numPeers := len(seedpeers)
+	s.mtx.Lock()
+	dnsseed.Lookups++
+	switch {
+	case err != nil:
+		dnsseed.LastError = err.Error()
+	case numPeers == 0:
+		dnsseed.LastError = "no addresses found"
+	default:
+		dnsseed.Successes++
+		dnsseed.Addresses += uint64(numPeers)
+		dnsseed.LastSuccess = time.Now()
+		dnsseed.LastError = ""
+	}
+	s.mtx.Unlock()
+	if err != nil {
+		log.Infof("DNS discovery failed on seed %s: %v", host, err)
+		return
+	}
+
+	log.Infof("%d addresses found from DNS seed %s", numPeers, host)
+
+	if numPeers == 0 {
+		return
+	}
+	addresses := make([]*wire.NetAddress, len(seedpeers))
+	// if this errors then we have *real* problems
+	intPort, _ := strconv.Atoi(s.defaultPort)
+	for i, peer := range seedpeers {
+		addresses[i] = wire.NewNetAddressTimestamp(
+			// bitcoind seeds with addresses from
+			// a time randomly selected between 3
+			// and 7 days ago.
+			time.Now().Add(-1*time.Second*time.Duration(secondsIn3Days+
+				randSource.Int31n(secondsIn4Days))),
+			0, peer, uint16(intPort))
+	}
+
+	s.seedFn(addresses)
+}
+
+// SeedFromDNS uses DNS seeding to populate the address manager with peers.
+func SeedFromDNS(chainParams *chaincfg.Params, reqServices wire.ServiceFlag, lookupFn LookupFunc, seedFn OnSeed) {
+	NewDNSSeeder(chainParams.DNSSeeds, chainParams.DefaultPort, reqServices,
+		lookupFn, seedFn).Seed()
 }
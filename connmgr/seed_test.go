@@ -0,0 +1,90 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package connmgr
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/EXCCoin/exccd/chaincfg"
+	"github.com/EXCCoin/exccd/wire"
+)
+
+// TestDNSSeeder ensures seeds can be added and removed at runtime and that the
+// lookup metrics of each seed are recorded.
+func TestDNSSeeder(t *testing.T) {
+	lookup := func(host string) ([]net.IP, error) {
+		switch host {
+		case "good.example.com":
+			return []net.IP{net.ParseIP("10.0.0.1"),
+				net.ParseIP("10.0.0.2")}, nil
+		case "empty.example.com":
+			return nil, nil
+		}
+		return nil, errors.New("lookup failed")
+	}
+	seeded := make(chan []*wire.NetAddress, 10)
+	seeder := NewDNSSeeder([]chaincfg.DNSSeed{
+		{Host: "good.example.com"},
+		{Host: "bad.example.com"},
+		{Host: "good.example.com"},
+	}, "9666", wire.SFNodeNetwork, lookup, func(addrs []*wire.NetAddress) {
+		seeded <- addrs
+	})
+
+	if err := seeder.AddSeed(chaincfg.DNSSeed{Host: "GOOD.example.com"}); err == nil {
+		t.Fatal("AddSeed: duplicate seed added without error")
+	}
+	if err := seeder.AddSeed(chaincfg.DNSSeed{Host: "empty.example.com"}); err != nil {
+		t.Fatalf("AddSeed: unexpected error: %v", err)
+	}
+	if err := seeder.RemoveSeed("missing.example.com"); err == nil {
+		t.Fatal("RemoveSeed: missing seed removed without error")
+	}
+
+	seeder.Seed()
+	select {
+	case addrs := <-seeded:
+		if len(addrs) != 2 || addrs[0].Port != 9666 {
+			t.Fatalf("unexpected seeded addresses %v", addrs)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for seeded addresses")
+	}
+
+	// Wait for the metrics of every lookup to be recorded.
+	var seeds []DNSSeedInfo
+	for start := time.Now(); time.Since(start) < time.Second; {
+		seeds = seeder.Seeds()
+		if seeds[0].Lookups+seeds[1].Lookups+seeds[2].Lookups == 3 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if len(seeds) != 3 {
+		t.Fatalf("unexpected number of seeds - got %d, want 3", len(seeds))
+	}
+	good, bad, empty := seeds[0], seeds[1], seeds[2]
+	if good.Lookups != 1 || good.Successes != 1 || good.Addresses != 2 ||
+		good.LastSuccess.IsZero() || good.LastError != "" {
+		t.Errorf("unexpected metrics for good seed %+v", good)
+	}
+	if bad.Lookups != 1 || bad.Successes != 0 || bad.LastError == "" {
+		t.Errorf("unexpected metrics for bad seed %+v", bad)
+	}
+	if empty.Lookups != 1 || empty.Successes != 0 || empty.LastError == "" {
+		t.Errorf("unexpected metrics for empty seed %+v", empty)
+	}
+
+	if err := seeder.RemoveSeed("bad.example.com"); err != nil {
+		t.Fatalf("RemoveSeed: unexpected error: %v", err)
+	}
+	seeds = seeder.Seeds()
+	if len(seeds) != 2 || seeds[1].Host != "empty.example.com" {
+		t.Fatalf("unexpected seeds after removal %v", seeds)
+	}
+}
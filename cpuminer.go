@@ -19,6 +19,7 @@ import (
 	"github.com/EXCCoin/exccd/mining"
 	"github.com/EXCCoin/exccd/wire"
 	"sync"
+	"sync/atomic"
 	"time"
 	"unsafe"
 )
@@ -46,6 +47,12 @@ const (
 	// for simnet so that you don't run out of memory if tickets for
 	// some reason run out during simulations.
 	maxSimnetToMine uint8 = 4
+
+	// templateRetryInterval is the amount of time each worker waits before
+	// generating a new block template when the current one can't be mined
+	// yet, such as when there are not enough votes.  It is measured by the
+	// clock of the server so simulations can fast-forward it.
+	templateRetryInterval = 333 * time.Millisecond
 )
 
 var (
@@ -66,6 +73,11 @@ var (
 // function, but the default is based on the number of processor cores in the
 // system which is typically sufficient.
 type CPUMiner struct {
+	// The following variables must only be used atomically.  They are
+	// placed first to ensure 64-bit alignment on 32-bit platforms.
+	timeRolls        uint64
+	templateRebuilds uint64
+
 	sync.Mutex
 	policy            *mining.Policy
 	txSource          mining.TxSource
@@ -74,6 +86,7 @@ type CPUMiner struct {
 	started           bool
 	discreteMining    bool
 	miningAddr        *exccutil.Address
+	failureBackoff    miningBackoff
 	submitBlockLock   sync.Mutex
 	wg                sync.WaitGroup
 	workerWg          sync.WaitGroup
@@ -152,12 +165,25 @@ out:
 	minrLog.Tracef("CPU miner speed monitor done")
 }
 
-// submitBlock submits the passed block to network after ensuring it passes all
-// of the consensus validation rules.
-func (m *CPUMiner) submitBlock(block *exccutil.Block) bool {
+// submitBlock submits the passed block solving the work with the passed ID to
+// network after ensuring it passes all of the consensus validation rules.
+// Blocks which were already submitted or solve already solved or stale work are
+// not processed.
+func (m *CPUMiner) submitBlock(block *exccutil.Block, id workID) bool {
 	m.submitBlockLock.Lock()
 	defer m.submitBlockLock.Unlock()
 
+	msgBlock := block.MsgBlock()
+	bestHash, _ := m.server.blockManager.chainState.Best()
+	err := m.server.workTracker.CheckSubmission(id, block.Hash(), bestHash)
+	if err != nil {
+		minrLog.Debugf("Not submitting block %v found by CPU miner for "+
+			"work %d: %v", block.Hash(), id, err)
+		m.server.archiveSolution(msgBlock, id, miningSourceCPUMiner,
+			miningOutcomeDropped, err.Error())
+		return false
+	}
+
 	// Process this block using the same rules as blocks coming from other
 	// nodes. This will in turn relay it to the network like normal.
 	isOrphan, err := m.server.blockManager.ProcessBlock(block, blockchain.BFNone)
@@ -167,6 +193,10 @@ func (m *CPUMiner) submitBlock(block *exccutil.Block) bool {
 		rErr, ok := err.(blockchain.RuleError)
 		if !ok {
 			minrLog.Errorf("Unexpected error while processing block submitted via CPU miner: %v", err)
+			m.server.notifyMinedBlock(block, "CPU miner", err.Error())
+			m.server.minedBlocks.Add(block.Hash(), miningOutcomeRejected)
+			m.server.archiveSolution(msgBlock, id, miningSourceCPUMiner,
+				miningOutcomeRejected, err.Error())
 			return false
 		}
 		// Occasionally errors are given out for timing errors with
@@ -176,20 +206,35 @@ func (m *CPUMiner) submitBlock(block *exccutil.Block) bool {
 			rErr.ErrorCode == blockchain.ErrHighHash {
 			minrLog.Debugf("Block submitted via CPU miner rejected because of ReduceMinDifficulty time sync "+
 				"failure: %v", err)
+			m.server.minedBlocks.Add(block.Hash(), miningOutcomeRejected)
+			m.server.archiveSolution(msgBlock, id, miningSourceCPUMiner,
+				miningOutcomeRejected, err.Error())
 			return false
 		}
 		// Other rule errors should be reported.
 		minrLog.Errorf("Block submitted via CPU miner rejected: %v", err)
+		m.server.notifyMinedBlock(block, "CPU miner", err.Error())
+		m.server.minedBlocks.Add(block.Hash(), miningOutcomeRejected)
+		m.server.archiveSolution(msgBlock, id, miningSourceCPUMiner,
+			miningOutcomeRejected, err.Error())
 		return false
 
 	}
 	if isOrphan {
 		minrLog.Errorf("Block submitted via CPU miner is an orphan building on parent %v",
 			block.MsgBlock().Header.PrevBlock)
+		m.server.notifyMinedBlock(block, "CPU miner", "orphan")
+		m.server.minedBlocks.Add(block.Hash(), miningOutcomeOrphan)
+		m.server.archiveSolution(msgBlock, id, miningSourceCPUMiner,
+			miningOutcomeOrphan, "")
 		return false
 	}
 
 	// The block was accepted.
+	m.server.workTracker.MarkSolved(id)
+	m.server.minedBlocks.Add(block.Hash(), miningOutcomeAccepted)
+	m.server.archiveSolution(msgBlock, id, miningSourceCPUMiner,
+		miningOutcomeAccepted, "")
 	coinbaseTxOuts := block.MsgBlock().Transactions[0].TxOut
 	coinbaseTxGenerated := int64(0)
 	for _, out := range coinbaseTxOuts {
@@ -197,6 +242,7 @@ func (m *CPUMiner) submitBlock(block *exccutil.Block) bool {
 	}
 	minrLog.Infof("Block submitted via CPU miner accepted (hash %s, height %v, amount %v)",
 		block.Hash(), block.Height(), exccutil.Amount(coinbaseTxGenerated))
+	m.server.notifyMinedBlock(block, "CPU miner", "")
 	return true
 }
 
@@ -206,6 +252,7 @@ type solutionValidatorData struct {
 	msgBlock *wire.MsgBlock
 	miner    *CPUMiner
 	quit     chan struct{}
+	workID   workID
 }
 
 // returns 1 when mining should be stopped for any reason
@@ -239,7 +286,16 @@ func (data solutionValidatorData) Validate(solution unsafe.Pointer) int {
 	hash := data.msgBlock.Header.BlockHash()
 
 	if blockchain.HashToBig(&hash).Cmp(blockchain.CompactToBig(data.msgBlock.Header.Bits)) <= 0 {
-		data.miner.submitBlock(exccutil.NewBlock(data.msgBlock))
+		// Keep looking for another solution rather than submitting a
+		// block with a solution the solver got wrong.
+		if !data.miner.verifySolvedBlock(&data.msgBlock.Header, solution) {
+			data.miner.server.archiveSolution(data.msgBlock,
+				data.workID, miningSourceCPUMiner,
+				miningOutcomeInvalid, "solution failed verification")
+			return 0
+		}
+
+		data.miner.submitBlock(exccutil.NewBlock(data.msgBlock), data.workID)
 		data.miner.minedOnParents[data.msgBlock.Header.PrevBlock]++
 		*data.solved = true
 		return 1
@@ -248,6 +304,49 @@ func (data solutionValidatorData) Validate(solution unsafe.Pointer) int {
 	return 0
 }
 
+// verifySolvedBlock independently verifies the equihash solution and target of
+// the passed solved block header in pure Go before the block is submitted, so
+// bugs in the C solver or its bindings are caught instead of broadcasting
+// invalid blocks.  The raw solution is the one reported by the solver.  A
+// detailed diagnostic is logged when the verification fails.
+func (m *CPUMiner) verifySolvedBlock(header *wire.BlockHeader, rawSolution unsafe.Pointer) bool {
+	params := m.server.chainParams
+	hash := header.BlockHash()
+	hashNum := blockchain.HashToBig(&hash)
+	target := blockchain.CompactToBig(header.Bits)
+	headerBytes, err := header.SerializeAllHeaderBytes()
+	if err == nil {
+		switch {
+		case target.Sign() <= 0 || target.Cmp(params.PowLimit) > 0:
+			err = fmt.Errorf("target %064x is out of range", target)
+		case hashNum.Cmp(target) > 0:
+			err = fmt.Errorf("block hash %064x is higher than target %064x",
+				hashNum, target)
+		default:
+			err = equihash.VerifyEquihash(params.N, params.K, headerBytes,
+				int64(header.Nonce), header.EquihashSolution[:])
+		}
+	}
+	if err == nil {
+		return true
+	}
+
+	// Include whether the C validator agrees and the solution indices as
+	// seen by the Go side in the diagnostic to help tell a solver bug from
+	// a binding bug.
+	solution := header.EquihashSolution[:equihash.EquihashSolutionSize(params.N, params.K)]
+	cValid := headerBytes != nil && equihash.ValidateEquihash(params.N,
+		params.K, headerBytes, int64(header.Nonce), solution)
+	minrLog.Errorf("CPU miner solver returned an invalid solution, not "+
+		"submitting block: %v (hash %s, height %d, nonce %d, extra data "+
+		"%x, bits %08x, C validator valid %v, solution %x, raw solver "+
+		"output %x, indices %v)", err, hash, header.Height, header.Nonce,
+		header.ExtraData, header.Bits, cValid, solution,
+		equihash.ExtractSolution(params.N, params.K, rawSolution),
+		equihash.SolutionIndices(params.N, params.K, solution))
+	return false
+}
+
 // solveAndSubmitBlock attempts to find some combination of a nonce, extra nonce, and
 // current timestamp which makes the passed block hash to a value less than the
 // target difficulty. After that, new block is submitted. The timestamp is
@@ -255,12 +354,20 @@ func (data solutionValidatorData) Validate(solution unsafe.Pointer) int {
 // during this process. This means that when the function returns true, the block is submitted.
 //
 // This function will return early with false when conditions that trigger a
-// stale block such as a new block showing up or periodically when there are
-// new transactions and enough time has elapsed without finding a solution.
+// stale block such as a new block showing up or periodically when enough time
+// has elapsed without finding a solution, which is sooner when new
+// transactions paying high fees or votes which raise the subsidy arrive.
 func (m *CPUMiner) solveAndSubmitBlock(msgBlock *wire.MsgBlock, ticker *time.Ticker, quit chan struct{}) bool {
+	// Each block passed here was created from a new block template, which
+	// is issued as new work.
+	atomic.AddUint64(&m.templateRebuilds, 1)
+	id := m.server.workTracker.Issue(&msgBlock.Header.PrevBlock,
+		int64(msgBlock.Header.Height))
+	m.server.archiveTemplate(msgBlock, id, miningSourceCPUMiner)
+
 	// Choose a random extra nonce offset for this block template and
 	// worker.
-	enOffset, err := wire.RandomUint64()
+	enOffset, err := randomExtraNonce()
 	if err != nil {
 		minrLog.Errorf("Unexpected error while generating random extra nonce offset: %v", err)
 		enOffset = 0
@@ -270,23 +377,31 @@ func (m *CPUMiner) solveAndSubmitBlock(msgBlock *wire.MsgBlock, ticker *time.Tic
 	header := &msgBlock.Header
 
 	// Initial state.
-	lastGenerated := time.Now()
-	lastTxUpdate := m.txSource.LastUpdated()
+	clock := m.server.clock
+	lastGenerated := clock.Now()
+	lastFeeCheck := lastGenerated
+	refresh := cfg.MiningRefreshMax
 
 	solved := false
 	exiting := false
-	validatorData := solutionValidatorData{&solved, &exiting, msgBlock, m, quit}
+	validatorData := solutionValidatorData{&solved, &exiting, msgBlock, m, quit, id}
+
+	// The equihash solver input bytes are reserialized into the same
+	// buffer whenever the header changes to avoid allocating.
+	var headerBytes []byte
 
 	// Note that the entire extra nonce range is iterated and the offset is
 	// added relying on the fact that overflow will wrap around 0 as
 	// provided by the Go spec.
 	for extraNonce := uint64(0); extraNonce < maxExtraNonce && !solved && !exiting; extraNonce++ {
 		// Update the extra nonce in the block template header with the
-		// new value.
-		littleEndian.PutUint64(header.ExtraData[:], extraNonce+enOffset)
+		// new value within the part of the extra nonce space reserved
+		// for the configured rig.
+		littleEndian.PutUint64(header.ExtraData[:],
+			rigExtraNonce(cfg.MiningRigID, extraNonce+enOffset))
 
 		// Update equihash solver input bytes
-		headerBytes, _ := header.SerializeAllHeaderBytes()
+		headerBytes = header.AppendAllHeaderBytes(headerBytes[:0])
 
 		// Search through the entire nonce range for a solution while
 		// periodically checking for early quit and stale block
@@ -301,31 +416,51 @@ func (m *CPUMiner) solveAndSubmitBlock(msgBlock *wire.MsgBlock, ticker *time.Tic
 			case <-ticker.C:
 				minrLog.Debugf("Miner is updating time for currently mined block")
 
-				// The current block is stale if the memory pool
-				// has been updated since the block template was
-				// generated and it has been at least 3 seconds,
-				// or if it's been one minute.
-				if (lastTxUpdate != m.txSource.LastUpdated() &&
-					time.Now().After(lastGenerated.Add(3*time.Second))) ||
-					time.Now().After(lastGenerated.Add(60*time.Second)) {
+				// Recalculate how long to work on the current
+				// block before it is stale whenever the memory
+				// pool has been updated since the last check.
+				// Votes do not pay fees, but new votes on the
+				// parent raise the subsidy of the block, so the
+				// minimum interval is used when they arrive.
+				// The last update time only has a resolution of
+				// seconds, so updates during the same second as
+				// the last check are checked again.
+				now := clock.Now()
+				txUpdate := m.txSource.LastUpdated()
+				if !txUpdate.Before(lastFeeCheck.Truncate(time.Second)) {
+					fees := newTxFees(m.txSource.MiningDescs(),
+						lastGenerated)
+					refresh = templateRefreshInterval(fees,
+						cfg.MiningRefreshMin, cfg.MiningRefreshMax,
+						cfg.miningRefreshFee)
+					votes := m.txSource.VoteHashesForBlock(
+						&header.PrevBlock)
+					if moreVotesAvailable(header.Voters, len(votes),
+						m.server.chainParams.TicketsPerBlock) {
+						refresh = cfg.MiningRefreshMin
+					}
+					lastFeeCheck = now
+				}
 
+				// The current block is stale once it has been
+				// worked on for the refresh interval, which is
+				// shorter the more fees the transactions which
+				// arrived since it was generated pay, or when
+				// votes which raise its subsidy arrived.
+				if now.After(lastGenerated.Add(refresh)) {
 					return false
 				}
 
-				err = UpdateBlockTime(msgBlock, m.server.blockManager)
-
+				// Roll the timestamp forward and only write the
+				// changed fields into the solver input bytes.
+				rolled, err := rollBlockTime(header, m.server.blockManager)
 				if err != nil {
 					minrLog.Warnf("CPU miner unable to update block template time: %v", err)
 					return false
 				}
-
-				// Rebuild all input data
-				headerBytes, err = header.SerializeAllHeaderBytes()
-
-				if err != nil {
-					minrLog.Warnf("CPU miner unable to rebuild header data for updated block template "+
-						"time: %v", err)
-					return false
+				if rolled {
+					header.PutAllHeaderBytesTime(headerBytes)
+					atomic.AddUint64(&m.timeRolls, 1)
 				}
 
 			default:
@@ -340,6 +475,41 @@ func (m *CPUMiner) solveAndSubmitBlock(msgBlock *wire.MsgBlock, ticker *time.Tic
 	return solved
 }
 
+// backoffAfterFailure records the passed failure of a worker to get a mining
+// address or create a block template in the passed round of attempts and waits
+// before the worker tries again.  Mining is paused and an alert is sent once
+// the failures reach a threshold.  It returns false when the worker is stopped
+// while waiting.
+func (m *CPUMiner) backoffAfterFailure(err error, round uint64, quit chan struct{}) bool {
+	delay, paused := m.failureBackoff.Failure(err, round)
+	if paused {
+		minrLog.Errorf("Pausing CPU mining after %d consecutive failures, "+
+			"trying again every %v: %v", miningFailureThreshold, delay,
+			err)
+		m.server.notifyAlert(alertEventMiningPaused, fmt.Sprintf("The "+
+			"CPU miner paused after %d consecutive failures: %v",
+			miningFailureThreshold, err), map[string]interface{}{
+			"failures": miningFailureThreshold,
+			"error":    err.Error(),
+		})
+	}
+
+	select {
+	case <-m.server.clock.After(delay):
+		return true
+	case <-quit:
+		return false
+	}
+}
+
+// PausedErr returns the failure which caused the CPU miner to pause mining, or
+// nil when it is not paused.
+//
+// This function is safe for concurrent access.
+func (m *CPUMiner) PausedErr() error {
+	return m.failureBackoff.PausedErr()
+}
+
 // generateBlocks is a worker that is controlled by the miningWorkerController.
 // It is self contained in that it creates block templates and attempts to solve
 // them while detecting when it is performing stale work and reacting
@@ -365,31 +535,26 @@ out:
 			// Non-blocking select to fall through
 		}
 
+		// Note the round of attempts before waiting for the lock below
+		// so the failures of workers that attempt to create a template
+		// at the same time are only counted once.
+		round := m.failureBackoff.Attempt()
+
 		// No point in searching for a solution before the chain is
 		// synced.  Also, grab the same lock as used for block
 		// submission, since the current block will be changing and
 		// this would otherwise end up building a new block template on
 		// a block that is in the process of becoming stale.
 		m.submitBlockLock.Lock()
-		time.Sleep(100 * time.Millisecond)
-
-		// Hacks to make exccd work with ExchangeCoin PoC (simnet only)
-		// TODO Remove before production.
-		if cfg.SimNet {
-			_, curHeight := m.server.blockManager.chainState.Best()
-
-			if curHeight == 1 {
-				time.Sleep(5500 * time.Millisecond) // let wallet reconn
-			} else if curHeight > 100 && curHeight < 201 { // slow down to i
-				time.Sleep(10 * time.Millisecond) // 2500
-			} else { // burn through the first pile of blocks
-				time.Sleep(10 * time.Millisecond)
-			}
-		}
 
 		payToAddr, err := m.server.blockManager.GetMiningAddr()
 		if err != nil {
-			minrLog.Errorf("Failed to get mining address: %v", err)
+			m.submitBlockLock.Unlock()
+			err = fmt.Errorf("failed to get mining address: %v", err)
+			minrLog.Errorf("CPU miner %v", err)
+			if !m.backoffAfterFailure(err, round, quit) {
+				break out
+			}
 			continue
 		}
 
@@ -399,13 +564,29 @@ out:
 		template, err := NewBlockTemplate(m.policy, m.server, payToAddr)
 		m.submitBlockLock.Unlock()
 		if err != nil {
-			errStr := fmt.Sprintf("Failed to create new block template: %v", err)
-			minrLog.Errorf(errStr)
+			err = fmt.Errorf("failed to create new block template: %v",
+				err)
+			minrLog.Errorf("CPU miner %v", err)
+			if !m.backoffAfterFailure(err, round, quit) {
+				break out
+			}
 			continue
 		}
+		if m.failureBackoff.Success() {
+			minrLog.Infof("CPU mining resumed")
+			m.server.notifyAlert(alertEventMiningResumed, "The CPU "+
+				"miner created a block template and resumed mining",
+				nil)
+		}
 
-		// Not enough voters.
+		// Not enough voters.  Wait for the votes to arrive before
+		// trying again.
 		if template == nil {
+			select {
+			case <-m.server.clock.After(templateRetryInterval):
+			case <-quit:
+				break out
+			}
 			continue
 		}
 
@@ -416,6 +597,11 @@ out:
 				maxSimnetToMine {
 				minrLog.Tracef("too many blocks mined on parent, stopping " +
 					"until there are enough votes on these to make a new block")
+				select {
+				case <-m.server.clock.After(templateRetryInterval):
+				case <-quit:
+					break out
+				}
 				continue
 			}
 		}
@@ -445,7 +631,7 @@ func (m *CPUMiner) miningWorkerController() {
 			runningWorkers = append(runningWorkers, quit)
 
 			m.workerWg.Add(1)
-			go m.generateBlocks(quit)
+			spawn(func() { m.generateBlocks(quit) })
 		}
 	}
 
@@ -510,8 +696,8 @@ func (m *CPUMiner) Start() {
 	m.quit = make(chan struct{})
 	m.speedMonitorQuit = make(chan struct{})
 	m.wg.Add(2)
-	go m.speedMonitor()
-	go m.miningWorkerController()
+	spawn(m.speedMonitor)
+	spawn(m.miningWorkerController)
 
 	m.started = true
 	minrLog.Infof("CPU miner started")
@@ -565,6 +751,16 @@ func (m *CPUMiner) HashesPerSecond() float64 {
 	return <-m.queryHashesPerSec
 }
 
+// TemplateStats returns the number of times the miner rolled the timestamp of
+// the block it was working on forward and the number of times it created a new
+// block template instead.
+//
+// This function is safe for concurrent access.
+func (m *CPUMiner) TemplateStats() (timeRolls, templateRebuilds uint64) {
+	return atomic.LoadUint64(&m.timeRolls),
+		atomic.LoadUint64(&m.templateRebuilds)
+}
+
 // SetNumWorkers sets the number of workers to create which solve blocks.  Any
 // negative values will cause a default number of workers to be used which is
 // based on the number of processor cores in the system.  A value of 0 will
@@ -632,7 +828,7 @@ func (m *CPUMiner) GenerateNBlocks(n uint32) ([]*chainhash.Hash, error) {
 
 	m.speedMonitorQuit = make(chan struct{})
 	m.wg.Add(1)
-	go m.speedMonitor()
+	spawn(m.speedMonitor)
 
 	m.Unlock()
 
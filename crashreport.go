@@ -0,0 +1,370 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// crashDirname is the name of the directory in the data directory crash
+	// reports are written to.
+	crashDirname = "crashes"
+
+	// crashNotedFilename is the name of the file in the crash directory
+	// which records the most recent crash report noted on startup, so each
+	// report is only noted once.
+	crashNotedFilename = "noted"
+
+	// maxCrashReports is the maximum number of crash reports which are kept.
+	// The oldest reports are removed on startup once there are more.
+	maxCrashReports = 10
+
+	// crashLogLines is the number of the most recent log lines included in
+	// crash reports.
+	crashLogLines = 500
+
+	// crashStateTimeout is the maximum amount of time spent collecting the
+	// chain state for a crash report.  The state is collected from
+	// subsystems which may be stuck or may have been the cause of the crash.
+	crashStateTimeout = 2 * time.Second
+
+	// crashReportTimeFormat is the format of the time in the names of crash
+	// report files, which sort in the order the reports were written.
+	crashReportTimeFormat = "20060102-150405"
+)
+
+// logTail keeps the most recent log lines in memory so they can be included
+// in crash reports.
+type logTail struct {
+	mtx   sync.Mutex
+	lines []string
+	next  int
+	full  bool
+}
+
+// newLogTail returns a log tail which keeps the passed number of lines.
+func newLogTail(numLines int) *logTail {
+	return &logTail{lines: make([]string, numLines)}
+}
+
+// Write records the passed log output, which is expected to be a single log
+// line as written by the logging backends.
+func (t *logTail) Write(p []byte) {
+	line := strings.TrimRight(string(p), "\n")
+	t.mtx.Lock()
+	t.lines[t.next] = line
+	t.next++
+	if t.next == len(t.lines) {
+		t.next = 0
+		t.full = true
+	}
+	t.mtx.Unlock()
+}
+
+// Lines returns the recorded log lines from oldest to newest.
+func (t *logTail) Lines() []string {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	if !t.full {
+		return append([]string(nil), t.lines[:t.next]...)
+	}
+	lines := make([]string, 0, len(t.lines))
+	lines = append(lines, t.lines[t.next:]...)
+	return append(lines, t.lines[:t.next]...)
+}
+
+// recentLogLines records the most recent log lines for crash reports.
+var recentLogLines = newLogTail(crashLogLines)
+
+// crashChainState summarizes the state of the chain and the server at the
+// time of a crash.
+type crashChainState struct {
+	Height      int64     `json:"height"`
+	Hash        string    `json:"hash"`
+	MedianTime  time.Time `json:"mediantime"`
+	TotalTxns   uint64    `json:"totaltxns"`
+	MempoolTxns *int      `json:"mempooltxns,omitempty"`
+	Peers       *int32    `json:"peers,omitempty"`
+}
+
+// crashReport is the JSON encoding of a crash report.
+type crashReport struct {
+	Time       time.Time              `json:"time"`
+	Version    string                 `json:"version"`
+	Commit     string                 `json:"commit,omitempty"`
+	GoVersion  string                 `json:"goversion"`
+	OS         string                 `json:"os"`
+	Arch       string                 `json:"arch"`
+	Uptime     float64                `json:"uptime"`
+	Panic      string                 `json:"panic"`
+	Stack      string                 `json:"stack"`
+	Goroutines string                 `json:"goroutines"`
+	Config     map[string]interface{} `json:"config,omitempty"`
+	Chain      *crashChainState       `json:"chain,omitempty"`
+	ChainError string                 `json:"chainerror,omitempty"`
+	Log        []string               `json:"log"`
+}
+
+// crashReporter writes crash reports to the crash directory.
+type crashReporter struct {
+	mtx      sync.Mutex
+	dir      string
+	started  time.Time
+	server   *server
+	reported bool
+}
+
+// crashReports writes the crash report of the process.
+var crashReports crashReporter
+
+// initCrashReports enables writing crash reports to the crash directory in the
+// passed data directory.  Crash reports written since the last time the
+// process started are noted in the log, and the oldest reports are removed.
+func initCrashReports(dataDir string) {
+	dir := filepath.Join(dataDir, crashDirname)
+	crashReports.mtx.Lock()
+	crashReports.dir = dir
+	crashReports.started = time.Now()
+	crashReports.mtx.Unlock()
+
+	reports, err := filepath.Glob(filepath.Join(dir, "crash-*.json"))
+	if err != nil || len(reports) == 0 {
+		return
+	}
+	sort.Strings(reports)
+
+	// Note the reports written since the last time they were noted.
+	notedFile := filepath.Join(dir, crashNotedFilename)
+	noted, _ := ioutil.ReadFile(notedFile)
+	last := reports[len(reports)-1]
+	if filepath.Base(last) != strings.TrimSpace(string(noted)) {
+		for _, report := range reports {
+			if filepath.Base(report) <= strings.TrimSpace(string(noted)) {
+				continue
+			}
+			exccLog.Warnf("The previous run crashed -- a crash "+
+				"report was written to %s.  Please include it "+
+				"when reporting the crash.", report)
+		}
+		err := ioutil.WriteFile(notedFile,
+			[]byte(filepath.Base(last)+"\n"), 0600)
+		if err != nil {
+			exccLog.Warnf("Unable to record noted crash reports: %v",
+				err)
+		}
+	}
+
+	// Remove the oldest reports.
+	for len(reports) > maxCrashReports {
+		if err := os.Remove(reports[0]); err != nil {
+			exccLog.Warnf("Unable to remove crash report: %v", err)
+		}
+		reports = reports[1:]
+	}
+}
+
+// setCrashReportServer sets the server whose chain state is included in crash
+// reports.
+func setCrashReportServer(s *server) {
+	crashReports.mtx.Lock()
+	crashReports.server = s
+	crashReports.mtx.Unlock()
+}
+
+// handleCrash writes a crash report when the goroutine it is deferred in
+// panics and then resumes panicking so the process exits as it would have
+// otherwise.  It must be deferred directly, such as by the functions which
+// handle requests in goroutines started by other packages, while the
+// long-running goroutines of the process are started with spawn.
+func handleCrash() {
+	r := recover()
+	if r == nil {
+		return
+	}
+	stack := debug.Stack()
+
+	path, err := crashReports.write(r, stack)
+	switch {
+	case err != nil:
+		exccLog.Criticalf("Unable to write crash report: %v", err)
+	case path != "":
+		exccLog.Criticalf("Crash report written to %s.  Please include "+
+			"it when reporting the crash.", path)
+	}
+	panic(r)
+}
+
+// spawn runs the passed function in a new goroutine which writes a crash report
+// when it panics.  It is used instead of the go statement to start the
+// long-running goroutines of the process.
+func spawn(f func()) {
+	go runReportingCrash(f)
+}
+
+// runReportingCrash runs the passed function and writes a crash report when it
+// panics.
+func runReportingCrash(f func()) {
+	defer handleCrash()
+	f()
+}
+
+// write writes a crash report for the passed panic value and stack trace of the
+// goroutine which panicked.  Only the first crash is reported, so an empty
+// path is returned when a report was already written or crash reports are not
+// enabled.
+func (c *crashReporter) write(panicValue interface{}, stack []byte) (string, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.dir == "" || c.reported {
+		return "", nil
+	}
+	c.reported = true
+
+	now := time.Now()
+	report := &crashReport{
+		Time:       now.UTC(),
+		Version:    version(),
+		Commit:     appCommit,
+		GoVersion:  runtime.Version(),
+		OS:         runtime.GOOS,
+		Arch:       runtime.GOARCH,
+		Uptime:     now.Sub(c.started).Seconds(),
+		Panic:      fmt.Sprint(panicValue),
+		Stack:      string(stack),
+		Goroutines: string(allGoroutineStacks()),
+		Log:        recentLogLines.Lines(),
+	}
+	if cfg != nil {
+		report.Config = redactedConfig(cfg)
+	}
+	if c.server != nil {
+		chain, err := collectCrashChainState(c.server)
+		report.Chain = chain
+		if err != nil {
+			report.ChainError = err.Error()
+		}
+	}
+
+	b, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("crash-%s.json",
+		now.UTC().Format(crashReportTimeFormat))
+	path := filepath.Join(c.dir, name)
+	if err := ioutil.WriteFile(path, b, 0600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// allGoroutineStacks returns the stack traces of all goroutines.
+func allGoroutineStacks() []byte {
+	buf := make([]byte, 1<<20)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) || len(buf) >= 64<<20 {
+			return buf[:n]
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+// isSecretConfigOption returns whether the value of the config option with the
+// passed long name is a secret which must not be included in crash reports.
+// Webhook URLs typically contain a secret token.
+func isSecretConfigOption(name string) bool {
+	return strings.Contains(name, "user") || strings.Contains(name, "pass") ||
+		name == "alertwebhook"
+}
+
+// redactedConfig returns the options of the passed config which are not set to
+// their zero value keyed by their long names.  The values of secrets such as
+// RPC and proxy credentials are replaced.
+func redactedConfig(c *config) map[string]interface{} {
+	options := make(map[string]interface{})
+	v := reflect.ValueOf(c).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := t.Field(i).Tag.Get("long")
+		if name == "" {
+			continue
+		}
+		field := v.Field(i)
+		if reflect.DeepEqual(field.Interface(),
+			reflect.Zero(field.Type()).Interface()) {
+
+			continue
+		}
+		switch value := field.Interface().(type) {
+		case time.Duration:
+			options[name] = value.String()
+		default:
+			options[name] = value
+		}
+		if isSecretConfigOption(name) {
+			options[name] = "<redacted>"
+		}
+	}
+	return options
+}
+
+// collectCrashChainState returns a summary of the state of the chain and the
+// passed server.  Collecting the state gives up after a timeout since the
+// subsystems which provide it may be stuck, in which case the parts which were
+// collected are returned along with an error.
+func collectCrashChainState(s *server) (*crashChainState, error) {
+	// The state is sent as each part of it is collected so the parts which
+	// were collected are available after a timeout.
+	states := make(chan crashChainState, 3)
+	go func() {
+		best := s.blockManager.chain.BestSnapshot()
+		state := crashChainState{
+			Height:     best.Height,
+			Hash:       best.Hash.String(),
+			MedianTime: best.MedianTime,
+			TotalTxns:  best.TotalTxns,
+		}
+		states <- state
+
+		mempoolTxns := s.txMemPool.Count()
+		state.MempoolTxns = &mempoolTxns
+		states <- state
+
+		peers := s.ConnectedCount()
+		state.Peers = &peers
+		states <- state
+	}()
+
+	var state *crashChainState
+	timeout := time.After(crashStateTimeout)
+	for i := 0; i < cap(states); i++ {
+		select {
+		case st := <-states:
+			state = &st
+		case <-timeout:
+			return state, fmt.Errorf("timed out collecting the chain " +
+				"state")
+		}
+	}
+	return state, nil
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestLogTail ensures only the most recent log lines are kept and they are
+// returned from oldest to newest.
+func TestLogTail(t *testing.T) {
+	tail := newLogTail(3)
+	if lines := tail.Lines(); len(lines) != 0 {
+		t.Fatalf("unexpected lines %q", lines)
+	}
+	tail.Write([]byte("one\n"))
+	tail.Write([]byte("two\n"))
+	if lines := tail.Lines(); !reflect.DeepEqual(lines,
+		[]string{"one", "two"}) {
+
+		t.Fatalf("unexpected lines %q", lines)
+	}
+	for i := 3; i <= 5; i++ {
+		tail.Write([]byte(fmt.Sprintf("%d\n", i)))
+	}
+	if lines := tail.Lines(); !reflect.DeepEqual(lines,
+		[]string{"3", "4", "5"}) {
+
+		t.Fatalf("unexpected lines %q", lines)
+	}
+}
+
+// TestCrashReport ensures crash reports include the panic, log, and config with
+// secrets redacted, and that they are noted once on startup.
+func TestCrashReport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "crashreport")
+	if err != nil {
+		t.Fatalf("TempDir: unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Ensure the values of secrets are redacted and options set to their
+	// zero value are omitted.
+	c := newDefaultConfig()
+	c.RPCUser = "user"
+	c.RPCPass = "secret"
+	options := redactedConfig(&c)
+	if options["rpcuser"] != "<redacted>" || options["rpcpass"] != "<redacted>" {
+		t.Fatalf("credentials not redacted: %v %v", options["rpcuser"],
+			options["rpcpass"])
+	}
+	if _, ok := options["rpclimitpass"]; ok {
+		t.Fatal("unset option included")
+	}
+	if options["banduration"] != defaultBanDuration.String() ||
+		options["maxpeers"] != defaultMaxPeers {
+
+		t.Fatalf("unexpected options %v %v", options["banduration"],
+			options["maxpeers"])
+	}
+
+	// Ensure only the first crash is reported.
+	reporter := &crashReporter{
+		dir:     filepath.Join(dir, crashDirname),
+		started: time.Now(),
+	}
+	recentLogLines.Write([]byte("last log line\n"))
+	path, err := reporter.write("test panic", []byte("stack"))
+	if err != nil {
+		t.Fatalf("write: unexpected error: %v", err)
+	}
+	if path2, err := reporter.write("second panic", nil); path2 != "" || err != nil {
+		t.Fatalf("write: unexpected second report %q (err %v)", path2, err)
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: unexpected error: %v", err)
+	}
+	var report crashReport
+	if err := json.Unmarshal(b, &report); err != nil {
+		t.Fatalf("unable to decode report: %v", err)
+	}
+	if report.Panic != "test panic" || report.Stack != "stack" ||
+		!strings.Contains(report.Goroutines, "goroutine") ||
+		len(report.Log) == 0 ||
+		report.Log[len(report.Log)-1] != "last log line" {
+
+		t.Fatalf("unexpected report %+v", report)
+	}
+	if strings.Contains(string(b), "secret") {
+		t.Fatal("report contains a secret")
+	}
+
+	// Ensure the report is noted on the first startup only and the oldest
+	// reports are removed.
+	for i := 0; i < maxCrashReports; i++ {
+		name := fmt.Sprintf("crash-20000101-0000%02d.json", i)
+		err := ioutil.WriteFile(filepath.Join(dir, crashDirname, name),
+			nil, 0600)
+		if err != nil {
+			t.Fatalf("WriteFile: unexpected error: %v", err)
+		}
+	}
+	initCrashReports(dir)
+	crashReports.mtx.Lock()
+	crashReports.dir = ""
+	crashReports.mtx.Unlock()
+	noted, err := ioutil.ReadFile(filepath.Join(dir, crashDirname,
+		crashNotedFilename))
+	if err != nil || strings.TrimSpace(string(noted)) != filepath.Base(path) {
+		t.Fatalf("unexpected noted report %q (err %v)", noted, err)
+	}
+	reports, _ := filepath.Glob(filepath.Join(dir, crashDirname, "crash-*"))
+	if len(reports) != maxCrashReports ||
+		filepath.Base(reports[0]) != "crash-20000101-000001.json" {
+
+		t.Fatalf("unexpected reports %q", reports)
+	}
+}
+
+// TestCrashReportGoroutine ensures a panic in a goroutine started with spawn is
+// reported and still panics afterwards.
+func TestCrashReportGoroutine(t *testing.T) {
+	dir, err := ioutil.TempDir("", "crashreport")
+	if err != nil {
+		t.Fatalf("TempDir: unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	crashReports.mtx.Lock()
+	crashReports.dir = dir
+	crashReports.started = time.Now()
+	crashReports.reported = false
+	crashReports.mtx.Unlock()
+	defer func() {
+		crashReports.mtx.Lock()
+		crashReports.dir = ""
+		crashReports.reported = false
+		crashReports.mtx.Unlock()
+	}()
+
+	// Run the function the way spawn does, except the goroutine recovers
+	// the resumed panic since it would otherwise exit the test binary.
+	repanicked := make(chan interface{})
+	go func() {
+		defer func() { repanicked <- recover() }()
+		runReportingCrash(func() { panic("boom") })
+	}()
+	if r := <-repanicked; r != "boom" {
+		t.Fatalf("unexpected resumed panic %v", r)
+	}
+
+	reports, _ := filepath.Glob(filepath.Join(dir, "crash-*.json"))
+	if len(reports) != 1 {
+		t.Fatalf("unexpected reports %q", reports)
+	}
+	b, err := ioutil.ReadFile(reports[0])
+	if err != nil {
+		t.Fatalf("ReadFile: unexpected error: %v", err)
+	}
+	var report crashReport
+	if err := json.Unmarshal(b, &report); err != nil {
+		t.Fatalf("unable to decode report: %v", err)
+	}
+	if report.Panic != "boom" ||
+		!strings.Contains(report.Stack, "runReportingCrash") {
+
+		t.Fatalf("unexpected report %+v", report)
+	}
+}
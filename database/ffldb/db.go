@@ -1792,6 +1792,7 @@ type db struct {
 	closed    bool         // Is the database closed?
 	store     *blockStore  // Handles read/writing blocks to flat files.
 	cache     *dbCache     // Cache layer which wraps underlying leveldb DB.
+	readOnly  bool         // Is the database opened read only?
 }
 
 // Enforce db implements the database.DB interface.
@@ -1812,6 +1813,12 @@ func (db *db) Type() string {
 // which is used by the managed transaction code while the database method
 // returns the interface.
 func (db *db) begin(writable bool) (*transaction, error) {
+	// Writable transactions are not allowed on a read only database.
+	if writable && db.readOnly {
+		str := "the database is opened read only"
+		return nil, makeDbErr(database.ErrTxNotWritable, str, nil)
+	}
+
 	// Whenever a new writable transaction is started, grab the write lock
 	// to ensure only a single write transaction can be active at the same
 	// time.  This lock will not be released until the transaction is
@@ -2042,7 +2049,8 @@ func initDB(ldb *leveldb.DB) error {
 
 // openDB opens the database at the provided path.  database.ErrDbDoesNotExist
 // is returned if the database doesn't exist and the create flag is not set.
-func openDB(dbPath string, network wire.CurrencyNet, create bool) (database.DB, error) {
+// The database is opened read only when the readOnly flag is set.
+func openDB(dbPath string, network wire.CurrencyNet, create, readOnly bool) (database.DB, error) {
 	// Error if the database doesn't exist and the create flag is not set.
 	metadataDbPath := filepath.Join(dbPath, metadataDbName)
 	dbExists := fileExists(metadataDbPath)
@@ -2062,6 +2070,7 @@ func openDB(dbPath string, network wire.CurrencyNet, create bool) (database.DB,
 	// Open the metadata database (will create it if needed).
 	opts := opt.Options{
 		ErrorIfExist: create,
+		ReadOnly:     readOnly,
 		Strict:       opt.DefaultStrict,
 		Compression:  opt.NoCompression,
 		Filter:       filter.NewBloomFilter(10),
@@ -2078,7 +2087,7 @@ func openDB(dbPath string, network wire.CurrencyNet, create bool) (database.DB,
 	// write caching.
 	store := newBlockStore(dbPath, network)
 	cache := newDbCache(ldb, store, defaultCacheSize, defaultFlushSecs)
-	pdb := &db{store: store, cache: cache}
+	pdb := &db{store: store, cache: cache, readOnly: readOnly}
 
 	// Perform any reconciliation needed between the block and metadata as
 	// well as database initialization, if needed.
@@ -27,5 +27,15 @@ database path as a string and the block network:
 	if err != nil {
 		// Handle error
 	}
+
+An existing database may also be opened read only by passing true as an
+additional parameter to Open.  Writable transactions on such a database fail
+and any repairs which would otherwise be made to it when it is opened are
+skipped:
+
+	db, err := database.Open("ffldb", "path/to/database", wire.MainNet, true)
+	if err != nil {
+		// Handle error
+	}
 */
 package ffldb
@@ -44,14 +44,22 @@ func parseArgs(funcName string, args ...interface{}) (string, wire.CurrencyNet,
 }
 
 // openDBDriver is the callback provided during driver registration that opens
-// an existing database for use.
+// an existing database for use.  An optional third boolean argument opens the
+// database read only.
 func openDBDriver(args ...interface{}) (database.DB, error) {
+	var readOnly bool
+	if len(args) == 3 {
+		if flag, ok := args[2].(bool); ok {
+			readOnly = flag
+			args = args[:2]
+		}
+	}
 	dbPath, network, err := parseArgs("Open", args...)
 	if err != nil {
 		return nil, err
 	}
 
-	return openDB(dbPath, network, false)
+	return openDB(dbPath, network, false, readOnly)
 }
 
 // createDBDriver is the callback provided during driver registration that
@@ -62,7 +70,7 @@ func createDBDriver(args ...interface{}) (database.DB, error) {
 		return nil, err
 	}
 
-	return openDB(dbPath, network, true)
+	return openDB(dbPath, network, true, false)
 }
 
 // useLogger is the callback provided during driver registration that sets the
@@ -256,6 +256,68 @@ func TestPersistence(t *testing.T) {
 	}
 }
 
+// TestReadOnly ensures a database opened read only can be read but not written
+// and that the read only flag is validated.
+func TestReadOnly(t *testing.T) {
+	t.Parallel()
+
+	// Create a new database with a value to read back.
+	dbPath := filepath.Join(os.TempDir(), "ffldb-readonlytest")
+	_ = os.RemoveAll(dbPath)
+	db, err := database.Create(dbType, dbPath, blockDataNet)
+	if err != nil {
+		t.Errorf("Failed to create test database (%s) %v", dbType, err)
+		return
+	}
+	defer os.RemoveAll(dbPath)
+	key, value := []byte("key"), []byte("value")
+	err = db.Update(func(tx database.Tx) error {
+		return tx.Metadata().Put(key, value)
+	})
+	if err != nil {
+		db.Close()
+		t.Errorf("Update: unexpected error: %v", err)
+		return
+	}
+	db.Close()
+
+	// Ensure a read only flag which is not a bool is rejected.
+	_, err = database.Open(dbType, dbPath, blockDataNet, "true")
+	if err == nil {
+		t.Errorf("Open: did not receive error for invalid read only flag")
+		return
+	}
+
+	db, err = database.Open(dbType, dbPath, blockDataNet, true)
+	if err != nil {
+		t.Errorf("failed to open test database (%s) %v", dbType, err)
+		return
+	}
+	defer db.Close()
+
+	// Ensure the stored value can be read.
+	err = db.View(func(tx database.Tx) error {
+		gotVal := tx.Metadata().Get(key)
+		if !reflect.DeepEqual(gotVal, value) {
+			return fmt.Errorf("Get: unexpected value - got %s, want "+
+				"%s", gotVal, value)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Errorf("View: unexpected error: %v", err)
+		return
+	}
+
+	// Ensure writable transactions are rejected.
+	err = db.Update(func(tx database.Tx) error {
+		return tx.Metadata().Put(key, []byte("other"))
+	})
+	if !checkDbError(t, "Update", err, database.ErrTxNotWritable) {
+		return
+	}
+}
+
 // TestInterface performs all interfaces tests for this database driver.
 func TestInterface(t *testing.T) {
 	t.Parallel()
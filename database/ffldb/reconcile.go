@@ -88,12 +88,21 @@ func reconcileDB(pdb *db, create bool) (database.DB, error) {
 	if wc.curFileNum > curFileNum || (wc.curFileNum == curFileNum &&
 		wc.curOffset > curOffset) {
 
-		log.Info("Detected unclean shutdown - Repairing...")
-		log.Debugf("Metadata claims file %d, offset %d. Block data is "+
-			"at file %d, offset %d", curFileNum, curOffset,
-			wc.curFileNum, wc.curOffset)
-		pdb.store.handleRollback(curFileNum, curOffset)
-		log.Infof("Database sync complete")
+		// The extra block data is not referenced by the metadata, so
+		// it is harmless to leave it in place when the database can't
+		// be written to.
+		if pdb.readOnly {
+			log.Warn("Detected unclean shutdown - the database " +
+				"will be repaired the next time it is opened " +
+				"for writing")
+		} else {
+			log.Info("Detected unclean shutdown - Repairing...")
+			log.Debugf("Metadata claims file %d, offset %d. Block "+
+				"data is at file %d, offset %d", curFileNum,
+				curOffset, wc.curFileNum, wc.curOffset)
+			pdb.store.handleRollback(curFileNum, curOffset)
+			log.Infof("Database sync complete")
+		}
 	}
 
 	// When the write cursor position found by scanning the block files on
@@ -0,0 +1,176 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/EXCCoin/exccd/blockchain"
+	"github.com/EXCCoin/exccd/blockchain/indexers"
+	"github.com/EXCCoin/exccd/database"
+	"github.com/EXCCoin/exccd/exccutil"
+)
+
+// dbInfoCheckBlocks is the number of the most recent blocks of the main chain
+// whose block index entries and data are checked by the dbinfo command.
+const dbInfoCheckBlocks = 288
+
+// dbDirSize describes the size on disk of a block database.
+type dbDirSize struct {
+	total         int64
+	metadata      int64
+	numBlockFiles int
+	blockFiles    int64
+}
+
+// blockDbDirSize walks the block database at the passed path and returns the
+// size of its files on disk.
+func blockDbDirSize(dbPath string) (*dbDirSize, error) {
+	// The metadata of the ffldb driver is stored in a leveldb database in
+	// the metadata directory while blocks are stored in flat files.
+	var size dbDirSize
+	metadataPath := filepath.Join(dbPath, "metadata") + string(filepath.Separator)
+	err := filepath.Walk(dbPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		size.total += info.Size()
+		switch {
+		case strings.HasPrefix(path, metadataPath):
+			size.metadata += info.Size()
+		case filepath.Ext(path) == ".fdb":
+			size.numBlockFiles++
+			size.blockFiles += info.Size()
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &size, nil
+}
+
+// formatBytes returns the passed number of bytes in a human-readable form.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// runDBInfo opens the block database of the configured data directory read
+// only and prints the state of the chain and indexes stored in it along with
+// the results of checking its consistency.  The node must not be running since
+// the database can only be opened by a single process.
+//
+// An error is returned when the database could not be inspected or problems
+// were found in it.
+func runDBInfo() error {
+	if cfg.DbType == "memdb" {
+		return errors.New("the dbinfo command requires a database " +
+			"stored on disk")
+	}
+	dbPath := blockDbPath(cfg.DbType)
+	fmt.Printf("Data directory:       %s\n", cfg.DataDir)
+	fmt.Printf("Database:             %s (%s)\n", dbPath, cfg.DbType)
+	fmt.Printf("Network:              %s\n", activeNetParams.Name)
+	if !fileExists(dbPath) {
+		return fmt.Errorf("no database exists at %s", dbPath)
+	}
+
+	size, err := blockDbDirSize(dbPath)
+	if err != nil {
+		return fmt.Errorf("unable to determine database size: %v", err)
+	}
+	fmt.Printf("Database size:        %s (metadata %s, %d block files "+
+		"%s)\n", formatBytes(size.total), formatBytes(size.metadata),
+		size.numBlockFiles, formatBytes(size.blockFiles))
+
+	db, err := database.Open(cfg.DbType, dbPath, activeNetParams.Net, true)
+	if err != nil {
+		if dbErr, ok := err.(database.Error); ok &&
+			dbErr.ErrorCode == database.ErrCorruption {
+
+			return fmt.Errorf("the database is corrupt: %v", err)
+		}
+		return fmt.Errorf("unable to open the database, which must not "+
+			"be in use by a running node: %v", err)
+	}
+	defer db.Close()
+
+	info, err := blockchain.FetchDatabaseInfo(db)
+	if err != nil {
+		return fmt.Errorf("unable to load chain state: %v", err)
+	}
+	fmt.Printf("Database versions:    chain %d, compression %d, block "+
+		"index %d\n", info.Version, info.CompressionVersion,
+		info.BlockIndexVersion)
+	if !info.Created.IsZero() {
+		fmt.Printf("Created:              %s\n", info.Created.UTC())
+	}
+	fmt.Printf("Best block:           %v (height %d)\n", info.BestHash,
+		info.BestHeight)
+	fmt.Printf("Total transactions:   %d\n", info.TotalTxns)
+	fmt.Printf("Total subsidy:        %v\n",
+		exccutil.Amount(info.TotalSubsidy))
+
+	tips, err := indexers.FetchIndexTips(db)
+	if err != nil {
+		return fmt.Errorf("unable to load index tips: %v", err)
+	}
+	fmt.Println("Indexes:")
+	if len(tips) == 0 {
+		fmt.Println("  none")
+	}
+	for _, tip := range tips {
+		state := "synced"
+		switch {
+		case tip.Dropping:
+			state = "being dropped"
+		case tip.Height < info.BestHeight:
+			state = fmt.Sprintf("%d blocks behind",
+				info.BestHeight-tip.Height)
+		case tip.Height > info.BestHeight:
+			state = fmt.Sprintf("%d blocks ahead",
+				tip.Height-info.BestHeight)
+		}
+		fmt.Printf("  %-28s height %d, %s\n", tip.Name+":", tip.Height,
+			state)
+	}
+
+	fmt.Println("Checking database consistency...")
+	result, err := blockchain.CheckDatabase(db, dbInfoCheckBlocks)
+	if err != nil {
+		return fmt.Errorf("unable to check the database: %v", err)
+	}
+	fmt.Printf("  Blocks checked:       %d\n", result.BlocksChecked)
+	fmt.Printf("  Block index entries:  %d\n", result.BlockIndexEntries)
+	fmt.Printf("  Utxo entries:         %d\n", result.UtxoEntries)
+	if result.NumProblems == 0 {
+		fmt.Println("No problems found")
+		return nil
+	}
+	for _, problem := range result.Problems {
+		fmt.Printf("  PROBLEM: %s\n", problem)
+	}
+	if omitted := result.NumProblems - len(result.Problems); omitted > 0 {
+		fmt.Printf("  ... and %d more problems\n", omitted)
+	}
+	return fmt.Errorf("%d problems found in the database",
+		result.NumProblems)
+}
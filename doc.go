@@ -28,8 +28,27 @@ Application Options:
   -b, --datadir=            Directory to store data
       --logdir=             Directory to log output.
       --nofilelogging=      Disable file logging.
+      --logformat=          Format of log output {text, json} -- json writes an
+                            object per message with the subsystem, level, and
+                            fields such as the peer, hash, and height (default:
+                            text)
+      --logmaxsize=         Roll log files once they reach this size in KiB --
+                            Use 0 to disable rolling by size (default: 10240)
+      --logmaxage=          Roll log files once they are older than this
+                            duration, such as 24h -- Use 0 to disable rolling
+                            by age
+      --logmaxrolls=        Maximum number of rolled files to keep for each log
+                            file -- Use 0 to keep all of them (default: 3)
+      --logcompression      Compress rolled log files with gzip
+      --logsplit=           Write the log messages of a subsystem, such as PEER,
+                            to its own file in the log directory instead of the
+                            main log file -- May be specified multiple times
   -a, --addpeer=            Add a peer to connect with at startup
       --connect=            Connect only to the specified peers at startup
+      --synconly=           Only accept blocks from the specified peers, which
+                            are connected to persistently at startup, and only
+                            use other peers to relay transactions -- May be
+                            specified multiple times
       --nolisten            Disable listening for incoming connections -- NOTE:
                             Listening is automatically disabled if the --connect
                             or --proxy options are used without also specifying
@@ -52,15 +71,60 @@ Application Options:
                             (default port: 9109, testnet: 19109)
       --rpccert=            File containing the certificate file
       --rpckey=             File containing the certificate key
+      --rpccerthost=        Add a hostname or IP address to the RPC server
+                            certificate when it is generated (may be used
+                            multiple times)
+      --rpccertkeytype=     Type of the key of the RPC server certificate when
+                            it is generated {P-256, P-384, P-521, RSA-2048,
+                            RSA-3072, RSA-4096} (default: P-521)
+      --rpccertvalidity=    Duration the RPC server certificate is valid for
+                            when it is generated (default: 87600h)
+      --rpcauthtype=        Method used to authenticate RPC clients {basic,
+                            clientcert} -- basic uses the RPC usernames and
+                            passwords, clientcert grants full access to clients
+                            with a certificate signed by a CA of
+                            --rpcclientcafile instead (default: basic)
+      --rpcclientcafile=    File containing the certificates of the CAs which
+                            sign the certificates of RPC clients -- Clients
+                            must present such a certificate, in addition to a
+                            password unless --rpcauthtype=clientcert is used
+      --rpccookiefile=      File the RPC auth cookie is written to while the
+                            RPC server runs (default: .cookie in the data
+                            directory)
+      --norpccookie         Disable cookie-based RPC authentication
+      --rpcpublicmethod=    Allow clients of the HTTP POST endpoint of the RPC
+                            server to call the given read-only method, such as
+                            getblock, without authenticating -- Methods which
+                            change the state of the server or are expensive to
+                            serve are rejected (may be used multiple times)
+      --rpccorsorigin=      Allow browser clients served from the given origin,
+                            such as https://explorer.example.com, to make
+                            cross-origin requests to the HTTP POST endpoint of
+                            the RPC server -- Use * to allow any origin without
+                            credentials (may be used multiple times)
       --rpcmaxclients=      Max number of RPC clients for standard connections
                             (10)
       --rpcmaxwebsockets=   Max number of RPC websocket connections (25)
+      --rpcmaxwssubscriptions=
+                            Max number of addresses and outpoints each RPC
+                            websocket client may add to its transaction filter
+                            (0 for no limit) (250000)
+      --rpcmaxwsqueuedntfns=
+                            Max number of notifications queued for each RPC
+                            websocket client which is not reading them fast
+                            enough -- Further notifications are dropped and the
+                            client is notified of the number dropped once it
+                            catches up (0 for no limit) (10000)
       --norpc               Disable built-in RPC server -- NOTE: The RPC server
                             is disabled by default if no rpcuser/rpcpass or
-                            rpclimituser/rpclimitpass is specified
+                            rpclimituser/rpclimitpass is specified and
+                            --norpccookie is used
       --notls               Disable TLS for the RPC server -- NOTE: This is only
                             allowed if the RPC server is bound to localhost
       --nodnsseed           Disable DNS seeding for peers
+      --adddnsseed=         Add a DNS seed to the default seeds of the network
+      --removednsseed=      Remove a DNS seed from the default seeds of the
+                            network
       --externalip=         Add an ip to the list of local addresses we claim to
                             listen on to peers
       --proxy=              Connect via SOCKS5 proxy (eg. 127.0.0.1:9050)
@@ -75,13 +139,60 @@ Application Options:
                             credentials for each connection.
       --testnet             Use the test network
       --simnet              Use the simulation test network
+      --simnetmagic=        Use the specified network magic instead of the
+                            standard one for the simulation test network so
+                            independent simnets on the same host do not
+                            communicate with each other -- Only valid with
+                            --simnet
+      --simnetport=         Use the specified default peer port instead of the
+                            standard one for the simulation test network --
+                            Only valid with --simnet
+      --simnetrpcport=      Use the specified default RPC port instead of the
+                            standard one for the simulation test network --
+                            Only valid with --simnet
+      --simnetgenesistime=  Use the specified timestamp (seconds since the unix
+                            epoch) for the genesis block of the simulation test
+                            network, which gives it a distinct genesis block --
+                            Only valid with --simnet
+      --regnet              Use the regression test network
+      --chainparams=        Use the custom network defined by the specified JSON
+                            file
       --nocheckpoints       Disable built-in checkpoints.  Don't do this unless
                             you know what you're doing.
+      --addcheckpoint=      Add a custom checkpoint which is enforced in
+                            addition to the built-in ones and verified against
+                            the existing chain on startup.  Format:
+                            '<height>:<hash>'
       --dbtype=             Database backend to use for the Block Chain (ffldb)
       --profile=            Enable HTTP profiling on given [addr:]port -- NOTE: port
                             must be between 1024 and 65536
       --cpuprofile=         Write CPU profile to the specified file
       --memprofile=         Write mem profile to the specified file
+      --healthlisten=       Serve the /healthz liveness and /readyz readiness
+                            HTTP endpoints on the given [addr:]port -- all
+                            interfaces are used when only a port is given
+      --healthmaxblockage=  Maximum age of the best block for /readyz to report
+                            the chain as synced (default: 1h)
+      --otlpendpoint=       Export traces of block processing, block template
+                            generation, and RPC handling to the specified
+                            OpenTelemetry collector using OTLP over HTTP, such
+                            as http://127.0.0.1:4318
+      --alertwebhook=       POST a JSON description of significant operational
+                            events, such as deep chain reorganizations, a
+                            stalled sync, and losing peers, to the specified
+                            HTTP(S) URL (may be used multiple times)
+      --alertcmd=           Run the specified command for significant
+                            operational events -- The JSON description of the
+                            event is written to its standard input and the
+                            name of the event is set in the EXCCD_EVENT
+                            environment variable
+      --alertreorgdepth=    Minimum number of blocks a chain reorganization
+                            must disconnect to trigger an alert (default: 3)
+      --alertsyncstall=     Duration without a new best block after which the
+                            sync is considered stalled and an alert is
+                            triggered (default: 1h)
+      --alertminpeers=      Trigger an alert when the number of connected peers
+                            drops below this number (default: 1)
       --dumpblockchain=     Write blockchain as a gob-encoded map to the
                             specified file
       --miningtimeoffset=   Offset the mining timestamp of a block by this many
@@ -101,27 +212,118 @@ Application Options:
                             high priority for relaying
       --maxorphantx=        Max number of orphan transactions to keep in memory
                             (1000)
+      --nodatacarrier       Do not relay or mine regular transactions with null
+                            data (OP_RETURN) outputs
+      --datacarriersize=    Maximum number of bytes of data carried by a null
+                            data (OP_RETURN) output of relayed and mined regular
+                            transactions (256)
+      --maxdatacarriers=    Maximum number of null data (OP_RETURN) outputs of
+                            relayed and mined regular transactions (4)
       --generate            Generate (mine) bitcoins using the CPU
       --miningaddr=         Add the specified payment address to the list of
                             addresses to use for generated blocks -- At least
                             one address is required if the generate option is
                             set
+      --miningrigid=        Identifier between 1 and 65535 of this rig which is
+                            encoded in the extra nonces of mined blocks, so rigs
+                            mining to the same address with distinct identifiers
+                            never search the same block headers (0 to disable)
+      --miningrefreshmin=   Minimum duration the CPU miner works on a block
+                            template before refreshing it to include new
+                            transactions paying at least the miningrefreshfee
+                            in fees or new votes (3s)
+      --miningrefreshmax=   Maximum duration the CPU miner works on a block
+                            template before refreshing it -- Templates are
+                            refreshed sooner the more fees new transactions
+                            pay, down to miningrefreshmin (1m0s)
+      --miningrefreshfee=   Fees in EXCC paid by the transactions which arrived
+                            since a block template was generated for the CPU
+                            miner to refresh it after miningrefreshmin (0.001)
+      --miningarchive=      Keep the specified number of the most recent block
+                            templates issued to miners and solutions submitted
+                            by them, with timestamps and outcomes, in a ring
+                            buffer file in the data directory which is queryable
+                            via the getminingarchive RPC (0 to disable, max
+                            100000)
       --blockminsize=       Mininum block size in bytes to be used when creating
                             a block
       --blockmaxsize=       Maximum block size in bytes to be used when creating
                             a block (750000)
       --blockprioritysize=  Size in bytes for high-priority/low-fee transactions
                             when creating a block (50000)
+      --blockminvotes=      Minimum number of votes for a block required before
+                            extending it when creating a block -- Defaults to
+                            the consensus minimum
+      --blockmaxvotewait=   Maximum duration to wait for missing votes for a new
+                            block before extending it with fewer than all of its
+                            votes when creating a block (e.g. 5s)
+      --blocknorevocations  Do not include revocations of missed and expired
+                            tickets when creating a block
       --getworkkey=         DEPRECATED -- Use the --miningaddr option instead
       --nonaggressive       Disable mining off of the parent block of the blockchain
                             if there aren't enough voters
       --nominingstatesync   Disable synchronizing the mining state with other nodes
       --allowoldvotes       Enable the addition of very old votes to the mempool
+      --autorevokescript=   Automatically create and relay revocations for missed
+                            and expired tickets with voting rights committed to
+                            the P2SH address of the given hex-encoded redeem
+                            script -- Signatures required by the redeem script
+                            are created with the keys given with
+                            --autorevokekey (may be used multiple times)
+      --autorevokekey=      Automatically create and relay revocations for missed
+                            and expired tickets with voting rights committed to
+                            the P2PKH address of the given WIF-encoded secp256k1
+                            private key, and sign the revocations of
+                            --autorevokescript redeem scripts with it (may be
+                            used multiple times)
+      --simnetautostake     Automatically purchase tickets and vote with a
+                            node-held key so blocks can be generated without an
+                            external wallet -- The key is publicly known, so
+                            this is only valid with --simnet
+      --simnetvote=         Cast the given choice on the given agenda in the
+                            form <agenda>=<choice> with the votes of the simnet
+                            staker, which are cast with the vote version of the
+                            agendas, so they must all belong to the same version
+                            (may be used multiple times)
 
       --nopeerbloomfilters  Disable bloom filtering support.
       --sigcachemaxsize=    The maximum number of entries in the signature
                             verification cache.
+      --persistsigcache     Save the signature verification cache on shutdown
+                            and load it on startup so signatures that were
+                            already verified are not verified again after a
+                            restart
+      --blockcachesize=     The maximum number of recently connected and
+                            requested blocks to keep in memory so they are
+                            served to peers and RPC clients without loading
+                            them from the database again (0 to disable)
+                            (default: 50)
+      --memlimit=           Soft limit in MiB on the memory used by the heap
+                            -- Caches and pools are trimmed and block
+                            downloads are throttled when the heap approaches
+                            it instead of running out of memory (0 to
+                            disable)
       --blocksonly          Do not accept transactions from remote peers.
+      --miningonly          Run a dedicated mining node which keeps the chain
+                            and transactions received from peers for building
+                            block templates, but does not relay transactions
+                            other than those submitted via RPC, does not serve
+                            blocks older than a week to peers which are not
+                            whitelisted or filters to any peer, and does not
+                            advertise itself as a full node
+      --privaterelay        Relay transactions submitted via RPC after a short
+                            random delay to a random subset of peers instead of
+                            immediately to all of them to make it harder for
+                            network observers to infer that they originated
+                            from this node
+      --privaterelaytor     Only relay transactions submitted via RPC to peers
+                            connected through Tor -- Implies --privaterelay and
+                            requires --proxy or --onion
+      --maxuploadtarget=    Try to keep the data uploaded to peers within the
+                            given number of MiB per 24 hours by disconnecting
+                            peers which are not whitelisted when they request
+                            blocks older than a week once it is reached (0 for
+                            no limit)
       --acceptnonstd        Accept and relay non-standard transactions to
                             the network regardless of the default settings
                             for the active network.
@@ -131,5 +333,16 @@ Application Options:
 Help Options:
   -h, --help           Show this help message
 
+Commands
+
+A command may be provided after the options to run it instead of the node:
+
+  dbinfo    Open the block database read only and print its size, the state
+            of the chain and indexes stored in it, and the results of checking
+            its consistency.  The node must not be running.
+
+Unknown commands are rejected, while arguments which are not command names are
+ignored.
+
 */
 package main
@@ -11,17 +11,56 @@ import (
 	"net/http"
 	_ "net/http/pprof"
 	"os"
+	"path/filepath"
 	"runtime"
 	"runtime/debug"
 	"runtime/pprof"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/EXCCoin/exccd/blockchain/indexers"
 	"github.com/EXCCoin/exccd/limits"
+	"github.com/EXCCoin/exccd/tracing"
 )
 
 var cfg *config
 
+// commands maps the names of the commands which may be provided after the
+// options to the functions which run them instead of the node.
+var commands = map[string]func() error{
+	"dbinfo": runDBInfo,
+}
+
+// isCommandName returns whether or not the passed argument has the form of a
+// command name, which consists of lowercase letters only.  Other arguments were
+// never interpreted, so they are ignored to keep existing invocations working.
+func isCommandName(arg string) bool {
+	if arg == "" {
+		return false
+	}
+	for _, r := range arg {
+		if r < 'a' || r > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// unknownCommandUsage returns a usage message which lists the available
+// commands.
+func unknownCommandUsage() string {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return fmt.Sprintf("Usage: %s [OPTIONS] [COMMAND]\nAvailable "+
+		"commands: %s\nUse %s -h to show the available options",
+		filepath.Base(os.Args[0]), strings.Join(names, ", "),
+		filepath.Base(os.Args[0]))
+}
+
 // winServiceMain is only invoked on Windows.  It detects when exccd is running
 // as a service and reacts accordingly.
 var winServiceMain func() (bool, error)
@@ -34,16 +73,32 @@ var winServiceMain func() (bool, error)
 func exccdMain(serverChan chan<- *server) error {
 	// Load configuration and parse command line.  This function also
 	// initializes logging and configures it accordingly.
-	tcfg, _, err := loadConfig()
+	tcfg, args, err := loadConfig()
 	if err != nil {
 		return err
 	}
 	cfg = tcfg
-	defer func() {
-		if logRotator != nil {
-			logRotator.Close()
+	defer closeLogRotators()
+
+	// Run the requested command instead of the node when one is provided.
+	// Unknown commands are rejected, while other arguments are ignored as
+	// they always have been.
+	if len(args) > 0 {
+		if runCommand, ok := commands[args[0]]; ok {
+			err := runCommand()
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+			}
+			return err
 		}
-	}()
+		if isCommandName(args[0]) {
+			err := fmt.Errorf("unknown command %q", args[0])
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, unknownCommandUsage())
+			return err
+		}
+		exccLog.Warnf("Ignoring unexpected arguments %q", args)
+	}
 
 	// Get a channel that will be closed when a shutdown signal has been
 	// triggered either from an OS signal such as SIGINT (Ctrl+C) or from
@@ -52,8 +107,19 @@ func exccdMain(serverChan chan<- *server) error {
 	defer exccLog.Info("Shutdown complete")
 
 	// Show version and home dir at startup.
-	exccLog.Infof("Version %s (Go version %s)", version(), runtime.Version())
+	if appCommit != "" {
+		exccLog.Infof("Version %s (commit %s, Go version %s)", version(),
+			appCommit, runtime.Version())
+	} else {
+		exccLog.Infof("Version %s (Go version %s)", version(),
+			runtime.Version())
+	}
 	exccLog.Infof("Home dir: %s", cfg.HomeDir)
+
+	// Write a crash report if the process panics and note the reports of
+	// previous crashes.
+	initCrashReports(cfg.DataDir)
+	defer handleCrash()
 	if cfg.NoFileLogging {
 		exccLog.Info("File logging disabled")
 	}
@@ -101,6 +167,36 @@ func exccdMain(serverChan chan<- *server) error {
 		}()
 	}
 
+	// Export traces of block processing, block template generation, and
+	// RPC handling if requested.
+	if cfg.OTLPEndpoint != "" {
+		err := tracing.StartExporter(&tracing.Config{
+			Endpoint:       cfg.OTLPEndpoint,
+			ServiceName:    "exccd",
+			ServiceVersion: version(),
+		})
+		if err != nil {
+			exccLog.Errorf("Unable to start trace exporter: %v", err)
+			return err
+		}
+		defer tracing.StopExporter()
+	}
+
+	// Serve the liveness and readiness endpoints if requested.  The server
+	// is started before the database is loaded so liveness is reported
+	// during long startup operations such as catching up indexes.
+	var healthSrv *healthServer
+	if cfg.HealthListen != "" {
+		healthSrv, err = newHealthServer(cfg.HealthListen,
+			cfg.HealthMaxBlockAge)
+		if err != nil {
+			exccLog.Errorf("Unable to start health server: %v", err)
+			return err
+		}
+		healthSrv.Start()
+		defer healthSrv.Stop()
+	}
+
 	var lifetimeNotifier lifetimeEventServer
 	if cfg.LifetimeEvents {
 		lifetimeNotifier = newLifetimeEventServer(outgoingPipeMessages)
@@ -175,6 +271,64 @@ func exccdMain(serverChan chan<- *server) error {
 
 		return nil
 	}
+	if cfg.DropTimeIndex {
+		if err := indexers.DropTimeIndex(db, interrupt); err != nil {
+			exccLog.Errorf("%v", err)
+			return err
+		}
+
+		return nil
+	}
+	if cfg.DropTicketIndex {
+		if err := indexers.DropTicketIndex(db, interrupt); err != nil {
+			exccLog.Errorf("%v", err)
+			return err
+		}
+
+		return nil
+	}
+	if len(cfg.DropExternalIndexers) > 0 {
+		for _, name := range cfg.DropExternalIndexers {
+			err := indexers.DropExternalIndex(db, name, interrupt)
+			if err != nil {
+				exccLog.Errorf("%v", err)
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	// Drop all indexes which were requested to be rebuilt.  They are
+	// enabled by the configuration, so the index manager rebuilds them from
+	// the existing block data while catching up the indexes during server
+	// creation.
+	//
+	// NOTE: Dropping the tx index also drops the address index since it
+	// relies on it.
+	for _, idxName := range cfg.RebuildIndexes {
+		exccLog.Infof("Rebuilding %s", idxName)
+
+		var err error
+		switch idxName {
+		case "txindex":
+			err = indexers.DropTxIndex(db, interrupt)
+		case "addrindex":
+			err = indexers.DropAddrIndex(db, interrupt)
+		case "existsaddrindex":
+			err = indexers.DropExistsAddrIndex(db, interrupt)
+		case "cfindex":
+			err = indexers.DropCfIndex(db, interrupt)
+		case "timeindex":
+			err = indexers.DropTimeIndex(db, interrupt)
+		case "ticketindex":
+			err = indexers.DropTicketIndex(db, interrupt)
+		}
+		if err != nil {
+			exccLog.Errorf("%v", err)
+			return err
+		}
+	}
 
 	// Create server and start it.
 	lifetimeNotifier.notifyStartupEvent(lifetimeEventP2PServer)
@@ -194,7 +348,16 @@ func exccdMain(serverChan chan<- *server) error {
 		srvrLog.Infof("Server shutdown complete")
 	}()
 
+	setCrashReportServer(server)
 	server.Start()
+	spawn(func() { reloadListener(server, interrupt) })
+	if healthSrv != nil {
+		healthSrv.setChain(server.blockManager.chain,
+			server.timeSource.AdjustedTime)
+
+		// Report the node as not ready before the server is stopped.
+		defer healthSrv.setChain(nil, nil)
+	}
 	if serverChan != nil {
 		serverChan <- server
 	}
@@ -861,6 +861,79 @@ func (curve *KoblitzCurve) ScalarMult(Bx, By *big.Int, k []byte) (*big.Int, *big
 	return curve.fieldJacobianToBigAffine(qx, qy, qz)
 }
 
+// MultiScalarMult returns k1*P1 + k2*P2 + ... + kn*Pn where each Pi is the
+// point (xs[i], ys[i]) and each ki is the big endian integer ks[i].
+//
+// This is considerably faster than summing the results of individual calls to
+// ScalarMult since the products are calculated simultaneously, which means the
+// point doublings are shared between all of the points.  See algorithm 3.48
+// (Strauss' method) from [GECC].
+func (curve *KoblitzCurve) MultiScalarMult(xs, ys []*big.Int, ks [][]byte) (*big.Int, *big.Int) {
+	// Each point contributes two terms after decomposing its scalar as done
+	// by ScalarMult, so keep track of the NAF representation of every term
+	// along with the point it is multiplied by.
+	type nafTerm struct {
+		x, y, yNeg, z *fieldVal
+		pos, neg      []byte
+	}
+	terms := make([]nafTerm, 0, 2*len(ks))
+	m := 0
+	addTerm := func(x, y *fieldVal, k []byte, signK int) {
+		yNeg := new(fieldVal).NegateVal(y, 1)
+		if signK == -1 {
+			y, yNeg = yNeg, y
+		}
+		pos, neg := NAF(k)
+		if len(pos) > m {
+			m = len(pos)
+		}
+		terms = append(terms, nafTerm{x, y, yNeg, new(fieldVal).SetInt(1),
+			pos, neg})
+	}
+	for i, k := range ks {
+		// k * P = k1 * P + k2 * ϕ(P) where ϕ(x,y) = (βx,y).
+		k1, k2, signK1, signK2 := curve.splitK(curve.moduloReduce(k))
+		p1x, p1y := curve.bigAffineToField(xs[i], ys[i])
+		p2x := new(fieldVal).Mul2(p1x, curve.beta)
+		p2y := new(fieldVal).Set(p1y)
+		addTerm(p1x, p1y, k1, signK1)
+		addTerm(p2x, p2y, k2, signK2)
+	}
+
+	// Point Q = ∞ (point at infinity).
+	qx, qy, qz := new(fieldVal), new(fieldVal), new(fieldVal)
+
+	// Add left-to-right using the NAF optimization while only doubling once
+	// per bit for all of the terms.
+	for i := 0; i < m; i++ {
+		for j := 7; j >= 0; j-- {
+			// Q = 2 * Q
+			curve.doubleJacobian(qx, qy, qz, qx, qy, qz)
+
+			for t := range terms {
+				term := &terms[t]
+
+				// Since we're going left-to-right, pad the front
+				// with 0s.
+				idx := i - (m - len(term.pos))
+				if idx < 0 {
+					continue
+				}
+				if (term.pos[idx]>>uint(j))&1 == 1 {
+					curve.addJacobian(qx, qy, qz, term.x, term.y,
+						term.z, qx, qy, qz)
+				} else if (term.neg[idx]>>uint(j))&1 == 1 {
+					curve.addJacobian(qx, qy, qz, term.x,
+						term.yNeg, term.z, qx, qy, qz)
+				}
+			}
+		}
+	}
+
+	// Convert the Jacobian coordinate field values back to affine big.Ints.
+	return curve.fieldJacobianToBigAffine(qx, qy, qz)
+}
+
 // ScalarBaseMult returns k*G where G is the base point of the group and k is a
 // big endian integer.
 // Part of the elliptic.Curve interface.
@@ -665,6 +665,42 @@ func TestScalarMultRand(t *testing.T) {
 	}
 }
 
+// TestMultiScalarMultRand ensures the result of a multi-scalar multiplication
+// of random points and scalars matches the sum of the individual products.
+func TestMultiScalarMultRand(t *testing.T) {
+	s256 := S256()
+	for n := 1; n <= 16; n++ {
+		xs := make([]*big.Int, n)
+		ys := make([]*big.Int, n)
+		ks := make([][]byte, n)
+		xWant, yWant := new(big.Int), new(big.Int)
+		for i := 0; i < n; i++ {
+			pointK := make([]byte, 32)
+			ks[i] = make([]byte, 32)
+			if _, err := rand.Read(pointK); err != nil {
+				t.Fatalf("failed to read random data: %v", err)
+			}
+			if _, err := rand.Read(ks[i]); err != nil {
+				t.Fatalf("failed to read random data: %v", err)
+			}
+			xs[i], ys[i] = s256.ScalarBaseMult(pointK)
+
+			x, y := s256.ScalarMult(xs[i], ys[i], ks[i])
+			if i == 0 {
+				xWant, yWant = x, y
+			} else {
+				xWant, yWant = s256.Add(xWant, yWant, x, y)
+			}
+		}
+
+		x, y := s256.MultiScalarMult(xs, ys, ks)
+		if x.Cmp(xWant) != 0 || y.Cmp(yWant) != 0 {
+			t.Fatalf("%d points: bad output: got (%X, %X), want "+
+				"(%X, %X)", n, x, y, xWant, yWant)
+		}
+	}
+}
+
 func TestSplitK(t *testing.T) {
 	tests := []struct {
 		k      string
@@ -0,0 +1,135 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package schnorr
+
+import (
+	"crypto/rand"
+	"math/big"
+
+	"github.com/EXCCoin/exccd/chaincfg/chainhash"
+	"github.com/EXCCoin/exccd/exccec/secp256k1"
+)
+
+// batchCoefficientSize is the size of the random coefficients used to combine
+// the signatures of a batch.  128 bits is sufficient to make the probability
+// of an invalid batch being accepted negligible.
+const batchCoefficientSize = 16
+
+// BatchVerify returns whether or not all of the passed secp256k1 Schnorr
+// signatures are valid for their associated public keys and messages.  It
+// returns the same result as calling Verify for each of the signatures and is
+// significantly faster for more than a few signatures.
+//
+// A signature (r, s) of the message m is valid for the public key Q when
+// R = hQ + sG, where R is the point with the x coordinate r and an even y
+// coordinate and h = BLAKE256(r || m).  Rather than checking this equation for
+// each signature individually, the equations are combined using random
+// coefficients a_i into the single equation
+//   a_1*R_1 + ... + a_n*R_n = (a_1*h_1)Q_1 + ... + (a_n*h_n)Q_n +
+//                             (a_1*s_1 + ... + a_n*s_n)G
+// which only holds, with overwhelming probability, when every individual
+// equation holds.  Both sides are calculated with a single multi-scalar
+// multiplication each.
+//
+// Since the result only says whether or not the entire batch is valid, callers
+// which need to know which signatures are invalid must verify them
+// individually when false is returned.
+func BatchVerify(pubKeys []*secp256k1.PublicKey, msgs [][]byte,
+	sigs []*Signature) bool {
+
+	numSigs := len(sigs)
+	if len(pubKeys) != numSigs || len(msgs) != numSigs {
+		return false
+	}
+	switch numSigs {
+	case 0:
+		return true
+	case 1:
+		return Verify(pubKeys[0], msgs[0], sigs[0].R, sigs[0].S)
+	}
+
+	// Generate the random coefficients.  The coefficient of the first
+	// signature is always 1 since randomizing it provides no additional
+	// security.
+	coefficients := make([]byte, batchCoefficientSize*numSigs)
+	if _, err := rand.Read(coefficients); err != nil {
+		for i := range sigs {
+			if !Verify(pubKeys[i], msgs[i], sigs[i].R, sigs[i].S) {
+				return false
+			}
+		}
+		return true
+	}
+
+	curve := secp256k1.S256()
+	rXs := make([]*big.Int, 0, numSigs)
+	rYs := make([]*big.Int, 0, numSigs)
+	rKs := make([][]byte, 0, numSigs)
+	qXs := make([]*big.Int, 0, numSigs+1)
+	qYs := make([]*big.Int, 0, numSigs+1)
+	qKs := make([][]byte, 0, numSigs+1)
+	sSum := new(big.Int)
+	for i, sig := range sigs {
+		pubKey, msg := pubKeys[i], msgs[i]
+		if pubKey == nil || len(msg) != scalarSize || sig == nil ||
+			sig.R == nil || sig.S == nil {
+			return false
+		}
+		if !curve.IsOnCurve(pubKey.GetX(), pubKey.GetY()) {
+			return false
+		}
+
+		// The hash of (r || m) and s must both be valid scalars.
+		rBytes := BigIntToEncodedBytes(sig.R)
+		h := chainhash.HashB(append(rBytes[:], msg...))
+		hBig := new(big.Int).SetBytes(h)
+		if hBig.Sign() == 0 || hBig.Cmp(curve.N) >= 0 {
+			return false
+		}
+		if sig.S.Sign() < 0 || sig.S.Cmp(curve.N) >= 0 {
+			return false
+		}
+
+		// Recover R from r by choosing the point with the even y
+		// coordinate.  This fails when r is not the x coordinate of a
+		// point on the curve.
+		compressedR := make([]byte, 0, PubKeyBytesLen)
+		compressedR = append(compressedR, pubkeyCompressed)
+		compressedR = append(compressedR, rBytes[:]...)
+		r, err := secp256k1.ParsePubKey(compressedR)
+		if err != nil {
+			return false
+		}
+
+		a := big.NewInt(1)
+		if i > 0 {
+			a.SetBytes(coefficients[i*batchCoefficientSize : (i+1)*
+				batchCoefficientSize])
+			if a.Sign() == 0 {
+				a.SetInt64(1)
+			}
+		}
+
+		rXs = append(rXs, r.GetX())
+		rYs = append(rYs, r.GetY())
+		rKs = append(rKs, a.Bytes())
+
+		ah := new(big.Int).Mul(a, hBig)
+		ah.Mod(ah, curve.N)
+		qXs = append(qXs, pubKey.GetX())
+		qYs = append(qYs, pubKey.GetY())
+		qKs = append(qKs, ah.Bytes())
+
+		sSum.Add(sSum, new(big.Int).Mul(a, sig.S))
+		sSum.Mod(sSum, curve.N)
+	}
+	qXs = append(qXs, curve.Gx)
+	qYs = append(qYs, curve.Gy)
+	qKs = append(qKs, sSum.Bytes())
+
+	lx, ly := curve.MultiScalarMult(rXs, rYs, rKs)
+	rx, ry := curve.MultiScalarMult(qXs, qYs, qKs)
+	return lx.Cmp(rx) == 0 && ly.Cmp(ry) == 0
+}
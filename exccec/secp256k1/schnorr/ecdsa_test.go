@@ -8,6 +8,7 @@ package schnorr
 import (
 	"bytes"
 	"encoding/hex"
+	"math/big"
 	"math/rand"
 	"testing"
 
@@ -395,3 +396,67 @@ func benchmarkVerification(b *testing.B) {
 }
 
 func BenchmarkVerification(b *testing.B) { benchmarkVerification(b) }
+
+// TestBatchVerify ensures batches of valid signatures are accepted and that a
+// batch is rejected when any of its signatures is invalid.
+func TestBatchVerify(t *testing.T) {
+	const numSigs = 64
+	sigList := randSigList(numSigs)
+	pubKeys := make([]*secp256k1.PublicKey, numSigs)
+	msgs := make([][]byte, numSigs)
+	sigs := make([]*Signature, numSigs)
+	for i, params := range sigList {
+		pubKeys[i] = params.pubkey
+		msgs[i] = params.msg
+		sigs[i] = params.sig
+	}
+
+	for _, n := range []int{0, 1, 2, 16, numSigs} {
+		if !BatchVerify(pubKeys[:n], msgs[:n], sigs[:n]) {
+			t.Fatalf("batch of %d valid signatures rejected", n)
+		}
+	}
+
+	// Swap the messages of two signatures, use a signature for the wrong
+	// public key, and corrupt the s value of a signature.
+	swapped := append([][]byte(nil), msgs...)
+	swapped[3], swapped[4] = swapped[4], swapped[3]
+	if BatchVerify(pubKeys, swapped, sigs) {
+		t.Fatal("batch with swapped messages accepted")
+	}
+	wrongKey := append([]*secp256k1.PublicKey(nil), pubKeys...)
+	wrongKey[numSigs-1] = pubKeys[0]
+	if BatchVerify(wrongKey, msgs, sigs) {
+		t.Fatal("batch with wrong public key accepted")
+	}
+	corrupted := append([]*Signature(nil), sigs...)
+	corrupted[7] = NewSignature(sigs[7].R, new(big.Int).Add(sigs[7].S,
+		big.NewInt(1)))
+	if BatchVerify(pubKeys, msgs, corrupted) {
+		t.Fatal("batch with corrupted signature accepted")
+	}
+	if BatchVerify(pubKeys[:1], msgs[:1], corrupted[7:8]) {
+		t.Fatal("corrupted signature accepted")
+	}
+}
+
+func benchmarkBatchVerification(b *testing.B, batchSize int) {
+	sigList := randSigList(batchSize)
+	pubKeys := make([]*secp256k1.PublicKey, batchSize)
+	msgs := make([][]byte, batchSize)
+	sigs := make([]*Signature, batchSize)
+	for i, params := range sigList {
+		pubKeys[i] = params.pubkey
+		msgs[i] = params.msg
+		sigs[i] = params.sig
+	}
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if !BatchVerify(pubKeys, msgs, sigs) {
+			panic("made invalid sig")
+		}
+	}
+}
+
+func BenchmarkBatchVerification64(b *testing.B) { benchmarkBatchVerification(b, 64) }
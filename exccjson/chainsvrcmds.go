@@ -146,6 +146,7 @@ type GetBlockCmd struct {
 	Hash      string
 	Verbose   *bool `jsonrpcdefault:"true"`
 	VerboseTx *bool `jsonrpcdefault:"false"`
+	PrevOuts  *bool `jsonrpcdefault:"false"`
 }
 
 // NewGetBlockCmd returns a new instance which can be used to issue a getblock
@@ -153,11 +154,12 @@ type GetBlockCmd struct {
 //
 // The parameters which are pointers indicate they are optional.  Passing nil
 // for optional parameters will use the default value.
-func NewGetBlockCmd(hash string, verbose, verboseTx *bool) *GetBlockCmd {
+func NewGetBlockCmd(hash string, verbose, verboseTx, prevOuts *bool) *GetBlockCmd {
 	return &GetBlockCmd{
 		Hash:      hash,
 		Verbose:   verbose,
 		VerboseTx: verboseTx,
+		PrevOuts:  prevOuts,
 	}
 }
 
@@ -463,6 +465,24 @@ func NewGetNetworkHashPSCmd(numBlocks, height *int) *GetNetworkHashPSCmd {
 	}
 }
 
+// GetNetworkSolPSCmd defines the getnetworksolps JSON-RPC command.
+type GetNetworkSolPSCmd struct {
+	Blocks *int `jsonrpcdefault:"120"`
+	Height *int `jsonrpcdefault:"-1"`
+}
+
+// NewGetNetworkSolPSCmd returns a new instance which can be used to issue a
+// getnetworksolps JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewGetNetworkSolPSCmd(numBlocks, height *int) *GetNetworkSolPSCmd {
+	return &GetNetworkSolPSCmd{
+		Blocks: numBlocks,
+		Height: height,
+	}
+}
+
 // GetPeerInfoCmd defines the getpeerinfo JSON-RPC command.
 type GetPeerInfoCmd struct{}
 
@@ -493,10 +513,35 @@ const (
 	GRMRevocations GetRawMempoolTxTypeCmd = "revocations"
 )
 
+// GetRawMempoolSortByCmd defines the type used in the getrawmempool JSON-RPC
+// command for the SortBy command field.
+type GetRawMempoolSortByCmd string
+
+const (
+	// GRMSortFeeRate indicates transactions should be returned in order of
+	// decreasing fee rate.
+	GRMSortFeeRate GetRawMempoolSortByCmd = "feerate"
+
+	// GRMSortAge indicates transactions should be returned in the order they
+	// entered the pool, oldest first.
+	GRMSortAge GetRawMempoolSortByCmd = "age"
+
+	// GRMSortSize indicates transactions should be returned in order of
+	// decreasing size.
+	GRMSortSize GetRawMempoolSortByCmd = "size"
+)
+
 // GetRawMempoolCmd defines the getmempool JSON-RPC command.
+//
+// Setting SortBy, Skip, or Count returns the selected transactions in order,
+// which changes the verbose result from an object keyed by transaction hash to
+// an array.  A Count of zero returns all remaining transactions.
 type GetRawMempoolCmd struct {
 	Verbose *bool `jsonrpcdefault:"false"`
 	TxType  *string
+	SortBy  *string
+	Skip    *uint32
+	Count   *uint32
 }
 
 // NewGetRawMempoolCmd returns a new instance which can be used to issue a
@@ -504,17 +549,21 @@ type GetRawMempoolCmd struct {
 //
 // The parameters which are pointers indicate they are optional.  Passing nil
 // for optional parameters will use the default value.
-func NewGetRawMempoolCmd(verbose *bool, txType *string) *GetRawMempoolCmd {
+func NewGetRawMempoolCmd(verbose *bool, txType *string, sortBy *string, skip, count *uint32) *GetRawMempoolCmd {
 	return &GetRawMempoolCmd{
 		Verbose: verbose,
 		TxType:  txType,
+		SortBy:  sortBy,
+		Skip:    skip,
+		Count:   count,
 	}
 }
 
 // GetRawTransactionCmd defines the getrawtransaction JSON-RPC command.
 //
 // NOTE: This field is an int versus a bool to remain compatible with Bitcoin
-// Core even though it really should be a bool.
+// Core.  A verbosity of 2 additionally includes the previous output spent by
+// each input.
 type GetRawTransactionCmd struct {
 	Txid    string
 	Verbose *int `jsonrpcdefault:"0"`
@@ -633,7 +682,8 @@ func NewSearchRawTransactionsCmd(address string, verbose, skip, count *int, vinE
 // SendRawTransactionCmd defines the sendrawtransaction JSON-RPC command.
 type SendRawTransactionCmd struct {
 	HexTx         string
-	AllowHighFees *bool `jsonrpcdefault:"false"`
+	AllowHighFees *bool    `jsonrpcdefault:"false"`
+	MaxFeeRate    *float64 `jsonrpcdefault:"0.1"`
 }
 
 // NewSendRawTransactionCmd returns a new instance which can be used to issue a
@@ -641,10 +691,36 @@ type SendRawTransactionCmd struct {
 //
 // The parameters which are pointers indicate they are optional.  Passing nil
 // for optional parameters will use the default value.
-func NewSendRawTransactionCmd(hexTx string, allowHighFees *bool) *SendRawTransactionCmd {
+func NewSendRawTransactionCmd(hexTx string, allowHighFees *bool, maxFeeRate *float64) *SendRawTransactionCmd {
 	return &SendRawTransactionCmd{
 		HexTx:         hexTx,
 		AllowHighFees: allowHighFees,
+		MaxFeeRate:    maxFeeRate,
+	}
+}
+
+// SendRawTransactionAndWaitCmd defines the sendrawtransactionandwait JSON-RPC
+// command.
+type SendRawTransactionAndWaitCmd struct {
+	HexTx         string
+	Confirmations *uint32  `jsonrpcdefault:"1"`
+	Timeout       *uint32  `jsonrpcdefault:"600"`
+	AllowHighFees *bool    `jsonrpcdefault:"false"`
+	MaxFeeRate    *float64 `jsonrpcdefault:"0.1"`
+}
+
+// NewSendRawTransactionAndWaitCmd returns a new instance which can be used to
+// issue a sendrawtransactionandwait JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewSendRawTransactionAndWaitCmd(hexTx string, confirmations, timeout *uint32, allowHighFees *bool, maxFeeRate *float64) *SendRawTransactionAndWaitCmd {
+	return &SendRawTransactionAndWaitCmd{
+		HexTx:         hexTx,
+		Confirmations: confirmations,
+		Timeout:       timeout,
+		AllowHighFees: allowHighFees,
+		MaxFeeRate:    maxFeeRate,
 	}
 }
 
@@ -668,6 +744,29 @@ func NewSetGenerateCmd(generate bool, genProcLimit *int, miningAddr *string) *Se
 	}
 }
 
+// SignRawTransactionWithKeyCmd defines the signrawtransactionwithkey JSON-RPC
+// command.
+type SignRawTransactionWithKeyCmd struct {
+	RawTx       string
+	PrivKeys    []string
+	Inputs      *[]RawTxInput
+	SigHashType *string `jsonrpcdefault:"\"ALL\""`
+}
+
+// NewSignRawTransactionWithKeyCmd returns a new instance which can be used to
+// issue a signrawtransactionwithkey JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewSignRawTransactionWithKeyCmd(hexEncodedTx string, privKeys []string, inputs *[]RawTxInput, sigHashType *string) *SignRawTransactionWithKeyCmd {
+	return &SignRawTransactionWithKeyCmd{
+		RawTx:       hexEncodedTx,
+		PrivKeys:    privKeys,
+		Inputs:      inputs,
+		SigHashType: sigHashType,
+	}
+}
+
 // StopCmd defines the stop JSON-RPC command.
 type StopCmd struct{}
 
@@ -702,16 +801,30 @@ func NewSubmitBlockCmd(hexBlock string, options *SubmitBlockOptions) *SubmitBloc
 	}
 }
 
+// UptimeCmd defines the uptime JSON-RPC command.
+type UptimeCmd struct{}
+
+// NewUptimeCmd returns a new instance which can be used to issue an uptime
+// JSON-RPC command.
+func NewUptimeCmd() *UptimeCmd {
+	return &UptimeCmd{}
+}
+
 // ValidateAddressCmd defines the validateaddress JSON-RPC command.
 type ValidateAddressCmd struct {
-	Address string
+	Address      string
+	RedeemScript *string
 }
 
 // NewValidateAddressCmd returns a new instance which can be used to issue a
 // validateaddress JSON-RPC command.
-func NewValidateAddressCmd(address string) *ValidateAddressCmd {
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewValidateAddressCmd(address string, redeemScript *string) *ValidateAddressCmd {
 	return &ValidateAddressCmd{
-		Address: address,
+		Address:      address,
+		RedeemScript: redeemScript,
 	}
 }
 
@@ -782,6 +895,7 @@ func init() {
 	MustRegisterCmd("getnetworkinfo", (*GetNetworkInfoCmd)(nil), flags)
 	MustRegisterCmd("getnettotals", (*GetNetTotalsCmd)(nil), flags)
 	MustRegisterCmd("getnetworkhashps", (*GetNetworkHashPSCmd)(nil), flags)
+	MustRegisterCmd("getnetworksolps", (*GetNetworkSolPSCmd)(nil), flags)
 	MustRegisterCmd("getpeerinfo", (*GetPeerInfoCmd)(nil), flags)
 	MustRegisterCmd("getrawmempool", (*GetRawMempoolCmd)(nil), flags)
 	MustRegisterCmd("getrawtransaction", (*GetRawTransactionCmd)(nil), flags)
@@ -792,9 +906,12 @@ func init() {
 	MustRegisterCmd("ping", (*PingCmd)(nil), flags)
 	MustRegisterCmd("searchrawtransactions", (*SearchRawTransactionsCmd)(nil), flags)
 	MustRegisterCmd("sendrawtransaction", (*SendRawTransactionCmd)(nil), flags)
+	MustRegisterCmd("sendrawtransactionandwait", (*SendRawTransactionAndWaitCmd)(nil), flags)
 	MustRegisterCmd("setgenerate", (*SetGenerateCmd)(nil), flags)
+	MustRegisterCmd("signrawtransactionwithkey", (*SignRawTransactionWithKeyCmd)(nil), flags)
 	MustRegisterCmd("stop", (*StopCmd)(nil), flags)
 	MustRegisterCmd("submitblock", (*SubmitBlockCmd)(nil), flags)
+	MustRegisterCmd("uptime", (*UptimeCmd)(nil), flags)
 	MustRegisterCmd("validateaddress", (*ValidateAddressCmd)(nil), flags)
 	MustRegisterCmd("verifychain", (*VerifyChainCmd)(nil), flags)
 	MustRegisterCmd("verifymessage", (*VerifyMessageCmd)(nil), flags)
@@ -145,13 +145,14 @@ func TestChainSvrCmds(t *testing.T) {
 				return exccjson.NewCmd("getblock", "123")
 			},
 			staticCmd: func() interface{} {
-				return exccjson.NewGetBlockCmd("123", nil, nil)
+				return exccjson.NewGetBlockCmd("123", nil, nil, nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"getblock","params":["123"],"id":1}`,
 			unmarshalled: &exccjson.GetBlockCmd{
 				Hash:      "123",
 				Verbose:   exccjson.Bool(true),
 				VerboseTx: exccjson.Bool(false),
+				PrevOuts:  exccjson.Bool(false),
 			},
 		},
 		{
@@ -164,13 +165,14 @@ func TestChainSvrCmds(t *testing.T) {
 				return exccjson.NewCmd("getblock", "123", &verbosePtr)
 			},
 			staticCmd: func() interface{} {
-				return exccjson.NewGetBlockCmd("123", exccjson.Bool(true), nil)
+				return exccjson.NewGetBlockCmd("123", exccjson.Bool(true), nil, nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"getblock","params":["123",true],"id":1}`,
 			unmarshalled: &exccjson.GetBlockCmd{
 				Hash:      "123",
 				Verbose:   exccjson.Bool(true),
 				VerboseTx: exccjson.Bool(false),
+				PrevOuts:  exccjson.Bool(false),
 			},
 		},
 		{
@@ -179,13 +181,30 @@ func TestChainSvrCmds(t *testing.T) {
 				return exccjson.NewCmd("getblock", "123", true, true)
 			},
 			staticCmd: func() interface{} {
-				return exccjson.NewGetBlockCmd("123", exccjson.Bool(true), exccjson.Bool(true))
+				return exccjson.NewGetBlockCmd("123", exccjson.Bool(true), exccjson.Bool(true), nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"getblock","params":["123",true,true],"id":1}`,
 			unmarshalled: &exccjson.GetBlockCmd{
 				Hash:      "123",
 				Verbose:   exccjson.Bool(true),
 				VerboseTx: exccjson.Bool(true),
+				PrevOuts:  exccjson.Bool(false),
+			},
+		},
+		{
+			name: "getblock required optional3",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("getblock", "123", true, true, true)
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewGetBlockCmd("123", exccjson.Bool(true), exccjson.Bool(true), exccjson.Bool(true))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getblock","params":["123",true,true,true],"id":1}`,
+			unmarshalled: &exccjson.GetBlockCmd{
+				Hash:      "123",
+				Verbose:   exccjson.Bool(true),
+				VerboseTx: exccjson.Bool(true),
+				PrevOuts:  exccjson.Bool(true),
 			},
 		},
 		{
@@ -512,6 +531,34 @@ func TestChainSvrCmds(t *testing.T) {
 				Height: exccjson.Int(123),
 			},
 		},
+		{
+			name: "getnetworksolps",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("getnetworksolps")
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewGetNetworkSolPSCmd(nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getnetworksolps","params":[],"id":1}`,
+			unmarshalled: &exccjson.GetNetworkSolPSCmd{
+				Blocks: exccjson.Int(120),
+				Height: exccjson.Int(-1),
+			},
+		},
+		{
+			name: "getnetworksolps optional",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("getnetworksolps", 200, 123)
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewGetNetworkSolPSCmd(exccjson.Int(200), exccjson.Int(123))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getnetworksolps","params":[200,123],"id":1}`,
+			unmarshalled: &exccjson.GetNetworkSolPSCmd{
+				Blocks: exccjson.Int(200),
+				Height: exccjson.Int(123),
+			},
+		},
 		{
 			name: "getpeerinfo",
 			newCmd: func() (interface{}, error) {
@@ -529,7 +576,7 @@ func TestChainSvrCmds(t *testing.T) {
 				return exccjson.NewCmd("getrawmempool")
 			},
 			staticCmd: func() interface{} {
-				return exccjson.NewGetRawMempoolCmd(nil, nil)
+				return exccjson.NewGetRawMempoolCmd(nil, nil, nil, nil, nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"getrawmempool","params":[],"id":1}`,
 			unmarshalled: &exccjson.GetRawMempoolCmd{
@@ -542,7 +589,7 @@ func TestChainSvrCmds(t *testing.T) {
 				return exccjson.NewCmd("getrawmempool", false)
 			},
 			staticCmd: func() interface{} {
-				return exccjson.NewGetRawMempoolCmd(exccjson.Bool(false), nil)
+				return exccjson.NewGetRawMempoolCmd(exccjson.Bool(false), nil, nil, nil, nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"getrawmempool","params":[false],"id":1}`,
 			unmarshalled: &exccjson.GetRawMempoolCmd{
@@ -555,7 +602,7 @@ func TestChainSvrCmds(t *testing.T) {
 				return exccjson.NewCmd("getrawmempool", false, "all")
 			},
 			staticCmd: func() interface{} {
-				return exccjson.NewGetRawMempoolCmd(exccjson.Bool(false), exccjson.String("all"))
+				return exccjson.NewGetRawMempoolCmd(exccjson.Bool(false), exccjson.String("all"), nil, nil, nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"getrawmempool","params":[false,"all"],"id":1}`,
 			unmarshalled: &exccjson.GetRawMempoolCmd{
@@ -563,6 +610,25 @@ func TestChainSvrCmds(t *testing.T) {
 				TxType:  exccjson.String("all"),
 			},
 		},
+		{
+			name: "getrawmempool sorted",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("getrawmempool", true, "regular", "feerate", 10, 20)
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewGetRawMempoolCmd(exccjson.Bool(true),
+					exccjson.String("regular"), exccjson.String("feerate"),
+					exccjson.Uint32(10), exccjson.Uint32(20))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getrawmempool","params":[true,"regular","feerate",10,20],"id":1}`,
+			unmarshalled: &exccjson.GetRawMempoolCmd{
+				Verbose: exccjson.Bool(true),
+				TxType:  exccjson.String("regular"),
+				SortBy:  exccjson.String("feerate"),
+				Skip:    exccjson.Uint32(10),
+				Count:   exccjson.Uint32(20),
+			},
+		},
 		{
 			name: "getrawtransaction",
 			newCmd: func() (interface{}, error) {
@@ -591,6 +657,20 @@ func TestChainSvrCmds(t *testing.T) {
 				Verbose: exccjson.Int(1),
 			},
 		},
+		{
+			name: "getrawtransaction prevouts",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("getrawtransaction", "123", 2)
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewGetRawTransactionCmd("123", exccjson.Int(2))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getrawtransaction","params":["123",2],"id":1}`,
+			unmarshalled: &exccjson.GetRawTransactionCmd{
+				Txid:    "123",
+				Verbose: exccjson.Int(2),
+			},
+		},
 		{
 			name: "gettxout",
 			newCmd: func() (interface{}, error) {
@@ -842,12 +922,13 @@ func TestChainSvrCmds(t *testing.T) {
 				return exccjson.NewCmd("sendrawtransaction", "1122")
 			},
 			staticCmd: func() interface{} {
-				return exccjson.NewSendRawTransactionCmd("1122", nil)
+				return exccjson.NewSendRawTransactionCmd("1122", nil, nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"sendrawtransaction","params":["1122"],"id":1}`,
 			unmarshalled: &exccjson.SendRawTransactionCmd{
 				HexTx:         "1122",
 				AllowHighFees: exccjson.Bool(false),
+				MaxFeeRate:    exccjson.Float64(0.1),
 			},
 		},
 		{
@@ -856,12 +937,64 @@ func TestChainSvrCmds(t *testing.T) {
 				return exccjson.NewCmd("sendrawtransaction", "1122", false)
 			},
 			staticCmd: func() interface{} {
-				return exccjson.NewSendRawTransactionCmd("1122", exccjson.Bool(false))
+				return exccjson.NewSendRawTransactionCmd("1122", exccjson.Bool(false), nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"sendrawtransaction","params":["1122",false],"id":1}`,
 			unmarshalled: &exccjson.SendRawTransactionCmd{
 				HexTx:         "1122",
 				AllowHighFees: exccjson.Bool(false),
+				MaxFeeRate:    exccjson.Float64(0.1),
+			},
+		},
+		{
+			name: "sendrawtransaction maxfeerate",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("sendrawtransaction", "1122", false, 0.5)
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewSendRawTransactionCmd("1122", exccjson.Bool(false), exccjson.Float64(0.5))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"sendrawtransaction","params":["1122",false,0.5],"id":1}`,
+			unmarshalled: &exccjson.SendRawTransactionCmd{
+				HexTx:         "1122",
+				AllowHighFees: exccjson.Bool(false),
+				MaxFeeRate:    exccjson.Float64(0.5),
+			},
+		},
+		{
+			name: "sendrawtransactionandwait",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("sendrawtransactionandwait", "1122")
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewSendRawTransactionAndWaitCmd("1122", nil, nil, nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"sendrawtransactionandwait","params":["1122"],"id":1}`,
+			unmarshalled: &exccjson.SendRawTransactionAndWaitCmd{
+				HexTx:         "1122",
+				Confirmations: exccjson.Uint32(1),
+				Timeout:       exccjson.Uint32(600),
+				AllowHighFees: exccjson.Bool(false),
+				MaxFeeRate:    exccjson.Float64(0.1),
+			},
+		},
+		{
+			name: "sendrawtransactionandwait optional",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("sendrawtransactionandwait", "1122", 6, 60, true, 0.5)
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewSendRawTransactionAndWaitCmd("1122",
+					exccjson.Uint32(6), exccjson.Uint32(60),
+					exccjson.Bool(true), exccjson.Float64(0.5))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"sendrawtransactionandwait","params":["1122",6,60,true,0.5],"id":1}`,
+			unmarshalled: &exccjson.SendRawTransactionAndWaitCmd{
+				HexTx:         "1122",
+				Confirmations: exccjson.Uint32(6),
+				Timeout:       exccjson.Uint32(60),
+				AllowHighFees: exccjson.Bool(true),
+				MaxFeeRate:    exccjson.Float64(0.5),
 			},
 		},
 		{
@@ -894,6 +1027,57 @@ func TestChainSvrCmds(t *testing.T) {
 				MiningAddr:   exccjson.String("22tv7nd31sMmD8BpcVRJAWQLqYCjaCuqpWpz"),
 			},
 		},
+		{
+			name: "signrawtransactionwithkey",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("signrawtransactionwithkey", "001122", []string{"abc"})
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewSignRawTransactionWithKeyCmd("001122", []string{"abc"}, nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"signrawtransactionwithkey","params":["001122",["abc"]],"id":1}`,
+			unmarshalled: &exccjson.SignRawTransactionWithKeyCmd{
+				RawTx:       "001122",
+				PrivKeys:    []string{"abc"},
+				Inputs:      nil,
+				SigHashType: exccjson.String("ALL"),
+			},
+		},
+		{
+			name: "signrawtransactionwithkey optional",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("signrawtransactionwithkey", "001122", []string{"abc"},
+					`[{"txid":"123","vout":1,"tree":0,"scriptPubKey":"00","redeemScript":"01"}]`,
+					"ALL|ANYONECANPAY")
+			},
+			staticCmd: func() interface{} {
+				txInputs := []exccjson.RawTxInput{
+					{
+						Txid:         "123",
+						Vout:         1,
+						ScriptPubKey: "00",
+						RedeemScript: "01",
+					},
+				}
+
+				return exccjson.NewSignRawTransactionWithKeyCmd("001122", []string{"abc"},
+					&txInputs, exccjson.String("ALL|ANYONECANPAY"))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"signrawtransactionwithkey","params":["001122",["abc"],[{"txid":"123","vout":1,"tree":0,"scriptPubKey":"00","redeemScript":"01"}],"ALL|ANYONECANPAY"],"id":1}`,
+			unmarshalled: &exccjson.SignRawTransactionWithKeyCmd{
+				RawTx:    "001122",
+				PrivKeys: []string{"abc"},
+				Inputs: &[]exccjson.RawTxInput{
+					{
+						Txid:         "123",
+						Vout:         1,
+						ScriptPubKey: "00",
+						RedeemScript: "01",
+					},
+				},
+				SigHashType: exccjson.String("ALL|ANYONECANPAY"),
+			},
+		},
 		{
 			name: "stop",
 			newCmd: func() (interface{}, error) {
@@ -938,19 +1122,45 @@ func TestChainSvrCmds(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "uptime",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("uptime")
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewUptimeCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"uptime","params":[],"id":1}`,
+			unmarshalled: &exccjson.UptimeCmd{},
+		},
 		{
 			name: "validateaddress",
 			newCmd: func() (interface{}, error) {
 				return exccjson.NewCmd("validateaddress", "1Address")
 			},
 			staticCmd: func() interface{} {
-				return exccjson.NewValidateAddressCmd("1Address")
+				return exccjson.NewValidateAddressCmd("1Address", nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"validateaddress","params":["1Address"],"id":1}`,
 			unmarshalled: &exccjson.ValidateAddressCmd{
 				Address: "1Address",
 			},
 		},
+		{
+			name: "validateaddress optional",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("validateaddress", "1Address", "5121")
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewValidateAddressCmd("1Address",
+					exccjson.String("5121"))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"validateaddress","params":["1Address","5121"],"id":1}`,
+			unmarshalled: &exccjson.ValidateAddressCmd{
+				Address:      "1Address",
+				RedeemScript: exccjson.String("5121"),
+			},
+		},
 		{
 			name: "verifychain",
 			newCmd: func() (interface{}, error) {
@@ -76,13 +76,30 @@ type CreateMultiSigResult struct {
 	RedeemScript string `json:"redeemScript"`
 }
 
-// DecodeScriptResult models the data returned from the decodescript command.
-type DecodeScriptResult struct {
+// DecodeScriptRedeemResult models the data of the redeem script of a
+// pay-to-script-hash signature script returned from the decodescript command.
+type DecodeScriptRedeemResult struct {
+	Hex       string   `json:"hex"`
 	Asm       string   `json:"asm"`
 	ReqSigs   int32    `json:"reqSigs,omitempty"`
+	NumKeys   int32    `json:"numKeys,omitempty"`
 	Type      string   `json:"type"`
+	SubType   string   `json:"subType,omitempty"`
 	Addresses []string `json:"addresses,omitempty"`
-	P2sh      string   `json:"p2sh,omitempty"`
+	P2sh      string   `json:"p2sh"`
+}
+
+// DecodeScriptResult models the data returned from the decodescript command.
+type DecodeScriptResult struct {
+	Asm          string                    `json:"asm"`
+	ReqSigs      int32                     `json:"reqSigs,omitempty"`
+	NumKeys      int32                     `json:"numKeys,omitempty"`
+	Type         string                    `json:"type"`
+	SubType      string                    `json:"subType,omitempty"`
+	Addresses    []string                  `json:"addresses,omitempty"`
+	Data         string                    `json:"data,omitempty"`
+	P2sh         string                    `json:"p2sh,omitempty"`
+	RedeemScript *DecodeScriptRedeemResult `json:"redeemScript,omitempty"`
 }
 
 // GetAddedNodeInfoResultAddr models the data of the addresses portion of the
@@ -201,6 +218,17 @@ type GetNetworkInfoResult struct {
 	LocalAddresses  []LocalAddressesResult `json:"localaddresses"`
 }
 
+// GetNetworkSolPSResult models the data returned from the getnetworksolps
+// command.
+type GetNetworkSolPSResult struct {
+	SolPS       float64 `json:"solps"`
+	StartHeight int64   `json:"startheight"`
+	EndHeight   int64   `json:"endheight"`
+	TimeSpan    int64   `json:"timespan"`
+	EquihashN   int     `json:"equihashn"`
+	EquihashK   int     `json:"equihashk"`
+}
+
 // GetPeerInfoResult models the data returned from the getpeerinfo command.
 type GetPeerInfoResult struct {
 	ID             int32   `json:"id"`
@@ -223,12 +251,14 @@ type GetPeerInfoResult struct {
 	CurrentHeight  int64   `json:"currentheight,omitempty"`
 	BanScore       int32   `json:"banscore"`
 	SyncNode       bool    `json:"syncnode"`
+	SyncOnly       bool    `json:"synconly,omitempty"`
 }
 
 // GetRawMempoolVerboseResult models the data returned from the getrawmempool
 // command when the verbose flag is set.  When the verbose flag is not set,
 // getrawmempool returns an array of transaction hashes.
 type GetRawMempoolVerboseResult struct {
+	TxID             string   `json:"txid,omitempty"`
 	Size             int32    `json:"size"`
 	Fee              float64  `json:"fee"`
 	Time             int64    `json:"time"`
@@ -259,11 +289,56 @@ type GetTxOutResult struct {
 	Coinbase      bool               `json:"coinbase"`
 }
 
+// GetTxOutSetInfoResult models the data from the gettxoutsetinfo command.
+type GetTxOutSetInfoResult struct {
+	Height       int64  `json:"height"`
+	BestBlock    string `json:"bestblock"`
+	Transactions uint64 `json:"transactions"`
+	TxOuts       uint64 `json:"txouts"`
+	UtxoSetHash  string `json:"utxosethash"`
+	DiskSize     uint64 `json:"disksize"`
+	TotalAmount  int64  `json:"totalamount"`
+}
+
+// NetTotalsMsg models the bandwidth used by the messages with a command as
+// part of the getnettotals command result.
+type NetTotalsMsg struct {
+	Command   string `json:"command"`
+	BytesRecv uint64 `json:"bytesrecv"`
+	BytesSent uint64 `json:"bytessent"`
+}
+
+// NetTotalsPeriod models the bandwidth used during a day or week as part of
+// the getnettotals command result.
+type NetTotalsPeriod struct {
+	Start     int64  `json:"start"`
+	BytesRecv uint64 `json:"bytesrecv"`
+	BytesSent uint64 `json:"bytessent"`
+}
+
+// NetUploadTarget models the state of the upload target as part of the
+// getnettotals command result.
+type NetUploadTarget struct {
+	TimeFrame             int64  `json:"timeframe"`
+	Target                uint64 `json:"target"`
+	TargetReached         bool   `json:"targetreached"`
+	ServeHistoricalBlocks bool   `json:"servehistoricalblocks"`
+	BytesLeftInCycle      uint64 `json:"bytesleftincycle"`
+	TimeLeftInCycle       int64  `json:"timeleftincycle"`
+}
+
 // GetNetTotalsResult models the data returned from the getnettotals command.
 type GetNetTotalsResult struct {
-	TotalBytesRecv uint64 `json:"totalbytesrecv"`
-	TotalBytesSent uint64 `json:"totalbytessent"`
-	TimeMillis     int64  `json:"timemillis"`
+	TotalBytesRecv     uint64            `json:"totalbytesrecv"`
+	TotalBytesSent     uint64            `json:"totalbytessent"`
+	TimeMillis         int64             `json:"timemillis"`
+	AccountingStart    int64             `json:"accountingstart"`
+	AccountedBytesRecv uint64            `json:"accountedbytesrecv"`
+	AccountedBytesSent uint64            `json:"accountedbytessent"`
+	Messages           []NetTotalsMsg    `json:"messages"`
+	Daily              []NetTotalsPeriod `json:"daily"`
+	Weekly             []NetTotalsPeriod `json:"weekly"`
+	UploadTarget       NetUploadTarget   `json:"uploadtarget"`
 }
 
 // ScriptSig models a signature script.  It is defined separately since it only
@@ -288,6 +363,7 @@ type Vin struct {
 	BlockHeight uint32     `json:"blockheight"`
 	BlockIndex  uint32     `json:"blockindex"`
 	ScriptSig   *ScriptSig `json:"scriptSig"`
+	PrevOut     *PrevOut   `json:"prevOut,omitempty"`
 }
 
 // IsCoinBase returns a bool to show if a Vin is a Coinbase one or not.
@@ -345,6 +421,7 @@ func (v *Vin) MarshalJSON() ([]byte, error) {
 		BlockHeight uint32     `json:"blockheight"`
 		BlockIndex  uint32     `json:"blockindex"`
 		ScriptSig   *ScriptSig `json:"scriptSig"`
+		PrevOut     *PrevOut   `json:"prevOut,omitempty"`
 	}{
 		Txid:        v.Txid,
 		Vout:        v.Vout,
@@ -354,14 +431,16 @@ func (v *Vin) MarshalJSON() ([]byte, error) {
 		BlockHeight: v.BlockHeight,
 		BlockIndex:  v.BlockIndex,
 		ScriptSig:   v.ScriptSig,
+		PrevOut:     v.PrevOut,
 	}
 	return json.Marshal(txStruct)
 }
 
 // PrevOut represents previous output for an input Vin.
 type PrevOut struct {
-	Addresses []string `json:"addresses,omitempty"`
-	Value     float64  `json:"value"`
+	Addresses    []string            `json:"addresses,omitempty"`
+	Value        float64             `json:"value"`
+	ScriptPubKey *ScriptPubKeyResult `json:"scriptPubKey,omitempty"`
 }
 
 // VinPrevOut is like Vin except it includes PrevOut.  It is used by searchrawtransaction
@@ -465,6 +544,8 @@ type GetMiningInfoResult struct {
 	NetworkHashPS    int64   `json:"networkhashps"`
 	PooledTx         uint64  `json:"pooledtx"`
 	TestNet          bool    `json:"testnet"`
+	TimeRolls        uint64  `json:"timerolls"`
+	TemplateRebuilds uint64  `json:"templaterebuilds"`
 }
 
 // GetWorkResult models the data from the getwork command.
@@ -485,6 +566,12 @@ type InfoChainResult struct {
 	TestNet         bool    `json:"testnet"`
 	RelayFee        float64 `json:"relayfee"`
 	Errors          string  `json:"errors"`
+
+	// Commit, GoVersion, and Features identify the build of the node and
+	// the optional features enabled in its configuration.
+	Commit    string   `json:"commit,omitempty"`
+	GoVersion string   `json:"goversion,omitempty"`
+	Features  []string `json:"features,omitempty"`
 }
 
 // LocalAddressesResult models the localaddresses data from the getnetworkinfo
@@ -535,6 +622,15 @@ type SearchRawTransactionsResult struct {
 	Blocktime     int64        `json:"blocktime,omitempty"`
 }
 
+// SendRawTransactionAndWaitResult models the data from the
+// sendrawtransactionandwait command.
+type SendRawTransactionAndWaitResult struct {
+	TxID          string `json:"txid"`
+	BlockHash     string `json:"blockhash,omitempty"`
+	BlockHeight   int64  `json:"blockheight,omitempty"`
+	Confirmations int64  `json:"confirmations"`
+}
+
 // TxRawDecodeResult models the data from the decoderawtransaction command.
 type TxRawDecodeResult struct {
 	Txid     string `json:"txid"`
@@ -548,8 +644,15 @@ type TxRawDecodeResult struct {
 // ValidateAddressChainResult models the data returned by the chain server
 // validateaddress command.
 type ValidateAddressChainResult struct {
-	IsValid bool   `json:"isvalid"`
-	Address string `json:"address,omitempty"`
+	IsValid      bool     `json:"isvalid"`
+	Address      string   `json:"address,omitempty"`
+	Network      string   `json:"network,omitempty"`
+	ScriptType   string   `json:"scripttype,omitempty"`
+	IsScript     bool     `json:"isscript,omitempty"`
+	IsCompressed bool     `json:"iscompressed,omitempty"`
+	SigsRequired int32    `json:"sigsrequired,omitempty"`
+	Script       string   `json:"script,omitempty"`
+	Addresses    []string `json:"addresses,omitempty"`
 }
 
 // GetHeadersResult models the data returned by the chain server getheaders
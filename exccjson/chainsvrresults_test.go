@@ -46,6 +46,28 @@ func TestChainSvrCustomResults(t *testing.T) {
 			},
 			expected: `{"txid":"123","vout":1,"tree":0,"sequence":4294967295,"amountin":0,"blockheight":0,"blockindex":0,"scriptSig":{"asm":"0","hex":"00"}}`,
 		},
+		{
+			name: "custom vin marshal with prevout",
+			result: &exccjson.Vin{
+				Txid: "123",
+				Vout: 1,
+				Tree: 0,
+				ScriptSig: &exccjson.ScriptSig{
+					Asm: "0",
+					Hex: "00",
+				},
+				Sequence: 4294967295,
+				PrevOut: &exccjson.PrevOut{
+					Value: 1,
+					ScriptPubKey: &exccjson.ScriptPubKeyResult{
+						Asm:  "OP_TRUE",
+						Hex:  "51",
+						Type: "nonstandard",
+					},
+				},
+			},
+			expected: `{"txid":"123","vout":1,"tree":0,"sequence":4294967295,"amountin":0,"blockheight":0,"blockindex":0,"scriptSig":{"asm":"0","hex":"00"},"prevOut":{"value":1,"scriptPubKey":{"asm":"OP_TRUE","hex":"51","type":"nonstandard"}}}`,
+		},
 		{
 			name: "custom vinprevout marshal with coinbase",
 			result: &exccjson.VinPrevOut{
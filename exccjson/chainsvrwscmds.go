@@ -103,6 +103,28 @@ func NewNotifyStakeDifficultyCmd() *NotifyStakeDifficultyCmd {
 	return &NotifyStakeDifficultyCmd{}
 }
 
+// NotifySyncProgressCmd defines the notifysyncprogress JSON-RPC command.
+type NotifySyncProgressCmd struct{}
+
+// NewNotifySyncProgressCmd returns a new instance which can be used to issue a
+// notifysyncprogress JSON-RPC command.
+func NewNotifySyncProgressCmd() *NotifySyncProgressCmd {
+	return &NotifySyncProgressCmd{}
+}
+
+// NotifyTicketStatusCmd defines the notifyticketstatus JSON-RPC command.
+type NotifyTicketStatusCmd struct {
+	TxHashes []string
+}
+
+// NewNotifyTicketStatusCmd returns a new instance which can be used to issue a
+// notifyticketstatus JSON-RPC command.
+func NewNotifyTicketStatusCmd(txHashes []string) *NotifyTicketStatusCmd {
+	return &NotifyTicketStatusCmd{
+		TxHashes: txHashes,
+	}
+}
+
 // StopNotifyBlocksCmd defines the stopnotifyblocks JSON-RPC command.
 type StopNotifyBlocksCmd struct{}
 
@@ -112,6 +134,53 @@ func NewStopNotifyBlocksCmd() *StopNotifyBlocksCmd {
 	return &StopNotifyBlocksCmd{}
 }
 
+// StopNotifySpentAndMissedTicketsCmd defines the
+// stopnotifyspentandmissedtickets JSON-RPC command.
+type StopNotifySpentAndMissedTicketsCmd struct{}
+
+// NewStopNotifySpentAndMissedTicketsCmd returns a new instance which can be
+// used to issue a stopnotifyspentandmissedtickets JSON-RPC command.
+func NewStopNotifySpentAndMissedTicketsCmd() *StopNotifySpentAndMissedTicketsCmd {
+	return &StopNotifySpentAndMissedTicketsCmd{}
+}
+
+// StopNotifySyncProgressCmd defines the stopnotifysyncprogress JSON-RPC
+// command.
+type StopNotifySyncProgressCmd struct{}
+
+// NewStopNotifySyncProgressCmd returns a new instance which can be used to
+// issue a stopnotifysyncprogress JSON-RPC command.
+func NewStopNotifySyncProgressCmd() *StopNotifySyncProgressCmd {
+	return &StopNotifySyncProgressCmd{}
+}
+
+// StopNotifyTicketStatusCmd defines the stopnotifyticketstatus JSON-RPC
+// command.
+type StopNotifyTicketStatusCmd struct {
+	TxHashes *[]string
+}
+
+// NewStopNotifyTicketStatusCmd returns a new instance which can be used to
+// issue a stopnotifyticketstatus JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewStopNotifyTicketStatusCmd(txHashes *[]string) *StopNotifyTicketStatusCmd {
+	return &StopNotifyTicketStatusCmd{
+		TxHashes: txHashes,
+	}
+}
+
+// StopNotifyWinningTicketsCmd defines the stopnotifywinningtickets JSON-RPC
+// command.
+type StopNotifyWinningTicketsCmd struct{}
+
+// NewStopNotifyWinningTicketsCmd returns a new instance which can be used to
+// issue a stopnotifywinningtickets JSON-RPC command.
+func NewStopNotifyWinningTicketsCmd() *StopNotifyWinningTicketsCmd {
+	return &StopNotifyWinningTicketsCmd{}
+}
+
 // NotifyNewTransactionsCmd defines the notifynewtransactions JSON-RPC command.
 type NotifyNewTransactionsCmd struct {
 	Verbose *bool `jsonrpcdefault:"false"`
@@ -162,6 +231,28 @@ func NewRescanCmd(blockHashes string) *RescanCmd {
 	return &RescanCmd{BlockHashes: blockHashes}
 }
 
+// RescanRangeCmd defines the rescanrange JSON-RPC command.
+type RescanRangeCmd struct {
+	Addresses   []string
+	OutPoints   []OutPoint
+	StartHeight int64
+	EndHeight   *int64
+}
+
+// NewRescanRangeCmd returns a new instance which can be used to issue a
+// rescanrange JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewRescanRangeCmd(addresses []string, outPoints []OutPoint, startHeight int64, endHeight *int64) *RescanRangeCmd {
+	return &RescanRangeCmd{
+		Addresses:   addresses,
+		OutPoints:   outPoints,
+		StartHeight: startHeight,
+		EndHeight:   endHeight,
+	}
+}
+
 func init() {
 	// The commands in this file are only usable by websockets.
 	flags := UFWebsocketOnly
@@ -175,10 +266,21 @@ func init() {
 		(*NotifySpentAndMissedTicketsCmd)(nil), flags)
 	MustRegisterCmd("notifystakedifficulty",
 		(*NotifyStakeDifficultyCmd)(nil), flags)
+	MustRegisterCmd("notifysyncprogress", (*NotifySyncProgressCmd)(nil), flags)
+	MustRegisterCmd("notifyticketstatus", (*NotifyTicketStatusCmd)(nil), flags)
 	MustRegisterCmd("notifywinningtickets",
 		(*NotifyWinningTicketsCmd)(nil), flags)
 	MustRegisterCmd("session", (*SessionCmd)(nil), flags)
 	MustRegisterCmd("stopnotifyblocks", (*StopNotifyBlocksCmd)(nil), flags)
 	MustRegisterCmd("stopnotifynewtransactions", (*StopNotifyNewTransactionsCmd)(nil), flags)
+	MustRegisterCmd("stopnotifyspentandmissedtickets",
+		(*StopNotifySpentAndMissedTicketsCmd)(nil), flags)
+	MustRegisterCmd("stopnotifysyncprogress",
+		(*StopNotifySyncProgressCmd)(nil), flags)
+	MustRegisterCmd("stopnotifyticketstatus",
+		(*StopNotifyTicketStatusCmd)(nil), flags)
+	MustRegisterCmd("stopnotifywinningtickets",
+		(*StopNotifyWinningTicketsCmd)(nil), flags)
 	MustRegisterCmd("rescan", (*RescanCmd)(nil), flags)
+	MustRegisterCmd("rescanrange", (*RescanRangeCmd)(nil), flags)
 }
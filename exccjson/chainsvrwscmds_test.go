@@ -53,6 +53,30 @@ func TestChainSvrWsCmds(t *testing.T) {
 			marshalled:   `{"jsonrpc":"1.0","method":"notifywinningtickets","params":[],"id":1}`,
 			unmarshalled: &exccjson.NotifyWinningTicketsCmd{},
 		},
+		{
+			name: "notifysyncprogress",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("notifysyncprogress")
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewNotifySyncProgressCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"notifysyncprogress","params":[],"id":1}`,
+			unmarshalled: &exccjson.NotifySyncProgressCmd{},
+		},
+		{
+			name: "notifyticketstatus",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("notifyticketstatus", []string{"deadbeef"})
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewNotifyTicketStatusCmd([]string{"deadbeef"})
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"notifyticketstatus","params":[["deadbeef"]],"id":1}`,
+			unmarshalled: &exccjson.NotifyTicketStatusCmd{
+				TxHashes: []string{"deadbeef"},
+			},
+		},
 		{
 			name: "notifyspentandmissedtickets",
 			newCmd: func() (interface{}, error) {
@@ -108,6 +132,66 @@ func TestChainSvrWsCmds(t *testing.T) {
 			marshalled:   `{"jsonrpc":"1.0","method":"stopnotifyblocks","params":[],"id":1}`,
 			unmarshalled: &exccjson.StopNotifyBlocksCmd{},
 		},
+		{
+			name: "stopnotifyspentandmissedtickets",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("stopnotifyspentandmissedtickets")
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewStopNotifySpentAndMissedTicketsCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"stopnotifyspentandmissedtickets","params":[],"id":1}`,
+			unmarshalled: &exccjson.StopNotifySpentAndMissedTicketsCmd{},
+		},
+		{
+			name: "stopnotifysyncprogress",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("stopnotifysyncprogress")
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewStopNotifySyncProgressCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"stopnotifysyncprogress","params":[],"id":1}`,
+			unmarshalled: &exccjson.StopNotifySyncProgressCmd{},
+		},
+		{
+			name: "stopnotifyticketstatus",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("stopnotifyticketstatus")
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewStopNotifyTicketStatusCmd(nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"stopnotifyticketstatus","params":[],"id":1}`,
+			unmarshalled: &exccjson.StopNotifyTicketStatusCmd{
+				TxHashes: nil,
+			},
+		},
+		{
+			name: "stopnotifyticketstatus optional",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("stopnotifyticketstatus", []string{"deadbeef"})
+			},
+			staticCmd: func() interface{} {
+				hashes := []string{"deadbeef"}
+				return exccjson.NewStopNotifyTicketStatusCmd(&hashes)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"stopnotifyticketstatus","params":[["deadbeef"]],"id":1}`,
+			unmarshalled: &exccjson.StopNotifyTicketStatusCmd{
+				TxHashes: &[]string{"deadbeef"},
+			},
+		},
+		{
+			name: "stopnotifywinningtickets",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("stopnotifywinningtickets")
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewStopNotifyWinningTicketsCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"stopnotifywinningtickets","params":[],"id":1}`,
+			unmarshalled: &exccjson.StopNotifyWinningTicketsCmd{},
+		},
 		{
 			name: "notifynewtransactions",
 			newCmd: func() (interface{}, error) {
@@ -158,6 +242,41 @@ func TestChainSvrWsCmds(t *testing.T) {
 				BlockHashes: "0000000000000000000000000000000000000000000000000000000000000123",
 			},
 		},
+		{
+			name: "rescanrange",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("rescanrange", `["1Address"]`,
+					`[{"hash":"123","tree":0,"index":1}]`, 100)
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewRescanRangeCmd([]string{"1Address"},
+					[]exccjson.OutPoint{{Hash: "123", Index: 1}}, 100, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"rescanrange","params":[["1Address"],[{"hash":"123","tree":0,"index":1}],100],"id":1}`,
+			unmarshalled: &exccjson.RescanRangeCmd{
+				Addresses:   []string{"1Address"},
+				OutPoints:   []exccjson.OutPoint{{Hash: "123", Index: 1}},
+				StartHeight: 100,
+			},
+		},
+		{
+			name: "rescanrange optional",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("rescanrange", `["1Address"]`,
+					`[]`, 100, 200)
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewRescanRangeCmd([]string{"1Address"},
+					[]exccjson.OutPoint{}, 100, exccjson.Int64(200))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"rescanrange","params":[["1Address"],[],100,200],"id":1}`,
+			unmarshalled: &exccjson.RescanRangeCmd{
+				Addresses:   []string{"1Address"},
+				OutPoints:   []exccjson.OutPoint{},
+				StartHeight: 100,
+				EndHeight:   exccjson.Int64(200),
+			},
+		},
 	}
 
 	t.Logf("Running %d tests", len(tests))
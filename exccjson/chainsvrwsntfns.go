@@ -36,6 +36,29 @@ const (
 	// from the chain server that inform a client that a relevant
 	// transaction was accepted by the mempool.
 	RelevantTxAcceptedNtfnMethod = "relevanttxaccepted"
+
+	// TicketStatusChangedNtfnMethod is the method used for notifications
+	// from the chain server that the status of one or more watched tickets
+	// changed.
+	TicketStatusChangedNtfnMethod = "ticketstatuschanged"
+
+	// SyncProgressNtfnMethod is the method used for notifications from the
+	// chain server about the progress of the chain sync.
+	SyncProgressNtfnMethod = "syncprogress"
+
+	// RescannedBlockNtfnMethod is the method used for notifications from
+	// the chain server about a block with relevant transactions found by a
+	// rescanrange request.
+	RescannedBlockNtfnMethod = "rescannedblock"
+
+	// RescanProgressNtfnMethod is the method used for notifications from
+	// the chain server about the progress of a rescanrange request.
+	RescanProgressNtfnMethod = "rescanprogress"
+
+	// NotificationsDroppedNtfnMethod is the method used for notifications
+	// from the chain server that notifications were dropped because the
+	// client did not read them fast enough.
+	NotificationsDroppedNtfnMethod = "notificationsdropped"
 )
 
 // BlockConnectedNtfn defines the blockconnected JSON-RPC notification.
@@ -126,6 +149,85 @@ func NewRelevantTxAcceptedNtfn(txHex string) *RelevantTxAcceptedNtfn {
 	return &RelevantTxAcceptedNtfn{Transaction: txHex}
 }
 
+// TicketStatusChangedNtfn defines the ticketstatuschanged JSON-RPC
+// notification.
+type TicketStatusChangedNtfn struct {
+	Hash    string             `json:"hash"`
+	Height  int64              `json:"height"`
+	Tickets []TicketInfoResult `json:"tickets"`
+}
+
+// NewTicketStatusChangedNtfn returns a new instance which can be used to issue
+// a ticketstatuschanged JSON-RPC notification.
+func NewTicketStatusChangedNtfn(hash string, height int64, tickets []TicketInfoResult) *TicketStatusChangedNtfn {
+	return &TicketStatusChangedNtfn{
+		Hash:    hash,
+		Height:  height,
+		Tickets: tickets,
+	}
+}
+
+// SyncProgressNtfn defines the syncprogress JSON-RPC notification.
+type SyncProgressNtfn struct {
+	SyncInfo GetSyncInfoResult
+}
+
+// NewSyncProgressNtfn returns a new instance which can be used to issue a
+// syncprogress JSON-RPC notification.
+func NewSyncProgressNtfn(syncInfo GetSyncInfoResult) *SyncProgressNtfn {
+	return &SyncProgressNtfn{
+		SyncInfo: syncInfo,
+	}
+}
+
+// RescannedBlockNtfn defines the rescannedblock JSON-RPC notification.
+type RescannedBlockNtfn struct {
+	Hash         string   `json:"hash"`
+	Height       int64    `json:"height"`
+	Transactions []string `json:"transactions"`
+}
+
+// NewRescannedBlockNtfn returns a new instance which can be used to issue a
+// rescannedblock JSON-RPC notification.
+func NewRescannedBlockNtfn(hash string, height int64, transactions []string) *RescannedBlockNtfn {
+	return &RescannedBlockNtfn{
+		Hash:         hash,
+		Height:       height,
+		Transactions: transactions,
+	}
+}
+
+// RescanProgressNtfn defines the rescanprogress JSON-RPC notification.
+type RescanProgressNtfn struct {
+	Hash   string `json:"hash"`
+	Height int64  `json:"height"`
+	Time   int64  `json:"time"`
+}
+
+// NewRescanProgressNtfn returns a new instance which can be used to issue a
+// rescanprogress JSON-RPC notification.
+func NewRescanProgressNtfn(hash string, height int64, time int64) *RescanProgressNtfn {
+	return &RescanProgressNtfn{
+		Hash:   hash,
+		Height: height,
+		Time:   time,
+	}
+}
+
+// NotificationsDroppedNtfn defines the notificationsdropped JSON-RPC
+// notification.
+type NotificationsDroppedNtfn struct {
+	Count int64 `json:"count"`
+}
+
+// NewNotificationsDroppedNtfn returns a new instance which can be used to
+// issue a notificationsdropped JSON-RPC notification.
+func NewNotificationsDroppedNtfn(count int64) *NotificationsDroppedNtfn {
+	return &NotificationsDroppedNtfn{
+		Count: count,
+	}
+}
+
 func init() {
 	// The commands in this file are only usable by websockets and are
 	// notifications.
@@ -137,4 +239,9 @@ func init() {
 	MustRegisterCmd(TxAcceptedNtfnMethod, (*TxAcceptedNtfn)(nil), flags)
 	MustRegisterCmd(TxAcceptedVerboseNtfnMethod, (*TxAcceptedVerboseNtfn)(nil), flags)
 	MustRegisterCmd(RelevantTxAcceptedNtfnMethod, (*RelevantTxAcceptedNtfn)(nil), flags)
+	MustRegisterCmd(TicketStatusChangedNtfnMethod, (*TicketStatusChangedNtfn)(nil), flags)
+	MustRegisterCmd(SyncProgressNtfnMethod, (*SyncProgressNtfn)(nil), flags)
+	MustRegisterCmd(RescannedBlockNtfnMethod, (*RescannedBlockNtfn)(nil), flags)
+	MustRegisterCmd(RescanProgressNtfnMethod, (*RescanProgressNtfn)(nil), flags)
+	MustRegisterCmd(NotificationsDroppedNtfnMethod, (*NotificationsDroppedNtfn)(nil), flags)
 }
@@ -70,6 +70,107 @@ func TestChainSvrWsNtfns(t *testing.T) {
 				Transaction: "001122",
 			},
 		},
+		{
+			name: "rescannedblock",
+			newNtfn: func() (interface{}, error) {
+				return exccjson.NewCmd("rescannedblock", "123", 100,
+					`["001122"]`)
+			},
+			staticNtfn: func() interface{} {
+				return exccjson.NewRescannedBlockNtfn("123", 100,
+					[]string{"001122"})
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"rescannedblock","params":["123",100,["001122"]],"id":null}`,
+			unmarshalled: &exccjson.RescannedBlockNtfn{
+				Hash:         "123",
+				Height:       100,
+				Transactions: []string{"001122"},
+			},
+		},
+		{
+			name: "rescanprogress",
+			newNtfn: func() (interface{}, error) {
+				return exccjson.NewCmd("rescanprogress", "123", 100,
+					1500000000)
+			},
+			staticNtfn: func() interface{} {
+				return exccjson.NewRescanProgressNtfn("123", 100,
+					1500000000)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"rescanprogress","params":["123",100,1500000000],"id":null}`,
+			unmarshalled: &exccjson.RescanProgressNtfn{
+				Hash:   "123",
+				Height: 100,
+				Time:   1500000000,
+			},
+		},
+		{
+			name: "notificationsdropped",
+			newNtfn: func() (interface{}, error) {
+				return exccjson.NewCmd("notificationsdropped", 25)
+			},
+			staticNtfn: func() interface{} {
+				return exccjson.NewNotificationsDroppedNtfn(25)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"notificationsdropped","params":[25],"id":null}`,
+			unmarshalled: &exccjson.NotificationsDroppedNtfn{
+				Count: 25,
+			},
+		},
+		{
+			name: "syncprogress",
+			newNtfn: func() (interface{}, error) {
+				return exccjson.NewCmd("syncprogress",
+					`{"synced":false,"blocks":50,"headers":200,"progress":25,"blockspersecond":2.5,"eta":60,"syncpeer":"127.0.0.1:9666","syncpeerheight":200,"bestblockhash":"123","bestblocktime":1500000000}`)
+			},
+			staticNtfn: func() interface{} {
+				return exccjson.NewSyncProgressNtfn(exccjson.GetSyncInfoResult{
+					Blocks:          50,
+					Headers:         200,
+					Progress:        25,
+					BlocksPerSecond: 2.5,
+					ETA:             60,
+					SyncPeer:        "127.0.0.1:9666",
+					SyncPeerHeight:  200,
+					BestBlockHash:   "123",
+					BestBlockTime:   1500000000,
+				})
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"syncprogress","params":[{"synced":false,"blocks":50,"headers":200,"progress":25,"blockspersecond":2.5,"eta":60,"syncpeer":"127.0.0.1:9666","syncpeerheight":200,"bestblockhash":"123","bestblocktime":1500000000}],"id":null}`,
+			unmarshalled: &exccjson.SyncProgressNtfn{
+				SyncInfo: exccjson.GetSyncInfoResult{
+					Blocks:          50,
+					Headers:         200,
+					Progress:        25,
+					BlocksPerSecond: 2.5,
+					ETA:             60,
+					SyncPeer:        "127.0.0.1:9666",
+					SyncPeerHeight:  200,
+					BestBlockHash:   "123",
+					BestBlockTime:   1500000000,
+				},
+			},
+		},
+		{
+			name: "ticketstatuschanged",
+			newNtfn: func() (interface{}, error) {
+				return exccjson.NewCmd("ticketstatuschanged", "123", 100,
+					`[{"hash":"456","status":"live","purchaseheight":50,"liveheight":66}]`)
+			},
+			staticNtfn: func() interface{} {
+				return exccjson.NewTicketStatusChangedNtfn("123", 100,
+					[]exccjson.TicketInfoResult{{Hash: "456",
+						Status: "live", PurchaseHeight: 50,
+						LiveHeight: 66}})
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"ticketstatuschanged","params":["123",100,[{"hash":"456","status":"live","purchaseheight":50,"liveheight":66}]],"id":null}`,
+			unmarshalled: &exccjson.TicketStatusChangedNtfn{
+				Hash:   "123",
+				Height: 100,
+				Tickets: []exccjson.TicketInfoResult{{Hash: "456",
+					Status: "live", PurchaseHeight: 50, LiveHeight: 66}},
+			},
+		},
 		{
 			name: "txaccepted",
 			newNtfn: func() (interface{}, error) {
@@ -22,3 +22,12 @@ type RescannedBlock struct {
 	Hash         string   `json:"hash"`
 	Transactions []string `json:"transactions"`
 }
+
+// RescanRangeResult models the result object returned by the rescanrange RPC.
+type RescanRangeResult struct {
+	Hash          string `json:"hash"`
+	Height        int64  `json:"height"`
+	BlocksScanned int64  `json:"blocksscanned"`
+	BlocksFetched int64  `json:"blocksfetched"`
+	BlocksMatched int64  `json:"blocksmatched"`
+}
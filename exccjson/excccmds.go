@@ -8,6 +8,136 @@
 
 package exccjson
 
+// AnalyzeScriptCmd defines the analyzescript JSON-RPC command.
+type AnalyzeScriptCmd struct {
+	HexScript string
+	Version   *uint16 `jsonrpcdefault:"0"`
+}
+
+// NewAnalyzeScriptCmd returns a new instance which can be used to issue an
+// analyzescript JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewAnalyzeScriptCmd(hexScript string, version *uint16) *AnalyzeScriptCmd {
+	return &AnalyzeScriptCmd{
+		HexScript: hexScript,
+		Version:   version,
+	}
+}
+
+// DebugProfileKind defines the type used in the debugprofile JSON-RPC command
+// for the kind of diagnostics to capture.
+type DebugProfileKind string
+
+const (
+	// DPGoroutine indicates the stack traces of all goroutines should be
+	// captured.
+	DPGoroutine DebugProfileKind = "goroutine"
+
+	// DPHeap indicates a heap profile should be captured.
+	DPHeap DebugProfileKind = "heap"
+
+	// DPCPU indicates a CPU profile should be captured for the requested
+	// number of seconds.
+	DPCPU DebugProfileKind = "cpu"
+
+	// DPTrace indicates an execution trace, which shows the work done to
+	// validate blocks along with everything else the process does, should
+	// be captured for the requested number of seconds.
+	DPTrace DebugProfileKind = "trace"
+)
+
+// DebugProfileCmd defines the debugprofile JSON-RPC command.
+type DebugProfileCmd struct {
+	Kind    DebugProfileKind `jsonrpcusage:"\"goroutine|heap|cpu|trace\""`
+	Path    string
+	Seconds *uint32 `jsonrpcdefault:"30"`
+}
+
+// NewDebugProfileCmd returns a new instance which can be used to issue a
+// debugprofile JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewDebugProfileCmd(kind DebugProfileKind, path string, seconds *uint32) *DebugProfileCmd {
+	return &DebugProfileCmd{
+		Kind:    kind,
+		Path:    path,
+		Seconds: seconds,
+	}
+}
+
+// DebugScriptCmd defines the debugscript JSON-RPC command.
+type DebugScriptCmd struct {
+	HexTx         string
+	Index         uint32
+	PkScript      *string
+	ScriptVersion *uint16 `jsonrpcdefault:"0"`
+}
+
+// NewDebugScriptCmd returns a new instance which can be used to issue a
+// debugscript JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewDebugScriptCmd(hexTx string, index uint32, pkScript *string, scriptVersion *uint16) *DebugScriptCmd {
+	return &DebugScriptCmd{
+		HexTx:         hexTx,
+		Index:         index,
+		PkScript:      pkScript,
+		ScriptVersion: scriptVersion,
+	}
+}
+
+// DNSSeedSubCmd defines the type used in the dnsseed JSON-RPC command for the
+// sub command field.
+type DNSSeedSubCmd string
+
+const (
+	// DSAdd indicates the specified host should be added as a DNS seed.
+	DSAdd DNSSeedSubCmd = "add"
+
+	// DSRemove indicates the specified DNS seed should be removed.
+	DSRemove DNSSeedSubCmd = "remove"
+
+	// DSSeed indicates the specified DNS seed should be queried for peers.
+	DSSeed DNSSeedSubCmd = "seed"
+)
+
+// DNSSeedCmd defines the dnsseed JSON-RPC command.
+type DNSSeedCmd struct {
+	Host         string
+	SubCmd       DNSSeedSubCmd `jsonrpcusage:"\"add|remove|seed\""`
+	HasFiltering *bool         `jsonrpcdefault:"false"`
+}
+
+// NewDNSSeedCmd returns a new instance which can be used to issue a dnsseed
+// JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewDNSSeedCmd(host string, subCmd DNSSeedSubCmd, hasFiltering *bool) *DNSSeedCmd {
+	return &DNSSeedCmd{
+		Host:         host,
+		SubCmd:       subCmd,
+		HasFiltering: hasFiltering,
+	}
+}
+
+// DumpTxOutSetCmd defines the dumptxoutset JSON-RPC command.
+type DumpTxOutSetCmd struct {
+	Path string
+}
+
+// NewDumpTxOutSetCmd returns a new instance which can be used to issue a
+// dumptxoutset JSON-RPC command.
+func NewDumpTxOutSetCmd(path string) *DumpTxOutSetCmd {
+	return &DumpTxOutSetCmd{
+		Path: path,
+	}
+}
+
 // EstimateStakeDiffCmd defines the eststakedifficulty JSON-RPC command.
 type EstimateStakeDiffCmd struct {
 	Tickets *uint32
@@ -111,6 +241,73 @@ func NewExistsMempoolTxsCmd(txHashBlob string) *ExistsMempoolTxsCmd {
 	}
 }
 
+// FundRawTransactionOptions represents the optional options struct provided
+// with a FundRawTransactionCmd command.
+type FundRawTransactionOptions struct {
+	ChangeAddress *string  `json:"changeaddress,omitempty"`
+	FeeRate       *float64 `json:"feerate,omitempty"`
+	MinConf       *int32   `json:"minconf,omitempty"`
+}
+
+// FundRawTransactionCmd defines the fundrawtransaction JSON-RPC command.
+type FundRawTransactionCmd struct {
+	HexTx     string
+	Addresses []string
+	Options   *FundRawTransactionOptions
+}
+
+// NewFundRawTransactionCmd returns a new instance which can be used to issue a
+// fundrawtransaction JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewFundRawTransactionCmd(hexTx string, addresses []string, options *FundRawTransactionOptions) *FundRawTransactionCmd {
+	return &FundRawTransactionCmd{
+		HexTx:     hexTx,
+		Addresses: addresses,
+		Options:   options,
+	}
+}
+
+// GetAddressTicketsCmd defines the getaddresstickets JSON-RPC command.
+type GetAddressTicketsCmd struct {
+	Address string
+}
+
+// NewGetAddressTicketsCmd returns a new instance which can be used to issue a
+// getaddresstickets JSON-RPC command.
+func NewGetAddressTicketsCmd(address string) *GetAddressTicketsCmd {
+	return &GetAddressTicketsCmd{
+		Address: address,
+	}
+}
+
+// GetAgendasCmd defines the getagendas JSON-RPC command.
+type GetAgendasCmd struct{}
+
+// NewGetAgendasCmd returns a new instance which can be used to issue a
+// getagendas JSON-RPC command.
+func NewGetAgendasCmd() *GetAgendasCmd {
+	return &GetAgendasCmd{}
+}
+
+// GetBlockHashByTimeCmd defines the getblockhashbytime JSON-RPC command.
+type GetBlockHashByTimeCmd struct {
+	StartTime int64
+	EndTime   *int64
+	Count     *int32 `jsonrpcdefault:"100"`
+}
+
+// NewGetBlockHashByTimeCmd returns a new instance which can be used to issue a
+// getblockhashbytime JSON-RPC command.
+func NewGetBlockHashByTimeCmd(startTime int64, endTime *int64, count *int32) *GetBlockHashByTimeCmd {
+	return &GetBlockHashByTimeCmd{
+		StartTime: startTime,
+		EndTime:   endTime,
+		Count:     count,
+	}
+}
+
 // GetCoinSupplyCmd defines the getcoinsupply JSON-RPC command.
 type GetCoinSupplyCmd struct{}
 
@@ -120,6 +317,103 @@ func NewGetCoinSupplyCmd() *GetCoinSupplyCmd {
 	return &GetCoinSupplyCmd{}
 }
 
+// GetDifficultyHistoryCmd defines the getdifficultyhistory JSON-RPC command.
+// An EndHeight of -1 uses the height of the current best block.
+type GetDifficultyHistoryCmd struct {
+	StartHeight int64
+	EndHeight   *int64 `jsonrpcdefault:"-1"`
+}
+
+// NewGetDifficultyHistoryCmd returns a new instance which can be used to issue
+// a getdifficultyhistory JSON-RPC command.
+func NewGetDifficultyHistoryCmd(startHeight int64, endHeight *int64) *GetDifficultyHistoryCmd {
+	return &GetDifficultyHistoryCmd{
+		StartHeight: startHeight,
+		EndHeight:   endHeight,
+	}
+}
+
+// GetDNSSeedInfoCmd defines the getdnsseedinfo JSON-RPC command.
+type GetDNSSeedInfoCmd struct{}
+
+// NewGetDNSSeedInfoCmd returns a new instance which can be used to issue a
+// getdnsseedinfo JSON-RPC command.
+func NewGetDNSSeedInfoCmd() *GetDNSSeedInfoCmd {
+	return &GetDNSSeedInfoCmd{}
+}
+
+// GetEmissionScheduleCmd defines the getemissionschedule JSON-RPC command.
+type GetEmissionScheduleCmd struct {
+	Intervals *int32 `jsonrpcdefault:"12"`
+}
+
+// NewGetEmissionScheduleCmd returns a new instance which can be used to issue a
+// getemissionschedule JSON-RPC command.
+func NewGetEmissionScheduleCmd(intervals *int32) *GetEmissionScheduleCmd {
+	return &GetEmissionScheduleCmd{
+		Intervals: intervals,
+	}
+}
+
+// GetIndexInfoCmd defines the getindexinfo JSON-RPC command.
+type GetIndexInfoCmd struct {
+	IndexName *string
+}
+
+// NewGetIndexInfoCmd returns a new instance which can be used to issue a
+// getindexinfo JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewGetIndexInfoCmd(indexName *string) *GetIndexInfoCmd {
+	return &GetIndexInfoCmd{
+		IndexName: indexName,
+	}
+}
+
+// GetMiningArchiveCmd defines the getminingarchive JSON-RPC command.
+type GetMiningArchiveCmd struct {
+	Count *int32 `jsonrpcdefault:"100"`
+}
+
+// NewGetMiningArchiveCmd returns a new instance which can be used to issue a
+// getminingarchive JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewGetMiningArchiveCmd(count *int32) *GetMiningArchiveCmd {
+	return &GetMiningArchiveCmd{
+		Count: count,
+	}
+}
+
+// GetMinedBlockStatsCmd defines the getminedblockstats JSON-RPC command.
+type GetMinedBlockStatsCmd struct{}
+
+// NewGetMinedBlockStatsCmd returns a new instance which can be used to issue a
+// getminedblockstats JSON-RPC command.
+func NewGetMinedBlockStatsCmd() *GetMinedBlockStatsCmd {
+	return &GetMinedBlockStatsCmd{}
+}
+
+// GetNetworkStakeInfoCmd defines the getnetworkstakeinfo JSON-RPC command.
+type GetNetworkStakeInfoCmd struct{}
+
+// NewGetNetworkStakeInfoCmd returns a new instance which can be used to issue a
+// getnetworkstakeinfo JSON-RPC command.
+func NewGetNetworkStakeInfoCmd() *GetNetworkStakeInfoCmd {
+	return &GetNetworkStakeInfoCmd{}
+}
+
+// GetSigCacheInfoCmd defines the getsigcacheinfo JSON-RPC command.
+type GetSigCacheInfoCmd struct{}
+
+// NewGetSigCacheInfoCmd returns a new instance which can be used to issue a
+// getsigcacheinfo JSON-RPC command.
+func NewGetSigCacheInfoCmd() *GetSigCacheInfoCmd {
+	return &GetSigCacheInfoCmd{}
+}
+
 // GetStakeDifficultyCmd is a type handling custom marshaling and
 // unmarshaling of getstakedifficulty JSON RPC commands.
 type GetStakeDifficultyCmd struct{}
@@ -160,6 +454,58 @@ func NewGetStakeVersionsCmd(hash string, count int32) *GetStakeVersionsCmd {
 	}
 }
 
+// GetSyncInfoCmd defines the getsyncinfo JSON-RPC command.
+type GetSyncInfoCmd struct{}
+
+// NewGetSyncInfoCmd returns a new instance which can be used to issue a
+// getsyncinfo JSON-RPC command.
+func NewGetSyncInfoCmd() *GetSyncInfoCmd {
+	return &GetSyncInfoCmd{}
+}
+
+// GetTicketInfoCmd defines the getticketinfo JSON-RPC command.
+type GetTicketInfoCmd struct {
+	TxHash string
+}
+
+// NewGetTicketInfoCmd returns a new instance which can be used to issue a
+// getticketinfo JSON-RPC command.
+func NewGetTicketInfoCmd(txHash string) *GetTicketInfoCmd {
+	return &GetTicketInfoCmd{
+		TxHash: txHash,
+	}
+}
+
+// GetTicketsInfoCmd defines the getticketsinfo JSON-RPC command.
+type GetTicketsInfoCmd struct {
+	TxHashes []string
+}
+
+// NewGetTicketsInfoCmd returns a new instance which can be used to issue a
+// getticketsinfo JSON-RPC command.
+func NewGetTicketsInfoCmd(txHashes []string) *GetTicketsInfoCmd {
+	return &GetTicketsInfoCmd{
+		TxHashes: txHashes,
+	}
+}
+
+// GetTicketPoolStatsCmd defines the getticketpoolstats JSON-RPC command.
+// Blocks is the number of most recent blocks to report on and Interval is the
+// number of blocks between each reported block.
+type GetTicketPoolStatsCmd struct {
+	Blocks   *int64 `jsonrpcdefault:"1"`
+	Interval *int64 `jsonrpcdefault:"1"`
+}
+
+// NewGetTicketPoolStatsCmd returns a new instance which can be used to issue a
+// getticketpoolstats JSON-RPC command.
+func NewGetTicketPoolStatsCmd(blocks, interval *int64) *GetTicketPoolStatsCmd {
+	return &GetTicketPoolStatsCmd{
+		Blocks:   blocks,
+		Interval: interval,
+	}
+}
+
 // GetTicketPoolValueCmd defines the getticketpoolvalue JSON-RPC command.
 type GetTicketPoolValueCmd struct{}
 
@@ -223,6 +569,62 @@ func NewRebroadcastWinnersCmd() *RebroadcastWinnersCmd {
 	return &RebroadcastWinnersCmd{}
 }
 
+// RegenerateCertCmd defines the regeneratecert JSON-RPC command.
+type RegenerateCertCmd struct{}
+
+// NewRegenerateCertCmd returns a new instance which can be used to issue a
+// regeneratecert JSON-RPC command.
+func NewRegenerateCertCmd() *RegenerateCertCmd {
+	return &RegenerateCertCmd{}
+}
+
+// ReloadConfigCmd defines the reloadconfig JSON-RPC command.
+type ReloadConfigCmd struct{}
+
+// NewReloadConfigCmd returns a new instance which can be used to issue a
+// reloadconfig JSON-RPC command.
+func NewReloadConfigCmd() *ReloadConfigCmd {
+	return &ReloadConfigCmd{}
+}
+
+// RotateLogsCmd defines the rotatelogs JSON-RPC command.
+type RotateLogsCmd struct{}
+
+// NewRotateLogsCmd returns a new instance which can be used to issue a
+// rotatelogs JSON-RPC command.
+func NewRotateLogsCmd() *RotateLogsCmd {
+	return &RotateLogsCmd{}
+}
+
+// SetMockTimeCmd defines the setmocktime JSON-RPC command.
+type SetMockTimeCmd struct {
+	Time int64
+}
+
+// NewSetMockTimeCmd returns a new instance which can be used to issue a
+// setmocktime JSON-RPC command.
+func NewSetMockTimeCmd(time int64) *SetMockTimeCmd {
+	return &SetMockTimeCmd{
+		Time: time,
+	}
+}
+
+// SignMessageWithPrivKeyCmd defines the signmessagewithprivkey JSON-RPC
+// command.
+type SignMessageWithPrivKeyCmd struct {
+	PrivKey string
+	Message string
+}
+
+// NewSignMessageWithPrivKeyCmd returns a new instance which can be used to
+// issue a signmessagewithprivkey JSON-RPC command.
+func NewSignMessageWithPrivKeyCmd(privKey, message string) *SignMessageWithPrivKeyCmd {
+	return &SignMessageWithPrivKeyCmd{
+		PrivKey: privKey,
+		Message: message,
+	}
+}
+
 // TicketFeeInfoCmd defines the ticketsfeeinfo JSON-RPC command.
 type TicketFeeInfoCmd struct {
 	Blocks  *uint32
@@ -281,6 +683,19 @@ func NewTxFeeInfoCmd(blocks *uint32, start *uint32, end *uint32) *TxFeeInfoCmd {
 	}
 }
 
+// ValidateAddressesCmd defines the validateaddresses JSON-RPC command.
+type ValidateAddressesCmd struct {
+	Addresses []string
+}
+
+// NewValidateAddressesCmd returns a new instance which can be used to issue a
+// validateaddresses JSON-RPC command.
+func NewValidateAddressesCmd(addresses []string) *ValidateAddressesCmd {
+	return &ValidateAddressesCmd{
+		Addresses: addresses,
+	}
+}
+
 // VersionCmd defines the version JSON-RPC command.
 type VersionCmd struct{}
 
@@ -292,6 +707,11 @@ func init() {
 	// No special flags for commands in this file.
 	flags := UsageFlag(0)
 
+	MustRegisterCmd("analyzescript", (*AnalyzeScriptCmd)(nil), flags)
+	MustRegisterCmd("debugprofile", (*DebugProfileCmd)(nil), flags)
+	MustRegisterCmd("debugscript", (*DebugScriptCmd)(nil), flags)
+	MustRegisterCmd("dnsseed", (*DNSSeedCmd)(nil), flags)
+	MustRegisterCmd("dumptxoutset", (*DumpTxOutSetCmd)(nil), flags)
 	MustRegisterCmd("estimatestakediff", (*EstimateStakeDiffCmd)(nil), flags)
 	MustRegisterCmd("existsaddress", (*ExistsAddressCmd)(nil), flags)
 	MustRegisterCmd("existsaddresses", (*ExistsAddressesCmd)(nil), flags)
@@ -300,19 +720,41 @@ func init() {
 	MustRegisterCmd("existsliveticket", (*ExistsLiveTicketCmd)(nil), flags)
 	MustRegisterCmd("existslivetickets", (*ExistsLiveTicketsCmd)(nil), flags)
 	MustRegisterCmd("existsmempooltxs", (*ExistsMempoolTxsCmd)(nil), flags)
+	MustRegisterCmd("fundrawtransaction", (*FundRawTransactionCmd)(nil), flags)
+	MustRegisterCmd("getaddresstickets", (*GetAddressTicketsCmd)(nil), flags)
+	MustRegisterCmd("getagendas", (*GetAgendasCmd)(nil), flags)
+	MustRegisterCmd("getblockhashbytime", (*GetBlockHashByTimeCmd)(nil), flags)
 	MustRegisterCmd("getcoinsupply", (*GetCoinSupplyCmd)(nil), flags)
+	MustRegisterCmd("getdifficultyhistory", (*GetDifficultyHistoryCmd)(nil), flags)
+	MustRegisterCmd("getdnsseedinfo", (*GetDNSSeedInfoCmd)(nil), flags)
+	MustRegisterCmd("getemissionschedule", (*GetEmissionScheduleCmd)(nil), flags)
+	MustRegisterCmd("getindexinfo", (*GetIndexInfoCmd)(nil), flags)
+	MustRegisterCmd("getminedblockstats", (*GetMinedBlockStatsCmd)(nil), flags)
+	MustRegisterCmd("getminingarchive", (*GetMiningArchiveCmd)(nil), flags)
+	MustRegisterCmd("getnetworkstakeinfo", (*GetNetworkStakeInfoCmd)(nil), flags)
+	MustRegisterCmd("getsigcacheinfo", (*GetSigCacheInfoCmd)(nil), flags)
 	MustRegisterCmd("getstakedifficulty", (*GetStakeDifficultyCmd)(nil), flags)
 	MustRegisterCmd("getstakeversioninfo", (*GetStakeVersionInfoCmd)(nil), flags)
 	MustRegisterCmd("getstakeversions", (*GetStakeVersionsCmd)(nil), flags)
+	MustRegisterCmd("getsyncinfo", (*GetSyncInfoCmd)(nil), flags)
+	MustRegisterCmd("getticketinfo", (*GetTicketInfoCmd)(nil), flags)
+	MustRegisterCmd("getticketpoolstats", (*GetTicketPoolStatsCmd)(nil), flags)
 	MustRegisterCmd("getticketpoolvalue", (*GetTicketPoolValueCmd)(nil), flags)
+	MustRegisterCmd("getticketsinfo", (*GetTicketsInfoCmd)(nil), flags)
 	MustRegisterCmd("getvoteinfo", (*GetVoteInfoCmd)(nil), flags)
 	MustRegisterCmd("livetickets", (*LiveTicketsCmd)(nil), flags)
 	MustRegisterCmd("missedtickets", (*MissedTicketsCmd)(nil), flags)
 	MustRegisterCmd("rebroadcastmissed", (*RebroadcastMissedCmd)(nil), flags)
 	MustRegisterCmd("rebroadcastwinners", (*RebroadcastWinnersCmd)(nil), flags)
+	MustRegisterCmd("regeneratecert", (*RegenerateCertCmd)(nil), flags)
+	MustRegisterCmd("reloadconfig", (*ReloadConfigCmd)(nil), flags)
+	MustRegisterCmd("rotatelogs", (*RotateLogsCmd)(nil), flags)
+	MustRegisterCmd("setmocktime", (*SetMockTimeCmd)(nil), flags)
+	MustRegisterCmd("signmessagewithprivkey", (*SignMessageWithPrivKeyCmd)(nil), flags)
 	MustRegisterCmd("ticketfeeinfo", (*TicketFeeInfoCmd)(nil), flags)
 	MustRegisterCmd("ticketsforaddress", (*TicketsForAddressCmd)(nil), flags)
 	MustRegisterCmd("ticketvwap", (*TicketVWAPCmd)(nil), flags)
 	MustRegisterCmd("txfeeinfo", (*TxFeeInfoCmd)(nil), flags)
+	MustRegisterCmd("validateaddresses", (*ValidateAddressesCmd)(nil), flags)
 	MustRegisterCmd("version", (*VersionCmd)(nil), flags)
 }
@@ -43,6 +43,390 @@ func TestExccdCmds(t *testing.T) {
 				LevelSpec: "trace",
 			},
 		},
+		{
+			name: "analyzescript",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("analyzescript", "76a914")
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewAnalyzeScriptCmd("76a914", nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"analyzescript","params":["76a914"],"id":1}`,
+			unmarshalled: &exccjson.AnalyzeScriptCmd{
+				HexScript: "76a914",
+				Version:   exccjson.Uint16(0),
+			},
+		},
+		{
+			name: "analyzescript optional",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("analyzescript", "76a914", 1)
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewAnalyzeScriptCmd("76a914",
+					exccjson.Uint16(1))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"analyzescript","params":["76a914",1],"id":1}`,
+			unmarshalled: &exccjson.AnalyzeScriptCmd{
+				HexScript: "76a914",
+				Version:   exccjson.Uint16(1),
+			},
+		},
+		{
+			name: "debugprofile",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("debugprofile", "goroutine", "goroutines.txt")
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewDebugProfileCmd(exccjson.DPGoroutine,
+					"goroutines.txt", nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"debugprofile","params":["goroutine","goroutines.txt"],"id":1}`,
+			unmarshalled: &exccjson.DebugProfileCmd{
+				Kind:    exccjson.DPGoroutine,
+				Path:    "goroutines.txt",
+				Seconds: exccjson.Uint32(30),
+			},
+		},
+		{
+			name: "debugprofile optional",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("debugprofile", "cpu", "cpu.prof", 10)
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewDebugProfileCmd(exccjson.DPCPU,
+					"cpu.prof", exccjson.Uint32(10))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"debugprofile","params":["cpu","cpu.prof",10],"id":1}`,
+			unmarshalled: &exccjson.DebugProfileCmd{
+				Kind:    exccjson.DPCPU,
+				Path:    "cpu.prof",
+				Seconds: exccjson.Uint32(10),
+			},
+		},
+		{
+			name: "debugscript",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("debugscript", "0100", 1)
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewDebugScriptCmd("0100", 1, nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"debugscript","params":["0100",1],"id":1}`,
+			unmarshalled: &exccjson.DebugScriptCmd{
+				HexTx:         "0100",
+				Index:         1,
+				ScriptVersion: exccjson.Uint16(0),
+			},
+		},
+		{
+			name: "debugscript optional",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("debugscript", "0100", 1, "51", 0)
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewDebugScriptCmd("0100", 1,
+					exccjson.String("51"), exccjson.Uint16(0))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"debugscript","params":["0100",1,"51",0],"id":1}`,
+			unmarshalled: &exccjson.DebugScriptCmd{
+				HexTx:         "0100",
+				Index:         1,
+				PkScript:      exccjson.String("51"),
+				ScriptVersion: exccjson.Uint16(0),
+			},
+		},
+		{
+			name: "dnsseed",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("dnsseed", "seed.example.com", exccjson.DSAdd)
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewDNSSeedCmd("seed.example.com", exccjson.DSAdd, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"dnsseed","params":["seed.example.com","add"],"id":1}`,
+			unmarshalled: &exccjson.DNSSeedCmd{
+				Host:         "seed.example.com",
+				SubCmd:       exccjson.DSAdd,
+				HasFiltering: exccjson.Bool(false),
+			},
+		},
+		{
+			name: "dnsseed optional",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("dnsseed", "seed.example.com", exccjson.DSAdd, true)
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewDNSSeedCmd("seed.example.com", exccjson.DSAdd,
+					exccjson.Bool(true))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"dnsseed","params":["seed.example.com","add",true],"id":1}`,
+			unmarshalled: &exccjson.DNSSeedCmd{
+				Host:         "seed.example.com",
+				SubCmd:       exccjson.DSAdd,
+				HasFiltering: exccjson.Bool(true),
+			},
+		},
+		{
+			name: "dumptxoutset",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("dumptxoutset", "utxos.dat")
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewDumpTxOutSetCmd("utxos.dat")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"dumptxoutset","params":["utxos.dat"],"id":1}`,
+			unmarshalled: &exccjson.DumpTxOutSetCmd{
+				Path: "utxos.dat",
+			},
+		},
+		{
+			name: "fundrawtransaction",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("fundrawtransaction", "0100", `["1Address"]`)
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewFundRawTransactionCmd("0100",
+					[]string{"1Address"}, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"fundrawtransaction","params":["0100",["1Address"]],"id":1}`,
+			unmarshalled: &exccjson.FundRawTransactionCmd{
+				HexTx:     "0100",
+				Addresses: []string{"1Address"},
+			},
+		},
+		{
+			name: "fundrawtransaction optional",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("fundrawtransaction", "0100", `["1Address"]`,
+					`{"changeaddress":"2Address","feerate":0.001,"minconf":6}`)
+			},
+			staticCmd: func() interface{} {
+				options := exccjson.FundRawTransactionOptions{
+					ChangeAddress: exccjson.String("2Address"),
+					FeeRate:       exccjson.Float64(0.001),
+					MinConf:       exccjson.Int32(6),
+				}
+				return exccjson.NewFundRawTransactionCmd("0100",
+					[]string{"1Address"}, &options)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"fundrawtransaction","params":["0100",["1Address"],{"changeaddress":"2Address","feerate":0.001,"minconf":6}],"id":1}`,
+			unmarshalled: &exccjson.FundRawTransactionCmd{
+				HexTx:     "0100",
+				Addresses: []string{"1Address"},
+				Options: &exccjson.FundRawTransactionOptions{
+					ChangeAddress: exccjson.String("2Address"),
+					FeeRate:       exccjson.Float64(0.001),
+					MinConf:       exccjson.Int32(6),
+				},
+			},
+		},
+		{
+			name: "getaddresstickets",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("getaddresstickets", "1Address")
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewGetAddressTicketsCmd("1Address")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getaddresstickets","params":["1Address"],"id":1}`,
+			unmarshalled: &exccjson.GetAddressTicketsCmd{
+				Address: "1Address",
+			},
+		},
+		{
+			name: "getagendas",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("getagendas")
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewGetAgendasCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getagendas","params":[],"id":1}`,
+			unmarshalled: &exccjson.GetAgendasCmd{},
+		},
+		{
+			name: "getblockhashbytime",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("getblockhashbytime", 1523000000)
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewGetBlockHashByTimeCmd(1523000000, nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getblockhashbytime","params":[1523000000],"id":1}`,
+			unmarshalled: &exccjson.GetBlockHashByTimeCmd{
+				StartTime: 1523000000,
+				EndTime:   nil,
+				Count:     exccjson.Int32(100),
+			},
+		},
+		{
+			name: "getblockhashbytime optional",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("getblockhashbytime", 1523000000, 1523086400, 10)
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewGetBlockHashByTimeCmd(1523000000,
+					exccjson.Int64(1523086400), exccjson.Int32(10))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getblockhashbytime","params":[1523000000,1523086400,10],"id":1}`,
+			unmarshalled: &exccjson.GetBlockHashByTimeCmd{
+				StartTime: 1523000000,
+				EndTime:   exccjson.Int64(1523086400),
+				Count:     exccjson.Int32(10),
+			},
+		},
+		{
+			name: "getdifficultyhistory",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("getdifficultyhistory", 100)
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewGetDifficultyHistoryCmd(100, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getdifficultyhistory","params":[100],"id":1}`,
+			unmarshalled: &exccjson.GetDifficultyHistoryCmd{
+				StartHeight: 100,
+				EndHeight:   exccjson.Int64(-1),
+			},
+		},
+		{
+			name: "getdifficultyhistory optional",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("getdifficultyhistory", 100, 200)
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewGetDifficultyHistoryCmd(100,
+					exccjson.Int64(200))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getdifficultyhistory","params":[100,200],"id":1}`,
+			unmarshalled: &exccjson.GetDifficultyHistoryCmd{
+				StartHeight: 100,
+				EndHeight:   exccjson.Int64(200),
+			},
+		},
+		{
+			name: "getdnsseedinfo",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("getdnsseedinfo")
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewGetDNSSeedInfoCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getdnsseedinfo","params":[],"id":1}`,
+			unmarshalled: &exccjson.GetDNSSeedInfoCmd{},
+		},
+		{
+			name: "getemissionschedule",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("getemissionschedule")
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewGetEmissionScheduleCmd(nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getemissionschedule","params":[],"id":1}`,
+			unmarshalled: &exccjson.GetEmissionScheduleCmd{
+				Intervals: exccjson.Int32(12),
+			},
+		},
+		{
+			name: "getemissionschedule optional",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("getemissionschedule", 3)
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewGetEmissionScheduleCmd(exccjson.Int32(3))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getemissionschedule","params":[3],"id":1}`,
+			unmarshalled: &exccjson.GetEmissionScheduleCmd{
+				Intervals: exccjson.Int32(3),
+			},
+		},
+		{
+			name: "getminedblockstats",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("getminedblockstats")
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewGetMinedBlockStatsCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getminedblockstats","params":[],"id":1}`,
+			unmarshalled: &exccjson.GetMinedBlockStatsCmd{},
+		},
+		{
+			name: "getminingarchive",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("getminingarchive")
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewGetMiningArchiveCmd(nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getminingarchive","params":[],"id":1}`,
+			unmarshalled: &exccjson.GetMiningArchiveCmd{
+				Count: exccjson.Int32(100),
+			},
+		},
+		{
+			name: "getminingarchive optional",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("getminingarchive", 10)
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewGetMiningArchiveCmd(exccjson.Int32(10))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getminingarchive","params":[10],"id":1}`,
+			unmarshalled: &exccjson.GetMiningArchiveCmd{
+				Count: exccjson.Int32(10),
+			},
+		},
+		{
+			name: "getindexinfo",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("getindexinfo")
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewGetIndexInfoCmd(nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getindexinfo","params":[],"id":1}`,
+			unmarshalled: &exccjson.GetIndexInfoCmd{
+				IndexName: nil,
+			},
+		},
+		{
+			name: "getindexinfo optional",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("getindexinfo", "transaction index")
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewGetIndexInfoCmd(exccjson.String("transaction index"))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getindexinfo","params":["transaction index"],"id":1}`,
+			unmarshalled: &exccjson.GetIndexInfoCmd{
+				IndexName: exccjson.String("transaction index"),
+			},
+		},
+		{
+			name: "getnetworkstakeinfo",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("getnetworkstakeinfo")
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewGetNetworkStakeInfoCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getnetworkstakeinfo","params":[],"id":1}`,
+			unmarshalled: &exccjson.GetNetworkStakeInfoCmd{},
+		},
+		{
+			name: "getsigcacheinfo",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("getsigcacheinfo")
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewGetSigCacheInfoCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getsigcacheinfo","params":[],"id":1}`,
+			unmarshalled: &exccjson.GetSigCacheInfoCmd{},
+		},
 		{
 			name: "getstakeversions",
 			newCmd: func() (interface{}, error) {
@@ -57,6 +441,72 @@ func TestExccdCmds(t *testing.T) {
 				Count: 1,
 			},
 		},
+		{
+			name: "getsyncinfo",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("getsyncinfo")
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewGetSyncInfoCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getsyncinfo","params":[],"id":1}`,
+			unmarshalled: &exccjson.GetSyncInfoCmd{},
+		},
+		{
+			name: "getticketsinfo",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("getticketsinfo", []string{"deadbeef", "cafe"})
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewGetTicketsInfoCmd([]string{"deadbeef", "cafe"})
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getticketsinfo","params":[["deadbeef","cafe"]],"id":1}`,
+			unmarshalled: &exccjson.GetTicketsInfoCmd{
+				TxHashes: []string{"deadbeef", "cafe"},
+			},
+		},
+		{
+			name: "getticketpoolstats",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("getticketpoolstats")
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewGetTicketPoolStatsCmd(nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getticketpoolstats","params":[],"id":1}`,
+			unmarshalled: &exccjson.GetTicketPoolStatsCmd{
+				Blocks:   exccjson.Int64(1),
+				Interval: exccjson.Int64(1),
+			},
+		},
+		{
+			name: "getticketpoolstats optional",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("getticketpoolstats", 288, 12)
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewGetTicketPoolStatsCmd(exccjson.Int64(288),
+					exccjson.Int64(12))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getticketpoolstats","params":[288,12],"id":1}`,
+			unmarshalled: &exccjson.GetTicketPoolStatsCmd{
+				Blocks:   exccjson.Int64(288),
+				Interval: exccjson.Int64(12),
+			},
+		},
+		{
+			name: "getticketinfo",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("getticketinfo", "deadbeef")
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewGetTicketInfoCmd("deadbeef")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getticketinfo","params":["deadbeef"],"id":1}`,
+			unmarshalled: &exccjson.GetTicketInfoCmd{
+				TxHash: "deadbeef",
+			},
+		},
 		{
 			name: "getvoteinfo",
 			newCmd: func() (interface{}, error) {
@@ -70,6 +520,81 @@ func TestExccdCmds(t *testing.T) {
 				Version: 1,
 			},
 		},
+		{
+			name: "regeneratecert",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("regeneratecert")
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewRegenerateCertCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"regeneratecert","params":[],"id":1}`,
+			unmarshalled: &exccjson.RegenerateCertCmd{},
+		},
+		{
+			name: "reloadconfig",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("reloadconfig")
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewReloadConfigCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"reloadconfig","params":[],"id":1}`,
+			unmarshalled: &exccjson.ReloadConfigCmd{},
+		},
+		{
+			name: "rotatelogs",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("rotatelogs")
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewRotateLogsCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"rotatelogs","params":[],"id":1}`,
+			unmarshalled: &exccjson.RotateLogsCmd{},
+		},
+		{
+			name: "setmocktime",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("setmocktime", 1500000000)
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewSetMockTimeCmd(1500000000)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"setmocktime","params":[1500000000],"id":1}`,
+			unmarshalled: &exccjson.SetMockTimeCmd{
+				Time: 1500000000,
+			},
+		},
+		{
+			name: "signmessagewithprivkey",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("signmessagewithprivkey", "privkey", "message")
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewSignMessageWithPrivKeyCmd("privkey", "message")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"signmessagewithprivkey","params":["privkey","message"],"id":1}`,
+			unmarshalled: &exccjson.SignMessageWithPrivKeyCmd{
+				PrivKey: "privkey",
+				Message: "message",
+			},
+		},
+		{
+			name: "validateaddresses",
+			newCmd: func() (interface{}, error) {
+				return exccjson.NewCmd("validateaddresses",
+					[]string{"1Address", "2Address"})
+			},
+			staticCmd: func() interface{} {
+				return exccjson.NewValidateAddressesCmd(
+					[]string{"1Address", "2Address"})
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"validateaddresses","params":[["1Address","2Address"]],"id":1}`,
+			unmarshalled: &exccjson.ValidateAddressesCmd{
+				Addresses: []string{"1Address", "2Address"},
+			},
+		},
 	}
 
 	t.Logf("Running %d tests", len(tests))
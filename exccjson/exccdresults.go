@@ -6,6 +6,206 @@
 
 package exccjson
 
+// AnalyzeScriptResult models the data returned from the analyzescript command.
+type AnalyzeScriptResult struct {
+	Type          string `json:"type"`
+	SubType       string `json:"subtype,omitempty"`
+	ReqSigs       int    `json:"reqsigs"`
+	SigOps        int    `json:"sigops"`
+	PreciseSigOps int    `json:"precisesigops"`
+	PushOnly      bool   `json:"pushonly"`
+	NumPushes     int    `json:"numpushes"`
+	MaxPushSize   int    `json:"maxpushsize"`
+	SigScriptSize int    `json:"sigscriptsize"`
+}
+
+// DebugProfileResult models the data returned from the debugprofile command.
+type DebugProfileResult struct {
+	Kind    string  `json:"kind"`
+	Path    string  `json:"path"`
+	Size    int64   `json:"size"`
+	Seconds float64 `json:"seconds,omitempty"`
+}
+
+// DumpTxOutSetResult models the data returned from the dumptxoutset command.
+type DumpTxOutSetResult struct {
+	Path        string `json:"path"`
+	Hash        string `json:"hash"`
+	Height      int64  `json:"height"`
+	NumOutputs  uint64 `json:"numoutputs"`
+	TotalAmount int64  `json:"totalamount"`
+	Commitment  string `json:"commitment"`
+}
+
+// FundRawTransactionResult models the data returned from the
+// fundrawtransaction command.
+type FundRawTransactionResult struct {
+	Hex       string  `json:"hex"`
+	Fee       float64 `json:"fee"`
+	ChangePos int32   `json:"changepos"`
+}
+
+// GetAgendasResult models the data returned for each agenda from the
+// getagendas command.
+type GetAgendasResult struct {
+	Id          string `json:"id"`
+	Description string `json:"description"`
+	VoteVersion uint32 `json:"voteversion"`
+	Mask        uint16 `json:"mask"`
+	StartTime   uint64 `json:"starttime"`
+	ExpireTime  uint64 `json:"expiretime"`
+	Quorum      uint32 `json:"quorum"`
+	Status      string `json:"status"`
+	Choice      string `json:"choice,omitempty"`
+}
+
+// GetBlockHashByTimeResult models the data returned for each block from the
+// getblockhashbytime command.
+type GetBlockHashByTimeResult struct {
+	Hash   string `json:"hash"`
+	Height int64  `json:"height"`
+	Time   int64  `json:"time"`
+}
+
+// DifficultyHistoryResult models the proof of work difficulty of a block
+// returned from the getdifficultyhistory command.
+type DifficultyHistoryResult struct {
+	Height     int64   `json:"height"`
+	Hash       string  `json:"hash"`
+	Time       int64   `json:"time"`
+	Bits       string  `json:"bits"`
+	Difficulty float64 `json:"difficulty"`
+	ChainWork  string  `json:"chainwork"`
+	Retarget   bool    `json:"retarget"`
+}
+
+// MinedBlockStatsResult models the number of blocks mined by the node or
+// submitted by its miners with each outcome over a window of time returned
+// from the getminedblockstats command.
+type MinedBlockStatsResult struct {
+	Window    string  `json:"window"`
+	Accepted  uint64  `json:"accepted"`
+	Stale     uint64  `json:"stale"`
+	Orphaned  uint64  `json:"orphaned"`
+	Rejected  uint64  `json:"rejected"`
+	StaleRate float64 `json:"stalerate"`
+}
+
+// GetMinedBlockStatsResult models the data returned from the
+// getminedblockstats command.
+type GetMinedBlockStatsResult struct {
+	Since   int64                   `json:"since"`
+	Windows []MinedBlockStatsResult `json:"windows"`
+}
+
+// MiningArchiveResult models a block template issued to a miner or a solution
+// submitted by one returned from the getminingarchive command.
+type MiningArchiveResult struct {
+	Seq      uint64 `json:"seq"`
+	Time     int64  `json:"time"`
+	Kind     string `json:"kind"`
+	Source   string `json:"source"`
+	WorkID   uint64 `json:"workid"`
+	Hash     string `json:"hash"`
+	PrevHash string `json:"prevhash"`
+	Height   uint32 `json:"height"`
+	NumTxns  uint16 `json:"numtxns"`
+	NumSTxns uint16 `json:"numstxns"`
+	Header   string `json:"header"`
+	Outcome  string `json:"outcome,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// GetDNSSeedInfoResult models the objects included in the getdnsseedinfo
+// response.
+type GetDNSSeedInfoResult struct {
+	Host         string `json:"host"`
+	HasFiltering bool   `json:"hasfiltering"`
+	Lookups      uint32 `json:"lookups"`
+	Successes    uint32 `json:"successes"`
+	Addresses    uint64 `json:"addresses"`
+	LastSuccess  int64  `json:"lastsuccess"`
+	LastError    string `json:"lasterror,omitempty"`
+}
+
+// EmissionIntervalResult models the data of a subsidy reduction interval
+// returned from the getemissionschedule command.
+type EmissionIntervalResult struct {
+	StartHeight  int64   `json:"startheight"`
+	EndHeight    int64   `json:"endheight"`
+	StartTime    int64   `json:"starttime"`
+	BlockSubsidy int64   `json:"blocksubsidy"`
+	WorkSubsidy  int64   `json:"worksubsidy"`
+	VoteSubsidy  int64   `json:"votesubsidy"`
+	Emission     int64   `json:"emission"`
+	Supply       int64   `json:"supply"`
+	Inflation    float64 `json:"inflation"`
+}
+
+// GetEmissionScheduleResult models the data returned from the
+// getemissionschedule command.
+type GetEmissionScheduleResult struct {
+	Height    int64                    `json:"height"`
+	Supply    int64                    `json:"supply"`
+	MaxSupply int64                    `json:"maxsupply"`
+	Intervals []EmissionIntervalResult `json:"intervals"`
+}
+
+// GetIndexInfoResult models the objects included in the getindexinfo
+// response.  In the actual result, these objects are keyed by the index name.
+type GetIndexInfoResult struct {
+	Ready           bool   `json:"ready"`
+	Synced          bool   `json:"synced"`
+	BestBlockHash   string `json:"bestblockhash"`
+	BestBlockHeight int64  `json:"bestblockheight"`
+	Error           string `json:"error,omitempty"`
+}
+
+// GetSigCacheInfoResult models the data returned from the getsigcacheinfo
+// command.
+type GetSigCacheInfoResult struct {
+	Entries    uint    `json:"entries"`
+	MaxEntries uint    `json:"maxentries"`
+	Hits       uint64  `json:"hits"`
+	Misses     uint64  `json:"misses"`
+	HitRate    float64 `json:"hitrate"`
+}
+
+// GetSyncInfoResult models the data returned from the getsyncinfo command and
+// the syncprogress notification.
+type GetSyncInfoResult struct {
+	Synced          bool    `json:"synced"`
+	Blocks          int64   `json:"blocks"`
+	Headers         int64   `json:"headers"`
+	Progress        float64 `json:"progress"`
+	BlocksPerSecond float64 `json:"blockspersecond"`
+	ETA             int64   `json:"eta"`
+	SyncPeer        string  `json:"syncpeer,omitempty"`
+	SyncPeerHeight  int64   `json:"syncpeerheight,omitempty"`
+	BestBlockHash   string  `json:"bestblockhash"`
+	BestBlockTime   int64   `json:"bestblocktime"`
+}
+
+// DebugScriptStep models the state of the script engine after executing a
+// single opcode as returned by the debugscript command.
+type DebugScriptStep struct {
+	Script   int      `json:"script"`
+	Offset   int      `json:"offset"`
+	Opcode   string   `json:"opcode"`
+	Stack    []string `json:"stack"`
+	AltStack []string `json:"altstack"`
+	Error    string   `json:"error,omitempty"`
+}
+
+// DebugScriptResult models the data returned from the debugscript command.
+type DebugScriptResult struct {
+	PkScript      string            `json:"pkscript"`
+	ScriptVersion uint16            `json:"scriptversion"`
+	Valid         bool              `json:"valid"`
+	Error         string            `json:"error,omitempty"`
+	Steps         []DebugScriptStep `json:"steps"`
+}
+
 // GetStakeDifficultyResult models the data returned from the
 // getstakedifficulty command.
 type GetStakeDifficultyResult struct {
@@ -13,6 +213,48 @@ type GetStakeDifficultyResult struct {
 	NextStakeDifficulty    float64 `json:"next"`
 }
 
+// NetworkStakeInfoResult models the data returned from the getnetworkstakeinfo
+// command.
+type NetworkStakeInfoResult struct {
+	Height          int64   `json:"height"`
+	PoolSize        uint32  `json:"poolsize"`
+	TicketPrice     float64 `json:"ticketprice"`
+	NextTicketPrice float64 `json:"nextticketprice"`
+	MempoolTickets  uint32  `json:"mempooltickets"`
+	RateWindow      int64   `json:"ratewindow"`
+	Voted           uint32  `json:"voted"`
+	Missed          uint32  `json:"missed"`
+	VoteRate        float64 `json:"voterate"`
+	MissRate        float64 `json:"missrate"`
+	VoteReward      float64 `json:"votereward"`
+	ExpectedReward  float64 `json:"expectedreward"`
+}
+
+// TicketPoolStatsResult models the state of the ticket pool as of a block
+// returned from the getticketpoolstats command.
+type TicketPoolStatsResult struct {
+	Height        int64   `json:"height"`
+	Hash          string  `json:"hash"`
+	PoolSize      uint32  `json:"poolsize"`
+	PoolValue     float64 `json:"poolvalue"`
+	AveragePrice  float64 `json:"averageprice"`
+	CoinSupply    float64 `json:"coinsupply"`
+	LockedPercent float64 `json:"lockedpercent"`
+}
+
+// TicketInfoResult models the lifecycle data of a ticket returned from the
+// getticketinfo, getticketsinfo, and getaddresstickets commands and included
+// in ticketstatuschanged notifications.
+type TicketInfoResult struct {
+	Hash           string `json:"hash"`
+	Status         string `json:"status"`
+	PurchaseHeight int64  `json:"purchaseheight"`
+	LiveHeight     int64  `json:"liveheight,omitempty"`
+	MissedHeight   int64  `json:"missedheight,omitempty"`
+	SpendHeight    int64  `json:"spendheight,omitempty"`
+	Spender        string `json:"spender,omitempty"`
+}
+
 // VersionCount models a generic version:count tuple.
 type VersionCount struct {
 	Version uint32 `json:"version"`
@@ -74,6 +316,7 @@ type Agenda struct {
 	Mask           uint16   `json:"mask"`
 	StartTime      uint64   `json:"starttime"`
 	ExpireTime     uint64   `json:"expiretime"`
+	Quorum         uint32   `json:"quorum"`
 	Status         string   `json:"status"`
 	QuorumProgress float64  `json:"quorumprogress"`
 	Choices        []Choice `json:"choices"`
@@ -192,4 +435,11 @@ type VersionResult struct {
 	Patch         uint32 `json:"patch"`
 	Prerelease    string `json:"prerelease"`
 	BuildMetadata string `json:"buildmetadata"`
+
+	// Commit, GoVersion, and Features are only set for the version of the
+	// node itself and identify its build and the optional features enabled
+	// in its configuration.
+	Commit    string   `json:"commit,omitempty"`
+	GoVersion string   `json:"goversion,omitempty"`
+	Features  []string `json:"features,omitempty"`
 }
@@ -63,6 +63,16 @@ func TestHelpers(t *testing.T) {
 				return &val
 			}(),
 		},
+		{
+			name: "uint16",
+			f: func() interface{} {
+				return exccjson.Uint16(5)
+			},
+			expected: func() interface{} {
+				val := uint16(5)
+				return &val
+			}(),
+		},
 		{
 			name: "uint32",
 			f: func() interface{} {
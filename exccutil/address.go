@@ -15,6 +15,7 @@ import (
 	"github.com/EXCCoin/base58"
 	"github.com/EXCCoin/exccd/chaincfg"
 	"github.com/EXCCoin/exccd/chaincfg/chainec"
+	"github.com/EXCCoin/exccd/exccutil/bech32"
 )
 
 var (
@@ -40,6 +41,12 @@ var (
 	// attempts to decode an address without defining which network to decode
 	// for.
 	ErrMissingDefaultNet = errors.New("default net not defined")
+
+	// ErrBech32NotSupported describes an error where a bech32 address is
+	// created for a network which does not define a bech32 human-readable
+	// part and therefore does not accept bech32 addresses.
+	ErrBech32NotSupported = errors.New("bech32 addresses are not " +
+		"supported by the network")
 )
 
 // encodeAddress returns a human-readable payment address given a ripemd160 hash
@@ -159,6 +166,12 @@ func NewAddressPubKey(decoded []byte, net *chaincfg.Params) (Address, error) {
 // DecodeAddress decodes the string encoding of an address and returns
 // the Address if addr is a valid encoding for a known address type
 func DecodeAddress(addr string) (Address, error) {
+	// Bech32 addresses are single case while base58 addresses mix cases,
+	// so any address which is a valid bech32 string is a bech32 address.
+	if hrp, data, err := bech32.Decode(addr); err == nil {
+		return decodeBech32Address(hrp, data)
+	}
+
 	// Switch on decoded length to determine the type.
 	decoded, netID, err := base58.CheckDecode(addr)
 	if err != nil {
@@ -169,7 +182,7 @@ func DecodeAddress(addr string) (Address, error) {
 			err.Error())
 	}
 
-	net, err := detectNetworkForAddress(addr)
+	net, err := detectNetworkForAddress(addr, netID)
 	if err != nil {
 		return nil, ErrUnknownAddressType
 	}
@@ -196,20 +209,22 @@ func DecodeAddress(addr string) (Address, error) {
 }
 
 // detectNetworkForAddress pops the first character from a string encoded
-// address and detects what network type it is for.
-func detectNetworkForAddress(addr string) (*chaincfg.Params, error) {
+// address and detects what network type it is for by looking it up among the
+// default and registered networks.  The address identifier is used to tell
+// apart networks which share the same prefix.
+func detectNetworkForAddress(addr string, netID [2]byte) (*chaincfg.Params,
+	error) {
 	if len(addr) < 1 {
 		return nil, fmt.Errorf("empty string given for network detection")
 	}
 
 	networkChar := addr[0:1]
-	switch networkChar {
-	case chaincfg.MainNetParams.NetworkAddressPrefix:
-		return &chaincfg.MainNetParams, nil
-	case chaincfg.TestNet2Params.NetworkAddressPrefix:
-		return &chaincfg.TestNet2Params, nil
-	case chaincfg.SimNetParams.NetworkAddressPrefix:
-		return &chaincfg.SimNetParams, nil
+	for _, net := range chaincfg.ParamsByNetworkAddressPrefix(networkChar) {
+		switch netID {
+		case net.PubKeyAddrID, net.PubKeyHashAddrID, net.PKHEdwardsAddrID,
+			net.PKHSchnorrAddrID, net.ScriptHashAddrID:
+			return net, nil
+		}
 	}
 
 	return nil, fmt.Errorf("unknown network type in string encoded address")
@@ -752,3 +767,175 @@ func (a *AddressSecSchnorrPubKey) DSA(net *chaincfg.Params) int {
 func (a *AddressSecSchnorrPubKey) Net() *chaincfg.Params {
 	return a.net
 }
+
+// The following constants define the versions of bech32 addresses, which
+// identify the kind of script the address pays to.
+const (
+	bech32VersionPubKeyHash byte = 0
+	bech32VersionScriptHash byte = 1
+	bech32VersionPKHEdwards byte = 2
+	bech32VersionPKHSchnorr byte = 3
+)
+
+// decodeBech32Address returns the bech32 address for the passed decoded
+// human-readable part and 5-bit data values.
+func decodeBech32Address(hrp string, data []byte) (Address, error) {
+	net, ok := chaincfg.ParamsByBech32HRP(hrp)
+	if !ok || len(data) == 0 {
+		return nil, ErrUnknownAddressType
+	}
+
+	hash, err := bech32.ConvertBits(data[1:], 5, 8, false)
+	if err != nil {
+		return nil, fmt.Errorf("decoded address is of unknown format: %v",
+			err)
+	}
+	switch data[0] {
+	case bech32VersionPubKeyHash, bech32VersionScriptHash,
+		bech32VersionPKHEdwards, bech32VersionPKHSchnorr:
+		return newAddressBech32(hash, data[0], net)
+	}
+	return nil, ErrUnknownAddressType
+}
+
+// AddressBech32 is an Address for a pay-to-pubkey-hash (P2PKH) or
+// pay-to-script-hash (P2SH) transaction which is encoded with bech32 rather
+// than base58.  The checksum of the encoding detects more errors and the
+// encoding only uses a single case, which makes it more compact in QR codes.
+// The address pays to the same scripts as the equivalent AddressPubKeyHash or
+// AddressScriptHash.
+type AddressBech32 struct {
+	net     *chaincfg.Params
+	version byte
+	hash    [ripemd160.Size]byte
+}
+
+// NewAddressBech32PubKeyHash returns a new bech32 encoded pay-to-pubkey-hash
+// address.  pkHash must be 20 bytes.
+func NewAddressBech32PubKeyHash(pkHash []byte, net *chaincfg.Params,
+	algo int) (*AddressBech32, error) {
+	var version byte
+	switch algo {
+	case chainec.ECTypeSecp256k1:
+		version = bech32VersionPubKeyHash
+	case chainec.ECTypeEdwards:
+		version = bech32VersionPKHEdwards
+	case chainec.ECTypeSecSchnorr:
+		version = bech32VersionPKHSchnorr
+	default:
+		return nil, errors.New("unknown ECDSA algorithm")
+	}
+	return newAddressBech32(pkHash, version, net)
+}
+
+// NewAddressBech32ScriptHashFromHash returns a new bech32 encoded
+// pay-to-script-hash address.  scriptHash must be 20 bytes.
+func NewAddressBech32ScriptHashFromHash(scriptHash []byte,
+	net *chaincfg.Params) (*AddressBech32, error) {
+	return newAddressBech32(scriptHash, bech32VersionScriptHash, net)
+}
+
+// NewAddressBech32 returns the bech32 encoded form of the passed
+// pay-to-pubkey-hash or pay-to-script-hash address, such as those extracted
+// from a public key script.
+func NewAddressBech32(addr Address) (*AddressBech32, error) {
+	switch addr := addr.(type) {
+	case *AddressPubKeyHash:
+		return NewAddressBech32PubKeyHash(addr.hash[:], addr.net,
+			addr.DSA(addr.net))
+	case *AddressScriptHash:
+		return NewAddressBech32ScriptHashFromHash(addr.hash[:], addr.net)
+	case *AddressBech32:
+		return addr, nil
+	}
+	return nil, ErrUnknownAddressType
+}
+
+// newAddressBech32 is the internal API to create a bech32 address with a
+// known version.
+func newAddressBech32(hash []byte, version byte,
+	net *chaincfg.Params) (*AddressBech32, error) {
+	if net == nil || net.Bech32HRP == "" {
+		return nil, ErrBech32NotSupported
+	}
+	if len(hash) != ripemd160.Size {
+		return nil, errors.New("hash must be 20 bytes")
+	}
+	addr := &AddressBech32{net: net, version: version}
+	copy(addr.hash[:], hash)
+	return addr, nil
+}
+
+// EncodeAddress returns the bech32 string encoding of the address.  Part of
+// the Address interface.
+func (a *AddressBech32) EncodeAddress() string {
+	// The conversion and encoding can not fail since the hash is always
+	// 20 bytes and the human-readable part is defined by the network.
+	data, _ := bech32.ConvertBits(a.hash[:], 8, 5, true)
+	encoded, _ := bech32.Encode(a.net.Bech32HRP,
+		append([]byte{a.version}, data...))
+	return encoded
+}
+
+// ScriptAddress returns the bytes to be included in a txout script to pay
+// to the pubkey or script hash.  Part of the Address interface.
+func (a *AddressBech32) ScriptAddress() []byte {
+	return a.hash[:]
+}
+
+// IsForNet returns whether or not the bech32 address is associated with the
+// passed network.
+func (a *AddressBech32) IsForNet(net *chaincfg.Params) bool {
+	return net.Bech32HRP != "" && a.net.Bech32HRP == net.Bech32HRP
+}
+
+// String returns a human-readable string for the bech32 address.  This is
+// equivalent to calling EncodeAddress, but is provided so the type can be used
+// as a fmt.Stringer.
+func (a *AddressBech32) String() string {
+	return a.EncodeAddress()
+}
+
+// Hash160 returns the underlying array of the pubkey or script hash.  This can
+// be useful when an array is more appropriate than a slice (for example, when
+// used as map keys).
+func (a *AddressBech32) Hash160() *[ripemd160.Size]byte {
+	return &a.hash
+}
+
+// DSA returns the digital signature algorithm for the associated public key
+// hash or -1 (invalid) for script hashes, as scripts may not involve digital
+// signatures at all.
+func (a *AddressBech32) DSA(net *chaincfg.Params) int {
+	switch a.version {
+	case bech32VersionPubKeyHash:
+		return chainec.ECTypeSecp256k1
+	case bech32VersionPKHEdwards:
+		return chainec.ECTypeEdwards
+	case bech32VersionPKHSchnorr:
+		return chainec.ECTypeSecSchnorr
+	}
+	return -1
+}
+
+// Net returns the network for the address.
+func (a *AddressBech32) Net() *chaincfg.Params {
+	return a.net
+}
+
+// IsScriptHash returns whether or not the address pays to a script hash
+// rather than a pubkey hash.
+func (a *AddressBech32) IsScriptHash() bool {
+	return a.version == bech32VersionScriptHash
+}
+
+// Base58 returns the equivalent base58 encoded pay-to-pubkey-hash or
+// pay-to-script-hash address.
+func (a *AddressBech32) Base58() Address {
+	if a.IsScriptHash() {
+		addr, _ := NewAddressScriptHashFromHash(a.hash[:], a.net)
+		return addr
+	}
+	addr, _ := NewAddressPubKeyHash(a.hash[:], a.net, a.DSA(a.net))
+	return addr
+}
@@ -11,6 +11,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/EXCCoin/exccd/chaincfg"
@@ -521,3 +522,187 @@ func TestAddresses(t *testing.T) {
 		}
 	}
 }
+
+// TestBech32Addresses ensures bech32 addresses round trip through their string
+// encoding, are equivalent to the base58 addresses for the same hash, and are
+// rejected on networks which do not support them.
+func TestBech32Addresses(t *testing.T) {
+	hash := []byte{
+		0x4d, 0x02, 0x1b, 0xca, 0xad, 0x14, 0xe2, 0x4d, 0xe9, 0x04,
+		0x78, 0xe7, 0x69, 0x52, 0xe3, 0xac, 0xdb, 0xd8, 0x08, 0x36}
+	net := &chaincfg.TestNet2Params
+
+	pkh, err := exccutil.NewAddressBech32PubKeyHash(hash, net,
+		chainec.ECTypeEdwards)
+	if err != nil {
+		t.Fatalf("NewAddressBech32PubKeyHash: unexpected error: %v", err)
+	}
+	sh, err := exccutil.NewAddressBech32ScriptHashFromHash(hash, net)
+	if err != nil {
+		t.Fatalf("NewAddressBech32ScriptHashFromHash: unexpected error: %v",
+			err)
+	}
+
+	for _, addr := range []*exccutil.AddressBech32{pkh, sh} {
+		encoded := addr.EncodeAddress()
+		if !strings.HasPrefix(encoded, net.Bech32HRP+"1") {
+			t.Errorf("%v: unexpected human-readable part", encoded)
+		}
+		decoded, err := exccutil.DecodeAddress(encoded)
+		if err != nil {
+			t.Errorf("%v: unexpected decode error: %v", encoded, err)
+			continue
+		}
+		if !reflect.DeepEqual(decoded, addr) {
+			t.Errorf("%v: decoded to %#v, want %#v", encoded, decoded,
+				addr)
+		}
+		upper, err := exccutil.DecodeAddress(strings.ToUpper(encoded))
+		if err != nil || !reflect.DeepEqual(upper, addr) {
+			t.Errorf("%v: uppercase address not decoded (%v)",
+				encoded, err)
+		}
+		if !addr.IsForNet(net) || addr.IsForNet(&chaincfg.SimNetParams) {
+			t.Errorf("%v: unexpected network association", encoded)
+		}
+		if !bytes.Equal(addr.ScriptAddress(), hash) {
+			t.Errorf("%v: unexpected script address %x", encoded,
+				addr.ScriptAddress())
+		}
+
+		// Ensure conversion to and from the base58 address is lossless.
+		converted, err := exccutil.NewAddressBech32(addr.Base58())
+		if err != nil || !reflect.DeepEqual(converted, addr) {
+			t.Errorf("%v: base58 conversion does not round trip (%v)",
+				encoded, err)
+		}
+	}
+	if pkh.IsScriptHash() || !sh.IsScriptHash() {
+		t.Errorf("unexpected script hash classification")
+	}
+	if pkh.DSA(net) != chainec.ECTypeEdwards || sh.DSA(net) != -1 {
+		t.Errorf("unexpected digital signature algorithm")
+	}
+	if _, ok := sh.Base58().(*exccutil.AddressScriptHash); !ok {
+		t.Errorf("unexpected base58 address type %T", sh.Base58())
+	}
+
+	// Ensure a corrupted character is detected by the checksum.
+	encoded := []byte(pkh.EncodeAddress())
+	last := len(encoded) - 1
+	if encoded[last] == 'q' {
+		encoded[last] = 'p'
+	} else {
+		encoded[last] = 'q'
+	}
+	if _, err := exccutil.DecodeAddress(string(encoded)); err == nil {
+		t.Errorf("%s: corrupted address decoded without error", encoded)
+	}
+
+	// Ensure bech32 addresses are not available on networks which do not
+	// define a human-readable part for them.
+	_, err = exccutil.NewAddressBech32ScriptHashFromHash(hash,
+		&chaincfg.MainNetParams)
+	if err != exccutil.ErrBech32NotSupported {
+		t.Errorf("unexpected error for unsupported network - got %v, "+
+			"want %v", err, exccutil.ErrBech32NotSupported)
+	}
+}
+
+// customNetParams returns the parameters of a custom network with its own
+// address encoding magics, registering them on first use so addresses of the
+// network can be decoded.
+func customNetParams(t *testing.T) *chaincfg.Params {
+	params, err := chaincfg.LoadCustomParams([]byte(`{
+		"name": "customnet",
+		"net": 305419896,
+		"defaultport": "19777",
+		"networkaddressprefix": "V",
+		"bech32hrp": "vexcc",
+		"pubkeyhashaddrid": "1000",
+		"scripthashaddrid": "1001"
+	}`))
+	if err != nil {
+		t.Fatalf("LoadCustomParams: unexpected error: %v", err)
+	}
+	err = chaincfg.Register(params)
+	if err != nil && err != chaincfg.ErrDuplicateNet {
+		t.Fatalf("Register: unexpected error: %v", err)
+	}
+	for _, net := range chaincfg.ParamsByNetworkAddressPrefix("V") {
+		if net.Net == params.Net {
+			return net
+		}
+	}
+	t.Fatalf("custom network is not registered")
+	return nil
+}
+
+// TestCustomNetAddresses ensures both base58 and bech32 addresses of a
+// registered custom network are decoded for that network.
+func TestCustomNetAddresses(t *testing.T) {
+	net := customNetParams(t)
+	hash := []byte{
+		0x4d, 0x02, 0x1b, 0xca, 0xad, 0x14, 0xe2, 0x4d, 0xe9, 0x04,
+		0x78, 0xe7, 0x69, 0x52, 0xe3, 0xac, 0xdb, 0xd8, 0x08, 0x36}
+
+	pkh, err := exccutil.NewAddressPubKeyHash(hash, net,
+		chainec.ECTypeSecp256k1)
+	if err != nil {
+		t.Fatalf("NewAddressPubKeyHash: unexpected error: %v", err)
+	}
+	sh, err := exccutil.NewAddressScriptHashFromHash(hash, net)
+	if err != nil {
+		t.Fatalf("NewAddressScriptHashFromHash: unexpected error: %v", err)
+	}
+	bech32PKH, err := exccutil.NewAddressBech32PubKeyHash(hash, net,
+		chainec.ECTypeSecp256k1)
+	if err != nil {
+		t.Fatalf("NewAddressBech32PubKeyHash: unexpected error: %v", err)
+	}
+	bech32SH, err := exccutil.NewAddressBech32ScriptHashFromHash(hash, net)
+	if err != nil {
+		t.Fatalf("NewAddressBech32ScriptHashFromHash: unexpected error: %v",
+			err)
+	}
+
+	for _, addr := range []exccutil.Address{pkh, sh} {
+		encoded := addr.EncodeAddress()
+		if !strings.HasPrefix(encoded, net.NetworkAddressPrefix) {
+			t.Errorf("%v: unexpected network prefix", encoded)
+		}
+		decoded, err := exccutil.DecodeAddress(encoded)
+		if err != nil {
+			t.Errorf("%v: unexpected decode error: %v", encoded, err)
+			continue
+		}
+		if !reflect.DeepEqual(decoded, addr) {
+			t.Errorf("%v: decoded to %#v, want %#v", encoded, decoded,
+				addr)
+		}
+		if decoded.Net() != net {
+			t.Errorf("%v: decoded for network %v, want %v", encoded,
+				decoded.Net().Name, net.Name)
+		}
+	}
+
+	for _, addr := range []*exccutil.AddressBech32{bech32PKH, bech32SH} {
+		encoded := addr.EncodeAddress()
+		if !strings.HasPrefix(encoded, net.Bech32HRP+"1") {
+			t.Errorf("%v: unexpected human-readable part", encoded)
+		}
+		decoded, err := exccutil.DecodeAddress(encoded)
+		if err != nil {
+			t.Errorf("%v: unexpected decode error: %v", encoded, err)
+			continue
+		}
+		if !reflect.DeepEqual(decoded, addr) {
+			t.Errorf("%v: decoded to %#v, want %#v", encoded, decoded,
+				addr)
+		}
+		if decoded.Net() != net {
+			t.Errorf("%v: decoded for network %v, want %v", encoded,
+				decoded.Net().Name, net.Name)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Copyright (c) 2017 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package bech32
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// charset is the set of characters used in the data part of a bech32
+	// string.  The index of a character is the 5-bit value it encodes.
+	charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+	// checksumLen is the number of characters of the checksum.
+	checksumLen = 6
+
+	// maxLen is the maximum length of a bech32 string.
+	maxLen = 90
+)
+
+// gen is the generator of the BCH code used for the checksum.
+var gen = [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+
+// polymod calculates the BCH checksum of the passed 5-bit values.
+func polymod(values []byte) uint32 {
+	chk := uint32(1)
+	for _, v := range values {
+		b := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (b>>uint(i))&1 == 1 {
+				chk ^= gen[i]
+			}
+		}
+	}
+	return chk
+}
+
+// hrpExpand expands the human-readable part into the values which are
+// included in the checksum.
+func hrpExpand(hrp string) []byte {
+	expanded := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]>>5)
+	}
+	expanded = append(expanded, 0)
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]&31)
+	}
+	return expanded
+}
+
+// createChecksum returns the checksum of the passed human-readable part and
+// 5-bit data values.
+func createChecksum(hrp string, data []byte) []byte {
+	values := append(hrpExpand(hrp), data...)
+	values = append(values, make([]byte, checksumLen)...)
+	mod := polymod(values) ^ 1
+	checksum := make([]byte, checksumLen)
+	for i := range checksum {
+		checksum[i] = byte((mod >> uint(5*(5-i))) & 31)
+	}
+	return checksum
+}
+
+// verifyChecksum returns whether or not the passed 5-bit data values, which
+// include the checksum, are valid for the human-readable part.
+func verifyChecksum(hrp string, data []byte) bool {
+	return polymod(append(hrpExpand(hrp), data...)) == 1
+}
+
+// Encode encodes the passed human-readable part and 5-bit data values as a
+// bech32 string.  The human-readable part is converted to lowercase.
+func Encode(hrp string, data []byte) (string, error) {
+	if len(hrp) == 0 {
+		return "", fmt.Errorf("empty human-readable part")
+	}
+	if len(hrp)+len(data)+1+checksumLen > maxLen {
+		return "", fmt.Errorf("encoded length exceeds maximum of %d", maxLen)
+	}
+	hrp = strings.ToLower(hrp)
+	for i := 0; i < len(hrp); i++ {
+		if hrp[i] < 33 || hrp[i] > 126 {
+			return "", fmt.Errorf("invalid character in human-readable "+
+				"part at position %d", i)
+		}
+	}
+
+	encoded := make([]byte, 0, len(hrp)+1+len(data)+checksumLen)
+	encoded = append(encoded, hrp...)
+	encoded = append(encoded, '1')
+	for _, v := range data {
+		if int(v) >= len(charset) {
+			return "", fmt.Errorf("invalid data value %d", v)
+		}
+		encoded = append(encoded, charset[v])
+	}
+	for _, v := range createChecksum(hrp, data) {
+		encoded = append(encoded, charset[v])
+	}
+	return string(encoded), nil
+}
+
+// Decode decodes the passed bech32 string and returns the lowercase
+// human-readable part along with the 5-bit data values, excluding the
+// checksum.  Strings which mix uppercase and lowercase characters are
+// rejected.
+func Decode(bech string) (string, []byte, error) {
+	if len(bech) < 8 || len(bech) > maxLen {
+		return "", nil, fmt.Errorf("invalid length %d", len(bech))
+	}
+	for i := 0; i < len(bech); i++ {
+		if bech[i] < 33 || bech[i] > 126 {
+			return "", nil, fmt.Errorf("invalid character at "+
+				"position %d", i)
+		}
+	}
+	lower := strings.ToLower(bech)
+	if bech != lower && bech != strings.ToUpper(bech) {
+		return "", nil, fmt.Errorf("string uses mixed case")
+	}
+	bech = lower
+
+	// The separator is the last '1' since the human-readable part may
+	// contain it while the data part may not.
+	sep := strings.LastIndexByte(bech, '1')
+	if sep < 1 || sep+checksumLen+1 > len(bech) {
+		return "", nil, fmt.Errorf("invalid separator position %d", sep)
+	}
+	hrp := bech[:sep]
+	data := make([]byte, 0, len(bech)-sep-1)
+	for i := sep + 1; i < len(bech); i++ {
+		v := strings.IndexByte(charset, bech[i])
+		if v == -1 {
+			return "", nil, fmt.Errorf("invalid data character %q at "+
+				"position %d", bech[i], i)
+		}
+		data = append(data, byte(v))
+	}
+	if !verifyChecksum(hrp, data) {
+		return "", nil, fmt.Errorf("invalid checksum")
+	}
+	return hrp, data[:len(data)-checksumLen], nil
+}
+
+// ConvertBits regroups the passed data from groups of fromBits bits to groups
+// of toBits bits, which must both be between 1 and 8.  When pad is true, the
+// final group is padded with zero bits.  Otherwise, an error is returned when
+// the data does not divide evenly into groups, except for up to fromBits-1
+// zero padding bits.
+func ConvertBits(data []byte, fromBits, toBits uint8, pad bool) ([]byte, error) {
+	if fromBits < 1 || fromBits > 8 || toBits < 1 || toBits > 8 {
+		return nil, fmt.Errorf("invalid group size")
+	}
+
+	var acc uint32
+	var bits uint8
+	maxv := uint32(1)<<toBits - 1
+	regrouped := make([]byte, 0, len(data)*int(fromBits)/int(toBits)+1)
+	for _, v := range data {
+		if v>>fromBits != 0 {
+			return nil, fmt.Errorf("invalid data value %d", v)
+		}
+		acc = acc<<fromBits | uint32(v)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			regrouped = append(regrouped, byte(acc>>bits&maxv))
+		}
+	}
+	if pad {
+		if bits > 0 {
+			regrouped = append(regrouped, byte(acc<<(toBits-bits)&maxv))
+		}
+	} else if bits >= fromBits || acc<<(toBits-bits)&maxv != 0 {
+		return nil, fmt.Errorf("invalid padding")
+	}
+	return regrouped, nil
+}
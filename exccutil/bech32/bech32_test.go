@@ -0,0 +1,89 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Copyright (c) 2017 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package bech32
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestBech32 ensures the BIP173 test vectors are decoded as expected and that
+// valid strings are encoded back to their lowercase form.
+func TestBech32(t *testing.T) {
+	tests := []struct {
+		str   string
+		valid bool
+	}{
+		{"A12UEL5L", true},
+		{"a12uel5l", true},
+		{"an83characterlonghumanreadablepartthatcontainsthenumber1andtheexcludedcharactersbio1tt5tgs", true},
+		{"abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw", true},
+		{"11" + strings.Repeat("q", 82) + "c8247j", true},
+		{"split1checkupstagehandshakeupstreamerranterredcaperred2y9e3w", true},
+		{"split1checkupstagehandshakeupstreamerranterredcaperred2y9e2w", false},                                // invalid checksum
+		{"s lit1checkupstagehandshakeupstreamerranterredcaperredp8hs2p", false},                                // invalid character in hrp
+		{"spl\x7Ft1checkupstagehandshakeupstreamerranterredcaperred2y9e3w", false},                             // invalid character in hrp
+		{"split1cheo2y9e2w", false},                                                                            // invalid character in data
+		{"split1a2y9w", false},                                                                                 // too short data part
+		{"1checkupstagehandshakeupstreamerranterredcaperred2y9e3w", false},                                     // empty hrp
+		{"an84characterslonghumanreadablepartthatcontainsthenumber1andtheexcludedcharactersbio1569pvx", false}, // too long
+		{"A1G7SGD8", false},     // invalid checksum
+		{"A12UeL5L", false},     // mixed case
+		{"pzry9x0s0muk", false}, // no separator
+	}
+
+	for _, test := range tests {
+		hrp, data, err := Decode(test.str)
+		if test.valid != (err == nil) {
+			t.Errorf("Decode(%q): valid %v, error %v", test.str,
+				test.valid, err)
+			continue
+		}
+		if !test.valid {
+			continue
+		}
+
+		encoded, err := Encode(hrp, data)
+		if err != nil {
+			t.Errorf("Encode(%q): unexpected error: %v", test.str, err)
+			continue
+		}
+		if encoded != strings.ToLower(test.str) {
+			t.Errorf("Encode(%q): got %q", test.str, encoded)
+		}
+	}
+}
+
+// TestConvertBits ensures data is regrouped between group sizes with and
+// without padding.
+func TestConvertBits(t *testing.T) {
+	data := []byte{0xff, 0x00, 0xa5}
+	regrouped, err := ConvertBits(data, 8, 5, true)
+	if err != nil {
+		t.Fatalf("ConvertBits: unexpected error: %v", err)
+	}
+	want := []byte{31, 28, 0, 10, 10}
+	if !bytes.Equal(regrouped, want) {
+		t.Fatalf("ConvertBits: got %v, want %v", regrouped, want)
+	}
+	original, err := ConvertBits(regrouped, 5, 8, false)
+	if err != nil {
+		t.Fatalf("ConvertBits: unexpected error: %v", err)
+	}
+	if !bytes.Equal(original, data) {
+		t.Fatalf("ConvertBits: got %v, want %v", original, data)
+	}
+
+	// Nonzero padding bits and values which exceed the group size must be
+	// rejected.
+	if _, err := ConvertBits([]byte{31, 28, 0, 10, 11}, 5, 8, false); err == nil {
+		t.Error("ConvertBits: nonzero padding accepted")
+	}
+	if _, err := ConvertBits([]byte{32}, 5, 8, true); err == nil {
+		t.Error("ConvertBits: out of range value accepted")
+	}
+}
@@ -0,0 +1,20 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+/*
+Package bech32 provides a bech32 encoding and decoding implementation.
+
+# Overview
+
+Bech32 is the base32 encoding with a BCH checksum specified by BIP173.  An
+encoded string consists of a human-readable part, the separator '1', and the
+data part, which is followed by a six character checksum that detects any
+error affecting up to four characters.  The encoding only uses a single case
+and omits characters that are easily confused, which makes it well suited to
+be read aloud, typed, and encoded in QR codes.
+
+The data part is encoded as 5-bit groups, so ConvertBits is provided to
+regroup data between 8-bit bytes and 5-bit groups.
+*/
+package bech32
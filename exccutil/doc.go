@@ -29,6 +29,12 @@ may well support more in the future.  This package currently provides
 implementations for the pay-to-pubkey, pay-to-pubkey-hash, and
 pay-to-script-hash address types.
 
+Pay-to-pubkey-hash and pay-to-script-hash addresses may also be encoded with
+bech32 instead of base58 on networks which define a bech32 human-readable part.
+The bech32 encoding uses a single case and a checksum which detects more
+errors, while the addresses pay to the same scripts as their base58
+counterparts.
+
 To decode/encode an address:
 
 	// NOTE: The default network is only used for address types which do not
@@ -0,0 +1,49 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"github.com/EXCCoin/exccd/wire"
+)
+
+const (
+	// extraNonceRigBits is the number of high bits of the extra nonces in
+	// block headers and coinbase transactions which hold the identifier of
+	// the rig when one is configured.
+	extraNonceRigBits = 16
+
+	// extraNonceRigShift is the number of bits the rig identifier is
+	// shifted left by in extra nonces.
+	extraNonceRigShift = 64 - extraNonceRigBits
+
+	// extraNonceRigMask is the mask of the bits of extra nonces which are
+	// searched by each rig.
+	extraNonceRigMask = 1<<extraNonceRigShift - 1
+
+	// maxMiningRigID is the maximum identifier of a rig.
+	maxMiningRigID = 1<<extraNonceRigBits - 1
+)
+
+// rigExtraNonce returns the passed extra nonce moved into the part of the extra
+// nonce space reserved for the rig with the passed identifier.  Since the part
+// reserved for each rig is disjoint, several rigs mining to the same address
+// never search the same block headers.  A rig identifier of zero means no rig
+// identifier is configured, in which case the whole space is used.
+func rigExtraNonce(rigID uint16, extraNonce uint64) uint64 {
+	if rigID == 0 {
+		return extraNonce
+	}
+	return uint64(rigID)<<extraNonceRigShift | extraNonce&extraNonceRigMask
+}
+
+// randomExtraNonce returns a random extra nonce within the part of the extra
+// nonce space reserved for the configured rig.
+func randomExtraNonce() (uint64, error) {
+	extraNonce, err := wire.RandomUint64()
+	if err != nil {
+		return 0, err
+	}
+	return rigExtraNonce(cfg.MiningRigID, extraNonce), nil
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import "testing"
+
+// TestRigExtraNonce ensures extra nonces are moved into the part of the extra
+// nonce space reserved for the configured rig and that incrementing them stays
+// within it.
+func TestRigExtraNonce(t *testing.T) {
+	tests := []struct {
+		name       string
+		rigID      uint16
+		extraNonce uint64
+		want       uint64
+	}{
+		{"no rig id", 0, 0xfedcba9876543210, 0xfedcba9876543210},
+		{"rig id 1", 1, 0xfedcba9876543210, 0x0001ba9876543210},
+		{"max rig id", maxMiningRigID, 0x0123456789abcdef, 0xffff456789abcdef},
+		{"wraps within rig space", 2, 0x0003000000000000, 0x0002000000000000},
+	}
+	for _, test := range tests {
+		got := rigExtraNonce(test.rigID, test.extraNonce)
+		if got != test.want {
+			t.Errorf("%s: unexpected extra nonce: got %x, want %x",
+				test.name, got, test.want)
+		}
+	}
+
+	// Incrementing the last extra nonce of a rig wraps around to its first
+	// one instead of moving into the space of the next rig.
+	last := rigExtraNonce(7, extraNonceRigMask)
+	if got, want := rigExtraNonce(7, last+1), rigExtraNonce(7, 0); got != want {
+		t.Errorf("unexpected extra nonce after the last one: got %x, "+
+			"want %x", got, want)
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/EXCCoin/exccd/blockchain"
+	"github.com/EXCCoin/exccd/chaincfg/chainhash"
+	"github.com/EXCCoin/exccd/wire"
+)
+
+// gbtMaxIssuedTemplates is the maximum number of block templates issued by the
+// getblocktemplate RPC which are kept to validate the blocks submitted for
+// them.  Templates for blocks more than workExpirationDiff below the newest one
+// are also pruned.
+const gbtMaxIssuedTemplates = 64
+
+// gbtIssuedTemplate houses the details of a block template issued by the
+// getblocktemplate RPC needed to validate the mutations made to it by the
+// blocks submitted for it.
+type gbtIssuedTemplate struct {
+	prevHash      chainhash.Hash
+	height        uint32
+	version       int32
+	bits          uint32
+	minTime       time.Time
+	txHashes      []chainhash.Hash
+	stakeTxHashes []chainhash.Hash
+}
+
+// newGbtIssuedTemplate returns the details of the passed block template with
+// the passed minimum allowed timestamp needed to validate the mutations made to
+// it.
+func newGbtIssuedTemplate(msgBlock *wire.MsgBlock, minTime time.Time) *gbtIssuedTemplate {
+	t := &gbtIssuedTemplate{
+		prevHash: msgBlock.Header.PrevBlock,
+		height:   msgBlock.Header.Height,
+		version:  msgBlock.Header.Version,
+		bits:     msgBlock.Header.Bits,
+		minTime:  minTime,
+	}
+
+	// The coinbase is created or appended to by the miner, so it is not
+	// kept.
+	t.txHashes = make([]chainhash.Hash, 0, len(msgBlock.Transactions))
+	for _, tx := range msgBlock.Transactions[1:] {
+		t.txHashes = append(t.txHashes, tx.TxHash())
+	}
+	t.stakeTxHashes = make([]chainhash.Hash, 0, len(msgBlock.STransactions))
+	for _, stx := range msgBlock.STransactions {
+		t.stakeTxHashes = append(t.stakeTxHashes, stx.TxHash())
+	}
+	return t
+}
+
+// checkMutations checks that the passed block submitted for the template only
+// makes the mutations allowed by gbtMutableFields given the passed current best
+// block hash and maximum allowed timestamp.  The BIP0022 reason it is rejected
+// for is returned when it does not, and an empty string otherwise.
+//
+// Miners may build on a newer best block than the one the template was
+// generated for, in which case the transactions and header fields derived from
+// the parent may change as required by the new parent, so only the chain
+// consensus rules apply to them.  Otherwise, the block must keep the version,
+// difficulty, and stake transactions of the template, keep its timestamp within
+// the allowed range, and include all of its transactions, but it may add more.
+func (t *gbtIssuedTemplate) checkMutations(msgBlock *wire.MsgBlock, bestHash *chainhash.Hash, maxTime time.Time) string {
+	header := &msgBlock.Header
+	if header.PrevBlock != t.prevHash {
+		if header.PrevBlock != *bestHash {
+			return "bad-prevblk"
+		}
+		return ""
+	}
+
+	switch {
+	case header.Version != t.version:
+		return "bad-version"
+	case header.Bits != t.bits:
+		return "bad-diffbits"
+	case header.Timestamp.Before(t.minTime):
+		return "time-too-old"
+	case header.Timestamp.After(maxTime):
+		return "time-too-new"
+	}
+
+	if len(msgBlock.STransactions) != len(t.stakeTxHashes) {
+		return "bad-stxns-mutated"
+	}
+	for i, stx := range msgBlock.STransactions {
+		if stx.TxHash() != t.stakeTxHashes[i] {
+			return "bad-stxns-mutated"
+		}
+	}
+
+	txHashes := make(map[chainhash.Hash]struct{}, len(msgBlock.Transactions))
+	for _, tx := range msgBlock.Transactions {
+		txHashes[tx.TxHash()] = struct{}{}
+	}
+	for i := range t.txHashes {
+		if _, ok := txHashes[t.txHashes[i]]; !ok {
+			return "bad-txns-removed"
+		}
+	}
+	return ""
+}
+
+// issueTemplate records the passed details of the block template issued as
+// work with the passed ID, so blocks submitted for it can be validated, and
+// prunes the templates for old blocks.
+//
+// This function MUST be called with the state locked.
+func (state *gbtWorkState) issueTemplate(id workID, t *gbtIssuedTemplate) {
+	for oldID, old := range state.issued {
+		if int64(old.height) < int64(t.height)-workExpirationDiff {
+			delete(state.issued, oldID)
+		}
+	}
+	for len(state.issued) >= gbtMaxIssuedTemplates {
+		oldestID := id
+		for oldID := range state.issued {
+			if oldID < oldestID {
+				oldestID = oldID
+			}
+		}
+		delete(state.issued, oldestID)
+	}
+	state.issued[id] = t
+	state.workID = id
+}
+
+// parseGbtWorkID parses the passed work ID returned with a block template by
+// the getblocktemplate RPC.
+func parseGbtWorkID(s string) (workID, bool) {
+	id, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return workID(id), true
+}
+
+// checkSubmission checks that the passed block submitted for the block template
+// with the passed work ID only makes the mutations allowed by gbtMutableFields
+// given the passed current best block hash.  The BIP0022 reason it is rejected
+// for is returned when it does not, and an empty string otherwise.
+//
+// This function is safe for concurrent access.
+func (state *gbtWorkState) checkSubmission(id workID, msgBlock *wire.MsgBlock, bestHash *chainhash.Hash) string {
+	state.Lock()
+	t, ok := state.issued[id]
+	state.Unlock()
+	if !ok {
+		return "unknown-work"
+	}
+
+	maxTime := state.timeSource.AdjustedTime().Add(time.Second *
+		blockchain.MaxTimeOffsetSeconds)
+	return t.checkMutations(msgBlock, bestHash, maxTime)
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/EXCCoin/exccd/chaincfg/chainhash"
+	"github.com/EXCCoin/exccd/wire"
+)
+
+// TestGbtCheckMutations ensures blocks submitted for block templates issued by
+// the getblocktemplate RPC are only allowed to make the mutations listed in
+// gbtMutableFields.
+func TestGbtCheckMutations(t *testing.T) {
+	prevHash := chainhash.Hash{0x01}
+	newerHash := chainhash.Hash{0x02}
+	minTime := time.Unix(1530000000, 0)
+	maxTime := minTime.Add(time.Hour)
+
+	// newTx returns a transaction which is distinguished by the passed
+	// lock time.
+	newTx := func(lockTime uint32) *wire.MsgTx {
+		tx := wire.NewMsgTx()
+		tx.LockTime = lockTime
+		return tx
+	}
+
+	// newBlock returns a block template building on the passed block with
+	// a coinbase, two transactions, and a stake transaction.
+	newBlock := func(prevHash chainhash.Hash) *wire.MsgBlock {
+		return &wire.MsgBlock{
+			Header: wire.BlockHeader{
+				Version:   6,
+				PrevBlock: prevHash,
+				Bits:      0x1d00ffff,
+				Timestamp: minTime.Add(time.Minute),
+				Height:    100,
+			},
+			Transactions:  []*wire.MsgTx{newTx(0), newTx(1), newTx(2)},
+			STransactions: []*wire.MsgTx{newTx(3)},
+		}
+	}
+	template := newGbtIssuedTemplate(newBlock(prevHash), minTime)
+
+	tests := []struct {
+		name   string
+		mutate func(b *wire.MsgBlock)
+		want   string
+	}{{
+		name:   "unchanged",
+		mutate: func(b *wire.MsgBlock) {},
+	}, {
+		name: "time, coinbase, and added transaction",
+		mutate: func(b *wire.MsgBlock) {
+			b.Header.Timestamp = maxTime
+			b.Transactions[0] = newTx(10)
+			b.Transactions = append(b.Transactions, newTx(11))
+		},
+	}, {
+		name: "reordered transactions",
+		mutate: func(b *wire.MsgBlock) {
+			b.Transactions[1], b.Transactions[2] = b.Transactions[2],
+				b.Transactions[1]
+		},
+	}, {
+		name: "newer best block",
+		mutate: func(b *wire.MsgBlock) {
+			b.Header.PrevBlock = newerHash
+			b.Header.Bits = 0x1c00ffff
+			b.Transactions = b.Transactions[:1]
+			b.STransactions[0] = newTx(12)
+		},
+	}, {
+		name:   "unknown parent",
+		mutate: func(b *wire.MsgBlock) { b.Header.PrevBlock = chainhash.Hash{0x03} },
+		want:   "bad-prevblk",
+	}, {
+		name:   "version",
+		mutate: func(b *wire.MsgBlock) { b.Header.Version++ },
+		want:   "bad-version",
+	}, {
+		name:   "difficulty",
+		mutate: func(b *wire.MsgBlock) { b.Header.Bits = 0x1c00ffff },
+		want:   "bad-diffbits",
+	}, {
+		name: "time too old",
+		mutate: func(b *wire.MsgBlock) {
+			b.Header.Timestamp = minTime.Add(-time.Second)
+		},
+		want: "time-too-old",
+	}, {
+		name: "time too new",
+		mutate: func(b *wire.MsgBlock) {
+			b.Header.Timestamp = maxTime.Add(time.Second)
+		},
+		want: "time-too-new",
+	}, {
+		name:   "removed transaction",
+		mutate: func(b *wire.MsgBlock) { b.Transactions = b.Transactions[:2] },
+		want:   "bad-txns-removed",
+	}, {
+		name: "added stake transaction",
+		mutate: func(b *wire.MsgBlock) {
+			b.STransactions = append(b.STransactions, newTx(13))
+		},
+		want: "bad-stxns-mutated",
+	}, {
+		name:   "replaced stake transaction",
+		mutate: func(b *wire.MsgBlock) { b.STransactions[0] = newTx(14) },
+		want:   "bad-stxns-mutated",
+	}}
+	for _, test := range tests {
+		block := newBlock(prevHash)
+		test.mutate(block)
+		got := template.checkMutations(block, &newerHash, maxTime)
+		if got != test.want {
+			t.Errorf("%s: unexpected result %q, want %q", test.name,
+				got, test.want)
+		}
+	}
+}
+
+// TestGbtIssueTemplate ensures the block templates issued by the
+// getblocktemplate RPC are pruned once they are for old blocks or there are too
+// many of them.
+func TestGbtIssueTemplate(t *testing.T) {
+	state := &gbtWorkState{issued: make(map[workID]*gbtIssuedTemplate)}
+	issue := func(id workID, height uint32) {
+		state.issueTemplate(id, &gbtIssuedTemplate{height: height})
+	}
+
+	issue(1, 100)
+	issue(2, 101)
+	issue(5, 103)
+	issue(6, 104)
+	for id, want := range map[workID]bool{1: false, 2: true, 5: true, 6: true} {
+		if _, ok := state.issued[id]; ok != want {
+			t.Errorf("unexpected template %d kept: %v, want %v", id,
+				ok, want)
+		}
+	}
+	if state.workID != 6 {
+		t.Errorf("unexpected current work ID %d, want 6", state.workID)
+	}
+
+	for id := workID(7); id < 7+gbtMaxIssuedTemplates; id++ {
+		issue(id, 104)
+	}
+	if len(state.issued) != gbtMaxIssuedTemplates {
+		t.Fatalf("unexpected number of templates %d, want %d",
+			len(state.issued), gbtMaxIssuedTemplates)
+	}
+	if _, ok := state.issued[6]; ok {
+		t.Error("oldest template was not pruned")
+	}
+}
@@ -48,6 +48,16 @@ the HardenedKeyStart constant + the hardened key number as the index to the
 Child function.  This provides the ability to cascade the keys into a tree and
 hence generate the hierarchical deterministic key chains.
 
+Derivation Paths
+
+A sequence of child derivations is described by a derivation path such as
+m/44'/0'/0'/0/1, where an apostrophe denotes a hardened index.  The ParsePath
+and FormatPath functions convert between the textual form and child indexes,
+and the DerivePath function derives the extended key at the end of a path.  The
+BIP44AccountPath function returns the path of a BIP0044 account using the coin
+type of the network, and the ExternalBranch and InternalBranch constants
+identify the receiving and change branches of an account.
+
 Normal vs Hardened Child Extended Keys
 
 A private extended key can be used to derive both hardened and non-hardened
@@ -0,0 +1,114 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package hdkeychain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/EXCCoin/exccd/chaincfg"
+)
+
+const (
+	// BIP44Purpose is the index of the hardened purpose level of BIP0044
+	// derivation paths.
+	BIP44Purpose = 44
+
+	// ExternalBranch is the index of the BIP0044 branch of an account which
+	// is used for addresses that are given out to receive payments.
+	ExternalBranch = 0
+
+	// InternalBranch is the index of the BIP0044 branch of an account which
+	// is used for change addresses.
+	InternalBranch = 1
+)
+
+// ParsePath parses a derivation path such as "m/44'/0'/0'/0/1" into the child
+// indexes it is composed of.  Hardened indexes are denoted by a trailing
+// apostrophe or 'h' and have HardenedKeyStart added to them.  The leading "m"
+// is optional.
+func ParsePath(path string) ([]uint32, error) {
+	elems := strings.Split(path, "/")
+	if elems[0] == "m" {
+		elems = elems[1:]
+	}
+	indexes := make([]uint32, 0, len(elems))
+	for _, elem := range elems {
+		hardened := strings.HasSuffix(elem, "'") ||
+			strings.HasSuffix(elem, "h")
+		if hardened {
+			elem = elem[:len(elem)-1]
+		}
+		i, err := strconv.ParseUint(elem, 10, 32)
+		if err != nil || i >= HardenedKeyStart {
+			return nil, fmt.Errorf("invalid derivation path element "+
+				"%q in path %q", elem, path)
+		}
+		if hardened {
+			i += HardenedKeyStart
+		}
+		indexes = append(indexes, uint32(i))
+	}
+	return indexes, nil
+}
+
+// FormatPath returns the passed child indexes as a derivation path in the
+// format accepted by ParsePath.
+func FormatPath(path []uint32) string {
+	elems := make([]string, 0, len(path)+1)
+	elems = append(elems, "m")
+	for _, i := range path {
+		if i >= HardenedKeyStart {
+			elems = append(elems, strconv.FormatUint(uint64(
+				i-HardenedKeyStart), 10)+"'")
+			continue
+		}
+		elems = append(elems, strconv.FormatUint(uint64(i), 10))
+	}
+	return strings.Join(elems, "/")
+}
+
+// BIP44AccountPath returns the BIP0044 derivation path of the extended key of
+// the passed account for the passed network, which is m/44'/<coin type>'/
+// <account>' where the coin type is the HDCoinType of the network.  The account
+// must be less than HardenedKeyStart.
+func BIP44AccountPath(net *chaincfg.Params, account uint32) []uint32 {
+	return []uint32{
+		HardenedKeyStart + BIP44Purpose,
+		HardenedKeyStart + net.HDCoinType,
+		HardenedKeyStart + account,
+	}
+}
+
+// DerivePath returns the extended key derived from this one by deriving the
+// child at each of the passed indexes in turn.  The same restrictions as for
+// Child apply, so ErrDeriveHardFromPublic is returned when the path contains a
+// hardened index and this is a public extended key, and ErrInvalidChild is
+// returned in the extremely unlikely case that any of the keys along the path
+// is invalid.
+func (k *ExtendedKey) DerivePath(path []uint32) (*ExtendedKey, error) {
+	key := k
+	for _, i := range path {
+		var err error
+		key, err = key.Child(i)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
+}
+
+// Depth returns the number of derivations between the master node and this
+// extended key.  It is zero for the master node.
+func (k *ExtendedKey) Depth() uint16 {
+	return k.depth
+}
+
+// ChildIndex returns the index at which this extended key was derived from its
+// parent.  Indexes of hardened extended keys include HardenedKeyStart.
+func (k *ExtendedKey) ChildIndex() uint32 {
+	return k.childNum
+}
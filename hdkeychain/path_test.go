@@ -0,0 +1,126 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package hdkeychain_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/EXCCoin/exccd/chaincfg"
+	"github.com/EXCCoin/exccd/hdkeychain"
+)
+
+// TestParsePath ensures derivation paths are parsed into the expected child
+// indexes and formatted back into their canonical form.
+func TestParsePath(t *testing.T) {
+	const hkStart = hdkeychain.HardenedKeyStart
+	tests := []struct {
+		path      string
+		indexes   []uint32
+		canonical string
+	}{
+		{"m", []uint32{}, "m"},
+		{"m/0", []uint32{0}, "m/0"},
+		{"m/44'/0'/3'/1/7", []uint32{hkStart + 44, hkStart, hkStart + 3,
+			1, 7}, "m/44'/0'/3'/1/7"},
+		{"44h/2147483647", []uint32{hkStart + 44, hkStart - 1},
+			"m/44'/2147483647"},
+	}
+	for _, test := range tests {
+		indexes, err := hdkeychain.ParsePath(test.path)
+		if err != nil {
+			t.Errorf("ParsePath(%q): unexpected error: %v", test.path, err)
+			continue
+		}
+		if !reflect.DeepEqual(indexes, test.indexes) {
+			t.Errorf("ParsePath(%q): got %v, want %v", test.path,
+				indexes, test.indexes)
+		}
+		if path := hdkeychain.FormatPath(indexes); path != test.canonical {
+			t.Errorf("FormatPath(%v): got %q, want %q", indexes, path,
+				test.canonical)
+		}
+	}
+
+	invalid := []string{"", "m/", "m//0", "m/x", "m/-1", "m/0''",
+		"m/2147483648", "m/1/m"}
+	for _, path := range invalid {
+		if _, err := hdkeychain.ParsePath(path); err == nil {
+			t.Errorf("ParsePath(%q): invalid path parsed without error",
+				path)
+		}
+	}
+}
+
+// TestDerivePath ensures deriving a path is equivalent to deriving each child
+// in turn and that BIP0044 account paths use the coin type of the network.
+func TestDerivePath(t *testing.T) {
+	net := &chaincfg.TestNet2Params
+	seed := bytes.Repeat([]byte{0x2a}, hdkeychain.RecommendedSeedLen)
+	master, err := hdkeychain.NewMaster(seed, net)
+	if err != nil {
+		t.Fatalf("NewMaster: unexpected error: %v", err)
+	}
+
+	accountPath := hdkeychain.BIP44AccountPath(net, 2)
+	if got := hdkeychain.FormatPath(accountPath); got != "m/44'/1'/2'" {
+		t.Fatalf("BIP44AccountPath: got %q, want %q", got, "m/44'/1'/2'")
+	}
+	path := append(accountPath, hdkeychain.ExternalBranch, 5)
+	derived, err := master.DerivePath(path)
+	if err != nil {
+		t.Fatalf("DerivePath: unexpected error: %v", err)
+	}
+
+	want := master
+	for _, i := range path {
+		want, err = want.Child(i)
+		if err != nil {
+			t.Fatalf("Child: unexpected error: %v", err)
+		}
+	}
+	derivedPub, _ := derived.ECPubKey()
+	wantPub, _ := want.ECPubKey()
+	if !bytes.Equal(derivedPub.SerializeCompressed(),
+		wantPub.SerializeCompressed()) {
+		t.Fatal("DerivePath: derived key does not match child derivation")
+	}
+	if derived.Depth() != uint16(len(path)) || derived.ChildIndex() != 5 ||
+		!derived.IsPrivate() {
+		t.Fatalf("DerivePath: unexpected depth %d or child index %d",
+			derived.Depth(), derived.ChildIndex())
+	}
+	if master.Depth() != 0 {
+		t.Fatalf("unexpected master depth %d", master.Depth())
+	}
+
+	// Ensure non-hardened paths can be derived from the neutered account
+	// key while hardened paths can not.
+	account, err := master.DerivePath(accountPath)
+	if err != nil {
+		t.Fatalf("DerivePath: unexpected error: %v", err)
+	}
+	accountPub, err := account.Neuter()
+	if err != nil {
+		t.Fatalf("Neuter: unexpected error: %v", err)
+	}
+	derivedFromPub, err := accountPub.DerivePath([]uint32{
+		hdkeychain.ExternalBranch, 5})
+	if err != nil {
+		t.Fatalf("DerivePath: unexpected error: %v", err)
+	}
+	pub, _ := derivedFromPub.ECPubKey()
+	if derivedFromPub.IsPrivate() || !bytes.Equal(pub.SerializeCompressed(),
+		wantPub.SerializeCompressed()) {
+		t.Fatal("DerivePath: public derivation does not match private " +
+			"derivation")
+	}
+	_, err = accountPub.DerivePath(accountPath)
+	if err != hdkeychain.ErrDeriveHardFromPublic {
+		t.Fatalf("DerivePath: mismatched error -- got: %v, want: %v",
+			err, hdkeychain.ErrDeriveHardFromPublic)
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/EXCCoin/exccd/blockchain"
+	"github.com/EXCCoin/exccd/chaincfg/chainhash"
+	"github.com/EXCCoin/exccd/wire"
+)
+
+const (
+	// defaultHealthMaxBlockAge is the default maximum age of the best block
+	// for the chain to be considered synced by the readiness endpoint.
+	defaultHealthMaxBlockAge = time.Hour
+
+	// healthReadTimeout is the maximum amount of time a client may take to
+	// send a request to the health server.
+	healthReadTimeout = 10 * time.Second
+)
+
+// healthChain describes the chain state queried by the readiness endpoint.  It
+// is satisfied by *blockchain.BlockChain.
+type healthChain interface {
+	BestSnapshot() *blockchain.BestState
+	FetchHeader(hash *chainhash.Hash) (wire.BlockHeader, error)
+}
+
+// healthChainStatus describes the best block reported by the readiness
+// endpoint.  The ages are in seconds.
+type healthChainStatus struct {
+	Height      int64  `json:"height"`
+	Hash        string `json:"hash"`
+	BlockAge    int64  `json:"blockage"`
+	MaxBlockAge int64  `json:"maxblockage"`
+}
+
+// healthStatus is the JSON body returned by the health endpoints.
+type healthStatus struct {
+	Status string             `json:"status"`
+	Reason string             `json:"reason,omitempty"`
+	Chain  *healthChainStatus `json:"chain,omitempty"`
+}
+
+// healthServer serves lightweight HTTP endpoints which report whether the
+// process is alive (/healthz) and whether the chain is synced closely enough
+// to the network to serve requests (/readyz), for use by orchestrators such as
+// Kubernetes and by load balancers.
+//
+// The server is started before the block database is loaded so liveness can be
+// reported during long startup operations.  Readiness is reported as failing
+// until the chain is provided with setChain, and again once it is cleared
+// during shutdown.
+type healthServer struct {
+	maxBlockAge time.Duration
+	listener    net.Listener
+	httpServer  *http.Server
+
+	mtx   sync.RWMutex
+	chain healthChain
+	now   func() time.Time
+}
+
+// newHealthServer returns a health server which listens on the passed address
+// and reports the chain as not synced once its best block is older than the
+// passed maximum age.
+func newHealthServer(listenAddr string, maxBlockAge time.Duration) (*healthServer, error) {
+	listener, err := net.Listen("tcp", listenAddr)
+	if err != nil {
+		return nil, err
+	}
+	h := &healthServer{
+		maxBlockAge: maxBlockAge,
+		listener:    listener,
+		now:         time.Now,
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", h.handleHealthz)
+	mux.HandleFunc("/readyz", h.handleReadyz)
+	h.httpServer = &http.Server{
+		Handler:     mux,
+		ReadTimeout: healthReadTimeout,
+	}
+	return h, nil
+}
+
+// Start begins serving the health endpoints.
+func (h *healthServer) Start() {
+	exccLog.Infof("Health server listening on %s", h.listener.Addr())
+	go func() {
+		err := h.httpServer.Serve(h.listener)
+		if err != nil && err != http.ErrServerClosed {
+			exccLog.Errorf("Health server: %v", err)
+		}
+	}()
+}
+
+// Stop stops serving the health endpoints.
+func (h *healthServer) Stop() {
+	h.httpServer.Close()
+}
+
+// setChain sets the chain whose sync state is reported by the readiness
+// endpoint along with the function which provides the current time to compare
+// the best block against.  A nil chain reports the node as not ready.
+func (h *healthServer) setChain(chain healthChain, now func() time.Time) {
+	h.mtx.Lock()
+	h.chain = chain
+	if now != nil {
+		h.now = now
+	}
+	h.mtx.Unlock()
+}
+
+// writeHealthStatus writes the passed status as JSON with the passed HTTP
+// status code.
+func writeHealthStatus(w http.ResponseWriter, code int, status *healthStatus) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(status)
+}
+
+// handleHealthz reports that the process is alive.  It succeeds whenever the
+// server is able to respond.
+func (h *healthServer) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	writeHealthStatus(w, http.StatusOK, &healthStatus{Status: "ok"})
+}
+
+// handleReadyz reports whether the node is ready to serve requests, which is
+// the case once the chain has been loaded and its best block is no older than
+// the maximum block age.  The service unavailable status is returned
+// otherwise.
+func (h *healthServer) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	h.mtx.RLock()
+	chain, now := h.chain, h.now
+	h.mtx.RUnlock()
+
+	if chain == nil {
+		writeHealthStatus(w, http.StatusServiceUnavailable, &healthStatus{
+			Status: "unavailable",
+			Reason: "chain not loaded",
+		})
+		return
+	}
+
+	best := chain.BestSnapshot()
+	header, err := chain.FetchHeader(&best.Hash)
+	if err != nil {
+		writeHealthStatus(w, http.StatusServiceUnavailable, &healthStatus{
+			Status: "unavailable",
+			Reason: err.Error(),
+		})
+		return
+	}
+
+	status := &healthStatus{
+		Status: "ok",
+		Chain: &healthChainStatus{
+			Height:      best.Height,
+			Hash:        best.Hash.String(),
+			BlockAge:    int64(now().Sub(header.Timestamp) / time.Second),
+			MaxBlockAge: int64(h.maxBlockAge / time.Second),
+		},
+	}
+	if status.Chain.BlockAge > status.Chain.MaxBlockAge {
+		status.Status = "unavailable"
+		status.Reason = "chain not synced"
+		writeHealthStatus(w, http.StatusServiceUnavailable, status)
+		return
+	}
+	writeHealthStatus(w, http.StatusOK, status)
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/EXCCoin/exccd/blockchain"
+	"github.com/EXCCoin/exccd/chaincfg/chainhash"
+	"github.com/EXCCoin/exccd/wire"
+)
+
+// fakeHealthChain provides a best block with a fixed timestamp for testing the
+// readiness endpoint.
+type fakeHealthChain struct {
+	best      blockchain.BestState
+	timestamp time.Time
+}
+
+func (c *fakeHealthChain) BestSnapshot() *blockchain.BestState {
+	return &c.best
+}
+
+func (c *fakeHealthChain) FetchHeader(hash *chainhash.Hash) (wire.BlockHeader, error) {
+	return wire.BlockHeader{Height: uint32(c.best.Height),
+		Timestamp: c.timestamp}, nil
+}
+
+// TestHealthServer ensures the liveness endpoint always succeeds and the
+// readiness endpoint only succeeds once the chain is loaded and its best block
+// is no older than the maximum block age.
+func TestHealthServer(t *testing.T) {
+	h := &healthServer{maxBlockAge: time.Hour, now: time.Now}
+	request := func(handler http.HandlerFunc) (int, *healthStatus) {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		handler(rec, httptest.NewRequest("GET", "/", nil))
+		var status healthStatus
+		if err := json.NewDecoder(rec.Body).Decode(&status); err != nil {
+			t.Fatalf("unable to decode status: %v", err)
+		}
+		return rec.Code, &status
+	}
+
+	code, status := request(h.handleHealthz)
+	if code != http.StatusOK || status.Status != "ok" {
+		t.Fatalf("healthz: unexpected result %d %+v", code, status)
+	}
+
+	// The node is not ready until the chain is loaded.
+	code, status = request(h.handleReadyz)
+	if code != http.StatusServiceUnavailable ||
+		status.Reason != "chain not loaded" {
+
+		t.Fatalf("readyz: unexpected result %d %+v", code, status)
+	}
+
+	now := time.Unix(1500000000, 0)
+	chain := &fakeHealthChain{
+		best:      blockchain.BestState{Height: 100},
+		timestamp: now.Add(-2 * time.Hour),
+	}
+	h.setChain(chain, func() time.Time { return now })
+	code, status = request(h.handleReadyz)
+	if code != http.StatusServiceUnavailable ||
+		status.Reason != "chain not synced" || status.Chain == nil ||
+		status.Chain.Height != 100 || status.Chain.BlockAge != 7200 ||
+		status.Chain.MaxBlockAge != 3600 {
+
+		t.Fatalf("readyz: unexpected result %d %+v", code, status)
+	}
+
+	chain.timestamp = now.Add(-10 * time.Minute)
+	code, status = request(h.handleReadyz)
+	if code != http.StatusOK || status.Status != "ok" ||
+		status.Chain == nil || status.Chain.BlockAge != 600 {
+
+		t.Fatalf("readyz: unexpected result %d %+v", code, status)
+	}
+
+	// The node is no longer ready once the chain is cleared on shutdown.
+	h.setChain(nil, nil)
+	code, _ = request(h.handleReadyz)
+	if code != http.StatusServiceUnavailable {
+		t.Fatalf("readyz: unexpected status %d after shutdown", code)
+	}
+}
@@ -7,9 +7,14 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/EXCCoin/exccd/addrmgr"
 	"github.com/EXCCoin/exccd/blockchain"
@@ -19,31 +24,47 @@ import (
 	"github.com/EXCCoin/exccd/database"
 	"github.com/EXCCoin/exccd/mempool"
 	"github.com/EXCCoin/exccd/peer"
+	"github.com/EXCCoin/exccd/tracing"
 	"github.com/EXCCoin/exccd/txscript"
 	"github.com/btcsuite/btclog"
-	"github.com/jrick/logrotate/rotator"
 )
 
 // logWriter implements an io.Writer that outputs to both standard output and
-// the write-end pipe of an initialized log rotator.
-type logWriter struct{}
+// the log rotator of the subsystems it is used for, which is the main log
+// rotator unless the subsystems are logged to their own file.  The most recent
+// output is also kept in memory for crash reports.
+type logWriter struct {
+	rotator *logFileRotator
+}
 
-func (logWriter) Write(p []byte) (n int, err error) {
+func (w logWriter) Write(p []byte) (n int, err error) {
 	os.Stdout.Write(p)
-	if logRotator != nil {
-		logRotator.Write(p)
+	recentLogLines.Write(p)
+	r := w.rotator
+	if r == nil {
+		r = logRotator
+	}
+	if r != nil {
+		r.Write(p)
 	}
 	return len(p), nil
 }
 
+// logBackend describes the logging backends which create subsystem loggers.
+// It is implemented by the backends of both the text and JSON log formats.
+type logBackend interface {
+	Logger(subsystem string) btclog.Logger
+}
+
 // Loggers per subsystem.  A single backend logger is created and all subsytem
-// loggers created from it will write to the backend.  When adding new
-// subsystems, add the subsystem logger variable here and to the
-// subsystemLoggers map.
+// loggers created from it will write to the backend.  Once the configuration is
+// loaded, the subsystem loggers are replaced by loggers of the configured log
+// format which write to the configured log files.  When adding new subsystems,
+// add the subsystem logger variable here and to useSubsystemLoggers.
 //
-// Loggers can not be used before the log rotator has been initialized with a
-// log file.  This must be performed early during application startup by calling
-// initLogRotator.
+// Loggers can not be used before the log rotators have been initialized with
+// log files.  This must be performed early during application startup by
+// calling initLogRotators.
 var (
 	// backendLog is the logging backend used to create all subsystem loggers.
 	// The backend must not be used before the log rotator has been initialized,
@@ -52,28 +73,63 @@ var (
 
 	// logRotator is one of the logging outputs.  It should be closed on
 	// application shutdown.
-	logRotator *rotator.Rotator
-
-	adxrLog = backendLog.Logger("ADXR")
-	amgrLog = backendLog.Logger("AMGR")
-	cmgrLog = backendLog.Logger("CMGR")
-	bcdbLog = backendLog.Logger("BCDB")
-	bmgrLog = backendLog.Logger("BMGR")
-	exccLog = backendLog.Logger("EXCC")
-	chanLog = backendLog.Logger("CHAN")
-	discLog = backendLog.Logger("DISC")
-	indxLog = backendLog.Logger("INDX")
-	minrLog = backendLog.Logger("MINR")
-	peerLog = backendLog.Logger("PEER")
-	rpcsLog = backendLog.Logger("RPCS")
-	scrpLog = backendLog.Logger("SCRP")
-	srvrLog = backendLog.Logger("SRVR")
-	stkeLog = backendLog.Logger("STKE")
-	txmpLog = backendLog.Logger("TXMP")
+	logRotator *logFileRotator
+
+	// subsystemLogRotators are the logging outputs of the subsystems which
+	// are logged to their own file instead of the main log file.  They
+	// should be closed on application shutdown.
+	subsystemLogRotators = map[string]*logFileRotator{}
+
+	adxrLog btclog.Logger
+	amgrLog btclog.Logger
+	cmgrLog btclog.Logger
+	bcdbLog btclog.Logger
+	bmgrLog btclog.Logger
+	exccLog btclog.Logger
+	chanLog btclog.Logger
+	discLog btclog.Logger
+	indxLog btclog.Logger
+	minrLog btclog.Logger
+	peerLog btclog.Logger
+	rpcsLog btclog.Logger
+	scrpLog btclog.Logger
+	srvrLog btclog.Logger
+	stkeLog btclog.Logger
+	trceLog btclog.Logger
+	txmpLog btclog.Logger
 )
 
 // Initialize package-global logger variables.
 func init() {
+	useSubsystemLoggers(backendLog.Logger)
+}
+
+// subsystemLoggers maps each subsystem identifier to its associated logger.
+var subsystemLoggers = map[string]btclog.Logger{}
+
+// useSubsystemLoggers creates the logger of each subsystem with the passed
+// function and uses them for the package-global logger variables of this and
+// all other packages.  It must be called before the loggers are used by any
+// goroutines.
+func useSubsystemLoggers(newLogger func(subsystem string) btclog.Logger) {
+	adxrLog = newLogger("ADXR")
+	amgrLog = newLogger("AMGR")
+	cmgrLog = newLogger("CMGR")
+	bcdbLog = newLogger("BCDB")
+	bmgrLog = newLogger("BMGR")
+	exccLog = newLogger("EXCC")
+	chanLog = newLogger("CHAN")
+	discLog = newLogger("DISC")
+	indxLog = newLogger("INDX")
+	minrLog = newLogger("MINR")
+	peerLog = newLogger("PEER")
+	rpcsLog = newLogger("RPCS")
+	scrpLog = newLogger("SCRP")
+	srvrLog = newLogger("SRVR")
+	stkeLog = newLogger("STKE")
+	trceLog = newLogger("TRCE")
+	txmpLog = newLogger("TXMP")
+
 	addrmgr.UseLogger(amgrLog)
 	connmgr.UseLogger(cmgrLog)
 	database.UseLogger(bcdbLog)
@@ -83,45 +139,143 @@ func init() {
 	txscript.UseLogger(scrpLog)
 	stake.UseLogger(stkeLog)
 	mempool.UseLogger(txmpLog)
+	tracing.UseLogger(trceLog)
+
+	subsystemLoggers["ADXR"] = adxrLog
+	subsystemLoggers["AMGR"] = amgrLog
+	subsystemLoggers["CMGR"] = cmgrLog
+	subsystemLoggers["BCDB"] = bcdbLog
+	subsystemLoggers["BMGR"] = bmgrLog
+	subsystemLoggers["EXCC"] = exccLog
+	subsystemLoggers["CHAN"] = chanLog
+	subsystemLoggers["DISC"] = discLog
+	subsystemLoggers["INDX"] = indxLog
+	subsystemLoggers["MINR"] = minrLog
+	subsystemLoggers["PEER"] = peerLog
+	subsystemLoggers["RPCS"] = rpcsLog
+	subsystemLoggers["SCRP"] = scrpLog
+	subsystemLoggers["SRVR"] = srvrLog
+	subsystemLoggers["STKE"] = stkeLog
+	subsystemLoggers["TRCE"] = trceLog
+	subsystemLoggers["TXMP"] = txmpLog
 }
 
-// subsystemLoggers maps each subsystem identifier to its associated logger.
-var subsystemLoggers = map[string]btclog.Logger{
-	"ADXR": adxrLog,
-	"AMGR": amgrLog,
-	"CMGR": cmgrLog,
-	"BCDB": bcdbLog,
-	"BMGR": bmgrLog,
-	"EXCC": exccLog,
-	"CHAN": chanLog,
-	"DISC": discLog,
-	"INDX": indxLog,
-	"MINR": minrLog,
-	"PEER": peerLog,
-	"RPCS": rpcsLog,
-	"SCRP": scrpLog,
-	"SRVR": srvrLog,
-	"STKE": stkeLog,
-	"TXMP": txmpLog,
+// logRotationConfig houses the settings which determine when log files are
+// rolled and how many rolled files are kept.
+type logRotationConfig struct {
+	maxSize  int64
+	maxAge   time.Duration
+	maxRolls int
+	compress bool
+}
+
+// subsystemLogFile returns the name of the file the passed subsystem is logged
+// to when it is logged to its own file, which is the name of the main log file
+// with the lowercase subsystem identifier appended.
+func subsystemLogFile(logFile, subsystemID string) string {
+	ext := filepath.Ext(logFile)
+	return strings.TrimSuffix(logFile, ext) + "-" +
+		strings.ToLower(subsystemID) + ext
 }
 
-// initLogRotator initializes the logging rotater to write logs to logFile and
+// initLogRotators initializes the logging rotaters to write logs to logFile,
+// or the file of each of the passed subsystems logged to their own file, and
 // create roll files in the same directory.  It must be called before the
 // package-global log rotater variables are used.
-func initLogRotator(logFile string) {
+func initLogRotators(logFile string, rc logRotationConfig, splitSubsystems []string) {
 	logDir, _ := filepath.Split(logFile)
 	err := os.MkdirAll(logDir, 0700)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "failed to create log directory: %v\n", err)
 		os.Exit(1)
 	}
-	r, err := rotator.New(logFile, 10*1024, false, 3)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "failed to create file rotator: %v\n", err)
-		os.Exit(1)
+	newRotator := func(name string) *logFileRotator {
+		r, err := newLogFileRotator(name, rc.maxSize, rc.maxAge,
+			rc.maxRolls, rc.compress)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to create file rotator: %v\n",
+				err)
+			os.Exit(1)
+		}
+		return r
 	}
 
-	logRotator = r
+	logRotator = newRotator(logFile)
+	for _, subsystemID := range splitSubsystems {
+		if _, ok := subsystemLogRotators[subsystemID]; ok {
+			continue
+		}
+		subsystemLogRotators[subsystemID] = newRotator(
+			subsystemLogFile(logFile, subsystemID))
+	}
+}
+
+// useLogBackends replaces the subsystem loggers with loggers of the passed log
+// format which write to standard output and the log rotator of their subsystem.
+// It must be called after the log rotators are initialized and before the log
+// levels are set.
+func useLogBackends(format string) {
+	newBackend := func(w io.Writer) logBackend {
+		return btclog.NewBackend(w)
+	}
+	if format == logFormatJSON {
+		newBackend = func(w io.Writer) logBackend {
+			return newJSONLogBackend(w)
+		}
+	}
+
+	mainBackend := newBackend(logWriter{})
+	backends := make(map[string]logBackend)
+	for subsystemID, r := range subsystemLogRotators {
+		backends[subsystemID] = newBackend(logWriter{rotator: r})
+	}
+	useSubsystemLoggers(func(subsystemID string) btclog.Logger {
+		if backend, ok := backends[subsystemID]; ok {
+			return backend.Logger(subsystemID)
+		}
+		return mainBackend.Logger(subsystemID)
+	})
+}
+
+// rotateLogFiles flushes the main log file and the files of the subsystems
+// logged to their own file to disk and rolls them.  It returns the names of
+// the rolled files.  Log files which are empty are not rolled.
+func rotateLogFiles() ([]string, error) {
+	if logRotator == nil {
+		return nil, errors.New("logging to files is disabled")
+	}
+	rotators := []*logFileRotator{logRotator}
+	subsystemIDs := make([]string, 0, len(subsystemLogRotators))
+	for subsystemID := range subsystemLogRotators {
+		subsystemIDs = append(subsystemIDs, subsystemID)
+	}
+	sort.Strings(subsystemIDs)
+	for _, subsystemID := range subsystemIDs {
+		rotators = append(rotators, subsystemLogRotators[subsystemID])
+	}
+
+	rolled := make([]string, 0, len(rotators))
+	for _, r := range rotators {
+		name, err := r.Rotate()
+		if err != nil {
+			return rolled, err
+		}
+		if name != "" {
+			rolled = append(rolled, name)
+		}
+	}
+	return rolled, nil
+}
+
+// closeLogRotators closes the main log file and the files of the subsystems
+// logged to their own file.
+func closeLogRotators() {
+	if logRotator != nil {
+		logRotator.Close()
+	}
+	for _, r := range subsystemLogRotators {
+		r.Close()
+	}
 }
 
 // setLogLevel sets the logging level for provided subsystem.  Invalid
@@ -163,8 +317,6 @@ func directionString(inbound bool) string {
 func fatalf(str string) {
 	exccLog.Errorf("Unable to create profiler: %v", str)
 	os.Stdout.Sync()
-	if logRotator != nil {
-		logRotator.Close()
-	}
+	closeLogRotators()
 	os.Exit(1)
 }
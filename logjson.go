@@ -0,0 +1,299 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/EXCCoin/exccd/chaincfg/chainhash"
+	"github.com/EXCCoin/exccd/exccutil"
+	"github.com/btcsuite/btclog"
+)
+
+const (
+	// logFormatText is the log format which writes a human-readable line
+	// per message.
+	logFormatText = "text"
+
+	// logFormatJSON is the log format which writes a JSON object per
+	// message with the subsystem, level, and fields extracted from the
+	// message arguments.
+	logFormatJSON = "json"
+)
+
+// jsonLogLevels maps the logging levels to their names in JSON log entries.
+var jsonLogLevels = map[btclog.Level]string{
+	btclog.LevelTrace:    "trace",
+	btclog.LevelDebug:    "debug",
+	btclog.LevelInfo:     "info",
+	btclog.LevelWarn:     "warn",
+	btclog.LevelError:    "error",
+	btclog.LevelCritical: "critical",
+}
+
+// jsonLogEntry is a log message as written by the JSON log format.  The fields
+// following the message are only set when they were found in the arguments of
+// the message.
+type jsonLogEntry struct {
+	Time      string `json:"time"`
+	Level     string `json:"level"`
+	Subsystem string `json:"subsystem"`
+	Message   string `json:"msg"`
+	Peer      string `json:"peer,omitempty"`
+	PeerID    *int32 `json:"peerid,omitempty"`
+	Hash      string `json:"hash,omitempty"`
+	Height    *int64 `json:"height,omitempty"`
+}
+
+// logPeer describes the peers which are logged, which allows their address and
+// ID to be extracted from the arguments of log messages.
+type logPeer interface {
+	ID() int32
+	Addr() string
+}
+
+// setPeer sets the peer fields of the entry unless they are already set.
+func (e *jsonLogEntry) setPeer(p logPeer) {
+	if e.PeerID != nil {
+		return
+	}
+	id := p.ID()
+	e.PeerID = &id
+	e.Peer = p.Addr()
+}
+
+// setHash sets the hash field of the entry unless it is already set.
+func (e *jsonLogEntry) setHash(hash *chainhash.Hash) {
+	if e.Hash == "" && hash != nil {
+		e.Hash = hash.String()
+	}
+}
+
+// setHeight sets the height field of the entry unless it is already set.
+func (e *jsonLogEntry) setHeight(height int64) {
+	if e.Height == nil {
+		e.Height = &height
+	}
+}
+
+// addFields sets the fields of the entry which are found in the passed
+// arguments of the log message.  Peers, hashes, and blocks are recognized by
+// their type, while heights are recognized by the word height immediately
+// preceding their formatting verb in the passed format string, such as in
+// "height %d".  The first argument found for each field is used.
+func (e *jsonLogEntry) addFields(format string, args []interface{}) {
+	var heightArgs map[int]bool
+	if format != "" {
+		heightArgs = heightVerbs(format)
+	}
+	for i, arg := range args {
+		switch a := arg.(type) {
+		case logPeer:
+			e.setPeer(a)
+		case *chainhash.Hash:
+			e.setHash(a)
+		case chainhash.Hash:
+			e.setHash(&a)
+		case *exccutil.Block:
+			e.setHash(a.Hash())
+			e.setHeight(a.Height())
+		}
+		if !heightArgs[i] {
+			continue
+		}
+		switch a := arg.(type) {
+		case int64:
+			e.setHeight(a)
+		case int32:
+			e.setHeight(int64(a))
+		case uint32:
+			e.setHeight(int64(a))
+		case int:
+			e.setHeight(int64(a))
+		}
+	}
+}
+
+// heightVerbs returns the indexes of the arguments of the passed format string
+// whose formatting verbs are immediately preceded by the word height, ignoring
+// case and a separating space, colon, or equals sign.
+func heightVerbs(format string) map[int]bool {
+	var indexes map[int]bool
+	arg := 0
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		start := i
+		i++
+		if i < len(format) && format[i] == '%' {
+			continue
+		}
+
+		// Skip the flags, width, and precision of the verb.
+		for i < len(format) && strings.IndexByte("+-# 0123456789.*", format[i]) != -1 {
+			i++
+		}
+		prefix := strings.TrimRight(strings.ToLower(format[:start]), " :=")
+		if strings.HasSuffix(prefix, "height") {
+			if indexes == nil {
+				indexes = make(map[int]bool)
+			}
+			indexes[arg] = true
+		}
+		arg++
+	}
+	return indexes
+}
+
+// jsonLogBackend writes the log messages of all of the JSON loggers created
+// from it to a single writer.
+type jsonLogBackend struct {
+	mtx sync.Mutex
+	w   io.Writer
+}
+
+// newJSONLogBackend returns a new JSON log backend which writes to the passed
+// writer.
+func newJSONLogBackend(w io.Writer) *jsonLogBackend {
+	return &jsonLogBackend{w: w}
+}
+
+// write writes a log message as a line containing a JSON object to the writer
+// of the backend.
+func (b *jsonLogBackend) write(lvl btclog.Level, subsystem, msg, format string, args []interface{}) {
+	entry := jsonLogEntry{
+		Time:      time.Now().UTC().Format(time.RFC3339Nano),
+		Level:     jsonLogLevels[lvl],
+		Subsystem: subsystem,
+		Message:   msg,
+	}
+	entry.addFields(format, args)
+	line, err := json.Marshal(&entry)
+	if err != nil {
+		return
+	}
+	line = append(line, '\n')
+
+	b.mtx.Lock()
+	b.w.Write(line)
+	b.mtx.Unlock()
+}
+
+// Logger returns a new JSON logger for the passed subsystem which writes to the
+// backend.
+func (b *jsonLogBackend) Logger(subsystem string) btclog.Logger {
+	return &jsonLogger{
+		level:     uint32(btclog.LevelInfo),
+		subsystem: subsystem,
+		b:         b,
+	}
+}
+
+// jsonLogger is a subsystem logger which writes log messages as JSON objects.
+// It implements the btclog.Logger interface.
+type jsonLogger struct {
+	level     uint32 // atomic
+	subsystem string
+	b         *jsonLogBackend
+}
+
+// Ensure jsonLogger implements the btclog.Logger interface.
+var _ btclog.Logger = (*jsonLogger)(nil)
+
+// print writes a message formatted using the default formats for its operands
+// when the passed level is enabled.
+func (l *jsonLogger) print(lvl btclog.Level, args []interface{}) {
+	if lvl < l.Level() {
+		return
+	}
+	msg := strings.TrimSuffix(fmt.Sprintln(args...), "\n")
+	l.b.write(lvl, l.subsystem, msg, "", args)
+}
+
+// printf writes a message formatted according to the passed format specifier
+// when the passed level is enabled.
+func (l *jsonLogger) printf(lvl btclog.Level, format string, args []interface{}) {
+	if lvl < l.Level() {
+		return
+	}
+	l.b.write(lvl, l.subsystem, fmt.Sprintf(format, args...), format, args)
+}
+
+// Trace formats message using the default formats for its operands and writes
+// to log with LevelTrace.
+func (l *jsonLogger) Trace(args ...interface{}) { l.print(btclog.LevelTrace, args) }
+
+// Tracef formats message according to format specifier and writes to log with
+// LevelTrace.
+func (l *jsonLogger) Tracef(format string, args ...interface{}) {
+	l.printf(btclog.LevelTrace, format, args)
+}
+
+// Debug formats message using the default formats for its operands and writes
+// to log with LevelDebug.
+func (l *jsonLogger) Debug(args ...interface{}) { l.print(btclog.LevelDebug, args) }
+
+// Debugf formats message according to format specifier and writes to log with
+// LevelDebug.
+func (l *jsonLogger) Debugf(format string, args ...interface{}) {
+	l.printf(btclog.LevelDebug, format, args)
+}
+
+// Info formats message using the default formats for its operands and writes
+// to log with LevelInfo.
+func (l *jsonLogger) Info(args ...interface{}) { l.print(btclog.LevelInfo, args) }
+
+// Infof formats message according to format specifier and writes to log with
+// LevelInfo.
+func (l *jsonLogger) Infof(format string, args ...interface{}) {
+	l.printf(btclog.LevelInfo, format, args)
+}
+
+// Warn formats message using the default formats for its operands and writes
+// to log with LevelWarn.
+func (l *jsonLogger) Warn(args ...interface{}) { l.print(btclog.LevelWarn, args) }
+
+// Warnf formats message according to format specifier and writes to log with
+// LevelWarn.
+func (l *jsonLogger) Warnf(format string, args ...interface{}) {
+	l.printf(btclog.LevelWarn, format, args)
+}
+
+// Error formats message using the default formats for its operands and writes
+// to log with LevelError.
+func (l *jsonLogger) Error(args ...interface{}) { l.print(btclog.LevelError, args) }
+
+// Errorf formats message according to format specifier and writes to log with
+// LevelError.
+func (l *jsonLogger) Errorf(format string, args ...interface{}) {
+	l.printf(btclog.LevelError, format, args)
+}
+
+// Critical formats message using the default formats for its operands and
+// writes to log with LevelCritical.
+func (l *jsonLogger) Critical(args ...interface{}) { l.print(btclog.LevelCritical, args) }
+
+// Criticalf formats message according to format specifier and writes to log
+// with LevelCritical.
+func (l *jsonLogger) Criticalf(format string, args ...interface{}) {
+	l.printf(btclog.LevelCritical, format, args)
+}
+
+// Level returns the current logging level.
+func (l *jsonLogger) Level() btclog.Level {
+	return btclog.Level(atomic.LoadUint32(&l.level))
+}
+
+// SetLevel changes the logging level to the passed level.
+func (l *jsonLogger) SetLevel(level btclog.Level) {
+	atomic.StoreUint32(&l.level, uint32(level))
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/EXCCoin/exccd/chaincfg/chainhash"
+	"github.com/btcsuite/btclog"
+)
+
+// testLogPeer is a peer as seen by the JSON loggers.
+type testLogPeer struct{}
+
+func (testLogPeer) ID() int32      { return 7 }
+func (testLogPeer) Addr() string   { return "127.0.0.1:9108" }
+func (testLogPeer) String() string { return "127.0.0.1:9108 (inbound)" }
+
+// TestJSONLogger ensures the JSON loggers write the expected entries,
+// including the fields extracted from the arguments of the messages.
+func TestJSONLogger(t *testing.T) {
+	hash := chainhash.HashH([]byte("block"))
+	id := int32(7)
+	height := int64(1234)
+
+	tests := []struct {
+		name  string
+		log   func(l btclog.Logger)
+		entry *jsonLogEntry
+	}{{
+		name: "disabled level",
+		log:  func(l btclog.Logger) { l.Debugf("not written") },
+	}, {
+		name: "plain message",
+		log:  func(l btclog.Logger) { l.Info("Version", 3) },
+		entry: &jsonLogEntry{Level: "info", Subsystem: "TEST",
+			Message: "Version 3"},
+	}, {
+		name: "peer, hash, and height",
+		log: func(l btclog.Logger) {
+			l.Warnf("Block %v at height %d from %v rejected", &hash,
+				int64(1234), testLogPeer{})
+		},
+		entry: &jsonLogEntry{Level: "warn", Subsystem: "TEST",
+			Message: "Block " + hash.String() + " at height 1234 " +
+				"from 127.0.0.1:9108 (inbound) rejected",
+			Peer: "127.0.0.1:9108", PeerID: &id, Hash: hash.String(),
+			Height: &height},
+	}, {
+		name: "height with separator and escaped percent",
+		log: func(l btclog.Logger) {
+			l.Errorf("%d%% done (%d blocks, height: %d)", 50, 10,
+				uint32(1234))
+		},
+		entry: &jsonLogEntry{Level: "error", Subsystem: "TEST",
+			Message: "50% done (10 blocks, height: 1234)",
+			Height:  &height},
+	}, {
+		name: "hash value without height",
+		log: func(l btclog.Logger) {
+			l.Infof("Tip %v with %d transactions", hash, 1234)
+		},
+		entry: &jsonLogEntry{Level: "info", Subsystem: "TEST",
+			Message: "Tip " + hash.String() + " with 1234 " +
+				"transactions", Hash: hash.String()},
+	}}
+
+	for _, test := range tests {
+		var buf bytes.Buffer
+		logger := newJSONLogBackend(&buf).Logger("TEST")
+		test.log(logger)
+		if test.entry == nil {
+			if buf.Len() != 0 {
+				t.Errorf("%s: unexpected output %q", test.name,
+					buf.String())
+			}
+			continue
+		}
+
+		var entry jsonLogEntry
+		if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+			t.Errorf("%s: unable to decode %q: %v", test.name,
+				buf.String(), err)
+			continue
+		}
+		if entry.Time == "" {
+			t.Errorf("%s: missing time", test.name)
+		}
+		entry.Time = ""
+		if !reflect.DeepEqual(&entry, test.entry) {
+			t.Errorf("%s: mismatched entry - got %+v, want %+v",
+				test.name, entry, *test.entry)
+		}
+	}
+}
@@ -0,0 +1,299 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// gzipSuffix is the suffix of rolled log files which were compressed.
+const gzipSuffix = ".gz"
+
+// logFileRotator writes log output to a file which is rolled once it reaches a
+// maximum size or age.  Rolled files are named after the log file with an
+// increasing number appended, are optionally compressed with gzip, and are
+// removed once there are more of them than the maximum number of rolls.
+//
+// The age of a log file is measured from when it was created, which is known
+// across restarts for files which already existed.  See startTime.
+type logFileRotator struct {
+	mtx      sync.Mutex
+	filename string
+	maxSize  int64
+	maxAge   time.Duration
+	maxRolls int
+	compress bool
+	out      *os.File
+	size     int64
+	started  time.Time
+	wg       sync.WaitGroup
+}
+
+// newLogFileRotator opens or creates the log file with the passed name and
+// returns a rotator which writes to it.  A maximum size or age of zero disables
+// rolling the file based on it and a maximum number of rolls of zero keeps all
+// rolled files.
+func newLogFileRotator(filename string, maxSize int64, maxAge time.Duration, maxRolls int, compress bool) (*logFileRotator, error) {
+	r := &logFileRotator{
+		filename: filename,
+		maxSize:  maxSize,
+		maxAge:   maxAge,
+		maxRolls: maxRolls,
+		compress: compress,
+	}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// open opens the log file for appending.
+//
+// This function MUST be called with the rotator lock held (for writes).
+func (r *logFileRotator) open() error {
+	f, err := os.OpenFile(r.filename, os.O_CREATE|os.O_APPEND|os.O_WRONLY,
+		0644)
+	if err != nil {
+		return err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	r.out = f
+	r.size = fi.Size()
+	r.started = time.Now()
+	if r.size > 0 {
+		r.started = r.startTime(fi)
+	}
+	return nil
+}
+
+// startTime returns when the existing log file with the passed info was
+// created.  A log file is created when the previous one is rolled, so that is
+// when the most recently rolled file was last modified.  The modification time
+// of the log file itself is used when no file was rolled yet.  Either way, the
+// age of the log file keeps increasing across restarts, so it is rolled even
+// when the process is restarted more frequently than the maximum age.
+//
+// This function MUST be called with the rotator lock held (for writes).
+func (r *logFileRotator) startTime(fi os.FileInfo) time.Time {
+	started := fi.ModTime()
+	nums, err := r.rolls()
+	if err != nil || len(nums) == 0 {
+		return started
+	}
+	newest := nums[len(nums)-1]
+	for _, compressed := range []bool{false, true} {
+		rolledInfo, err := os.Stat(r.rollName(newest, compressed))
+		if err == nil && rolledInfo.ModTime().Before(started) {
+			started = rolledInfo.ModTime()
+		}
+	}
+	return started
+}
+
+// Write writes the passed log output to the log file and rolls the file once it
+// reaches the maximum size or age.  Files are only rolled after complete lines
+// so a line is never split across files.  It implements the io.Writer
+// interface.
+func (r *logFileRotator) Write(p []byte) (int, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if r.out == nil {
+		return 0, os.ErrClosed
+	}
+	n, err := r.out.Write(p)
+	r.size += int64(n)
+	if err != nil {
+		return n, err
+	}
+
+	if len(p) == 0 || p[len(p)-1] != '\n' {
+		return n, nil
+	}
+	if (r.maxSize > 0 && r.size >= r.maxSize) ||
+		(r.maxAge > 0 && time.Since(r.started) >= r.maxAge) {
+
+		if _, err := r.rotate(); err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// Rotate flushes the log file to disk and rolls it regardless of its size and
+// age.  It returns the name of the rolled file, which is the name of the file
+// once compressed when compression is enabled, or an empty string when the log
+// file was empty and therefore not rolled.
+//
+// This function is safe for concurrent access.
+func (r *logFileRotator) Rotate() (string, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if r.out == nil {
+		return "", os.ErrClosed
+	}
+	if r.size == 0 {
+		return "", nil
+	}
+	return r.rotate()
+}
+
+// rolls returns the numbers of the existing rolled files of the log file in
+// ascending order.  A rolled file which is being compressed exists both with
+// and without the compression suffix, but its number is only returned once.
+func (r *logFileRotator) rolls() ([]int, error) {
+	existing, err := filepath.Glob(r.filename + ".*")
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[int]struct{})
+	var nums []int
+	for _, name := range existing {
+		suffix := strings.TrimSuffix(strings.TrimPrefix(name,
+			r.filename+"."), gzipSuffix)
+		num, err := strconv.Atoi(suffix)
+		if err != nil || num < 1 {
+			continue
+		}
+		if _, ok := seen[num]; ok {
+			continue
+		}
+		seen[num] = struct{}{}
+		nums = append(nums, num)
+	}
+	sort.Ints(nums)
+	return nums, nil
+}
+
+// rollName returns the name of the rolled file with the passed number.
+func (r *logFileRotator) rollName(num int, compressed bool) string {
+	name := fmt.Sprintf("%s.%d", r.filename, num)
+	if compressed {
+		name += gzipSuffix
+	}
+	return name
+}
+
+// rotate rolls the log file by renaming it after the next roll number, opening
+// a new log file, compressing the rolled file in the background when
+// compression is enabled, and removing the oldest rolled files which exceed the
+// maximum number of rolls.
+//
+// This function MUST be called with the rotator lock held (for writes).
+func (r *logFileRotator) rotate() (string, error) {
+	nums, err := r.rolls()
+	if err != nil {
+		return "", err
+	}
+	next := 1
+	if len(nums) > 0 {
+		next = nums[len(nums)-1] + 1
+	}
+
+	r.out.Sync()
+	if err := r.out.Close(); err != nil {
+		return "", err
+	}
+	r.out = nil
+	rolled := r.rollName(next, false)
+	renameErr := os.Rename(r.filename, rolled)
+	if err := r.open(); err != nil {
+		return "", err
+	}
+	if renameErr != nil {
+		return "", renameErr
+	}
+
+	// Remove the oldest rolled files, including the one just rolled, which
+	// exceed the maximum number of rolls.
+	if r.maxRolls > 0 {
+		nums = append(nums, next)
+		for len(nums) > r.maxRolls {
+			os.Remove(r.rollName(nums[0], false))
+			os.Remove(r.rollName(nums[0], true))
+			nums = nums[1:]
+		}
+	}
+
+	if !r.compress {
+		return rolled, nil
+	}
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		if err := compressLogFile(rolled); err == nil {
+			os.Remove(rolled)
+		}
+	}()
+	return rolled + gzipSuffix, nil
+}
+
+// compressLogFile writes a gzip-compressed copy of the passed rolled log file
+// next to it.
+func compressLogFile(name string) error {
+	in, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(name+gzipSuffix, os.O_CREATE|os.O_TRUNC|
+		os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(out)
+	_, err = io.Copy(zw, in)
+	if closeErr := zw.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(name + gzipSuffix)
+	}
+	return err
+}
+
+// Sync flushes the log file to disk.
+//
+// This function is safe for concurrent access.
+func (r *logFileRotator) Sync() error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if r.out == nil {
+		return os.ErrClosed
+	}
+	return r.out.Sync()
+}
+
+// Close closes the log file and waits for rolled files to be compressed.
+func (r *logFileRotator) Close() error {
+	r.mtx.Lock()
+	var err error
+	if r.out != nil {
+		err = r.out.Close()
+		r.out = nil
+	}
+	r.mtx.Unlock()
+
+	r.wg.Wait()
+	return err
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+// TestLogFileRotator ensures log files are rolled by size, age, and on demand,
+// that rolled files are compressed, and that only the maximum number of rolled
+// files are kept.
+func TestLogFileRotator(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logrotator")
+	if err != nil {
+		t.Fatalf("TempDir: unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	logFile := filepath.Join(dir, "exccd.log")
+	r, err := newLogFileRotator(logFile, 10, 0, 2, false)
+	if err != nil {
+		t.Fatalf("newLogFileRotator: unexpected error: %v", err)
+	}
+
+	// Files are only rolled after complete lines.
+	r.Write([]byte("0123456789"))
+	r.Write([]byte("abc\n"))
+	r.Write([]byte("line 2 is long\n"))
+	r.Write([]byte("line 3 is long\n"))
+	r.Write([]byte("tail\n"))
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close: unexpected error: %v", err)
+	}
+
+	// Only the two most recent rolled files are kept.
+	wantFiles := map[string]string{
+		"exccd.log":   "tail\n",
+		"exccd.log.2": "line 2 is long\n",
+		"exccd.log.3": "line 3 is long\n",
+	}
+	checkFiles := func(wantFiles map[string]string) {
+		t.Helper()
+		matches, _ := filepath.Glob(filepath.Join(dir, "*"))
+		var got, want []string
+		for _, match := range matches {
+			got = append(got, filepath.Base(match))
+		}
+		for name := range wantFiles {
+			want = append(want, name)
+		}
+		sort.Strings(want)
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("mismatched files - got %v, want %v", got, want)
+		}
+		for name, contents := range wantFiles {
+			b, err := ioutil.ReadFile(filepath.Join(dir, name))
+			if err != nil {
+				t.Fatalf("ReadFile: unexpected error: %v", err)
+			}
+			if filepath.Ext(name) == gzipSuffix {
+				f, _ := os.Open(filepath.Join(dir, name))
+				zr, err := gzip.NewReader(f)
+				if err != nil {
+					t.Fatalf("gzip.NewReader: unexpected error: %v",
+						err)
+				}
+				b, err = ioutil.ReadAll(zr)
+				f.Close()
+				if err != nil {
+					t.Fatalf("ReadAll: unexpected error: %v", err)
+				}
+			}
+			if string(b) != contents {
+				t.Fatalf("mismatched contents of %s - got %q, "+
+					"want %q", name, b, contents)
+			}
+		}
+	}
+	checkFiles(wantFiles)
+
+	// Reopen the log file with compression and rolling by age and ensure
+	// an empty log file is not rolled on demand.
+	r, err = newLogFileRotator(logFile, 0, time.Hour, 2, true)
+	if err != nil {
+		t.Fatalf("newLogFileRotator: unexpected error: %v", err)
+	}
+	rolled, err := r.Rotate()
+	if err != nil || rolled != filepath.Join(dir, "exccd.log.4.gz") {
+		t.Fatalf("Rotate: unexpected result %q (err %v)", rolled, err)
+	}
+	rolled, err = r.Rotate()
+	if err != nil || rolled != "" {
+		t.Fatalf("Rotate: unexpected result %q (err %v)", rolled, err)
+	}
+
+	// Roll the log file once it is older than the maximum age.
+	r.Write([]byte("old\n"))
+	r.mtx.Lock()
+	r.started = r.started.Add(-time.Hour)
+	r.mtx.Unlock()
+	r.Write([]byte("older\n"))
+	r.Write([]byte("new\n"))
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close: unexpected error: %v", err)
+	}
+	checkFiles(map[string]string{
+		"exccd.log":      "new\n",
+		"exccd.log.4.gz": "tail\n",
+		"exccd.log.5.gz": "old\nolder\n",
+	})
+
+	// The age of an existing log file is measured from when the most
+	// recently rolled file was last modified, so it is rolled once it is
+	// older than the maximum age even when it was reopened since.
+	created := time.Now().Add(-2 * time.Hour).Truncate(time.Second)
+	err = os.Chtimes(filepath.Join(dir, "exccd.log.5.gz"), created, created)
+	if err != nil {
+		t.Fatalf("Chtimes: unexpected error: %v", err)
+	}
+	r, err = newLogFileRotator(logFile, 0, time.Hour, 2, false)
+	if err != nil {
+		t.Fatalf("newLogFileRotator: unexpected error: %v", err)
+	}
+	if !r.started.Equal(created) {
+		t.Fatalf("unexpected start time %v, want %v", r.started, created)
+	}
+	r.Write([]byte("reopened\n"))
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close: unexpected error: %v", err)
+	}
+	checkFiles(map[string]string{
+		"exccd.log":      "",
+		"exccd.log.5.gz": "old\nolder\n",
+		"exccd.log.6":    "new\nreopened\n",
+	})
+
+	// The modification time of the log file itself is used when no file
+	// was rolled yet.
+	logFile2 := filepath.Join(dir, "other.log")
+	if err := ioutil.WriteFile(logFile2, []byte("old\n"), 0644); err != nil {
+		t.Fatalf("WriteFile: unexpected error: %v", err)
+	}
+	if err := os.Chtimes(logFile2, created, created); err != nil {
+		t.Fatalf("Chtimes: unexpected error: %v", err)
+	}
+	r, err = newLogFileRotator(logFile2, 0, time.Hour, 2, false)
+	if err != nil {
+		t.Fatalf("newLogFileRotator: unexpected error: %v", err)
+	}
+	if !r.started.Equal(created) {
+		t.Fatalf("unexpected start time %v, want %v", r.started, created)
+	}
+	r.Close()
+}
@@ -0,0 +1,206 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+const (
+	// memCheckInterval is the interval at which the memory governor checks
+	// the heap usage.
+	memCheckInterval = 5 * time.Second
+
+	// memReliefInterval is the interval at which the memory governor
+	// relieves memory pressure again while it persists.
+	memReliefInterval = time.Minute
+
+	// memElevatedPercent is the percentage of the memory limit the heap
+	// usage must reach for the memory pressure to become elevated.
+	memElevatedPercent = 80
+
+	// memRecoveredPercent is the percentage of the memory limit the heap
+	// usage must drop below for the memory pressure to end once it is
+	// elevated.  It is lower than memElevatedPercent so the pressure does
+	// not flap when the heap usage hovers around the threshold.
+	memRecoveredPercent = 70
+)
+
+// memPressure describes how close the heap usage is to the memory limit.
+type memPressure int
+
+const (
+	// memPressureNone indicates the heap usage is well below the limit.
+	memPressureNone memPressure = iota
+
+	// memPressureElevated indicates the heap usage is approaching the
+	// limit.
+	memPressureElevated
+
+	// memPressureCritical indicates the heap usage has reached the limit.
+	memPressureCritical
+)
+
+// String returns the memory pressure level as a human-readable string.
+func (p memPressure) String() string {
+	switch p {
+	case memPressureNone:
+		return "none"
+	case memPressureElevated:
+		return "elevated"
+	case memPressureCritical:
+		return "critical"
+	}
+	return "unknown"
+}
+
+// memPressureLevel returns the memory pressure for the passed heap usage and
+// memory limit given the previous memory pressure.
+func memPressureLevel(heap, limit uint64, prev memPressure) memPressure {
+	switch {
+	case heap >= limit:
+		return memPressureCritical
+	case heap >= limit/100*memElevatedPercent:
+		return memPressureElevated
+	case prev != memPressureNone && heap >= limit/100*memRecoveredPercent:
+		return memPressureElevated
+	}
+	return memPressureNone
+}
+
+// memGovernor monitors the heap usage of the process and relieves memory
+// pressure as the heap approaches the configured memory limit by shrinking the
+// signature and block caches, trimming the mempool and orphan pools, and
+// throttling block downloads.  This keeps the process running instead of it
+// being killed for running out of memory.
+type memGovernor struct {
+	server *server
+	limit  uint64
+
+	// The following fields are only accessed by the governor handler.
+	pressure   memPressure
+	lastRelief time.Time
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// relieve frees memory according to the passed memory pressure.  Elevated
+// pressure halves the signature cache, block cache, and mempool and clears the
+// orphan pools.  Critical pressure additionally clears the signature and block
+// caches, trims the mempool to a quarter of its size, and returns the freed
+// memory to the operating system.
+func (g *memGovernor) relieve(pressure memPressure) {
+	s := g.server
+
+	sigCacheEntries := s.sigCache.Stats().Entries / 2
+	if pressure == memPressureCritical {
+		sigCacheEntries = 0
+	}
+	numSigs := s.sigCache.Shrink(sigCacheEntries)
+
+	chain := s.blockManager.chain
+	blockCacheEntries := uint(chain.BlockCacheLen() / 2)
+	if pressure == memPressureCritical {
+		blockCacheEntries = 0
+	}
+	numBlocks := chain.ShrinkBlockCache(blockCacheEntries)
+
+	numOrphanTxns := s.txMemPool.TrimOrphans(0)
+	numOrphanBlocks := chain.RemoveOrphanBlocks()
+
+	var poolSize int64
+	for _, desc := range s.txMemPool.TxDescs() {
+		poolSize += int64(desc.Tx.MsgTx().SerializeSize())
+	}
+	maxPoolSize := poolSize / 2
+	if pressure == memPressureCritical {
+		maxPoolSize = poolSize / 4
+	}
+	numTxns := s.txMemPool.TrimToSize(maxPoolSize)
+
+	if pressure == memPressureCritical {
+		debug.FreeOSMemory()
+	}
+
+	srvrLog.Infof("Relieved %s memory pressure: evicted %d signatures, %d "+
+		"cached blocks, %d orphan transactions, %d orphan blocks, and "+
+		"%d mempool transactions", pressure, numSigs, numBlocks,
+		numOrphanTxns, numOrphanBlocks, numTxns)
+}
+
+// check updates the memory pressure for the passed heap usage and relieves it
+// when it increased or has persisted since it was last relieved.
+func (g *memGovernor) check(heap uint64, now time.Time) {
+	pressure := memPressureLevel(heap, g.limit, g.pressure)
+	if pressure != g.pressure {
+		const mib = 1024 * 1024
+		if pressure > g.pressure {
+			srvrLog.Warnf("Memory pressure is %s -- heap usage %d MiB "+
+				"of %d MiB limit", pressure, heap/mib, g.limit/mib)
+		} else {
+			srvrLog.Infof("Memory pressure is %s -- heap usage %d MiB "+
+				"of %d MiB limit", pressure, heap/mib, g.limit/mib)
+		}
+		g.server.blockManager.SetMemoryPressure(pressure !=
+			memPressureNone)
+	}
+
+	if pressure > g.pressure || (pressure != memPressureNone &&
+		now.Sub(g.lastRelief) >= memReliefInterval) {
+
+		g.relieve(pressure)
+		g.lastRelief = now
+	}
+	g.pressure = pressure
+}
+
+// governorHandler periodically checks the heap usage.  It must be run as a
+// goroutine.
+func (g *memGovernor) governorHandler() {
+	ticker := time.NewTicker(memCheckInterval)
+	defer ticker.Stop()
+
+	var memStats runtime.MemStats
+out:
+	for {
+		select {
+		case <-ticker.C:
+			runtime.ReadMemStats(&memStats)
+			g.check(memStats.HeapAlloc, time.Now())
+
+		case <-g.quit:
+			break out
+		}
+	}
+
+	g.wg.Done()
+}
+
+// Start begins monitoring the heap usage.
+func (g *memGovernor) Start() {
+	g.wg.Add(1)
+	spawn(g.governorHandler)
+}
+
+// Stop signals the governor to stop monitoring the heap usage and waits for it
+// to finish.
+func (g *memGovernor) Stop() {
+	close(g.quit)
+	g.wg.Wait()
+}
+
+// newMemGovernor returns a new memory governor for the provided server which
+// keeps the heap usage below the provided limit in bytes.
+func newMemGovernor(s *server, limit uint64) *memGovernor {
+	return &memGovernor{
+		server: s,
+		limit:  limit,
+		quit:   make(chan struct{}),
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import "testing"
+
+// TestMemPressureLevel ensures the memory pressure is determined from the heap
+// usage relative to the memory limit and only ends once the heap usage drops
+// well below the threshold it started at.
+func TestMemPressureLevel(t *testing.T) {
+	const limit = 1000
+	tests := []struct {
+		name string
+		heap uint64
+		prev memPressure
+		want memPressure
+	}{
+		{"low", 500, memPressureNone, memPressureNone},
+		{"below elevated", 799, memPressureNone, memPressureNone},
+		{"elevated", 800, memPressureNone, memPressureElevated},
+		{"critical", 1000, memPressureNone, memPressureCritical},
+		{"above limit", 2000, memPressureElevated, memPressureCritical},
+		{"critical to elevated", 900, memPressureCritical,
+			memPressureElevated},
+		{"elevated above recovered", 700, memPressureElevated,
+			memPressureElevated},
+		{"critical above recovered", 750, memPressureCritical,
+			memPressureElevated},
+		{"recovered", 699, memPressureElevated, memPressureNone},
+	}
+
+	for _, test := range tests {
+		got := memPressureLevel(test.heap, limit, test.prev)
+		if got != test.want {
+			t.Errorf("%s: unexpected pressure -- got %v, want %v",
+				test.name, got, test.want)
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"container/list"
 	"fmt"
 	"math"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -58,10 +59,6 @@ const (
 	// If a vote is on a block whose height is before tip minus this
 	// amount, reject it from being added to the mempool.
 	maximumVoteAgeDelta = 1440
-
-	// maxNullDataOutputs is the maximum number of OP_RETURN null data
-	// pushes in a transaction, after which it is considered non-standard.
-	maxNullDataOutputs = 4
 )
 
 // Config is a descriptor containing the memory pool configuration.
@@ -101,6 +98,11 @@ type Config struct {
 	// tip within the best chain.
 	PastMedianTime func() time.Time
 
+	// Clock defines the clock to use for the times transactions are added to
+	// the pool and the rate limiting of free transactions.  This can be nil
+	// to use the local clock.
+	Clock blockchain.Clock
+
 	// CalcSequenceLock defines the function to use in order to generate
 	// the current sequence lock for the given transaction using the passed
 	// utxo view.
@@ -167,6 +169,11 @@ type Policy struct {
 	// admitted and relayed.
 	AllowOldVotes bool
 
+	// DataCarrier defines the policy for null data outputs of regular
+	// transactions.  Transactions which violate it are considered
+	// non-standard.
+	DataCarrier mining.DataCarrierPolicy
+
 	// StandardVerifyFlags defines the function to retrieve the flags to
 	// use for verifying scripts for the block after the current best block.
 	// It must set the verification flags properly depending on the result
@@ -510,6 +517,19 @@ func (mp *TxPool) HaveTransactions(hashes []*chainhash.Hash) []bool {
 	return haveTxns
 }
 
+// CheckSpend checks whether the passed outpoint is already spent by a
+// transaction in the main pool.  If that's the case the spending transaction
+// will be returned, if not nil will be returned.
+//
+// This function is safe for concurrent access.
+func (mp *TxPool) CheckSpend(op wire.OutPoint) *exccutil.Tx {
+	mp.mtx.RLock()
+	txR := mp.outpoints[op]
+	mp.mtx.RUnlock()
+
+	return txR
+}
+
 // HaveAllTransactions returns whether or not all of the passed transaction
 // hashes exist in the mempool.
 //
@@ -566,7 +586,7 @@ func (mp *TxPool) removeTransaction(tx *exccutil.Tx, removeRedeemers bool) {
 			delete(mp.outpoints, txIn.PreviousOutPoint)
 		}
 		delete(mp.pool, *txHash)
-		atomic.StoreInt64(&mp.lastUpdated, time.Now().Unix())
+		atomic.StoreInt64(&mp.lastUpdated, mp.cfg.Clock.Now().Unix())
 	}
 }
 
@@ -618,7 +638,7 @@ func (mp *TxPool) addTransaction(utxoView *blockchain.UtxoViewpoint,
 		TxDesc: mining.TxDesc{
 			Tx:     tx,
 			Type:   txType,
-			Added:  time.Now(),
+			Added:  mp.cfg.Clock.Now(),
 			Height: height,
 			Fee:    fee,
 		},
@@ -627,7 +647,7 @@ func (mp *TxPool) addTransaction(utxoView *blockchain.UtxoViewpoint,
 	for _, txIn := range msgTx.TxIn {
 		mp.outpoints[txIn.PreviousOutPoint] = tx
 	}
-	atomic.StoreInt64(&mp.lastUpdated, time.Now().Unix())
+	atomic.StoreInt64(&mp.lastUpdated, mp.cfg.Clock.Now().Unix())
 
 	// Add unconfirmed address index entries associated with the transaction
 	// if enabled.
@@ -825,7 +845,7 @@ func (mp *TxPool) maybeAcceptTransaction(tx *exccutil.Tx, isNew, rateLimit, allo
 	if !mp.cfg.Policy.AcceptNonStd {
 		err := checkTransactionStandard(tx, txType, nextBlockHeight,
 			medianTime, mp.cfg.Policy.MinRelayTxFee,
-			mp.cfg.Policy.MaxTxVersion)
+			mp.cfg.Policy.MaxTxVersion, &mp.cfg.Policy.DataCarrier)
 		if err != nil {
 			// Attempt to extract a reject code from the error so
 			// it can be retained.  When not possible, fall back to
@@ -1098,7 +1118,7 @@ func (mp *TxPool) maybeAcceptTransaction(tx *exccutil.Tx, isNew, rateLimit, allo
 	// penny-flooding with tiny transactions as a form of attack.
 	// This applies to non-stake transactions only.
 	if rateLimit && txFee < minFee && txType == stake.TxTypeRegular {
-		nowUnix := time.Now().Unix()
+		nowUnix := mp.cfg.Clock.Now().Unix()
 		// Decay passed data with an exponentially decaying ~10 minute
 		// window.
 		mp.pennyTotal *= math.Pow(1.0-1.0/600.0,
@@ -1425,6 +1445,17 @@ func (mp *TxPool) ProcessTransaction(tx *exccutil.Tx, allowOrphan, rateLimit, al
 	return nil, err
 }
 
+// SetMinRelayTxFee changes the minimum transaction fee in atoms/kB required for
+// transactions accepted to the pool afterwards to be considered a non-zero fee.
+// Transactions already in the pool are not affected.
+//
+// This function is safe for concurrent access.
+func (mp *TxPool) SetMinRelayTxFee(fee exccutil.Amount) {
+	mp.mtx.Lock()
+	mp.cfg.Policy.MinRelayTxFee = fee
+	mp.mtx.Unlock()
+}
+
 // Count returns the number of transactions in the main pool.  It does not
 // include the orphan pool.
 //
@@ -1437,6 +1468,104 @@ func (mp *TxPool) Count() int {
 	return count
 }
 
+// TrimOrphans evicts random orphan transactions until no more than the passed
+// number remain in the orphan pool.  It returns the number of evicted orphans.
+//
+// This function is safe for concurrent access.
+func (mp *TxPool) TrimOrphans(maxOrphans int) int {
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+
+	// See limitNumOrphans for why the iteration order does not matter.
+	numEvicted := 0
+	for txHash := range mp.orphans {
+		if len(mp.orphans) <= maxOrphans {
+			break
+		}
+		mp.removeOrphan(&txHash)
+		numEvicted++
+	}
+	return numEvicted
+}
+
+// totalSize returns the total serialized size of the transactions in the main
+// pool.
+//
+// This function MUST be called with the mempool lock held (for reads).
+func (mp *TxPool) totalSize() int64 {
+	var size int64
+	for _, desc := range mp.pool {
+		size += int64(desc.Tx.MsgTx().SerializeSize())
+	}
+	return size
+}
+
+// TrimToSize evicts the regular transactions which pay the lowest fee per
+// kilobyte, along with any transactions which redeem their outputs, until the
+// total serialized size of the transactions in the main pool is no more than
+// the passed size.  Stake transactions are never evicted since the votes,
+// tickets, and revocations they consist of are needed to keep the chain moving,
+// so the pool may remain larger than the passed size.  It returns the number of
+// evicted transactions.
+//
+// This function is safe for concurrent access.
+func (mp *TxPool) TrimToSize(maxSize int64) int {
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+
+	type evictCandidate struct {
+		desc     *TxDesc
+		size     int64
+		feePerKB int64
+	}
+	var totalSize int64
+	candidates := make([]evictCandidate, 0, len(mp.pool))
+	for _, desc := range mp.pool {
+		size := int64(desc.Tx.MsgTx().SerializeSize())
+		totalSize += size
+		if desc.Type != stake.TxTypeRegular {
+			continue
+		}
+		candidates = append(candidates, evictCandidate{
+			desc:     desc,
+			size:     size,
+			feePerKB: desc.Fee * 1000 / size,
+		})
+	}
+	if totalSize <= maxSize {
+		return 0
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].feePerKB < candidates[j].feePerKB
+	})
+
+	numBefore := len(mp.pool)
+	for _, candidate := range candidates {
+		if totalSize <= maxSize {
+			break
+		}
+
+		// Skip transactions which were already evicted as redeemers of
+		// an earlier candidate.
+		txHash := candidate.desc.Tx.Hash()
+		if _, exists := mp.pool[*txHash]; !exists {
+			continue
+		}
+		poolSize := len(mp.pool)
+		mp.removeTransaction(candidate.desc.Tx, true)
+		if len(mp.pool) == poolSize-1 {
+			totalSize -= candidate.size
+		} else {
+			totalSize = mp.totalSize()
+		}
+	}
+
+	numEvicted := numBefore - len(mp.pool)
+	log.Debugf("Evicted %d transactions to trim the pool to %d bytes",
+		numEvicted, maxSize)
+	return numEvicted
+}
+
 // TxHashes returns a slice of hashes for all of the transactions in the memory
 // pool.
 //
@@ -1490,6 +1619,41 @@ func (mp *TxPool) MiningDescs() []*mining.TxDesc {
 	return descs
 }
 
+// rawMempoolVerboseResult returns a fully populated JSON result for the provided
+// transaction descriptor.
+//
+// This function MUST be called with the mempool lock held (for reads).
+func (mp *TxPool) rawMempoolVerboseResult(desc *TxDesc, bestHeight int64) *exccjson.GetRawMempoolVerboseResult {
+	// Calculate the current priority based on the inputs to the
+	// transaction.  Use zero if one or more of the input transactions can't
+	// be found for some reason.
+	tx := desc.Tx
+	var currentPriority float64
+	utxos, err := mp.fetchInputUtxos(tx)
+	if err == nil {
+		currentPriority = mining.CalcPriority(tx.MsgTx(), utxos,
+			bestHeight+1)
+	}
+
+	mpd := &exccjson.GetRawMempoolVerboseResult{
+		Size:             int32(tx.MsgTx().SerializeSize()),
+		Fee:              exccutil.Amount(desc.Fee).ToCoin(),
+		Time:             desc.Added.Unix(),
+		Height:           desc.Height,
+		StartingPriority: desc.StartingPriority,
+		CurrentPriority:  currentPriority,
+		Depends:          make([]string, 0),
+	}
+	for _, txIn := range tx.MsgTx().TxIn {
+		hash := &txIn.PreviousOutPoint.Hash
+		if mp.haveTransaction(hash) {
+			mpd.Depends = append(mpd.Depends, hash.String())
+		}
+	}
+
+	return mpd
+}
+
 // RawMempoolVerbose returns all of the entries in the mempool filtered by the
 // provided stake type as a fully populated JSON result.  The filter type can be
 // nil in which case all transactions will be returned.
@@ -1510,35 +1674,29 @@ func (mp *TxPool) RawMempoolVerbose(filterType *stake.TxType) map[string]*exccjs
 			continue
 		}
 
-		// Calculate the current priority based on the inputs to
-		// the transaction.  Use zero if one or more of the
-		// input transactions can't be found for some reason.
-		tx := desc.Tx
-		var currentPriority float64
-		utxos, err := mp.fetchInputUtxos(tx)
-		if err == nil {
-			currentPriority = mining.CalcPriority(tx.MsgTx(), utxos,
-				bestHeight+1)
-		}
+		result[desc.Tx.Hash().String()] = mp.rawMempoolVerboseResult(desc,
+			bestHeight)
+	}
 
-		mpd := &exccjson.GetRawMempoolVerboseResult{
-			Size:             int32(tx.MsgTx().SerializeSize()),
-			Fee:              exccutil.Amount(desc.Fee).ToCoin(),
-			Time:             desc.Added.Unix(),
-			Height:           desc.Height,
-			StartingPriority: desc.StartingPriority,
-			CurrentPriority:  currentPriority,
-			Depends:          make([]string, 0),
-		}
-		for _, txIn := range tx.MsgTx().TxIn {
-			hash := &txIn.PreviousOutPoint.Hash
-			if mp.haveTransaction(hash) {
-				mpd.Depends = append(mpd.Depends,
-					hash.String())
-			}
-		}
+	return result
+}
 
-		result[tx.Hash().String()] = mpd
+// RawMempoolVerboseDescs returns fully populated JSON results for the provided
+// transaction descriptors in the same order, such as those returned by
+// TxDescs.  The transaction hash of each result is set since the results are
+// not keyed by it.
+//
+// This function is safe for concurrent access.
+func (mp *TxPool) RawMempoolVerboseDescs(descs []*TxDesc) []*exccjson.GetRawMempoolVerboseResult {
+	mp.mtx.RLock()
+	defer mp.mtx.RUnlock()
+
+	result := make([]*exccjson.GetRawMempoolVerboseResult, 0, len(descs))
+	bestHeight := mp.cfg.BestHeight()
+	for _, desc := range descs {
+		mpd := mp.rawMempoolVerboseResult(desc, bestHeight)
+		mpd.TxID = desc.Tx.Hash().String()
+		result = append(result, mpd)
 	}
 
 	return result
@@ -1555,7 +1713,7 @@ func (mp *TxPool) LastUpdated() time.Time {
 // New returns a new memory pool for validating and storing standalone
 // transactions until they are mined into a block.
 func New(cfg *Config) *TxPool {
-	return &TxPool{
+	mp := &TxPool{
 		cfg:           *cfg,
 		pool:          make(map[chainhash.Hash]*TxDesc),
 		orphans:       make(map[chainhash.Hash]*exccutil.Tx),
@@ -1563,4 +1721,8 @@ func New(cfg *Config) *TxPool {
 		outpoints:     make(map[wire.OutPoint]*exccutil.Tx),
 		votes:         make(map[chainhash.Hash][]mining.VoteDesc),
 	}
+	if mp.cfg.Clock == nil {
+		mp.cfg.Clock = blockchain.SystemClock
+	}
+	return mp
 }
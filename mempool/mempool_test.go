@@ -20,6 +20,7 @@ import (
 	"github.com/EXCCoin/exccd/chaincfg/chainhash"
 	"github.com/EXCCoin/exccd/exccec/secp256k1"
 	"github.com/EXCCoin/exccd/exccutil"
+	"github.com/EXCCoin/exccd/mining"
 	"github.com/EXCCoin/exccd/txscript"
 	"github.com/EXCCoin/exccd/wire"
 )
@@ -390,7 +391,11 @@ func newPoolHarness(chainParams *chaincfg.Params) (*poolHarness, []spendableOutp
 				MaxOrphanTxSize:      1000,
 				MaxSigOpsPerTx:       blockchain.MaxSigOpsPerBlock / 5,
 				MinRelayTxFee:        1000, // 1 Satoshi per byte
-				StandardVerifyFlags:  chain.StandardVerifyFlags,
+				DataCarrier: mining.DataCarrierPolicy{
+					MaxSize:    txscript.MaxDataCarrierSize,
+					MaxOutputs: mining.DefaultMaxDataCarrierOutputs,
+				},
+				StandardVerifyFlags: chain.StandardVerifyFlags,
 			},
 			ChainParams:         chainParams,
 			NextStakeDifficulty: chain.NextStakeDifficulty,
@@ -653,3 +658,71 @@ func TestOrphanEviction(t *testing.T) {
 		}
 	}
 }
+
+// TestTrimPool ensures trimming the main and orphan pools evicts transactions
+// until they are within the requested limits.
+func TestTrimPool(t *testing.T) {
+	t.Parallel()
+
+	harness, outputs, err := newPoolHarness(&chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("unable to create test pool: %v", err)
+	}
+
+	// Create a chain of transactions and add the first part of it to the
+	// main pool and the part after the missing transaction which follows
+	// it to the orphan pool.
+	chainedTxns, err := harness.CreateTxChain(outputs[0], 7)
+	if err != nil {
+		t.Fatalf("unable to create transaction chain: %v", err)
+	}
+	pooledTxns, orphanTxns := chainedTxns[:3], chainedTxns[3:]
+	for _, tx := range chainedTxns {
+		if tx == orphanTxns[0] {
+			continue
+		}
+		_, err := harness.txPool.ProcessTransaction(tx, true, false, true)
+		if err != nil {
+			t.Fatalf("ProcessTransaction: failed to accept valid "+
+				"transaction %v", err)
+		}
+	}
+	if count := harness.txPool.Count(); count != len(pooledTxns) {
+		t.Fatalf("unexpected pool count -- got %d, want %d", count,
+			len(pooledTxns))
+	}
+
+	// Ensure the pools are not trimmed when they are within the limits.
+	if n := harness.txPool.TrimToSize(1 << 20); n != 0 {
+		t.Fatalf("TrimToSize: unexpected evictions %d", n)
+	}
+	if n := harness.txPool.TrimOrphans(3); n != 0 {
+		t.Fatalf("TrimOrphans: unexpected evictions %d", n)
+	}
+
+	// Ensure the orphan pool is trimmed to the limit.
+	if n := harness.txPool.TrimOrphans(1); n != 2 {
+		t.Fatalf("TrimOrphans: unexpected evictions -- got %d, want 2", n)
+	}
+	numOrphans := 0
+	for _, tx := range orphanTxns[1:] {
+		if harness.txPool.IsOrphanInPool(tx.Hash()) {
+			numOrphans++
+		}
+	}
+	if numOrphans != 1 {
+		t.Fatalf("unexpected orphans -- got %d, want 1", numOrphans)
+	}
+
+	// Ensure trimming the main pool to nothing evicts every transaction,
+	// including those evicted as redeemers of the others.
+	if n := harness.txPool.TrimToSize(0); n != len(pooledTxns) {
+		t.Fatalf("TrimToSize: unexpected evictions -- got %d, want %d",
+			n, len(pooledTxns))
+	}
+	for _, tx := range pooledTxns {
+		if harness.txPool.IsTransactionInPool(tx.Hash()) {
+			t.Fatal("IsTransactionInPool: true for evicted transaction")
+		}
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/EXCCoin/exccd/blockchain"
 	"github.com/EXCCoin/exccd/blockchain/stake"
 	"github.com/EXCCoin/exccd/exccutil"
+	"github.com/EXCCoin/exccd/mining"
 	"github.com/EXCCoin/exccd/txscript"
 	"github.com/EXCCoin/exccd/wire"
 )
@@ -209,12 +210,12 @@ func checkPkScriptStandard(version uint16, pkScript []byte,
 	return nil
 }
 
-// isDust returns whether or not the passed transaction output amount is
+// IsDust returns whether or not the passed transaction output amount is
 // considered dust or not based on the passed minimum transaction relay fee.
 // Dust is defined in terms of the minimum transaction relay fee.  In
 // particular, if the cost to the network to spend coins is more than 1/3 of the
 // minimum transaction relay fee, it is considered dust.
-func isDust(txOut *wire.TxOut, minRelayTxFee exccutil.Amount) bool {
+func IsDust(txOut *wire.TxOut, minRelayTxFee exccutil.Amount) bool {
 	// Unspendable outputs are considered dust.
 	if txscript.IsUnspendable(txOut.Value, txOut.PkScript) {
 		return true
@@ -288,10 +289,12 @@ func isDust(txOut *wire.TxOut, minRelayTxFee exccutil.Amount) bool {
 // "sane" transaction such as having a version in the supported range, being
 // finalized, conforming to more stringent size constraints, having scripts
 // of recognized forms, and not containing "dust" outputs (those that are
-// so small it costs more to process them than they are worth).
+// so small it costs more to process them than they are worth).  Null data
+// outputs of regular transactions must also conform to the provided data
+// carrier policy.
 func checkTransactionStandard(tx *exccutil.Tx, txType stake.TxType, height int64,
 	medianTime time.Time, minRelayTxFee exccutil.Amount,
-	maxTxVersion uint16) error {
+	maxTxVersion uint16, dataCarrier *mining.DataCarrierPolicy) error {
 
 	// The transaction must be a currently supported version and serialize
 	// type.
@@ -350,7 +353,6 @@ func checkTransactionStandard(tx *exccutil.Tx, txType stake.TxType, height int64
 
 	// None of the output public key scripts can be a non-standard script or
 	// be "dust" (except when the script is a null data script).
-	numNullDataOutputs := 0
 	for i, txOut := range msgTx.TxOut {
 		scriptClass := txscript.GetScriptClass(txOut.Version, txOut.PkScript)
 		err := checkPkScriptStandard(txOut.Version, txOut.PkScript, scriptClass)
@@ -366,26 +368,22 @@ func checkTransactionStandard(tx *exccutil.Tx, txType stake.TxType, height int64
 			return txRuleError(rejectCode, str)
 		}
 
-		// Accumulate the number of outputs which only carry data.  For
-		// all other script types, ensure the output value is not
-		// "dust".
-		if scriptClass == txscript.NullDataTy {
-			numNullDataOutputs++
-		} else if txType == stake.TxTypeRegular && isDust(txOut, minRelayTxFee) {
+		// Ensure the output value is not "dust" for all script types
+		// other than those which only carry data.
+		if scriptClass != txscript.NullDataTy &&
+			txType == stake.TxTypeRegular && IsDust(txOut, minRelayTxFee) {
+
 			str := fmt.Sprintf("transaction output %d: payment "+
 				"of %d is dust", i, txOut.Value)
 			return txRuleError(wire.RejectDust, str)
 		}
 	}
 
-	// A standard transaction must not have more than one output script that
-	// only carries data. However, certain types of standard stake transactions
-	// are allowed to have multiple OP_RETURN outputs, so only throw an error here
-	// if the tx is TxTypeRegular.
-	if numNullDataOutputs > maxNullDataOutputs && txType == stake.TxTypeRegular {
-		str := "more than one transaction output in a nulldata script for a " +
-			"regular type tx"
-		return txRuleError(wire.RejectNonstandard, str)
+	// The outputs of regular transactions which only carry data must
+	// conform to the data carrier policy.  Stake transactions are exempt
+	// since their null data outputs are required by the consensus rules.
+	if err := dataCarrier.Check(msgTx, txType); err != nil {
+		return txRuleError(wire.RejectNonstandard, err.Error())
 	}
 
 	return nil
@@ -17,6 +17,7 @@ import (
 	"github.com/EXCCoin/exccd/chaincfg/chainec"
 	"github.com/EXCCoin/exccd/chaincfg/chainhash"
 	"github.com/EXCCoin/exccd/exccutil"
+	"github.com/EXCCoin/exccd/mining"
 	"github.com/EXCCoin/exccd/txscript"
 	"github.com/EXCCoin/exccd/wire"
 )
@@ -277,7 +278,7 @@ func TestDust(t *testing.T) {
 		},
 	}
 	for _, test := range tests {
-		res := isDust(&test.txOut, test.relayFee)
+		res := IsDust(&test.txOut, test.relayFee)
 		if res != test.isDust {
 			t.Fatalf("Dust test '%s' failed: want %v got %v",
 				test.name, test.isDust, res)
@@ -323,12 +324,28 @@ func TestCheckTransactionStandard(t *testing.T) {
 		PkScript: dummyPkScript,
 	}
 
+	// defaultDataCarrier is the data carrier policy used by the tests which
+	// do not specify one.
+	defaultDataCarrier := mining.DataCarrierPolicy{
+		MaxSize:    txscript.MaxDataCarrierSize,
+		MaxOutputs: mining.DefaultMaxDataCarrierOutputs,
+	}
+	nullDataScript := func(data []byte) []byte {
+		script, err := txscript.NewScriptBuilder().AddOp(txscript.OP_RETURN).
+			AddData(data).Script()
+		if err != nil {
+			t.Fatalf("unable to create null data script: %v", err)
+		}
+		return script
+	}
+
 	tests := []struct {
-		name       string
-		tx         wire.MsgTx
-		height     int64
-		isStandard bool
-		code       wire.RejectCode
+		name        string
+		tx          wire.MsgTx
+		height      int64
+		dataCarrier *mining.DataCarrierPolicy
+		isStandard  bool
+		code        wire.RejectCode
 	}{
 		{
 			name: "Typical pay-to-pubkey-hash transaction",
@@ -513,15 +530,102 @@ func TestCheckTransactionStandard(t *testing.T) {
 			height:     300000,
 			isStandard: true,
 		},
+		{
+			name: "Null data output at configured max size (standard)",
+			tx: wire.MsgTx{
+				SerType: wire.TxSerializeFull,
+				Version: 1,
+				TxIn:    []*wire.TxIn{&dummyTxIn},
+				TxOut: []*wire.TxOut{{
+					Value:    0,
+					PkScript: nullDataScript([]byte{1, 2, 3, 4}),
+				}},
+				LockTime: 0,
+			},
+			height: 300000,
+			dataCarrier: &mining.DataCarrierPolicy{
+				MaxSize:    4,
+				MaxOutputs: 1,
+			},
+			isStandard: true,
+		},
+		{
+			name: "Null data output larger than configured max size",
+			tx: wire.MsgTx{
+				SerType: wire.TxSerializeFull,
+				Version: 1,
+				TxIn:    []*wire.TxIn{&dummyTxIn},
+				TxOut: []*wire.TxOut{{
+					Value:    0,
+					PkScript: nullDataScript([]byte{1, 2, 3, 4, 5}),
+				}},
+				LockTime: 0,
+			},
+			height: 300000,
+			dataCarrier: &mining.DataCarrierPolicy{
+				MaxSize:    4,
+				MaxOutputs: 1,
+			},
+			isStandard: false,
+			code:       wire.RejectNonstandard,
+		},
+		{
+			name: "More null data outputs than configured max",
+			tx: wire.MsgTx{
+				SerType: wire.TxSerializeFull,
+				Version: 1,
+				TxIn:    []*wire.TxIn{&dummyTxIn},
+				TxOut: []*wire.TxOut{{
+					Value:    0,
+					PkScript: []byte{txscript.OP_RETURN},
+				}, {
+					Value:    0,
+					PkScript: []byte{txscript.OP_RETURN},
+				}},
+				LockTime: 0,
+			},
+			height: 300000,
+			dataCarrier: &mining.DataCarrierPolicy{
+				MaxSize:    txscript.MaxDataCarrierSize,
+				MaxOutputs: 1,
+			},
+			isStandard: false,
+			code:       wire.RejectNonstandard,
+		},
+		{
+			name: "Null data output with data carriers disabled",
+			tx: wire.MsgTx{
+				SerType: wire.TxSerializeFull,
+				Version: 1,
+				TxIn:    []*wire.TxIn{&dummyTxIn},
+				TxOut: []*wire.TxOut{{
+					Value:    0,
+					PkScript: []byte{txscript.OP_RETURN},
+				}},
+				LockTime: 0,
+			},
+			height: 300000,
+			dataCarrier: &mining.DataCarrierPolicy{
+				Disable:    true,
+				MaxSize:    txscript.MaxDataCarrierSize,
+				MaxOutputs: mining.DefaultMaxDataCarrierOutputs,
+			},
+			isStandard: false,
+			code:       wire.RejectNonstandard,
+		},
 	}
 
 	medianTime := time.Now()
 	for _, test := range tests {
 		// Ensure standardness is as expected.
 		tx := exccutil.NewTx(&test.tx)
+		dataCarrier := test.dataCarrier
+		if dataCarrier == nil {
+			dataCarrier = &defaultDataCarrier
+		}
 		err := checkTransactionStandard(tx, stake.DetermineTxType(&test.tx),
 			test.height, medianTime, DefaultMinRelayTxFee,
-			maxTxVersion)
+			maxTxVersion, dataCarrier)
 		if err == nil && test.isStandard {
 			// Test passes since function returned standard for a
 			// transaction which is intended to be standard.
@@ -0,0 +1,231 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/EXCCoin/exccd/chaincfg/chainhash"
+	"github.com/EXCCoin/exccd/exccjson"
+)
+
+const (
+	// minedBlockRetention is the amount of time the outcome of each mined
+	// block is kept for.  It is the longest window the statistics are
+	// reported for.  Older blocks are only counted in the totals since
+	// startup.
+	minedBlockRetention = 7 * 24 * time.Hour
+
+	// maxMinedBlocks is the maximum number of mined blocks whose outcome is
+	// kept.  Older blocks are only counted in the totals since startup,
+	// which bounds the memory used when blocks are mined quickly such as on
+	// the simulation test network.
+	maxMinedBlocks = 10000
+)
+
+// minedBlockStale is the outcome of an accepted mined block which is no longer
+// part of the main chain.
+const minedBlockStale = "stale"
+
+// minedBlockWindows are the windows the mined block statistics are reported
+// for in addition to the totals since startup.
+var minedBlockWindows = []struct {
+	name     string
+	duration time.Duration
+}{
+	{"1h", time.Hour},
+	{"24h", 24 * time.Hour},
+	{"7d", minedBlockRetention},
+}
+
+// minedBlockChain describes the chain state used to find out whether accepted
+// mined blocks are still part of the main chain.  It is satisfied by
+// *blockchain.BlockChain.
+type minedBlockChain interface {
+	MainChainHasBlock(hash *chainhash.Hash) (bool, error)
+}
+
+// minedBlock houses the outcome of processing a block mined by this node or
+// submitted by its miners.
+type minedBlock struct {
+	hash    chainhash.Hash
+	time    time.Time
+	outcome string
+}
+
+// minedBlockCounts houses the number of mined blocks with each outcome.
+// Accepted blocks which are no longer part of the main chain are counted as
+// stale instead of accepted.
+type minedBlockCounts struct {
+	accepted uint64
+	stale    uint64
+	orphaned uint64
+	rejected uint64
+}
+
+// add counts a mined block with the passed outcome.
+func (c *minedBlockCounts) add(outcome string) {
+	switch outcome {
+	case miningOutcomeAccepted:
+		c.accepted++
+	case minedBlockStale:
+		c.stale++
+	case miningOutcomeOrphan:
+		c.orphaned++
+	case miningOutcomeRejected:
+		c.rejected++
+	}
+}
+
+// result returns the counts as a mined block statistics result for the window
+// with the passed name.  The stale rate is the fraction of the valid mined
+// blocks which did not end up in the main chain, either because they were
+// orphans or because they were reorganized out of it.
+func (c *minedBlockCounts) result(window string) exccjson.MinedBlockStatsResult {
+	var staleRate float64
+	lost := c.stale + c.orphaned
+	if valid := c.accepted + lost; valid > 0 {
+		staleRate = float64(lost) / float64(valid)
+	}
+	return exccjson.MinedBlockStatsResult{
+		Window:    window,
+		Accepted:  c.accepted,
+		Stale:     c.stale,
+		Orphaned:  c.orphaned,
+		Rejected:  c.rejected,
+		StaleRate: staleRate,
+	}
+}
+
+// minedBlockStats tracks how many of the blocks mined by this node or submitted
+// by its miners were accepted and remained in the main chain versus how many
+// ended up stale, orphaned, or rejected, so miners can quantify their
+// propagation disadvantage.
+//
+// Whether accepted blocks are stale, and whether orphans joined the main chain,
+// is determined when the statistics are requested, so blocks which are
+// reorganized out of the main chain and back into it are counted correctly.
+type minedBlockStats struct {
+	mtx     sync.Mutex
+	chain   minedBlockChain
+	now     func() time.Time
+	started time.Time
+	blocks  []minedBlock
+	pruned  minedBlockCounts
+}
+
+// newMinedBlockStats returns a mined block statistics tracker which determines
+// whether accepted blocks are still part of the passed chain.
+func newMinedBlockStats(chain minedBlockChain) *minedBlockStats {
+	return &minedBlockStats{
+		chain:   chain,
+		now:     time.Now,
+		started: time.Now(),
+	}
+}
+
+// outcome returns the outcome of the passed mined block, which is stale for
+// accepted blocks which are no longer part of the main chain.  Orphans which
+// joined the main chain once their parent arrived are accepted from then on,
+// so they are counted as stale when they are reorganized out of it again.
+//
+// This function MUST be called with the mutex held.
+func (m *minedBlockStats) outcome(b *minedBlock) string {
+	switch b.outcome {
+	case miningOutcomeAccepted, miningOutcomeOrphan:
+	default:
+		return b.outcome
+	}
+	inMainChain, err := m.chain.MainChainHasBlock(&b.hash)
+	if err != nil {
+		minrLog.Warnf("Unable to determine whether mined block %v is in "+
+			"the main chain: %v", b.hash, err)
+		return b.outcome
+	}
+	if b.outcome == miningOutcomeOrphan {
+		if inMainChain {
+			b.outcome = miningOutcomeAccepted
+		}
+		return b.outcome
+	}
+	if !inMainChain {
+		return minedBlockStale
+	}
+	return b.outcome
+}
+
+// prune moves the mined blocks older than the retention period, or beyond the
+// maximum number of kept blocks, into the totals.
+//
+// This function MUST be called with the mutex held.
+func (m *minedBlockStats) prune(now time.Time) {
+	var n int
+	for n < len(m.blocks) && (len(m.blocks)-n > maxMinedBlocks ||
+		now.Sub(m.blocks[n].time) >= minedBlockRetention) {
+
+		m.pruned.add(m.outcome(&m.blocks[n]))
+		n++
+	}
+	if n > 0 {
+		m.blocks = append(m.blocks[:0:0], m.blocks[n:]...)
+	}
+}
+
+// Add records the outcome of processing the mined block with the passed hash.
+// Only the accepted, orphan, and rejected outcomes are counted.
+//
+// This function is safe for concurrent access.
+func (m *minedBlockStats) Add(hash *chainhash.Hash, outcome string) {
+	switch outcome {
+	case miningOutcomeAccepted, miningOutcomeOrphan, miningOutcomeRejected:
+	default:
+		return
+	}
+
+	m.mtx.Lock()
+	now := m.now()
+	m.blocks = append(m.blocks, minedBlock{
+		hash:    *hash,
+		time:    now,
+		outcome: outcome,
+	})
+	m.prune(now)
+	m.mtx.Unlock()
+}
+
+// Stats returns the mined block statistics for each of the reporting windows
+// followed by the totals since startup along with the time the tracking
+// started.
+//
+// This function is safe for concurrent access.
+func (m *minedBlockStats) Stats() ([]exccjson.MinedBlockStatsResult, time.Time) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	now := m.now()
+	m.prune(now)
+
+	windows := make([]minedBlockCounts, len(minedBlockWindows))
+	total := m.pruned
+	for i := range m.blocks {
+		b := &m.blocks[i]
+		outcome := m.outcome(b)
+		total.add(outcome)
+		for j, window := range minedBlockWindows {
+			if now.Sub(b.time) < window.duration {
+				windows[j].add(outcome)
+			}
+		}
+	}
+
+	results := make([]exccjson.MinedBlockStatsResult, 0,
+		len(minedBlockWindows)+1)
+	for i, window := range minedBlockWindows {
+		results = append(results, windows[i].result(window.name))
+	}
+	results = append(results, total.result("all"))
+	return results, m.started
+}
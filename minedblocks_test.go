@@ -0,0 +1,119 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/EXCCoin/exccd/chaincfg/chainhash"
+	"github.com/EXCCoin/exccd/exccjson"
+)
+
+// fakeMinedBlockChain is a minedBlockChain whose main chain consists of the
+// blocks in the map.
+type fakeMinedBlockChain map[chainhash.Hash]bool
+
+// MainChainHasBlock returns whether the block with the passed hash is in the
+// fake main chain.
+func (c fakeMinedBlockChain) MainChainHasBlock(hash *chainhash.Hash) (bool, error) {
+	return c[*hash], nil
+}
+
+// TestMinedBlockStats ensures the mined block statistics count the outcome of
+// blocks in the windows they were mined in, count accepted blocks which are no
+// longer in the main chain as stale, and keep counting blocks past the
+// retention period in the totals.
+func TestMinedBlockStats(t *testing.T) {
+	chain := make(fakeMinedBlockChain)
+	now := time.Unix(1530000000, 0)
+	m := newMinedBlockStats(chain)
+	m.now = func() time.Time { return now }
+
+	// add records a block with the passed outcome mined at the passed time
+	// and adds accepted blocks to the main chain.
+	var n byte
+	add := func(age time.Duration, outcome string) chainhash.Hash {
+		n++
+		hash := chainhash.Hash{n}
+		if outcome == miningOutcomeAccepted {
+			chain[hash] = true
+		}
+		saved := now
+		now = now.Add(-age)
+		m.Add(&hash, outcome)
+		now = saved
+		return hash
+	}
+
+	add(8*24*time.Hour, miningOutcomeAccepted)
+	add(2*24*time.Hour, miningOutcomeOrphan)
+	add(2*time.Hour, miningOutcomeAccepted)
+	stale := add(2*time.Hour, miningOutcomeAccepted)
+	add(time.Minute, miningOutcomeAccepted)
+	add(time.Minute, miningOutcomeRejected)
+	add(time.Minute, miningOutcomeDropped)
+
+	// The block reorganized out of the main chain is stale.
+	delete(chain, stale)
+
+	want := []exccjson.MinedBlockStatsResult{
+		{Window: "1h", Accepted: 1, Rejected: 1},
+		{Window: "24h", Accepted: 2, Stale: 1, Rejected: 1, StaleRate: 1.0 / 3},
+		{Window: "7d", Accepted: 2, Stale: 1, Orphaned: 1, Rejected: 1,
+			StaleRate: 0.5},
+		{Window: "all", Accepted: 3, Stale: 1, Orphaned: 1, Rejected: 1,
+			StaleRate: 0.4},
+	}
+	got, since := m.Stats()
+	if len(got) != len(want) {
+		t.Fatalf("unexpected number of windows %d, want %d", len(got),
+			len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("window %d: unexpected stats: got %+v, want %+v", i,
+				got[i], want[i])
+		}
+	}
+	if !since.Equal(m.started) {
+		t.Errorf("unexpected start time %v, want %v", since, m.started)
+	}
+	if len(m.blocks) != 5 {
+		t.Errorf("unexpected number of kept blocks %d, want 5",
+			len(m.blocks))
+	}
+
+	// Blocks which are reorganized back into the main chain are no longer
+	// stale.
+	chain[stale] = true
+	got, _ = m.Stats()
+	wantAll := exccjson.MinedBlockStatsResult{Window: "all", Accepted: 4,
+		Orphaned: 1, Rejected: 1, StaleRate: 0.2}
+	if got[3] != wantAll {
+		t.Errorf("unexpected stats after reorganization: got %+v, want %+v",
+			got[3], wantAll)
+	}
+
+	// Orphans which join the main chain once their parent arrives are
+	// accepted, and stale when they are reorganized out of it again.
+	orphan := add(time.Minute, miningOutcomeOrphan)
+	chain[orphan] = true
+	got, _ = m.Stats()
+	wantAll = exccjson.MinedBlockStatsResult{Window: "all", Accepted: 5,
+		Orphaned: 1, Rejected: 1, StaleRate: 1.0 / 6}
+	if got[3] != wantAll {
+		t.Errorf("unexpected stats after orphan joined the main chain: "+
+			"got %+v, want %+v", got[3], wantAll)
+	}
+	delete(chain, orphan)
+	got, _ = m.Stats()
+	wantAll = exccjson.MinedBlockStatsResult{Window: "all", Accepted: 4,
+		Stale: 1, Orphaned: 1, Rejected: 1, StaleRate: 2.0 / 6}
+	if got[3] != wantAll {
+		t.Errorf("unexpected stats after orphan was reorganized out of "+
+			"the main chain: got %+v, want %+v", got[3], wantAll)
+	}
+}
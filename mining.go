@@ -21,6 +21,7 @@ import (
 	"github.com/EXCCoin/exccd/exccutil"
 	"github.com/EXCCoin/exccd/mempool"
 	"github.com/EXCCoin/exccd/mining"
+	"github.com/EXCCoin/exccd/tracing"
 	"github.com/EXCCoin/exccd/txscript"
 	"github.com/EXCCoin/exccd/wire"
 )
@@ -281,6 +282,28 @@ func (b byNumberOfVotes) Less(i, j int) bool {
 //
 // This function is safe for concurrent access.
 func SortParentsByVotes(txSource mining.TxSource, currentTopBlock chainhash.Hash, blocks []chainhash.Hash, params *chaincfg.Params) []chainhash.Hash {
+	minVotesRequired := (params.TicketsPerBlock / 2) + 1
+	return sortParentsByVotes(txSource, currentTopBlock, blocks,
+		minVotesRequired)
+}
+
+// minVotesRequired returns the minimum number of votes for a block that must
+// be available according to the passed mining policy before a block template
+// extending it is generated.  It is never less than the consensus minimum.
+func minVotesRequired(policy *mining.Policy, params *chaincfg.Params) uint16 {
+	minVotes := (params.TicketsPerBlock / 2) + 1
+	if policy.MinVotes > minVotes {
+		minVotes = policy.MinVotes
+	}
+	return minVotes
+}
+
+// sortParentsByVotes is the implementation of SortParentsByVotes which allows
+// the minimum number of votes a block must have in order to be eligible to be
+// specified.
+//
+// This function is safe for concurrent access.
+func sortParentsByVotes(txSource mining.TxSource, currentTopBlock chainhash.Hash, blocks []chainhash.Hash, minVotesRequired uint16) []chainhash.Hash {
 	// Return now when no blocks were provided.
 	lenBlocks := len(blocks)
 	if lenBlocks == 0 {
@@ -290,7 +313,6 @@ func SortParentsByVotes(txSource mining.TxSource, currentTopBlock chainhash.Hash
 	// Fetch the vote metadata for the provided block hashes from the
 	// mempool and filter out any blocks that do not have the minimum
 	// required number of votes.
-	minVotesRequired := (params.TicketsPerBlock / 2) + 1
 	voteMetadata := txSource.VotesForBlocks(blocks)
 	filtered := make([]*blockWithNumVotes, 0, lenBlocks)
 	for i := range blocks {
@@ -792,7 +814,8 @@ func handleTooFewVoters(subsidyCache *blockchain.SubsidyCache, nextHeight int64,
 				// Choose a new extra nonce value that is one greater
 				// than the previous extra nonce, so we don't remine the
 				// same block and choose the same winners as before.
-				en := cptCopy.extractCoinbaseExtraNonce() + 1
+				en := rigExtraNonce(cfg.MiningRigID,
+					cptCopy.extractCoinbaseExtraNonce()+1)
 				err = UpdateExtraNonce(cptCopy.Block, cptCopy.Height, en)
 				if err != nil {
 					return nil, err
@@ -834,7 +857,7 @@ func handleTooFewVoters(subsidyCache *blockchain.SubsidyCache, nextHeight int64,
 					return nil, miningRuleError(ErrGetTopBlock, str)
 				}
 				btMsgBlock := new(wire.MsgBlock)
-				rand, err := wire.RandomUint64()
+				rand, err := randomExtraNonce()
 				if err != nil {
 					return nil, err
 				}
@@ -1051,7 +1074,33 @@ func handleCreatedBlockTemplate(blockTemplate *BlockTemplate, bm *blockManager)
 //
 //  This function returns nil, nil if there are not enough voters on any of
 //  the current top blocks to create a new block template.
+//
+// The generation of the template is recorded as a span when tracing is
+// enabled.
 func NewBlockTemplate(policy *mining.Policy, server *server, payToAddress exccutil.Address) (*BlockTemplate, error) {
+	// The minimum fee of the policy may be changed by reloading the config,
+	// so generate the template using a copy of the current policy.
+	reloadMtx.RLock()
+	currentPolicy := *policy
+	reloadMtx.RUnlock()
+
+	span := tracing.Start("mining.NewBlockTemplate")
+	template, err := newBlockTemplate(&currentPolicy, server, payToAddress)
+	if template != nil {
+		span.SetAttribute("block.height", template.Height)
+		span.SetAttribute("block.transactions",
+			len(template.Block.Transactions))
+		span.SetAttribute("block.stransactions",
+			len(template.Block.STransactions))
+	}
+	span.SetError(err)
+	span.End()
+	return template, err
+}
+
+// newBlockTemplate generates a new block template as described by
+// NewBlockTemplate.
+func newBlockTemplate(policy *mining.Policy, server *server, payToAddress exccutil.Address) (*BlockTemplate, error) {
 	var txSource mining.TxSource = server.txMemPool
 	blockManager := server.blockManager
 	timeSource := server.timeSource
@@ -1112,8 +1161,10 @@ func NewBlockTemplate(policy *mining.Policy, server *server, payToAddress exccut
 			prevHash, nextBlockHeight-1, chainBest.Hash, chainBest.Height)
 	}
 
-	// Calculate the stake enabled height.
+	// Calculate the stake enabled height and the minimum number of votes
+	// required to extend a block according to the policy.
 	stakeValidationHeight := server.chainParams.StakeValidationHeight
+	minVotes := minVotesRequired(policy, server.chainParams)
 
 	if nextBlockHeight >= stakeValidationHeight {
 		// Obtain the entire generation of blocks stemming from this parent.
@@ -1125,8 +1176,8 @@ func NewBlockTemplate(policy *mining.Policy, server *server, payToAddress exccut
 		// Get the list of blocks that we can actually build on top of. If we're
 		// not currently on the block that has the most votes, switch to that
 		// block.
-		eligibleParents := SortParentsByVotes(txSource, *prevHash, children,
-			blockManager.server.chainParams)
+		eligibleParents := sortParentsByVotes(txSource, *prevHash, children,
+			minVotes)
 		if len(eligibleParents) == 0 {
 			minrLog.Debugf("Too few voters found on any HEAD block, " +
 				"recycling a parent block to mine on")
@@ -1134,6 +1185,27 @@ func NewBlockTemplate(policy *mining.Policy, server *server, payToAddress exccut
 				payToAddress, server.blockManager)
 		}
 
+		// Keep waiting for any votes that are still missing for the
+		// parent when the policy calls for it and the maximum wait
+		// duration since the first block at its height was connected
+		// has not elapsed yet.
+		if policy.MaxVoteWait > 0 {
+			ticketsPerBlock := int(server.chainParams.TicketsPerBlock)
+			parentVotes := txSource.VotesForBlocks(eligibleParents[:1])
+			numVotes := len(parentVotes[0])
+			chainState.Lock()
+			waited := time.Since(chainState.newestTime)
+			chainState.Unlock()
+			if numVotes < ticketsPerBlock && waited < policy.MaxVoteWait {
+				minrLog.Debugf("Waiting for missing votes on HEAD "+
+					"block %v (%d of %d votes found after %v)",
+					eligibleParents[0], numVotes, ticketsPerBlock,
+					waited)
+				return handleTooFewVoters(subsidyCache, nextBlockHeight,
+					payToAddress, server.blockManager)
+			}
+		}
+
 		minrLog.Debugf("Found eligible parent %v with enough votes to build "+
 			"block on, proceeding to create a new block template",
 			eligibleParents[0])
@@ -1228,6 +1300,13 @@ mempoolLoop:
 			continue
 		}
 
+		// Skip transactions with null data outputs which violate the
+		// data carrier policy.
+		if err := policy.DataCarrier.Check(msgTx, txDesc.Type); err != nil {
+			minrLog.Tracef("Skipping tx %s: %v", tx.Hash(), err)
+			continue
+		}
+
 		// Need this for a check below for stake base input, and to check
 		// the ticket number.
 		isSSGen := txDesc.Type == stake.TxTypeSSGen
@@ -1710,6 +1789,12 @@ mempoolLoop:
 			break // No SSRtx should be present before this height.
 		}
 
+		// Revocations are excluded entirely when the policy calls for
+		// it.
+		if policy.NoRevocations {
+			break
+		}
+
 		msgTx := tx.MsgTx()
 		if tx.Tree() == wire.TxTreeStake && stake.IsSSRtx(msgTx) {
 			txCopy := exccutil.NewTxDeepTxIns(msgTx)
@@ -1741,7 +1826,7 @@ mempoolLoop:
 	// Add a random coinbase nonce to ensure that tx prefix hash
 	// so that our merkle root is unique for lookups needed for
 	// getwork, etc.
-	rand, err := wire.RandomUint64()
+	rand, err := randomExtraNonce()
 	if err != nil {
 		return nil, err
 	}
@@ -1857,10 +1942,7 @@ mempoolLoop:
 	// Return nil if we don't yet have enough voters; sometimes it takes a
 	// bit for the mempool to sync with the votes map and we end up down
 	// here despite having the relevant votes available in the votes map.
-	minimumVotesRequired :=
-		int((server.chainParams.TicketsPerBlock / 2) + 1)
-	if nextBlockHeight >= stakeValidationHeight &&
-		voters < minimumVotesRequired {
+	if nextBlockHeight >= stakeValidationHeight && voters < int(minVotes) {
 		minrLog.Warnf("incongruent number of voters in mempool " +
 			"vs mempool.voters; not enough voters found")
 		return handleTooFewVoters(subsidyCache, nextBlockHeight, payToAddress,
@@ -2022,6 +2104,38 @@ mempoolLoop:
 	return handleCreatedBlockTemplate(blockTemplate, server.blockManager)
 }
 
+// rollBlockTime rolls the timestamp in the passed block header forward to the
+// current time when it has advanced since the timestamp was last set and
+// returns whether it did.  Finally, it will update the target difficulty if
+// needed based on the new time for the test networks since their target
+// difficulty can change based upon time.
+//
+// Unlike UpdateBlockTime, the median time of the last several blocks is not
+// consulted since the header timestamp already satisfies the consensus rules
+// and only moves forward, which allows miners to cheaply keep the timestamp
+// of the block they are working on current.
+func rollBlockTime(header *wire.BlockHeader, bManager *blockManager) (bool, error) {
+	newTimestamp := bManager.server.timeSource.AdjustedTime().Add(
+		time.Duration(-cfg.MiningTimeOffset) * time.Second)
+	if !newTimestamp.After(header.Timestamp) {
+		return false, nil
+	}
+	header.Timestamp = newTimestamp
+
+	// If running on a network that requires recalculating the difficulty,
+	// do so now.
+	if activeNetParams.ReduceMinDifficulty {
+		difficulty, err := bManager.chain.CalcNextRequiredDifficulty(
+			newTimestamp)
+		if err != nil {
+			return false, miningRuleError(ErrGettingDifficulty, err.Error())
+		}
+		header.Bits = difficulty
+	}
+
+	return true, nil
+}
+
 // UpdateBlockTime updates the timestamp in the header of the passed block to
 // the current time while taking into account the median time of the last
 // several blocks to ensure the new time is after that time per the chain
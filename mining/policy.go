@@ -7,8 +7,13 @@
 package mining
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/EXCCoin/exccd/blockchain"
+	"github.com/EXCCoin/exccd/blockchain/stake"
 	"github.com/EXCCoin/exccd/exccutil"
+	"github.com/EXCCoin/exccd/txscript"
 	"github.com/EXCCoin/exccd/wire"
 )
 
@@ -17,8 +22,71 @@ const (
 	// contextual transaction information provided in a transaction store
 	// when it has not yet been mined into a block.
 	UnminedHeight = 0x7fffffff
+
+	// DefaultMaxDataCarrierOutputs is the default maximum number of null
+	// data outputs a regular transaction may have.
+	DefaultMaxDataCarrierOutputs = 4
 )
 
+// DataCarrierPolicy houses the policy which controls the outputs of regular
+// transactions that only carry data (null data outputs).  Stake transactions are
+// not subject to the policy since their null data outputs are required by the
+// consensus rules.
+type DataCarrierPolicy struct {
+	// Disable specifies that regular transactions with any null data
+	// outputs are rejected.
+	Disable bool
+
+	// MaxSize is the maximum number of bytes of data a null data output
+	// may carry.  Null data outputs can never carry more than
+	// txscript.MaxDataCarrierSize bytes.
+	MaxSize int
+
+	// MaxOutputs is the maximum number of null data outputs a transaction
+	// may have.
+	MaxOutputs int
+}
+
+// Check returns an error when the passed transaction of the provided type has
+// null data outputs which violate the policy.
+func (p *DataCarrierPolicy) Check(tx *wire.MsgTx, txType stake.TxType) error {
+	if txType != stake.TxTypeRegular {
+		return nil
+	}
+
+	numNullDataOutputs := 0
+	for i, txOut := range tx.TxOut {
+		class := txscript.GetScriptClass(txOut.Version, txOut.PkScript)
+		if class != txscript.NullDataTy {
+			continue
+		}
+		if p.Disable {
+			return fmt.Errorf("transaction output %d: null data "+
+				"outputs are not accepted", i)
+		}
+
+		// Ignore the error since the script is already known to parse.
+		pushes, _ := txscript.PushedData(txOut.PkScript)
+		dataSize := 0
+		for _, data := range pushes {
+			dataSize += len(data)
+		}
+		if dataSize > p.MaxSize {
+			return fmt.Errorf("transaction output %d: null data "+
+				"size of %d bytes is larger than max allowed "+
+				"size of %d bytes", i, dataSize, p.MaxSize)
+		}
+		numNullDataOutputs++
+	}
+
+	if numNullDataOutputs > p.MaxOutputs {
+		return fmt.Errorf("transaction has %d null data outputs which "+
+			"is more than the max allowed of %d",
+			numNullDataOutputs, p.MaxOutputs)
+	}
+	return nil
+}
+
 // Policy houses the policy (configuration parameters) which is used to control
 // the generation of block templates.  See the documentation for
 // NewBlockTemplate for more details on each of these parameters are used.
@@ -39,6 +107,31 @@ type Policy struct {
 	// required for a transaction to be treated as free for mining purposes
 	// (block template generation).
 	TxMinFreeFee exccutil.Amount
+
+	// MinVotes is the minimum number of votes for a block that must be
+	// available before a block template extending it is generated.  Zero
+	// means the consensus minimum of a majority of the tickets per block.
+	// It must not be less than the consensus minimum nor more than the
+	// number of tickets per block.
+	MinVotes uint16
+
+	// MaxVoteWait is the maximum duration to wait after a new block is
+	// connected for votes which are still missing for it.  While the
+	// duration has not elapsed and not every ticket selected to vote on
+	// the block has voted, no block template extending it is generated.
+	// Zero means block templates are generated as soon as the minimum
+	// number of votes is available.
+	MaxVoteWait time.Duration
+
+	// NoRevocations specifies that revocations of missed and expired
+	// tickets are not included in block templates.  By default all
+	// available revocations are included.
+	NoRevocations bool
+
+	// DataCarrier is the policy for null data outputs of regular
+	// transactions.  Transactions which violate it are not included in
+	// block templates.
+	DataCarrier DataCarrierPolicy
 }
 
 // minInt is a helper function to return the minimum of two ints.  This avoids
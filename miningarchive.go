@@ -0,0 +1,429 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/EXCCoin/exccd/exccjson"
+	"github.com/EXCCoin/exccd/wire"
+)
+
+const (
+	// miningArchiveFilename is the name of the file in the data directory
+	// the mining archive is kept in.
+	miningArchiveFilename = "miningarchive.dat"
+
+	// miningArchiveRecordSize is the size of each record in the mining
+	// archive file.  Records are kept in fixed size slots, so the file acts
+	// as a ring buffer which is overwritten in place.
+	miningArchiveRecordSize = 512
+
+	// miningArchiveHeaderOffset and miningArchiveReasonOffset are the
+	// offsets of the block header and the reason in each record.
+	miningArchiveHeaderOffset = 64
+	miningArchiveReasonOffset = miningArchiveHeaderOffset +
+		wire.MaxBlockHeaderPayload
+
+	// defaultMiningArchiveCount is the default number of the most recent
+	// records returned by the getminingarchive RPC.
+	defaultMiningArchiveCount = 100
+
+	// maxMiningArchiveRecords is the maximum number of records the mining
+	// archive may be configured to hold, which limits the file to about 50
+	// MiB.
+	maxMiningArchiveRecords = 100000
+)
+
+// Kinds of records in the mining archive.
+const (
+	miningArchiveTemplate uint8 = iota + 1
+	miningArchiveSolution
+)
+
+// Sources of the work and solutions recorded in the mining archive.
+const (
+	miningSourceCPUMiner         = "cpuminer"
+	miningSourceGetWork          = "getwork"
+	miningSourceGetBlockTemplate = "getblocktemplate"
+	miningSourceSubmitBlock      = "submitblock"
+)
+
+// Outcomes of the solutions recorded in the mining archive.
+const (
+	miningOutcomeAccepted = "accepted"
+	miningOutcomeRejected = "rejected"
+	miningOutcomeOrphan   = "orphan"
+	miningOutcomeDropped  = "dropped"
+	miningOutcomeInvalid  = "invalid"
+)
+
+// miningArchiveRecord houses a block template issued to a miner or a solution
+// submitted by one along with when and via which source, and for solutions,
+// the outcome of processing the block.
+//
+// Records are encoded as follows:
+//
+//	Field       Type               Size
+//	seq         uint64             8
+//	time        int64 (unix nano)  8
+//	kind        uint8              1
+//	source      padded string      16
+//	numtxns     uint16             2
+//	numstxns    uint16             2
+//	workid      uint64             8
+//	outcome     padded string      16
+//	reserved                       3
+//	header      wire.BlockHeader   wire.MaxBlockHeaderPayload
+//	reason      padded string      remainder of the record
+//
+// Strings which are longer than their field are truncated.  A sequence number
+// of zero marks an empty slot.
+type miningArchiveRecord struct {
+	seq      uint64
+	time     time.Time
+	kind     uint8
+	source   string
+	numTxns  uint16
+	numSTxns uint16
+	workID   workID
+	outcome  string
+	header   wire.BlockHeader
+	reason   string
+}
+
+// putPadded copies the passed string into the passed fixed size field,
+// truncating it when needed and zero padding the remainder.
+func putPadded(field []byte, s string) {
+	n := copy(field, s)
+	for i := n; i < len(field); i++ {
+		field[i] = 0
+	}
+}
+
+// getPadded returns the string in the passed zero padded fixed size field.
+func getPadded(field []byte) string {
+	if i := bytes.IndexByte(field, 0); i >= 0 {
+		field = field[:i]
+	}
+	return string(field)
+}
+
+// encode serializes the record into the passed buffer, which must be
+// miningArchiveRecordSize bytes.
+func (r *miningArchiveRecord) encode(b []byte) error {
+	binary.LittleEndian.PutUint64(b[0:8], r.seq)
+	binary.LittleEndian.PutUint64(b[8:16], uint64(r.time.UnixNano()))
+	b[16] = r.kind
+	putPadded(b[17:33], r.source)
+	binary.LittleEndian.PutUint16(b[33:35], r.numTxns)
+	binary.LittleEndian.PutUint16(b[35:37], r.numSTxns)
+	binary.LittleEndian.PutUint64(b[37:45], uint64(r.workID))
+	putPadded(b[45:61], r.outcome)
+	putPadded(b[61:miningArchiveHeaderOffset], "")
+
+	hdr, err := r.header.Bytes()
+	if err != nil {
+		return err
+	}
+	copy(b[miningArchiveHeaderOffset:miningArchiveReasonOffset], hdr)
+	putPadded(b[miningArchiveReasonOffset:], r.reason)
+	return nil
+}
+
+// decode deserializes the record from the passed buffer, which must be
+// miningArchiveRecordSize bytes.
+func (r *miningArchiveRecord) decode(b []byte) error {
+	r.seq = binary.LittleEndian.Uint64(b[0:8])
+	r.time = time.Unix(0, int64(binary.LittleEndian.Uint64(b[8:16])))
+	r.kind = b[16]
+	r.source = getPadded(b[17:33])
+	r.numTxns = binary.LittleEndian.Uint16(b[33:35])
+	r.numSTxns = binary.LittleEndian.Uint16(b[35:37])
+	r.workID = workID(binary.LittleEndian.Uint64(b[37:45]))
+	r.outcome = getPadded(b[45:61])
+	hdr := b[miningArchiveHeaderOffset:miningArchiveReasonOffset]
+	if err := r.header.FromBytes(hdr); err != nil {
+		return err
+	}
+	r.reason = getPadded(b[miningArchiveReasonOffset:])
+	return nil
+}
+
+// result returns the passed record as a getminingarchive result.
+func (r *miningArchiveRecord) result() exccjson.MiningArchiveResult {
+	hdr, _ := r.header.Bytes()
+	result := exccjson.MiningArchiveResult{
+		Seq:      r.seq,
+		Time:     r.time.Unix(),
+		Source:   r.source,
+		WorkID:   uint64(r.workID),
+		Hash:     r.header.BlockHash().String(),
+		PrevHash: r.header.PrevBlock.String(),
+		Height:   r.header.Height,
+		NumTxns:  r.numTxns,
+		NumSTxns: r.numSTxns,
+		Header:   hex.EncodeToString(hdr),
+		Outcome:  r.outcome,
+		Reason:   r.reason,
+	}
+	switch r.kind {
+	case miningArchiveTemplate:
+		result.Kind = "template"
+	case miningArchiveSolution:
+		result.Kind = "solution"
+	}
+	return result
+}
+
+// miningArchive persists the block templates issued to miners and the
+// solutions submitted by them, with timestamps and outcomes, to a ring buffer
+// file in the data directory, so incidents such as orphaned or rejected blocks
+// can be reconstructed after the fact.  Once the file holds the configured
+// number of records, the oldest record is overwritten by each new one.
+type miningArchive struct {
+	mtx      sync.Mutex
+	file     *os.File
+	slots    uint32
+	nextSlot uint32
+	nextSeq  uint64
+	buf      [miningArchiveRecordSize]byte
+}
+
+// readRecords returns all records in the archive file ordered from the oldest
+// to the newest record.  Since it reads the entire file, it is only used to
+// rewrite the records when the number of records the archive holds changed.
+//
+// This function MUST be called with the archive lock held.
+func (a *miningArchive) readRecords() ([]*miningArchiveRecord, error) {
+	if _, err := a.file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	var records []*miningArchiveRecord
+	for {
+		_, err := io.ReadFull(a.file, a.buf[:])
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		var r miningArchiveRecord
+		if err := r.decode(a.buf[:]); err != nil {
+			return nil, err
+		}
+		if r.seq != 0 {
+			records = append(records, &r)
+		}
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].seq < records[j].seq
+	})
+	return records, nil
+}
+
+// writeRecord writes the passed record to the passed slot of the archive file.
+//
+// This function MUST be called with the archive lock held.
+func (a *miningArchive) writeRecord(slot uint32, r *miningArchiveRecord) error {
+	if err := r.encode(a.buf[:]); err != nil {
+		return err
+	}
+	_, err := a.file.WriteAt(a.buf[:], int64(slot)*miningArchiveRecordSize)
+	return err
+}
+
+// Add appends the passed record to the archive, overwriting the oldest record
+// when the archive is full, and assigns it the next sequence number.
+//
+// This function is safe for concurrent access.
+func (a *miningArchive) Add(r *miningArchiveRecord) error {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	r.seq = a.nextSeq
+	if err := a.writeRecord(a.nextSlot, r); err != nil {
+		return err
+	}
+	a.nextSeq++
+	a.nextSlot = (a.nextSlot + 1) % a.slots
+	return nil
+}
+
+// Records returns up to the passed number of the most recent records in the
+// archive ordered from the oldest to the newest record.  Only the slots of the
+// returned records are read, starting from the newest one.
+//
+// This function is safe for concurrent access.
+func (a *miningArchive) Records(count int) ([]*miningArchiveRecord, error) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	if count > int(a.slots) {
+		count = int(a.slots)
+	}
+	records := make([]*miningArchiveRecord, 0, count)
+	slot := a.nextSlot
+	for len(records) < count {
+		// Stop at the first slot which is past the end of the file or
+		// empty since the archive is not full yet.
+		slot = (slot + a.slots - 1) % a.slots
+		offset := int64(slot) * miningArchiveRecordSize
+		_, err := a.file.ReadAt(a.buf[:], offset)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		var r miningArchiveRecord
+		if err := r.decode(a.buf[:]); err != nil {
+			return nil, err
+		}
+		if r.seq == 0 {
+			break
+		}
+		records = append(records, &r)
+	}
+
+	// Order the records from the oldest to the newest record.
+	for i, j := 0, len(records)-1; i < j; i, j = i+1, j-1 {
+		records[i], records[j] = records[j], records[i]
+	}
+	return records, nil
+}
+
+// Close closes the archive file.
+//
+// This function is safe for concurrent access.
+func (a *miningArchive) Close() error {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	return a.file.Close()
+}
+
+// openMiningArchive opens the mining archive file at the passed path, creating
+// it if needed, which holds up to the passed number of records.  The most
+// recent records of an existing file are kept, which are rewritten in order
+// when the number of records it may hold changed.
+func openMiningArchive(path string, slots uint32) (*miningArchive, error) {
+	if slots == 0 {
+		return nil, fmt.Errorf("the mining archive must hold at least " +
+			"one record")
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	a := &miningArchive{file: f, slots: slots, nextSeq: 1}
+
+	// Find the newest record by only reading the sequence numbers, and
+	// determine the slot after it when the records fill the slots in
+	// order, which is the case unless the number of records the archive
+	// holds changed.
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	fileSlots := fi.Size() / miningArchiveRecordSize
+	inOrder := fi.Size()%miningArchiveRecordSize == 0 &&
+		fileSlots <= int64(slots)
+	newestSlot := int64(-1)
+	var seqBuf [8]byte
+	for slot := int64(0); slot < fileSlots; slot++ {
+		_, err := f.ReadAt(seqBuf[:], slot*miningArchiveRecordSize)
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		seq := binary.LittleEndian.Uint64(seqBuf[:])
+		if seq == 0 {
+			inOrder = false
+			continue
+		}
+		if seq >= a.nextSeq {
+			a.nextSeq = seq + 1
+			newestSlot = slot
+		}
+	}
+	if newestSlot == -1 {
+		return a, nil
+	}
+	if inOrder && (fileSlots == int64(slots) || newestSlot == fileSlots-1) {
+		a.nextSlot = uint32(newestSlot+1) % slots
+		return a, nil
+	}
+
+	// Otherwise, rewrite the most recent records in order.
+	records, err := a.readRecords()
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("unable to read mining archive %s: %v",
+			path, err)
+	}
+	if len(records) > int(slots) {
+		records = records[len(records)-int(slots):]
+	}
+	if err := f.Truncate(0); err != nil {
+		f.Close()
+		return nil, err
+	}
+	for i, r := range records {
+		if err := a.writeRecord(uint32(i), r); err != nil {
+			f.Close()
+			return nil, err
+		}
+	}
+	a.nextSlot = uint32(len(records)) % slots
+	return a, nil
+}
+
+// archiveMining adds a record of the passed kind for the passed block issued as
+// work with the passed ID or submitted as a solution for it via the passed
+// source to the mining archive when it is enabled.
+func (s *server) archiveMining(kind uint8, msgBlock *wire.MsgBlock, id workID, source, outcome, reason string) {
+	if s.miningArchive == nil {
+		return
+	}
+	r := &miningArchiveRecord{
+		time:     time.Now(),
+		kind:     kind,
+		source:   source,
+		numTxns:  uint16(len(msgBlock.Transactions)),
+		numSTxns: uint16(len(msgBlock.STransactions)),
+		workID:   id,
+		outcome:  outcome,
+		header:   msgBlock.Header,
+		reason:   reason,
+	}
+	if err := s.miningArchive.Add(r); err != nil {
+		minrLog.Warnf("Unable to add %s from %s to the mining archive: %v",
+			r.result().Kind, source, err)
+	}
+}
+
+// archiveTemplate records the passed block template issued as work with the
+// passed ID to a miner via the passed source in the mining archive when it is
+// enabled.
+func (s *server) archiveTemplate(msgBlock *wire.MsgBlock, id workID, source string) {
+	s.archiveMining(miningArchiveTemplate, msgBlock, id, source, "", "")
+}
+
+// archiveSolution records the passed solved block submitted for the work with
+// the passed ID via the passed source in the mining archive when it is enabled
+// along with the outcome of processing it and the reason for it.
+func (s *server) archiveSolution(msgBlock *wire.MsgBlock, id workID, source, outcome, reason string) {
+	s.archiveMining(miningArchiveSolution, msgBlock, id, source, outcome,
+		reason)
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/EXCCoin/exccd/wire"
+)
+
+// TestMiningArchive ensures the mining archive keeps the configured number of
+// the most recent records, overwriting the oldest ones, and that they survive
+// reopening the archive, including with a different number of records.
+func TestMiningArchive(t *testing.T) {
+	dir, err := ioutil.TempDir("", "miningarchive")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, miningArchiveFilename)
+
+	// newRecord returns a record for a block at the passed height.
+	newRecord := func(height uint32) *miningArchiveRecord {
+		return &miningArchiveRecord{
+			time:    time.Unix(1530000000+int64(height), 0),
+			kind:    miningArchiveSolution,
+			source:  miningSourceGetWork,
+			numTxns: 3,
+			workID:  workID(height),
+			outcome: miningOutcomeRejected,
+			header:  wire.BlockHeader{Height: height, Nonce: height * 7},
+			reason:  strings.Repeat("x", miningArchiveRecordSize),
+		}
+	}
+
+	// checkHeights ensures the passed records are for blocks at the passed
+	// heights and decoded intact.
+	checkHeights := func(name string, records []*miningArchiveRecord, heights ...uint32) {
+		t.Helper()
+		if len(records) != len(heights) {
+			t.Fatalf("%s: unexpected number of records %d, want %d",
+				name, len(records), len(heights))
+		}
+		for i, r := range records {
+			want := newRecord(heights[i])
+			if r.header.Height != want.header.Height ||
+				r.header.Nonce != want.header.Nonce ||
+				r.workID != want.workID || !r.time.Equal(want.time) ||
+				r.kind != want.kind || r.source != want.source ||
+				r.numTxns != want.numTxns ||
+				r.outcome != want.outcome {
+
+				t.Fatalf("%s: unexpected record %d: %+v", name, i, r)
+			}
+			wantReason := want.reason[:miningArchiveRecordSize-
+				miningArchiveReasonOffset]
+			if r.reason != wantReason {
+				t.Fatalf("%s: unexpected reason %q", name, r.reason)
+			}
+		}
+	}
+
+	a, err := openMiningArchive(path, 3)
+	if err != nil {
+		t.Fatalf("openMiningArchive: unexpected error: %v", err)
+	}
+	records, err := a.Records(10)
+	if err != nil {
+		t.Fatalf("Records: unexpected error: %v", err)
+	}
+	checkHeights("empty archive", records)
+	for height := uint32(1); height <= 5; height++ {
+		if err := a.Add(newRecord(height)); err != nil {
+			t.Fatalf("Add: unexpected error: %v", err)
+		}
+	}
+	records, err = a.Records(10)
+	if err != nil {
+		t.Fatalf("Records: unexpected error: %v", err)
+	}
+	checkHeights("full archive", records, 3, 4, 5)
+	if records[2].seq != 5 {
+		t.Fatalf("unexpected sequence number %d, want 5", records[2].seq)
+	}
+	records, err = a.Records(2)
+	if err != nil {
+		t.Fatalf("Records: unexpected error: %v", err)
+	}
+	checkHeights("limited count", records, 4, 5)
+	a.Close()
+
+	// Reopening the archive continues after the newest record.
+	a, err = openMiningArchive(path, 3)
+	if err != nil {
+		t.Fatalf("openMiningArchive: unexpected error: %v", err)
+	}
+	if err := a.Add(newRecord(6)); err != nil {
+		t.Fatalf("Add: unexpected error: %v", err)
+	}
+	records, err = a.Records(10)
+	if err != nil {
+		t.Fatalf("Records: unexpected error: %v", err)
+	}
+	checkHeights("reopened archive", records, 4, 5, 6)
+	if records[2].seq != 6 {
+		t.Fatalf("unexpected sequence number %d, want 6", records[2].seq)
+	}
+	a.Close()
+
+	// Reopening the archive with fewer records keeps the newest ones.
+	a, err = openMiningArchive(path, 2)
+	if err != nil {
+		t.Fatalf("openMiningArchive: unexpected error: %v", err)
+	}
+	if err := a.Add(newRecord(7)); err != nil {
+		t.Fatalf("Add: unexpected error: %v", err)
+	}
+	records, err = a.Records(10)
+	if err != nil {
+		t.Fatalf("Records: unexpected error: %v", err)
+	}
+	checkHeights("shrunk archive", records, 6, 7)
+	a.Close()
+
+	// Reopening the archive with more records keeps all of them.
+	a, err = openMiningArchive(path, 4)
+	if err != nil {
+		t.Fatalf("openMiningArchive: unexpected error: %v", err)
+	}
+	for height := uint32(8); height <= 10; height++ {
+		if err := a.Add(newRecord(height)); err != nil {
+			t.Fatalf("Add: unexpected error: %v", err)
+		}
+	}
+	records, err = a.Records(10)
+	if err != nil {
+		t.Fatalf("Records: unexpected error: %v", err)
+	}
+	checkHeights("grown archive", records, 7, 8, 9, 10)
+	a.Close()
+
+	// Reopening an archive which is not full yet continues after the
+	// newest record as well.
+	a, err = openMiningArchive(path, 6)
+	if err != nil {
+		t.Fatalf("openMiningArchive: unexpected error: %v", err)
+	}
+	if err := a.Add(newRecord(11)); err != nil {
+		t.Fatalf("Add: unexpected error: %v", err)
+	}
+	a.Close()
+	a, err = openMiningArchive(path, 6)
+	if err != nil {
+		t.Fatalf("openMiningArchive: unexpected error: %v", err)
+	}
+	if err := a.Add(newRecord(12)); err != nil {
+		t.Fatalf("Add: unexpected error: %v", err)
+	}
+	records, err = a.Records(10)
+	if err != nil {
+		t.Fatalf("Records: unexpected error: %v", err)
+	}
+	checkHeights("partial archive", records, 7, 8, 9, 10, 11, 12)
+	records, err = a.Records(2)
+	if err != nil {
+		t.Fatalf("Records: unexpected error: %v", err)
+	}
+	checkHeights("partial archive limited count", records, 11, 12)
+	a.Close()
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// miningFailureBackoff is the amount of time CPU miner workers wait
+	// before trying again after failing to get a mining address or create a
+	// block template.  It doubles with each consecutive failure.
+	miningFailureBackoff = time.Second
+
+	// miningFailureThreshold is the number of consecutive failures after
+	// which the circuit breaker opens and mining is paused.
+	miningFailureThreshold = 6
+
+	// miningPausedInterval is the amount of time CPU miner workers wait
+	// between attempts while the circuit breaker is open.
+	miningPausedInterval = 5 * time.Minute
+)
+
+// miningBackoff tracks consecutive failures of the CPU miner workers to get a
+// mining address or create a block template, such as due to a misconfigured
+// mining address, so they back off exponentially instead of spinning.  Once
+// the failures reach a threshold, it acts as a circuit breaker which pauses
+// mining with only an occasional attempt until one succeeds.
+//
+// The workers all try again at about the same time after a failure, so the
+// failures are counted per round of attempts rather than per worker.  Otherwise
+// a single transient failure would be counted once for every worker and open
+// the circuit breaker right away.
+type miningBackoff struct {
+	mtx      sync.Mutex
+	round    uint64
+	failures int
+	lastErr  error
+	paused   bool
+}
+
+// Attempt returns the current round of attempts.  It must be called before a
+// worker attempts to get a mining address and create a block template, and the
+// result passed to Failure when the attempt fails.
+//
+// This function is safe for concurrent access.
+func (b *miningBackoff) Attempt() uint64 {
+	b.mtx.Lock()
+	round := b.round
+	b.mtx.Unlock()
+	return round
+}
+
+// delay returns how long to wait before trying again after the recorded
+// failures.
+//
+// This function MUST be called with the mutex held.
+func (b *miningBackoff) delay() time.Duration {
+	if b.failures < miningFailureThreshold {
+		return miningFailureBackoff << uint(b.failures-1)
+	}
+	return miningPausedInterval
+}
+
+// Failure records the passed failure of an attempt which started in the passed
+// round and returns how long to wait before trying again along with whether it
+// paused mining.  Only the first failure of a round is counted, so the failures
+// of the other attempts of the same round just share its wait.
+//
+// This function is safe for concurrent access.
+func (b *miningBackoff) Failure(err error, round uint64) (time.Duration, bool) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	b.lastErr = err
+	if round != b.round && b.failures > 0 {
+		return b.delay(), false
+	}
+	b.round++
+	b.failures++
+	if b.failures < miningFailureThreshold {
+		return b.delay(), false
+	}
+	paused := !b.paused
+	b.paused = true
+	return b.delay(), paused
+}
+
+// Success resets the consecutive failures and returns whether mining was
+// paused.
+//
+// This function is safe for concurrent access.
+func (b *miningBackoff) Success() bool {
+	b.mtx.Lock()
+	paused := b.paused
+	b.failures = 0
+	b.lastErr = nil
+	b.paused = false
+	b.mtx.Unlock()
+	return paused
+}
+
+// PausedErr returns the most recent failure when mining is paused and nil
+// otherwise.
+//
+// This function is safe for concurrent access.
+func (b *miningBackoff) PausedErr() error {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if !b.paused {
+		return nil
+	}
+	return b.lastErr
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestMiningBackoff ensures the mining backoff doubles the wait after each
+// consecutive failure, pauses mining once the failures reach the threshold, and
+// resumes it after a success.
+func TestMiningBackoff(t *testing.T) {
+	var b miningBackoff
+	failure := errors.New("no mining address")
+
+	wantDelay := miningFailureBackoff
+	for i := 1; i < miningFailureThreshold; i++ {
+		delay, paused := b.Failure(failure, b.Attempt())
+		if delay != wantDelay || paused {
+			t.Fatalf("failure %d: unexpected result: got %v, %v, want "+
+				"%v, false", i, delay, paused, wantDelay)
+		}
+		if err := b.PausedErr(); err != nil {
+			t.Fatalf("failure %d: unexpected paused error %v", i, err)
+		}
+		wantDelay *= 2
+	}
+
+	// Mining is paused by the failure which reaches the threshold and
+	// stays paused after further failures.
+	for i, wantPaused := range []bool{true, false} {
+		delay, paused := b.Failure(failure, b.Attempt())
+		if delay != miningPausedInterval || paused != wantPaused {
+			t.Fatalf("paused failure %d: unexpected result: got %v, "+
+				"%v, want %v, %v", i, delay, paused,
+				miningPausedInterval, wantPaused)
+		}
+		if err := b.PausedErr(); err != failure {
+			t.Fatalf("paused failure %d: unexpected paused error: "+
+				"got %v, want %v", i, err, failure)
+		}
+	}
+
+	// A success resumes mining and resets the backoff.
+	if !b.Success() {
+		t.Fatal("success did not report mining was paused")
+	}
+	if b.Success() {
+		t.Fatal("second success reported mining was paused")
+	}
+	if err := b.PausedErr(); err != nil {
+		t.Fatalf("unexpected paused error %v after success", err)
+	}
+	if delay, _ := b.Failure(failure, b.Attempt()); delay != miningFailureBackoff {
+		t.Fatalf("unexpected delay %v after success, want %v", delay,
+			miningFailureBackoff)
+	}
+}
+
+// TestMiningBackoffConcurrentWorkers ensures the failures of workers which
+// attempt to create a template in the same round are only counted once, so a
+// single failed round does not pause mining.
+func TestMiningBackoffConcurrentWorkers(t *testing.T) {
+	const numWorkers = 16
+	var b miningBackoff
+	failure := errors.New("no mining address")
+
+	wantDelay := miningFailureBackoff
+	for i := 1; i < miningFailureThreshold; i++ {
+		// All workers start their attempts before any of them fails.
+		rounds := make([]uint64, numWorkers)
+		for j := range rounds {
+			rounds[j] = b.Attempt()
+		}
+
+		var wg sync.WaitGroup
+		delays := make([]time.Duration, numWorkers)
+		pauses := make([]bool, numWorkers)
+		for j := range rounds {
+			wg.Add(1)
+			go func(j int) {
+				defer wg.Done()
+				delays[j], pauses[j] = b.Failure(failure, rounds[j])
+			}(j)
+		}
+		wg.Wait()
+
+		for j := range rounds {
+			if delays[j] != wantDelay || pauses[j] {
+				t.Fatalf("round %d worker %d: unexpected result: got "+
+					"%v, %v, want %v, false", i, j, delays[j],
+					pauses[j], wantDelay)
+			}
+		}
+		if err := b.PausedErr(); err != nil {
+			t.Fatalf("round %d: unexpected paused error %v", i, err)
+		}
+		wantDelay *= 2
+	}
+
+	// The next failed round reaches the threshold and pauses mining, which
+	// is reported by exactly one of the workers.
+	round := b.Attempt()
+	var numPaused int
+	for j := 0; j < numWorkers; j++ {
+		delay, paused := b.Failure(failure, round)
+		if delay != miningPausedInterval {
+			t.Fatalf("worker %d: unexpected delay %v, want %v", j,
+				delay, miningPausedInterval)
+		}
+		if paused {
+			numPaused++
+		}
+	}
+	if numPaused != 1 {
+		t.Fatalf("mining pause reported %d times, want 1", numPaused)
+	}
+}
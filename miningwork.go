@@ -0,0 +1,155 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/EXCCoin/exccd/chaincfg/chainhash"
+)
+
+// workExpirationDiff is the number of blocks below the height of the most
+// recently issued work to begin pruning out old work and the solutions
+// submitted for it.
+const workExpirationDiff = 3
+
+// maxIssuedWork is the maximum number of units of work which are tracked.  The
+// oldest half is pruned once it is exceeded, which bounds the memory used when
+// work is requested repeatedly without the best block changing.
+const maxIssuedWork = 20000
+
+var (
+	// errUnknownWork indicates a solution was submitted for work which was
+	// never issued or has already been pruned.
+	errUnknownWork = errors.New("unknown or expired work")
+
+	// errDuplicateSolution indicates the same solution was already
+	// submitted.
+	errDuplicateSolution = errors.New("duplicate solution")
+
+	// errWorkAlreadySolved indicates a solution was submitted for work a
+	// solution was already accepted for.
+	errWorkAlreadySolved = errors.New("work already solved")
+
+	// errStaleWork indicates a solution was submitted for work which builds
+	// on a block which is no longer the best block.
+	errStaleWork = errors.New("stale work")
+)
+
+// workID identifies a unit of work issued to a miner, such as a block template
+// being solved by a CPU miner worker or a getwork reply.
+type workID uint64
+
+// issuedWork houses details about a unit of work issued to a miner.
+type issuedWork struct {
+	prevHash chainhash.Hash
+	height   int64
+	solved   bool
+}
+
+// workTracker assigns IDs to the work issued to miners and checks the solutions
+// submitted for it, so solutions which were already submitted or which are for
+// already solved or stale work are rejected before the block is processed.
+// This avoids validating the same block repeatedly and the confusing duplicate
+// block rejections which would otherwise be logged.
+type workTracker struct {
+	mtx       sync.Mutex
+	lastID    workID
+	maxHeight int64
+	work      map[workID]*issuedWork
+	solutions map[chainhash.Hash]workID
+}
+
+// Issue records new work which builds on the block with the passed hash at the
+// passed height and returns its ID.  Work for blocks more than
+// workExpirationDiff below the highest issued work is pruned.
+//
+// This function is safe for concurrent access.
+func (t *workTracker) Issue(prevHash *chainhash.Hash, height int64) workID {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	if height > t.maxHeight {
+		t.maxHeight = height
+		for id, work := range t.work {
+			if work.height < height-workExpirationDiff {
+				delete(t.work, id)
+			}
+		}
+		t.pruneSolutions()
+	}
+
+	if len(t.work) >= maxIssuedWork {
+		for id := range t.work {
+			if id <= t.lastID-maxIssuedWork/2 {
+				delete(t.work, id)
+			}
+		}
+		t.pruneSolutions()
+	}
+
+	t.lastID++
+	t.work[t.lastID] = &issuedWork{prevHash: *prevHash, height: height}
+	return t.lastID
+}
+
+// pruneSolutions removes the submitted solutions for work which was pruned.
+//
+// This function MUST be called with the tracker lock held.
+func (t *workTracker) pruneSolutions() {
+	for hash, id := range t.solutions {
+		if _, ok := t.work[id]; !ok {
+			delete(t.solutions, hash)
+		}
+	}
+}
+
+// CheckSubmission checks whether the block with the passed hash solving the
+// work with the passed ID should be processed given the passed current best
+// block hash, and records it as submitted when it should.  An error describing
+// why is returned when it should not.
+//
+// This function is safe for concurrent access.
+func (t *workTracker) CheckSubmission(id workID, blockHash, bestHash *chainhash.Hash) error {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	if _, ok := t.solutions[*blockHash]; ok {
+		return errDuplicateSolution
+	}
+	work, ok := t.work[id]
+	switch {
+	case !ok:
+		return errUnknownWork
+	case work.solved:
+		return errWorkAlreadySolved
+	case work.prevHash != *bestHash:
+		return errStaleWork
+	}
+
+	t.solutions[*blockHash] = id
+	return nil
+}
+
+// MarkSolved records that a solution for the work with the passed ID was
+// accepted, so further solutions for it are rejected.
+//
+// This function is safe for concurrent access.
+func (t *workTracker) MarkSolved(id workID) {
+	t.mtx.Lock()
+	if work, ok := t.work[id]; ok {
+		work.solved = true
+	}
+	t.mtx.Unlock()
+}
+
+// newWorkTracker returns a new work tracker with no issued work.
+func newWorkTracker() *workTracker {
+	return &workTracker{
+		work:      make(map[workID]*issuedWork),
+		solutions: make(map[chainhash.Hash]workID),
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/EXCCoin/exccd/chaincfg/chainhash"
+)
+
+// TestWorkTracker ensures the work tracker only allows the first solution for
+// issued work building on the current best block to be processed and prunes
+// old work.
+func TestWorkTracker(t *testing.T) {
+	prevHash := chainhash.HashH([]byte("prev"))
+	otherHash := chainhash.HashH([]byte("other"))
+	blockHashes := make([]chainhash.Hash, 4)
+	for i := range blockHashes {
+		blockHashes[i][0] = byte(i)
+	}
+
+	tracker := newWorkTracker()
+	id := tracker.Issue(&prevHash, 10)
+	id2 := tracker.Issue(&prevHash, 10)
+	if id == id2 {
+		t.Fatalf("work issued with the same id %d", id)
+	}
+
+	tests := []struct {
+		name      string
+		id        workID
+		blockHash *chainhash.Hash
+		bestHash  *chainhash.Hash
+		want      error
+	}{
+		{"first solution", id, &blockHashes[0], &prevHash, nil},
+		{"duplicate solution", id2, &blockHashes[0], &prevHash, errDuplicateSolution},
+		{"unknown work", id2 + 1, &blockHashes[1], &prevHash, errUnknownWork},
+		{"stale work", id2, &blockHashes[1], &otherHash, errStaleWork},
+		{"other solution", id2, &blockHashes[1], &prevHash, nil},
+	}
+	for _, test := range tests {
+		err := tracker.CheckSubmission(test.id, test.blockHash, test.bestHash)
+		if err != test.want {
+			t.Fatalf("%s: unexpected error: got %v, want %v", test.name,
+				err, test.want)
+		}
+	}
+
+	// Further solutions for solved work are rejected.
+	tracker.MarkSolved(id)
+	err := tracker.CheckSubmission(id, &blockHashes[2], &prevHash)
+	if err != errWorkAlreadySolved {
+		t.Fatalf("unexpected error for solved work: got %v, want %v", err,
+			errWorkAlreadySolved)
+	}
+
+	// Issuing work far enough above the existing work prunes it along with
+	// its solutions.
+	tracker.Issue(&otherHash, 10+workExpirationDiff+1)
+	if _, ok := tracker.work[id]; ok {
+		t.Fatal("old work was not pruned")
+	}
+	if len(tracker.solutions) != 0 {
+		t.Fatalf("unexpected number of solutions %d after pruning",
+			len(tracker.solutions))
+	}
+	err = tracker.CheckSubmission(id2, &blockHashes[3], &prevHash)
+	if err != errUnknownWork {
+		t.Fatalf("unexpected error for pruned work: got %v, want %v", err,
+			errUnknownWork)
+	}
+
+	// The number of units of tracked work is bounded.
+	for i := 0; i < maxIssuedWork+10; i++ {
+		tracker.Issue(&otherHash, 10+workExpirationDiff+1)
+	}
+	if len(tracker.work) > maxIssuedWork {
+		t.Fatalf("unexpected number of units of work %d, want at most %d",
+			len(tracker.work), maxIssuedWork)
+	}
+}
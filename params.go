@@ -7,6 +7,10 @@
 package main
 
 import (
+	"encoding/json"
+	"io/ioutil"
+	"time"
+
 	"github.com/EXCCoin/exccd/chaincfg"
 	"github.com/EXCCoin/exccd/wire"
 )
@@ -47,6 +51,82 @@ var simNetParams = params{
 	rpcPort: "19556",
 }
 
+// regNetParams contains parameters specific to the regression test network
+// (wire.RegTest).
+var regNetParams = params{
+	Params:  &chaincfg.RegNetParams,
+	rpcPort: "18656",
+}
+
+// isolatedSimNetParams returns parameters for a simulation test network that
+// uses the passed network magic, default peer and RPC ports, and genesis block
+// timestamp in place of the ones of the standard simulation test network.
+// Zero and empty values leave the corresponding parameter unchanged.  This
+// allows several independent simulation test networks to run on the same host
+// without their nodes communicating with each other.
+//
+// The network is registered when it uses a magic other than the one of the
+// standard simulation test network.
+func isolatedSimNetParams(magic uint32, port, rpcPort string, genesisTime int64) (*params, error) {
+	chainParams := chaincfg.SimNetParams
+	isolated := params{
+		Params:  &chainParams,
+		rpcPort: simNetParams.rpcPort,
+	}
+	if port != "" {
+		chainParams.DefaultPort = port
+	}
+	if rpcPort != "" {
+		isolated.rpcPort = rpcPort
+	}
+	if genesisTime != 0 {
+		genesis := *chainParams.GenesisBlock
+		genesis.Header.Timestamp = time.Unix(genesisTime, 0)
+		genesisHash := genesis.BlockHash()
+		chainParams.GenesisBlock = &genesis
+		chainParams.GenesisHash = &genesisHash
+	}
+	if magic != 0 && wire.CurrencyNet(magic) != wire.SimNet {
+		chainParams.Net = wire.CurrencyNet(magic)
+		if err := chaincfg.Register(&chainParams); err != nil {
+			return nil, err
+		}
+	}
+	return &isolated, nil
+}
+
+// loadCustomNetParams loads the parameters of a custom network from the JSON
+// file at the passed path and registers the network.  See
+// chaincfg.LoadCustomParams for the format of the file.  In addition to the
+// chain parameters, the file may specify the RPC port of the network, which
+// defaults to the RPC port of the simulation test network.
+func loadCustomNetParams(path string) (*params, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	chainParams, err := chaincfg.LoadCustomParams(data)
+	if err != nil {
+		return nil, err
+	}
+	var rpcParams struct {
+		RPCPort string `json:"rpcport"`
+	}
+	if err := json.Unmarshal(data, &rpcParams); err != nil {
+		return nil, err
+	}
+	if rpcParams.RPCPort == "" {
+		rpcParams.RPCPort = simNetParams.rpcPort
+	}
+	if err := chaincfg.Register(chainParams); err != nil {
+		return nil, err
+	}
+	return &params{
+		Params:  chainParams,
+		rpcPort: rpcParams.RPCPort,
+	}, nil
+}
+
 // netName returns the name used when referring to a ExchangeCoin network.  At the
 // time of writing, exccd currently places blocks for testnet version 0 in the
 // data and log directory "testnet", which does not match the Name field of the
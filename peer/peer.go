@@ -263,6 +263,11 @@ type Config struct {
 	// Listeners houses callback functions to be invoked on receiving peer
 	// messages.
 	Listeners MessageListeners
+
+	// PanicHandler, when set, is deferred by every goroutine the peer
+	// starts so panics in them can be reported.  Since it is deferred
+	// directly, it may call recover itself.  This field can be omitted.
+	PanicHandler func()
 }
 
 // minUint32 is a helper function to return the minimum of two uint32s.
@@ -1921,12 +1926,12 @@ func (p *Peer) AssociateConnection(conn net.Conn) {
 		p.na = na
 	}
 
-	go func(peer *Peer) {
-		if err := peer.start(); err != nil {
-			log.Debugf("Cannot start peer %v: %v", peer, err)
-			peer.Disconnect()
+	p.spawn(func() {
+		if err := p.start(); err != nil {
+			log.Debugf("Cannot start peer %v: %v", p, err)
+			p.Disconnect()
 		}
-	}(p)
+	})
 }
 
 // Connected returns whether or not the peer is currently connected.
@@ -1952,18 +1957,29 @@ func (p *Peer) Disconnect() {
 	close(p.quit)
 }
 
+// spawn runs the passed function in a new goroutine which defers the panic
+// handler of the peer configuration, if any.
+func (p *Peer) spawn(f func()) {
+	go func() {
+		if h := p.cfg.PanicHandler; h != nil {
+			defer h()
+		}
+		f()
+	}()
+}
+
 // start begins processing input and output messages.
 func (p *Peer) start() error {
 	log.Tracef("Starting peer %s", p)
 
 	negotiateErr := make(chan error, 1)
-	go func() {
+	p.spawn(func() {
 		if p.inbound {
 			negotiateErr <- p.negotiateInboundProtocol()
 		} else {
 			negotiateErr <- p.negotiateOutboundProtocol()
 		}
-	}()
+	})
 
 	// Negotiate the protocol within the specified negotiateTimeout.
 	select {
@@ -1978,10 +1994,10 @@ func (p *Peer) start() error {
 
 	// The protocol has been negotiated successfully so start processing input
 	// and output messages.
-	go p.stallHandler()
-	go p.inHandler()
-	go p.queueHandler()
-	go p.outHandler()
+	p.spawn(p.stallHandler)
+	p.spawn(p.inHandler)
+	p.spawn(p.queueHandler)
+	p.spawn(p.outHandler)
 
 	// Send our verack message now that the IO processing machinery has started.
 	p.QueueMessage(wire.NewMsgVerAck(), nil)
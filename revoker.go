@@ -0,0 +1,345 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/EXCCoin/exccd/blockchain"
+	"github.com/EXCCoin/exccd/blockchain/stake"
+	"github.com/EXCCoin/exccd/chaincfg/chainec"
+	"github.com/EXCCoin/exccd/chaincfg/chainhash"
+	"github.com/EXCCoin/exccd/exccutil"
+	"github.com/EXCCoin/exccd/mempool"
+	"github.com/EXCCoin/exccd/txscript"
+	"github.com/EXCCoin/exccd/wire"
+)
+
+// ticketRevoker automatically creates and relays revocations for missed and
+// expired tickets whose voting rights are committed to the pay-to-pubkey-hash
+// address of one of a set of configured private keys or to the
+// pay-to-script-hash address of one of a set of configured redeem scripts.
+// This allows the funds locked in those tickets to be returned to their
+// commitment addresses without requiring a wallet to be online.
+//
+// The revocations are signed with the configured private keys.  Redeem scripts
+// which are not of a standard form are assumed to not require any signatures,
+// so the revocations of their tickets simply push the redeem script.
+type ticketRevoker struct {
+	server  *server
+	scripts map[[20]byte][]byte
+
+	// keys houses the configured private keys keyed by the encoding of the
+	// pay-to-pubkey-hash address of their public key, which is also the
+	// encoding of the public key addresses in multisig redeem scripts.
+	keys map[string]*exccutil.WIF
+
+	// pending houses the missed tickets that have been reported by the
+	// chain, but not processed yet.  It is protected by the mutex since the
+	// chain reports them while the chain lock is held, so they must be
+	// processed asynchronously.
+	mtx     sync.Mutex
+	pending []chainhash.Hash
+
+	// scannedAll indicates whether or not all currently missed tickets
+	// have been checked since the node last became current.  It is only
+	// accessed by the revoke handler.
+	scannedAll bool
+
+	wake chan struct{}
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NotifyMissedTickets queues the passed tickets, which were missed or expired
+// by the most recently connected block, to be revoked when they match one of
+// the configured redeem scripts.  It should be invoked for every connected
+// block, even when no tickets were missed, so all currently missed tickets are
+// checked once the node becomes current.
+//
+// This function is safe for concurrent access and never blocks.
+func (r *ticketRevoker) NotifyMissedTickets(tickets []chainhash.Hash) {
+	r.mtx.Lock()
+	r.pending = append(r.pending, tickets...)
+	r.mtx.Unlock()
+
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+// revocationForTicket returns a signed revocation that spends the passed ticket
+// and pays the ticket commitments without any fee.  Nil is returned when the
+// ticket is not spendable or its voting rights are not committed to one of the
+// configured private keys or redeem scripts.
+func (r *ticketRevoker) revocationForTicket(ticketHash *chainhash.Hash) (*wire.MsgTx, error) {
+	entry, err := r.server.blockManager.chain.FetchUtxoEntry(ticketHash)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil || entry.IsOutputSpent(0) ||
+		entry.TransactionType() != stake.TxTypeSStx {
+		return nil, nil
+	}
+
+	// Ensure the voting rights of the ticket are committed to one of the
+	// configured private keys or redeem scripts.
+	ticketScript := entry.PkScriptByIndex(0)
+	_, addrs, _, err := txscript.ExtractPkScriptAddrs(
+		entry.ScriptVersionByIndex(0), ticketScript, r.server.chainParams)
+	if err != nil || len(addrs) != 1 {
+		return nil, nil
+	}
+	switch addr := addrs[0].(type) {
+	case *exccutil.AddressPubKeyHash:
+		if _, ok := r.keys[addr.EncodeAddress()]; !ok {
+			return nil, nil
+		}
+	case *exccutil.AddressScriptHash:
+		if _, ok := r.scripts[*addr.Hash160()]; !ok {
+			return nil, nil
+		}
+	default:
+		return nil, nil
+	}
+
+	// Refund the ticket price to the commitment addresses of the ticket.
+	minimalOutputs := blockchain.ConvertUtxosToMinimalOutputs(entry)
+	payTypes, payHashes, commitAmts, _, _, _ :=
+		stake.SStxStakeOutputInfo(minimalOutputs)
+	ticketPrice := entry.AmountByIndex(0)
+	refunds := stake.CalculateRewards(commitAmts, ticketPrice, 0)
+
+	mtx := wire.NewMsgTx()
+	mtx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: *wire.NewOutPoint(ticketHash, 0,
+			wire.TxTreeStake),
+		Sequence:    wire.MaxTxInSequenceNum,
+		ValueIn:     ticketPrice,
+		BlockHeight: uint32(entry.BlockHeight()),
+		BlockIndex:  entry.BlockIndex(),
+	})
+	for i, payHash := range payHashes {
+		var pkScript []byte
+		if payTypes[i] {
+			pkScript, err = txscript.PayToSSRtxSHDirect(payHash)
+		} else {
+			pkScript, err = txscript.PayToSSRtxPKHDirect(payHash)
+		}
+		if err != nil {
+			return nil, err
+		}
+		mtx.AddTxOut(wire.NewTxOut(refunds[i], pkScript))
+	}
+
+	if err := stake.CheckSSRtx(mtx); err != nil {
+		return nil, fmt.Errorf("invalid revocation: %v", err)
+	}
+	if err := r.signRevocation(mtx, ticketScript); err != nil {
+		return nil, err
+	}
+	return mtx, nil
+}
+
+// signRevocation signs the ticket input of the passed revocation, which spends
+// a ticket with the passed output script, with the configured private keys and
+// redeem scripts.  An error is returned when the resulting signature script
+// does not satisfy the ticket script, such as when a redeem script requires
+// signatures of keys that are not configured.
+func (r *ticketRevoker) signRevocation(mtx *wire.MsgTx, ticketScript []byte) error {
+	getKey := txscript.KeyClosure(func(addr exccutil.Address) (chainec.PrivateKey, bool, error) {
+		wif, ok := r.keys[addr.EncodeAddress()]
+		if !ok {
+			return nil, false, fmt.Errorf("no private key configured "+
+				"for address %s", addr.EncodeAddress())
+		}
+		return wif.PrivKey, wif.CompressPubKey, nil
+	})
+	getScript := txscript.ScriptClosure(func(addr exccutil.Address) ([]byte, error) {
+		var scriptHash [20]byte
+		copy(scriptHash[:], addr.ScriptAddress())
+		script, ok := r.scripts[scriptHash]
+		if !ok {
+			return nil, fmt.Errorf("no redeem script configured for "+
+				"address %s", addr.EncodeAddress())
+		}
+		return script, nil
+	})
+
+	// Redeem scripts which are not of a standard form can't be signed, so
+	// only push them.
+	var redeemScript []byte
+	_, addrs, _, err := txscript.ExtractPkScriptAddrs(
+		txscript.DefaultScriptVersion, ticketScript, r.server.chainParams)
+	if err == nil && len(addrs) == 1 {
+		if addr, ok := addrs[0].(*exccutil.AddressScriptHash); ok {
+			redeemScript = r.scripts[*addr.Hash160()]
+		}
+	}
+	var sigScript []byte
+	if redeemScript != nil && txscript.GetScriptClass(
+		txscript.DefaultScriptVersion, redeemScript) == txscript.NonStandardTy {
+
+		sigScript, err = txscript.NewScriptBuilder().AddData(redeemScript).
+			Script()
+	} else {
+		sigScript, err = txscript.SignTxOutput(r.server.chainParams, mtx,
+			0, ticketScript, txscript.SigHashAll, getKey, getScript,
+			nil, chainec.ECTypeSecp256k1)
+	}
+	if err != nil {
+		return err
+	}
+	mtx.TxIn[0].SignatureScript = sigScript
+
+	flags, err := standardScriptVerifyFlags(r.server.blockManager.chain)
+	if err != nil {
+		return err
+	}
+	vm, err := txscript.NewEngine(ticketScript, mtx, 0, flags,
+		txscript.DefaultScriptVersion, nil)
+	if err == nil {
+		err = vm.Execute()
+	}
+	if err != nil {
+		return fmt.Errorf("unable to sign revocation: %v", err)
+	}
+	return nil
+}
+
+// revokeTicket creates a revocation for the passed ticket when it matches one
+// of the configured redeem scripts and submits it to the memory pool so it is
+// relayed to the network.
+func (r *ticketRevoker) revokeTicket(ticketHash *chainhash.Hash) {
+	mtx, err := r.revocationForTicket(ticketHash)
+	if err != nil {
+		srvrLog.Errorf("Unable to create revocation for ticket %v: %v",
+			ticketHash, err)
+		return
+	}
+	if mtx == nil {
+		return
+	}
+
+	tx := exccutil.NewTx(mtx)
+	acceptedTxs, err := r.server.blockManager.ProcessTransaction(tx, false,
+		false, true)
+	if err != nil {
+		// Rule errors are expected when the ticket has already been
+		// revoked by some other means, so only log them at the debug
+		// level.
+		if _, ok := err.(mempool.RuleError); ok {
+			srvrLog.Debugf("Revocation %v for ticket %v rejected: %v",
+				tx.Hash(), ticketHash, err)
+			return
+		}
+		srvrLog.Errorf("Failed to process revocation %v for ticket %v: "+
+			"%v", tx.Hash(), ticketHash, err)
+		return
+	}
+	r.server.AnnounceNewTransactions(acceptedTxs)
+	srvrLog.Infof("Revoked missed ticket %v with revocation %v",
+		ticketHash, tx.Hash())
+}
+
+// revokeTickets revokes the passed missed tickets.  All currently missed
+// tickets are checked instead the first time it is invoked after the node has
+// become current in order to catch any tickets that were missed while it was
+// offline or syncing.  Nothing is revoked while the node is not current since
+// the missed tickets reported during that time are covered by that check.
+func (r *ticketRevoker) revokeTickets(tickets []chainhash.Hash) {
+	if !r.server.blockManager.IsCurrent() {
+		r.scannedAll = false
+		return
+	}
+	if !r.scannedAll {
+		missed, err := r.server.blockManager.chain.MissedTickets()
+		if err != nil {
+			srvrLog.Errorf("Unable to fetch missed tickets: %v", err)
+			return
+		}
+		tickets = missed
+		r.scannedAll = true
+	}
+
+	for i := range tickets {
+		select {
+		case <-r.quit:
+			return
+		default:
+		}
+		r.revokeTicket(&tickets[i])
+	}
+}
+
+// revokeHandler processes the missed tickets reported by the chain.  It must
+// be run as a goroutine.
+func (r *ticketRevoker) revokeHandler() {
+out:
+	for {
+		select {
+		case <-r.wake:
+			r.mtx.Lock()
+			tickets := r.pending
+			r.pending = nil
+			r.mtx.Unlock()
+
+			r.revokeTickets(tickets)
+
+		case <-r.quit:
+			break out
+		}
+	}
+
+	r.wg.Done()
+}
+
+// Start begins processing missed tickets.
+func (r *ticketRevoker) Start() {
+	r.wg.Add(1)
+	spawn(r.revokeHandler)
+
+	// Check all currently missed tickets once the node is current.
+	r.NotifyMissedTickets(nil)
+}
+
+// Stop signals the revoker to stop processing missed tickets and waits for it
+// to finish.
+func (r *ticketRevoker) Stop() {
+	close(r.quit)
+	r.wg.Wait()
+}
+
+// newTicketRevoker returns a new ticket revoker for the provided server which
+// revokes missed and expired tickets whose voting rights are committed to one
+// of the provided private keys or redeem scripts.
+func newTicketRevoker(s *server, redeemScripts [][]byte, privKeys []*exccutil.WIF) (*ticketRevoker, error) {
+	scripts := make(map[[20]byte][]byte, len(redeemScripts))
+	for _, script := range redeemScripts {
+		var scriptHash [20]byte
+		copy(scriptHash[:], exccutil.Hash160(script))
+		scripts[scriptHash] = script
+	}
+	keys := make(map[string]*exccutil.WIF, len(privKeys))
+	for _, wif := range privKeys {
+		addr, err := exccutil.NewAddressPubKeyHash(
+			exccutil.Hash160(wif.SerializePubKey()), s.chainParams,
+			chainec.ECTypeSecp256k1)
+		if err != nil {
+			return nil, err
+		}
+		keys[addr.EncodeAddress()] = wif
+	}
+
+	return &ticketRevoker{
+		server:  s,
+		scripts: scripts,
+		keys:    keys,
+		wake:    make(chan struct{}, 1),
+		quit:    make(chan struct{}),
+	}, nil
+}
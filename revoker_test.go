@@ -0,0 +1,138 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/EXCCoin/exccd/chaincfg"
+	"github.com/EXCCoin/exccd/chaincfg/chainec"
+	"github.com/EXCCoin/exccd/chaincfg/chainhash"
+	"github.com/EXCCoin/exccd/exccutil"
+	"github.com/EXCCoin/exccd/txscript"
+	"github.com/EXCCoin/exccd/wire"
+)
+
+// TestSignRevocation ensures revocations of tickets with voting rights
+// committed to configured private keys and redeem scripts are signed so they
+// satisfy the ticket script, and that tickets which require signatures of keys
+// that are not configured are rejected.
+func TestSignRevocation(t *testing.T) {
+	params := &chaincfg.SimNetParams
+	newKey := func(seed byte) (*exccutil.WIF, *exccutil.AddressSecpPubKey) {
+		privKey, _ := chainec.Secp256k1.PrivKeyFromBytes([]byte{
+			seed, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07,
+			0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f,
+			0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17,
+			0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f})
+		wif, err := exccutil.NewWIF(privKey, params,
+			chainec.ECTypeSecp256k1)
+		if err != nil {
+			t.Fatalf("NewWIF: unexpected error: %v", err)
+		}
+		pubKeyAddr, err := exccutil.NewAddressSecpPubKey(
+			wif.SerializePubKey(), params)
+		if err != nil {
+			t.Fatalf("NewAddressSecpPubKey: unexpected error: %v", err)
+		}
+		return wif, pubKeyAddr
+	}
+	wif, pubKeyAddr := newKey(0x01)
+	_, otherPubKeyAddr := newKey(0x02)
+
+	multiSigScript, err := txscript.MultiSigScript(
+		[]*exccutil.AddressSecpPubKey{pubKeyAddr}, 1)
+	if err != nil {
+		t.Fatalf("MultiSigScript: unexpected error: %v", err)
+	}
+	otherMultiSigScript, err := txscript.MultiSigScript(
+		[]*exccutil.AddressSecpPubKey{otherPubKeyAddr}, 1)
+	if err != nil {
+		t.Fatalf("MultiSigScript: unexpected error: %v", err)
+	}
+	trueScript := []byte{txscript.OP_TRUE}
+
+	s := &server{chainParams: params, blockManager: &blockManager{}}
+	r, err := newTicketRevoker(s, [][]byte{multiSigScript,
+		otherMultiSigScript, trueScript}, []*exccutil.WIF{wif})
+	if err != nil {
+		t.Fatalf("newTicketRevoker: unexpected error: %v", err)
+	}
+
+	p2sh := func(script []byte) exccutil.Address {
+		addr, err := exccutil.NewAddressScriptHash(script, params)
+		if err != nil {
+			t.Fatalf("NewAddressScriptHash: unexpected error: %v", err)
+		}
+		return addr
+	}
+	tests := []struct {
+		name    string
+		addr    exccutil.Address
+		wantErr bool
+	}{{
+		name: "p2pkh of configured key",
+		addr: pubKeyAddr.AddressPubKeyHash(),
+	}, {
+		name:    "p2pkh of unknown key",
+		addr:    otherPubKeyAddr.AddressPubKeyHash(),
+		wantErr: true,
+	}, {
+		name: "multisig of configured key",
+		addr: p2sh(multiSigScript),
+	}, {
+		name:    "multisig of unknown key",
+		addr:    p2sh(otherMultiSigScript),
+		wantErr: true,
+	}, {
+		name: "script without signatures",
+		addr: p2sh(trueScript),
+	}}
+
+	for _, test := range tests {
+		ticketScript, err := txscript.PayToSStx(test.addr)
+		if err != nil {
+			t.Fatalf("%s: PayToSStx: unexpected error: %v", test.name,
+				err)
+		}
+		refundScript, err := txscript.PayToSSRtxPKHDirect(
+			pubKeyAddr.AddressPubKeyHash().ScriptAddress())
+		if err != nil {
+			t.Fatalf("%s: PayToSSRtxPKHDirect: unexpected error: %v",
+				test.name, err)
+		}
+		mtx := wire.NewMsgTx()
+		mtx.AddTxIn(&wire.TxIn{
+			PreviousOutPoint: *wire.NewOutPoint(&chainhash.Hash{0x01},
+				0, wire.TxTreeStake),
+			Sequence: wire.MaxTxInSequenceNum,
+			ValueIn:  100000000,
+		})
+		mtx.AddTxOut(wire.NewTxOut(100000000, refundScript))
+
+		err = r.signRevocation(mtx, ticketScript)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("%s: revocation signed without error",
+					test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+
+		// Ensure the signature script satisfies the ticket script.
+		vm, err := txscript.NewEngine(ticketScript, mtx, 0,
+			txscript.ScriptBip16, txscript.DefaultScriptVersion, nil)
+		if err == nil {
+			err = vm.Execute()
+		}
+		if err != nil {
+			t.Errorf("%s: invalid signature script: %v", test.name, err)
+		}
+	}
+}
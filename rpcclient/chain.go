@@ -8,6 +8,7 @@ package rpcclient
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
@@ -47,8 +48,16 @@ func (r FutureGetBestBlockHashResult) Receive() (*chainhash.Hash, error) {
 //
 // See GetBestBlockHash for the blocking version and more details.
 func (c *Client) GetBestBlockHashAsync() FutureGetBestBlockHashResult {
+	return c.GetBestBlockHashAsyncContext(context.Background())
+}
+
+// GetBestBlockHashAsyncContext is like GetBestBlockHashAsync but the request is
+// abandoned once the passed context is done.
+//
+// See GetBestBlockHashContext for the blocking version.
+func (c *Client) GetBestBlockHashAsyncContext(ctx context.Context) FutureGetBestBlockHashResult {
 	cmd := exccjson.NewGetBestBlockHashCmd()
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetBestBlockHash returns the hash of the best block in the longest block
@@ -57,6 +66,13 @@ func (c *Client) GetBestBlockHash() (*chainhash.Hash, error) {
 	return c.GetBestBlockHashAsync().Receive()
 }
 
+// GetBestBlockHashContext is like GetBestBlockHash but the request is abandoned
+// with the error of the passed context once it is done, such as when it times
+// out or is canceled.
+func (c *Client) GetBestBlockHashContext(ctx context.Context) (*chainhash.Hash, error) {
+	return c.GetBestBlockHashAsyncContext(ctx).Receive()
+}
+
 // FutureGetBlockResult is a future promise to deliver the result of a
 // GetBlockAsync RPC invocation (or an applicable error).
 type FutureGetBlockResult chan *response
@@ -97,13 +113,21 @@ func (r FutureGetBlockResult) Receive() (*wire.MsgBlock, error) {
 //
 // See GetBlock for the blocking version and more details.
 func (c *Client) GetBlockAsync(blockHash *chainhash.Hash) FutureGetBlockResult {
+	return c.GetBlockAsyncContext(context.Background(), blockHash)
+}
+
+// GetBlockAsyncContext is like GetBlockAsync but the request is abandoned once
+// the passed context is done.
+//
+// See GetBlockContext for the blocking version.
+func (c *Client) GetBlockAsyncContext(ctx context.Context, blockHash *chainhash.Hash) FutureGetBlockResult {
 	hash := ""
 	if blockHash != nil {
 		hash = blockHash.String()
 	}
 
-	cmd := exccjson.NewGetBlockCmd(hash, exccjson.Bool(false), nil)
-	return c.sendCmd(cmd)
+	cmd := exccjson.NewGetBlockCmd(hash, exccjson.Bool(false), nil, nil)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetBlock returns a raw block from the server given its hash.
@@ -114,6 +138,13 @@ func (c *Client) GetBlock(blockHash *chainhash.Hash) (*wire.MsgBlock, error) {
 	return c.GetBlockAsync(blockHash).Receive()
 }
 
+// GetBlockContext is like GetBlock but the request is abandoned with the error
+// of the passed context once it is done, such as when it times out or is
+// canceled.
+func (c *Client) GetBlockContext(ctx context.Context, blockHash *chainhash.Hash) (*wire.MsgBlock, error) {
+	return c.GetBlockAsyncContext(ctx, blockHash).Receive()
+}
+
 // FutureGetBlockVerboseResult is a future promise to deliver the result of a
 // GetBlockVerboseAsync RPC invocation (or an applicable error).
 type FutureGetBlockVerboseResult chan *response
@@ -141,13 +172,22 @@ func (r FutureGetBlockVerboseResult) Receive() (*exccjson.GetBlockVerboseResult,
 //
 // See GetBlockVerbose for the blocking version and more details.
 func (c *Client) GetBlockVerboseAsync(blockHash *chainhash.Hash, verboseTx bool) FutureGetBlockVerboseResult {
+	return c.GetBlockVerboseAsyncContext(context.Background(), blockHash, verboseTx)
+}
+
+// GetBlockVerboseAsyncContext is like GetBlockVerboseAsync but the request is
+// abandoned once the passed context is done.
+//
+// See GetBlockVerboseContext for the blocking version.
+func (c *Client) GetBlockVerboseAsyncContext(ctx context.Context, blockHash *chainhash.Hash, verboseTx bool) FutureGetBlockVerboseResult {
 	hash := ""
 	if blockHash != nil {
 		hash = blockHash.String()
 	}
 
-	cmd := exccjson.NewGetBlockCmd(hash, exccjson.Bool(true), &verboseTx)
-	return c.sendCmd(cmd)
+	cmd := exccjson.NewGetBlockCmd(hash, exccjson.Bool(true), &verboseTx,
+		nil)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetBlockVerbose returns a data structure from the server with information
@@ -158,6 +198,55 @@ func (c *Client) GetBlockVerbose(blockHash *chainhash.Hash, verboseTx bool) (*ex
 	return c.GetBlockVerboseAsync(blockHash, verboseTx).Receive()
 }
 
+// GetBlockVerboseContext is like GetBlockVerbose but the request is abandoned
+// with the error of the passed context once it is done, such as when it times
+// out or is canceled.
+func (c *Client) GetBlockVerboseContext(ctx context.Context, blockHash *chainhash.Hash, verboseTx bool) (*exccjson.GetBlockVerboseResult, error) {
+	return c.GetBlockVerboseAsyncContext(ctx, blockHash, verboseTx).Receive()
+}
+
+// GetBlockVerbosePrevOutsAsync returns an instance of a type that can be used
+// to get the result of the RPC at some future time by invoking the Receive
+// function on the returned instance.
+//
+// See GetBlockVerbosePrevOuts for the blocking version and more details.
+func (c *Client) GetBlockVerbosePrevOutsAsync(blockHash *chainhash.Hash) FutureGetBlockVerboseResult {
+	return c.GetBlockVerbosePrevOutsAsyncContext(context.Background(),
+		blockHash)
+}
+
+// GetBlockVerbosePrevOutsAsyncContext is like GetBlockVerbosePrevOutsAsync but
+// the request is abandoned once the passed context is done.
+//
+// See GetBlockVerbosePrevOutsContext for the blocking version.
+func (c *Client) GetBlockVerbosePrevOutsAsyncContext(ctx context.Context, blockHash *chainhash.Hash) FutureGetBlockVerboseResult {
+	hash := ""
+	if blockHash != nil {
+		hash = blockHash.String()
+	}
+
+	cmd := exccjson.NewGetBlockCmd(hash, exccjson.Bool(true),
+		exccjson.Bool(true), exccjson.Bool(true))
+	return c.sendCmdContext(ctx, cmd)
+}
+
+// GetBlockVerbosePrevOuts returns a data structure from the server with
+// information about a block given its hash, including each of its
+// transactions with the previous output spent by each of their inputs.
+//
+// Previous outputs which were already spent are only included when the server
+// has the transaction index enabled.
+func (c *Client) GetBlockVerbosePrevOuts(blockHash *chainhash.Hash) (*exccjson.GetBlockVerboseResult, error) {
+	return c.GetBlockVerbosePrevOutsAsync(blockHash).Receive()
+}
+
+// GetBlockVerbosePrevOutsContext is like GetBlockVerbosePrevOuts but the
+// request is abandoned with the error of the passed context once it is done,
+// such as when it times out or is canceled.
+func (c *Client) GetBlockVerbosePrevOutsContext(ctx context.Context, blockHash *chainhash.Hash) (*exccjson.GetBlockVerboseResult, error) {
+	return c.GetBlockVerbosePrevOutsAsyncContext(ctx, blockHash).Receive()
+}
+
 // FutureGetBlockCountResult is a future promise to deliver the result of a
 // GetBlockCountAsync RPC invocation (or an applicable error).
 type FutureGetBlockCountResult chan *response
@@ -185,8 +274,16 @@ func (r FutureGetBlockCountResult) Receive() (int64, error) {
 //
 // See GetBlockCount for the blocking version and more details.
 func (c *Client) GetBlockCountAsync() FutureGetBlockCountResult {
+	return c.GetBlockCountAsyncContext(context.Background())
+}
+
+// GetBlockCountAsyncContext is like GetBlockCountAsync but the request is
+// abandoned once the passed context is done.
+//
+// See GetBlockCountContext for the blocking version.
+func (c *Client) GetBlockCountAsyncContext(ctx context.Context) FutureGetBlockCountResult {
 	cmd := exccjson.NewGetBlockCountCmd()
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetBlockCount returns the number of blocks in the longest block chain.
@@ -194,6 +291,13 @@ func (c *Client) GetBlockCount() (int64, error) {
 	return c.GetBlockCountAsync().Receive()
 }
 
+// GetBlockCountContext is like GetBlockCount but the request is abandoned with
+// the error of the passed context once it is done, such as when it times out or
+// is canceled.
+func (c *Client) GetBlockCountContext(ctx context.Context) (int64, error) {
+	return c.GetBlockCountAsyncContext(ctx).Receive()
+}
+
 // FutureGetDifficultyResult is a future promise to deliver the result of a
 // GetDifficultyAsync RPC invocation (or an applicable error).
 type FutureGetDifficultyResult chan *response
@@ -221,8 +325,16 @@ func (r FutureGetDifficultyResult) Receive() (float64, error) {
 //
 // See GetDifficulty for the blocking version and more details.
 func (c *Client) GetDifficultyAsync() FutureGetDifficultyResult {
+	return c.GetDifficultyAsyncContext(context.Background())
+}
+
+// GetDifficultyAsyncContext is like GetDifficultyAsync but the request is
+// abandoned once the passed context is done.
+//
+// See GetDifficultyContext for the blocking version.
+func (c *Client) GetDifficultyAsyncContext(ctx context.Context) FutureGetDifficultyResult {
 	cmd := exccjson.NewGetDifficultyCmd()
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetDifficulty returns the proof-of-work difficulty as a multiple of the
@@ -231,6 +343,13 @@ func (c *Client) GetDifficulty() (float64, error) {
 	return c.GetDifficultyAsync().Receive()
 }
 
+// GetDifficultyContext is like GetDifficulty but the request is abandoned with
+// the error of the passed context once it is done, such as when it times out or
+// is canceled.
+func (c *Client) GetDifficultyContext(ctx context.Context) (float64, error) {
+	return c.GetDifficultyAsyncContext(ctx).Receive()
+}
+
 // FutureGetBlockHashResult is a future promise to deliver the result of a
 // GetBlockHashAsync RPC invocation (or an applicable error).
 type FutureGetBlockHashResult chan *response
@@ -258,8 +377,16 @@ func (r FutureGetBlockHashResult) Receive() (*chainhash.Hash, error) {
 //
 // See GetBlockHash for the blocking version and more details.
 func (c *Client) GetBlockHashAsync(blockHeight int64) FutureGetBlockHashResult {
+	return c.GetBlockHashAsyncContext(context.Background(), blockHeight)
+}
+
+// GetBlockHashAsyncContext is like GetBlockHashAsync but the request is
+// abandoned once the passed context is done.
+//
+// See GetBlockHashContext for the blocking version.
+func (c *Client) GetBlockHashAsyncContext(ctx context.Context, blockHeight int64) FutureGetBlockHashResult {
 	cmd := exccjson.NewGetBlockHashCmd(blockHeight)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetBlockHash returns the hash of the block in the best block chain at the
@@ -268,6 +395,13 @@ func (c *Client) GetBlockHash(blockHeight int64) (*chainhash.Hash, error) {
 	return c.GetBlockHashAsync(blockHeight).Receive()
 }
 
+// GetBlockHashContext is like GetBlockHash but the request is abandoned with
+// the error of the passed context once it is done, such as when it times out or
+// is canceled.
+func (c *Client) GetBlockHashContext(ctx context.Context, blockHeight int64) (*chainhash.Hash, error) {
+	return c.GetBlockHashAsyncContext(ctx, blockHeight).Receive()
+}
+
 // FutureGetBlockHeaderResult is a future promise to deliver the result of a
 // GetBlockHeaderAsync RPC invocation (or an applicable error).
 type FutureGetBlockHeaderResult chan *response
@@ -308,8 +442,16 @@ func (r FutureGetBlockHeaderResult) Receive() (*wire.BlockHeader, error) {
 //
 // See GetBlockHeader for the blocking version and more details.
 func (c *Client) GetBlockHeaderAsync(hash *chainhash.Hash) FutureGetBlockHeaderResult {
+	return c.GetBlockHeaderAsyncContext(context.Background(), hash)
+}
+
+// GetBlockHeaderAsyncContext is like GetBlockHeaderAsync but the request is
+// abandoned once the passed context is done.
+//
+// See GetBlockHeaderContext for the blocking version.
+func (c *Client) GetBlockHeaderAsyncContext(ctx context.Context, hash *chainhash.Hash) FutureGetBlockHeaderResult {
 	cmd := exccjson.NewGetBlockHeaderCmd(hash.String(), exccjson.Bool(false))
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetBlockHeader returns the hash of the block in the best block chain at the
@@ -318,6 +460,13 @@ func (c *Client) GetBlockHeader(hash *chainhash.Hash) (*wire.BlockHeader, error)
 	return c.GetBlockHeaderAsync(hash).Receive()
 }
 
+// GetBlockHeaderContext is like GetBlockHeader but the request is abandoned
+// with the error of the passed context once it is done, such as when it times
+// out or is canceled.
+func (c *Client) GetBlockHeaderContext(ctx context.Context, hash *chainhash.Hash) (*wire.BlockHeader, error) {
+	return c.GetBlockHeaderAsyncContext(ctx, hash).Receive()
+}
+
 // FutureGetBlockHeaderVerboseResult is a future promise to deliver the result of a
 // GetBlockHeaderAsync RPC invocation (or an applicable error).
 type FutureGetBlockHeaderVerboseResult chan *response
@@ -345,8 +494,16 @@ func (r FutureGetBlockHeaderVerboseResult) Receive() (*exccjson.GetBlockHeaderVe
 //
 // See GetBlockHeaderVerbose for the blocking version and more details.
 func (c *Client) GetBlockHeaderVerboseAsync(hash *chainhash.Hash) FutureGetBlockHeaderVerboseResult {
+	return c.GetBlockHeaderVerboseAsyncContext(context.Background(), hash)
+}
+
+// GetBlockHeaderVerboseAsyncContext is like GetBlockHeaderVerboseAsync but the
+// request is abandoned once the passed context is done.
+//
+// See GetBlockHeaderVerboseContext for the blocking version.
+func (c *Client) GetBlockHeaderVerboseAsyncContext(ctx context.Context, hash *chainhash.Hash) FutureGetBlockHeaderVerboseResult {
 	cmd := exccjson.NewGetBlockHeaderCmd(hash.String(), exccjson.Bool(true))
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetBlockHeaderVerbose returns a data structure of the block header from the
@@ -357,6 +514,13 @@ func (c *Client) GetBlockHeaderVerbose(hash *chainhash.Hash) (*exccjson.GetBlock
 	return c.GetBlockHeaderVerboseAsync(hash).Receive()
 }
 
+// GetBlockHeaderVerboseContext is like GetBlockHeaderVerbose but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) GetBlockHeaderVerboseContext(ctx context.Context, hash *chainhash.Hash) (*exccjson.GetBlockHeaderVerboseResult, error) {
+	return c.GetBlockHeaderVerboseAsyncContext(ctx, hash).Receive()
+}
+
 // FutureGetBlockSubsidyResult is a future promise to deliver the result of a
 // GetBlockSubsidyAsync RPC invocation (or an applicable error).
 type FutureGetBlockSubsidyResult chan *response
@@ -385,8 +549,16 @@ func (r FutureGetBlockSubsidyResult) Receive() (*exccjson.GetBlockSubsidyResult,
 //
 // See GetBlockSubsidy for the blocking version and more details.
 func (c *Client) GetBlockSubsidyAsync(height int64, voters uint16) FutureGetBlockSubsidyResult {
+	return c.GetBlockSubsidyAsyncContext(context.Background(), height, voters)
+}
+
+// GetBlockSubsidyAsyncContext is like GetBlockSubsidyAsync but the request is
+// abandoned once the passed context is done.
+//
+// See GetBlockSubsidyContext for the blocking version.
+func (c *Client) GetBlockSubsidyAsyncContext(ctx context.Context, height int64, voters uint16) FutureGetBlockSubsidyResult {
 	cmd := exccjson.NewGetBlockSubsidyCmd(height, voters)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetBlockSubsidy returns a data structure of the block subsidy
@@ -395,6 +567,13 @@ func (c *Client) GetBlockSubsidy(height int64, voters uint16) (*exccjson.GetBloc
 	return c.GetBlockSubsidyAsync(height, voters).Receive()
 }
 
+// GetBlockSubsidyContext is like GetBlockSubsidy but the request is abandoned
+// with the error of the passed context once it is done, such as when it times
+// out or is canceled.
+func (c *Client) GetBlockSubsidyContext(ctx context.Context, height int64, voters uint16) (*exccjson.GetBlockSubsidyResult, error) {
+	return c.GetBlockSubsidyAsyncContext(ctx, height, voters).Receive()
+}
+
 // FutureGetCoinSupplyResult is a future promise to deliver the result of a
 // GetCoinSupplyAsync RPC invocation (or an applicable error).
 type FutureGetCoinSupplyResult chan *response
@@ -422,8 +601,16 @@ func (r FutureGetCoinSupplyResult) Receive() (exccutil.Amount, error) {
 //
 // See GetCoinSupply for the blocking version and more details.
 func (c *Client) GetCoinSupplyAsync() FutureGetCoinSupplyResult {
+	return c.GetCoinSupplyAsyncContext(context.Background())
+}
+
+// GetCoinSupplyAsyncContext is like GetCoinSupplyAsync but the request is
+// abandoned once the passed context is done.
+//
+// See GetCoinSupplyContext for the blocking version.
+func (c *Client) GetCoinSupplyAsyncContext(ctx context.Context) FutureGetCoinSupplyResult {
 	cmd := exccjson.NewGetCoinSupplyCmd()
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetCoinSupply returns the current coin supply
@@ -431,6 +618,71 @@ func (c *Client) GetCoinSupply() (exccutil.Amount, error) {
 	return c.GetCoinSupplyAsync().Receive()
 }
 
+// GetCoinSupplyContext is like GetCoinSupply but the request is abandoned with
+// the error of the passed context once it is done, such as when it times out or
+// is canceled.
+func (c *Client) GetCoinSupplyContext(ctx context.Context) (exccutil.Amount, error) {
+	return c.GetCoinSupplyAsyncContext(ctx).Receive()
+}
+
+// FutureGetEmissionScheduleResult is a future promise to deliver the result of
+// a GetEmissionScheduleAsync RPC invocation (or an applicable error).
+type FutureGetEmissionScheduleResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// current coin supply along with the projected emission schedule.
+func (r FutureGetEmissionScheduleResult) Receive() (*exccjson.GetEmissionScheduleResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal the result as a getemissionschedule result object.
+	var schedule exccjson.GetEmissionScheduleResult
+	err = json.Unmarshal(res, &schedule)
+	if err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+// GetEmissionScheduleAsync returns an instance of a type that can be used to
+// get the result of the RPC at some future time by invoking the Receive
+// function on the returned instance.
+//
+// See GetEmissionSchedule for the blocking version and more details.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) GetEmissionScheduleAsync(intervals *int32) FutureGetEmissionScheduleResult {
+	return c.GetEmissionScheduleAsyncContext(context.Background(), intervals)
+}
+
+// GetEmissionScheduleAsyncContext is like GetEmissionScheduleAsync but the
+// request is abandoned once the passed context is done.
+//
+// See GetEmissionScheduleContext for the blocking version.
+func (c *Client) GetEmissionScheduleAsyncContext(ctx context.Context, intervals *int32) FutureGetEmissionScheduleResult {
+	cmd := exccjson.NewGetEmissionScheduleCmd(intervals)
+	return c.sendCmdContext(ctx, cmd)
+}
+
+// GetEmissionSchedule returns the current coin supply along with the projected
+// emission of the requested number of subsidy reduction intervals following the
+// current best block.  The default number of intervals is used when intervals
+// is nil.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) GetEmissionSchedule(intervals *int32) (*exccjson.GetEmissionScheduleResult, error) {
+	return c.GetEmissionScheduleAsync(intervals).Receive()
+}
+
+// GetEmissionScheduleContext is like GetEmissionSchedule but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) GetEmissionScheduleContext(ctx context.Context, intervals *int32) (*exccjson.GetEmissionScheduleResult, error) {
+	return c.GetEmissionScheduleAsyncContext(ctx, intervals).Receive()
+}
+
 // FutureGetRawMempoolResult is a future promise to deliver the result of a
 // GetRawMempoolAsync RPC invocation (or an applicable error).
 type FutureGetRawMempoolResult chan *response
@@ -469,9 +721,17 @@ func (r FutureGetRawMempoolResult) Receive() ([]*chainhash.Hash, error) {
 //
 // See GetRawMempool for the blocking version and more details.
 func (c *Client) GetRawMempoolAsync(txType exccjson.GetRawMempoolTxTypeCmd) FutureGetRawMempoolResult {
+	return c.GetRawMempoolAsyncContext(context.Background(), txType)
+}
+
+// GetRawMempoolAsyncContext is like GetRawMempoolAsync but the request is
+// abandoned once the passed context is done.
+//
+// See GetRawMempoolContext for the blocking version.
+func (c *Client) GetRawMempoolAsyncContext(ctx context.Context, txType exccjson.GetRawMempoolTxTypeCmd) FutureGetRawMempoolResult {
 	cmd := exccjson.NewGetRawMempoolCmd(exccjson.Bool(false),
-		exccjson.String(string(txType)))
-	return c.sendCmd(cmd)
+		exccjson.String(string(txType)), nil, nil, nil)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetRawMempool returns the hashes of all transactions in the memory pool for
@@ -483,6 +743,13 @@ func (c *Client) GetRawMempool(txType exccjson.GetRawMempoolTxTypeCmd) ([]*chain
 	return c.GetRawMempoolAsync(txType).Receive()
 }
 
+// GetRawMempoolContext is like GetRawMempool but the request is abandoned with
+// the error of the passed context once it is done, such as when it times out or
+// is canceled.
+func (c *Client) GetRawMempoolContext(ctx context.Context, txType exccjson.GetRawMempoolTxTypeCmd) ([]*chainhash.Hash, error) {
+	return c.GetRawMempoolAsyncContext(ctx, txType).Receive()
+}
+
 // FutureGetRawMempoolVerboseResult is a future promise to deliver the result of
 // a GetRawMempoolVerboseAsync RPC invocation (or an applicable error).
 type FutureGetRawMempoolVerboseResult chan *response
@@ -512,9 +779,17 @@ func (r FutureGetRawMempoolVerboseResult) Receive() (map[string]exccjson.GetRawM
 //
 // See GetRawMempoolVerbose for the blocking version and more details.
 func (c *Client) GetRawMempoolVerboseAsync(txType exccjson.GetRawMempoolTxTypeCmd) FutureGetRawMempoolVerboseResult {
+	return c.GetRawMempoolVerboseAsyncContext(context.Background(), txType)
+}
+
+// GetRawMempoolVerboseAsyncContext is like GetRawMempoolVerboseAsync but the
+// request is abandoned once the passed context is done.
+//
+// See GetRawMempoolVerboseContext for the blocking version.
+func (c *Client) GetRawMempoolVerboseAsyncContext(ctx context.Context, txType exccjson.GetRawMempoolTxTypeCmd) FutureGetRawMempoolVerboseResult {
 	cmd := exccjson.NewGetRawMempoolCmd(exccjson.Bool(true),
-		exccjson.String(string(txType)))
-	return c.sendCmd(cmd)
+		exccjson.String(string(txType)), nil, nil, nil)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetRawMempoolVerbose returns a map of transaction hashes to an associated
@@ -526,6 +801,117 @@ func (c *Client) GetRawMempoolVerbose(txType exccjson.GetRawMempoolTxTypeCmd) (m
 	return c.GetRawMempoolVerboseAsync(txType).Receive()
 }
 
+// GetRawMempoolVerboseContext is like GetRawMempoolVerbose but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) GetRawMempoolVerboseContext(ctx context.Context, txType exccjson.GetRawMempoolTxTypeCmd) (map[string]exccjson.GetRawMempoolVerboseResult, error) {
+	return c.GetRawMempoolVerboseAsyncContext(ctx, txType).Receive()
+}
+
+// GetRawMempoolSortedAsync returns an instance of a type that can be used to
+// get the result of the RPC at some future time by invoking the Receive
+// function on the returned instance.
+//
+// See GetRawMempoolSorted for the blocking version and more details.
+func (c *Client) GetRawMempoolSortedAsync(txType exccjson.GetRawMempoolTxTypeCmd, sortBy exccjson.GetRawMempoolSortByCmd, skip, count uint32) FutureGetRawMempoolResult {
+	return c.GetRawMempoolSortedAsyncContext(context.Background(), txType,
+		sortBy, skip, count)
+}
+
+// GetRawMempoolSortedAsyncContext is like GetRawMempoolSortedAsync but the
+// request is abandoned once the passed context is done.
+//
+// See GetRawMempoolSortedContext for the blocking version.
+func (c *Client) GetRawMempoolSortedAsyncContext(ctx context.Context, txType exccjson.GetRawMempoolTxTypeCmd, sortBy exccjson.GetRawMempoolSortByCmd, skip, count uint32) FutureGetRawMempoolResult {
+	cmd := exccjson.NewGetRawMempoolCmd(exccjson.Bool(false),
+		exccjson.String(string(txType)), exccjson.String(string(sortBy)),
+		&skip, &count)
+	return c.sendCmdContext(ctx, cmd)
+}
+
+// GetRawMempoolSorted returns the hashes of the transactions in the memory pool
+// for the given txType in the given sort order, skipping the first skip
+// transactions and returning at most count of them.  A count of zero returns
+// all of the remaining transactions.
+//
+// See GetRawMempoolSortedVerbose to retrieve data structures with information
+// about the transactions instead.
+func (c *Client) GetRawMempoolSorted(txType exccjson.GetRawMempoolTxTypeCmd, sortBy exccjson.GetRawMempoolSortByCmd, skip, count uint32) ([]*chainhash.Hash, error) {
+	return c.GetRawMempoolSortedAsync(txType, sortBy, skip, count).Receive()
+}
+
+// GetRawMempoolSortedContext is like GetRawMempoolSorted but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) GetRawMempoolSortedContext(ctx context.Context, txType exccjson.GetRawMempoolTxTypeCmd, sortBy exccjson.GetRawMempoolSortByCmd, skip, count uint32) ([]*chainhash.Hash, error) {
+	return c.GetRawMempoolSortedAsyncContext(ctx, txType, sortBy, skip,
+		count).Receive()
+}
+
+// FutureGetRawMempoolSortedVerboseResult is a future promise to deliver the
+// result of a GetRawMempoolSortedVerboseAsync RPC invocation (or an applicable
+// error).
+type FutureGetRawMempoolSortedVerboseResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// ordered data structures with information about the selected transactions in
+// the memory pool.
+func (r FutureGetRawMempoolSortedVerboseResult) Receive() ([]exccjson.GetRawMempoolVerboseResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal the result as an array of detailed results.
+	var mempoolItems []exccjson.GetRawMempoolVerboseResult
+	err = json.Unmarshal(res, &mempoolItems)
+	if err != nil {
+		return nil, err
+	}
+	return mempoolItems, nil
+}
+
+// GetRawMempoolSortedVerboseAsync returns an instance of a type that can be
+// used to get the result of the RPC at some future time by invoking the Receive
+// function on the returned instance.
+//
+// See GetRawMempoolSortedVerbose for the blocking version and more details.
+func (c *Client) GetRawMempoolSortedVerboseAsync(txType exccjson.GetRawMempoolTxTypeCmd, sortBy exccjson.GetRawMempoolSortByCmd, skip, count uint32) FutureGetRawMempoolSortedVerboseResult {
+	return c.GetRawMempoolSortedVerboseAsyncContext(context.Background(),
+		txType, sortBy, skip, count)
+}
+
+// GetRawMempoolSortedVerboseAsyncContext is like
+// GetRawMempoolSortedVerboseAsync but the request is abandoned once the passed
+// context is done.
+//
+// See GetRawMempoolSortedVerboseContext for the blocking version.
+func (c *Client) GetRawMempoolSortedVerboseAsyncContext(ctx context.Context, txType exccjson.GetRawMempoolTxTypeCmd, sortBy exccjson.GetRawMempoolSortByCmd, skip, count uint32) FutureGetRawMempoolSortedVerboseResult {
+	cmd := exccjson.NewGetRawMempoolCmd(exccjson.Bool(true),
+		exccjson.String(string(txType)), exccjson.String(string(sortBy)),
+		&skip, &count)
+	return c.sendCmdContext(ctx, cmd)
+}
+
+// GetRawMempoolSortedVerbose returns data structures with information about
+// the transactions in the memory pool for the given txType in the given sort
+// order, skipping the first skip transactions and returning at most count of
+// them.  A count of zero returns all of the remaining transactions.
+//
+// See GetRawMempoolSorted to retrieve only the transaction hashes instead.
+func (c *Client) GetRawMempoolSortedVerbose(txType exccjson.GetRawMempoolTxTypeCmd, sortBy exccjson.GetRawMempoolSortByCmd, skip, count uint32) ([]exccjson.GetRawMempoolVerboseResult, error) {
+	return c.GetRawMempoolSortedVerboseAsync(txType, sortBy, skip,
+		count).Receive()
+}
+
+// GetRawMempoolSortedVerboseContext is like GetRawMempoolSortedVerbose but the
+// request is abandoned with the error of the passed context once it is done,
+// such as when it times out or is canceled.
+func (c *Client) GetRawMempoolSortedVerboseContext(ctx context.Context, txType exccjson.GetRawMempoolTxTypeCmd, sortBy exccjson.GetRawMempoolSortByCmd, skip, count uint32) ([]exccjson.GetRawMempoolVerboseResult, error) {
+	return c.GetRawMempoolSortedVerboseAsyncContext(ctx, txType, sortBy,
+		skip, count).Receive()
+}
+
 // FutureVerifyChainResult is a future promise to deliver the result of a
 // VerifyChainAsync, VerifyChainLevelAsyncRPC, or VerifyChainBlocksAsync
 // invocation (or an applicable error).
@@ -555,8 +941,16 @@ func (r FutureVerifyChainResult) Receive() (bool, error) {
 //
 // See VerifyChain for the blocking version and more details.
 func (c *Client) VerifyChainAsync() FutureVerifyChainResult {
+	return c.VerifyChainAsyncContext(context.Background())
+}
+
+// VerifyChainAsyncContext is like VerifyChainAsync but the request is abandoned
+// once the passed context is done.
+//
+// See VerifyChainContext for the blocking version.
+func (c *Client) VerifyChainAsyncContext(ctx context.Context) FutureVerifyChainResult {
 	cmd := exccjson.NewVerifyChainCmd(nil, nil)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // VerifyChain requests the server to verify the block chain database using
@@ -567,14 +961,29 @@ func (c *Client) VerifyChain() (bool, error) {
 	return c.VerifyChainAsync().Receive()
 }
 
+// VerifyChainContext is like VerifyChain but the request is abandoned with the
+// error of the passed context once it is done, such as when it times out or is
+// canceled.
+func (c *Client) VerifyChainContext(ctx context.Context) (bool, error) {
+	return c.VerifyChainAsyncContext(ctx).Receive()
+}
+
 // VerifyChainLevelAsync returns an instance of a type that can be used to get
 // the result of the RPC at some future time by invoking the Receive function on
 // the returned instance.
 //
 // See VerifyChainLevel for the blocking version and more details.
 func (c *Client) VerifyChainLevelAsync(checkLevel int64) FutureVerifyChainResult {
+	return c.VerifyChainLevelAsyncContext(context.Background(), checkLevel)
+}
+
+// VerifyChainLevelAsyncContext is like VerifyChainLevelAsync but the request is
+// abandoned once the passed context is done.
+//
+// See VerifyChainLevelContext for the blocking version.
+func (c *Client) VerifyChainLevelAsyncContext(ctx context.Context, checkLevel int64) FutureVerifyChainResult {
 	cmd := exccjson.NewVerifyChainCmd(&checkLevel, nil)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // VerifyChainLevel requests the server to verify the block chain database using
@@ -590,14 +999,29 @@ func (c *Client) VerifyChainLevel(checkLevel int64) (bool, error) {
 	return c.VerifyChainLevelAsync(checkLevel).Receive()
 }
 
+// VerifyChainLevelContext is like VerifyChainLevel but the request is abandoned
+// with the error of the passed context once it is done, such as when it times
+// out or is canceled.
+func (c *Client) VerifyChainLevelContext(ctx context.Context, checkLevel int64) (bool, error) {
+	return c.VerifyChainLevelAsyncContext(ctx, checkLevel).Receive()
+}
+
 // VerifyChainBlocksAsync returns an instance of a type that can be used to get
 // the result of the RPC at some future time by invoking the Receive function on
 // the returned instance.
 //
 // See VerifyChainBlocks for the blocking version and more details.
 func (c *Client) VerifyChainBlocksAsync(checkLevel, numBlocks int64) FutureVerifyChainResult {
+	return c.VerifyChainBlocksAsyncContext(context.Background(), checkLevel, numBlocks)
+}
+
+// VerifyChainBlocksAsyncContext is like VerifyChainBlocksAsync but the request
+// is abandoned once the passed context is done.
+//
+// See VerifyChainBlocksContext for the blocking version.
+func (c *Client) VerifyChainBlocksAsyncContext(ctx context.Context, checkLevel, numBlocks int64) FutureVerifyChainResult {
 	cmd := exccjson.NewVerifyChainCmd(&checkLevel, &numBlocks)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // VerifyChainBlocks requests the server to verify the block chain database
@@ -615,6 +1039,13 @@ func (c *Client) VerifyChainBlocks(checkLevel, numBlocks int64) (bool, error) {
 	return c.VerifyChainBlocksAsync(checkLevel, numBlocks).Receive()
 }
 
+// VerifyChainBlocksContext is like VerifyChainBlocks but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) VerifyChainBlocksContext(ctx context.Context, checkLevel, numBlocks int64) (bool, error) {
+	return c.VerifyChainBlocksAsyncContext(ctx, checkLevel, numBlocks).Receive()
+}
+
 // FutureGetTxOutResult is a future promise to deliver the result of a
 // GetTxOutAsync RPC invocation (or an applicable error).
 type FutureGetTxOutResult chan *response
@@ -649,13 +1080,21 @@ func (r FutureGetTxOutResult) Receive() (*exccjson.GetTxOutResult, error) {
 //
 // See GetTxOut for the blocking version and more details.
 func (c *Client) GetTxOutAsync(txHash *chainhash.Hash, index uint32, mempool bool) FutureGetTxOutResult {
+	return c.GetTxOutAsyncContext(context.Background(), txHash, index, mempool)
+}
+
+// GetTxOutAsyncContext is like GetTxOutAsync but the request is abandoned once
+// the passed context is done.
+//
+// See GetTxOutContext for the blocking version.
+func (c *Client) GetTxOutAsyncContext(ctx context.Context, txHash *chainhash.Hash, index uint32, mempool bool) FutureGetTxOutResult {
 	hash := ""
 	if txHash != nil {
 		hash = txHash.String()
 	}
 
 	cmd := exccjson.NewGetTxOutCmd(hash, index, &mempool)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetTxOut returns the transaction output info if it's unspent and
@@ -664,6 +1103,66 @@ func (c *Client) GetTxOut(txHash *chainhash.Hash, index uint32, mempool bool) (*
 	return c.GetTxOutAsync(txHash, index, mempool).Receive()
 }
 
+// GetTxOutContext is like GetTxOut but the request is abandoned with the error
+// of the passed context once it is done, such as when it times out or is
+// canceled.
+func (c *Client) GetTxOutContext(ctx context.Context, txHash *chainhash.Hash, index uint32, mempool bool) (*exccjson.GetTxOutResult, error) {
+	return c.GetTxOutAsyncContext(ctx, txHash, index, mempool).Receive()
+}
+
+// FutureGetTxOutSetInfoResult is a future promise to deliver the result of a
+// GetTxOutSetInfoAsync RPC invocation (or an applicable error).
+type FutureGetTxOutSetInfoResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// statistics of the utxo set.
+func (r FutureGetTxOutSetInfoResult) Receive() (*exccjson.GetTxOutSetInfoResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a gettxoutsetinfo result object.
+	var info exccjson.GetTxOutSetInfoResult
+	err = json.Unmarshal(res, &info)
+	if err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+// GetTxOutSetInfoAsync returns an instance of a type that can be used to get
+// the result of the RPC at some future time by invoking the Receive function on
+// the returned instance.
+//
+// See GetTxOutSetInfo for the blocking version and more details.
+func (c *Client) GetTxOutSetInfoAsync() FutureGetTxOutSetInfoResult {
+	return c.GetTxOutSetInfoAsyncContext(context.Background())
+}
+
+// GetTxOutSetInfoAsyncContext is like GetTxOutSetInfoAsync but the request is
+// abandoned once the passed context is done.
+//
+// See GetTxOutSetInfoContext for the blocking version.
+func (c *Client) GetTxOutSetInfoAsyncContext(ctx context.Context) FutureGetTxOutSetInfoResult {
+	cmd := exccjson.NewGetTxOutSetInfoCmd()
+	return c.sendCmdContext(ctx, cmd)
+}
+
+// GetTxOutSetInfo returns the statistics of the utxo set as of the best block
+// along with a hash which commits to its contents.
+func (c *Client) GetTxOutSetInfo() (*exccjson.GetTxOutSetInfoResult, error) {
+	return c.GetTxOutSetInfoAsync().Receive()
+}
+
+// GetTxOutSetInfoContext is like GetTxOutSetInfo but the request is abandoned
+// with the error of the passed context once it is done, such as when it times
+// out or is canceled.
+func (c *Client) GetTxOutSetInfoContext(ctx context.Context) (*exccjson.GetTxOutSetInfoResult, error) {
+	return c.GetTxOutSetInfoAsyncContext(ctx).Receive()
+}
+
 // FutureRescanResult is a future promise to deliver the result of a
 // RescanAsynnc RPC invocation (or an applicable error).
 type FutureRescanResult chan *response
@@ -691,13 +1190,21 @@ func (r FutureRescanResult) Receive() (*exccjson.RescanResult, error) {
 //
 // See Rescan for the blocking version and more details.
 func (c *Client) RescanAsync(blockHashes []chainhash.Hash) FutureRescanResult {
+	return c.RescanAsyncContext(context.Background(), blockHashes)
+}
+
+// RescanAsyncContext is like RescanAsync but the request is abandoned once the
+// passed context is done.
+//
+// See RescanContext for the blocking version.
+func (c *Client) RescanAsyncContext(ctx context.Context, blockHashes []chainhash.Hash) FutureRescanResult {
 	concatenatedBlockHashes := make([]byte, chainhash.HashSize*len(blockHashes))
 	for i := range blockHashes {
 		copy(concatenatedBlockHashes[i*chainhash.HashSize:], blockHashes[i][:])
 	}
 
 	cmd := exccjson.NewRescanCmd(hex.EncodeToString(concatenatedBlockHashes))
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // Rescan rescans the blocks identified by blockHashes, in order, using the
@@ -707,6 +1214,102 @@ func (c *Client) Rescan(blockHashes []chainhash.Hash) (*exccjson.RescanResult, e
 	return c.RescanAsync(blockHashes).Receive()
 }
 
+// RescanContext is like Rescan but the request is abandoned with the error of
+// the passed context once it is done, such as when it times out or is canceled.
+func (c *Client) RescanContext(ctx context.Context, blockHashes []chainhash.Hash) (*exccjson.RescanResult, error) {
+	return c.RescanAsyncContext(ctx, blockHashes).Receive()
+}
+
+// FutureRescanRangeResult is a future promise to deliver the result of a
+// RescanRangeAsync RPC invocation (or an applicable error).
+type FutureRescanRangeResult chan *response
+
+// Receive waits for the response promised by the future and returns the last
+// rescanned block and the number of blocks which were scanned, fetched, and
+// matched.
+func (r FutureRescanRangeResult) Receive() (*exccjson.RescanRangeResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var rescanResult exccjson.RescanRangeResult
+	err = json.Unmarshal(res, &rescanResult)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rescanResult, nil
+}
+
+// RescanRangeAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See RescanRange for the blocking version and more details.
+//
+// NOTE: This is a exccd extension and requires a websocket connection.
+func (c *Client) RescanRangeAsync(addresses []exccutil.Address, outPoints []*wire.OutPoint, startHeight int64, endHeight *int64) FutureRescanRangeResult {
+	return c.RescanRangeAsyncContext(context.Background(), addresses,
+		outPoints, startHeight, endHeight)
+}
+
+// RescanRangeAsyncContext is like RescanRangeAsync but the request is abandoned
+// once the passed context is done.
+//
+// See RescanRangeContext for the blocking version.
+//
+// NOTE: This is a exccd extension and requires a websocket connection.
+func (c *Client) RescanRangeAsyncContext(ctx context.Context, addresses []exccutil.Address, outPoints []*wire.OutPoint, startHeight int64, endHeight *int64) FutureRescanRangeResult {
+	// Not supported in HTTP POST mode.
+	if c.config.HTTPPostMode {
+		return newFutureError(ErrWebsocketsRequired)
+	}
+
+	addrs := make([]string, len(addresses))
+	for i, addr := range addresses {
+		addrs[i] = addr.EncodeAddress()
+	}
+	ops := make([]exccjson.OutPoint, len(outPoints))
+	for i, op := range outPoints {
+		ops[i] = exccjson.OutPoint{
+			Hash:  op.Hash.String(),
+			Tree:  op.Tree,
+			Index: op.Index,
+		}
+	}
+
+	cmd := exccjson.NewRescanRangeCmd(addrs, ops, startHeight, endHeight)
+	return c.sendCmdContext(ctx, cmd)
+}
+
+// RescanRange rescans the main chain blocks from startHeight through endHeight,
+// or the current best block when it is nil, for transactions paying to the
+// passed addresses or spending the passed outpoints.  Outputs paying to the
+// addresses which are found along the way are rescanned for spending
+// transactions as well.  The server skips the blocks whose committed filter
+// does not match, so the blocks do not need to be downloaded.
+//
+// The blocks with relevant transactions are delivered to the OnRescannedBlock
+// notification handler before this function returns, and the progress of long
+// rescans to OnRescanProgress.
+//
+// NOTE: This is a exccd extension and requires a websocket connection.
+func (c *Client) RescanRange(addresses []exccutil.Address, outPoints []*wire.OutPoint, startHeight int64, endHeight *int64) (*exccjson.RescanRangeResult, error) {
+	return c.RescanRangeAsync(addresses, outPoints, startHeight,
+		endHeight).Receive()
+}
+
+// RescanRangeContext is like RescanRange but the request is abandoned with the
+// error of the passed context once it is done, such as when it times out or is
+// canceled.
+//
+// NOTE: This is a exccd extension and requires a websocket connection.
+func (c *Client) RescanRangeContext(ctx context.Context, addresses []exccutil.Address, outPoints []*wire.OutPoint, startHeight int64, endHeight *int64) (*exccjson.RescanRangeResult, error) {
+	return c.RescanRangeAsyncContext(ctx, addresses, outPoints,
+		startHeight, endHeight).Receive()
+}
+
 // FutureGetCFilterResult is a future promise to deliver the result of a
 // GetCFilterAsync RPC invocation (or an applicable error).
 type FutureGetCFilterResult chan *response
@@ -738,6 +1341,14 @@ func (r FutureGetCFilterResult) Receive() (*gcs.Filter, error) {
 //
 // See GetCFilter for the blocking version and more details.
 func (c *Client) GetCFilterAsync(blockHash *chainhash.Hash, filterType wire.FilterType) FutureGetCFilterResult {
+	return c.GetCFilterAsyncContext(context.Background(), blockHash, filterType)
+}
+
+// GetCFilterAsyncContext is like GetCFilterAsync but the request is abandoned
+// once the passed context is done.
+//
+// See GetCFilterContext for the blocking version.
+func (c *Client) GetCFilterAsyncContext(ctx context.Context, blockHash *chainhash.Hash, filterType wire.FilterType) FutureGetCFilterResult {
 	var ft string
 	switch filterType {
 	case wire.GCSFilterRegular:
@@ -749,7 +1360,7 @@ func (c *Client) GetCFilterAsync(blockHash *chainhash.Hash, filterType wire.Filt
 	}
 
 	cmd := exccjson.NewGetCFilterCmd(blockHash.String(), ft)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetCFilter returns the committed filter of type filterType for a block.
@@ -757,6 +1368,13 @@ func (c *Client) GetCFilter(blockHash *chainhash.Hash, filterType wire.FilterTyp
 	return c.GetCFilterAsync(blockHash, filterType).Receive()
 }
 
+// GetCFilterContext is like GetCFilter but the request is abandoned with the
+// error of the passed context once it is done, such as when it times out or is
+// canceled.
+func (c *Client) GetCFilterContext(ctx context.Context, blockHash *chainhash.Hash, filterType wire.FilterType) (*gcs.Filter, error) {
+	return c.GetCFilterAsyncContext(ctx, blockHash, filterType).Receive()
+}
+
 // FutureGetCFilterHeaderResult is a future promise to deliver the result of a
 // GetCFilterHeaderAsync RPC invocation (or an applicable error).
 type FutureGetCFilterHeaderResult chan *response
@@ -784,6 +1402,14 @@ func (r FutureGetCFilterHeaderResult) Receive() (*chainhash.Hash, error) {
 //
 // See GetCFilterHeader for the blocking version and more details.
 func (c *Client) GetCFilterHeaderAsync(blockHash *chainhash.Hash, filterType wire.FilterType) FutureGetCFilterHeaderResult {
+	return c.GetCFilterHeaderAsyncContext(context.Background(), blockHash, filterType)
+}
+
+// GetCFilterHeaderAsyncContext is like GetCFilterHeaderAsync but the request is
+// abandoned once the passed context is done.
+//
+// See GetCFilterHeaderContext for the blocking version.
+func (c *Client) GetCFilterHeaderAsyncContext(ctx context.Context, blockHash *chainhash.Hash, filterType wire.FilterType) FutureGetCFilterHeaderResult {
 	var ft string
 	switch filterType {
 	case wire.GCSFilterRegular:
@@ -795,7 +1421,7 @@ func (c *Client) GetCFilterHeaderAsync(blockHash *chainhash.Hash, filterType wir
 	}
 
 	cmd := exccjson.NewGetCFilterHeaderCmd(blockHash.String(), ft)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetCFilterHeader returns the committed filter header hash of type filterType
@@ -803,3 +1429,10 @@ func (c *Client) GetCFilterHeaderAsync(blockHash *chainhash.Hash, filterType wir
 func (c *Client) GetCFilterHeader(blockHash *chainhash.Hash, filterType wire.FilterType) (*chainhash.Hash, error) {
 	return c.GetCFilterHeaderAsync(blockHash, filterType).Receive()
 }
+
+// GetCFilterHeaderContext is like GetCFilterHeader but the request is abandoned
+// with the error of the passed context once it is done, such as when it times
+// out or is canceled.
+func (c *Client) GetCFilterHeaderContext(ctx context.Context, blockHash *chainhash.Hash, filterType wire.FilterType) (*chainhash.Hash, error) {
+	return c.GetCFilterHeaderAsyncContext(ctx, blockHash, filterType).Receive()
+}
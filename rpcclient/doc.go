@@ -59,6 +59,22 @@ the Receive method on the returned instance will either return the result
 immediately if it has already arrived, or block until it has.  This is useful
 since it provides the caller with greater control over concurrency.
 
+Timeouts and Cancellation
+
+Every command also has a variant of both APIs that accepts a context, such as
+GetBestBlockContext and GetBestBlockAsyncContext.  Once the context is done, the
+request is abandoned and the error of the context, such as
+context.DeadlineExceeded, is returned instead of waiting for the reply any
+longer.  This allows the latency of RPCs to be bounded so a caller does not
+hang on an unresponsive server:
+
+  ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+  defer cancel()
+  hash, height, err := client.GetBestBlockContext(ctx)
+
+Note that the server might still process an abandoned request, and abandoned
+requests are not reissued on reconnect.
+
 Notifications
 
 The first important part of notifications is to realize that they will only
@@ -95,6 +111,15 @@ notifications are automatically re-registered and any in-flight commands are
 re-issued.  This means from the caller's perspective, the request simply takes
 longer to complete.
 
+The transaction filter loaded with LoadTxFilter is restored as well, however,
+outpoints the RPC server added to the filter on its own as it found relevant
+transactions are not known to the client and are lost.  Since notifications
+sent while the connection was down are missed, the OnReconnected handler is
+invoked with a description of the gap, including the best block known before
+the connection was lost and the current best block, once everything was
+re-established so the caller can catch up, for example by rescanning the missed
+blocks.
+
 The caller may invoke the Shutdown method on the client to force the client
 to cease reconnect attempts and return ErrClientShutdown for all outstanding
 commands.
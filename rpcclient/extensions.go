@@ -7,10 +7,13 @@
 package rpcclient
 
 import (
+	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/EXCCoin/exccd/chaincfg/chainhash"
 	"github.com/EXCCoin/exccd/exccjson"
@@ -23,6 +26,66 @@ var (
 	zeroUint32 = uint32(0)
 )
 
+// FutureAnalyzeScriptResult is a future promise to deliver the result of an
+// AnalyzeScriptAsync RPC invocation (or an applicable error).
+type FutureAnalyzeScriptResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// results of statically analyzing the script.
+func (r FutureAnalyzeScriptResult) Receive() (*exccjson.AnalyzeScriptResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as an analyzescript result object.
+	var analysis exccjson.AnalyzeScriptResult
+	err = json.Unmarshal(res, &analysis)
+	if err != nil {
+		return nil, err
+	}
+
+	return &analysis, nil
+}
+
+// AnalyzeScriptAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See AnalyzeScript for the blocking version and more details.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) AnalyzeScriptAsync(script []byte, version uint16) FutureAnalyzeScriptResult {
+	return c.AnalyzeScriptAsyncContext(context.Background(), script, version)
+}
+
+// AnalyzeScriptAsyncContext is like AnalyzeScriptAsync but the request is
+// abandoned once the passed context is done.
+//
+// See AnalyzeScriptContext for the blocking version.
+func (c *Client) AnalyzeScriptAsyncContext(ctx context.Context, script []byte, version uint16) FutureAnalyzeScriptResult {
+	scriptHex := hex.EncodeToString(script)
+	cmd := exccjson.NewAnalyzeScriptCmd(scriptHex, &version)
+	return c.sendCmdContext(ctx, cmd)
+}
+
+// AnalyzeScript statically analyzes the passed script of the given script
+// version and returns information such as its type, the number of signature
+// operations it contains, and the estimated size of a signature script which
+// redeems it.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) AnalyzeScript(script []byte, version uint16) (*exccjson.AnalyzeScriptResult, error) {
+	return c.AnalyzeScriptAsync(script, version).Receive()
+}
+
+// AnalyzeScriptContext is like AnalyzeScript but the request is abandoned with
+// the error of the passed context once it is done, such as when it times out or
+// is canceled.
+func (c *Client) AnalyzeScriptContext(ctx context.Context, script []byte, version uint16) (*exccjson.AnalyzeScriptResult, error) {
+	return c.AnalyzeScriptAsyncContext(ctx, script, version).Receive()
+}
+
 // FutureCreateEncryptedWalletResult is a future promise to deliver the error
 // result of a CreateEncryptedWalletAsync RPC invocation.
 type FutureCreateEncryptedWalletResult chan *response
@@ -41,8 +104,16 @@ func (r FutureCreateEncryptedWalletResult) Receive() error {
 //
 // NOTE: This is a exccwallet extension.
 func (c *Client) CreateEncryptedWalletAsync(passphrase string) FutureCreateEncryptedWalletResult {
+	return c.CreateEncryptedWalletAsyncContext(context.Background(), passphrase)
+}
+
+// CreateEncryptedWalletAsyncContext is like CreateEncryptedWalletAsync but the
+// request is abandoned once the passed context is done.
+//
+// See CreateEncryptedWalletContext for the blocking version.
+func (c *Client) CreateEncryptedWalletAsyncContext(ctx context.Context, passphrase string) FutureCreateEncryptedWalletResult {
 	cmd := exccjson.NewCreateEncryptedWalletCmd(passphrase)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // CreateEncryptedWallet requests the creation of an encrypted wallet.  Wallets
@@ -57,6 +128,13 @@ func (c *Client) CreateEncryptedWallet(passphrase string) error {
 	return c.CreateEncryptedWalletAsync(passphrase).Receive()
 }
 
+// CreateEncryptedWalletContext is like CreateEncryptedWallet but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) CreateEncryptedWalletContext(ctx context.Context, passphrase string) error {
+	return c.CreateEncryptedWalletAsyncContext(ctx, passphrase).Receive()
+}
+
 // FutureDebugLevelResult is a future promise to deliver the result of a
 // DebugLevelAsync RPC invocation (or an applicable error).
 type FutureDebugLevelResult chan *response
@@ -87,8 +165,16 @@ func (r FutureDebugLevelResult) Receive() (string, error) {
 //
 // NOTE: This is a exccd extension.
 func (c *Client) DebugLevelAsync(levelSpec string) FutureDebugLevelResult {
+	return c.DebugLevelAsyncContext(context.Background(), levelSpec)
+}
+
+// DebugLevelAsyncContext is like DebugLevelAsync but the request is abandoned
+// once the passed context is done.
+//
+// See DebugLevelContext for the blocking version.
+func (c *Client) DebugLevelAsyncContext(ctx context.Context, levelSpec string) FutureDebugLevelResult {
 	cmd := exccjson.NewDebugLevelCmd(levelSpec)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // DebugLevel dynamically sets the debug logging level to the passed level
@@ -105,6 +191,141 @@ func (c *Client) DebugLevel(levelSpec string) (string, error) {
 	return c.DebugLevelAsync(levelSpec).Receive()
 }
 
+// DebugLevelContext is like DebugLevel but the request is abandoned with the
+// error of the passed context once it is done, such as when it times out or is
+// canceled.
+func (c *Client) DebugLevelContext(ctx context.Context, levelSpec string) (string, error) {
+	return c.DebugLevelAsyncContext(ctx, levelSpec).Receive()
+}
+
+// FutureDebugProfileResult is a future promise to deliver the result of a
+// DebugProfileAsync RPC invocation (or an applicable error).
+type FutureDebugProfileResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// details of the file the requested diagnostics were written to.
+func (r FutureDebugProfileResult) Receive() (*exccjson.DebugProfileResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a debugprofile result object.
+	var result exccjson.DebugProfileResult
+	err = json.Unmarshal(res, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// DebugProfileAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on
+// the returned instance.
+//
+// See DebugProfile for the blocking version and more details.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) DebugProfileAsync(kind exccjson.DebugProfileKind, path string, seconds uint32) FutureDebugProfileResult {
+	return c.DebugProfileAsyncContext(context.Background(), kind, path, seconds)
+}
+
+// DebugProfileAsyncContext is like DebugProfileAsync but the request is
+// abandoned once the passed context is done.
+//
+// See DebugProfileContext for the blocking version.
+func (c *Client) DebugProfileAsyncContext(ctx context.Context, kind exccjson.DebugProfileKind, path string, seconds uint32) FutureDebugProfileResult {
+	cmd := exccjson.NewDebugProfileCmd(kind, path, &seconds)
+	return c.sendCmdContext(ctx, cmd)
+}
+
+// DebugProfile requests the server to write the passed kind of diagnostics to
+// the file at the passed path, which is relative to the data directory of the
+// server unless it is absolute.  CPU profiles and execution traces are captured
+// for the passed number of seconds, which is ignored for the other kinds.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) DebugProfile(kind exccjson.DebugProfileKind, path string, seconds uint32) (*exccjson.DebugProfileResult, error) {
+	return c.DebugProfileAsync(kind, path, seconds).Receive()
+}
+
+// DebugProfileContext is like DebugProfile but the request is abandoned with
+// the error of the passed context once it is done, such as when it times out or
+// is canceled.
+func (c *Client) DebugProfileContext(ctx context.Context, kind exccjson.DebugProfileKind, path string, seconds uint32) (*exccjson.DebugProfileResult, error) {
+	return c.DebugProfileAsyncContext(ctx, kind, path, seconds).Receive()
+}
+
+// FutureDebugScriptResult is a future promise to deliver the result of a
+// DebugScriptAsync RPC invocation (or an applicable error).
+type FutureDebugScriptResult chan *response
+
+// Receive waits for the response promised by the future and returns the trace
+// of executing the scripts which redeem the requested transaction input.
+func (r FutureDebugScriptResult) Receive() (*exccjson.DebugScriptResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a debugscript result object.
+	var debugScriptResult exccjson.DebugScriptResult
+	err = json.Unmarshal(res, &debugScriptResult)
+	if err != nil {
+		return nil, err
+	}
+
+	return &debugScriptResult, nil
+}
+
+// DebugScriptAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See DebugScript for the blocking version and more details.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) DebugScriptAsync(tx *wire.MsgTx, index uint32) FutureDebugScriptResult {
+	return c.DebugScriptAsyncContext(context.Background(), tx, index)
+}
+
+// DebugScriptAsyncContext is like DebugScriptAsync but the request is abandoned
+// once the passed context is done.
+//
+// See DebugScriptContext for the blocking version.
+func (c *Client) DebugScriptAsyncContext(ctx context.Context, tx *wire.MsgTx, index uint32) FutureDebugScriptResult {
+	txHex := ""
+	if tx != nil {
+		// Serialize the transaction and convert to hex string.
+		buf := bytes.NewBuffer(make([]byte, 0, tx.SerializeSize()))
+		if err := tx.Serialize(buf); err != nil {
+			return newFutureError(err)
+		}
+		txHex = hex.EncodeToString(buf.Bytes())
+	}
+
+	cmd := exccjson.NewDebugScriptCmd(txHex, index, nil, nil)
+	return c.sendCmdContext(ctx, cmd)
+}
+
+// DebugScript executes the scripts which redeem the input at the provided
+// index of the passed transaction one opcode at a time and returns the state of
+// the script engine after each step.  The output spent by the input must be in
+// the memory pool or the main chain of the server.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) DebugScript(tx *wire.MsgTx, index uint32) (*exccjson.DebugScriptResult, error) {
+	return c.DebugScriptAsync(tx, index).Receive()
+}
+
+// DebugScriptContext is like DebugScript but the request is abandoned with the
+// error of the passed context once it is done, such as when it times out or is
+// canceled.
+func (c *Client) DebugScriptContext(ctx context.Context, tx *wire.MsgTx, index uint32) (*exccjson.DebugScriptResult, error) {
+	return c.DebugScriptAsyncContext(ctx, tx, index).Receive()
+}
+
 // FutureEstimateStakeDiffResult is a future promise to deliver the result of a
 // EstimateStakeDiffAsync RPC invocation (or an applicable error).
 type FutureEstimateStakeDiffResult chan *response
@@ -135,8 +356,16 @@ func (r FutureEstimateStakeDiffResult) Receive() (*exccjson.EstimateStakeDiffRes
 //
 // NOTE: This is a exccd extension.
 func (c *Client) EstimateStakeDiffAsync(tickets *uint32) FutureEstimateStakeDiffResult {
+	return c.EstimateStakeDiffAsyncContext(context.Background(), tickets)
+}
+
+// EstimateStakeDiffAsyncContext is like EstimateStakeDiffAsync but the request
+// is abandoned once the passed context is done.
+//
+// See EstimateStakeDiffContext for the blocking version.
+func (c *Client) EstimateStakeDiffAsyncContext(ctx context.Context, tickets *uint32) FutureEstimateStakeDiffResult {
 	cmd := exccjson.NewEstimateStakeDiffCmd(tickets)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // EstimateStakeDiff returns the minimum, maximum, and expected next stake
@@ -147,6 +376,13 @@ func (c *Client) EstimateStakeDiff(tickets *uint32) (*exccjson.EstimateStakeDiff
 	return c.EstimateStakeDiffAsync(tickets).Receive()
 }
 
+// EstimateStakeDiffContext is like EstimateStakeDiff but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) EstimateStakeDiffContext(ctx context.Context, tickets *uint32) (*exccjson.EstimateStakeDiffResult, error) {
+	return c.EstimateStakeDiffAsyncContext(ctx, tickets).Receive()
+}
+
 // FutureExistsAddressResult is a future promise to deliver the result
 // of a FutureExistsAddressResultAsync RPC invocation (or an applicable error).
 type FutureExistsAddressResult chan *response
@@ -172,8 +408,16 @@ func (r FutureExistsAddressResult) Receive() (bool, error) {
 // result of the RPC at some future time by invoking the Receive function on the
 // returned instance.
 func (c *Client) ExistsAddressAsync(address exccutil.Address) FutureExistsAddressResult {
+	return c.ExistsAddressAsyncContext(context.Background(), address)
+}
+
+// ExistsAddressAsyncContext is like ExistsAddressAsync but the request is
+// abandoned once the passed context is done.
+//
+// See ExistsAddressContext for the blocking version.
+func (c *Client) ExistsAddressAsyncContext(ctx context.Context, address exccutil.Address) FutureExistsAddressResult {
 	cmd := exccjson.NewExistsAddressCmd(address.EncodeAddress())
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // ExistsAddress returns information about whether or not an address has been
@@ -184,6 +428,13 @@ func (c *Client) ExistsAddress(address exccutil.Address) (bool, error) {
 	return c.ExistsAddressAsync(address).Receive()
 }
 
+// ExistsAddressContext is like ExistsAddress but the request is abandoned with
+// the error of the passed context once it is done, such as when it times out or
+// is canceled.
+func (c *Client) ExistsAddressContext(ctx context.Context, address exccutil.Address) (bool, error) {
+	return c.ExistsAddressAsyncContext(ctx, address).Receive()
+}
+
 // FutureExistsAddressesResult is a future promise to deliver the result
 // of a FutureExistsAddressesResultAsync RPC invocation (or an
 // applicable error).
@@ -210,13 +461,21 @@ func (r FutureExistsAddressesResult) Receive() (string, error) {
 // result of the RPC at some future time by invoking the Receive function on the
 // returned instance.
 func (c *Client) ExistsAddressesAsync(addresses []exccutil.Address) FutureExistsAddressesResult {
+	return c.ExistsAddressesAsyncContext(context.Background(), addresses)
+}
+
+// ExistsAddressesAsyncContext is like ExistsAddressesAsync but the request is
+// abandoned once the passed context is done.
+//
+// See ExistsAddressesContext for the blocking version.
+func (c *Client) ExistsAddressesAsyncContext(ctx context.Context, addresses []exccutil.Address) FutureExistsAddressesResult {
 	addrsStr := make([]string, len(addresses))
 	for i := range addresses {
 		addrsStr[i] = addresses[i].EncodeAddress()
 	}
 
 	cmd := exccjson.NewExistsAddressesCmd(addrsStr)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // ExistsAddresses returns information about whether or not an address exists
@@ -227,6 +486,13 @@ func (c *Client) ExistsAddresses(addresses []exccutil.Address) (string, error) {
 	return c.ExistsAddressesAsync(addresses).Receive()
 }
 
+// ExistsAddressesContext is like ExistsAddresses but the request is abandoned
+// with the error of the passed context once it is done, such as when it times
+// out or is canceled.
+func (c *Client) ExistsAddressesContext(ctx context.Context, addresses []exccutil.Address) (string, error) {
+	return c.ExistsAddressesAsyncContext(ctx, addresses).Receive()
+}
+
 // FutureExistsMissedTicketsResult is a future promise to deliver the result of
 // an ExistsMissedTicketsAsync RPC invocation (or an applicable error).
 type FutureExistsMissedTicketsResult chan *response
@@ -252,13 +518,21 @@ func (r FutureExistsMissedTicketsResult) Receive() (string, error) {
 // get the result of the RPC at some future time by invoking the Receive
 // function on the returned instance.
 func (c *Client) ExistsMissedTicketsAsync(hashes []*chainhash.Hash) FutureExistsMissedTicketsResult {
+	return c.ExistsMissedTicketsAsyncContext(context.Background(), hashes)
+}
+
+// ExistsMissedTicketsAsyncContext is like ExistsMissedTicketsAsync but the
+// request is abandoned once the passed context is done.
+//
+// See ExistsMissedTicketsContext for the blocking version.
+func (c *Client) ExistsMissedTicketsAsyncContext(ctx context.Context, hashes []*chainhash.Hash) FutureExistsMissedTicketsResult {
 	hashBlob := make([]byte, len(hashes)*chainhash.HashSize)
 	for i, hash := range hashes {
 		copy(hashBlob[i*chainhash.HashSize:(i+1)*chainhash.HashSize],
 			hash[:])
 	}
 	cmd := exccjson.NewExistsMissedTicketsCmd(hex.EncodeToString(hashBlob))
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // ExistsMissedTickets returns a hex-encoded bitset describing whether or not
@@ -267,6 +541,13 @@ func (c *Client) ExistsMissedTickets(hashes []*chainhash.Hash) (string, error) {
 	return c.ExistsMissedTicketsAsync(hashes).Receive()
 }
 
+// ExistsMissedTicketsContext is like ExistsMissedTickets but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) ExistsMissedTicketsContext(ctx context.Context, hashes []*chainhash.Hash) (string, error) {
+	return c.ExistsMissedTicketsAsyncContext(ctx, hashes).Receive()
+}
+
 // FutureExistsExpiredTicketsResult is a future promise to deliver the result
 // of a FutureExistsExpiredTicketsResultAsync RPC invocation (or an
 // applicable error).
@@ -293,13 +574,21 @@ func (r FutureExistsExpiredTicketsResult) Receive() (string, error) {
 // result of the RPC at some future time by invoking the Receive function on the
 // returned instance.
 func (c *Client) ExistsExpiredTicketsAsync(hashes []*chainhash.Hash) FutureExistsExpiredTicketsResult {
+	return c.ExistsExpiredTicketsAsyncContext(context.Background(), hashes)
+}
+
+// ExistsExpiredTicketsAsyncContext is like ExistsExpiredTicketsAsync but the
+// request is abandoned once the passed context is done.
+//
+// See ExistsExpiredTicketsContext for the blocking version.
+func (c *Client) ExistsExpiredTicketsAsyncContext(ctx context.Context, hashes []*chainhash.Hash) FutureExistsExpiredTicketsResult {
 	hashBlob := make([]byte, len(hashes)*chainhash.HashSize)
 	for i, hash := range hashes {
 		copy(hashBlob[i*chainhash.HashSize:(i+1)*chainhash.HashSize],
 			hash[:])
 	}
 	cmd := exccjson.NewExistsExpiredTicketsCmd(hex.EncodeToString(hashBlob))
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // ExistsExpiredTickets returns information about whether or not a ticket hash exists
@@ -310,6 +599,13 @@ func (c *Client) ExistsExpiredTickets(hashes []*chainhash.Hash) (string, error)
 	return c.ExistsExpiredTicketsAsync(hashes).Receive()
 }
 
+// ExistsExpiredTicketsContext is like ExistsExpiredTickets but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) ExistsExpiredTicketsContext(ctx context.Context, hashes []*chainhash.Hash) (string, error) {
+	return c.ExistsExpiredTicketsAsyncContext(ctx, hashes).Receive()
+}
+
 // FutureExistsLiveTicketResult is a future promise to deliver the result
 // of a FutureExistsLiveTicketResultAsync RPC invocation (or an
 // applicable error).
@@ -336,8 +632,16 @@ func (r FutureExistsLiveTicketResult) Receive() (bool, error) {
 // result of the RPC at some future time by invoking the Receive function on the
 // returned instance.
 func (c *Client) ExistsLiveTicketAsync(hash *chainhash.Hash) FutureExistsLiveTicketResult {
+	return c.ExistsLiveTicketAsyncContext(context.Background(), hash)
+}
+
+// ExistsLiveTicketAsyncContext is like ExistsLiveTicketAsync but the request is
+// abandoned once the passed context is done.
+//
+// See ExistsLiveTicketContext for the blocking version.
+func (c *Client) ExistsLiveTicketAsyncContext(ctx context.Context, hash *chainhash.Hash) FutureExistsLiveTicketResult {
 	cmd := exccjson.NewExistsLiveTicketCmd(hash.String())
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // ExistsLiveTicket returns information about whether or not a ticket hash exists
@@ -348,6 +652,13 @@ func (c *Client) ExistsLiveTicket(hash *chainhash.Hash) (bool, error) {
 	return c.ExistsLiveTicketAsync(hash).Receive()
 }
 
+// ExistsLiveTicketContext is like ExistsLiveTicket but the request is abandoned
+// with the error of the passed context once it is done, such as when it times
+// out or is canceled.
+func (c *Client) ExistsLiveTicketContext(ctx context.Context, hash *chainhash.Hash) (bool, error) {
+	return c.ExistsLiveTicketAsyncContext(ctx, hash).Receive()
+}
+
 // FutureExistsLiveTicketsResult is a future promise to deliver the result
 // of a FutureExistsLiveTicketsResultAsync RPC invocation (or an
 // applicable error).
@@ -374,13 +685,21 @@ func (r FutureExistsLiveTicketsResult) Receive() (string, error) {
 // result of the RPC at some future time by invoking the Receive function on the
 // returned instance.
 func (c *Client) ExistsLiveTicketsAsync(hashes []*chainhash.Hash) FutureExistsLiveTicketsResult {
+	return c.ExistsLiveTicketsAsyncContext(context.Background(), hashes)
+}
+
+// ExistsLiveTicketsAsyncContext is like ExistsLiveTicketsAsync but the request
+// is abandoned once the passed context is done.
+//
+// See ExistsLiveTicketsContext for the blocking version.
+func (c *Client) ExistsLiveTicketsAsyncContext(ctx context.Context, hashes []*chainhash.Hash) FutureExistsLiveTicketsResult {
 	hashBlob := make([]byte, len(hashes)*chainhash.HashSize)
 	for i, hash := range hashes {
 		copy(hashBlob[i*chainhash.HashSize:(i+1)*chainhash.HashSize],
 			hash[:])
 	}
 	cmd := exccjson.NewExistsLiveTicketsCmd(hex.EncodeToString(hashBlob))
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // ExistsLiveTickets returns information about whether or not a ticket hash exists
@@ -391,6 +710,13 @@ func (c *Client) ExistsLiveTickets(hashes []*chainhash.Hash) (string, error) {
 	return c.ExistsLiveTicketsAsync(hashes).Receive()
 }
 
+// ExistsLiveTicketsContext is like ExistsLiveTickets but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) ExistsLiveTicketsContext(ctx context.Context, hashes []*chainhash.Hash) (string, error) {
+	return c.ExistsLiveTicketsAsyncContext(ctx, hashes).Receive()
+}
+
 // FutureExistsMempoolTxsResult is a future promise to deliver the result
 // of a FutureExistsMempoolTxsResultAsync RPC invocation (or an
 // applicable error).
@@ -417,13 +743,21 @@ func (r FutureExistsMempoolTxsResult) Receive() (string, error) {
 // result of the RPC at some future time by invoking the Receive function on the
 // returned instance.
 func (c *Client) ExistsMempoolTxsAsync(hashes []*chainhash.Hash) FutureExistsMempoolTxsResult {
+	return c.ExistsMempoolTxsAsyncContext(context.Background(), hashes)
+}
+
+// ExistsMempoolTxsAsyncContext is like ExistsMempoolTxsAsync but the request is
+// abandoned once the passed context is done.
+//
+// See ExistsMempoolTxsContext for the blocking version.
+func (c *Client) ExistsMempoolTxsAsyncContext(ctx context.Context, hashes []*chainhash.Hash) FutureExistsMempoolTxsResult {
 	hashBlob := make([]byte, len(hashes)*chainhash.HashSize)
 	for i, hash := range hashes {
 		copy(hashBlob[i*chainhash.HashSize:(i+1)*chainhash.HashSize],
 			hash[:])
 	}
 	cmd := exccjson.NewExistsMempoolTxsCmd(hex.EncodeToString(hashBlob))
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // ExistsMempoolTxs returns information about whether or not a ticket hash exists
@@ -434,6 +768,13 @@ func (c *Client) ExistsMempoolTxs(hashes []*chainhash.Hash) (string, error) {
 	return c.ExistsMempoolTxsAsync(hashes).Receive()
 }
 
+// ExistsMempoolTxsContext is like ExistsMempoolTxs but the request is abandoned
+// with the error of the passed context once it is done, such as when it times
+// out or is canceled.
+func (c *Client) ExistsMempoolTxsContext(ctx context.Context, hashes []*chainhash.Hash) (string, error) {
+	return c.ExistsMempoolTxsAsyncContext(ctx, hashes).Receive()
+}
+
 // FutureExportWatchingWalletResult is a future promise to deliver the result of
 // an ExportWatchingWalletAsync RPC invocation (or an applicable error).
 type FutureExportWatchingWalletResult chan *response
@@ -489,8 +830,16 @@ func (r FutureExportWatchingWalletResult) Receive() ([]byte, []byte, error) {
 //
 // NOTE: This is a exccwallet extension.
 func (c *Client) ExportWatchingWalletAsync(account string) FutureExportWatchingWalletResult {
+	return c.ExportWatchingWalletAsyncContext(context.Background(), account)
+}
+
+// ExportWatchingWalletAsyncContext is like ExportWatchingWalletAsync but the
+// request is abandoned once the passed context is done.
+//
+// See ExportWatchingWalletContext for the blocking version.
+func (c *Client) ExportWatchingWalletAsyncContext(ctx context.Context, account string) FutureExportWatchingWalletResult {
 	cmd := exccjson.NewExportWatchingWalletCmd(&account, exccjson.Bool(true))
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // ExportWatchingWallet returns the raw bytes for a watching-only version of
@@ -503,6 +852,128 @@ func (c *Client) ExportWatchingWallet(account string) ([]byte, []byte, error) {
 	return c.ExportWatchingWalletAsync(account).Receive()
 }
 
+// ExportWatchingWalletContext is like ExportWatchingWallet but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) ExportWatchingWalletContext(ctx context.Context, account string) ([]byte, []byte, error) {
+	return c.ExportWatchingWalletAsyncContext(ctx, account).Receive()
+}
+
+// FutureGetAddressTicketsResult is a future promise to deliver the result of a
+// GetAddressTicketsAsync RPC invocation (or an applicable error).
+type FutureGetAddressTicketsResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// lifecycles of the tickets which commit their rewards to the address.
+func (r FutureGetAddressTicketsResult) Receive() ([]exccjson.TicketInfoResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as an array of ticket info result objects.
+	var tickets []exccjson.TicketInfoResult
+	err = json.Unmarshal(res, &tickets)
+	if err != nil {
+		return nil, err
+	}
+
+	return tickets, nil
+}
+
+// GetAddressTicketsAsync returns an instance of a type that can be used to get
+// the result of the RPC at some future time by invoking the Receive function on
+// the returned instance.
+//
+// See GetAddressTickets for the blocking version and more details.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) GetAddressTicketsAsync(address exccutil.Address) FutureGetAddressTicketsResult {
+	return c.GetAddressTicketsAsyncContext(context.Background(), address)
+}
+
+// GetAddressTicketsAsyncContext is like GetAddressTicketsAsync but the request
+// is abandoned once the passed context is done.
+//
+// See GetAddressTicketsContext for the blocking version.
+func (c *Client) GetAddressTicketsAsyncContext(ctx context.Context, address exccutil.Address) FutureGetAddressTicketsResult {
+	cmd := exccjson.NewGetAddressTicketsCmd(address.EncodeAddress())
+	return c.sendCmdContext(ctx, cmd)
+}
+
+// GetAddressTickets returns the lifecycles of all tickets which commit their
+// rewards to the passed address.  The server must be running with the ticket
+// lifecycle index enabled.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) GetAddressTickets(address exccutil.Address) ([]exccjson.TicketInfoResult, error) {
+	return c.GetAddressTicketsAsync(address).Receive()
+}
+
+// GetAddressTicketsContext is like GetAddressTickets but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) GetAddressTicketsContext(ctx context.Context, address exccutil.Address) ([]exccjson.TicketInfoResult, error) {
+	return c.GetAddressTicketsAsyncContext(ctx, address).Receive()
+}
+
+// FutureGetAgendasResult is a future promise to deliver the result of a
+// GetAgendasAsync RPC invocation (or an applicable error).
+type FutureGetAgendasResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// consensus rule change agendas along with their status.
+func (r FutureGetAgendasResult) Receive() ([]exccjson.GetAgendasResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as an array of agenda result objects.
+	var agendas []exccjson.GetAgendasResult
+	err = json.Unmarshal(res, &agendas)
+	if err != nil {
+		return nil, err
+	}
+
+	return agendas, nil
+}
+
+// GetAgendasAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See GetAgendas for the blocking version and more details.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) GetAgendasAsync() FutureGetAgendasResult {
+	return c.GetAgendasAsyncContext(context.Background())
+}
+
+// GetAgendasAsyncContext is like GetAgendasAsync but the request is abandoned
+// once the passed context is done.
+//
+// See GetAgendasContext for the blocking version.
+func (c *Client) GetAgendasAsyncContext(ctx context.Context) FutureGetAgendasResult {
+	cmd := exccjson.NewGetAgendasCmd()
+	return c.sendCmdContext(ctx, cmd)
+}
+
+// GetAgendas returns the consensus rule change agendas defined for all stake
+// versions along with their status for the block after the current best block.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) GetAgendas() ([]exccjson.GetAgendasResult, error) {
+	return c.GetAgendasAsync().Receive()
+}
+
+// GetAgendasContext is like GetAgendas but the request is abandoned with the
+// error of the passed context once it is done, such as when it times out or is
+// canceled.
+func (c *Client) GetAgendasContext(ctx context.Context) ([]exccjson.GetAgendasResult, error) {
+	return c.GetAgendasAsyncContext(ctx).Receive()
+}
+
 // FutureGetBestBlockResult is a future promise to deliver the result of a
 // GetBestBlockAsync RPC invocation (or an applicable error).
 type FutureGetBestBlockResult chan *response
@@ -539,8 +1010,16 @@ func (r FutureGetBestBlockResult) Receive() (*chainhash.Hash, int64, error) {
 //
 // NOTE: This is a exccd extension.
 func (c *Client) GetBestBlockAsync() FutureGetBestBlockResult {
+	return c.GetBestBlockAsyncContext(context.Background())
+}
+
+// GetBestBlockAsyncContext is like GetBestBlockAsync but the request is
+// abandoned once the passed context is done.
+//
+// See GetBestBlockContext for the blocking version.
+func (c *Client) GetBestBlockAsyncContext(ctx context.Context) FutureGetBestBlockResult {
 	cmd := exccjson.NewGetBestBlockCmd()
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetBestBlock returns the hash and height of the block in the longest (best)
@@ -551,90 +1030,588 @@ func (c *Client) GetBestBlock() (*chainhash.Hash, int64, error) {
 	return c.GetBestBlockAsync().Receive()
 }
 
-// FutureGetCurrentNetResult is a future promise to deliver the result of a
-// GetCurrentNetAsync RPC invocation (or an applicable error).
-type FutureGetCurrentNetResult chan *response
+// GetBestBlockContext is like GetBestBlock but the request is abandoned with
+// the error of the passed context once it is done, such as when it times out or
+// is canceled.
+func (c *Client) GetBestBlockContext(ctx context.Context) (*chainhash.Hash, int64, error) {
+	return c.GetBestBlockAsyncContext(ctx).Receive()
+}
 
-// Receive waits for the response promised by the future and returns the network
-// the server is running on.
-func (r FutureGetCurrentNetResult) Receive() (wire.CurrencyNet, error) {
+// FutureGetBlockHashByTimeResult is a future promise to deliver the result of
+// a GetBlockHashByTimeAsync RPC invocation (or an applicable error).
+type FutureGetBlockHashByTimeResult chan *response
+
+// Receive waits for the response promised by the future and returns the blocks
+// within the requested time range.
+func (r FutureGetBlockHashByTimeResult) Receive() ([]exccjson.GetBlockHashByTimeResult, error) {
 	res, err := receiveFuture(r)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	// Unmarshal result as an int64.
-	var net int64
-	err = json.Unmarshal(res, &net)
+	// Unmarshal result as an array of getblockhashbytime result objects.
+	var blocks []exccjson.GetBlockHashByTimeResult
+	err = json.Unmarshal(res, &blocks)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	return wire.CurrencyNet(net), nil
+	return blocks, nil
 }
 
-// GetCurrentNetAsync returns an instance of a type that can be used to get the
-// result of the RPC at some future time by invoking the Receive function on the
-// returned instance.
+// GetBlockHashByTimeAsync returns an instance of a type that can be used to get
+// the result of the RPC at some future time by invoking the Receive function on
+// the returned instance.
 //
-// See GetCurrentNet for the blocking version and more details.
+// See GetBlockHashByTime for the blocking version and more details.
 //
 // NOTE: This is a exccd extension.
-func (c *Client) GetCurrentNetAsync() FutureGetCurrentNetResult {
-	cmd := exccjson.NewGetCurrentNetCmd()
-	return c.sendCmd(cmd)
+func (c *Client) GetBlockHashByTimeAsync(startTime int64, endTime *int64, count *int32) FutureGetBlockHashByTimeResult {
+	return c.GetBlockHashByTimeAsyncContext(context.Background(), startTime, endTime, count)
 }
 
-// GetCurrentNet returns the network the server is running on.
+// GetBlockHashByTimeAsyncContext is like GetBlockHashByTimeAsync but the
+// request is abandoned once the passed context is done.
+//
+// See GetBlockHashByTimeContext for the blocking version.
+func (c *Client) GetBlockHashByTimeAsyncContext(ctx context.Context, startTime int64, endTime *int64, count *int32) FutureGetBlockHashByTimeResult {
+	cmd := exccjson.NewGetBlockHashByTimeCmd(startTime, endTime, count)
+	return c.sendCmdContext(ctx, cmd)
+}
+
+// GetBlockHashByTime returns the main chain blocks whose header timestamps are
+// within the provided range.  The server must be running with the block
+// timestamp index enabled.
 //
 // NOTE: This is a exccd extension.
-func (c *Client) GetCurrentNet() (wire.CurrencyNet, error) {
-	return c.GetCurrentNetAsync().Receive()
+func (c *Client) GetBlockHashByTime(startTime int64, endTime *int64, count *int32) ([]exccjson.GetBlockHashByTimeResult, error) {
+	return c.GetBlockHashByTimeAsync(startTime, endTime, count).Receive()
 }
 
-// FutureGetHeadersResult is a future promise to deliver the result of a
-// getheaders RPC invocation (or an applicable error).
-type FutureGetHeadersResult chan *response
+// GetBlockHashByTimeContext is like GetBlockHashByTime but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) GetBlockHashByTimeContext(ctx context.Context, startTime int64, endTime *int64, count *int32) ([]exccjson.GetBlockHashByTimeResult, error) {
+	return c.GetBlockHashByTimeAsyncContext(ctx, startTime, endTime, count).Receive()
+}
+
+// FutureGetDifficultyHistoryResult is a future promise to deliver the result
+// of a GetDifficultyHistoryAsync RPC invocation (or an applicable error).
+type FutureGetDifficultyHistoryResult chan *response
 
 // Receive waits for the response promised by the future and returns the
-// getheaders result.
-func (r FutureGetHeadersResult) Receive() (*exccjson.GetHeadersResult, error) {
+// difficulty history of the requested blocks.
+func (r FutureGetDifficultyHistoryResult) Receive() ([]exccjson.DifficultyHistoryResult, error) {
 	res, err := receiveFuture(r)
 	if err != nil {
 		return nil, err
 	}
 
-	// Unmarsal result as a getheaders result object.
-	var vr exccjson.GetHeadersResult
-	err = json.Unmarshal(res, &vr)
+	// Unmarshal result as an array of getdifficultyhistory result objects.
+	var history []exccjson.DifficultyHistoryResult
+	err = json.Unmarshal(res, &history)
 	if err != nil {
 		return nil, err
 	}
 
-	return &vr, nil
+	return history, nil
 }
 
-// GetHeadersAsync returns an instance of a type that can be used to get the result
-// of the RPC at some future time by invoking the Receive function on the returned instance.
+// GetDifficultyHistoryAsync returns an instance of a type that can be used to
+// get the result of the RPC at some future time by invoking the Receive
+// function on the returned instance.
 //
-// See GetHeaders for the blocking version and more details.
-func (c *Client) GetHeadersAsync(blockLocators []*chainhash.Hash, hashStop *chainhash.Hash) FutureGetHeadersResult {
-	concatenatedLocators := make([]byte, chainhash.HashSize*len(blockLocators))
-	for i := range blockLocators {
-		copy(concatenatedLocators[i*chainhash.HashSize:], blockLocators[i][:])
-	}
-	cmd := exccjson.NewGetHeadersCmd(hex.EncodeToString(concatenatedLocators),
-		hashStop.String())
-	return c.sendCmd(cmd)
+// See GetDifficultyHistory for the blocking version and more details.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) GetDifficultyHistoryAsync(startHeight, endHeight int64) FutureGetDifficultyHistoryResult {
+	return c.GetDifficultyHistoryAsyncContext(context.Background(), startHeight, endHeight)
+}
+
+// GetDifficultyHistoryAsyncContext is like GetDifficultyHistoryAsync but the
+// request is abandoned once the passed context is done.
+//
+// See GetDifficultyHistoryContext for the blocking version.
+func (c *Client) GetDifficultyHistoryAsyncContext(ctx context.Context, startHeight, endHeight int64) FutureGetDifficultyHistoryResult {
+	cmd := exccjson.NewGetDifficultyHistoryCmd(startHeight, &endHeight)
+	return c.sendCmdContext(ctx, cmd)
+}
+
+// GetDifficultyHistory returns the proof-of-work difficulty, the cumulative
+// chain work, and the difficulty retarget boundaries of the main chain blocks
+// from the start height through the end height.  An end height of -1 uses the
+// current best block.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) GetDifficultyHistory(startHeight, endHeight int64) ([]exccjson.DifficultyHistoryResult, error) {
+	return c.GetDifficultyHistoryAsync(startHeight, endHeight).Receive()
+}
+
+// GetDifficultyHistoryContext is like GetDifficultyHistory but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) GetDifficultyHistoryContext(ctx context.Context, startHeight, endHeight int64) ([]exccjson.DifficultyHistoryResult, error) {
+	return c.GetDifficultyHistoryAsyncContext(ctx, startHeight, endHeight).Receive()
+}
+
+// FutureGetCurrentNetResult is a future promise to deliver the result of a
+// GetCurrentNetAsync RPC invocation (or an applicable error).
+type FutureGetCurrentNetResult chan *response
+
+// Receive waits for the response promised by the future and returns the network
+// the server is running on.
+func (r FutureGetCurrentNetResult) Receive() (wire.CurrencyNet, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return 0, err
+	}
+
+	// Unmarshal result as an int64.
+	var net int64
+	err = json.Unmarshal(res, &net)
+	if err != nil {
+		return 0, err
+	}
+
+	return wire.CurrencyNet(net), nil
+}
+
+// GetCurrentNetAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See GetCurrentNet for the blocking version and more details.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) GetCurrentNetAsync() FutureGetCurrentNetResult {
+	return c.GetCurrentNetAsyncContext(context.Background())
+}
+
+// GetCurrentNetAsyncContext is like GetCurrentNetAsync but the request is
+// abandoned once the passed context is done.
+//
+// See GetCurrentNetContext for the blocking version.
+func (c *Client) GetCurrentNetAsyncContext(ctx context.Context) FutureGetCurrentNetResult {
+	cmd := exccjson.NewGetCurrentNetCmd()
+	return c.sendCmdContext(ctx, cmd)
+}
+
+// GetCurrentNet returns the network the server is running on.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) GetCurrentNet() (wire.CurrencyNet, error) {
+	return c.GetCurrentNetAsync().Receive()
+}
+
+// GetCurrentNetContext is like GetCurrentNet but the request is abandoned with
+// the error of the passed context once it is done, such as when it times out or
+// is canceled.
+func (c *Client) GetCurrentNetContext(ctx context.Context) (wire.CurrencyNet, error) {
+	return c.GetCurrentNetAsyncContext(ctx).Receive()
+}
+
+// FutureGetHeadersResult is a future promise to deliver the result of a
+// getheaders RPC invocation (or an applicable error).
+type FutureGetHeadersResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// getheaders result.
+func (r FutureGetHeadersResult) Receive() (*exccjson.GetHeadersResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarsal result as a getheaders result object.
+	var vr exccjson.GetHeadersResult
+	err = json.Unmarshal(res, &vr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vr, nil
+}
+
+// GetHeadersAsync returns an instance of a type that can be used to get the result
+// of the RPC at some future time by invoking the Receive function on the returned instance.
+//
+// See GetHeaders for the blocking version and more details.
+func (c *Client) GetHeadersAsync(blockLocators []*chainhash.Hash, hashStop *chainhash.Hash) FutureGetHeadersResult {
+	return c.GetHeadersAsyncContext(context.Background(), blockLocators, hashStop)
+}
+
+// GetHeadersAsyncContext is like GetHeadersAsync but the request is abandoned
+// once the passed context is done.
+//
+// See GetHeadersContext for the blocking version.
+func (c *Client) GetHeadersAsyncContext(ctx context.Context, blockLocators []*chainhash.Hash, hashStop *chainhash.Hash) FutureGetHeadersResult {
+	concatenatedLocators := make([]byte, chainhash.HashSize*len(blockLocators))
+	for i := range blockLocators {
+		copy(concatenatedLocators[i*chainhash.HashSize:], blockLocators[i][:])
+	}
+	var hashStopStr string
+	if hashStop != nil {
+		hashStopStr = hashStop.String()
+	}
+	cmd := exccjson.NewGetHeadersCmd(hex.EncodeToString(concatenatedLocators),
+		hashStopStr)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetHeaders mimics the wire protocol getheaders and headers messages by
 // returning all headers on the main chain after the first known block in the
-// locators, up until a block hash matches hashStop.
+// locators, up until a block hash matches hashStop.  The hashStop parameter may
+// be nil to return as many headers as allowed.
 func (c *Client) GetHeaders(blockLocators []*chainhash.Hash, hashStop *chainhash.Hash) (*exccjson.GetHeadersResult, error) {
 	return c.GetHeadersAsync(blockLocators, hashStop).Receive()
 }
 
+// GetHeadersContext is like GetHeaders but the request is abandoned with the
+// error of the passed context once it is done, such as when it times out or is
+// canceled.
+func (c *Client) GetHeadersContext(ctx context.Context, blockLocators []*chainhash.Hash, hashStop *chainhash.Hash) (*exccjson.GetHeadersResult, error) {
+	return c.GetHeadersAsyncContext(ctx, blockLocators, hashStop).Receive()
+}
+
+// FutureGetIndexInfoResult is a future promise to deliver the result of a
+// GetIndexInfoAsync RPC invocation (or an applicable error).
+type FutureGetIndexInfoResult chan *response
+
+// Receive waits for the response promised by the future and returns the status
+// of the enabled indexes keyed by their names.
+func (r FutureGetIndexInfoResult) Receive() (map[string]exccjson.GetIndexInfoResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a map of index names to getindexinfo result
+	// objects.
+	var infos map[string]exccjson.GetIndexInfoResult
+	err = json.Unmarshal(res, &infos)
+	if err != nil {
+		return nil, err
+	}
+
+	return infos, nil
+}
+
+// GetIndexInfoAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See GetIndexInfo for the blocking version and more details.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) GetIndexInfoAsync(indexName *string) FutureGetIndexInfoResult {
+	return c.GetIndexInfoAsyncContext(context.Background(), indexName)
+}
+
+// GetIndexInfoAsyncContext is like GetIndexInfoAsync but the request is
+// abandoned once the passed context is done.
+//
+// See GetIndexInfoContext for the blocking version.
+func (c *Client) GetIndexInfoAsyncContext(ctx context.Context, indexName *string) FutureGetIndexInfoResult {
+	cmd := exccjson.NewGetIndexInfoCmd(indexName)
+	return c.sendCmdContext(ctx, cmd)
+}
+
+// GetIndexInfo returns the status of the optional indexes enabled on the server,
+// including whether or not they have finished catching up to the main chain.
+// Only the status of the index with the provided name is returned when it is
+// not nil.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) GetIndexInfo(indexName *string) (map[string]exccjson.GetIndexInfoResult, error) {
+	return c.GetIndexInfoAsync(indexName).Receive()
+}
+
+// GetIndexInfoContext is like GetIndexInfo but the request is abandoned with
+// the error of the passed context once it is done, such as when it times out or
+// is canceled.
+func (c *Client) GetIndexInfoContext(ctx context.Context, indexName *string) (map[string]exccjson.GetIndexInfoResult, error) {
+	return c.GetIndexInfoAsyncContext(ctx, indexName).Receive()
+}
+
+// FutureGetMinedBlockStatsResult is a future promise to deliver the result of
+// a GetMinedBlockStatsAsync RPC invocation (or an applicable error).
+type FutureGetMinedBlockStatsResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// statistics about the outcome of the blocks mined by the server or submitted
+// to it.
+func (r FutureGetMinedBlockStatsResult) Receive() (*exccjson.GetMinedBlockStatsResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a getminedblockstats result object.
+	var stats exccjson.GetMinedBlockStatsResult
+	err = json.Unmarshal(res, &stats)
+	if err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+// GetMinedBlockStatsAsync returns an instance of a type that can be used to get
+// the result of the RPC at some future time by invoking the Receive function on
+// the returned instance.
+//
+// See GetMinedBlockStats for the blocking version and more details.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) GetMinedBlockStatsAsync() FutureGetMinedBlockStatsResult {
+	return c.GetMinedBlockStatsAsyncContext(context.Background())
+}
+
+// GetMinedBlockStatsAsyncContext is like GetMinedBlockStatsAsync but the
+// request is abandoned once the passed context is done.
+//
+// See GetMinedBlockStatsContext for the blocking version.
+func (c *Client) GetMinedBlockStatsAsyncContext(ctx context.Context) FutureGetMinedBlockStatsResult {
+	cmd := exccjson.NewGetMinedBlockStatsCmd()
+	return c.sendCmdContext(ctx, cmd)
+}
+
+// GetMinedBlockStats returns how many of the blocks mined by the server or
+// submitted to it were accepted versus how many ended up stale, orphaned, or
+// rejected over several windows of time along with the stale rate.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) GetMinedBlockStats() (*exccjson.GetMinedBlockStatsResult, error) {
+	return c.GetMinedBlockStatsAsync().Receive()
+}
+
+// GetMinedBlockStatsContext is like GetMinedBlockStats but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) GetMinedBlockStatsContext(ctx context.Context) (*exccjson.GetMinedBlockStatsResult, error) {
+	return c.GetMinedBlockStatsAsyncContext(ctx).Receive()
+}
+
+// FutureGetMiningArchiveResult is a future promise to deliver the result of a
+// GetMiningArchiveAsync RPC invocation (or an applicable error).
+type FutureGetMiningArchiveResult chan *response
+
+// Receive waits for the response promised by the future and returns the most
+// recent records of the mining archive.
+func (r FutureGetMiningArchiveResult) Receive() ([]exccjson.MiningArchiveResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as an array of getminingarchive result objects.
+	var records []exccjson.MiningArchiveResult
+	err = json.Unmarshal(res, &records)
+	if err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// GetMiningArchiveAsync returns an instance of a type that can be used to get
+// the result of the RPC at some future time by invoking the Receive function on
+// the returned instance.
+//
+// See GetMiningArchive for the blocking version and more details.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) GetMiningArchiveAsync(count int32) FutureGetMiningArchiveResult {
+	return c.GetMiningArchiveAsyncContext(context.Background(), count)
+}
+
+// GetMiningArchiveAsyncContext is like GetMiningArchiveAsync but the request is
+// abandoned once the passed context is done.
+//
+// See GetMiningArchiveContext for the blocking version.
+func (c *Client) GetMiningArchiveAsyncContext(ctx context.Context, count int32) FutureGetMiningArchiveResult {
+	cmd := exccjson.NewGetMiningArchiveCmd(&count)
+	return c.sendCmdContext(ctx, cmd)
+}
+
+// GetMiningArchive returns up to the passed number of the most recent block
+// templates issued to miners and solutions submitted by them from the mining
+// archive of the server, ordered from the oldest to the newest record.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) GetMiningArchive(count int32) ([]exccjson.MiningArchiveResult, error) {
+	return c.GetMiningArchiveAsync(count).Receive()
+}
+
+// GetMiningArchiveContext is like GetMiningArchive but the request is abandoned
+// with the error of the passed context once it is done, such as when it times
+// out or is canceled.
+func (c *Client) GetMiningArchiveContext(ctx context.Context, count int32) ([]exccjson.MiningArchiveResult, error) {
+	return c.GetMiningArchiveAsyncContext(ctx, count).Receive()
+}
+
+// FutureDNSSeedResult is a future promise to deliver the result of a
+// DNSSeedAsync RPC invocation (or an applicable error).
+type FutureDNSSeedResult chan *response
+
+// Receive waits for the response promised by the future and returns an error if
+// any occurred when performing the specified command.
+func (r FutureDNSSeedResult) Receive() error {
+	_, err := receiveFuture(r)
+	return err
+}
+
+// DNSSeedAsync returns an instance of a type that can be used to get the result
+// of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See DNSSeed for the blocking version and more details.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) DNSSeedAsync(host string, command exccjson.DNSSeedSubCmd, hasFiltering bool) FutureDNSSeedResult {
+	return c.DNSSeedAsyncContext(context.Background(), host, command, hasFiltering)
+}
+
+// DNSSeedAsyncContext is like DNSSeedAsync but the request is abandoned once
+// the passed context is done.
+//
+// See DNSSeedContext for the blocking version.
+func (c *Client) DNSSeedAsyncContext(ctx context.Context, host string, command exccjson.DNSSeedSubCmd, hasFiltering bool) FutureDNSSeedResult {
+	cmd := exccjson.NewDNSSeedCmd(host, command, &hasFiltering)
+	return c.sendCmdContext(ctx, cmd)
+}
+
+// DNSSeed attempts to perform the passed command on the passed DNS seed.  For
+// example, it can be used to add or remove a DNS seed used to discover peers,
+// or to query a DNS seed for peers immediately.  The hasFiltering parameter
+// is only used when adding a seed.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) DNSSeed(host string, command exccjson.DNSSeedSubCmd, hasFiltering bool) error {
+	return c.DNSSeedAsync(host, command, hasFiltering).Receive()
+}
+
+// DNSSeedContext is like DNSSeed but the request is abandoned with the error of
+// the passed context once it is done, such as when it times out or is canceled.
+func (c *Client) DNSSeedContext(ctx context.Context, host string, command exccjson.DNSSeedSubCmd, hasFiltering bool) error {
+	return c.DNSSeedAsyncContext(ctx, host, command, hasFiltering).Receive()
+}
+
+// FutureGetDNSSeedInfoResult is a future promise to deliver the result of a
+// GetDNSSeedInfoAsync RPC invocation (or an applicable error).
+type FutureGetDNSSeedInfoResult chan *response
+
+// Receive waits for the response promised by the future and returns the DNS
+// seeds of the server along with their lookup metrics.
+func (r FutureGetDNSSeedInfoResult) Receive() ([]exccjson.GetDNSSeedInfoResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as an array of getdnsseedinfo result objects.
+	var seeds []exccjson.GetDNSSeedInfoResult
+	err = json.Unmarshal(res, &seeds)
+	if err != nil {
+		return nil, err
+	}
+
+	return seeds, nil
+}
+
+// GetDNSSeedInfoAsync returns an instance of a type that can be used to get
+// the result of the RPC at some future time by invoking the Receive function on
+// the returned instance.
+//
+// See GetDNSSeedInfo for the blocking version and more details.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) GetDNSSeedInfoAsync() FutureGetDNSSeedInfoResult {
+	return c.GetDNSSeedInfoAsyncContext(context.Background())
+}
+
+// GetDNSSeedInfoAsyncContext is like GetDNSSeedInfoAsync but the request is
+// abandoned once the passed context is done.
+//
+// See GetDNSSeedInfoContext for the blocking version.
+func (c *Client) GetDNSSeedInfoAsyncContext(ctx context.Context) FutureGetDNSSeedInfoResult {
+	cmd := exccjson.NewGetDNSSeedInfoCmd()
+	return c.sendCmdContext(ctx, cmd)
+}
+
+// GetDNSSeedInfo returns the DNS seeds the server uses to discover peers along
+// with the number of lookups of each of them and how many succeeded.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) GetDNSSeedInfo() ([]exccjson.GetDNSSeedInfoResult, error) {
+	return c.GetDNSSeedInfoAsync().Receive()
+}
+
+// GetDNSSeedInfoContext is like GetDNSSeedInfo but the request is abandoned
+// with the error of the passed context once it is done, such as when it times
+// out or is canceled.
+func (c *Client) GetDNSSeedInfoContext(ctx context.Context) ([]exccjson.GetDNSSeedInfoResult, error) {
+	return c.GetDNSSeedInfoAsyncContext(ctx).Receive()
+}
+
+// FutureGetSigCacheInfoResult is a future promise to deliver the result of a
+// GetSigCacheInfoAsync RPC invocation (or an applicable error).
+type FutureGetSigCacheInfoResult chan *response
+
+// Receive waits for the response promised by the future and returns the size
+// and lookup statistics of the signature cache of the server.
+func (r FutureGetSigCacheInfoResult) Receive() (*exccjson.GetSigCacheInfoResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a getsigcacheinfo result object.
+	var info exccjson.GetSigCacheInfoResult
+	err = json.Unmarshal(res, &info)
+	if err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+// GetSigCacheInfoAsync returns an instance of a type that can be used to get
+// the result of the RPC at some future time by invoking the Receive function on
+// the returned instance.
+//
+// See GetSigCacheInfo for the blocking version and more details.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) GetSigCacheInfoAsync() FutureGetSigCacheInfoResult {
+	return c.GetSigCacheInfoAsyncContext(context.Background())
+}
+
+// GetSigCacheInfoAsyncContext is like GetSigCacheInfoAsync but the request is
+// abandoned once the passed context is done.
+//
+// See GetSigCacheInfoContext for the blocking version.
+func (c *Client) GetSigCacheInfoAsyncContext(ctx context.Context) FutureGetSigCacheInfoResult {
+	cmd := exccjson.NewGetSigCacheInfoCmd()
+	return c.sendCmdContext(ctx, cmd)
+}
+
+// GetSigCacheInfo returns the size of the signature verification cache of the
+// server along with the number of lookups which did and did not find a
+// signature in it.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) GetSigCacheInfo() (*exccjson.GetSigCacheInfoResult, error) {
+	return c.GetSigCacheInfoAsync().Receive()
+}
+
+// GetSigCacheInfoContext is like GetSigCacheInfo but the request is abandoned
+// with the error of the passed context once it is done, such as when it times
+// out or is canceled.
+func (c *Client) GetSigCacheInfoContext(ctx context.Context) (*exccjson.GetSigCacheInfoResult, error) {
+	return c.GetSigCacheInfoAsyncContext(ctx).Receive()
+}
+
 // FutureGetStakeDifficultyResult is a future promise to deliver the result of a
 // GetStakeDifficultyAsync RPC invocation (or an applicable error).
 type FutureGetStakeDifficultyResult chan *response
@@ -665,8 +1642,16 @@ func (r FutureGetStakeDifficultyResult) Receive() (*exccjson.GetStakeDifficultyR
 //
 // NOTE: This is a exccd extension.
 func (c *Client) GetStakeDifficultyAsync() FutureGetStakeDifficultyResult {
+	return c.GetStakeDifficultyAsyncContext(context.Background())
+}
+
+// GetStakeDifficultyAsyncContext is like GetStakeDifficultyAsync but the
+// request is abandoned once the passed context is done.
+//
+// See GetStakeDifficultyContext for the blocking version.
+func (c *Client) GetStakeDifficultyAsyncContext(ctx context.Context) FutureGetStakeDifficultyResult {
 	cmd := exccjson.NewGetStakeDifficultyCmd()
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetStakeDifficulty returns the current and next stake difficulty.
@@ -676,6 +1661,71 @@ func (c *Client) GetStakeDifficulty() (*exccjson.GetStakeDifficultyResult, error
 	return c.GetStakeDifficultyAsync().Receive()
 }
 
+// GetStakeDifficultyContext is like GetStakeDifficulty but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) GetStakeDifficultyContext(ctx context.Context) (*exccjson.GetStakeDifficultyResult, error) {
+	return c.GetStakeDifficultyAsyncContext(ctx).Receive()
+}
+
+// FutureGetNetworkStakeInfoResult is a future promise to deliver the result of
+// a GetNetworkStakeInfoAsync RPC invocation (or an applicable error).
+type FutureGetNetworkStakeInfoResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// aggregated staking information of the network.
+func (r FutureGetNetworkStakeInfoResult) Receive() (*exccjson.NetworkStakeInfoResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a network stake info result object.
+	var infoRes exccjson.NetworkStakeInfoResult
+	err = json.Unmarshal(res, &infoRes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &infoRes, nil
+}
+
+// GetNetworkStakeInfoAsync returns an instance of a type that can be used to
+// get the result of the RPC at some future time by invoking the Receive
+// function on the returned instance.
+//
+// See GetNetworkStakeInfo for the blocking version and more details.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) GetNetworkStakeInfoAsync() FutureGetNetworkStakeInfoResult {
+	return c.GetNetworkStakeInfoAsyncContext(context.Background())
+}
+
+// GetNetworkStakeInfoAsyncContext is like GetNetworkStakeInfoAsync but the
+// request is abandoned once the passed context is done.
+//
+// See GetNetworkStakeInfoContext for the blocking version.
+func (c *Client) GetNetworkStakeInfoAsyncContext(ctx context.Context) FutureGetNetworkStakeInfoResult {
+	cmd := exccjson.NewGetNetworkStakeInfoCmd()
+	return c.sendCmdContext(ctx, cmd)
+}
+
+// GetNetworkStakeInfo returns aggregated information about the ticket pool,
+// ticket price, and recent voting of the network.  Unlike GetStakeInfo, it must
+// be issued to the node rather than a wallet.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) GetNetworkStakeInfo() (*exccjson.NetworkStakeInfoResult, error) {
+	return c.GetNetworkStakeInfoAsync().Receive()
+}
+
+// GetNetworkStakeInfoContext is like GetNetworkStakeInfo but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) GetNetworkStakeInfoContext(ctx context.Context) (*exccjson.NetworkStakeInfoResult, error) {
+	return c.GetNetworkStakeInfoAsyncContext(ctx).Receive()
+}
+
 // FutureGetStakeVersionsResult is a future promise to deliver the result of a
 // GetStakeVersionsAsync RPC invocation (or an applicable error).
 type FutureGetStakeVersionsResult chan *response
@@ -706,8 +1756,16 @@ func (r FutureGetStakeVersionsResult) Receive() (*exccjson.GetStakeVersionsResul
 //
 // NOTE: This is a exccd extension.
 func (c *Client) GetStakeVersionInfoAsync(count int32) FutureGetStakeVersionInfoResult {
+	return c.GetStakeVersionInfoAsyncContext(context.Background(), count)
+}
+
+// GetStakeVersionInfoAsyncContext is like GetStakeVersionInfoAsync but the
+// request is abandoned once the passed context is done.
+//
+// See GetStakeVersionInfoContext for the blocking version.
+func (c *Client) GetStakeVersionInfoAsyncContext(ctx context.Context, count int32) FutureGetStakeVersionInfoResult {
 	cmd := exccjson.NewGetStakeVersionInfoCmd(count)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetStakeVersionInfo returns the stake versions results for past requested intervals (count).
@@ -717,6 +1775,13 @@ func (c *Client) GetStakeVersionInfo(count int32) (*exccjson.GetStakeVersionInfo
 	return c.GetStakeVersionInfoAsync(count).Receive()
 }
 
+// GetStakeVersionInfoContext is like GetStakeVersionInfo but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) GetStakeVersionInfoContext(ctx context.Context, count int32) (*exccjson.GetStakeVersionInfoResult, error) {
+	return c.GetStakeVersionInfoAsyncContext(ctx, count).Receive()
+}
+
 // FutureGetStakeVersionInfoResult is a future promise to deliver the result of a
 // GetStakeVersionInfoAsync RPC invocation (or an applicable error).
 type FutureGetStakeVersionInfoResult chan *response
@@ -747,8 +1812,16 @@ func (r FutureGetStakeVersionInfoResult) Receive() (*exccjson.GetStakeVersionInf
 //
 // NOTE: This is a exccd extension.
 func (c *Client) GetStakeVersionsAsync(hash string, count int32) FutureGetStakeVersionsResult {
+	return c.GetStakeVersionsAsyncContext(context.Background(), hash, count)
+}
+
+// GetStakeVersionsAsyncContext is like GetStakeVersionsAsync but the request is
+// abandoned once the passed context is done.
+//
+// See GetStakeVersionsContext for the blocking version.
+func (c *Client) GetStakeVersionsAsyncContext(ctx context.Context, hash string, count int32) FutureGetStakeVersionsResult {
 	cmd := exccjson.NewGetStakeVersionsCmd(hash, count)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetStakeVersions returns the stake versions and vote versions of past requested blocks.
@@ -758,6 +1831,248 @@ func (c *Client) GetStakeVersions(hash string, count int32) (*exccjson.GetStakeV
 	return c.GetStakeVersionsAsync(hash, count).Receive()
 }
 
+// GetStakeVersionsContext is like GetStakeVersions but the request is abandoned
+// with the error of the passed context once it is done, such as when it times
+// out or is canceled.
+func (c *Client) GetStakeVersionsContext(ctx context.Context, hash string, count int32) (*exccjson.GetStakeVersionsResult, error) {
+	return c.GetStakeVersionsAsyncContext(ctx, hash, count).Receive()
+}
+
+// FutureGetSyncInfoResult is a future promise to deliver the result of a
+// GetSyncInfoAsync RPC invocation (or an applicable error).
+type FutureGetSyncInfoResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// progress of the chain sync of the server.
+func (r FutureGetSyncInfoResult) Receive() (*exccjson.GetSyncInfoResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a getsyncinfo result object.
+	var info exccjson.GetSyncInfoResult
+	err = json.Unmarshal(res, &info)
+	if err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+// GetSyncInfoAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See GetSyncInfo for the blocking version and more details.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) GetSyncInfoAsync() FutureGetSyncInfoResult {
+	return c.GetSyncInfoAsyncContext(context.Background())
+}
+
+// GetSyncInfoAsyncContext is like GetSyncInfoAsync but the request is abandoned
+// once the passed context is done.
+//
+// See GetSyncInfoContext for the blocking version.
+func (c *Client) GetSyncInfoAsyncContext(ctx context.Context) FutureGetSyncInfoResult {
+	cmd := exccjson.NewGetSyncInfoCmd()
+	return c.sendCmdContext(ctx, cmd)
+}
+
+// GetSyncInfo returns the progress of the chain sync of the server, namely the
+// height of its best chain compared to the best known header, the download
+// rate, and the estimated time until the sync completes.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) GetSyncInfo() (*exccjson.GetSyncInfoResult, error) {
+	return c.GetSyncInfoAsync().Receive()
+}
+
+// GetSyncInfoContext is like GetSyncInfo but the request is abandoned with the
+// error of the passed context once it is done, such as when it times out or is
+// canceled.
+func (c *Client) GetSyncInfoContext(ctx context.Context) (*exccjson.GetSyncInfoResult, error) {
+	return c.GetSyncInfoAsyncContext(ctx).Receive()
+}
+
+// FutureGetTicketInfoResult is a future promise to deliver the result of a
+// GetTicketInfoAsync RPC invocation (or an applicable error).
+type FutureGetTicketInfoResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// lifecycle of the ticket.
+func (r FutureGetTicketInfoResult) Receive() (*exccjson.TicketInfoResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a ticket info result object.
+	var ticket exccjson.TicketInfoResult
+	err = json.Unmarshal(res, &ticket)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ticket, nil
+}
+
+// GetTicketInfoAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See GetTicketInfo for the blocking version and more details.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) GetTicketInfoAsync(hash *chainhash.Hash) FutureGetTicketInfoResult {
+	return c.GetTicketInfoAsyncContext(context.Background(), hash)
+}
+
+// GetTicketInfoAsyncContext is like GetTicketInfoAsync but the request is
+// abandoned once the passed context is done.
+//
+// See GetTicketInfoContext for the blocking version.
+func (c *Client) GetTicketInfoAsyncContext(ctx context.Context, hash *chainhash.Hash) FutureGetTicketInfoResult {
+	cmd := exccjson.NewGetTicketInfoCmd(hash.String())
+	return c.sendCmdContext(ctx, cmd)
+}
+
+// GetTicketInfo returns the lifecycle of the passed ticket.  The server must be
+// running with the ticket lifecycle index enabled.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) GetTicketInfo(hash *chainhash.Hash) (*exccjson.TicketInfoResult, error) {
+	return c.GetTicketInfoAsync(hash).Receive()
+}
+
+// GetTicketInfoContext is like GetTicketInfo but the request is abandoned with
+// the error of the passed context once it is done, such as when it times out or
+// is canceled.
+func (c *Client) GetTicketInfoContext(ctx context.Context, hash *chainhash.Hash) (*exccjson.TicketInfoResult, error) {
+	return c.GetTicketInfoAsyncContext(ctx, hash).Receive()
+}
+
+// FutureGetTicketsInfoResult is a future promise to deliver the result of a
+// GetTicketsInfoAsync RPC invocation (or an applicable error).
+type FutureGetTicketsInfoResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// lifecycles of the requested tickets.
+func (r FutureGetTicketsInfoResult) Receive() ([]exccjson.TicketInfoResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as an array of ticket info results.
+	var tickets []exccjson.TicketInfoResult
+	err = json.Unmarshal(res, &tickets)
+	if err != nil {
+		return nil, err
+	}
+
+	return tickets, nil
+}
+
+// GetTicketsInfoAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on
+// the returned instance.
+//
+// See GetTicketsInfo for the blocking version and more details.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) GetTicketsInfoAsync(tickets []*chainhash.Hash) FutureGetTicketsInfoResult {
+	return c.GetTicketsInfoAsyncContext(context.Background(), tickets)
+}
+
+// GetTicketsInfoAsyncContext is like GetTicketsInfoAsync but the request is
+// abandoned once the passed context is done.
+//
+// See GetTicketsInfoContext for the blocking version.
+func (c *Client) GetTicketsInfoAsyncContext(ctx context.Context, tickets []*chainhash.Hash) FutureGetTicketsInfoResult {
+	hashes := make([]string, 0, len(tickets))
+	for _, ticket := range tickets {
+		hashes = append(hashes, ticket.String())
+	}
+	cmd := exccjson.NewGetTicketsInfoCmd(hashes)
+	return c.sendCmdContext(ctx, cmd)
+}
+
+// GetTicketsInfo returns the lifecycles of the passed tickets in the same
+// order.  Tickets which are not known to the server have an unknown status.
+// The server must be running with the ticket lifecycle index enabled.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) GetTicketsInfo(tickets []*chainhash.Hash) ([]exccjson.TicketInfoResult, error) {
+	return c.GetTicketsInfoAsync(tickets).Receive()
+}
+
+// GetTicketsInfoContext is like GetTicketsInfo but the request is abandoned
+// with the error of the passed context once it is done, such as when it times
+// out or is canceled.
+func (c *Client) GetTicketsInfoContext(ctx context.Context, tickets []*chainhash.Hash) ([]exccjson.TicketInfoResult, error) {
+	return c.GetTicketsInfoAsyncContext(ctx, tickets).Receive()
+}
+
+// FutureGetTicketPoolStatsResult is a future promise to deliver the result of a
+// GetTicketPoolStatsAsync RPC invocation (or an applicable error).
+type FutureGetTicketPoolStatsResult chan *response
+
+// Receive waits for the response promised by the future and returns the ticket
+// pool statistics for the requested blocks.
+func (r FutureGetTicketPoolStatsResult) Receive() ([]exccjson.TicketPoolStatsResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a getticketpoolstats result object.
+	var stats []exccjson.TicketPoolStatsResult
+	err = json.Unmarshal(res, &stats)
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// GetTicketPoolStatsAsync returns an instance of a type that can be used to
+// get the result of the RPC at some future time by invoking the Receive
+// function on the returned instance.
+//
+// See GetTicketPoolStats for the blocking version and more details.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) GetTicketPoolStatsAsync(blocks, interval int64) FutureGetTicketPoolStatsResult {
+	return c.GetTicketPoolStatsAsyncContext(context.Background(), blocks, interval)
+}
+
+// GetTicketPoolStatsAsyncContext is like GetTicketPoolStatsAsync but the
+// request is abandoned once the passed context is done.
+//
+// See GetTicketPoolStatsContext for the blocking version.
+func (c *Client) GetTicketPoolStatsAsyncContext(ctx context.Context, blocks, interval int64) FutureGetTicketPoolStatsResult {
+	cmd := exccjson.NewGetTicketPoolStatsCmd(&blocks, &interval)
+	return c.sendCmdContext(ctx, cmd)
+}
+
+// GetTicketPoolStats returns the size and value of the ticket pool, the average
+// ticket price, and the percentage of the coin supply locked in tickets for the
+// provided number of most recent blocks, sampled every interval blocks.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) GetTicketPoolStats(blocks, interval int64) ([]exccjson.TicketPoolStatsResult, error) {
+	return c.GetTicketPoolStatsAsync(blocks, interval).Receive()
+}
+
+// GetTicketPoolStatsContext is like GetTicketPoolStats but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) GetTicketPoolStatsContext(ctx context.Context, blocks, interval int64) ([]exccjson.TicketPoolStatsResult, error) {
+	return c.GetTicketPoolStatsAsyncContext(ctx, blocks, interval).Receive()
+}
+
 // FutureGetTicketPoolValueResult is a future promise to deliver the result of a
 // GetTicketPoolValueAsync RPC invocation (or an applicable error).
 type FutureGetTicketPoolValueResult chan *response
@@ -794,8 +2109,16 @@ func (r FutureGetTicketPoolValueResult) Receive() (exccutil.Amount, error) {
 //
 // NOTE: This is a exccd extension.
 func (c *Client) GetTicketPoolValueAsync() FutureGetTicketPoolValueResult {
+	return c.GetTicketPoolValueAsyncContext(context.Background())
+}
+
+// GetTicketPoolValueAsyncContext is like GetTicketPoolValueAsync but the
+// request is abandoned once the passed context is done.
+//
+// See GetTicketPoolValueContext for the blocking version.
+func (c *Client) GetTicketPoolValueAsyncContext(ctx context.Context) FutureGetTicketPoolValueResult {
 	cmd := exccjson.NewGetTicketPoolValueCmd()
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetTicketPoolValue returns the value of the live ticket pool.
@@ -805,6 +2128,13 @@ func (c *Client) GetTicketPoolValue() (exccutil.Amount, error) {
 	return c.GetTicketPoolValueAsync().Receive()
 }
 
+// GetTicketPoolValueContext is like GetTicketPoolValue but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) GetTicketPoolValueContext(ctx context.Context) (exccutil.Amount, error) {
+	return c.GetTicketPoolValueAsyncContext(ctx).Receive()
+}
+
 // FutureGetVoteInfoResult is a future promise to deliver the result of a
 // GetVoteInfoAsync RPC invocation (or an applicable error).
 type FutureGetVoteInfoResult chan *response
@@ -835,8 +2165,16 @@ func (r FutureGetVoteInfoResult) Receive() (*exccjson.GetVoteInfoResult, error)
 //
 // NOTE: This is a exccd extension.
 func (c *Client) GetVoteInfoAsync(version uint32) FutureGetVoteInfoResult {
+	return c.GetVoteInfoAsyncContext(context.Background(), version)
+}
+
+// GetVoteInfoAsyncContext is like GetVoteInfoAsync but the request is abandoned
+// once the passed context is done.
+//
+// See GetVoteInfoContext for the blocking version.
+func (c *Client) GetVoteInfoAsyncContext(ctx context.Context, version uint32) FutureGetVoteInfoResult {
 	cmd := exccjson.NewGetVoteInfoCmd(version)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetVoteInfo returns voting information for the specified stake version. This
@@ -847,6 +2185,13 @@ func (c *Client) GetVoteInfo(version uint32) (*exccjson.GetVoteInfoResult, error
 	return c.GetVoteInfoAsync(version).Receive()
 }
 
+// GetVoteInfoContext is like GetVoteInfo but the request is abandoned with the
+// error of the passed context once it is done, such as when it times out or is
+// canceled.
+func (c *Client) GetVoteInfoContext(ctx context.Context, version uint32) (*exccjson.GetVoteInfoResult, error) {
+	return c.GetVoteInfoAsyncContext(ctx, version).Receive()
+}
+
 // FutureListAddressTransactionsResult is a future promise to deliver the result
 // of a ListAddressTransactionsAsync RPC invocation (or an applicable error).
 type FutureListAddressTransactionsResult chan *response
@@ -876,13 +2221,21 @@ func (r FutureListAddressTransactionsResult) Receive() ([]exccjson.ListTransacti
 //
 // NOTE: This is a exccd extension.
 func (c *Client) ListAddressTransactionsAsync(addresses []exccutil.Address, account string) FutureListAddressTransactionsResult {
+	return c.ListAddressTransactionsAsyncContext(context.Background(), addresses, account)
+}
+
+// ListAddressTransactionsAsyncContext is like ListAddressTransactionsAsync but
+// the request is abandoned once the passed context is done.
+//
+// See ListAddressTransactionsContext for the blocking version.
+func (c *Client) ListAddressTransactionsAsyncContext(ctx context.Context, addresses []exccutil.Address, account string) FutureListAddressTransactionsResult {
 	// Convert addresses to strings.
 	addrs := make([]string, 0, len(addresses))
 	for _, addr := range addresses {
 		addrs = append(addrs, addr.EncodeAddress())
 	}
 	cmd := exccjson.NewListAddressTransactionsCmd(addrs, &account)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // ListAddressTransactions returns information about all transactions associated
@@ -893,6 +2246,13 @@ func (c *Client) ListAddressTransactions(addresses []exccutil.Address, account s
 	return c.ListAddressTransactionsAsync(addresses, account).Receive()
 }
 
+// ListAddressTransactionsContext is like ListAddressTransactions but the
+// request is abandoned with the error of the passed context once it is done,
+// such as when it times out or is canceled.
+func (c *Client) ListAddressTransactionsContext(ctx context.Context, addresses []exccutil.Address, account string) ([]exccjson.ListTransactionsResult, error) {
+	return c.ListAddressTransactionsAsyncContext(ctx, addresses, account).Receive()
+}
+
 // FutureLiveTicketsResult is a future promise to deliver the result
 // of a FutureLiveTicketsResultAsync RPC invocation (or an applicable error).
 type FutureLiveTicketsResult chan *response
@@ -928,8 +2288,16 @@ func (r FutureLiveTicketsResult) Receive() ([]*chainhash.Hash, error) {
 // result of the RPC at some future time by invoking the Receive function on the
 // returned instance.
 func (c *Client) LiveTicketsAsync() FutureLiveTicketsResult {
+	return c.LiveTicketsAsyncContext(context.Background())
+}
+
+// LiveTicketsAsyncContext is like LiveTicketsAsync but the request is abandoned
+// once the passed context is done.
+//
+// See LiveTicketsContext for the blocking version.
+func (c *Client) LiveTicketsAsyncContext(ctx context.Context) FutureLiveTicketsResult {
 	cmd := exccjson.NewLiveTicketsCmd()
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // LiveTickets returns all currently missed tickets from the missed
@@ -940,6 +2308,13 @@ func (c *Client) LiveTickets() ([]*chainhash.Hash, error) {
 	return c.LiveTicketsAsync().Receive()
 }
 
+// LiveTicketsContext is like LiveTickets but the request is abandoned with the
+// error of the passed context once it is done, such as when it times out or is
+// canceled.
+func (c *Client) LiveTicketsContext(ctx context.Context) ([]*chainhash.Hash, error) {
+	return c.LiveTicketsAsyncContext(ctx).Receive()
+}
+
 // FutureMissedTicketsResult is a future promise to deliver the result
 // of a FutureMissedTicketsResultAsync RPC invocation (or an applicable error).
 type FutureMissedTicketsResult chan *response
@@ -975,8 +2350,16 @@ func (r FutureMissedTicketsResult) Receive() ([]*chainhash.Hash, error) {
 // result of the RPC at some future time by invoking the Receive function on the
 // returned instance.
 func (c *Client) MissedTicketsAsync() FutureMissedTicketsResult {
+	return c.MissedTicketsAsyncContext(context.Background())
+}
+
+// MissedTicketsAsyncContext is like MissedTicketsAsync but the request is
+// abandoned once the passed context is done.
+//
+// See MissedTicketsContext for the blocking version.
+func (c *Client) MissedTicketsAsyncContext(ctx context.Context) FutureMissedTicketsResult {
 	cmd := exccjson.NewMissedTicketsCmd()
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // MissedTickets returns all currently missed tickets from the missed
@@ -987,6 +2370,13 @@ func (c *Client) MissedTickets() ([]*chainhash.Hash, error) {
 	return c.MissedTicketsAsync().Receive()
 }
 
+// MissedTicketsContext is like MissedTickets but the request is abandoned with
+// the error of the passed context once it is done, such as when it times out or
+// is canceled.
+func (c *Client) MissedTicketsContext(ctx context.Context) ([]*chainhash.Hash, error) {
+	return c.MissedTicketsAsyncContext(ctx).Receive()
+}
+
 // FutureSessionResult is a future promise to deliver the result of a
 // SessionAsync RPC invocation (or an applicable error).
 type FutureSessionResult chan *response
@@ -1017,13 +2407,21 @@ func (r FutureSessionResult) Receive() (*exccjson.SessionResult, error) {
 //
 // NOTE: This is a ExchangeCoin extension.
 func (c *Client) SessionAsync() FutureSessionResult {
+	return c.SessionAsyncContext(context.Background())
+}
+
+// SessionAsyncContext is like SessionAsync but the request is abandoned once
+// the passed context is done.
+//
+// See SessionContext for the blocking version.
+func (c *Client) SessionAsyncContext(ctx context.Context) FutureSessionResult {
 	// Not supported in HTTP POST mode.
 	if c.config.HTTPPostMode {
 		return newFutureError(ErrWebsocketsRequired)
 	}
 
 	cmd := exccjson.NewSessionCmd()
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // Session returns details regarding a websocket client's current connection.
@@ -1035,6 +2433,12 @@ func (c *Client) Session() (*exccjson.SessionResult, error) {
 	return c.SessionAsync().Receive()
 }
 
+// SessionContext is like Session but the request is abandoned with the error of
+// the passed context once it is done, such as when it times out or is canceled.
+func (c *Client) SessionContext(ctx context.Context) (*exccjson.SessionResult, error) {
+	return c.SessionAsyncContext(ctx).Receive()
+}
+
 // FutureTicketFeeInfoResult is a future promise to deliver the result of a
 // TicketFeeInfoAsync RPC invocation (or an applicable error).
 type FutureTicketFeeInfoResult chan *response
@@ -1065,6 +2469,14 @@ func (r FutureTicketFeeInfoResult) Receive() (*exccjson.TicketFeeInfoResult, err
 //
 // NOTE: This is a ExchangeCoin extension.
 func (c *Client) TicketFeeInfoAsync(blocks *uint32, windows *uint32) FutureTicketFeeInfoResult {
+	return c.TicketFeeInfoAsyncContext(context.Background(), blocks, windows)
+}
+
+// TicketFeeInfoAsyncContext is like TicketFeeInfoAsync but the request is
+// abandoned once the passed context is done.
+//
+// See TicketFeeInfoContext for the blocking version.
+func (c *Client) TicketFeeInfoAsyncContext(ctx context.Context, blocks *uint32, windows *uint32) FutureTicketFeeInfoResult {
 	// Not supported in HTTP POST mode.
 	if c.config.HTTPPostMode {
 		return newFutureError(ErrWebsocketsRequired)
@@ -1080,7 +2492,7 @@ func (c *Client) TicketFeeInfoAsync(blocks *uint32, windows *uint32) FutureTicke
 	}
 
 	cmd := exccjson.NewTicketFeeInfoCmd(blocks, windows)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // TicketFeeInfo returns information about ticket fees.
@@ -1092,6 +2504,13 @@ func (c *Client) TicketFeeInfo(blocks *uint32, windows *uint32) (*exccjson.Ticke
 	return c.TicketFeeInfoAsync(blocks, windows).Receive()
 }
 
+// TicketFeeInfoContext is like TicketFeeInfo but the request is abandoned with
+// the error of the passed context once it is done, such as when it times out or
+// is canceled.
+func (c *Client) TicketFeeInfoContext(ctx context.Context, blocks *uint32, windows *uint32) (*exccjson.TicketFeeInfoResult, error) {
+	return c.TicketFeeInfoAsyncContext(ctx, blocks, windows).Receive()
+}
+
 // FutureTicketVWAPResult is a future promise to deliver the result of a
 // TicketVWAPAsync RPC invocation (or an applicable error).
 type FutureTicketVWAPResult chan *response
@@ -1127,13 +2546,21 @@ func (r FutureTicketVWAPResult) Receive() (exccutil.Amount, error) {
 //
 // NOTE: This is a ExchangeCoin extension.
 func (c *Client) TicketVWAPAsync(start *uint32, end *uint32) FutureTicketVWAPResult {
+	return c.TicketVWAPAsyncContext(context.Background(), start, end)
+}
+
+// TicketVWAPAsyncContext is like TicketVWAPAsync but the request is abandoned
+// once the passed context is done.
+//
+// See TicketVWAPContext for the blocking version.
+func (c *Client) TicketVWAPAsyncContext(ctx context.Context, start *uint32, end *uint32) FutureTicketVWAPResult {
 	// Not supported in HTTP POST mode.
 	if c.config.HTTPPostMode {
 		return newFutureError(ErrWebsocketsRequired)
 	}
 
 	cmd := exccjson.NewTicketVWAPCmd(start, end)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // TicketVWAP returns the vwap weighted average price of tickets.
@@ -1145,6 +2572,13 @@ func (c *Client) TicketVWAP(start *uint32, end *uint32) (exccutil.Amount, error)
 	return c.TicketVWAPAsync(start, end).Receive()
 }
 
+// TicketVWAPContext is like TicketVWAP but the request is abandoned with the
+// error of the passed context once it is done, such as when it times out or is
+// canceled.
+func (c *Client) TicketVWAPContext(ctx context.Context, start *uint32, end *uint32) (exccutil.Amount, error) {
+	return c.TicketVWAPAsyncContext(ctx, start, end).Receive()
+}
+
 // FutureTxFeeInfoResult is a future promise to deliver the result of a
 // TxFeeInfoAsync RPC invocation (or an applicable error).
 type FutureTxFeeInfoResult chan *response
@@ -1175,13 +2609,21 @@ func (r FutureTxFeeInfoResult) Receive() (*exccjson.TxFeeInfoResult, error) {
 //
 // NOTE: This is a ExchangeCoin extension.
 func (c *Client) TxFeeInfoAsync(blocks *uint32, start *uint32, end *uint32) FutureTxFeeInfoResult {
+	return c.TxFeeInfoAsyncContext(context.Background(), blocks, start, end)
+}
+
+// TxFeeInfoAsyncContext is like TxFeeInfoAsync but the request is abandoned
+// once the passed context is done.
+//
+// See TxFeeInfoContext for the blocking version.
+func (c *Client) TxFeeInfoAsyncContext(ctx context.Context, blocks *uint32, start *uint32, end *uint32) FutureTxFeeInfoResult {
 	// Not supported in HTTP POST mode.
 	if c.config.HTTPPostMode {
 		return newFutureError(ErrWebsocketsRequired)
 	}
 
 	cmd := exccjson.NewTxFeeInfoCmd(blocks, start, end)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // TxFeeInfo returns information about tx fees.
@@ -1193,6 +2635,13 @@ func (c *Client) TxFeeInfo(blocks *uint32, start *uint32, end *uint32) (*exccjso
 	return c.TxFeeInfoAsync(blocks, start, end).Receive()
 }
 
+// TxFeeInfoContext is like TxFeeInfo but the request is abandoned with the
+// error of the passed context once it is done, such as when it times out or is
+// canceled.
+func (c *Client) TxFeeInfoContext(ctx context.Context, blocks *uint32, start *uint32, end *uint32) (*exccjson.TxFeeInfoResult, error) {
+	return c.TxFeeInfoAsyncContext(ctx, blocks, start, end).Receive()
+}
+
 // FutureVersionResult is a future promise to deliver the result of a version
 // RPC invocation (or an applicable error).
 type FutureVersionResult chan *response
@@ -1220,11 +2669,500 @@ func (r FutureVersionResult) Receive() (map[string]exccjson.VersionResult, error
 //
 // See Version for the blocking version and more details.
 func (c *Client) VersionAsync() FutureVersionResult {
+	return c.VersionAsyncContext(context.Background())
+}
+
+// VersionAsyncContext is like VersionAsync but the request is abandoned once
+// the passed context is done.
+//
+// See VersionContext for the blocking version.
+func (c *Client) VersionAsyncContext(ctx context.Context) FutureVersionResult {
 	cmd := exccjson.NewVersionCmd()
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // Version returns information about the server's JSON-RPC API versions.
 func (c *Client) Version() (map[string]exccjson.VersionResult, error) {
 	return c.VersionAsync().Receive()
 }
+
+// VersionContext is like Version but the request is abandoned with the error of
+// the passed context once it is done, such as when it times out or is canceled.
+func (c *Client) VersionContext(ctx context.Context) (map[string]exccjson.VersionResult, error) {
+	return c.VersionAsyncContext(ctx).Receive()
+}
+
+// FutureSignMessageWithPrivKeyResult is a future promise to deliver the result
+// of a SignMessageWithPrivKeyAsync RPC invocation (or an applicable error).
+type FutureSignMessageWithPrivKeyResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// base64-encoded signature of the message.
+func (r FutureSignMessageWithPrivKeyResult) Receive() (string, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return "", err
+	}
+
+	// Unmarshal result as a string.
+	var b64 string
+	err = json.Unmarshal(res, &b64)
+	if err != nil {
+		return "", err
+	}
+
+	return b64, nil
+}
+
+// SignMessageWithPrivKeyAsync returns an instance of a type that can be used to
+// get the result of the RPC at some future time by invoking the Receive
+// function on the returned instance.
+//
+// See SignMessageWithPrivKey for the blocking version and more details.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) SignMessageWithPrivKeyAsync(privKey *exccutil.WIF, message string) FutureSignMessageWithPrivKeyResult {
+	return c.SignMessageWithPrivKeyAsyncContext(context.Background(), privKey, message)
+}
+
+// SignMessageWithPrivKeyAsyncContext is like SignMessageWithPrivKeyAsync but
+// the request is abandoned once the passed context is done.
+//
+// See SignMessageWithPrivKeyContext for the blocking version.
+func (c *Client) SignMessageWithPrivKeyAsyncContext(ctx context.Context, privKey *exccutil.WIF, message string) FutureSignMessageWithPrivKeyResult {
+	cmd := exccjson.NewSignMessageWithPrivKeyCmd(privKey.String(), message)
+	return c.sendCmdContext(ctx, cmd)
+}
+
+// SignMessageWithPrivKey signs a message with the passed private key without
+// the server storing it.  The returned signature may be checked with
+// VerifyMessage.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) SignMessageWithPrivKey(privKey *exccutil.WIF, message string) (string, error) {
+	return c.SignMessageWithPrivKeyAsync(privKey, message).Receive()
+}
+
+// SignMessageWithPrivKeyContext is like SignMessageWithPrivKey but the request
+// is abandoned with the error of the passed context once it is done, such as
+// when it times out or is canceled.
+func (c *Client) SignMessageWithPrivKeyContext(ctx context.Context, privKey *exccutil.WIF, message string) (string, error) {
+	return c.SignMessageWithPrivKeyAsyncContext(ctx, privKey, message).Receive()
+}
+
+// FutureDumpTxOutSetResult is a future promise to deliver the result of a
+// DumpTxOutSetAsync RPC invocation (or an applicable error).
+type FutureDumpTxOutSetResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// details of the written dump of the utxo set.
+func (r FutureDumpTxOutSetResult) Receive() (*exccjson.DumpTxOutSetResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a dumptxoutset result object.
+	var result exccjson.DumpTxOutSetResult
+	err = json.Unmarshal(res, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// DumpTxOutSetAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See DumpTxOutSet for the blocking version and more details.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) DumpTxOutSetAsync(path string) FutureDumpTxOutSetResult {
+	return c.DumpTxOutSetAsyncContext(context.Background(), path)
+}
+
+// DumpTxOutSetAsyncContext is like DumpTxOutSetAsync but the request is
+// abandoned once the passed context is done.
+//
+// See DumpTxOutSetContext for the blocking version.
+func (c *Client) DumpTxOutSetAsyncContext(ctx context.Context, path string) FutureDumpTxOutSetResult {
+	cmd := exccjson.NewDumpTxOutSetCmd(path)
+	return c.sendCmdContext(ctx, cmd)
+}
+
+// DumpTxOutSet requests the server to write its utxo set to the file at the
+// passed path, which is relative to the data directory of the server unless
+// it is absolute.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) DumpTxOutSet(path string) (*exccjson.DumpTxOutSetResult, error) {
+	return c.DumpTxOutSetAsync(path).Receive()
+}
+
+// DumpTxOutSetContext is like DumpTxOutSet but the request is abandoned with
+// the error of the passed context once it is done, such as when it times out or
+// is canceled.
+func (c *Client) DumpTxOutSetContext(ctx context.Context, path string) (*exccjson.DumpTxOutSetResult, error) {
+	return c.DumpTxOutSetAsyncContext(ctx, path).Receive()
+}
+
+// FutureFundRawTransactionResult is a future promise to deliver the result of a
+// FundRawTransactionAsync RPC invocation (or an applicable error).
+type FutureFundRawTransactionResult chan *response
+
+// Receive waits for the response promised by the future and returns the funded
+// transaction along with its fee and the index of its change output.
+func (r FutureFundRawTransactionResult) Receive() (*exccjson.FundRawTransactionResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a fundrawtransaction result object.
+	var result exccjson.FundRawTransactionResult
+	err = json.Unmarshal(res, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// FundRawTransactionAsync returns an instance of a type that can be used to get
+// the result of the RPC at some future time by invoking the Receive function on
+// the returned instance.
+//
+// See FundRawTransaction for the blocking version and more details.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) FundRawTransactionAsync(tx *wire.MsgTx, addresses []exccutil.Address, options *exccjson.FundRawTransactionOptions) FutureFundRawTransactionResult {
+	return c.FundRawTransactionAsyncContext(context.Background(), tx, addresses, options)
+}
+
+// FundRawTransactionAsyncContext is like FundRawTransactionAsync but the
+// request is abandoned once the passed context is done.
+//
+// See FundRawTransactionContext for the blocking version.
+func (c *Client) FundRawTransactionAsyncContext(ctx context.Context, tx *wire.MsgTx, addresses []exccutil.Address, options *exccjson.FundRawTransactionOptions) FutureFundRawTransactionResult {
+	txHex := ""
+	if tx != nil {
+		// Serialize the transaction and convert to hex string.
+		buf := bytes.NewBuffer(make([]byte, 0, tx.SerializeSize()))
+		if err := tx.Serialize(buf); err != nil {
+			return newFutureError(err)
+		}
+		txHex = hex.EncodeToString(buf.Bytes())
+	}
+
+	addrs := make([]string, 0, len(addresses))
+	for _, addr := range addresses {
+		addrs = append(addrs, addr.EncodeAddress())
+	}
+	cmd := exccjson.NewFundRawTransactionCmd(txHex, addrs, options)
+	return c.sendCmdContext(ctx, cmd)
+}
+
+// FundRawTransaction adds inputs spending the confirmed unspent outputs of the
+// passed addresses, along with a change output, to the passed transaction
+// until its outputs and fee are covered.  The returned transaction is not
+// signed.
+//
+// This function requires the server to have the address index enabled.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) FundRawTransaction(tx *wire.MsgTx, addresses []exccutil.Address, options *exccjson.FundRawTransactionOptions) (*exccjson.FundRawTransactionResult, error) {
+	return c.FundRawTransactionAsync(tx, addresses, options).Receive()
+}
+
+// FundRawTransactionContext is like FundRawTransaction but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) FundRawTransactionContext(ctx context.Context, tx *wire.MsgTx, addresses []exccutil.Address, options *exccjson.FundRawTransactionOptions) (*exccjson.FundRawTransactionResult, error) {
+	return c.FundRawTransactionAsyncContext(ctx, tx, addresses, options).Receive()
+}
+
+// FutureRegenerateCertResult is a future promise to deliver the result of a
+// RegenerateCertAsync RPC invocation (or an applicable error).
+type FutureRegenerateCertResult chan *response
+
+// Receive waits for the response promised by the future and returns the new
+// PEM-encoded certificate of the server.
+func (r FutureRegenerateCertResult) Receive() ([]byte, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a string.
+	var cert string
+	err = json.Unmarshal(res, &cert)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(cert), nil
+}
+
+// RegenerateCertAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See RegenerateCert for the blocking version and more details.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) RegenerateCertAsync() FutureRegenerateCertResult {
+	return c.RegenerateCertAsyncContext(context.Background())
+}
+
+// RegenerateCertAsyncContext is like RegenerateCertAsync but the request is
+// abandoned once the passed context is done.
+//
+// See RegenerateCertContext for the blocking version.
+func (c *Client) RegenerateCertAsyncContext(ctx context.Context) FutureRegenerateCertResult {
+	cmd := exccjson.NewRegenerateCertCmd()
+	return c.sendCmdContext(ctx, cmd)
+}
+
+// RegenerateCert requests the server to generate a new certificate for its RPC
+// server and present it to clients which connect afterwards.  It returns the new
+// PEM-encoded certificate, which the client must be configured with to connect
+// again.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) RegenerateCert() ([]byte, error) {
+	return c.RegenerateCertAsync().Receive()
+}
+
+// RegenerateCertContext is like RegenerateCert but the request is abandoned
+// with the error of the passed context once it is done, such as when it times
+// out or is canceled.
+func (c *Client) RegenerateCertContext(ctx context.Context) ([]byte, error) {
+	return c.RegenerateCertAsyncContext(ctx).Receive()
+}
+
+// FutureReloadConfigResult is a future promise to deliver the result of a
+// ReloadConfigAsync RPC invocation (or an applicable error).
+type FutureReloadConfigResult chan *response
+
+// Receive waits for the response promised by the future and returns the names
+// of the options which changed.
+func (r FutureReloadConfigResult) Receive() ([]string, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as an array of strings.
+	var changed []string
+	err = json.Unmarshal(res, &changed)
+	if err != nil {
+		return nil, err
+	}
+
+	return changed, nil
+}
+
+// ReloadConfigAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See ReloadConfig for the blocking version and more details.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) ReloadConfigAsync() FutureReloadConfigResult {
+	return c.ReloadConfigAsyncContext(context.Background())
+}
+
+// ReloadConfigAsyncContext is like ReloadConfigAsync but the request is
+// abandoned once the passed context is done.
+//
+// See ReloadConfigContext for the blocking version.
+func (c *Client) ReloadConfigAsyncContext(ctx context.Context) FutureReloadConfigResult {
+	cmd := exccjson.NewReloadConfigCmd()
+	return c.sendCmdContext(ctx, cmd)
+}
+
+// ReloadConfig requests the server to reload its config file and apply the
+// options which may be changed without restarting it.  It returns the names of
+// the options which changed.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) ReloadConfig() ([]string, error) {
+	return c.ReloadConfigAsync().Receive()
+}
+
+// ReloadConfigContext is like ReloadConfig but the request is abandoned with
+// the error of the passed context once it is done, such as when it times out or
+// is canceled.
+func (c *Client) ReloadConfigContext(ctx context.Context) ([]string, error) {
+	return c.ReloadConfigAsyncContext(ctx).Receive()
+}
+
+// FutureRotateLogsResult is a future promise to deliver the result of a
+// RotateLogsAsync RPC invocation (or an applicable error).
+type FutureRotateLogsResult chan *response
+
+// Receive waits for the response promised by the future and returns the paths
+// of the rolled log files.
+func (r FutureRotateLogsResult) Receive() ([]string, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as an array of strings.
+	var rolled []string
+	err = json.Unmarshal(res, &rolled)
+	if err != nil {
+		return nil, err
+	}
+
+	return rolled, nil
+}
+
+// RotateLogsAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See RotateLogs for the blocking version and more details.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) RotateLogsAsync() FutureRotateLogsResult {
+	return c.RotateLogsAsyncContext(context.Background())
+}
+
+// RotateLogsAsyncContext is like RotateLogsAsync but the request is abandoned
+// once the passed context is done.
+//
+// See RotateLogsContext for the blocking version.
+func (c *Client) RotateLogsAsyncContext(ctx context.Context) FutureRotateLogsResult {
+	cmd := exccjson.NewRotateLogsCmd()
+	return c.sendCmdContext(ctx, cmd)
+}
+
+// RotateLogs requests the server to flush its log files to disk and roll them so
+// logging continues in new files.  It returns the paths of the rolled files.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) RotateLogs() ([]string, error) {
+	return c.RotateLogsAsync().Receive()
+}
+
+// RotateLogsContext is like RotateLogs but the request is abandoned with the
+// error of the passed context once it is done, such as when it times out or is
+// canceled.
+func (c *Client) RotateLogsContext(ctx context.Context) ([]string, error) {
+	return c.RotateLogsAsyncContext(ctx).Receive()
+}
+
+// FutureSetMockTimeResult is a future promise to deliver the result of a
+// SetMockTimeAsync RPC invocation (or an applicable error).
+type FutureSetMockTimeResult chan *response
+
+// Receive waits for the response promised by the future and returns an error if
+// any occurred when setting the mock time.
+func (r FutureSetMockTimeResult) Receive() error {
+	_, err := receiveFuture(r)
+	return err
+}
+
+// SetMockTimeAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See SetMockTime for the blocking version and more details.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) SetMockTimeAsync(t time.Time) FutureSetMockTimeResult {
+	return c.SetMockTimeAsyncContext(context.Background(), t)
+}
+
+// SetMockTimeAsyncContext is like SetMockTimeAsync but the request is abandoned
+// once the passed context is done.
+//
+// See SetMockTimeContext for the blocking version.
+func (c *Client) SetMockTimeAsyncContext(ctx context.Context, t time.Time) FutureSetMockTimeResult {
+	var unixTime int64
+	if !t.IsZero() {
+		unixTime = t.Unix()
+	}
+	cmd := exccjson.NewSetMockTimeCmd(unixTime)
+	return c.sendCmdContext(ctx, cmd)
+}
+
+// SetMockTime sets the current time of a simnet or regnet server so simulations
+// can fast-forward it deterministically.  The zero time makes the server go
+// back to using its local clock.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) SetMockTime(t time.Time) error {
+	return c.SetMockTimeAsync(t).Receive()
+}
+
+// SetMockTimeContext is like SetMockTime but the request is abandoned with the
+// error of the passed context once it is done, such as when it times out or is
+// canceled.
+func (c *Client) SetMockTimeContext(ctx context.Context, t time.Time) error {
+	return c.SetMockTimeAsyncContext(ctx, t).Receive()
+}
+
+// FutureValidateAddressesResult is a future promise to deliver the result of a
+// ValidateAddressesAsync RPC invocation (or an applicable error).
+type FutureValidateAddressesResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// validation results of the requested addresses.
+func (r FutureValidateAddressesResult) Receive() ([]exccjson.ValidateAddressChainResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as an array of validateaddress result objects.
+	var results []exccjson.ValidateAddressChainResult
+	err = json.Unmarshal(res, &results)
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// ValidateAddressesAsync returns an instance of a type that can be used to get
+// the result of the RPC at some future time by invoking the Receive function on
+// the returned instance.
+//
+// See ValidateAddresses for the blocking version and more details.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) ValidateAddressesAsync(addresses []string) FutureValidateAddressesResult {
+	return c.ValidateAddressesAsyncContext(context.Background(), addresses)
+}
+
+// ValidateAddressesAsyncContext is like ValidateAddressesAsync but the request
+// is abandoned once the passed context is done.
+//
+// See ValidateAddressesContext for the blocking version.
+func (c *Client) ValidateAddressesAsyncContext(ctx context.Context, addresses []string) FutureValidateAddressesResult {
+	cmd := exccjson.NewValidateAddressesCmd(addresses)
+	return c.sendCmdContext(ctx, cmd)
+}
+
+// ValidateAddresses returns whether or not each of the passed encoded addresses
+// is valid for the network of the server along with the type of script it pays
+// to.  Addresses which fail to decode are reported as invalid rather than
+// failing the request.
+//
+// NOTE: This is a exccd extension.
+func (c *Client) ValidateAddresses(addresses []string) ([]exccjson.ValidateAddressChainResult, error) {
+	return c.ValidateAddressesAsync(addresses).Receive()
+}
+
+// ValidateAddressesContext is like ValidateAddresses but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) ValidateAddressesContext(ctx context.Context, addresses []string) ([]exccjson.ValidateAddressChainResult, error) {
+	return c.ValidateAddressesAsyncContext(ctx, addresses).Receive()
+}
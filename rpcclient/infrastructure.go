@@ -26,6 +26,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/EXCCoin/exccd/chaincfg/chainhash"
 	"github.com/EXCCoin/exccd/exccjson"
 
 	"github.com/btcsuite/go-socks/socks"
@@ -106,6 +107,19 @@ type jsonRequest struct {
 	cmd            interface{}
 	marshalledJSON []byte
 	responseChan   chan *response
+
+	// ctx is the context of the request when it was sent with one, which
+	// also bounds the HTTP request in HTTP POST mode.
+	ctx context.Context
+}
+
+// done returns a channel which is closed once the context of the request is
+// done, or nil when the request was not sent with a context.
+func (jReq *jsonRequest) done() <-chan struct{} {
+	if jReq.ctx == nil {
+		return nil
+	}
+	return jReq.ctx.Done()
 }
 
 // Client represents a ExchangeCoin RPC client which allows easy access to the
@@ -154,6 +168,18 @@ type Client struct {
 	ntfnStateLock sync.Mutex
 	ntfnState     *notificationState
 
+	// Best block known to the client, and the time the connection was lost
+	// along with the best block at that time for the gap report delivered
+	// once reconnected.  The gap is only reset after it was reported, so a
+	// report covers the whole gap even when reconnecting takes several
+	// attempts.
+	gapLock        sync.Mutex
+	bestHash       *chainhash.Hash
+	bestHeight     int64
+	disconnectedAt time.Time
+	gapPrevHash    *chainhash.Hash
+	gapPrevHeight  int64
+
 	// Networking infrastructure.
 	sendChan        chan []byte
 	sendPostChan    chan *sendPostDetails
@@ -258,12 +284,39 @@ func (c *Client) trackRegisteredNtfns(cmd interface{}) {
 	case *exccjson.NotifyBlocksCmd:
 		c.ntfnState.notifyBlocks = true
 
+	case *exccjson.NotifySyncProgressCmd:
+		c.ntfnState.notifySyncProgress = true
+
+	case *exccjson.NotifyTicketStatusCmd:
+		if c.ntfnState.watchedTickets == nil {
+			c.ntfnState.watchedTickets = make(map[chainhash.Hash]struct{})
+		}
+		for _, hashStr := range bcmd.TxHashes {
+			hash, err := chainhash.NewHashFromStr(hashStr)
+			if err != nil {
+				continue
+			}
+			c.ntfnState.watchedTickets[*hash] = struct{}{}
+		}
+
 	case *exccjson.NotifyNewTransactionsCmd:
 		if bcmd.Verbose != nil && *bcmd.Verbose {
 			c.ntfnState.notifyNewTxVerbose = true
 		} else {
 			c.ntfnState.notifyNewTx = true
 		}
+
+	case *exccjson.LoadTxFilterCmd:
+		if bcmd.Reload || c.ntfnState.txFilterAddrs == nil {
+			c.ntfnState.txFilterAddrs = make(map[string]struct{})
+			c.ntfnState.txFilterOutPoints = make(map[exccjson.OutPoint]struct{})
+		}
+		for _, addr := range bcmd.Addresses {
+			c.ntfnState.txFilterAddrs[addr] = struct{}{}
+		}
+		for _, op := range bcmd.OutPoints {
+			c.ntfnState.txFilterOutPoints[op] = struct{}{}
+		}
 	}
 }
 
@@ -488,13 +541,17 @@ cleanup:
 
 // sendMessage sends the passed JSON to the connected server using the
 // websocket connection.  It is backed by a buffered channel, so it will not
-// block until the send channel is full.
-func (c *Client) sendMessage(marshalledJSON []byte) {
+// block until the send channel is full.  The message is dropped when the
+// passed done channel is closed before it could be queued, which may be nil
+// to wait until the client disconnects.
+func (c *Client) sendMessage(marshalledJSON []byte, done <-chan struct{}) {
 	// Don't send the message if disconnected.
 	select {
 	case c.sendChan <- marshalledJSON:
 	case <-c.disconnectChan():
 		return
+	case <-done:
+		return
 	}
 }
 
@@ -559,6 +616,28 @@ func (c *Client) reregisterNtfns() error {
 		}
 	}
 
+	// Reregister notifysyncprogress if needed.
+	if stateCopy.notifySyncProgress {
+		log.Debugf("Reregistering [notifysyncprogress]")
+		if _, err := c.NotifySyncProgress(); err != nil {
+			return err
+		}
+	}
+
+	// Reregister notifyticketstatus if needed.
+	if len(stateCopy.watchedTickets) > 0 {
+		log.Debugf("Reregistering [notifyticketstatus] (%d tickets)",
+			len(stateCopy.watchedTickets))
+		tickets := make([]*chainhash.Hash, 0, len(stateCopy.watchedTickets))
+		for hash := range stateCopy.watchedTickets {
+			hash := hash
+			tickets = append(tickets, &hash)
+		}
+		if _, err := c.NotifyTicketStatus(tickets); err != nil {
+			return err
+		}
+	}
+
 	// Reregister notifynewtransactions if needed.
 	if stateCopy.notifyNewTx || stateCopy.notifyNewTxVerbose {
 		log.Debugf("Reregistering [notifynewtransactions] (verbose=%v)",
@@ -569,9 +648,97 @@ func (c *Client) reregisterNtfns() error {
 		}
 	}
 
+	// Reload the transaction filter if needed.
+	if len(stateCopy.txFilterAddrs) > 0 || len(stateCopy.txFilterOutPoints) > 0 {
+		log.Debugf("Reloading [loadtxfilter] (%d addresses, %d outpoints)",
+			len(stateCopy.txFilterAddrs), len(stateCopy.txFilterOutPoints))
+		addrs := make([]string, 0, len(stateCopy.txFilterAddrs))
+		for addr := range stateCopy.txFilterAddrs {
+			addrs = append(addrs, addr)
+		}
+		outPoints := make([]exccjson.OutPoint, 0,
+			len(stateCopy.txFilterOutPoints))
+		for op := range stateCopy.txFilterOutPoints {
+			outPoints = append(outPoints, op)
+		}
+		cmd := exccjson.NewLoadTxFilterCmd(true, addrs, outPoints)
+		if err := FutureLoadTxFilterResult(c.sendCmd(cmd)).Receive(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// setBestBlock records the passed block as the best block known to the client.
+//
+// This function is safe for concurrent access.
+func (c *Client) setBestBlock(hash *chainhash.Hash, height int64) {
+	c.gapLock.Lock()
+	c.bestHash = hash
+	c.bestHeight = height
+	c.gapLock.Unlock()
+}
+
+// markDisconnected records the time the connection was lost unless the gap
+// starting at an earlier disconnect has not been reported yet.
+//
+// This function is safe for concurrent access.
+func (c *Client) markDisconnected() {
+	c.gapLock.Lock()
+	if c.disconnectedAt.IsZero() {
+		c.disconnectedAt = time.Now()
+		c.gapPrevHash = c.bestHash
+		c.gapPrevHeight = c.bestHeight
+	}
+	c.gapLock.Unlock()
+}
+
+// reportReconnectGap queries the best block of the RPC server and delivers the
+// gap since the connection was lost to the OnReconnected handler.  It is
+// intended to be called once the notifications were re-established after a
+// reconnect.
+func (c *Client) reportReconnectGap() {
+	if c.ntfnHandlers == nil || c.ntfnHandlers.OnReconnected == nil {
+		return
+	}
+
+	bestHash, bestHeight, err := c.GetBestBlock()
+	if err != nil {
+		// The gap is reported once the next reconnect succeeds.
+		log.Warnf("Unable to query the best block after reconnecting: %v",
+			err)
+		return
+	}
+
+	c.gapLock.Lock()
+	if c.disconnectedAt.IsZero() {
+		// The gap was already reported by a concurrent reconnect.
+		c.gapLock.Unlock()
+		return
+	}
+	gap := &ReconnectGap{
+		DisconnectedAt: c.disconnectedAt,
+		Downtime:       time.Since(c.disconnectedAt),
+		PrevHash:       c.gapPrevHash,
+		PrevHeight:     c.gapPrevHeight,
+		BestHash:       bestHash,
+		BestHeight:     bestHeight,
+	}
+	if c.bestHash == nil || bestHeight >= c.bestHeight {
+		c.bestHash = bestHash
+		c.bestHeight = bestHeight
+	}
+	c.disconnectedAt = time.Time{}
+	c.gapPrevHash = nil
+	c.gapPrevHeight = 0
+	c.gapLock.Unlock()
+
+	log.Infof("Reconnected to %s after %s (%d missed blocks)",
+		c.config.Host, gap.Downtime, gap.MissedBlocks())
+	c.ntfnHandlers.OnReconnected(gap)
+}
+
 // ignoreResends is a set of all methods for requests that are "long running"
 // are not be reissued by the client on reconnect.
 var ignoreResends = map[string]struct{}{
@@ -622,8 +789,12 @@ func (c *Client) resendRequests() {
 
 		log.Tracef("Sending command [%s] with id %d", jReq.method,
 			jReq.id)
-		c.sendMessage(jReq.marshalledJSON)
+		c.sendMessage(jReq.marshalledJSON, jReq.done())
 	}
+
+	// Report the gap since the connection was lost now that the client is
+	// up to date again.
+	c.reportReconnectGap()
 }
 
 // wsReconnectHandler listens for client disconnects and automatically tries
@@ -641,6 +812,7 @@ out:
 		case <-c.disconnectChan():
 			// On disconnect, fallthrough to reestablish the
 			// connection.
+			c.markDisconnected()
 
 		case <-c.shutdown:
 			break out
@@ -834,6 +1006,9 @@ func (c *Client) sendPost(jReq *jsonRequest) {
 		jReq.responseChan <- &response{result: nil, err: err}
 		return
 	}
+	if jReq.ctx != nil {
+		httpReq = httpReq.WithContext(jReq.ctx)
+	}
 	httpReq.Close = true
 	httpReq.Header.Set("Content-Type", "application/json")
 
@@ -869,13 +1044,47 @@ func (c *Client) sendRequest(jReq *jsonRequest) {
 	// Add the request to the internal tracking map so the response from the
 	// remote server can be properly detected and routed to the response
 	// channel.  Then send the marshalled request via the websocket
-	// connection.
+	// connection unless the context of the request is done first, in which
+	// case the request was already abandoned.
 	if err := c.addRequest(jReq); err != nil {
 		jReq.responseChan <- &response{err: err}
 		return
 	}
 	log.Tracef("Sending command [%s] with id %d", jReq.method, jReq.id)
-	c.sendMessage(jReq.marshalledJSON)
+	c.sendMessage(jReq.marshalledJSON, jReq.done())
+}
+
+// sendRequestContext sends the passed json request like sendRequest and
+// returns its response channel.  When the passed context is done before the
+// reply arrived, the request is abandoned, so it is neither tracked nor
+// reissued on reconnect anymore, and the error of the context is delivered as
+// the reply instead.
+func (c *Client) sendRequestContext(ctx context.Context, jReq *jsonRequest) chan *response {
+	responseChan := jReq.responseChan
+
+	// Nothing more to do when the context can never be done.
+	if ctx.Done() == nil {
+		c.sendRequest(jReq)
+		return responseChan
+	}
+
+	// Receive the reply on a separate channel so it can be raced against
+	// the context.
+	replyChan := make(chan *response, 1)
+	jReq.ctx = ctx
+	jReq.responseChan = replyChan
+	go func() {
+		select {
+		case r := <-replyChan:
+			responseChan <- r
+		case <-ctx.Done():
+			c.removeRequest(jReq.id)
+			responseChan <- &response{err: ctx.Err()}
+		}
+	}()
+	c.sendRequest(jReq)
+
+	return responseChan
 }
 
 // sendCmd sends the passed command to the associated server and returns a
@@ -883,6 +1092,12 @@ func (c *Client) sendRequest(jReq *jsonRequest) {
 // future.  It handles both websocket and HTTP POST mode depending on the
 // configuration of the client.
 func (c *Client) sendCmd(cmd interface{}) chan *response {
+	return c.sendCmdContext(context.Background(), cmd)
+}
+
+// sendCmdContext is sendCmd with a context that abandons the request once it is
+// done.  See sendRequestContext for details.
+func (c *Client) sendCmdContext(ctx context.Context, cmd interface{}) chan *response {
 	// Get the method associated with the command.
 	method, err := exccjson.CmdMethod(cmd)
 	if err != nil {
@@ -905,9 +1120,8 @@ func (c *Client) sendCmd(cmd interface{}) chan *response {
 		marshalledJSON: marshalledJSON,
 		responseChan:   responseChan,
 	}
-	c.sendRequest(jReq)
 
-	return responseChan
+	return c.sendRequestContext(ctx, jReq)
 }
 
 // sendCmdAndWait sends the passed command to the associated server, waits
@@ -1094,6 +1308,13 @@ type ConnConfig struct {
 	// is true.
 	Certificates []byte
 
+	// ClientCert and ClientKey are the bytes of a PEM-encoded certificate
+	// and its key which are presented to servers that authenticate clients
+	// with certificates.  They have no effect if the DisableTLS parameter
+	// is true.
+	ClientCert []byte
+	ClientKey  []byte
+
 	// Proxy specifies to connect through a SOCKS 5 proxy server.  It may
 	// be an empty string if a proxy is not required.
 	Proxy string
@@ -1127,6 +1348,17 @@ type ConnConfig struct {
 	HTTPPostMode bool
 }
 
+// addClientCert adds the client certificate of the passed connection
+// configuration to the passed TLS config, so it is presented to the server.
+func addClientCert(tlsConfig *tls.Config, config *ConnConfig) error {
+	cert, err := tls.X509KeyPair(config.ClientCert, config.ClientKey)
+	if err != nil {
+		return fmt.Errorf("invalid client certificate: %v", err)
+	}
+	tlsConfig.Certificates = []tls.Certificate{cert}
+	return nil
+}
+
 // newHTTPClient returns a new http client that is configured according to the
 // proxy and TLS settings in the associated connection configuration.
 func newHTTPClient(config *ConnConfig) (*http.Client, error) {
@@ -1150,6 +1382,14 @@ func newHTTPClient(config *ConnConfig) (*http.Client, error) {
 				RootCAs: pool,
 			}
 		}
+		if len(config.ClientCert) > 0 {
+			if tlsConfig == nil {
+				tlsConfig = &tls.Config{}
+			}
+			if err := addClientCert(tlsConfig, config); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	client := http.Client{
@@ -1177,6 +1417,11 @@ func dial(config *ConnConfig) (*websocket.Conn, error) {
 			pool.AppendCertsFromPEM(config.Certificates)
 			tlsConfig.RootCAs = pool
 		}
+		if len(config.ClientCert) > 0 {
+			if err := addClientCert(tlsConfig, config); err != nil {
+				return nil, err
+			}
+		}
 		scheme = "wss"
 	}
 
@@ -7,6 +7,7 @@
 package rpcclient
 
 import (
+	"context"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
@@ -54,8 +55,16 @@ func (r FutureGenerateResult) Receive() ([]*chainhash.Hash, error) {
 //
 // See Generate for the blocking version and more details.
 func (c *Client) GenerateAsync(numBlocks uint32) FutureGenerateResult {
+	return c.GenerateAsyncContext(context.Background(), numBlocks)
+}
+
+// GenerateAsyncContext is like GenerateAsync but the request is abandoned once
+// the passed context is done.
+//
+// See GenerateContext for the blocking version.
+func (c *Client) GenerateAsyncContext(ctx context.Context, numBlocks uint32) FutureGenerateResult {
 	cmd := exccjson.NewGenerateCmd(numBlocks)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // Generate generates numBlocks blocks and returns their hashes.
@@ -63,6 +72,13 @@ func (c *Client) Generate(numBlocks uint32) ([]*chainhash.Hash, error) {
 	return c.GenerateAsync(numBlocks).Receive()
 }
 
+// GenerateContext is like Generate but the request is abandoned with the error
+// of the passed context once it is done, such as when it times out or is
+// canceled.
+func (c *Client) GenerateContext(ctx context.Context, numBlocks uint32) ([]*chainhash.Hash, error) {
+	return c.GenerateAsyncContext(ctx, numBlocks).Receive()
+}
+
 // FutureGetGenerateResult is a future promise to deliver the result of a
 // GetGenerateAsync RPC invocation (or an applicable error).
 type FutureGetGenerateResult chan *response
@@ -91,8 +107,16 @@ func (r FutureGetGenerateResult) Receive() (bool, error) {
 //
 // See GetGenerate for the blocking version and more details.
 func (c *Client) GetGenerateAsync() FutureGetGenerateResult {
+	return c.GetGenerateAsyncContext(context.Background())
+}
+
+// GetGenerateAsyncContext is like GetGenerateAsync but the request is abandoned
+// once the passed context is done.
+//
+// See GetGenerateContext for the blocking version.
+func (c *Client) GetGenerateAsyncContext(ctx context.Context) FutureGetGenerateResult {
 	cmd := exccjson.NewGetGenerateCmd()
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetGenerate returns true if the server is set to mine, otherwise false.
@@ -100,6 +124,13 @@ func (c *Client) GetGenerate() (bool, error) {
 	return c.GetGenerateAsync().Receive()
 }
 
+// GetGenerateContext is like GetGenerate but the request is abandoned with the
+// error of the passed context once it is done, such as when it times out or is
+// canceled.
+func (c *Client) GetGenerateContext(ctx context.Context) (bool, error) {
+	return c.GetGenerateAsyncContext(ctx).Receive()
+}
+
 // FutureSetGenerateResult is a future promise to deliver the result of a
 // SetGenerateAsync RPC invocation (or an applicable error).
 type FutureSetGenerateResult chan *response
@@ -117,8 +148,16 @@ func (r FutureSetGenerateResult) Receive() error {
 //
 // See SetGenerate for the blocking version and more details.
 func (c *Client) SetGenerateAsync(enable bool, numCPUs int, miningAddr *string) FutureSetGenerateResult {
+	return c.SetGenerateAsyncContext(context.Background(), enable, numCPUs, miningAddr)
+}
+
+// SetGenerateAsyncContext is like SetGenerateAsync but the request is abandoned
+// once the passed context is done.
+//
+// See SetGenerateContext for the blocking version.
+func (c *Client) SetGenerateAsyncContext(ctx context.Context, enable bool, numCPUs int, miningAddr *string) FutureSetGenerateResult {
 	cmd := exccjson.NewSetGenerateCmd(enable, &numCPUs, miningAddr)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // SetGenerate sets the server to generate coins (mine) or not.
@@ -126,6 +165,13 @@ func (c *Client) SetGenerate(enable bool, numCPUs int, miningAddr *string) error
 	return c.SetGenerateAsync(enable, numCPUs, miningAddr).Receive()
 }
 
+// SetGenerateContext is like SetGenerate but the request is abandoned with the
+// error of the passed context once it is done, such as when it times out or is
+// canceled.
+func (c *Client) SetGenerateContext(ctx context.Context, enable bool, numCPUs int, miningAddr *string) error {
+	return c.SetGenerateAsyncContext(ctx, enable, numCPUs, miningAddr).Receive()
+}
+
 // FutureGetHashesPerSecResult is a future promise to deliver the result of a
 // GetHashesPerSecAsync RPC invocation (or an applicable error).
 type FutureGetHashesPerSecResult chan *response
@@ -155,8 +201,16 @@ func (r FutureGetHashesPerSecResult) Receive() (int64, error) {
 //
 // See GetHashesPerSec for the blocking version and more details.
 func (c *Client) GetHashesPerSecAsync() FutureGetHashesPerSecResult {
+	return c.GetHashesPerSecAsyncContext(context.Background())
+}
+
+// GetHashesPerSecAsyncContext is like GetHashesPerSecAsync but the request is
+// abandoned once the passed context is done.
+//
+// See GetHashesPerSecContext for the blocking version.
+func (c *Client) GetHashesPerSecAsyncContext(ctx context.Context) FutureGetHashesPerSecResult {
 	cmd := exccjson.NewGetHashesPerSecCmd()
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetHashesPerSec returns a recent hashes per second performance measurement
@@ -166,6 +220,13 @@ func (c *Client) GetHashesPerSec() (int64, error) {
 	return c.GetHashesPerSecAsync().Receive()
 }
 
+// GetHashesPerSecContext is like GetHashesPerSec but the request is abandoned
+// with the error of the passed context once it is done, such as when it times
+// out or is canceled.
+func (c *Client) GetHashesPerSecContext(ctx context.Context) (int64, error) {
+	return c.GetHashesPerSecAsyncContext(ctx).Receive()
+}
+
 // FutureGetMiningInfoResult is a future promise to deliver the result of a
 // GetMiningInfoAsync RPC invocation (or an applicable error).
 type FutureGetMiningInfoResult chan *response
@@ -194,8 +255,16 @@ func (r FutureGetMiningInfoResult) Receive() (*exccjson.GetMiningInfoResult, err
 //
 // See GetMiningInfo for the blocking version and more details.
 func (c *Client) GetMiningInfoAsync() FutureGetMiningInfoResult {
+	return c.GetMiningInfoAsyncContext(context.Background())
+}
+
+// GetMiningInfoAsyncContext is like GetMiningInfoAsync but the request is
+// abandoned once the passed context is done.
+//
+// See GetMiningInfoContext for the blocking version.
+func (c *Client) GetMiningInfoAsyncContext(ctx context.Context) FutureGetMiningInfoResult {
 	cmd := exccjson.NewGetMiningInfoCmd()
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetMiningInfo returns mining information.
@@ -203,6 +272,13 @@ func (c *Client) GetMiningInfo() (*exccjson.GetMiningInfoResult, error) {
 	return c.GetMiningInfoAsync().Receive()
 }
 
+// GetMiningInfoContext is like GetMiningInfo but the request is abandoned with
+// the error of the passed context once it is done, such as when it times out or
+// is canceled.
+func (c *Client) GetMiningInfoContext(ctx context.Context) (*exccjson.GetMiningInfoResult, error) {
+	return c.GetMiningInfoAsyncContext(ctx).Receive()
+}
+
 // FutureGetNetworkHashPS is a future promise to deliver the result of a
 // GetNetworkHashPSAsync RPC invocation (or an applicable error).
 type FutureGetNetworkHashPS chan *response
@@ -232,8 +308,16 @@ func (r FutureGetNetworkHashPS) Receive() (int64, error) {
 //
 // See GetNetworkHashPS for the blocking version and more details.
 func (c *Client) GetNetworkHashPSAsync() FutureGetNetworkHashPS {
+	return c.GetNetworkHashPSAsyncContext(context.Background())
+}
+
+// GetNetworkHashPSAsyncContext is like GetNetworkHashPSAsync but the request is
+// abandoned once the passed context is done.
+//
+// See GetNetworkHashPSContext for the blocking version.
+func (c *Client) GetNetworkHashPSAsyncContext(ctx context.Context) FutureGetNetworkHashPS {
 	cmd := exccjson.NewGetNetworkHashPSCmd(nil, nil)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetNetworkHashPS returns the estimated network hashes per second using the
@@ -245,14 +329,29 @@ func (c *Client) GetNetworkHashPS() (int64, error) {
 	return c.GetNetworkHashPSAsync().Receive()
 }
 
+// GetNetworkHashPSContext is like GetNetworkHashPS but the request is abandoned
+// with the error of the passed context once it is done, such as when it times
+// out or is canceled.
+func (c *Client) GetNetworkHashPSContext(ctx context.Context) (int64, error) {
+	return c.GetNetworkHashPSAsyncContext(ctx).Receive()
+}
+
 // GetNetworkHashPS2Async returns an instance of a type that can be used to get
 // the result of the RPC at some future time by invoking the Receive function on
 // the returned instance.
 //
 // See GetNetworkHashPS2 for the blocking version and more details.
 func (c *Client) GetNetworkHashPS2Async(blocks int) FutureGetNetworkHashPS {
+	return c.GetNetworkHashPS2AsyncContext(context.Background(), blocks)
+}
+
+// GetNetworkHashPS2AsyncContext is like GetNetworkHashPS2Async but the request
+// is abandoned once the passed context is done.
+//
+// See GetNetworkHashPS2Context for the blocking version.
+func (c *Client) GetNetworkHashPS2AsyncContext(ctx context.Context, blocks int) FutureGetNetworkHashPS {
 	cmd := exccjson.NewGetNetworkHashPSCmd(&blocks, nil)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetNetworkHashPS2 returns the estimated network hashes per second for the
@@ -266,14 +365,29 @@ func (c *Client) GetNetworkHashPS2(blocks int) (int64, error) {
 	return c.GetNetworkHashPS2Async(blocks).Receive()
 }
 
+// GetNetworkHashPS2Context is like GetNetworkHashPS2 but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) GetNetworkHashPS2Context(ctx context.Context, blocks int) (int64, error) {
+	return c.GetNetworkHashPS2AsyncContext(ctx, blocks).Receive()
+}
+
 // GetNetworkHashPS3Async returns an instance of a type that can be used to get
 // the result of the RPC at some future time by invoking the Receive function on
 // the returned instance.
 //
 // See GetNetworkHashPS3 for the blocking version and more details.
 func (c *Client) GetNetworkHashPS3Async(blocks, height int) FutureGetNetworkHashPS {
+	return c.GetNetworkHashPS3AsyncContext(context.Background(), blocks, height)
+}
+
+// GetNetworkHashPS3AsyncContext is like GetNetworkHashPS3Async but the request
+// is abandoned once the passed context is done.
+//
+// See GetNetworkHashPS3Context for the blocking version.
+func (c *Client) GetNetworkHashPS3AsyncContext(ctx context.Context, blocks, height int) FutureGetNetworkHashPS {
 	cmd := exccjson.NewGetNetworkHashPSCmd(&blocks, &height)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetNetworkHashPS3 returns the estimated network hashes per second for the
@@ -286,6 +400,69 @@ func (c *Client) GetNetworkHashPS3(blocks, height int) (int64, error) {
 	return c.GetNetworkHashPS3Async(blocks, height).Receive()
 }
 
+// GetNetworkHashPS3Context is like GetNetworkHashPS3 but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) GetNetworkHashPS3Context(ctx context.Context, blocks, height int) (int64, error) {
+	return c.GetNetworkHashPS3AsyncContext(ctx, blocks, height).Receive()
+}
+
+// FutureGetNetworkSolPS is a future promise to deliver the result of a
+// GetNetworkSolPSAsync RPC invocation (or an applicable error).
+type FutureGetNetworkSolPS chan *response
+
+// Receive waits for the response promised by the future and returns the
+// estimated network equihash solutions per second along with the window of
+// blocks the estimate is for.
+func (r FutureGetNetworkSolPS) Receive() (*exccjson.GetNetworkSolPSResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a getnetworksolps result object.
+	var result exccjson.GetNetworkSolPSResult
+	err = json.Unmarshal(res, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetNetworkSolPSAsync returns an instance of a type that can be used to get
+// the result of the RPC at some future time by invoking the Receive function on
+// the returned instance.
+//
+// See GetNetworkSolPS for the blocking version and more details.
+func (c *Client) GetNetworkSolPSAsync(blocks, height int) FutureGetNetworkSolPS {
+	return c.GetNetworkSolPSAsyncContext(context.Background(), blocks, height)
+}
+
+// GetNetworkSolPSAsyncContext is like GetNetworkSolPSAsync but the request is
+// abandoned once the passed context is done.
+//
+// See GetNetworkSolPSContext for the blocking version.
+func (c *Client) GetNetworkSolPSAsyncContext(ctx context.Context, blocks, height int) FutureGetNetworkSolPS {
+	cmd := exccjson.NewGetNetworkSolPSCmd(&blocks, &height)
+	return c.sendCmdContext(ctx, cmd)
+}
+
+// GetNetworkSolPS returns the estimated network equihash solutions per second
+// over the passed number of blocks ending at the passed height.  A height of -1
+// uses the most recent block height, and a number of blocks of -1 uses the
+// blocks since the last difficulty change.
+func (c *Client) GetNetworkSolPS(blocks, height int) (*exccjson.GetNetworkSolPSResult, error) {
+	return c.GetNetworkSolPSAsync(blocks, height).Receive()
+}
+
+// GetNetworkSolPSContext is like GetNetworkSolPS but the request is abandoned
+// with the error of the passed context once it is done, such as when it times
+// out or is canceled.
+func (c *Client) GetNetworkSolPSContext(ctx context.Context, blocks, height int) (*exccjson.GetNetworkSolPSResult, error) {
+	return c.GetNetworkSolPSAsyncContext(ctx, blocks, height).Receive()
+}
+
 // FutureGetWork is a future promise to deliver the result of a
 // GetWorkAsync RPC invocation (or an applicable error).
 type FutureGetWork chan *response
@@ -314,8 +491,16 @@ func (r FutureGetWork) Receive() (*exccjson.GetWorkResult, error) {
 //
 // See GetWork for the blocking version and more details.
 func (c *Client) GetWorkAsync() FutureGetWork {
+	return c.GetWorkAsyncContext(context.Background())
+}
+
+// GetWorkAsyncContext is like GetWorkAsync but the request is abandoned once
+// the passed context is done.
+//
+// See GetWorkContext for the blocking version.
+func (c *Client) GetWorkAsyncContext(ctx context.Context) FutureGetWork {
 	cmd := exccjson.NewGetWorkCmd(nil)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetWork returns hash data to work on.
@@ -325,6 +510,12 @@ func (c *Client) GetWork() (*exccjson.GetWorkResult, error) {
 	return c.GetWorkAsync().Receive()
 }
 
+// GetWorkContext is like GetWork but the request is abandoned with the error of
+// the passed context once it is done, such as when it times out or is canceled.
+func (c *Client) GetWorkContext(ctx context.Context) (*exccjson.GetWorkResult, error) {
+	return c.GetWorkAsyncContext(ctx).Receive()
+}
+
 // FutureGetWorkSubmit is a future promise to deliver the result of a
 // GetWorkSubmitAsync RPC invocation (or an applicable error).
 type FutureGetWorkSubmit chan *response
@@ -353,8 +544,16 @@ func (r FutureGetWorkSubmit) Receive() (bool, error) {
 //
 // See GetWorkSubmit for the blocking version and more details.
 func (c *Client) GetWorkSubmitAsync(data string) FutureGetWorkSubmit {
+	return c.GetWorkSubmitAsyncContext(context.Background(), data)
+}
+
+// GetWorkSubmitAsyncContext is like GetWorkSubmitAsync but the request is
+// abandoned once the passed context is done.
+//
+// See GetWorkSubmitContext for the blocking version.
+func (c *Client) GetWorkSubmitAsyncContext(ctx context.Context, data string) FutureGetWorkSubmit {
 	cmd := exccjson.NewGetWorkCmd(&data)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetWorkSubmit submits a block header which is a solution to previously
@@ -365,6 +564,13 @@ func (c *Client) GetWorkSubmit(data string) (bool, error) {
 	return c.GetWorkSubmitAsync(data).Receive()
 }
 
+// GetWorkSubmitContext is like GetWorkSubmit but the request is abandoned with
+// the error of the passed context once it is done, such as when it times out or
+// is canceled.
+func (c *Client) GetWorkSubmitContext(ctx context.Context, data string) (bool, error) {
+	return c.GetWorkSubmitAsyncContext(ctx, data).Receive()
+}
+
 // FutureGetBlockTemplate is a future promise to deliver the result of a
 // GetBlockTemplateAsync RPC invocation (or an applicable error).
 type FutureGetBlockTemplate chan *response
@@ -393,8 +599,16 @@ func (r FutureGetBlockTemplate) Receive() (*exccjson.GetBlockTemplateResult, err
 //
 // See GetBlockTemplate for the blocking version and more details.
 func (c *Client) GetBlockTemplateAsync(req *exccjson.TemplateRequest) FutureGetBlockTemplate {
+	return c.GetBlockTemplateAsyncContext(context.Background(), req)
+}
+
+// GetBlockTemplateAsyncContext is like GetBlockTemplateAsync but the request is
+// abandoned once the passed context is done.
+//
+// See GetBlockTemplateContext for the blocking version.
+func (c *Client) GetBlockTemplateAsyncContext(ctx context.Context, req *exccjson.TemplateRequest) FutureGetBlockTemplate {
 	cmd := exccjson.NewGetBlockTemplateCmd(req)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetBlockTemplate returns a block template to work on.
@@ -404,6 +618,13 @@ func (c *Client) GetBlockTemplate(req *exccjson.TemplateRequest) (*exccjson.GetB
 	return c.GetBlockTemplateAsync(req).Receive()
 }
 
+// GetBlockTemplateContext is like GetBlockTemplate but the request is abandoned
+// with the error of the passed context once it is done, such as when it times
+// out or is canceled.
+func (c *Client) GetBlockTemplateContext(ctx context.Context, req *exccjson.TemplateRequest) (*exccjson.GetBlockTemplateResult, error) {
+	return c.GetBlockTemplateAsyncContext(ctx, req).Receive()
+}
+
 // FutureSubmitBlockResult is a future promise to deliver the result of a
 // SubmitBlockAsync RPC invocation (or an applicable error).
 type FutureSubmitBlockResult chan *response
@@ -436,6 +657,14 @@ func (r FutureSubmitBlockResult) Receive() error {
 //
 // See SubmitBlock for the blocking version and more details.
 func (c *Client) SubmitBlockAsync(block *exccutil.Block, options *exccjson.SubmitBlockOptions) FutureSubmitBlockResult {
+	return c.SubmitBlockAsyncContext(context.Background(), block, options)
+}
+
+// SubmitBlockAsyncContext is like SubmitBlockAsync but the request is abandoned
+// once the passed context is done.
+//
+// See SubmitBlockContext for the blocking version.
+func (c *Client) SubmitBlockAsyncContext(ctx context.Context, block *exccutil.Block, options *exccjson.SubmitBlockOptions) FutureSubmitBlockResult {
 	blockHex := ""
 	if block != nil {
 		blockBytes, err := block.Bytes()
@@ -447,10 +676,17 @@ func (c *Client) SubmitBlockAsync(block *exccutil.Block, options *exccjson.Submi
 	}
 
 	cmd := exccjson.NewSubmitBlockCmd(blockHex, options)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // SubmitBlock attempts to submit a new block into the excc network.
 func (c *Client) SubmitBlock(block *exccutil.Block, options *exccjson.SubmitBlockOptions) error {
 	return c.SubmitBlockAsync(block, options).Receive()
 }
+
+// SubmitBlockContext is like SubmitBlock but the request is abandoned with the
+// error of the passed context once it is done, such as when it times out or is
+// canceled.
+func (c *Client) SubmitBlockContext(ctx context.Context, block *exccutil.Block, options *exccjson.SubmitBlockOptions) error {
+	return c.SubmitBlockAsyncContext(ctx, block, options).Receive()
+}
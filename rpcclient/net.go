@@ -7,6 +7,7 @@
 package rpcclient
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/EXCCoin/exccd/exccjson"
@@ -52,8 +53,16 @@ func (r FutureAddNodeResult) Receive() error {
 //
 // See AddNode for the blocking version and more details.
 func (c *Client) AddNodeAsync(host string, command AddNodeCommand) FutureAddNodeResult {
+	return c.AddNodeAsyncContext(context.Background(), host, command)
+}
+
+// AddNodeAsyncContext is like AddNodeAsync but the request is abandoned once
+// the passed context is done.
+//
+// See AddNodeContext for the blocking version.
+func (c *Client) AddNodeAsyncContext(ctx context.Context, host string, command AddNodeCommand) FutureAddNodeResult {
 	cmd := exccjson.NewAddNodeCmd(host, exccjson.AddNodeSubCmd(command))
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // AddNode attempts to perform the passed command on the passed persistent peer.
@@ -65,6 +74,12 @@ func (c *Client) AddNode(host string, command AddNodeCommand) error {
 	return c.AddNodeAsync(host, command).Receive()
 }
 
+// AddNodeContext is like AddNode but the request is abandoned with the error of
+// the passed context once it is done, such as when it times out or is canceled.
+func (c *Client) AddNodeContext(ctx context.Context, host string, command AddNodeCommand) error {
+	return c.AddNodeAsyncContext(ctx, host, command).Receive()
+}
+
 // FutureGetAddedNodeInfoResult is a future promise to deliver the result of a
 // GetAddedNodeInfoAsync RPC invocation (or an applicable error).
 type FutureGetAddedNodeInfoResult chan *response
@@ -93,8 +108,16 @@ func (r FutureGetAddedNodeInfoResult) Receive() ([]exccjson.GetAddedNodeInfoResu
 //
 // See GetAddedNodeInfo for the blocking version and more details.
 func (c *Client) GetAddedNodeInfoAsync(peer string) FutureGetAddedNodeInfoResult {
+	return c.GetAddedNodeInfoAsyncContext(context.Background(), peer)
+}
+
+// GetAddedNodeInfoAsyncContext is like GetAddedNodeInfoAsync but the request is
+// abandoned once the passed context is done.
+//
+// See GetAddedNodeInfoContext for the blocking version.
+func (c *Client) GetAddedNodeInfoAsyncContext(ctx context.Context, peer string) FutureGetAddedNodeInfoResult {
 	cmd := exccjson.NewGetAddedNodeInfoCmd(true, &peer)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetAddedNodeInfo returns information about manually added (persistent) peers.
@@ -105,6 +128,13 @@ func (c *Client) GetAddedNodeInfo(peer string) ([]exccjson.GetAddedNodeInfoResul
 	return c.GetAddedNodeInfoAsync(peer).Receive()
 }
 
+// GetAddedNodeInfoContext is like GetAddedNodeInfo but the request is abandoned
+// with the error of the passed context once it is done, such as when it times
+// out or is canceled.
+func (c *Client) GetAddedNodeInfoContext(ctx context.Context, peer string) ([]exccjson.GetAddedNodeInfoResult, error) {
+	return c.GetAddedNodeInfoAsyncContext(ctx, peer).Receive()
+}
+
 // FutureGetAddedNodeInfoNoDNSResult is a future promise to deliver the result
 // of a GetAddedNodeInfoNoDNSAsync RPC invocation (or an applicable error).
 type FutureGetAddedNodeInfoNoDNSResult chan *response
@@ -133,8 +163,16 @@ func (r FutureGetAddedNodeInfoNoDNSResult) Receive() ([]string, error) {
 //
 // See GetAddedNodeInfoNoDNS for the blocking version and more details.
 func (c *Client) GetAddedNodeInfoNoDNSAsync(peer string) FutureGetAddedNodeInfoNoDNSResult {
+	return c.GetAddedNodeInfoNoDNSAsyncContext(context.Background(), peer)
+}
+
+// GetAddedNodeInfoNoDNSAsyncContext is like GetAddedNodeInfoNoDNSAsync but the
+// request is abandoned once the passed context is done.
+//
+// See GetAddedNodeInfoNoDNSContext for the blocking version.
+func (c *Client) GetAddedNodeInfoNoDNSAsyncContext(ctx context.Context, peer string) FutureGetAddedNodeInfoNoDNSResult {
 	cmd := exccjson.NewGetAddedNodeInfoCmd(false, &peer)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetAddedNodeInfoNoDNS returns a list of manually added (persistent) peers.
@@ -146,6 +184,13 @@ func (c *Client) GetAddedNodeInfoNoDNS(peer string) ([]string, error) {
 	return c.GetAddedNodeInfoNoDNSAsync(peer).Receive()
 }
 
+// GetAddedNodeInfoNoDNSContext is like GetAddedNodeInfoNoDNS but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) GetAddedNodeInfoNoDNSContext(ctx context.Context, peer string) ([]string, error) {
+	return c.GetAddedNodeInfoNoDNSAsyncContext(ctx, peer).Receive()
+}
+
 // FutureGetConnectionCountResult is a future promise to deliver the result
 // of a GetConnectionCountAsync RPC invocation (or an applicable error).
 type FutureGetConnectionCountResult chan *response
@@ -174,8 +219,16 @@ func (r FutureGetConnectionCountResult) Receive() (int64, error) {
 //
 // See GetConnectionCount for the blocking version and more details.
 func (c *Client) GetConnectionCountAsync() FutureGetConnectionCountResult {
+	return c.GetConnectionCountAsyncContext(context.Background())
+}
+
+// GetConnectionCountAsyncContext is like GetConnectionCountAsync but the
+// request is abandoned once the passed context is done.
+//
+// See GetConnectionCountContext for the blocking version.
+func (c *Client) GetConnectionCountAsyncContext(ctx context.Context) FutureGetConnectionCountResult {
 	cmd := exccjson.NewGetConnectionCountCmd()
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetConnectionCount returns the number of active connections to other peers.
@@ -183,6 +236,13 @@ func (c *Client) GetConnectionCount() (int64, error) {
 	return c.GetConnectionCountAsync().Receive()
 }
 
+// GetConnectionCountContext is like GetConnectionCount but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) GetConnectionCountContext(ctx context.Context) (int64, error) {
+	return c.GetConnectionCountAsyncContext(ctx).Receive()
+}
+
 // FuturePingResult is a future promise to deliver the result of a PingAsync RPC
 // invocation (or an applicable error).
 type FuturePingResult chan *response
@@ -200,8 +260,16 @@ func (r FuturePingResult) Receive() error {
 //
 // See Ping for the blocking version and more details.
 func (c *Client) PingAsync() FuturePingResult {
+	return c.PingAsyncContext(context.Background())
+}
+
+// PingAsyncContext is like PingAsync but the request is abandoned once the
+// passed context is done.
+//
+// See PingContext for the blocking version.
+func (c *Client) PingAsyncContext(ctx context.Context) FuturePingResult {
 	cmd := exccjson.NewPingCmd()
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // Ping queues a ping to be sent to each connected peer.
@@ -212,6 +280,12 @@ func (c *Client) Ping() error {
 	return c.PingAsync().Receive()
 }
 
+// PingContext is like Ping but the request is abandoned with the error of the
+// passed context once it is done, such as when it times out or is canceled.
+func (c *Client) PingContext(ctx context.Context) error {
+	return c.PingAsyncContext(ctx).Receive()
+}
+
 // FutureGetPeerInfoResult is a future promise to deliver the result of a
 // GetPeerInfoAsync RPC invocation (or an applicable error).
 type FutureGetPeerInfoResult chan *response
@@ -240,8 +314,16 @@ func (r FutureGetPeerInfoResult) Receive() ([]exccjson.GetPeerInfoResult, error)
 //
 // See GetPeerInfo for the blocking version and more details.
 func (c *Client) GetPeerInfoAsync() FutureGetPeerInfoResult {
+	return c.GetPeerInfoAsyncContext(context.Background())
+}
+
+// GetPeerInfoAsyncContext is like GetPeerInfoAsync but the request is abandoned
+// once the passed context is done.
+//
+// See GetPeerInfoContext for the blocking version.
+func (c *Client) GetPeerInfoAsyncContext(ctx context.Context) FutureGetPeerInfoResult {
 	cmd := exccjson.NewGetPeerInfoCmd()
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetPeerInfo returns data about each connected network peer.
@@ -249,6 +331,13 @@ func (c *Client) GetPeerInfo() ([]exccjson.GetPeerInfoResult, error) {
 	return c.GetPeerInfoAsync().Receive()
 }
 
+// GetPeerInfoContext is like GetPeerInfo but the request is abandoned with the
+// error of the passed context once it is done, such as when it times out or is
+// canceled.
+func (c *Client) GetPeerInfoContext(ctx context.Context) ([]exccjson.GetPeerInfoResult, error) {
+	return c.GetPeerInfoAsyncContext(ctx).Receive()
+}
+
 // FutureGetNetTotalsResult is a future promise to deliver the result of a
 // GetNetTotalsAsync RPC invocation (or an applicable error).
 type FutureGetNetTotalsResult chan *response
@@ -277,11 +366,77 @@ func (r FutureGetNetTotalsResult) Receive() (*exccjson.GetNetTotalsResult, error
 //
 // See GetNetTotals for the blocking version and more details.
 func (c *Client) GetNetTotalsAsync() FutureGetNetTotalsResult {
+	return c.GetNetTotalsAsyncContext(context.Background())
+}
+
+// GetNetTotalsAsyncContext is like GetNetTotalsAsync but the request is
+// abandoned once the passed context is done.
+//
+// See GetNetTotalsContext for the blocking version.
+func (c *Client) GetNetTotalsAsyncContext(ctx context.Context) FutureGetNetTotalsResult {
 	cmd := exccjson.NewGetNetTotalsCmd()
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetNetTotals returns network traffic statistics.
 func (c *Client) GetNetTotals() (*exccjson.GetNetTotalsResult, error) {
 	return c.GetNetTotalsAsync().Receive()
 }
+
+// GetNetTotalsContext is like GetNetTotals but the request is abandoned with
+// the error of the passed context once it is done, such as when it times out or
+// is canceled.
+func (c *Client) GetNetTotalsContext(ctx context.Context) (*exccjson.GetNetTotalsResult, error) {
+	return c.GetNetTotalsAsyncContext(ctx).Receive()
+}
+
+// FutureUptimeResult is a future promise to deliver the result of an
+// UptimeAsync RPC invocation (or an applicable error).
+type FutureUptimeResult chan *response
+
+// Receive waits for the response promised by the future and returns the number
+// of seconds since the server was started.
+func (r FutureUptimeResult) Receive() (int64, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return 0, err
+	}
+
+	// Unmarshal result as an int64.
+	var uptime int64
+	err = json.Unmarshal(res, &uptime)
+	if err != nil {
+		return 0, err
+	}
+
+	return uptime, nil
+}
+
+// UptimeAsync returns an instance of a type that can be used to get the result
+// of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See Uptime for the blocking version and more details.
+func (c *Client) UptimeAsync() FutureUptimeResult {
+	return c.UptimeAsyncContext(context.Background())
+}
+
+// UptimeAsyncContext is like UptimeAsync but the request is abandoned once the
+// passed context is done.
+//
+// See UptimeContext for the blocking version.
+func (c *Client) UptimeAsyncContext(ctx context.Context) FutureUptimeResult {
+	cmd := exccjson.NewUptimeCmd()
+	return c.sendCmdContext(ctx, cmd)
+}
+
+// Uptime returns the number of seconds since the server was started.
+func (c *Client) Uptime() (int64, error) {
+	return c.UptimeAsync().Receive()
+}
+
+// UptimeContext is like Uptime but the request is abandoned with the error of
+// the passed context once it is done, such as when it times out or is canceled.
+func (c *Client) UptimeContext(ctx context.Context) (int64, error) {
+	return c.UptimeAsyncContext(ctx).Receive()
+}
@@ -7,11 +7,13 @@
 package rpcclient
 
 import (
+	"context"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/EXCCoin/exccd/chaincfg/chainhash"
 	"github.com/EXCCoin/exccd/exccjson"
@@ -37,8 +39,12 @@ type notificationState struct {
 	notifySpentAndMissedTickets bool
 	notifyNewTickets            bool
 	notifyStakeDifficulty       bool
+	notifySyncProgress          bool
 	notifyNewTx                 bool
 	notifyNewTxVerbose          bool
+	watchedTickets              map[chainhash.Hash]struct{}
+	txFilterAddrs               map[string]struct{}
+	txFilterOutPoints           map[exccjson.OutPoint]struct{}
 }
 
 // Copy returns a deep copy of the receiver.
@@ -49,8 +55,28 @@ func (s *notificationState) Copy() *notificationState {
 	stateCopy.notifySpentAndMissedTickets = s.notifySpentAndMissedTickets
 	stateCopy.notifyNewTickets = s.notifyNewTickets
 	stateCopy.notifyStakeDifficulty = s.notifyStakeDifficulty
+	stateCopy.notifySyncProgress = s.notifySyncProgress
 	stateCopy.notifyNewTx = s.notifyNewTx
 	stateCopy.notifyNewTxVerbose = s.notifyNewTxVerbose
+	stateCopy.watchedTickets = make(map[chainhash.Hash]struct{},
+		len(s.watchedTickets))
+	for hash := range s.watchedTickets {
+		stateCopy.watchedTickets[hash] = struct{}{}
+	}
+	if s.txFilterAddrs != nil {
+		stateCopy.txFilterAddrs = make(map[string]struct{},
+			len(s.txFilterAddrs))
+		for addr := range s.txFilterAddrs {
+			stateCopy.txFilterAddrs[addr] = struct{}{}
+		}
+	}
+	if s.txFilterOutPoints != nil {
+		stateCopy.txFilterOutPoints = make(map[exccjson.OutPoint]struct{},
+			len(s.txFilterOutPoints))
+		for op := range s.txFilterOutPoints {
+			stateCopy.txFilterOutPoints[op] = struct{}{}
+		}
+	}
 
 	return &stateCopy
 }
@@ -85,6 +111,16 @@ type NotificationHandlers struct {
 	// notification handlers, and is safe for blocking client requests.
 	OnClientConnected func()
 
+	// OnReconnected is invoked after the client reconnected to the RPC
+	// server and re-established all of the notifications that were
+	// registered before the connection was lost.  The passed gap describes
+	// the period the client was disconnected, during which notifications
+	// were missed, so the caller can catch up, for example by rescanning
+	// the blocks connected in the meantime.  This callback is run async
+	// with the rest of the notification handlers, and is safe for blocking
+	// client requests.
+	OnReconnected func(gap *ReconnectGap)
+
 	// OnBlockConnected is invoked when a block is connected to the longest
 	// (best) chain.  It will only be invoked if a preceding call to
 	// NotifyBlocks has been made to register for the notification and the
@@ -101,6 +137,17 @@ type NotificationHandlers struct {
 	// the client's transaction filter.
 	OnRelevantTxAccepted func(transaction []byte)
 
+	// OnRescannedBlock is invoked for each block with relevant transactions
+	// found by a rescan requested with RescanRange before the rescan
+	// completes.  The transactions are serialized.
+	OnRescannedBlock func(hash *chainhash.Hash, height int64,
+		transactions [][]byte)
+
+	// OnRescanProgress is invoked periodically while a rescan requested
+	// with RescanRange is underway with the last rescanned block.
+	OnRescanProgress func(hash *chainhash.Hash, height int64,
+		blkTime time.Time)
+
 	// OnReorganization is invoked when the blockchain begins reorganizing.
 	// It will only be invoked if a preceding call to NotifyBlocks has been
 	// made to register for the notification and the function is non-nil.
@@ -141,6 +188,22 @@ type NotificationHandlers struct {
 		height int64,
 		stakeDiff int64)
 
+	// OnSyncProgress is invoked periodically while the chain of the server
+	// is syncing and once more when the sync completes.  It will only be
+	// invoked if a preceding call to NotifySyncProgress has been made to
+	// register for the notification and the function is non-nil.
+	OnSyncProgress func(info *exccjson.GetSyncInfoResult)
+
+	// OnTicketStatusChanged is invoked when a block is connected to or
+	// disconnected from the main chain and it changes the status of any
+	// of the watched tickets.  Only the tickets which changed are provided.
+	// It will only be invoked if a preceding call to NotifyTicketStatus
+	// has been made to register for the notification and the function is
+	// non-nil.
+	OnTicketStatusChanged func(hash *chainhash.Hash,
+		height int64,
+		tickets []exccjson.TicketInfoResult)
+
 	// OnTxAccepted is invoked when a transaction is accepted into the
 	// memory pool.  It will only be invoked if a preceding call to
 	// NotifyNewTransactions with the verbose flag set to false has been
@@ -153,6 +216,12 @@ type NotificationHandlers struct {
 	// made to register for the notification and the function is non-nil.
 	OnTxAcceptedVerbose func(txDetails *exccjson.TxRawResult)
 
+	// OnNotificationsDropped is invoked when the server dropped the
+	// provided number of notifications to the client because it did not
+	// read them fast enough.  Any state maintained from notifications
+	// should be resynchronized since the notifications were lost.
+	OnNotificationsDropped func(count int64)
+
 	// OnBtcdConnected is invoked when a wallet connects or disconnects from
 	// exccd.
 	//
@@ -203,6 +272,72 @@ type NotificationHandlers struct {
 	OnUnknownNotification func(method string, params []json.RawMessage)
 }
 
+// ReconnectGap describes the period a websocket client was disconnected from
+// the RPC server and therefore did not receive any notifications.
+type ReconnectGap struct {
+	// DisconnectedAt is the time the connection was lost and Downtime is
+	// how long it took to reconnect and re-establish the notifications.
+	DisconnectedAt time.Time
+	Downtime       time.Duration
+
+	// PrevHash and PrevHeight identify the best block the client knew of
+	// before the connection was lost.  It is learned from the block
+	// notifications and the best block of previous reconnects, so PrevHash
+	// is nil when no block was known yet.  Note the block might no longer
+	// be part of the main chain when a reorganization happened meanwhile.
+	PrevHash   *chainhash.Hash
+	PrevHeight int64
+
+	// BestHash and BestHeight identify the best block of the RPC server
+	// after reconnecting.
+	BestHash   *chainhash.Hash
+	BestHeight int64
+}
+
+// MissedBlocks returns the number of blocks that were connected to the main
+// chain while the client was disconnected, or -1 when it is not known since the
+// best block before the disconnect is not known.
+func (g *ReconnectGap) MissedBlocks() int64 {
+	if g.PrevHash == nil {
+		return -1
+	}
+	if g.BestHeight < g.PrevHeight {
+		return 0
+	}
+	return g.BestHeight - g.PrevHeight
+}
+
+// trackBlockNtfn updates the best block known to the client from the passed
+// block notification so it can be reported in the gap of the next reconnect.
+func (c *Client) trackBlockNtfn(ntfn *rawNotification) {
+	var serializedHeader []byte
+	var err error
+	switch ntfn.Method {
+	case exccjson.BlockConnectedNtfnMethod:
+		serializedHeader, _, err = parseBlockConnectedParams(ntfn.Params)
+	case exccjson.BlockDisconnectedNtfnMethod:
+		serializedHeader, err = parseBlockDisconnectedParams(ntfn.Params)
+	default:
+		return
+	}
+	if err != nil {
+		return
+	}
+	var header wire.BlockHeader
+	if err := header.FromBytes(serializedHeader); err != nil {
+		return
+	}
+
+	// The parent of a disconnected block becomes the best block.
+	hash := header.BlockHash()
+	height := int64(header.Height)
+	if ntfn.Method == exccjson.BlockDisconnectedNtfnMethod {
+		hash = header.PrevBlock
+		height--
+	}
+	c.setBestBlock(&hash, height)
+}
+
 // handleNotification examines the passed notification type, performs
 // conversions to get the raw notification types into higher level types and
 // delivers the notification to the appropriate On<X> handler registered with
@@ -214,6 +349,12 @@ func (c *Client) handleNotification(ntfn *rawNotification) {
 		return
 	}
 
+	// Keep track of the best block for the gap report of reconnects even
+	// when the caller does not handle the block notifications.
+	if c.ntfnHandlers.OnReconnected != nil {
+		c.trackBlockNtfn(ntfn)
+	}
+
 	switch ntfn.Method {
 	// OnBlockConnected
 	case exccjson.BlockConnectedNtfnMethod:
@@ -265,6 +406,42 @@ func (c *Client) handleNotification(ntfn *rawNotification) {
 
 		c.ntfnHandlers.OnRelevantTxAccepted(transaction)
 
+	// OnRescannedBlock
+	case exccjson.RescannedBlockNtfnMethod:
+		// Ignore the notification if the client is not interested in
+		// it.
+		if c.ntfnHandlers.OnRescannedBlock == nil {
+			return
+		}
+
+		hash, height, transactions, err :=
+			parseRescannedBlockNtfnParams(ntfn.Params)
+		if err != nil {
+			log.Warnf("Received invalid rescannedblock "+
+				"notification: %v", err)
+			return
+		}
+
+		c.ntfnHandlers.OnRescannedBlock(hash, height, transactions)
+
+	// OnRescanProgress
+	case exccjson.RescanProgressNtfnMethod:
+		// Ignore the notification if the client is not interested in
+		// it.
+		if c.ntfnHandlers.OnRescanProgress == nil {
+			return
+		}
+
+		hash, height, blkTime, err :=
+			parseRescanProgressNtfnParams(ntfn.Params)
+		if err != nil {
+			log.Warnf("Received invalid rescanprogress "+
+				"notification: %v", err)
+			return
+		}
+
+		c.ntfnHandlers.OnRescanProgress(hash, height, blkTime)
+
 	case exccjson.ReorganizationNtfnMethod:
 		// Ignore the notification if the client is not interested in
 		// it.
@@ -364,6 +541,42 @@ func (c *Client) handleNotification(ntfn *rawNotification) {
 			blockHeight,
 			stakeDiff)
 
+	// OnSyncProgress
+	case exccjson.SyncProgressNtfnMethod:
+		// Ignore the notification if the client is not interested in
+		// it.
+		if c.ntfnHandlers.OnSyncProgress == nil {
+			return
+		}
+
+		info, err := parseSyncProgressNtfnParams(ntfn.Params)
+		if err != nil {
+			log.Warnf("Received invalid sync progress notification: %v",
+				err)
+			return
+		}
+
+		c.ntfnHandlers.OnSyncProgress(info)
+
+	// OnTicketStatusChanged
+	case exccjson.TicketStatusChangedNtfnMethod:
+		// Ignore the notification if the client is not interested in
+		// it.
+		if c.ntfnHandlers.OnTicketStatusChanged == nil {
+			return
+		}
+
+		blockHash, blockHeight, tickets, err :=
+			parseTicketStatusChangedNtfnParams(ntfn.Params)
+		if err != nil {
+			log.Warnf("Received invalid ticket status changed "+
+				"notification: %v", err)
+			return
+		}
+
+		c.ntfnHandlers.OnTicketStatusChanged(blockHash, blockHeight,
+			tickets)
+
 	// OnTxAccepted
 	case exccjson.TxAcceptedNtfnMethod:
 		// Ignore the notification if the client is not interested in
@@ -398,6 +611,23 @@ func (c *Client) handleNotification(ntfn *rawNotification) {
 
 		c.ntfnHandlers.OnTxAcceptedVerbose(rawTx)
 
+	// OnNotificationsDropped
+	case exccjson.NotificationsDroppedNtfnMethod:
+		// Ignore the notification if the client is not interested in
+		// it.
+		if c.ntfnHandlers.OnNotificationsDropped == nil {
+			return
+		}
+
+		count, err := parseNotificationsDroppedNtfnParams(ntfn.Params)
+		if err != nil {
+			log.Warnf("Received invalid notifications dropped "+
+				"notification: %v", err)
+			return
+		}
+
+		c.ntfnHandlers.OnNotificationsDropped(count)
+
 	// OnBtcdConnected
 	case exccjson.BtcdConnectedNtfnMethod:
 		// Ignore the notification if the client is not interested in
@@ -581,6 +811,84 @@ func parseRelevantTxAcceptedParams(params []json.RawMessage) (transaction []byte
 	return parseHexParam(params[0])
 }
 
+// parseRescannedBlockNtfnParams parses out the block hash, block height, and
+// serialized relevant transactions from the parameters of a rescannedblock
+// notification.
+func parseRescannedBlockNtfnParams(params []json.RawMessage) (*chainhash.Hash, int64, [][]byte, error) {
+	if len(params) != 3 {
+		return nil, 0, nil, wrongNumParams(len(params))
+	}
+
+	// Unmarshal first parameter as a string.
+	var blockHashStr string
+	err := json.Unmarshal(params[0], &blockHashStr)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	blockHash, err := chainhash.NewHashFromStr(blockHashStr)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	// Unmarshal second parameter as an integer.
+	var blockHeight int64
+	err = json.Unmarshal(params[1], &blockHeight)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	// Unmarshal third parameter as an array of hex-encoded transactions.
+	var txsHex []string
+	err = json.Unmarshal(params[2], &txsHex)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	transactions := make([][]byte, len(txsHex))
+	for i, txHex := range txsHex {
+		transactions[i], err = hex.DecodeString(txHex)
+		if err != nil {
+			return nil, 0, nil, err
+		}
+	}
+
+	return blockHash, blockHeight, transactions, nil
+}
+
+// parseRescanProgressNtfnParams parses out the block hash, block height, and
+// block time from the parameters of a rescanprogress notification.
+func parseRescanProgressNtfnParams(params []json.RawMessage) (*chainhash.Hash, int64, time.Time, error) {
+	if len(params) != 3 {
+		return nil, 0, time.Time{}, wrongNumParams(len(params))
+	}
+
+	// Unmarshal first parameter as a string.
+	var blockHashStr string
+	err := json.Unmarshal(params[0], &blockHashStr)
+	if err != nil {
+		return nil, 0, time.Time{}, err
+	}
+	blockHash, err := chainhash.NewHashFromStr(blockHashStr)
+	if err != nil {
+		return nil, 0, time.Time{}, err
+	}
+
+	// Unmarshal second parameter as an integer.
+	var blockHeight int64
+	err = json.Unmarshal(params[1], &blockHeight)
+	if err != nil {
+		return nil, 0, time.Time{}, err
+	}
+
+	// Unmarshal third parameter as a unix time.
+	var blkTime int64
+	err = json.Unmarshal(params[2], &blkTime)
+	if err != nil {
+		return nil, 0, time.Time{}, err
+	}
+
+	return blockHash, blockHeight, time.Unix(blkTime, 0), nil
+}
+
 func parseReorganizationNtfnParams(params []json.RawMessage) (*chainhash.Hash,
 	int32, *chainhash.Hash, int32, error) {
 	errorOut := func(err error) (*chainhash.Hash, int32, *chainhash.Hash,
@@ -817,6 +1125,59 @@ func parseNewTicketsNtfnParams(params []json.RawMessage) (*chainhash.Hash, int64
 	return sha, bh, stakeDiff, t, nil
 }
 
+// parseSyncProgressNtfnParams parses out the progress of the chain sync from
+// the parameters of a syncprogress notification.
+func parseSyncProgressNtfnParams(params []json.RawMessage) (*exccjson.GetSyncInfoResult, error) {
+	if len(params) != 1 {
+		return nil, wrongNumParams(len(params))
+	}
+
+	// Unmarshal first parameter as a sync info result.
+	var info exccjson.GetSyncInfoResult
+	err := json.Unmarshal(params[0], &info)
+	if err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+// parseTicketStatusChangedNtfnParams parses out the block hash, block height,
+// and changed tickets from the parameters of a ticketstatuschanged
+// notification.
+func parseTicketStatusChangedNtfnParams(params []json.RawMessage) (*chainhash.Hash, int64, []exccjson.TicketInfoResult, error) {
+	if len(params) != 3 {
+		return nil, 0, nil, wrongNumParams(len(params))
+	}
+
+	// Unmarshal first parameter as a string.
+	var blockHashStr string
+	err := json.Unmarshal(params[0], &blockHashStr)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	blockHash, err := chainhash.NewHashFromStr(blockHashStr)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	// Unmarshal second parameter as an integer.
+	var blockHeight int64
+	err = json.Unmarshal(params[1], &blockHeight)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	// Unmarshal third parameter as an array of ticket info results.
+	var tickets []exccjson.TicketInfoResult
+	err = json.Unmarshal(params[2], &tickets)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	return blockHash, blockHeight, tickets, nil
+}
+
 // parseStakeDifficultyNtfnParams parses out the list of block hash, block
 // height, and stake difficulty from a WinningTickets notification.
 func parseStakeDifficultyNtfnParams(params []json.RawMessage) (
@@ -920,6 +1281,23 @@ func parseTxAcceptedVerboseNtfnParams(params []json.RawMessage) (*exccjson.TxRaw
 	return &rawTx, nil
 }
 
+// parseNotificationsDroppedNtfnParams parses out the number of dropped
+// notifications from the parameters of a notificationsdropped notification.
+func parseNotificationsDroppedNtfnParams(params []json.RawMessage) (int64, error) {
+	if len(params) != 1 {
+		return 0, wrongNumParams(len(params))
+	}
+
+	// Unmarshal first parameter as an integer.
+	var count int64
+	err := json.Unmarshal(params[0], &count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
 // parseBtcdConnectedNtfnParams parses out the connection status of exccd
 // and exccwallet from the parameters of a btcdconnected notification.
 func parseBtcdConnectedNtfnParams(params []json.RawMessage) (bool, error) {
@@ -1146,6 +1524,14 @@ func (r FutureNotifyBlocksResult) Receive() error {
 //
 // NOTE: This is a exccd extension and requires a websocket connection.
 func (c *Client) NotifyBlocksAsync() FutureNotifyBlocksResult {
+	return c.NotifyBlocksAsyncContext(context.Background())
+}
+
+// NotifyBlocksAsyncContext is like NotifyBlocksAsync but the request is
+// abandoned once the passed context is done.
+//
+// See NotifyBlocksContext for the blocking version.
+func (c *Client) NotifyBlocksAsyncContext(ctx context.Context) FutureNotifyBlocksResult {
 	// Not supported in HTTP POST mode.
 	if c.config.HTTPPostMode {
 		return newFutureError(ErrWebsocketsRequired)
@@ -1158,7 +1544,7 @@ func (c *Client) NotifyBlocksAsync() FutureNotifyBlocksResult {
 	}
 
 	cmd := exccjson.NewNotifyBlocksCmd()
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // NotifyBlocks registers the client to receive notifications when blocks are
@@ -1175,6 +1561,13 @@ func (c *Client) NotifyBlocks() error {
 	return c.NotifyBlocksAsync().Receive()
 }
 
+// NotifyBlocksContext is like NotifyBlocks but the request is abandoned with
+// the error of the passed context once it is done, such as when it times out or
+// is canceled.
+func (c *Client) NotifyBlocksContext(ctx context.Context) error {
+	return c.NotifyBlocksAsyncContext(ctx).Receive()
+}
+
 // FutureNotifyWinningTicketsResult is a future promise to deliver the result of a
 // NotifyWinningTicketsAsync RPC invocation (or an applicable error).
 type FutureNotifyWinningTicketsResult chan *response
@@ -1194,6 +1587,14 @@ func (r FutureNotifyWinningTicketsResult) Receive() error {
 //
 // NOTE: This is a exccd extension and requires a websocket connection.
 func (c *Client) NotifyWinningTicketsAsync() FutureNotifyWinningTicketsResult {
+	return c.NotifyWinningTicketsAsyncContext(context.Background())
+}
+
+// NotifyWinningTicketsAsyncContext is like NotifyWinningTicketsAsync but the
+// request is abandoned once the passed context is done.
+//
+// See NotifyWinningTicketsContext for the blocking version.
+func (c *Client) NotifyWinningTicketsAsyncContext(ctx context.Context) FutureNotifyWinningTicketsResult {
 	// Not supported in HTTP POST mode.
 	if c.config.HTTPPostMode {
 		return newFutureError(ErrWebsocketsRequired)
@@ -1207,7 +1608,7 @@ func (c *Client) NotifyWinningTicketsAsync() FutureNotifyWinningTicketsResult {
 
 	cmd := exccjson.NewNotifyWinningTicketsCmd()
 
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // NotifyWinningTickets registers the client to receive notifications when
@@ -1225,6 +1626,13 @@ func (c *Client) NotifyWinningTickets() error {
 	return c.NotifyWinningTicketsAsync().Receive()
 }
 
+// NotifyWinningTicketsContext is like NotifyWinningTickets but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) NotifyWinningTicketsContext(ctx context.Context) error {
+	return c.NotifyWinningTicketsAsyncContext(ctx).Receive()
+}
+
 // FutureNotifySpentAndMissedTicketsResult is a future promise to deliver the result of a
 // NotifySpentAndMissedTicketsAsync RPC invocation (or an applicable error).
 type FutureNotifySpentAndMissedTicketsResult chan *response
@@ -1244,6 +1652,15 @@ func (r FutureNotifySpentAndMissedTicketsResult) Receive() error {
 //
 // NOTE: This is a exccd extension and requires a websocket connection.
 func (c *Client) NotifySpentAndMissedTicketsAsync() FutureNotifySpentAndMissedTicketsResult {
+	return c.NotifySpentAndMissedTicketsAsyncContext(context.Background())
+}
+
+// NotifySpentAndMissedTicketsAsyncContext is like
+// NotifySpentAndMissedTicketsAsync but the request is abandoned once the passed
+// context is done.
+//
+// See NotifySpentAndMissedTicketsContext for the blocking version.
+func (c *Client) NotifySpentAndMissedTicketsAsyncContext(ctx context.Context) FutureNotifySpentAndMissedTicketsResult {
 	// Not supported in HTTP POST mode.
 	if c.config.HTTPPostMode {
 		return newFutureError(ErrWebsocketsRequired)
@@ -1257,7 +1674,7 @@ func (c *Client) NotifySpentAndMissedTicketsAsync() FutureNotifySpentAndMissedTi
 
 	cmd := exccjson.NewNotifySpentAndMissedTicketsCmd()
 
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // NotifySpentAndMissedTickets registers the client to receive notifications when
@@ -1275,6 +1692,13 @@ func (c *Client) NotifySpentAndMissedTickets() error {
 	return c.NotifySpentAndMissedTicketsAsync().Receive()
 }
 
+// NotifySpentAndMissedTicketsContext is like NotifySpentAndMissedTickets but
+// the request is abandoned with the error of the passed context once it is
+// done, such as when it times out or is canceled.
+func (c *Client) NotifySpentAndMissedTicketsContext(ctx context.Context) error {
+	return c.NotifySpentAndMissedTicketsAsyncContext(ctx).Receive()
+}
+
 // FutureNotifyNewTicketsResult is a future promise to deliver the result of a
 // NotifyNewTicketsAsync RPC invocation (or an applicable error).
 type FutureNotifyNewTicketsResult chan *response
@@ -1294,6 +1718,14 @@ func (r FutureNotifyNewTicketsResult) Receive() error {
 //
 // NOTE: This is a exccd extension and requires a websocket connection.
 func (c *Client) NotifyNewTicketsAsync() FutureNotifyNewTicketsResult {
+	return c.NotifyNewTicketsAsyncContext(context.Background())
+}
+
+// NotifyNewTicketsAsyncContext is like NotifyNewTicketsAsync but the request is
+// abandoned once the passed context is done.
+//
+// See NotifyNewTicketsContext for the blocking version.
+func (c *Client) NotifyNewTicketsAsyncContext(ctx context.Context) FutureNotifyNewTicketsResult {
 	// Not supported in HTTP POST mode.
 	if c.config.HTTPPostMode {
 		return newFutureError(ErrWebsocketsRequired)
@@ -1307,7 +1739,7 @@ func (c *Client) NotifyNewTicketsAsync() FutureNotifyNewTicketsResult {
 
 	cmd := exccjson.NewNotifyNewTicketsCmd()
 
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // NotifyNewTickets registers the client to receive notifications when blocks are
@@ -1323,6 +1755,13 @@ func (c *Client) NotifyNewTickets() error {
 	return c.NotifyNewTicketsAsync().Receive()
 }
 
+// NotifyNewTicketsContext is like NotifyNewTickets but the request is abandoned
+// with the error of the passed context once it is done, such as when it times
+// out or is canceled.
+func (c *Client) NotifyNewTicketsContext(ctx context.Context) error {
+	return c.NotifyNewTicketsAsyncContext(ctx).Receive()
+}
+
 // FutureNotifyStakeDifficultyResult is a future promise to deliver the result of a
 // NotifyStakeDifficultyAsync RPC invocation (or an applicable error).
 type FutureNotifyStakeDifficultyResult chan *response
@@ -1342,6 +1781,14 @@ func (r FutureNotifyStakeDifficultyResult) Receive() error {
 //
 // NOTE: This is a exccd extension and requires a websocket connection.
 func (c *Client) NotifyStakeDifficultyAsync() FutureNotifyStakeDifficultyResult {
+	return c.NotifyStakeDifficultyAsyncContext(context.Background())
+}
+
+// NotifyStakeDifficultyAsyncContext is like NotifyStakeDifficultyAsync but the
+// request is abandoned once the passed context is done.
+//
+// See NotifyStakeDifficultyContext for the blocking version.
+func (c *Client) NotifyStakeDifficultyAsyncContext(ctx context.Context) FutureNotifyStakeDifficultyResult {
 	// Not supported in HTTP POST mode.
 	if c.config.HTTPPostMode {
 		return newFutureError(ErrWebsocketsRequired)
@@ -1355,7 +1802,7 @@ func (c *Client) NotifyStakeDifficultyAsync() FutureNotifyStakeDifficultyResult
 
 	cmd := exccjson.NewNotifyStakeDifficultyCmd()
 
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // NotifyStakeDifficulty registers the client to receive notifications when
@@ -1373,6 +1820,182 @@ func (c *Client) NotifyStakeDifficulty() error {
 	return c.NotifyStakeDifficultyAsync().Receive()
 }
 
+// NotifyStakeDifficultyContext is like NotifyStakeDifficulty but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) NotifyStakeDifficultyContext(ctx context.Context) error {
+	return c.NotifyStakeDifficultyAsyncContext(ctx).Receive()
+}
+
+// FutureNotifySyncProgressResult is a future promise to deliver the result of
+// a NotifySyncProgressAsync RPC invocation (or an applicable error).
+type FutureNotifySyncProgressResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// current progress of the chain sync.
+func (r FutureNotifySyncProgressResult) Receive() (*exccjson.GetSyncInfoResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// A nil result is returned when the client is not interested in
+	// notifications.
+	if res == nil {
+		return nil, nil
+	}
+
+	// Unmarshal result as a getsyncinfo result object.
+	var info exccjson.GetSyncInfoResult
+	err = json.Unmarshal(res, &info)
+	if err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+// NotifySyncProgressAsync returns an instance of a type that can be used to get
+// the result of the RPC at some future time by invoking the Receive function on
+// the returned instance.
+//
+// See NotifySyncProgress for the blocking version and more details.
+//
+// NOTE: This is a exccd extension and requires a websocket connection.
+func (c *Client) NotifySyncProgressAsync() FutureNotifySyncProgressResult {
+	return c.NotifySyncProgressAsyncContext(context.Background())
+}
+
+// NotifySyncProgressAsyncContext is like NotifySyncProgressAsync but the
+// request is abandoned once the passed context is done.
+//
+// See NotifySyncProgressContext for the blocking version.
+func (c *Client) NotifySyncProgressAsyncContext(ctx context.Context) FutureNotifySyncProgressResult {
+	// Not supported in HTTP POST mode.
+	if c.config.HTTPPostMode {
+		return newFutureError(ErrWebsocketsRequired)
+	}
+
+	// Ignore the notification if the client is not interested in
+	// notifications.
+	if c.ntfnHandlers == nil {
+		return newNilFutureResult()
+	}
+
+	cmd := exccjson.NewNotifySyncProgressCmd()
+	return c.sendCmdContext(ctx, cmd)
+}
+
+// NotifySyncProgress registers the client to receive notifications about the
+// progress of the chain sync of the server and returns its current progress.
+// The notifications are sent periodically while the chain is syncing and once
+// more when the sync completes, and are delivered to the notification handlers
+// associated with the client.  Calling this function has no effect if there are
+// no notification handlers and will result in an error if the client is
+// configured to run in HTTP POST mode.
+//
+// The notifications delivered as a result of this call will be via
+// OnSyncProgress.
+//
+// NOTE: This is a exccd extension and requires a websocket connection.
+func (c *Client) NotifySyncProgress() (*exccjson.GetSyncInfoResult, error) {
+	return c.NotifySyncProgressAsync().Receive()
+}
+
+// NotifySyncProgressContext is like NotifySyncProgress but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) NotifySyncProgressContext(ctx context.Context) (*exccjson.GetSyncInfoResult, error) {
+	return c.NotifySyncProgressAsyncContext(ctx).Receive()
+}
+
+// FutureNotifyTicketStatusResult is a future promise to deliver the result of
+// a NotifyTicketStatusAsync RPC invocation (or an applicable error).
+type FutureNotifyTicketStatusResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// current state of the watched tickets.
+func (r FutureNotifyTicketStatusResult) Receive() ([]exccjson.TicketInfoResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// A nil result is returned when the client is not interested in
+	// notifications.
+	if res == nil {
+		return nil, nil
+	}
+
+	// Unmarshal result as an array of ticket info results.
+	var tickets []exccjson.TicketInfoResult
+	err = json.Unmarshal(res, &tickets)
+	if err != nil {
+		return nil, err
+	}
+
+	return tickets, nil
+}
+
+// NotifyTicketStatusAsync returns an instance of a type that can be used to get
+// the result of the RPC at some future time by invoking the Receive function on
+// the returned instance.
+//
+// See NotifyTicketStatus for the blocking version and more details.
+//
+// NOTE: This is a exccd extension and requires a websocket connection.
+func (c *Client) NotifyTicketStatusAsync(tickets []*chainhash.Hash) FutureNotifyTicketStatusResult {
+	return c.NotifyTicketStatusAsyncContext(context.Background(), tickets)
+}
+
+// NotifyTicketStatusAsyncContext is like NotifyTicketStatusAsync but the
+// request is abandoned once the passed context is done.
+//
+// See NotifyTicketStatusContext for the blocking version.
+func (c *Client) NotifyTicketStatusAsyncContext(ctx context.Context, tickets []*chainhash.Hash) FutureNotifyTicketStatusResult {
+	// Not supported in HTTP POST mode.
+	if c.config.HTTPPostMode {
+		return newFutureError(ErrWebsocketsRequired)
+	}
+
+	// Ignore the notification if the client is not interested in
+	// notifications.
+	if c.ntfnHandlers == nil {
+		return newNilFutureResult()
+	}
+
+	hashes := make([]string, 0, len(tickets))
+	for _, ticket := range tickets {
+		hashes = append(hashes, ticket.String())
+	}
+	cmd := exccjson.NewNotifyTicketStatusCmd(hashes)
+
+	return c.sendCmdContext(ctx, cmd)
+}
+
+// NotifyTicketStatus registers the client to receive notifications when
+// blocks connected to or disconnected from the main chain change the status of
+// any of the passed tickets and returns their current state.  The server must
+// be running with the ticket lifecycle index enabled.  The notifications are
+// delivered to the notification handlers associated with the client.  Calling
+// this function has no effect if there are no notification handlers and will
+// result in an error if the client is configured to run in HTTP POST mode.
+//
+// The notifications delivered as a result of this call will be via
+// OnTicketStatusChanged.
+//
+// NOTE: This is a exccd extension and requires a websocket connection.
+func (c *Client) NotifyTicketStatus(tickets []*chainhash.Hash) ([]exccjson.TicketInfoResult, error) {
+	return c.NotifyTicketStatusAsync(tickets).Receive()
+}
+
+// NotifyTicketStatusContext is like NotifyTicketStatus but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) NotifyTicketStatusContext(ctx context.Context, tickets []*chainhash.Hash) ([]exccjson.TicketInfoResult, error) {
+	return c.NotifyTicketStatusAsyncContext(ctx, tickets).Receive()
+}
+
 // FutureNotifyNewTransactionsResult is a future promise to deliver the result
 // of a NotifyNewTransactionsAsync RPC invocation (or an applicable error).
 type FutureNotifyNewTransactionsResult chan *response
@@ -1392,6 +2015,14 @@ func (r FutureNotifyNewTransactionsResult) Receive() error {
 //
 // NOTE: This is a exccd extension and requires a websocket connection.
 func (c *Client) NotifyNewTransactionsAsync(verbose bool) FutureNotifyNewTransactionsResult {
+	return c.NotifyNewTransactionsAsyncContext(context.Background(), verbose)
+}
+
+// NotifyNewTransactionsAsyncContext is like NotifyNewTransactionsAsync but the
+// request is abandoned once the passed context is done.
+//
+// See NotifyNewTransactionsContext for the blocking version.
+func (c *Client) NotifyNewTransactionsAsyncContext(ctx context.Context, verbose bool) FutureNotifyNewTransactionsResult {
 	// Not supported in HTTP POST mode.
 	if c.config.HTTPPostMode {
 		return newFutureError(ErrWebsocketsRequired)
@@ -1404,7 +2035,7 @@ func (c *Client) NotifyNewTransactionsAsync(verbose bool) FutureNotifyNewTransac
 	}
 
 	cmd := exccjson.NewNotifyNewTransactionsCmd(&verbose)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // NotifyNewTransactions registers the client to receive notifications every
@@ -1422,6 +2053,13 @@ func (c *Client) NotifyNewTransactions(verbose bool) error {
 	return c.NotifyNewTransactionsAsync(verbose).Receive()
 }
 
+// NotifyNewTransactionsContext is like NotifyNewTransactions but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) NotifyNewTransactionsContext(ctx context.Context, verbose bool) error {
+	return c.NotifyNewTransactionsAsyncContext(ctx, verbose).Receive()
+}
+
 // FutureLoadTxFilterResult is a future promise to deliver the result
 // of a LoadTxFilterAsync RPC invocation (or an applicable error).
 type FutureLoadTxFilterResult chan *response
@@ -1442,6 +2080,15 @@ func (r FutureLoadTxFilterResult) Receive() error {
 // NOTE: This is a exccd extension and requires a websocket connection.
 func (c *Client) LoadTxFilterAsync(reload bool, addresses []exccutil.Address,
 	outPoints []wire.OutPoint) FutureLoadTxFilterResult {
+	return c.LoadTxFilterAsyncContext(context.Background(), reload, addresses, outPoints)
+}
+
+// LoadTxFilterAsyncContext is like LoadTxFilterAsync but the request is
+// abandoned once the passed context is done.
+//
+// See LoadTxFilterContext for the blocking version.
+func (c *Client) LoadTxFilterAsyncContext(ctx context.Context, reload bool, addresses []exccutil.Address,
+	outPoints []wire.OutPoint) FutureLoadTxFilterResult {
 
 	addrStrs := make([]string, len(addresses))
 	for i, a := range addresses {
@@ -1457,7 +2104,7 @@ func (c *Client) LoadTxFilterAsync(reload bool, addresses []exccutil.Address,
 	}
 
 	cmd := exccjson.NewLoadTxFilterCmd(reload, addrStrs, outPointObjects)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // LoadTxFilter loads, reloads, or adds data to a websocket client's transaction
@@ -1468,3 +2115,10 @@ func (c *Client) LoadTxFilterAsync(reload bool, addresses []exccutil.Address,
 func (c *Client) LoadTxFilter(reload bool, addresses []exccutil.Address, outPoints []wire.OutPoint) error {
 	return c.LoadTxFilterAsync(reload, addresses, outPoints).Receive()
 }
+
+// LoadTxFilterContext is like LoadTxFilter but the request is abandoned with
+// the error of the passed context once it is done, such as when it times out or
+// is canceled.
+func (c *Client) LoadTxFilterContext(ctx context.Context, reload bool, addresses []exccutil.Address, outPoints []wire.OutPoint) error {
+	return c.LoadTxFilterAsyncContext(ctx, reload, addresses, outPoints).Receive()
+}
@@ -7,6 +7,7 @@
 package rpcclient
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 
@@ -29,6 +30,14 @@ func (r FutureRawResult) Receive() (json.RawMessage, error) {
 //
 // See RawRequest for the blocking version and more details.
 func (c *Client) RawRequestAsync(method string, params []json.RawMessage) FutureRawResult {
+	return c.RawRequestAsyncContext(context.Background(), method, params)
+}
+
+// RawRequestAsyncContext is like RawRequestAsync but the request is abandoned
+// once the passed context is done.
+//
+// See RawRequestContext for the blocking version.
+func (c *Client) RawRequestAsyncContext(ctx context.Context, method string, params []json.RawMessage) FutureRawResult {
 	// Method may not be empty.
 	if method == "" {
 		return newFutureError(errors.New("no method"))
@@ -65,9 +74,8 @@ func (c *Client) RawRequestAsync(method string, params []json.RawMessage) Future
 		marshalledJSON: marshalledJSON,
 		responseChan:   responseChan,
 	}
-	c.sendRequest(jReq)
 
-	return responseChan
+	return c.sendRequestContext(ctx, jReq)
 }
 
 // RawRequest allows the caller to send a raw or custom request to the server.
@@ -78,3 +86,10 @@ func (c *Client) RawRequestAsync(method string, params []json.RawMessage) Future
 func (c *Client) RawRequest(method string, params []json.RawMessage) (json.RawMessage, error) {
 	return c.RawRequestAsync(method, params).Receive()
 }
+
+// RawRequestContext is like RawRequest but the request is abandoned with the
+// error of the passed context once it is done, such as when it times out or is
+// canceled.
+func (c *Client) RawRequestContext(ctx context.Context, method string, params []json.RawMessage) (json.RawMessage, error) {
+	return c.RawRequestAsyncContext(ctx, method, params).Receive()
+}
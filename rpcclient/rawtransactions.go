@@ -8,8 +8,10 @@ package rpcclient
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"encoding/json"
+	"time"
 
 	"github.com/EXCCoin/exccd/chaincfg/chainhash"
 	"github.com/EXCCoin/exccd/exccjson"
@@ -99,13 +101,21 @@ func (r FutureGetRawTransactionResult) Receive() (*exccutil.Tx, error) {
 //
 // See GetRawTransaction for the blocking version and more details.
 func (c *Client) GetRawTransactionAsync(txHash *chainhash.Hash) FutureGetRawTransactionResult {
+	return c.GetRawTransactionAsyncContext(context.Background(), txHash)
+}
+
+// GetRawTransactionAsyncContext is like GetRawTransactionAsync but the request
+// is abandoned once the passed context is done.
+//
+// See GetRawTransactionContext for the blocking version.
+func (c *Client) GetRawTransactionAsyncContext(ctx context.Context, txHash *chainhash.Hash) FutureGetRawTransactionResult {
 	hash := ""
 	if txHash != nil {
 		hash = txHash.String()
 	}
 
 	cmd := exccjson.NewGetRawTransactionCmd(hash, exccjson.Int(0))
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetRawTransaction returns a transaction given its hash.
@@ -116,6 +126,13 @@ func (c *Client) GetRawTransaction(txHash *chainhash.Hash) (*exccutil.Tx, error)
 	return c.GetRawTransactionAsync(txHash).Receive()
 }
 
+// GetRawTransactionContext is like GetRawTransaction but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) GetRawTransactionContext(ctx context.Context, txHash *chainhash.Hash) (*exccutil.Tx, error) {
+	return c.GetRawTransactionAsyncContext(ctx, txHash).Receive()
+}
+
 // FutureGetRawTransactionVerboseResult is a future promise to deliver the
 // result of a GetRawTransactionVerboseAsync RPC invocation (or an applicable
 // error).
@@ -145,13 +162,21 @@ func (r FutureGetRawTransactionVerboseResult) Receive() (*exccjson.TxRawResult,
 //
 // See GetRawTransactionVerbose for the blocking version and more details.
 func (c *Client) GetRawTransactionVerboseAsync(txHash *chainhash.Hash) FutureGetRawTransactionVerboseResult {
+	return c.GetRawTransactionVerboseAsyncContext(context.Background(), txHash)
+}
+
+// GetRawTransactionVerboseAsyncContext is like GetRawTransactionVerboseAsync
+// but the request is abandoned once the passed context is done.
+//
+// See GetRawTransactionVerboseContext for the blocking version.
+func (c *Client) GetRawTransactionVerboseAsyncContext(ctx context.Context, txHash *chainhash.Hash) FutureGetRawTransactionVerboseResult {
 	hash := ""
 	if txHash != nil {
 		hash = txHash.String()
 	}
 
 	cmd := exccjson.NewGetRawTransactionCmd(hash, exccjson.Int(1))
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetRawTransactionVerbose returns information about a transaction given
@@ -162,6 +187,56 @@ func (c *Client) GetRawTransactionVerbose(txHash *chainhash.Hash) (*exccjson.TxR
 	return c.GetRawTransactionVerboseAsync(txHash).Receive()
 }
 
+// GetRawTransactionVerboseContext is like GetRawTransactionVerbose but the
+// request is abandoned with the error of the passed context once it is done,
+// such as when it times out or is canceled.
+func (c *Client) GetRawTransactionVerboseContext(ctx context.Context, txHash *chainhash.Hash) (*exccjson.TxRawResult, error) {
+	return c.GetRawTransactionVerboseAsyncContext(ctx, txHash).Receive()
+}
+
+// GetRawTransactionVerbosePrevOutsAsync returns an instance of a type that can
+// be used to get the result of the RPC at some future time by invoking the
+// Receive function on the returned instance.
+//
+// See GetRawTransactionVerbosePrevOuts for the blocking version and more
+// details.
+func (c *Client) GetRawTransactionVerbosePrevOutsAsync(txHash *chainhash.Hash) FutureGetRawTransactionVerboseResult {
+	return c.GetRawTransactionVerbosePrevOutsAsyncContext(
+		context.Background(), txHash)
+}
+
+// GetRawTransactionVerbosePrevOutsAsyncContext is like
+// GetRawTransactionVerbosePrevOutsAsync but the request is abandoned once the
+// passed context is done.
+//
+// See GetRawTransactionVerbosePrevOutsContext for the blocking version.
+func (c *Client) GetRawTransactionVerbosePrevOutsAsyncContext(ctx context.Context, txHash *chainhash.Hash) FutureGetRawTransactionVerboseResult {
+	hash := ""
+	if txHash != nil {
+		hash = txHash.String()
+	}
+
+	cmd := exccjson.NewGetRawTransactionCmd(hash, exccjson.Int(2))
+	return c.sendCmdContext(ctx, cmd)
+}
+
+// GetRawTransactionVerbosePrevOuts returns information about a transaction
+// given its hash, including the previous output spent by each of its inputs.
+//
+// Previous outputs which were already spent are only included when the server
+// has the transaction index enabled.
+func (c *Client) GetRawTransactionVerbosePrevOuts(txHash *chainhash.Hash) (*exccjson.TxRawResult, error) {
+	return c.GetRawTransactionVerbosePrevOutsAsync(txHash).Receive()
+}
+
+// GetRawTransactionVerbosePrevOutsContext is like
+// GetRawTransactionVerbosePrevOuts but the request is abandoned with the error
+// of the passed context once it is done, such as when it times out or is
+// canceled.
+func (c *Client) GetRawTransactionVerbosePrevOutsContext(ctx context.Context, txHash *chainhash.Hash) (*exccjson.TxRawResult, error) {
+	return c.GetRawTransactionVerbosePrevOutsAsyncContext(ctx, txHash).Receive()
+}
+
 // FutureDecodeRawTransactionResult is a future promise to deliver the result
 // of a DecodeRawTransactionAsync RPC invocation (or an applicable error).
 type FutureDecodeRawTransactionResult chan *response
@@ -190,9 +265,17 @@ func (r FutureDecodeRawTransactionResult) Receive() (*exccjson.TxRawResult, erro
 //
 // See DecodeRawTransaction for the blocking version and more details.
 func (c *Client) DecodeRawTransactionAsync(serializedTx []byte) FutureDecodeRawTransactionResult {
+	return c.DecodeRawTransactionAsyncContext(context.Background(), serializedTx)
+}
+
+// DecodeRawTransactionAsyncContext is like DecodeRawTransactionAsync but the
+// request is abandoned once the passed context is done.
+//
+// See DecodeRawTransactionContext for the blocking version.
+func (c *Client) DecodeRawTransactionAsyncContext(ctx context.Context, serializedTx []byte) FutureDecodeRawTransactionResult {
 	txHex := hex.EncodeToString(serializedTx)
 	cmd := exccjson.NewDecodeRawTransactionCmd(txHex)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // DecodeRawTransaction returns information about a transaction given its
@@ -201,6 +284,13 @@ func (c *Client) DecodeRawTransaction(serializedTx []byte) (*exccjson.TxRawResul
 	return c.DecodeRawTransactionAsync(serializedTx).Receive()
 }
 
+// DecodeRawTransactionContext is like DecodeRawTransaction but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) DecodeRawTransactionContext(ctx context.Context, serializedTx []byte) (*exccjson.TxRawResult, error) {
+	return c.DecodeRawTransactionAsyncContext(ctx, serializedTx).Receive()
+}
+
 // FutureCreateRawTransactionResult is a future promise to deliver the result
 // of a CreateRawTransactionAsync RPC invocation (or an applicable error).
 type FutureCreateRawTransactionResult chan *response
@@ -242,13 +332,22 @@ func (r FutureCreateRawTransactionResult) Receive() (*wire.MsgTx, error) {
 // See CreateRawTransaction for the blocking version and more details.
 func (c *Client) CreateRawTransactionAsync(inputs []exccjson.TransactionInput,
 	amounts map[exccutil.Address]exccutil.Amount, lockTime *int64) FutureCreateRawTransactionResult {
+	return c.CreateRawTransactionAsyncContext(context.Background(), inputs, amounts, lockTime)
+}
+
+// CreateRawTransactionAsyncContext is like CreateRawTransactionAsync but the
+// request is abandoned once the passed context is done.
+//
+// See CreateRawTransactionContext for the blocking version.
+func (c *Client) CreateRawTransactionAsyncContext(ctx context.Context, inputs []exccjson.TransactionInput,
+	amounts map[exccutil.Address]exccutil.Amount, lockTime *int64) FutureCreateRawTransactionResult {
 
 	convertedAmts := make(map[string]float64, len(amounts))
 	for addr, amount := range amounts {
 		convertedAmts[addr.String()] = amount.ToCoin()
 	}
 	cmd := exccjson.NewCreateRawTransactionCmd(inputs, convertedAmts, lockTime)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // CreateRawTransaction returns a new transaction spending the provided inputs
@@ -259,6 +358,15 @@ func (c *Client) CreateRawTransaction(inputs []exccjson.TransactionInput,
 	return c.CreateRawTransactionAsync(inputs, amounts, lockTime).Receive()
 }
 
+// CreateRawTransactionContext is like CreateRawTransaction but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) CreateRawTransactionContext(ctx context.Context, inputs []exccjson.TransactionInput,
+	amounts map[exccutil.Address]exccutil.Amount, lockTime *int64) (*wire.MsgTx, error) {
+
+	return c.CreateRawTransactionAsyncContext(ctx, inputs, amounts, lockTime).Receive()
+}
+
 // FutureCreateRawSStxResult is a future promise to deliver the result
 // of a CreateRawSStxAsync RPC invocation (or an applicable error).
 type FutureCreateRawSStxResult chan *response
@@ -311,6 +419,16 @@ type SStxCommitOut struct {
 func (c *Client) CreateRawSStxAsync(inputs []exccjson.SStxInput,
 	amount map[exccutil.Address]exccutil.Amount,
 	couts []SStxCommitOut) FutureCreateRawSStxResult {
+	return c.CreateRawSStxAsyncContext(context.Background(), inputs, amount, couts)
+}
+
+// CreateRawSStxAsyncContext is like CreateRawSStxAsync but the request is
+// abandoned once the passed context is done.
+//
+// See CreateRawSStxContext for the blocking version.
+func (c *Client) CreateRawSStxAsyncContext(ctx context.Context, inputs []exccjson.SStxInput,
+	amount map[exccutil.Address]exccutil.Amount,
+	couts []SStxCommitOut) FutureCreateRawSStxResult {
 
 	convertedAmt := make(map[string]int64, len(amount))
 	for addr, amt := range amount {
@@ -328,7 +446,7 @@ func (c *Client) CreateRawSStxAsync(inputs []exccjson.SStxInput,
 		convertedAmt,
 		convertedCouts)
 
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // CreateRawSStx returns a new transaction spending the provided inputs
@@ -340,6 +458,16 @@ func (c *Client) CreateRawSStx(inputs []exccjson.SStxInput,
 	return c.CreateRawSStxAsync(inputs, amount, couts).Receive()
 }
 
+// CreateRawSStxContext is like CreateRawSStx but the request is abandoned with
+// the error of the passed context once it is done, such as when it times out or
+// is canceled.
+func (c *Client) CreateRawSStxContext(ctx context.Context, inputs []exccjson.SStxInput,
+	amount map[exccutil.Address]exccutil.Amount,
+	couts []SStxCommitOut) (*wire.MsgTx, error) {
+
+	return c.CreateRawSStxAsyncContext(ctx, inputs, amount, couts).Receive()
+}
+
 // FutureCreateRawSSGenTxResult is a future promise to deliver the result
 // of a CreateRawSSGenTxAsync RPC invocation (or an applicable error).
 type FutureCreateRawSSGenTxResult chan *response
@@ -381,9 +509,18 @@ func (r FutureCreateRawSSGenTxResult) Receive() (*wire.MsgTx, error) {
 // See CreateRawSSGenTx for the blocking version and more details.
 func (c *Client) CreateRawSSGenTxAsync(inputs []exccjson.TransactionInput,
 	votebits uint16) FutureCreateRawSSGenTxResult {
+	return c.CreateRawSSGenTxAsyncContext(context.Background(), inputs, votebits)
+}
+
+// CreateRawSSGenTxAsyncContext is like CreateRawSSGenTxAsync but the request is
+// abandoned once the passed context is done.
+//
+// See CreateRawSSGenTxContext for the blocking version.
+func (c *Client) CreateRawSSGenTxAsyncContext(ctx context.Context, inputs []exccjson.TransactionInput,
+	votebits uint16) FutureCreateRawSSGenTxResult {
 
 	cmd := exccjson.NewCreateRawSSGenTxCmd(inputs, votebits)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // CreateRawSSGenTx returns a new transaction spending the provided inputs
@@ -394,6 +531,15 @@ func (c *Client) CreateRawSSGenTx(inputs []exccjson.TransactionInput,
 	return c.CreateRawSSGenTxAsync(inputs, votebits).Receive()
 }
 
+// CreateRawSSGenTxContext is like CreateRawSSGenTx but the request is abandoned
+// with the error of the passed context once it is done, such as when it times
+// out or is canceled.
+func (c *Client) CreateRawSSGenTxContext(ctx context.Context, inputs []exccjson.TransactionInput,
+	votebits uint16) (*wire.MsgTx, error) {
+
+	return c.CreateRawSSGenTxAsyncContext(ctx, inputs, votebits).Receive()
+}
+
 // FutureCreateRawSSRtxResult is a future promise to deliver the result
 // of a CreateRawSSRtxAsync RPC invocation (or an applicable error).
 type FutureCreateRawSSRtxResult chan *response
@@ -434,9 +580,17 @@ func (r FutureCreateRawSSRtxResult) Receive() (*wire.MsgTx, error) {
 //
 // See CreateRawSSRtx for the blocking version and more details.
 func (c *Client) CreateRawSSRtxAsync(inputs []exccjson.TransactionInput, fee exccutil.Amount) FutureCreateRawSSRtxResult {
+	return c.CreateRawSSRtxAsyncContext(context.Background(), inputs, fee)
+}
+
+// CreateRawSSRtxAsyncContext is like CreateRawSSRtxAsync but the request is
+// abandoned once the passed context is done.
+//
+// See CreateRawSSRtxContext for the blocking version.
+func (c *Client) CreateRawSSRtxAsyncContext(ctx context.Context, inputs []exccjson.TransactionInput, fee exccutil.Amount) FutureCreateRawSSRtxResult {
 	feeF64 := fee.ToCoin()
 	cmd := exccjson.NewCreateRawSSRtxCmd(inputs, &feeF64)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // CreateRawSSRtx returns a new SSR transactionm (revoking an sstx).
@@ -444,6 +598,13 @@ func (c *Client) CreateRawSSRtx(inputs []exccjson.TransactionInput, fee exccutil
 	return c.CreateRawSSRtxAsync(inputs, fee).Receive()
 }
 
+// CreateRawSSRtxContext is like CreateRawSSRtx but the request is abandoned
+// with the error of the passed context once it is done, such as when it times
+// out or is canceled.
+func (c *Client) CreateRawSSRtxContext(ctx context.Context, inputs []exccjson.TransactionInput, fee exccutil.Amount) (*wire.MsgTx, error) {
+	return c.CreateRawSSRtxAsyncContext(ctx, inputs, fee).Receive()
+}
+
 // FutureSendRawTransactionResult is a future promise to deliver the result
 // of a SendRawTransactionAsync RPC invocation (or an applicable error).
 type FutureSendRawTransactionResult chan *response
@@ -473,18 +634,43 @@ func (r FutureSendRawTransactionResult) Receive() (*chainhash.Hash, error) {
 //
 // See SendRawTransaction for the blocking version and more details.
 func (c *Client) SendRawTransactionAsync(tx *wire.MsgTx, allowHighFees bool) FutureSendRawTransactionResult {
-	txHex := ""
-	if tx != nil {
-		// Serialize the transaction and convert to hex string.
-		buf := bytes.NewBuffer(make([]byte, 0, tx.SerializeSize()))
-		if err := tx.Serialize(buf); err != nil {
-			return newFutureError(err)
-		}
-		txHex = hex.EncodeToString(buf.Bytes())
+	return c.SendRawTransactionAsyncContext(context.Background(), tx, allowHighFees)
+}
+
+// SendRawTransactionAsyncContext is like SendRawTransactionAsync but the
+// request is abandoned once the passed context is done.
+//
+// See SendRawTransactionContext for the blocking version.
+func (c *Client) SendRawTransactionAsyncContext(ctx context.Context, tx *wire.MsgTx, allowHighFees bool) FutureSendRawTransactionResult {
+	return c.sendRawTransactionAsyncContext(ctx, tx, &allowHighFees, nil)
+}
+
+// encodeRawTransaction returns the passed transaction serialized as a
+// hex-encoded string, which is empty for a nil transaction.
+func encodeRawTransaction(tx *wire.MsgTx) (string, error) {
+	if tx == nil {
+		return "", nil
 	}
 
-	cmd := exccjson.NewSendRawTransactionCmd(txHex, &allowHighFees)
-	return c.sendCmd(cmd)
+	// Serialize the transaction and convert to hex string.
+	buf := bytes.NewBuffer(make([]byte, 0, tx.SerializeSize()))
+	if err := tx.Serialize(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf.Bytes()), nil
+}
+
+// sendRawTransactionAsyncContext sends the sendrawtransaction command for the
+// passed transaction with the passed optional parameters.
+func (c *Client) sendRawTransactionAsyncContext(ctx context.Context, tx *wire.MsgTx, allowHighFees *bool, maxFeeRate *float64) FutureSendRawTransactionResult {
+	txHex, err := encodeRawTransaction(tx)
+	if err != nil {
+		return newFutureError(err)
+	}
+
+	cmd := exccjson.NewSendRawTransactionCmd(txHex, allowHighFees,
+		maxFeeRate)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // SendRawTransaction submits the encoded transaction to the server which will
@@ -493,6 +679,122 @@ func (c *Client) SendRawTransaction(tx *wire.MsgTx, allowHighFees bool) (*chainh
 	return c.SendRawTransactionAsync(tx, allowHighFees).Receive()
 }
 
+// SendRawTransactionContext is like SendRawTransaction but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) SendRawTransactionContext(ctx context.Context, tx *wire.MsgTx, allowHighFees bool) (*chainhash.Hash, error) {
+	return c.SendRawTransactionAsyncContext(ctx, tx, allowHighFees).Receive()
+}
+
+// SendRawTransactionMaxFeeRateAsync returns an instance of a type that can be
+// used to get the result of the RPC at some future time by invoking the Receive
+// function on the returned instance.
+//
+// See SendRawTransactionMaxFeeRate for the blocking version and more details.
+func (c *Client) SendRawTransactionMaxFeeRateAsync(tx *wire.MsgTx, maxFeeRate exccutil.Amount) FutureSendRawTransactionResult {
+	return c.SendRawTransactionMaxFeeRateAsyncContext(context.Background(),
+		tx, maxFeeRate)
+}
+
+// SendRawTransactionMaxFeeRateAsyncContext is like
+// SendRawTransactionMaxFeeRateAsync but the request is abandoned once the
+// passed context is done.
+//
+// See SendRawTransactionMaxFeeRateContext for the blocking version.
+func (c *Client) SendRawTransactionMaxFeeRateAsyncContext(ctx context.Context, tx *wire.MsgTx, maxFeeRate exccutil.Amount) FutureSendRawTransactionResult {
+	return c.sendRawTransactionAsyncContext(ctx, tx, nil,
+		exccjson.Float64(maxFeeRate.ToCoin()))
+}
+
+// SendRawTransactionMaxFeeRate submits the encoded transaction to the server
+// which will then relay it to the network unless it pays a fee rate per kB
+// above the passed maximum.  A maximum of zero disables the check.
+//
+// See SendRawTransaction to submit a transaction with the default maximum fee
+// rate of the server instead.
+func (c *Client) SendRawTransactionMaxFeeRate(tx *wire.MsgTx, maxFeeRate exccutil.Amount) (*chainhash.Hash, error) {
+	return c.SendRawTransactionMaxFeeRateAsync(tx, maxFeeRate).Receive()
+}
+
+// SendRawTransactionMaxFeeRateContext is like SendRawTransactionMaxFeeRate but
+// the request is abandoned with the error of the passed context once it is
+// done, such as when it times out or is canceled.
+func (c *Client) SendRawTransactionMaxFeeRateContext(ctx context.Context, tx *wire.MsgTx, maxFeeRate exccutil.Amount) (*chainhash.Hash, error) {
+	return c.SendRawTransactionMaxFeeRateAsyncContext(ctx, tx,
+		maxFeeRate).Receive()
+}
+
+// FutureSendRawTransactionAndWaitResult is a future promise to deliver the
+// result of a SendRawTransactionAndWaitAsync RPC invocation (or an applicable
+// error).
+type FutureSendRawTransactionAndWaitResult chan *response
+
+// Receive waits for the response promised by the future and returns the hash
+// of the submitted transaction along with the block which includes it and its
+// number of confirmations.
+func (r FutureSendRawTransactionAndWaitResult) Receive() (*exccjson.SendRawTransactionAndWaitResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a sendrawtransactionandwait result object.
+	var result exccjson.SendRawTransactionAndWaitResult
+	err = json.Unmarshal(res, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// SendRawTransactionAndWaitAsync returns an instance of a type that can be
+// used to get the result of the RPC at some future time by invoking the Receive
+// function on the returned instance.
+//
+// See SendRawTransactionAndWait for the blocking version and more details.
+func (c *Client) SendRawTransactionAndWaitAsync(tx *wire.MsgTx, confirmations uint32, timeout time.Duration) FutureSendRawTransactionAndWaitResult {
+	return c.SendRawTransactionAndWaitAsyncContext(context.Background(), tx,
+		confirmations, timeout)
+}
+
+// SendRawTransactionAndWaitAsyncContext is like SendRawTransactionAndWaitAsync
+// but the request is abandoned once the passed context is done.
+//
+// See SendRawTransactionAndWaitContext for the blocking version.
+func (c *Client) SendRawTransactionAndWaitAsyncContext(ctx context.Context, tx *wire.MsgTx, confirmations uint32, timeout time.Duration) FutureSendRawTransactionAndWaitResult {
+	txHex, err := encodeRawTransaction(tx)
+	if err != nil {
+		return newFutureError(err)
+	}
+
+	timeoutSecs := uint32(timeout / time.Second)
+	cmd := exccjson.NewSendRawTransactionAndWaitCmd(txHex, &confirmations,
+		&timeoutSecs, nil, nil)
+	return c.sendCmdContext(ctx, cmd)
+}
+
+// SendRawTransactionAndWait submits the encoded transaction to the server
+// which will then relay it to the network and waits until it is mined in a
+// block with the passed number of confirmations or the passed timeout, which
+// is rounded down to seconds, expires.  A timeout of zero waits without a
+// limit.
+//
+// The returned number of confirmations is lower than requested when the
+// timeout expired first.
+func (c *Client) SendRawTransactionAndWait(tx *wire.MsgTx, confirmations uint32, timeout time.Duration) (*exccjson.SendRawTransactionAndWaitResult, error) {
+	return c.SendRawTransactionAndWaitAsync(tx, confirmations,
+		timeout).Receive()
+}
+
+// SendRawTransactionAndWaitContext is like SendRawTransactionAndWait but the
+// request is abandoned with the error of the passed context once it is done,
+// such as when it times out or is canceled.
+func (c *Client) SendRawTransactionAndWaitContext(ctx context.Context, tx *wire.MsgTx, confirmations uint32, timeout time.Duration) (*exccjson.SendRawTransactionAndWaitResult, error) {
+	return c.SendRawTransactionAndWaitAsyncContext(ctx, tx, confirmations,
+		timeout).Receive()
+}
+
 // FutureSignRawTransactionResult is a future promise to deliver the result
 // of one of the SignRawTransactionAsync family of RPC invocations (or an
 // applicable error).
@@ -534,6 +836,14 @@ func (r FutureSignRawTransactionResult) Receive() (*wire.MsgTx, bool, error) {
 //
 // See SignRawTransaction for the blocking version and more details.
 func (c *Client) SignRawTransactionAsync(tx *wire.MsgTx) FutureSignRawTransactionResult {
+	return c.SignRawTransactionAsyncContext(context.Background(), tx)
+}
+
+// SignRawTransactionAsyncContext is like SignRawTransactionAsync but the
+// request is abandoned once the passed context is done.
+//
+// See SignRawTransactionContext for the blocking version.
+func (c *Client) SignRawTransactionAsyncContext(ctx context.Context, tx *wire.MsgTx) FutureSignRawTransactionResult {
 	txHex := ""
 	if tx != nil {
 		// Serialize the transaction and convert to hex string.
@@ -545,7 +855,7 @@ func (c *Client) SignRawTransactionAsync(tx *wire.MsgTx) FutureSignRawTransactio
 	}
 
 	cmd := exccjson.NewSignRawTransactionCmd(txHex, nil, nil, nil)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // SignRawTransaction signs inputs for the passed transaction and returns the
@@ -559,12 +869,27 @@ func (c *Client) SignRawTransaction(tx *wire.MsgTx) (*wire.MsgTx, bool, error) {
 	return c.SignRawTransactionAsync(tx).Receive()
 }
 
+// SignRawTransactionContext is like SignRawTransaction but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) SignRawTransactionContext(ctx context.Context, tx *wire.MsgTx) (*wire.MsgTx, bool, error) {
+	return c.SignRawTransactionAsyncContext(ctx, tx).Receive()
+}
+
 // SignRawTransaction2Async returns an instance of a type that can be used to
 // get the result of the RPC at some future time by invoking the Receive
 // function on the returned instance.
 //
 // See SignRawTransaction2 for the blocking version and more details.
 func (c *Client) SignRawTransaction2Async(tx *wire.MsgTx, inputs []exccjson.RawTxInput) FutureSignRawTransactionResult {
+	return c.SignRawTransaction2AsyncContext(context.Background(), tx, inputs)
+}
+
+// SignRawTransaction2AsyncContext is like SignRawTransaction2Async but the
+// request is abandoned once the passed context is done.
+//
+// See SignRawTransaction2Context for the blocking version.
+func (c *Client) SignRawTransaction2AsyncContext(ctx context.Context, tx *wire.MsgTx, inputs []exccjson.RawTxInput) FutureSignRawTransactionResult {
 	txHex := ""
 	if tx != nil {
 		// Serialize the transaction and convert to hex string.
@@ -576,7 +901,7 @@ func (c *Client) SignRawTransaction2Async(tx *wire.MsgTx, inputs []exccjson.RawT
 	}
 
 	cmd := exccjson.NewSignRawTransactionCmd(txHex, &inputs, nil, nil)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // SignRawTransaction2 signs inputs for the passed transaction given the list
@@ -593,6 +918,13 @@ func (c *Client) SignRawTransaction2(tx *wire.MsgTx, inputs []exccjson.RawTxInpu
 	return c.SignRawTransaction2Async(tx, inputs).Receive()
 }
 
+// SignRawTransaction2Context is like SignRawTransaction2 but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) SignRawTransaction2Context(ctx context.Context, tx *wire.MsgTx, inputs []exccjson.RawTxInput) (*wire.MsgTx, bool, error) {
+	return c.SignRawTransaction2AsyncContext(ctx, tx, inputs).Receive()
+}
+
 // SignRawTransaction3Async returns an instance of a type that can be used to
 // get the result of the RPC at some future time by invoking the Receive
 // function on the returned instance.
@@ -601,6 +933,16 @@ func (c *Client) SignRawTransaction2(tx *wire.MsgTx, inputs []exccjson.RawTxInpu
 func (c *Client) SignRawTransaction3Async(tx *wire.MsgTx,
 	inputs []exccjson.RawTxInput,
 	privKeysWIF []string) FutureSignRawTransactionResult {
+	return c.SignRawTransaction3AsyncContext(context.Background(), tx, inputs, privKeysWIF)
+}
+
+// SignRawTransaction3AsyncContext is like SignRawTransaction3Async but the
+// request is abandoned once the passed context is done.
+//
+// See SignRawTransaction3Context for the blocking version.
+func (c *Client) SignRawTransaction3AsyncContext(ctx context.Context, tx *wire.MsgTx,
+	inputs []exccjson.RawTxInput,
+	privKeysWIF []string) FutureSignRawTransactionResult {
 
 	txHex := ""
 	if tx != nil {
@@ -614,7 +956,7 @@ func (c *Client) SignRawTransaction3Async(tx *wire.MsgTx,
 
 	cmd := exccjson.NewSignRawTransactionCmd(txHex, &inputs, &privKeysWIF,
 		nil)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // SignRawTransaction3 signs inputs for the passed transaction given the list
@@ -641,6 +983,16 @@ func (c *Client) SignRawTransaction3(tx *wire.MsgTx,
 	return c.SignRawTransaction3Async(tx, inputs, privKeysWIF).Receive()
 }
 
+// SignRawTransaction3Context is like SignRawTransaction3 but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) SignRawTransaction3Context(ctx context.Context, tx *wire.MsgTx,
+	inputs []exccjson.RawTxInput,
+	privKeysWIF []string) (*wire.MsgTx, bool, error) {
+
+	return c.SignRawTransaction3AsyncContext(ctx, tx, inputs, privKeysWIF).Receive()
+}
+
 // SignRawTransaction4Async returns an instance of a type that can be used to
 // get the result of the RPC at some future time by invoking the Receive
 // function on the returned instance.
@@ -649,6 +1001,16 @@ func (c *Client) SignRawTransaction3(tx *wire.MsgTx,
 func (c *Client) SignRawTransaction4Async(tx *wire.MsgTx,
 	inputs []exccjson.RawTxInput, privKeysWIF []string,
 	hashType SigHashType) FutureSignRawTransactionResult {
+	return c.SignRawTransaction4AsyncContext(context.Background(), tx, inputs, privKeysWIF, hashType)
+}
+
+// SignRawTransaction4AsyncContext is like SignRawTransaction4Async but the
+// request is abandoned once the passed context is done.
+//
+// See SignRawTransaction4Context for the blocking version.
+func (c *Client) SignRawTransaction4AsyncContext(ctx context.Context, tx *wire.MsgTx,
+	inputs []exccjson.RawTxInput, privKeysWIF []string,
+	hashType SigHashType) FutureSignRawTransactionResult {
 
 	txHex := ""
 	if tx != nil {
@@ -662,7 +1024,7 @@ func (c *Client) SignRawTransaction4Async(tx *wire.MsgTx,
 
 	cmd := exccjson.NewSignRawTransactionCmd(txHex, &inputs, &privKeysWIF,
 		exccjson.String(string(hashType)))
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // SignRawTransaction4 signs inputs for the passed transaction using the
@@ -692,12 +1054,93 @@ func (c *Client) SignRawTransaction4(tx *wire.MsgTx,
 		hashType).Receive()
 }
 
+// SignRawTransaction4Context is like SignRawTransaction4 but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) SignRawTransaction4Context(ctx context.Context, tx *wire.MsgTx,
+	inputs []exccjson.RawTxInput, privKeysWIF []string,
+	hashType SigHashType) (*wire.MsgTx, bool, error) {
+
+	return c.SignRawTransaction4AsyncContext(ctx, tx, inputs, privKeysWIF,
+		hashType).Receive()
+}
+
+// SignRawTransactionWithKeyAsync returns an instance of a type that can be
+// used to get the result of the RPC at some future time by invoking the Receive
+// function on the returned instance.
+//
+// See SignRawTransactionWithKey for the blocking version and more details.
+func (c *Client) SignRawTransactionWithKeyAsync(tx *wire.MsgTx,
+	privKeysWIF []string, inputs []exccjson.RawTxInput,
+	hashType SigHashType) FutureSignRawTransactionResult {
+	return c.SignRawTransactionWithKeyAsyncContext(context.Background(), tx, privKeysWIF, inputs, hashType)
+}
+
+// SignRawTransactionWithKeyAsyncContext is like SignRawTransactionWithKeyAsync
+// but the request is abandoned once the passed context is done.
+//
+// See SignRawTransactionWithKeyContext for the blocking version.
+func (c *Client) SignRawTransactionWithKeyAsyncContext(ctx context.Context, tx *wire.MsgTx,
+	privKeysWIF []string, inputs []exccjson.RawTxInput,
+	hashType SigHashType) FutureSignRawTransactionResult {
+
+	txHex := ""
+	if tx != nil {
+		// Serialize the transaction and convert to hex string.
+		buf := bytes.NewBuffer(make([]byte, 0, tx.SerializeSize()))
+		if err := tx.Serialize(buf); err != nil {
+			return newFutureError(err)
+		}
+		txHex = hex.EncodeToString(buf.Bytes())
+	}
+
+	cmd := exccjson.NewSignRawTransactionWithKeyCmd(txHex, privKeysWIF,
+		&inputs, exccjson.String(string(hashType)))
+	return c.sendCmdContext(ctx, cmd)
+}
+
+// SignRawTransactionWithKey signs inputs for the passed transaction using the
+// specified signature hash type and WIF-encoded private keys and returns the
+// signed transaction as well as whether or not all inputs are now signed.
+// Unlike the SignRawTransaction family, the signing is performed by the node
+// itself, so no wallet is required.  The private keys are not stored.
+//
+// The only input transactions that need to be specified are ones the node does
+// not have in its memory pool or main chain along with the redeem scripts of any
+// pay-to-script-hash outputs.
+func (c *Client) SignRawTransactionWithKey(tx *wire.MsgTx,
+	privKeysWIF []string, inputs []exccjson.RawTxInput,
+	hashType SigHashType) (*wire.MsgTx, bool, error) {
+
+	return c.SignRawTransactionWithKeyAsync(tx, privKeysWIF, inputs,
+		hashType).Receive()
+}
+
+// SignRawTransactionWithKeyContext is like SignRawTransactionWithKey but the
+// request is abandoned with the error of the passed context once it is done,
+// such as when it times out or is canceled.
+func (c *Client) SignRawTransactionWithKeyContext(ctx context.Context, tx *wire.MsgTx,
+	privKeysWIF []string, inputs []exccjson.RawTxInput,
+	hashType SigHashType) (*wire.MsgTx, bool, error) {
+
+	return c.SignRawTransactionWithKeyAsyncContext(ctx, tx, privKeysWIF, inputs,
+		hashType).Receive()
+}
+
 // SignRawSSGenTxAsync returns an instance of a type that can be used to
 // get the result of the RPC at some future time by invoking the Receive
 // function on the returned instance.
 //
 // See SignRawSSGenTx for the blocking version and more details.
 func (c *Client) SignRawSSGenTxAsync(tx *wire.MsgTx) FutureSignRawTransactionResult {
+	return c.SignRawSSGenTxAsyncContext(context.Background(), tx)
+}
+
+// SignRawSSGenTxAsyncContext is like SignRawSSGenTxAsync but the request is
+// abandoned once the passed context is done.
+//
+// See SignRawSSGenTxContext for the blocking version.
+func (c *Client) SignRawSSGenTxAsyncContext(ctx context.Context, tx *wire.MsgTx) FutureSignRawTransactionResult {
 
 	txHex := ""
 	if tx != nil {
@@ -711,7 +1154,7 @@ func (c *Client) SignRawSSGenTxAsync(tx *wire.MsgTx) FutureSignRawTransactionRes
 
 	cmd := exccjson.NewSignRawTransactionCmd(txHex, &[]exccjson.RawTxInput{},
 		nil, exccjson.String("ssgen"))
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // SignRawSSGenTx signs inputs for the passed transaction using the
@@ -728,6 +1171,14 @@ func (c *Client) SignRawSSGenTx(tx *wire.MsgTx) (*wire.MsgTx, bool, error) {
 	return c.SignRawSSGenTxAsync(tx).Receive()
 }
 
+// SignRawSSGenTxContext is like SignRawSSGenTx but the request is abandoned
+// with the error of the passed context once it is done, such as when it times
+// out or is canceled.
+func (c *Client) SignRawSSGenTxContext(ctx context.Context, tx *wire.MsgTx) (*wire.MsgTx, bool, error) {
+
+	return c.SignRawSSGenTxAsyncContext(ctx, tx).Receive()
+}
+
 // FutureSearchRawTransactionsResult is a future promise to deliver the result
 // of the SearchRawTransactionsAsync RPC invocation (or an applicable error).
 type FutureSearchRawTransactionsResult chan *response
@@ -776,13 +1227,23 @@ func (r FutureSearchRawTransactionsResult) Receive() ([]*wire.MsgTx, error) {
 func (c *Client) SearchRawTransactionsAsync(address exccutil.Address, skip,
 	count int, reverse bool,
 	filterAddrs []string) FutureSearchRawTransactionsResult {
+	return c.SearchRawTransactionsAsyncContext(context.Background(), address, skip, count, reverse, filterAddrs)
+}
+
+// SearchRawTransactionsAsyncContext is like SearchRawTransactionsAsync but the
+// request is abandoned once the passed context is done.
+//
+// See SearchRawTransactionsContext for the blocking version.
+func (c *Client) SearchRawTransactionsAsyncContext(ctx context.Context, address exccutil.Address, skip,
+	count int, reverse bool,
+	filterAddrs []string) FutureSearchRawTransactionsResult {
 
 	addr := address.EncodeAddress()
 	verbose := exccjson.Int(0)
 	prevOut := exccjson.Int(0)
 	cmd := exccjson.NewSearchRawTransactionsCmd(addr, verbose, &skip, &count,
 		prevOut, &reverse, &filterAddrs)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // SearchRawTransactions returns transactions that involve the passed address.
@@ -799,6 +1260,16 @@ func (c *Client) SearchRawTransactions(address exccutil.Address, skip, count int
 		filterAddrs).Receive()
 }
 
+// SearchRawTransactionsContext is like SearchRawTransactions but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) SearchRawTransactionsContext(ctx context.Context, address exccutil.Address, skip, count int,
+	reverse bool, filterAddrs []string) ([]*wire.MsgTx, error) {
+
+	return c.SearchRawTransactionsAsyncContext(ctx, address, skip, count, reverse,
+		filterAddrs).Receive()
+}
+
 // FutureSearchRawTransactionsVerboseResult is a future promise to deliver the
 // result of the SearchRawTransactionsVerboseAsync RPC invocation (or an
 // applicable error).
@@ -830,6 +1301,17 @@ func (r FutureSearchRawTransactionsVerboseResult) Receive() ([]*exccjson.SearchR
 func (c *Client) SearchRawTransactionsVerboseAsync(address exccutil.Address, skip,
 	count int, includePrevOut bool, reverse bool,
 	filterAddrs *[]string) FutureSearchRawTransactionsVerboseResult {
+	return c.SearchRawTransactionsVerboseAsyncContext(context.Background(), address, skip, count, includePrevOut, reverse, filterAddrs)
+}
+
+// SearchRawTransactionsVerboseAsyncContext is like
+// SearchRawTransactionsVerboseAsync but the request is abandoned once the
+// passed context is done.
+//
+// See SearchRawTransactionsVerboseContext for the blocking version.
+func (c *Client) SearchRawTransactionsVerboseAsyncContext(ctx context.Context, address exccutil.Address, skip,
+	count int, includePrevOut bool, reverse bool,
+	filterAddrs *[]string) FutureSearchRawTransactionsVerboseResult {
 
 	addr := address.EncodeAddress()
 	verbose := exccjson.Int(1)
@@ -839,7 +1321,7 @@ func (c *Client) SearchRawTransactionsVerboseAsync(address exccutil.Address, ski
 	}
 	cmd := exccjson.NewSearchRawTransactionsCmd(addr, verbose, &skip, &count,
 		prevOut, &reverse, filterAddrs)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // SearchRawTransactionsVerbose returns a list of data structures that describe
@@ -856,3 +1338,14 @@ func (c *Client) SearchRawTransactionsVerbose(address exccutil.Address, skip,
 	return c.SearchRawTransactionsVerboseAsync(address, skip, count,
 		includePrevOut, reverse, &filterAddrs).Receive()
 }
+
+// SearchRawTransactionsVerboseContext is like SearchRawTransactionsVerbose but
+// the request is abandoned with the error of the passed context once it is
+// done, such as when it times out or is canceled.
+func (c *Client) SearchRawTransactionsVerboseContext(ctx context.Context, address exccutil.Address, skip,
+	count int, includePrevOut bool, reverse bool,
+	filterAddrs []string) ([]*exccjson.SearchRawTransactionsResult, error) {
+
+	return c.SearchRawTransactionsVerboseAsyncContext(ctx, address, skip, count,
+		includePrevOut, reverse, &filterAddrs).Receive()
+}
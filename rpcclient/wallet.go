@@ -7,6 +7,7 @@
 package rpcclient
 
 import (
+	"context"
 	"encoding/hex"
 	"encoding/json"
 
@@ -48,13 +49,21 @@ func (r FutureGetTransactionResult) Receive() (*exccjson.GetTransactionResult, e
 //
 // See GetTransaction for the blocking version and more details.
 func (c *Client) GetTransactionAsync(txHash *chainhash.Hash) FutureGetTransactionResult {
+	return c.GetTransactionAsyncContext(context.Background(), txHash)
+}
+
+// GetTransactionAsyncContext is like GetTransactionAsync but the request is
+// abandoned once the passed context is done.
+//
+// See GetTransactionContext for the blocking version.
+func (c *Client) GetTransactionAsyncContext(ctx context.Context, txHash *chainhash.Hash) FutureGetTransactionResult {
 	hash := ""
 	if txHash != nil {
 		hash = txHash.String()
 	}
 
 	cmd := exccjson.NewGetTransactionCmd(hash, nil)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetTransaction returns detailed information about a wallet transaction.
@@ -64,6 +73,13 @@ func (c *Client) GetTransaction(txHash *chainhash.Hash) (*exccjson.GetTransactio
 	return c.GetTransactionAsync(txHash).Receive()
 }
 
+// GetTransactionContext is like GetTransaction but the request is abandoned
+// with the error of the passed context once it is done, such as when it times
+// out or is canceled.
+func (c *Client) GetTransactionContext(ctx context.Context, txHash *chainhash.Hash) (*exccjson.GetTransactionResult, error) {
+	return c.GetTransactionAsyncContext(ctx, txHash).Receive()
+}
+
 // FutureListTransactionsResult is a future promise to deliver the result of a
 // ListTransactionsAsync, ListTransactionsCountAsync, or
 // ListTransactionsCountFromAsync RPC invocation (or an applicable error).
@@ -93,8 +109,16 @@ func (r FutureListTransactionsResult) Receive() ([]exccjson.ListTransactionsResu
 //
 // See ListTransactions for the blocking version and more details.
 func (c *Client) ListTransactionsAsync(account string) FutureListTransactionsResult {
+	return c.ListTransactionsAsyncContext(context.Background(), account)
+}
+
+// ListTransactionsAsyncContext is like ListTransactionsAsync but the request is
+// abandoned once the passed context is done.
+//
+// See ListTransactionsContext for the blocking version.
+func (c *Client) ListTransactionsAsyncContext(ctx context.Context, account string) FutureListTransactionsResult {
 	cmd := exccjson.NewListTransactionsCmd(&account, nil, nil, nil)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // ListTransactions returns a list of the most recent transactions.
@@ -105,14 +129,29 @@ func (c *Client) ListTransactions(account string) ([]exccjson.ListTransactionsRe
 	return c.ListTransactionsAsync(account).Receive()
 }
 
+// ListTransactionsContext is like ListTransactions but the request is abandoned
+// with the error of the passed context once it is done, such as when it times
+// out or is canceled.
+func (c *Client) ListTransactionsContext(ctx context.Context, account string) ([]exccjson.ListTransactionsResult, error) {
+	return c.ListTransactionsAsyncContext(ctx, account).Receive()
+}
+
 // ListTransactionsCountAsync returns an instance of a type that can be used to
 // get the result of the RPC at some future time by invoking the Receive
 // function on the returned instance.
 //
 // See ListTransactionsCount for the blocking version and more details.
 func (c *Client) ListTransactionsCountAsync(account string, count int) FutureListTransactionsResult {
+	return c.ListTransactionsCountAsyncContext(context.Background(), account, count)
+}
+
+// ListTransactionsCountAsyncContext is like ListTransactionsCountAsync but the
+// request is abandoned once the passed context is done.
+//
+// See ListTransactionsCountContext for the blocking version.
+func (c *Client) ListTransactionsCountAsyncContext(ctx context.Context, account string, count int) FutureListTransactionsResult {
 	cmd := exccjson.NewListTransactionsCmd(&account, &count, nil, nil)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // ListTransactionsCount returns a list of the most recent transactions up
@@ -124,14 +163,29 @@ func (c *Client) ListTransactionsCount(account string, count int) ([]exccjson.Li
 	return c.ListTransactionsCountAsync(account, count).Receive()
 }
 
+// ListTransactionsCountContext is like ListTransactionsCount but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) ListTransactionsCountContext(ctx context.Context, account string, count int) ([]exccjson.ListTransactionsResult, error) {
+	return c.ListTransactionsCountAsyncContext(ctx, account, count).Receive()
+}
+
 // ListTransactionsCountFromAsync returns an instance of a type that can be used
 // to get the result of the RPC at some future time by invoking the Receive
 // function on the returned instance.
 //
 // See ListTransactionsCountFrom for the blocking version and more details.
 func (c *Client) ListTransactionsCountFromAsync(account string, count, from int) FutureListTransactionsResult {
+	return c.ListTransactionsCountFromAsyncContext(context.Background(), account, count, from)
+}
+
+// ListTransactionsCountFromAsyncContext is like ListTransactionsCountFromAsync
+// but the request is abandoned once the passed context is done.
+//
+// See ListTransactionsCountFromContext for the blocking version.
+func (c *Client) ListTransactionsCountFromAsyncContext(ctx context.Context, account string, count, from int) FutureListTransactionsResult {
 	cmd := exccjson.NewListTransactionsCmd(&account, &count, &from, nil)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // ListTransactionsCountFrom returns a list of the most recent transactions up
@@ -142,6 +196,13 @@ func (c *Client) ListTransactionsCountFrom(account string, count, from int) ([]e
 	return c.ListTransactionsCountFromAsync(account, count, from).Receive()
 }
 
+// ListTransactionsCountFromContext is like ListTransactionsCountFrom but the
+// request is abandoned with the error of the passed context once it is done,
+// such as when it times out or is canceled.
+func (c *Client) ListTransactionsCountFromContext(ctx context.Context, account string, count, from int) ([]exccjson.ListTransactionsResult, error) {
+	return c.ListTransactionsCountFromAsyncContext(ctx, account, count, from).Receive()
+}
+
 // FutureListUnspentResult is a future promise to deliver the result of a
 // ListUnspentAsync, ListUnspentMinAsync, ListUnspentMinMaxAsync, or
 // ListUnspentMinMaxAddressesAsync RPC invocation (or an applicable error).
@@ -174,8 +235,16 @@ func (r FutureListUnspentResult) Receive() ([]exccjson.ListUnspentResult, error)
 //
 // See ListUnspent for the blocking version and more details.
 func (c *Client) ListUnspentAsync() FutureListUnspentResult {
+	return c.ListUnspentAsyncContext(context.Background())
+}
+
+// ListUnspentAsyncContext is like ListUnspentAsync but the request is abandoned
+// once the passed context is done.
+//
+// See ListUnspentContext for the blocking version.
+func (c *Client) ListUnspentAsyncContext(ctx context.Context) FutureListUnspentResult {
 	cmd := exccjson.NewListUnspentCmd(nil, nil, nil)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // ListUnspentMinAsync returns an instance of a type that can be used to get
@@ -184,8 +253,16 @@ func (c *Client) ListUnspentAsync() FutureListUnspentResult {
 //
 // See ListUnspentMin for the blocking version and more details.
 func (c *Client) ListUnspentMinAsync(minConf int) FutureListUnspentResult {
+	return c.ListUnspentMinAsyncContext(context.Background(), minConf)
+}
+
+// ListUnspentMinAsyncContext is like ListUnspentMinAsync but the request is
+// abandoned once the passed context is done.
+//
+// See ListUnspentMinContext for the blocking version.
+func (c *Client) ListUnspentMinAsyncContext(ctx context.Context, minConf int) FutureListUnspentResult {
 	cmd := exccjson.NewListUnspentCmd(&minConf, nil, nil)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // ListUnspentMinMaxAsync returns an instance of a type that can be used to get
@@ -194,8 +271,16 @@ func (c *Client) ListUnspentMinAsync(minConf int) FutureListUnspentResult {
 //
 // See ListUnspentMinMax for the blocking version and more details.
 func (c *Client) ListUnspentMinMaxAsync(minConf, maxConf int) FutureListUnspentResult {
+	return c.ListUnspentMinMaxAsyncContext(context.Background(), minConf, maxConf)
+}
+
+// ListUnspentMinMaxAsyncContext is like ListUnspentMinMaxAsync but the request
+// is abandoned once the passed context is done.
+//
+// See ListUnspentMinMaxContext for the blocking version.
+func (c *Client) ListUnspentMinMaxAsyncContext(ctx context.Context, minConf, maxConf int) FutureListUnspentResult {
 	cmd := exccjson.NewListUnspentCmd(&minConf, &maxConf, nil)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // ListUnspentMinMaxAddressesAsync returns an instance of a type that can be
@@ -204,13 +289,22 @@ func (c *Client) ListUnspentMinMaxAsync(minConf, maxConf int) FutureListUnspentR
 //
 // See ListUnspentMinMaxAddresses for the blocking version and more details.
 func (c *Client) ListUnspentMinMaxAddressesAsync(minConf, maxConf int, addrs []exccutil.Address) FutureListUnspentResult {
+	return c.ListUnspentMinMaxAddressesAsyncContext(context.Background(), minConf, maxConf, addrs)
+}
+
+// ListUnspentMinMaxAddressesAsyncContext is like
+// ListUnspentMinMaxAddressesAsync but the request is abandoned once the passed
+// context is done.
+//
+// See ListUnspentMinMaxAddressesContext for the blocking version.
+func (c *Client) ListUnspentMinMaxAddressesAsyncContext(ctx context.Context, minConf, maxConf int, addrs []exccutil.Address) FutureListUnspentResult {
 	addrStrs := make([]string, 0, len(addrs))
 	for _, a := range addrs {
 		addrStrs = append(addrStrs, a.EncodeAddress())
 	}
 
 	cmd := exccjson.NewListUnspentCmd(&minConf, &maxConf, &addrStrs)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // ListUnspent returns all unspent transaction outputs known to a wallet, using
@@ -220,6 +314,13 @@ func (c *Client) ListUnspent() ([]exccjson.ListUnspentResult, error) {
 	return c.ListUnspentAsync().Receive()
 }
 
+// ListUnspentContext is like ListUnspent but the request is abandoned with the
+// error of the passed context once it is done, such as when it times out or is
+// canceled.
+func (c *Client) ListUnspentContext(ctx context.Context) ([]exccjson.ListUnspentResult, error) {
+	return c.ListUnspentAsyncContext(ctx).Receive()
+}
+
 // ListUnspentMin returns all unspent transaction outputs known to a wallet,
 // using the specified number of minimum conformations and default number of
 // maximum confiramtions (9999999) as a filter.
@@ -227,6 +328,13 @@ func (c *Client) ListUnspentMin(minConf int) ([]exccjson.ListUnspentResult, erro
 	return c.ListUnspentMinAsync(minConf).Receive()
 }
 
+// ListUnspentMinContext is like ListUnspentMin but the request is abandoned
+// with the error of the passed context once it is done, such as when it times
+// out or is canceled.
+func (c *Client) ListUnspentMinContext(ctx context.Context, minConf int) ([]exccjson.ListUnspentResult, error) {
+	return c.ListUnspentMinAsyncContext(ctx, minConf).Receive()
+}
+
 // ListUnspentMinMax returns all unspent transaction outputs known to a wallet,
 // using the specified number of minimum and maximum number of confirmations as
 // a filter.
@@ -234,6 +342,13 @@ func (c *Client) ListUnspentMinMax(minConf, maxConf int) ([]exccjson.ListUnspent
 	return c.ListUnspentMinMaxAsync(minConf, maxConf).Receive()
 }
 
+// ListUnspentMinMaxContext is like ListUnspentMinMax but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) ListUnspentMinMaxContext(ctx context.Context, minConf, maxConf int) ([]exccjson.ListUnspentResult, error) {
+	return c.ListUnspentMinMaxAsyncContext(ctx, minConf, maxConf).Receive()
+}
+
 // ListUnspentMinMaxAddresses returns all unspent transaction outputs that pay
 // to any of specified addresses in a wallet using the specified number of
 // minimum and maximum number of confirmations as a filter.
@@ -241,6 +356,13 @@ func (c *Client) ListUnspentMinMaxAddresses(minConf, maxConf int, addrs []exccut
 	return c.ListUnspentMinMaxAddressesAsync(minConf, maxConf, addrs).Receive()
 }
 
+// ListUnspentMinMaxAddressesContext is like ListUnspentMinMaxAddresses but the
+// request is abandoned with the error of the passed context once it is done,
+// such as when it times out or is canceled.
+func (c *Client) ListUnspentMinMaxAddressesContext(ctx context.Context, minConf, maxConf int, addrs []exccutil.Address) ([]exccjson.ListUnspentResult, error) {
+	return c.ListUnspentMinMaxAddressesAsyncContext(ctx, minConf, maxConf, addrs).Receive()
+}
+
 // FutureListSinceBlockResult is a future promise to deliver the result of a
 // ListSinceBlockAsync or ListSinceBlockMinConfAsync RPC invocation (or an
 // applicable error).
@@ -271,13 +393,21 @@ func (r FutureListSinceBlockResult) Receive() (*exccjson.ListSinceBlockResult, e
 //
 // See ListSinceBlock for the blocking version and more details.
 func (c *Client) ListSinceBlockAsync(blockHash *chainhash.Hash) FutureListSinceBlockResult {
+	return c.ListSinceBlockAsyncContext(context.Background(), blockHash)
+}
+
+// ListSinceBlockAsyncContext is like ListSinceBlockAsync but the request is
+// abandoned once the passed context is done.
+//
+// See ListSinceBlockContext for the blocking version.
+func (c *Client) ListSinceBlockAsyncContext(ctx context.Context, blockHash *chainhash.Hash) FutureListSinceBlockResult {
 	var hash *string
 	if blockHash != nil {
 		hash = exccjson.String(blockHash.String())
 	}
 
 	cmd := exccjson.NewListSinceBlockCmd(hash, nil, nil)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // ListSinceBlock returns all transactions added in blocks since the specified
@@ -289,19 +419,34 @@ func (c *Client) ListSinceBlock(blockHash *chainhash.Hash) (*exccjson.ListSinceB
 	return c.ListSinceBlockAsync(blockHash).Receive()
 }
 
+// ListSinceBlockContext is like ListSinceBlock but the request is abandoned
+// with the error of the passed context once it is done, such as when it times
+// out or is canceled.
+func (c *Client) ListSinceBlockContext(ctx context.Context, blockHash *chainhash.Hash) (*exccjson.ListSinceBlockResult, error) {
+	return c.ListSinceBlockAsyncContext(ctx, blockHash).Receive()
+}
+
 // ListSinceBlockMinConfAsync returns an instance of a type that can be used to
 // get the result of the RPC at some future time by invoking the Receive
 // function on the returned instance.
 //
 // See ListSinceBlockMinConf for the blocking version and more details.
 func (c *Client) ListSinceBlockMinConfAsync(blockHash *chainhash.Hash, minConfirms int) FutureListSinceBlockResult {
+	return c.ListSinceBlockMinConfAsyncContext(context.Background(), blockHash, minConfirms)
+}
+
+// ListSinceBlockMinConfAsyncContext is like ListSinceBlockMinConfAsync but the
+// request is abandoned once the passed context is done.
+//
+// See ListSinceBlockMinConfContext for the blocking version.
+func (c *Client) ListSinceBlockMinConfAsyncContext(ctx context.Context, blockHash *chainhash.Hash, minConfirms int) FutureListSinceBlockResult {
 	var hash *string
 	if blockHash != nil {
 		hash = exccjson.String(blockHash.String())
 	}
 
 	cmd := exccjson.NewListSinceBlockCmd(hash, &minConfirms, nil)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // ListSinceBlockMinConf returns all transactions added in blocks since the
@@ -313,6 +458,13 @@ func (c *Client) ListSinceBlockMinConf(blockHash *chainhash.Hash, minConfirms in
 	return c.ListSinceBlockMinConfAsync(blockHash, minConfirms).Receive()
 }
 
+// ListSinceBlockMinConfContext is like ListSinceBlockMinConf but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) ListSinceBlockMinConfContext(ctx context.Context, blockHash *chainhash.Hash, minConfirms int) (*exccjson.ListSinceBlockResult, error) {
+	return c.ListSinceBlockMinConfAsyncContext(ctx, blockHash, minConfirms).Receive()
+}
+
 // **************************
 // Transaction Send Functions
 // **************************
@@ -334,6 +486,14 @@ func (r FutureLockUnspentResult) Receive() error {
 //
 // See LockUnspent for the blocking version and more details.
 func (c *Client) LockUnspentAsync(unlock bool, ops []*wire.OutPoint) FutureLockUnspentResult {
+	return c.LockUnspentAsyncContext(context.Background(), unlock, ops)
+}
+
+// LockUnspentAsyncContext is like LockUnspentAsync but the request is abandoned
+// once the passed context is done.
+//
+// See LockUnspentContext for the blocking version.
+func (c *Client) LockUnspentAsyncContext(ctx context.Context, unlock bool, ops []*wire.OutPoint) FutureLockUnspentResult {
 	outputs := make([]exccjson.TransactionInput, len(ops))
 	for i, op := range ops {
 		outputs[i] = exccjson.TransactionInput{
@@ -343,7 +503,7 @@ func (c *Client) LockUnspentAsync(unlock bool, ops []*wire.OutPoint) FutureLockU
 		}
 	}
 	cmd := exccjson.NewLockUnspentCmd(unlock, outputs)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // LockUnspent marks outputs as locked or unlocked, depending on the value of
@@ -367,6 +527,13 @@ func (c *Client) LockUnspent(unlock bool, ops []*wire.OutPoint) error {
 	return c.LockUnspentAsync(unlock, ops).Receive()
 }
 
+// LockUnspentContext is like LockUnspent but the request is abandoned with the
+// error of the passed context once it is done, such as when it times out or is
+// canceled.
+func (c *Client) LockUnspentContext(ctx context.Context, unlock bool, ops []*wire.OutPoint) error {
+	return c.LockUnspentAsyncContext(ctx, unlock, ops).Receive()
+}
+
 // FutureListLockUnspentResult is a future promise to deliver the result of a
 // ListLockUnspentAsync RPC invocation (or an applicable error).
 type FutureListLockUnspentResult chan *response
@@ -405,8 +572,16 @@ func (r FutureListLockUnspentResult) Receive() ([]*wire.OutPoint, error) {
 //
 // See ListLockUnspent for the blocking version and more details.
 func (c *Client) ListLockUnspentAsync() FutureListLockUnspentResult {
+	return c.ListLockUnspentAsyncContext(context.Background())
+}
+
+// ListLockUnspentAsyncContext is like ListLockUnspentAsync but the request is
+// abandoned once the passed context is done.
+//
+// See ListLockUnspentContext for the blocking version.
+func (c *Client) ListLockUnspentAsyncContext(ctx context.Context) FutureListLockUnspentResult {
 	cmd := exccjson.NewListLockUnspentCmd()
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // ListLockUnspent returns a slice of outpoints for all unspent outputs marked
@@ -416,6 +591,13 @@ func (c *Client) ListLockUnspent() ([]*wire.OutPoint, error) {
 	return c.ListLockUnspentAsync().Receive()
 }
 
+// ListLockUnspentContext is like ListLockUnspent but the request is abandoned
+// with the error of the passed context once it is done, such as when it times
+// out or is canceled.
+func (c *Client) ListLockUnspentContext(ctx context.Context) ([]*wire.OutPoint, error) {
+	return c.ListLockUnspentAsyncContext(ctx).Receive()
+}
+
 // FutureSendToAddressResult is a future promise to deliver the result of a
 // SendToAddressAsync RPC invocation (or an applicable error).
 type FutureSendToAddressResult chan *response
@@ -444,9 +626,17 @@ func (r FutureSendToAddressResult) Receive() (*chainhash.Hash, error) {
 //
 // See SendToAddress for the blocking version and more details.
 func (c *Client) SendToAddressAsync(address exccutil.Address, amount exccutil.Amount) FutureSendToAddressResult {
+	return c.SendToAddressAsyncContext(context.Background(), address, amount)
+}
+
+// SendToAddressAsyncContext is like SendToAddressAsync but the request is
+// abandoned once the passed context is done.
+//
+// See SendToAddressContext for the blocking version.
+func (c *Client) SendToAddressAsyncContext(ctx context.Context, address exccutil.Address, amount exccutil.Amount) FutureSendToAddressResult {
 	addr := address.EncodeAddress()
 	cmd := exccjson.NewSendToAddressCmd(addr, amount.ToCoin(), nil, nil)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // SendToAddress sends the passed amount to the given address.
@@ -461,6 +651,13 @@ func (c *Client) SendToAddress(address exccutil.Address, amount exccutil.Amount)
 	return c.SendToAddressAsync(address, amount).Receive()
 }
 
+// SendToAddressContext is like SendToAddress but the request is abandoned with
+// the error of the passed context once it is done, such as when it times out or
+// is canceled.
+func (c *Client) SendToAddressContext(ctx context.Context, address exccutil.Address, amount exccutil.Amount) (*chainhash.Hash, error) {
+	return c.SendToAddressAsyncContext(ctx, address, amount).Receive()
+}
+
 // SendToAddressCommentAsync returns an instance of a type that can be used to
 // get the result of the RPC at some future time by invoking the Receive
 // function on the returned instance.
@@ -469,11 +666,21 @@ func (c *Client) SendToAddress(address exccutil.Address, amount exccutil.Amount)
 func (c *Client) SendToAddressCommentAsync(address exccutil.Address,
 	amount exccutil.Amount, comment,
 	commentTo string) FutureSendToAddressResult {
+	return c.SendToAddressCommentAsyncContext(context.Background(), address, amount, comment, commentTo)
+}
+
+// SendToAddressCommentAsyncContext is like SendToAddressCommentAsync but the
+// request is abandoned once the passed context is done.
+//
+// See SendToAddressCommentContext for the blocking version.
+func (c *Client) SendToAddressCommentAsyncContext(ctx context.Context, address exccutil.Address,
+	amount exccutil.Amount, comment,
+	commentTo string) FutureSendToAddressResult {
 
 	addr := address.EncodeAddress()
 	cmd := exccjson.NewSendToAddressCmd(addr, amount.ToCoin(), &comment,
 		&commentTo)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // SendToAddressComment sends the passed amount to the given address and stores
@@ -493,6 +700,14 @@ func (c *Client) SendToAddressComment(address exccutil.Address, amount exccutil.
 		commentTo).Receive()
 }
 
+// SendToAddressCommentContext is like SendToAddressComment but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) SendToAddressCommentContext(ctx context.Context, address exccutil.Address, amount exccutil.Amount, comment, commentTo string) (*chainhash.Hash, error) {
+	return c.SendToAddressCommentAsyncContext(ctx, address, amount, comment,
+		commentTo).Receive()
+}
+
 // FutureSendFromResult is a future promise to deliver the result of a
 // SendFromAsync, SendFromMinConfAsync, or SendFromCommentAsync RPC invocation
 // (or an applicable error).
@@ -523,10 +738,18 @@ func (r FutureSendFromResult) Receive() (*chainhash.Hash, error) {
 //
 // See SendFrom for the blocking version and more details.
 func (c *Client) SendFromAsync(fromAccount string, toAddress exccutil.Address, amount exccutil.Amount) FutureSendFromResult {
+	return c.SendFromAsyncContext(context.Background(), fromAccount, toAddress, amount)
+}
+
+// SendFromAsyncContext is like SendFromAsync but the request is abandoned once
+// the passed context is done.
+//
+// See SendFromContext for the blocking version.
+func (c *Client) SendFromAsyncContext(ctx context.Context, fromAccount string, toAddress exccutil.Address, amount exccutil.Amount) FutureSendFromResult {
 	addr := toAddress.EncodeAddress()
 	cmd := exccjson.NewSendFromCmd(fromAccount, addr, amount.ToCoin(), nil,
 		nil, nil)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // SendFrom sends the passed amount to the given address using the provided
@@ -541,16 +764,31 @@ func (c *Client) SendFrom(fromAccount string, toAddress exccutil.Address, amount
 	return c.SendFromAsync(fromAccount, toAddress, amount).Receive()
 }
 
+// SendFromContext is like SendFrom but the request is abandoned with the error
+// of the passed context once it is done, such as when it times out or is
+// canceled.
+func (c *Client) SendFromContext(ctx context.Context, fromAccount string, toAddress exccutil.Address, amount exccutil.Amount) (*chainhash.Hash, error) {
+	return c.SendFromAsyncContext(ctx, fromAccount, toAddress, amount).Receive()
+}
+
 // SendFromMinConfAsync returns an instance of a type that can be used to get
 // the result of the RPC at some future time by invoking the Receive function on
 // the returned instance.
 //
 // See SendFromMinConf for the blocking version and more details.
 func (c *Client) SendFromMinConfAsync(fromAccount string, toAddress exccutil.Address, amount exccutil.Amount, minConfirms int) FutureSendFromResult {
+	return c.SendFromMinConfAsyncContext(context.Background(), fromAccount, toAddress, amount, minConfirms)
+}
+
+// SendFromMinConfAsyncContext is like SendFromMinConfAsync but the request is
+// abandoned once the passed context is done.
+//
+// See SendFromMinConfContext for the blocking version.
+func (c *Client) SendFromMinConfAsyncContext(ctx context.Context, fromAccount string, toAddress exccutil.Address, amount exccutil.Amount, minConfirms int) FutureSendFromResult {
 	addr := toAddress.EncodeAddress()
 	cmd := exccjson.NewSendFromCmd(fromAccount, addr, amount.ToCoin(),
 		&minConfirms, nil, nil)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // SendFromMinConf sends the passed amount to the given address using the
@@ -567,6 +805,14 @@ func (c *Client) SendFromMinConf(fromAccount string, toAddress exccutil.Address,
 		minConfirms).Receive()
 }
 
+// SendFromMinConfContext is like SendFromMinConf but the request is abandoned
+// with the error of the passed context once it is done, such as when it times
+// out or is canceled.
+func (c *Client) SendFromMinConfContext(ctx context.Context, fromAccount string, toAddress exccutil.Address, amount exccutil.Amount, minConfirms int) (*chainhash.Hash, error) {
+	return c.SendFromMinConfAsyncContext(ctx, fromAccount, toAddress, amount,
+		minConfirms).Receive()
+}
+
 // SendFromCommentAsync returns an instance of a type that can be used to get
 // the result of the RPC at some future time by invoking the Receive function on
 // the returned instance.
@@ -575,11 +821,21 @@ func (c *Client) SendFromMinConf(fromAccount string, toAddress exccutil.Address,
 func (c *Client) SendFromCommentAsync(fromAccount string,
 	toAddress exccutil.Address, amount exccutil.Amount, minConfirms int,
 	comment, commentTo string) FutureSendFromResult {
+	return c.SendFromCommentAsyncContext(context.Background(), fromAccount, toAddress, amount, minConfirms, comment, commentTo)
+}
+
+// SendFromCommentAsyncContext is like SendFromCommentAsync but the request is
+// abandoned once the passed context is done.
+//
+// See SendFromCommentContext for the blocking version.
+func (c *Client) SendFromCommentAsyncContext(ctx context.Context, fromAccount string,
+	toAddress exccutil.Address, amount exccutil.Amount, minConfirms int,
+	comment, commentTo string) FutureSendFromResult {
 
 	addr := toAddress.EncodeAddress()
 	cmd := exccjson.NewSendFromCmd(fromAccount, addr, amount.ToCoin(),
 		&minConfirms, &comment, &commentTo)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // SendFromComment sends the passed amount to the given address using the
@@ -601,6 +857,17 @@ func (c *Client) SendFromComment(fromAccount string, toAddress exccutil.Address,
 		minConfirms, comment, commentTo).Receive()
 }
 
+// SendFromCommentContext is like SendFromComment but the request is abandoned
+// with the error of the passed context once it is done, such as when it times
+// out or is canceled.
+func (c *Client) SendFromCommentContext(ctx context.Context, fromAccount string, toAddress exccutil.Address,
+	amount exccutil.Amount, minConfirms int,
+	comment, commentTo string) (*chainhash.Hash, error) {
+
+	return c.SendFromCommentAsyncContext(ctx, fromAccount, toAddress, amount,
+		minConfirms, comment, commentTo).Receive()
+}
+
 // FutureSendManyResult is a future promise to deliver the result of a
 // SendManyAsync, SendManyMinConfAsync, or SendManyCommentAsync RPC invocation
 // (or an applicable error).
@@ -631,12 +898,20 @@ func (r FutureSendManyResult) Receive() (*chainhash.Hash, error) {
 //
 // See SendMany for the blocking version and more details.
 func (c *Client) SendManyAsync(fromAccount string, amounts map[exccutil.Address]exccutil.Amount) FutureSendManyResult {
+	return c.SendManyAsyncContext(context.Background(), fromAccount, amounts)
+}
+
+// SendManyAsyncContext is like SendManyAsync but the request is abandoned once
+// the passed context is done.
+//
+// See SendManyContext for the blocking version.
+func (c *Client) SendManyAsyncContext(ctx context.Context, fromAccount string, amounts map[exccutil.Address]exccutil.Amount) FutureSendManyResult {
 	convertedAmounts := make(map[string]float64, len(amounts))
 	for addr, amount := range amounts {
 		convertedAmounts[addr.EncodeAddress()] = amount.ToCoin()
 	}
 	cmd := exccjson.NewSendManyCmd(fromAccount, convertedAmounts, nil, nil)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // SendMany sends multiple amounts to multiple addresses using the provided
@@ -651,6 +926,13 @@ func (c *Client) SendMany(fromAccount string, amounts map[exccutil.Address]exccu
 	return c.SendManyAsync(fromAccount, amounts).Receive()
 }
 
+// SendManyContext is like SendMany but the request is abandoned with the error
+// of the passed context once it is done, such as when it times out or is
+// canceled.
+func (c *Client) SendManyContext(ctx context.Context, fromAccount string, amounts map[exccutil.Address]exccutil.Amount) (*chainhash.Hash, error) {
+	return c.SendManyAsyncContext(ctx, fromAccount, amounts).Receive()
+}
+
 // SendManyMinConfAsync returns an instance of a type that can be used to get
 // the result of the RPC at some future time by invoking the Receive function on
 // the returned instance.
@@ -659,6 +941,16 @@ func (c *Client) SendMany(fromAccount string, amounts map[exccutil.Address]exccu
 func (c *Client) SendManyMinConfAsync(fromAccount string,
 	amounts map[exccutil.Address]exccutil.Amount,
 	minConfirms int) FutureSendManyResult {
+	return c.SendManyMinConfAsyncContext(context.Background(), fromAccount, amounts, minConfirms)
+}
+
+// SendManyMinConfAsyncContext is like SendManyMinConfAsync but the request is
+// abandoned once the passed context is done.
+//
+// See SendManyMinConfContext for the blocking version.
+func (c *Client) SendManyMinConfAsyncContext(ctx context.Context, fromAccount string,
+	amounts map[exccutil.Address]exccutil.Amount,
+	minConfirms int) FutureSendManyResult {
 
 	convertedAmounts := make(map[string]float64, len(amounts))
 	for addr, amount := range amounts {
@@ -666,7 +958,7 @@ func (c *Client) SendManyMinConfAsync(fromAccount string,
 	}
 	cmd := exccjson.NewSendManyCmd(fromAccount, convertedAmounts,
 		&minConfirms, nil)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // SendManyMinConf sends multiple amounts to multiple addresses using the
@@ -685,6 +977,16 @@ func (c *Client) SendManyMinConf(fromAccount string,
 	return c.SendManyMinConfAsync(fromAccount, amounts, minConfirms).Receive()
 }
 
+// SendManyMinConfContext is like SendManyMinConf but the request is abandoned
+// with the error of the passed context once it is done, such as when it times
+// out or is canceled.
+func (c *Client) SendManyMinConfContext(ctx context.Context, fromAccount string,
+	amounts map[exccutil.Address]exccutil.Amount,
+	minConfirms int) (*chainhash.Hash, error) {
+
+	return c.SendManyMinConfAsyncContext(ctx, fromAccount, amounts, minConfirms).Receive()
+}
+
 // SendManyCommentAsync returns an instance of a type that can be used to get
 // the result of the RPC at some future time by invoking the Receive function on
 // the returned instance.
@@ -693,6 +995,16 @@ func (c *Client) SendManyMinConf(fromAccount string,
 func (c *Client) SendManyCommentAsync(fromAccount string,
 	amounts map[exccutil.Address]exccutil.Amount, minConfirms int,
 	comment string) FutureSendManyResult {
+	return c.SendManyCommentAsyncContext(context.Background(), fromAccount, amounts, minConfirms, comment)
+}
+
+// SendManyCommentAsyncContext is like SendManyCommentAsync but the request is
+// abandoned once the passed context is done.
+//
+// See SendManyCommentContext for the blocking version.
+func (c *Client) SendManyCommentAsyncContext(ctx context.Context, fromAccount string,
+	amounts map[exccutil.Address]exccutil.Amount, minConfirms int,
+	comment string) FutureSendManyResult {
 
 	convertedAmounts := make(map[string]float64, len(amounts))
 	for addr, amount := range amounts {
@@ -700,7 +1012,7 @@ func (c *Client) SendManyCommentAsync(fromAccount string,
 	}
 	cmd := exccjson.NewSendManyCmd(fromAccount, convertedAmounts,
 		&minConfirms, &comment)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // SendManyComment sends multiple amounts to multiple addresses using the
@@ -721,6 +1033,17 @@ func (c *Client) SendManyComment(fromAccount string,
 		comment).Receive()
 }
 
+// SendManyCommentContext is like SendManyComment but the request is abandoned
+// with the error of the passed context once it is done, such as when it times
+// out or is canceled.
+func (c *Client) SendManyCommentContext(ctx context.Context, fromAccount string,
+	amounts map[exccutil.Address]exccutil.Amount, minConfirms int,
+	comment string) (*chainhash.Hash, error) {
+
+	return c.SendManyCommentAsyncContext(ctx, fromAccount, amounts, minConfirms,
+		comment).Receive()
+}
+
 // Begin ExchangeCoin FUNCTIONS ---------------------------------------------------------
 //
 // SStx generation RPC call handling
@@ -760,6 +1083,17 @@ func (r FuturePurchaseTicketResult) Receive() ([]*chainhash.Hash, error) {
 // future chan to receive the transactions representing the purchased tickets
 // when they come.
 func (c *Client) PurchaseTicketAsync(fromAccount string,
+	spendLimit exccutil.Amount, minConf *int, ticketAddress exccutil.Address,
+	numTickets *int, poolAddress exccutil.Address, poolFees *exccutil.Amount,
+	expiry *int, noSplitTransaction *bool, ticketFee *exccutil.Amount) FuturePurchaseTicketResult {
+	return c.PurchaseTicketAsyncContext(context.Background(), fromAccount, spendLimit, minConf, ticketAddress, numTickets, poolAddress, poolFees, expiry, noSplitTransaction, ticketFee)
+}
+
+// PurchaseTicketAsyncContext is like PurchaseTicketAsync but the request is
+// abandoned once the passed context is done.
+//
+// See PurchaseTicketContext for the blocking version.
+func (c *Client) PurchaseTicketAsyncContext(ctx context.Context, fromAccount string,
 	spendLimit exccutil.Amount, minConf *int, ticketAddress exccutil.Address,
 	numTickets *int, poolAddress exccutil.Address, poolFees *exccutil.Amount,
 	expiry *int, noSplitTransaction *bool, ticketFee *exccutil.Amount) FuturePurchaseTicketResult {
@@ -808,7 +1142,7 @@ func (c *Client) PurchaseTicketAsync(fromAccount string,
 		&minConfVal, &ticketAddrStr, &numTicketsVal, &poolAddrStr,
 		&poolFeesFloat, &expiryVal, nil, noSplitTransaction, &ticketFeeFloat)
 
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // PurchaseTicket takes an account and a spending limit and calls the async
@@ -822,6 +1156,18 @@ func (c *Client) PurchaseTicket(fromAccount string,
 		numTickets, poolAddress, poolFees, expiry, noSplitTransaction, ticketFee).Receive()
 }
 
+// PurchaseTicketContext is like PurchaseTicket but the request is abandoned
+// with the error of the passed context once it is done, such as when it times
+// out or is canceled.
+func (c *Client) PurchaseTicketContext(ctx context.Context, fromAccount string,
+	spendLimit exccutil.Amount, minConf *int, ticketAddress exccutil.Address,
+	numTickets *int, poolAddress exccutil.Address, poolFees *exccutil.Amount,
+	expiry *int, noSplitTransaction *bool, ticketFee *exccutil.Amount) ([]*chainhash.Hash, error) {
+
+	return c.PurchaseTicketAsyncContext(ctx, fromAccount, spendLimit, minConf, ticketAddress,
+		numTickets, poolAddress, poolFees, expiry, noSplitTransaction, ticketFee).Receive()
+}
+
 // SStx generation RPC call handling
 
 // FutureSendToSStxResult is a future promise to deliver the result of a
@@ -857,6 +1203,17 @@ func (c *Client) SendToSStxAsync(fromAccount string,
 	inputs []exccjson.SStxInput,
 	amounts map[exccutil.Address]exccutil.Amount,
 	couts []SStxCommitOut) FutureSendToSStxResult {
+	return c.SendToSStxAsyncContext(context.Background(), fromAccount, inputs, amounts, couts)
+}
+
+// SendToSStxAsyncContext is like SendToSStxAsync but the request is abandoned
+// once the passed context is done.
+//
+// See SendToSStxContext for the blocking version.
+func (c *Client) SendToSStxAsyncContext(ctx context.Context, fromAccount string,
+	inputs []exccjson.SStxInput,
+	amounts map[exccutil.Address]exccutil.Amount,
+	couts []SStxCommitOut) FutureSendToSStxResult {
 
 	convertedAmounts := make(map[string]int64, len(amounts))
 	for addr, amount := range amounts {
@@ -873,7 +1230,7 @@ func (c *Client) SendToSStxAsync(fromAccount string,
 	cmd := exccjson.NewSendToSStxCmd(fromAccount, convertedAmounts,
 		inputs, convertedCouts, nil, nil)
 
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // SendToSStx purchases a stake ticket with the amounts specified, with
@@ -892,6 +1249,17 @@ func (c *Client) SendToSStx(fromAccount string,
 	return c.SendToSStxAsync(fromAccount, inputs, amounts, couts).Receive()
 }
 
+// SendToSStxContext is like SendToSStx but the request is abandoned with the
+// error of the passed context once it is done, such as when it times out or is
+// canceled.
+func (c *Client) SendToSStxContext(ctx context.Context, fromAccount string,
+	inputs []exccjson.SStxInput,
+	amounts map[exccutil.Address]exccutil.Amount,
+	couts []SStxCommitOut) (*chainhash.Hash, error) {
+
+	return c.SendToSStxAsyncContext(ctx, fromAccount, inputs, amounts, couts).Receive()
+}
+
 // SendToSStxMinConfAsync returns an instance of a type that can be used to get
 // the result of the RPC at some future time by invoking the Receive function on
 // the returned instance.
@@ -902,6 +1270,18 @@ func (c *Client) SendToSStxMinConfAsync(fromAccount string,
 	amounts map[exccutil.Address]exccutil.Amount,
 	couts []SStxCommitOut,
 	minConfirms int) FutureSendToSStxResult {
+	return c.SendToSStxMinConfAsyncContext(context.Background(), fromAccount, inputs, amounts, couts, minConfirms)
+}
+
+// SendToSStxMinConfAsyncContext is like SendToSStxMinConfAsync but the request
+// is abandoned once the passed context is done.
+//
+// See SendToSStxMinConfContext for the blocking version.
+func (c *Client) SendToSStxMinConfAsyncContext(ctx context.Context, fromAccount string,
+	inputs []exccjson.SStxInput,
+	amounts map[exccutil.Address]exccutil.Amount,
+	couts []SStxCommitOut,
+	minConfirms int) FutureSendToSStxResult {
 
 	convertedAmounts := make(map[string]int64, len(amounts))
 	for addr, amount := range amounts {
@@ -918,7 +1298,7 @@ func (c *Client) SendToSStxMinConfAsync(fromAccount string,
 	cmd := exccjson.NewSendToSStxCmd(fromAccount, convertedAmounts,
 		inputs, convertedCouts, &minConfirms, nil)
 
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // SendToSStxMinConf purchases a stake ticket with the amounts specified, with
@@ -939,6 +1319,18 @@ func (c *Client) SendToSStxMinConf(fromAccount string,
 	return c.SendToSStxMinConfAsync(fromAccount, inputs, amounts, couts, minConfirms).Receive()
 }
 
+// SendToSStxMinConfContext is like SendToSStxMinConf but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) SendToSStxMinConfContext(ctx context.Context, fromAccount string,
+	inputs []exccjson.SStxInput,
+	amounts map[exccutil.Address]exccutil.Amount,
+	couts []SStxCommitOut,
+	minConfirms int) (*chainhash.Hash, error) {
+
+	return c.SendToSStxMinConfAsyncContext(ctx, fromAccount, inputs, amounts, couts, minConfirms).Receive()
+}
+
 // SendToSStxCommentAsync returns an instance of a type that can be used to get
 // the result of the RPC at some future time by invoking the Receive function on
 // the returned instance.
@@ -950,6 +1342,19 @@ func (c *Client) SendToSStxCommentAsync(fromAccount string,
 	couts []SStxCommitOut,
 	minConfirms int,
 	comment string) FutureSendToSStxResult {
+	return c.SendToSStxCommentAsyncContext(context.Background(), fromAccount, inputs, amounts, couts, minConfirms, comment)
+}
+
+// SendToSStxCommentAsyncContext is like SendToSStxCommentAsync but the request
+// is abandoned once the passed context is done.
+//
+// See SendToSStxCommentContext for the blocking version.
+func (c *Client) SendToSStxCommentAsyncContext(ctx context.Context, fromAccount string,
+	inputs []exccjson.SStxInput,
+	amounts map[exccutil.Address]exccutil.Amount,
+	couts []SStxCommitOut,
+	minConfirms int,
+	comment string) FutureSendToSStxResult {
 
 	convertedAmounts := make(map[string]int64, len(amounts))
 	for addr, amount := range amounts {
@@ -966,7 +1371,7 @@ func (c *Client) SendToSStxCommentAsync(fromAccount string,
 	cmd := exccjson.NewSendToSStxCmd(fromAccount, convertedAmounts,
 		inputs, convertedCouts, &minConfirms, &comment)
 
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // SendToSStxComment spurchases a stake ticket with the amounts specified, with
@@ -990,6 +1395,20 @@ func (c *Client) SendToSStxComment(fromAccount string,
 		comment).Receive()
 }
 
+// SendToSStxCommentContext is like SendToSStxComment but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) SendToSStxCommentContext(ctx context.Context, fromAccount string,
+	inputs []exccjson.SStxInput,
+	amounts map[exccutil.Address]exccutil.Amount,
+	couts []SStxCommitOut,
+	minConfirms int,
+	comment string) (*chainhash.Hash, error) {
+
+	return c.SendToSStxCommentAsyncContext(ctx, fromAccount, inputs, amounts, couts, minConfirms,
+		comment).Receive()
+}
+
 // --------------------------------------------------------------------------------
 
 // SSGen generation RPC call handling
@@ -1028,6 +1447,18 @@ func (c *Client) SendToSSGenAsync(fromAccount string,
 	blockhash chainhash.Hash,
 	height int64,
 	votebits uint16) FutureSendToSSGenResult {
+	return c.SendToSSGenAsyncContext(context.Background(), fromAccount, tickethash, blockhash, height, votebits)
+}
+
+// SendToSSGenAsyncContext is like SendToSSGenAsync but the request is abandoned
+// once the passed context is done.
+//
+// See SendToSSGenContext for the blocking version.
+func (c *Client) SendToSSGenAsyncContext(ctx context.Context, fromAccount string,
+	tickethash chainhash.Hash,
+	blockhash chainhash.Hash,
+	height int64,
+	votebits uint16) FutureSendToSSGenResult {
 
 	ticketHashString := hex.EncodeToString(tickethash[:])
 	blockHashString := hex.EncodeToString(blockhash[:])
@@ -1035,7 +1466,7 @@ func (c *Client) SendToSSGenAsync(fromAccount string,
 	cmd := exccjson.NewSendToSSGenCmd(fromAccount, ticketHashString,
 		blockHashString, height, votebits, nil)
 
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // SendToSSGen spends a stake ticket to validate a block (blockhash) at a certain
@@ -1055,6 +1486,18 @@ func (c *Client) SendToSSGen(fromAccount string,
 	return c.SendToSSGenAsync(fromAccount, tickethash, blockhash, height, votebits).Receive()
 }
 
+// SendToSSGenContext is like SendToSSGen but the request is abandoned with the
+// error of the passed context once it is done, such as when it times out or is
+// canceled.
+func (c *Client) SendToSSGenContext(ctx context.Context, fromAccount string,
+	tickethash chainhash.Hash,
+	blockhash chainhash.Hash,
+	height int64,
+	votebits uint16) (*chainhash.Hash, error) {
+
+	return c.SendToSSGenAsyncContext(ctx, fromAccount, tickethash, blockhash, height, votebits).Receive()
+}
+
 // SendToSSGenCommentAsync returns an instance of a type that can be used to get
 // the result of the RPC at some future time by invoking the Receive function on
 // the returned instance.
@@ -1066,6 +1509,19 @@ func (c *Client) SendToSSGenCommentAsync(fromAccount string,
 	height int64,
 	votebits uint16,
 	comment string) FutureSendToSSGenResult {
+	return c.SendToSSGenCommentAsyncContext(context.Background(), fromAccount, tickethash, blockhash, height, votebits, comment)
+}
+
+// SendToSSGenCommentAsyncContext is like SendToSSGenCommentAsync but the
+// request is abandoned once the passed context is done.
+//
+// See SendToSSGenCommentContext for the blocking version.
+func (c *Client) SendToSSGenCommentAsyncContext(ctx context.Context, fromAccount string,
+	tickethash chainhash.Hash,
+	blockhash chainhash.Hash,
+	height int64,
+	votebits uint16,
+	comment string) FutureSendToSSGenResult {
 
 	ticketHashString := hex.EncodeToString(tickethash[:])
 	blockHashString := hex.EncodeToString(blockhash[:])
@@ -1073,7 +1529,7 @@ func (c *Client) SendToSSGenCommentAsync(fromAccount string,
 	cmd := exccjson.NewSendToSSGenCmd(fromAccount, ticketHashString,
 		blockHashString, height, votebits, &comment)
 
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // SendToSSGenComment spends a stake ticket to validate a block (blockhash) at a
@@ -1097,6 +1553,20 @@ func (c *Client) SendToSSGenComment(fromAccount string,
 		votebits, comment).Receive()
 }
 
+// SendToSSGenCommentContext is like SendToSSGenComment but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) SendToSSGenCommentContext(ctx context.Context, fromAccount string,
+	tickethash chainhash.Hash,
+	blockhash chainhash.Hash,
+	height int64,
+	votebits uint16,
+	comment string) (*chainhash.Hash, error) {
+
+	return c.SendToSSGenCommentAsyncContext(ctx, fromAccount, tickethash, blockhash, height,
+		votebits, comment).Receive()
+}
+
 // --------------------------------------------------------------------------------
 
 // SSRtx generation RPC call handling
@@ -1132,12 +1602,21 @@ func (r FutureSendToSSRtxResult) Receive() (*chainhash.Hash, error) {
 // See SendToSSRtx for the blocking version and more details.
 func (c *Client) SendToSSRtxAsync(fromAccount string,
 	tickethash chainhash.Hash) FutureSendToSSRtxResult {
+	return c.SendToSSRtxAsyncContext(context.Background(), fromAccount, tickethash)
+}
+
+// SendToSSRtxAsyncContext is like SendToSSRtxAsync but the request is abandoned
+// once the passed context is done.
+//
+// See SendToSSRtxContext for the blocking version.
+func (c *Client) SendToSSRtxAsyncContext(ctx context.Context, fromAccount string,
+	tickethash chainhash.Hash) FutureSendToSSRtxResult {
 
 	ticketHashString := hex.EncodeToString(tickethash[:])
 
 	cmd := exccjson.NewSendToSSRtxCmd(fromAccount, ticketHashString, nil)
 
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // SendToSSRtx spends a stake ticket that previously missed its chance to vote on
@@ -1153,6 +1632,15 @@ func (c *Client) SendToSSRtx(fromAccount string,
 	return c.SendToSSRtxAsync(fromAccount, tickethash).Receive()
 }
 
+// SendToSSRtxContext is like SendToSSRtx but the request is abandoned with the
+// error of the passed context once it is done, such as when it times out or is
+// canceled.
+func (c *Client) SendToSSRtxContext(ctx context.Context, fromAccount string,
+	tickethash chainhash.Hash) (*chainhash.Hash, error) {
+
+	return c.SendToSSRtxAsyncContext(ctx, fromAccount, tickethash).Receive()
+}
+
 // SendToSSRtxCommentAsync returns an instance of a type that can be used to get
 // the result of the RPC at some future time by invoking the Receive function on
 // the returned instance.
@@ -1161,12 +1649,22 @@ func (c *Client) SendToSSRtx(fromAccount string,
 func (c *Client) SendToSSRtxCommentAsync(fromAccount string,
 	tickethash chainhash.Hash,
 	comment string) FutureSendToSSRtxResult {
+	return c.SendToSSRtxCommentAsyncContext(context.Background(), fromAccount, tickethash, comment)
+}
+
+// SendToSSRtxCommentAsyncContext is like SendToSSRtxCommentAsync but the
+// request is abandoned once the passed context is done.
+//
+// See SendToSSRtxCommentContext for the blocking version.
+func (c *Client) SendToSSRtxCommentAsyncContext(ctx context.Context, fromAccount string,
+	tickethash chainhash.Hash,
+	comment string) FutureSendToSSRtxResult {
 
 	ticketHashString := hex.EncodeToString(tickethash[:])
 
 	cmd := exccjson.NewSendToSSRtxCmd(fromAccount, ticketHashString, &comment)
 
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // SendToSSRtxComment spends a stake ticket that previously missed its chance to
@@ -1185,6 +1683,16 @@ func (c *Client) SendToSSRtxComment(fromAccount string,
 	return c.SendToSSRtxCommentAsync(fromAccount, tickethash, comment).Receive()
 }
 
+// SendToSSRtxCommentContext is like SendToSSRtxComment but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) SendToSSRtxCommentContext(ctx context.Context, fromAccount string,
+	tickethash chainhash.Hash,
+	comment string) (*chainhash.Hash, error) {
+
+	return c.SendToSSRtxCommentAsyncContext(ctx, fromAccount, tickethash, comment).Receive()
+}
+
 // END ExchangeCoin FUNCTIONS -----------------------------------------------------------
 
 // *************************
@@ -1220,13 +1728,21 @@ func (r FutureAddMultisigAddressResult) Receive() (exccutil.Address, error) {
 //
 // See AddMultisigAddress for the blocking version and more details.
 func (c *Client) AddMultisigAddressAsync(requiredSigs int, addresses []exccutil.Address, account string) FutureAddMultisigAddressResult {
+	return c.AddMultisigAddressAsyncContext(context.Background(), requiredSigs, addresses, account)
+}
+
+// AddMultisigAddressAsyncContext is like AddMultisigAddressAsync but the
+// request is abandoned once the passed context is done.
+//
+// See AddMultisigAddressContext for the blocking version.
+func (c *Client) AddMultisigAddressAsyncContext(ctx context.Context, requiredSigs int, addresses []exccutil.Address, account string) FutureAddMultisigAddressResult {
 	addrs := make([]string, 0, len(addresses))
 	for _, addr := range addresses {
 		addrs = append(addrs, addr.String())
 	}
 
 	cmd := exccjson.NewAddMultisigAddressCmd(requiredSigs, addrs, &account)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // AddMultisigAddress adds a multisignature address that requires the specified
@@ -1236,6 +1752,14 @@ func (c *Client) AddMultisigAddress(requiredSigs int, addresses []exccutil.Addre
 		account).Receive()
 }
 
+// AddMultisigAddressContext is like AddMultisigAddress but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) AddMultisigAddressContext(ctx context.Context, requiredSigs int, addresses []exccutil.Address, account string) (exccutil.Address, error) {
+	return c.AddMultisigAddressAsyncContext(ctx, requiredSigs, addresses,
+		account).Receive()
+}
+
 // FutureCreateMultisigResult is a future promise to deliver the result of a
 // CreateMultisigAsync RPC invocation (or an applicable error).
 type FutureCreateMultisigResult chan *response
@@ -1264,13 +1788,21 @@ func (r FutureCreateMultisigResult) Receive() (*exccjson.CreateMultiSigResult, e
 //
 // See CreateMultisig for the blocking version and more details.
 func (c *Client) CreateMultisigAsync(requiredSigs int, addresses []exccutil.Address) FutureCreateMultisigResult {
+	return c.CreateMultisigAsyncContext(context.Background(), requiredSigs, addresses)
+}
+
+// CreateMultisigAsyncContext is like CreateMultisigAsync but the request is
+// abandoned once the passed context is done.
+//
+// See CreateMultisigContext for the blocking version.
+func (c *Client) CreateMultisigAsyncContext(ctx context.Context, requiredSigs int, addresses []exccutil.Address) FutureCreateMultisigResult {
 	addrs := make([]string, 0, len(addresses))
 	for _, addr := range addresses {
 		addrs = append(addrs, addr.String())
 	}
 
 	cmd := exccjson.NewCreateMultisigCmd(requiredSigs, addrs)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // CreateMultisig creates a multisignature address that requires the specified
@@ -1280,6 +1812,13 @@ func (c *Client) CreateMultisig(requiredSigs int, addresses []exccutil.Address)
 	return c.CreateMultisigAsync(requiredSigs, addresses).Receive()
 }
 
+// CreateMultisigContext is like CreateMultisig but the request is abandoned
+// with the error of the passed context once it is done, such as when it times
+// out or is canceled.
+func (c *Client) CreateMultisigContext(ctx context.Context, requiredSigs int, addresses []exccutil.Address) (*exccjson.CreateMultiSigResult, error) {
+	return c.CreateMultisigAsyncContext(ctx, requiredSigs, addresses).Receive()
+}
+
 // FutureCreateNewAccountResult is a future promise to deliver the result of a
 // CreateNewAccountAsync RPC invocation (or an applicable error).
 type FutureCreateNewAccountResult chan *response
@@ -1297,8 +1836,16 @@ func (r FutureCreateNewAccountResult) Receive() error {
 //
 // See CreateNewAccount for the blocking version and more details.
 func (c *Client) CreateNewAccountAsync(account string) FutureCreateNewAccountResult {
+	return c.CreateNewAccountAsyncContext(context.Background(), account)
+}
+
+// CreateNewAccountAsyncContext is like CreateNewAccountAsync but the request is
+// abandoned once the passed context is done.
+//
+// See CreateNewAccountContext for the blocking version.
+func (c *Client) CreateNewAccountAsyncContext(ctx context.Context, account string) FutureCreateNewAccountResult {
 	cmd := exccjson.NewCreateNewAccountCmd(account)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // CreateNewAccount creates a new wallet account.
@@ -1306,6 +1853,13 @@ func (c *Client) CreateNewAccount(account string) error {
 	return c.CreateNewAccountAsync(account).Receive()
 }
 
+// CreateNewAccountContext is like CreateNewAccount but the request is abandoned
+// with the error of the passed context once it is done, such as when it times
+// out or is canceled.
+func (c *Client) CreateNewAccountContext(ctx context.Context, account string) error {
+	return c.CreateNewAccountAsyncContext(ctx, account).Receive()
+}
+
 // FutureGetNewAddressResult is a future promise to deliver the result of a
 // GetNewAddressAsync RPC invocation (or an applicable error).
 type FutureGetNewAddressResult chan *response
@@ -1346,8 +1900,16 @@ const (
 //
 // See GetNewAddress for the blocking version and more details.
 func (c *Client) GetNewAddressAsync(account string) FutureGetNewAddressResult {
+	return c.GetNewAddressAsyncContext(context.Background(), account)
+}
+
+// GetNewAddressAsyncContext is like GetNewAddressAsync but the request is
+// abandoned once the passed context is done.
+//
+// See GetNewAddressContext for the blocking version.
+func (c *Client) GetNewAddressAsyncContext(ctx context.Context, account string) FutureGetNewAddressResult {
 	cmd := exccjson.NewGetNewAddressCmd(&account, nil)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetNewAddressGapPolicyAsync returns an instance of a type that can be used to
@@ -1356,8 +1918,16 @@ func (c *Client) GetNewAddressAsync(account string) FutureGetNewAddressResult {
 //
 // See GetNewAddressGapPolicy for the blocking version and more details.
 func (c *Client) GetNewAddressGapPolicyAsync(account string, gapPolicy GapPolicy) FutureGetNewAddressResult {
+	return c.GetNewAddressGapPolicyAsyncContext(context.Background(), account, gapPolicy)
+}
+
+// GetNewAddressGapPolicyAsyncContext is like GetNewAddressGapPolicyAsync but
+// the request is abandoned once the passed context is done.
+//
+// See GetNewAddressGapPolicyContext for the blocking version.
+func (c *Client) GetNewAddressGapPolicyAsyncContext(ctx context.Context, account string, gapPolicy GapPolicy) FutureGetNewAddressResult {
 	cmd := exccjson.NewGetNewAddressCmd(&account, (*string)(&gapPolicy))
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetNewAddress returns a new address.
@@ -1365,12 +1935,26 @@ func (c *Client) GetNewAddress(account string) (exccutil.Address, error) {
 	return c.GetNewAddressAsync(account).Receive()
 }
 
+// GetNewAddressContext is like GetNewAddress but the request is abandoned with
+// the error of the passed context once it is done, such as when it times out or
+// is canceled.
+func (c *Client) GetNewAddressContext(ctx context.Context, account string) (exccutil.Address, error) {
+	return c.GetNewAddressAsyncContext(ctx, account).Receive()
+}
+
 // GetNewAddressGapPolicy returns a new address while allowing callers to
 // control the BIP0044 unused address gap limit policy.
 func (c *Client) GetNewAddressGapPolicy(account string, gapPolicy GapPolicy) (exccutil.Address, error) {
 	return c.GetNewAddressGapPolicyAsync(account, gapPolicy).Receive()
 }
 
+// GetNewAddressGapPolicyContext is like GetNewAddressGapPolicy but the request
+// is abandoned with the error of the passed context once it is done, such as
+// when it times out or is canceled.
+func (c *Client) GetNewAddressGapPolicyContext(ctx context.Context, account string, gapPolicy GapPolicy) (exccutil.Address, error) {
+	return c.GetNewAddressGapPolicyAsyncContext(ctx, account, gapPolicy).Receive()
+}
+
 // FutureGetRawChangeAddressResult is a future promise to deliver the result of
 // a GetRawChangeAddressAsync RPC invocation (or an applicable error).
 type FutureGetRawChangeAddressResult chan *response
@@ -1400,8 +1984,16 @@ func (r FutureGetRawChangeAddressResult) Receive() (exccutil.Address, error) {
 //
 // See GetRawChangeAddress for the blocking version and more details.
 func (c *Client) GetRawChangeAddressAsync(account string) FutureGetRawChangeAddressResult {
+	return c.GetRawChangeAddressAsyncContext(context.Background(), account)
+}
+
+// GetRawChangeAddressAsyncContext is like GetRawChangeAddressAsync but the
+// request is abandoned once the passed context is done.
+//
+// See GetRawChangeAddressContext for the blocking version.
+func (c *Client) GetRawChangeAddressAsyncContext(ctx context.Context, account string) FutureGetRawChangeAddressResult {
 	cmd := exccjson.NewGetRawChangeAddressCmd(&account)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetRawChangeAddress returns a new address for receiving change that will be
@@ -1411,6 +2003,13 @@ func (c *Client) GetRawChangeAddress(account string) (exccutil.Address, error) {
 	return c.GetRawChangeAddressAsync(account).Receive()
 }
 
+// GetRawChangeAddressContext is like GetRawChangeAddress but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) GetRawChangeAddressContext(ctx context.Context, account string) (exccutil.Address, error) {
+	return c.GetRawChangeAddressAsyncContext(ctx, account).Receive()
+}
+
 // FutureGetAccountAddressResult is a future promise to deliver the result of a
 // GetAccountAddressAsync RPC invocation (or an applicable error).
 type FutureGetAccountAddressResult chan *response
@@ -1439,8 +2038,16 @@ func (r FutureGetAccountAddressResult) Receive() (exccutil.Address, error) {
 //
 // See GetAccountAddress for the blocking version and more details.
 func (c *Client) GetAccountAddressAsync(account string) FutureGetAccountAddressResult {
+	return c.GetAccountAddressAsyncContext(context.Background(), account)
+}
+
+// GetAccountAddressAsyncContext is like GetAccountAddressAsync but the request
+// is abandoned once the passed context is done.
+//
+// See GetAccountAddressContext for the blocking version.
+func (c *Client) GetAccountAddressAsyncContext(ctx context.Context, account string) FutureGetAccountAddressResult {
 	cmd := exccjson.NewGetAccountAddressCmd(account)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetAccountAddress returns the current ExchangeCoin address for receiving payments
@@ -1449,6 +2056,13 @@ func (c *Client) GetAccountAddress(account string) (exccutil.Address, error) {
 	return c.GetAccountAddressAsync(account).Receive()
 }
 
+// GetAccountAddressContext is like GetAccountAddress but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) GetAccountAddressContext(ctx context.Context, account string) (exccutil.Address, error) {
+	return c.GetAccountAddressAsyncContext(ctx, account).Receive()
+}
+
 // FutureGetAccountResult is a future promise to deliver the result of a
 // GetAccountAsync RPC invocation (or an applicable error).
 type FutureGetAccountResult chan *response
@@ -1477,9 +2091,17 @@ func (r FutureGetAccountResult) Receive() (string, error) {
 //
 // See GetAccount for the blocking version and more details.
 func (c *Client) GetAccountAsync(address exccutil.Address) FutureGetAccountResult {
+	return c.GetAccountAsyncContext(context.Background(), address)
+}
+
+// GetAccountAsyncContext is like GetAccountAsync but the request is abandoned
+// once the passed context is done.
+//
+// See GetAccountContext for the blocking version.
+func (c *Client) GetAccountAsyncContext(ctx context.Context, address exccutil.Address) FutureGetAccountResult {
 	addr := address.EncodeAddress()
 	cmd := exccjson.NewGetAccountCmd(addr)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetAccount returns the account associated with the passed address.
@@ -1487,6 +2109,13 @@ func (c *Client) GetAccount(address exccutil.Address) (string, error) {
 	return c.GetAccountAsync(address).Receive()
 }
 
+// GetAccountContext is like GetAccount but the request is abandoned with the
+// error of the passed context once it is done, such as when it times out or is
+// canceled.
+func (c *Client) GetAccountContext(ctx context.Context, address exccutil.Address) (string, error) {
+	return c.GetAccountAsyncContext(ctx, address).Receive()
+}
+
 // FutureGetAddressesByAccountResult is a future promise to deliver the result
 // of a GetAddressesByAccountAsync RPC invocation (or an applicable error).
 type FutureGetAddressesByAccountResult chan *response
@@ -1524,8 +2153,16 @@ func (r FutureGetAddressesByAccountResult) Receive() ([]exccutil.Address, error)
 //
 // See GetAddressesByAccount for the blocking version and more details.
 func (c *Client) GetAddressesByAccountAsync(account string) FutureGetAddressesByAccountResult {
+	return c.GetAddressesByAccountAsyncContext(context.Background(), account)
+}
+
+// GetAddressesByAccountAsyncContext is like GetAddressesByAccountAsync but the
+// request is abandoned once the passed context is done.
+//
+// See GetAddressesByAccountContext for the blocking version.
+func (c *Client) GetAddressesByAccountAsyncContext(ctx context.Context, account string) FutureGetAddressesByAccountResult {
 	cmd := exccjson.NewGetAddressesByAccountCmd(account)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetAddressesByAccount returns the list of addresses associated with the
@@ -1534,6 +2171,13 @@ func (c *Client) GetAddressesByAccount(account string) ([]exccutil.Address, erro
 	return c.GetAddressesByAccountAsync(account).Receive()
 }
 
+// GetAddressesByAccountContext is like GetAddressesByAccount but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) GetAddressesByAccountContext(ctx context.Context, account string) ([]exccutil.Address, error) {
+	return c.GetAddressesByAccountAsyncContext(ctx, account).Receive()
+}
+
 // FutureMoveResult is a future promise to deliver the result of a MoveAsync,
 // MoveMinConfAsync, or MoveCommentAsync RPC invocation (or an applicable
 // error).
@@ -1574,8 +2218,16 @@ func (r FutureRenameAccountResult) Receive() error {
 //
 // See RenameAccount for the blocking version and more details.
 func (c *Client) RenameAccountAsync(oldAccount, newAccount string) FutureRenameAccountResult {
+	return c.RenameAccountAsyncContext(context.Background(), oldAccount, newAccount)
+}
+
+// RenameAccountAsyncContext is like RenameAccountAsync but the request is
+// abandoned once the passed context is done.
+//
+// See RenameAccountContext for the blocking version.
+func (c *Client) RenameAccountAsyncContext(ctx context.Context, oldAccount, newAccount string) FutureRenameAccountResult {
 	cmd := exccjson.NewRenameAccountCmd(oldAccount, newAccount)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // RenameAccount creates a new wallet account.
@@ -1583,6 +2235,13 @@ func (c *Client) RenameAccount(oldAccount, newAccount string) error {
 	return c.RenameAccountAsync(oldAccount, newAccount).Receive()
 }
 
+// RenameAccountContext is like RenameAccount but the request is abandoned with
+// the error of the passed context once it is done, such as when it times out or
+// is canceled.
+func (c *Client) RenameAccountContext(ctx context.Context, oldAccount, newAccount string) error {
+	return c.RenameAccountAsyncContext(ctx, oldAccount, newAccount).Receive()
+}
+
 // FutureValidateAddressResult is a future promise to deliver the result of a
 // ValidateAddressAsync RPC invocation (or an applicable error).
 type FutureValidateAddressResult chan *response
@@ -1611,9 +2270,17 @@ func (r FutureValidateAddressResult) Receive() (*exccjson.ValidateAddressWalletR
 //
 // See ValidateAddress for the blocking version and more details.
 func (c *Client) ValidateAddressAsync(address exccutil.Address) FutureValidateAddressResult {
+	return c.ValidateAddressAsyncContext(context.Background(), address)
+}
+
+// ValidateAddressAsyncContext is like ValidateAddressAsync but the request is
+// abandoned once the passed context is done.
+//
+// See ValidateAddressContext for the blocking version.
+func (c *Client) ValidateAddressAsyncContext(ctx context.Context, address exccutil.Address) FutureValidateAddressResult {
 	addr := address.EncodeAddress()
-	cmd := exccjson.NewValidateAddressCmd(addr)
-	return c.sendCmd(cmd)
+	cmd := exccjson.NewValidateAddressCmd(addr, nil)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // ValidateAddress returns information about the given ExchangeCoin address.
@@ -1621,6 +2288,13 @@ func (c *Client) ValidateAddress(address exccutil.Address) (*exccjson.ValidateAd
 	return c.ValidateAddressAsync(address).Receive()
 }
 
+// ValidateAddressContext is like ValidateAddress but the request is abandoned
+// with the error of the passed context once it is done, such as when it times
+// out or is canceled.
+func (c *Client) ValidateAddressContext(ctx context.Context, address exccutil.Address) (*exccjson.ValidateAddressWalletResult, error) {
+	return c.ValidateAddressAsyncContext(ctx, address).Receive()
+}
+
 // FutureKeyPoolRefillResult is a future promise to deliver the result of a
 // KeyPoolRefillAsync RPC invocation (or an applicable error).
 type FutureKeyPoolRefillResult chan *response
@@ -1638,8 +2312,16 @@ func (r FutureKeyPoolRefillResult) Receive() error {
 //
 // See KeyPoolRefill for the blocking version and more details.
 func (c *Client) KeyPoolRefillAsync() FutureKeyPoolRefillResult {
+	return c.KeyPoolRefillAsyncContext(context.Background())
+}
+
+// KeyPoolRefillAsyncContext is like KeyPoolRefillAsync but the request is
+// abandoned once the passed context is done.
+//
+// See KeyPoolRefillContext for the blocking version.
+func (c *Client) KeyPoolRefillAsyncContext(ctx context.Context) FutureKeyPoolRefillResult {
 	cmd := exccjson.NewKeyPoolRefillCmd(nil)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // KeyPoolRefill fills the key pool as necessary to reach the default size.
@@ -1649,14 +2331,29 @@ func (c *Client) KeyPoolRefill() error {
 	return c.KeyPoolRefillAsync().Receive()
 }
 
+// KeyPoolRefillContext is like KeyPoolRefill but the request is abandoned with
+// the error of the passed context once it is done, such as when it times out or
+// is canceled.
+func (c *Client) KeyPoolRefillContext(ctx context.Context) error {
+	return c.KeyPoolRefillAsyncContext(ctx).Receive()
+}
+
 // KeyPoolRefillSizeAsync returns an instance of a type that can be used to get
 // the result of the RPC at some future time by invoking the Receive function on
 // the returned instance.
 //
 // See KeyPoolRefillSize for the blocking version and more details.
 func (c *Client) KeyPoolRefillSizeAsync(newSize uint) FutureKeyPoolRefillResult {
+	return c.KeyPoolRefillSizeAsyncContext(context.Background(), newSize)
+}
+
+// KeyPoolRefillSizeAsyncContext is like KeyPoolRefillSizeAsync but the request
+// is abandoned once the passed context is done.
+//
+// See KeyPoolRefillSizeContext for the blocking version.
+func (c *Client) KeyPoolRefillSizeAsyncContext(ctx context.Context, newSize uint) FutureKeyPoolRefillResult {
 	cmd := exccjson.NewKeyPoolRefillCmd(&newSize)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // KeyPoolRefillSize fills the key pool as necessary to reach the specified
@@ -1665,6 +2362,13 @@ func (c *Client) KeyPoolRefillSize(newSize uint) error {
 	return c.KeyPoolRefillSizeAsync(newSize).Receive()
 }
 
+// KeyPoolRefillSizeContext is like KeyPoolRefillSize but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) KeyPoolRefillSizeContext(ctx context.Context, newSize uint) error {
+	return c.KeyPoolRefillSizeAsyncContext(ctx, newSize).Receive()
+}
+
 // ************************
 // Amount/Balance Functions
 // ************************
@@ -1708,8 +2412,16 @@ func (r FutureListAccountsResult) Receive() (map[string]exccutil.Amount, error)
 //
 // See ListAccounts for the blocking version and more details.
 func (c *Client) ListAccountsAsync() FutureListAccountsResult {
+	return c.ListAccountsAsyncContext(context.Background())
+}
+
+// ListAccountsAsyncContext is like ListAccountsAsync but the request is
+// abandoned once the passed context is done.
+//
+// See ListAccountsContext for the blocking version.
+func (c *Client) ListAccountsAsyncContext(ctx context.Context) FutureListAccountsResult {
 	cmd := exccjson.NewListAccountsCmd(nil)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // ListAccounts returns a map of account names and their associated balances
@@ -1720,14 +2432,29 @@ func (c *Client) ListAccounts() (map[string]exccutil.Amount, error) {
 	return c.ListAccountsAsync().Receive()
 }
 
+// ListAccountsContext is like ListAccounts but the request is abandoned with
+// the error of the passed context once it is done, such as when it times out or
+// is canceled.
+func (c *Client) ListAccountsContext(ctx context.Context) (map[string]exccutil.Amount, error) {
+	return c.ListAccountsAsyncContext(ctx).Receive()
+}
+
 // ListAccountsMinConfAsync returns an instance of a type that can be used to
 // get the result of the RPC at some future time by invoking the Receive
 // function on the returned instance.
 //
 // See ListAccountsMinConf for the blocking version and more details.
 func (c *Client) ListAccountsMinConfAsync(minConfirms int) FutureListAccountsResult {
+	return c.ListAccountsMinConfAsyncContext(context.Background(), minConfirms)
+}
+
+// ListAccountsMinConfAsyncContext is like ListAccountsMinConfAsync but the
+// request is abandoned once the passed context is done.
+//
+// See ListAccountsMinConfContext for the blocking version.
+func (c *Client) ListAccountsMinConfAsyncContext(ctx context.Context, minConfirms int) FutureListAccountsResult {
 	cmd := exccjson.NewListAccountsCmd(&minConfirms)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // ListAccountsMinConf returns a map of account names and their associated
@@ -1738,6 +2465,13 @@ func (c *Client) ListAccountsMinConf(minConfirms int) (map[string]exccutil.Amoun
 	return c.ListAccountsMinConfAsync(minConfirms).Receive()
 }
 
+// ListAccountsMinConfContext is like ListAccountsMinConf but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) ListAccountsMinConfContext(ctx context.Context, minConfirms int) (map[string]exccutil.Amount, error) {
+	return c.ListAccountsMinConfAsyncContext(ctx, minConfirms).Receive()
+}
+
 // FutureGetBalanceResult is a future promise to deliver the result of a
 // GetBalanceAsync or GetBalanceMinConfAsync RPC invocation (or an applicable
 // error).
@@ -1767,8 +2501,16 @@ func (r FutureGetBalanceResult) Receive() (*exccjson.GetBalanceResult, error) {
 //
 // See GetBalance for the blocking version and more details.
 func (c *Client) GetBalanceAsync(account string) FutureGetBalanceResult {
+	return c.GetBalanceAsyncContext(context.Background(), account)
+}
+
+// GetBalanceAsyncContext is like GetBalanceAsync but the request is abandoned
+// once the passed context is done.
+//
+// See GetBalanceContext for the blocking version.
+func (c *Client) GetBalanceAsyncContext(ctx context.Context, account string) FutureGetBalanceResult {
 	cmd := exccjson.NewGetBalanceCmd(&account, nil)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetBalance returns the available balance from the server for the specified
@@ -1780,14 +2522,29 @@ func (c *Client) GetBalance(account string) (*exccjson.GetBalanceResult, error)
 	return c.GetBalanceAsync(account).Receive()
 }
 
+// GetBalanceContext is like GetBalance but the request is abandoned with the
+// error of the passed context once it is done, such as when it times out or is
+// canceled.
+func (c *Client) GetBalanceContext(ctx context.Context, account string) (*exccjson.GetBalanceResult, error) {
+	return c.GetBalanceAsyncContext(ctx, account).Receive()
+}
+
 // GetBalanceMinConfAsync returns an instance of a type that can be used to get
 // the result of the RPC at some future time by invoking the Receive function on
 // the returned instance.
 //
 // See GetBalanceMinConf for the blocking version and more details.
 func (c *Client) GetBalanceMinConfAsync(account string, minConfirms int) FutureGetBalanceResult {
+	return c.GetBalanceMinConfAsyncContext(context.Background(), account, minConfirms)
+}
+
+// GetBalanceMinConfAsyncContext is like GetBalanceMinConfAsync but the request
+// is abandoned once the passed context is done.
+//
+// See GetBalanceMinConfContext for the blocking version.
+func (c *Client) GetBalanceMinConfAsyncContext(ctx context.Context, account string, minConfirms int) FutureGetBalanceResult {
 	cmd := exccjson.NewGetBalanceCmd(&account, &minConfirms)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetBalanceMinConf returns the available balance from the server for the
@@ -1799,6 +2556,13 @@ func (c *Client) GetBalanceMinConf(account string, minConfirms int) (*exccjson.G
 	return c.GetBalanceMinConfAsync(account, minConfirms).Receive()
 }
 
+// GetBalanceMinConfContext is like GetBalanceMinConf but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) GetBalanceMinConfContext(ctx context.Context, account string, minConfirms int) (*exccjson.GetBalanceResult, error) {
+	return c.GetBalanceMinConfAsyncContext(ctx, account, minConfirms).Receive()
+}
+
 // FutureGetReceivedByAccountResult is a future promise to deliver the result of
 // a GetReceivedByAccountAsync or GetReceivedByAccountMinConfAsync RPC
 // invocation (or an applicable error).
@@ -1833,8 +2597,16 @@ func (r FutureGetReceivedByAccountResult) Receive() (exccutil.Amount, error) {
 //
 // See GetReceivedByAccount for the blocking version and more details.
 func (c *Client) GetReceivedByAccountAsync(account string) FutureGetReceivedByAccountResult {
+	return c.GetReceivedByAccountAsyncContext(context.Background(), account)
+}
+
+// GetReceivedByAccountAsyncContext is like GetReceivedByAccountAsync but the
+// request is abandoned once the passed context is done.
+//
+// See GetReceivedByAccountContext for the blocking version.
+func (c *Client) GetReceivedByAccountAsyncContext(ctx context.Context, account string) FutureGetReceivedByAccountResult {
 	cmd := exccjson.NewGetReceivedByAccountCmd(account, nil)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetReceivedByAccount returns the total amount received with the specified
@@ -1846,14 +2618,30 @@ func (c *Client) GetReceivedByAccount(account string) (exccutil.Amount, error) {
 	return c.GetReceivedByAccountAsync(account).Receive()
 }
 
+// GetReceivedByAccountContext is like GetReceivedByAccount but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) GetReceivedByAccountContext(ctx context.Context, account string) (exccutil.Amount, error) {
+	return c.GetReceivedByAccountAsyncContext(ctx, account).Receive()
+}
+
 // GetReceivedByAccountMinConfAsync returns an instance of a type that can be
 // used to get the result of the RPC at some future time by invoking the Receive
 // function on the returned instance.
 //
 // See GetReceivedByAccountMinConf for the blocking version and more details.
 func (c *Client) GetReceivedByAccountMinConfAsync(account string, minConfirms int) FutureGetReceivedByAccountResult {
+	return c.GetReceivedByAccountMinConfAsyncContext(context.Background(), account, minConfirms)
+}
+
+// GetReceivedByAccountMinConfAsyncContext is like
+// GetReceivedByAccountMinConfAsync but the request is abandoned once the passed
+// context is done.
+//
+// See GetReceivedByAccountMinConfContext for the blocking version.
+func (c *Client) GetReceivedByAccountMinConfAsyncContext(ctx context.Context, account string, minConfirms int) FutureGetReceivedByAccountResult {
 	cmd := exccjson.NewGetReceivedByAccountCmd(account, &minConfirms)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetReceivedByAccountMinConf returns the total amount received with the
@@ -1865,6 +2653,13 @@ func (c *Client) GetReceivedByAccountMinConf(account string, minConfirms int) (e
 	return c.GetReceivedByAccountMinConfAsync(account, minConfirms).Receive()
 }
 
+// GetReceivedByAccountMinConfContext is like GetReceivedByAccountMinConf but
+// the request is abandoned with the error of the passed context once it is
+// done, such as when it times out or is canceled.
+func (c *Client) GetReceivedByAccountMinConfContext(ctx context.Context, account string, minConfirms int) (exccutil.Amount, error) {
+	return c.GetReceivedByAccountMinConfAsyncContext(ctx, account, minConfirms).Receive()
+}
+
 // FutureGetUnconfirmedBalanceResult is a future promise to deliver the result
 // of a GetUnconfirmedBalanceAsync RPC invocation (or an applicable error).
 type FutureGetUnconfirmedBalanceResult chan *response
@@ -1898,8 +2693,16 @@ func (r FutureGetUnconfirmedBalanceResult) Receive() (exccutil.Amount, error) {
 //
 // See GetUnconfirmedBalance for the blocking version and more details.
 func (c *Client) GetUnconfirmedBalanceAsync(account string) FutureGetUnconfirmedBalanceResult {
+	return c.GetUnconfirmedBalanceAsyncContext(context.Background(), account)
+}
+
+// GetUnconfirmedBalanceAsyncContext is like GetUnconfirmedBalanceAsync but the
+// request is abandoned once the passed context is done.
+//
+// See GetUnconfirmedBalanceContext for the blocking version.
+func (c *Client) GetUnconfirmedBalanceAsyncContext(ctx context.Context, account string) FutureGetUnconfirmedBalanceResult {
 	cmd := exccjson.NewGetUnconfirmedBalanceCmd(&account)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetUnconfirmedBalance returns the unconfirmed balance from the server for
@@ -1908,6 +2711,13 @@ func (c *Client) GetUnconfirmedBalance(account string) (exccutil.Amount, error)
 	return c.GetUnconfirmedBalanceAsync(account).Receive()
 }
 
+// GetUnconfirmedBalanceContext is like GetUnconfirmedBalance but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) GetUnconfirmedBalanceContext(ctx context.Context, account string) (exccutil.Amount, error) {
+	return c.GetUnconfirmedBalanceAsyncContext(ctx, account).Receive()
+}
+
 // FutureGetReceivedByAddressResult is a future promise to deliver the result of
 // a GetReceivedByAddressAsync or GetReceivedByAddressMinConfAsync RPC
 // invocation (or an applicable error).
@@ -1942,9 +2752,17 @@ func (r FutureGetReceivedByAddressResult) Receive() (exccutil.Amount, error) {
 //
 // See GetReceivedByAddress for the blocking version and more details.
 func (c *Client) GetReceivedByAddressAsync(address exccutil.Address) FutureGetReceivedByAddressResult {
+	return c.GetReceivedByAddressAsyncContext(context.Background(), address)
+}
+
+// GetReceivedByAddressAsyncContext is like GetReceivedByAddressAsync but the
+// request is abandoned once the passed context is done.
+//
+// See GetReceivedByAddressContext for the blocking version.
+func (c *Client) GetReceivedByAddressAsyncContext(ctx context.Context, address exccutil.Address) FutureGetReceivedByAddressResult {
 	addr := address.EncodeAddress()
 	cmd := exccjson.NewGetReceivedByAddressCmd(addr, nil)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 
 }
 
@@ -1957,15 +2775,31 @@ func (c *Client) GetReceivedByAddress(address exccutil.Address) (exccutil.Amount
 	return c.GetReceivedByAddressAsync(address).Receive()
 }
 
+// GetReceivedByAddressContext is like GetReceivedByAddress but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) GetReceivedByAddressContext(ctx context.Context, address exccutil.Address) (exccutil.Amount, error) {
+	return c.GetReceivedByAddressAsyncContext(ctx, address).Receive()
+}
+
 // GetReceivedByAddressMinConfAsync returns an instance of a type that can be
 // used to get the result of the RPC at some future time by invoking the Receive
 // function on the returned instance.
 //
 // See GetReceivedByAddressMinConf for the blocking version and more details.
 func (c *Client) GetReceivedByAddressMinConfAsync(address exccutil.Address, minConfirms int) FutureGetReceivedByAddressResult {
+	return c.GetReceivedByAddressMinConfAsyncContext(context.Background(), address, minConfirms)
+}
+
+// GetReceivedByAddressMinConfAsyncContext is like
+// GetReceivedByAddressMinConfAsync but the request is abandoned once the passed
+// context is done.
+//
+// See GetReceivedByAddressMinConfContext for the blocking version.
+func (c *Client) GetReceivedByAddressMinConfAsyncContext(ctx context.Context, address exccutil.Address, minConfirms int) FutureGetReceivedByAddressResult {
 	addr := address.EncodeAddress()
 	cmd := exccjson.NewGetReceivedByAddressCmd(addr, &minConfirms)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetReceivedByAddressMinConf returns the total amount received by the specified
@@ -1976,6 +2810,13 @@ func (c *Client) GetReceivedByAddressMinConf(address exccutil.Address, minConfir
 	return c.GetReceivedByAddressMinConfAsync(address, minConfirms).Receive()
 }
 
+// GetReceivedByAddressMinConfContext is like GetReceivedByAddressMinConf but
+// the request is abandoned with the error of the passed context once it is
+// done, such as when it times out or is canceled.
+func (c *Client) GetReceivedByAddressMinConfContext(ctx context.Context, address exccutil.Address, minConfirms int) (exccutil.Amount, error) {
+	return c.GetReceivedByAddressMinConfAsyncContext(ctx, address, minConfirms).Receive()
+}
+
 // FutureListReceivedByAccountResult is a future promise to deliver the result
 // of a ListReceivedByAccountAsync, ListReceivedByAccountMinConfAsync, or
 // ListReceivedByAccountIncludeEmptyAsync RPC invocation (or an applicable
@@ -2006,8 +2847,16 @@ func (r FutureListReceivedByAccountResult) Receive() ([]exccjson.ListReceivedByA
 //
 // See ListReceivedByAccount for the blocking version and more details.
 func (c *Client) ListReceivedByAccountAsync() FutureListReceivedByAccountResult {
+	return c.ListReceivedByAccountAsyncContext(context.Background())
+}
+
+// ListReceivedByAccountAsyncContext is like ListReceivedByAccountAsync but the
+// request is abandoned once the passed context is done.
+//
+// See ListReceivedByAccountContext for the blocking version.
+func (c *Client) ListReceivedByAccountAsyncContext(ctx context.Context) FutureListReceivedByAccountResult {
 	cmd := exccjson.NewListReceivedByAccountCmd(nil, nil, nil)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // ListReceivedByAccount lists balances by account using the default number
@@ -2021,14 +2870,30 @@ func (c *Client) ListReceivedByAccount() ([]exccjson.ListReceivedByAccountResult
 	return c.ListReceivedByAccountAsync().Receive()
 }
 
+// ListReceivedByAccountContext is like ListReceivedByAccount but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) ListReceivedByAccountContext(ctx context.Context) ([]exccjson.ListReceivedByAccountResult, error) {
+	return c.ListReceivedByAccountAsyncContext(ctx).Receive()
+}
+
 // ListReceivedByAccountMinConfAsync returns an instance of a type that can be
 // used to get the result of the RPC at some future time by invoking the Receive
 // function on the returned instance.
 //
 // See ListReceivedByAccountMinConf for the blocking version and more details.
 func (c *Client) ListReceivedByAccountMinConfAsync(minConfirms int) FutureListReceivedByAccountResult {
+	return c.ListReceivedByAccountMinConfAsyncContext(context.Background(), minConfirms)
+}
+
+// ListReceivedByAccountMinConfAsyncContext is like
+// ListReceivedByAccountMinConfAsync but the request is abandoned once the
+// passed context is done.
+//
+// See ListReceivedByAccountMinConfContext for the blocking version.
+func (c *Client) ListReceivedByAccountMinConfAsyncContext(ctx context.Context, minConfirms int) FutureListReceivedByAccountResult {
 	cmd := exccjson.NewListReceivedByAccountCmd(&minConfirms, nil, nil)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // ListReceivedByAccountMinConf lists balances by account using the specified
@@ -2042,15 +2907,31 @@ func (c *Client) ListReceivedByAccountMinConf(minConfirms int) ([]exccjson.ListR
 	return c.ListReceivedByAccountMinConfAsync(minConfirms).Receive()
 }
 
+// ListReceivedByAccountMinConfContext is like ListReceivedByAccountMinConf but
+// the request is abandoned with the error of the passed context once it is
+// done, such as when it times out or is canceled.
+func (c *Client) ListReceivedByAccountMinConfContext(ctx context.Context, minConfirms int) ([]exccjson.ListReceivedByAccountResult, error) {
+	return c.ListReceivedByAccountMinConfAsyncContext(ctx, minConfirms).Receive()
+}
+
 // ListReceivedByAccountIncludeEmptyAsync returns an instance of a type that can
 // be used to get the result of the RPC at some future time by invoking the
 // Receive function on the returned instance.
 //
 // See ListReceivedByAccountIncludeEmpty for the blocking version and more details.
 func (c *Client) ListReceivedByAccountIncludeEmptyAsync(minConfirms int, includeEmpty bool) FutureListReceivedByAccountResult {
+	return c.ListReceivedByAccountIncludeEmptyAsyncContext(context.Background(), minConfirms, includeEmpty)
+}
+
+// ListReceivedByAccountIncludeEmptyAsyncContext is like
+// ListReceivedByAccountIncludeEmptyAsync but the request is abandoned once the
+// passed context is done.
+//
+// See ListReceivedByAccountIncludeEmptyContext for the blocking version.
+func (c *Client) ListReceivedByAccountIncludeEmptyAsyncContext(ctx context.Context, minConfirms int, includeEmpty bool) FutureListReceivedByAccountResult {
 	cmd := exccjson.NewListReceivedByAccountCmd(&minConfirms, &includeEmpty,
 		nil)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // ListReceivedByAccountIncludeEmpty lists balances by account using the
@@ -2063,6 +2944,15 @@ func (c *Client) ListReceivedByAccountIncludeEmpty(minConfirms int, includeEmpty
 		includeEmpty).Receive()
 }
 
+// ListReceivedByAccountIncludeEmptyContext is like
+// ListReceivedByAccountIncludeEmpty but the request is abandoned with the error
+// of the passed context once it is done, such as when it times out or is
+// canceled.
+func (c *Client) ListReceivedByAccountIncludeEmptyContext(ctx context.Context, minConfirms int, includeEmpty bool) ([]exccjson.ListReceivedByAccountResult, error) {
+	return c.ListReceivedByAccountIncludeEmptyAsyncContext(ctx, minConfirms,
+		includeEmpty).Receive()
+}
+
 // FutureListReceivedByAddressResult is a future promise to deliver the result
 // of a ListReceivedByAddressAsync, ListReceivedByAddressMinConfAsync, or
 // ListReceivedByAddressIncludeEmptyAsync RPC invocation (or an applicable
@@ -2093,8 +2983,16 @@ func (r FutureListReceivedByAddressResult) Receive() ([]exccjson.ListReceivedByA
 //
 // See ListReceivedByAddress for the blocking version and more details.
 func (c *Client) ListReceivedByAddressAsync() FutureListReceivedByAddressResult {
+	return c.ListReceivedByAddressAsyncContext(context.Background())
+}
+
+// ListReceivedByAddressAsyncContext is like ListReceivedByAddressAsync but the
+// request is abandoned once the passed context is done.
+//
+// See ListReceivedByAddressContext for the blocking version.
+func (c *Client) ListReceivedByAddressAsyncContext(ctx context.Context) FutureListReceivedByAddressResult {
 	cmd := exccjson.NewListReceivedByAddressCmd(nil, nil, nil)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // ListReceivedByAddress lists balances by address using the default number
@@ -2108,14 +3006,30 @@ func (c *Client) ListReceivedByAddress() ([]exccjson.ListReceivedByAddressResult
 	return c.ListReceivedByAddressAsync().Receive()
 }
 
+// ListReceivedByAddressContext is like ListReceivedByAddress but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) ListReceivedByAddressContext(ctx context.Context) ([]exccjson.ListReceivedByAddressResult, error) {
+	return c.ListReceivedByAddressAsyncContext(ctx).Receive()
+}
+
 // ListReceivedByAddressMinConfAsync returns an instance of a type that can be
 // used to get the result of the RPC at some future time by invoking the Receive
 // function on the returned instance.
 //
 // See ListReceivedByAddressMinConf for the blocking version and more details.
 func (c *Client) ListReceivedByAddressMinConfAsync(minConfirms int) FutureListReceivedByAddressResult {
+	return c.ListReceivedByAddressMinConfAsyncContext(context.Background(), minConfirms)
+}
+
+// ListReceivedByAddressMinConfAsyncContext is like
+// ListReceivedByAddressMinConfAsync but the request is abandoned once the
+// passed context is done.
+//
+// See ListReceivedByAddressMinConfContext for the blocking version.
+func (c *Client) ListReceivedByAddressMinConfAsyncContext(ctx context.Context, minConfirms int) FutureListReceivedByAddressResult {
 	cmd := exccjson.NewListReceivedByAddressCmd(&minConfirms, nil, nil)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // ListReceivedByAddressMinConf lists balances by address using the specified
@@ -2129,15 +3043,31 @@ func (c *Client) ListReceivedByAddressMinConf(minConfirms int) ([]exccjson.ListR
 	return c.ListReceivedByAddressMinConfAsync(minConfirms).Receive()
 }
 
+// ListReceivedByAddressMinConfContext is like ListReceivedByAddressMinConf but
+// the request is abandoned with the error of the passed context once it is
+// done, such as when it times out or is canceled.
+func (c *Client) ListReceivedByAddressMinConfContext(ctx context.Context, minConfirms int) ([]exccjson.ListReceivedByAddressResult, error) {
+	return c.ListReceivedByAddressMinConfAsyncContext(ctx, minConfirms).Receive()
+}
+
 // ListReceivedByAddressIncludeEmptyAsync returns an instance of a type that can
 // be used to get the result of the RPC at some future time by invoking the
 // Receive function on the returned instance.
 //
 // See ListReceivedByAccountIncludeEmpty for the blocking version and more details.
 func (c *Client) ListReceivedByAddressIncludeEmptyAsync(minConfirms int, includeEmpty bool) FutureListReceivedByAddressResult {
+	return c.ListReceivedByAddressIncludeEmptyAsyncContext(context.Background(), minConfirms, includeEmpty)
+}
+
+// ListReceivedByAddressIncludeEmptyAsyncContext is like
+// ListReceivedByAddressIncludeEmptyAsync but the request is abandoned once the
+// passed context is done.
+//
+// See ListReceivedByAddressIncludeEmptyContext for the blocking version.
+func (c *Client) ListReceivedByAddressIncludeEmptyAsyncContext(ctx context.Context, minConfirms int, includeEmpty bool) FutureListReceivedByAddressResult {
 	cmd := exccjson.NewListReceivedByAddressCmd(&minConfirms, &includeEmpty,
 		nil)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // ListReceivedByAddressIncludeEmpty lists balances by address using the
@@ -2150,6 +3080,15 @@ func (c *Client) ListReceivedByAddressIncludeEmpty(minConfirms int, includeEmpty
 		includeEmpty).Receive()
 }
 
+// ListReceivedByAddressIncludeEmptyContext is like
+// ListReceivedByAddressIncludeEmpty but the request is abandoned with the error
+// of the passed context once it is done, such as when it times out or is
+// canceled.
+func (c *Client) ListReceivedByAddressIncludeEmptyContext(ctx context.Context, minConfirms int, includeEmpty bool) ([]exccjson.ListReceivedByAddressResult, error) {
+	return c.ListReceivedByAddressIncludeEmptyAsyncContext(ctx, minConfirms,
+		includeEmpty).Receive()
+}
+
 // ************************
 // Wallet Locking Functions
 // ************************
@@ -2171,8 +3110,16 @@ func (r FutureWalletLockResult) Receive() error {
 //
 // See WalletLock for the blocking version and more details.
 func (c *Client) WalletLockAsync() FutureWalletLockResult {
+	return c.WalletLockAsyncContext(context.Background())
+}
+
+// WalletLockAsyncContext is like WalletLockAsync but the request is abandoned
+// once the passed context is done.
+//
+// See WalletLockContext for the blocking version.
+func (c *Client) WalletLockAsyncContext(ctx context.Context) FutureWalletLockResult {
 	cmd := exccjson.NewWalletLockCmd()
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // WalletLock locks the wallet by removing the encryption key from memory.
@@ -2184,6 +3131,13 @@ func (c *Client) WalletLock() error {
 	return c.WalletLockAsync().Receive()
 }
 
+// WalletLockContext is like WalletLock but the request is abandoned with the
+// error of the passed context once it is done, such as when it times out or is
+// canceled.
+func (c *Client) WalletLockContext(ctx context.Context) error {
+	return c.WalletLockAsyncContext(ctx).Receive()
+}
+
 // WalletPassphrase unlocks the wallet by using the passphrase to derive the
 // decryption key which is then stored in memory for the specified timeout
 // (in seconds).
@@ -2193,6 +3147,15 @@ func (c *Client) WalletPassphrase(passphrase string, timeoutSecs int64) error {
 	return err
 }
 
+// WalletPassphraseContext is like WalletPassphrase but the request is abandoned
+// with the error of the passed context once it is done, such as when it times
+// out or is canceled.
+func (c *Client) WalletPassphraseContext(ctx context.Context, passphrase string, timeoutSecs int64) error {
+	cmd := exccjson.NewWalletPassphraseCmd(passphrase, timeoutSecs)
+	_, err := receiveFuture(c.sendCmdContext(ctx, cmd))
+	return err
+}
+
 // FutureWalletPassphraseChangeResult is a future promise to deliver the result
 // of a WalletPassphraseChangeAsync RPC invocation (or an applicable error).
 type FutureWalletPassphraseChangeResult chan *response
@@ -2210,8 +3173,16 @@ func (r FutureWalletPassphraseChangeResult) Receive() error {
 //
 // See WalletPassphraseChange for the blocking version and more details.
 func (c *Client) WalletPassphraseChangeAsync(old, new string) FutureWalletPassphraseChangeResult {
+	return c.WalletPassphraseChangeAsyncContext(context.Background(), old, new)
+}
+
+// WalletPassphraseChangeAsyncContext is like WalletPassphraseChangeAsync but
+// the request is abandoned once the passed context is done.
+//
+// See WalletPassphraseChangeContext for the blocking version.
+func (c *Client) WalletPassphraseChangeAsyncContext(ctx context.Context, old, new string) FutureWalletPassphraseChangeResult {
 	cmd := exccjson.NewWalletPassphraseChangeCmd(old, new)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // WalletPassphraseChange changes the wallet passphrase from the specified old
@@ -2220,6 +3191,13 @@ func (c *Client) WalletPassphraseChange(old, new string) error {
 	return c.WalletPassphraseChangeAsync(old, new).Receive()
 }
 
+// WalletPassphraseChangeContext is like WalletPassphraseChange but the request
+// is abandoned with the error of the passed context once it is done, such as
+// when it times out or is canceled.
+func (c *Client) WalletPassphraseChangeContext(ctx context.Context, old, new string) error {
+	return c.WalletPassphraseChangeAsyncContext(ctx, old, new).Receive()
+}
+
 // *************************
 // Message Signing Functions
 // *************************
@@ -2252,9 +3230,17 @@ func (r FutureSignMessageResult) Receive() (string, error) {
 //
 // See SignMessage for the blocking version and more details.
 func (c *Client) SignMessageAsync(address exccutil.Address, message string) FutureSignMessageResult {
+	return c.SignMessageAsyncContext(context.Background(), address, message)
+}
+
+// SignMessageAsyncContext is like SignMessageAsync but the request is abandoned
+// once the passed context is done.
+//
+// See SignMessageContext for the blocking version.
+func (c *Client) SignMessageAsyncContext(ctx context.Context, address exccutil.Address, message string) FutureSignMessageResult {
 	addr := address.EncodeAddress()
 	cmd := exccjson.NewSignMessageCmd(addr, message)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // SignMessage signs a message with the private key of the specified address.
@@ -2265,6 +3251,13 @@ func (c *Client) SignMessage(address exccutil.Address, message string) (string,
 	return c.SignMessageAsync(address, message).Receive()
 }
 
+// SignMessageContext is like SignMessage but the request is abandoned with the
+// error of the passed context once it is done, such as when it times out or is
+// canceled.
+func (c *Client) SignMessageContext(ctx context.Context, address exccutil.Address, message string) (string, error) {
+	return c.SignMessageAsyncContext(ctx, address, message).Receive()
+}
+
 // FutureVerifyMessageResult is a future promise to deliver the result of a
 // VerifyMessageAsync RPC invocation (or an applicable error).
 type FutureVerifyMessageResult chan *response
@@ -2293,9 +3286,17 @@ func (r FutureVerifyMessageResult) Receive() (bool, error) {
 //
 // See VerifyMessage for the blocking version and more details.
 func (c *Client) VerifyMessageAsync(address exccutil.Address, signature, message string) FutureVerifyMessageResult {
+	return c.VerifyMessageAsyncContext(context.Background(), address, signature, message)
+}
+
+// VerifyMessageAsyncContext is like VerifyMessageAsync but the request is
+// abandoned once the passed context is done.
+//
+// See VerifyMessageContext for the blocking version.
+func (c *Client) VerifyMessageAsyncContext(ctx context.Context, address exccutil.Address, signature, message string) FutureVerifyMessageResult {
 	addr := address.EncodeAddress()
 	cmd := exccjson.NewVerifyMessageCmd(addr, signature, message)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // VerifyMessage verifies a signed message.
@@ -2306,6 +3307,13 @@ func (c *Client) VerifyMessage(address exccutil.Address, signature, message stri
 	return c.VerifyMessageAsync(address, signature, message).Receive()
 }
 
+// VerifyMessageContext is like VerifyMessage but the request is abandoned with
+// the error of the passed context once it is done, such as when it times out or
+// is canceled.
+func (c *Client) VerifyMessageContext(ctx context.Context, address exccutil.Address, signature, message string) (bool, error) {
+	return c.VerifyMessageAsyncContext(ctx, address, signature, message).Receive()
+}
+
 // *********************
 // Dump/Import Functions
 // *********************
@@ -2339,9 +3347,17 @@ func (r FutureDumpPrivKeyResult) Receive() (*exccutil.WIF, error) {
 //
 // See DumpPrivKey for the blocking version and more details.
 func (c *Client) DumpPrivKeyAsync(address exccutil.Address) FutureDumpPrivKeyResult {
+	return c.DumpPrivKeyAsyncContext(context.Background(), address)
+}
+
+// DumpPrivKeyAsyncContext is like DumpPrivKeyAsync but the request is abandoned
+// once the passed context is done.
+//
+// See DumpPrivKeyContext for the blocking version.
+func (c *Client) DumpPrivKeyAsyncContext(ctx context.Context, address exccutil.Address) FutureDumpPrivKeyResult {
 	addr := address.EncodeAddress()
 	cmd := exccjson.NewDumpPrivKeyCmd(addr)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // DumpPrivKey gets the private key corresponding to the passed address encoded
@@ -2353,6 +3369,13 @@ func (c *Client) DumpPrivKey(address exccutil.Address) (*exccutil.WIF, error) {
 	return c.DumpPrivKeyAsync(address).Receive()
 }
 
+// DumpPrivKeyContext is like DumpPrivKey but the request is abandoned with the
+// error of the passed context once it is done, such as when it times out or is
+// canceled.
+func (c *Client) DumpPrivKeyContext(ctx context.Context, address exccutil.Address) (*exccutil.WIF, error) {
+	return c.DumpPrivKeyAsyncContext(ctx, address).Receive()
+}
+
 // FutureImportAddressResult is a future promise to deliver the result of an
 // ImportAddressAsync RPC invocation (or an applicable error).
 type FutureImportAddressResult chan *response
@@ -2370,8 +3393,16 @@ func (r FutureImportAddressResult) Receive() error {
 //
 // See ImportAddress for the blocking version and more details.
 func (c *Client) ImportAddressAsync(address string) FutureImportAddressResult {
+	return c.ImportAddressAsyncContext(context.Background(), address)
+}
+
+// ImportAddressAsyncContext is like ImportAddressAsync but the request is
+// abandoned once the passed context is done.
+//
+// See ImportAddressContext for the blocking version.
+func (c *Client) ImportAddressAsyncContext(ctx context.Context, address string) FutureImportAddressResult {
 	cmd := exccjson.NewImportAddressCmd(address, nil)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // ImportAddress imports the passed public address.
@@ -2379,14 +3410,29 @@ func (c *Client) ImportAddress(address string) error {
 	return c.ImportAddressAsync(address).Receive()
 }
 
+// ImportAddressContext is like ImportAddress but the request is abandoned with
+// the error of the passed context once it is done, such as when it times out or
+// is canceled.
+func (c *Client) ImportAddressContext(ctx context.Context, address string) error {
+	return c.ImportAddressAsyncContext(ctx, address).Receive()
+}
+
 // ImportAddressRescanAsync returns an instance of a type that can be used to get the
 // result of the RPC at some future time by invoking the Receive function on the
 // returned instance.
 //
 // See ImportAddress for the blocking version and more details.
 func (c *Client) ImportAddressRescanAsync(address string, rescan bool) FutureImportAddressResult {
+	return c.ImportAddressRescanAsyncContext(context.Background(), address, rescan)
+}
+
+// ImportAddressRescanAsyncContext is like ImportAddressRescanAsync but the
+// request is abandoned once the passed context is done.
+//
+// See ImportAddressRescanContext for the blocking version.
+func (c *Client) ImportAddressRescanAsyncContext(ctx context.Context, address string, rescan bool) FutureImportAddressResult {
 	cmd := exccjson.NewImportAddressCmd(address, &rescan)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // ImportAddressRescan imports the passed public address. When rescan is true,
@@ -2395,6 +3441,13 @@ func (c *Client) ImportAddressRescan(address string, rescan bool) error {
 	return c.ImportAddressRescanAsync(address, rescan).Receive()
 }
 
+// ImportAddressRescanContext is like ImportAddressRescan but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) ImportAddressRescanContext(ctx context.Context, address string, rescan bool) error {
+	return c.ImportAddressRescanAsyncContext(ctx, address, rescan).Receive()
+}
+
 // FutureImportPrivKeyResult is a future promise to deliver the result of an
 // ImportPrivKeyAsync RPC invocation (or an applicable error).
 type FutureImportPrivKeyResult chan *response
@@ -2413,13 +3466,21 @@ func (r FutureImportPrivKeyResult) Receive() error {
 //
 // See ImportPrivKey for the blocking version and more details.
 func (c *Client) ImportPrivKeyAsync(privKeyWIF *exccutil.WIF) FutureImportPrivKeyResult {
+	return c.ImportPrivKeyAsyncContext(context.Background(), privKeyWIF)
+}
+
+// ImportPrivKeyAsyncContext is like ImportPrivKeyAsync but the request is
+// abandoned once the passed context is done.
+//
+// See ImportPrivKeyContext for the blocking version.
+func (c *Client) ImportPrivKeyAsyncContext(ctx context.Context, privKeyWIF *exccutil.WIF) FutureImportPrivKeyResult {
 	wif := ""
 	if privKeyWIF != nil {
 		wif = privKeyWIF.String()
 	}
 
 	cmd := exccjson.NewImportPrivKeyCmd(wif, nil, nil, nil)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // ImportPrivKey imports the passed private key which must be the wallet import
@@ -2428,19 +3489,34 @@ func (c *Client) ImportPrivKey(privKeyWIF *exccutil.WIF) error {
 	return c.ImportPrivKeyAsync(privKeyWIF).Receive()
 }
 
+// ImportPrivKeyContext is like ImportPrivKey but the request is abandoned with
+// the error of the passed context once it is done, such as when it times out or
+// is canceled.
+func (c *Client) ImportPrivKeyContext(ctx context.Context, privKeyWIF *exccutil.WIF) error {
+	return c.ImportPrivKeyAsyncContext(ctx, privKeyWIF).Receive()
+}
+
 // ImportPrivKeyLabelAsync returns an instance of a type that can be used to get the
 // result of the RPC at some future time by invoking the Receive function on the
 // returned instance.
 //
 // See ImportPrivKey for the blocking version and more details.
 func (c *Client) ImportPrivKeyLabelAsync(privKeyWIF *exccutil.WIF, label string) FutureImportPrivKeyResult {
+	return c.ImportPrivKeyLabelAsyncContext(context.Background(), privKeyWIF, label)
+}
+
+// ImportPrivKeyLabelAsyncContext is like ImportPrivKeyLabelAsync but the
+// request is abandoned once the passed context is done.
+//
+// See ImportPrivKeyLabelContext for the blocking version.
+func (c *Client) ImportPrivKeyLabelAsyncContext(ctx context.Context, privKeyWIF *exccutil.WIF, label string) FutureImportPrivKeyResult {
 	wif := ""
 	if privKeyWIF != nil {
 		wif = privKeyWIF.String()
 	}
 
 	cmd := exccjson.NewImportPrivKeyCmd(wif, &label, nil, nil)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // ImportPrivKeyLabel imports the passed private key which must be the wallet import
@@ -2449,19 +3525,34 @@ func (c *Client) ImportPrivKeyLabel(privKeyWIF *exccutil.WIF, label string) erro
 	return c.ImportPrivKeyLabelAsync(privKeyWIF, label).Receive()
 }
 
+// ImportPrivKeyLabelContext is like ImportPrivKeyLabel but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) ImportPrivKeyLabelContext(ctx context.Context, privKeyWIF *exccutil.WIF, label string) error {
+	return c.ImportPrivKeyLabelAsyncContext(ctx, privKeyWIF, label).Receive()
+}
+
 // ImportPrivKeyRescanAsync returns an instance of a type that can be used to get the
 // result of the RPC at some future time by invoking the Receive function on the
 // returned instance.
 //
 // See ImportPrivKey for the blocking version and more details.
 func (c *Client) ImportPrivKeyRescanAsync(privKeyWIF *exccutil.WIF, label string, rescan bool) FutureImportPrivKeyResult {
+	return c.ImportPrivKeyRescanAsyncContext(context.Background(), privKeyWIF, label, rescan)
+}
+
+// ImportPrivKeyRescanAsyncContext is like ImportPrivKeyRescanAsync but the
+// request is abandoned once the passed context is done.
+//
+// See ImportPrivKeyRescanContext for the blocking version.
+func (c *Client) ImportPrivKeyRescanAsyncContext(ctx context.Context, privKeyWIF *exccutil.WIF, label string, rescan bool) FutureImportPrivKeyResult {
 	wif := ""
 	if privKeyWIF != nil {
 		wif = privKeyWIF.String()
 	}
 
 	cmd := exccjson.NewImportPrivKeyCmd(wif, &label, &rescan, nil)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // ImportPrivKeyRescan imports the passed private key which must be the wallet import
@@ -2471,6 +3562,13 @@ func (c *Client) ImportPrivKeyRescan(privKeyWIF *exccutil.WIF, label string, res
 	return c.ImportPrivKeyRescanAsync(privKeyWIF, label, rescan).Receive()
 }
 
+// ImportPrivKeyRescanContext is like ImportPrivKeyRescan but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) ImportPrivKeyRescanContext(ctx context.Context, privKeyWIF *exccutil.WIF, label string, rescan bool) error {
+	return c.ImportPrivKeyRescanAsyncContext(ctx, privKeyWIF, label, rescan).Receive()
+}
+
 // ImportPrivKeyRescanFromAsync returns an instance of a type that can be used to
 // get the result of the RPC at some future time by invoking the Receive function o
 // n the
@@ -2478,13 +3576,21 @@ func (c *Client) ImportPrivKeyRescan(privKeyWIF *exccutil.WIF, label string, res
 //
 // See ImportPrivKey for the blocking version and more details.
 func (c *Client) ImportPrivKeyRescanFromAsync(privKeyWIF *exccutil.WIF, label string, rescan bool, scanFrom int) FutureImportPrivKeyResult {
+	return c.ImportPrivKeyRescanFromAsyncContext(context.Background(), privKeyWIF, label, rescan, scanFrom)
+}
+
+// ImportPrivKeyRescanFromAsyncContext is like ImportPrivKeyRescanFromAsync but
+// the request is abandoned once the passed context is done.
+//
+// See ImportPrivKeyRescanFromContext for the blocking version.
+func (c *Client) ImportPrivKeyRescanFromAsyncContext(ctx context.Context, privKeyWIF *exccutil.WIF, label string, rescan bool, scanFrom int) FutureImportPrivKeyResult {
 	wif := ""
 	if privKeyWIF != nil {
 		wif = privKeyWIF.String()
 	}
 
 	cmd := exccjson.NewImportPrivKeyCmd(wif, &label, &rescan, &scanFrom)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // ImportPrivKeyRescanFrom imports the passed private key which must be the wallet
@@ -2495,6 +3601,13 @@ func (c *Client) ImportPrivKeyRescanFrom(privKeyWIF *exccutil.WIF, label string,
 	return c.ImportPrivKeyRescanFromAsync(privKeyWIF, label, rescan, scanFrom).Receive()
 }
 
+// ImportPrivKeyRescanFromContext is like ImportPrivKeyRescanFrom but the
+// request is abandoned with the error of the passed context once it is done,
+// such as when it times out or is canceled.
+func (c *Client) ImportPrivKeyRescanFromContext(ctx context.Context, privKeyWIF *exccutil.WIF, label string, rescan bool, scanFrom int) error {
+	return c.ImportPrivKeyRescanFromAsyncContext(ctx, privKeyWIF, label, rescan, scanFrom).Receive()
+}
+
 // FutureImportPubKeyResult is a future promise to deliver the result of an
 // ImportPubKeyAsync RPC invocation (or an applicable error).
 type FutureImportPubKeyResult chan *response
@@ -2512,8 +3625,16 @@ func (r FutureImportPubKeyResult) Receive() error {
 //
 // See ImportPubKey for the blocking version and more details.
 func (c *Client) ImportPubKeyAsync(pubKey string) FutureImportPubKeyResult {
+	return c.ImportPubKeyAsyncContext(context.Background(), pubKey)
+}
+
+// ImportPubKeyAsyncContext is like ImportPubKeyAsync but the request is
+// abandoned once the passed context is done.
+//
+// See ImportPubKeyContext for the blocking version.
+func (c *Client) ImportPubKeyAsyncContext(ctx context.Context, pubKey string) FutureImportPubKeyResult {
 	cmd := exccjson.NewImportPubKeyCmd(pubKey, nil)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // ImportPubKey imports the passed public key.
@@ -2521,14 +3642,29 @@ func (c *Client) ImportPubKey(pubKey string) error {
 	return c.ImportPubKeyAsync(pubKey).Receive()
 }
 
+// ImportPubKeyContext is like ImportPubKey but the request is abandoned with
+// the error of the passed context once it is done, such as when it times out or
+// is canceled.
+func (c *Client) ImportPubKeyContext(ctx context.Context, pubKey string) error {
+	return c.ImportPubKeyAsyncContext(ctx, pubKey).Receive()
+}
+
 // ImportPubKeyRescanAsync returns an instance of a type that can be used to get the
 // result of the RPC at some future time by invoking the Receive function on the
 // returned instance.
 //
 // See ImportPubKey for the blocking version and more details.
 func (c *Client) ImportPubKeyRescanAsync(pubKey string, rescan bool) FutureImportPubKeyResult {
+	return c.ImportPubKeyRescanAsyncContext(context.Background(), pubKey, rescan)
+}
+
+// ImportPubKeyRescanAsyncContext is like ImportPubKeyRescanAsync but the
+// request is abandoned once the passed context is done.
+//
+// See ImportPubKeyRescanContext for the blocking version.
+func (c *Client) ImportPubKeyRescanAsyncContext(ctx context.Context, pubKey string, rescan bool) FutureImportPubKeyResult {
 	cmd := exccjson.NewImportPubKeyCmd(pubKey, &rescan)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // ImportPubKeyRescan imports the passed public key. When rescan is true, the
@@ -2537,6 +3673,13 @@ func (c *Client) ImportPubKeyRescan(pubKey string, rescan bool) error {
 	return c.ImportPubKeyRescanAsync(pubKey, rescan).Receive()
 }
 
+// ImportPubKeyRescanContext is like ImportPubKeyRescan but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) ImportPubKeyRescanContext(ctx context.Context, pubKey string, rescan bool) error {
+	return c.ImportPubKeyRescanAsyncContext(ctx, pubKey, rescan).Receive()
+}
+
 // FutureImportScriptResult is a future promise to deliver the result
 // of a ImportScriptAsync RPC invocation (or an applicable error).
 type FutureImportScriptResult chan *response
@@ -2553,13 +3696,21 @@ func (r FutureImportScriptResult) Receive() error {
 //
 // See ImportScript for the blocking version and more details.
 func (c *Client) ImportScriptAsync(script []byte) FutureImportScriptResult {
+	return c.ImportScriptAsyncContext(context.Background(), script)
+}
+
+// ImportScriptAsyncContext is like ImportScriptAsync but the request is
+// abandoned once the passed context is done.
+//
+// See ImportScriptContext for the blocking version.
+func (c *Client) ImportScriptAsyncContext(ctx context.Context, script []byte) FutureImportScriptResult {
 	scriptStr := ""
 	if script != nil {
 		scriptStr = hex.EncodeToString(script)
 	}
 
 	cmd := exccjson.NewImportScriptCmd(scriptStr, nil, nil)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // ImportScript attempts to import a byte code script into wallet.
@@ -2567,19 +3718,34 @@ func (c *Client) ImportScript(script []byte) error {
 	return c.ImportScriptAsync(script).Receive()
 }
 
+// ImportScriptContext is like ImportScript but the request is abandoned with
+// the error of the passed context once it is done, such as when it times out or
+// is canceled.
+func (c *Client) ImportScriptContext(ctx context.Context, script []byte) error {
+	return c.ImportScriptAsyncContext(ctx, script).Receive()
+}
+
 // ImportScriptRescanAsync returns an instance of a type that can be used to
 // get the result of the RPC at some future time by invoking the Receive
 // function on the returned instance.
 //
 // See ImportScript for the blocking version and more details.
 func (c *Client) ImportScriptRescanAsync(script []byte, rescan bool) FutureImportScriptResult {
+	return c.ImportScriptRescanAsyncContext(context.Background(), script, rescan)
+}
+
+// ImportScriptRescanAsyncContext is like ImportScriptRescanAsync but the
+// request is abandoned once the passed context is done.
+//
+// See ImportScriptRescanContext for the blocking version.
+func (c *Client) ImportScriptRescanAsyncContext(ctx context.Context, script []byte, rescan bool) FutureImportScriptResult {
 	scriptStr := ""
 	if script != nil {
 		scriptStr = hex.EncodeToString(script)
 	}
 
 	cmd := exccjson.NewImportScriptCmd(scriptStr, &rescan, nil)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // ImportScriptRescan attempts to import a byte code script into wallet. It also
@@ -2588,19 +3754,34 @@ func (c *Client) ImportScriptRescan(script []byte, rescan bool) error {
 	return c.ImportScriptRescanAsync(script, rescan).Receive()
 }
 
+// ImportScriptRescanContext is like ImportScriptRescan but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) ImportScriptRescanContext(ctx context.Context, script []byte, rescan bool) error {
+	return c.ImportScriptRescanAsyncContext(ctx, script, rescan).Receive()
+}
+
 // ImportScriptRescanFromAsync returns an instance of a type that can be used to
 // get the result of the RPC at some future time by invoking the Receive
 // function on the returned instance.
 //
 // See ImportScript for the blocking version and more details.
 func (c *Client) ImportScriptRescanFromAsync(script []byte, rescan bool, scanFrom int) FutureImportScriptResult {
+	return c.ImportScriptRescanFromAsyncContext(context.Background(), script, rescan, scanFrom)
+}
+
+// ImportScriptRescanFromAsyncContext is like ImportScriptRescanFromAsync but
+// the request is abandoned once the passed context is done.
+//
+// See ImportScriptRescanFromContext for the blocking version.
+func (c *Client) ImportScriptRescanFromAsyncContext(ctx context.Context, script []byte, rescan bool, scanFrom int) FutureImportScriptResult {
 	scriptStr := ""
 	if script != nil {
 		scriptStr = hex.EncodeToString(script)
 	}
 
 	cmd := exccjson.NewImportScriptCmd(scriptStr, &rescan, &scanFrom)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // ImportScriptRescanFrom attempts to import a byte code script into wallet. It
@@ -2610,6 +3791,13 @@ func (c *Client) ImportScriptRescanFrom(script []byte, rescan bool, scanFrom int
 	return c.ImportScriptRescanFromAsync(script, rescan, scanFrom).Receive()
 }
 
+// ImportScriptRescanFromContext is like ImportScriptRescanFrom but the request
+// is abandoned with the error of the passed context once it is done, such as
+// when it times out or is canceled.
+func (c *Client) ImportScriptRescanFromContext(ctx context.Context, script []byte, rescan bool, scanFrom int) error {
+	return c.ImportScriptRescanFromAsyncContext(ctx, script, rescan, scanFrom).Receive()
+}
+
 // ***********************
 // Miscellaneous Functions
 // ***********************
@@ -2642,8 +3830,16 @@ func (r FutureAccountAddressIndexResult) Receive() (int, error) {
 //
 // See AccountAddressIndex for the blocking version and more details.
 func (c *Client) AccountAddressIndexAsync(account string, branch uint32) FutureAccountAddressIndexResult {
+	return c.AccountAddressIndexAsyncContext(context.Background(), account, branch)
+}
+
+// AccountAddressIndexAsyncContext is like AccountAddressIndexAsync but the
+// request is abandoned once the passed context is done.
+//
+// See AccountAddressIndexContext for the blocking version.
+func (c *Client) AccountAddressIndexAsyncContext(ctx context.Context, account string, branch uint32) FutureAccountAddressIndexResult {
 	cmd := exccjson.NewAccountAddressIndexCmd(account, int(branch))
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // AccountAddressIndex returns the address index for a given account's branch.
@@ -2651,6 +3847,13 @@ func (c *Client) AccountAddressIndex(account string, branch uint32) (int, error)
 	return c.AccountAddressIndexAsync(account, branch).Receive()
 }
 
+// AccountAddressIndexContext is like AccountAddressIndex but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) AccountAddressIndexContext(ctx context.Context, account string, branch uint32) (int, error) {
+	return c.AccountAddressIndexAsyncContext(ctx, account, branch).Receive()
+}
+
 // FutureAccountSyncAddressIndexResult is a future promise to deliver the
 // result of an AccountSyncAddressIndexAsync RPC invocation (or an
 // applicable error).
@@ -2669,8 +3872,16 @@ func (r FutureAccountSyncAddressIndexResult) Receive() error {
 //
 // See AccountSyncAddressIndex for the blocking version and more details.
 func (c *Client) AccountSyncAddressIndexAsync(account string, branch uint32, index int) FutureAccountSyncAddressIndexResult {
+	return c.AccountSyncAddressIndexAsyncContext(context.Background(), account, branch, index)
+}
+
+// AccountSyncAddressIndexAsyncContext is like AccountSyncAddressIndexAsync but
+// the request is abandoned once the passed context is done.
+//
+// See AccountSyncAddressIndexContext for the blocking version.
+func (c *Client) AccountSyncAddressIndexAsyncContext(ctx context.Context, account string, branch uint32, index int) FutureAccountSyncAddressIndexResult {
 	cmd := exccjson.NewAccountSyncAddressIndexCmd(account, int(branch), index)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // AccountSyncAddressIndex synchronizes an account branch to the passed address
@@ -2679,6 +3890,13 @@ func (c *Client) AccountSyncAddressIndex(account string, branch uint32, index in
 	return c.AccountSyncAddressIndexAsync(account, branch, index).Receive()
 }
 
+// AccountSyncAddressIndexContext is like AccountSyncAddressIndex but the
+// request is abandoned with the error of the passed context once it is done,
+// such as when it times out or is canceled.
+func (c *Client) AccountSyncAddressIndexContext(ctx context.Context, account string, branch uint32, index int) error {
+	return c.AccountSyncAddressIndexAsyncContext(ctx, account, branch, index).Receive()
+}
+
 // FutureRevokeTicketsResult is a future promise to deliver the result of a
 // RevokeTicketsAsync RPC invocation (or an applicable error).
 type FutureRevokeTicketsResult chan *response
@@ -2696,8 +3914,16 @@ func (r FutureRevokeTicketsResult) Receive() error {
 //
 // See RevokeTickets for the blocking version and more details.
 func (c *Client) RevokeTicketsAsync() FutureRevokeTicketsResult {
+	return c.RevokeTicketsAsyncContext(context.Background())
+}
+
+// RevokeTicketsAsyncContext is like RevokeTicketsAsync but the request is
+// abandoned once the passed context is done.
+//
+// See RevokeTicketsContext for the blocking version.
+func (c *Client) RevokeTicketsAsyncContext(ctx context.Context) FutureRevokeTicketsResult {
 	cmd := exccjson.NewRevokeTicketsCmd()
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // RevokeTickets triggers the wallet to issue revocations for any missed tickets that
@@ -2706,6 +3932,13 @@ func (c *Client) RevokeTickets() error {
 	return c.RevokeTicketsAsync().Receive()
 }
 
+// RevokeTicketsContext is like RevokeTickets but the request is abandoned with
+// the error of the passed context once it is done, such as when it times out or
+// is canceled.
+func (c *Client) RevokeTicketsContext(ctx context.Context) error {
+	return c.RevokeTicketsAsyncContext(ctx).Receive()
+}
+
 // FutureAddTicketResult is a future promise to deliver the result of a
 // AddTicketAsync RPC invocation (or an applicable error).
 type FutureAddTicketResult chan *response
@@ -2723,8 +3956,16 @@ func (r FutureAddTicketResult) Receive() error {
 //
 // See AddTicket for the blocking version and more details.
 func (c *Client) AddTicketAsync(rawHex string) FutureAddTicketResult {
+	return c.AddTicketAsyncContext(context.Background(), rawHex)
+}
+
+// AddTicketAsyncContext is like AddTicketAsync but the request is abandoned
+// once the passed context is done.
+//
+// See AddTicketContext for the blocking version.
+func (c *Client) AddTicketAsyncContext(ctx context.Context, rawHex string) FutureAddTicketResult {
 	cmd := exccjson.NewAddTicketCmd(rawHex)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // AddTicket manually adds a new ticket to the wallet stake manager. This is used
@@ -2739,6 +3980,18 @@ func (c *Client) AddTicket(ticket *exccutil.Tx) error {
 	return c.AddTicketAsync(hex.EncodeToString(ticketB)).Receive()
 }
 
+// AddTicketContext is like AddTicket but the request is abandoned with the
+// error of the passed context once it is done, such as when it times out or is
+// canceled.
+func (c *Client) AddTicketContext(ctx context.Context, ticket *exccutil.Tx) error {
+	ticketB, err := ticket.MsgTx().Bytes()
+	if err != nil {
+		return err
+	}
+
+	return c.AddTicketAsyncContext(ctx, hex.EncodeToString(ticketB)).Receive()
+}
+
 // FutureGenerateVoteResult is a future promise to deliver the result of a
 // GenerateVoteAsync RPC invocation (or an applicable error).
 type FutureGenerateVoteResult chan *response
@@ -2767,8 +4020,16 @@ func (r FutureGenerateVoteResult) Receive() (*exccjson.GenerateVoteResult, error
 //
 // See GenerateVote for the blocking version and more details.
 func (c *Client) GenerateVoteAsync(blockHash *chainhash.Hash, height int64, sstxHash *chainhash.Hash, voteBits uint16, voteBitsExt string) FutureGenerateVoteResult {
+	return c.GenerateVoteAsyncContext(context.Background(), blockHash, height, sstxHash, voteBits, voteBitsExt)
+}
+
+// GenerateVoteAsyncContext is like GenerateVoteAsync but the request is
+// abandoned once the passed context is done.
+//
+// See GenerateVoteContext for the blocking version.
+func (c *Client) GenerateVoteAsyncContext(ctx context.Context, blockHash *chainhash.Hash, height int64, sstxHash *chainhash.Hash, voteBits uint16, voteBitsExt string) FutureGenerateVoteResult {
 	cmd := exccjson.NewGenerateVoteCmd(blockHash.String(), height, sstxHash.String(), voteBits, voteBitsExt)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GenerateVote returns hex of an SSGen.
@@ -2776,6 +4037,13 @@ func (c *Client) GenerateVote(blockHash *chainhash.Hash, height int64, sstxHash
 	return c.GenerateVoteAsync(blockHash, height, sstxHash, voteBits, voteBitsExt).Receive()
 }
 
+// GenerateVoteContext is like GenerateVote but the request is abandoned with
+// the error of the passed context once it is done, such as when it times out or
+// is canceled.
+func (c *Client) GenerateVoteContext(ctx context.Context, blockHash *chainhash.Hash, height int64, sstxHash *chainhash.Hash, voteBits uint16, voteBitsExt string) (*exccjson.GenerateVoteResult, error) {
+	return c.GenerateVoteAsyncContext(ctx, blockHash, height, sstxHash, voteBits, voteBitsExt).Receive()
+}
+
 // NOTE: While getinfo is implemented here (in wallet.go), a exccd chain server
 // will respond to getinfo requests as well, excluding any wallet information.
 
@@ -2807,8 +4075,16 @@ func (r FutureGetInfoResult) Receive() (*exccjson.InfoWalletResult, error) {
 //
 // See GetInfo for the blocking version and more details.
 func (c *Client) GetInfoAsync() FutureGetInfoResult {
+	return c.GetInfoAsyncContext(context.Background())
+}
+
+// GetInfoAsyncContext is like GetInfoAsync but the request is abandoned once
+// the passed context is done.
+//
+// See GetInfoContext for the blocking version.
+func (c *Client) GetInfoAsyncContext(ctx context.Context) FutureGetInfoResult {
 	cmd := exccjson.NewGetInfoCmd()
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetInfo returns miscellaneous info regarding the RPC server.  The returned
@@ -2818,6 +4094,12 @@ func (c *Client) GetInfo() (*exccjson.InfoWalletResult, error) {
 	return c.GetInfoAsync().Receive()
 }
 
+// GetInfoContext is like GetInfo but the request is abandoned with the error of
+// the passed context once it is done, such as when it times out or is canceled.
+func (c *Client) GetInfoContext(ctx context.Context) (*exccjson.InfoWalletResult, error) {
+	return c.GetInfoAsyncContext(ctx).Receive()
+}
+
 // FutureGetStakeInfoResult is a future promise to deliver the result of a
 // GetStakeInfoAsync RPC invocation (or an applicable error).
 type FutureGetStakeInfoResult chan *response
@@ -2846,8 +4128,16 @@ func (r FutureGetStakeInfoResult) Receive() (*exccjson.GetStakeInfoResult, error
 //
 // See GetStakeInfo for the blocking version and more details.
 func (c *Client) GetStakeInfoAsync() FutureGetStakeInfoResult {
+	return c.GetStakeInfoAsyncContext(context.Background())
+}
+
+// GetStakeInfoAsyncContext is like GetStakeInfoAsync but the request is
+// abandoned once the passed context is done.
+//
+// See GetStakeInfoContext for the blocking version.
+func (c *Client) GetStakeInfoAsyncContext(ctx context.Context) FutureGetStakeInfoResult {
 	cmd := exccjson.NewGetStakeInfoCmd()
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetStakeInfo returns stake mining info from a given wallet. This includes
@@ -2856,6 +4146,13 @@ func (c *Client) GetStakeInfo() (*exccjson.GetStakeInfoResult, error) {
 	return c.GetStakeInfoAsync().Receive()
 }
 
+// GetStakeInfoContext is like GetStakeInfo but the request is abandoned with
+// the error of the passed context once it is done, such as when it times out or
+// is canceled.
+func (c *Client) GetStakeInfoContext(ctx context.Context) (*exccjson.GetStakeInfoResult, error) {
+	return c.GetStakeInfoAsyncContext(ctx).Receive()
+}
+
 // FutureGetTicketsResult is a future promise to deliver the result of a
 // GetTickets RPC invocation (or an applicable error).
 type FutureGetTicketsResult chan *response
@@ -2894,8 +4191,16 @@ func (r FutureGetTicketsResult) Receive() ([]*chainhash.Hash, error) {
 //
 // See GetTicketsfor the blocking version and more details.
 func (c *Client) GetTicketsAsync(includeImmature bool) FutureGetTicketsResult {
+	return c.GetTicketsAsyncContext(context.Background(), includeImmature)
+}
+
+// GetTicketsAsyncContext is like GetTicketsAsync but the request is abandoned
+// once the passed context is done.
+//
+// See GetTicketsContext for the blocking version.
+func (c *Client) GetTicketsAsyncContext(ctx context.Context, includeImmature bool) FutureGetTicketsResult {
 	cmd := exccjson.NewGetTicketsCmd(includeImmature)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetTickets returns a list of the tickets owned by the wallet, partially
@@ -2905,6 +4210,13 @@ func (c *Client) GetTickets(includeImmature bool) ([]*chainhash.Hash, error) {
 	return c.GetTicketsAsync(includeImmature).Receive()
 }
 
+// GetTicketsContext is like GetTickets but the request is abandoned with the
+// error of the passed context once it is done, such as when it times out or is
+// canceled.
+func (c *Client) GetTicketsContext(ctx context.Context, includeImmature bool) ([]*chainhash.Hash, error) {
+	return c.GetTicketsAsyncContext(ctx, includeImmature).Receive()
+}
+
 // FutureListScriptsResult is a future promise to deliver the result of a
 // ListScriptsAsync RPC invocation (or an applicable error).
 type FutureListScriptsResult chan *response
@@ -2945,8 +4257,16 @@ func (r FutureListScriptsResult) Receive() ([][]byte, error) {
 //
 // See ListScripts for the blocking version and more details.
 func (c *Client) ListScriptsAsync() FutureListScriptsResult {
+	return c.ListScriptsAsyncContext(context.Background())
+}
+
+// ListScriptsAsyncContext is like ListScriptsAsync but the request is abandoned
+// once the passed context is done.
+//
+// See ListScriptsContext for the blocking version.
+func (c *Client) ListScriptsAsyncContext(ctx context.Context) FutureListScriptsResult {
 	cmd := exccjson.NewListScriptsCmd()
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // ListScripts returns a list of the currently known redeemscripts from the
@@ -2955,6 +4275,13 @@ func (c *Client) ListScripts() ([][]byte, error) {
 	return c.ListScriptsAsync().Receive()
 }
 
+// ListScriptsContext is like ListScripts but the request is abandoned with the
+// error of the passed context once it is done, such as when it times out or is
+// canceled.
+func (c *Client) ListScriptsContext(ctx context.Context) ([][]byte, error) {
+	return c.ListScriptsAsyncContext(ctx).Receive()
+}
+
 // FutureSetTicketFeeResult is a future promise to deliver the result of a
 // SetTicketFeeAsync RPC invocation (or an applicable error).
 type FutureSetTicketFeeResult chan *response
@@ -2972,8 +4299,16 @@ func (r FutureSetTicketFeeResult) Receive() error {
 //
 // See SetTicketFee for the blocking version and more details.
 func (c *Client) SetTicketFeeAsync(fee exccutil.Amount) FutureSetTicketFeeResult {
+	return c.SetTicketFeeAsyncContext(context.Background(), fee)
+}
+
+// SetTicketFeeAsyncContext is like SetTicketFeeAsync but the request is
+// abandoned once the passed context is done.
+//
+// See SetTicketFeeContext for the blocking version.
+func (c *Client) SetTicketFeeAsyncContext(ctx context.Context, fee exccutil.Amount) FutureSetTicketFeeResult {
 	cmd := exccjson.NewSetTicketFeeCmd(fee.ToCoin())
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // SetTicketFee sets the ticket fee per KB amount.
@@ -2981,6 +4316,13 @@ func (c *Client) SetTicketFee(fee exccutil.Amount) error {
 	return c.SetTicketFeeAsync(fee).Receive()
 }
 
+// SetTicketFeeContext is like SetTicketFee but the request is abandoned with
+// the error of the passed context once it is done, such as when it times out or
+// is canceled.
+func (c *Client) SetTicketFeeContext(ctx context.Context, fee exccutil.Amount) error {
+	return c.SetTicketFeeAsyncContext(ctx, fee).Receive()
+}
+
 // FutureSetTxFeeResult is a future promise to deliver the result of a
 // SetTxFeeAsync RPC invocation (or an applicable error).
 type FutureSetTxFeeResult chan *response
@@ -2997,8 +4339,16 @@ func (r FutureSetTxFeeResult) Receive() error {
 //
 // See SetTxFee for the blocking version and more details.
 func (c *Client) SetTxFeeAsync(fee exccutil.Amount) FutureSetTxFeeResult {
+	return c.SetTxFeeAsyncContext(context.Background(), fee)
+}
+
+// SetTxFeeAsyncContext is like SetTxFeeAsync but the request is abandoned once
+// the passed context is done.
+//
+// See SetTxFeeContext for the blocking version.
+func (c *Client) SetTxFeeAsyncContext(ctx context.Context, fee exccutil.Amount) FutureSetTxFeeResult {
 	cmd := exccjson.NewSetTxFeeCmd(fee.ToCoin())
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // SetTxFee sets the transaction fee per KB amount.
@@ -3006,6 +4356,13 @@ func (c *Client) SetTxFee(fee exccutil.Amount) error {
 	return c.SetTxFeeAsync(fee).Receive()
 }
 
+// SetTxFeeContext is like SetTxFee but the request is abandoned with the error
+// of the passed context once it is done, such as when it times out or is
+// canceled.
+func (c *Client) SetTxFeeContext(ctx context.Context, fee exccutil.Amount) error {
+	return c.SetTxFeeAsyncContext(ctx, fee).Receive()
+}
+
 // FutureGetVoteChoicesResult is a future promise to deliver the result of a
 // GetVoteChoicesAsync RPC invocation (or an applicable error).
 type FutureGetVoteChoicesResult chan *response
@@ -3033,8 +4390,16 @@ func (r FutureGetVoteChoicesResult) Receive() (*exccjson.GetVoteChoicesResult, e
 //
 // See GetVoteChoices for the blocking version and more details.
 func (c *Client) GetVoteChoicesAsync() FutureGetVoteChoicesResult {
+	return c.GetVoteChoicesAsyncContext(context.Background())
+}
+
+// GetVoteChoicesAsyncContext is like GetVoteChoicesAsync but the request is
+// abandoned once the passed context is done.
+//
+// See GetVoteChoicesContext for the blocking version.
+func (c *Client) GetVoteChoicesAsyncContext(ctx context.Context) FutureGetVoteChoicesResult {
 	cmd := exccjson.NewGetVoteChoicesCmd()
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // GetVoteChoices returns the currently-set vote choices for each agenda in the
@@ -3043,6 +4408,13 @@ func (c *Client) GetVoteChoices() (*exccjson.GetVoteChoicesResult, error) {
 	return c.GetVoteChoicesAsync().Receive()
 }
 
+// GetVoteChoicesContext is like GetVoteChoices but the request is abandoned
+// with the error of the passed context once it is done, such as when it times
+// out or is canceled.
+func (c *Client) GetVoteChoicesContext(ctx context.Context) (*exccjson.GetVoteChoicesResult, error) {
+	return c.GetVoteChoicesAsyncContext(ctx).Receive()
+}
+
 // FutureSetVoteChoiceResult is a future promise to deliver the result of a
 // SetVoteChoiceAsync RPC invocation (or an applicable error).
 type FutureSetVoteChoiceResult chan *response
@@ -3059,8 +4431,16 @@ func (r FutureSetVoteChoiceResult) Receive() error {
 //
 // See SetVoteChoice for the blocking version and more details.
 func (c *Client) SetVoteChoiceAsync(agendaID, choiceID string) FutureSetVoteChoiceResult {
+	return c.SetVoteChoiceAsyncContext(context.Background(), agendaID, choiceID)
+}
+
+// SetVoteChoiceAsyncContext is like SetVoteChoiceAsync but the request is
+// abandoned once the passed context is done.
+//
+// See SetVoteChoiceContext for the blocking version.
+func (c *Client) SetVoteChoiceAsyncContext(ctx context.Context, agendaID, choiceID string) FutureSetVoteChoiceResult {
 	cmd := exccjson.NewSetVoteChoiceCmd(agendaID, choiceID)
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // SetVoteChoice sets a voting choice preference for an agenda.
@@ -3068,6 +4448,13 @@ func (c *Client) SetVoteChoice(agendaID, choiceID string) error {
 	return c.SetVoteChoiceAsync(agendaID, choiceID).Receive()
 }
 
+// SetVoteChoiceContext is like SetVoteChoice but the request is abandoned with
+// the error of the passed context once it is done, such as when it times out or
+// is canceled.
+func (c *Client) SetVoteChoiceContext(ctx context.Context, agendaID, choiceID string) error {
+	return c.SetVoteChoiceAsyncContext(ctx, agendaID, choiceID).Receive()
+}
+
 // FutureStakePoolUserInfoResult is a future promise to deliver the result of a
 // GetInfoAsync RPC invocation (or an applicable error).
 type FutureStakePoolUserInfoResult chan *response
@@ -3096,8 +4483,16 @@ func (r FutureStakePoolUserInfoResult) Receive() (*exccjson.StakePoolUserInfoRes
 //
 // See GetInfo for the blocking version and more details.
 func (c *Client) StakePoolUserInfoAsync(addr exccutil.Address) FutureStakePoolUserInfoResult {
+	return c.StakePoolUserInfoAsyncContext(context.Background(), addr)
+}
+
+// StakePoolUserInfoAsyncContext is like StakePoolUserInfoAsync but the request
+// is abandoned once the passed context is done.
+//
+// See StakePoolUserInfoContext for the blocking version.
+func (c *Client) StakePoolUserInfoAsyncContext(ctx context.Context, addr exccutil.Address) FutureStakePoolUserInfoResult {
 	cmd := exccjson.NewStakePoolUserInfoCmd(addr.EncodeAddress())
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // StakePoolUserInfo returns a list of tickets and information about them
@@ -3106,6 +4501,13 @@ func (c *Client) StakePoolUserInfo(addr exccutil.Address) (*exccjson.StakePoolUs
 	return c.StakePoolUserInfoAsync(addr).Receive()
 }
 
+// StakePoolUserInfoContext is like StakePoolUserInfo but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) StakePoolUserInfoContext(ctx context.Context, addr exccutil.Address) (*exccjson.StakePoolUserInfoResult, error) {
+	return c.StakePoolUserInfoAsyncContext(ctx, addr).Receive()
+}
+
 // FutureTicketsForAddressResult is a future promise to deliver the result of a
 // GetInfoAsync RPC invocation (or an applicable error).
 type FutureTicketsForAddressResult chan *response
@@ -3134,8 +4536,16 @@ func (r FutureTicketsForAddressResult) Receive() (*exccjson.TicketsForAddressRes
 //
 // See GetInfo for the blocking version and more details.
 func (c *Client) TicketsForAddressAsync(addr exccutil.Address) FutureTicketsForAddressResult {
+	return c.TicketsForAddressAsyncContext(context.Background(), addr)
+}
+
+// TicketsForAddressAsyncContext is like TicketsForAddressAsync but the request
+// is abandoned once the passed context is done.
+//
+// See TicketsForAddressContext for the blocking version.
+func (c *Client) TicketsForAddressAsyncContext(ctx context.Context, addr exccutil.Address) FutureTicketsForAddressResult {
 	cmd := exccjson.NewTicketsForAddressCmd(addr.EncodeAddress())
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // TicketsForAddress returns a list of tickets paying to the passed address.
@@ -3146,6 +4556,13 @@ func (c *Client) TicketsForAddress(addr exccutil.Address) (*exccjson.TicketsForA
 	return c.TicketsForAddressAsync(addr).Receive()
 }
 
+// TicketsForAddressContext is like TicketsForAddress but the request is
+// abandoned with the error of the passed context once it is done, such as when
+// it times out or is canceled.
+func (c *Client) TicketsForAddressContext(ctx context.Context, addr exccutil.Address) (*exccjson.TicketsForAddressResult, error) {
+	return c.TicketsForAddressAsyncContext(ctx, addr).Receive()
+}
+
 // FutureWalletInfoResult is a future promise to deliver the result of a
 // WalletInfoAsync RPC invocation (or an applicable error).
 type FutureWalletInfoResult chan *response
@@ -3174,8 +4591,16 @@ func (r FutureWalletInfoResult) Receive() (*exccjson.WalletInfoResult, error) {
 //
 // See WalletInfo for the blocking version and more details.
 func (c *Client) WalletInfoAsync() FutureWalletInfoResult {
+	return c.WalletInfoAsyncContext(context.Background())
+}
+
+// WalletInfoAsyncContext is like WalletInfoAsync but the request is abandoned
+// once the passed context is done.
+//
+// See WalletInfoContext for the blocking version.
+func (c *Client) WalletInfoAsyncContext(ctx context.Context) FutureWalletInfoResult {
 	cmd := exccjson.NewWalletInfoCmd()
-	return c.sendCmd(cmd)
+	return c.sendCmdContext(ctx, cmd)
 }
 
 // WalletInfo returns wallet global state info for a given wallet.
@@ -3183,6 +4608,13 @@ func (c *Client) WalletInfo() (*exccjson.WalletInfoResult, error) {
 	return c.WalletInfoAsync().Receive()
 }
 
+// WalletInfoContext is like WalletInfo but the request is abandoned with the
+// error of the passed context once it is done, such as when it times out or is
+// canceled.
+func (c *Client) WalletInfoContext(ctx context.Context) (*exccjson.WalletInfoResult, error) {
+	return c.WalletInfoAsyncContext(ctx).Receive()
+}
+
 // TODO(davec): Implement
 // backupwallet (NYI in exccwallet)
 // encryptwallet (Won't be supported by exccwallet since it's always encrypted)
@@ -9,9 +9,11 @@ package main
 import (
 	"bytes"
 	"crypto/elliptic"
+	crand "crypto/rand"
 	"crypto/sha256"
 	"crypto/subtle"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/binary"
 	"encoding/hex"
@@ -26,7 +28,10 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"path/filepath"
 	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
 	"sort"
 	"strconv"
 	"strings"
@@ -37,16 +42,19 @@ import (
 	"github.com/btcsuite/websocket"
 
 	"github.com/EXCCoin/exccd/blockchain"
+	"github.com/EXCCoin/exccd/blockchain/indexers"
 	"github.com/EXCCoin/exccd/blockchain/stake"
 	"github.com/EXCCoin/exccd/certgen"
 	"github.com/EXCCoin/exccd/chaincfg"
 	"github.com/EXCCoin/exccd/chaincfg/chainec"
 	"github.com/EXCCoin/exccd/chaincfg/chainhash"
 	"github.com/EXCCoin/exccd/database"
+	"github.com/EXCCoin/exccd/exccec/secp256k1"
 	"github.com/EXCCoin/exccd/exccjson"
 	"github.com/EXCCoin/exccd/exccutil"
 	"github.com/EXCCoin/exccd/mempool"
 	"github.com/EXCCoin/exccd/mining"
+	"github.com/EXCCoin/exccd/tracing"
 	"github.com/EXCCoin/exccd/txscript"
 	"github.com/EXCCoin/exccd/wire"
 	"github.com/jrick/bitset"
@@ -106,6 +114,29 @@ const (
 	// sstxCommitmentString is the string to insert when a verbose
 	// transaction output's pkscript type is a ticket commitment.
 	sstxCommitmentString = "sstxcommitment"
+
+	// maxTicketsInfoHashes is the maximum number of tickets which may be
+	// queried with a single getticketsinfo request.
+	maxTicketsInfoHashes = 2000
+
+	// maxTxWaitTimeoutSeconds is the maximum number of seconds the
+	// sendrawtransactionandwait RPC waits for the transaction to be mined
+	// with the requested number of confirmations.  Waiting requests made
+	// over HTTP hold one of the RPC client connections, so they are never
+	// allowed to wait without a limit.
+	maxTxWaitTimeoutSeconds = 3600
+
+	// ticketStatusUnknown is the status reported for tickets which are
+	// not known to the ticket lifecycle index.
+	ticketStatusUnknown = "unknown"
+
+	// maxTicketPoolStatsBlocks is the maximum number of blocks the
+	// getticketpoolstats RPC will walk back from the best block.
+	maxTicketPoolStatsBlocks = 8192
+
+	// maxDifficultyHistoryBlocks is the maximum number of blocks which may
+	// be queried with a single getdifficultyhistory request.
+	maxDifficultyHistoryBlocks = 10000
 )
 
 var (
@@ -116,9 +147,10 @@ var (
 	blake256Pad []byte
 
 	// gbtMutableFields are the manipulations the server allows to be made
-	// to block templates generated by the getblocktemplate RPC.  It is
-	// declared here to avoid the overhead of creating the slice on every
-	// invocation for constant data.
+	// to block templates generated by the getblocktemplate RPC.  Blocks
+	// submitted with the work ID of a template are checked to only make
+	// these mutations by checkMutations.  It is declared here to avoid the
+	// overhead of creating the slice on every invocation for constant data.
 	gbtMutableFields = []string{
 		"time", "transactions/add", "prevblock", "coinbase/append",
 	}
@@ -170,77 +202,110 @@ type commandHandler func(*rpcServer, interface{}, <-chan struct{}) (interface{},
 // a dependency loop.
 var rpcHandlers map[string]commandHandler
 var rpcHandlersBeforeInit = map[string]commandHandler{
-	"addnode":               handleAddNode,
-	"createrawsstx":         handleCreateRawSStx,
-	"createrawssgentx":      handleCreateRawSSGenTx,
-	"createrawssrtx":        handleCreateRawSSRtx,
-	"createrawtransaction":  handleCreateRawTransaction,
-	"debuglevel":            handleDebugLevel,
-	"decoderawtransaction":  handleDecodeRawTransaction,
-	"decodescript":          handleDecodeScript,
-	"estimatefee":           handleEstimateFee,
-	"estimatestakediff":     handleEstimateStakeDiff,
-	"existsaddress":         handleExistsAddress,
-	"existsaddresses":       handleExistsAddresses,
-	"existsmissedtickets":   handleExistsMissedTickets,
-	"existsexpiredtickets":  handleExistsExpiredTickets,
-	"existsliveticket":      handleExistsLiveTicket,
-	"existslivetickets":     handleExistsLiveTickets,
-	"existsmempooltxs":      handleExistsMempoolTxs,
-	"generate":              handleGenerate,
-	"getaddednodeinfo":      handleGetAddedNodeInfo,
-	"getbestblock":          handleGetBestBlock,
-	"getbestblockhash":      handleGetBestBlockHash,
-	"getblock":              handleGetBlock,
-	"getblockcount":         handleGetBlockCount,
-	"getblockhash":          handleGetBlockHash,
-	"getblockheader":        handleGetBlockHeader,
-	"getblocksubsidy":       handleGetBlockSubsidy,
-	"getchaintips":          handleGetChainTips,
-	"getcoinsupply":         handleGetCoinSupply,
-	"getconnectioncount":    handleGetConnectionCount,
-	"getcurrentnet":         handleGetCurrentNet,
-	"getdifficulty":         handleGetDifficulty,
-	"getgenerate":           handleGetGenerate,
-	"gethashespersec":       handleGetHashesPerSec,
-	"getcfilter":            handleGetCFilter,
-	"getcfilterheader":      handleGetCFilterHeader,
-	"getheaders":            handleGetHeaders,
-	"getinfo":               handleGetInfo,
-	"getmempoolinfo":        handleGetMempoolInfo,
-	"getmininginfo":         handleGetMiningInfo,
-	"getnettotals":          handleGetNetTotals,
-	"getnetworkhashps":      handleGetNetworkHashPS,
-	"getpeerinfo":           handleGetPeerInfo,
-	"getrawmempool":         handleGetRawMempool,
-	"getrawtransaction":     handleGetRawTransaction,
-	"getstakedifficulty":    handleGetStakeDifficulty,
-	"getstakeversioninfo":   handleGetStakeVersionInfo,
-	"getstakeversions":      handleGetStakeVersions,
-	"getticketpoolvalue":    handleGetTicketPoolValue,
-	"getvoteinfo":           handleGetVoteInfo,
-	"gettxout":              handleGetTxOut,
-	"getwork":               handleGetWork,
-	"help":                  handleHelp,
-	"livetickets":           handleLiveTickets,
-	"missedtickets":         handleMissedTickets,
-	"node":                  handleNode,
-	"ping":                  handlePing,
-	"searchrawtransactions": handleSearchRawTransactions,
-	"rebroadcastmissed":     handleRebroadcastMissed,
-	"rebroadcastwinners":    handleRebroadcastWinners,
-	"sendrawtransaction":    handleSendRawTransaction,
-	"setgenerate":           handleSetGenerate,
-	"stop":                  handleStop,
-	"submitblock":           handleSubmitBlock,
-	"ticketfeeinfo":         handleTicketFeeInfo,
-	"ticketsforaddress":     handleTicketsForAddress,
-	"ticketvwap":            handleTicketVWAP,
-	"txfeeinfo":             handleTxFeeInfo,
-	"validateaddress":       handleValidateAddress,
-	"verifychain":           handleVerifyChain,
-	"verifymessage":         handleVerifyMessage,
-	"version":               handleVersion,
+	"addnode":                   handleAddNode,
+	"analyzescript":             handleAnalyzeScript,
+	"createmultisig":            handleCreateMultisig,
+	"createrawsstx":             handleCreateRawSStx,
+	"createrawssgentx":          handleCreateRawSSGenTx,
+	"createrawssrtx":            handleCreateRawSSRtx,
+	"createrawtransaction":      handleCreateRawTransaction,
+	"debuglevel":                handleDebugLevel,
+	"debugprofile":              handleDebugProfile,
+	"debugscript":               handleDebugScript,
+	"dnsseed":                   handleDNSSeed,
+	"decoderawtransaction":      handleDecodeRawTransaction,
+	"decodescript":              handleDecodeScript,
+	"dumptxoutset":              handleDumpTxOutSet,
+	"estimatefee":               handleEstimateFee,
+	"estimatestakediff":         handleEstimateStakeDiff,
+	"existsaddress":             handleExistsAddress,
+	"existsaddresses":           handleExistsAddresses,
+	"existsmissedtickets":       handleExistsMissedTickets,
+	"existsexpiredtickets":      handleExistsExpiredTickets,
+	"existsliveticket":          handleExistsLiveTicket,
+	"existslivetickets":         handleExistsLiveTickets,
+	"existsmempooltxs":          handleExistsMempoolTxs,
+	"fundrawtransaction":        handleFundRawTransaction,
+	"generate":                  handleGenerate,
+	"getaddednodeinfo":          handleGetAddedNodeInfo,
+	"getaddresstickets":         handleGetAddressTickets,
+	"getagendas":                handleGetAgendas,
+	"getbestblock":              handleGetBestBlock,
+	"getbestblockhash":          handleGetBestBlockHash,
+	"getblock":                  handleGetBlock,
+	"getblockcount":             handleGetBlockCount,
+	"getblockhash":              handleGetBlockHash,
+	"getblockhashbytime":        handleGetBlockHashByTime,
+	"getblockheader":            handleGetBlockHeader,
+	"getblocksubsidy":           handleGetBlockSubsidy,
+	"getchaintips":              handleGetChainTips,
+	"getcoinsupply":             handleGetCoinSupply,
+	"getconnectioncount":        handleGetConnectionCount,
+	"getcurrentnet":             handleGetCurrentNet,
+	"getdifficulty":             handleGetDifficulty,
+	"getdifficultyhistory":      handleGetDifficultyHistory,
+	"getemissionschedule":       handleGetEmissionSchedule,
+	"getgenerate":               handleGetGenerate,
+	"gethashespersec":           handleGetHashesPerSec,
+	"getcfilter":                handleGetCFilter,
+	"getcfilterheader":          handleGetCFilterHeader,
+	"getheaders":                handleGetHeaders,
+	"getdnsseedinfo":            handleGetDNSSeedInfo,
+	"getindexinfo":              handleGetIndexInfo,
+	"getinfo":                   handleGetInfo,
+	"getmempoolinfo":            handleGetMempoolInfo,
+	"getminedblockstats":        handleGetMinedBlockStats,
+	"getminingarchive":          handleGetMiningArchive,
+	"getmininginfo":             handleGetMiningInfo,
+	"getnettotals":              handleGetNetTotals,
+	"getnetworkhashps":          handleGetNetworkHashPS,
+	"getnetworksolps":           handleGetNetworkSolPS,
+	"getnetworkstakeinfo":       handleGetNetworkStakeInfo,
+	"getpeerinfo":               handleGetPeerInfo,
+	"getrawmempool":             handleGetRawMempool,
+	"getrawtransaction":         handleGetRawTransaction,
+	"getsigcacheinfo":           handleGetSigCacheInfo,
+	"getstakedifficulty":        handleGetStakeDifficulty,
+	"getstakeversioninfo":       handleGetStakeVersionInfo,
+	"getstakeversions":          handleGetStakeVersions,
+	"getsyncinfo":               handleGetSyncInfo,
+	"getticketinfo":             handleGetTicketInfo,
+	"getticketpoolstats":        handleGetTicketPoolStats,
+	"getticketpoolvalue":        handleGetTicketPoolValue,
+	"getticketsinfo":            handleGetTicketsInfo,
+	"getvoteinfo":               handleGetVoteInfo,
+	"gettxout":                  handleGetTxOut,
+	"gettxoutsetinfo":           handleGetTxOutSetInfo,
+	"getwork":                   handleGetWork,
+	"help":                      handleHelp,
+	"livetickets":               handleLiveTickets,
+	"missedtickets":             handleMissedTickets,
+	"node":                      handleNode,
+	"ping":                      handlePing,
+	"searchrawtransactions":     handleSearchRawTransactions,
+	"rebroadcastmissed":         handleRebroadcastMissed,
+	"rebroadcastwinners":        handleRebroadcastWinners,
+	"regeneratecert":            handleRegenerateCert,
+	"reloadconfig":              handleReloadConfig,
+	"rotatelogs":                handleRotateLogs,
+	"sendrawtransaction":        handleSendRawTransaction,
+	"sendrawtransactionandwait": handleSendRawTransactionAndWait,
+	"setgenerate":               handleSetGenerate,
+	"setmocktime":               handleSetMockTime,
+	"signmessagewithprivkey":    handleSignMessageWithPrivKey,
+	"signrawtransactionwithkey": handleSignRawTransactionWithKey,
+	"stop":                      handleStop,
+	"submitblock":               handleSubmitBlock,
+	"ticketfeeinfo":             handleTicketFeeInfo,
+	"ticketsforaddress":         handleTicketsForAddress,
+	"ticketvwap":                handleTicketVWAP,
+	"txfeeinfo":                 handleTxFeeInfo,
+	"uptime":                    handleUptime,
+	"validateaddress":           handleValidateAddress,
+	"validateaddresses":         handleValidateAddresses,
+	"verifychain":               handleVerifyChain,
+	"verifymessage":             handleVerifyMessage,
+	"version":                   handleVersion,
 }
 
 // list of commands that we recognize, but for which exccd has no support because
@@ -252,7 +317,6 @@ var rpcAskWallet = map[string]struct{}{
 	"addmultisigaddress":      {},
 	"addticket":               {},
 	"createencryptedwallet":   {},
-	"createmultisig":          {},
 	"dumpprivkey":             {},
 	"getaccount":              {},
 	"getaccountaddress":       {},
@@ -265,7 +329,6 @@ var rpcAskWallet = map[string]struct{}{
 	"getstakeinfo":            {},
 	"getvotechoices":          {},
 	"gettransaction":          {},
-	"gettxoutsetinfo":         {},
 	"getunconfirmedbalance":   {},
 	"importprivkey":           {},
 	"keypoolrefill":           {},
@@ -312,35 +375,94 @@ var rpcLimited = map[string]struct{}{
 	"notifyreceived":        {},
 	"notifyspent":           {},
 	"rescan":                {},
+	"rescanrange":           {},
 	"session":               {},
 
 	// Websockets AND HTTP/S commands
 	"help": {},
 
 	// HTTP/S-only commands
-	"createrawtransaction":  {},
-	"decoderawtransaction":  {},
-	"decodescript":          {},
-	"getbestblock":          {},
-	"getbestblockhash":      {},
-	"getblock":              {},
-	"getblockcount":         {},
-	"getblockhash":          {},
-	"getchaintips":          {},
-	"getcurrentnet":         {},
-	"getdifficulty":         {},
-	"getinfo":               {},
-	"getnettotals":          {},
-	"getnetworkhashps":      {},
-	"getrawmempool":         {},
-	"getrawtransaction":     {},
-	"gettxout":              {},
-	"searchrawtransactions": {},
-	"sendrawtransaction":    {},
-	"submitblock":           {},
-	"validateaddress":       {},
-	"verifymessage":         {},
-	"version":               {},
+	"analyzescript":             {},
+	"createmultisig":            {},
+	"createrawtransaction":      {},
+	"debugscript":               {},
+	"decoderawtransaction":      {},
+	"decodescript":              {},
+	"existsaddresses":           {},
+	"existsexpiredtickets":      {},
+	"existsliveticket":          {},
+	"existslivetickets":         {},
+	"existsmissedtickets":       {},
+	"getbestblock":              {},
+	"getbestblockhash":          {},
+	"getblock":                  {},
+	"getblockcount":             {},
+	"getblockhash":              {},
+	"getblockhashbytime":        {},
+	"getaddresstickets":         {},
+	"getagendas":                {},
+	"getchaintips":              {},
+	"getcoinsupply":             {},
+	"getcurrentnet":             {},
+	"getdifficulty":             {},
+	"getdifficultyhistory":      {},
+	"getemissionschedule":       {},
+	"getheaders":                {},
+	"getindexinfo":              {},
+	"getinfo":                   {},
+	"getnettotals":              {},
+	"getnetworkhashps":          {},
+	"getnetworksolps":           {},
+	"getnetworkstakeinfo":       {},
+	"getrawmempool":             {},
+	"getrawtransaction":         {},
+	"getsigcacheinfo":           {},
+	"getstakeversioninfo":       {},
+	"getstakeversions":          {},
+	"getsyncinfo":               {},
+	"getticketinfo":             {},
+	"getticketpoolstats":        {},
+	"getticketpoolvalue":        {},
+	"getticketsinfo":            {},
+	"gettxout":                  {},
+	"gettxoutsetinfo":           {},
+	"getvoteinfo":               {},
+	"livetickets":               {},
+	"missedtickets":             {},
+	"searchrawtransactions":     {},
+	"sendrawtransaction":        {},
+	"signmessagewithprivkey":    {},
+	"signrawtransactionwithkey": {},
+	"submitblock":               {},
+	"ticketfeeinfo":             {},
+	"uptime":                    {},
+	"validateaddress":           {},
+	"validateaddresses":         {},
+	"verifymessage":             {},
+	"version":                   {},
+}
+
+// rpcPublicAllowed houses the read-only RPC methods which only return public
+// chain data and are cheap to serve, so they may be made callable without
+// authenticating with the rpcpublicmethod option.  Methods which change the
+// state of the server or may be expensive to serve, such as those which scan
+// many blocks, are never allowed.
+var rpcPublicAllowed = map[string]struct{}{
+	"getbestblock":       {},
+	"getbestblockhash":   {},
+	"getblock":           {},
+	"getblockcount":      {},
+	"getblockhash":       {},
+	"getchaintips":       {},
+	"getcoinsupply":      {},
+	"getcurrentnet":      {},
+	"getdifficulty":      {},
+	"getinfo":            {},
+	"getrawtransaction":  {},
+	"getticketpoolvalue": {},
+	"gettxout":           {},
+	"validateaddress":    {},
+	"version":            {},
 }
 
 // builderScript is a convenience function which is used for hard-coded scripts
@@ -424,6 +546,21 @@ func rpcNoTxInfoError(txHash *chainhash.Hash) *exccjson.RPCError {
 			txHash))
 }
 
+// rpcIndexNotSyncedError returns an RPC error which indicates the passed index
+// is still catching up to the main chain when that is the case and nil
+// otherwise.  Queries which aggregate results over an index, or which find
+// nothing in it, return it instead of their result while the index is behind
+// since the result might be incomplete.
+func (s *rpcServer) rpcIndexNotSyncedError(indexer indexers.Indexer) error {
+	indexManager := s.server.indexManager
+	if indexManager == nil || indexManager.IndexSynced(indexer) {
+		return nil
+	}
+	return exccjson.NewRPCError(exccjson.ErrRPCMisc, fmt.Sprintf("The %s "+
+		"is still catching up to the main chain -- see getindexinfo",
+		indexer.Name()))
+}
+
 // rpcMiscError is a convenience function for returning a nicely formatted RPC
 // error which indicates there is a unquantifiable error.  Use this sparingly;
 // misc return codes are a cop out.
@@ -436,6 +573,7 @@ func rpcMiscError(message string) *exccjson.RPCError {
 type workStateBlockInfo struct {
 	msgBlock *wire.MsgBlock
 	pkScript []byte
+	workID   workID
 }
 
 // workState houses state that is used in between multiple RPC invocations to
@@ -464,6 +602,8 @@ type gbtWorkState struct {
 	prevHash      *chainhash.Hash
 	minTimestamp  time.Time
 	template      *BlockTemplate
+	workID        workID
+	issued        map[workID]*gbtIssuedTemplate
 	notifyMap     map[chainhash.Hash]map[int64]chan struct{}
 	timeSource    blockchain.MedianTimeSource
 }
@@ -472,6 +612,7 @@ type gbtWorkState struct {
 // fields initialized and ready to use.
 func newGbtWorkState(timeSource blockchain.MedianTimeSource) *gbtWorkState {
 	return &gbtWorkState{
+		issued:     make(map[workID]*gbtIssuedTemplate),
 		notifyMap:  make(map[chainhash.Hash]map[int64]chan struct{}),
 		timeSource: timeSource,
 	}
@@ -515,6 +656,40 @@ func handleAddNode(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (in
 	return nil, nil
 }
 
+// handleAnalyzeScript handles analyzescript commands.
+func handleAnalyzeScript(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	c := cmd.(*exccjson.AnalyzeScriptCmd)
+
+	// Convert the hex script to bytes.
+	hexStr := c.HexScript
+	if len(hexStr)%2 != 0 {
+		hexStr = "0" + hexStr
+	}
+	script, err := hex.DecodeString(hexStr)
+	if err != nil {
+		return nil, rpcDecodeHexError(hexStr)
+	}
+
+	analysis, err := txscript.AnalyzeScript(*c.Version, script)
+	if err != nil {
+		return nil, rpcInvalidError("Unable to analyze script: %v", err)
+	}
+	result := &exccjson.AnalyzeScriptResult{
+		Type:          analysis.Class.String(),
+		ReqSigs:       analysis.ReqSigs,
+		SigOps:        analysis.SigOps,
+		PreciseSigOps: analysis.PreciseSigOps,
+		PushOnly:      analysis.PushOnly,
+		NumPushes:     analysis.NumPushes,
+		MaxPushSize:   analysis.MaxPushSize,
+		SigScriptSize: analysis.SigScriptSize,
+	}
+	if analysis.SubClass != txscript.NonStandardTy {
+		result.SubType = analysis.SubClass.String()
+	}
+	return result, nil
+}
+
 // handleNode handles node commands.
 func handleNode(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
 	c := cmd.(*exccjson.NodeCmd)
@@ -674,6 +849,7 @@ func handleCreateRawTransaction(s *rpcServer, cmd interface{}, closeChan <-chan
 		switch addr.(type) {
 		case *exccutil.AddressPubKeyHash:
 		case *exccutil.AddressScriptHash:
+		case *exccutil.AddressBech32:
 		default:
 			return nil, rpcAddressKeyError("Invalid type: %T", addr)
 		}
@@ -715,6 +891,81 @@ func handleCreateRawTransaction(s *rpcServer, cmd interface{}, closeChan <-chan
 	return mtxHex, nil
 }
 
+// handleCreateMultisig handles createmultisig commands.
+func handleCreateMultisig(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	c := cmd.(*exccjson.CreateMultisigCmd)
+
+	// Ensure the number of required signatures and keys are sane.
+	numKeys := len(c.Keys)
+	if numKeys == 0 || numKeys > txscript.MaxPubKeysPerMultiSig {
+		return nil, rpcInvalidError("Number of keys must be between 1 "+
+			"and %d -- got %d", txscript.MaxPubKeysPerMultiSig, numKeys)
+	}
+	if c.NRequired < 1 || c.NRequired > numKeys {
+		return nil, rpcInvalidError("Number of required signatures must "+
+			"be between 1 and the number of keys (%d) -- got %d",
+			numKeys, c.NRequired)
+	}
+
+	// Convert the keys, which may either be hex-encoded secp256k1 public
+	// keys or public key addresses, to public key addresses.  The node does
+	// not have a wallet, so it is not possible to look up the public key of
+	// a pay-to-pubkey-hash address.
+	params := s.server.chainParams
+	pubKeys := make([]*exccutil.AddressSecpPubKey, 0, numKeys)
+	for i, key := range c.Keys {
+		var pubKeyAddr *exccutil.AddressSecpPubKey
+		if serializedKey, err := hex.DecodeString(key); err == nil {
+			pubKeyAddr, err = exccutil.NewAddressSecpPubKey(serializedKey,
+				params)
+			if err != nil {
+				return nil, rpcInvalidError("Invalid public key %d: %v",
+					i, err)
+			}
+		} else {
+			addr, err := exccutil.DecodeAddress(key)
+			if err != nil || !addr.IsForNet(params) {
+				return nil, rpcAddressKeyError("Invalid key %d: %q "+
+					"is neither a public key nor an address for "+
+					"the active network", i, key)
+			}
+			var ok bool
+			pubKeyAddr, ok = addr.(*exccutil.AddressSecpPubKey)
+			if !ok {
+				return nil, rpcAddressKeyError("Invalid key %d: "+
+					"address %s is not a secp256k1 public key "+
+					"address", i, key)
+			}
+		}
+		pubKeys = append(pubKeys, pubKeyAddr)
+	}
+
+	script, err := txscript.MultiSigScript(pubKeys, c.NRequired)
+	if err != nil {
+		return nil, rpcInvalidError("Unable to create redeem script: %v",
+			err)
+	}
+
+	// The redeem script must be able to be pushed by the signature script
+	// which spends the pay-to-script-hash output.
+	if len(script) > txscript.MaxScriptElementSize {
+		return nil, rpcInvalidError("Redeem script is too large -- %d "+
+			"bytes exceeds the maximum of %d", len(script),
+			txscript.MaxScriptElementSize)
+	}
+
+	addr, err := exccutil.NewAddressScriptHash(script, params)
+	if err != nil {
+		context := "Failed to create script hash address"
+		return nil, rpcInternalError(err.Error(), context)
+	}
+
+	return exccjson.CreateMultiSigResult{
+		Address:      addr.EncodeAddress(),
+		RedeemScript: hex.EncodeToString(script),
+	}, nil
+}
+
 // handleCreateRawSStx handles createrawsstx commands.
 func handleCreateRawSStx(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
 	c := cmd.(*exccjson.CreateRawSStxCmd)
@@ -1397,6 +1648,396 @@ func createTxRawResult(chainParams *chaincfg.Params, mtx *wire.MsgTx, txHash str
 	return txReply, nil
 }
 
+// fetchPrevOuts returns the outputs spent by the inputs of the passed
+// transactions keyed by their outpoints.  Outputs created by the passed
+// transactions themselves, such as those spent by later transactions of the
+// same block, are resolved directly, while all others are looked up in the
+// memory pool, the unspent transaction outputs of the main chain and, when it
+// is enabled, the transaction index.  Outputs which can't be found, such as
+// spent outputs when the transaction index is disabled, are not included.
+func fetchPrevOuts(s *rpcServer, txns []*wire.MsgTx) (map[wire.OutPoint]*wire.TxOut, error) {
+	// Keep track of the transactions the outputs are resolved from so each
+	// of them is only looked up once.
+	originTxns := make(map[chainhash.Hash]*wire.MsgTx, len(txns))
+	for _, tx := range txns {
+		originTxns[tx.TxHash()] = tx
+	}
+	utxoEntries := make(map[chainhash.Hash]*blockchain.UtxoEntry)
+
+	prevOuts := make(map[wire.OutPoint]*wire.TxOut)
+	for _, tx := range txns {
+		// Coinbase transactions and the stakebase input of votes don't
+		// spend any previous outputs.
+		if blockchain.IsCoinBaseTx(tx) {
+			continue
+		}
+		isSSGen := stake.IsSSGen(tx)
+
+		for i, txIn := range tx.TxIn {
+			if isSSGen && i == 0 {
+				continue
+			}
+			origin := txIn.PreviousOutPoint
+			if _, ok := prevOuts[origin]; ok {
+				continue
+			}
+
+			// Attempt to use the origin transaction when it was
+			// already resolved or is in the memory pool.
+			originTx, ok := originTxns[origin.Hash]
+			if !ok {
+				mpTx, err := s.server.txMemPool.FetchTransaction(
+					&origin.Hash, true)
+				if err == nil {
+					originTx = mpTx.MsgTx()
+					originTxns[origin.Hash] = originTx
+				}
+			}
+			if originTx != nil {
+				if origin.Index < uint32(len(originTx.TxOut)) {
+					prevOuts[origin] = originTx.TxOut[origin.Index]
+				}
+				continue
+			}
+
+			// Look up the output in the unspent outputs of the main
+			// chain.
+			entry, ok := utxoEntries[origin.Hash]
+			if !ok {
+				var err error
+				entry, err = s.chain.FetchUtxoEntry(&origin.Hash)
+				if err != nil {
+					context := "Failed to fetch utxo entry"
+					return nil, rpcInternalError(err.Error(),
+						context)
+				}
+				utxoEntries[origin.Hash] = entry
+			}
+			if entry != nil && !entry.IsOutputSpent(origin.Index) {
+				prevOuts[origin] = &wire.TxOut{
+					Value:    entry.AmountByIndex(origin.Index),
+					Version:  entry.ScriptVersionByIndex(origin.Index),
+					PkScript: entry.PkScriptByIndex(origin.Index),
+				}
+				continue
+			}
+
+			// Spent outputs can only be found via the transaction
+			// index.
+			txIndex := s.server.txIndex
+			if txIndex == nil {
+				continue
+			}
+			blockRegion, err := txIndex.TxBlockRegion(origin.Hash)
+			if err != nil {
+				context := "Failed to retrieve transaction location"
+				return nil, rpcInternalError(err.Error(), context)
+			}
+			if blockRegion == nil {
+				err := s.rpcIndexNotSyncedError(txIndex)
+				if err != nil {
+					return nil, err
+				}
+				continue
+			}
+			var txBytes []byte
+			err = s.server.db.View(func(dbTx database.Tx) error {
+				var err error
+				txBytes, err = dbTx.FetchBlockRegion(blockRegion)
+				return err
+			})
+			if err != nil {
+				return nil, rpcNoTxInfoError(&origin.Hash)
+			}
+			var msgTx wire.MsgTx
+			err = msgTx.Deserialize(bytes.NewReader(txBytes))
+			if err != nil {
+				context := "Failed to deserialize transaction"
+				return nil, rpcInternalError(err.Error(), context)
+			}
+			originTxns[origin.Hash] = &msgTx
+			if origin.Index < uint32(len(msgTx.TxOut)) {
+				prevOuts[origin] = msgTx.TxOut[origin.Index]
+			}
+		}
+	}
+
+	return prevOuts, nil
+}
+
+// addVinPrevOuts adds the details of the previous outputs spent by the inputs
+// of the passed transaction which are in the passed map to the JSON objects of
+// the inputs created by createVinList.
+func addVinPrevOuts(vinList []exccjson.Vin, mtx *wire.MsgTx, chainParams *chaincfg.Params, prevOuts map[wire.OutPoint]*wire.TxOut) {
+	if blockchain.IsCoinBaseTx(mtx) {
+		return
+	}
+	isSSGen := stake.IsSSGen(mtx)
+
+	for i, txIn := range mtx.TxIn {
+		if isSSGen && i == 0 {
+			continue
+		}
+		txOut, ok := prevOuts[txIn.PreviousOutPoint]
+		if !ok {
+			continue
+		}
+
+		// Ignore the error here since an error means the script
+		// couldn't parse and there is no additional information about
+		// it anyways.
+		disbuf, _ := txscript.DisasmString(txOut.PkScript)
+		sc, addrs, reqSigs, _ := txscript.ExtractPkScriptAddrs(
+			txOut.Version, txOut.PkScript, chainParams)
+		encodedAddrs := make([]string, len(addrs))
+		for j, addr := range addrs {
+			encodedAddrs[j] = addr.EncodeAddress()
+		}
+
+		vinList[i].PrevOut = &exccjson.PrevOut{
+			Addresses: encodedAddrs,
+			Value:     exccutil.Amount(txOut.Value).ToCoin(),
+			ScriptPubKey: &exccjson.ScriptPubKeyResult{
+				Asm:       disbuf,
+				Hex:       hex.EncodeToString(txOut.PkScript),
+				ReqSigs:   int32(reqSigs),
+				Type:      sc.String(),
+				Addresses: encodedAddrs,
+			},
+		}
+	}
+}
+
+// fetchPrevOutScript returns the public key script and script version of the
+// unspent output referenced by the passed outpoint by looking it up in the
+// memory pool and the main chain.  A nil script is returned when the output is
+// unknown or spent.
+func fetchPrevOutScript(s *rpcServer, prevOut *wire.OutPoint) ([]byte, uint16, error) {
+	prevTx, err := s.server.txMemPool.FetchTransaction(&prevOut.Hash, true)
+	if err == nil && int(prevOut.Index) < len(prevTx.MsgTx().TxOut) {
+		txOut := prevTx.MsgTx().TxOut[prevOut.Index]
+		return txOut.PkScript, txOut.Version, nil
+	}
+
+	entry, err := s.chain.FetchUtxoEntry(&prevOut.Hash)
+	if err != nil {
+		return nil, 0, err
+	}
+	if entry == nil || entry.IsOutputSpent(prevOut.Index) {
+		return nil, 0, nil
+	}
+	return entry.PkScriptByIndex(prevOut.Index),
+		entry.ScriptVersionByIndex(prevOut.Index), nil
+}
+
+// rpcServerFilePath returns the path of a file the server is requested to write
+// by an RPC.  Relative paths are relative to the data directory.  An error is
+// returned when the file already exists since an existing file is never
+// overwritten.
+func rpcServerFilePath(path string) (string, error) {
+	path = cleanAndExpandPath(path)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(cfg.DataDir, path)
+	}
+	if _, err := os.Stat(path); err == nil {
+		return "", rpcInvalidError("%s already exists", path)
+	}
+	return path, nil
+}
+
+// maxDebugProfileSeconds is the maximum number of seconds a CPU profile or
+// execution trace may be captured for by the debugprofile command.
+const maxDebugProfileSeconds = 300
+
+// handleDebugProfile implements the debugprofile command.
+func handleDebugProfile(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	c := cmd.(*exccjson.DebugProfileCmd)
+
+	// Profiles which are captured over time require a sane duration.
+	var seconds uint32
+	switch c.Kind {
+	case exccjson.DPGoroutine, exccjson.DPHeap:
+	case exccjson.DPCPU, exccjson.DPTrace:
+		seconds = *c.Seconds
+		if seconds == 0 || seconds > maxDebugProfileSeconds {
+			return nil, rpcInvalidError("Seconds must be between 1 "+
+				"and %d", maxDebugProfileSeconds)
+		}
+	default:
+		return nil, rpcInvalidError("Unknown profile kind %q -- must "+
+			"be one of goroutine, heap, cpu, or trace", c.Kind)
+	}
+
+	path, err := rpcServerFilePath(c.Path)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return nil, rpcInternalError(err.Error(), "Failed to create file")
+	}
+
+	// waitDuration waits for the requested number of seconds or until the
+	// client disconnects or the server shuts down, whichever happens first,
+	// and returns how long it waited.
+	waitDuration := func() float64 {
+		start := time.Now()
+		timer := time.NewTimer(time.Duration(seconds) * time.Second)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-closeChan:
+		case <-s.quit:
+		}
+		return time.Since(start).Seconds()
+	}
+
+	var elapsed float64
+	switch c.Kind {
+	case exccjson.DPGoroutine:
+		err = pprof.Lookup("goroutine").WriteTo(f, 2)
+
+	case exccjson.DPHeap:
+		// Run a garbage collection first so the profile reflects the
+		// memory which is still in use.
+		runtime.GC()
+		err = pprof.WriteHeapProfile(f)
+
+	case exccjson.DPCPU:
+		// Only a single CPU profile may be captured at a time, including
+		// one requested with the --cpuprofile option.
+		err = pprof.StartCPUProfile(f)
+		if err == nil {
+			elapsed = waitDuration()
+			pprof.StopCPUProfile()
+		}
+
+	case exccjson.DPTrace:
+		err = trace.Start(f)
+		if err == nil {
+			elapsed = waitDuration()
+			trace.Stop()
+		}
+	}
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	var fi os.FileInfo
+	if err == nil {
+		fi, err = os.Stat(path)
+	}
+	if err != nil {
+		os.Remove(path)
+		return nil, rpcInternalError(err.Error(), "Failed to write "+
+			string(c.Kind)+" profile")
+	}
+	rpcsLog.Infof("Wrote %s profile to %s", c.Kind, path)
+
+	return &exccjson.DebugProfileResult{
+		Kind:    string(c.Kind),
+		Path:    path,
+		Size:    fi.Size(),
+		Seconds: elapsed,
+	}, nil
+}
+
+// handleDebugScript implements the debugscript command.
+func handleDebugScript(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	c := cmd.(*exccjson.DebugScriptCmd)
+
+	// Deserialize the transaction.
+	hexStr := c.HexTx
+	if len(hexStr)%2 != 0 {
+		hexStr = "0" + hexStr
+	}
+	serializedTx, err := hex.DecodeString(hexStr)
+	if err != nil {
+		return nil, rpcDecodeHexError(hexStr)
+	}
+	var mtx wire.MsgTx
+	err = mtx.Deserialize(bytes.NewReader(serializedTx))
+	if err != nil {
+		return nil, rpcDeserializationError("Could not decode Tx: %v",
+			err)
+	}
+	if int(c.Index) >= len(mtx.TxIn) {
+		return nil, rpcInvalidError("Input index %d is out of range for "+
+			"transaction with %d inputs", c.Index, len(mtx.TxIn))
+	}
+
+	// Use the provided public key script or look up the one of the output
+	// referenced by the input in the memory pool and the main chain.
+	var pkScript []byte
+	var scriptVersion uint16
+	if c.PkScript != nil {
+		pkScript, err = hex.DecodeString(*c.PkScript)
+		if err != nil {
+			return nil, rpcDecodeHexError(*c.PkScript)
+		}
+		scriptVersion = *c.ScriptVersion
+	} else {
+		prevOut := &mtx.TxIn[c.Index].PreviousOutPoint
+		pkScript, scriptVersion, err = fetchPrevOutScript(s, prevOut)
+		if err != nil {
+			return nil, rpcInternalError(err.Error(),
+				"Could not fetch output")
+		}
+		if pkScript == nil {
+			return nil, rpcInvalidError("Output %v referenced by "+
+				"input %d is unknown or spent -- provide its "+
+				"public key script", prevOut, c.Index)
+		}
+	}
+
+	flags, err := standardScriptVerifyFlags(s.chain)
+	if err != nil {
+		return nil, rpcInternalError(err.Error(),
+			"Could not obtain script flags")
+	}
+
+	result := &exccjson.DebugScriptResult{
+		PkScript:      hex.EncodeToString(pkScript),
+		ScriptVersion: scriptVersion,
+		Steps:         []exccjson.DebugScriptStep{},
+	}
+	vm, err := txscript.NewEngine(pkScript, &mtx, int(c.Index), flags,
+		scriptVersion, nil)
+	if err != nil {
+		result.Error = err.Error()
+		return result, nil
+	}
+	steps, err := vm.Trace()
+	hexStack := func(stack [][]byte) []string {
+		hexItems := make([]string, 0, len(stack))
+		for _, item := range stack {
+			hexItems = append(hexItems, hex.EncodeToString(item))
+		}
+		return hexItems
+	}
+	for _, step := range steps {
+		debugStep := exccjson.DebugScriptStep{
+			Script:   step.ScriptIdx,
+			Offset:   step.ScriptOff,
+			Opcode:   step.Opcode,
+			Stack:    hexStack(step.Stack),
+			AltStack: hexStack(step.AltStack),
+		}
+		if step.Err != nil {
+			debugStep.Error = step.Err.Error()
+		}
+		result.Steps = append(result.Steps, debugStep)
+	}
+	if err != nil {
+		result.Error = err.Error()
+		return result, nil
+	}
+	result.Valid = true
+	return result, nil
+}
+
 // handleDecodeRawTransaction handles decoderawtransaction commands.
 func handleDecodeRawTransaction(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
 	c := cmd.(*exccjson.DecodeRawTransactionCmd)
@@ -1448,18 +2089,12 @@ func handleDecodeScript(s *rpcServer, cmd interface{}, closeChan <-chan struct{}
 	disbuf, _ := txscript.DisasmString(script)
 
 	// Get information about the script.
-	// Ignore the error here since an error means the script couldn't parse
-	// and there is no additinal information about it anyways.
 	// TODO Replace magic version with argument passed to RPC call
-	scriptClass, addrs, reqSigs, _ := txscript.ExtractPkScriptAddrs(
-		txscript.DefaultScriptVersion, script, s.server.chainParams)
-	addresses := make([]string, len(addrs))
-	for i, addr := range addrs {
-		addresses[i] = addr.EncodeAddress()
-	}
+	params := s.server.chainParams
+	info := decodeScriptInfo(script, params)
 
 	// Convert the script itself to a pay-to-script-hash address.
-	p2sh, err := exccutil.NewAddressScriptHash(script, s.server.chainParams)
+	p2sh, err := exccutil.NewAddressScriptHash(script, params)
 	if err != nil {
 		return nil, rpcInternalError(err.Error(),
 			"Failed to convert script to pay-to-script-hash")
@@ -1468,30 +2103,200 @@ func handleDecodeScript(s *rpcServer, cmd interface{}, closeChan <-chan struct{}
 	// Generate and return the reply.
 	reply := exccjson.DecodeScriptResult{
 		Asm:       disbuf,
-		ReqSigs:   int32(reqSigs),
-		Type:      scriptClass.String(),
-		Addresses: addresses,
+		ReqSigs:   info.reqSigs,
+		NumKeys:   info.numKeys,
+		Type:      info.class.String(),
+		SubType:   info.subType,
+		Addresses: info.addresses,
 	}
-	if scriptClass != txscript.ScriptHashTy {
+	if info.class != txscript.ScriptHashTy {
 		reply.P2sh = p2sh.EncodeAddress()
 	}
+
+	switch info.class {
+	case txscript.NullDataTy:
+		// Null data scripts carry their payload in a single data push.
+		// Ignore the error since the script is already known to parse.
+		pushes, _ := txscript.PushedData(script)
+		if len(pushes) > 0 {
+			reply.Data = hex.EncodeToString(pushes[len(pushes)-1])
+		}
+
+	case txscript.NonStandardTy:
+		// Signature scripts which redeem pay-to-script-hash outputs
+		// only push data and the final push is the redeem script, so
+		// decode it when it is a standard script.
+		if !txscript.IsPushOnlyScript(script) {
+			break
+		}
+		pushes, err := txscript.PushedData(script)
+		if err != nil || len(pushes) == 0 {
+			break
+		}
+		redeemScript := pushes[len(pushes)-1]
+		redeemInfo := decodeScriptInfo(redeemScript, params)
+		if redeemInfo.class == txscript.NonStandardTy {
+			break
+		}
+		redeemP2sh, err := exccutil.NewAddressScriptHash(redeemScript,
+			params)
+		if err != nil {
+			return nil, rpcInternalError(err.Error(),
+				"Failed to convert redeem script to "+
+					"pay-to-script-hash")
+		}
+		redeemDisbuf, _ := txscript.DisasmString(redeemScript)
+		reply.RedeemScript = &exccjson.DecodeScriptRedeemResult{
+			Hex:       hex.EncodeToString(redeemScript),
+			Asm:       redeemDisbuf,
+			ReqSigs:   redeemInfo.reqSigs,
+			NumKeys:   redeemInfo.numKeys,
+			Type:      redeemInfo.class.String(),
+			SubType:   redeemInfo.subType,
+			Addresses: redeemInfo.addresses,
+			P2sh:      redeemP2sh.EncodeAddress(),
+		}
+	}
+
 	return reply, nil
 }
 
+// scriptInfo houses information about a script which is returned by the
+// decodescript command.
+type scriptInfo struct {
+	class     txscript.ScriptClass
+	subType   string
+	addresses []string
+	reqSigs   int32
+	numKeys   int32
+}
+
+// decodeScriptInfo returns the class of the passed version 0 script along with
+// the class of the script tagged by the stake opcode when it is a stake script,
+// the addresses it pays to, the number of signatures required to redeem it, and
+// the number of public keys when it is a multi-signature script.
+func decodeScriptInfo(script []byte, params *chaincfg.Params) *scriptInfo {
+	// Ignore the error here since an error means the script couldn't parse
+	// and there is no additional information about it anyways.
+	scriptClass, addrs, reqSigs, _ := txscript.ExtractPkScriptAddrs(
+		txscript.DefaultScriptVersion, script, params)
+	addresses := make([]string, len(addrs))
+	for i, addr := range addrs {
+		addresses[i] = addr.EncodeAddress()
+	}
+
+	info := &scriptInfo{
+		class:     scriptClass,
+		addresses: addresses,
+		reqSigs:   int32(reqSigs),
+	}
+	switch scriptClass {
+	case txscript.StakeSubmissionTy, txscript.StakeGenTy,
+		txscript.StakeRevocationTy, txscript.StakeSubChangeTy:
+
+		analysis, err := txscript.AnalyzeScript(
+			txscript.DefaultScriptVersion, script)
+		if err == nil {
+			info.subType = analysis.SubClass.String()
+		}
+
+	case txscript.MultiSigTy:
+		numKeys, _, err := txscript.CalcMultiSigStats(script)
+		if err == nil {
+			info.numKeys = int32(numKeys)
+		}
+	}
+	return info
+}
+
 // handleEstimateFee implenents the estimatefee command.
 // TODO this is a very basic implementation.  It should be
 // modified to match the bitcoin-core one.
 func handleEstimateFee(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
-	return cfg.minRelayTxFee.ToCoin(), nil
+	return cfg.currentMinRelayTxFee().ToCoin(), nil
 }
 
-// handleEstimateStakeDiff implements the estimatestakediff command.
-func handleEstimateStakeDiff(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
-	c := cmd.(*exccjson.EstimateStakeDiffCmd)
+// handleDNSSeed implements the dnsseed command.
+func handleDNSSeed(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	c := cmd.(*exccjson.DNSSeedCmd)
+
+	seeder := s.server.dnsSeeder
+	var err error
+	switch c.SubCmd {
+	case exccjson.DSAdd:
+		err = seeder.AddSeed(chaincfg.DNSSeed{
+			Host:         c.Host,
+			HasFiltering: *c.HasFiltering,
+		})
+	case exccjson.DSRemove:
+		err = seeder.RemoveSeed(c.Host)
+	case exccjson.DSSeed:
+		if cfg.DisableDNSSeed {
+			return nil, rpcInvalidError("DNS seeding is disabled " +
+				"via --nodnsseed")
+		}
+		err = seeder.SeedHost(c.Host)
+	default:
+		return nil, rpcInvalidError("Invalid subcommand for dnsseed")
+	}
 
-	// Minimum possible stake difficulty.
-	chain := s.server.blockManager.chain
-	min, err := chain.EstimateNextStakeDifficulty(0, false)
+	if err != nil {
+		return nil, rpcInvalidError("%v: %v", c.SubCmd, err)
+	}
+
+	// no data returned unless an error.
+	return nil, nil
+}
+
+// handleDumpTxOutSet implements the dumptxoutset command.
+func handleDumpTxOutSet(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	c := cmd.(*exccjson.DumpTxOutSetCmd)
+
+	path, err := rpcServerFilePath(c.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Write the dump to a temporary file which is only moved into place
+	// once it is complete so a partial dump is never mistaken for a full
+	// one.
+	tmpPath := path + ".incomplete"
+	f, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return nil, rpcInternalError(err.Error(), "Failed to create file")
+	}
+	dump, err := s.chain.DumpUtxoSet(f)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, path)
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return nil, rpcInternalError(err.Error(), "Failed to dump utxo set")
+	}
+
+	return &exccjson.DumpTxOutSetResult{
+		Path:        path,
+		Hash:        dump.Hash.String(),
+		Height:      dump.Height,
+		NumOutputs:  dump.NumOutputs,
+		TotalAmount: dump.TotalAmount,
+		Commitment:  hex.EncodeToString(dump.Commitment[:]),
+	}, nil
+}
+
+// handleEstimateStakeDiff implements the estimatestakediff command.
+func handleEstimateStakeDiff(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	c := cmd.(*exccjson.EstimateStakeDiffCmd)
+
+	// Minimum possible stake difficulty.
+	chain := s.server.blockManager.chain
+	min, err := chain.EstimateNextStakeDifficulty(0, false)
 	if err != nil {
 		return nil, rpcInternalError(err.Error(), "Could not "+
 			"estimate next minimum stake difficulty")
@@ -1563,6 +2368,9 @@ func handleExistsAddress(s *rpcServer, cmd interface{}, closeChan <-chan struct{
 		return nil, rpcInternalError("Exists address index disabled",
 			"Configuration")
 	}
+	if err := s.rpcIndexNotSyncedError(existsAddrIndex); err != nil {
+		return nil, err
+	}
 
 	c := cmd.(*exccjson.ExistsAddressCmd)
 
@@ -1588,6 +2396,9 @@ func handleExistsAddresses(s *rpcServer, cmd interface{}, closeChan <-chan struc
 		return nil, rpcInternalError("Exists address index disabled",
 			"Configuration")
 	}
+	if err := s.rpcIndexNotSyncedError(existsAddrIndex); err != nil {
+		return nil, err
+	}
 
 	c := cmd.(*exccjson.ExistsAddressesCmd)
 	addresses := make([]exccutil.Address, len(c.Addresses))
@@ -1749,6 +2560,330 @@ func handleExistsMempoolTxs(s *rpcServer, cmd interface{}, closeChan <-chan stru
 	return hex.EncodeToString([]byte(set)), nil
 }
 
+// p2pkhSigScriptSize is the maximum size of a signature script which redeems
+// a pay-to-pubkey-hash output with a compressed public key.  It consists of a
+// data push of a DER encoded signature of at most 72 bytes along with the
+// hash type and a data push of the 33 byte public key.
+const p2pkhSigScriptSize = 1 + 73 + 1 + 33
+
+// fundingUtxo describes an unspent pay-to-pubkey-hash output which may be used
+// to fund a transaction.
+type fundingUtxo struct {
+	outPoint    wire.OutPoint
+	amount      int64
+	blockHeight int64
+	blockIndex  uint32
+}
+
+// fetchFundingUtxos queries the address index for the transactions which pay
+// to the provided pay-to-pubkey-hash address and returns their regular tree
+// outputs to the address which are unspent, not spent by a transaction in the
+// memory pool, mature, and have at least the provided number of
+// confirmations.
+func fetchFundingUtxos(s *rpcServer, addr exccutil.Address, minConf int32) ([]fundingUtxo, error) {
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	const pageSize = 1000
+	var utxos []fundingUtxo
+	seen := make(map[chainhash.Hash]struct{})
+	best := s.chain.BestSnapshot()
+	params := s.server.chainParams
+	for skip := uint32(0); ; skip += pageSize {
+		var serializedTxns [][]byte
+		err := s.server.db.View(func(dbTx database.Tx) error {
+			regions, _, err := s.server.addrIndex.TxRegionsForAddress(
+				dbTx, addr, skip, pageSize, false)
+			if err != nil {
+				return err
+			}
+			serializedTxns, err = dbTx.FetchBlockRegions(regions)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, serializedTx := range serializedTxns {
+			var mtx wire.MsgTx
+			err := mtx.Deserialize(bytes.NewReader(serializedTx))
+			if err != nil {
+				return nil, err
+			}
+			if stake.DetermineTxType(&mtx) != stake.TxTypeRegular {
+				continue
+			}
+
+			// The address index includes transactions which spend
+			// from the address as well, so the same transaction
+			// may be seen more than once.
+			txHash := mtx.TxHash()
+			if _, ok := seen[txHash]; ok {
+				continue
+			}
+			seen[txHash] = struct{}{}
+
+			entry, err := s.chain.FetchUtxoEntry(&txHash)
+			if err != nil {
+				return nil, err
+			}
+			if entry == nil {
+				continue
+			}
+			confs := best.Height - entry.BlockHeight() + 1
+			if confs < int64(minConf) {
+				continue
+			}
+			if entry.IsCoinBase() &&
+				confs <= int64(params.CoinbaseMaturity) {
+				continue
+			}
+
+			for i, txOut := range mtx.TxOut {
+				idx := uint32(i)
+				if txOut.Version != txscript.DefaultScriptVersion ||
+					!bytes.Equal(txOut.PkScript, pkScript) ||
+					entry.IsOutputSpent(idx) {
+
+					continue
+				}
+				outPoint := wire.OutPoint{Hash: txHash, Index: idx,
+					Tree: wire.TxTreeRegular}
+				if s.server.txMemPool.CheckSpend(outPoint) != nil {
+					continue
+				}
+				utxos = append(utxos, fundingUtxo{
+					outPoint:    outPoint,
+					amount:      txOut.Value,
+					blockHeight: entry.BlockHeight(),
+					blockIndex:  entry.BlockIndex(),
+				})
+			}
+		}
+
+		if len(serializedTxns) < pageSize {
+			break
+		}
+	}
+
+	return utxos, nil
+}
+
+// handleFundRawTransaction implements the fundrawtransaction command.
+func handleFundRawTransaction(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	c := cmd.(*exccjson.FundRawTransactionCmd)
+
+	// Respond with an error if the address index is not enabled or still
+	// catching up to the main chain.
+	if s.server.addrIndex == nil {
+		return nil, rpcInternalError("Address index must be "+
+			"enabled (--addrindex)", "Configuration")
+	}
+	if err := s.rpcIndexNotSyncedError(s.server.addrIndex); err != nil {
+		return nil, err
+	}
+
+	// Deserialize the transaction.
+	hexStr := c.HexTx
+	if len(hexStr)%2 != 0 {
+		hexStr = "0" + hexStr
+	}
+	serializedTx, err := hex.DecodeString(hexStr)
+	if err != nil {
+		return nil, rpcDecodeHexError(hexStr)
+	}
+	var mtx wire.MsgTx
+	err = mtx.Deserialize(bytes.NewReader(serializedTx))
+	if err != nil {
+		return nil, rpcDeserializationError("Could not decode Tx: %v",
+			err)
+	}
+	if len(mtx.TxOut) == 0 {
+		return nil, rpcInvalidError("Transaction has no outputs")
+	}
+
+	// Apply the options.
+	params := s.server.chainParams
+	feeRate := cfg.currentMinRelayTxFee()
+	minConf := int32(1)
+	var changeAddr exccutil.Address
+	if opts := c.Options; opts != nil {
+		if opts.FeeRate != nil {
+			feeRate, err = exccutil.NewAmount(*opts.FeeRate)
+			if err != nil || feeRate < 0 {
+				return nil, rpcInvalidError("Invalid fee rate: %v",
+					*opts.FeeRate)
+			}
+		}
+		if opts.MinConf != nil {
+			minConf = *opts.MinConf
+			if minConf < 1 {
+				return nil, rpcInvalidError("Minimum number of "+
+					"confirmations must be at least 1, got %d",
+					minConf)
+			}
+		}
+		if opts.ChangeAddress != nil {
+			changeAddr, err = exccutil.DecodeAddress(*opts.ChangeAddress)
+			if err != nil {
+				return nil, rpcAddressKeyError("Could not decode "+
+					"change address: %v", err)
+			}
+			switch changeAddr.(type) {
+			case *exccutil.AddressPubKeyHash:
+			case *exccutil.AddressScriptHash:
+			case *exccutil.AddressBech32:
+			default:
+				return nil, rpcAddressKeyError("Invalid change "+
+					"address type: %T", changeAddr)
+			}
+			if !changeAddr.IsForNet(params) {
+				return nil, rpcAddressKeyError("Wrong network: %v",
+					changeAddr)
+			}
+		}
+	}
+
+	// Decode the funding addresses.  Only the outputs of pay-to-pubkey-hash
+	// addresses are used since the size of the signature scripts which
+	// redeem them is known.
+	if len(c.Addresses) == 0 {
+		return nil, rpcInvalidError("No funding addresses specified")
+	}
+	fundingAddrs := make([]exccutil.Address, 0, len(c.Addresses))
+	for _, encodedAddr := range c.Addresses {
+		addr, err := exccutil.DecodeAddress(encodedAddr)
+		if err != nil {
+			return nil, rpcAddressKeyError("Could not decode "+
+				"address: %v", err)
+		}
+		if bech32Addr, ok := addr.(*exccutil.AddressBech32); ok {
+			addr = bech32Addr.Base58()
+		}
+		pkhAddr, ok := addr.(*exccutil.AddressPubKeyHash)
+		if !ok || pkhAddr.DSA(params) != chainec.ECTypeSecp256k1 {
+			return nil, rpcAddressKeyError("Address %s is not a "+
+				"secp256k1 pay-to-pubkey-hash address", encodedAddr)
+		}
+		if !addr.IsForNet(params) {
+			return nil, rpcAddressKeyError("Wrong network: %v", addr)
+		}
+		fundingAddrs = append(fundingAddrs, addr)
+	}
+	if changeAddr == nil {
+		changeAddr = fundingAddrs[0]
+	}
+	changeScript, err := txscript.PayToAddrScript(changeAddr)
+	if err != nil {
+		return nil, rpcInternalError(err.Error(), "Pay to address script")
+	}
+
+	// Look up the amounts of any inputs the transaction already spends
+	// since they contribute to funding it.
+	used := make(map[wire.OutPoint]struct{})
+	var inputAmount, outputAmount int64
+	numUnsigned := 0
+	for _, txIn := range mtx.TxIn {
+		prevOut := &txIn.PreviousOutPoint
+		entry, err := s.chain.FetchUtxoEntry(&prevOut.Hash)
+		if err != nil {
+			context := "Failed to fetch utxo"
+			return nil, rpcInternalError(err.Error(), context)
+		}
+		if entry == nil || entry.IsOutputSpent(prevOut.Index) {
+			return nil, rpcInvalidError("Input %v is not an unspent "+
+				"output of the main chain", prevOut)
+		}
+		txIn.ValueIn = entry.AmountByIndex(prevOut.Index)
+		inputAmount += txIn.ValueIn
+		used[*prevOut] = struct{}{}
+		if len(txIn.SignatureScript) == 0 {
+			numUnsigned++
+		}
+	}
+	for _, txOut := range mtx.TxOut {
+		outputAmount += txOut.Value
+	}
+
+	// Gather the spendable outputs of the funding addresses and spend the
+	// largest of them first until the outputs and the fee of the
+	// transaction, including a change output, are covered.  Inputs which
+	// are not signed yet are assumed to redeem pay-to-pubkey-hash outputs.
+	var utxos []fundingUtxo
+	for _, addr := range fundingAddrs {
+		addrUtxos, err := fetchFundingUtxos(s, addr, minConf)
+		if err != nil {
+			context := "Failed to fetch unspent outputs"
+			return nil, rpcInternalError(err.Error(), context)
+		}
+		for _, utxo := range addrUtxos {
+			if _, ok := used[utxo.outPoint]; ok {
+				continue
+			}
+			used[utxo.outPoint] = struct{}{}
+			utxos = append(utxos, utxo)
+		}
+	}
+	sort.Slice(utxos, func(i, j int) bool {
+		return utxos[i].amount > utxos[j].amount
+	})
+
+	changeOut := wire.NewTxOut(0, changeScript)
+	calcFee := func() int64 {
+		size := int64(mtx.SerializeSize() + changeOut.SerializeSize() +
+			numUnsigned*p2pkhSigScriptSize)
+		fee := size * int64(feeRate) / 1000
+		if fee == 0 && feeRate > 0 {
+			fee = int64(feeRate)
+		}
+		return fee
+	}
+	for len(utxos) > 0 && inputAmount < outputAmount+calcFee() {
+		utxo := utxos[0]
+		utxos = utxos[1:]
+		txIn := wire.NewTxIn(&utxo.outPoint, nil)
+		txIn.ValueIn = utxo.amount
+		txIn.BlockHeight = uint32(utxo.blockHeight)
+		txIn.BlockIndex = utxo.blockIndex
+		mtx.AddTxIn(txIn)
+		inputAmount += utxo.amount
+		numUnsigned++
+	}
+	fee := calcFee()
+	if inputAmount < outputAmount+fee {
+		return nil, &exccjson.RPCError{
+			Code: exccjson.ErrRPCWalletInsufficientFunds,
+			Message: fmt.Sprintf("Insufficient funds: the transaction "+
+				"requires %v, but only %v is available",
+				exccutil.Amount(outputAmount+fee),
+				exccutil.Amount(inputAmount)),
+		}
+	}
+
+	// Add the change output unless it would be dust, in which case it is
+	// left to the fee.
+	changePos := int32(-1)
+	changeOut.Value = inputAmount - outputAmount - fee
+	if !mempool.IsDust(changeOut, cfg.currentMinRelayTxFee()) {
+		mtx.AddTxOut(changeOut)
+		changePos = int32(len(mtx.TxOut) - 1)
+	} else {
+		fee = inputAmount - outputAmount
+	}
+
+	mtxHex, err := messageToHex(&mtx)
+	if err != nil {
+		return nil, err
+	}
+	return &exccjson.FundRawTransactionResult{
+		Hex:       mtxHex,
+		Fee:       exccutil.Amount(fee).ToCoin(),
+		ChangePos: changePos,
+	}, nil
+}
+
 // handleGenerate handles generate commands.
 func handleGenerate(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
 	// Respond with an error if there are no addresses to pay the
@@ -1784,6 +2919,130 @@ func handleGenerate(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (i
 	return reply, nil
 }
 
+// ticketInfoResult converts the passed ticket lifecycle to the result format
+// returned by the ticket lifecycle RPCs.
+func ticketInfoResult(ticket *indexers.TicketLifecycle) exccjson.TicketInfoResult {
+	result := exccjson.TicketInfoResult{
+		Hash:           ticket.Hash.String(),
+		Status:         ticket.Status().String(),
+		PurchaseHeight: ticket.PurchaseHeight,
+		LiveHeight:     ticket.LiveHeight,
+		MissedHeight:   ticket.MissedHeight,
+		SpendHeight:    ticket.SpendHeight,
+	}
+	if ticket.SpendHeight != 0 {
+		result.Spender = ticket.Spender.String()
+	}
+	return result
+}
+
+// ticketInfoResults returns the JSON-RPC results for the passed ticket
+// lifecycles, which must be in the same order as the passed ticket hashes.
+// Tickets which are not known to the ticket index are reported with an unknown
+// status.
+func ticketInfoResults(hashes []chainhash.Hash, tickets []*indexers.TicketLifecycle) []exccjson.TicketInfoResult {
+	results := make([]exccjson.TicketInfoResult, len(tickets))
+	for i, ticket := range tickets {
+		if ticket == nil {
+			results[i] = exccjson.TicketInfoResult{
+				Hash:   hashes[i].String(),
+				Status: ticketStatusUnknown,
+			}
+			continue
+		}
+		results[i] = ticketInfoResult(ticket)
+	}
+	return results
+}
+
+// decodeTicketHashes decodes the passed hex-encoded ticket hashes.
+func decodeTicketHashes(hashStrs []string) ([]chainhash.Hash, error) {
+	hashes := make([]chainhash.Hash, len(hashStrs))
+	for i, hashStr := range hashStrs {
+		hash, err := chainhash.NewHashFromStr(hashStr)
+		if err != nil {
+			return nil, rpcDecodeHexError(hashStr)
+		}
+		hashes[i] = *hash
+	}
+	return hashes, nil
+}
+
+// handleGetAddressTickets implements the getaddresstickets command.
+func handleGetAddressTickets(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	ticketIndex := s.server.ticketIndex
+	if ticketIndex == nil {
+		return nil, rpcInternalError("The ticket lifecycle index must be "+
+			"enabled to query tickets (specify --ticketindex)",
+			"Configuration")
+	}
+
+	c := cmd.(*exccjson.GetAddressTicketsCmd)
+	addr, err := exccutil.DecodeAddress(c.Address)
+	if err != nil {
+		return nil, rpcInvalidError("Invalid address: %v", err)
+	}
+
+	tickets, err := ticketIndex.TicketsForAddress(addr)
+	if err != nil {
+		return nil, rpcInternalError(err.Error(),
+			"Could not obtain tickets")
+	}
+
+	result := make([]exccjson.TicketInfoResult, 0, len(tickets))
+	for _, ticket := range tickets {
+		result = append(result, ticketInfoResult(ticket))
+	}
+	return result, nil
+}
+
+// handleGetAgendas implements the getagendas command.
+func handleGetAgendas(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	params := s.server.chainParams
+	versions := make([]uint32, 0, len(params.Deployments))
+	for version := range params.Deployments {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i] < versions[j]
+	})
+
+	snapshot := s.chain.BestSnapshot()
+	result := make([]exccjson.GetAgendasResult, 0, len(versions))
+	for _, version := range versions {
+		for i := range params.Deployments[version] {
+			deployment := &params.Deployments[version][i]
+			state, err := s.chain.ThresholdState(&snapshot.Hash, version,
+				deployment.Vote.Id)
+			if err != nil {
+				return nil, rpcInternalError(err.Error(),
+					"Could not obtain agenda status")
+			}
+
+			agenda := exccjson.GetAgendasResult{
+				Id:          deployment.Vote.Id,
+				Description: deployment.Vote.Description,
+				VoteVersion: version,
+				Mask:        deployment.Vote.Mask,
+				StartTime:   deployment.StartTime,
+				ExpireTime:  deployment.ExpireTime,
+				Quorum:      params.DeploymentQuorum(deployment),
+				Status:      state.String(),
+			}
+
+			// The choice is only set once the agenda is locked in or
+			// has failed due to the votes.
+			choices := deployment.Vote.Choices
+			if state.Choice < uint32(len(choices)) {
+				agenda.Choice = choices[state.Choice].Id
+			}
+			result = append(result, agenda)
+		}
+	}
+
+	return result, nil
+}
+
 // handleGetAddedNodeInfo handles getaddednodeinfo commands.
 func handleGetAddedNodeInfo(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
 	c := cmd.(*exccjson.GetAddedNodeInfoCmd)
@@ -1980,7 +3239,22 @@ func handleGetBlock(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (i
 		EquihashSolution: blockHeader.EquihashSolution[:],
 	}
 
-	if c.VerboseTx == nil || !*c.VerboseTx {
+	// Resolve the previous outputs spent by all transactions of the block
+	// at once when they are requested, which implies verbose transactions.
+	var prevOuts map[wire.OutPoint]*wire.TxOut
+	if c.PrevOuts != nil && *c.PrevOuts {
+		msgBlock := blk.MsgBlock()
+		txns := make([]*wire.MsgTx, 0, len(msgBlock.Transactions)+
+			len(msgBlock.STransactions))
+		txns = append(txns, msgBlock.Transactions...)
+		txns = append(txns, msgBlock.STransactions...)
+		prevOuts, err = fetchPrevOuts(s, txns)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if prevOuts == nil && (c.VerboseTx == nil || !*c.VerboseTx) {
 		transactions := blk.Transactions()
 		txNames := make([]string, len(transactions))
 		for i, tx := range transactions {
@@ -2008,6 +3282,10 @@ func handleGetBlock(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (i
 				return nil, rpcInternalError(err.Error(),
 					"Could not create transaction")
 			}
+			if prevOuts != nil {
+				addVinPrevOuts(rawTxn.Vin, tx.MsgTx(),
+					s.server.chainParams, prevOuts)
+			}
 			rawTxns[i] = *rawTxn
 		}
 		blockReply.RawTx = rawTxns
@@ -2023,6 +3301,10 @@ func handleGetBlock(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (i
 				return nil, rpcInternalError(err.Error(),
 					"Could not create stake transaction")
 			}
+			if prevOuts != nil {
+				addVinPrevOuts(rawSTxn.Vin, tx.MsgTx(),
+					s.server.chainParams, prevOuts)
+			}
 			rawSTxns[i] = *rawSTxn
 		}
 		blockReply.RawSTx = rawSTxns
@@ -2052,6 +3334,59 @@ func handleGetBlockHash(s *rpcServer, cmd interface{}, closeChan <-chan struct{}
 	return hash.String(), nil
 }
 
+// handleGetBlockHashByTime implements the getblockhashbytime command.
+func handleGetBlockHashByTime(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	timeIndex := s.server.timeIndex
+	if timeIndex == nil {
+		return nil, rpcInternalError("The block timestamp index must be "+
+			"enabled to query blocks by time (specify --timeindex)",
+			"Configuration")
+	}
+
+	c := cmd.(*exccjson.GetBlockHashByTimeCmd)
+	if c.StartTime < 0 {
+		return nil, rpcInvalidError("Start time must not be negative")
+	}
+	startTime := time.Unix(c.StartTime, 0)
+
+	// Default to all blocks up to the current time when no end time is
+	// specified.
+	endTime := s.server.timeSource.AdjustedTime()
+	if c.EndTime != nil {
+		if *c.EndTime < c.StartTime {
+			return nil, rpcInvalidError("End time %d is before start "+
+				"time %d", *c.EndTime, c.StartTime)
+		}
+		endTime = time.Unix(*c.EndTime, 0)
+	}
+
+	count := int32(100)
+	if c.Count != nil {
+		count = *c.Count
+	}
+	if count <= 0 {
+		return nil, rpcInvalidError("Count must be greater than zero")
+	}
+
+	entries, err := timeIndex.BlocksInTimeRange(startTime, endTime,
+		int(count))
+	if err != nil {
+		context := "Failed to query block timestamp index"
+		return nil, rpcInternalError(err.Error(), context)
+	}
+
+	result := make([]exccjson.GetBlockHashByTimeResult, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, exccjson.GetBlockHashByTimeResult{
+			Hash:   entry.Hash.String(),
+			Height: entry.Height,
+			Time:   entry.Timestamp.Unix(),
+		})
+	}
+
+	return result, nil
+}
+
 // handleGetBlockHeader implements the getblockheader command.
 func handleGetBlockHeader(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
 	c := cmd.(*exccjson.GetBlockHeaderCmd)
@@ -2242,12 +3577,12 @@ func (state *gbtWorkState) notifyLongPollers(latestHash *chainhash.Hash, lastGen
 // clients with a new block template when their existing block template is
 // stale due to the newly connected block.
 func (state *gbtWorkState) NotifyBlockConnected(blockHash *chainhash.Hash) {
-	go func() {
+	spawn(func() {
 		state.Lock()
 		defer state.Unlock()
 
 		state.notifyLongPollers(blockHash, state.lastTxUpdate)
-	}()
+	})
 }
 
 // NotifyMempoolTx uses the new last updated time for the transaction memory
@@ -2255,7 +3590,7 @@ func (state *gbtWorkState) NotifyBlockConnected(blockHash *chainhash.Hash) {
 // existing block template is stale due to enough time passing and the contents
 // of the memory pool changing.
 func (state *gbtWorkState) NotifyMempoolTx(lastUpdated time.Time) {
-	go func() {
+	spawn(func() {
 		state.Lock()
 		defer state.Unlock()
 
@@ -2270,7 +3605,7 @@ func (state *gbtWorkState) NotifyMempoolTx(lastUpdated time.Time) {
 
 			state.notifyLongPollers(state.prevHash, lastUpdated)
 		}
-	}()
+	})
 }
 
 // templateUpdateChan returns a channel that will be closed once the block
@@ -2388,10 +3723,19 @@ func (state *gbtWorkState) updateBlockTemplate(s *rpcServer, useCoinbaseValue bo
 		state.prevHash = latestHash
 		state.minTimestamp = minTimestamp
 
+		// Issue the new template as work, so the blocks submitted for
+		// it can be checked to only make the allowed mutations.
+		id := s.server.workTracker.Issue(&msgBlock.Header.PrevBlock,
+			int64(msgBlock.Header.Height))
+		state.issueTemplate(id, newGbtIssuedTemplate(msgBlock,
+			minTimestamp))
+
 		rpcsLog.Debugf("Generated block template (timestamp %v, "+
 			"target %s, merkle root %s)",
 			msgBlock.Header.Timestamp, targetDifficulty,
 			msgBlock.Header.MerkleRoot)
+		s.server.archiveTemplate(msgBlock, id,
+			miningSourceGetBlockTemplate)
 
 		// Notify any clients that are long polling about the new
 		// template.
@@ -2411,7 +3755,12 @@ func (state *gbtWorkState) updateBlockTemplate(s *rpcServer, useCoinbaseValue bo
 		// returned if none have been specified.
 		if !useCoinbaseValue && !template.ValidPayAddress {
 			// Choose a payment address at random.
-			payToAddr := cfg.miningAddrs[rand.Intn(len(cfg.miningAddrs))]
+			miningAddrs := cfg.currentMiningAddrs()
+			if len(miningAddrs) == 0 {
+				return rpcInternalError("No payment addresses "+
+					"specified via --miningaddr", "Configuration")
+			}
+			payToAddr := miningAddrs[rand.Intn(len(miningAddrs))]
 
 			// Update the block coinbase output of the template to
 			// pay to the randomly selected payment address.
@@ -2627,6 +3976,7 @@ func (state *gbtWorkState) blockTemplateResult(bm *blockManager, useCoinbaseValu
 		SizeLimit:     maxBlockSize,
 		Transactions:  transactions,
 		STransactions: stransactions,
+		WorkID:        strconv.FormatUint(uint64(state.workID), 10),
 		LongPollID:    templateID,
 		SubmitOld:     submitOld,
 		Target:        targetDifficulty,
@@ -2802,7 +4152,7 @@ func handleGetBlockTemplateRequest(s *rpcServer, request *exccjson.TemplateReque
 	// When a coinbase transaction has been requested, respond with an
 	// error if there are no addresses to pay the created block template
 	// to.
-	if !useCoinbaseValue && len(cfg.miningAddrs) == 0 {
+	if !useCoinbaseValue && len(cfg.currentMiningAddrs()) == 0 {
 		return nil, rpcInternalError("A coinbase transaction has "+
 			"been requested, but the server has not been "+
 			"configured with any payment addresses via "+
@@ -2813,7 +4163,7 @@ func handleGetBlockTemplateRequest(s *rpcServer, request *exccjson.TemplateReque
 	// way to relay a found block or receive transactions to work on.
 	// However, allow this state when running in the regression test or
 	// simulation test mode.
-	if !cfg.SimNet && s.server.ConnectedCount() == 0 {
+	if !cfg.SimNet && !cfg.RegNet && s.server.ConnectedCount() == 0 {
 		return nil, &exccjson.RPCError{
 			Code:    exccjson.ErrRPCClientNotConnected,
 			Message: "ExchangeCoin is not connected",
@@ -2976,6 +4326,22 @@ func handleGetBlockTemplateProposal(s *rpcServer, request *exccjson.TemplateRequ
 		return "bad-prevblk", nil
 	}
 
+	// Ensure the block only makes the allowed mutations to the block
+	// template it is proposed for when a work ID is provided.
+	if request.WorkID != "" {
+		id, ok := parseGbtWorkID(request.WorkID)
+		if !ok {
+			return "unknown-work", nil
+		}
+		reason := s.gbtWorkState.checkSubmission(id, &msgBlock,
+			expectedPrevHash)
+		if reason != "" {
+			rpcsLog.Infof("Rejected block proposal for work %d: %s",
+				id, reason)
+			return reason, nil
+		}
+	}
+
 	flags := blockchain.BFNoPoWCheck
 	err = s.server.blockManager.chain.CheckConnectBlock(block, flags)
 	if err != nil {
@@ -3057,6 +4423,129 @@ func handleGetDifficulty(s *rpcServer, cmd interface{}, closeChan <-chan struct{
 	return getDifficultyRatio(best.Bits), nil
 }
 
+// handleGetDifficultyHistory implements the getdifficultyhistory command.
+func handleGetDifficultyHistory(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	c := cmd.(*exccjson.GetDifficultyHistoryCmd)
+
+	best := s.chain.BestSnapshot()
+	endHeight := best.Height
+	if c.EndHeight != nil && *c.EndHeight >= 0 {
+		endHeight = *c.EndHeight
+	}
+	if c.StartHeight < 0 || c.StartHeight > endHeight ||
+		endHeight > best.Height {
+
+		return nil, &exccjson.RPCError{
+			Code: exccjson.ErrRPCOutOfRange,
+			Message: fmt.Sprintf("Block range %d to %d is not within "+
+				"the main chain (best height %d)", c.StartHeight,
+				endHeight, best.Height),
+		}
+	}
+	if endHeight-c.StartHeight >= maxDifficultyHistoryBlocks {
+		return nil, rpcInvalidError("Too many blocks requested "+
+			"(%d > %d)", endHeight-c.StartHeight+1,
+			maxDifficultyHistoryBlocks)
+	}
+
+	entries, err := s.chain.DifficultyHistory(c.StartHeight, endHeight)
+	if err != nil {
+		return nil, rpcInternalError(err.Error(),
+			"Could not obtain difficulty history")
+	}
+
+	// The best chain may have been reorganized to a lower height since the
+	// range was checked above.
+	if entries == nil {
+		return nil, &exccjson.RPCError{
+			Code: exccjson.ErrRPCOutOfRange,
+			Message: fmt.Sprintf("Block range %d to %d is not within "+
+				"the main chain", c.StartHeight, endHeight),
+		}
+	}
+
+	result := make([]exccjson.DifficultyHistoryResult, 0, len(entries))
+	for i := range entries {
+		entry := &entries[i]
+		result = append(result, exccjson.DifficultyHistoryResult{
+			Height:     entry.Height,
+			Hash:       entry.Hash.String(),
+			Time:       entry.Timestamp.Unix(),
+			Bits:       strconv.FormatInt(int64(entry.Bits), 16),
+			Difficulty: getDifficultyRatio(entry.Bits),
+			ChainWork:  fmt.Sprintf("%064x", entry.WorkSum),
+			Retarget:   entry.Retarget,
+		})
+	}
+	return result, nil
+}
+
+// handleGetEmissionSchedule implements the getemissionschedule command.
+func handleGetEmissionSchedule(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	c := cmd.(*exccjson.GetEmissionScheduleCmd)
+
+	maxIntervals := int(*c.Intervals)
+	if maxIntervals < 1 {
+		return nil, rpcInvalidError("Number of intervals must be at "+
+			"least 1, got %d", maxIntervals)
+	}
+
+	cache := s.chain.FetchSubsidyCache()
+	if cache == nil {
+		return nil, rpcInternalError("empty subsidy cache", "")
+	}
+
+	// Project the emission of the blocks after the current best block from
+	// its timestamp and the target time per block.
+	best := s.chain.BestSnapshot()
+	header, err := s.chain.FetchHeader(&best.Hash)
+	if err != nil {
+		context := "Failed to fetch best block header"
+		return nil, rpcInternalError(err.Error(), context)
+	}
+	params := s.server.chainParams
+	targetSecs := int64(params.TargetTimePerBlock / time.Second)
+	blocksPerYear := float64(365*24*time.Hour) /
+		float64(params.TargetTimePerBlock)
+
+	// The schedule is calculated until the end of the emission in order to
+	// determine the maximum supply, but only the requested number of
+	// intervals are returned.
+	schedule := cache.EmissionSchedule(best.Height+1, 0)
+	supply := best.TotalSubsidy
+	intervals := make([]exccjson.EmissionIntervalResult, 0, maxIntervals)
+	for _, interval := range schedule {
+		if len(intervals) < maxIntervals {
+			var inflation float64
+			if supply > 0 {
+				inflation = float64(interval.BlockSubsidy) *
+					blocksPerYear / float64(supply) * 100
+			}
+			startTime := header.Timestamp.Unix() + targetSecs*
+				(interval.StartHeight-best.Height)
+			intervals = append(intervals, exccjson.EmissionIntervalResult{
+				StartHeight:  interval.StartHeight,
+				EndHeight:    interval.EndHeight,
+				StartTime:    startTime,
+				BlockSubsidy: interval.BlockSubsidy,
+				WorkSubsidy:  interval.WorkSubsidy,
+				VoteSubsidy:  interval.VoteSubsidy,
+				Emission:     interval.Emission,
+				Supply:       supply + interval.Emission,
+				Inflation:    inflation,
+			})
+		}
+		supply += interval.Emission
+	}
+
+	return &exccjson.GetEmissionScheduleResult{
+		Height:    best.Height,
+		Supply:    best.TotalSubsidy,
+		MaxSupply: supply,
+		Intervals: intervals,
+	}, nil
+}
+
 // handleGetGenerate implements the getgenerate command.
 func handleGetGenerate(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
 	return s.server.cpuMiner.IsMining(), nil
@@ -3096,6 +4585,9 @@ func handleGetCFilter(s *rpcServer, cmd interface{}, closeChan <-chan struct{})
 	if err != nil {
 		rpcsLog.Debugf("Could not find committed filter for %v: %v",
 			hash, err)
+		if err := s.rpcIndexNotSyncedError(s.server.cfIndex); err != nil {
+			return nil, err
+		}
 		return nil, &exccjson.RPCError{
 			Code:    exccjson.ErrRPCBlockNotFound,
 			Message: "Block not found",
@@ -3137,6 +4629,9 @@ func handleGetCFilterHeader(s *rpcServer, cmd interface{}, closeChan <-chan stru
 	} else {
 		rpcsLog.Debugf("Could not find header of committed filter for %v: %v",
 			hash, err)
+		if err := s.rpcIndexNotSyncedError(s.server.cfIndex); err != nil {
+			return nil, err
+		}
 		return nil, &exccjson.RPCError{
 			Code:    exccjson.ErrRPCBlockNotFound,
 			Message: "Block not found",
@@ -3203,6 +4698,61 @@ func handleGetHeaders(s *rpcServer, cmd interface{}, closeChan <-chan struct{})
 	return &exccjson.GetHeadersResult{Headers: hexBlockHeaders}, nil
 }
 
+// handleGetDNSSeedInfo implements the getdnsseedinfo command.
+func handleGetDNSSeedInfo(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	seeds := s.server.dnsSeeder.Seeds()
+	results := make([]exccjson.GetDNSSeedInfoResult, 0, len(seeds))
+	for _, seed := range seeds {
+		var lastSuccess int64
+		if !seed.LastSuccess.IsZero() {
+			lastSuccess = seed.LastSuccess.Unix()
+		}
+		results = append(results, exccjson.GetDNSSeedInfoResult{
+			Host:         seed.Host,
+			HasFiltering: seed.HasFiltering,
+			Lookups:      seed.Lookups,
+			Successes:    seed.Successes,
+			Addresses:    seed.Addresses,
+			LastSuccess:  lastSuccess,
+			LastError:    seed.LastError,
+		})
+	}
+	return results, nil
+}
+
+// handleGetIndexInfo implements the getindexinfo command.
+func handleGetIndexInfo(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	c := cmd.(*exccjson.GetIndexInfoCmd)
+
+	result := make(map[string]exccjson.GetIndexInfoResult)
+	indexManager := s.server.indexManager
+	if indexManager == nil {
+		return result, nil
+	}
+
+	infos, err := indexManager.IndexInfo()
+	if err != nil {
+		return nil, rpcInternalError(err.Error(),
+			"Could not obtain index info")
+	}
+	for _, info := range infos {
+		if c.IndexName != nil && *c.IndexName != info.Name {
+			continue
+		}
+		idxInfo := exccjson.GetIndexInfoResult{
+			Ready:           info.Ready,
+			Synced:          info.Synced,
+			BestBlockHash:   info.Hash.String(),
+			BestBlockHeight: info.Height,
+		}
+		if info.LoadErr != nil {
+			idxInfo.Error = info.LoadErr.Error()
+		}
+		result[info.Name] = idxInfo
+	}
+	return result, nil
+}
+
 // handleGetInfo implements the getinfo command. We only return the fields
 // that are not related to wallet functionality.
 func handleGetInfo(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
@@ -3217,7 +4767,10 @@ func handleGetInfo(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (in
 		Proxy:           cfg.Proxy,
 		Difficulty:      getDifficultyRatio(best.Bits),
 		TestNet:         cfg.TestNet,
-		RelayFee:        cfg.minRelayTxFee.ToCoin(),
+		RelayFee:        cfg.currentMinRelayTxFee().ToCoin(),
+		Commit:          appCommit,
+		GoVersion:       runtime.Version(),
+		Features:        enabledFeatures(s),
 	}
 
 	return ret, nil
@@ -3240,6 +4793,47 @@ func handleGetMempoolInfo(s *rpcServer, cmd interface{}, closeChan <-chan struct
 	return ret, nil
 }
 
+// handleGetMinedBlockStats implements the getminedblockstats command.
+func handleGetMinedBlockStats(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	windows, since := s.server.minedBlocks.Stats()
+	return &exccjson.GetMinedBlockStatsResult{
+		Since:   since.Unix(),
+		Windows: windows,
+	}, nil
+}
+
+// handleGetMiningArchive implements the getminingarchive command.
+func handleGetMiningArchive(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	c := cmd.(*exccjson.GetMiningArchiveCmd)
+
+	// Respond with an error if the mining archive is not enabled.
+	archive := s.server.miningArchive
+	if archive == nil {
+		return nil, rpcInternalError("Mining archive must be enabled "+
+			"(--miningarchive)", "Configuration")
+	}
+
+	count := defaultMiningArchiveCount
+	if c.Count != nil {
+		count = int(*c.Count)
+	}
+	if count <= 0 {
+		return nil, rpcInvalidError("Count must be positive -- "+
+			"parsed [%d]", count)
+	}
+
+	records, err := archive.Records(count)
+	if err != nil {
+		return nil, rpcInternalError(err.Error(),
+			"Could not read mining archive")
+	}
+	results := make([]exccjson.MiningArchiveResult, 0, len(records))
+	for _, r := range records {
+		results = append(results, r.result())
+	}
+	return results, nil
+}
+
 // handleGetMiningInfo implements the getmininginfo command. We only return the
 // fields that are not related to wallet functionality.
 func handleGetMiningInfo(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
@@ -3265,6 +4859,7 @@ func handleGetMiningInfo(s *rpcServer, cmd interface{}, closeChan <-chan struct{
 			"Could not calculate next stake difficulty")
 	}
 
+	timeRolls, templateRebuilds := s.server.cpuMiner.TemplateStats()
 	result := exccjson.GetMiningInfoResult{
 		Blocks:           best.Height,
 		CurrentBlockSize: best.BlockSize,
@@ -3277,6 +4872,11 @@ func handleGetMiningInfo(s *rpcServer, cmd interface{}, closeChan <-chan struct{
 		NetworkHashPS:    networkHashesPerSec,
 		PooledTx:         uint64(s.server.txMemPool.Count()),
 		TestNet:          cfg.TestNet,
+		TimeRolls:        timeRolls,
+		TemplateRebuilds: templateRebuilds,
+	}
+	if err := s.server.cpuMiner.PausedErr(); err != nil {
+		result.Errors = fmt.Sprintf("CPU mining is paused: %v", err)
 	}
 	return &result, nil
 }
@@ -3289,28 +4889,30 @@ func handleGetNetTotals(s *rpcServer, cmd interface{}, closeChan <-chan struct{}
 		TotalBytesSent: totalBytesSent,
 		TimeMillis:     time.Now().UTC().UnixNano() / int64(time.Millisecond),
 	}
+	s.server.bandwidth.NetTotals(reply)
 	return reply, nil
 }
 
-// handleGetNetworkHashPS implements the getnetworkhashps command.
-func handleGetNetworkHashPS(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
-	// Note: All valid error return paths should return an int64.  Literal
-	// zeros are inferred as int, and won't coerce to int64 because the
-	// return value is an interface{}.
-
-	c := cmd.(*exccjson.GetNetworkHashPSCmd)
+// networkWorkWindow describes the work done to find a window of blocks of the
+// main chain, which is used to estimate the rate of the network.
+type networkWorkWindow struct {
+	startHeight int64
+	endHeight   int64
+	totalWork   *big.Int
+	timeSpan    int64 // seconds
+}
 
-	// When the passed height is too high or zero, just return 0 now since
-	// we can't reasonably calculate the number of network hashes per
-	// second from invalid values.  When it's negative, use the current
-	// best block height.
+// calcNetworkWorkWindow calculates the work done to find the passed number of
+// blocks of the main chain ending at the passed height along with the time
+// spanned by their timestamps.  A negative end height uses the current best
+// chain block height, and a non-positive number of blocks uses the blocks since
+// the last difficulty retarget interval.  Nil is returned when the end height
+// is zero or after the best block since no reasonable estimate can be made
+// from such values.
+func calcNetworkWorkWindow(s *rpcServer, numBlocks, endHeight int64) (*networkWorkWindow, error) {
 	best := s.chain.BestSnapshot()
-	endHeight := int64(-1)
-	if c.Height != nil {
-		endHeight = int64(*c.Height)
-	}
 	if endHeight > best.Height || endHeight == 0 {
-		return int64(0), nil
+		return nil, nil
 	}
 	if endHeight < 0 {
 		endHeight = best.Height
@@ -3325,12 +4927,6 @@ func handleGetNetworkHashPS(s *rpcServer, cmd interface{}, closeChan <-chan stru
 	// blocks.  When the passed value is negative, use the last block the
 	// difficulty changed as the starting height.  Also make sure the
 	// starting height is not before the beginning of the chain.
-
-	numBlocks := int64(120)
-	if c.Blocks != nil {
-		numBlocks = int64(*c.Blocks)
-	}
-
 	var startHeight int64
 	if numBlocks <= 0 {
 		startHeight = endHeight - ((endHeight % blocksPerRetarget) + 1)
@@ -3340,7 +4936,7 @@ func handleGetNetworkHashPS(s *rpcServer, cmd interface{}, closeChan <-chan stru
 	if startHeight < 0 {
 		startHeight = 0
 	}
-	rpcsLog.Debugf("Calculating network hashes per second from %d to %d",
+	rpcsLog.Debugf("Calculating network work rate from %d to %d",
 		startHeight, endHeight)
 
 	// Find the min and max block timestamps as well as calculate the total
@@ -3376,18 +4972,85 @@ func handleGetNetworkHashPS(s *rpcServer, cmd interface{}, closeChan <-chan stru
 		}
 	}
 
-	// Calculate the difference in seconds between the min and max block
-	// timestamps and avoid division by zero in the case where there is no
-	// time difference.
-	timeDiff := int64(maxTimestamp.Sub(minTimestamp) / time.Second)
-	if timeDiff == 0 {
+	return &networkWorkWindow{
+		startHeight: startHeight,
+		endHeight:   endHeight,
+		totalWork:   totalWork,
+		timeSpan:    int64(maxTimestamp.Sub(minTimestamp) / time.Second),
+	}, nil
+}
+
+// handleGetNetworkHashPS implements the getnetworkhashps command.
+func handleGetNetworkHashPS(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	// Note: All valid error return paths should return an int64.  Literal
+	// zeros are inferred as int, and won't coerce to int64 because the
+	// return value is an interface{}.
+
+	c := cmd.(*exccjson.GetNetworkHashPSCmd)
+
+	numBlocks := int64(120)
+	if c.Blocks != nil {
+		numBlocks = int64(*c.Blocks)
+	}
+	endHeight := int64(-1)
+	if c.Height != nil {
+		endHeight = int64(*c.Height)
+	}
+	window, err := calcNetworkWorkWindow(s, numBlocks, endHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	// Avoid division by zero in the case where there is no time difference.
+	if window == nil || window.timeSpan == 0 {
 		return int64(0), nil
 	}
 
-	hashesPerSec := new(big.Int).Div(totalWork, big.NewInt(timeDiff))
+	hashesPerSec := new(big.Int).Div(window.totalWork,
+		big.NewInt(window.timeSpan))
 	return hashesPerSec.Int64(), nil
 }
 
+// handleGetNetworkSolPS implements the getnetworksolps command.
+func handleGetNetworkSolPS(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	c := cmd.(*exccjson.GetNetworkSolPSCmd)
+
+	numBlocks := int64(120)
+	if c.Blocks != nil {
+		numBlocks = int64(*c.Blocks)
+	}
+	endHeight := int64(-1)
+	if c.Height != nil {
+		endHeight = int64(*c.Height)
+	}
+	window, err := calcNetworkWorkWindow(s, numBlocks, endHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	params := s.server.chainParams
+	result := &exccjson.GetNetworkSolPSResult{
+		EquihashN: params.N,
+		EquihashK: params.K,
+	}
+	if window == nil {
+		return result, nil
+	}
+	result.StartHeight = window.startHeight
+	result.EndHeight = window.endHeight
+	result.TimeSpan = window.timeSpan
+
+	// Every equihash solution results in a block hash which is checked
+	// against the target, so the work of a block is also the expected number
+	// of solutions needed to find it.  Avoid division by zero in the case
+	// where there is no time difference.
+	if window.timeSpan != 0 {
+		work, _ := new(big.Float).SetInt(window.totalWork).Float64()
+		result.SolPS = work / float64(window.timeSpan)
+	}
+	return result, nil
+}
+
 // handleGetPeerInfo implements the getpeerinfo command.
 func handleGetPeerInfo(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
 	peers := s.server.Peers()
@@ -3415,6 +5078,7 @@ func handleGetPeerInfo(s *rpcServer, cmd interface{}, closeChan <-chan struct{})
 			CurrentHeight:  statsSnap.LastBlock,
 			BanScore:       int32(p.banScore.Int()),
 			SyncNode:       p == syncPeer,
+			SyncOnly:       p.isSyncOnlyPeer,
 		}
 		if p.LastPingNonce() != 0 {
 			wait := float64(time.Since(statsSnap.LastPingTime).Nanoseconds())
@@ -3455,25 +5119,99 @@ func handleGetRawMempool(s *rpcServer, cmd interface{}, closeChan <-chan struct{
 		}
 	}
 
-	// Return verbose results if requested.
+	// The results are only ordered when sorting or pagination is requested.
+	// Pagination without an explicit sort order uses the order in which the
+	// transactions entered the pool so the pages are consistent.
+	verbose := c.Verbose != nil && *c.Verbose
+	ordered := c.SortBy != nil || c.Skip != nil || c.Count != nil
+	sortBy := exccjson.GRMSortAge
+	if c.SortBy != nil {
+		sortBy = exccjson.GetRawMempoolSortByCmd(*c.SortBy)
+		switch sortBy {
+		case exccjson.GRMSortFeeRate, exccjson.GRMSortAge,
+			exccjson.GRMSortSize:
+		default:
+			return nil, rpcInvalidError("Invalid sort order: %v",
+				*c.SortBy)
+		}
+	}
+
+	// Return verbose results keyed by transaction hash if requested.
 	mp := s.server.txMemPool
-	if c.Verbose != nil && *c.Verbose {
+	if verbose && !ordered {
 		return mp.RawMempoolVerbose(filterType), nil
 	}
 
+	descs := mp.TxDescs()
+	filtered := descs[:0]
+	for _, desc := range descs {
+		if filterType != nil && desc.Type != *filterType {
+			continue
+		}
+		filtered = append(filtered, desc)
+	}
+	descs = filtered
+
+	if ordered {
+		sortMempoolTxDescs(descs, sortBy)
+
+		// Select the requested page of results.  A count of zero means
+		// all of the remaining transactions.
+		if c.Skip != nil {
+			skip := int(*c.Skip)
+			if skip > len(descs) {
+				skip = len(descs)
+			}
+			descs = descs[skip:]
+		}
+		if c.Count != nil && *c.Count != 0 && int(*c.Count) < len(descs) {
+			descs = descs[:*c.Count]
+		}
+
+		if verbose {
+			return mp.RawMempoolVerboseDescs(descs), nil
+		}
+	}
+
 	// The response is simply an array of the transaction hashes if the
 	// verbose flag is not set.
-	descs := mp.TxDescs()
 	hashStrings := make([]string, 0, len(descs))
 	for i := range descs {
-		if filterType != nil && descs[i].Type != *filterType {
-			continue
-		}
 		hashStrings = append(hashStrings, descs[i].Tx.Hash().String())
 	}
 	return hashStrings, nil
 }
 
+// sortMempoolTxDescs sorts the provided mempool transaction descriptors in
+// place according to the provided sort order.  Transactions with fee rates,
+// times, or sizes that compare equal are ordered by their hash so the order is
+// deterministic.
+func sortMempoolTxDescs(descs []*mempool.TxDesc, sortBy exccjson.GetRawMempoolSortByCmd) {
+	feeRate := func(desc *mempool.TxDesc) float64 {
+		return float64(desc.Fee) / float64(desc.Tx.MsgTx().SerializeSize())
+	}
+	sort.Slice(descs, func(i, j int) bool {
+		a, b := descs[i], descs[j]
+		switch sortBy {
+		case exccjson.GRMSortFeeRate:
+			if rateA, rateB := feeRate(a), feeRate(b); rateA != rateB {
+				return rateA > rateB
+			}
+		case exccjson.GRMSortAge:
+			if !a.Added.Equal(b.Added) {
+				return a.Added.Before(b.Added)
+			}
+		case exccjson.GRMSortSize:
+			sizeA := a.Tx.MsgTx().SerializeSize()
+			sizeB := b.Tx.MsgTx().SerializeSize()
+			if sizeA != sizeB {
+				return sizeA > sizeB
+			}
+		}
+		return bytes.Compare(a.Tx.Hash()[:], b.Tx.Hash()[:]) < 0
+	})
+}
+
 // handleGetRawTransaction implements the getrawtransaction command.
 func handleGetRawTransaction(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
 	c := cmd.(*exccjson.GetRawTransactionCmd)
@@ -3510,6 +5248,9 @@ func handleGetRawTransaction(s *rpcServer, cmd interface{}, closeChan <-chan str
 			return nil, rpcInternalError(err.Error(), context)
 		}
 		if blockRegion == nil {
+			if err := s.rpcIndexNotSyncedError(txIndex); err != nil {
+				return nil, err
+			}
 			return nil, rpcNoTxInfoError(txHash)
 		}
 
@@ -3591,9 +5332,35 @@ func handleGetRawTransaction(s *rpcServer, cmd interface{}, closeChan <-chan str
 	if err != nil {
 		return nil, err
 	}
+
+	// Include the previous outputs spent by the inputs at the higher
+	// verbosity.
+	if *c.Verbose >= 2 {
+		prevOuts, err := fetchPrevOuts(s, []*wire.MsgTx{mtx})
+		if err != nil {
+			return nil, err
+		}
+		addVinPrevOuts(rawTxn.Vin, mtx, s.server.chainParams, prevOuts)
+	}
 	return *rawTxn, nil
 }
 
+// handleGetSigCacheInfo implements the getsigcacheinfo command.
+func handleGetSigCacheInfo(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	stats := s.server.sigCache.Stats()
+	var hitRate float64
+	if lookups := stats.Hits + stats.Misses; lookups > 0 {
+		hitRate = float64(stats.Hits) / float64(lookups)
+	}
+	return &exccjson.GetSigCacheInfoResult{
+		Entries:    stats.Entries,
+		MaxEntries: stats.MaxEntries,
+		Hits:       stats.Hits,
+		Misses:     stats.Misses,
+		HitRate:    hitRate,
+	}, nil
+}
+
 // handleGetStakeDifficulty implements the getstakedifficulty command.
 func handleGetStakeDifficulty(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
 	best := s.chain.BestSnapshot()
@@ -3622,6 +5389,71 @@ func handleGetStakeDifficulty(s *rpcServer, cmd interface{}, closeChan <-chan st
 	return sDiffResult, nil
 }
 
+// handleGetNetworkStakeInfo implements the getnetworkstakeinfo command.  The
+// vote and miss rates are calculated from the votes included in the most recent
+// stake difficulty window worth of blocks.
+func handleGetNetworkStakeInfo(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	params := s.server.chainParams
+	best := s.chain.BestSnapshot()
+	blockHeader, err := s.chain.HeaderByHeight(best.Height)
+	if err != nil {
+		return nil, rpcInternalError(err.Error(), "Could not fetch header")
+	}
+	nextSdiff, err := s.server.blockManager.CalcNextRequiredStakeDifficulty()
+	if err != nil {
+		return nil, rpcInternalError("Could not calculate next stake "+
+			"difficulty "+err.Error(), "")
+	}
+
+	var mempoolTickets uint32
+	for _, txD := range s.server.txMemPool.TxDescs() {
+		if txD.Type == stake.TxTypeSStx {
+			mempoolTickets++
+		}
+	}
+
+	// Count the votes and misses of the blocks in the window which were
+	// required to include votes.
+	var window int64
+	var voted, missed uint32
+	for height := best.Height; height > best.Height-params.StakeDiffWindowSize &&
+		height >= params.StakeValidationHeight; height-- {
+
+		header, err := s.chain.HeaderByHeight(height)
+		if err != nil {
+			return nil, rpcInternalError(err.Error(),
+				"Could not fetch header")
+		}
+		window++
+		voted += uint32(header.Voters)
+		missed += uint32(params.TicketsPerBlock - header.Voters)
+	}
+	var voteRate, missRate float64
+	if window > 0 {
+		voteRate = float64(voted) / float64(voted+missed)
+		missRate = float64(missed) / float64(voted+missed)
+	}
+
+	voteReward := exccutil.Amount(blockchain.CalcStakeVoteSubsidy(
+		s.chain.FetchSubsidyCache(), best.Height+1, params))
+	expectedReward := exccutil.Amount(float64(voteReward) * voteRate)
+
+	return &exccjson.NetworkStakeInfoResult{
+		Height:          best.Height,
+		PoolSize:        blockHeader.PoolSize,
+		TicketPrice:     exccutil.Amount(blockHeader.SBits).ToCoin(),
+		NextTicketPrice: exccutil.Amount(nextSdiff).ToCoin(),
+		MempoolTickets:  mempoolTickets,
+		RateWindow:      window,
+		Voted:           voted,
+		Missed:          missed,
+		VoteRate:        voteRate,
+		MissRate:        missRate,
+		VoteReward:      voteReward.ToCoin(),
+		ExpectedReward:  expectedReward.ToCoin(),
+	}, nil
+}
+
 // convertVersionMap translates a map[int]int into a sorted array of
 // VersionCount that contains the same information.
 func convertVersionMap(m map[int]int) []exccjson.VersionCount {
@@ -3717,40 +5549,152 @@ func handleGetStakeVersionInfo(s *rpcServer, cmd interface{}, closeChan <-chan s
 func handleGetStakeVersions(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
 	c := cmd.(*exccjson.GetStakeVersionsCmd)
 
-	hash, err := chainhash.NewHashFromStr(c.Hash)
-	if err != nil {
-		return nil, rpcDecodeHexError(c.Hash)
+	hash, err := chainhash.NewHashFromStr(c.Hash)
+	if err != nil {
+		return nil, rpcDecodeHexError(c.Hash)
+	}
+	if c.Count <= 0 {
+		return nil, rpcInvalidError("Invalid parameter, count must " +
+			"be > 0")
+	}
+
+	sv, err := s.chain.GetStakeVersions(hash, c.Count)
+	if err != nil {
+		return nil, rpcInternalError(err.Error(),
+			"Could not obtain stake versions")
+	}
+
+	result := exccjson.GetStakeVersionsResult{
+		StakeVersions: make([]exccjson.StakeVersions, 0, len(sv)),
+	}
+	for _, v := range sv {
+		nsv := exccjson.StakeVersions{
+			Hash:         v.Hash.String(),
+			Height:       v.Height,
+			BlockVersion: v.BlockVersion,
+			StakeVersion: v.StakeVersion,
+			Votes: make([]exccjson.VersionBits, 0,
+				len(v.Votes)),
+		}
+		for _, vote := range v.Votes {
+			nsv.Votes = append(nsv.Votes,
+				exccjson.VersionBits{Version: vote.Version,
+					Bits: vote.Bits})
+		}
+
+		result.StakeVersions = append(result.StakeVersions, nsv)
+	}
+
+	return result, nil
+}
+
+// handleGetSyncInfo implements the getsyncinfo command.
+func handleGetSyncInfo(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	return s.server.syncInfo(), nil
+}
+
+// handleGetTicketInfo implements the getticketinfo command.
+func handleGetTicketInfo(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	ticketIndex := s.server.ticketIndex
+	if ticketIndex == nil {
+		return nil, rpcInternalError("The ticket lifecycle index must be "+
+			"enabled to query tickets (specify --ticketindex)",
+			"Configuration")
+	}
+
+	c := cmd.(*exccjson.GetTicketInfoCmd)
+	hash, err := chainhash.NewHashFromStr(c.TxHash)
+	if err != nil {
+		return nil, rpcDecodeHexError(c.TxHash)
+	}
+
+	ticket, err := ticketIndex.TicketLifecycle(hash)
+	if err != nil {
+		return nil, rpcInternalError(err.Error(),
+			"Could not obtain ticket")
+	}
+	if ticket == nil {
+		return nil, rpcNoTxInfoError(hash)
+	}
+
+	return ticketInfoResult(ticket), nil
+}
+
+// handleGetTicketsInfo implements the getticketsinfo command.
+func handleGetTicketsInfo(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	ticketIndex := s.server.ticketIndex
+	if ticketIndex == nil {
+		return nil, rpcInternalError("The ticket lifecycle index must be "+
+			"enabled to query tickets (specify --ticketindex)",
+			"Configuration")
 	}
-	if c.Count <= 0 {
-		return nil, rpcInvalidError("Invalid parameter, count must " +
-			"be > 0")
+
+	c := cmd.(*exccjson.GetTicketsInfoCmd)
+	if len(c.TxHashes) > maxTicketsInfoHashes {
+		return nil, rpcInvalidError("Too many tickets requested "+
+			"(%d > %d)", len(c.TxHashes), maxTicketsInfoHashes)
+	}
+	hashes, err := decodeTicketHashes(c.TxHashes)
+	if err != nil {
+		return nil, err
 	}
 
-	sv, err := s.chain.GetStakeVersions(hash, c.Count)
+	tickets, err := ticketIndex.TicketLifecycles(hashes)
 	if err != nil {
 		return nil, rpcInternalError(err.Error(),
-			"Could not obtain stake versions")
+			"Could not obtain tickets")
 	}
 
-	result := exccjson.GetStakeVersionsResult{
-		StakeVersions: make([]exccjson.StakeVersions, 0, len(sv)),
+	return ticketInfoResults(hashes, tickets), nil
+}
+
+// handleGetTicketPoolStats implements the getticketpoolstats command.
+func handleGetTicketPoolStats(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	c := cmd.(*exccjson.GetTicketPoolStatsCmd)
+
+	blocks := int64(1)
+	if c.Blocks != nil {
+		blocks = *c.Blocks
+	}
+	if blocks < 1 || blocks > maxTicketPoolStatsBlocks {
+		return nil, rpcInvalidError("Number of blocks must be between "+
+			"1 and %d", maxTicketPoolStatsBlocks)
+	}
+	interval := int64(1)
+	if c.Interval != nil {
+		interval = *c.Interval
+	}
+	if interval < 1 {
+		return nil, rpcInvalidError("Interval must be positive")
 	}
-	for _, v := range sv {
-		nsv := exccjson.StakeVersions{
-			Hash:         v.Hash.String(),
-			Height:       v.Height,
-			BlockVersion: v.BlockVersion,
-			StakeVersion: v.StakeVersion,
-			Votes: make([]exccjson.VersionBits, 0,
-				len(v.Votes)),
-		}
-		for _, vote := range v.Votes {
-			nsv.Votes = append(nsv.Votes,
-				exccjson.VersionBits{Version: vote.Version,
-					Bits: vote.Bits})
-		}
 
-		result.StakeVersions = append(result.StakeVersions, nsv)
+	stats, err := s.chain.TicketPoolStatsHistory(blocks, interval)
+	if err != nil {
+		return nil, rpcInternalError(err.Error(),
+			"Could not obtain ticket pool statistics")
+	}
+
+	result := make([]exccjson.TicketPoolStatsResult, 0, len(stats))
+	for i := range stats {
+		stat := &stats[i]
+		var avgPrice exccutil.Amount
+		if stat.PoolSize > 0 {
+			avgPrice = stat.PoolValue / exccutil.Amount(stat.PoolSize)
+		}
+		var lockedPercent float64
+		if stat.CoinSupply > 0 {
+			lockedPercent = float64(stat.PoolValue) /
+				float64(stat.CoinSupply) * 100
+		}
+		result = append(result, exccjson.TicketPoolStatsResult{
+			Height:        stat.Height,
+			Hash:          stat.Hash.String(),
+			PoolSize:      stat.PoolSize,
+			PoolValue:     stat.PoolValue.ToCoin(),
+			AveragePrice:  avgPrice.ToCoin(),
+			CoinSupply:    stat.CoinSupply.ToCoin(),
+			LockedPercent: lockedPercent,
+		})
 	}
 
 	return result, nil
@@ -3776,8 +5720,9 @@ func handleGetVoteInfo(s *rpcServer, cmd interface{}, closeChan <-chan struct{})
 
 	snapshot := s.chain.BestSnapshot()
 
-	interval := int64(s.server.chainParams.RuleChangeActivationInterval)
-	quorum := s.server.chainParams.RuleChangeActivationQuorum
+	params := s.server.chainParams
+	interval := int64(params.RuleChangeActivationInterval)
+	quorum := params.RuleChangeActivationQuorum
 	// Assemble JSON result.
 	result := exccjson.GetVoteInfoResult{
 		CurrentHeight: snapshot.Height,
@@ -3805,7 +5750,8 @@ func handleGetVoteInfo(s *rpcServer, cmd interface{}, closeChan <-chan struct{})
 	}
 
 	result.Agendas = make([]exccjson.Agenda, 0, len(vi.Agendas))
-	for _, agenda := range vi.Agendas {
+	for i := range vi.Agendas {
+		agenda := &vi.Agendas[i]
 		a := exccjson.Agenda{
 			Id:          agenda.Vote.Id,
 			Description: agenda.Vote.Description,
@@ -3814,6 +5760,7 @@ func handleGetVoteInfo(s *rpcServer, cmd interface{}, closeChan <-chan struct{})
 				len(agenda.Vote.Choices)),
 			StartTime:  agenda.StartTime,
 			ExpireTime: agenda.ExpireTime,
+			Quorum:     params.DeploymentQuorum(agenda),
 		}
 
 		// Handle choices.
@@ -3832,7 +5779,8 @@ func handleGetVoteInfo(s *rpcServer, cmd interface{}, closeChan <-chan struct{})
 		state, err := s.chain.ThresholdState(&snapshot.Hash, c.Version,
 			agenda.Vote.Id)
 		if err != nil {
-			return nil, err
+			return nil, rpcInternalError(err.Error(),
+				"Could not obtain agenda status")
 		}
 
 		// Save off status.
@@ -3851,16 +5799,22 @@ func handleGetVoteInfo(s *rpcServer, cmd interface{}, closeChan <-chan struct{})
 		}
 
 		// Calculate quorum.
-		qmin := quorum
+		qmin := a.Quorum
 		totalNonAbstain := counts.Total - counts.TotalAbstain
-		if totalNonAbstain < quorum {
+		if totalNonAbstain < a.Quorum {
 			qmin = totalNonAbstain
 		}
-		a.QuorumProgress = float64(qmin) / float64(quorum)
+		a.QuorumProgress = float64(qmin) / float64(a.Quorum)
 
-		// Calculate choice progress.
+		// Calculate choice progress.  The progress is left at zero when
+		// no votes have been cast yet in the current interval since the
+		// division would otherwise result in a NaN which can't be
+		// represented in JSON.
 		for k := range a.Choices {
 			a.Choices[k].Count = counts.VoteChoices[k]
+			if counts.Total == 0 {
+				continue
+			}
 			a.Choices[k].Progress = float64(counts.VoteChoices[k]) /
 				float64(counts.Total)
 		}
@@ -4007,6 +5961,25 @@ func handleGetTxOut(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (i
 	return txOutReply, nil
 }
 
+// handleGetTxOutSetInfo implements the gettxoutsetinfo command.
+func handleGetTxOutSetInfo(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	info, err := s.chain.FetchUtxoSetInfo()
+	if err != nil {
+		return nil, rpcInternalError(err.Error(),
+			"Failed to fetch utxo set info")
+	}
+
+	return &exccjson.GetTxOutSetInfoResult{
+		Height:       info.Height,
+		BestBlock:    info.Hash.String(),
+		Transactions: info.Transactions,
+		TxOuts:       info.Outputs,
+		UtxoSetHash:  hex.EncodeToString(info.UtxoSetHash[:]),
+		DiskSize:     info.SerializedSize,
+		TotalAmount:  info.TotalAmount,
+	}, nil
+}
+
 // pruneOldBlockTemplates prunes all old block templates from the templatePool
 // map. Must be called with the RPC workstate locked to avoid races to the map.
 func pruneOldBlockTemplates(s *rpcServer, bestHeight int64) {
@@ -4123,7 +6096,8 @@ func handleGetWorkRequest(s *rpcServer) (interface{}, error) {
 			// Increment the extra nonce and update the block template
 			// with the new value by regenerating the coinbase script and
 			// setting the merkle root to the new value.
-			en := extractCoinbaseExtraNonce(msgBlock) + 1
+			en := rigExtraNonce(cfg.MiningRigID,
+				extractCoinbaseExtraNonce(msgBlock)+1)
 			state.extraNonce++
 			err := UpdateExtraNonce(msgBlock, latestHeight+1, en)
 			if err != nil {
@@ -4154,15 +6128,21 @@ func handleGetWorkRequest(s *rpcServer) (interface{}, error) {
 	copy(merkleRootPair[:chainhash.HashSize], msgBlock.Header.MerkleRoot[:])
 	copy(merkleRootPair[chainhash.HashSize:], msgBlock.Header.StakeRoot[:])
 
-	if msgBlock.Header.Height > 1 {
-		s.templatePool[merkleRootPair] = &workStateBlockInfo{
+	// The same work is handed out again with an updated timestamp until
+	// the template changes, so only new templates are issued as new work.
+	blockInfo, ok := s.templatePool[merkleRootPair]
+	if !ok || blockInfo.msgBlock != msgBlock {
+		blockInfo = &workStateBlockInfo{
 			msgBlock: msgBlock,
-			pkScript: coinbaseTx.TxOut[1].PkScript,
+			workID: s.server.workTracker.Issue(&msgBlock.Header.PrevBlock,
+				int64(msgBlock.Header.Height)),
 		}
-	} else {
-		s.templatePool[merkleRootPair] = &workStateBlockInfo{
-			msgBlock: msgBlock,
+		if msgBlock.Header.Height > 1 {
+			blockInfo.pkScript = coinbaseTx.TxOut[1].PkScript
 		}
+		s.templatePool[merkleRootPair] = blockInfo
+		s.server.archiveTemplate(msgBlock, blockInfo.workID,
+			miningSourceGetWork)
 	}
 
 	// Serialize the block header into a buffer large enough to hold the
@@ -4279,6 +6259,21 @@ func handleGetWorkSubmission(s *rpcServer, hexData string) (interface{}, error)
 
 		rpcsLog.Errorf("Block submitted via getwork does not meet "+
 			"the required proof of work: %v", err)
+		s.server.archiveSolution(block.MsgBlock(), blockInfo.workID,
+			miningSourceGetWork, miningOutcomeInvalid, err.Error())
+		return false, nil
+	}
+
+	// Don't process solutions which were already submitted or which are
+	// for work that was already solved or is stale.
+	best := s.chain.BestSnapshot()
+	err = s.server.workTracker.CheckSubmission(blockInfo.workID,
+		block.Hash(), &best.Hash)
+	if err != nil {
+		rpcsLog.Debugf("Block %v submitted via getwork for work %d not "+
+			"processed: %v", block.Hash(), blockInfo.workID, err)
+		s.server.archiveSolution(block.MsgBlock(), blockInfo.workID,
+			miningSourceGetWork, miningOutcomeDropped, err.Error())
 		return false, nil
 	}
 
@@ -4289,23 +6284,36 @@ func handleGetWorkSubmission(s *rpcServer, hexData string) (interface{}, error)
 	if err != nil {
 		// Anything other than a rule violation is an unexpected error,
 		// so return that error as an internal error.
+		s.server.minedBlocks.Add(block.Hash(), miningOutcomeRejected)
+		s.server.archiveSolution(block.MsgBlock(), blockInfo.workID,
+			miningSourceGetWork, miningOutcomeRejected, err.Error())
 		if _, ok := err.(blockchain.RuleError); !ok {
 			return false, rpcInternalError("Unexpected error "+
 				"while processing block: "+err.Error(), "")
 		}
 
 		rpcsLog.Infof("Block submitted via getwork rejected: %v", err)
+		s.server.notifyMinedBlock(block, "getwork", err.Error())
 		return false, nil
 	}
 
 	if isOrphan {
 		rpcsLog.Infof("Block submitted via getwork rejected: an orphan building "+
 			"on parent %v", block.MsgBlock().Header.PrevBlock)
+		s.server.notifyMinedBlock(block, "getwork", "orphan")
+		s.server.minedBlocks.Add(block.Hash(), miningOutcomeOrphan)
+		s.server.archiveSolution(block.MsgBlock(), blockInfo.workID,
+			miningSourceGetWork, miningOutcomeOrphan, "")
 		return false, nil
 	}
 
 	// The block was accepted.
+	s.server.workTracker.MarkSolved(blockInfo.workID)
+	s.server.minedBlocks.Add(block.Hash(), miningOutcomeAccepted)
+	s.server.archiveSolution(block.MsgBlock(), blockInfo.workID,
+		miningSourceGetWork, miningOutcomeAccepted, "")
 	rpcsLog.Infof("Block submitted via getwork accepted: %s", block.Hash())
+	s.server.notifyMinedBlock(block, "getwork", "")
 	return true, nil
 }
 
@@ -4319,7 +6327,7 @@ func handleGetWork(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (in
 
 	// Respond with an error if there are no addresses to pay the created
 	// blocks to.
-	if len(cfg.miningAddrs) == 0 {
+	if len(cfg.currentMiningAddrs()) == 0 {
 		return nil, rpcInternalError("No payment addresses specified "+
 			"via --miningaddr", "Configuration")
 	}
@@ -4328,7 +6336,7 @@ func handleGetWork(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (in
 	// way to relay a found block or receive transactions to work on.
 	// However, allow this state when running in the regression test or
 	// simulation test mode.
-	if !cfg.SimNet && s.server.ConnectedCount() == 0 {
+	if !cfg.SimNet && !cfg.RegNet && s.server.ConnectedCount() == 0 {
 		return nil, &exccjson.RPCError{
 			Code:    exccjson.ErrRPCClientNotConnected,
 			Message: "ExchangeCoin is not connected",
@@ -4550,6 +6558,10 @@ func fetchInputTxos(s *rpcServer, tx *wire.MsgTx) (map[wire.OutPoint]wire.TxOut,
 			return nil, rpcInternalError(err.Error(), context)
 		}
 		if blockRegion == nil {
+			err := s.rpcIndexNotSyncedError(s.server.txIndex)
+			if err != nil {
+				return nil, err
+			}
 			return nil, rpcNoTxInfoError(&origin.Hash)
 		}
 
@@ -4746,6 +6758,56 @@ func fetchMempoolTxnsForAddress(s *rpcServer, addr exccutil.Address, numToSkip,
 	return mpTxns[numToSkip:rangeEnd], numToSkip
 }
 
+// handleRegenerateCert implements the regeneratecert command.
+func handleRegenerateCert(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	if cfg.DisableTLS {
+		return nil, rpcMiscError("TLS is disabled for the RPC server")
+	}
+
+	// Generate the new pair and ensure it can be used before replacing the
+	// files and the certificate presented to clients.
+	cert, key, err := newRPCCertPair()
+	if err != nil {
+		return nil, rpcInternalError(err.Error(),
+			"Failed to generate certificate")
+	}
+	keypair, err := tls.X509KeyPair(cert, key)
+	if err != nil {
+		return nil, rpcInternalError(err.Error(),
+			"Failed to load generated certificate")
+	}
+	if err := writeCertPair(cfg.RPCCert, cfg.RPCKey, cert, key); err != nil {
+		return nil, rpcInternalError(err.Error(),
+			"Failed to write certificate")
+	}
+	s.setTLSCertificate(&keypair)
+
+	rpcsLog.Infof("Regenerated the RPC server certificate %s", cfg.RPCCert)
+	return string(cert), nil
+}
+
+// handleReloadConfig implements the reloadconfig command.
+func handleReloadConfig(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	changed, err := s.server.reloadConfig()
+	if err != nil {
+		return nil, rpcInternalError(err.Error(), "Failed to reload config")
+	}
+	if changed == nil {
+		changed = []string{}
+	}
+	return changed, nil
+}
+
+// handleRotateLogs implements the rotatelogs command.
+func handleRotateLogs(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	rolled, err := rotateLogFiles()
+	if err != nil {
+		return nil, rpcInternalError(err.Error(), "Failed to rotate logs")
+	}
+	rpcsLog.Infof("Rotated log files %v", rolled)
+	return rolled, nil
+}
+
 // handleSearchRawTransactions implements the searchrawtransactions command.
 func handleSearchRawTransactions(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
 	// Respond with an error if the address index is not enabled.
@@ -4772,6 +6834,12 @@ func handleSearchRawTransactions(s *rpcServer, cmd interface{}, closeChan <-chan
 			"enabled (--txindex)", "Configuration")
 	}
 
+	// Respond with an error while the address index is still catching up
+	// to the main chain since the results would be incomplete.
+	if err := s.rpcIndexNotSyncedError(addrIndex); err != nil {
+		return nil, err
+	}
+
 	// Attempt to decode the supplied address.
 	addr, err := exccutil.DecodeAddress(c.Address)
 	if err != nil {
@@ -5002,13 +7070,38 @@ func handleSearchRawTransactions(s *rpcServer, cmd interface{}, closeChan <-chan
 	return srtList, nil
 }
 
-// handleSendRawTransaction implements the sendrawtransaction command.
-func handleSendRawTransaction(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
-	c := cmd.(*exccjson.SendRawTransactionCmd)
-	// Deserialize and send off to tx relay
+// txFee returns the fee paid by the passed transaction along with whether it
+// could be determined, which requires all of the previous outputs spent by it
+// to be known.  The fee of coinbase transactions and votes is never determined
+// since they create coins without spending previous outputs.
+func txFee(s *rpcServer, mtx *wire.MsgTx) (exccutil.Amount, bool, error) {
+	if blockchain.IsCoinBaseTx(mtx) || stake.IsSSGen(mtx) {
+		return 0, false, nil
+	}
 
-	allowHighFees := *c.AllowHighFees
-	hexStr := c.HexTx
+	prevOuts, err := fetchPrevOuts(s, []*wire.MsgTx{mtx})
+	if err != nil {
+		return 0, false, err
+	}
+	var fee int64
+	for _, txIn := range mtx.TxIn {
+		prevOut, ok := prevOuts[txIn.PreviousOutPoint]
+		if !ok {
+			return 0, false, nil
+		}
+		fee += prevOut.Value
+	}
+	for _, txOut := range mtx.TxOut {
+		fee -= txOut.Value
+	}
+	return exccutil.Amount(fee), true, nil
+}
+
+// sendRawTransaction deserializes the passed hex-encoded transaction, submits
+// it to the memory pool, and relays it to the network.  It implements the
+// common parts of the sendrawtransaction and sendrawtransactionandwait
+// commands.
+func sendRawTransaction(s *rpcServer, hexStr string, allowHighFees bool, maxFeeRateCoins float64) (*exccutil.Tx, error) {
 	if len(hexStr)%2 != 0 {
 		hexStr = "0" + hexStr
 	}
@@ -5023,6 +7116,37 @@ func handleSendRawTransaction(s *rpcServer, cmd interface{}, closeChan <-chan st
 			err)
 	}
 
+	// Reject transactions paying a fee rate above the maximum unless high
+	// fees are allowed or the maximum is zero.  The much looser high fee
+	// check of the memory pool is skipped when the fee rate was checked
+	// here and only applies when the fee is unknown, such as for orphans.
+	maxFeeRate, err := exccutil.NewAmount(maxFeeRateCoins)
+	if err != nil || maxFeeRate < 0 {
+		return nil, rpcInvalidError("Invalid max fee rate: %v",
+			maxFeeRateCoins)
+	}
+	if maxFeeRate == 0 {
+		allowHighFees = true
+	}
+	if !allowHighFees {
+		fee, ok, err := txFee(s, msgtx)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			size := int64(msgtx.SerializeSize())
+			if int64(fee)*1000 > int64(maxFeeRate)*size {
+				feeRate := exccutil.Amount(int64(fee) * 1000 / size)
+				return nil, rpcRuleError("Rejected transaction %v: "+
+					"fee rate of %v/kB is above the maximum fee "+
+					"rate of %v/kB -- use allowhighfees or a "+
+					"higher maxfeerate to send it anyway",
+					msgtx.TxHash(), feeRate, maxFeeRate)
+			}
+			allowHighFees = true
+		}
+	}
+
 	tx := exccutil.NewTx(msgtx)
 	acceptedTxs, err := s.server.blockManager.ProcessTransaction(tx, false,
 		false, allowHighFees)
@@ -5055,7 +7179,7 @@ func handleSendRawTransaction(s *rpcServer, cmd interface{}, closeChan <-chan st
 		return nil, rpcDeserializationError("rejected: %v", err)
 	}
 
-	s.server.AnnounceNewTransactions(acceptedTxs)
+	s.server.AnnounceLocalTransactions(acceptedTxs)
 
 	// Keep track of all the regular sendrawtransaction request txns so that
 	// they can be rebroadcast if they don't make their way into a block.
@@ -5076,54 +7200,475 @@ func handleSendRawTransaction(s *rpcServer, cmd interface{}, closeChan <-chan st
 		s.server.AddRebroadcastInventory(iv, tx)
 	}
 
+	return tx, nil
+}
+
+// handleSendRawTransaction implements the sendrawtransaction command.
+func handleSendRawTransaction(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	c := cmd.(*exccjson.SendRawTransactionCmd)
+	tx, err := sendRawTransaction(s, c.HexTx, *c.AllowHighFees,
+		*c.MaxFeeRate)
+	if err != nil {
+		return nil, err
+	}
 	return tx.Hash().String(), nil
 }
 
+// findMainChainTx returns the hash and height of the block in the main chain
+// with a height in the passed range which contains the transaction with the
+// passed hash in either of its transaction trees.  A nil hash is returned when
+// none of the blocks contain the transaction.
+func findMainChainTx(s *rpcServer, txHash *chainhash.Hash, startHeight, endHeight int64) (*chainhash.Hash, int64, error) {
+	for height := startHeight; height <= endHeight; height++ {
+		block, err := s.chain.BlockByHeight(height)
+		if err != nil {
+			return nil, 0, err
+		}
+		msgBlock := block.MsgBlock()
+		for _, txns := range [][]*wire.MsgTx{msgBlock.Transactions,
+			msgBlock.STransactions} {
+
+			for _, tx := range txns {
+				if tx.TxHash() == *txHash {
+					return block.Hash(), height, nil
+				}
+			}
+		}
+	}
+	return nil, 0, nil
+}
+
+// handleSendRawTransactionAndWait implements the sendrawtransactionandwait
+// command.
+func handleSendRawTransactionAndWait(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	c := cmd.(*exccjson.SendRawTransactionAndWaitCmd)
+
+	// Subscribe to best block changes before submitting the transaction so
+	// none of the blocks which might include it are missed.
+	bestBlockChanged := s.bestBlockChanged()
+	startHeight := s.chain.BestSnapshot().Height
+	tx, err := sendRawTransaction(s, c.HexTx, *c.AllowHighFees,
+		*c.MaxFeeRate)
+	if err != nil {
+		return nil, err
+	}
+	txHash := tx.Hash()
+	result := &exccjson.SendRawTransactionAndWaitResult{
+		TxID: txHash.String(),
+	}
+	confirmations := int64(*c.Confirmations)
+	if confirmations == 0 {
+		return result, nil
+	}
+
+	timeoutSecs := *c.Timeout
+	if timeoutSecs == 0 || timeoutSecs > maxTxWaitTimeoutSeconds {
+		timeoutSecs = maxTxWaitTimeoutSeconds
+	}
+	timer := time.NewTimer(time.Duration(timeoutSecs) * time.Second)
+	defer timer.Stop()
+
+	// Look for the transaction in the blocks connected since it was
+	// submitted each time the best block changes until it has the requested
+	// number of confirmations.  The blocks are scanned again from the start
+	// when a reorganization replaced any of the blocks scanned already.
+	var blockHash *chainhash.Hash
+	var blockHeight int64
+	scannedHeight := startHeight
+	var scannedHash *chainhash.Hash
+	for {
+		// The transaction must be in the memory pool until it is found
+		// in a block.  This is checked before scanning the blocks since
+		// it is removed from the memory pool after the block which
+		// includes it is connected.
+		inMempool := s.server.txMemPool.HaveTransaction(txHash)
+
+		best := s.chain.BestSnapshot()
+		if blockHash != nil {
+			onMainChain, err := s.chain.MainChainHasBlock(blockHash)
+			if err != nil {
+				context := "Failed to check main chain"
+				return nil, rpcInternalError(err.Error(), context)
+			}
+			if !onMainChain {
+				blockHash = nil
+			}
+		}
+		if scannedHash != nil {
+			hash, err := s.chain.BlockHashByHeight(scannedHeight)
+			if err != nil || *hash != *scannedHash {
+				scannedHeight, scannedHash = startHeight, nil
+			}
+		}
+		if blockHash == nil && best.Height > scannedHeight {
+			blockHash, blockHeight, err = findMainChainTx(s, txHash,
+				scannedHeight+1, best.Height)
+			if err != nil {
+				context := "Failed to scan blocks"
+				return nil, rpcInternalError(err.Error(), context)
+			}
+			scannedHeight = best.Height
+			scannedHash = &best.Hash
+		}
+
+		result.BlockHash, result.BlockHeight = "", 0
+		result.Confirmations = 0
+		if blockHash != nil {
+			result.BlockHash = blockHash.String()
+			result.BlockHeight = blockHeight
+			result.Confirmations = best.Height - blockHeight + 1
+			if result.Confirmations >= confirmations {
+				return result, nil
+			}
+		} else if !inMempool {
+			return nil, rpcMiscError(fmt.Sprintf("Transaction %v "+
+				"was removed from the memory pool without being "+
+				"mined", txHash))
+		}
+
+		select {
+		case <-bestBlockChanged:
+			bestBlockChanged = s.bestBlockChanged()
+		case <-timer.C:
+			return result, nil
+		case <-closeChan:
+			return nil, ErrClientQuit
+		case <-s.quit:
+			return nil, ErrClientQuit
+		}
+	}
+}
+
 // handleSetGenerate implements the setgenerate command.
 func handleSetGenerate(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
 	c := cmd.(*exccjson.SetGenerateCmd)
 
-	// Disable generation regardless of the provided generate flag if the
-	// maximum number of threads (goroutines for our purposes) is 0.
-	// Otherwise enable or disable it depending on the provided flag.
-	generate := c.Generate
-	genProcLimit := -1
-	if c.GenProcLimit != nil {
-		genProcLimit = *c.GenProcLimit
+	// Disable generation regardless of the provided generate flag if the
+	// maximum number of threads (goroutines for our purposes) is 0.
+	// Otherwise enable or disable it depending on the provided flag.
+	generate := c.Generate
+	genProcLimit := -1
+	if c.GenProcLimit != nil {
+		genProcLimit = *c.GenProcLimit
+	}
+	if genProcLimit == 0 {
+		generate = false
+	}
+
+	if !generate {
+		s.server.cpuMiner.Stop()
+	} else {
+		// Check mining address is valid
+		if c.MiningAddr != nil {
+			strAddr := *c.MiningAddr
+			miningAddr, err := exccutil.DecodeAddress(strAddr)
+			if err != nil {
+				return nil, rpcInternalError("Invalid mining address",
+					"Address parsing")
+			}
+			if !miningAddr.IsForNet(activeNetParams.Params) {
+				return nil, rpcInternalError("Mining address is on wrong network",
+					"Address check")
+			}
+			s.server.blockManager.SetMiningAddr(&miningAddr)
+		} else if len(cfg.currentMiningAddrs()) == 0 {
+			return nil, rpcInternalError("No payment addresses specified via --miningaddr",
+				"Configuration")
+		} else {
+			// Reset miningaddr and fallback to startup configuration
+			s.server.blockManager.SetMiningAddr(nil)
+		}
+
+		// It's safe to call start even if it's already started.
+		s.server.cpuMiner.SetNumWorkers(int32(genProcLimit))
+		s.server.cpuMiner.Start()
+	}
+	return nil, nil
+}
+
+// sigHashTypes maps the signature hash types accepted by the
+// signrawtransactionwithkey command to their script values.
+var sigHashTypes = map[string]txscript.SigHashType{
+	"ALL":                 txscript.SigHashAll,
+	"NONE":                txscript.SigHashNone,
+	"SINGLE":              txscript.SigHashSingle,
+	"ALL|ANYONECANPAY":    txscript.SigHashAll | txscript.SigHashAnyOneCanPay,
+	"NONE|ANYONECANPAY":   txscript.SigHashNone | txscript.SigHashAnyOneCanPay,
+	"SINGLE|ANYONECANPAY": txscript.SigHashSingle | txscript.SigHashAnyOneCanPay,
+}
+
+// messageSignatureHash returns the hash that is signed by message signatures
+// of the passed message.  The message is prefixed with the ExchangeCoin message
+// magic so that signatures of messages can never be mistaken for signatures of
+// transactions.
+func messageSignatureHash(message string) []byte {
+	var buf bytes.Buffer
+	wire.WriteVarString(&buf, 0, "ExchangeCoin Signed Message:\n")
+	wire.WriteVarString(&buf, 0, message)
+	return chainhash.HashB(buf.Bytes())
+}
+
+// handleSetMockTime implements the setmocktime command.
+func handleSetMockTime(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	c := cmd.(*exccjson.SetMockTimeCmd)
+
+	// The time can only be mocked on the test networks.
+	clock, ok := s.server.clock.(*blockchain.MockClock)
+	if !ok {
+		return nil, rpcMiscError("setmocktime is only supported on " +
+			"simnet and regnet")
+	}
+	if c.Time < 0 {
+		return nil, rpcInvalidError("Mock time must not be negative: %d",
+			c.Time)
+	}
+
+	// A time of zero goes back to using the local clock.
+	if c.Time == 0 {
+		clock.SetTime(time.Time{})
+		rpcsLog.Infof("Stopped mocking the time")
+		return nil, nil
+	}
+	mockTime := time.Unix(c.Time, 0)
+	clock.SetTime(mockTime)
+	rpcsLog.Infof("Mock time set to %v", mockTime)
+	return nil, nil
+}
+
+// handleSignMessageWithPrivKey implements the signmessagewithprivkey command.
+func handleSignMessageWithPrivKey(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	c := cmd.(*exccjson.SignMessageWithPrivKeyCmd)
+
+	// The private key is intentionally not included in any errors.
+	wif, err := exccutil.DecodeWIF(c.PrivKey)
+	if err != nil {
+		return nil, rpcInvalidError("Invalid private key: %v", err)
+	}
+	if !wif.IsForNet(s.server.chainParams) {
+		return nil, rpcInvalidError("Private key is not for the active " +
+			"network")
+	}
+
+	// Only secp256k1 keys produce signatures the public key can be
+	// recovered from, which is required by verifymessage.
+	if wif.DSA() != chainec.ECTypeSecp256k1 {
+		return nil, rpcInvalidError("Only secp256k1 private keys may be " +
+			"used to sign messages")
+	}
+
+	privKey, _ := secp256k1.PrivKeyFromBytes(wif.PrivKey.Serialize())
+	sig, err := secp256k1.SignCompact(privKey,
+		messageSignatureHash(c.Message), wif.CompressPubKey)
+	if err != nil {
+		return nil, rpcInternalError(err.Error(), "Failed to sign message")
+	}
+
+	return base64.StdEncoding.EncodeToString(sig), nil
+}
+
+// handleSignRawTransactionWithKey implements the signrawtransactionwithkey
+// command.  The provided private keys are only used to sign the transaction
+// and are never stored or logged.
+func handleSignRawTransactionWithKey(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	c := cmd.(*exccjson.SignRawTransactionWithKeyCmd)
+
+	// Deserialize the transaction.
+	hexStr := c.RawTx
+	if len(hexStr)%2 != 0 {
+		hexStr = "0" + hexStr
+	}
+	serializedTx, err := hex.DecodeString(hexStr)
+	if err != nil {
+		return nil, rpcDecodeHexError(hexStr)
+	}
+	var mtx wire.MsgTx
+	err = mtx.Deserialize(bytes.NewReader(serializedTx))
+	if err != nil {
+		return nil, rpcDeserializationError("Could not decode Tx: %v",
+			err)
+	}
+
+	hashType, ok := sigHashTypes[*c.SigHashType]
+	if !ok {
+		return nil, rpcInvalidError("Invalid signature hash type %q",
+			*c.SigHashType)
+	}
+
+	// Decode the private keys and index them by the pay-to-pubkey-hash
+	// address of their public key, which is the encoding of the public key
+	// addresses that signing looks keys up with.  The keys themselves are
+	// intentionally not included in any errors.
+	params := s.server.chainParams
+	keys := make(map[string]*exccutil.WIF, len(c.PrivKeys))
+	for i, key := range c.PrivKeys {
+		wif, err := exccutil.DecodeWIF(key)
+		if err != nil {
+			return nil, rpcInvalidError("Invalid private key %d: %v",
+				i, err)
+		}
+		if !wif.IsForNet(params) {
+			return nil, rpcInvalidError("Private key %d is not for "+
+				"the active network", i)
+		}
+
+		var addr exccutil.Address
+		serializedPubKey := wif.SerializePubKey()
+		switch wif.DSA() {
+		case chainec.ECTypeSecp256k1:
+			addr, err = exccutil.NewAddressSecpPubKey(serializedPubKey,
+				params)
+		case chainec.ECTypeEdwards:
+			addr, err = exccutil.NewAddressEdwardsPubKey(
+				serializedPubKey, params)
+		case chainec.ECTypeSecSchnorr:
+			addr, err = exccutil.NewAddressSecSchnorrPubKey(
+				serializedPubKey, params)
+		default:
+			err = fmt.Errorf("unsupported signature type %d",
+				wif.DSA())
+		}
+		if err != nil {
+			return nil, rpcInvalidError("Invalid private key %d: %v",
+				i, err)
+		}
+		keys[addr.EncodeAddress()] = wif
+	}
+	getKey := txscript.KeyClosure(func(addr exccutil.Address) (chainec.PrivateKey, bool, error) {
+		wif, ok := keys[addr.EncodeAddress()]
+		if !ok {
+			return nil, false, fmt.Errorf("no private key provided "+
+				"for address %s", addr.EncodeAddress())
+		}
+		return wif.PrivKey, wif.CompressPubKey, nil
+	})
+
+	// Decode the provided previous outputs along with the redeem scripts
+	// of the pay-to-script-hash outputs.
+	prevScripts := make(map[wire.OutPoint][]byte)
+	redeemScripts := make(map[string][]byte)
+	if c.Inputs != nil {
+		for _, input := range *c.Inputs {
+			txHash, err := chainhash.NewHashFromStr(input.Txid)
+			if err != nil {
+				return nil, rpcDecodeHexError(input.Txid)
+			}
+			pkScript, err := hex.DecodeString(input.ScriptPubKey)
+			if err != nil {
+				return nil, rpcDecodeHexError(input.ScriptPubKey)
+			}
+			outPoint := wire.OutPoint{Hash: *txHash, Index: input.Vout,
+				Tree: input.Tree}
+			prevScripts[outPoint] = pkScript
+
+			if input.RedeemScript == "" {
+				continue
+			}
+			redeemScript, err := hex.DecodeString(input.RedeemScript)
+			if err != nil {
+				return nil, rpcDecodeHexError(input.RedeemScript)
+			}
+			addr, err := exccutil.NewAddressScriptHash(redeemScript,
+				params)
+			if err != nil {
+				return nil, rpcInvalidError("Invalid redeem "+
+					"script: %v", err)
+			}
+			redeemScripts[addr.EncodeAddress()] = redeemScript
+		}
 	}
-	if genProcLimit == 0 {
-		generate = false
+	getScript := txscript.ScriptClosure(func(addr exccutil.Address) ([]byte, error) {
+		script, ok := redeemScripts[addr.EncodeAddress()]
+		if !ok {
+			return nil, fmt.Errorf("no redeem script provided for "+
+				"address %s", addr.EncodeAddress())
+		}
+		return script, nil
+	})
+
+	flags, err := standardScriptVerifyFlags(s.chain)
+	if err != nil {
+		return nil, rpcInternalError(err.Error(),
+			"Could not obtain script flags")
 	}
 
-	if !generate {
-		s.server.cpuMiner.Stop()
-	} else {
-		// Check mining address is valid
-		if c.MiningAddr != nil {
-			strAddr := *c.MiningAddr
-			miningAddr, err := exccutil.DecodeAddress(strAddr)
+	// Sign every input for which the public key script of the output it
+	// spends is known, merging the new signatures with any that are
+	// already present, and then verify the result.  Failures are reported
+	// per input rather than failing the entire request so partially signed
+	// transactions can be passed along to other signers.
+	var signErrors []exccjson.SignRawTransactionError
+	for i, txIn := range mtx.TxIn {
+		addSignError := func(err error) {
+			signErrors = append(signErrors,
+				exccjson.SignRawTransactionError{
+					TxID:      txIn.PreviousOutPoint.Hash.String(),
+					Vout:      txIn.PreviousOutPoint.Index,
+					ScriptSig: hex.EncodeToString(txIn.SignatureScript),
+					Sequence:  txIn.Sequence,
+					Error:     err.Error(),
+				})
+		}
+
+		prevOut := &txIn.PreviousOutPoint
+		pkScript, ok := prevScripts[*prevOut]
+		scriptVersion := txscript.DefaultScriptVersion
+		if !ok {
+			pkScript, scriptVersion, err = fetchPrevOutScript(s, prevOut)
 			if err != nil {
-				return nil, rpcInternalError("Invalid mining address",
-					"Address parsing")
+				return nil, rpcInternalError(err.Error(),
+					"Could not fetch output")
 			}
-			if !miningAddr.IsForNet(activeNetParams.Params) {
-				return nil, rpcInternalError("Mining address is on wrong network",
-					"Address check")
+			if pkScript == nil {
+				addSignError(fmt.Errorf("output %v is unknown or "+
+					"spent", prevOut))
+				continue
 			}
-			s.server.blockManager.SetMiningAddr(&miningAddr)
-		} else if len(cfg.miningAddrs) == 0 {
-			return nil, rpcInternalError("No payment addresses specified via --miningaddr",
-				"Configuration")
-		} else {
-			// Reset miningaddr and fallback to startup configuration
-			s.server.blockManager.SetMiningAddr(nil)
+		}
+		if scriptVersion != txscript.DefaultScriptVersion {
+			addSignError(fmt.Errorf("unsupported script version %d",
+				scriptVersion))
+			continue
 		}
 
-		// It's safe to call start even if it's already started.
-		s.server.cpuMiner.SetNumWorkers(int32(genProcLimit))
-		s.server.cpuMiner.Start()
+		// Signatures for alternative signature scripts must be of the
+		// type committed to by the script.
+		sigType := chainec.ECTypeSecp256k1
+		if altSigType, err := txscript.ExtractPkScriptAltSigType(
+			pkScript); err == nil {
+
+			sigType = altSigType
+		}
+
+		sigScript, err := txscript.SignTxOutput(params, &mtx, i,
+			pkScript, hashType, getKey, getScript,
+			txIn.SignatureScript, sigType)
+		if err != nil {
+			addSignError(err)
+			continue
+		}
+		txIn.SignatureScript = sigScript
+
+		vm, err := txscript.NewEngine(pkScript, &mtx, i, flags,
+			scriptVersion, nil)
+		if err == nil {
+			err = vm.Execute()
+		}
+		if err != nil {
+			addSignError(err)
+		}
 	}
-	return nil, nil
+
+	var buf bytes.Buffer
+	buf.Grow(mtx.SerializeSize())
+	if err := mtx.Serialize(&buf); err != nil {
+		return nil, rpcInternalError(err.Error(),
+			"Could not serialize transaction")
+	}
+	return &exccjson.SignRawTransactionResult{
+		Hex:      hex.EncodeToString(buf.Bytes()),
+		Complete: len(signErrors) == 0,
+		Errors:   signErrors,
+	}, nil
 }
 
 // handleStop implements the stop command.
@@ -5154,12 +7699,48 @@ func handleSubmitBlock(s *rpcServer, cmd interface{}, closeChan <-chan struct{})
 		return nil, rpcInternalError(err.Error(), "Block decode")
 	}
 
-	_, err = s.server.blockManager.ProcessBlock(block, blockchain.BFNone)
+	// Check the block only makes the allowed mutations to the block template
+	// returned by getblocktemplate when its work ID is provided.  Templates
+	// are only kept for a limited time and the block may be valid anyway,
+	// so the result is only logged and recorded in the mining archive, and
+	// only the consensus rules decide whether the block is accepted.
+	var id workID
+	var mutationReason string
+	if c.Options != nil && c.Options.WorkID != "" {
+		var ok bool
+		id, ok = parseGbtWorkID(c.Options.WorkID)
+		mutationReason = "unknown-work"
+		if ok {
+			bestHash, _ := s.server.blockManager.chainState.Best()
+			mutationReason = s.gbtWorkState.checkSubmission(id,
+				block.MsgBlock(), bestHash)
+		}
+		if mutationReason != "" {
+			rpcsLog.Warnf("Block %s submitted via submitblock for "+
+				"work %s fails template checks: %s", block.Hash(),
+				c.Options.WorkID, mutationReason)
+		}
+	}
+
+	isOrphan, err := s.server.blockManager.ProcessBlock(block,
+		blockchain.BFNone)
 	if err != nil {
+		s.server.notifyMinedBlock(block, "submitblock", err.Error())
+		s.server.minedBlocks.Add(block.Hash(), miningOutcomeRejected)
+		s.server.archiveSolution(block.MsgBlock(), id,
+			miningSourceSubmitBlock, miningOutcomeRejected, err.Error())
 		return fmt.Sprintf("rejected: %v", err), nil
 	}
+	outcome := miningOutcomeAccepted
+	if isOrphan {
+		outcome = miningOutcomeOrphan
+	}
+	s.server.minedBlocks.Add(block.Hash(), outcome)
+	s.server.archiveSolution(block.MsgBlock(), id, miningSourceSubmitBlock,
+		outcome, mutationReason)
 
 	rpcsLog.Infof("Accepted block %s via submitblock", block.Hash())
+	s.server.notifyMinedBlock(block, "submitblock", "")
 	return nil, nil
 }
 
@@ -5398,8 +7979,14 @@ func handleTicketFeeInfo(s *rpcServer, cmd interface{}, closeChan <-chan struct{
 		blocks = *c.Blocks
 	}
 	if blocks > 0 {
+		// Go down to the last height requested, except in the case
+		// that the user has specified too many blocks.  In that case,
+		// just proceed to the first block.
 		start := bestHeight
-		end := bestHeight - int64(blocks)
+		end := int64(-1)
+		if bestHeight-int64(blocks) > end {
+			end = bestHeight - int64(blocks)
+		}
 
 		for i := start; i > end; i-- {
 			feeInfo, err := ticketFeeInfoForBlock(s, i, stake.TxTypeSStx)
@@ -5637,19 +8224,88 @@ func handleTxFeeInfo(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (
 	}, nil
 }
 
-// handleValidateAddress implements the validateaddress command.
-func handleValidateAddress(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
-	c := cmd.(*exccjson.ValidateAddressCmd)
+// validateAddress returns the validateaddress result for the passed encoded
+// address.  Details about the script are included when the address is a
+// pay-to-script-hash address and the optional redeem script hashes to it.
+func validateAddress(params *chaincfg.Params, encodedAddr string, redeemScript []byte) exccjson.ValidateAddressChainResult {
 	result := exccjson.ValidateAddressChainResult{}
-	addr, err := exccutil.DecodeAddress(c.Address)
-	if err != nil || !addr.IsForNet(s.server.chainParams) {
+	addr, err := exccutil.DecodeAddress(encodedAddr)
+	if err != nil || !addr.IsForNet(params) {
 		// Return the default value (false) for IsValid.
-		return result, nil
+		return result
 	}
 
 	result.Address = addr.EncodeAddress()
 	result.IsValid = true
-	return result, nil
+	result.Network = params.Name
+
+	// Report the type of script the address pays to.  Addresses which pay
+	// to a public key or its hash require a single signature while the
+	// number of signatures required by a script is only known from its
+	// redeem script.
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err == nil {
+		class := txscript.GetScriptClass(txscript.DefaultScriptVersion,
+			pkScript)
+		result.ScriptType = class.String()
+		result.IsScript = class == txscript.ScriptHashTy
+		if !result.IsScript {
+			result.SigsRequired = 1
+		}
+	}
+
+	// Only public key addresses reveal whether the key is compressed.
+	// Ed25519 and secp256k1 Schnorr public keys are always compressed.
+	switch addr := addr.(type) {
+	case *exccutil.AddressSecpPubKey:
+		result.IsCompressed = addr.Format() == exccutil.PKFCompressed
+	case *exccutil.AddressEdwardsPubKey, *exccutil.AddressSecSchnorrPubKey:
+		result.IsCompressed = true
+	}
+
+	if result.IsScript && redeemScript != nil &&
+		bytes.Equal(exccutil.Hash160(redeemScript), addr.ScriptAddress()) {
+
+		class, addrs, reqSigs, _ := txscript.ExtractPkScriptAddrs(
+			txscript.DefaultScriptVersion, redeemScript, params)
+		result.Script = class.String()
+		result.SigsRequired = int32(reqSigs)
+		for _, a := range addrs {
+			result.Addresses = append(result.Addresses,
+				a.EncodeAddress())
+		}
+	}
+
+	return result
+}
+
+// handleValidateAddress implements the validateaddress command.
+func handleValidateAddress(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	c := cmd.(*exccjson.ValidateAddressCmd)
+
+	var redeemScript []byte
+	if c.RedeemScript != nil {
+		var err error
+		redeemScript, err = hex.DecodeString(*c.RedeemScript)
+		if err != nil {
+			return nil, rpcDecodeHexError(*c.RedeemScript)
+		}
+	}
+
+	return validateAddress(s.server.chainParams, c.Address, redeemScript), nil
+}
+
+// handleValidateAddresses implements the validateaddresses command.
+func handleValidateAddresses(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	c := cmd.(*exccjson.ValidateAddressesCmd)
+
+	results := make([]exccjson.ValidateAddressChainResult, 0,
+		len(c.Addresses))
+	for _, addr := range c.Addresses {
+		results = append(results, validateAddress(s.server.chainParams,
+			addr, nil))
+	}
+	return results, nil
 }
 
 func verifyChain(s *rpcServer, level, depth int64) error {
@@ -5708,12 +8364,24 @@ func handleVerifyChain(s *rpcServer, cmd interface{}, closeChan <-chan struct{})
 func handleVerifyMessage(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
 	c := cmd.(*exccjson.VerifyMessageCmd)
 
-	// Decode the provided address.
+	// Decode the provided address.  Bech32 encoded addresses commit to the
+	// same public key hash as their base58 counterparts, so they are
+	// converted in order to verify signatures against either encoding.
 	addr, err := exccutil.DecodeAddress(c.Address)
 	if err != nil {
 		return nil, rpcAddressKeyError("Could not decode address: %v",
 			err)
 	}
+	if bech32Addr, ok := addr.(*exccutil.AddressBech32); ok {
+		addr = bech32Addr.Base58()
+	}
+
+	// A signature can not be valid for an address of another network, so
+	// the message fails to verify the same way it does for any other
+	// address which did not sign it.
+	if !addr.IsForNet(s.server.chainParams) {
+		return false, nil
+	}
 
 	// Only P2PKH addresses are valid for signing.
 	if _, ok := addr.(*exccutil.AddressPubKeyHash); !ok {
@@ -5734,12 +8402,8 @@ func handleVerifyMessage(s *rpcServer, cmd interface{}, closeChan <-chan struct{
 
 	// Validate the signature - this just shows that it was valid at all.
 	// we will compare it with the key next.
-	var buf bytes.Buffer
-	wire.WriteVarString(&buf, 0, "ExchangeCoin Signed Message:\n")
-	wire.WriteVarString(&buf, 0, c.Message)
-	expectedMessageHash := chainhash.HashB(buf.Bytes())
 	pk, wasCompressed, err := chainec.Secp256k1.RecoverCompact(sig,
-		expectedMessageHash)
+		messageSignatureHash(c.Message))
 	if err != nil {
 		// Mirror Bitcoin Core behavior, which treats error in
 		// RecoverCompact as invalid signature.
@@ -5755,7 +8419,7 @@ func handleVerifyMessage(s *rpcServer, cmd interface{}, closeChan <-chan struct{
 		serializedPK = exccPK.SerializeUncompressed()
 	}
 	address, err := exccutil.NewAddressSecpPubKey(serializedPK,
-		activeNetParams.Params)
+		s.server.chainParams)
 	if err != nil {
 		// Again mirror Bitcoin Core behavior, which treats error in
 		// public key reconstruction as invalid signature.
@@ -5763,7 +8427,50 @@ func handleVerifyMessage(s *rpcServer, cmd interface{}, closeChan <-chan struct{
 	}
 
 	// Return boolean if addresses match.
-	return address.EncodeAddress() == c.Address, nil
+	return address.EncodeAddress() == addr.EncodeAddress(), nil
+}
+
+// enabledFeatures returns the names of the optional features which are enabled
+// in the configuration of the node, so what a node is running can be
+// identified when diagnosing problems.
+func enabledFeatures(s *rpcServer) []string {
+	features := make([]string, 0, 9)
+	if s.server.txIndex != nil {
+		features = append(features, "txindex")
+	}
+	if s.server.addrIndex != nil {
+		features = append(features, "addrindex")
+	}
+	if s.server.existsAddrIndex != nil {
+		features = append(features, "existsaddrindex")
+	}
+	if s.server.cfIndex != nil {
+		features = append(features, "cfindex")
+	}
+	if s.server.timeIndex != nil {
+		features = append(features, "timeindex")
+	}
+	if s.server.ticketIndex != nil {
+		features = append(features, "ticketindex")
+	}
+	if !cfg.NoPeerBloomFilters && !cfg.MiningOnly {
+		features = append(features, "bloomfilters")
+	}
+	if cfg.BlocksOnly {
+		features = append(features, "blocksonly")
+	}
+	if cfg.MiningOnly {
+		features = append(features, "miningonly")
+	}
+	if cfg.Generate {
+		features = append(features, "generate")
+	}
+	return features
+}
+
+// handleUptime implements the uptime command.
+func handleUptime(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	return int64(time.Since(s.server.startupTime) / time.Second), nil
 }
 
 // handleVersion implements the version command.
@@ -5787,6 +8494,9 @@ func handleVersion(s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (in
 			Patch:         uint32(appPatch),
 			Prerelease:    normalizePreRelString(appPreRelease),
 			BuildMetadata: buildMeta,
+			Commit:        appCommit,
+			GoVersion:     runtime.Version(),
+			Features:      enabledFeatures(s),
 		},
 	}
 	return result, nil
@@ -5802,6 +8512,8 @@ type rpcServer struct {
 	chain                  *blockchain.BlockChain
 	authsha                [sha256.Size]byte
 	limitauthsha           [sha256.Size]byte
+	cookieauthsha          [sha256.Size]byte
+	cookieFile             string
 	ntfnMgr                *wsNotificationManager
 	numClients             int32
 	statusLines            map[int]string
@@ -5814,6 +8526,24 @@ type rpcServer struct {
 	helpCacher             *helpCacher
 	requestProcessShutdown chan struct{}
 	quit                   chan int
+
+	// publicMethods houses the methods clients of the HTTP POST endpoint
+	// may call without authenticating and corsOrigins houses the origins
+	// of the browser clients which may make cross-origin requests to it.
+	// They are set during initial creation of the server and never changed
+	// afterwards.
+	publicMethods map[string]struct{}
+	corsOrigins   map[string]struct{}
+
+	// tlsCert is the certificate presented to clients, which is replaced
+	// when it is regenerated.
+	tlsCertMtx sync.RWMutex
+	tlsCert    *tls.Certificate
+
+	// bestBlockChan is closed and replaced each time the best block
+	// changes to wake up the handlers waiting for it.
+	bestBlockMtx  sync.Mutex
+	bestBlockChan chan struct{}
 }
 
 // httpStatusLine returns a response Status-Line (RFC 2616 Section 6.1) for the
@@ -5890,10 +8620,41 @@ func (s *rpcServer) Stop() error {
 	s.ntfnMgr.WaitForShutdown()
 	close(s.quit)
 	s.wg.Wait()
+
+	// The auth cookie is only valid for as long as the server runs.
+	if s.cookieFile != "" {
+		if err := os.Remove(s.cookieFile); err != nil {
+			rpcsLog.Errorf("Unable to remove the RPC auth cookie: %v",
+				err)
+		}
+	}
 	rpcsLog.Infof("RPC server shutdown complete")
 	return nil
 }
 
+// NotifyBestBlockChanged wakes up the RPC handlers waiting for the best block
+// to change.  It must be called after a block is connected to or disconnected
+// from the main chain.
+//
+// This function is safe for concurrent access.
+func (s *rpcServer) NotifyBestBlockChanged() {
+	s.bestBlockMtx.Lock()
+	close(s.bestBlockChan)
+	s.bestBlockChan = make(chan struct{})
+	s.bestBlockMtx.Unlock()
+}
+
+// bestBlockChanged returns a channel which is closed the next time the best
+// block changes.
+//
+// This function is safe for concurrent access.
+func (s *rpcServer) bestBlockChanged() <-chan struct{} {
+	s.bestBlockMtx.Lock()
+	c := s.bestBlockChan
+	s.bestBlockMtx.Unlock()
+	return c
+}
+
 // RequestedProcessShutdown returns a channel that is sent to when an
 // authorized RPC client requests the process to shutdown.  If the request can
 // not be read immediately, it is dropped.
@@ -5937,7 +8698,9 @@ func (s *rpcServer) decrementClients() {
 
 // checkAuth checks the HTTP Basic authentication supplied by a wallet or RPC
 // client in the HTTP request r.  If the supplied authentication does not match
-// the username and password expected, a non-nil error is returned.
+// the username and password expected or the auth cookie, a non-nil error is
+// returned.  When client certificate authentication is used instead, it checks
+// the client presented a certificate signed by one of the client CAs.
 //
 // This check is time-constant.
 //
@@ -5946,6 +8709,17 @@ func (s *rpcServer) decrementClients() {
 // of the server (true) or whether the user is limited (false). The second is
 // always false if the first is.
 func (s *rpcServer) checkAuth(r *http.Request, require bool) (bool, bool, error) {
+	// The certificate was already verified during the TLS handshake, which
+	// fails for clients without one.
+	if cfg.RPCAuthType == rpcAuthTypeClientCert {
+		if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 {
+			rpcsLog.Warnf("RPC client certificate authentication "+
+				"failure from %s", r.RemoteAddr)
+			return false, false, errors.New("auth failure")
+		}
+		return true, true, nil
+	}
+
 	authhdr := r.Header["Authorization"]
 	if len(authhdr) <= 0 {
 		if require {
@@ -5972,6 +8746,12 @@ func (s *rpcServer) checkAuth(r *http.Request, require bool) (bool, bool, error)
 		return true, true, nil
 	}
 
+	// Check for the auth cookie, which also grants admin-level access.
+	cookiecmp := subtle.ConstantTimeCompare(authsha[:], s.cookieauthsha[:])
+	if cookiecmp == 1 {
+		return true, true, nil
+	}
+
 	// Request's auth doesn't match either user
 	rpcsLog.Warnf("RPC authentication failure from %s", r.RemoteAddr)
 	return false, false, errors.New("auth failure")
@@ -6009,7 +8789,16 @@ func (s *rpcServer) standardCmdResult(cmd *parsedRPCCmd, closeChan <-chan struct
 	}
 	return nil, exccjson.ErrRPCMethodNotFound
 handled:
-	return handler(s, cmd.cmd, closeChan)
+	// Record the handling of the command as a span when tracing is
+	// enabled.
+	span := tracing.Start("rpc." + cmd.method)
+	span.SetKind(tracing.KindServer)
+	span.SetAttribute("rpc.system", "jsonrpc")
+	span.SetAttribute("rpc.method", cmd.method)
+	result, err := handler(s, cmd.cmd, closeChan)
+	span.SetError(err)
+	span.End()
+	return result, err
 }
 
 // parseCmd parses a JSON-RPC request object into known concrete command.  The
@@ -6060,12 +8849,19 @@ func createMarshalledReply(rpcVersion string, id interface{}, result interface{}
 }
 
 // processRequest determines the incoming request type (single or batched),
-// parses it and returns a marshalled response.
-func (s *rpcServer) processRequest(request *exccjson.Request, isAdmin bool, closeChan <-chan struct{}) []byte {
+// parses it and returns a marshalled response.  Clients which did not
+// authenticate may only call the public methods.
+func (s *rpcServer) processRequest(request *exccjson.Request, authenticated, isAdmin bool, closeChan <-chan struct{}) []byte {
 	var result interface{}
 	var jsonErr error
 
-	if !isAdmin {
+	switch {
+	case !authenticated:
+		if _, ok := s.publicMethods[request.Method]; !ok {
+			jsonErr = rpcInvalidError("authentication required " +
+				"for this method")
+		}
+	case !isAdmin:
 		if _, ok := rpcLimited[request.Method]; !ok {
 			jsonErr = rpcInvalidError("limited user not " +
 				"authorized for this method")
@@ -6113,7 +8909,7 @@ func (s *rpcServer) processRequest(request *exccjson.Request, isAdmin bool, clos
 }
 
 // jsonRPCRead handles reading and responding to RPC messages.
-func (s *rpcServer) jsonRPCRead(w http.ResponseWriter, r *http.Request, isAdmin bool) {
+func (s *rpcServer) jsonRPCRead(w http.ResponseWriter, r *http.Request, authenticated, isAdmin bool) {
 	if atomic.LoadInt32(&s.shutdown) != 0 {
 		return
 	}
@@ -6194,7 +8990,8 @@ func (s *rpcServer) jsonRPCRead(w http.ResponseWriter, r *http.Request, isAdmin
 		}
 
 		if err == nil {
-			resp = s.processRequest(&req, isAdmin, closeChan)
+			resp = s.processRequest(&req, authenticated, isAdmin,
+				closeChan)
 		}
 
 		if resp != nil {
@@ -6283,7 +9080,8 @@ func (s *rpcServer) jsonRPCRead(w http.ResponseWriter, r *http.Request, isAdmin
 						continue
 					}
 
-					resp = s.processRequest(&req, isAdmin, closeChan)
+					resp = s.processRequest(&req, authenticated,
+						isAdmin, closeChan)
 					if resp != nil {
 						results = append(results, resp)
 					}
@@ -6329,6 +9127,38 @@ func (s *rpcServer) jsonRPCRead(w http.ResponseWriter, r *http.Request, isAdmin
 	}
 }
 
+// setCORSHeaders sets the headers which allow browser clients served from the
+// origin of the passed request to make cross-origin requests and read the
+// responses when the origin is one of the allowed origins.  Credentials may
+// only be sent from the explicitly allowed origins.  It returns whether the
+// origin is allowed.
+func (s *rpcServer) setCORSHeaders(headers http.Header, r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" || len(s.corsOrigins) == 0 {
+		return false
+	}
+	_, allowed := s.corsOrigins[strings.ToLower(origin)]
+	_, allowAny := s.corsOrigins["*"]
+	if !allowed && !allowAny {
+		return false
+	}
+
+	headers.Add("Vary", "Origin")
+	if allowed {
+		headers.Set("Access-Control-Allow-Origin", origin)
+		headers.Set("Access-Control-Allow-Credentials", "true")
+	} else {
+		headers.Set("Access-Control-Allow-Origin", "*")
+	}
+	if r.Method == http.MethodOptions {
+		headers.Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+		headers.Set("Access-Control-Allow-Headers",
+			"Authorization, Content-Type")
+		headers.Set("Access-Control-Max-Age", "600")
+	}
+	return true
+}
+
 // jsonAuthFail sends a message back to the client if the http auth is rejected.
 func jsonAuthFail(w http.ResponseWriter) {
 	w.Header().Add("WWW-Authenticate", `Basic realm="exccd RPC"`)
@@ -6351,6 +9181,8 @@ func (s *rpcServer) Start() {
 		ReadTimeout: time.Second * rpcAuthTimeoutSeconds,
 	}
 	rpcServeMux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		defer handleCrash()
+
 		w.Header().Set("Connection", "close")
 		w.Header().Set("Content-Type", "application/json")
 		r.Close = true
@@ -6363,18 +9195,34 @@ func (s *rpcServer) Start() {
 		// Keep track of the number of connected clients.
 		s.incrementClients()
 		defer s.decrementClients()
-		_, isAdmin, err := s.checkAuth(r, true)
+
+		// Answer the preflight requests browsers make before
+		// cross-origin requests without authenticating them since they
+		// never carry credentials.
+		if s.setCORSHeaders(w.Header(), r) &&
+			r.Method == http.MethodOptions {
+
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		// Authentication is only optional when there are methods which
+		// may be called without it.
+		requireAuth := len(s.publicMethods) == 0
+		authenticated, isAdmin, err := s.checkAuth(r, requireAuth)
 		if err != nil {
 			jsonAuthFail(w)
 			return
 		}
 
 		// Read and respond to the request.
-		s.jsonRPCRead(w, r, isAdmin)
+		s.jsonRPCRead(w, r, authenticated, isAdmin)
 	})
 
 	// Websocket endpoint.
 	rpcServeMux.HandleFunc("/ws", func(w http.ResponseWriter, r *http.Request) {
+		defer handleCrash()
+
 		authenticated, isAdmin, err := s.checkAuth(r, false)
 		if err != nil {
 			jsonAuthFail(w)
@@ -6410,31 +9258,207 @@ func (s *rpcServer) Start() {
 	s.ntfnMgr.Start()
 }
 
+const (
+	// rpcAuthTypeBasic and rpcAuthTypeClientCert are the methods RPC
+	// clients may be authenticated with.  Basic authentication uses the RPC
+	// usernames and passwords, while client certificate authentication
+	// grants full access to clients with a certificate signed by one of the
+	// configured client CAs.
+	rpcAuthTypeBasic      = "basic"
+	rpcAuthTypeClientCert = "clientcert"
+
+	// rpcCookieUser is the username of the credentials in the auth cookie
+	// file.
+	rpcCookieUser = "__cookie__"
+
+	// defaultRPCCertKeyType is the default type of the key of generated RPC
+	// server certificates.
+	defaultRPCCertKeyType = "P-521"
+
+	// defaultRPCCertValidity is the default duration generated RPC server
+	// certificates are valid for.
+	defaultRPCCertValidity = 10 * 365 * 24 * time.Hour
+)
+
+// certPairFunc generates a new PEM-encoded key/cert pair for the passed
+// organization which is valid until the passed time for the passed hosts in
+// addition to the local ones.
+type certPairFunc func(org string, validUntil time.Time, extraHosts []string) (cert, key []byte, err error)
+
+// ecdsaCertPairFunc returns a function which generates certificate pairs with
+// ECDSA keys on the passed curve.
+func ecdsaCertPairFunc(curve elliptic.Curve) certPairFunc {
+	return func(org string, validUntil time.Time, extraHosts []string) ([]byte, []byte, error) {
+		return certgen.NewTLSCertPair(curve, org, validUntil, extraHosts)
+	}
+}
+
+// rsaCertPairFunc returns a function which generates certificate pairs with RSA
+// keys of the passed size in bits.
+func rsaCertPairFunc(bits int) certPairFunc {
+	return func(org string, validUntil time.Time, extraHosts []string) ([]byte, []byte, error) {
+		return certgen.NewRSATLSCertPair(bits, org, validUntil, extraHosts)
+	}
+}
+
+// rpcCertKeyTypes maps the supported types of the keys of generated RPC server
+// certificates to the functions which generate a certificate pair with them.
+var rpcCertKeyTypes = map[string]certPairFunc{
+	"P-256":    ecdsaCertPairFunc(elliptic.P256()),
+	"P-384":    ecdsaCertPairFunc(elliptic.P384()),
+	"P-521":    ecdsaCertPairFunc(elliptic.P521()),
+	"RSA-2048": rsaCertPairFunc(2048),
+	"RSA-3072": rsaCertPairFunc(3072),
+	"RSA-4096": rsaCertPairFunc(4096),
+}
+
+// supportedRPCCertKeyTypes returns the sorted types of the keys of generated
+// RPC server certificates which are supported.
+func supportedRPCCertKeyTypes() []string {
+	keyTypes := make([]string, 0, len(rpcCertKeyTypes))
+	for keyType := range rpcCertKeyTypes {
+		keyTypes = append(keyTypes, keyType)
+	}
+	sort.Strings(keyTypes)
+	return keyTypes
+}
+
+// newRPCCertPair generates a new PEM-encoded key/cert pair for the RPC server
+// according to the current options for generated certificates.
+func newRPCCertPair() (cert, key []byte, err error) {
+	keyType, validity, hosts := cfg.currentRPCCertOptions()
+	genFunc, ok := rpcCertKeyTypes[keyType]
+	if !ok {
+		return nil, nil, fmt.Errorf("unsupported certificate key type %q",
+			keyType)
+	}
+
+	org := "exccd autogenerated cert"
+	validUntil := time.Now().Add(validity)
+	return genFunc(org, validUntil, hosts)
+}
+
+// writeCertPair writes the passed PEM-encoded key/cert pair to the paths
+// provided.  Both files are written next to their paths first and only moved
+// into place once both were written, so a failed write never leaves a key
+// which does not match the cert on disk.  The previous key is restored in the
+// unlikely event the cert can not be moved into place after the key.
+func writeCertPair(certFile, keyFile string, cert, key []byte) error {
+	tmpKeyFile := keyFile + ".new"
+	tmpCertFile := certFile + ".new"
+	if err := ioutil.WriteFile(tmpKeyFile, key, 0600); err != nil {
+		os.Remove(tmpKeyFile)
+		return err
+	}
+	if err := ioutil.WriteFile(tmpCertFile, cert, 0666); err != nil {
+		os.Remove(tmpKeyFile)
+		os.Remove(tmpCertFile)
+		return err
+	}
+
+	oldKey, err := ioutil.ReadFile(keyFile)
+	if err != nil && !os.IsNotExist(err) {
+		os.Remove(tmpKeyFile)
+		os.Remove(tmpCertFile)
+		return err
+	}
+	if err := os.Rename(tmpKeyFile, keyFile); err != nil {
+		os.Remove(tmpKeyFile)
+		os.Remove(tmpCertFile)
+		return err
+	}
+	if err := os.Rename(tmpCertFile, certFile); err != nil {
+		os.Remove(tmpCertFile)
+		if oldKey != nil {
+			ioutil.WriteFile(keyFile, oldKey, 0600)
+		} else {
+			os.Remove(keyFile)
+		}
+		return err
+	}
+	return nil
+}
+
 // genCertPair generates a key/cert pair to the paths provided.
 func genCertPair(certFile, keyFile string) error {
 	rpcsLog.Infof("Generating TLS certificates...")
 
-	org := "exccd autogenerated cert"
-	validUntil := time.Now().Add(10 * 365 * 24 * time.Hour)
-	cert, key, err := certgen.NewTLSCertPair(elliptic.P521(), org,
-		validUntil, nil)
+	cert, key, err := newRPCCertPair()
 	if err != nil {
 		return err
 	}
+	if err := writeCertPair(certFile, keyFile, cert, key); err != nil {
+		return err
+	}
+
+	rpcsLog.Infof("Done generating TLS certificates")
+	return nil
+}
+
+// newRPCCookie returns new random credentials for cookie-based authentication
+// in the user:password form they are written to the cookie file in.
+func newRPCCookie() (string, error) {
+	var pass [32]byte
+	if _, err := crand.Read(pass[:]); err != nil {
+		return "", err
+	}
+	return rpcCookieUser + ":" + hex.EncodeToString(pass[:]), nil
+}
 
-	// Write cert and key files.
-	if err = ioutil.WriteFile(certFile, cert, 0666); err != nil {
+// writeRPCCookie writes the passed credentials to the cookie file at the passed
+// path, which is only readable by the user running the server.
+func writeRPCCookie(path, login string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
 		return err
 	}
-	if err = ioutil.WriteFile(keyFile, key, 0600); err != nil {
-		os.Remove(certFile)
+	tmpPath := path + ".new"
+	if err := ioutil.WriteFile(tmpPath, []byte(login), 0600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
 		return err
 	}
-
-	rpcsLog.Infof("Done generating TLS certificates")
 	return nil
 }
 
+// loadClientCAs returns a pool of the PEM-encoded CA certificates in the passed
+// file, which sign the certificates RPC clients must present.
+func loadClientCAs(caFile string) (*x509.CertPool, error) {
+	pem, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in client CA "+
+			"file %s", caFile)
+	}
+	return pool, nil
+}
+
+// tlsCertificate returns the certificate the RPC server presents to clients.
+// It is used as the GetCertificate callback of the TLS config of the
+// listeners, so the certificate can be replaced without restarting them.
+//
+// This function is safe for concurrent access.
+func (s *rpcServer) tlsCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	s.tlsCertMtx.RLock()
+	cert := s.tlsCert
+	s.tlsCertMtx.RUnlock()
+	return cert, nil
+}
+
+// setTLSCertificate replaces the certificate the RPC server presents to
+// clients.  Connections which are already established are not affected.
+//
+// This function is safe for concurrent access.
+func (s *rpcServer) setTLSCertificate(cert *tls.Certificate) {
+	s.tlsCertMtx.Lock()
+	s.tlsCert = cert
+	s.tlsCertMtx.Unlock()
+}
+
 // newRPCServer returns a new instance of the rpcServer struct.
 func newRPCServer(listenAddrs []string, policy *mining.Policy, s *server) (*rpcServer, error) {
 	rpc := rpcServer{
@@ -6447,7 +9471,8 @@ func newRPCServer(listenAddrs []string, policy *mining.Policy, s *server) (*rpcS
 		gbtWorkState:           newGbtWorkState(s.timeSource),
 		helpCacher:             newHelpCacher(),
 		requestProcessShutdown: make(chan struct{}),
-		quit: make(chan int),
+		quit:                   make(chan int),
+		bestBlockChan:          make(chan struct{}),
 	}
 	if cfg.RPCUser != "" && cfg.RPCPass != "" {
 		login := cfg.RPCUser + ":" + cfg.RPCPass
@@ -6461,6 +9486,14 @@ func newRPCServer(listenAddrs []string, policy *mining.Policy, s *server) (*rpcS
 			base64.StdEncoding.EncodeToString([]byte(login))
 		rpc.limitauthsha = sha256.Sum256([]byte(auth))
 	}
+	rpc.publicMethods = make(map[string]struct{}, len(cfg.RPCPublicMethods))
+	for _, method := range cfg.RPCPublicMethods {
+		rpc.publicMethods[method] = struct{}{}
+	}
+	rpc.corsOrigins = make(map[string]struct{}, len(cfg.RPCCORSOrigins))
+	for _, origin := range cfg.RPCCORSOrigins {
+		rpc.corsOrigins[origin] = struct{}{}
+	}
 	rpc.ntfnMgr = newWsNotificationManager(&rpc)
 
 	// Setup TLS if not disabled.
@@ -6478,10 +9511,22 @@ func newRPCServer(listenAddrs []string, policy *mining.Policy, s *server) (*rpcS
 		if err != nil {
 			return nil, err
 		}
+		rpc.tlsCert = &keypair
 
 		tlsConfig := tls.Config{
-			Certificates: []tls.Certificate{keypair},
-			MinVersion:   tls.VersionTLS12,
+			GetCertificate: rpc.tlsCertificate,
+			MinVersion:     tls.VersionTLS12,
+		}
+
+		// Require clients to present a certificate signed by one of the
+		// client CAs when they are configured.
+		if cfg.RPCClientCAFile != "" {
+			pool, err := loadClientCAs(cfg.RPCClientCAFile)
+			if err != nil {
+				return nil, err
+			}
+			tlsConfig.ClientCAs = pool
+			tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
 		}
 
 		// Change the standard net.Listen function to the tls one.
@@ -6521,6 +9566,23 @@ func newRPCServer(listenAddrs []string, policy *mining.Policy, s *server) (*rpcS
 
 	rpc.listeners = listeners
 
+	// Generate the auth cookie which allows local clients to authenticate
+	// without configured credentials.
+	if cfg.RPCAuthType == rpcAuthTypeBasic && !cfg.NoRPCCookie {
+		login, err := newRPCCookie()
+		if err != nil {
+			return nil, err
+		}
+		if err := writeRPCCookie(cfg.RPCCookieFile, login); err != nil {
+			return nil, err
+		}
+		auth := "Basic " +
+			base64.StdEncoding.EncodeToString([]byte(login))
+		rpc.cookieauthsha = sha256.Sum256([]byte(auth))
+		rpc.cookieFile = cfg.RPCCookieFile
+		rpcsLog.Infof("RPC auth cookie written to %s", cfg.RPCCookieFile)
+	}
+
 	return &rpc, nil
 }
 
@@ -11,13 +11,20 @@ package main
 
 import (
 	"bytes"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"runtime/debug"
 	"testing"
+	"time"
 
 	"github.com/EXCCoin/exccd/chaincfg"
+	"github.com/EXCCoin/exccd/chaincfg/chainhash"
+	"github.com/EXCCoin/exccd/exccjson"
+	"github.com/EXCCoin/exccd/exccutil"
 	"github.com/EXCCoin/exccd/rpctest"
+	"github.com/EXCCoin/exccd/txscript"
+	"github.com/EXCCoin/exccd/wire"
 )
 
 func testGetBestBlock(r *rpctest.Harness, t *testing.T) {
@@ -96,10 +103,312 @@ func testGetBlockHash(r *rpctest.Harness, t *testing.T) {
 	}
 }
 
+func testGetPrevOuts(r *rpctest.Harness, t *testing.T) {
+	// Spend some of the mature coinbase outputs of the harness.
+	addr, err := r.NewAddress()
+	if err != nil {
+		t.Fatalf("Unable to generate new address: %v", err)
+	}
+	script, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatalf("Unable to create script: %v", err)
+	}
+	output := wire.NewTxOut(exccutil.AtomsPerCoin, script)
+	txHash, err := r.SendOutputs([]*wire.TxOut{output}, 10)
+	if err != nil {
+		t.Fatalf("Unable to send outputs: %v", err)
+	}
+
+	// The previous outputs of the unconfirmed transaction are unspent, so
+	// they must be found without the transaction index.
+	rawTx, err := r.Node.GetRawTransactionVerbosePrevOuts(txHash)
+	if err != nil {
+		t.Fatalf("Call to `getrawtransaction` failed: %v", err)
+	}
+	var totalIn, totalOut float64
+	for i, vin := range rawTx.Vin {
+		if vin.PrevOut == nil || vin.PrevOut.ScriptPubKey == nil {
+			t.Fatalf("Input %d is missing its previous output", i)
+		}
+		if len(vin.PrevOut.Addresses) == 0 {
+			t.Fatalf("Previous output of input %d has no addresses", i)
+		}
+		totalIn += vin.PrevOut.Value
+	}
+	for _, vout := range rawTx.Vout {
+		totalOut += vout.Value
+	}
+	if totalIn <= totalOut {
+		t.Fatalf("Previous outputs of %v do not cover the outputs of %v",
+			totalIn, totalOut)
+	}
+
+	// Requesting the previous outputs of a block returns its transactions
+	// as JSON objects.
+	generatedBlockHashes, err := r.Node.Generate(1)
+	if err != nil {
+		t.Fatalf("Unable to generate block: %v", err)
+	}
+	block, err := r.Node.GetBlockVerbosePrevOuts(generatedBlockHashes[0])
+	if err != nil {
+		t.Fatalf("Call to `getblock` failed: %v", err)
+	}
+	for _, tx := range block.RawTx {
+		if tx.Txid == txHash.String() {
+			return
+		}
+	}
+	t.Fatalf("Transaction %v is not in block %v", txHash,
+		generatedBlockHashes[0])
+}
+
+func testSendRawTransactionAndWait(r *rpctest.Harness, t *testing.T) {
+	addr, err := r.NewAddress()
+	if err != nil {
+		t.Fatalf("Unable to generate new address: %v", err)
+	}
+	script, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatalf("Unable to create script: %v", err)
+	}
+	output := wire.NewTxOut(exccutil.AtomsPerCoin, script)
+	tx, err := r.CreateTransaction([]*wire.TxOut{output}, 10)
+	if err != nil {
+		t.Fatalf("Unable to create transaction: %v", err)
+	}
+	txHash := tx.TxHash()
+
+	// Send the transaction and wait for a confirmation in the background
+	// since it only returns once a block including it is generated.
+	type waitResult struct {
+		result *exccjson.SendRawTransactionAndWaitResult
+		err    error
+	}
+	resultChan := make(chan waitResult, 1)
+	go func() {
+		result, err := r.Node.SendRawTransactionAndWait(tx, 1, time.Minute)
+		resultChan <- waitResult{result, err}
+	}()
+
+	// Wait for the transaction to reach the mempool before generating the
+	// block which includes it.
+	inMempool := false
+	for i := 0; i < 100 && !inMempool; i++ {
+		hashes, err := r.Node.GetRawMempool(exccjson.GRMAll)
+		if err != nil {
+			t.Fatalf("Call to `getrawmempool` failed: %v", err)
+		}
+		for _, hash := range hashes {
+			if *hash == txHash {
+				inMempool = true
+			}
+		}
+		if !inMempool {
+			time.Sleep(100 * time.Millisecond)
+		}
+	}
+	if !inMempool {
+		t.Fatalf("Transaction %v did not reach the mempool", txHash)
+	}
+	generatedBlockHashes, err := r.Node.Generate(1)
+	if err != nil {
+		t.Fatalf("Unable to generate block: %v", err)
+	}
+
+	res := <-resultChan
+	if res.err != nil {
+		t.Fatalf("Call to `sendrawtransactionandwait` failed: %v",
+			res.err)
+	}
+	if res.result.TxID != txHash.String() {
+		t.Fatalf("Unexpected transaction hash - got %v, want %v",
+			res.result.TxID, txHash)
+	}
+	if res.result.BlockHash != generatedBlockHashes[0].String() ||
+		res.result.Confirmations != 1 {
+
+		t.Fatalf("Unexpected confirmation - got block %v with %d "+
+			"confirmations, want block %v with 1 confirmation",
+			res.result.BlockHash, res.result.Confirmations,
+			generatedBlockHashes[0])
+	}
+}
+
+func testGetRawMempoolSorted(r *rpctest.Harness, t *testing.T) {
+	// Create and send two transactions paying different fee rates.
+	addr, err := r.NewAddress()
+	if err != nil {
+		t.Fatalf("Unable to generate new address: %v", err)
+	}
+	script, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatalf("Unable to create script: %v", err)
+	}
+	var txHashes []chainhash.Hash
+	for _, feeRate := range []exccutil.Amount{10, 100} {
+		output := wire.NewTxOut(exccutil.AtomsPerCoin, script)
+		tx, err := r.CreateTransaction([]*wire.TxOut{output}, feeRate)
+		if err != nil {
+			t.Fatalf("Unable to create transaction: %v", err)
+		}
+		txHash, err := r.Node.SendRawTransaction(tx, false)
+		if err != nil {
+			t.Fatalf("Call to `sendrawtransaction` failed: %v", err)
+		}
+		txHashes = append(txHashes, *txHash)
+	}
+	lowFeeHash, highFeeHash := txHashes[0], txHashes[1]
+
+	// Both transactions are returned in order of decreasing fee rate.
+	hashes, err := r.Node.GetRawMempoolSorted(exccjson.GRMRegular,
+		exccjson.GRMSortFeeRate, 0, 0)
+	if err != nil {
+		t.Fatalf("Call to `getrawmempool` failed: %v", err)
+	}
+	if len(hashes) != 2 || *hashes[0] != highFeeHash ||
+		*hashes[1] != lowFeeHash {
+
+		t.Fatalf("Unexpected sorted mempool - got %v, want [%v %v]",
+			hashes, highFeeHash, lowFeeHash)
+	}
+
+	// Each page only includes the selected transaction.
+	for skip, wantHash := range []chainhash.Hash{highFeeHash, lowFeeHash} {
+		results, err := r.Node.GetRawMempoolSortedVerbose(
+			exccjson.GRMRegular, exccjson.GRMSortFeeRate,
+			uint32(skip), 1)
+		if err != nil {
+			t.Fatalf("Call to `getrawmempool` failed: %v", err)
+		}
+		if len(results) != 1 || results[0].TxID != wantHash.String() {
+			t.Fatalf("Unexpected mempool page %d - got %v, want %v",
+				skip, results, wantHash)
+		}
+	}
+
+	// Mine the transactions so the mempool is empty for the other tests.
+	if _, err := r.Node.Generate(1); err != nil {
+		t.Fatalf("Unable to generate block: %v", err)
+	}
+}
+
+func testGetDifficultyHistory(r *rpctest.Harness, t *testing.T) {
+	_, bestHeight, err := r.Node.GetBestBlock()
+	if err != nil {
+		t.Fatalf("Call to `getbestblock` failed: %v", err)
+	}
+
+	// The history of the most recent blocks matches their hashes and the
+	// chain work only grows.
+	startHeight := bestHeight - 9
+	history, err := r.Node.GetDifficultyHistory(startHeight, -1)
+	if err != nil {
+		t.Fatalf("Call to `getdifficultyhistory` failed: %v", err)
+	}
+	if len(history) != 10 {
+		t.Fatalf("Unexpected number of blocks - got %d, want 10",
+			len(history))
+	}
+	for i, entry := range history {
+		blockHash, err := r.Node.GetBlockHash(startHeight + int64(i))
+		if err != nil {
+			t.Fatalf("Call to `getblockhash` failed: %v", err)
+		}
+		if entry.Height != startHeight+int64(i) ||
+			entry.Hash != blockHash.String() {
+
+			t.Fatalf("Unexpected block %d (%s) - want %d (%v)",
+				entry.Height, entry.Hash, startHeight+int64(i),
+				blockHash)
+		}
+		if i > 0 && entry.ChainWork <= history[i-1].ChainWork {
+			t.Fatalf("Chain work did not grow at height %d",
+				entry.Height)
+		}
+	}
+
+	// Ranges after the best block are rejected.
+	_, err = r.Node.GetDifficultyHistory(bestHeight, bestHeight+1)
+	if err == nil {
+		t.Fatal("Call to `getdifficultyhistory` succeeded for a range " +
+			"after the best block")
+	}
+}
+
+func testGetHeaders(r *rpctest.Harness, t *testing.T) {
+	_, bestHeight, err := r.Node.GetBestBlock()
+	if err != nil {
+		t.Fatalf("Call to `getbestblock` failed: %v", err)
+	}
+
+	// checkHeaders ensures the passed serialized headers are the headers of
+	// the main chain blocks starting at the passed height.
+	checkHeaders := func(headers []string, startHeight int64) {
+		for i, serialized := range headers {
+			var header wire.BlockHeader
+			b, err := hex.DecodeString(serialized)
+			if err != nil {
+				t.Fatalf("Unable to decode header %d: %v", i, err)
+			}
+			if err := header.Deserialize(bytes.NewReader(b)); err != nil {
+				t.Fatalf("Unable to deserialize header %d: %v", i,
+					err)
+			}
+			height := startHeight + int64(i)
+			blockHash, err := r.Node.GetBlockHash(height)
+			if err != nil {
+				t.Fatalf("Call to `getblockhash` failed: %v", err)
+			}
+			if header.BlockHash() != *blockHash {
+				t.Fatalf("Unexpected header at height %d - got %v, "+
+					"want %v", height, header.BlockHash(),
+					blockHash)
+			}
+		}
+	}
+
+	// All headers after the locator are returned when there is no stop
+	// hash.
+	locatorHeight := bestHeight - 5
+	locator, err := r.Node.GetBlockHash(locatorHeight)
+	if err != nil {
+		t.Fatalf("Call to `getblockhash` failed: %v", err)
+	}
+	result, err := r.Node.GetHeaders([]*chainhash.Hash{locator}, nil)
+	if err != nil {
+		t.Fatalf("Call to `getheaders` failed: %v", err)
+	}
+	if len(result.Headers) != 5 {
+		t.Fatalf("Unexpected number of headers - got %d, want 5",
+			len(result.Headers))
+	}
+	checkHeaders(result.Headers, locatorHeight+1)
+
+	// Headers up to and including the stop hash are returned.
+	hashStop, err := r.Node.GetBlockHash(locatorHeight + 2)
+	if err != nil {
+		t.Fatalf("Call to `getblockhash` failed: %v", err)
+	}
+	result, err = r.Node.GetHeaders([]*chainhash.Hash{locator}, hashStop)
+	if err != nil {
+		t.Fatalf("Call to `getheaders` failed: %v", err)
+	}
+	if len(result.Headers) != 2 {
+		t.Fatalf("Unexpected number of headers - got %d, want 2",
+			len(result.Headers))
+	}
+	checkHeaders(result.Headers, locatorHeight+1)
+}
+
 var rpcTestCases = []rpctest.HarnessTestCase{
 	testGetBestBlock,
 	testGetBlockCount,
 	testGetBlockHash,
+	testGetPrevOuts,
+	testSendRawTransactionAndWait,
+	testGetRawMempoolSorted,
+	testGetDifficultyHistory,
+	testGetHeaders,
 }
 
 var primaryHarness *rpctest.Harness
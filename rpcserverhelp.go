@@ -35,6 +35,22 @@ var helpDescsEnUS = map[string]string{
 	"addnode-addr":      "IP address and port of the peer to operate on",
 	"addnode-subcmd":    "'add' to add a persistent peer, 'remove' to remove a persistent peer, or 'onetry' to try a single connection to a peer",
 
+	// AnalyzeScriptCmd help.
+	"analyzescript--synopsis": "Statically analyzes a script without executing it.",
+	"analyzescript-hexscript": "Hex-encoded script",
+	"analyzescript-version":   "The script version of the script",
+
+	// AnalyzeScriptResult help.
+	"analyzescriptresult-type":          "The type of the script (e.g. 'pubkeyhash' or 'nonstandard')",
+	"analyzescriptresult-subtype":       "The type of the script tagged by the stake opcode of stake scripts",
+	"analyzescriptresult-reqsigs":       "The number of signatures required to redeem the script, or 0 when unknown",
+	"analyzescriptresult-sigops":        "The number of signature operations as counted for public key and signature scripts",
+	"analyzescriptresult-precisesigops": "The number of signature operations as counted for pay-to-script-hash redeem scripts",
+	"analyzescriptresult-pushonly":      "Whether or not the script only pushes data",
+	"analyzescriptresult-numpushes":     "The number of data pushes in the script",
+	"analyzescriptresult-maxpushsize":   "The size of the largest data pushed by the script",
+	"analyzescriptresult-sigscriptsize": "The estimated maximum size of a signature script which redeems the script using compressed public keys, or -1 when unknown",
+
 	// NodeCmd help.
 	"node--synopsis":     "Attempts to add or remove a peer.",
 	"node-subcmd":        "'disconnect' to remove all matching non-persistent peers, 'remove' to remove a persistent peer, or 'connect' to connect to a peer",
@@ -45,6 +61,15 @@ var helpDescsEnUS = map[string]string{
 	"transactioninput-txid": "The hash of the input transaction",
 	"transactioninput-vout": "The specific output of the input transaction to redeem",
 	"transactioninput-tree": "The tree that the transaction input is located",
+	// CreateMultisigCmd help.
+	"createmultisig--synopsis": "Creates a multi-signature redeem script which requires the specified number of signatures from the provided keys and returns it along with its pay-to-script-hash address.",
+	"createmultisig-nrequired": "The number of signatures required to redeem the script",
+	"createmultisig-keys":      "The hex-encoded secp256k1 public keys or public key addresses to include in the script",
+
+	// CreateMultiSigResult help.
+	"createmultisigresult-address":      "The pay-to-script-hash address of the redeem script",
+	"createmultisigresult-redeemScript": "The hex-encoded redeem script",
+
 	// TODO review cmd help messages for stake stuff
 	// CreateRawSSTxCmd help.
 	"createrawsstx--synopsis": "Returns a new transaction spending the provided inputs and sending to the provided addresses.\n" +
@@ -99,8 +124,9 @@ var helpDescsEnUS = map[string]string{
 	"scriptsig-hex": "Hex-encoded bytes of the script",
 
 	// PrevOut help.
-	"prevout-addresses": "previous output addresses",
-	"prevout-value":     "previous output value",
+	"prevout-addresses":    "previous output addresses",
+	"prevout-value":        "previous output value",
+	"prevout-scriptPubKey": "The public key script of the previous output (only with the prevouts of getblock or verbose=2 of getrawtransaction)",
 
 	// VinPrevOut help.
 	"vinprevout-coinbase":    "The hex-encoded bytes of the signature script (coinbase txns only)",
@@ -126,6 +152,7 @@ var helpDescsEnUS = map[string]string{
 	"vin-blockindex":  "The block idx of the origin transaction",
 	"vin-blockheight": "The block height of the origin transaction",
 	"vin-amountin":    "The amount in",
+	"vin-prevOut":     "Data from the origin transaction output with index vout when it was requested and found (non-coinbase txns only)",
 
 	// ScriptPubKeyResult help.
 	"scriptpubkeyresult-asm":       "Disassembly of the script",
@@ -154,11 +181,25 @@ var helpDescsEnUS = map[string]string{
 	"decoderawtransaction-hextx":     "Serialized, hex-encoded transaction",
 
 	// DecodeScriptResult help.
-	"decodescriptresult-asm":       "Disassembly of the script",
-	"decodescriptresult-reqSigs":   "The number of required signatures",
-	"decodescriptresult-type":      "The type of the script (e.g. 'pubkeyhash')",
-	"decodescriptresult-addresses": "The ExchangeCoin addresses associated with this script",
-	"decodescriptresult-p2sh":      "The script hash for use in pay-to-script-hash transactions (only present if the provided redeem script is not already a pay-to-script-hash script)",
+	"decodescriptresult-asm":          "Disassembly of the script",
+	"decodescriptresult-reqSigs":      "The number of required signatures",
+	"decodescriptresult-numKeys":      "The number of public keys (only present for multi-signature scripts)",
+	"decodescriptresult-type":         "The type of the script (e.g. 'pubkeyhash')",
+	"decodescriptresult-subType":      "The type of the script tagged by the stake opcode (only present for stake scripts)",
+	"decodescriptresult-addresses":    "The ExchangeCoin addresses associated with this script",
+	"decodescriptresult-data":         "The hex-encoded data carried by the script (only present for null data scripts)",
+	"decodescriptresult-p2sh":         "The script hash for use in pay-to-script-hash transactions (only present if the provided redeem script is not already a pay-to-script-hash script)",
+	"decodescriptresult-redeemScript": "The decoded redeem script (only present if the script is a signature script which redeems a pay-to-script-hash output with a standard redeem script)",
+
+	// DecodeScriptRedeemResult help.
+	"decodescriptredeemresult-hex":       "Hex-encoded redeem script",
+	"decodescriptredeemresult-asm":       "Disassembly of the redeem script",
+	"decodescriptredeemresult-reqSigs":   "The number of required signatures",
+	"decodescriptredeemresult-numKeys":   "The number of public keys (only present for multi-signature scripts)",
+	"decodescriptredeemresult-type":      "The type of the redeem script (e.g. 'multisig')",
+	"decodescriptredeemresult-subType":   "The type of the redeem script tagged by the stake opcode (only present for stake scripts)",
+	"decodescriptredeemresult-addresses": "The ExchangeCoin addresses associated with the redeem script",
+	"decodescriptredeemresult-p2sh":      "The pay-to-script-hash address redeemed by the signature script",
 
 	// DecodeScriptCmd help.
 	"decodescript--synopsis": "Returns a JSON object with information about the provided hex-encoded script.",
@@ -199,6 +240,23 @@ var helpDescsEnUS = map[string]string{
 	"existsmempooltxs-txhashblob": "Blob containing the hashes to check",
 	"existsmempooltxs--result0":   "Bool blob showing if txs exist in the mempool or not",
 
+	// FundRawTransactionOptions help.
+	"fundrawtransactionoptions-changeaddress": "The address to pay the change to (default: the first funding address)",
+	"fundrawtransactionoptions-feerate":       "The fee rate to pay in EXCC/kB (default: the minimum relay fee)",
+	"fundrawtransactionoptions-minconf":       "The minimum number of confirmations of the outputs to spend (default: 1)",
+
+	// FundRawTransactionCmd help.
+	"fundrawtransaction--synopsis": "Adds inputs spending the confirmed unspent outputs of the provided pay-to-pubkey-hash addresses, along with a change output, to a transaction until its outputs and fee are covered.\n" +
+		"The unspent outputs are found with the address index, which must be enabled (--addrindex), and the returned transaction is not signed.",
+	"fundrawtransaction-hextx":     "The hex-encoded serialized transaction to fund",
+	"fundrawtransaction-addresses": "The pay-to-pubkey-hash addresses whose unspent outputs may be spent",
+	"fundrawtransaction-options":   "Options for funding the transaction",
+
+	// FundRawTransactionResult help.
+	"fundrawtransactionresult-hex":       "The hex-encoded serialized funded transaction",
+	"fundrawtransactionresult-fee":       "The fee paid by the funded transaction in EXCC",
+	"fundrawtransactionresult-changepos": "The index of the change output, or -1 when no change output was added",
+
 	// GenerateCmd help
 	"generate--synopsis": "Generates a set number of blocks (simnet or regtest only) and returns a JSON\n" +
 		" array of their hashes.",
@@ -239,6 +297,7 @@ var helpDescsEnUS = map[string]string{
 	"getblock-hash":        "The hash of the block",
 	"getblock-verbose":     "Specifies the block is returned as a JSON object instead of hex-encoded string",
 	"getblock-verbosetx":   "Specifies that each transaction is returned as a JSON object and only applies if the verbose flag is true (exccd extension)",
+	"getblock-prevouts":    "Specifies that each transaction is returned as a JSON object with the previous output spent by each input, which is found in the same block, the memory pool, the unspent outputs, or the transaction index for spent outputs (--txindex), and only applies if the verbose flag is true (exccd extension)",
 	"getblock--condition0": "verbose=false",
 	"getblock--condition1": "verbose=true",
 	"getblock--result0":    "Hex-encoded bytes of the serialized block",
@@ -277,8 +336,8 @@ var helpDescsEnUS = map[string]string{
 	"getblockverboseresult-height":            "The height of the block in the block chain",
 	"getblockverboseresult-version":           "The block version",
 	"getblockverboseresult-merkleroot":        "Root hash of the merkle tree",
-	"getblockverboseresult-tx":                "The transaction hashes (only when verbosetx=false)",
-	"getblockverboseresult-rawtx":             "The transactions as JSON objects (only when verbosetx=true)",
+	"getblockverboseresult-tx":                "The transaction hashes (only when verbosetx=false and prevouts=false)",
+	"getblockverboseresult-rawtx":             "The transactions as JSON objects (only when verbosetx=true or prevouts=true)",
 	"getblockverboseresult-time":              "The block time in seconds since 1 Jan 1970 GMT",
 	"getblockverboseresult-nonce":             "The block nonce",
 	"getblockverboseresult-bits":              "The bits which represent the block difficulty",
@@ -311,6 +370,45 @@ var helpDescsEnUS = map[string]string{
 	"getblockhash-index":     "The block height",
 	"getblockhash--result0":  "The block hash",
 
+	// TicketInfoResult help.
+	"ticketinforesult-hash":           "The hash of the ticket",
+	"ticketinforesult-status":         "The current state of the ticket (immature, live, voted, missed, expired, or revoked)",
+	"ticketinforesult-purchaseheight": "The height of the block which included the ticket purchase",
+	"ticketinforesult-liveheight":     "The height at which the ticket matured and entered the live ticket pool",
+	"ticketinforesult-missedheight":   "The height at which the ticket was missed or expired",
+	"ticketinforesult-spendheight":    "The height of the block which included the vote or revocation spending the ticket",
+	"ticketinforesult-spender":        "The hash of the vote or revocation spending the ticket",
+
+	// GetAddressTicketsCmd help.
+	"getaddresstickets--synopsis": "Returns the lifecycle of all tickets which commit their rewards to the provided address (requires --ticketindex).",
+	"getaddresstickets-address":   "The reward commitment address of the tickets",
+	"getaddresstickets--result0":  "The tickets which commit their rewards to the address",
+
+	// GetAgendasCmd help.
+	"getagendas--synopsis":         "Returns the consensus rule change agendas defined for all stake versions along with their status for the next block.",
+	"getagendas--result0":          "The agendas ordered by stake version",
+	"getagendasresult-id":          "Unique identifier of the agenda",
+	"getagendasresult-description": "Description of the agenda",
+	"getagendasresult-voteversion": "The stake version the agenda is voted on with",
+	"getagendasresult-mask":        "The vote bits used by the agenda",
+	"getagendasresult-starttime":   "The median block time after which voting on the agenda starts",
+	"getagendasresult-expiretime":  "The median block time after which the agenda expires if it has not been locked in",
+	"getagendasresult-quorum":      "The minimum number of non-abstaining votes required in a rule change interval",
+	"getagendasresult-status":      "The status of the agenda (defined, started, lockedin, active, or failed)",
+	"getagendasresult-choice":      "The identifier of the choice that was locked in or that caused the agenda to fail, if any",
+
+	// GetBlockHashByTimeCmd help.
+	"getblockhashbytime--synopsis": "Returns the main chain blocks whose header timestamps fall within the provided range, ordered by timestamp (requires --timeindex).",
+	"getblockhashbytime-starttime": "The start of the time range in seconds since 1 Jan 1970 GMT (inclusive)",
+	"getblockhashbytime-endtime":   "The end of the time range in seconds since 1 Jan 1970 GMT (inclusive, defaults to the current time)",
+	"getblockhashbytime-count":     "The maximum number of blocks to return",
+	"getblockhashbytime--result0":  "The blocks within the time range",
+
+	// GetBlockHashByTimeResult help.
+	"getblockhashbytimeresult-hash":   "The hash of the block",
+	"getblockhashbytimeresult-height": "The height of the block",
+	"getblockhashbytimeresult-time":   "The block time in seconds since 1 Jan 1970 GMT",
+
 	// GetBlockHeaderCmd help.
 	"getblockheader--synopsis":   "Returns information about a block header given its hash.",
 	"getblockheader-hash":        "The hash of the block",
@@ -362,7 +460,7 @@ var helpDescsEnUS = map[string]string{
 	"templaterequest-maxversion":   "Highest supported block version number (this parameter is ignored)",
 	"templaterequest-target":       "The desired target for the block template (this parameter is ignored)",
 	"templaterequest-data":         "Hex-encoded block data (only for mode=proposal)",
-	"templaterequest-workid":       "The server provided workid if provided in block template (not applicable)",
+	"templaterequest-workid":       "The workid provided with the block template the proposed block was created from, to check that it only makes the allowed mutations to it (only for mode=proposal)",
 
 	// GetBlockTemplateResultTx help.
 	"getblocktemplateresulttx-data":    "Hex-encoded transaction data (byte-for-byte)",
@@ -387,7 +485,7 @@ var helpDescsEnUS = map[string]string{
 	"getblocktemplateresult-coinbaseaux":       "Data that should be included in the coinbase signature script",
 	"getblocktemplateresult-coinbasetxn":       "Information about the coinbase transaction",
 	"getblocktemplateresult-coinbasevalue":     "Total amount available for the coinbase in Atoms",
-	"getblocktemplateresult-workid":            "This value must be returned with result if provided (not provided)",
+	"getblocktemplateresult-workid":            "Identifier of the block template which should be passed to submitblock with blocks created from it, so it is logged whether they only make the allowed mutations to it",
 	"getblocktemplateresult-longpollid":        "Identifier for long poll request which allows monitoring for expiration",
 	"getblocktemplateresult-longpolluri":       "An alternate URI to use for long poll requests if provided (not provided)",
 	"getblocktemplateresult-submitold":         "Not applicable",
@@ -395,7 +493,7 @@ var helpDescsEnUS = map[string]string{
 	"getblocktemplateresult-expires":           "Maximum number of seconds (starting from when the server sent the response) this work is valid for",
 	"getblocktemplateresult-maxtime":           "Maximum allowed time",
 	"getblocktemplateresult-mintime":           "Minimum allowed time",
-	"getblocktemplateresult-mutable":           "List of mutations the server explicitly allows",
+	"getblocktemplateresult-mutable":           "List of mutations the server explicitly allows: changing the time within mintime and maxtime, adding transactions, building on a newer best block, and appending to the coinbase",
 	"getblocktemplateresult-noncerange":        "Two concatenated hex-encoded big-endian 32-bit integers which represent the valid ranges of nonces the miner may scan",
 	"getblocktemplateresult-capabilities":      "List of server capabilities including 'proposal' to indicate support for block proposals",
 	"getblocktemplateresult-reject-reason":     "Reason the proposal was invalid as-is (only applies to proposal responses)",
@@ -451,11 +549,40 @@ var helpDescsEnUS = map[string]string{
 	"getdifficulty--synopsis": "Returns the proof-of-work difficulty as a multiple of the minimum difficulty.",
 	"getdifficulty--result0":  "The difficulty",
 
+	// GetDifficultyHistoryCmd help.
+	"getdifficultyhistory--synopsis":   "Returns the proof-of-work difficulty and the cumulative chain work of up to 10000 blocks in the main chain along with whether each block is a difficulty retarget boundary, ordered from the oldest to the newest block.",
+	"getdifficultyhistory-startheight": "The height of the first block",
+	"getdifficultyhistory-endheight":   "The height of the last block or -1 for the current best block",
+
+	// DifficultyHistoryResult help.
+	"difficultyhistoryresult-height":     "The height of the block",
+	"difficultyhistoryresult-hash":       "The hash of the block",
+	"difficultyhistoryresult-time":       "The block time in seconds since 1 Jan 1970 GMT",
+	"difficultyhistoryresult-bits":       "The difficulty bits of the block",
+	"difficultyhistoryresult-difficulty": "The proof-of-work difficulty of the block as a multiple of the minimum difficulty",
+	"difficultyhistoryresult-chainwork":  "The total work of the main chain up to and including the block as a hex string",
+	"difficultyhistoryresult-retarget":   "Whether the difficulty retarget rules scheduled a new required difficulty for the block",
+
 	// GetStakeDifficultyCmd help.
 	"getstakedifficulty--synopsis":     "Returns the proof-of-stake difficulty.",
 	"getstakedifficultyresult-current": "The current top block's stake difficulty",
 	"getstakedifficultyresult-next":    "The calculated stake difficulty of the next block",
 
+	// GetNetworkStakeInfoCmd help.
+	"getnetworkstakeinfo--synopsis":          "Returns aggregated information about the ticket pool, ticket price, and recent voting of the network.",
+	"networkstakeinforesult-height":          "The height of the current best block",
+	"networkstakeinforesult-poolsize":        "The number of live tickets in the ticket pool",
+	"networkstakeinforesult-ticketprice":     "The stake difficulty of the current best block",
+	"networkstakeinforesult-nextticketprice": "The calculated stake difficulty of the next block",
+	"networkstakeinforesult-mempooltickets":  "The number of ticket purchases in the memory pool of the node",
+	"networkstakeinforesult-ratewindow":      "The number of most recent blocks the vote and miss statistics are calculated from",
+	"networkstakeinforesult-voted":           "The number of votes included in the blocks of the window",
+	"networkstakeinforesult-missed":          "The number of votes missing from the blocks of the window",
+	"networkstakeinforesult-voterate":        "The proportion of called tickets which voted in the blocks of the window",
+	"networkstakeinforesult-missrate":        "The proportion of called tickets which missed their vote in the blocks of the window",
+	"networkstakeinforesult-votereward":      "The subsidy paid to each vote in the next block",
+	"networkstakeinforesult-expectedreward":  "The vote reward scaled by the vote rate",
+
 	// GetStakeVersionInfoCmd help.
 	"getstakeversioninfo--synopsis":           "Returns stake version statistics for one or more stake version intervals.",
 	"getstakeversioninfo-count":               "Number of intervals to return.",
@@ -482,6 +609,21 @@ var helpDescsEnUS = map[string]string{
 	"versionbits-version":                  "The version of the vote.",
 	"versionbits-bits":                     "The bits assigned by the vote.",
 
+	// GetSyncInfoCmd help.
+	"getsyncinfo--synopsis": "Returns the progress of the chain sync, namely the height of the best chain compared to the best known header, the download rate, and the estimated time until the sync completes.",
+
+	// GetSyncInfoResult help.
+	"getsyncinforesult-synced":          "Whether or not the chain is believed to be synced",
+	"getsyncinforesult-blocks":          "The height of the best chain",
+	"getsyncinforesult-headers":         "The height of the best known header, which is the highest of the best chain, the latest header downloaded from the sync peer, and the best block announced by it",
+	"getsyncinforesult-progress":        "The percentage of the best known headers which have their blocks in the best chain",
+	"getsyncinforesult-blockspersecond": "The rate at which blocks were added to the best chain over roughly the last minute",
+	"getsyncinforesult-eta":             "The estimated number of seconds until the sync completes at the current download rate, or -1 when no blocks are being downloaded",
+	"getsyncinforesult-syncpeer":        "The address of the peer the chain is synced from, if any",
+	"getsyncinforesult-syncpeerheight":  "The height of the best block announced by the sync peer",
+	"getsyncinforesult-bestblockhash":   "The hash of the best block",
+	"getsyncinforesult-bestblocktime":   "The timestamp of the best block in seconds since 1 Jan 1970 GMT",
+
 	// GetVoteInfo
 	"getvoteinfo--synopsis":           "Returns the vote info statistics.",
 	"getvoteinfo-version":             "The stake version.",
@@ -498,6 +640,7 @@ var helpDescsEnUS = map[string]string{
 	"agenda-mask":                     "Agenda mask.",
 	"agenda-starttime":                "Time aganda becomes valid.",
 	"agenda-expiretime":               "Time aganda becomes invalid.",
+	"agenda-quorum":                   "Minimum amount of non-abstaining votes required for this agenda.",
 	"agenda-status":                   "Aganda status.",
 	"agenda-quorumprogress":           "Progress of quorum reached.",
 	"agenda-choices":                  "All choices in this agenda.",
@@ -528,6 +671,9 @@ var helpDescsEnUS = map[string]string{
 	"infochainresult-testnet":         "Whether or not server is using testnet",
 	"infochainresult-relayfee":        "The minimum relay fee for non-free transactions in EXCC/KB",
 	"infochainresult-errors":          "Any current errors",
+	"infochainresult-commit":          "The git commit the server was built from (only when set at build time)",
+	"infochainresult-goversion":       "The version of Go the server was built with",
+	"infochainresult-features":        "The optional features enabled in the configuration of the server, such as txindex, addrindex, and generate",
 
 	// InfoWalletResult help.
 	"infowalletresult-version":         "The version of the server",
@@ -553,6 +699,100 @@ var helpDescsEnUS = map[string]string{
 	"getheaders-hashstop":      "Optional block hash to stop including block headers for",
 	"getheadersresult-headers": "Serialized block headers of all located blocks, limited to some arbitrary maximum number of hashes (currently 2000, which matches the wire protocol headers message, but this is not guaranteed)",
 
+	// GetEmissionScheduleCmd help.
+	"getemissionschedule--synopsis": "Returns the current coin supply along with the projected emission of the subsidy reduction intervals following the current best block.\n" +
+		"The projection assumes every future block includes all of its votes, so the projected supply is an upper bound.",
+	"getemissionschedule-intervals": "The number of subsidy reduction intervals to return",
+
+	// GetEmissionScheduleResult help.
+	"getemissionscheduleresult-height":    "The height of the current best block",
+	"getemissionscheduleresult-supply":    "The current coin supply in atoms",
+	"getemissionscheduleresult-maxsupply": "The projected coin supply in atoms once the subsidy is exhausted",
+	"getemissionscheduleresult-intervals": "The projected emission of the subsidy reduction intervals following the current best block",
+
+	// EmissionIntervalResult help.
+	"emissionintervalresult-startheight":  "The first height of the interval",
+	"emissionintervalresult-endheight":    "The last height of the interval",
+	"emissionintervalresult-starttime":    "The estimated time of the first block of the interval in seconds since 1 Jan 1970 GMT",
+	"emissionintervalresult-blocksubsidy": "The total subsidy in atoms of each block of the interval",
+	"emissionintervalresult-worksubsidy":  "The proof-of-work subsidy in atoms of each block of the interval",
+	"emissionintervalresult-votesubsidy":  "The subsidy in atoms of each vote of the interval",
+	"emissionintervalresult-emission":     "The total subsidy in atoms paid by the blocks of the interval",
+	"emissionintervalresult-supply":       "The projected coin supply in atoms at the end of the interval",
+	"emissionintervalresult-inflation":    "The annualized inflation rate in percent at the start of the interval",
+
+	// GetDNSSeedInfoCmd help.
+	"getdnsseedinfo--synopsis": "Returns the DNS seeds used to discover peers along with how often each of them returned addresses.",
+
+	// GetDNSSeedInfoResult help.
+	"getdnsseedinforesult-host":         "The hostname of the DNS seed",
+	"getdnsseedinforesult-hasfiltering": "Whether or not the DNS seed supports filtering by service flags",
+	"getdnsseedinforesult-lookups":      "The number of lookups of the DNS seed since the node was started",
+	"getdnsseedinforesult-successes":    "The number of lookups which returned at least one address",
+	"getdnsseedinforesult-addresses":    "The total number of addresses returned by the DNS seed",
+	"getdnsseedinforesult-lastsuccess":  "The time of the last lookup which returned at least one address in seconds since 1 Jan 1970 GMT (0 when none has)",
+	"getdnsseedinforesult-lasterror":    "The reason the last lookup failed",
+
+	// GetIndexInfoCmd help.
+	"getindexinfo--synopsis":       "Returns the status of the enabled optional indexes, including whether or not they have finished loading and catching up to the main chain.",
+	"getindexinfo-indexname":       "Only return the status of the index with this name",
+	"getindexinfo--result0--desc":  "Index status objects keyed by the index name",
+	"getindexinfo--result0--key":   "The name of the index",
+	"getindexinfo--result0--value": "Object containing the status of the index",
+
+	// GetIndexInfoResult help.
+	"getindexinforesult-ready":           "Whether or not the index has been loaded and is updated along with the main chain",
+	"getindexinforesult-synced":          "Whether or not the index has caught up to the main chain",
+	"getindexinforesult-bestblockhash":   "The hash of the most recent block included in the index",
+	"getindexinforesult-bestblockheight": "The height of the most recent block included in the index",
+	"getindexinforesult-error":           "The reason the index could not be loaded (omitted when it is loading or ready)",
+
+	// GetMinedBlockStatsCmd help.
+	"getminedblockstats--synopsis": "Returns how many of the blocks mined by the CPU miner or submitted via getwork or submitblock since startup were accepted versus how many ended up stale, orphaned, or rejected.\n" +
+		"The statistics are reported for the last hour, day, and week followed by the totals since startup.",
+
+	// GetMinedBlockStatsResult help.
+	"getminedblockstatsresult-since":   "The time the statistics have been tracked since in seconds since 1 Jan 1970 GMT",
+	"getminedblockstatsresult-windows": "The statistics for each window of time",
+
+	// MinedBlockStatsResult help.
+	"minedblockstatsresult-window":    "The window of time the statistics are for (1h, 24h, 7d, or all for the totals since startup)",
+	"minedblockstatsresult-accepted":  "The number of blocks which were accepted and are part of the main chain",
+	"minedblockstatsresult-stale":     "The number of blocks which were accepted but are no longer part of the main chain, such as when another block won the race to extend the chain",
+	"minedblockstatsresult-orphaned":  "The number of blocks which were orphans because their parent is unknown",
+	"minedblockstatsresult-rejected":  "The number of blocks which were rejected as invalid",
+	"minedblockstatsresult-stalerate": "The fraction of the accepted, stale, and orphaned blocks which were stale or orphaned",
+
+	// GetMiningArchiveCmd help.
+	"getminingarchive--synopsis": "Returns the most recent block templates issued to miners and solutions submitted by them from the mining archive, ordered from the oldest to the newest record.\n" +
+		"The mining archive must be enabled with --miningarchive.",
+	"getminingarchive-count": "The number of the most recent records to return",
+
+	// MiningArchiveResult help.
+	"miningarchiveresult-seq":      "The sequence number of the record, which increases with each record",
+	"miningarchiveresult-time":     "The time the record was added in seconds since 1 Jan 1970 GMT",
+	"miningarchiveresult-kind":     "The kind of the record (template or solution)",
+	"miningarchiveresult-source":   "How the template was issued or the solution was submitted (cpuminer, getwork, getblocktemplate, or submitblock)",
+	"miningarchiveresult-workid":   "The ID of the work the template was issued as or the solution was submitted for (0 when it is not tracked)",
+	"miningarchiveresult-hash":     "The hash of the block header",
+	"miningarchiveresult-prevhash": "The hash of the parent block",
+	"miningarchiveresult-height":   "The height of the block",
+	"miningarchiveresult-numtxns":  "The number of regular transactions in the block",
+	"miningarchiveresult-numstxns": "The number of stake transactions in the block",
+	"miningarchiveresult-header":   "The hex-encoded serialized block header",
+	"miningarchiveresult-outcome":  "The outcome of processing a solution (accepted, rejected, orphan, dropped when it was a duplicate or for already solved or stale work, or invalid when its proof of work is invalid)",
+	"miningarchiveresult-reason":   "The reason for the outcome of a solution, or the mutations to its template not allowed by getblocktemplate when it was accepted",
+
+	// GetSigCacheInfoCmd help.
+	"getsigcacheinfo--synopsis": "Returns the size of the signature verification cache and how often signatures were found in it.",
+
+	// GetSigCacheInfoResult help.
+	"getsigcacheinforesult-entries":    "The number of signatures in the cache",
+	"getsigcacheinforesult-maxentries": "The maximum number of signatures in the cache",
+	"getsigcacheinforesult-hits":       "The number of lookups which found the signature in the cache since the node was started",
+	"getsigcacheinforesult-misses":     "The number of lookups which did not find the signature in the cache since the node was started",
+	"getsigcacheinforesult-hitrate":    "The ratio of lookups which found the signature in the cache",
+
 	// GetInfoCmd help.
 	"getinfo--synopsis": "Returns a JSON object containing various state info.",
 
@@ -569,13 +809,15 @@ var helpDescsEnUS = map[string]string{
 	"getmininginforesult-currentblocktx":   "Number of transactions in the latest best block",
 	"getmininginforesult-difficulty":       "Current target difficulty",
 	"getmininginforesult-stakedifficulty":  "Stake difficulty required for the next block",
-	"getmininginforesult-errors":           "Any current errors",
+	"getmininginforesult-errors":           "Any current errors, such as the CPU miner being paused after repeatedly failing to create block templates",
 	"getmininginforesult-generate":         "Whether or not server is set to generate coins",
 	"getmininginforesult-genproclimit":     "Number of processors to use for coin generation (-1 when disabled)",
 	"getmininginforesult-hashespersec":     "Recent hashes per second performance measurement while generating coins",
 	"getmininginforesult-networkhashps":    "Estimated network hashes per second for the most recent blocks",
 	"getmininginforesult-pooledtx":         "Number of transactions in the memory pool",
 	"getmininginforesult-testnet":          "Whether or not server is using testnet",
+	"getmininginforesult-timerolls":        "Number of times the CPU miner rolled the timestamp of the block it was working on forward instead of creating a new block template",
+	"getmininginforesult-templaterebuilds": "Number of block templates the CPU miner created",
 
 	// GetMiningInfoCmd help.
 	"getmininginfo--synopsis": "Returns a JSON object containing mining-related information.",
@@ -586,13 +828,52 @@ var helpDescsEnUS = map[string]string{
 	"getnetworkhashps-height":    "Perform estimate ending with this height or -1 for current best chain block height",
 	"getnetworkhashps--result0":  "Estimated hashes per second",
 
+	// GetNetworkSolPSCmd help.
+	"getnetworksolps--synopsis": "Returns the estimated network equihash solutions per second for the block heights provided by the parameters.\n" +
+		"Each solution results in a block hash which is checked against the target, so the rate is the work of the blocks divided by the time spanned by their timestamps.",
+	"getnetworksolps-blocks": "The number of blocks, or -1 for blocks since last difficulty change",
+	"getnetworksolps-height": "Perform estimate ending with this height or -1 for current best chain block height",
+
+	// GetNetworkSolPSResult help.
+	"getnetworksolpsresult-solps":       "Estimated equihash solutions per second, or 0 when no estimate could be made",
+	"getnetworksolpsresult-startheight": "The height of the first block of the window the estimate is for",
+	"getnetworksolpsresult-endheight":   "The height of the last block of the window the estimate is for",
+	"getnetworksolpsresult-timespan":    "The number of seconds spanned by the timestamps of the blocks of the window",
+	"getnetworksolpsresult-equihashn":   "The equihash n parameter of the network",
+	"getnetworksolpsresult-equihashk":   "The equihash k parameter of the network",
+
 	// GetNetTotalsCmd help.
 	"getnettotals--synopsis": "Returns a JSON object containing network traffic statistics.",
 
 	// GetNetTotalsResult help.
-	"getnettotalsresult-totalbytesrecv": "Total bytes received",
-	"getnettotalsresult-totalbytessent": "Total bytes sent",
-	"getnettotalsresult-timemillis":     "Number of milliseconds since 1 Jan 1970 GMT",
+	"getnettotalsresult-totalbytesrecv":     "Total bytes received since the process started",
+	"getnettotalsresult-totalbytessent":     "Total bytes sent since the process started",
+	"getnettotalsresult-timemillis":         "Number of milliseconds since 1 Jan 1970 GMT",
+	"getnettotalsresult-accountingstart":    "Unix time the bandwidth accounting, which persists across restarts, started",
+	"getnettotalsresult-accountedbytesrecv": "Total bytes received since the bandwidth accounting started",
+	"getnettotalsresult-accountedbytessent": "Total bytes sent since the bandwidth accounting started",
+	"getnettotalsresult-messages":           "Bytes received and sent since the bandwidth accounting started by message command",
+	"getnettotalsresult-daily":              "Bytes received and sent during each of the last 7 days (UTC) from oldest to newest",
+	"getnettotalsresult-weekly":             "Bytes received and sent during each of the last 4 weeks (UTC, starting on Monday) from oldest to newest",
+	"getnettotalsresult-uploadtarget":       "The state of the upload target",
+
+	// NetTotalsMsg help.
+	"nettotalsmsg-command":   "The message command",
+	"nettotalsmsg-bytesrecv": "Bytes received in messages with the command",
+	"nettotalsmsg-bytessent": "Bytes sent in messages with the command",
+
+	// NetTotalsPeriod help.
+	"nettotalsperiod-start":     "Unix time the period starts",
+	"nettotalsperiod-bytesrecv": "Bytes received during the period",
+	"nettotalsperiod-bytessent": "Bytes sent during the period",
+
+	// NetUploadTarget help.
+	"netuploadtarget-timeframe":             "Duration in seconds of the cycles the upload target applies to",
+	"netuploadtarget-target":                "Maximum bytes to upload per cycle, or 0 for no limit",
+	"netuploadtarget-targetreached":         "Whether the bytes uploaded during the current cycle reached the target",
+	"netuploadtarget-servehistoricalblocks": "Whether blocks older than a week are served to peers which are not whitelisted",
+	"netuploadtarget-bytesleftincycle":      "Bytes which may be uploaded during the current cycle before the target is reached",
+	"netuploadtarget-timeleftincycle":       "Seconds left in the current cycle",
 
 	// GetPeerInfoResult help.
 	"getpeerinforesult-id":             "A unique node ID",
@@ -615,11 +896,13 @@ var helpDescsEnUS = map[string]string{
 	"getpeerinforesult-currentheight":  "The current height of the peer",
 	"getpeerinforesult-banscore":       "The ban score",
 	"getpeerinforesult-syncnode":       "Whether or not the peer is the sync peer",
+	"getpeerinforesult-synconly":       "Whether or not the peer is one of the peers configured with --synconly, which are the only peers blocks are accepted from when any are configured",
 
 	// GetPeerInfoCmd help.
 	"getpeerinfo--synopsis": "Returns data about each connected network peer as an array of json objects.",
 
 	// GetRawMempoolVerboseResult help.
+	"getrawmempoolverboseresult-txid":             "The hash of the transaction (only for ordered results)",
 	"getrawmempoolverboseresult-size":             "Transaction size in bytes",
 	"getrawmempoolverboseresult-fee":              "Transaction fee in EXCC",
 	"getrawmempoolverboseresult-time":             "Local time transaction entered pool in seconds since 1 Jan 1970 GMT",
@@ -632,18 +915,44 @@ var helpDescsEnUS = map[string]string{
 	"getrawmempool--synopsis":   "Returns information about all of the transactions currently in the memory pool.",
 	"getrawmempool-verbose":     "Returns JSON object when true or an array of transaction hashes when false",
 	"getrawmempool-txtype":      "Type of tx to return. (all/regular/tickets/votes/revocations)",
+	"getrawmempool-sortby":      "Order to return the transactions in (feerate/age/size); feerate and size are highest first and age is oldest first",
+	"getrawmempool-skip":        "The number of transactions to skip in the selected order",
+	"getrawmempool-count":       "The maximum number of transactions to return, or 0 for all of them",
 	"getrawmempool--condition0": "verbose=false",
 	"getrawmempool--condition1": "verbose=true",
+	"getrawmempool--condition2": "verbose=true and sortby, skip, or count is set",
 	"getrawmempool--result0":    "Array of transaction hashes",
 
 	// GetRawTransactionCmd help.
 	"getrawtransaction--synopsis":   "Returns information about a transaction given its hash.",
 	"getrawtransaction-txid":        "The hash of the transaction",
-	"getrawtransaction-verbose":     "Specifies the transaction is returned as a JSON object instead of a hex-encoded string",
+	"getrawtransaction-verbose":     "Specifies the transaction is returned as a JSON object instead of a hex-encoded string -- A value of 2 also includes the previous output spent by each input, which is found in the memory pool, the unspent outputs, or the transaction index for spent outputs (--txindex)",
 	"getrawtransaction--condition0": "verbose=false",
 	"getrawtransaction--condition1": "verbose=true",
 	"getrawtransaction--result0":    "Hex-encoded bytes of the serialized transaction",
 
+	// GetTicketInfoCmd help.
+	"getticketinfo--synopsis": "Returns the lifecycle of the provided ticket (requires --ticketindex).",
+	"getticketinfo-txhash":    "The hash of the ticket",
+
+	// GetTicketsInfoCmd help.
+	"getticketsinfo--synopsis": "Returns the lifecycles of up to 2000 tickets in the same order as requested (requires --ticketindex).  Tickets which are not known have an unknown status.",
+	"getticketsinfo-txhashes":  "The hashes of the tickets",
+
+	// GetTicketPoolStatsCmd help.
+	"getticketpoolstats--synopsis": "Returns the size and value of the ticket pool, the average ticket price, and the percentage of the coin supply locked in tickets for recent blocks, ordered from the best block backwards.",
+	"getticketpoolstats-blocks":    "The number of most recent blocks to report on, up to 8192",
+	"getticketpoolstats-interval":  "The number of blocks between each reported block",
+
+	// TicketPoolStatsResult help.
+	"ticketpoolstatsresult-height":        "The height of the block",
+	"ticketpoolstatsresult-hash":          "The hash of the block",
+	"ticketpoolstatsresult-poolsize":      "The number of live tickets after the block was connected",
+	"ticketpoolstatsresult-poolvalue":     "The total value locked in live tickets in EXCC",
+	"ticketpoolstatsresult-averageprice":  "The average price of the live tickets in EXCC",
+	"ticketpoolstatsresult-coinsupply":    "The total coin supply as of the block in EXCC",
+	"ticketpoolstatsresult-lockedpercent": "The percentage of the coin supply locked in live tickets",
+
 	// GetTicketPoolValue help.
 	"getticketpoolvalue--synopsis": "Return the current value of all locked funds in the ticket pool",
 	"getticketpoolvalue--result0":  "Total value of ticket pool",
@@ -662,6 +971,19 @@ var helpDescsEnUS = map[string]string{
 	"gettxout-vout":           "The index of the output",
 	"gettxout-includemempool": "Include the mempool when true",
 
+	// GetTxOutSetInfoCmd help.
+	"gettxoutsetinfo--synopsis": "Returns statistics about the unspent transaction output set along with a hash which commits to its contents.\n" +
+		"The statistics are maintained as blocks are connected and disconnected, so the utxo set is not scanned.",
+
+	// GetTxOutSetInfoResult help.
+	"gettxoutsetinforesult-height":       "The height of the best block the statistics are for",
+	"gettxoutsetinforesult-bestblock":    "The hash of the best block the statistics are for",
+	"gettxoutsetinforesult-transactions": "The number of transactions with unspent outputs",
+	"gettxoutsetinforesult-txouts":       "The number of unspent transaction outputs",
+	"gettxoutsetinforesult-utxosethash":  "The hex-encoded muhash of the unspent transaction outputs, which does not depend on how they are stored and may be compared across nodes",
+	"gettxoutsetinforesult-disksize":     "The size of the serialized utxo set in bytes",
+	"gettxoutsetinforesult-totalamount":  "The total amount of the unspent transaction outputs in atoms",
+
 	// GetWorkResult help.
 	"getworkresult-data":     "Hex-encoded block data",
 	"getworkresult-hash1":    "(DEPRECATED) Hex-encoded formatted hash buffer",
@@ -693,6 +1015,23 @@ var helpDescsEnUS = map[string]string{
 	// RebroadcastWinnerCmd help.
 	"rebroadcastwinners--synopsis": "Asks the daemon to rebroadcast the winners of the voting lottery.\n",
 
+	// RegenerateCertCmd help.
+	"regeneratecert--synopsis": "Generates a new certificate and key for the RPC server according to the rpccerthost, rpccertkeytype, and rpccertvalidity options, replaces the certificate and key files, and presents the new certificate to clients which connect afterwards without restarting the server.\n" +
+		"Clients must be configured with the new certificate to connect again.",
+	"regeneratecert--result0": "The new PEM-encoded certificate",
+
+	// ReloadConfigCmd help.
+	"reloadconfig--synopsis": "Reloads the config file and applies the options which may be changed without restarting the server.\n" +
+		"These are debuglevel, banduration, banthreshold, whitelist, miningaddr, getworkkey, minrelaytxfee, rpccerthost, rpccertkeytype, and rpccertvalidity.\n" +
+		"Command line options continue to take precedence over the config file, and changes to other options are ignored until the server is restarted.",
+	"reloadconfig--result0": "The names of the options which changed",
+
+	// RotateLogsCmd help.
+	"rotatelogs--synopsis": "Flushes the log files of the server to disk and rolls them, regardless of their size and age, so logging continues in new files.\n" +
+		"This includes the files of the subsystems logged to their own file.\n" +
+		"Empty log files are not rolled.",
+	"rotatelogs--result0": "The paths of the rolled log files, which are compressed in the background when log compression is enabled",
+
 	// SearchRawTransactionsCmd help.
 	"searchrawtransactions--synopsis": "Returns raw data for transactions involving the passed address.\n" +
 		"Returned transactions are pulled from both the database, and transactions currently in the mempool.\n" +
@@ -713,37 +1052,107 @@ var helpDescsEnUS = map[string]string{
 	// SendRawTransactionCmd help.
 	"sendrawtransaction--synopsis":     "Submits the serialized, hex-encoded transaction to the local peer and relays it to the network.",
 	"sendrawtransaction-hextx":         "Serialized, hex-encoded signed transaction",
-	"sendrawtransaction-allowhighfees": "Whether or not to allow insanely high fees (exccd does not yet implement this parameter, so it has no effect)",
+	"sendrawtransaction-allowhighfees": "Whether or not to allow insanely high fees, which disables the maxfeerate check",
+	"sendrawtransaction-maxfeerate":    "The maximum fee rate in EXCC/kB the transaction may pay -- Transactions paying more are rejected unless allowhighfees is set (0 to disable)",
 	"sendrawtransaction--result0":      "The hash of the transaction",
 
+	// SendRawTransactionAndWaitCmd help.
+	"sendrawtransactionandwait--synopsis":     "Submits the serialized, hex-encoded transaction to the local peer, relays it to the network, and waits until it is mined in a block of the main chain with the requested number of confirmations.",
+	"sendrawtransactionandwait-hextx":         "Serialized, hex-encoded signed transaction",
+	"sendrawtransactionandwait-confirmations": "The number of confirmations to wait for (0 to return without waiting)",
+	"sendrawtransactionandwait-timeout":       "The maximum number of seconds to wait for before returning the current confirmations (0 or more than 3600 to wait for 3600 seconds)",
+	"sendrawtransactionandwait-allowhighfees": "Whether or not to allow insanely high fees, which disables the maxfeerate check",
+	"sendrawtransactionandwait-maxfeerate":    "The maximum fee rate in EXCC/kB the transaction may pay -- Transactions paying more are rejected unless allowhighfees is set (0 to disable)",
+
+	// SendRawTransactionAndWaitResult help.
+	"sendrawtransactionandwaitresult-txid":          "The hash of the transaction",
+	"sendrawtransactionandwaitresult-blockhash":     "The hash of the block of the main chain which includes the transaction (only when it was mined)",
+	"sendrawtransactionandwaitresult-blockheight":   "The height of the block which includes the transaction (only when it was mined)",
+	"sendrawtransactionandwaitresult-confirmations": "The number of confirmations of the transaction, which is lower than requested when the timeout expired first",
+
 	// SetGenerateCmd help.
 	"setgenerate--synopsis":    "Set the server to generate coins (mine) or not.",
 	"setgenerate-generate":     "Use true to enable generation, false to disable it",
 	"setgenerate-genproclimit": "The number of processors (cores) to limit generation to or -1 for default",
 	"setgenerate-miningaddr":   "The mining address",
 
+	// SetMockTimeCmd help.
+	"setmocktime--synopsis": "Sets the current time of the server on simnet and regnet so simulations can fast-forward it deterministically.\n" +
+		"The time only advances when it is set again, which also completes any waits of the CPU miner that are due.",
+	"setmocktime-time": "The time to use in seconds since 1 Jan 1970 GMT or 0 to go back to using the local clock",
+
+	// SignMessageWithPrivKeyCmd help.
+	"signmessagewithprivkey--synopsis": "Sign a message with the provided private key without storing it.\n" +
+		"The signature may be verified against the pay-to-pubkey-hash address of the key with verifymessage.",
+	"signmessagewithprivkey-privkey":  "The WIF-encoded secp256k1 private key to sign with",
+	"signmessagewithprivkey-message":  "The message to sign",
+	"signmessagewithprivkey--result0": "The base-64 encoded signature of the message",
+
+	// SignRawTransactionWithKeyCmd help.
+	"signrawtransactionwithkey--synopsis": "Signs the inputs of a raw transaction using the provided private keys without storing them.\n" +
+		"The public key scripts of the outputs spent by the transaction are looked up in the memory pool and the main chain unless they are provided.",
+	"signrawtransactionwithkey-rawtx":       "Hex-encoded serialized transaction to sign",
+	"signrawtransactionwithkey-privkeys":    "The WIF-encoded private keys to sign with",
+	"signrawtransactionwithkey-inputs":      "The outputs spent by the transaction along with the redeem scripts of pay-to-script-hash outputs",
+	"signrawtransactionwithkey-sighashtype": "The signature hash type (ALL, NONE, or SINGLE, optionally combined with ANYONECANPAY, e.g. 'ALL|ANYONECANPAY')",
+
+	// RawTxInput help.
+	"rawtxinput-txid":         "The hash of the transaction which contains the output",
+	"rawtxinput-vout":         "The index of the output",
+	"rawtxinput-tree":         "The tree of the transaction which contains the output",
+	"rawtxinput-scriptPubKey": "The hex-encoded public key script of the output",
+	"rawtxinput-redeemScript": "The hex-encoded redeem script when the output is a pay-to-script-hash output",
+
+	// SignRawTransactionResult help.
+	"signrawtransactionresult-hex":      "Hex-encoded serialized transaction with the signatures that were added",
+	"signrawtransactionresult-complete": "Whether or not all inputs of the transaction are signed",
+	"signrawtransactionresult-errors":   "Errors for the inputs which could not be signed or verified",
+
+	// SignRawTransactionError help.
+	"signrawtransactionerror-txid":      "The hash of the transaction which contains the output spent by the input",
+	"signrawtransactionerror-vout":      "The index of the output spent by the input",
+	"signrawtransactionerror-scriptSig": "The hex-encoded signature script of the input",
+	"signrawtransactionerror-sequence":  "The sequence number of the input",
+	"signrawtransactionerror-error":     "The reason the input could not be signed or verified",
+
 	// StopCmd help.
 	"stop--synopsis": "Shutdown exccd.",
 	"stop--result0":  "The string 'exccd stopping.'",
 
 	// SubmitBlockOptions help.
-	"submitblockoptions-workid": "This parameter is currently ignored",
+	"submitblockoptions-workid": "The workid provided with the block template the block was created from, to log whether it only makes the allowed mutations to it",
 
 	// SubmitBlockCmd help.
 	"submitblock--synopsis":   "Attempts to submit a new serialized, hex-encoded block to the network.",
 	"submitblock-hexblock":    "Serialized, hex-encoded block",
-	"submitblock-options":     "This parameter is currently ignored",
+	"submitblock-options":     "Options for the submission",
 	"submitblock--condition0": "Block successfully submitted",
 	"submitblock--condition1": "Block rejected",
 	"submitblock--result1":    "The reason the block was rejected",
 
 	// ValidateAddressResult help.
-	"validateaddresschainresult-isvalid": "Whether or not the address is valid",
-	"validateaddresschainresult-address": "The ExchangeCoin address (only when isvalid is true)",
+	"validateaddresschainresult-isvalid":      "Whether or not the address is valid",
+	"validateaddresschainresult-address":      "The ExchangeCoin address (only when isvalid is true)",
+	"validateaddresschainresult-network":      "The name of the network of the address (only when isvalid is true)",
+	"validateaddresschainresult-scripttype":   "The type of script the address pays to (pubkey, pubkeyalt, pubkeyhash, pubkeyhashalt, or scripthash)",
+	"validateaddresschainresult-isscript":     "Whether or not the address pays to a script hash",
+	"validateaddresschainresult-iscompressed": "Whether or not the address is for a compressed public key (only known for public key addresses)",
+	"validateaddresschainresult-sigsrequired": "The number of signatures required to spend outputs paying to the address (only known for script hash addresses when the redeem script is provided)",
+	"validateaddresschainresult-script":       "The type of the provided redeem script",
+	"validateaddresschainresult-addresses":    "The addresses involved in the provided redeem script",
+
+	// UptimeCmd help.
+	"uptime--synopsis": "Returns the number of seconds since the server was started.",
+	"uptime--result0":  "The number of seconds since the server was started",
 
 	// ValidateAddressCmd help.
-	"validateaddress--synopsis": "Verify an address is valid.",
-	"validateaddress-address":   "ExchangeCoin address to validate",
+	"validateaddress--synopsis":    "Verify an address is valid and return details about the script it pays to.",
+	"validateaddress-address":      "ExchangeCoin address to validate",
+	"validateaddress-redeemscript": "Hex-encoded redeem script of a pay-to-script-hash address which is used to report the required signatures when it hashes to the address",
+
+	// ValidateAddressesCmd help.
+	"validateaddresses--synopsis": "Verify many addresses are valid in a single request and return details about the scripts they pay to.",
+	"validateaddresses-addresses": "ExchangeCoin addresses to validate",
 
 	// VerifyChainCmd help.
 	"verifychain--synopsis": "Verifies the block chain database.\n" +
@@ -757,7 +1166,7 @@ var helpDescsEnUS = map[string]string{
 
 	// VerifyMessageCmd help.
 	"verifymessage--synopsis": "Verify a signed message.",
-	"verifymessage-address":   "The ExchangeCoin address to use for the signature",
+	"verifymessage-address":   "The ExchangeCoin pay-to-pubkey-hash address, in either base58 or bech32 encoding, to use for the signature",
 	"verifymessage-signature": "The base-64 encoded signature provided by the signer",
 	"verifymessage-message":   "The signed message",
 	"verifymessage--result0":  "Whether or not the signature verified",
@@ -777,6 +1186,13 @@ var helpDescsEnUS = map[string]string{
 	// NotifyStakeDifficultyCmd help
 	"notifystakedifficulty--synopsis": "Request notifications for whenever stake difficulty goes up.",
 
+	// NotifySyncProgressCmd help.
+	"notifysyncprogress--synopsis": "Request a syncprogress notification every 10 seconds while the chain is syncing and once more when the sync completes.  The current progress of the sync is returned.",
+
+	// NotifyTicketStatusCmd help.
+	"notifyticketstatus--synopsis": "Request a ticketstatuschanged notification whenever a connected or disconnected block changes the status of any of the provided tickets (requires --ticketindex).  The current state of the tickets is returned.",
+	"notifyticketstatus-txhashes":  "The hashes of the tickets to watch",
+
 	// NotifyWinningTicketsCmd help
 	"notifywinningtickets--synopsis": "Request notifications for whenever any tickets is chosen to vote.",
 
@@ -786,6 +1202,19 @@ var helpDescsEnUS = map[string]string{
 	// StopNotifyBlocksCmd help.
 	"stopnotifyblocks--synopsis": "Cancel registered notifications for whenever a block is connected or disconnected from the main (best) chain.",
 
+	// StopNotifySpentAndMissedTicketsCmd help.
+	"stopnotifyspentandmissedtickets--synopsis": "Cancel registered notifications for whenever tickets are spent or missed.",
+
+	// StopNotifySyncProgressCmd help.
+	"stopnotifysyncprogress--synopsis": "Cancel registered notifications for the progress of the chain sync.",
+
+	// StopNotifyTicketStatusCmd help.
+	"stopnotifyticketstatus--synopsis": "Stop watching the provided tickets for status changes, or all watched tickets when none are provided.",
+	"stopnotifyticketstatus-txhashes":  "The hashes of the tickets to stop watching",
+
+	// StopNotifyWinningTicketsCmd help.
+	"stopnotifywinningtickets--synopsis": "Cancel registered notifications for whenever tickets are chosen to vote.",
+
 	// NotifyNewTransactionsCmd help.
 	"notifynewtransactions--synopsis": "Send either a txaccepted or a txacceptedverbose notification when a new transaction is accepted into the mempool.",
 	"notifynewtransactions-verbose":   "Specifies which type of notification to receive. If verbose is true, then the caller receives txacceptedverbose, otherwise the caller receives txaccepted",
@@ -808,6 +1237,20 @@ var helpDescsEnUS = map[string]string{
 	"rescan--synopsis":   "Rescan blocks for transactions matching the loaded transaction filter.",
 	"rescan-blockhashes": "Concatenated block hashes to rescan.  Each next block must be a child of the previous.",
 
+	// RescanRangeCmd help.
+	"rescanrange--synopsis":   "Rescan the main chain blocks in a range for transactions paying to the provided addresses or spending the provided outpoints, and for transactions spending the outputs paying to the addresses which are found along the way (unavailable with --nocfilters).  Blocks whose committed filter does not match are skipped, and each block with relevant transactions is sent in a rescannedblock notification before the result is returned.  A rescanprogress notification is sent every 10 seconds while the rescan is underway.",
+	"rescanrange-addresses":   "The addresses to rescan for",
+	"rescanrange-outpoints":   "The outpoints to rescan for spending transactions",
+	"rescanrange-startheight": "The height of the first block to rescan",
+	"rescanrange-endheight":   "The height of the last block to rescan (default: the current best block)",
+
+	// RescanRangeResult help.
+	"rescanrangeresult-hash":          "The hash of the last rescanned block",
+	"rescanrangeresult-height":        "The height of the last rescanned block",
+	"rescanrangeresult-blocksscanned": "The number of blocks in the range",
+	"rescanrangeresult-blocksfetched": "The number of blocks whose committed filter matched and which were fetched",
+	"rescanrangeresult-blocksmatched": "The number of blocks with relevant transactions, which were sent in rescannedblock notifications",
+
 	// -------- ExchangeCoin-specific help --------
 
 	// EstimateFee help.
@@ -815,6 +1258,58 @@ var helpDescsEnUS = map[string]string{
 	"estimatefee-numblocks": "(unused)",
 	"estimatefee--result0":  "Estimated fee.",
 
+	// DebugProfileCmd help.
+	"debugprofile--synopsis": "Writes diagnostics of the server to a file on the server.\n" +
+		"The kinds are 'goroutine' for the stack traces of all goroutines, 'heap' for a heap profile, 'cpu' for a CPU profile, and 'trace' for an execution trace which shows the work done to validate blocks along with everything else the server does.\n" +
+		"CPU profiles and execution traces are captured for the requested number of seconds, or until the client disconnects, before the command returns.\n" +
+		"The profiles are in the formats read by 'go tool pprof' and 'go tool trace' and an existing file is never overwritten.",
+	"debugprofile-kind":    "The kind of diagnostics to capture (goroutine, heap, cpu, or trace)",
+	"debugprofile-path":    "The path of the file to write, which is relative to the data directory unless it is absolute",
+	"debugprofile-seconds": "The number of seconds to capture a CPU profile or execution trace for (1 to 300)",
+
+	// DebugProfileResult help.
+	"debugprofileresult-kind":    "The kind of diagnostics captured",
+	"debugprofileresult-path":    "The path of the written file",
+	"debugprofileresult-size":    "The size of the written file in bytes",
+	"debugprofileresult-seconds": "The number of seconds the CPU profile or execution trace was captured for",
+
+	// DebugScriptCmd help.
+	"debugscript--synopsis":           "Executes the scripts which redeem a transaction input one opcode at a time and returns the state of the script engine after each step.",
+	"debugscript-hextx":               "Serialized, hex-encoded transaction",
+	"debugscript-index":               "The index of the input to debug",
+	"debugscript-pkscript":            "The hex-encoded public key script of the output spent by the input (default: looked up in the memory pool and main chain)",
+	"debugscript-scriptversion":       "The script version of the provided public key script",
+	"debugscriptresult-pkscript":      "The hex-encoded public key script of the spent output",
+	"debugscriptresult-scriptversion": "The script version of the spent output",
+	"debugscriptresult-valid":         "Whether or not the input successfully redeems the output",
+	"debugscriptresult-error":         "The reason the scripts failed to execute",
+	"debugscriptresult-steps":         "The state of the script engine after every executed opcode",
+	"debugscriptstep-script":          "The executed script (0 = signature script, 1 = public key script, 2 = pay-to-script-hash redeem script)",
+	"debugscriptstep-offset":          "The position of the executed opcode in the script",
+	"debugscriptstep-opcode":          "The disassembly of the executed opcode",
+	"debugscriptstep-stack":           "The hex-encoded items of the data stack after executing the opcode, top item last",
+	"debugscriptstep-altstack":        "The hex-encoded items of the alternate stack after executing the opcode, top item last",
+	"debugscriptstep-error":           "The error that resulted from executing the opcode",
+
+	// DNSSeedCmd help.
+	"dnsseed--synopsis":    "Attempts to add, remove, or query a DNS seed used to discover peers.",
+	"dnsseed-host":         "Hostname of the DNS seed to operate on",
+	"dnsseed-subcmd":       "'add' to add a DNS seed, 'remove' to remove a DNS seed, or 'seed' to query a DNS seed for peers",
+	"dnsseed-hasfiltering": "Whether or not the added DNS seed supports filtering by service flags",
+
+	// DumpTxOutSetCmd help.
+	"dumptxoutset--synopsis": "Writes every unspent transaction output to a file on the server in a deterministic format which ends with a sha256 commitment to its contents.\n" +
+		"The file reflects the utxo set as of the best block when the command is issued and an existing file is never overwritten.",
+	"dumptxoutset-path": "The path of the file to write, which is relative to the data directory unless it is absolute",
+
+	// DumpTxOutSetResult help.
+	"dumptxoutsetresult-path":        "The path of the written file",
+	"dumptxoutsetresult-hash":        "The hash of the best block the utxo set was dumped at",
+	"dumptxoutsetresult-height":      "The height of the best block the utxo set was dumped at",
+	"dumptxoutsetresult-numoutputs":  "The number of unspent transaction outputs written",
+	"dumptxoutsetresult-totalamount": "The total amount of the unspent transaction outputs in atoms",
+	"dumptxoutsetresult-commitment":  "The hex-encoded sha256 hash of the contents of the file preceding the commitment",
+
 	// EstimateStakeDiff help.
 	"estimatestakediff--synopsis":      "Estimate the next minimum, maximum, expected, and user-specified stake difficulty",
 	"estimatestakediff-tickets":        "Use this number of new tickets in blocks to estimate the next difficulty",
@@ -910,102 +1405,142 @@ var helpDescsEnUS = map[string]string{
 	"version--synopsis":       "Returns the JSON-RPC API version (semver)",
 	"version--result0--desc":  "Version objects keyed by the program or API name",
 	"version--result0--key":   "Program or API name",
-	"version--result0--value": "Object containing the semantic version",
+	"version--result0--value": "Object containing the semantic version along with, for exccd, the git commit it was built from (when set at build time), the version of Go it was built with, and the optional features enabled in its configuration",
 }
 
 // rpcResultTypes specifies the result types that each RPC command can return.
 // This information is used to generate the help.  Each result type must be a
 // pointer to the type (or nil to indicate no return value).
 var rpcResultTypes = map[string][]interface{}{
-	"addnode":               nil,
-	"createrawsstx":         {(*string)(nil)},
-	"createrawssgentx":      {(*string)(nil)},
-	"createrawssrtx":        {(*string)(nil)},
-	"createrawtransaction":  {(*string)(nil)},
-	"debuglevel":            {(*string)(nil), (*string)(nil)},
-	"decoderawtransaction":  {(*exccjson.TxRawDecodeResult)(nil)},
-	"decodescript":          {(*exccjson.DecodeScriptResult)(nil)},
-	"estimatefee":           {(*float64)(nil)},
-	"estimatestakediff":     {(*exccjson.EstimateStakeDiffResult)(nil)},
-	"existsaddress":         {(*bool)(nil)},
-	"existsaddresses":       {(*string)(nil)},
-	"existsmissedtickets":   {(*string)(nil)},
-	"existsexpiredtickets":  {(*string)(nil)},
-	"existsliveticket":      {(*bool)(nil)},
-	"existslivetickets":     {(*string)(nil)},
-	"existsmempooltxs":      {(*string)(nil)},
-	"getaddednodeinfo":      {(*[]string)(nil), (*[]exccjson.GetAddedNodeInfoResult)(nil)},
-	"getbestblock":          {(*exccjson.GetBestBlockResult)(nil)},
-	"generate":              {(*[]string)(nil)},
-	"getbestblockhash":      {(*string)(nil)},
-	"getblock":              {(*string)(nil), (*exccjson.GetBlockVerboseResult)(nil)},
-	"getblockcount":         {(*int64)(nil)},
-	"getblockhash":          {(*string)(nil)},
-	"getblockheader":        {(*string)(nil), (*exccjson.GetBlockHeaderVerboseResult)(nil)},
-	"getblocksubsidy":       {(*exccjson.GetBlockSubsidyResult)(nil)},
-	"getblocktemplate":      {(*exccjson.GetBlockTemplateResult)(nil), (*string)(nil), nil},
-	"getcfilter":            {(*string)(nil)},
-	"getcfilterheader":      {(*string)(nil)},
-	"getchaintips":          {(*[]exccjson.GetChainTipsResult)(nil)},
-	"getconnectioncount":    {(*int32)(nil)},
-	"getcurrentnet":         {(*uint32)(nil)},
-	"getdifficulty":         {(*float64)(nil)},
-	"getstakedifficulty":    {(*exccjson.GetStakeDifficultyResult)(nil)},
-	"getstakeversioninfo":   {(*exccjson.GetStakeVersionInfoResult)(nil)},
-	"getstakeversions":      {(*exccjson.GetStakeVersionsResult)(nil)},
-	"getgenerate":           {(*bool)(nil)},
-	"gethashespersec":       {(*float64)(nil)},
-	"getheaders":            {(*exccjson.GetHeadersResult)(nil)},
-	"getinfo":               {(*exccjson.InfoChainResult)(nil)},
-	"getmempoolinfo":        {(*exccjson.GetMempoolInfoResult)(nil)},
-	"getmininginfo":         {(*exccjson.GetMiningInfoResult)(nil)},
-	"getnettotals":          {(*exccjson.GetNetTotalsResult)(nil)},
-	"getnetworkhashps":      {(*int64)(nil)},
-	"getpeerinfo":           {(*[]exccjson.GetPeerInfoResult)(nil)},
-	"getrawmempool":         {(*[]string)(nil), (*exccjson.GetRawMempoolVerboseResult)(nil)},
-	"getrawtransaction":     {(*string)(nil), (*exccjson.TxRawResult)(nil)},
-	"getticketpoolvalue":    {(*float64)(nil)},
-	"gettxout":              {(*exccjson.GetTxOutResult)(nil)},
-	"getvoteinfo":           {(*exccjson.GetVoteInfoResult)(nil)},
-	"getwork":               {(*exccjson.GetWorkResult)(nil), (*bool)(nil)},
-	"getcoinsupply":         {(*int64)(nil)},
-	"help":                  {(*string)(nil), (*string)(nil)},
-	"livetickets":           {(*exccjson.LiveTicketsResult)(nil)},
-	"missedtickets":         {(*exccjson.MissedTicketsResult)(nil)},
-	"node":                  nil,
-	"ping":                  nil,
-	"rebroadcastmissed":     nil,
-	"rebroadcastwinners":    nil,
-	"searchrawtransactions": {(*string)(nil), (*[]exccjson.SearchRawTransactionsResult)(nil)},
-	"sendrawtransaction":    {(*string)(nil)},
-	"setgenerate":           nil,
-	"stop":                  {(*string)(nil)},
-	"submitblock":           {nil, (*string)(nil)},
-	"ticketfeeinfo":         {(*exccjson.TicketFeeInfoResult)(nil)},
-	"ticketsforaddress":     {(*exccjson.TicketsForAddressResult)(nil)},
-	"ticketvwap":            {(*float64)(nil)},
-	"txfeeinfo":             {(*exccjson.TxFeeInfoResult)(nil)},
-	"validateaddress":       {(*exccjson.ValidateAddressChainResult)(nil)},
-	"verifychain":           {(*bool)(nil)},
-	"verifymessage":         {(*bool)(nil)},
-	"version":               {(*map[string]exccjson.VersionResult)(nil)},
+	"addnode":                   nil,
+	"analyzescript":             {(*exccjson.AnalyzeScriptResult)(nil)},
+	"createmultisig":            {(*exccjson.CreateMultiSigResult)(nil)},
+	"createrawsstx":             {(*string)(nil)},
+	"createrawssgentx":          {(*string)(nil)},
+	"createrawssrtx":            {(*string)(nil)},
+	"createrawtransaction":      {(*string)(nil)},
+	"debuglevel":                {(*string)(nil), (*string)(nil)},
+	"debugprofile":              {(*exccjson.DebugProfileResult)(nil)},
+	"debugscript":               {(*exccjson.DebugScriptResult)(nil)},
+	"dnsseed":                   nil,
+	"decoderawtransaction":      {(*exccjson.TxRawDecodeResult)(nil)},
+	"decodescript":              {(*exccjson.DecodeScriptResult)(nil)},
+	"estimatefee":               {(*float64)(nil)},
+	"dumptxoutset":              {(*exccjson.DumpTxOutSetResult)(nil)},
+	"estimatestakediff":         {(*exccjson.EstimateStakeDiffResult)(nil)},
+	"existsaddress":             {(*bool)(nil)},
+	"existsaddresses":           {(*string)(nil)},
+	"existsmissedtickets":       {(*string)(nil)},
+	"existsexpiredtickets":      {(*string)(nil)},
+	"existsliveticket":          {(*bool)(nil)},
+	"existslivetickets":         {(*string)(nil)},
+	"existsmempooltxs":          {(*string)(nil)},
+	"getaddednodeinfo":          {(*[]string)(nil), (*[]exccjson.GetAddedNodeInfoResult)(nil)},
+	"getaddresstickets":         {(*[]exccjson.TicketInfoResult)(nil)},
+	"getagendas":                {(*[]exccjson.GetAgendasResult)(nil)},
+	"getbestblock":              {(*exccjson.GetBestBlockResult)(nil)},
+	"fundrawtransaction":        {(*exccjson.FundRawTransactionResult)(nil)},
+	"generate":                  {(*[]string)(nil)},
+	"getbestblockhash":          {(*string)(nil)},
+	"getblock":                  {(*string)(nil), (*exccjson.GetBlockVerboseResult)(nil)},
+	"getblockcount":             {(*int64)(nil)},
+	"getblockhash":              {(*string)(nil)},
+	"getblockhashbytime":        {(*[]exccjson.GetBlockHashByTimeResult)(nil)},
+	"getblockheader":            {(*string)(nil), (*exccjson.GetBlockHeaderVerboseResult)(nil)},
+	"getblocksubsidy":           {(*exccjson.GetBlockSubsidyResult)(nil)},
+	"getblocktemplate":          {(*exccjson.GetBlockTemplateResult)(nil), (*string)(nil), nil},
+	"getcfilter":                {(*string)(nil)},
+	"getcfilterheader":          {(*string)(nil)},
+	"getchaintips":              {(*[]exccjson.GetChainTipsResult)(nil)},
+	"getconnectioncount":        {(*int32)(nil)},
+	"getcurrentnet":             {(*uint32)(nil)},
+	"getdifficulty":             {(*float64)(nil)},
+	"getdifficultyhistory":      {(*[]exccjson.DifficultyHistoryResult)(nil)},
+	"getstakedifficulty":        {(*exccjson.GetStakeDifficultyResult)(nil)},
+	"getstakeversioninfo":       {(*exccjson.GetStakeVersionInfoResult)(nil)},
+	"getstakeversions":          {(*exccjson.GetStakeVersionsResult)(nil)},
+	"getsyncinfo":               {(*exccjson.GetSyncInfoResult)(nil)},
+	"getgenerate":               {(*bool)(nil)},
+	"gethashespersec":           {(*float64)(nil)},
+	"getheaders":                {(*exccjson.GetHeadersResult)(nil)},
+	"getdnsseedinfo":            {(*[]exccjson.GetDNSSeedInfoResult)(nil)},
+	"getemissionschedule":       {(*exccjson.GetEmissionScheduleResult)(nil)},
+	"getindexinfo":              {(*map[string]exccjson.GetIndexInfoResult)(nil)},
+	"getminedblockstats":        {(*exccjson.GetMinedBlockStatsResult)(nil)},
+	"getminingarchive":          {(*[]exccjson.MiningArchiveResult)(nil)},
+	"getsigcacheinfo":           {(*exccjson.GetSigCacheInfoResult)(nil)},
+	"getinfo":                   {(*exccjson.InfoChainResult)(nil)},
+	"getmempoolinfo":            {(*exccjson.GetMempoolInfoResult)(nil)},
+	"getmininginfo":             {(*exccjson.GetMiningInfoResult)(nil)},
+	"getnettotals":              {(*exccjson.GetNetTotalsResult)(nil)},
+	"getnetworkhashps":          {(*int64)(nil)},
+	"getnetworksolps":           {(*exccjson.GetNetworkSolPSResult)(nil)},
+	"getnetworkstakeinfo":       {(*exccjson.NetworkStakeInfoResult)(nil)},
+	"getpeerinfo":               {(*[]exccjson.GetPeerInfoResult)(nil)},
+	"getrawmempool":             {(*[]string)(nil), (*exccjson.GetRawMempoolVerboseResult)(nil), (*[]exccjson.GetRawMempoolVerboseResult)(nil)},
+	"getrawtransaction":         {(*string)(nil), (*exccjson.TxRawResult)(nil)},
+	"getticketinfo":             {(*exccjson.TicketInfoResult)(nil)},
+	"getticketpoolstats":        {(*[]exccjson.TicketPoolStatsResult)(nil)},
+	"getticketsinfo":            {(*[]exccjson.TicketInfoResult)(nil)},
+	"getticketpoolvalue":        {(*float64)(nil)},
+	"gettxout":                  {(*exccjson.GetTxOutResult)(nil)},
+	"gettxoutsetinfo":           {(*exccjson.GetTxOutSetInfoResult)(nil)},
+	"getvoteinfo":               {(*exccjson.GetVoteInfoResult)(nil)},
+	"getwork":                   {(*exccjson.GetWorkResult)(nil), (*bool)(nil)},
+	"getcoinsupply":             {(*int64)(nil)},
+	"help":                      {(*string)(nil), (*string)(nil)},
+	"livetickets":               {(*exccjson.LiveTicketsResult)(nil)},
+	"missedtickets":             {(*exccjson.MissedTicketsResult)(nil)},
+	"node":                      nil,
+	"ping":                      nil,
+	"rebroadcastmissed":         nil,
+	"rebroadcastwinners":        nil,
+	"regeneratecert":            {(*string)(nil)},
+	"reloadconfig":              {(*[]string)(nil)},
+	"rotatelogs":                {(*[]string)(nil)},
+	"searchrawtransactions":     {(*string)(nil), (*[]exccjson.SearchRawTransactionsResult)(nil)},
+	"sendrawtransaction":        {(*string)(nil)},
+	"sendrawtransactionandwait": {(*exccjson.SendRawTransactionAndWaitResult)(nil)},
+	"setgenerate":               nil,
+	"setmocktime":               nil,
+	"signmessagewithprivkey":    {(*string)(nil)},
+	"signrawtransactionwithkey": {(*exccjson.SignRawTransactionResult)(nil)},
+	"stop":                      {(*string)(nil)},
+	"submitblock":               {nil, (*string)(nil)},
+	"ticketfeeinfo":             {(*exccjson.TicketFeeInfoResult)(nil)},
+	"ticketsforaddress":         {(*exccjson.TicketsForAddressResult)(nil)},
+	"ticketvwap":                {(*float64)(nil)},
+	"txfeeinfo":                 {(*exccjson.TxFeeInfoResult)(nil)},
+	"uptime":                    {(*int64)(nil)},
+	"validateaddress":           {(*exccjson.ValidateAddressChainResult)(nil)},
+	"validateaddresses":         {(*[]exccjson.ValidateAddressChainResult)(nil)},
+	"verifychain":               {(*bool)(nil)},
+	"verifymessage":             {(*bool)(nil)},
+	"version":                   {(*map[string]exccjson.VersionResult)(nil)},
 
 	// Websocket commands.
-	"loadtxfilter":                nil,
-	"session":                     {(*exccjson.SessionResult)(nil)},
-	"notifywinningtickets":        nil,
-	"notifyspentandmissedtickets": nil,
-	"notifynewtickets":            nil,
-	"notifystakedifficulty":       nil,
-	"notifyblocks":                nil,
-	"notifynewtransactions":       nil,
-	"notifyreceived":              nil,
-	"notifyspent":                 nil,
-	"rescan":                      nil,
-	"stopnotifyblocks":            nil,
-	"stopnotifynewtransactions":   nil,
-	"stopnotifyreceived":          nil,
-	"stopnotifyspent":             nil,
+	"loadtxfilter":                    nil,
+	"session":                         {(*exccjson.SessionResult)(nil)},
+	"notifywinningtickets":            nil,
+	"notifyspentandmissedtickets":     nil,
+	"notifynewtickets":                nil,
+	"notifystakedifficulty":           nil,
+	"notifyblocks":                    nil,
+	"notifynewtransactions":           nil,
+	"notifysyncprogress":              {(*exccjson.GetSyncInfoResult)(nil)},
+	"notifyticketstatus":              {(*[]exccjson.TicketInfoResult)(nil)},
+	"notifyreceived":                  nil,
+	"notifyspent":                     nil,
+	"rescan":                          nil,
+	"rescanrange":                     {(*exccjson.RescanRangeResult)(nil)},
+	"stopnotifyblocks":                nil,
+	"stopnotifynewtransactions":       nil,
+	"stopnotifyreceived":              nil,
+	"stopnotifyspent":                 nil,
+	"stopnotifyspentandmissedtickets": nil,
+	"stopnotifysyncprogress":          nil,
+	"stopnotifyticketstatus":          nil,
+	"stopnotifywinningtickets":        nil,
 }
 
 // helpCacher provides a concurrent safe type that provides help and usage for
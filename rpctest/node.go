@@ -15,12 +15,61 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"sync"
 	"time"
 
 	"github.com/EXCCoin/exccd/certgen"
 	rpc "github.com/EXCCoin/exccd/rpcclient"
 )
 
+var (
+	// compileMtx guards access to the executable path so that the project
+	// is only compiled once.
+	compileMtx sync.Mutex
+
+	// executablePath is the path to the compiled executable.  This is the
+	// empty string until exccd is compiled.  This should not be accessed
+	// directly; instead use the function exccdExecutablePath().
+	executablePath string
+)
+
+// exccdExecutablePath returns a path to the exccd executable to be used by the
+// test harnesses.  To ensure the code tests against the most up-to-date version
+// of exccd, this function compiles exccd the first time it is called.  After
+// that, the generated binary is used for subsequent test harnesses.  The
+// executable file is not cleaned up, but since it lives at a static path in a
+// temp directory, it is not a big deal.
+func exccdExecutablePath() (string, error) {
+	compileMtx.Lock()
+	defer compileMtx.Unlock()
+
+	// If exccd has already been compiled, just use that.
+	if len(executablePath) != 0 {
+		return executablePath, nil
+	}
+
+	testDir := filepath.Join(os.TempDir(), "exccd", "rpctest")
+	if err := os.MkdirAll(testDir, 0755); err != nil {
+		return "", err
+	}
+
+	// Build exccd and output an executable in a static temp path.
+	outputPath := filepath.Join(testDir, "exccd")
+	if runtime.GOOS == "windows" {
+		outputPath += ".exe"
+	}
+	cmd := exec.Command("go", "build", "-o", outputPath,
+		"github.com/EXCCoin/exccd")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("failed to build exccd: %v: %s", err,
+			output)
+	}
+
+	// Save executable path so future calls do not recompile.
+	executablePath = outputPath
+	return executablePath, nil
+}
+
 // nodeConfig contains all the args, and data required to launch a exccd process
 // and connect the rpc client to it.
 type nodeConfig struct {
@@ -45,6 +94,15 @@ type nodeConfig struct {
 
 // newConfig returns a newConfig with all default values.
 func newConfig(prefix, certFile, keyFile string, extra []string) (*nodeConfig, error) {
+	// Fall back to the exccd executable in the path when it can't be
+	// compiled, such as when the source is not available.
+	exccdPath, err := exccdExecutablePath()
+	if err != nil {
+		log.Printf("unable to compile exccd, using the one in the "+
+			"path: %v", err)
+		exccdPath = "exccd"
+	}
+
 	a := &nodeConfig{
 		listen:    "127.0.0.1:18555",
 		rpcListen: "127.0.0.1:18556",
@@ -53,7 +111,7 @@ func newConfig(prefix, certFile, keyFile string, extra []string) (*nodeConfig, e
 		extra:     extra,
 		prefix:    prefix,
 
-		exe:      "exccd",
+		exe:      exccdPath,
 		endpoint: "ws",
 		certFile: certFile,
 		keyFile:  keyFile,
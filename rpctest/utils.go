@@ -115,7 +115,7 @@ func ConnectNode(from *Harness, to *Harness) error {
 	}
 	numPeers := len(peerInfo)
 
-	targetAddr := to.node.config.listen
+	targetAddr := to.P2PAddress()
 	if err := from.Node.AddNode(targetAddr, rpcclient.ANAdd); err != nil {
 		return err
 	}
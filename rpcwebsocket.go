@@ -29,6 +29,8 @@ import (
 	"github.com/EXCCoin/exccd/chaincfg/chainhash"
 	"github.com/EXCCoin/exccd/exccjson"
 	"github.com/EXCCoin/exccd/exccutil"
+	"github.com/EXCCoin/exccd/gcs"
+	"github.com/EXCCoin/exccd/gcs/blockcf"
 	"github.com/EXCCoin/exccd/txscript"
 	"github.com/EXCCoin/exccd/wire"
 )
@@ -40,6 +42,15 @@ const (
 	// handler since notifications have their own queuing mechanism
 	// independent of the send channel buffer.
 	websocketSendBufferSize = 50
+
+	// maxWatchedTickets is the maximum number of tickets a websocket client
+	// may request status change notifications for.
+	maxWatchedTickets = 10000
+
+	// rescanProgressInterval is the minimum duration between the
+	// rescanprogress notifications sent while a rescanrange request is
+	// serviced.
+	rescanProgressInterval = 10 * time.Second
 )
 
 type semaphore chan struct{}
@@ -64,18 +75,25 @@ type wsCommandHandler func(*wsClient, interface{}) (interface{}, error)
 // causes a dependency loop.
 var wsHandlers map[string]wsCommandHandler
 var wsHandlersBeforeInit = map[string]wsCommandHandler{
-	"loadtxfilter":                handleLoadTxFilter,
-	"notifyblocks":                handleNotifyBlocks,
-	"notifywinningtickets":        handleWinningTickets,
-	"notifyspentandmissedtickets": handleSpentAndMissedTickets,
-	"notifynewtickets":            handleNewTickets,
-	"notifystakedifficulty":       handleStakeDifficulty,
-	"notifynewtransactions":       handleNotifyNewTransactions,
-	"session":                     handleSession,
-	"help":                        handleWebsocketHelp,
-	"rescan":                      handleRescan,
-	"stopnotifyblocks":            handleStopNotifyBlocks,
-	"stopnotifynewtransactions":   handleStopNotifyNewTransactions,
+	"loadtxfilter":                    handleLoadTxFilter,
+	"notifyblocks":                    handleNotifyBlocks,
+	"notifywinningtickets":            handleWinningTickets,
+	"notifyspentandmissedtickets":     handleSpentAndMissedTickets,
+	"notifynewtickets":                handleNewTickets,
+	"notifystakedifficulty":           handleStakeDifficulty,
+	"notifynewtransactions":           handleNotifyNewTransactions,
+	"notifysyncprogress":              handleNotifySyncProgress,
+	"notifyticketstatus":              handleNotifyTicketStatus,
+	"session":                         handleSession,
+	"help":                            handleWebsocketHelp,
+	"rescan":                          handleRescan,
+	"rescanrange":                     handleRescanRange,
+	"stopnotifyblocks":                handleStopNotifyBlocks,
+	"stopnotifynewtransactions":       handleStopNotifyNewTransactions,
+	"stopnotifyspentandmissedtickets": handleStopNotifySpentAndMissedTickets,
+	"stopnotifysyncprogress":          handleStopNotifySyncProgress,
+	"stopnotifyticketstatus":          handleStopNotifyTicketStatus,
+	"stopnotifywinningtickets":        handleStopNotifyWinningTickets,
 }
 
 // WebsocketHandler handles a new websocket client by creating a new wsClient,
@@ -294,6 +312,19 @@ func (m *wsNotificationManager) NotifyStakeDifficulty(
 	}
 }
 
+// NotifySyncProgress passes the progress of the chain sync to the
+// notification manager for sync progress notification processing.
+func (m *wsNotificationManager) NotifySyncProgress(info *exccjson.GetSyncInfoResult) {
+	// As NotifySyncProgress will be called by the sync tracker and the RPC
+	// server may no longer be running, use a select statement to unblock
+	// enqueuing the notification once the RPC server has begun shutting
+	// down.
+	select {
+	case m.queueNotification <- (*notificationSyncProgress)(info):
+	case <-m.quit:
+	}
+}
+
 // NotifyMempoolTx passes a transaction accepted by mempool to the
 // notification manager for transaction notification processing.  If
 // isNew is true, the tx is is a new transaction, rather than one
@@ -369,8 +400,19 @@ func makeWSClientFilter(addresses []string, unspentOutPoints []*wire.OutPoint) *
 	return filter
 }
 
+// size returns the number of addresses and outpoints in the filter.
+func (f *wsClientFilter) size() int {
+	return len(f.pubKeyHashes) + len(f.scriptHashes) +
+		len(f.compressedPubKeys) + len(f.uncompressedPubKeys) +
+		len(f.otherAddresses) + len(f.unspent)
+}
+
 func (f *wsClientFilter) addAddress(a exccutil.Address) {
 	switch a := a.(type) {
+	case *exccutil.AddressBech32:
+		// Addresses extracted from scripts are always base58 encoded.
+		f.addAddress(a.Base58())
+		return
 	case *exccutil.AddressPubKeyHash:
 		f.pubKeyHashes[*a.Hash160()] = struct{}{}
 		return
@@ -458,6 +500,7 @@ type notificationWinningTickets WinningTicketsNtfnData
 type notificationSpentAndMissedTickets blockchain.TicketNotificationsData
 type notificationNewTickets blockchain.TicketNotificationsData
 type notificationStakeDifficulty StakeDifficultyNtfnData
+type notificationSyncProgress exccjson.GetSyncInfoResult
 type notificationTxAcceptedByMempool struct {
 	isNew bool
 	tx    *exccutil.Tx
@@ -478,6 +521,10 @@ type notificationRegisterStakeDifficulty wsClient
 type notificationUnregisterStakeDifficulty wsClient
 type notificationRegisterNewMempoolTxs wsClient
 type notificationUnregisterNewMempoolTxs wsClient
+type notificationRegisterTicketStatus wsClient
+type notificationUnregisterTicketStatus wsClient
+type notificationRegisterSyncProgress wsClient
+type notificationUnregisterSyncProgress wsClient
 
 // notificationHandler reads notifications and control messages from the queue
 // handler and processes one at a time.
@@ -498,6 +545,8 @@ func (m *wsNotificationManager) notificationHandler() {
 	ticketNewNotifications := make(map[chan struct{}]*wsClient)
 	stakeDifficultyNotifications := make(map[chan struct{}]*wsClient)
 	txNotifications := make(map[chan struct{}]*wsClient)
+	ticketStatusNotifications := make(map[chan struct{}]*wsClient)
+	syncProgressNotifications := make(map[chan struct{}]*wsClient)
 
 out:
 	for {
@@ -510,6 +559,7 @@ out:
 			switch n := n.(type) {
 			case *notificationBlockConnected:
 				block := (*exccutil.Block)(n)
+				m.notifyTicketStatus(ticketStatusNotifications, block)
 
 				// Skip iterating through all txs if no tx
 				// notification requests exist.
@@ -520,8 +570,9 @@ out:
 				m.notifyBlockConnected(blockNotifications, block)
 
 			case *notificationBlockDisconnected:
-				m.notifyBlockDisconnected(blockNotifications,
-					(*exccutil.Block)(n))
+				block := (*exccutil.Block)(n)
+				m.notifyTicketStatus(ticketStatusNotifications, block)
+				m.notifyBlockDisconnected(blockNotifications, block)
 
 			case *notificationReorganization:
 				m.notifyReorganization(blockNotifications,
@@ -543,6 +594,10 @@ out:
 				m.notifyStakeDifficulty(stakeDifficultyNotifications,
 					(*StakeDifficultyNtfnData)(n))
 
+			case *notificationSyncProgress:
+				m.notifySyncProgress(syncProgressNotifications,
+					(*exccjson.GetSyncInfoResult)(n))
+
 			case *notificationTxAcceptedByMempool:
 				if n.isNew && len(txNotifications) != 0 {
 					m.notifyForNewTx(txNotifications, n.tx)
@@ -599,6 +654,8 @@ out:
 				// the client itself.
 				delete(blockNotifications, wsc.quit)
 				delete(txNotifications, wsc.quit)
+				delete(ticketStatusNotifications, wsc.quit)
+				delete(syncProgressNotifications, wsc.quit)
 				delete(clients, wsc.quit)
 
 			case *notificationRegisterNewMempoolTxs:
@@ -609,6 +666,22 @@ out:
 				wsc := (*wsClient)(n)
 				delete(txNotifications, wsc.quit)
 
+			case *notificationRegisterTicketStatus:
+				wsc := (*wsClient)(n)
+				ticketStatusNotifications[wsc.quit] = wsc
+
+			case *notificationUnregisterTicketStatus:
+				wsc := (*wsClient)(n)
+				delete(ticketStatusNotifications, wsc.quit)
+
+			case *notificationRegisterSyncProgress:
+				wsc := (*wsClient)(n)
+				syncProgressNotifications[wsc.quit] = wsc
+
+			case *notificationUnregisterSyncProgress:
+				wsc := (*wsClient)(n)
+				delete(syncProgressNotifications, wsc.quit)
+
 			default:
 				rpcsLog.Warn("Unhandled notification type")
 			}
@@ -1101,6 +1174,105 @@ func (m *wsNotificationManager) notifyRelevantTxAccepted(tx *exccutil.Tx,
 	}
 }
 
+// RegisterSyncProgress requests sync progress notifications to the passed
+// websocket client.
+func (m *wsNotificationManager) RegisterSyncProgress(wsc *wsClient) {
+	m.queueNotification <- (*notificationRegisterSyncProgress)(wsc)
+}
+
+// UnregisterSyncProgress removes sync progress notifications for the passed
+// websocket client.
+func (m *wsNotificationManager) UnregisterSyncProgress(wsc *wsClient) {
+	m.queueNotification <- (*notificationUnregisterSyncProgress)(wsc)
+}
+
+// notifySyncProgress notifies websocket clients that have registered for sync
+// progress updates about the progress of the chain sync.
+func (*wsNotificationManager) notifySyncProgress(clients map[chan struct{}]*wsClient, info *exccjson.GetSyncInfoResult) {
+	// Skip notification creation if no clients have requested sync progress
+	// notifications.
+	if len(clients) == 0 {
+		return
+	}
+
+	ntfn := exccjson.NewSyncProgressNtfn(*info)
+	marshalledJSON, err := exccjson.MarshalCmd("1.0", nil, ntfn)
+	if err != nil {
+		rpcsLog.Errorf("Failed to marshal sync progress notification: "+
+			"%v", err)
+		return
+	}
+	for _, wsc := range clients {
+		wsc.QueueNotification(marshalledJSON)
+	}
+}
+
+// RegisterTicketStatus requests ticket status change notifications for the
+// tickets watched by the passed websocket client.
+func (m *wsNotificationManager) RegisterTicketStatus(wsc *wsClient) {
+	m.queueNotification <- (*notificationRegisterTicketStatus)(wsc)
+}
+
+// UnregisterTicketStatus removes ticket status change notifications for the
+// passed websocket client.
+func (m *wsNotificationManager) UnregisterTicketStatus(wsc *wsClient) {
+	m.queueNotification <- (*notificationUnregisterTicketStatus)(wsc)
+}
+
+// notifyTicketStatus notifies websocket clients that have registered for
+// ticket status changes about the watched tickets whose status changed since
+// they were last reported.  It is invoked whenever a block is connected to or
+// disconnected from the main chain.
+func (m *wsNotificationManager) notifyTicketStatus(clients map[chan struct{}]*wsClient, block *exccutil.Block) {
+	ticketIndex := m.server.server.ticketIndex
+	if len(clients) == 0 || ticketIndex == nil {
+		return
+	}
+
+	for _, wsc := range clients {
+		wsc.Lock()
+		hashes := make([]chainhash.Hash, 0, len(wsc.watchedTickets))
+		for hash := range wsc.watchedTickets {
+			hashes = append(hashes, hash)
+		}
+		wsc.Unlock()
+
+		tickets, err := ticketIndex.TicketLifecycles(hashes)
+		if err != nil {
+			rpcsLog.Errorf("Failed to look up watched tickets: %v", err)
+			return
+		}
+		results := ticketInfoResults(hashes, tickets)
+
+		// Only notify about the tickets that are still watched and whose
+		// status differs from the most recently reported one.
+		var changed []exccjson.TicketInfoResult
+		wsc.Lock()
+		for i := range hashes {
+			prev, ok := wsc.watchedTickets[hashes[i]]
+			if !ok || prev == results[i] {
+				continue
+			}
+			wsc.watchedTickets[hashes[i]] = results[i]
+			changed = append(changed, results[i])
+		}
+		wsc.Unlock()
+		if len(changed) == 0 {
+			continue
+		}
+
+		ntfn := exccjson.NewTicketStatusChangedNtfn(block.Hash().String(),
+			block.Height(), changed)
+		marshalledJSON, err := exccjson.MarshalCmd("1.0", nil, ntfn)
+		if err != nil {
+			rpcsLog.Errorf("Failed to marshal ticket status changed "+
+				"notification: %v", err)
+			continue
+		}
+		wsc.QueueNotification(marshalledJSON)
+	}
+}
+
 // AddClient adds the passed websocket client to the notification manager.
 func (m *wsNotificationManager) AddClient(wsc *wsClient) {
 	m.queueNotification <- (*notificationRegisterClient)(wsc)
@@ -1119,8 +1291,8 @@ func (m *wsNotificationManager) RemoveClient(wsc *wsClient) {
 // websocket client notifications.
 func (m *wsNotificationManager) Start() {
 	m.wg.Add(2)
-	go m.queueHandler()
-	go m.notificationHandler()
+	spawn(m.queueHandler)
+	spawn(m.notificationHandler)
 }
 
 // WaitForShutdown blocks until all notification manager goroutines have
@@ -1201,6 +1373,11 @@ type wsClient struct {
 
 	filterData *wsClientFilter
 
+	// watchedTickets houses the tickets the client requested status
+	// change notifications for along with the most recently reported
+	// state of each ticket.  It is protected by the client mutex.
+	watchedTickets map[chainhash.Hash]exccjson.TicketInfoResult
+
 	// Networking infrastructure.
 	serviceRequestSem semaphore
 	ntfnChan          chan []byte
@@ -1324,6 +1501,7 @@ out:
 				auth := "Basic " + base64.StdEncoding.EncodeToString([]byte(login))
 				authSha := sha256.Sum256([]byte(auth))
 				cmp := subtle.ConstantTimeCompare(authSha[:], c.server.authsha[:])
+				cmp |= subtle.ConstantTimeCompare(authSha[:], c.server.cookieauthsha[:])
 				limitcmp := subtle.ConstantTimeCompare(authSha[:], c.server.limitauthsha[:])
 				if cmp != 1 && limitcmp != 1 {
 					rpcsLog.Warnf("Auth failure.")
@@ -1384,10 +1562,10 @@ out:
 			// read of the next request from the websocket client and allow
 			// many requests to be waited on concurrently.
 			c.serviceRequestSem.acquire()
-			go func() {
+			spawn(func() {
 				c.serviceRequest(cmd)
 				c.serviceRequestSem.release()
-			}()
+			})
 		}
 
 		// Process a batched request
@@ -1561,6 +1739,7 @@ out:
 							auth := "Basic " + base64.StdEncoding.EncodeToString([]byte(login))
 							authSha := sha256.Sum256([]byte(auth))
 							cmp := subtle.ConstantTimeCompare(authSha[:], c.server.authsha[:])
+							cmp |= subtle.ConstantTimeCompare(authSha[:], c.server.cookieauthsha[:])
 							limitcmp := subtle.ConstantTimeCompare(authSha[:], c.server.limitauthsha[:])
 							if cmp != 1 && limitcmp != 1 {
 								rpcsLog.Warnf("Auth failure.")
@@ -1715,8 +1894,15 @@ func (c *wsClient) notificationQueueHandler() {
 	// future, not knowing what has and hasn't been sent to the outHandler
 	// (and thus who should respond to the done channel) would be
 	// problematic without using this approach.
+	//
+	// Once the number of pending notifications reaches the limit, further
+	// notifications are dropped so a client which is not reading them fast
+	// enough can't make the queue grow without bound.  The number dropped
+	// is accumulated and sent to the client in a single notification once
+	// it has caught up with the pending ones.
 	pendingNtfns := list.New()
 	waiting := false
+	var numDropped int64
 out:
 	for {
 		select {
@@ -1726,9 +1912,18 @@ out:
 		// queue the message to be sent once the other pending messages
 		// are sent.
 		case msg := <-c.ntfnChan:
-			if !waiting {
+			switch {
+			case !waiting:
 				c.SendMessage(msg, ntfnSentChan)
-			} else {
+			case cfg.RPCMaxWSQueuedNtfns > 0 &&
+				pendingNtfns.Len() >= cfg.RPCMaxWSQueuedNtfns:
+				if numDropped == 0 {
+					rpcsLog.Warnf("Dropping notifications for "+
+						"websocket client %s which is not "+
+						"reading them fast enough", c.addr)
+				}
+				numDropped++
+			default:
 				pendingNtfns.PushBack(msg)
 			}
 			waiting = true
@@ -1736,9 +1931,22 @@ out:
 			// This channel is notified when a notification has been sent
 			// across the network socket.
 		case <-ntfnSentChan:
-			// No longer waiting if there are no more messages in
-			// the pending messages queue.
+			// Notify the client about the notifications which were
+			// dropped once there are no more messages in the pending
+			// messages queue, and no longer wait otherwise.
 			next := pendingNtfns.Front()
+			if next == nil && numDropped > 0 {
+				ntfn := exccjson.NewNotificationsDroppedNtfn(numDropped)
+				numDropped = 0
+				marshalledJSON, err := exccjson.MarshalCmd("1.0", nil,
+					ntfn)
+				if err == nil {
+					c.SendMessage(marshalledJSON, ntfnSentChan)
+					continue
+				}
+				rpcsLog.Errorf("Failed to marshal notifications "+
+					"dropped notification: %v", err)
+			}
 			if next == nil {
 				waiting = false
 				continue
@@ -1884,9 +2092,9 @@ func (c *wsClient) Start() {
 
 	// Start processing input and output.
 	c.wg.Add(3)
-	go c.inHandler()
-	go c.notificationQueueHandler()
-	go c.outHandler()
+	spawn(c.inHandler)
+	spawn(c.notificationQueueHandler)
+	spawn(c.outHandler)
 }
 
 // WaitForShutdown blocks until the websocket client goroutines are stopped
@@ -1976,24 +2184,30 @@ func handleWebsocketHelp(wsc *wsClient, icmd interface{}) (interface{}, error) {
 func handleLoadTxFilter(wsc *wsClient, icmd interface{}) (interface{}, error) {
 	cmd := icmd.(*exccjson.LoadTxFilterCmd)
 
-	outPoints := make([]*wire.OutPoint, len(cmd.OutPoints))
-	for i := range cmd.OutPoints {
-		hash, err := chainhash.NewHashFromStr(cmd.OutPoints[i].Hash)
-		if err != nil {
-			return nil, &exccjson.RPCError{
-				Code:    exccjson.ErrRPCInvalidParameter,
-				Message: err.Error(),
-			}
-		}
-		outPoints[i] = &wire.OutPoint{
-			Hash:  *hash,
-			Index: cmd.OutPoints[i].Index,
-			Tree:  cmd.OutPoints[i].Tree,
-		}
+	outPoints, err := decodeOutPoints(cmd.OutPoints)
+	if err != nil {
+		return nil, err
+	}
+
+	// tooManySubscriptions returns whether adding the requested addresses
+	// and outpoints to a filter of the passed size exceeds the limit.
+	// Duplicates are not taken into account.
+	tooManySubscriptions := func(filterSize int) bool {
+		numSubs := filterSize + len(cmd.Addresses) + len(outPoints)
+		return cfg.RPCMaxWSSubs > 0 && numSubs > cfg.RPCMaxWSSubs
+	}
+	errTooManySubscriptions := &exccjson.RPCError{
+		Code: exccjson.ErrRPCInvalidParameter,
+		Message: fmt.Sprintf("Too many addresses and outpoints in the "+
+			"transaction filter (max %d)", cfg.RPCMaxWSSubs),
 	}
 
 	wsc.Lock()
 	if cmd.Reload || wsc.filterData == nil {
+		if tooManySubscriptions(0) {
+			wsc.Unlock()
+			return nil, errTooManySubscriptions
+		}
 		wsc.filterData = makeWSClientFilter(cmd.Addresses, outPoints)
 		wsc.Unlock()
 	} else {
@@ -2001,6 +2215,10 @@ func handleLoadTxFilter(wsc *wsClient, icmd interface{}) (interface{}, error) {
 		wsc.Unlock()
 
 		filter.mu.Lock()
+		if tooManySubscriptions(filter.size()) {
+			filter.mu.Unlock()
+			return nil, errTooManySubscriptions
+		}
 		for _, a := range cmd.Addresses {
 			filter.addAddressStr(a)
 		}
@@ -2013,6 +2231,27 @@ func handleLoadTxFilter(wsc *wsClient, icmd interface{}) (interface{}, error) {
 	return nil, nil
 }
 
+// decodeOutPoints returns the outpoints described by the passed JSON-RPC
+// outpoints.
+func decodeOutPoints(ops []exccjson.OutPoint) ([]*wire.OutPoint, error) {
+	outPoints := make([]*wire.OutPoint, len(ops))
+	for i := range ops {
+		hash, err := chainhash.NewHashFromStr(ops[i].Hash)
+		if err != nil {
+			return nil, &exccjson.RPCError{
+				Code:    exccjson.ErrRPCInvalidParameter,
+				Message: err.Error(),
+			}
+		}
+		outPoints[i] = &wire.OutPoint{
+			Hash:  *hash,
+			Index: ops[i].Index,
+			Tree:  ops[i].Tree,
+		}
+	}
+	return outPoints, nil
+}
+
 // handleNotifyBlocks implements the notifyblocks command extension for
 // websocket connections.
 func handleNotifyBlocks(wsc *wsClient, icmd interface{}) (interface{}, error) {
@@ -2061,6 +2300,119 @@ func handleStopNotifyBlocks(wsc *wsClient, icmd interface{}) (interface{}, error
 	return nil, nil
 }
 
+// handleStopNotifySpentAndMissedTickets implements the
+// stopnotifyspentandmissedtickets command extension for websocket connections.
+func handleStopNotifySpentAndMissedTickets(wsc *wsClient, icmd interface{}) (interface{}, error) {
+	wsc.server.ntfnMgr.UnregisterSpentAndMissedTickets(wsc)
+	return nil, nil
+}
+
+// handleStopNotifyWinningTickets implements the stopnotifywinningtickets
+// command extension for websocket connections.
+func handleStopNotifyWinningTickets(wsc *wsClient, icmd interface{}) (interface{}, error) {
+	wsc.server.ntfnMgr.UnregisterWinningTickets(wsc)
+	return nil, nil
+}
+
+// handleNotifySyncProgress implements the notifysyncprogress command extension
+// for websocket connections.  The current progress of the chain sync is
+// returned so clients don't need to wait for the first notification.
+func handleNotifySyncProgress(wsc *wsClient, icmd interface{}) (interface{}, error) {
+	wsc.server.ntfnMgr.RegisterSyncProgress(wsc)
+	return wsc.server.server.syncInfo(), nil
+}
+
+// handleStopNotifySyncProgress implements the stopnotifysyncprogress command
+// extension for websocket connections.
+func handleStopNotifySyncProgress(wsc *wsClient, icmd interface{}) (interface{}, error) {
+	wsc.server.ntfnMgr.UnregisterSyncProgress(wsc)
+	return nil, nil
+}
+
+// handleNotifyTicketStatus implements the notifyticketstatus command extension
+// for websocket connections.  The passed tickets are added to the tickets the
+// client is watching and their current state is returned so later
+// notifications only need to describe changes.
+func handleNotifyTicketStatus(wsc *wsClient, icmd interface{}) (interface{}, error) {
+	cmd, ok := icmd.(*exccjson.NotifyTicketStatusCmd)
+	if !ok {
+		return nil, exccjson.ErrRPCInternal
+	}
+
+	ticketIndex := wsc.server.server.ticketIndex
+	if ticketIndex == nil {
+		return nil, rpcInternalError("The ticket lifecycle index must be "+
+			"enabled to watch tickets (specify --ticketindex)",
+			"Configuration")
+	}
+
+	hashes, err := decodeTicketHashes(cmd.TxHashes)
+	if err != nil {
+		return nil, err
+	}
+	tickets, err := ticketIndex.TicketLifecycles(hashes)
+	if err != nil {
+		return nil, rpcInternalError(err.Error(),
+			"Could not obtain tickets")
+	}
+	results := ticketInfoResults(hashes, tickets)
+
+	wsc.Lock()
+	if wsc.watchedTickets == nil {
+		wsc.watchedTickets = make(map[chainhash.Hash]exccjson.TicketInfoResult)
+	}
+	numWatched := len(wsc.watchedTickets)
+	for i := range hashes {
+		if _, ok := wsc.watchedTickets[hashes[i]]; !ok {
+			numWatched++
+		}
+	}
+	if numWatched > maxWatchedTickets {
+		wsc.Unlock()
+		return nil, &exccjson.RPCError{
+			Code: exccjson.ErrRPCInvalidParameter,
+			Message: fmt.Sprintf("Too many watched tickets (max %d)",
+				maxWatchedTickets),
+		}
+	}
+	for i := range hashes {
+		wsc.watchedTickets[hashes[i]] = results[i]
+	}
+	wsc.Unlock()
+
+	wsc.server.ntfnMgr.RegisterTicketStatus(wsc)
+	return results, nil
+}
+
+// handleStopNotifyTicketStatus implements the stopnotifyticketstatus command
+// extension for websocket connections.  The passed tickets are no longer
+// watched, or all tickets when none are specified.
+func handleStopNotifyTicketStatus(wsc *wsClient, icmd interface{}) (interface{}, error) {
+	cmd, ok := icmd.(*exccjson.StopNotifyTicketStatusCmd)
+	if !ok {
+		return nil, exccjson.ErrRPCInternal
+	}
+
+	if cmd.TxHashes == nil {
+		wsc.Lock()
+		wsc.watchedTickets = nil
+		wsc.Unlock()
+		wsc.server.ntfnMgr.UnregisterTicketStatus(wsc)
+		return nil, nil
+	}
+
+	hashes, err := decodeTicketHashes(*cmd.TxHashes)
+	if err != nil {
+		return nil, err
+	}
+	wsc.Lock()
+	for i := range hashes {
+		delete(wsc.watchedTickets, hashes[i])
+	}
+	wsc.Unlock()
+	return nil, nil
+}
+
 // handleNotifyNewTransations implements the notifynewtransactions command
 // extension for websocket connections.
 func handleNotifyNewTransactions(wsc *wsClient, icmd interface{}) (interface{}, error) {
@@ -2223,6 +2575,228 @@ func handleRescan(wsc *wsClient, icmd interface{}) (interface{}, error) {
 	return &exccjson.RescanResult{DiscoveredData: discoveredData}, nil
 }
 
+// sendRescanNtfn marshals and sends the passed notification about a rescan
+// which is being serviced for the client.  Unlike QueueNotification, the
+// notification is sent in order with the reply to the rescan request and the
+// rescan waits until it is written, so it can not outpace the client.
+func (c *wsClient) sendRescanNtfn(ntfn interface{}) error {
+	marshalledJSON, err := exccjson.MarshalCmd("1.0", nil, ntfn)
+	if err != nil {
+		return err
+	}
+	done := make(chan bool, 1)
+	c.SendMessage(marshalledJSON, done)
+	if !<-done {
+		return ErrClientQuit
+	}
+	return nil
+}
+
+// rescanAddressScripts returns the output scripts which pay to the passed
+// addresses as they are committed to the regular committed filters of blocks.
+// Since rescans also match outputs paying to the pubkey hash of a public key
+// address, the pubkey hash script of such addresses is included as well.
+func rescanAddressScripts(addrs []exccutil.Address) ([][]byte, error) {
+	scripts := make([][]byte, 0, len(addrs))
+	for _, addr := range addrs {
+		script, err := txscript.PayToAddrScript(addr)
+		if err != nil {
+			return nil, err
+		}
+		scripts = append(scripts, script)
+
+		if addr, ok := addr.(*exccutil.AddressSecpPubKey); ok {
+			script, err := txscript.PayToAddrScript(addr.AddressPubKeyHash())
+			if err != nil {
+				return nil, err
+			}
+			scripts = append(scripts, script)
+		}
+	}
+	return scripts, nil
+}
+
+// rescanFilterEntries returns the committed filter entries that match blocks
+// which may contain transactions relevant to a rescan, namely the passed
+// address scripts and the unspent outpoints of the passed filter.
+func rescanFilterEntries(scripts [][]byte, filter *wsClientFilter) blockcf.Entries {
+	filter.mu.Lock()
+	entries := make(blockcf.Entries, 0, len(scripts)+len(filter.unspent))
+	entries = append(entries, scripts...)
+	for op := range filter.unspent {
+		entries.AddOutPoint(&op)
+	}
+	filter.mu.Unlock()
+	return entries
+}
+
+// rescanFilterMatch returns whether the passed serialized regular committed
+// filter of the block with the passed hash matches any of the passed entries.
+// Blocks without a filter are reported as matching so they are never skipped.
+func rescanFilterMatch(filterBytes []byte, hash *chainhash.Hash, entries blockcf.Entries) bool {
+	if len(filterBytes) == 0 {
+		return true
+	}
+	filter, err := gcs.FromNBytes(blockcf.P, filterBytes)
+	if err != nil {
+		return true
+	}
+	return filter.MatchAny(blockcf.Key(hash), entries)
+}
+
+// handleRescanRange implements the rescanrange command extension for websocket
+// connections.  The main chain blocks in the requested range are rescanned for
+// transactions paying to the passed addresses or spending the passed outpoints,
+// and any outputs paying to the addresses which are found along the way.  The
+// committed filter index is used to skip blocks which can not contain such
+// transactions, and each block which does is sent to the client in a
+// rescannedblock notification.
+func handleRescanRange(wsc *wsClient, icmd interface{}) (interface{}, error) {
+	cmd, ok := icmd.(*exccjson.RescanRangeCmd)
+	if !ok {
+		return nil, exccjson.ErrRPCInternal
+	}
+
+	cfIndex := wsc.server.server.cfIndex
+	if cfIndex == nil {
+		return nil, &exccjson.RPCError{
+			Code:    exccjson.ErrRPCNoCFIndex,
+			Message: "Compact filters must be enabled for this command",
+		}
+	}
+	if err := wsc.server.rpcIndexNotSyncedError(cfIndex); err != nil {
+		return nil, err
+	}
+
+	// Load the addresses and outpoints into a transaction filter which is
+	// private to this request.
+	addrs := make([]exccutil.Address, 0, len(cmd.Addresses))
+	for _, s := range cmd.Addresses {
+		addr, err := exccutil.DecodeAddress(s)
+		if err != nil || !addr.IsForNet(activeNetParams.Params) {
+			return nil, &exccjson.RPCError{
+				Code:    exccjson.ErrRPCInvalidAddressOrKey,
+				Message: "Invalid address: " + s,
+			}
+		}
+		addrs = append(addrs, addr)
+	}
+	outPoints, err := decodeOutPoints(cmd.OutPoints)
+	if err != nil {
+		return nil, err
+	}
+	if len(addrs) == 0 && len(outPoints) == 0 {
+		return nil, &exccjson.RPCError{
+			Code:    exccjson.ErrRPCInvalidParameter,
+			Message: "No addresses or outpoints to rescan for",
+		}
+	}
+	scripts, err := rescanAddressScripts(addrs)
+	if err != nil {
+		return nil, &exccjson.RPCError{
+			Code:    exccjson.ErrRPCInvalidAddressOrKey,
+			Message: "Unsupported address: " + err.Error(),
+		}
+	}
+	filter := makeWSClientFilter(nil, outPoints)
+	for _, addr := range addrs {
+		filter.addAddress(addr)
+	}
+
+	// The range defaults to ending at the current best block.
+	bc := wsc.server.chain
+	bestHeight := bc.BestSnapshot().Height
+	endHeight := bestHeight
+	if cmd.EndHeight != nil {
+		endHeight = *cmd.EndHeight
+	}
+	if cmd.StartHeight < 0 || cmd.StartHeight > endHeight ||
+		endHeight > bestHeight {
+		return nil, &exccjson.RPCError{
+			Code: exccjson.ErrRPCInvalidParameter,
+			Message: fmt.Sprintf("Invalid block range %d-%d -- the "+
+				"current height is %d", cmd.StartHeight,
+				endHeight, bestHeight),
+		}
+	}
+
+	entries := rescanFilterEntries(scripts, filter)
+	result := &exccjson.RescanRangeResult{}
+	var prevHash chainhash.Hash
+	lastProgress := time.Now()
+	for height := cmd.StartHeight; height <= endHeight; height++ {
+		// Nothing receives the results once the client disconnects.
+		if wsc.Disconnected() {
+			return nil, ErrClientQuit
+		}
+
+		header, err := bc.HeaderByHeight(height)
+		if err != nil {
+			return nil, &exccjson.RPCError{
+				Code:    exccjson.ErrRPCBlockNotFound,
+				Message: "Failed to fetch block: " + err.Error(),
+			}
+		}
+		hash := header.BlockHash()
+
+		// Blocks of different chains must not be mixed when the chain
+		// is reorganized during the rescan.
+		if height != cmd.StartHeight && header.PrevBlock != prevHash {
+			return nil, &exccjson.RPCError{
+				Code: exccjson.ErrRPCMisc,
+				Message: fmt.Sprintf("The chain was reorganized "+
+					"at height %d during the rescan", height),
+			}
+		}
+		prevHash = hash
+		result.BlocksScanned++
+
+		filterBytes, err := cfIndex.FilterByBlockHash(&hash,
+			wire.GCSFilterRegular)
+		if err != nil {
+			return nil, rpcInternalError(err.Error(),
+				"Failed to fetch committed filter")
+		}
+		if rescanFilterMatch(filterBytes, &hash, entries) {
+			result.BlocksFetched++
+			block, err := bc.BlockByHash(&hash)
+			if err != nil {
+				return nil, &exccjson.RPCError{
+					Code:    exccjson.ErrRPCBlockNotFound,
+					Message: "Failed to fetch block: " + err.Error(),
+				}
+			}
+			transactions := rescanBlock(filter, block)
+			if len(transactions) != 0 {
+				result.BlocksMatched++
+				ntfn := exccjson.NewRescannedBlockNtfn(hash.String(),
+					height, transactions)
+				if err := wsc.sendRescanNtfn(ntfn); err != nil {
+					return nil, err
+				}
+
+				// The outputs paying to the addresses which were
+				// found must match the filters of the blocks which
+				// spend them.
+				entries = rescanFilterEntries(scripts, filter)
+			}
+		}
+
+		if time.Since(lastProgress) >= rescanProgressInterval {
+			ntfn := exccjson.NewRescanProgressNtfn(hash.String(),
+				height, header.Timestamp.Unix())
+			if err := wsc.sendRescanNtfn(ntfn); err != nil {
+				return nil, err
+			}
+			lastProgress = time.Now()
+		}
+	}
+
+	result.Hash = prevHash.String()
+	result.Height = endHeight
+	return result, nil
+}
+
 func init() {
 	wsHandlers = wsHandlersBeforeInit
 }
@@ -0,0 +1,185 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/EXCCoin/exccd/chaincfg/chainec"
+	"github.com/EXCCoin/exccd/chaincfg/chainhash"
+	"github.com/EXCCoin/exccd/exccutil"
+	"github.com/EXCCoin/exccd/gcs/blockcf"
+	"github.com/EXCCoin/exccd/txscript"
+	"github.com/EXCCoin/exccd/wire"
+)
+
+// TestRescanFilterMatch ensures the committed filter entries of a rescan match
+// the regular committed filters of blocks with transactions paying to its
+// addresses or spending its outpoints, including the outputs paying to its
+// addresses which are found by rescanning a block.
+func TestRescanFilterMatch(t *testing.T) {
+	newAddr := func(b byte) exccutil.Address {
+		addr, err := exccutil.NewAddressPubKeyHash(bytes.Repeat([]byte{b},
+			20), activeNetParams.Params, chainec.ECTypeSecp256k1)
+		if err != nil {
+			t.Fatalf("unable to create address: %v", err)
+		}
+		return addr
+	}
+	addrScript := func(addr exccutil.Address) []byte {
+		script, err := txscript.PayToAddrScript(addr)
+		if err != nil {
+			t.Fatalf("unable to create script: %v", err)
+		}
+		return script
+	}
+	relevantAddr, otherAddr := newAddr(0x01), newAddr(0x02)
+	spentOutPoint := wire.OutPoint{Hash: chainhash.Hash{0x03}}
+	otherOutPoint := wire.OutPoint{Hash: chainhash.Hash{0x04}}
+
+	// Create a block with a transaction which spends the outpoint and pays
+	// to the address, and a block with a transaction spending its output.
+	coinbase := wire.NewMsgTx()
+	coinbase.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{},
+		wire.MaxPrevOutIndex, wire.TxTreeRegular), nil))
+	coinbase.AddTxOut(wire.NewTxOut(1, addrScript(otherAddr)))
+	tx := wire.NewMsgTx()
+	tx.AddTxIn(wire.NewTxIn(&spentOutPoint, nil))
+	tx.AddTxOut(wire.NewTxOut(1, addrScript(relevantAddr)))
+	block := &wire.MsgBlock{Transactions: []*wire.MsgTx{coinbase, tx}}
+
+	txHash := tx.TxHash()
+	spendTx := wire.NewMsgTx()
+	spendTx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&txHash, 0,
+		wire.TxTreeRegular), nil))
+	spendTx.AddTxOut(wire.NewTxOut(1, addrScript(otherAddr)))
+	spendBlock := &wire.MsgBlock{Transactions: []*wire.MsgTx{coinbase,
+		spendTx}}
+
+	filterBytes := func(block *wire.MsgBlock) []byte {
+		f, err := blockcf.Regular(block)
+		if err != nil {
+			t.Fatalf("unable to create filter: %v", err)
+		}
+		return f.NBytes()
+	}
+	newEntries := func(addrs []exccutil.Address, outPoints []*wire.OutPoint) (blockcf.Entries, *wsClientFilter) {
+		scripts, err := rescanAddressScripts(addrs)
+		if err != nil {
+			t.Fatalf("unable to create scripts: %v", err)
+		}
+		filter := makeWSClientFilter(nil, outPoints)
+		for _, addr := range addrs {
+			filter.addAddress(addr)
+		}
+		return rescanFilterEntries(scripts, filter), filter
+	}
+
+	tests := []struct {
+		name      string
+		addrs     []exccutil.Address
+		outPoints []*wire.OutPoint
+		match     bool
+	}{{
+		name:  "address",
+		addrs: []exccutil.Address{relevantAddr},
+		match: true,
+	}, {
+		name:      "outpoint",
+		outPoints: []*wire.OutPoint{&spentOutPoint},
+		match:     true,
+	}, {
+		name:      "unrelated",
+		addrs:     []exccutil.Address{newAddr(0x05)},
+		outPoints: []*wire.OutPoint{&otherOutPoint},
+		match:     false,
+	}}
+
+	hash := block.BlockHash()
+	for _, test := range tests {
+		entries, _ := newEntries(test.addrs, test.outPoints)
+		match := rescanFilterMatch(filterBytes(block), &hash, entries)
+		if match != test.match {
+			t.Errorf("%s: got match %v, want %v", test.name, match,
+				test.match)
+		}
+	}
+
+	// Blocks without a filter are never skipped.
+	entries, filter := newEntries([]exccutil.Address{relevantAddr}, nil)
+	if !rescanFilterMatch(nil, &hash, entries) {
+		t.Error("block without filter was skipped")
+	}
+
+	// The block spending the output paying to the address only matches
+	// once the output was found by rescanning the block which created it.
+	spendHash := spendBlock.BlockHash()
+	if rescanFilterMatch(filterBytes(spendBlock), &spendHash, entries) {
+		t.Fatal("spending block matched before rescanning")
+	}
+	txs := rescanBlock(filter, exccutil.NewBlock(block))
+	if len(txs) != 1 {
+		t.Fatalf("got %d relevant transactions, want 1", len(txs))
+	}
+	scripts, _ := rescanAddressScripts([]exccutil.Address{relevantAddr})
+	entries = rescanFilterEntries(scripts, filter)
+	if !rescanFilterMatch(filterBytes(spendBlock), &spendHash, entries) {
+		t.Error("spending block did not match after rescanning")
+	}
+}
+
+// TestNotificationQueueLimit ensures notifications for a websocket client are
+// dropped once the limit of queued notifications is reached and the client is
+// notified of the number dropped once it catches up.
+func TestNotificationQueueLimit(t *testing.T) {
+	origCfg := cfg
+	cfg = &config{RPCMaxWSQueuedNtfns: 2}
+	defer func() { cfg = origCfg }()
+
+	// The notification channel is unbuffered so each notification is
+	// handled before the next one is queued.
+	c := &wsClient{
+		addr:     "test",
+		ntfnChan: make(chan []byte),
+		sendChan: make(chan wsResponse, websocketSendBufferSize),
+		quit:     make(chan struct{}),
+	}
+	c.wg.Add(1)
+	go c.notificationQueueHandler()
+	defer func() {
+		close(c.quit)
+		c.wg.Wait()
+	}()
+
+	// recvNtfn receives the next message sent to the client, ensures it
+	// is the expected one, and reports it was sent.
+	recvNtfn := func(want string) {
+		r := <-c.sendChan
+		if string(r.msg) != want {
+			t.Fatalf("got notification %s, want %s", r.msg, want)
+		}
+		r.doneChan <- true
+	}
+
+	// The first notification is sent right away, the next two are queued,
+	// and the rest are dropped.
+	for _, ntfn := range []string{"1", "2", "3", "4", "5"} {
+		if err := c.QueueNotification([]byte(ntfn)); err != nil {
+			t.Fatalf("QueueNotification: unexpected error: %v", err)
+		}
+	}
+	recvNtfn("1")
+	recvNtfn("2")
+	recvNtfn("3")
+	recvNtfn(`{"jsonrpc":"1.0","method":"notificationsdropped",` +
+		`"params":[2],"id":null}`)
+
+	// Notifications are sent again once the client caught up.
+	if err := c.QueueNotification([]byte("6")); err != nil {
+		t.Fatalf("QueueNotification: unexpected error: %v", err)
+	}
+	recvNtfn("6")
+}
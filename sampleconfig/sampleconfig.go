@@ -8,6 +8,11 @@ package sampleconfig
 // FileContents is a string containing the commented example config for exccd.
 const FileContents = `[Application Options]
 
+; The debuglevel, banduration, banthreshold, whitelist, miningaddr, getworkkey,
+; minrelaytxfee, rpccerthost, rpccertkeytype, and rpccertvalidity options are
+; reloaded without restarting exccd when it receives SIGHUP or the reloadconfig
+; RPC is issued.  Changes to other options require a restart.
+
 ; ------------------------------------------------------------------------------
 ; Data settings
 ; ------------------------------------------------------------------------------
@@ -23,6 +28,12 @@ const FileContents = `[Application Options]
 ; datadir=$LOCALAPPDATA/Exccd/data                 ; Windows
 ; datadir=~/Library/Application Support/Exccd/data ; macOS
 
+; Add custom checkpoints which are enforced in addition to the built-in ones.
+; The blocks of the existing chain are verified against them on startup, so a
+; node which is already synced to a conflicting chain will refuse to start.  One
+; checkpoint per line in the format '<height>:<hash>'.
+; addcheckpoint=<height>:<hash>
+
 
 ; ------------------------------------------------------------------------------
 ; Network settings
@@ -34,6 +45,44 @@ const FileContents = `[Application Options]
 ; Use simnet.
 ; simnet=1
 
+; Override the network magic, default peer and RPC ports, and genesis block
+; timestamp of simnet so several independent simnets can run on the same host
+; without their nodes communicating with each other.  Each instance must also
+; use its own data directory.  These are only valid with simnet=1.
+; simnetmagic=305419896
+; simnetport=12998
+; simnetrpcport=20556
+; simnetgenesistime=1538352000
+
+; Use regnet, the regression test network.  The difficulty never rises above the
+; minimum and votes are never required, so it is suited to automated tests which
+; need to generate a large number of blocks quickly.
+; regnet=1
+
+; Use a custom network defined by a JSON file.  The file must specify the name,
+; network magic ("net"), and default peer port ("defaultport") of the network,
+; and may override any of the genesis block, proof of work, equihash, subsidy,
+; stake, consensus upgrade, address encoding, and DNS seed parameters of the
+; network named by "base" (mainnet, testnet2, regnet, or simnet, which is the
+; default) as well as the RPC port ("rpcport").  For example:
+;
+;   {
+;     "name": "privnet",
+;     "net": 305419896,
+;     "defaultport": "19777",
+;     "rpcport": "19778",
+;     "genesis": {"timestamp": 1538352000},
+;     "targettimeperblock": "2m30s",
+;     "equihashn": 144,
+;     "equihashk": 5,
+;     "basesubsidy": 3119582664,
+;     "consensusupgrades": {"lwmaworkdiff": 1000},
+;     "workdifflwmawindowsize": 45,
+;     "dnsseeds": [{"host": "seed.example.com", "hasfiltering": true}]
+;   }
+;
+; chainparams=~/privnet.json
+
 ; Connect via a SOCKS5 proxy.  NOTE: Specifying a proxy will disable listening
 ; for incoming connections unless listen addresses are provided via the 'listen'
 ; option.
@@ -113,6 +162,15 @@ const FileContents = `[Application Options]
 ; connect=fe80::1
 ; connect=[fe80::2]:9108
 
+; Only accept blocks from the specified peers, which are connected to
+; persistently, and only use other peers to relay transactions.  One peer per
+; line.  This is useful to sync from a curated upstream node during sensitive
+; upgrades while still relaying transactions with the rest of the network.  Only
+; outbound connections to the specified peers are trusted, so blocks are not
+; accepted from them when they connect inbound.
+; synconly=192.168.1.1
+; synconly=10.0.0.2:9108
+
 ; Maximum number of inbound and outbound peers.
 ; maxpeers=8
 
@@ -138,6 +196,13 @@ const FileContents = `[Application Options]
 ; DNS to query for available peers to connect with.
 ; nodnsseed=1
 
+; Add or remove DNS seeds used to discover peers.  The seeds are added to or
+; removed from the default seeds of the network, which may go stale.  Use the
+; dnsseed RPC to modify the seeds of a running node and getdnsseedinfo to view
+; how often each seed returned addresses.  One seed per line.
+; adddnsseed=seed.example.com
+; removednsseed=seed.excc.co
+
 ; Specify the interfaces to listen on.  One listen address per line.
 ; NOTE: The default port is modified by some options such as 'testnet', so it is
 ; recommended to not specify a port and allow a proper default to be chosen
@@ -179,11 +244,11 @@ const FileContents = `[Application Options]
 ; which is used to control and query information from a running exccd process.
 ;
 ; NOTE: The RPC server is disabled by default if no rpcuser or rpcpass is
-; specified.
+; specified and cookie authentication is disabled.
 ; ------------------------------------------------------------------------------
 
 ; Secure the RPC API by specifying the username and password.  You must specify
-; both or the RPC server will be disabled.
+; both or only the auth cookie described below is accepted.
 ; rpcuser=whatever_username_you_want
 ; rpcpass=
 
@@ -217,12 +282,66 @@ const FileContents = `[Application Options]
 ; All ipv6 interfaces on non-standard port 8337:
 ;   rpclisten=[::]:8337
 
+; Add hostnames or IP addresses clients use to connect to the RPC server to its
+; certificate when it is generated.  One per line.  The local addresses are
+; always included.
+; rpccerthost=node.example.com
+; rpccerthost=203.0.113.5
+
+; Require RPC clients to present a certificate signed by one of the CAs in the
+; given PEM-encoded file.  With the basic authentication type, clients must also
+; authenticate with a username and password, while the clientcert type grants
+; full access to clients with a valid certificate instead, so no rpcuser or
+; rpcpass is needed.
+; rpcclientcafile=~/.exccd/clients-ca.cert
+; rpcauthtype=clientcert
+
+; While the RPC server runs, it writes random credentials to an auth cookie file,
+; which local clients such as exccctl use to authenticate when no rpcuser and
+; rpcpass are configured.  The file is only readable by the user running exccd,
+; is regenerated on every start, and is removed on shutdown.  The RPC server is
+; disabled when cookie authentication is disabled and no rpcuser/rpcpass or
+; rpclimituser/rpclimitpass is specified.
+; rpccookiefile=~/.exccd/data/mainnet/.cookie
+; norpccookie=1
+
+; Allow clients of the HTTP POST endpoint to call the following methods without
+; authenticating, such as in-browser explorers and dashboards.  Only read-only
+; methods which are cheap to serve, such as getblock and getrawtransaction, may
+; be made public, and they may not be used with rpcclientcafile.  One per line.
+; rpcpublicmethod=getblockcount
+; rpcpublicmethod=getblock
+
+; Allow browser clients served from the following origins to make cross-origin
+; requests to the HTTP POST endpoint.  Pages from these origins may also send
+; credentials, while * allows pages from any origin to call the public methods
+; above without credentials.  One per line.
+; rpccorsorigin=https://explorer.example.com
+; rpccorsorigin=*
+
+; The type of the key and the validity of the RPC server certificate when it is
+; generated.  The certificate is generated when neither the certificate nor the
+; key file exist and by the regeneratecert RPC, which presents it to new clients
+; without a restart.
+; rpccertkeytype=P-521
+; rpccertvalidity=87600h
+
 ; Specify the maximum number of concurrent RPC clients for standard connections.
 ; rpcmaxclients=10
 
 ; Specify the maximum number of concurrent RPC websocket clients.
 ; rpcmaxwebsockets=25
 
+; Specify the maximum number of addresses and outpoints each RPC websocket client
+; may add to its transaction filter (0 for no limit).
+; rpcmaxwssubscriptions=250000
+
+; Specify the maximum number of notifications queued for each RPC websocket
+; client which is not reading them fast enough.  Further notifications are
+; dropped, and the client is sent a notificationsdropped notification with the
+; number dropped once it catches up (0 for no limit).
+; rpcmaxwsqueuedntfns=10000
+
 ; Use the following setting to disable the RPC server even if the rpcuser and
 ; rpcpass are specified above.  This allows one to quickly disable the RPC
 ; server without having to remove credentials from the config file.
@@ -247,9 +366,48 @@ const FileContents = `[Application Options]
 ; Limit orphan transaction pool to 1000 transactions.
 ; maxorphantx=1000
 
+; Do not relay or mine regular transactions with null data (OP_RETURN) outputs.
+; nodatacarrier=1
+
+; Limit the data carried by null data (OP_RETURN) outputs of relayed and mined
+; regular transactions to 256 bytes and allow at most 4 of them per transaction.
+; datacarriersize=256
+; maxdatacarriers=4
+
 ; Do not accept transactions from remote peers.
 ; blocksonly=1
 
+; Run a dedicated mining node which minimizes its attack surface and bandwidth.
+; It keeps the chain and the transactions received from peers in its memory pool
+; for building block templates, but does not relay them.  Only transactions
+; submitted via RPC and blocks are relayed.  It does not answer requests for the
+; mining state, does not serve blocks older than a week to peers which are not
+; whitelisted or compact and bloom filters to any peer, and does not advertise
+; itself as a full node, so other nodes do not sync from it.  This can not be
+; combined with blocksonly.
+; miningonly=1
+
+; Relay transactions submitted via RPC after a short random delay to a random
+; subset of peers instead of immediately to all of them.  This makes it harder
+; for network observers to infer that the transactions originated from this
+; node.  Transactions which are rebroadcast because they have not been mined
+; are relayed the same way.
+; privaterelay=1
+
+; Only relay transactions submitted via RPC to outbound peers connected through
+; Tor, which are all outbound peers when the proxy above is set.  This implies
+; privaterelay and requires either proxy or onion to be set.
+; privaterelaytor=1
+
+; Try to keep the data uploaded to peers within the given number of MiB per 24
+; hours.  Once the target is reached, peers which are not whitelisted are
+; disconnected when they request blocks older than a week for the rest of the
+; cycle.  The bandwidth used, including the data uploaded during the current
+; cycle, is saved to bandwidth.json in the data directory so it is tracked
+; across restarts, and is reported by the getnettotals RPC.  The default of 0
+; disables the target.
+; maxuploadtarget=5000
+
 ; Accept and relay non-standard transactions to the network regardless of the
 ; default network settings.
 ; acceptnonstd=1
@@ -278,6 +436,25 @@ const FileContents = `[Application Options]
 ; searchrawtransactions RPC available.
 ; addrindex=1
 
+; Build and maintain an index of blocks by their header timestamps which makes
+; the getblockhashbytime RPC available.
+; timeindex=1
+
+; Build and maintain an index of the lifecycle of every ticket which makes the
+; getticketinfo and getaddresstickets RPCs available.
+; ticketindex=1
+
+; Drop the specified index on start up and rebuild it from the existing block
+; data.  Valid indexes are txindex, addrindex, existsaddrindex, cfindex,
+; timeindex, and ticketindex.  May be specified multiple times.
+; rebuildindex=txindex
+
+; Maintain an index in an external process (sidecar) which is notified of all
+; blocks connected to and disconnected from the main chain.  The value is the
+; name of the index followed by the address the sidecar listens on.  See the
+; indexers package documentation for details about the protocol.
+; externalindexer=myindex@127.0.0.1:9200
+
 
 ; ------------------------------------------------------------------------------
 ; Signature Verification Cache
@@ -286,6 +463,35 @@ const FileContents = `[Application Options]
 ; Limit the signature cache to a max of 50000 entries.
 ; sigcachemaxsize=50000
 
+; Save the signature cache to the data directory on shutdown and load it on
+; startup so signatures that were already verified are not verified again after
+; a restart.
+; persistsigcache=1
+
+
+; ------------------------------------------------------------------------------
+; Block Cache
+; ------------------------------------------------------------------------------
+
+; Keep up to 200 of the most recently connected and requested blocks in memory
+; so the blocks requested repeatedly by syncing peers and block explorers are
+; not loaded from the database again.  The default is 50 and 0 disables the
+; cache.
+; blockcachesize=200
+
+
+; ------------------------------------------------------------------------------
+; Memory Usage
+; ------------------------------------------------------------------------------
+
+; Soft limit in MiB on the memory used by the heap.  Once the heap reaches 80%
+; of the limit, the signature and block caches, orphan pools, and mempool are
+; trimmed and fewer blocks are downloaded at once during the initial block
+; download.  They are trimmed further once the heap reaches the limit.  This keeps the node
+; running on machines with little memory instead of it being killed for running
+; out of memory.  The default of 0 disables the limit.
+; memlimit=2048
+
 
 ; ------------------------------------------------------------------------------
 ; Coin Generation (Mining) Settings - The following options control the
@@ -295,9 +501,9 @@ const FileContents = `[Application Options]
 
 ; Enable built-in CPU mining.
 ;
-; NOTE: This is typically only useful for testing purposes such as testnet or
-; simnet since the difficulty on mainnet is far too high for CPU mining to be
-; worth your while.
+; NOTE: This is typically only useful for testing purposes such as testnet,
+; simnet, or regnet since the difficulty on mainnet is far too high for CPU
+; mining to be worth your while.
 ; generate=false
 
 ; Add addresses to pay mined blocks to for CPU mining and the block templates
@@ -306,6 +512,37 @@ const FileContents = `[Application Options]
 ; miningaddr=youraddress2
 ; miningaddr=youraddress3
 
+; Identifier of this rig between 1 and 65535.  The high 16 bits of the extra
+; nonces in the headers of blocks mined by the CPU miner and in the coinbase
+; transactions of block templates are set to it, so several rigs mining to the
+; same address never search the same block headers as long as each one is given
+; a distinct identifier.  Extra nonces are chosen from the whole space at random
+; when it is not set.
+; miningrigid=1
+
+; Control how often the CPU miner refreshes its block template to include the
+; transactions which arrived in the memory pool since it was generated.  The
+; template is refreshed after the maximum duration, or sooner the more fees the
+; new transactions pay, down to the minimum duration once they pay at least the
+; specified refresh fee in EXCC.  A refresh fee of 0 refreshes the template
+; after the minimum duration whenever new transactions paying any fees arrive.
+; New votes which raise the subsidy of the block always refresh the template
+; after the minimum duration.
+; miningrefreshmin=3s
+; miningrefreshmax=60s
+; miningrefreshfee=0.001
+
+; Keep a record of the most recent block templates issued to the CPU miner and
+; via the getwork and getblocktemplate RPCs, and the solutions found by the CPU
+; miner or submitted via the getwork and submitblock RPCs along with the outcome
+; of processing them.  The records are kept in miningarchive.dat in the data
+; directory, which holds the specified number of records and overwrites the
+; oldest one with each new record once full.  They are returned by the
+; getminingarchive RPC, so incidents with orphaned or rejected blocks can be
+; reconstructed after the fact.  Each record takes 512 bytes and at most
+; 100000 records may be kept.  The archive is disabled by default.
+; miningarchive=10000
+
 ; Specify the minimum block size in bytes to create.  By default, only
 ; transactions which have enough fees or a high enough priority will be included
 ; in generated block templates.  Specifying a minimum block size will instead
@@ -325,6 +562,56 @@ const FileContents = `[Application Options]
 ; by the blackmaxsize option and will be limited as needed.
 ; blockprioritysize=50000
 
+; Minimum number of votes for a block that must be available before a block
+; template extending it is created.  It must be between a majority of the
+; tickets per block, which is the consensus minimum and the default, and the
+; number of tickets per block.
+; blockminvotes=3
+
+; Maximum duration to wait after a new block arrives for votes which are still
+; missing for it.  Block templates extending the new block are not created until
+; either every selected ticket has voted or the duration has passed.  The
+; default of 0 creates block templates as soon as the minimum number of votes
+; is available.
+; blockmaxvotewait=5s
+
+; Do not include revocations of missed and expired tickets in block templates.
+; blocknorevocations=1
+
+
+; ------------------------------------------------------------------------------
+; Automatic Ticket Revocations
+; ------------------------------------------------------------------------------
+
+; Automatically create and relay revocations for missed and expired tickets
+; whose voting rights are committed to the P2SH address of the given
+; hex-encoded redeem script so the locked funds are returned to the ticket
+; commitment addresses without the need for a wallet.  Signatures required by
+; the redeem script are created with the keys given with autorevokekey.  One
+; redeem script per line.
+; autorevokescript=51
+
+; Automatically create and relay revocations for missed and expired tickets
+; whose voting rights are committed to the P2PKH address of the given
+; WIF-encoded secp256k1 private key.  The key also signs the revocations of the
+; autorevokescript redeem scripts which require its signature.  One private key
+; per line.
+; autorevokekey=
+
+; Automatically purchase tickets and vote with a key held by the node so blocks
+; can continuously be generated on the simulation test network without a
+; wallet providing votes.  The coinbases of generated blocks are partially paid
+; to the key in order to fund the tickets.  The key is publicly known, so this
+; is only valid with --simnet.
+; simnetautostake=1
+
+; Cast the given choice on the given agenda with the votes of the automatic
+; staker, which are then cast with the vote version of the agenda so the stake
+; version is upgraded as well.  Combined with the simagenda utility, this drives
+; the agenda through its full lifecycle.  All of the agendas must belong to the
+; same vote version.  One agenda choice per line.
+; simnetvote=maxblocksize=yes
+
 
 ; ------------------------------------------------------------------------------
 ; Debug
@@ -337,6 +624,29 @@ const FileContents = `[Application Options]
 ; available subsystems.
 ; debuglevel=info
 
+; Format of the log output.  Valid formats are {text, json}.  The json format
+; writes a JSON object per line with the time, level, subsystem, and message
+; along with fields such as the peer, hash, and height found in the message.
+; logformat=text
+
+; Log files are rolled once they reach a maximum size in KiB or are older than
+; a maximum age.  A value of 0 disables rolling by size or age respectively.
+; Rolled files are compressed with gzip when logcompression is set, and only the
+; most recent logmaxrolls of them are kept (0 keeps all of them).  The age of a
+; log file is measured from when it was created, so it keeps increasing across
+; restarts.  The rotatelogs RPC rolls the log files on demand.
+; logmaxsize=10240
+; logmaxage=24h
+; logmaxrolls=3
+; logcompression=1
+
+; Write the log messages of a subsystem to its own file in the log directory,
+; such as exccd-peer.log for the PEER subsystem, instead of the main log file.
+; May be specified multiple times.
+; logsplit=MINR
+; logsplit=PEER
+; logsplit=RPCS
+
 ; ------------------------------------------------------------------------------
 ; Profile - enable the HTTP profiler
 ; ------------------------------------------------------------------------------
@@ -353,4 +663,64 @@ const FileContents = `[Application Options]
 ;   profile=192.168.1.123:6061
 ; Listen on ipv6 loopback interface:
 ;   profile=[::1]:6061
+
+; ------------------------------------------------------------------------------
+; Health - serve liveness and readiness endpoints
+; ------------------------------------------------------------------------------
+
+; The health server will be disabled if this option is not specified.  Once
+; running, http://ipaddr:<healthport>/healthz responds with status 200 while the
+; process is up, and http://ipaddr:<healthport>/readyz responds with status 200
+; once the chain is loaded and the best block is no older than the maximum block
+; age and with status 503 otherwise, which is suitable for Kubernetes probes and
+; load balancer health checks.  All network interfaces are used if an IP address
+; is not specified.
+; healthlisten=9190
+; healthlisten=127.0.0.1:9190
+; healthmaxblockage=1h
+
+; ------------------------------------------------------------------------------
+; Tracing - export spans to an OpenTelemetry collector
+; ------------------------------------------------------------------------------
+
+; Traces of block processing, block template generation, and RPC handling are
+; exported to the specified endpoint using the OpenTelemetry protocol (OTLP)
+; with the JSON encoding over HTTP.  The standard /v1/traces path is used when
+; the endpoint does not specify a path.  Tracing is disabled if this option is
+; not specified.
+; otlpendpoint=http://127.0.0.1:4318
+
+; ------------------------------------------------------------------------------
+; Alerts - notify operators of significant events
+; ------------------------------------------------------------------------------
+
+; Alerts are sent for the following events when a webhook or command is
+; specified:
+;
+;   reorg          a chain reorganization disconnected at least alertreorgdepth
+;                  blocks
+;   syncstalled    no new best block for alertsyncstall
+;   syncresumed    a new best block after the sync stalled
+;   lowpeers       fewer than alertminpeers peers are connected
+;   peersrestored  at least alertminpeers peers are connected again
+;   blockaccepted  a block mined by the CPU miner or submitted via getwork or
+;                  submitblock was accepted
+;   blockrejected  a block mined by the CPU miner or submitted via getwork or
+;                  submitblock was rejected
+;   indexerror     an optional index could not be updated, such as when it is
+;                  corrupt
+;   miningpaused   the CPU miner paused after repeatedly failing to get a mining
+;                  address or create a block template
+;   miningresumed  the CPU miner created a block template again after pausing
+;
+; Each alert is a JSON object with the event, time, network, a human-readable
+; message, and event-specific data.  It is sent as the body of a POST request to
+; every webhook and written to the standard input of the command, which also
+; has the name of the event set in the EXCCD_EVENT environment variable.  The
+; command is split into its arguments on whitespace and is not run by a shell.
+; alertwebhook=https://hooks.example.com/exccd/secrettoken
+; alertcmd=/usr/local/bin/exccd-alert --email ops@example.com
+; alertreorgdepth=3
+; alertsyncstall=1h
+; alertminpeers=1
 `
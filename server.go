@@ -13,6 +13,8 @@ import (
 	"fmt"
 	"math"
 	"net"
+	"os"
+	"path/filepath"
 	"runtime"
 	"strconv"
 	"strings"
@@ -58,6 +60,19 @@ const (
 
 	// maxProtocolVersion is the max protocol version the server supports.
 	maxProtocolVersion = wire.NodeCFVersion
+
+	// sigCacheFilename is the name of the file in the data directory the
+	// signature cache is saved to when --persistsigcache is set.
+	sigCacheFilename = "sigcache.dat"
+
+	// privateRelayMaxDelay is the maximum random delay before transactions
+	// submitted via RPC are relayed when --privaterelay is enabled.
+	privateRelayMaxDelay = time.Second * 10
+
+	// privateRelayPeers is the number of randomly selected peers
+	// transactions submitted via RPC are relayed to when --privaterelay is
+	// enabled.
+	privateRelayPeers = 2
 )
 
 var (
@@ -90,6 +105,10 @@ type broadcastInventoryDel *wire.InvVect
 type relayMsg struct {
 	invVect *wire.InvVect
 	data    interface{}
+
+	// private indicates the inventory is for a transaction submitted via
+	// RPC which must only be relayed to a random subset of peers.
+	private bool
 }
 
 // updatePeerHeightsMsg is a message sent from the blockmanager to the server
@@ -154,11 +173,21 @@ type server struct {
 	chainParams          *chaincfg.Params
 	addrManager          *addrmgr.AddrManager
 	connManager          *connmgr.ConnManager
+	dnsSeeder            *connmgr.DNSSeeder
 	sigCache             *txscript.SigCache
 	rpcServer            *rpcServer
 	blockManager         *blockManager
 	txMemPool            *mempool.TxPool
 	cpuMiner             *CPUMiner
+	workTracker          *workTracker
+	miningArchive        *miningArchive
+	minedBlocks          *minedBlockStats
+	ticketRevoker        *ticketRevoker
+	simnetStaker         *simnetStaker
+	memGovernor          *memGovernor
+	alerter              *alerter
+	syncTracker          *syncTracker
+	bandwidth            *bandwidthAccounting
 	modifyRebroadcastInv chan interface{}
 	newPeers             chan *serverPeer
 	donePeers            chan *serverPeer
@@ -171,9 +200,20 @@ type server struct {
 	quit                 chan struct{}
 	nat                  NAT
 	db                   database.DB
+	clock                blockchain.Clock
 	timeSource           blockchain.MedianTimeSource
 	services             wire.ServiceFlag
 
+	// syncOnlyAddrs houses the resolved addresses of the peers configured
+	// with --synconly, which are the only peers blocks are accepted from
+	// when any are configured.  It is set during initial creation of the
+	// server and never changed afterwards.
+	syncOnlyAddrs map[string]struct{}
+
+	// startupTime is the time the server was started.  It is set before the
+	// RPC server is started and never changed afterwards.
+	startupTime time.Time
+
 	// The following fields are used for optional indexes.  They will be nil
 	// if the associated index is not enabled.  These fields are set during
 	// initial creation of the server and never changed afterwards, so they
@@ -182,6 +222,9 @@ type server struct {
 	addrIndex       *indexers.AddrIndex
 	existsAddrIndex *indexers.ExistsAddrIndex
 	cfIndex         *indexers.CFIndex
+	timeIndex       *indexers.TimeIndex
+	ticketIndex     *indexers.TicketIndex
+	indexManager    *indexers.Manager
 }
 
 // serverPeer extends the peer to maintain state shared by the server and
@@ -196,6 +239,7 @@ type serverPeer struct {
 	relayMtx        sync.Mutex
 	disableRelayTx  bool
 	isWhitelisted   bool
+	isSyncOnlyPeer  bool
 	requestQueue    []*wire.InvVect
 	requestedTxns   map[chainhash.Hash]struct{}
 	requestedBlocks map[chainhash.Hash]struct{}
@@ -254,6 +298,21 @@ func (sp *serverPeer) setDisableRelayTx(disable bool) {
 	sp.relayMtx.Unlock()
 }
 
+// isTorPeer returns whether the peer is connected through Tor.  This is the
+// case for outbound connections to onion addresses and, since the proxy is
+// treated as Tor unless --noonion is specified, all outbound connections when
+// --proxy is set.
+func (sp *serverPeer) isTorPeer() bool {
+	if sp.Inbound() || cfg.NoOnion {
+		return false
+	}
+	if cfg.Proxy != "" {
+		return true
+	}
+	host, _, err := net.SplitHostPort(sp.Addr())
+	return err == nil && strings.HasSuffix(host, ".onion")
+}
+
 // relayTxDisabled returns whether or not relaying of transactions for the given
 // peer is disabled.
 // It is safe for concurrent access.
@@ -299,7 +358,8 @@ func (sp *serverPeer) addBanScore(persistent, transient uint32, reason string) {
 		return
 	}
 
-	warnThreshold := cfg.BanThreshold >> 1
+	_, banThreshold := cfg.currentBanSettings()
+	warnThreshold := banThreshold >> 1
 	if transient == 0 && persistent == 0 {
 		// The score is not being increased, but a warning message is still
 		// logged if the score is above the warn threshold.
@@ -314,7 +374,7 @@ func (sp *serverPeer) addBanScore(persistent, transient uint32, reason string) {
 	if score > warnThreshold {
 		peerLog.Warnf("Misbehaving peer %s: %s -- ban score increased to %d",
 			sp, reason, score)
-		if score > cfg.BanThreshold {
+		if score > banThreshold {
 			peerLog.Warnf("Misbehaving peer %s -- banning and disconnecting",
 				sp)
 			sp.server.BanPeer(sp)
@@ -340,10 +400,10 @@ func (sp *serverPeer) OnVersion(p *peer.Peer, msg *wire.MsgVersion) {
 
 	// Update the address manager and request known addresses from the
 	// remote peer for outbound connections.  This is skipped when running
-	// on the simulation test network since it is only intended to connect
-	// to specified peers and actively avoids advertising and connecting to
-	// discovered peers.
-	if !cfg.SimNet {
+	// on the simulation and regression test networks since they are only
+	// intended to connect to specified peers and actively avoid advertising
+	// and connecting to discovered peers.
+	if !cfg.SimNet && !cfg.RegNet {
 		addrManager := sp.server.addrManager
 		// Outbound connections.
 		if !p.Inbound() {
@@ -449,6 +509,12 @@ func (sp *serverPeer) pushMiningStateMsg(height uint32, blockHashes []chainhash.
 // It constructs a list of the current best blocks and votes that should be
 // mined on and pushes a miningstate wire message back to the requesting peer.
 func (sp *serverPeer) OnGetMiningState(p *peer.Peer, msg *wire.MsgGetMiningState) {
+	if cfg.MiningOnly {
+		peerLog.Tracef("Ignoring getminingstate from %v - miningonly "+
+			"enabled", p)
+		return
+	}
+
 	// Access the block manager and get the list of best blocks to mine on.
 	bm := sp.server.blockManager
 	mp := sp.server.txMemPool
@@ -1207,11 +1273,11 @@ func (sp *serverPeer) OnFilterLoad(p *peer.Peer, msg *wire.MsgFilterLoad) {
 // OnGetAddr is invoked when a peer receives a getaddr wire message and is used
 // to provide the peer with known addresses from the address manager.
 func (sp *serverPeer) OnGetAddr(p *peer.Peer, msg *wire.MsgGetAddr) {
-	// Don't return any addresses when running on the simulation test
-	// network.  This helps prevent the network from becoming another
-	// public test network since it will not be able to learn about other
-	// peers that have not specifically been provided.
-	if cfg.SimNet {
+	// Don't return any addresses when running on the simulation or
+	// regression test networks.  This helps prevent the network from
+	// becoming another public test network since it will not be able to
+	// learn about other peers that have not specifically been provided.
+	if cfg.SimNet || cfg.RegNet {
 		return
 	}
 
@@ -1231,11 +1297,11 @@ func (sp *serverPeer) OnGetAddr(p *peer.Peer, msg *wire.MsgGetAddr) {
 // OnAddr is invoked when a peer receives an addr wire message and is used to
 // notify the server about advertised addresses.
 func (sp *serverPeer) OnAddr(p *peer.Peer, msg *wire.MsgAddr) {
-	// Ignore addresses when running on the simulation test network.  This
-	// helps prevent the network from becoming another public test network
-	// since it will not be able to learn about other peers that have not
-	// specifically been provided.
-	if cfg.SimNet {
+	// Ignore addresses when running on the simulation or regression test
+	// networks.  This helps prevent the network from becoming another
+	// public test network since it will not be able to learn about other
+	// peers that have not specifically been provided.
+	if cfg.SimNet || cfg.RegNet {
 		return
 	}
 
@@ -1277,12 +1343,19 @@ func (sp *serverPeer) OnAddr(p *peer.Peer, msg *wire.MsgAddr) {
 // the bytes received by the server.
 func (sp *serverPeer) OnRead(p *peer.Peer, bytesRead int, msg wire.Message, err error) {
 	sp.server.AddBytesReceived(uint64(bytesRead))
+
+	command := unknownMsgCommand
+	if msg != nil {
+		command = msg.Command()
+	}
+	sp.server.bandwidth.record(command, uint64(bytesRead), 0)
 }
 
 // OnWrite is invoked when a peer sends a message and it is used to update
 // the bytes sent by the server.
 func (sp *serverPeer) OnWrite(p *peer.Peer, bytesWritten int, msg wire.Message, err error) {
 	sp.server.AddBytesSent(uint64(bytesWritten))
+	sp.server.bandwidth.record(msg.Command(), 0, uint64(bytesWritten))
 }
 
 // randomUint16Number returns a random uint16 in a specified input range.  Note
@@ -1329,14 +1402,37 @@ func (s *server) RemoveRebroadcastInventory(iv *wire.InvVect) {
 // both websocket and getblocktemplate long poll clients of the passed
 // transactions.  This function should be called whenever new transactions
 // are added to the mempool.
+//
+// The transactions are not relayed when mining-only mode is enabled.
 func (s *server) AnnounceNewTransactions(newTxs []*exccutil.Tx) {
+	s.announceTransactions(newTxs, false, !cfg.MiningOnly)
+}
+
+// AnnounceLocalTransactions is the same as AnnounceNewTransactions except it
+// is for transactions submitted via RPC, which are relayed privately when
+// --privaterelay is enabled.
+func (s *server) AnnounceLocalTransactions(newTxs []*exccutil.Tx) {
+	s.announceTransactions(newTxs, cfg.PrivateRelay, true)
+}
+
+// announceTransactions generates and relays inventory vectors and notifies
+// clients of the passed transactions.  The transactions are only relayed when
+// the relay flag is set, and are relayed privately instead of to all peers
+// when the private flag is set.
+func (s *server) announceTransactions(newTxs []*exccutil.Tx, private, relay bool) {
+	if relay && private {
+		s.relayTransactionsPrivately(newTxs)
+	}
+
 	// Generate and relay inventory vectors for all newly accepted
 	// transactions into the memory pool due to the original being
 	// accepted.
 	for _, tx := range newTxs {
 		// Generate the inventory vector and relay it.
-		iv := wire.NewInvVect(wire.InvTypeTx, tx.Hash())
-		s.RelayInventory(iv, tx)
+		if relay && !private {
+			iv := wire.NewInvVect(wire.InvTypeTx, tx.Hash())
+			s.RelayInventory(iv, tx)
+		}
 
 		if s.rpcServer != nil {
 			// Notify websocket clients about mempool transactions.
@@ -1350,6 +1446,31 @@ func (s *server) AnnounceNewTransactions(newTxs []*exccutil.Tx) {
 	}
 }
 
+// relayTransactionsPrivately relays inventory vectors for the passed
+// transactions after a random delay to a random subset of peers instead of
+// immediately to all of them, which makes it harder for network observers to
+// infer that they originated from this node.  All of the transactions are
+// relayed after the same delay so any which depend on each other are relayed
+// in order.
+func (s *server) relayTransactionsPrivately(txns []*exccutil.Tx) {
+	maxDelay := uint16(privateRelayMaxDelay / time.Millisecond)
+	delay := time.Duration(randomUint16Number(maxDelay)) * time.Millisecond
+	time.AfterFunc(delay, func() {
+		for _, tx := range txns {
+			msg := relayMsg{
+				invVect: wire.NewInvVect(wire.InvTypeTx, tx.Hash()),
+				data:    tx,
+				private: true,
+			}
+			select {
+			case s.relayInv <- msg:
+			case <-s.quit:
+				return
+			}
+		}
+	})
+}
+
 // pushTxMsg sends a tx message for the provided transaction hash to the
 // connected peer.  An error is returned if the transaction hash is not known.
 func (s *server) pushTxMsg(sp *serverPeer, hash *chainhash.Hash, doneChan chan<- struct{}, waitChan <-chan struct{}) error {
@@ -1380,6 +1501,27 @@ func (s *server) pushTxMsg(sp *serverPeer, hash *chainhash.Hash, doneChan chan<-
 	return nil
 }
 
+// historicalBlockRefusal returns why the passed peer is not served the block
+// with the passed header, or an empty string when it is served.  Peers which
+// are not whitelisted are not served historical blocks in mining-only mode or
+// once the upload target is reached so the data uploaded stays within the
+// target.
+func (s *server) historicalBlockRefusal(sp *serverPeer, header *wire.BlockHeader) string {
+	if sp.isWhitelisted ||
+		time.Since(header.Timestamp) <= historicalBlockAge {
+
+		return ""
+	}
+
+	switch {
+	case cfg.MiningOnly:
+		return "mining-only mode enabled"
+	case s.bandwidth.UploadTargetReached():
+		return "upload target reached"
+	}
+	return ""
+}
+
 // pushBlockMsg sends a block message for the provided block hash to the
 // connected peer.  An error is returned if the block hash is not known.
 func (s *server) pushBlockMsg(sp *serverPeer, hash *chainhash.Hash, doneChan chan<- struct{}, waitChan <-chan struct{}) error {
@@ -1394,6 +1536,18 @@ func (s *server) pushBlockMsg(sp *serverPeer, hash *chainhash.Hash, doneChan cha
 		return err
 	}
 
+	// Disconnect peers which are not allowed to request the block.
+	reason := s.historicalBlockRefusal(sp, &block.MsgBlock().Header)
+	if reason != "" {
+		peerLog.Infof("Disconnecting peer %v which requested historical "+
+			"block %v -- %s", sp, hash, reason)
+		sp.Disconnect()
+		if doneChan != nil {
+			doneChan <- struct{}{}
+		}
+		return errors.New(reason)
+	}
+
 	// Once we have fetched data wait for any previous operation to finish.
 	if waitChan != nil {
 		<-waitChan
@@ -1618,14 +1772,20 @@ func (s *server) handleBanPeerMsg(state *peerState, sp *serverPeer) {
 		return
 	}
 	direction := directionString(sp.Inbound())
+	banDuration, _ := cfg.currentBanSettings()
 	srvrLog.Infof("Banned peer %s (%s) for %v", host, direction,
-		cfg.BanDuration)
-	state.banned[host] = time.Now().Add(cfg.BanDuration)
+		banDuration)
+	state.banned[host] = time.Now().Add(banDuration)
 }
 
 // handleRelayInvMsg deals with relaying inventory to peers that are not already
 // known to have it.  It is invoked from the peerHandler goroutine.
 func (s *server) handleRelayInvMsg(state *peerState, msg relayMsg) {
+	if msg.private {
+		s.handlePrivateRelayInvMsg(state, msg)
+		return
+	}
+
 	state.forAllPeers(func(sp *serverPeer) {
 		if !sp.Connected() {
 			return
@@ -1680,6 +1840,51 @@ func (s *server) handleRelayInvMsg(state *peerState, msg relayMsg) {
 	})
 }
 
+// handlePrivateRelayInvMsg deals with relaying inventory for a transaction
+// submitted via RPC to a random subset of the peers which relay transactions,
+// which are limited to the peers connected through Tor when --privaterelaytor
+// is enabled.  It is invoked from the peerHandler goroutine.
+func (s *server) handlePrivateRelayInvMsg(state *peerState, msg relayMsg) {
+	// Nothing to do if the transaction was mined or removed from the memory
+	// pool while the relay was delayed.
+	if !s.txMemPool.HaveTransaction(&msg.invVect.Hash) {
+		return
+	}
+
+	// Peers with a bloom filter loaded are lightweight clients which do not
+	// relay transactions any further, so they are not candidates.
+	var candidates []*serverPeer
+	state.forAllPeers(func(sp *serverPeer) {
+		if !sp.Connected() || sp.relayTxDisabled() ||
+			sp.filter.IsLoaded() {
+
+			return
+		}
+		if cfg.PrivateRelayTor && !sp.isTorPeer() {
+			return
+		}
+		candidates = append(candidates, sp)
+	})
+	if len(candidates) == 0 {
+		srvrLog.Warnf("No peers to privately relay transaction %v to",
+			msg.invVect.Hash)
+		return
+	}
+
+	// Queue the inventory to be relayed to randomly selected candidates.
+	numPeers := privateRelayPeers
+	if numPeers > len(candidates) {
+		numPeers = len(candidates)
+	}
+	for i := 0; i < numPeers; i++ {
+		j := i + int(randomUint16Number(uint16(len(candidates)-i)))
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+		candidates[i].QueueInventory(msg.invVect)
+	}
+	srvrLog.Debugf("Privately relaying transaction %v to %v", msg.invVect.Hash,
+		candidates[:numPeers])
+}
+
 // handleBroadcastMsg deals with broadcasting messages to peers.  It is invoked
 // from the peerHandler goroutine.
 func (s *server) handleBroadcastMsg(state *peerState, bmsg *broadcastMsg) {
@@ -1902,6 +2107,7 @@ func newPeerConfig(sp *serverPeer) *peer.Config {
 		Services:         sp.server.services,
 		DisableRelayTx:   cfg.BlocksOnly,
 		ProtocolVersion:  maxProtocolVersion,
+		PanicHandler:     handleCrash,
 	}
 }
 
@@ -1914,7 +2120,7 @@ func (s *server) inboundPeerConnected(conn net.Conn) {
 	sp.isWhitelisted = isWhitelisted(conn.RemoteAddr())
 	sp.Peer = peer.NewInboundPeer(newPeerConfig(sp))
 	sp.AssociateConnection(conn)
-	go s.peerDoneHandler(sp)
+	spawn(func() { s.peerDoneHandler(sp) })
 }
 
 // outboundPeerConnected is invoked by the connection manager when a new
@@ -1932,8 +2138,9 @@ func (s *server) outboundPeerConnected(c *connmgr.ConnReq, conn net.Conn) {
 	sp.Peer = p
 	sp.connReq = c
 	sp.isWhitelisted = isWhitelisted(conn.RemoteAddr())
+	_, sp.isSyncOnlyPeer = s.syncOnlyAddrs[c.Addr.String()]
 	sp.AssociateConnection(conn)
-	go s.peerDoneHandler(sp)
+	spawn(func() { s.peerDoneHandler(sp) })
 	s.addrManager.Attempt(sp.NA())
 }
 
@@ -1974,14 +2181,7 @@ func (s *server) peerHandler() {
 
 	if !cfg.DisableDNSSeed {
 		// Add peers discovered through DNS to the address manager.
-		connmgr.SeedFromDNS(activeNetParams.Params, defaultRequiredServices, exccdLookup, func(addrs []*wire.NetAddress) {
-			// Bitcoind uses a lookup of the dns seeder here. This
-			// is rather strange since the values looked up by the
-			// DNS seed lookups will vary quite a lot.
-			// to replicate this behaviour we put all addresses as
-			// having come from the first one.
-			s.addrManager.AddAddresses(addrs, addrs[0])
-		})
+		s.dnsSeeder.Seed()
 	}
 	go s.connManager.Start()
 
@@ -2029,6 +2229,14 @@ out:
 	s.connManager.Stop()
 	s.blockManager.Stop()
 	s.addrManager.Stop()
+	if cfg.PersistSigCache {
+		s.saveSigCache()
+	}
+	if s.miningArchive != nil {
+		if err := s.miningArchive.Close(); err != nil {
+			srvrLog.Errorf("Unable to close mining archive: %v", err)
+		}
+	}
 
 	// Drain channels before exiting so nothing is left waiting around
 	// to send.
@@ -2049,6 +2257,85 @@ cleanup:
 	srvrLog.Tracef("Peer handler done")
 }
 
+// loadSigCache loads the signature cache entries saved to the data directory
+// by saveSigCache, if any, into the signature cache of the server.
+func (s *server) loadSigCache() {
+	path := filepath.Join(cfg.DataDir, sigCacheFilename)
+	f, err := os.Open(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			srvrLog.Warnf("Unable to open signature cache file: %v", err)
+		}
+		return
+	}
+	defer f.Close()
+
+	n, err := s.sigCache.Load(f)
+	if err != nil {
+		srvrLog.Warnf("Unable to load signature cache from %s: %v", path,
+			err)
+	}
+	srvrLog.Infof("Loaded %d signature cache entries from %s", n, path)
+}
+
+// saveSigCache saves the entries of the signature cache of the server to the
+// data directory so they can be loaded by loadSigCache after a restart.
+func (s *server) saveSigCache() {
+	// Write to a temporary file first so an existing file is not truncated
+	// in case of failure.
+	path := filepath.Join(cfg.DataDir, sigCacheFilename)
+	tmpPath := path + ".tmp"
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		srvrLog.Errorf("Unable to create signature cache file: %v", err)
+		return
+	}
+	err = s.sigCache.Save(f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, path)
+	}
+	if err != nil {
+		srvrLog.Errorf("Unable to save signature cache to %s: %v", path,
+			err)
+		os.Remove(tmpPath)
+		return
+	}
+	srvrLog.Infof("Saved %d signature cache entries to %s",
+		s.sigCache.Stats().Entries, path)
+}
+
+// bandwidthHandler periodically saves the bandwidth totals to the data
+// directory and saves them a final time on shutdown.  It must be run as a
+// goroutine.
+func (s *server) bandwidthHandler() {
+	path := filepath.Join(cfg.DataDir, bandwidthFilename)
+	ticker := time.NewTicker(bandwidthSaveInterval)
+	defer ticker.Stop()
+
+out:
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.bandwidth.save(path); err != nil {
+				srvrLog.Errorf("Unable to save bandwidth totals "+
+					"to %s: %v", path, err)
+			}
+
+		case <-s.quit:
+			break out
+		}
+	}
+
+	if err := s.bandwidth.save(path); err != nil {
+		srvrLog.Errorf("Unable to save bandwidth totals to %s: %v",
+			path, err)
+	}
+	s.wg.Done()
+}
+
 // AddPeer adds a new peer that has already been connected to the server.
 func (s *server) AddPeer(sp *serverPeer) {
 	s.newPeers <- sp
@@ -2233,6 +2520,11 @@ out:
 			// Any inventory we have has not made it into a block
 			// yet. We periodically resubmit them until they have.
 			for iv, data := range pendingInvs {
+				tx, ok := data.(*exccutil.Tx)
+				if ok && cfg.PrivateRelay {
+					s.relayTransactionsPrivately([]*exccutil.Tx{tx})
+					continue
+				}
 				ivCopy := iv
 				s.RelayInventory(&ivCopy, data)
 			}
@@ -2270,15 +2562,19 @@ func (s *server) Start() {
 	}
 
 	srvrLog.Trace("Starting server")
+	s.startupTime = time.Now()
 
 	// Start the peer handler which in turn starts the address and block
 	// managers.
 	s.wg.Add(1)
-	go s.peerHandler()
+	spawn(s.peerHandler)
+
+	s.wg.Add(1)
+	spawn(s.bandwidthHandler)
 
 	if s.nat != nil {
 		s.wg.Add(1)
-		go s.upnpUpdateThread()
+		spawn(s.upnpUpdateThread)
 	}
 
 	if !cfg.DisableRPC {
@@ -2286,7 +2582,7 @@ func (s *server) Start() {
 
 		// Start the rebroadcastHandler, which ensures user tx received by
 		// the RPC server are rebroadcast until being included in a block.
-		go s.rebroadcastHandler()
+		spawn(s.rebroadcastHandler)
 
 		s.rpcServer.Start()
 	}
@@ -2295,6 +2591,36 @@ func (s *server) Start() {
 	if cfg.Generate {
 		s.cpuMiner.Start()
 	}
+
+	// Start the ticket revoker if automatic revocations are enabled.
+	if s.ticketRevoker != nil {
+		s.ticketRevoker.Start()
+	}
+
+	// Start the simnet staker if automatic staking is enabled.
+	if s.simnetStaker != nil {
+		s.simnetStaker.Start()
+	}
+
+	// Start the memory governor if the memory limit is enabled.
+	if s.memGovernor != nil {
+		s.memGovernor.Start()
+	}
+
+	// Start the alerter if alerts are enabled.
+	if s.alerter != nil {
+		s.alerter.Start()
+	}
+
+	// Start tracking the progress of the chain sync.
+	s.syncTracker.Start()
+
+	// Load the optional indexes in the background now that the node is
+	// accepting connections since it can take a long time on large
+	// databases.
+	if s.indexManager != nil {
+		s.indexManager.Load()
+	}
 }
 
 // Stop gracefully shuts down the server by stopping and disconnecting all
@@ -2313,6 +2639,29 @@ func (s *server) Stop() error {
 		s.cpuMiner.Stop()
 	}
 
+	// Stop the ticket revoker if needed.
+	if s.ticketRevoker != nil {
+		s.ticketRevoker.Stop()
+	}
+
+	// Stop the simnet staker if needed.
+	if s.simnetStaker != nil {
+		s.simnetStaker.Stop()
+	}
+
+	// Stop the memory governor if needed.
+	if s.memGovernor != nil {
+		s.memGovernor.Stop()
+	}
+
+	// Stop the alerter if needed.
+	if s.alerter != nil {
+		s.alerter.Stop()
+	}
+
+	// Stop tracking the progress of the chain sync.
+	s.syncTracker.Stop()
+
 	// Shutdown the RPC server if it's not disabled.
 	if !cfg.DisableRPC && s.rpcServer != nil {
 		s.rpcServer.Stop()
@@ -2485,10 +2834,11 @@ func standardScriptVerifyFlags(chain *blockchain.BlockChain) (txscript.ScriptFla
 	return scriptFlags, nil
 }
 
-// newServer returns a new exccd server configured to listen on addr for the
-// ExchangeCoin network type specified by chainParams.  Use start to begin accepting
-// connections from peers.
-func newServer(listenAddrs []string, db database.DB, chainParams *chaincfg.Params, interrupt <-chan struct{}) (*server, error) {
+// serverServices returns the services the server supports and advertises to
+// peers according to the configuration.  Mining-only nodes do not advertise
+// themselves as full nodes and do not serve any filters, so other nodes do not
+// sync from them.
+func serverServices() wire.ServiceFlag {
 	services := defaultServices
 	if cfg.NoPeerBloomFilters {
 		services &^= wire.SFNodeBloom
@@ -2496,6 +2846,17 @@ func newServer(listenAddrs []string, db database.DB, chainParams *chaincfg.Param
 	if cfg.NoCFilters {
 		services &^= wire.SFNodeCF
 	}
+	if cfg.MiningOnly {
+		services &^= wire.SFNodeNetwork | wire.SFNodeBloom | wire.SFNodeCF
+	}
+	return services
+}
+
+// newServer returns a new exccd server configured to listen on addr for the
+// ExchangeCoin network type specified by chainParams.  Use start to begin accepting
+// connections from peers.
+func newServer(listenAddrs []string, db database.DB, chainParams *chaincfg.Params, interrupt <-chan struct{}) (*server, error) {
+	services := serverServices()
 
 	amgr := addrmgr.New(cfg.DataDir, exccdLookup)
 
@@ -2626,6 +2987,13 @@ func newServer(listenAddrs []string, db database.DB, chainParams *chaincfg.Param
 		}
 	}
 
+	// Use a mock clock on the test networks so simulations are able to
+	// fast-forward time via the setmocktime RPC.
+	clock := blockchain.SystemClock
+	if cfg.SimNet || cfg.RegNet {
+		clock = blockchain.NewMockClock()
+	}
+
 	s := server{
 		chainParams:          chainParams,
 		addrManager:          amgr,
@@ -2640,10 +3008,21 @@ func newServer(listenAddrs []string, db database.DB, chainParams *chaincfg.Param
 		peerHeightsUpdate:    make(chan updatePeerHeightsMsg),
 		nat:                  nat,
 		db:                   db,
-		timeSource:           blockchain.NewMedianTime(),
+		clock:                clock,
+		timeSource:           blockchain.NewMedianTimeWithClock(clock),
 		services:             services,
 		sigCache:             txscript.NewSigCache(cfg.SigCacheMaxSize),
 	}
+	if cfg.PersistSigCache {
+		s.loadSigCache()
+	}
+	s.bandwidth = newBandwidthAccounting(cfg.MaxUploadTarget*1024*1024,
+		time.Now)
+	bandwidthPath := filepath.Join(cfg.DataDir, bandwidthFilename)
+	if err := s.bandwidth.load(bandwidthPath); err != nil {
+		srvrLog.Warnf("Unable to load bandwidth totals from %s: %v",
+			bandwidthPath, err)
+	}
 
 	// Create the transaction and address indexes if needed.
 	//
@@ -2681,11 +3060,40 @@ func newServer(listenAddrs []string, db database.DB, chainParams *chaincfg.Param
 		s.cfIndex = indexers.NewCfIndex(db, chainParams)
 		indexes = append(indexes, s.cfIndex)
 	}
+	if cfg.TimeIndex {
+		indxLog.Info("Block timestamp index is enabled")
+		s.timeIndex = indexers.NewTimeIndex(db)
+		indexes = append(indexes, s.timeIndex)
+	}
+	if cfg.TicketIndex {
+		indxLog.Info("Ticket lifecycle index is enabled")
+		s.ticketIndex = indexers.NewTicketIndex(db, chainParams)
+		indexes = append(indexes, s.ticketIndex)
+	}
+	for _, extIndexer := range cfg.externalIndexers {
+		indxLog.Infof("External index %s is enabled (%s)", extIndexer.name,
+			extIndexer.addr)
+		indexes = append(indexes, indexers.NewExternalIndex(
+			extIndexer.name, extIndexer.addr, chainParams))
+	}
+
+	// Create the alerter before the index manager since it reports index
+	// errors while the indexes are initialized.
+	if len(cfg.AlertWebhooks) > 0 || cfg.AlertCmd != "" {
+		s.alerter = newAlerter(&s)
+	}
 
 	// Create an index manager if any of the optional indexes are enabled.
 	var indexManager blockchain.IndexManager
 	if len(indexes) > 0 {
-		indexManager = indexers.NewManager(db, indexes, chainParams)
+		s.indexManager = indexers.NewManager(db, indexes, chainParams)
+		indexManager = s.indexManager
+		s.indexManager.SetErrorHandler(func(err error) {
+			s.notifyAlert(alertEventIndexError, fmt.Sprintf("Unable "+
+				"to update the indexes: %v", err),
+				map[string]interface{}{"error": err.Error()})
+		})
+		s.indexManager.SetPanicHandler(handleCrash)
 	}
 	bm, err := newBlockManager(&s, indexManager, interrupt)
 	if err != nil {
@@ -2693,6 +3101,13 @@ func newServer(listenAddrs []string, db database.DB, chainParams *chaincfg.Param
 	}
 	s.blockManager = bm
 
+	// The same data carrier policy is enforced when accepting transactions
+	// into the memory pool and when generating block templates.
+	dataCarrierPolicy := mining.DataCarrierPolicy{
+		Disable:    cfg.NoDataCarrier,
+		MaxSize:    cfg.DataCarrierSize,
+		MaxOutputs: cfg.MaxDataCarriers,
+	}
 	txC := mempool.Config{
 		Policy: mempool.Policy{
 			MaxTxVersion:         2,
@@ -2704,6 +3119,7 @@ func newServer(listenAddrs []string, db database.DB, chainParams *chaincfg.Param
 			MaxSigOpsPerTx:       blockchain.MaxSigOpsPerBlock / 5,
 			MinRelayTxFee:        cfg.minRelayTxFee,
 			AllowOldVotes:        cfg.AllowOldVotes,
+			DataCarrier:          dataCarrierPolicy,
 			StandardVerifyFlags: func() (txscript.ScriptFlags, error) {
 				return standardScriptVerifyFlags(bm.chain)
 			},
@@ -2723,6 +3139,7 @@ func newServer(listenAddrs []string, db database.DB, chainParams *chaincfg.Param
 		SubsidyCache:     bm.chain.FetchSubsidyCache(),
 		SigCache:         s.sigCache,
 		PastMedianTime:   func() time.Time { return bm.chain.BestSnapshot().MedianTime },
+		Clock:            s.clock,
 		AddrIndex:        s.addrIndex,
 		ExistsAddrIndex:  s.existsAddrIndex,
 	}
@@ -2736,9 +3153,42 @@ func newServer(listenAddrs []string, db database.DB, chainParams *chaincfg.Param
 		BlockMaxSize:      cfg.BlockMaxSize,
 		BlockPrioritySize: cfg.BlockPrioritySize,
 		TxMinFreeFee:      cfg.minRelayTxFee,
+		MinVotes:          cfg.BlockMinVotes,
+		MaxVoteWait:       cfg.BlockMaxVoteWait,
+		NoRevocations:     cfg.BlockNoRevocations,
+		DataCarrier:       dataCarrierPolicy,
+	}
+	s.workTracker = newWorkTracker()
+	s.minedBlocks = newMinedBlockStats(bm.chain)
+	if cfg.MiningArchive > 0 {
+		path := filepath.Join(cfg.DataDir, miningArchiveFilename)
+		s.miningArchive, err = openMiningArchive(path, cfg.MiningArchive)
+		if err != nil {
+			return nil, err
+		}
 	}
 	s.cpuMiner = newCPUMiner(&policy, &s)
 
+	if len(cfg.autoRevokeScripts) > 0 || len(cfg.autoRevokeKeys) > 0 {
+		s.ticketRevoker, err = newTicketRevoker(&s, cfg.autoRevokeScripts,
+			cfg.autoRevokeKeys)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.SimNetAutoStake {
+		s.simnetStaker, err = newSimnetStaker(&s)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.MemLimit > 0 {
+		s.memGovernor = newMemGovernor(&s, cfg.MemLimit*1024*1024)
+	}
+	s.syncTracker = newSyncTracker(&s)
+
 	// Only setup a function to return new addresses to connect to when
 	// not running in connect-only mode.  The simulation network is always
 	// in connect-only mode since it is only intended to connect to
@@ -2746,7 +3196,7 @@ func newServer(listenAddrs []string, db database.DB, chainParams *chaincfg.Param
 	// discovered peers in order to prevent it from becoming a public test
 	// network.
 	var newAddressFunc func() (net.Addr, error)
-	if !cfg.SimNet && len(cfg.ConnectPeers) == 0 {
+	if !cfg.SimNet && !cfg.RegNet && len(cfg.ConnectPeers) == 0 {
 		newAddressFunc = func() (net.Addr, error) {
 			for tries := 0; tries < 100; tries++ {
 				addr := s.addrManager.GetAddress()
@@ -2804,19 +3254,69 @@ func newServer(listenAddrs []string, db database.DB, chainParams *chaincfg.Param
 	}
 	s.connManager = cmgr
 
+	// Create the DNS seeder from the seeds of the network as modified by
+	// the configuration.
+	s.dnsSeeder = connmgr.NewDNSSeeder(chainParams.DNSSeeds,
+		chainParams.DefaultPort, defaultRequiredServices, exccdLookup,
+		func(addrs []*wire.NetAddress) {
+			// Bitcoind uses a lookup of the dns seeder here. This
+			// is rather strange since the values looked up by the
+			// DNS seed lookups will vary quite a lot.
+			// to replicate this behaviour we put all addresses as
+			// having come from the first one.
+			s.addrManager.AddAddresses(addrs, addrs[0])
+		})
+	for _, host := range cfg.RemoveDNSSeeds {
+		if err := s.dnsSeeder.RemoveSeed(host); err != nil {
+			return nil, err
+		}
+	}
+	for _, host := range cfg.AddDNSSeeds {
+		err := s.dnsSeeder.AddSeed(chaincfg.DNSSeed{Host: host})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Start up persistent peers.
 	permanentPeers := cfg.ConnectPeers
 	if len(permanentPeers) == 0 {
 		permanentPeers = cfg.AddPeers
 	}
+	permanentAddrs := make([]net.Addr, 0, len(permanentPeers)+
+		len(cfg.SyncOnly))
+	permanentAddrStrs := make(map[string]struct{}, len(permanentPeers))
 	for _, addr := range permanentPeers {
 		tcpAddr, err := addrStringToNetAddr(addr)
 		if err != nil {
 			return nil, err
 		}
+		permanentAddrs = append(permanentAddrs, tcpAddr)
+		permanentAddrStrs[tcpAddr.String()] = struct{}{}
+	}
 
+	// Blocks are only accepted from the peers configured with --synconly
+	// when there are any, so they are also connected to persistently
+	// unless they already are.
+	if len(cfg.SyncOnly) > 0 {
+		srvrLog.Infof("Only accepting blocks from sync only peers %v",
+			cfg.SyncOnly)
+		s.syncOnlyAddrs = make(map[string]struct{}, len(cfg.SyncOnly))
+		for _, addr := range cfg.SyncOnly {
+			tcpAddr, err := addrStringToNetAddr(addr)
+			if err != nil {
+				return nil, err
+			}
+			s.syncOnlyAddrs[tcpAddr.String()] = struct{}{}
+			if _, ok := permanentAddrStrs[tcpAddr.String()]; !ok {
+				permanentAddrs = append(permanentAddrs, tcpAddr)
+				permanentAddrStrs[tcpAddr.String()] = struct{}{}
+			}
+		}
+	}
+	for _, addr := range permanentAddrs {
 		go s.connManager.Connect(&connmgr.ConnReq{
-			Addr:      tcpAddr,
+			Addr:      addr,
 			Permanent: true,
 		})
 	}
@@ -2893,7 +3393,8 @@ func dynamicTickDuration(remaining time.Duration) time.Duration {
 // isWhitelisted returns whether the IP address is included in the whitelisted
 // networks and IPs.
 func isWhitelisted(addr net.Addr) bool {
-	if len(cfg.whitelists) == 0 {
+	whitelists := cfg.currentWhitelists()
+	if len(whitelists) == 0 {
 		return false
 	}
 
@@ -2908,7 +3409,7 @@ func isWhitelisted(addr net.Addr) bool {
 		return false
 	}
 
-	for _, ipnet := range cfg.whitelists {
+	for _, ipnet := range whitelists {
 		if ipnet.Contains(ip) {
 			return true
 		}
@@ -0,0 +1,119 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/EXCCoin/exccd/exccutil"
+	"github.com/EXCCoin/exccd/wire"
+)
+
+// TestMiningOnlyServices ensures mining-only nodes do not advertise themselves
+// as full nodes or serve any filters.
+func TestMiningOnlyServices(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+
+	tests := []struct {
+		name string
+		cfg  config
+		want wire.ServiceFlag
+	}{{
+		name: "default",
+		want: wire.SFNodeNetwork | wire.SFNodeBloom | wire.SFNodeCF,
+	}, {
+		name: "no filters",
+		cfg:  config{NoPeerBloomFilters: true, NoCFilters: true},
+		want: wire.SFNodeNetwork,
+	}, {
+		name: "mining only",
+		cfg:  config{MiningOnly: true},
+		want: 0,
+	}}
+
+	for _, test := range tests {
+		testCfg := test.cfg
+		cfg = &testCfg
+		if got := serverServices(); got != test.want {
+			t.Errorf("%s: unexpected services %v, want %v", test.name,
+				got, test.want)
+		}
+	}
+}
+
+// TestMiningOnlyRelay ensures mining-only nodes only relay transactions which
+// were submitted via RPC.
+func TestMiningOnlyRelay(t *testing.T) {
+	origCfg := cfg
+	cfg = &config{MiningOnly: true}
+	defer func() { cfg = origCfg }()
+
+	s := &server{relayInv: make(chan relayMsg, 1)}
+	tx := exccutil.NewTx(wire.NewMsgTx())
+
+	s.AnnounceNewTransactions([]*exccutil.Tx{tx})
+	if len(s.relayInv) != 0 {
+		t.Fatal("transaction received from a peer was relayed")
+	}
+
+	s.AnnounceLocalTransactions([]*exccutil.Tx{tx})
+	if len(s.relayInv) != 1 {
+		t.Fatal("transaction submitted via RPC was not relayed")
+	}
+	msg := <-s.relayInv
+	if msg.invVect.Type != wire.InvTypeTx || msg.invVect.Hash != *tx.Hash() {
+		t.Fatalf("unexpected relayed inventory %v", msg.invVect)
+	}
+}
+
+// TestMiningOnlyHistoricalBlocks ensures mining-only nodes only serve
+// historical blocks to whitelisted peers while recent blocks are served to all
+// peers.
+func TestMiningOnlyHistoricalBlocks(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+
+	s := &server{bandwidth: newBandwidthAccounting(0, time.Now)}
+	recent := &wire.BlockHeader{Timestamp: time.Now()}
+	historical := &wire.BlockHeader{
+		Timestamp: time.Now().Add(-historicalBlockAge - time.Hour),
+	}
+
+	tests := []struct {
+		name        string
+		miningOnly  bool
+		whitelisted bool
+		header      *wire.BlockHeader
+		refused     bool
+	}{{
+		name:   "historical block without mining only",
+		header: historical,
+	}, {
+		name:       "recent block",
+		miningOnly: true,
+		header:     recent,
+	}, {
+		name:       "historical block",
+		miningOnly: true,
+		header:     historical,
+		refused:    true,
+	}, {
+		name:        "historical block to whitelisted peer",
+		miningOnly:  true,
+		whitelisted: true,
+		header:      historical,
+	}}
+
+	for _, test := range tests {
+		cfg = &config{MiningOnly: test.miningOnly}
+		sp := &serverPeer{isWhitelisted: test.whitelisted}
+		reason := s.historicalBlockRefusal(sp, test.header)
+		if refused := reason != ""; refused != test.refused {
+			t.Errorf("%s: unexpected refusal %q", test.name, reason)
+		}
+	}
+}
@@ -71,3 +71,34 @@ func interruptRequested(interrupted <-chan struct{}) bool {
 
 	return false
 }
+
+// reloadSignals defines the signals which request the config file to be
+// reloaded.  It is empty on platforms which do not support such a signal.
+var reloadSignals []os.Signal
+
+// reloadListener reloads the config file of the passed server each time one of
+// the reload signals is received until the passed interrupt channel is closed.
+// It must be run as a goroutine.
+func reloadListener(s *server, interrupted <-chan struct{}) {
+	if len(reloadSignals) == 0 {
+		return
+	}
+
+	reloadChannel := make(chan os.Signal, 1)
+	signal.Notify(reloadChannel, reloadSignals...)
+	defer signal.Stop(reloadChannel)
+	for {
+		select {
+		case sig := <-reloadChannel:
+			exccLog.Infof("Received signal (%s).  Reloading config "+
+				"file...", sig)
+			if _, err := s.reloadConfig(); err != nil {
+				exccLog.Errorf("Unable to reload config file: %v",
+					err)
+			}
+
+		case <-interrupted:
+			return
+		}
+	}
+}
@@ -0,0 +1,537 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/EXCCoin/exccd/blockchain"
+	"github.com/EXCCoin/exccd/blockchain/stake"
+	"github.com/EXCCoin/exccd/chaincfg"
+	"github.com/EXCCoin/exccd/chaincfg/chainec"
+	"github.com/EXCCoin/exccd/chaincfg/chainhash"
+	"github.com/EXCCoin/exccd/exccutil"
+	"github.com/EXCCoin/exccd/mempool"
+	"github.com/EXCCoin/exccd/txscript"
+	"github.com/EXCCoin/exccd/wire"
+)
+
+// simnetStakerKeySeed is hashed to derive the private key held by the simnet
+// staker.  The key is intentionally deterministic so the tickets purchased by
+// the staker can still be voted after the node is restarted.  It must NEVER be
+// used on any network other than simnet since the key is public knowledge.
+const simnetStakerKeySeed = "exccd simnet staker"
+
+// simnetStakerKey returns the private key held by the simnet staker along with
+// the pay-to-pubkey-hash address for it on the provided network.
+func simnetStakerKey(params *chaincfg.Params) (chainec.PrivateKey, *exccutil.AddressPubKeyHash, error) {
+	seed := sha256.Sum256([]byte(simnetStakerKeySeed))
+	privKey, pubKey := chainec.Secp256k1.PrivKeyFromBytes(seed[:])
+	addr, err := exccutil.NewAddressPubKeyHash(
+		exccutil.Hash160(pubKey.SerializeCompressed()), params,
+		chainec.ECTypeSecp256k1)
+	if err != nil {
+		return nil, nil, err
+	}
+	return privKey, addr, nil
+}
+
+// parseSimnetVotes returns the vote version and vote bits which cast the passed
+// agenda choices in the form <agenda>=<choice> along with approving the block
+// being voted on.  All of the agendas must belong to the same vote version,
+// which is the version the votes are cast with.  Zero is returned for the
+// version when there are no agenda choices so the votes are cast without one.
+func parseSimnetVotes(params *chaincfg.Params, votes []string) (uint32, uint16, error) {
+	var voteVersion uint32
+	voteBits := uint16(exccutil.BlockValid)
+	seen := make(map[string]struct{}, len(votes))
+	for _, vote := range votes {
+		parts := strings.SplitN(vote, "=", 2)
+		if len(parts) != 2 {
+			return 0, 0, fmt.Errorf("vote %q is not in the form "+
+				"<agenda>=<choice>", vote)
+		}
+		agendaID, choiceID := parts[0], parts[1]
+		if _, ok := seen[agendaID]; ok {
+			return 0, 0, fmt.Errorf("agenda %q is voted on more "+
+				"than once", agendaID)
+		}
+		seen[agendaID] = struct{}{}
+
+		// Find the agenda along with the version it belongs to.
+		var agenda *chaincfg.Vote
+		var version uint32
+		for v, deployments := range params.Deployments {
+			for i := range deployments {
+				if deployments[i].Vote.Id == agendaID {
+					agenda = &deployments[i].Vote
+					version = v
+				}
+			}
+		}
+		if agenda == nil {
+			return 0, 0, fmt.Errorf("unknown agenda %q", agendaID)
+		}
+		if voteVersion != 0 && version != voteVersion {
+			return 0, 0, fmt.Errorf("agenda %q belongs to vote "+
+				"version %d instead of %d like the other agendas",
+				agendaID, version, voteVersion)
+		}
+		voteVersion = version
+
+		var choice *chaincfg.Choice
+		for i := range agenda.Choices {
+			if agenda.Choices[i].Id == choiceID {
+				choice = &agenda.Choices[i]
+			}
+		}
+		if choice == nil {
+			return 0, 0, fmt.Errorf("unknown choice %q for agenda %q",
+				choiceID, agendaID)
+		}
+		voteBits |= choice.Bits
+	}
+	return voteVersion, voteBits, nil
+}
+
+// simnetVotesScript returns the script of the vote bits output of a vote which
+// casts the passed vote bits with the passed vote version.  The version is
+// omitted when it is zero.
+func simnetVotesScript(voteBits uint16, voteVersion uint32) ([]byte, error) {
+	if voteVersion == 0 {
+		return txscript.GenerateSSGenVotes(voteBits)
+	}
+	data := make([]byte, 6)
+	binary.LittleEndian.PutUint16(data[0:2], voteBits)
+	binary.LittleEndian.PutUint32(data[2:6], voteVersion)
+	return txscript.GenerateProvablyPruneableOut(data)
+}
+
+// stakerOutput describes an output controlled by the simnet staker which can be
+// used to purchase tickets once it is mature.
+type stakerOutput struct {
+	outPoint    wire.OutPoint
+	amount      int64
+	pkScript    []byte
+	blockHeight int64
+	blockIndex  uint32
+	maturity    int64
+}
+
+// simnetStaker automatically purchases tickets and votes with them using a
+// node-held key so blocks can continuously be generated on simnet without an
+// external wallet providing the votes required once stake validation begins.
+//
+// The staker is funded by coinbases paid to its address, which is added to the
+// mining addresses, along with the change of the tickets it purchases.  Only
+// the coinbases of the blocks that are connected while the node is running are
+// used to fund tickets.
+type simnetStaker struct {
+	server  *server
+	privKey chainec.PrivateKey
+	addr    *exccutil.AddressPubKeyHash
+
+	// voteVersion and voteBits are the vote version and bits the votes of
+	// the staker are cast with, which include the agenda choices
+	// configured via the simnetvote option.
+	voteVersion uint32
+	voteBits    uint16
+
+	// pending houses the blocks that have been connected by the chain, but
+	// not processed yet.  It is protected by the mutex since the chain
+	// reports them while the chain lock is held, so they must be processed
+	// asynchronously.
+	mtx     sync.Mutex
+	pending []*exccutil.Block
+
+	// outputs houses the known outputs which can fund new tickets.  It is
+	// only accessed by the stake handler.
+	outputs map[wire.OutPoint]*stakerOutput
+
+	wake chan struct{}
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NotifyBlockConnected queues the passed block, which was connected to the main
+// chain, so that the staker can vote on it and track any outputs in it that
+// fund new tickets.
+//
+// This function is safe for concurrent access and never blocks.
+func (s *simnetStaker) NotifyBlockConnected(block *exccutil.Block) {
+	s.mtx.Lock()
+	s.pending = append(s.pending, block)
+	s.mtx.Unlock()
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// trackOutputs records the outputs of the passed block that pay to the staker,
+// which are the coinbase outputs and the change of purchased tickets.
+func (s *simnetStaker) trackOutputs(block *exccutil.Block) {
+	params := s.server.chainParams
+	addOutputs := func(tx *exccutil.Tx, tree int8, index uint32, maturity int64) {
+		for i, txOut := range tx.MsgTx().TxOut {
+			class, addrs, _, err := txscript.ExtractPkScriptAddrs(
+				txOut.Version, txOut.PkScript, params)
+			if err != nil || len(addrs) != 1 ||
+				addrs[0].EncodeAddress() != s.addr.EncodeAddress() {
+				continue
+			}
+			if tree == wire.TxTreeStake && class != txscript.StakeSubChangeTy {
+				continue
+			}
+
+			outPoint := wire.OutPoint{Hash: *tx.Hash(), Index: uint32(i),
+				Tree: tree}
+			s.outputs[outPoint] = &stakerOutput{
+				outPoint:    outPoint,
+				amount:      txOut.Value,
+				pkScript:    txOut.PkScript,
+				blockHeight: block.Height(),
+				blockIndex:  index,
+				maturity:    maturity,
+			}
+		}
+	}
+
+	addOutputs(block.Transactions()[0], wire.TxTreeRegular, 0,
+		int64(params.CoinbaseMaturity))
+	for i, stx := range block.STransactions() {
+		if stake.IsSStx(stx.MsgTx()) {
+			addOutputs(stx, wire.TxTreeStake, uint32(i),
+				int64(params.SStxChangeMaturity))
+		}
+	}
+}
+
+// processTransaction submits the passed transaction to the memory pool so it is
+// relayed to the network.  It returns whether or not the transaction was
+// accepted.
+func (s *simnetStaker) processTransaction(tx *exccutil.Tx, desc string) bool {
+	acceptedTxs, err := s.server.blockManager.ProcessTransaction(tx, false,
+		false, true)
+	if err != nil {
+		// Rule errors are expected when the staker races with the chain,
+		// such as voting on a block that has since been replaced, so only
+		// log them at the debug level.
+		if _, ok := err.(mempool.RuleError); ok {
+			minrLog.Debugf("Simnet staker %s %v rejected: %v", desc,
+				tx.Hash(), err)
+			return false
+		}
+		minrLog.Errorf("Failed to process simnet staker %s %v: %v", desc,
+			tx.Hash(), err)
+		return false
+	}
+	s.server.AnnounceNewTransactions(acceptedTxs)
+	return true
+}
+
+// createVote returns a vote which approves the passed block by spending the
+// passed ticket when the ticket is owned by the staker.  Nil is returned when
+// the ticket is not owned by the staker.
+func (s *simnetStaker) createVote(block *exccutil.Block, ticketHash *chainhash.Hash) (*wire.MsgTx, error) {
+	chain := s.server.blockManager.chain
+	entry, err := chain.FetchUtxoEntry(ticketHash)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil || entry.IsOutputSpent(0) ||
+		entry.TransactionType() != stake.TxTypeSStx {
+		return nil, nil
+	}
+	ticketScript := entry.PkScriptByIndex(0)
+	_, addrs, _, err := txscript.ExtractPkScriptAddrs(
+		entry.ScriptVersionByIndex(0), ticketScript, s.server.chainParams)
+	if err != nil || len(addrs) != 1 ||
+		addrs[0].EncodeAddress() != s.addr.EncodeAddress() {
+		return nil, nil
+	}
+
+	// Pay the ticket commitments along with the vote subsidy.
+	minimalOutputs := blockchain.ConvertUtxosToMinimalOutputs(entry)
+	payTypes, payHashes, commitAmts, _, _, _ :=
+		stake.SStxStakeOutputInfo(minimalOutputs)
+	ticketPrice := entry.AmountByIndex(0)
+	subsidy := blockchain.CalcStakeVoteSubsidy(chain.FetchSubsidyCache(),
+		block.Height(), s.server.chainParams)
+	payouts := stake.CalculateRewards(commitAmts, ticketPrice, subsidy)
+
+	blockRefScript, err := txscript.GenerateSSGenBlockRef(*block.Hash(),
+		uint32(block.Height()))
+	if err != nil {
+		return nil, err
+	}
+	votesScript, err := simnetVotesScript(s.voteBits, s.voteVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	mtx := wire.NewMsgTx()
+	mtx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: *wire.NewOutPoint(&chainhash.Hash{},
+			wire.MaxPrevOutIndex, wire.TxTreeRegular),
+		Sequence:        wire.MaxTxInSequenceNum,
+		ValueIn:         subsidy,
+		BlockHeight:     wire.NullBlockHeight,
+		BlockIndex:      wire.NullBlockIndex,
+		SignatureScript: s.server.chainParams.StakeBaseSigScript,
+	})
+	mtx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: *wire.NewOutPoint(ticketHash, 0,
+			wire.TxTreeStake),
+		Sequence:    wire.MaxTxInSequenceNum,
+		ValueIn:     ticketPrice,
+		BlockHeight: uint32(entry.BlockHeight()),
+		BlockIndex:  entry.BlockIndex(),
+	})
+	mtx.AddTxOut(wire.NewTxOut(0, blockRefScript))
+	mtx.AddTxOut(wire.NewTxOut(0, votesScript))
+	for i, payHash := range payHashes {
+		var pkScript []byte
+		if payTypes[i] {
+			pkScript, err = txscript.PayToSSGenSHDirect(payHash)
+		} else {
+			pkScript, err = txscript.PayToSSGenPKHDirect(payHash)
+		}
+		if err != nil {
+			return nil, err
+		}
+		mtx.AddTxOut(wire.NewTxOut(payouts[i], pkScript))
+	}
+
+	sigScript, err := txscript.SignatureScript(mtx, 1, ticketScript,
+		txscript.SigHashAll, s.privKey, true)
+	if err != nil {
+		return nil, err
+	}
+	mtx.TxIn[1].SignatureScript = sigScript
+
+	if err := stake.CheckSSGen(mtx); err != nil {
+		return nil, fmt.Errorf("invalid vote: %v", err)
+	}
+	return mtx, nil
+}
+
+// vote creates and relays votes for all of the winning tickets of the passed
+// block that are owned by the staker.
+func (s *simnetStaker) vote(block *exccutil.Block) {
+	if block.Height() < s.server.chainParams.StakeValidationHeight-1 {
+		return
+	}
+
+	winners, _, _, err := s.server.blockManager.chain.LotteryDataForBlock(
+		block.Hash())
+	if err != nil {
+		minrLog.Errorf("Unable to obtain winning tickets for block %v: %v",
+			block.Hash(), err)
+		return
+	}
+	for i := range winners {
+		mtx, err := s.createVote(block, &winners[i])
+		if err != nil {
+			minrLog.Errorf("Unable to create vote for ticket %v: %v",
+				&winners[i], err)
+			continue
+		}
+		if mtx == nil {
+			continue
+		}
+		tx := exccutil.NewTx(mtx)
+		if s.processTransaction(tx, "vote") {
+			minrLog.Debugf("Simnet staker voted on block %v with ticket "+
+				"%v", block.Hash(), &winners[i])
+		}
+	}
+}
+
+// createTicket returns a ticket purchase at the provided price and fee which is
+// funded by the passed output.  The voting rights, commitment, and change are
+// all assigned to the staker.
+func (s *simnetStaker) createTicket(output *stakerOutput, price, fee int64) (*wire.MsgTx, error) {
+	ticketScript, err := txscript.PayToSStx(s.addr)
+	if err != nil {
+		return nil, err
+	}
+	commitScript, err := txscript.GenerateSStxAddrPush(s.addr,
+		exccutil.Amount(price+fee), 0)
+	if err != nil {
+		return nil, err
+	}
+	changeScript, err := txscript.PayToSStxChange(s.addr)
+	if err != nil {
+		return nil, err
+	}
+
+	mtx := wire.NewMsgTx()
+	mtx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: output.outPoint,
+		Sequence:         wire.MaxTxInSequenceNum,
+		ValueIn:          output.amount,
+		BlockHeight:      uint32(output.blockHeight),
+		BlockIndex:       output.blockIndex,
+	})
+	mtx.AddTxOut(wire.NewTxOut(price, ticketScript))
+	mtx.AddTxOut(wire.NewTxOut(0, commitScript))
+	mtx.AddTxOut(wire.NewTxOut(output.amount-price-fee, changeScript))
+
+	sigScript, err := txscript.SignatureScript(mtx, 0, output.pkScript,
+		txscript.SigHashAll, s.privKey, true)
+	if err != nil {
+		return nil, err
+	}
+	mtx.TxIn[0].SignatureScript = sigScript
+
+	if err := stake.CheckSStx(mtx); err != nil {
+		return nil, fmt.Errorf("invalid ticket: %v", err)
+	}
+	return mtx, nil
+}
+
+// purchaseTickets purchases tickets with the mature outputs owned by the
+// staker.  The maximum number of new tickets allowed per block are purchased
+// until the staker owns enough live tickets to fill the target ticket pool,
+// after which only enough tickets to replace the ones voted in each block are
+// purchased.
+func (s *simnetStaker) purchaseTickets() {
+	chain := s.server.blockManager.chain
+	params := s.server.chainParams
+	nextHeight := chain.BestSnapshot().Height + 1
+	if nextHeight < params.StakeEnabledHeight {
+		return
+	}
+
+	live, err := chain.TicketsWithAddress(s.addr)
+	if err != nil {
+		minrLog.Errorf("Unable to fetch simnet staker tickets: %v", err)
+		return
+	}
+	numTickets := int(params.TicketsPerBlock)
+	if len(live) < int(params.TicketPoolSize)*int(params.TicketsPerBlock) {
+		numTickets = int(params.MaxFreshStakePerBlock)
+	}
+
+	price, err := chain.CalcNextRequiredStakeDifficulty()
+	if err != nil {
+		minrLog.Errorf("Unable to calculate stake difficulty: %v", err)
+		return
+	}
+
+	// Tickets with a single input are well under a kilobyte, so paying the
+	// minimum relay fee for a full kilobyte is always sufficient.
+	fee := int64(cfg.currentMinRelayTxFee())
+
+	for outPoint, output := range s.outputs {
+		if numTickets == 0 {
+			return
+		}
+		if nextHeight-output.blockHeight < output.maturity {
+			continue
+		}
+
+		// Forget about outputs which have been spent or are too small to
+		// fund a ticket.
+		entry, err := chain.FetchUtxoEntry(&outPoint.Hash)
+		if err != nil {
+			minrLog.Errorf("Unable to fetch output %v: %v", outPoint, err)
+			return
+		}
+		if entry == nil || entry.IsOutputSpent(outPoint.Index) ||
+			output.amount <= price+fee {
+			delete(s.outputs, outPoint)
+			continue
+		}
+
+		mtx, err := s.createTicket(output, price, fee)
+		if err != nil {
+			minrLog.Errorf("Unable to create ticket: %v", err)
+			return
+		}
+		tx := exccutil.NewTx(mtx)
+		if !s.processTransaction(tx, "ticket") {
+			continue
+		}
+		delete(s.outputs, outPoint)
+		numTickets--
+		minrLog.Debugf("Simnet staker purchased ticket %v", tx.Hash())
+	}
+}
+
+// stakeHandler votes on and purchases tickets for the blocks reported by the
+// chain.  It must be run as a goroutine.
+func (s *simnetStaker) stakeHandler() {
+out:
+	for {
+		select {
+		case <-s.wake:
+			s.mtx.Lock()
+			blocks := s.pending
+			s.pending = nil
+			s.mtx.Unlock()
+			if len(blocks) == 0 {
+				continue
+			}
+
+			for _, block := range blocks {
+				s.trackOutputs(block)
+			}
+
+			// Only the most recently connected block needs votes
+			// since the others have already been extended.
+			s.vote(blocks[len(blocks)-1])
+			s.purchaseTickets()
+
+		case <-s.quit:
+			break out
+		}
+	}
+
+	s.wg.Done()
+}
+
+// Start begins voting and purchasing tickets.
+func (s *simnetStaker) Start() {
+	s.wg.Add(1)
+	spawn(s.stakeHandler)
+}
+
+// Stop signals the staker to stop voting and purchasing tickets and waits for
+// it to finish.
+func (s *simnetStaker) Stop() {
+	close(s.quit)
+	s.wg.Wait()
+}
+
+// newSimnetStaker returns a new simnet staker for the provided server.  It must
+// only be used on simnet.
+func newSimnetStaker(s *server) (*simnetStaker, error) {
+	privKey, addr, err := simnetStakerKey(s.chainParams)
+	if err != nil {
+		return nil, err
+	}
+	voteVersion, voteBits, err := parseSimnetVotes(s.chainParams,
+		cfg.SimNetVotes)
+	if err != nil {
+		return nil, err
+	}
+	return &simnetStaker{
+		server:      s,
+		privKey:     privKey,
+		addr:        addr,
+		voteVersion: voteVersion,
+		voteBits:    voteBits,
+		outputs:     make(map[wire.OutPoint]*stakerOutput),
+		wake:        make(chan struct{}, 1),
+		quit:        make(chan struct{}),
+	}, nil
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/EXCCoin/exccd/chaincfg"
+)
+
+// TestParseSimnetVotes ensures the agenda choices cast by the simnet staker are
+// parsed into the expected vote version and bits.
+func TestParseSimnetVotes(t *testing.T) {
+	tests := []struct {
+		name        string
+		votes       []string
+		voteVersion uint32
+		voteBits    uint16
+		wantErr     bool
+	}{{
+		name:     "no choices",
+		voteBits: 0x0001,
+	}, {
+		name:        "yes",
+		votes:       []string{"maxblocksize=yes"},
+		voteVersion: 4,
+		voteBits:    0x0005,
+	}, {
+		name:        "no",
+		votes:       []string{"sdiffdamping=no"},
+		voteVersion: 5,
+		voteBits:    0x0003,
+	}, {
+		name:    "missing choice",
+		votes:   []string{"maxblocksize"},
+		wantErr: true,
+	}, {
+		name:    "unknown agenda",
+		votes:   []string{"unknown=yes"},
+		wantErr: true,
+	}, {
+		name:    "unknown choice",
+		votes:   []string{"maxblocksize=maybe"},
+		wantErr: true,
+	}, {
+		name:    "duplicate agenda",
+		votes:   []string{"maxblocksize=yes", "maxblocksize=no"},
+		wantErr: true,
+	}, {
+		name:    "mixed versions",
+		votes:   []string{"maxblocksize=yes", "sdiffdamping=yes"},
+		wantErr: true,
+	}}
+
+	for _, test := range tests {
+		voteVersion, voteBits, err := parseSimnetVotes(
+			&chaincfg.SimNetParams, test.votes)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("%s: no error", test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if voteVersion != test.voteVersion || voteBits != test.voteBits {
+			t.Errorf("%s: got version %d and bits %#04x, want %d and "+
+				"%#04x", test.name, voteVersion, voteBits,
+				test.voteVersion, test.voteBits)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/EXCCoin/exccd/exccjson"
+)
+
+const (
+	// syncProgressInterval is the interval at which the download rate is
+	// sampled and websocket clients are notified of the sync progress while
+	// the chain is syncing.
+	syncProgressInterval = 10 * time.Second
+
+	// maxSyncRateSamples is the number of the most recent samples the
+	// download rate is calculated from, which smooths out short stalls
+	// without hiding a sync which is actually stuck for long.
+	maxSyncRateSamples = 7
+)
+
+// syncRateSample is the height of the best chain at a point in time.
+type syncRateSample struct {
+	time   time.Time
+	height int64
+}
+
+// syncTracker tracks the rate at which blocks are added to the best chain over
+// the most recent samples and periodically notifies websocket clients of the
+// sync progress while the chain is syncing.
+type syncTracker struct {
+	server *server
+
+	mtx     sync.Mutex
+	samples []syncRateSample
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// addSample records the passed height of the best chain at the passed time,
+// discarding the oldest sample once the maximum number are kept.  The samples
+// are reset when the best chain became shorter, such as after a reorg.
+//
+// This function is safe for concurrent access.
+func (t *syncTracker) addSample(now time.Time, height int64) {
+	t.mtx.Lock()
+	if n := len(t.samples); n > 0 && height < t.samples[n-1].height {
+		t.samples = t.samples[:0]
+	}
+	if len(t.samples) == maxSyncRateSamples {
+		copy(t.samples, t.samples[1:])
+		t.samples = t.samples[:maxSyncRateSamples-1]
+	}
+	t.samples = append(t.samples, syncRateSample{time: now, height: height})
+	t.mtx.Unlock()
+}
+
+// BlocksPerSecond returns the rate at which blocks were added to the best
+// chain between the oldest and newest samples, or zero when there are not
+// enough samples.
+//
+// This function is safe for concurrent access.
+func (t *syncTracker) BlocksPerSecond() float64 {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	if len(t.samples) < 2 {
+		return 0
+	}
+	oldest, newest := t.samples[0], t.samples[len(t.samples)-1]
+	elapsed := newest.time.Sub(oldest.time).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(newest.height-oldest.height) / elapsed
+}
+
+// progressHandler periodically samples the download rate and notifies
+// websocket clients of the sync progress while the chain is syncing, along with
+// once more when the sync completes.  It must be run as a goroutine.
+func (t *syncTracker) progressHandler() {
+	ticker := time.NewTicker(syncProgressInterval)
+	defer ticker.Stop()
+
+	var synced bool
+out:
+	for {
+		select {
+		case now := <-ticker.C:
+			best := t.server.blockManager.chain.BestSnapshot()
+			t.addSample(now, best.Height)
+
+			info := t.server.syncInfo()
+			if info.Synced && synced {
+				continue
+			}
+			synced = info.Synced
+			if r := t.server.rpcServer; r != nil {
+				r.ntfnMgr.NotifySyncProgress(info)
+			}
+
+		case <-t.quit:
+			break out
+		}
+	}
+
+	t.wg.Done()
+}
+
+// Start begins tracking the sync progress.
+func (t *syncTracker) Start() {
+	best := t.server.blockManager.chain.BestSnapshot()
+	t.addSample(time.Now(), best.Height)
+
+	t.wg.Add(1)
+	spawn(t.progressHandler)
+}
+
+// Stop signals the sync tracker to stop and waits for it to finish.
+func (t *syncTracker) Stop() {
+	close(t.quit)
+	t.wg.Wait()
+}
+
+// newSyncTracker returns a new sync tracker for the provided server.
+func newSyncTracker(s *server) *syncTracker {
+	return &syncTracker{
+		server:  s,
+		samples: make([]syncRateSample, 0, maxSyncRateSamples),
+		quit:    make(chan struct{}),
+	}
+}
+
+// syncProgress returns the percentage of the passed number of headers which
+// have their blocks in the best chain and the estimated number of seconds until
+// the remaining blocks are downloaded at the passed rate.  The estimate is -1
+// when it is unknown because no blocks are being downloaded.
+func syncProgress(blocks, headers int64, blocksPerSecond float64) (float64, int64) {
+	if blocks >= headers {
+		return 100, 0
+	}
+	progress := float64(blocks) / float64(headers) * 100
+	if blocksPerSecond <= 0 {
+		return progress, -1
+	}
+	return progress, int64(float64(headers-blocks)/blocksPerSecond + 0.5)
+}
+
+// syncInfo returns the progress of the chain sync, namely the height of the
+// best chain compared to the height of the best known header, the download
+// rate, and the estimated time until the sync completes.
+func (s *server) syncInfo() *exccjson.GetSyncInfoResult {
+	best := s.blockManager.chain.BestSnapshot()
+	status := s.blockManager.SyncStatus()
+
+	// The best known header is the latest one downloaded in headers-first
+	// mode or the best block announced by the sync peer, whichever is
+	// higher.
+	result := &exccjson.GetSyncInfoResult{
+		Synced:        status.current,
+		Blocks:        best.Height,
+		Headers:       best.Height,
+		BestBlockHash: best.Hash.String(),
+	}
+	if status.headersHeight > result.Headers {
+		result.Headers = status.headersHeight
+	}
+	if status.syncPeer != nil {
+		result.SyncPeer = status.syncPeer.Addr()
+		result.SyncPeerHeight = status.syncPeer.LastBlock()
+		if result.SyncPeerHeight > result.Headers {
+			result.Headers = result.SyncPeerHeight
+		}
+	}
+	if header, err := s.blockManager.chain.FetchHeader(&best.Hash); err == nil {
+		result.BestBlockTime = header.Timestamp.Unix()
+	}
+
+	result.BlocksPerSecond = s.syncTracker.BlocksPerSecond()
+	result.Progress, result.ETA = syncProgress(result.Blocks, result.Headers,
+		result.BlocksPerSecond)
+	return result
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// TestSyncProgress ensures the download rate is calculated over the most recent
+// samples and the sync progress and estimated time until it completes are
+// calculated from it.
+func TestSyncProgress(t *testing.T) {
+	tracker := newSyncTracker(nil)
+	start := time.Unix(1500000000, 0)
+	if rate := tracker.BlocksPerSecond(); rate != 0 {
+		t.Fatalf("unexpected rate without samples %v", rate)
+	}
+
+	// Add samples at 10 second intervals with the first ones at a rate of 1
+	// block per second and the rest at a rate of 5 blocks per second, so
+	// the older samples are discarded.
+	var height int64
+	for i := 0; i < maxSyncRateSamples+2; i++ {
+		if i > 2 {
+			height += 50
+		} else if i > 0 {
+			height += 10
+		}
+		tracker.addSample(start.Add(time.Duration(i)*10*time.Second),
+			height)
+	}
+	if rate := tracker.BlocksPerSecond(); rate != 5 {
+		t.Fatalf("unexpected rate %v", rate)
+	}
+
+	// The samples are reset when the best chain becomes shorter.
+	tracker.addSample(start.Add(time.Hour), height-1)
+	if rate := tracker.BlocksPerSecond(); rate != 0 {
+		t.Fatalf("unexpected rate after reorg %v", rate)
+	}
+
+	tests := []struct {
+		blocks, headers int64
+		rate            float64
+		progress        float64
+		eta             int64
+	}{
+		{blocks: 100, headers: 100, rate: 5, progress: 100, eta: 0},
+		{blocks: 100, headers: 50, rate: 0, progress: 100, eta: 0},
+		{blocks: 25, headers: 100, rate: 5, progress: 25, eta: 15},
+		{blocks: 25, headers: 100, rate: 0, progress: 25, eta: -1},
+		{blocks: 0, headers: 200, rate: 3, progress: 0, eta: 67},
+	}
+	for i, test := range tests {
+		progress, eta := syncProgress(test.blocks, test.headers, test.rate)
+		if progress != test.progress || eta != test.eta {
+			t.Errorf("#%d: unexpected progress %v and eta %d, want %v "+
+				"and %d", i, progress, eta, test.progress, test.eta)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"time"
+
+	"github.com/EXCCoin/exccd/exccutil"
+	"github.com/EXCCoin/exccd/mining"
+)
+
+const (
+	// defaultMiningRefreshMin is the default minimum amount of time the
+	// CPU miner works on a block template before refreshing it to include
+	// new transactions.
+	defaultMiningRefreshMin = 3 * time.Second
+
+	// defaultMiningRefreshMax is the default maximum amount of time the CPU
+	// miner works on a block template before refreshing it.
+	defaultMiningRefreshMax = 60 * time.Second
+
+	// defaultMiningRefreshFee is the default amount of fees paid by the
+	// transactions which arrived since a block template was generated for
+	// the CPU miner to refresh it after the minimum amount of time.
+	defaultMiningRefreshFee exccutil.Amount = 1e5
+)
+
+// newTxFees returns the total fees paid by the transactions described by the
+// passed descriptors which were added to the memory pool after the passed time.
+func newTxFees(descs []*mining.TxDesc, since time.Time) int64 {
+	var fees int64
+	for _, desc := range descs {
+		if desc.Added.After(since) {
+			fees += desc.Fee
+		}
+	}
+	return fees
+}
+
+// moreVotesAvailable returns whether the passed number of votes on the parent
+// of a block template which are available exceeds the passed number of votes
+// the template includes while it does not include the maximum number of votes
+// per block.  The proof-of-work subsidy is reduced proportionally to the
+// number of missing votes, so such templates should be refreshed as soon as
+// possible even though votes do not pay any fees.
+func moreVotesAvailable(templateVoters uint16, availableVotes int, ticketsPerBlock uint16) bool {
+	return templateVoters < ticketsPerBlock &&
+		availableVotes > int(templateVoters)
+}
+
+// templateRefreshInterval returns the amount of time the CPU miner works on a
+// block template before refreshing it given the passed fees paid by the
+// transactions which arrived since it was generated.
+//
+// The interval shrinks linearly from the passed maximum down to the passed
+// minimum as the new fees approach the passed refresh fee, so templates are
+// refreshed quickly when transactions paying high fees arrive without
+// regenerating them for every low-fee transaction.  A refresh fee of zero
+// refreshes templates after the minimum interval whenever new transactions
+// paying any fees arrive.
+func templateRefreshInterval(newFees int64, min, max time.Duration, refreshFee exccutil.Amount) time.Duration {
+	if newFees <= 0 {
+		return max
+	}
+	if newFees >= int64(refreshFee) {
+		return min
+	}
+	span := float64(max - min)
+	return max - time.Duration(span*float64(newFees)/float64(refreshFee))
+}
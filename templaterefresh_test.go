@@ -0,0 +1,80 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/EXCCoin/exccd/exccutil"
+	"github.com/EXCCoin/exccd/mining"
+)
+
+// TestTemplateRefreshInterval ensures the CPU miner refreshes block templates
+// sooner the more fees the transactions which arrived since they were
+// generated pay.
+func TestTemplateRefreshInterval(t *testing.T) {
+	const min, max = 3 * time.Second, 63 * time.Second
+	tests := []struct {
+		name       string
+		newFees    int64
+		refreshFee int64
+		want       time.Duration
+	}{
+		{"no new fees", 0, 1e5, max},
+		{"quarter of refresh fee", 25000, 1e5, 48 * time.Second},
+		{"half of refresh fee", 50000, 1e5, 33 * time.Second},
+		{"refresh fee", 1e5, 1e5, min},
+		{"above refresh fee", 1e7, 1e5, min},
+		{"zero refresh fee", 1, 0, min},
+		{"zero refresh fee without new fees", 0, 0, max},
+	}
+	for _, test := range tests {
+		got := templateRefreshInterval(test.newFees, min, max,
+			exccutil.Amount(test.refreshFee))
+		if got != test.want {
+			t.Errorf("%s: unexpected interval: got %v, want %v",
+				test.name, got, test.want)
+		}
+	}
+
+	// Only the fees of transactions added after the template was generated
+	// are counted.
+	generated := time.Unix(1530000000, 0)
+	descs := []*mining.TxDesc{
+		{Added: generated.Add(-time.Second), Fee: 1000},
+		{Added: generated, Fee: 2000},
+		{Added: generated.Add(time.Second), Fee: 3000},
+		{Added: generated.Add(2 * time.Second), Fee: 4000},
+	}
+	if fees := newTxFees(descs, generated); fees != 7000 {
+		t.Errorf("unexpected new fees %d, want 7000", fees)
+	}
+}
+
+// TestMoreVotesAvailable ensures block templates are only considered to miss
+// votes when more votes on their parent are available than they include and
+// they do not include the maximum number of votes.
+func TestMoreVotesAvailable(t *testing.T) {
+	tests := []struct {
+		name      string
+		voters    uint16
+		available int
+		want      bool
+	}{
+		{"new vote", 3, 4, true},
+		{"several new votes", 3, 7, true},
+		{"no new votes", 3, 3, false},
+		{"all votes included", 5, 6, false},
+		{"votes not required yet", 0, 0, false},
+	}
+	for _, test := range tests {
+		got := moreVotesAvailable(test.voters, test.available, 5)
+		if got != test.want {
+			t.Errorf("%s: unexpected result %v, want %v", test.name,
+				got, test.want)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+/*
+Package tracing records spans which measure how long operations such as block
+processing take and exports them to an OpenTelemetry collector.
+
+Tracing Overview
+
+A span describes a single timed operation along with attributes such as the
+hash of the block being processed.  Spans started with Start are the roots of
+new traces, while spans started with StartChild are recorded as part of the
+trace of their parent so the time spent in each stage of an operation is
+visible.
+
+Spans are only recorded once an exporter has been started with StartExporter.
+Until then, and after StopExporter is called, Start returns a nil span and all
+methods of a nil span do nothing, so instrumented code does not need to check
+whether tracing is enabled.
+
+Exporting
+
+Finished spans are queued and exported in batches to the configured endpoint
+using the OpenTelemetry protocol (OTLP) with the JSON encoding over HTTP, which
+is accepted by the OpenTelemetry collector and most tracing backends.  Spans are
+dropped rather than blocking the instrumented code when the queue is full.
+*/
+package tracing
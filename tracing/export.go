@@ -0,0 +1,375 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package tracing
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// defaultBatchSize is the default maximum number of spans exported in a
+	// single request.
+	defaultBatchSize = 512
+
+	// defaultQueueSize is the default maximum number of finished spans which
+	// are queued to be exported.  Further spans are dropped until the queue
+	// drains.
+	defaultQueueSize = 4096
+
+	// defaultFlushInterval is the default interval at which queued spans
+	// are exported when fewer than a full batch of them are queued.
+	defaultFlushInterval = 5 * time.Second
+
+	// exportTimeout is the maximum amount of time a request exporting spans
+	// may take.
+	exportTimeout = 10 * time.Second
+
+	// tracesPath is the path of the OTLP endpoint which receives spans.  It
+	// is appended to endpoints which do not specify a path.
+	tracesPath = "/v1/traces"
+
+	// scopeName is the name of the instrumentation scope reported with the
+	// exported spans.
+	scopeName = "github.com/EXCCoin/exccd/tracing"
+)
+
+// Config describes how and where spans are exported.
+type Config struct {
+	// Endpoint is the URL of the OTLP/HTTP endpoint spans are exported to,
+	// such as http://127.0.0.1:4318.  The standard path for traces is used
+	// when the URL does not specify a path.
+	Endpoint string
+
+	// ServiceName and ServiceVersion identify the process which recorded
+	// the spans.
+	ServiceName    string
+	ServiceVersion string
+
+	// BatchSize, QueueSize, and FlushInterval override the defaults for the
+	// maximum number of spans exported in a single request, the maximum
+	// number of queued spans, and the interval at which queued spans are
+	// exported when they are zero.
+	BatchSize     int
+	QueueSize     int
+	FlushInterval time.Duration
+}
+
+// Exporter queues finished spans and exports them in batches to an OTLP/HTTP
+// endpoint.
+type Exporter struct {
+	url           string
+	resource      otlpResource
+	batchSize     int
+	flushInterval time.Duration
+	client        *http.Client
+	spans         chan *Span
+	dropped       uint64 // atomic
+	quit          chan struct{}
+	wg            sync.WaitGroup
+}
+
+// StartExporter starts exporting spans as described by the passed config and
+// enables recording them.  An error is returned when the endpoint is not a
+// valid HTTP URL or an exporter is already running.
+func StartExporter(cfg *Config) error {
+	u, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid OTLP endpoint %q: %v", cfg.Endpoint, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid OTLP endpoint %q: must be an http or "+
+			"https URL", cfg.Endpoint)
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = tracesPath
+	}
+
+	e := &Exporter{
+		url: u.String(),
+		resource: otlpResource{Attributes: []otlpKeyValue{
+			newKeyValue("service.name", cfg.ServiceName),
+			newKeyValue("service.version", cfg.ServiceVersion),
+		}},
+		batchSize:     cfg.BatchSize,
+		flushInterval: cfg.FlushInterval,
+		client:        &http.Client{Timeout: exportTimeout},
+		quit:          make(chan struct{}),
+	}
+	if e.batchSize <= 0 {
+		e.batchSize = defaultBatchSize
+	}
+	if e.flushInterval <= 0 {
+		e.flushInterval = defaultFlushInterval
+	}
+	queueSize := cfg.QueueSize
+	if queueSize <= 0 {
+		queueSize = defaultQueueSize
+	}
+	e.spans = make(chan *Span, queueSize)
+
+	exporterMtx.Lock()
+	defer exporterMtx.Unlock()
+	if exporter != nil {
+		return errors.New("an exporter is already running")
+	}
+	exporter = e
+	e.wg.Add(1)
+	go e.exportHandler()
+	log.Infof("Exporting traces to %s", e.url)
+	return nil
+}
+
+// StopExporter disables recording spans, exports the spans which are still
+// queued, and stops the exporter.  It does nothing when no exporter is running.
+func StopExporter() {
+	exporterMtx.Lock()
+	e := exporter
+	exporter = nil
+	exporterMtx.Unlock()
+	if e == nil {
+		return
+	}
+
+	close(e.quit)
+	e.wg.Wait()
+	if dropped := atomic.LoadUint64(&e.dropped); dropped > 0 {
+		log.Infof("Dropped %d spans which could not be queued for export",
+			dropped)
+	}
+}
+
+// queue queues a finished span to be exported.  The span is dropped when the
+// queue is full or the exporter is stopped so instrumented code never blocks.
+func (e *Exporter) queue(s *Span) {
+	select {
+	case <-e.quit:
+		atomic.AddUint64(&e.dropped, 1)
+		return
+	default:
+	}
+
+	select {
+	case e.spans <- s:
+	default:
+		atomic.AddUint64(&e.dropped, 1)
+	}
+}
+
+// exportHandler exports the queued spans once a full batch is queued or the
+// flush interval passes, and exports the remaining spans before exiting when
+// the exporter is stopped.  It must be run as a goroutine.
+func (e *Exporter) exportHandler() {
+	defer e.wg.Done()
+
+	ticker := time.NewTicker(e.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*Span, 0, e.batchSize)
+	failing := false
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		err := e.export(batch)
+		switch {
+		case err != nil && !failing:
+			log.Warnf("Unable to export %d spans to %s: %v",
+				len(batch), e.url, err)
+			failing = true
+		case err != nil:
+			log.Debugf("Unable to export %d spans to %s: %v",
+				len(batch), e.url, err)
+		case failing:
+			log.Infof("Resumed exporting spans to %s", e.url)
+			failing = false
+		}
+		batch = batch[:0]
+	}
+
+out:
+	for {
+		select {
+		case s := <-e.spans:
+			batch = append(batch, s)
+			if len(batch) >= e.batchSize {
+				flush()
+			}
+
+		case <-ticker.C:
+			flush()
+
+		case <-e.quit:
+			break out
+		}
+	}
+
+	// Export the spans which are still queued.
+	for {
+		select {
+		case s := <-e.spans:
+			batch = append(batch, s)
+			if len(batch) >= e.batchSize {
+				flush()
+			}
+		default:
+			flush()
+			return
+		}
+	}
+}
+
+// export sends the passed spans to the endpoint in a single request.
+func (e *Exporter) export(spans []*Span) error {
+	req := otlpExportRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource: e.resource,
+		ScopeSpans: []otlpScopeSpans{{
+			Scope: otlpScope{Name: scopeName},
+			Spans: make([]otlpSpan, 0, len(spans)),
+		}},
+	}}}
+	scopeSpans := &req.ResourceSpans[0].ScopeSpans[0]
+	for _, s := range spans {
+		scopeSpans.Spans = append(scopeSpans.Spans, newOTLPSpan(s))
+	}
+	body, err := json.Marshal(&req)
+	if err != nil {
+		return err
+	}
+
+	resp, err := e.client.Post(e.url, "application/json",
+		bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+	return nil
+}
+
+// The following types model the JSON encoding of the OTLP trace export request.
+type (
+	otlpExportRequest struct {
+		ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+	}
+
+	otlpResourceSpans struct {
+		Resource   otlpResource     `json:"resource"`
+		ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+	}
+
+	otlpResource struct {
+		Attributes []otlpKeyValue `json:"attributes"`
+	}
+
+	otlpScopeSpans struct {
+		Scope otlpScope  `json:"scope"`
+		Spans []otlpSpan `json:"spans"`
+	}
+
+	otlpScope struct {
+		Name string `json:"name"`
+	}
+
+	otlpSpan struct {
+		TraceID           string         `json:"traceId"`
+		SpanID            string         `json:"spanId"`
+		ParentSpanID      string         `json:"parentSpanId,omitempty"`
+		Name              string         `json:"name"`
+		Kind              SpanKind       `json:"kind"`
+		StartTimeUnixNano string         `json:"startTimeUnixNano"`
+		EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+		Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+		Status            *otlpStatus    `json:"status,omitempty"`
+	}
+
+	otlpStatus struct {
+		Code    int    `json:"code"`
+		Message string `json:"message,omitempty"`
+	}
+
+	otlpKeyValue struct {
+		Key   string       `json:"key"`
+		Value otlpAnyValue `json:"value"`
+	}
+
+	otlpAnyValue struct {
+		StringValue *string  `json:"stringValue,omitempty"`
+		BoolValue   *bool    `json:"boolValue,omitempty"`
+		IntValue    string   `json:"intValue,omitempty"`
+		DoubleValue *float64 `json:"doubleValue,omitempty"`
+	}
+)
+
+// otlpStatusError is the OTLP status code of spans whose operation failed.
+const otlpStatusError = 2
+
+// newKeyValue returns the OTLP encoding of the passed attribute.  Integers are
+// encoded as strings as required by the JSON encoding of OTLP.
+func newKeyValue(key string, value interface{}) otlpKeyValue {
+	kv := otlpKeyValue{Key: key}
+	switch v := value.(type) {
+	case string:
+		kv.Value.StringValue = &v
+	case bool:
+		kv.Value.BoolValue = &v
+	case int:
+		kv.Value.IntValue = strconv.FormatInt(int64(v), 10)
+	case int32:
+		kv.Value.IntValue = strconv.FormatInt(int64(v), 10)
+	case int64:
+		kv.Value.IntValue = strconv.FormatInt(v, 10)
+	case uint32:
+		kv.Value.IntValue = strconv.FormatUint(uint64(v), 10)
+	case uint64:
+		kv.Value.IntValue = strconv.FormatUint(v, 10)
+	case float64:
+		kv.Value.DoubleValue = &v
+	default:
+		str := fmt.Sprint(v)
+		kv.Value.StringValue = &str
+	}
+	return kv
+}
+
+// newOTLPSpan returns the OTLP encoding of the passed finished span.
+func newOTLPSpan(s *Span) otlpSpan {
+	span := otlpSpan{
+		TraceID:           hex.EncodeToString(s.traceID[:]),
+		SpanID:            hex.EncodeToString(s.spanID[:]),
+		Name:              s.name,
+		Kind:              s.kind,
+		StartTimeUnixNano: strconv.FormatInt(s.start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(s.end.UnixNano(), 10),
+	}
+	if s.parentID != [8]byte{} {
+		span.ParentSpanID = hex.EncodeToString(s.parentID[:])
+	}
+	for _, attr := range s.attrs {
+		span.Attributes = append(span.Attributes,
+			newKeyValue(attr.key, attr.value))
+	}
+	if s.err != nil {
+		span.Status = &otlpStatus{
+			Code:    otlpStatusError,
+			Message: s.err.Error(),
+		}
+	}
+	return span
+}
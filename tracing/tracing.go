@@ -0,0 +1,148 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package tracing
+
+import (
+	"crypto/rand"
+	"sync"
+	"time"
+)
+
+// SpanKind describes the relationship of a span to the remote parties of the
+// operation it measures.
+type SpanKind int
+
+// These constants define the span kinds.  The values match those of the
+// OpenTelemetry protocol.
+const (
+	// KindInternal indicates the span measures an internal operation.
+	KindInternal SpanKind = 1
+
+	// KindServer indicates the span measures the handling of a request
+	// from a remote client, such as an RPC.
+	KindServer SpanKind = 2
+)
+
+// attribute is a key and value which describes a span.
+type attribute struct {
+	key   string
+	value interface{}
+}
+
+// Span measures a single timed operation.  A nil span is valid and all of its
+// methods do nothing, which is what Start and StartChild return when tracing
+// is disabled.
+//
+// The methods of a span must not be called concurrently.
+type Span struct {
+	exporter *Exporter
+	traceID  [16]byte
+	spanID   [8]byte
+	parentID [8]byte
+	name     string
+	kind     SpanKind
+	start    time.Time
+	end      time.Time
+	attrs    []attribute
+	err      error
+	ended    bool
+}
+
+// exporterMtx protects the exporter which records spans.
+var (
+	exporterMtx sync.RWMutex
+	exporter    *Exporter
+)
+
+// newSpanID returns a new random span ID.
+func newSpanID() (id [8]byte) {
+	rand.Read(id[:])
+	return id
+}
+
+// Start starts a new span which is the root of a new trace.  It returns nil
+// when tracing is disabled.
+func Start(name string) *Span {
+	exporterMtx.RLock()
+	e := exporter
+	exporterMtx.RUnlock()
+	if e == nil {
+		return nil
+	}
+
+	s := &Span{
+		exporter: e,
+		spanID:   newSpanID(),
+		name:     name,
+		kind:     KindInternal,
+		start:    time.Now(),
+	}
+	rand.Read(s.traceID[:])
+	return s
+}
+
+// Enabled returns whether spans are being recorded.
+func Enabled() bool {
+	exporterMtx.RLock()
+	enabled := exporter != nil
+	exporterMtx.RUnlock()
+	return enabled
+}
+
+// StartChild starts a new span which measures a part of the operation measured
+// by the span and is recorded as part of its trace.  It returns nil when the
+// span is nil.
+func (s *Span) StartChild(name string) *Span {
+	if s == nil {
+		return nil
+	}
+	return &Span{
+		exporter: s.exporter,
+		traceID:  s.traceID,
+		spanID:   newSpanID(),
+		parentID: s.spanID,
+		name:     name,
+		kind:     KindInternal,
+		start:    time.Now(),
+	}
+}
+
+// SetKind sets the kind of the span, which defaults to KindInternal.
+func (s *Span) SetKind(kind SpanKind) {
+	if s == nil {
+		return
+	}
+	s.kind = kind
+}
+
+// SetAttribute sets an attribute which describes the span.  The value must be
+// a string, bool, integer, or floating point number.  Other values are recorded
+// as strings using their default format.
+func (s *Span) SetAttribute(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+	s.attrs = append(s.attrs, attribute{key: key, value: value})
+}
+
+// SetError records the passed error as the reason the operation measured by
+// the span failed.  A nil error is ignored.
+func (s *Span) SetError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.err = err
+}
+
+// End finishes the span and queues it to be exported.  Calling End more than
+// once has no effect.
+func (s *Span) End() {
+	if s == nil || s.ended {
+		return
+	}
+	s.ended = true
+	s.end = time.Now()
+	s.exporter.queue(s)
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package tracing
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestDisabled ensures spans are not recorded and nil spans may be used when
+// no exporter is running.
+func TestDisabled(t *testing.T) {
+	if Enabled() {
+		t.Fatal("tracing enabled without an exporter")
+	}
+	span := Start("disabled")
+	if span != nil {
+		t.Fatalf("Start returned a span without an exporter: %+v", span)
+	}
+	child := span.StartChild("child")
+	child.SetKind(KindServer)
+	child.SetAttribute("key", "value")
+	child.SetError(errors.New("failed"))
+	child.End()
+	span.End()
+}
+
+// TestExport ensures finished spans are exported with the expected OTLP JSON
+// encoding and the remaining spans are exported when the exporter is stopped.
+func TestExport(t *testing.T) {
+	requests := make(chan *otlpExportRequest, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != tracesPath {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		var req otlpExportRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("unable to decode request: %v", err)
+		}
+		requests <- &req
+	}))
+	defer server.Close()
+
+	err := StartExporter(&Config{
+		Endpoint:       server.URL,
+		ServiceName:    "exccd",
+		ServiceVersion: "1.0.0",
+		FlushInterval:  time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("StartExporter: unexpected error: %v", err)
+	}
+	if err := StartExporter(&Config{Endpoint: server.URL}); err == nil {
+		t.Fatal("StartExporter: started a second exporter")
+	}
+	if !Enabled() {
+		t.Fatal("tracing not enabled by the exporter")
+	}
+
+	root := Start("ProcessBlock")
+	root.SetAttribute("block.height", int64(100))
+	root.SetAttribute("block.hash", "00ff")
+	child := root.StartChild("checkBlockSanity")
+	child.SetError(errors.New("bad block"))
+	child.End()
+	root.SetKind(KindServer)
+	root.End()
+	root.End()
+	StopExporter()
+	if Enabled() {
+		t.Fatal("tracing enabled after stopping the exporter")
+	}
+
+	var req *otlpExportRequest
+	select {
+	case req = <-requests:
+	default:
+		t.Fatal("spans were not exported when the exporter was stopped")
+	}
+	if len(req.ResourceSpans) != 1 ||
+		len(req.ResourceSpans[0].ScopeSpans) != 1 {
+		t.Fatalf("unexpected request %+v", req)
+	}
+	wantResource := []otlpKeyValue{
+		newKeyValue("service.name", "exccd"),
+		newKeyValue("service.version", "1.0.0"),
+	}
+	resource := req.ResourceSpans[0].Resource.Attributes
+	if !reflect.DeepEqual(resource, wantResource) {
+		t.Fatalf("mismatched resource - got %+v, want %+v", resource,
+			wantResource)
+	}
+	spans := req.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 2 {
+		t.Fatalf("unexpected number of spans - got %d, want 2",
+			len(spans))
+	}
+
+	// Ensure the child is part of the trace of its parent and the status
+	// and attributes are encoded.
+	c, r := spans[0], spans[1]
+	if c.Name != "checkBlockSanity" || r.Name != "ProcessBlock" {
+		t.Fatalf("unexpected span names %q and %q", c.Name, r.Name)
+	}
+	if c.TraceID != r.TraceID || c.ParentSpanID != r.SpanID ||
+		r.ParentSpanID != "" || len(r.TraceID) != 32 ||
+		len(r.SpanID) != 16 {
+
+		t.Fatalf("unexpected span IDs - child %+v, root %+v", c, r)
+	}
+	if c.Status == nil || c.Status.Code != otlpStatusError ||
+		c.Status.Message != "bad block" || r.Status != nil {
+
+		t.Fatalf("unexpected span status - child %+v, root %+v",
+			c.Status, r.Status)
+	}
+	if c.Kind != KindInternal || r.Kind != KindServer {
+		t.Fatalf("unexpected span kinds %d and %d", c.Kind, r.Kind)
+	}
+	wantAttrs := []otlpKeyValue{
+		{Key: "block.height", Value: otlpAnyValue{IntValue: "100"}},
+		newKeyValue("block.hash", "00ff"),
+	}
+	if !reflect.DeepEqual(r.Attributes, wantAttrs) {
+		t.Fatalf("mismatched attributes - got %+v, want %+v",
+			r.Attributes, wantAttrs)
+	}
+	if r.StartTimeUnixNano == "" || r.EndTimeUnixNano == "" {
+		t.Fatalf("missing span times %+v", r)
+	}
+
+	// Ensure invalid endpoints are rejected.
+	for _, endpoint := range []string{"", "127.0.0.1:4318", "ftp://host"} {
+		if err := StartExporter(&Config{Endpoint: endpoint}); err == nil {
+			StopExporter()
+			t.Fatalf("StartExporter: accepted endpoint %q", endpoint)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package txscript
+
+import (
+	"fmt"
+)
+
+const (
+	// maxECDSASigPushSize is the maximum size of a data push of a DER
+	// encoded secp256k1 ECDSA signature along with its hash type.  It
+	// consists of OP_DATA_73 followed by a 72 byte signature and the hash
+	// type byte.
+	maxECDSASigPushSize = 1 + 72 + 1
+
+	// altSigPushSize is the size of a data push of an Ed25519 or secp256k1
+	// Schnorr signature along with its hash type.  It consists of
+	// OP_DATA_65 followed by a 64 byte signature and the hash type byte.
+	altSigPushSize = 1 + 64 + 1
+
+	// compressedPubKeyPushSize is the size of a data push of a compressed
+	// secp256k1 public key.  It consists of OP_DATA_33 followed by the 33
+	// byte public key.
+	compressedPubKeyPushSize = 1 + 33
+
+	// edwardsPubKeyPushSize is the size of a data push of an Ed25519 public
+	// key.  It consists of OP_DATA_32 followed by the 32 byte public key.
+	edwardsPubKeyPushSize = 1 + 32
+)
+
+// ScriptAnalysis houses the results of statically analyzing a script as
+// returned by AnalyzeScript.
+type ScriptAnalysis struct {
+	// Class is the class of the script.  It is NonStandardTy when the
+	// script is not one of the standard forms.
+	Class ScriptClass
+
+	// SubClass is the class of the script tagged by the stake opcode of
+	// stake scripts.  It is NonStandardTy for all other scripts.
+	SubClass ScriptClass
+
+	// ReqSigs is the number of signatures required to redeem the script.
+	// It is zero when the number is not known, such as for
+	// pay-to-script-hash scripts.
+	ReqSigs int
+
+	// SigOps is the number of signature operations in the script as
+	// counted by the consensus rules for public key and signature scripts.
+	SigOps int
+
+	// PreciseSigOps is the number of signature operations in the script as
+	// counted by the consensus rules for pay-to-script-hash redeem scripts,
+	// which counts the actual number of public keys of multi-signature
+	// checks.
+	PreciseSigOps int
+
+	// PushOnly is whether or not the script only pushes data.
+	PushOnly bool
+
+	// NumPushes is the number of data pushes in the script, including
+	// pushes of small integers.
+	NumPushes int
+
+	// MaxPushSize is the size of the largest data pushed by the script.
+	MaxPushSize int
+
+	// SigScriptSize is the estimated maximum size of a signature script
+	// which redeems the script, assuming compressed public keys are used.
+	// It is -1 when the size can not be estimated, such as for
+	// pay-to-script-hash and nonstandard scripts.
+	SigScriptSize int
+}
+
+// estimateSigScriptSize returns the estimated maximum size of a signature
+// script which redeems a public key script with the passed parsed opcodes and
+// class.  It returns -1 when the size can not be estimated.
+func estimateSigScriptSize(pops []parsedOpcode, class ScriptClass) int {
+	switch class {
+	case PubKeyTy:
+		return maxECDSASigPushSize
+
+	case PubKeyHashTy:
+		return maxECDSASigPushSize + compressedPubKeyPushSize
+
+	case PubkeyAltTy:
+		return altSigPushSize
+
+	case PubkeyHashAltTy:
+		valInt := extractOneBytePush(pops[4])
+		if sigTypes(valInt) == edwards {
+			return altSigPushSize + edwardsPubKeyPushSize
+		}
+		return altSigPushSize + compressedPubKeyPushSize
+
+	case MultiSigTy:
+		// The extra item popped by OP_CHECKMULTISIG requires an
+		// additional push of an empty item.
+		return 1 + asSmallInt(pops[0].opcode)*maxECDSASigPushSize
+	}
+
+	return -1
+}
+
+// AnalyzeScript statically analyzes the passed script without executing it
+// and returns information about it such as its class, the number of signature
+// operations it contains, and the estimated size of a signature script which
+// redeems it.  This is useful for estimating fees and building block templates.
+//
+// An error is returned when the script version is unknown or the script does
+// not parse.
+func AnalyzeScript(version uint16, script []byte) (*ScriptAnalysis, error) {
+	semantics, ok := scriptVersions[version]
+	if !ok {
+		return nil, fmt.Errorf("unsupported script version %d", version)
+	}
+	pops, err := parseScriptTemplate(script, semantics.opcodes)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &ScriptAnalysis{
+		Class:         typeOfScript(pops),
+		SubClass:      NonStandardTy,
+		SigOps:        getSigOpCount(pops, false),
+		PreciseSigOps: getSigOpCount(pops, true),
+		PushOnly:      isPushOnly(pops),
+	}
+	for _, pop := range pops {
+		if pop.opcode.value > OP_16 {
+			continue
+		}
+		a.NumPushes++
+		if len(pop.data) > a.MaxPushSize {
+			a.MaxPushSize = len(pop.data)
+		}
+	}
+
+	switch a.Class {
+	case StakeSubmissionTy, StakeGenTy, StakeRevocationTy, StakeSubChangeTy:
+		// Stake scripts are pay-to-pubkey-hash or pay-to-script-hash
+		// scripts tagged with a stake opcode.
+		if isPubkeyHash(pops[1:]) {
+			a.SubClass = PubKeyHashTy
+			a.ReqSigs = 1
+			a.SigScriptSize = estimateSigScriptSize(pops[1:],
+				PubKeyHashTy)
+		} else {
+			a.SubClass = ScriptHashTy
+			a.SigScriptSize = -1
+		}
+
+	case PubKeyTy, PubKeyHashTy, PubkeyAltTy, PubkeyHashAltTy:
+		a.ReqSigs = 1
+		a.SigScriptSize = estimateSigScriptSize(pops, a.Class)
+
+	case MultiSigTy:
+		a.ReqSigs = asSmallInt(pops[0].opcode)
+		a.SigScriptSize = estimateSigScriptSize(pops, a.Class)
+
+	default:
+		a.SigScriptSize = -1
+	}
+
+	return a, nil
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package txscript_test
+
+import (
+	"testing"
+
+	"github.com/EXCCoin/exccd/txscript"
+)
+
+// TestAnalyzeScript ensures AnalyzeScript returns the expected results for
+// various scripts.
+func TestAnalyzeScript(t *testing.T) {
+	t.Parallel()
+
+	const pkh = "DATA_20 0x660d4ef3a743e3e696ad990364e555c271ad504b"
+	const pubKey = "DATA_33 0x0102030405060708090a0b0c0d0e0f101112131415" +
+		"161718191a1b1c1d1e1f2021"
+	tests := []struct {
+		name     string
+		version  uint16
+		script   string
+		analysis *txscript.ScriptAnalysis
+	}{
+		{
+			name:   "pay to pubkey hash",
+			script: "DUP HASH160 " + pkh + " EQUALVERIFY CHECKSIG",
+			analysis: &txscript.ScriptAnalysis{
+				Class:         txscript.PubKeyHashTy,
+				SubClass:      txscript.NonStandardTy,
+				ReqSigs:       1,
+				SigOps:        1,
+				PreciseSigOps: 1,
+				NumPushes:     1,
+				MaxPushSize:   20,
+				SigScriptSize: 108,
+			},
+		},
+		{
+			name:   "pay to script hash",
+			script: "HASH160 " + pkh + " EQUAL",
+			analysis: &txscript.ScriptAnalysis{
+				Class:         txscript.ScriptHashTy,
+				SubClass:      txscript.NonStandardTy,
+				NumPushes:     1,
+				MaxPushSize:   20,
+				SigScriptSize: -1,
+			},
+		},
+		{
+			name: "2-of-3 multisig",
+			script: "2 " + pubKey + " " + pubKey + " " + pubKey +
+				" 3 CHECKMULTISIG",
+			analysis: &txscript.ScriptAnalysis{
+				Class:         txscript.MultiSigTy,
+				SubClass:      txscript.NonStandardTy,
+				ReqSigs:       2,
+				SigOps:        txscript.MaxPubKeysPerMultiSig,
+				PreciseSigOps: 3,
+				NumPushes:     5,
+				MaxPushSize:   33,
+				SigScriptSize: 149,
+			},
+		},
+		{
+			name:   "ticket submission",
+			script: "SSTX DUP HASH160 " + pkh + " EQUALVERIFY CHECKSIG",
+			analysis: &txscript.ScriptAnalysis{
+				Class:         txscript.StakeSubmissionTy,
+				SubClass:      txscript.PubKeyHashTy,
+				ReqSigs:       1,
+				SigOps:        1,
+				PreciseSigOps: 1,
+				NumPushes:     1,
+				MaxPushSize:   20,
+				SigScriptSize: 108,
+			},
+		},
+		{
+			name:   "signature script",
+			script: "DATA_2 0x0102 " + pubKey,
+			analysis: &txscript.ScriptAnalysis{
+				Class:         txscript.NonStandardTy,
+				SubClass:      txscript.NonStandardTy,
+				PushOnly:      true,
+				NumPushes:     2,
+				MaxPushSize:   33,
+				SigScriptSize: -1,
+			},
+		},
+		{
+			name:   "nulldata",
+			script: "RETURN DATA_4 0x01020304",
+			analysis: &txscript.ScriptAnalysis{
+				Class:         txscript.NullDataTy,
+				SubClass:      txscript.NonStandardTy,
+				NumPushes:     1,
+				MaxPushSize:   4,
+				SigScriptSize: -1,
+			},
+		},
+		{
+			name:   "does not parse",
+			script: "DATA_5 0x01020304",
+		},
+		{
+			name:    "unknown script version",
+			version: 0xffff,
+			script:  "TRUE",
+		},
+	}
+
+	for _, test := range tests {
+		script := mustParseShortForm(test.script)
+		analysis, err := txscript.AnalyzeScript(test.version, script)
+		if test.analysis == nil {
+			if err == nil {
+				t.Errorf("%s: did not receive expected error",
+					test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if *analysis != *test.analysis {
+			t.Errorf("%s: unexpected analysis - got %+v, want %+v",
+				test.name, analysis, test.analysis)
+		}
+	}
+}
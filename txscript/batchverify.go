@@ -0,0 +1,196 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package txscript
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/EXCCoin/exccd/chaincfg/chainec"
+	"github.com/EXCCoin/exccd/chaincfg/chainhash"
+	"github.com/EXCCoin/exccd/exccec/secp256k1"
+	"github.com/EXCCoin/exccd/exccec/secp256k1/schnorr"
+)
+
+// batchVerifySize is the maximum number of signatures which are verified
+// together in a single batch.  Batches are verified concurrently, so limiting
+// their size allows the work to be spread across all processors and to stop
+// early once an invalid batch has been found.
+const batchVerifySize = 64
+
+// batchEntry describes a deferred signature check.
+type batchEntry struct {
+	sigType int
+	pubKey  chainec.PublicKey
+	hash    []byte
+	sig     chainec.Signature
+}
+
+// BatchVerifier collects the signature checks performed by script engines for
+// OP_CHECKSIG and OP_CHECKSIGALT that have been configured to use it via
+// SetBatchVerifier so they can all be verified together once the scripts have
+// been executed.  Secp256k1 Schnorr
+// signatures are verified in aggregated batches, which is significantly faster
+// than verifying them individually.  Secp256k1 ECDSA and Ed25519 signatures can
+// not be combined and are verified individually, but still concurrently.
+//
+// Since deferred signature checks are assumed to be valid while the scripts
+// are executed, the results of the engines are only meaningful when Verify
+// returns true.  Callers must validate the scripts again without a batch
+// verifier when either any of the scripts failed or Verify returns false in
+// order to determine the actual result.
+//
+// A BatchVerifier is safe for concurrent use by multiple engines.
+type BatchVerifier struct {
+	sigCache *SigCache
+
+	mtx     sync.Mutex
+	entries []batchEntry
+}
+
+// NewBatchVerifier returns a new batch verifier.  Secp256k1 ECDSA signatures
+// which are found in the provided signature cache are not deferred, and those
+// that are verified by the batch are added to it.  The cache may be nil.
+func NewBatchVerifier(sigCache *SigCache) *BatchVerifier {
+	return &BatchVerifier{sigCache: sigCache}
+}
+
+// add defers the verification of the passed signature.
+func (b *BatchVerifier) add(sigType int, pubKey chainec.PublicKey, hash []byte,
+	sig chainec.Signature) {
+
+	b.mtx.Lock()
+	b.entries = append(b.entries, batchEntry{sigType, pubKey, hash, sig})
+	b.mtx.Unlock()
+}
+
+// Len returns the number of signature checks that have been deferred and not
+// verified yet.
+func (b *BatchVerifier) Len() int {
+	b.mtx.Lock()
+	n := len(b.entries)
+	b.mtx.Unlock()
+	return n
+}
+
+// verifyBatch returns whether or not all signatures of the passed batch, which
+// must all be of the same signature type, are valid.
+func verifyBatch(batch []batchEntry) bool {
+	if batch[0].sigType == chainec.ECTypeSecSchnorr {
+		pubKeys := make([]*secp256k1.PublicKey, len(batch))
+		msgs := make([][]byte, len(batch))
+		sigs := make([]*schnorr.Signature, len(batch))
+		for i := range batch {
+			entry := &batch[i]
+			pubKeys[i] = secp256k1.NewPublicKey(entry.pubKey.GetX(),
+				entry.pubKey.GetY())
+			msgs[i] = entry.hash
+			sigs[i] = schnorr.NewSignature(entry.sig.GetR(),
+				entry.sig.GetS())
+		}
+		return schnorr.BatchVerify(pubKeys, msgs, sigs)
+	}
+
+	for i := range batch {
+		entry := &batch[i]
+		if !verifySig(entry.sigType, entry.pubKey, entry.hash, entry.sig) {
+			return false
+		}
+	}
+	return true
+}
+
+// Verify verifies all of the signature checks that have been deferred since
+// the verifier was created or Verify was last called and returns whether or
+// not all of them are valid.  The verification stops as soon as any invalid
+// signature is found.
+func (b *BatchVerifier) Verify() bool {
+	b.mtx.Lock()
+	entries := b.entries
+	b.entries = nil
+	b.mtx.Unlock()
+
+	// Split the entries into batches of the same signature type.
+	var batches [][]batchEntry
+	var byType [chainec.ECTypeSecSchnorr + 1][]batchEntry
+	for _, entry := range entries {
+		byType[entry.sigType] = append(byType[entry.sigType], entry)
+	}
+	for _, typeEntries := range byType {
+		for len(typeEntries) > 0 {
+			n := batchVerifySize
+			if n > len(typeEntries) {
+				n = len(typeEntries)
+			}
+			batches = append(batches, typeEntries[:n])
+			typeEntries = typeEntries[n:]
+		}
+	}
+	if len(batches) == 0 {
+		return true
+	}
+
+	// Verify the batches using a goroutine per processor core and stop
+	// handing out batches once any of them is invalid.
+	numWorkers := runtime.NumCPU()
+	if numWorkers > len(batches) {
+		numWorkers = len(batches)
+	}
+	var invalid int32
+	var wg sync.WaitGroup
+	batchChan := make(chan []batchEntry)
+	wg.Add(numWorkers)
+	for i := 0; i < numWorkers; i++ {
+		go func() {
+			for batch := range batchChan {
+				if !verifyBatch(batch) {
+					atomic.StoreInt32(&invalid, 1)
+				}
+			}
+			wg.Done()
+		}()
+	}
+	for _, batch := range batches {
+		if atomic.LoadInt32(&invalid) != 0 {
+			break
+		}
+		batchChan <- batch
+	}
+	close(batchChan)
+	wg.Wait()
+	if invalid != 0 {
+		return false
+	}
+
+	// Add the now verified ECDSA signatures to the signature cache.
+	if b.sigCache != nil {
+		for _, entry := range byType[chainec.ECTypeSecp256k1] {
+			var sigHash chainhash.Hash
+			copy(sigHash[:], entry.hash)
+			b.sigCache.Add(sigHash, entry.sig, entry.pubKey)
+		}
+	}
+	return true
+}
+
+// verifySig returns whether or not the passed signature of the provided
+// signature type is valid for the public key and hash.
+func verifySig(sigType int, pubKey chainec.PublicKey, hash []byte,
+	sig chainec.Signature) bool {
+
+	switch sigType {
+	case chainec.ECTypeSecp256k1:
+		return chainec.Secp256k1.Verify(pubKey, hash, sig.GetR(),
+			sig.GetS())
+	case chainec.ECTypeEdwards:
+		return chainec.Edwards.Verify(pubKey, hash, sig.GetR(),
+			sig.GetS())
+	case chainec.ECTypeSecSchnorr:
+		return chainec.SecSchnorr.Verify(pubKey, hash, sig.GetR(),
+			sig.GetS())
+	}
+	return false
+}
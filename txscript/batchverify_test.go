@@ -0,0 +1,241 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package txscript
+
+import (
+	"testing"
+
+	"github.com/EXCCoin/exccd/chaincfg/chainec"
+	"github.com/EXCCoin/exccd/chaincfg/chainhash"
+	"github.com/EXCCoin/exccd/exccutil"
+	"github.com/EXCCoin/exccd/wire"
+)
+
+// TestBatchVerifier ensures the signature checks of engines which use a batch
+// verifier are deferred and that the batch is only valid when all of the
+// deferred signatures are.
+func TestBatchVerifier(t *testing.T) {
+	// Create a transaction which spends a pay-to-pubkey-hash output and a
+	// secp256k1 Schnorr pay-to-pubkey output.
+	ecdsaKey, ecdsaPub := chainec.Secp256k1.PrivKeyFromBytes(
+		chainhash.HashB([]byte("ecdsa")))
+	schnorrKey, schnorrPub := chainec.SecSchnorr.PrivKeyFromBytes(
+		chainhash.HashB([]byte("schnorr")))
+	pkScripts := make([][]byte, 2)
+	var err error
+	pkScripts[0], err = NewScriptBuilder().AddOp(OP_DUP).AddOp(OP_HASH160).
+		AddData(exccutil.Hash160(ecdsaPub.SerializeCompressed())).
+		AddOp(OP_EQUALVERIFY).AddOp(OP_CHECKSIG).Script()
+	if err != nil {
+		t.Fatalf("unable to create script: %v", err)
+	}
+	pkScripts[1], err = NewScriptBuilder().AddData(schnorrPub.Serialize()).
+		AddInt64(int64(secSchnorr)).AddOp(OP_CHECKSIGALT).Script()
+	if err != nil {
+		t.Fatalf("unable to create script: %v", err)
+	}
+
+	tx := wire.NewMsgTx()
+	tx.AddTxIn(&wire.TxIn{PreviousOutPoint: wire.OutPoint{Index: 0}})
+	tx.AddTxIn(&wire.TxIn{PreviousOutPoint: wire.OutPoint{Index: 1}})
+	tx.AddTxOut(wire.NewTxOut(1, []byte{OP_TRUE}))
+	tx.TxIn[0].SignatureScript, err = SignatureScript(tx, 0, pkScripts[0],
+		SigHashAll, ecdsaKey, true)
+	if err != nil {
+		t.Fatalf("unable to sign input: %v", err)
+	}
+	sig, err := RawTxInSignatureAlt(tx, 1, pkScripts[1], SigHashAll,
+		schnorrKey, secSchnorr)
+	if err != nil {
+		t.Fatalf("unable to sign input: %v", err)
+	}
+	tx.TxIn[1].SignatureScript, err = NewScriptBuilder().AddData(sig).Script()
+	if err != nil {
+		t.Fatalf("unable to create script: %v", err)
+	}
+
+	// execute executes the scripts of all inputs of the transaction with
+	// the provided batch verifier and returns the first error.
+	execute := func(batch *BatchVerifier) error {
+		for i, pkScript := range pkScripts {
+			vm, err := NewEngine(pkScript, tx, i, 0, 0, nil)
+			if err != nil {
+				t.Fatalf("unable to create engine: %v", err)
+			}
+			if batch != nil {
+				vm.SetBatchVerifier(batch)
+			}
+			if err := vm.Execute(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	batch := NewBatchVerifier(nil)
+	if err := execute(batch); err != nil {
+		t.Fatalf("unexpected script failure: %v", err)
+	}
+	if batch.Len() != 2 {
+		t.Fatalf("unexpected number of deferred signatures - got %d, "+
+			"want 2", batch.Len())
+	}
+	if !batch.Verify() {
+		t.Fatal("batch of valid signatures is invalid")
+	}
+	if batch.Len() != 0 {
+		t.Fatalf("deferred signatures not cleared after verification")
+	}
+
+	// Modify the transaction so both signatures are invalid and ensure the
+	// scripts still succeed when the signature checks are deferred, but
+	// the batch is invalid.
+	tx.TxOut[0].Value++
+	if err := execute(batch); err != nil {
+		t.Fatalf("unexpected script failure with deferred signature "+
+			"checks: %v", err)
+	}
+	if batch.Verify() {
+		t.Fatal("batch of invalid signatures is valid")
+	}
+	if err := execute(nil); err == nil {
+		t.Fatal("scripts with invalid signatures succeeded")
+	}
+}
+
+// TestBatchVerifierMultiSig ensures the signature checks of OP_CHECKMULTISIG
+// are not deferred to the batch verifier, so signatures which are tried
+// against public keys they are not for do not make the batch invalid.
+func TestBatchVerifierMultiSig(t *testing.T) {
+	// Create a transaction which spends a 1-of-2 multisig output with a
+	// signature for the second public key.
+	_, pub1 := chainec.Secp256k1.PrivKeyFromBytes(
+		chainhash.HashB([]byte("multisig1")))
+	key2, pub2 := chainec.Secp256k1.PrivKeyFromBytes(
+		chainhash.HashB([]byte("multisig2")))
+	pkScript, err := NewScriptBuilder().AddOp(OP_1).
+		AddData(pub1.SerializeCompressed()).
+		AddData(pub2.SerializeCompressed()).AddOp(OP_2).
+		AddOp(OP_CHECKMULTISIG).Script()
+	if err != nil {
+		t.Fatalf("unable to create script: %v", err)
+	}
+
+	tx := wire.NewMsgTx()
+	tx.AddTxIn(&wire.TxIn{PreviousOutPoint: wire.OutPoint{Index: 0}})
+	tx.AddTxOut(wire.NewTxOut(1, []byte{OP_TRUE}))
+	sig, err := RawTxInSignature(tx, 0, pkScript, SigHashAll, key2)
+	if err != nil {
+		t.Fatalf("unable to sign input: %v", err)
+	}
+	tx.TxIn[0].SignatureScript, err = NewScriptBuilder().AddData(sig).Script()
+	if err != nil {
+		t.Fatalf("unable to create script: %v", err)
+	}
+
+	vm, err := NewEngine(pkScript, tx, 0, 0, 0, nil)
+	if err != nil {
+		t.Fatalf("unable to create engine: %v", err)
+	}
+	batch := NewBatchVerifier(nil)
+	vm.SetBatchVerifier(batch)
+	if err := vm.Execute(); err != nil {
+		t.Fatalf("unexpected script failure: %v", err)
+	}
+	if batch.Len() != 0 {
+		t.Fatalf("unexpected number of deferred signatures - got %d, "+
+			"want 0", batch.Len())
+	}
+	if !batch.Verify() {
+		t.Fatal("empty batch is invalid")
+	}
+}
+
+// TestBatchVerifierRequiredResult ensures signature checks are only deferred to
+// the batch verifier when the script fails unless they succeed, so scripts
+// which succeed because of an invalid signature do not make the batch invalid.
+func TestBatchVerifierRequiredResult(t *testing.T) {
+	key, pub := chainec.Secp256k1.PrivKeyFromBytes(
+		chainhash.HashB([]byte("required")))
+	pubKey := pub.SerializeCompressed()
+
+	tests := []struct {
+		name     string
+		builder  *ScriptBuilder
+		invalid  bool
+		deferred bool
+	}{{
+		name:     "checksig at end of script",
+		builder:  NewScriptBuilder().AddData(pubKey).AddOp(OP_CHECKSIG),
+		deferred: true,
+	}, {
+		name: "checksig followed by verify",
+		builder: NewScriptBuilder().AddData(pubKey).AddOp(OP_CHECKSIG).
+			AddOp(OP_VERIFY).AddOp(OP_TRUE),
+		deferred: true,
+	}, {
+		name: "checksigverify",
+		builder: NewScriptBuilder().AddData(pubKey).
+			AddOp(OP_CHECKSIGVERIFY).AddOp(OP_TRUE),
+		deferred: true,
+	}, {
+		name: "negated checksig",
+		builder: NewScriptBuilder().AddData(pubKey).AddOp(OP_CHECKSIG).
+			AddOp(OP_NOT),
+		invalid: true,
+	}, {
+		name: "checksig selecting a branch",
+		builder: NewScriptBuilder().AddData(pubKey).AddOp(OP_CHECKSIG).
+			AddOp(OP_IF).AddOp(OP_FALSE).AddOp(OP_ELSE).AddOp(OP_TRUE).
+			AddOp(OP_ENDIF),
+		invalid: true,
+	}}
+
+	for _, test := range tests {
+		pkScript, err := test.builder.Script()
+		if err != nil {
+			t.Fatalf("%s: unable to create script: %v", test.name, err)
+		}
+		tx := wire.NewMsgTx()
+		tx.AddTxIn(&wire.TxIn{PreviousOutPoint: wire.OutPoint{Index: 0}})
+		tx.AddTxOut(wire.NewTxOut(1, []byte{OP_TRUE}))
+		sig, err := RawTxInSignature(tx, 0, pkScript, SigHashAll, key)
+		if err != nil {
+			t.Fatalf("%s: unable to sign input: %v", test.name, err)
+		}
+		tx.TxIn[0].SignatureScript, err = NewScriptBuilder().AddData(sig).
+			Script()
+		if err != nil {
+			t.Fatalf("%s: unable to create script: %v", test.name, err)
+		}
+		if test.invalid {
+			tx.TxOut[0].Value++
+		}
+
+		vm, err := NewEngine(pkScript, tx, 0, 0, 0, nil)
+		if err != nil {
+			t.Fatalf("%s: unable to create engine: %v", test.name, err)
+		}
+		batch := NewBatchVerifier(nil)
+		vm.SetBatchVerifier(batch)
+		if err := vm.Execute(); err != nil {
+			t.Errorf("%s: unexpected script failure: %v", test.name,
+				err)
+			continue
+		}
+		want := 0
+		if test.deferred {
+			want = 1
+		}
+		if batch.Len() != want {
+			t.Errorf("%s: unexpected number of deferred signatures - "+
+				"got %d, want %d", test.name, batch.Len(), want)
+			continue
+		}
+		if !batch.Verify() {
+			t.Errorf("%s: batch is invalid", test.name)
+		}
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"math/big"
 
 	"github.com/EXCCoin/exccd/chaincfg/chainec"
+	"github.com/EXCCoin/exccd/chaincfg/chainhash"
 	"github.com/EXCCoin/exccd/wire"
 )
 
@@ -99,6 +100,7 @@ type Engine struct {
 	scripts         [][]parsedOpcode
 	savedFirstStack [][]byte // stack from first script for bip16 scripts
 	sigCache        *SigCache
+	batch           *BatchVerifier
 
 	scriptIdx   int
 	scriptOff   int
@@ -111,6 +113,7 @@ type Engine struct {
 	numOps      int
 	flags       ScriptFlags
 	version     uint16
+	semantics   *versionSemantics
 	bip16       bool // treat execution as pay-to-script-hash
 }
 
@@ -130,6 +133,102 @@ func (vm *Engine) isBranchExecuting() bool {
 	return vm.condStack[len(vm.condStack)-1] == OpCondTrue
 }
 
+// parseScript parses the passed script with the opcodes defined by the script
+// version of the engine.
+func (vm *Engine) parseScript(script []byte) ([]parsedOpcode, error) {
+	return parseScriptTemplate(script, vm.semantics.opcodes)
+}
+
+// calcSignatureHash calculates the signature hash of the passed script for the
+// input being validated as defined by the script version of the engine.
+func (vm *Engine) calcSignatureHash(script []parsedOpcode, hashType SigHashType, cachedPrefix *chainhash.Hash) ([]byte, error) {
+	return vm.semantics.calcSignatureHash(script, hashType, &vm.tx, vm.txIdx,
+		cachedPrefix)
+}
+
+// verifySignature returns whether or not the passed signature of the provided
+// signature type is valid for the public key and hash.  Secp256k1 ECDSA
+// signatures are looked up in and added to the signature cache when the engine
+// has one.
+func (vm *Engine) verifySignature(sigType int, pubKey chainec.PublicKey, hash []byte, sig chainec.Signature) bool {
+	useCache := vm.sigCache != nil && sigType == chainec.ECTypeSecp256k1
+	var sigHash chainhash.Hash
+	if useCache {
+		copy(sigHash[:], hash)
+		if vm.sigCache.Exists(sigHash, sig, pubKey) {
+			return true
+		}
+	}
+
+	valid := verifySig(sigType, pubKey, hash, sig)
+	if valid && useCache {
+		vm.sigCache.Add(sigHash, sig, pubKey)
+	}
+	return valid
+}
+
+// sigCheckMustSucceed returns whether or not the script fails unless the
+// signature check performed by the opcode which is being executed succeeds.
+// That is the case for the verify variants of the signature checking opcodes,
+// and for the others when they are immediately followed by OP_VERIFY or are the
+// final opcode of the final script, so their result is the result of the
+// script.  The result of any other signature check might be inverted or used to
+// select a branch, such as by OP_NOT or OP_IF, so a script can succeed when it
+// fails.
+func (vm *Engine) sigCheckMustSucceed() bool {
+	script := vm.scripts[vm.scriptIdx]
+	switch script[vm.scriptOff].opcode.value {
+	case OP_CHECKSIGVERIFY, OP_CHECKSIGALTVERIFY:
+		return true
+	}
+	if vm.scriptOff+1 < len(script) {
+		return script[vm.scriptOff+1].opcode.value == OP_VERIFY
+	}
+	return vm.scriptIdx == len(vm.scripts)-1
+}
+
+// verifyOrDeferSignature returns whether or not the passed signature of the
+// provided signature type is valid for the public key and hash the same way as
+// verifySignature, except that when a batch verifier is set, signatures which
+// are not in the signature cache are instead added to it and assumed to be
+// valid.
+//
+// Signatures are only deferred when the script fails unless they are valid.
+// Otherwise, a script which succeeds because of an invalid signature would
+// make the batch fail and force all scripts to be validated again without it.
+// For the same reason, this must only be used by opcodes which check a
+// signature against a single public key.  The signatures checked by
+// OP_CHECKMULTISIG are tried against several public keys, so the invalid pairs
+// would make the batch fail as well.
+func (vm *Engine) verifyOrDeferSignature(sigType int, pubKey chainec.PublicKey, hash []byte, sig chainec.Signature) bool {
+	if vm.batch == nil || !vm.sigCheckMustSucceed() {
+		return vm.verifySignature(sigType, pubKey, hash, sig)
+	}
+
+	if vm.sigCache != nil && sigType == chainec.ECTypeSecp256k1 {
+		var sigHash chainhash.Hash
+		copy(sigHash[:], hash)
+		if vm.sigCache.Exists(sigHash, sig, pubKey) {
+			return true
+		}
+	}
+
+	vm.batch.add(sigType, pubKey, hash, sig)
+	return true
+}
+
+// SetBatchVerifier defers the signature checks performed by the engine for
+// OP_CHECKSIG and OP_CHECKSIGALT to the passed batch verifier.  The signatures
+// are assumed to be valid while the scripts are executed, so the result of
+// executing them is only meaningful when all signatures of the batch are
+// subsequently verified.  The signatures checked by OP_CHECKMULTISIG, and those
+// whose result the script does not require to be true, such as when it is
+// negated by OP_NOT, are always verified immediately.  See BatchVerifier for
+// more details.
+func (vm *Engine) SetBatchVerifier(batch *BatchVerifier) {
+	vm.batch = batch
+}
+
 // executeOpcode peforms execution on the passed opcode.  It takes into account
 // whether or not it is hidden by conditionals, but some rules still must be
 // tested in this case.
@@ -321,7 +420,7 @@ func (vm *Engine) Step() (done bool, err error) {
 			}
 
 			script := vm.savedFirstStack[len(vm.savedFirstStack)-1]
-			pops, err := parseScript(script)
+			pops, err := vm.parseScript(script)
 			if err != nil {
 				return false, err
 			}
@@ -349,10 +448,10 @@ func (vm *Engine) Step() (done bool, err error) {
 // Execute will execute all scripts in the script engine and return either nil
 // for successful validation or an error if one occurred.
 func (vm *Engine) Execute() (err error) {
-	// All non-default version scripts currently execute without issue,
-	// making all outputs to them anyone can pay. In the future this
-	// will allow for the addition of new scripting languages.
-	if vm.version != DefaultScriptVersion {
+	// Scripts of versions which are not defined yet execute without issue,
+	// making all outputs to them anyone can pay.  This allows new script
+	// versions to be introduced as a soft fork.
+	if !IsKnownScriptVersion(vm.version) {
 		return nil
 	}
 
@@ -388,6 +487,67 @@ func (vm *Engine) Execute() (err error) {
 	return vm.CheckErrorCondition(true)
 }
 
+// TraceStep describes the state of the engine after it executed a single
+// opcode while tracing script execution.
+type TraceStep struct {
+	// ScriptIdx and ScriptOff identify the executed opcode.  The index is
+	// 0 for the signature script, 1 for the public key script, and 2 for
+	// the redeem script of a pay-to-script-hash output.
+	ScriptIdx int
+	ScriptOff int
+
+	// Opcode is the disassembly of the executed opcode.
+	Opcode string
+
+	// Stack and AltStack are the contents of the data and alternate stacks
+	// after executing the opcode, with the top item last.
+	Stack    [][]byte
+	AltStack [][]byte
+
+	// Err is the error that resulted from executing the opcode, if any.
+	Err error
+}
+
+// Trace executes all scripts in the script engine exactly like Execute,
+// however it also records the state of the engine after every executed opcode.
+// The recorded steps are returned along with nil for successful validation or
+// the error that caused validation to fail.  When the failure is detected after
+// all opcodes were executed, such as a false value left on the stack, the error
+// is not associated with any step.
+//
+// This is intended to help debug scripts and is considerably slower than
+// Execute, so it should not be used for validation.
+func (vm *Engine) Trace() ([]TraceStep, error) {
+	if !IsKnownScriptVersion(vm.version) {
+		return nil, nil
+	}
+
+	var steps []TraceStep
+	done := false
+	for !done {
+		if err := vm.validPC(); err != nil {
+			return steps, err
+		}
+		step := TraceStep{
+			ScriptIdx: vm.scriptIdx,
+			ScriptOff: vm.scriptOff,
+			Opcode:    vm.scripts[vm.scriptIdx][vm.scriptOff].print(false),
+		}
+
+		var err error
+		done, err = vm.Step()
+		step.Stack = vm.GetStack()
+		step.AltStack = vm.GetAltStack()
+		step.Err = err
+		steps = append(steps, step)
+		if err != nil {
+			return steps, err
+		}
+	}
+
+	return steps, vm.CheckErrorCondition(true)
+}
+
 // subScript returns the script since the last OP_CODESEPARATOR.
 func (vm *Engine) subScript() []parsedOpcode {
 	return vm.scripts[vm.scriptIdx][vm.lastCodeSep:]
@@ -400,8 +560,7 @@ func (vm *Engine) checkHashTypeEncoding(hashType SigHashType) error {
 		return nil
 	}
 
-	sigHashType := hashType & ^SigHashAnyOneCanPay
-	if sigHashType < SigHashAll || sigHashType > SigHashSingle {
+	if !vm.semantics.isValidHashType(hashType) {
 		return fmt.Errorf("invalid hashtype: 0x%x\n", hashType)
 	}
 	return nil
@@ -642,6 +801,15 @@ func NewEngine(scriptPubKey []byte, tx *wire.MsgTx, txIdx int,
 		}
 	}
 
+	// Look up the semantics of the script version.  Scripts of versions
+	// which are not defined are parsed with the semantics of the default
+	// version, although they are never executed.
+	semantics, ok := scriptVersions[scriptVersion]
+	if !ok {
+		semantics = scriptVersions[DefaultScriptVersion]
+	}
+	vm.semantics = semantics
+
 	// The engine stores the scripts in parsed form using a slice.  This
 	// allows multiple scripts to be executed in sequence.  For example,
 	// with a pay-to-script-hash transaction, there will be ultimately be
@@ -653,7 +821,7 @@ func NewEngine(scriptPubKey []byte, tx *wire.MsgTx, txIdx int,
 			return nil, ErrStackLongScript
 		}
 		var err error
-		vm.scripts[i], err = parseScript(scr)
+		vm.scripts[i], err = vm.parseScript(scr)
 		if err != nil {
 			return nil, err
 		}
@@ -458,3 +458,53 @@ func TestCheckSignatureEncoding(t *testing.T) {
 		}
 	}
 }
+
+// TestTrace ensures tracing script execution records the state of the engine
+// after every executed opcode along with the error that caused execution to
+// fail.
+func TestTrace(t *testing.T) {
+	t.Parallel()
+
+	tx := wire.NewMsgTx()
+	tx.AddTxIn(&wire.TxIn{SignatureScript: []byte{txscript.OP_1,
+		txscript.OP_2}})
+	pkScript := []byte{txscript.OP_TOALTSTACK, txscript.OP_VERIFY,
+		txscript.OP_VERIFY}
+
+	vm, err := txscript.NewEngine(pkScript, tx, 0, 0, 0, nil)
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	steps, err := vm.Trace()
+	if err != txscript.ErrStackUnderflow {
+		t.Fatalf("unexpected error - got %v, want %v", err,
+			txscript.ErrStackUnderflow)
+	}
+
+	wantOpcodes := []string{"OP_1", "OP_2", "OP_TOALTSTACK", "OP_VERIFY",
+		"OP_VERIFY"}
+	if len(steps) != len(wantOpcodes) {
+		t.Fatalf("unexpected number of steps - got %d, want %d",
+			len(steps), len(wantOpcodes))
+	}
+	for i, step := range steps {
+		if step.Opcode != wantOpcodes[i] {
+			t.Errorf("step %d: unexpected opcode - got %s, want %s", i,
+				step.Opcode, wantOpcodes[i])
+		}
+	}
+	if steps[2].ScriptIdx != 1 || steps[2].ScriptOff != 0 {
+		t.Errorf("unexpected position of first public key script step "+
+			"- got %d:%d, want 1:0", steps[2].ScriptIdx,
+			steps[2].ScriptOff)
+	}
+	if len(steps[2].Stack) != 1 || len(steps[2].AltStack) != 1 {
+		t.Errorf("unexpected stack depths after OP_TOALTSTACK - got "+
+			"%d/%d, want 1/1", len(steps[2].Stack),
+			len(steps[2].AltStack))
+	}
+	if steps[3].Err != nil || steps[4].Err != txscript.ErrStackUnderflow {
+		t.Errorf("unexpected step errors - got %v and %v", steps[3].Err,
+			steps[4].Err)
+	}
+}
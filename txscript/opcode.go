@@ -2447,8 +2447,7 @@ func opcodeCheckSig(op *parsedOpcode, vm *Engine) error {
 			prefixHash = ph
 		}
 	}
-	hash, err := calcSignatureHash(subScript, hashType, &vm.tx, vm.txIdx,
-		prefixHash)
+	hash, err := vm.calcSignatureHash(subScript, hashType, prefixHash)
 	if err != nil {
 		vm.dstack.PushBool(false)
 		return nil
@@ -2472,23 +2471,8 @@ func opcodeCheckSig(op *parsedOpcode, vm *Engine) error {
 		return nil
 	}
 
-	var valid bool
-	if vm.sigCache != nil {
-		var sigHash chainhash.Hash
-		copy(sigHash[:], hash)
-
-		valid = vm.sigCache.Exists(sigHash, signature, pubKey)
-		if !valid && chainec.Secp256k1.Verify(pubKey, hash,
-			signature.GetR(), signature.GetS()) {
-
-			vm.sigCache.Add(sigHash, signature, pubKey)
-			valid = true
-		}
-	} else {
-		valid = chainec.Secp256k1.Verify(pubKey, hash, signature.GetR(),
-			signature.GetS())
-	}
-
+	valid := vm.verifyOrDeferSignature(chainec.ECTypeSecp256k1, pubKey,
+		hash, signature)
 	vm.dstack.PushBool(valid)
 	return nil
 }
@@ -2669,30 +2653,13 @@ func opcodeCheckMultiSig(op *parsedOpcode, vm *Engine) error {
 				prefixHash = ph
 			}
 		}
-		hash, err := calcSignatureHash(script, hashType, &vm.tx, vm.txIdx,
-			prefixHash)
+		hash, err := vm.calcSignatureHash(script, hashType, prefixHash)
 		if err != nil {
 			return err
 		}
 
-		var valid bool
-		if vm.sigCache != nil {
-			var sigHash chainhash.Hash
-			copy(sigHash[:], hash)
-
-			valid = vm.sigCache.Exists(sigHash, parsedSig, parsedPubKey)
-			if !valid && chainec.Secp256k1.Verify(parsedPubKey, hash,
-				parsedSig.GetR(), parsedSig.GetS()) {
-
-				vm.sigCache.Add(sigHash, parsedSig, parsedPubKey)
-				valid = true
-			}
-		} else {
-			valid = chainec.Secp256k1.Verify(parsedPubKey, hash,
-				parsedSig.GetR(), parsedSig.GetS())
-		}
-
-		if valid {
+		if vm.verifySignature(chainec.ECTypeSecp256k1, parsedPubKey, hash,
+			parsedSig) {
 			// PubKey verified, move on to the next signature.
 			signatureIdx++
 			numSignatures--
@@ -2831,8 +2798,7 @@ func opcodeCheckSigAlt(op *parsedOpcode, vm *Engine) error {
 			prefixHash = ph
 		}
 	}
-	hash, err := calcSignatureHash(subScript, hashType, &vm.tx, vm.txIdx,
-		prefixHash)
+	hash, err := vm.calcSignatureHash(subScript, hashType, prefixHash)
 	if err != nil {
 		vm.dstack.PushBool(false)
 		return nil
@@ -2880,22 +2846,9 @@ func opcodeCheckSigAlt(op *parsedOpcode, vm *Engine) error {
 	}
 
 	// Attempt to validate the signature.
-	switch sigTypes(sigType) {
-	case edwards:
-		ok := chainec.Edwards.Verify(pubKey, hash, signature.GetR(),
-			signature.GetS())
-		vm.dstack.PushBool(ok)
-		return nil
-	case secSchnorr:
-		ok := chainec.SecSchnorr.Verify(pubKey, hash, signature.GetR(),
-			signature.GetS())
-		vm.dstack.PushBool(ok)
-		return nil
-	}
-
-	// Fallthrough of somekind automatically results in false, but
-	// this should never be hit.
-	vm.dstack.PushBool(false)
+	valid := vm.verifyOrDeferSignature(int(sigType), pubKey, hash,
+		signature)
+	vm.dstack.PushBool(valid)
 	return nil
 }
 
@@ -0,0 +1,60 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package txscript
+
+import (
+	"github.com/EXCCoin/exccd/chaincfg/chainhash"
+	"github.com/EXCCoin/exccd/wire"
+)
+
+// versionSemantics houses the semantics which are specific to a script
+// version.  Every script executed by the engine is parsed and executed with the
+// semantics of the version of the public key script it is spending, so new
+// opcodes and signature hash modes can be introduced by defining a new version
+// without modifying the semantics of any existing version.
+type versionSemantics struct {
+	// opcodes is the table used to parse and execute scripts of the
+	// version.
+	opcodes *[256]opcode
+
+	// isValidHashType returns whether or not the passed signature hash type
+	// is valid for the version when strict encoding is enforced.
+	isValidHashType func(hashType SigHashType) bool
+
+	// calcSignatureHash calculates the signature hash of the passed script
+	// for the input of the transaction at the provided index.
+	calcSignatureHash func(script []parsedOpcode, hashType SigHashType,
+		tx *wire.MsgTx, idx int, cachedPrefix *chainhash.Hash) ([]byte, error)
+}
+
+// isValidHashTypeV0 returns whether or not the passed signature hash type is one
+// of the types defined by version 0 scripts, optionally combined with the
+// anyone can pay flag.
+func isValidHashTypeV0(hashType SigHashType) bool {
+	sigHashType := hashType & ^SigHashAnyOneCanPay
+	return sigHashType >= SigHashAll && sigHashType <= SigHashSingle
+}
+
+// scriptVersions houses the semantics of all script versions that have been
+// defined.  Scripts of any version which is not defined are not executed at
+// all, which makes outputs paying to them spendable by anyone until the
+// version is defined by a consensus change.
+//
+// New versions MUST be introduced as new entries rather than by modifying the
+// semantics of an existing version since doing so would be a hard fork.
+var scriptVersions = map[uint16]*versionSemantics{
+	DefaultScriptVersion: {
+		opcodes:           &opcodeArray,
+		isValidHashType:   isValidHashTypeV0,
+		calcSignatureHash: calcSignatureHash,
+	},
+}
+
+// IsKnownScriptVersion returns whether or not the semantics of the passed
+// script version are defined.  Scripts of unknown versions are not executed.
+func IsKnownScriptVersion(version uint16) bool {
+	_, ok := scriptVersions[version]
+	return ok
+}
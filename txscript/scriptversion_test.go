@@ -0,0 +1,61 @@
+// Copyright (c) 2018 The ExchangeCoin team
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package txscript
+
+import (
+	"testing"
+
+	"github.com/EXCCoin/exccd/wire"
+)
+
+// TestScriptVersionDispatch ensures scripts are parsed and executed with the
+// semantics of their script version and that scripts of unknown versions are
+// not executed.
+func TestScriptVersionDispatch(t *testing.T) {
+	// Define a test version which redefines OP_NOP10 to push false.
+	const testVersion = 0xfffe
+	testOpcodes := opcodeArray
+	testOpcodes[OP_NOP10].opfunc = opcodeFalse
+	scriptVersions[testVersion] = &versionSemantics{
+		opcodes:           &testOpcodes,
+		isValidHashType:   isValidHashTypeV0,
+		calcSignatureHash: calcSignatureHash,
+	}
+	defer delete(scriptVersions, testVersion)
+
+	tx := wire.NewMsgTx()
+	tx.AddTxIn(&wire.TxIn{})
+	pkScript := []byte{OP_TRUE, OP_NOP10}
+
+	tests := []struct {
+		name    string
+		version uint16
+		valid   bool
+	}{
+		{"default version", DefaultScriptVersion, true},
+		{"redefined opcode", testVersion, false},
+		{"unknown version", 0xffff, true},
+	}
+	for _, test := range tests {
+		vm, err := NewEngine(pkScript, tx, 0, 0, test.version, nil)
+		if err != nil {
+			t.Errorf("%s: unexpected error creating engine: %v", test.name,
+				err)
+			continue
+		}
+		err = vm.Execute()
+		if (err == nil) != test.valid {
+			t.Errorf("%s: unexpected result - got %v, want valid %v",
+				test.name, err, test.valid)
+		}
+	}
+
+	if !IsKnownScriptVersion(DefaultScriptVersion) {
+		t.Error("default script version is not known")
+	}
+	if IsKnownScriptVersion(0xffff) {
+		t.Error("undefined script version is known")
+	}
+}
@@ -7,8 +7,13 @@
 package txscript
 
 import (
+	"bufio"
 	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
 	"sync"
+	"sync/atomic"
 
 	"github.com/EXCCoin/exccd/chaincfg/chainec"
 	"github.com/EXCCoin/exccd/chaincfg/chainhash"
@@ -36,11 +41,23 @@ type sigCacheEntry struct {
 // optimization which speeds up the validation of transactions within a block,
 // if they've already been seen and verified within the mempool.
 type SigCache struct {
+	// The following variables must only be used atomically.
+	hits   uint64
+	misses uint64
+
 	sync.RWMutex
 	validSigs  map[chainhash.Hash]sigCacheEntry
 	maxEntries uint
 }
 
+// SigCacheStats describes the size and effectiveness of a signature cache.
+type SigCacheStats struct {
+	Entries    uint
+	MaxEntries uint
+	Hits       uint64
+	Misses     uint64
+}
+
 // NewSigCache creates and initializes a new instance of SigCache. Its sole
 // parameter 'maxEntries' represents the maximum number of entries allowed to
 // exist in the SigCache at any particular moment. Random entries are evicted
@@ -63,10 +80,34 @@ func (s *SigCache) Exists(sigHash chainhash.Hash, sig chainec.Signature, pubKey
 	entry, ok := s.validSigs[sigHash]
 	s.RUnlock()
 
-	return ok &&
+	found := ok &&
 		bytes.Equal(entry.pubKey.SerializeCompressed(),
 			pubKey.SerializeCompressed()) &&
 		bytes.Equal(entry.sig.Serialize(), sig.Serialize())
+	if found {
+		atomic.AddUint64(&s.hits, 1)
+	} else {
+		atomic.AddUint64(&s.misses, 1)
+	}
+	return found
+}
+
+// Stats returns the current number of entries in the signature cache along
+// with the number of lookups which did and did not find an entry since the
+// cache was created.
+//
+// NOTE: This function is safe for concurrent access.
+func (s *SigCache) Stats() SigCacheStats {
+	s.RLock()
+	entries := uint(len(s.validSigs))
+	s.RUnlock()
+
+	return SigCacheStats{
+		Entries:    entries,
+		MaxEntries: s.maxEntries,
+		Hits:       atomic.LoadUint64(&s.hits),
+		Misses:     atomic.LoadUint64(&s.misses),
+	}
 }
 
 // Add adds an entry for a signature over 'sigHash' under public key 'pubKey'
@@ -103,3 +144,146 @@ func (s *SigCache) Add(sigHash chainhash.Hash, sig chainec.Signature, pubKey cha
 	}
 	s.validSigs[sigHash] = sigCacheEntry{sig, pubKey}
 }
+
+// Shrink evicts random entries from the signature cache until no more than the
+// passed number of entries remain in order to release memory.  The cache may
+// grow to its maximum number of entries again afterwards.  It returns the
+// number of evicted entries.
+//
+// NOTE: This function is safe for concurrent access.
+func (s *SigCache) Shrink(maxEntries uint) int {
+	s.Lock()
+	defer s.Unlock()
+
+	numEntries := uint(len(s.validSigs))
+	if numEntries <= maxEntries {
+		return 0
+	}
+
+	// Copy the retained entries to a new map since the memory used by a map
+	// is not released when entries are deleted from it.  The entries are
+	// chosen randomly as described by Add.
+	validSigs := make(map[chainhash.Hash]sigCacheEntry, maxEntries)
+	for sigHash, entry := range s.validSigs {
+		if uint(len(validSigs)) == maxEntries {
+			break
+		}
+		validSigs[sigHash] = entry
+	}
+	s.validSigs = validSigs
+	return int(numEntries - maxEntries)
+}
+
+// sigCacheSerializationVersion is the current version of the serialized
+// signature cache entries written by Save.
+const sigCacheSerializationVersion = 1
+
+// Save writes all entries of the signature cache to the passed writer so they
+// can be restored with Load, for example after a restart.
+//
+// The serialized format is:
+//
+//   <version><num entries><entry 1><entry 2>...
+//
+//   Field            Type      Size
+//   version          uint32    4 bytes
+//   num entries      uint32    4 bytes
+//   entries          []entry   variable
+//
+// where each entry is:
+//
+//   Field            Type      Size
+//   sig hash         [32]byte  32 bytes
+//   public key       []byte    33 bytes (compressed)
+//   signature len    uint8     1 byte
+//   signature        []byte    variable (DER)
+//
+// All integers are encoded in little endian.
+//
+// NOTE: This function is safe for concurrent access.
+func (s *SigCache) Save(w io.Writer) error {
+	s.RLock()
+	defer s.RUnlock()
+
+	bw := bufio.NewWriter(w)
+	var hdr [8]byte
+	binary.LittleEndian.PutUint32(hdr[0:4], sigCacheSerializationVersion)
+	binary.LittleEndian.PutUint32(hdr[4:8], uint32(len(s.validSigs)))
+	if _, err := bw.Write(hdr[:]); err != nil {
+		return err
+	}
+	for sigHash, entry := range s.validSigs {
+		sig := entry.sig.Serialize()
+		if len(sig) > 0xff {
+			return fmt.Errorf("signature of %v too long to serialize",
+				sigHash)
+		}
+		if _, err := bw.Write(sigHash[:]); err != nil {
+			return err
+		}
+		if _, err := bw.Write(entry.pubKey.SerializeCompressed()); err != nil {
+			return err
+		}
+		if err := bw.WriteByte(uint8(len(sig))); err != nil {
+			return err
+		}
+		if _, err := bw.Write(sig); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// Load reads signature cache entries previously written by Save from the
+// passed reader and adds them to the signature cache.  It returns the number of
+// loaded entries.
+//
+// The entries are NOT verified again, so they must only be loaded from a
+// trusted source such as the data directory of the node.
+//
+// NOTE: This function is safe for concurrent access.
+func (s *SigCache) Load(r io.Reader) (int, error) {
+	br := bufio.NewReader(r)
+	var hdr [8]byte
+	if _, err := io.ReadFull(br, hdr[:]); err != nil {
+		return 0, err
+	}
+	version := binary.LittleEndian.Uint32(hdr[0:4])
+	if version != sigCacheSerializationVersion {
+		return 0, fmt.Errorf("unsupported signature cache version %d",
+			version)
+	}
+	numEntries := binary.LittleEndian.Uint32(hdr[4:8])
+
+	var sigHash chainhash.Hash
+	var pubKeyBytes [33]byte
+	var sigBytes [0xff]byte
+	for i := uint32(0); i < numEntries; i++ {
+		if _, err := io.ReadFull(br, sigHash[:]); err != nil {
+			return int(i), err
+		}
+		if _, err := io.ReadFull(br, pubKeyBytes[:]); err != nil {
+			return int(i), err
+		}
+		sigLen, err := br.ReadByte()
+		if err != nil {
+			return int(i), err
+		}
+		if _, err := io.ReadFull(br, sigBytes[:sigLen]); err != nil {
+			return int(i), err
+		}
+
+		pubKey, err := chainec.Secp256k1.ParsePubKey(pubKeyBytes[:])
+		if err != nil {
+			return int(i), fmt.Errorf("invalid public key for "+
+				"signature cache entry %v: %v", sigHash, err)
+		}
+		sig, err := chainec.Secp256k1.ParseSignature(sigBytes[:sigLen])
+		if err != nil {
+			return int(i), fmt.Errorf("invalid signature for "+
+				"signature cache entry %v: %v", sigHash, err)
+		}
+		s.Add(sigHash, sig, pubKey)
+	}
+	return int(numEntries), nil
+}
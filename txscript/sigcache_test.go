@@ -7,6 +7,7 @@
 package txscript
 
 import (
+	"bytes"
 	"crypto/rand"
 	"math/big"
 	"testing"
@@ -116,6 +117,46 @@ func TestSigCacheAddEvictEntry(t *testing.T) {
 	}
 }
 
+// TestSigCacheShrink ensures shrinking the signature cache evicts entries until
+// no more than the requested number remain and the cache may grow again
+// afterwards.
+func TestSigCacheShrink(t *testing.T) {
+	sigCacheSize := uint(50)
+	sigCache := NewSigCache(sigCacheSize)
+	for i := uint(0); i < sigCacheSize; i++ {
+		msg, sig, key, err := genRandomSig()
+		if err != nil {
+			t.Fatalf("unable to generate random signature test data")
+		}
+		sigCache.Add(*msg, sig, key)
+	}
+
+	if evicted := sigCache.Shrink(sigCacheSize); evicted != 0 {
+		t.Fatalf("Shrink: evicted %d entries from a cache within the "+
+			"limit", evicted)
+	}
+	if evicted := sigCache.Shrink(10); evicted != 40 {
+		t.Fatalf("Shrink: evicted %d entries, want 40", evicted)
+	}
+	if entries := sigCache.Stats().Entries; entries != 10 {
+		t.Fatalf("sigcache should now have 10 entries, instead it has %v",
+			entries)
+	}
+
+	// The cache may grow to its maximum number of entries again.
+	for i := uint(0); i < sigCacheSize; i++ {
+		msg, sig, key, err := genRandomSig()
+		if err != nil {
+			t.Fatalf("unable to generate random signature test data")
+		}
+		sigCache.Add(*msg, sig, key)
+	}
+	if entries := sigCache.Stats().Entries; entries != sigCacheSize {
+		t.Fatalf("sigcache should now have %v entries, instead it has %v",
+			sigCacheSize, entries)
+	}
+}
+
 // TestSigCacheAddMaxEntriesZeroOrNegative tests that if a sigCache is created
 // with a max size <= 0, then no entries are added to the sigcache at all.
 func TestSigCacheAddMaxEntriesZeroOrNegative(t *testing.T) {
@@ -145,3 +186,53 @@ func TestSigCacheAddMaxEntriesZeroOrNegative(t *testing.T) {
 			"been added", len(sigCache.validSigs))
 	}
 }
+
+// TestSigCacheSaveLoad ensures the entries of a saved signature cache are
+// found in a cache they are loaded into and that lookups are counted.
+func TestSigCacheSaveLoad(t *testing.T) {
+	const numEntries = 10
+	sigCache := NewSigCache(numEntries)
+	msgs := make([]*chainhash.Hash, numEntries)
+	sigs := make([]chainec.Signature, numEntries)
+	keys := make([]chainec.PublicKey, numEntries)
+	for i := 0; i < numEntries; i++ {
+		var err error
+		msgs[i], sigs[i], keys[i], err = genRandomSig()
+		if err != nil {
+			t.Fatalf("unable to generate random signature test data")
+		}
+		sigCache.Add(*msgs[i], sigs[i], keys[i])
+	}
+
+	var buf bytes.Buffer
+	if err := sigCache.Save(&buf); err != nil {
+		t.Fatalf("unable to save signature cache: %v", err)
+	}
+	loaded := NewSigCache(numEntries)
+	n, err := loaded.Load(&buf)
+	if err != nil {
+		t.Fatalf("unable to load signature cache: %v", err)
+	}
+	if n != numEntries {
+		t.Fatalf("unexpected number of loaded entries - got %d, want %d",
+			n, numEntries)
+	}
+	for i := 0; i < numEntries; i++ {
+		if !loaded.Exists(*msgs[i], sigs[i], keys[i]) {
+			t.Fatalf("saved entry %d not found in loaded cache", i)
+		}
+	}
+	if loaded.Exists(*msgs[0], sigs[1], keys[1]) {
+		t.Fatal("unexpected entry found in loaded cache")
+	}
+
+	want := SigCacheStats{
+		Entries:    numEntries,
+		MaxEntries: numEntries,
+		Hits:       numEntries,
+		Misses:     1,
+	}
+	if stats := loaded.Stats(); stats != want {
+		t.Fatalf("unexpected stats - got %+v, want %+v", stats, want)
+	}
+}
@@ -1031,6 +1031,12 @@ func PayToAddrScript(addr exccutil.Address) ([]byte, error) {
 		}
 		return payToScriptHashScript(addr.ScriptAddress())
 
+	case *exccutil.AddressBech32:
+		if addr == nil {
+			return nil, ErrUnsupportedAddress
+		}
+		return PayToAddrScript(addr.Base58())
+
 	case *exccutil.AddressSecpPubKey:
 		if addr == nil {
 			return nil, ErrUnsupportedAddress
@@ -576,6 +576,22 @@ func TestPayToAddrScript(t *testing.T) {
 		return
 	}
 
+	p2pkhBech32, err := exccutil.NewAddressBech32PubKeyHash(decodeHex(
+		"e34cce70c86373273efcc54ce7d2a491bb4a0e84"),
+		&chaincfg.TestNet2Params, secp)
+	if err != nil {
+		t.Errorf("Unable to create bech32 public key hash address: %v",
+			err)
+		return
+	}
+	p2shBech32, err := exccutil.NewAddressBech32ScriptHashFromHash(decodeHex(
+		"e8c300c87986efa84c37c0519929019ef86eb5b4"),
+		&chaincfg.TestNet2Params)
+	if err != nil {
+		t.Errorf("Unable to create bech32 script hash address: %v", err)
+		return
+	}
+
 	p2pkUncompressedMain := newAddressPubKey(decodeHex("0411db" +
 		"93e1dcdb8a016b49840f8c53bc1eb68a382e97b1482ecad7b148a6909a5cb2" +
 		"e0eaddfb84ccf9744464f82e160bfa9b8b64f9d4c03f999b8643f656b412a3"))
@@ -622,9 +638,24 @@ func TestPayToAddrScript(t *testing.T) {
 				"1482ecad7b148a6909a5cac",
 			nil,
 		},
+		// bech32 pay-to-pubkey-hash address on testnet.
+		{
+			p2pkhBech32,
+			"DUP HASH160 DATA_20 0xe34cce70c86373273efcc54ce7d2a4" +
+				"91bb4a0e8488 CHECKSIG",
+			nil,
+		},
+		// bech32 pay-to-script-hash address on testnet.
+		{
+			p2shBech32,
+			"HASH160 DATA_20 0xe8c300c87986efa84c37c0519929019ef8" +
+				"6eb5b4 EQUAL",
+			nil,
+		},
 
 		// Supported address types with nil pointers.
 		{(*exccutil.AddressPubKeyHash)(nil), "", txscript.ErrUnsupportedAddress},
+		{(*exccutil.AddressBech32)(nil), "", txscript.ErrUnsupportedAddress},
 		{(*exccutil.AddressScriptHash)(nil), "", txscript.ErrUnsupportedAddress},
 		{(*exccutil.AddressSecpPubKey)(nil), "", txscript.ErrUnsupportedAddress},
 
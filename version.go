@@ -42,6 +42,11 @@ var (
 	// MUST only contain characters from semanticBuildAlphabet per the
 	// semantic versioning spec.
 	appBuild = "dev"
+
+	// appCommit is the hash of the git commit the application was built
+	// from.  It is empty unless it is set during the build process with
+	// '-ldflags "-X main.appCommit=$(git rev-parse HEAD)"'.
+	appCommit = ""
 )
 
 // version returns the application version as a properly formed string per the
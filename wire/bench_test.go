@@ -677,6 +677,23 @@ func BenchmarkTxHash(b *testing.B) {
 	}
 }
 
+// BenchmarkBlockHash performs a benchmark on how long it takes to hash a block
+// header.
+func BenchmarkBlockHash(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		blockOne.Header.BlockHash()
+	}
+}
+
+// BenchmarkWriteMessageBlock performs a benchmark on how long it takes to write
+// a block message.
+func BenchmarkWriteMessageBlock(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		WriteMessageN(ioutil.Discard, &blockOne, ProtocolVersion, MainNet)
+	}
+}
+
 // BenchmarkHashB performs a benchmark on how long it takes to perform a hash
 // returning a byte slice.
 func BenchmarkHashB(b *testing.B) {
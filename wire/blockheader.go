@@ -113,10 +113,12 @@ func (h *BlockHeader) BlockHash() chainhash.Hash {
 	// transactions.  Ignore the error returns since there is no way the
 	// encode could fail except being out of memory which would cause a
 	// run-time panic.
-	buf := bytes.NewBuffer(make([]byte, 0, MaxBlockHeaderPayload))
+	buf := bufferPool.Borrow()
 	_ = writeBlockHeader(buf, 0, h)
+	hash := chainhash.HashH(buf.Bytes())
+	bufferPool.Return(buf)
 
-	return chainhash.HashH(buf.Bytes())
+	return hash
 }
 
 // BtcDecode decodes r using the bitcoin protocol encoding into the receiver.
@@ -172,18 +174,53 @@ func (h *BlockHeader) Bytes() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-// TODO: add tests
-func (h *BlockHeader) SerializeAllHeaderBytes() ([]byte, error) {
-	buf := bytes.NewBuffer(make([]byte, 0, MaxBlockHeaderPayload))
+// allHeaderBytesLen is the length of the header bytes used as the input of the
+// equihash solver and validator: the version, previous block, merkle root, bits,
+// timestamp, and extra data.
+const allHeaderBytesLen = 4 + chainhash.HashSize*2 + 4 + 4 + 32
 
-	sec := uint32(h.Timestamp.Unix())
-	err := writeElements(buf, h.Version, &h.PrevBlock, &h.MerkleRoot, h.Bits, sec, h.ExtraData)
+// allHeaderBytesBitsOffset is the offset of the bits in the header bytes used
+// as the input of the equihash solver and validator.  The timestamp directly
+// follows them.
+const allHeaderBytesBitsOffset = 4 + chainhash.HashSize*2
 
-	if err != nil {
-		return nil, err
-	}
+// SerializeAllHeaderBytes returns the header bytes used as the input of the
+// equihash solver and validator.
+func (h *BlockHeader) SerializeAllHeaderBytes() ([]byte, error) {
+	return h.AppendAllHeaderBytes(make([]byte, 0, allHeaderBytesLen)), nil
+}
 
-	return buf.Bytes(), nil
+// AppendAllHeaderBytes appends the header bytes used as the input of the
+// equihash solver and validator to the passed byte slice and returns the
+// extended slice.  Passing a slice with enough capacity, such as the one
+// returned by a previous call resliced to zero length, avoids allocating when
+// the header is reserialized repeatedly while mining.
+func (h *BlockHeader) AppendAllHeaderBytes(b []byte) []byte {
+	var buf [allHeaderBytesLen]byte
+	offset := 0
+	littleEndian.PutUint32(buf[offset:], uint32(h.Version))
+	offset += 4
+	copy(buf[offset:], h.PrevBlock[:])
+	offset += chainhash.HashSize
+	copy(buf[offset:], h.MerkleRoot[:])
+	offset += chainhash.HashSize
+	littleEndian.PutUint32(buf[offset:], h.Bits)
+	offset += 4
+	littleEndian.PutUint32(buf[offset:], uint32(h.Timestamp.Unix()))
+	offset += 4
+	copy(buf[offset:], h.ExtraData[:])
+
+	return append(b, buf[:]...)
+}
+
+// PutAllHeaderBytesTime writes the bits and timestamp of the header into the
+// passed header bytes previously produced by AppendAllHeaderBytes for the same
+// header.  It allows rolling the timestamp while mining without reserializing
+// the other fields, which are left unchanged.
+func (h *BlockHeader) PutAllHeaderBytesTime(b []byte) {
+	littleEndian.PutUint32(b[allHeaderBytesBitsOffset:], h.Bits)
+	littleEndian.PutUint32(b[allHeaderBytesBitsOffset+4:],
+		uint32(h.Timestamp.Unix()))
 }
 
 // NewBlockHeader returns a new BlockHeader using the provided previous block
@@ -381,3 +381,58 @@ func TestBlockHeaderHashing(t *testing.T) {
 			hash2)
 	}
 }
+
+// TestBlockHeaderAllHeaderBytes ensures the header bytes used as the input of
+// the equihash solver are serialized as expected and appended to the passed
+// byte slice.
+func TestBlockHeaderAllHeaderBytes(t *testing.T) {
+	bh := BlockHeader{
+		Version:    1,
+		PrevBlock:  chainhash.Hash{0x01},
+		MerkleRoot: chainhash.Hash{0x02},
+		Bits:       0x1d00ffff,
+		Timestamp:  time.Unix(0x495fab29, 0),
+		ExtraData:  [32]byte{0x03},
+		Nonce:      0xffffffff,
+	}
+
+	var want bytes.Buffer
+	err := writeElements(&want, bh.Version, &bh.PrevBlock, &bh.MerkleRoot,
+		bh.Bits, uint32(bh.Timestamp.Unix()), bh.ExtraData)
+	if err != nil {
+		t.Fatalf("writeElements: unexpected error: %v", err)
+	}
+
+	got, err := bh.SerializeAllHeaderBytes()
+	if err != nil {
+		t.Fatalf("SerializeAllHeaderBytes: unexpected error: %v", err)
+	}
+	if !bytes.Equal(got, want.Bytes()) {
+		t.Fatalf("SerializeAllHeaderBytes: wrong bytes - got %x, want %x",
+			got, want.Bytes())
+	}
+
+	// Ensure the bytes are appended to the passed slice and the slice is
+	// reused when it has enough capacity.
+	prefix := []byte{0xff}
+	got = bh.AppendAllHeaderBytes(prefix)
+	if !bytes.Equal(got, append([]byte{0xff}, want.Bytes()...)) {
+		t.Fatalf("AppendAllHeaderBytes: wrong bytes - got %x", got)
+	}
+	reused := bh.AppendAllHeaderBytes(got[:0])
+	if &reused[0] != &got[0] || !bytes.Equal(reused, want.Bytes()) {
+		t.Fatalf("AppendAllHeaderBytes: buffer not reused - got %x",
+			reused)
+	}
+
+	// Ensure writing only the bits and timestamp produces the same bytes as
+	// reserializing the header after changing them.
+	bh.Bits = 0x1c00ffff
+	bh.Timestamp = bh.Timestamp.Add(time.Minute)
+	bh.PutAllHeaderBytesTime(reused)
+	want2, _ := bh.SerializeAllHeaderBytes()
+	if !bytes.Equal(reused, want2) {
+		t.Fatalf("PutAllHeaderBytesTime: wrong bytes - got %x, want %x",
+			reused, want2)
+	}
+}
@@ -7,6 +7,7 @@
 package wire
 
 import (
+	"bytes"
 	"crypto/rand"
 	"encoding/binary"
 	"fmt"
@@ -26,6 +27,15 @@ const (
 	// binaryFreeListMaxItems is the number of buffers to keep in the free
 	// list to use for binary serialization and deserialization.
 	binaryFreeListMaxItems = 1024
+
+	// bufferFreeListMaxItems is the number of buffers to keep in the free
+	// list to use for serializing messages, headers, and transactions.
+	bufferFreeListMaxItems = 16
+
+	// bufferFreeListMaxSize is the maximum capacity of the buffers kept in
+	// the free list to use for serializing messages, headers, and
+	// transactions.  It is large enough to serialize a block message.
+	bufferFreeListMaxSize = MessageHeaderSize + MaxBlockPayload
 )
 
 var (
@@ -175,6 +185,52 @@ func (l binaryFreeList) PutUint64(w io.Writer, byteOrder binary.ByteOrder, val u
 // deserializing primitive integer values to and from io.Readers and io.Writers.
 var binarySerializer binaryFreeList = make(chan []byte, binaryFreeListMaxItems)
 
+// bufferFreeList defines a concurrent safe free list of byte buffers (up to the
+// maximum number defined by the bufferFreeListMaxItems constant) that are used
+// to serialize messages, block headers, and transactions before they are
+// written or hashed.  Reusing the buffers avoids allocating, growing, and then
+// discarding a new buffer for every serialization, which is significant for
+// large messages such as blocks and for data which is hashed frequently such
+// as the block headers reserialized by miners.
+//
+// The caller can obtain a buffer from the free list by calling the Borrow
+// function and must return it via the Return function once it no longer
+// references the serialized bytes.
+type bufferFreeList chan *bytes.Buffer
+
+// Borrow returns an empty buffer from the free list.  A new buffer is
+// allocated if there are not any available on the free list.
+func (l bufferFreeList) Borrow() *bytes.Buffer {
+	var buf *bytes.Buffer
+	select {
+	case buf = <-l:
+		buf.Reset()
+	default:
+		buf = new(bytes.Buffer)
+	}
+	return buf
+}
+
+// Return puts the provided buffer back on the free list when it is not full.
+// Buffers which grew larger than the bufferFreeListMaxSize constant are
+// ignored so they can go to the garbage collector rather than holding on to
+// the memory.
+func (l bufferFreeList) Return(buf *bytes.Buffer) {
+	if buf.Cap() > bufferFreeListMaxSize {
+		return
+	}
+
+	select {
+	case l <- buf:
+	default:
+		// Let it go to the garbage collector.
+	}
+}
+
+// bufferPool provides a free list of buffers to use for serializing messages,
+// block headers, and transactions.
+var bufferPool bufferFreeList = make(chan *bytes.Buffer, bufferFreeListMaxItems)
+
 // errNonCanonicalVarInt is the common format string used for non-canonically
 // encoded variable length integer errors.
 var errNonCanonicalVarInt = "non-canonical varint %x - discriminant %x must " +
@@ -242,13 +242,18 @@ func WriteMessageN(w io.Writer, msg Message, pver uint32, exccnet CurrencyNet) (
 	}
 	copy(command[:], []byte(cmd))
 
-	// Encode the message payload.
-	var bw bytes.Buffer
-	err := msg.BtcEncode(&bw, pver)
+	// Encode the message payload to a buffer from the free list after
+	// space reserved for the header, so neither the header nor the payload
+	// require a separate buffer.
+	buf := bufferPool.Borrow()
+	defer bufferPool.Return(buf)
+	var hdrBytes [MessageHeaderSize]byte
+	buf.Write(hdrBytes[:])
+	err := msg.BtcEncode(buf, pver)
 	if err != nil {
 		return totalBytes, err
 	}
-	payload := bw.Bytes()
+	payload := buf.Bytes()[MessageHeaderSize:]
 	lenp := len(payload)
 
 	// Enforce maximum overall message payload.
@@ -268,21 +273,16 @@ func WriteMessageN(w io.Writer, msg Message, pver uint32, exccnet CurrencyNet) (
 		return totalBytes, messageError("WriteMessage", str)
 	}
 
-	// Create header for the message.
-	hdr := messageHeader{}
-	hdr.magic = exccnet
-	hdr.command = cmd
-	hdr.length = uint32(lenp)
-	copy(hdr.checksum[:], chainhash.HashB(payload)[0:4])
-
-	// Encode the header for the message.  This is done to a buffer
-	// rather than directly to the writer since writeElements doesn't
-	// return the number of bytes written.
-	hw := bytes.NewBuffer(make([]byte, 0, MessageHeaderSize))
-	writeElements(hw, hdr.magic, command, hdr.length, hdr.checksum)
+	// Encode the header for the message into the space reserved for it.
+	checksum := chainhash.HashH(payload)
+	hdr := buf.Bytes()[:MessageHeaderSize]
+	littleEndian.PutUint32(hdr[0:4], uint32(exccnet))
+	copy(hdr[4:4+CommandSize], command[:])
+	littleEndian.PutUint32(hdr[4+CommandSize:8+CommandSize], uint32(lenp))
+	copy(hdr[8+CommandSize:], checksum[0:4])
 
 	// Write header.
-	n, err := w.Write(hw.Bytes())
+	n, err := w.Write(hdr)
 	totalBytes += n
 	if err != nil {
 		return totalBytes, err
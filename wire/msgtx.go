@@ -454,16 +454,25 @@ func (msg *MsgTx) serialize(serType TxSerializeType) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-// mustSerialize returns the serialization of the transaction for the provided
-// serialization type without modifying the original transaction.  It will panic
-// if any errors occur.
-func (msg *MsgTx) mustSerialize(serType TxSerializeType) []byte {
-	serialized, err := msg.serialize(serType)
+// hash returns the hash of the serialization of the transaction for the
+// provided serialization type without modifying the original transaction.  The
+// transaction is serialized to a buffer from the free list since the
+// serialization is discarded once it is hashed.  It will panic if any errors
+// occur.
+func (msg *MsgTx) hash(serType TxSerializeType) chainhash.Hash {
+	// Shallow copy so the serialization type can be changed without
+	// modifying the original transaction.
+	mtxCopy := *msg
+	mtxCopy.SerType = serType
+	buf := bufferPool.Borrow()
+	err := mtxCopy.Serialize(buf)
 	if err != nil {
 		panic(fmt.Sprintf("MsgTx failed serializing for type %v",
 			serType))
 	}
-	return serialized
+	hash := chainhash.HashH(buf.Bytes())
+	bufferPool.Return(buf)
+	return hash
 }
 
 // TxHash generates the hash for the transaction prefix.  Since it does not
@@ -471,7 +480,7 @@ func (msg *MsgTx) mustSerialize(serType TxSerializeType) []byte {
 // use in unconfirmed transaction chains.
 func (msg *MsgTx) TxHash() chainhash.Hash {
 	// TxHash should always calculate a non-witnessed hash.
-	return chainhash.HashH(msg.mustSerialize(TxSerializeNoWitness))
+	return msg.hash(TxSerializeNoWitness)
 }
 
 // CachedTxHash is equivalent to calling TxHash, however it caches the result so
@@ -499,21 +508,21 @@ func (msg *MsgTx) RecacheTxHash() *chainhash.Hash {
 // TxHashWitness generates the hash for the transaction witness.
 func (msg *MsgTx) TxHashWitness() chainhash.Hash {
 	// TxHashWitness should always calculate a witnessed hash.
-	return chainhash.HashH(msg.mustSerialize(TxSerializeOnlyWitness))
+	return msg.hash(TxSerializeOnlyWitness)
 }
 
 // TxHashWitnessSigning generates the hash for the transaction witness with the
 // malleable portions (AmountIn, BlockHeight, BlockIndex) removed.  These are
 // verified and set by the miner instead.
 func (msg *MsgTx) TxHashWitnessSigning() chainhash.Hash {
-	return chainhash.HashH(msg.mustSerialize(TxSerializeWitnessSigning))
+	return msg.hash(TxSerializeWitnessSigning)
 }
 
 // TxHashWitnessValueSigning generates the hash for the transaction witness with
 // BlockHeight and BlockIndex removed, allowing the signer to specify the
 // ValueIn.
 func (msg *MsgTx) TxHashWitnessValueSigning() chainhash.Hash {
-	return chainhash.HashH(msg.mustSerialize(TxSerializeWitnessValueSigning))
+	return msg.hash(TxSerializeWitnessValueSigning)
 }
 
 // TxHashFull generates the hash for the transaction prefix || witness. It first